@@ -0,0 +1,112 @@
+// Command datakeys manages the data keys that encrypt owner contact columns
+// (see ENCRYPTION_MASTER_KEYS). The server creates the first data key itself;
+// this command rotates keys and re-encrypts existing values.
+//
+// Usage:
+//
+//	datakeys -list
+//	datakeys -rewrap                    # after making a new master key active
+//	datakeys -rotate [-reencrypt]       # start encrypting with a new data key
+//	datakeys -reencrypt [-batch 1000]   # also encrypts values stored in plaintext
+//
+// To retire a master key, add the new one to ENCRYPTION_MASTER_KEYS, make it
+// ENCRYPTION_ACTIVE_KEY, run -rewrap and then remove the old key. Values keep
+// their data key; -rotate -reencrypt replaces that too.
+//
+// Configuration comes from the same environment variables and .env file as the
+// API server.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/app"
+)
+
+func main() {
+	list := flag.Bool("list", false, "list the data keys")
+	rewrap := flag.Bool("rewrap", false, "rewrap every data key with the active master key")
+	rotate := flag.Bool("rotate", false, "create a data key that encrypts new values")
+	reencrypt := flag.Bool("reencrypt", false, "re-encrypt values not encrypted with the active data key")
+	batch := flag.Int("batch", 1000, "values re-encrypted per column and round trip")
+	flag.Parse()
+
+	if !*list && !*rewrap && !*rotate && !*reencrypt {
+		fmt.Fprintln(os.Stderr, "one of -list, -rewrap, -rotate or -reencrypt is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *batch < 1 {
+		fmt.Fprintln(os.Stderr, "-batch must be at least 1")
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+	a, err := app.Load(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize: %v\n", err)
+		os.Exit(1)
+	}
+	defer func() { _ = a.Stop(context.Background()) }()
+	if a.Keyring == nil {
+		fail(a, fmt.Errorf("ENCRYPTION_MASTER_KEYS is not set"))
+	}
+
+	if *list {
+		keys, err := a.Repositories.Encryption.ListDataKeys(ctx)
+		if err != nil {
+			fail(a, err)
+		}
+		active := a.Keyring.ActiveKeyID()
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tMASTER KEY\tCREATED\tACTIVE")
+		for _, key := range keys {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%t\n", key.ID, key.MasterKeyID, key.CreatedAt.Format(time.RFC3339), key.ID == active)
+		}
+		_ = w.Flush()
+	}
+
+	if *rewrap {
+		count, err := a.Keyring.RewrapDataKeys(ctx)
+		if err != nil {
+			fail(a, err)
+		}
+		fmt.Printf("Rewrapped %d data keys with master key %s\n", count, a.Config.Encryption.ActiveKey)
+	}
+
+	if *rotate {
+		id, err := a.Keyring.RotateDataKey(ctx)
+		if err != nil {
+			fail(a, err)
+		}
+		fmt.Printf("Created data key %d; new values are encrypted with it\n", id)
+	}
+
+	if *reencrypt {
+		var total int64
+		for {
+			count, err := a.Repositories.Encryption.ReencryptContacts(ctx, a.Keyring, *batch)
+			if err != nil {
+				fail(a, err)
+			}
+			if count == 0 {
+				break
+			}
+			total += count
+			fmt.Fprintf(os.Stderr, "Re-encrypted %d values\n", total)
+		}
+		fmt.Printf("Re-encrypted %d values with data key %d\n", total, a.Keyring.ActiveKeyID())
+	}
+}
+
+// fail closes the database and exits; deferred calls do not run on os.Exit.
+func fail(a *app.App, err error) {
+	fmt.Fprintf(os.Stderr, "Failed: %v\n", err)
+	_ = a.Stop(context.Background())
+	os.Exit(1)
+}
@@ -0,0 +1,747 @@
+// Command ingest loads county parcels into tax_parcels, from a GeoJSON
+// FeatureCollection file or an ArcGIS REST FeatureServer layer, refreshes
+// their owner and address attributes from the county appraisal district roll,
+// whose values it records as the year's assessments, loads the county's lakes and rivers to compute parcel waterfront frontage,
+// its schools, hospitals and fire stations for nearest-amenity distances, its
+// zoning districts, its FEMA flood zones, its school district and taxing
+// unit boundaries, its sales history and any other dataset as a generic layer.
+// Every load also stores the ingest-stage derived attributes of DERIVED_ATTRIBUTES_FILE,
+// which -derived recomputes on its own after the file changes.
+//
+// Usage:
+//
+//	ingest -file data/montgomery_parcels.geojson [-mapping ../scripts/mappings/montgomery-tx.json]
+//	       [-mode replace|append|sync] [-srid 2278] [-max-invalid 100] [-lock-timeout 30s] [-allow-anomalies]
+//	       [-max-count-change 10] [-max-area-change 10] [-max-null-increase 0.05] [-dry-run]
+//	ingest -arcgis-url https://gis.example.gov/arcgis/rest/services/Parcels/FeatureServer/0
+//	       -mapping mapping.json [-arcgis-where "1=1"] [-arcgis-page-size 1000] [-arcgis-retries 3] [-arcgis-rate-limit 0] ...
+//	ingest -appraisal APPRAISAL_INFO.TXT [-mapping mapping.json] [-appraisal-layout layout.json]
+//	       [-appraisal-delimiter ","] [-max-invalid 100] [-lock-timeout 30s] [-dry-run]
+//	ingest -hydrology NHDWaterbody.geojson -water-kind lake|river [-mapping mapping.json]
+//	       [-water-name-field GNIS_Name] [-max-invalid 100] [-lock-timeout 30s] [-dry-run]
+//	ingest -amenities Fire_Stations.geojson -amenity-category school|hospital|fire_station [-mapping mapping.json]
+//	       [-amenity-name-field NAME] [-max-invalid 100] [-lock-timeout 30s] [-dry-run]
+//	ingest -zoning Zoning.geojson [-mapping mapping.json] [-zoning-code-field ZONING]
+//	       [-zoning-name-field ZONE_DESC] [-max-invalid 100] [-lock-timeout 30s] [-dry-run]
+//	ingest -flood-zones S_FLD_HAZ_AR.geojson [-mapping mapping.json] [-max-invalid 100] [-lock-timeout 30s] [-dry-run]
+//	ingest -jurisdictions School_Districts.geojson -jurisdiction-kind school_district|taxing_unit [-mapping mapping.json]
+//	       [-jurisdiction-name-field NAME] [-jurisdiction-code-field CODE] [-max-invalid 100] [-lock-timeout 30s] [-dry-run]
+//	ingest -layer Wetlands.geojson -layer-name wetlands [-mapping mapping.json] [-layer-title "Wetlands"]
+//	       [-layer-description TEXT] [-layer-properties WETLAND_TYPE,ACRES] [-max-invalid 100] [-lock-timeout 30s] [-dry-run]
+//	ingest -sales Sales.csv [-mapping mapping.json] [-sales-delimiter ","] [-max-invalid 100] [-lock-timeout 30s] [-dry-run]
+//	ingest -derived [-mapping mapping.json] [-lock-timeout 30s]
+//
+// GeoJSON files in EPSG:2278 (Texas South Central state plane) or EPSG:3857 are
+// reprojected to WGS84 while they load; -srid gives the CRS of files that do not
+// declare one.
+//
+// Loads of the same county never overlap, across hosts and with import-parcels.sh:
+// a load waits up to -lock-timeout for a running one, then exits with code 3.
+// Replace and sync loads that deviate from the county's recent runs beyond the
+// -max-* thresholds are rolled back and exit with code 4, unless
+// -allow-anomalies is given; import-parcels.sh runs its replace imports through
+// this check.
+//
+// Database settings come from the same environment variables and .env file as the
+// API server.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stwalsh4118/atlas/api/internal/app"
+	"github.com/stwalsh4118/atlas/api/internal/ingest"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+)
+
+// readBufferSize keeps reads of large files efficient without holding them in memory.
+const readBufferSize = 1 << 20
+
+// exitCountyLocked is the exit code when another load of the county is running,
+// so schedulers can retry later instead of alerting.
+const exitCountyLocked = 3
+
+// exitAnomaliesBlocked is the exit code when a parcel load failed the anomaly
+// check and was rolled back.
+const exitAnomaliesBlocked = 4
+
+func main() {
+	file := flag.String("file", "", "GeoJSON FeatureCollection to load")
+	arcgisURL := flag.String("arcgis-url", "", "ArcGIS REST FeatureServer or MapServer layer URL to load instead of a file")
+	arcgisWhere := flag.String("arcgis-where", "1=1", "where clause selecting the ArcGIS features")
+	arcgisPageSize := flag.Int("arcgis-page-size", ingest.DefaultArcGISPageSize, "features per ArcGIS request (capped at the layer's maxRecordCount)")
+	arcgisRetries := flag.Int("arcgis-retries", ingest.DefaultArcGISRetries, "retries per failed ArcGIS request")
+	arcgisRateLimit := flag.Float64("arcgis-rate-limit", 0, "maximum ArcGIS requests per second (0 is unlimited)")
+	appraisal := flag.String("appraisal", "", "appraisal roll export whose owner and address attributes refresh the county's parcels and whose values are recorded as the year's assessments")
+	appraisalLayout := flag.String("appraisal-layout", "", "fixed-width layout JSON (default: built-in Texas appraisal export layout)")
+	appraisalDelimiter := flag.String("appraisal-delimiter", "", "field delimiter of a delimited roll with a header row, e.g. \",\" or \"|\" (default: fixed width)")
+	hydrology := flag.String("hydrology", "", "GeoJSON FeatureCollection of lakes or rivers whose frontage is computed for the county's parcels")
+	waterKind := flag.String("water-kind", "", "kind of the -hydrology features (lake|river)")
+	waterNameField := flag.String("water-name-field", ingest.DefaultWaterNameField, "-hydrology feature property holding the water body name")
+	amenities := flag.String("amenities", "", "GeoJSON FeatureCollection of amenities replacing the county's amenities of -amenity-category")
+	amenityCategory := flag.String("amenity-category", "", "category of the -amenities features (school|hospital|fire_station)")
+	amenityNameField := flag.String("amenity-name-field", ingest.DefaultAmenityNameField, "-amenities feature property holding the amenity name")
+	zoning := flag.String("zoning", "", "GeoJSON FeatureCollection of zoning districts replacing the county's zoning")
+	zoningCodeField := flag.String("zoning-code-field", ingest.DefaultZoningCodeField, "-zoning feature property holding the zoning designation")
+	zoningNameField := flag.String("zoning-name-field", ingest.DefaultZoningNameField, "-zoning feature property describing the designation")
+	floodZones := flag.String("flood-zones", "", "GeoJSON FeatureCollection of NFHL flood hazard areas (S_FLD_HAZ_AR) replacing the county's flood zones")
+	jurisdictions := flag.String("jurisdictions", "", "GeoJSON FeatureCollection of boundaries replacing the county's jurisdictions of -jurisdiction-kind")
+	jurisdictionKind := flag.String("jurisdiction-kind", "", "kind of the -jurisdictions features (school_district|taxing_unit)")
+	jurisdictionNameField := flag.String("jurisdiction-name-field", ingest.DefaultJurisdictionNameField, "-jurisdictions feature property holding the name")
+	jurisdictionCodeField := flag.String("jurisdiction-code-field", ingest.DefaultJurisdictionCodeField, "-jurisdictions feature property holding the identifier")
+	layer := flag.String("layer", "", "GeoJSON FeatureCollection replacing the county's features of the generic layer -layer-name")
+	layerName := flag.String("layer-name", "", "URL name of the -layer layer, registered on its first load, e.g. wetlands")
+	layerTitle := flag.String("layer-title", "", "display name of the -layer layer (default: -layer-name)")
+	layerDescription := flag.String("layer-description", "", "description of the -layer layer")
+	layerProperties := flag.String("layer-properties", "", "comma-separated -layer feature properties to keep (default: all)")
+	sales := flag.String("sales", "", "delimited deed or sales export replacing the county's sales history")
+	salesDelimiter := flag.String("sales-delimiter", ",", "field delimiter of the -sales export, e.g. \",\" or \"|\"")
+	derived := flag.Bool("derived", false, "recompute the county's ingest-stage derived attributes (DERIVED_ATTRIBUTES_FILE) without loading")
+	srid := flag.Int("srid", 0, "EPSG code of GeoJSON files without a crs member, e.g. 2278 for Texas South Central state plane or 3857 (default: 4326)")
+	mappingFile := flag.String("mapping", "", "field mapping JSON (default: built-in Montgomery County, TX mapping)")
+	mode := flag.String("mode", ingest.ModeReplace,
+		"replace the county's parcels, append to them, or sync changes by object_id and content hash (replace|append|sync)")
+	maxInvalid := flag.Int("max-invalid", 100, "invalid features to skip before aborting")
+	lockTimeout := flag.Duration("lock-timeout", ingest.DefaultLockTimeout, "how long to wait for a running load of the same county")
+	allowAnomalies := flag.Bool("allow-anomalies", false, "commit a replace or sync load even if it deviates from the county's recent runs beyond the anomaly thresholds")
+	defaultThresholds := ingest.DefaultAnomalyThresholds()
+	maxCountChange := flag.Float64("max-count-change", defaultThresholds.MaxCountChangePct,
+		"max record count change of a replace or sync load vs the county's baseline, in percent either direction")
+	maxAreaChange := flag.Float64("max-area-change", defaultThresholds.MaxAreaChangePct,
+		"max total acreage change of a replace or sync load vs the county's baseline, in percent either direction")
+	maxNullIncrease := flag.Float64("max-null-increase", defaultThresholds.MaxNullRateIncrease,
+		"max increase of the owner, situs and year built null rates vs the county's baseline (0-1)")
+	dryRun := flag.Bool("dry-run", false, "validate the file without connecting to the database")
+	flag.Parse()
+
+	sources := 0
+	for _, source := range []string{*file, *arcgisURL, *appraisal, *hydrology, *amenities, *zoning, *floodZones, *jurisdictions, *layer, *sales} {
+		if source != "" {
+			sources++
+		}
+	}
+	if *derived {
+		sources++
+	}
+	if sources != 1 {
+		fmt.Fprintln(os.Stderr, "exactly one of -file, -arcgis-url, -appraisal, -hydrology, -amenities, -zoning, -flood-zones, -jurisdictions, -layer, -sales or -derived is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *derived {
+		exit(recomputeDerived(*mappingFile, ingest.DerivedOptions{LockTimeout: *lockTimeout}))
+		return
+	}
+
+	if *appraisal != "" {
+		exit(refresh(*appraisal, *appraisalLayout, *appraisalDelimiter, *mappingFile, ingest.RefreshOptions{
+			MaxInvalid:  *maxInvalid,
+			LockTimeout: *lockTimeout,
+			DryRun:      *dryRun,
+		}))
+		return
+	}
+
+	if *hydrology != "" {
+		exit(loadHydrology(*hydrology, *srid, *mappingFile, ingest.HydrologyOptions{
+			Kind:        *waterKind,
+			NameField:   *waterNameField,
+			MaxInvalid:  *maxInvalid,
+			LockTimeout: *lockTimeout,
+			DryRun:      *dryRun,
+		}))
+		return
+	}
+
+	if *amenities != "" {
+		exit(loadAmenities(*amenities, *srid, *mappingFile, ingest.AmenityOptions{
+			Category:    *amenityCategory,
+			NameField:   *amenityNameField,
+			MaxInvalid:  *maxInvalid,
+			LockTimeout: *lockTimeout,
+			DryRun:      *dryRun,
+		}))
+		return
+	}
+
+	if *zoning != "" {
+		opts := ingest.ZoningOptions{
+			CodeField:   *zoningCodeField,
+			NameField:   *zoningNameField,
+			MaxInvalid:  *maxInvalid,
+			LockTimeout: *lockTimeout,
+			DryRun:      *dryRun,
+		}
+		exit(runLayer(*zoning, *srid, *mappingFile, *dryRun, layerRun{
+			name:     "Zoning",
+			features: "zoning districts",
+			load: func(ctx context.Context, loader *ingest.Loader, source ingest.Source) (*ingest.LayerSummary, error) {
+				return loader.LoadZoning(ctx, source, opts)
+			},
+		}))
+		return
+	}
+
+	if *floodZones != "" {
+		opts := ingest.FloodZoneOptions{
+			MaxInvalid:  *maxInvalid,
+			LockTimeout: *lockTimeout,
+			DryRun:      *dryRun,
+		}
+		exit(runLayer(*floodZones, *srid, *mappingFile, *dryRun, layerRun{
+			name:     "Flood zone",
+			features: "flood zones",
+			load: func(ctx context.Context, loader *ingest.Loader, source ingest.Source) (*ingest.LayerSummary, error) {
+				return loader.LoadFloodZones(ctx, source, opts)
+			},
+		}))
+		return
+	}
+
+	if *jurisdictions != "" {
+		opts := ingest.JurisdictionOptions{
+			Kind:        *jurisdictionKind,
+			NameField:   *jurisdictionNameField,
+			CodeField:   *jurisdictionCodeField,
+			MaxInvalid:  *maxInvalid,
+			LockTimeout: *lockTimeout,
+			DryRun:      *dryRun,
+		}
+		exit(runLayer(*jurisdictions, *srid, *mappingFile, *dryRun, layerRun{
+			name:     "Jurisdiction",
+			features: "jurisdictions",
+			fields:   map[string]interface{}{"kind": opts.Kind},
+			load: func(ctx context.Context, loader *ingest.Loader, source ingest.Source) (*ingest.LayerSummary, error) {
+				return loader.LoadJurisdictions(ctx, source, opts)
+			},
+		}))
+		return
+	}
+
+	if *sales != "" {
+		exit(loadSales(*sales, *salesDelimiter, *mappingFile, ingest.SalesOptions{
+			MaxInvalid:  *maxInvalid,
+			LockTimeout: *lockTimeout,
+			DryRun:      *dryRun,
+		}))
+		return
+	}
+
+	if *layer != "" {
+		var properties []string
+		if *layerProperties != "" {
+			properties = strings.Split(*layerProperties, ",")
+		}
+		opts := ingest.LayerOptions{
+			Name:        *layerName,
+			Title:       *layerTitle,
+			Description: *layerDescription,
+			Properties:  properties,
+			MaxInvalid:  *maxInvalid,
+			LockTimeout: *lockTimeout,
+			DryRun:      *dryRun,
+		}
+		exit(runLayer(*layer, *srid, *mappingFile, *dryRun, layerRun{
+			name:     "Layer",
+			features: "layer features",
+			fields:   map[string]interface{}{"layer": opts.Name},
+			load: func(ctx context.Context, loader *ingest.Loader, source ingest.Source) (*ingest.LayerSummary, error) {
+				return loader.LoadLayer(ctx, source, opts)
+			},
+		}))
+		return
+	}
+
+	sourceName := *file
+	openSource := func() (ingest.Source, func(), error) {
+		return openFile(*file, *srid)
+	}
+	if *arcgisURL != "" {
+		sourceName = *arcgisURL
+		retries := *arcgisRetries
+		if retries == 0 {
+			// ArcGISOptions treats zero as the default
+			retries = -1
+		}
+		openSource = func() (ingest.Source, func(), error) {
+			return ingest.NewArcGISSource(*arcgisURL, ingest.ArcGISOptions{
+				Where:      *arcgisWhere,
+				PageSize:   *arcgisPageSize,
+				MaxRetries: retries,
+				RateLimit:  *arcgisRateLimit,
+			}), func() {}, nil
+		}
+	}
+
+	if err := run(openSource, *mappingFile, ingest.Options{
+		Mode:           *mode,
+		SourceFile:     sourceName,
+		MaxInvalid:     *maxInvalid,
+		LockTimeout:    *lockTimeout,
+		DryRun:         *dryRun,
+		AllowAnomalies: *allowAnomalies,
+		Thresholds: ingest.AnomalyThresholds{
+			BaselineRuns:        defaultThresholds.BaselineRuns,
+			MaxCountChangePct:   *maxCountChange,
+			MaxAreaChangePct:    *maxAreaChange,
+			MaxNullRateIncrease: *maxNullIncrease,
+		},
+	}); err != nil {
+		exit(err)
+	}
+}
+
+// exit reports a failed ingest and exits, with exitCountyLocked when another
+// load of the county held the lock and exitAnomaliesBlocked when the load failed
+// the anomaly check. It returns if err is nil.
+func exit(err error) {
+	if err == nil {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Ingest failed: %v\n", err)
+	if errors.Is(err, ingest.ErrCountyLocked) {
+		os.Exit(exitCountyLocked)
+	}
+	if errors.Is(err, ingest.ErrAnomaliesBlocked) {
+		os.Exit(exitAnomaliesBlocked)
+	}
+	os.Exit(1)
+}
+
+// run loads the source and logs a summary.
+func run(openSource func() (ingest.Source, func(), error), mappingFile string, opts ingest.Options) error {
+	mapping, err := loadMapping(mappingFile)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log, loader, closeDB, err := connect(ctx, opts.DryRun, mapping)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	source, closeSource, err := openSource()
+	if err != nil {
+		return err
+	}
+	defer closeSource()
+
+	log.Info("Starting parcel ingest", map[string]interface{}{
+		"source":  opts.SourceFile,
+		"county":  mapping.County,
+		"mode":    opts.Mode,
+		"dry_run": opts.DryRun,
+	})
+
+	start := time.Now()
+	summary, err := loader.Load(ctx, source, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, ingest.ErrTooManyInvalid):
+			log.Error("Ingest aborted, existing parcels left unchanged", err, nil)
+		case errors.Is(err, ingest.ErrCountyLocked):
+			log.Error("Ingest skipped, another load of the county is running", err, nil)
+		case errors.Is(err, ingest.ErrAnomaliesBlocked):
+			log.Error("Ingest blocked, existing parcels left unchanged; re-run with -allow-anomalies to load anyway", err, nil)
+		}
+		return err
+	}
+
+	log.Info("Parcel ingest completed", map[string]interface{}{
+		"read":        summary.Read,
+		"inserted":    summary.Inserted,
+		"updated":     summary.Updated,
+		"unchanged":   summary.Unchanged,
+		"deleted":     summary.Deleted,
+		"invalid":     summary.Invalid,
+		"duration_ms": time.Since(start).Milliseconds(),
+	})
+	return nil
+}
+
+// refresh applies an appraisal roll to the mapping's county and logs a summary.
+func refresh(file, layoutFile, delimiter, mappingFile string, opts ingest.RefreshOptions) error {
+	mapping, err := loadMapping(mappingFile)
+	if err != nil {
+		return err
+	}
+	layout := ingest.DefaultAppraisalLayout()
+	if layoutFile != "" {
+		if layout, err = ingest.LoadAppraisalLayout(layoutFile); err != nil {
+			return err
+		}
+	}
+	if utf8.RuneCountInString(delimiter) > 1 {
+		return fmt.Errorf("invalid appraisal delimiter %q, expected one character", delimiter)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log, loader, closeDB, err := connect(ctx, opts.DryRun, mapping)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	f, err := os.Open(file) // #nosec G304 -- operator-supplied CLI argument
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", file, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	buffered := bufio.NewReaderSize(f, readBufferSize)
+	source := ingest.NewFixedWidthAppraisalReader(buffered, layout)
+	if delimiter != "" {
+		comma, _ := utf8.DecodeRuneInString(delimiter)
+		source = ingest.NewDelimitedAppraisalReader(buffered, comma)
+	}
+
+	log.Info("Starting appraisal refresh", map[string]interface{}{
+		"source":  file,
+		"county":  mapping.County,
+		"dry_run": opts.DryRun,
+	})
+
+	start := time.Now()
+	summary, err := loader.RefreshAppraisal(ctx, source, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, ingest.ErrTooManyInvalid):
+			log.Error("Appraisal refresh aborted, parcels left unchanged", err, nil)
+		case errors.Is(err, ingest.ErrCountyLocked):
+			log.Error("Appraisal refresh skipped, another load of the county is running", err, nil)
+		}
+		return err
+	}
+
+	log.Info("Appraisal refresh completed", map[string]interface{}{
+		"read":        summary.Read,
+		"updated":     summary.Updated,
+		"unchanged":   summary.Unchanged,
+		"unmatched":   summary.Unmatched,
+		"assessments": summary.Assessments,
+		"skipped":     summary.Skipped,
+		"invalid":     summary.Invalid,
+		"duration_ms": time.Since(start).Milliseconds(),
+	})
+	return nil
+}
+
+// loadHydrology loads a hydrology layer into the mapping's county and logs a summary.
+func loadHydrology(file string, srid int, mappingFile string, opts ingest.HydrologyOptions) error {
+	mapping, err := loadMapping(mappingFile)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log, loader, closeDB, err := connect(ctx, opts.DryRun, mapping)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	source, closeSource, err := openFile(file, srid)
+	if err != nil {
+		return err
+	}
+	defer closeSource()
+
+	log.Info("Starting hydrology load", map[string]interface{}{
+		"source":  file,
+		"county":  mapping.County,
+		"kind":    opts.Kind,
+		"dry_run": opts.DryRun,
+	})
+
+	start := time.Now()
+	summary, err := loader.LoadHydrology(ctx, source, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, ingest.ErrTooManyInvalid):
+			log.Error("Hydrology load aborted, water features left unchanged", err, nil)
+		case errors.Is(err, ingest.ErrCountyLocked):
+			log.Error("Hydrology load skipped, another load of the county is running", err, nil)
+		}
+		return err
+	}
+
+	log.Info("Hydrology load completed", map[string]interface{}{
+		"read":        summary.Read,
+		"loaded":      summary.Loaded,
+		"updated":     summary.Updated,
+		"waterfront":  summary.Waterfront,
+		"invalid":     summary.Invalid,
+		"duration_ms": time.Since(start).Milliseconds(),
+	})
+	return nil
+}
+
+// loadAmenities loads an amenity layer into the mapping's county and logs a summary.
+func loadAmenities(file string, srid int, mappingFile string, opts ingest.AmenityOptions) error {
+	mapping, err := loadMapping(mappingFile)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log, loader, closeDB, err := connect(ctx, opts.DryRun, mapping)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	source, closeSource, err := openFile(file, srid)
+	if err != nil {
+		return err
+	}
+	defer closeSource()
+
+	log.Info("Starting amenity load", map[string]interface{}{
+		"source":   file,
+		"county":   mapping.County,
+		"category": opts.Category,
+		"dry_run":  opts.DryRun,
+	})
+
+	start := time.Now()
+	summary, err := loader.LoadAmenities(ctx, source, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, ingest.ErrTooManyInvalid):
+			log.Error("Amenity load aborted, amenities left unchanged", err, nil)
+		case errors.Is(err, ingest.ErrCountyLocked):
+			log.Error("Amenity load skipped, another load of the county is running", err, nil)
+		}
+		return err
+	}
+
+	log.Info("Amenity load completed", map[string]interface{}{
+		"read":        summary.Read,
+		"loaded":      summary.Loaded,
+		"invalid":     summary.Invalid,
+		"duration_ms": time.Since(start).Milliseconds(),
+	})
+	return nil
+}
+
+// layerRun is a load of layer features for runLayer: the zoning, flood zone
+// and jurisdiction overlays and generic layers share their source handling and
+// logging.
+type layerRun struct {
+	// name starts the log messages of the load, e.g. "Zoning"
+	name string
+	// features is what an aborted load leaves unchanged, e.g. "zoning districts"
+	features string
+	// fields are logged with the start of the load
+	fields map[string]interface{}
+	// load runs the load with the options from the command line
+	load func(ctx context.Context, loader *ingest.Loader, source ingest.Source) (*ingest.LayerSummary, error)
+}
+
+// runLayer loads the features of a GeoJSON file into the mapping's county,
+// replacing the county's features of the layer, and logs a summary.
+func runLayer(file string, srid int, mappingFile string, dryRun bool, run layerRun) error {
+	mapping, err := loadMapping(mappingFile)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log, loader, closeDB, err := connect(ctx, dryRun, mapping)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	source, closeSource, err := openFile(file, srid)
+	if err != nil {
+		return err
+	}
+	defer closeSource()
+
+	fields := map[string]interface{}{
+		"source":  file,
+		"county":  mapping.County,
+		"dry_run": dryRun,
+	}
+	for key, value := range run.fields {
+		fields[key] = value
+	}
+	log.Info("Starting "+strings.ToLower(run.name)+" load", fields)
+
+	start := time.Now()
+	summary, err := run.load(ctx, loader, source)
+	if err != nil {
+		switch {
+		case errors.Is(err, ingest.ErrTooManyInvalid):
+			log.Error(run.name+" load aborted, "+run.features+" left unchanged", err, nil)
+		case errors.Is(err, ingest.ErrCountyLocked):
+			log.Error(run.name+" load skipped, another load of the county is running", err, nil)
+		}
+		return err
+	}
+
+	log.Info(run.name+" load completed", map[string]interface{}{
+		"read":        summary.Read,
+		"loaded":      summary.Loaded,
+		"invalid":     summary.Invalid,
+		"duration_ms": time.Since(start).Milliseconds(),
+	})
+	return nil
+}
+
+// loadSales loads a delimited sales export into the mapping's county, replacing
+// the county's sales history.
+func loadSales(file, delimiter, mappingFile string, opts ingest.SalesOptions) error {
+	mapping, err := loadMapping(mappingFile)
+	if err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(delimiter) != 1 {
+		return fmt.Errorf("invalid sales delimiter %q, expected one character", delimiter)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log, loader, closeDB, err := connect(ctx, opts.DryRun, mapping)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	f, err := os.Open(file) // #nosec G304 -- operator-supplied CLI argument
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", file, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	comma, _ := utf8.DecodeRuneInString(delimiter)
+	source := ingest.NewSalesReader(bufio.NewReaderSize(f, readBufferSize), comma)
+
+	log.Info("Starting sales load", map[string]interface{}{
+		"source":  file,
+		"county":  mapping.County,
+		"dry_run": opts.DryRun,
+	})
+
+	start := time.Now()
+	summary, err := loader.LoadSales(ctx, source, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, ingest.ErrTooManyInvalid):
+			log.Error("Sales load aborted, sales left unchanged", err, nil)
+		case errors.Is(err, ingest.ErrCountyLocked):
+			log.Error("Sales load skipped, another load of the county is running", err, nil)
+		}
+		return err
+	}
+
+	log.Info("Sales load completed", map[string]interface{}{
+		"read":        summary.Read,
+		"loaded":      summary.Loaded,
+		"invalid":     summary.Invalid,
+		"unmatched":   summary.Unmatched,
+		"duration_ms": time.Since(start).Milliseconds(),
+	})
+	return nil
+}
+
+// recomputeDerived stores the configured ingest-stage derived attributes of the
+// mapping's county again and logs how many parcels changed.
+func recomputeDerived(mappingFile string, opts ingest.DerivedOptions) error {
+	mapping, err := loadMapping(mappingFile)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log, loader, closeDB, err := connect(ctx, false, mapping)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	log.Info("Starting derived attribute recompute", map[string]interface{}{
+		"county": mapping.County,
+	})
+
+	start := time.Now()
+	updated, err := loader.RecomputeDerived(ctx, opts)
+	if err != nil {
+		if errors.Is(err, ingest.ErrCountyLocked) {
+			log.Error("Derived attribute recompute skipped, another load of the county is running", err, nil)
+		}
+		return err
+	}
+
+	log.Info("Derived attribute recompute completed", map[string]interface{}{
+		"updated":     updated,
+		"duration_ms": time.Since(start).Milliseconds(),
+	})
+	return nil
+}
+
+// loadMapping reads the mapping file, or returns the built-in mapping if none is given.
+func loadMapping(mappingFile string) (*ingest.Mapping, error) {
+	if mappingFile == "" {
+		return ingest.DefaultMapping(), nil
+	}
+	return ingest.LoadMapping(mappingFile)
+}
+
+// connect loads the configuration and connects to the database, returning a
+// loader for the mapping's county that stores the configured derived attributes,
+// and encrypts owner contact columns when configured, and a function that closes
+// the database. Dry runs validate the source without configuration or a database.
+func connect(ctx context.Context, dryRun bool, mapping *ingest.Mapping) (*logger.Logger, *ingest.Loader, func(), error) {
+	if dryRun {
+		log := logger.New(os.Getenv("ENV"))
+		return log, ingest.NewLoader(nil, mapping, log), func() {}, nil
+	}
+	a, err := app.Load(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	loader := ingest.NewLoader(a.DB, mapping, a.Log).
+		WithDerivedAttributes(a.Config.Derived.Attributes).
+		WithKeyring(a.Keyring)
+	return a.Log, loader, func() { _ = a.Stop(context.Background()) }, nil
+}
+
+// openFile opens a GeoJSON file as a buffered Reader, taking coordinates of
+// files without a crs member to be in srid (0 for WGS84).
+func openFile(file string, srid int) (ingest.Source, func(), error) {
+	f, err := os.Open(file) // #nosec G304 -- operator-supplied CLI argument
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open %s: %w", file, err)
+	}
+	return ingest.NewReader(bufio.NewReaderSize(f, readBufferSize)).WithSRID(srid), func() {
+		_ = f.Close()
+	}, nil
+}
@@ -0,0 +1,100 @@
+// Command migrate inspects the database schema. Migrations are applied with
+// golang-migrate (make migrate-up); this command only reports.
+//
+// Usage:
+//
+//	migrate plan [-json]
+//
+// plan compares the live schema with the embedded migrations and the model
+// definitions and lists the drift: pending migrations, a dirty version,
+// missing indexes and columns, and columns whose type changed. Nothing is
+// applied. It exits with status 1 when there is drift, so it can gate deploys;
+// GET /api/v1/admin/schema serves the same report for monitoring.
+//
+// Configuration comes from the same environment variables and .env file as the
+// API server.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/stwalsh4118/atlas/api/internal/app"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "plan" {
+		fmt.Fprintln(os.Stderr, "usage: migrate plan [-json]")
+		os.Exit(2)
+	}
+	plan := flag.NewFlagSet("plan", flag.ExitOnError)
+	asJSON := plan.Bool("json", false, "print the report as JSON")
+	_ = plan.Parse(os.Args[2:])
+
+	ctx := context.Background()
+	a, err := app.Load(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize: %v\n", err)
+		os.Exit(1)
+	}
+	defer func() { _ = a.Stop(context.Background()) }()
+
+	report, err := a.Services.Schema.Plan(ctx)
+	if err != nil {
+		fail(a, err)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(report)
+	} else {
+		printReport(report)
+	}
+
+	if report.Drift {
+		_ = a.Stop(context.Background())
+		os.Exit(1)
+	}
+}
+
+// printReport prints the report for people: a version line, then one line per
+// difference.
+func printReport(report *models.SchemaReport) {
+	dirty := ""
+	if report.Dirty {
+		dirty = " (dirty: a migration failed; fix it and run make migrate-force)"
+	}
+	fmt.Printf("Schema version %d of %d%s\n", report.CurrentVersion, report.LatestVersion, dirty)
+	if !report.Drift {
+		fmt.Println("No drift")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, migration := range report.Pending {
+		fmt.Fprintf(w, "pending migration\t%06d_%s\t\n", migration.Version, migration.Name)
+	}
+	for _, index := range report.MissingIndexes {
+		fmt.Fprintf(w, "missing index\t%s\t\n", index)
+	}
+	for _, column := range report.MissingColumns {
+		fmt.Fprintf(w, "missing column\t%s.%s\texpected %s\n", column.Table, column.Column, column.Expected)
+	}
+	for _, column := range report.TypeMismatches {
+		fmt.Fprintf(w, "changed type\t%s.%s\texpected %s, found %s\n", column.Table, column.Column, column.Expected, column.Actual)
+	}
+	_ = w.Flush()
+}
+
+// fail closes the database and exits; deferred calls do not run on os.Exit.
+func fail(a *app.App, err error) {
+	fmt.Fprintf(os.Stderr, "Failed: %v\n", err)
+	_ = a.Stop(context.Background())
+	os.Exit(1)
+}
@@ -0,0 +1,178 @@
+// Command replay validates a schema or query change before rollout by driving
+// production requests against a staging deployment. Production captures a
+// sample of its parcel requests, anonymized, when
+// QUERY_AUDIT_REPLAY_SAMPLE_RATIO is set.
+//
+// Usage:
+//
+//	replay export [-since 24h] [-limit 10000] > replay.jsonl
+//	replay run -target https://staging.example.com [-api-key KEY] [-concurrency 4]
+//	           [-latency-factor 2] [-json] < replay.jsonl
+//
+// export writes the captures of the last -since from the database as JSON
+// Lines; it is configured from the same environment variables and .env file
+// as the API server, pointing at production. run replays a log against
+// -target and compares each response with production's: the status, the
+// X-Result-Count header and the latency. Captures keep no credentials, so
+// -api-key authenticates every request; use a key of a tenant with the same
+// counties as production's callers, or result counts will differ.
+//
+// run prints the status and result count mismatches and each route's p50 and
+// p95 latency in production and on the target. It exits with status 1 when a
+// request mismatched or failed, or a route's p95 latency grew more than
+// -latency-factor times (0 disables the latency check), so it can gate deploys.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/app"
+	"github.com/stwalsh4118/atlas/api/internal/replay"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	switch os.Args[1] {
+	case "export":
+		export(os.Args[2:])
+	case "run":
+		run(os.Args[2:])
+	default:
+		usage()
+	}
+}
+
+// usage prints the commands and exits.
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: replay export [-since 24h] [-limit N] | replay run -target URL [flags] < replay.jsonl")
+	os.Exit(2)
+}
+
+// export writes the recent captures to standard output.
+func export(args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	since := flags.Duration("since", 24*time.Hour, "export the captures of this last period")
+	limit := flags.Int("limit", 10000, "export at most this many captures, oldest first")
+	_ = flags.Parse(args)
+	if *since <= 0 || *limit < 1 {
+		fmt.Fprintln(os.Stderr, "-since must be positive and -limit at least 1")
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+	a, err := app.Load(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize: %v\n", err)
+		os.Exit(1)
+	}
+	defer func() { _ = a.Stop(context.Background()) }()
+
+	captures, err := a.Repositories.QueryAudit.ListReplay(ctx, time.Now().Add(-*since), *limit)
+	if err != nil {
+		fail(a, err)
+	}
+	if err := replay.Write(os.Stdout, captures); err != nil {
+		fail(a, err)
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d captures\n", len(captures))
+}
+
+// run replays the log on standard input. It needs no database.
+func run(args []string) {
+	flags := flag.NewFlagSet("run", flag.ExitOnError)
+	target := flags.String("target", "", "base URL of the deployment to replay against")
+	apiKey := flags.String("api-key", "", "API key sent with every request")
+	concurrency := flags.Int("concurrency", 4, "requests in flight at once")
+	timeout := flags.Duration("timeout", 30*time.Second, "timeout of each request")
+	latencyFactor := flags.Float64("latency-factor", 2, "fail when a route's p95 latency grows more than this many times; 0 disables")
+	asJSON := flags.Bool("json", false, "print the report as JSON")
+	_ = flags.Parse(args)
+	if *target == "" || *concurrency < 1 || *latencyFactor < 0 {
+		fmt.Fprintln(os.Stderr, "-target is required, -concurrency must be at least 1 and -latency-factor not negative")
+		os.Exit(2)
+	}
+
+	captures, err := replay.Read(os.Stdin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Ctrl-C stops sending and reports what was replayed
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	runner := &replay.Runner{
+		Client:      &http.Client{Timeout: *timeout},
+		Target:      *target,
+		APIKey:      *apiKey,
+		Concurrency: *concurrency,
+	}
+	report := runner.Run(ctx, captures)
+
+	var regressed []replay.RouteReport
+	if *latencyFactor > 0 {
+		regressed = report.LatencyRegressions(*latencyFactor)
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(report)
+	} else {
+		printReport(report, regressed)
+	}
+
+	if report.Failed() || len(regressed) > 0 {
+		os.Exit(1)
+	}
+}
+
+// printReport prints the report for people: the mismatches, then a table of
+// routes.
+func printReport(report *replay.Report, regressed []replay.RouteReport) {
+	for _, m := range report.Mismatches {
+		request := m.Capture.Method + " " + m.Capture.Path
+		if m.Capture.Query != "" {
+			request += "?" + m.Capture.Query
+		}
+		switch {
+		case m.Error != "":
+			fmt.Printf("error     %s: %s\n", request, m.Error)
+		case m.Status != m.Capture.Status:
+			fmt.Printf("status    %s: %d, production %d\n", request, m.Status, m.Capture.Status)
+		default:
+			fmt.Printf("count     %s: %d, production %d\n", request, *m.ResultCount, *m.Capture.ResultCount)
+		}
+	}
+	for _, route := range regressed {
+		fmt.Printf("latency   %s: p95 %v, production %v\n", route.Route, route.TargetP95.Round(time.Millisecond), route.BaselineP95)
+	}
+	if len(report.Mismatches) > 0 || len(regressed) > 0 {
+		fmt.Println()
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ROUTE\tREQUESTS\tSTATUS\tCOUNT\tERRORS\tP50\tP95\tPROD P50\tPROD P95")
+	for _, route := range append(report.Routes, report.Summary) {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%v\t%v\t%v\t%v\n", route.Route, route.Requests,
+			route.StatusMismatches, route.CountMismatches, route.Errors,
+			route.TargetP50.Round(time.Millisecond), route.TargetP95.Round(time.Millisecond), route.BaselineP50, route.BaselineP95)
+	}
+	_ = w.Flush()
+}
+
+// fail closes the database and exits; deferred calls do not run on os.Exit.
+func fail(a *app.App, err error) {
+	fmt.Fprintf(os.Stderr, "Failed: %v\n", err)
+	_ = a.Stop(context.Background())
+	os.Exit(1)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+)
+
+// listener is an HTTP server of the process, named in its logs.
+type listener struct {
+	name string
+	srv  *http.Server
+	// certFile and keyFile serve HTTPS when set
+	certFile, keyFile string
+}
+
+// serve accepts connections until the listener is shut down. Failing to
+// listen exits the process.
+func (l listener) serve(log *logger.Logger) {
+	log.Info(l.name+" listening", map[string]interface{}{
+		"addr": l.srv.Addr,
+		"tls":  l.certFile != "",
+	})
+	var err error
+	if l.certFile != "" {
+		err = l.srv.ListenAndServeTLS(l.certFile, l.keyFile)
+	} else {
+		err = l.srv.ListenAndServe()
+	}
+	if err != nil && err != http.ErrServerClosed {
+		log.Fatal(l.name+" failed to start", err, nil)
+	}
+}
+
+// shutdownListeners shuts the listeners down at once, each draining its own
+// requests until ctx ends; one that is forced to close does not hold up the others.
+func shutdownListeners(ctx context.Context, log *logger.Logger, listeners []listener) {
+	var wg sync.WaitGroup
+	for _, l := range listeners {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := l.srv.Shutdown(ctx); err != nil {
+				log.Error(l.name+" forced to shutdown", err, map[string]interface{}{
+					"timeout": shutdownTimeout.String(),
+				})
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// httpListener returns the plain HTTP listener of HTTP_PORT: a redirect to
+// HTTPS on PORT, or the /health endpoints of router.
+func httpListener(cfg config.ServerConfig, router http.Handler) listener {
+	handler := httpsRedirect(cfg.Port)
+	if cfg.HTTPMode == config.HTTPModeHealth {
+		handler = healthOnly(router)
+	}
+	return listener{
+		name: "HTTP " + cfg.HTTPMode,
+		srv:  &http.Server{Addr: ":" + cfg.HTTPPort, Handler: handler},
+	}
+}
+
+// httpsRedirect redirects requests to the same host, path and query over
+// HTTPS on port, with 308 so clients repeat the method and body.
+func httpsRedirect(port string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		host = strings.Trim(host, "[]")
+		if host == "" {
+			http.Error(w, "missing Host header", http.StatusBadRequest)
+			return
+		}
+		if port == "443" {
+			// Brackets IPv6 addresses like JoinHostPort does
+			host = strings.TrimSuffix(net.JoinHostPort(host, port), ":443")
+		} else {
+			host = net.JoinHostPort(host, port)
+		}
+		target := url.URL{Scheme: "https", Host: host, Path: r.URL.Path, RawPath: r.URL.RawPath, RawQuery: r.URL.RawQuery}
+		http.Redirect(w, r, target.String(), http.StatusPermanentRedirect)
+	})
+}
+
+// healthOnly serves the /health endpoints of router and 404 for other paths,
+// for load balancer and uptime checks that cannot speak HTTPS.
+func healthOnly(router http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" && !strings.HasPrefix(r.URL.Path, "/health/") {
+			http.NotFound(w, r)
+			return
+		}
+		router.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPSRedirect(t *testing.T) {
+	testCases := []struct {
+		name     string
+		port     string
+		method   string
+		host     string
+		target   string
+		status   int
+		location string
+	}{
+		{"default port drops the port", "443", http.MethodGet, "example.com", "/api/v1/parcels/42?fields=id&srid=3857",
+			http.StatusPermanentRedirect, "https://example.com/api/v1/parcels/42?fields=id&srid=3857"},
+		{"HTTP port is replaced", "8443", http.MethodGet, "example.com:8080", "/health",
+			http.StatusPermanentRedirect, "https://example.com:8443/health"},
+		{"POST keeps its method", "443", http.MethodPost, "example.com:80", "/api/v1/graphql",
+			http.StatusPermanentRedirect, "https://example.com/api/v1/graphql"},
+		{"escaped path is kept", "443", http.MethodGet, "example.com", "/api/v1/layers/a%2Fb/at-point?lat=30.3&lng=-95.4",
+			http.StatusPermanentRedirect, "https://example.com/api/v1/layers/a%2Fb/at-point?lat=30.3&lng=-95.4"},
+		{"IPv6 host on the default port", "443", http.MethodGet, "[::1]:8080", "/",
+			http.StatusPermanentRedirect, "https://[::1]/"},
+		{"IPv6 host on another port", "8443", http.MethodGet, "[::1]", "/",
+			http.StatusPermanentRedirect, "https://[::1]:8443/"},
+		{"missing host", "443", http.MethodGet, "", "/", http.StatusBadRequest, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.target, nil)
+			req.Host = tc.host
+			w := httptest.NewRecorder()
+
+			httpsRedirect(tc.port).ServeHTTP(w, req)
+
+			assert.Equal(t, tc.status, w.Code)
+			assert.Equal(t, tc.location, w.Header().Get("Location"))
+		})
+	}
+}
+
+func TestHealthOnly(t *testing.T) {
+	router := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(r.URL.Path))
+	})
+
+	testCases := []struct {
+		path   string
+		status int
+	}{
+		{"/health", http.StatusOK},
+		{"/health/ready", http.StatusOK},
+		{"/health/live", http.StatusOK},
+		{"/healthz", http.StatusNotFound},
+		{"/", http.StatusNotFound},
+		{"/api/v1/parcels/42", http.StatusNotFound},
+		{"/api/v1/health", http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			healthOnly(router).ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+			assert.Equal(t, tc.status, w.Code)
+			if tc.status == http.StatusOK {
+				assert.Equal(t, tc.path, w.Body.String(), "health paths reach the router")
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"net/http"
 	"os"
@@ -10,22 +11,27 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/stwalsh4118/atlas/api/internal/app"
 	"github.com/stwalsh4118/atlas/api/internal/config"
-	"github.com/stwalsh4118/atlas/api/internal/database"
-	"github.com/stwalsh4118/atlas/api/internal/handlers"
 	"github.com/stwalsh4118/atlas/api/internal/logger"
 	"github.com/stwalsh4118/atlas/api/internal/middleware"
-	"github.com/stwalsh4118/atlas/api/internal/repository"
-	"github.com/stwalsh4118/atlas/api/internal/services"
+	"github.com/stwalsh4118/atlas/api/internal/routes"
 )
 
 const (
+	apiTitle        = "Atlas API"
+	apiVersion      = "0.1.0"
 	shutdownTimeout = 30 * time.Second
+	// embedPathPrefix is served with a public CORS policy for partner sites
+	embedPathPrefix = "/api/v1/embed"
 )
 
 func main() {
-	// Load configuration from environment variables
-	cfg, err := config.Load()
+	configFile := flag.String("config", "", "YAML or TOML config file (default: config.yaml, config.yml or config.toml if present)")
+	flag.Parse()
+
+	// Load configuration from the config file and environment variables
+	cfg, err := config.LoadFile(*configFile)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
 		os.Exit(1)
@@ -33,31 +39,19 @@ func main() {
 
 	// Initialize structured logger
 	log := logger.New(cfg.Server.Env)
+	_ = log.SetLevel(cfg.Server.Level()) // validated by config.LoadFile
 	log.Info("Starting Atlas API", map[string]interface{}{
-		"version":     "0.1.0",
+		"version":     apiVersion,
 		"environment": cfg.Server.Env,
 		"port":        cfg.Server.Port,
 	})
 
-	// Create database connection pool
+	// Connect to the database and wire the repositories, services and handlers
 	ctx := context.Background()
-	db, err := database.NewPostgresPool(ctx, cfg.Database)
+	a, err := app.New(ctx, cfg, log)
 	if err != nil {
-		log.Fatal("Failed to connect to database", err, map[string]interface{}{
-			"host": cfg.Database.Host,
-			"port": cfg.Database.Port,
-			"name": cfg.Database.Name,
-		})
+		log.Fatal("Failed to initialize application", err, nil)
 	}
-	defer db.Close()
-
-	log.Info("Database connection established", map[string]interface{}{
-		"host":     cfg.Database.Host,
-		"port":     cfg.Database.Port,
-		"database": cfg.Database.Name,
-		"pool_min": cfg.Database.PoolMin,
-		"pool_max": cfg.Database.PoolMax,
-	})
 
 	// Setup Gin router
 	if cfg.Server.Env == "production" {
@@ -65,51 +59,129 @@ func main() {
 	}
 	router := gin.New()
 
-	// Add middleware in order: RequestID -> Logger -> Recovery -> CORS
+	// X-Forwarded-For is only believed from the configured proxies
+	proxies := make([]string, 0, len(cfg.Security.TrustedProxies))
+	for _, proxy := range cfg.Security.TrustedProxies {
+		proxies = append(proxies, proxy.String())
+	}
+	if err := router.SetTrustedProxies(proxies); err != nil {
+		log.Fatal("Invalid trusted proxies", err, nil)
+	}
+
+	// Add middleware in order: RequestID -> SecurityHeaders -> Metrics -> Logger -> Tracing -> Recovery -> Compression -> CORS -> Tenant -> RetryBudget -> BruteForceGuard -> APIKeyAuth -> UserAuth -> AuditTrail
 	router.Use(middleware.RequestID())
+	router.Use(middleware.SecurityHeaders(middleware.SecurityOptions{
+		HSTSMaxAge:     int(cfg.Security.HSTSMaxAge.Seconds()),
+		FrameAncestors: cfg.Security.FrameAncestors,
+		ReferrerPolicy: cfg.Security.ReferrerPolicy,
+	}))
+	if a.Services.HTTPMetrics != nil {
+		router.Use(middleware.Metrics(a.Services.HTTPMetrics))
+	}
 	router.Use(middleware.Logger(log))
+	if a.Services.Tracer != nil {
+		router.Use(middleware.Tracing(a.Services.Tracer))
+	}
 	router.Use(middleware.Recovery(log))
-	router.Use(middleware.CORS(cfg.CORS.Origins))
-
-	// Register health check routes
-	healthHandler := handlers.NewHealthHandler(db, cfg.Server.Env)
-	router.GET("/health", healthHandler.Health)
-	router.GET("/health/ready", healthHandler.Ready)
-	router.GET("/api/v1/info", healthHandler.Info)
-
-	// Initialize repository and service layers
-	parcelRepo := repository.NewParcelRepository(db)
-	parcelService := services.NewParcelService(parcelRepo, log)
-
-	// Initialize handlers
-	parcelHandler := handlers.NewParcelHandler(parcelService)
-
-	// Register API v1 routes
-	v1 := router.Group("/api/v1")
-	{
-		parcels := v1.Group("/parcels")
-		{
-			parcels.GET("/at-point", parcelHandler.AtPoint)
-			parcels.GET("/nearby", parcelHandler.Nearby)
-		}
+	if cfg.Compression.Enabled {
+		router.Use(middleware.Compression(cfg.Compression.Level, cfg.Compression.MinSize))
+	}
+	cors := middleware.NewCORSPolicy(cfg.CORS.Origins, embedPathPrefix)
+	router.Use(cors.Handler())
+	router.Use(middleware.Tenant())
+	if cfg.Database.RetryBudget > 0 {
+		router.Use(middleware.RetryBudget(cfg.Database.RetryBudget))
+	}
+	router.Use(middleware.BruteForceGuard(a.Services.Security))
+	router.Use(middleware.APIKeyAuth(a.Services.APIKeys))
+	if a.Services.Tokens != nil {
+		router.Use(middleware.UserAuth(a.Services.Tokens))
+	}
+	if a.Services.SIEM != nil {
+		router.Use(middleware.AuditTrail(a.Services.SIEM))
 	}
 
-	// Create HTTP server
-	srv := &http.Server{
-		Addr:    fmt.Sprintf(":%s", cfg.Server.Port),
-		Handler: router,
+	// Route the app's handlers; parcel routes also record usage
+	h := routeHandlers{
+		Handlers: a.Handlers,
+		// Sampled at-point results are re-checked against the geometry in Go
+		atPointMiddleware: []gin.HandlerFunc{middleware.UsageTracker(a.Services.Audit)},
+	}
+	if a.Services.QueryAudit != nil {
+		// Usage accounting of every parcel query
+		h.queryAuditMiddleware = []gin.HandlerFunc{middleware.QueryAudit(a.Services.QueryAudit,
+			cfg.QueryAudit.ReplaySampleRatio)}
+	}
+	if cfg.Warming.Enabled {
+		// Feeds the hotspot table the warming job reads
+		h.parcelMiddleware = []gin.HandlerFunc{middleware.UsageTracker(a.Services.Warming)}
+	}
+	if h.Widgets == nil {
+		log.Warn("EMBED_SIGNING_SECRET not set; embeddable widget endpoints disabled", nil)
+	}
+	if h.Users == nil {
+		log.Warn("JWT_SIGNING_SECRET not set; user account, saved search, favorite and annotation endpoints disabled", nil)
 	}
 
-	// Start server in goroutine
-	go func() {
-		log.Info("Server listening", map[string]interface{}{
-			"port": cfg.Server.Port,
-			"addr": srv.Addr,
+	// Register routes from their declarations
+	registry := routes.NewRegistry()
+	if cfg.Auth.APIKeysRequired {
+		registry.RequireAPIKeys()
+	} else {
+		log.Warn("API_KEYS_REQUIRED is false; the API accepts anonymous requests", nil)
+	}
+	if cfg.Security.AdminNetworks != nil {
+		registry.RestrictAdmin(cfg.Security.AdminNetworks)
+	}
+	declareRoutes(registry, h)
+	if a.Services.ResponseCache != nil {
+		// Deterministic GET routes named in RESPONSE_CACHE_ROUTES answer repeated requests from the cache
+		// Their TTLs are looked up by route name, so a reload can change them
+		ttls := middleware.NewRouteTTLs(cfg.ResponseCache.Routes)
+		err := registry.CacheResponses(cfg.ResponseCache.Routes, func(time.Duration) gin.HandlerFunc {
+			return middleware.ReloadableResponseCache(a.Services.ResponseCache, ttls, cfg.ResponseCache.Precision, log)
 		})
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatal("Server failed to start", err, nil)
+		if err != nil {
+			log.Fatal("Invalid RESPONSE_CACHE_ROUTES", err, nil)
 		}
-	}()
+		a.OnReload(func(cfg *config.Config) { ttls.Set(cfg.ResponseCache.Routes) })
+	}
+	a.OnReload(func(cfg *config.Config) { cors.SetOrigins(cfg.CORS.Origins) })
+	// Parcels link the related parcel routes that were declared
+	h.Parcels.SetLinks(registry.Links("parcels"))
+	if err := registry.Mount(router); err != nil {
+		log.Fatal("Invalid route declarations", err, nil)
+	}
+
+	// Start background jobs; stopped during graceful shutdown
+	a.Append(a.RequestJobs())
+	// SIGHUP reloads the log level, CORS origins, provider rate limits and cache TTLs
+	a.Append(a.ReloadOnSignal(*configFile))
+	if cfg.Worker.Enabled {
+		log.Info("Scheduled jobs run by the worker (WORKER_ENABLED=true)", nil)
+	} else {
+		a.Append(a.ScheduledJobs())
+	}
+	if err := a.Start(ctx); err != nil {
+		log.Fatal("Failed to start application", err, nil)
+	}
+
+	// Create the server, over HTTPS when TLS_CERT_FILE is set, and the plain
+	// HTTP listener of HTTP_PORT, which redirects to it or serves health checks
+	listeners := []listener{{
+		name:     "Server",
+		srv:      &http.Server{Addr: fmt.Sprintf(":%s", cfg.Server.Port), Handler: router},
+		certFile: cfg.Server.TLSCertFile,
+		keyFile:  cfg.Server.TLSKeyFile,
+	}}
+	if cfg.Server.HTTPPort != "" {
+		listeners = append(listeners, httpListener(cfg.Server, router))
+	}
+
+	// Start servers in goroutines
+	for _, l := range listeners {
+		go l.serve(log)
+	}
 
 	// Wait for interrupt signal (SIGINT or SIGTERM)
 	quit := make(chan os.Signal, 1)
@@ -122,8 +194,11 @@ func main() {
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
 
-	if err := srv.Shutdown(shutdownCtx); err != nil {
-		log.Error("Server forced to shutdown", err, map[string]interface{}{
+	shutdownListeners(shutdownCtx, log, listeners)
+
+	// Stop background jobs, then close the database
+	if err := a.Stop(shutdownCtx); err != nil {
+		log.Error("Application forced to stop", err, map[string]interface{}{
 			"timeout": shutdownTimeout.String(),
 		})
 	}
@@ -0,0 +1,415 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/gin-gonic/gin"
+	"github.com/stwalsh4118/atlas/api/internal/app"
+	"github.com/stwalsh4118/atlas/api/internal/handlers"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/routes"
+)
+
+// routeHandlers are the handlers behind the declared routes.
+// Optional features leave their handler nil, which skips their routes.
+type routeHandlers struct {
+	app.Handlers
+	// queryAuditMiddleware runs first on every route that queries parcels
+	queryAuditMiddleware []gin.HandlerFunc
+	// parcelMiddleware runs on every parcel route, e.g. usage tracking for cache warming
+	parcelMiddleware []gin.HandlerFunc
+	// atPointMiddleware runs on the at-point route after parcelMiddleware
+	atPointMiddleware []gin.HandlerFunc
+}
+
+// declareRoutes adds every API endpoint to the registry.
+func declareRoutes(registry *routes.Registry, h routeHandlers) {
+	registry.Add(
+		routes.Route{Method: http.MethodGet, Path: "/health", Name: "health", Tag: "health",
+			Summary: "Liveness check", Handler: h.Health.Health, Response: handlers.HealthResponse{},
+			Scope: routes.ScopeOpen, RateClass: routes.RateExempt},
+		routes.Route{Method: http.MethodGet, Path: "/health/ready", Name: "health.ready", Tag: "health",
+			Summary: "Readiness with the status, latency and error of each dependency", Handler: h.Health.Ready, Response: handlers.ReadyResponse{},
+			Scope: routes.ScopeOpen, RateClass: routes.RateExempt},
+		routes.Route{Method: http.MethodGet, Path: "/health/leaders", Name: "health.leaders", Tag: "health",
+			Summary: "Scheduled job roles and the instance leading each", Handler: h.Leaders.Leaders, Response: handlers.LeadersResponse{},
+			Scope: routes.ScopeOpen, RateClass: routes.RateExempt},
+		routes.Route{Method: http.MethodGet, Path: "/health/providers", Name: "health.providers", Tag: "health",
+			Summary: "Circuit breaker state and request counters of each external provider", Handler: h.Providers.Providers, Response: handlers.ProvidersResponse{},
+			Scope: routes.ScopeOpen, RateClass: routes.RateExempt},
+		routes.Route{Method: http.MethodGet, Path: "/api/v1/info", Name: "info", Tag: "health",
+			Summary: "API version, environment and uptime", Handler: h.Health.Info, Response: handlers.InfoResponse{},
+			Scope: routes.ScopeOpen, RateClass: routes.RateExempt},
+		routes.Route{Method: http.MethodGet, Path: "/api/v1/openapi.json", Name: "openapi", Tag: "health",
+			Summary: "OpenAPI document generated from the route registry", Handler: registry.OpenAPIHandler(apiTitle, apiVersion),
+			Scope: routes.ScopeOpen, RateClass: routes.RateExempt},
+		routes.Route{Method: http.MethodGet, Path: "/docs", Name: "docs", Tag: "health",
+			Summary: "Swagger UI for the OpenAPI document", Handler: routes.SwaggerUIHandler(apiTitle, "/api/v1/openapi.json"),
+			Scope: routes.ScopeOpen, RateClass: routes.RateExempt},
+	)
+
+	if h.Metrics != nil {
+		registry.Add(routes.Route{Method: http.MethodGet, Path: "/metrics", Name: "metrics", Tag: "health",
+			Summary: "Prometheus metrics of this instance", Handler: h.Metrics.Metrics,
+			Scope: routes.ScopeOpen, RateClass: routes.RateExempt})
+	}
+
+	parcelRoute := func(method, path, name, summary string, handler gin.HandlerFunc, rateClass routes.RateClass) routes.Route {
+		return routes.Route{Method: method, Path: "/api/v1/parcels" + path, Name: "parcels." + name, Tag: "parcels",
+			Summary: summary, Handler: handler, Middleware: concatMiddleware(h.queryAuditMiddleware, h.parcelMiddleware),
+			Scope: routes.ScopePublic, RateClass: rateClass}
+	}
+	atPoint := parcelRoute(http.MethodGet, "/at-point", "at_point", "Parcel containing a point, plus code or what3words address",
+		h.Parcels.AtPoint, routes.RateStandard)
+	atPoint.Query, atPoint.Response = handlers.AtPointRequest{}, handlers.ParcelResponse{}
+	atPoint.Middleware = concatMiddleware(atPoint.Middleware, h.atPointMiddleware)
+	nearby := parcelRoute(http.MethodGet, "/nearby", "nearby", "Parcels within a radius of a point, nearest first",
+		h.Parcels.Nearby, routes.RateStandard)
+	nearby.Query, nearby.Response = handlers.NearbyRequest{}, handlers.NearbyResponse{}
+	identify := parcelRoute(http.MethodGet, "/identify", "identify", "Geometry-free parcel summary for hover tooltips",
+		h.Parcels.Identify, routes.RateStandard)
+	identify.Query, identify.Response = handlers.IdentifyRequest{}, handlers.IdentifyResponse{}
+	identify.CachePolicy = handlers.IdentifyCacheControl
+	alongRoute := parcelRoute(http.MethodPost, "/along-route", "along_route", "Parcels within a buffer of a GeoJSON LineString",
+		h.Parcels.AlongRoute, routes.RateHeavy)
+	alongRoute.Query, alongRoute.Body, alongRoute.Response = handlers.AlongRouteRequest{}, json.RawMessage{}, handlers.AlongRouteResponse{}
+	compare := parcelRoute(http.MethodGet, "/compare", "compare", "Side-by-side comparison of up to 5 parcels",
+		h.Parcels.Compare, routes.RateStandard)
+	compare.Query, compare.Response = handlers.CompareRequest{}, handlers.CompareResponse{}
+	documents := parcelRoute(http.MethodGet, "/:id/documents", "documents", "Deeds, plats and instruments cited by a parcel, with clerk search links",
+		h.Documents.List, routes.RateStandard)
+	documents.Response, documents.Rel = handlers.DocumentListResponse{}, "documents"
+	history := parcelRoute(http.MethodGet, "/:id/history", "history", "Prior versions of a parcel's attributes and boundary, newest first",
+		h.History.List, routes.RateStandard)
+	history.Query, history.Response, history.Rel = handlers.HistoryRequest{}, handlers.ParcelHistoryResponse{}, "history"
+	sales := parcelRoute(http.MethodGet, "/:id/sales", "sales", "Recorded sales of a parcel, newest first",
+		h.Sales.List, routes.RateStandard)
+	sales.Response, sales.Rel = handlers.ParcelSalesResponse{}, "sales"
+	assessments := parcelRoute(http.MethodGet, "/:id/assessments", "assessments", "Certified values of a parcel by tax year, with year-over-year changes",
+		h.Assessments.List, routes.RateStandard)
+	assessments.Response, assessments.Rel = handlers.ParcelAssessmentsResponse{}, "assessments"
+	comparables := parcelRoute(http.MethodGet, "/:id/comparables", "comparables", "Nearby parcels most similar in acreage, land use and year built",
+		h.Comparables.List, routes.RateStandard)
+	comparables.Query, comparables.Response, comparables.Rel = handlers.ComparablesRequest{}, handlers.ComparablesResponse{}, "comparables"
+	get := parcelRoute(http.MethodGet, "/:id", "get", "Parcel by ID",
+		h.Parcels.Get, routes.RateStandard)
+	get.Query, get.Response, get.Rel = handlers.GetRequest{}, handlers.ParcelResponse{}, "self"
+	intersects := parcelRoute(http.MethodPost, "/intersects", "intersects", "Parcels intersecting a GeoJSON Polygon or MultiPolygon",
+		h.Parcels.Intersects, routes.RateHeavy)
+	intersects.Query, intersects.Body, intersects.Response = handlers.IntersectsRequest{}, models.MultiPolygon{}, handlers.IntersectsResponse{}
+	searchAddress := parcelRoute(http.MethodGet, "/search-address", "search_address", "Fuzzy situs address search",
+		h.Parcels.SearchAddress, routes.RateStandard)
+	searchAddress.Query, searchAddress.Response = handlers.SearchAddressRequest{}, handlers.SearchAddressResponse{}
+
+	registry.Add(alongRoute, assessments, atPoint, comparables, compare, documents, get, history, identify, intersects, nearby, sales, searchAddress)
+
+	registry.Add(
+		routes.Route{Method: http.MethodPost, Path: "/api/v1/geo/dissolve", Name: "geo.dissolve", Tag: "geo",
+			Summary: "Outer boundary of a set of parcels merged into one shape", Handler: h.Parcels.Dissolve,
+			Body: handlers.DissolveRequest{}, Response: handlers.DissolveResponse{}, Middleware: h.queryAuditMiddleware,
+			Scope: routes.ScopePublic, RateClass: routes.RateHeavy},
+		routes.Route{Method: http.MethodPost, Path: "/api/v1/analysis/assemblages", Name: "analysis.assemblages", Tag: "analysis",
+			Summary: "Contiguous parcel groups in an area meeting a target acreage, for development sites", Handler: h.Assemblages.Find,
+			Query: handlers.AssemblageRequest{}, Body: models.MultiPolygon{}, Response: handlers.AssemblagesResponse{},
+			Middleware: h.queryAuditMiddleware, Scope: routes.ScopePublic, RateClass: routes.RateHeavy},
+		routes.Route{Method: http.MethodGet, Path: "/api/v1/graphql", Name: "graphql.get", Tag: "graphql",
+			Summary: "Parcel queries with nested field selection, as URL parameters", Handler: h.GraphQL.Execute,
+			Query: handlers.GraphQLQueryRequest{}, Response: graphql.Response{}, Middleware: h.queryAuditMiddleware,
+			Scope: routes.ScopePublic, RateClass: routes.RateHeavy},
+		routes.Route{Method: http.MethodPost, Path: "/api/v1/graphql", Name: "graphql.post", Tag: "graphql",
+			Summary: "Parcel queries with nested field selection", Handler: h.GraphQL.Execute,
+			Body: handlers.GraphQLRequest{}, Response: graphql.Response{}, Middleware: h.queryAuditMiddleware,
+			Scope: routes.ScopePublic, RateClass: routes.RateHeavy},
+		routes.Route{Method: http.MethodGet, Path: "/api/v1/graphql/schema", Name: "graphql.schema", Tag: "graphql",
+			Summary: "GraphQL schema definition for client code generation", Handler: h.GraphQL.Schema,
+			Scope: routes.ScopeOpen, RateClass: routes.RateExempt},
+		routes.Route{Method: http.MethodGet, Path: "/api/v1/zoning/at-point", Name: "zoning.at_point", Tag: "zoning",
+			Summary: "Zoning districts containing a point", Handler: h.Overlays.ZoningAtPoint,
+			Query: handlers.ZoningAtPointRequest{}, Response: handlers.ZoningAtPointResponse{}, Middleware: h.queryAuditMiddleware,
+			Scope: routes.ScopePublic, RateClass: routes.RateStandard},
+		routes.Route{Method: http.MethodGet, Path: "/api/v1/flood-zones/at-point", Name: "flood_zones.at_point", Tag: "flood-zones",
+			Summary: "FEMA flood zones containing a point", Handler: h.Overlays.FloodZonesAtPoint,
+			Query: handlers.FloodZonesAtPointRequest{}, Response: handlers.FloodZonesAtPointResponse{}, Middleware: h.queryAuditMiddleware,
+			Scope: routes.ScopePublic, RateClass: routes.RateStandard},
+		routes.Route{Method: http.MethodGet, Path: "/api/v1/jurisdictions/at-point", Name: "jurisdictions.at_point", Tag: "jurisdictions",
+			Summary: "School districts and taxing units containing a point", Handler: h.Overlays.JurisdictionsAtPoint,
+			Query: handlers.JurisdictionsAtPointRequest{}, Response: handlers.JurisdictionsAtPointResponse{}, Middleware: h.queryAuditMiddleware,
+			Scope: routes.ScopePublic, RateClass: routes.RateStandard},
+		routes.Route{Method: http.MethodGet, Path: "/api/v1/layers", Name: "layers.list", Tag: "layers",
+			Summary: "List the registered spatial layers", Handler: h.Layers.List, Response: handlers.LayerListResponse{},
+			Scope: routes.ScopePublic, RateClass: routes.RateStandard},
+		routes.Route{Method: http.MethodGet, Path: "/api/v1/layers/:name/at-point", Name: "layers.at_point", Tag: "layers",
+			Summary: "Features of a layer at or near a point", Handler: h.Layers.AtPoint,
+			Query: handlers.LayerAtPointRequest{}, Response: handlers.LayerFeaturesResponse{}, Middleware: h.queryAuditMiddleware,
+			Scope: routes.ScopePublic, RateClass: routes.RateStandard},
+		routes.Route{Method: http.MethodGet, Path: "/api/v1/layers/:name/in-bbox", Name: "layers.in_bbox", Tag: "layers",
+			Summary: "Features of a layer in a bounding box", Handler: h.Layers.InBBox,
+			Query: handlers.LayerInBBoxRequest{}, Response: handlers.LayerFeaturesResponse{}, Middleware: h.queryAuditMiddleware,
+			Scope: routes.ScopePublic, RateClass: routes.RateStandard},
+		routes.Route{Method: http.MethodGet, Path: "/api/v1/owners/:id/parcels", Name: "owners.parcels", Tag: "owners",
+			Summary: "Parcels held by an owner under any spelling of its name", Handler: h.Owners.Parcels,
+			Query: handlers.OwnerParcelsRequest{}, Response: handlers.OwnerParcelsResponse{}, Middleware: h.queryAuditMiddleware,
+			Scope: routes.ScopePublic, RateClass: routes.RateStandard},
+		routes.Route{Method: http.MethodGet, Path: "/api/v1/counties", Name: "counties.list", Tag: "counties",
+			Summary: "List the counties with loaded parcels", Handler: h.Counties.List, Response: handlers.CountyListResponse{},
+			Scope: routes.ScopePublic, RateClass: routes.RateStandard},
+		routes.Route{Method: http.MethodGet, Path: "/api/v1/styles", Name: "styles.list", Tag: "styles",
+			Summary: "List the tenant's map styles", Handler: h.Styles.List, Response: handlers.StyleListResponse{},
+			Scope: routes.ScopeTenant, RateClass: routes.RateStandard},
+		routes.Route{Method: http.MethodGet, Path: "/api/v1/styles/:name", Name: "styles.get", Tag: "styles",
+			Summary: "Get a map style", Handler: h.Styles.Get, Response: handlers.StyleResponse{},
+			Scope: routes.ScopeTenant, RateClass: routes.RateStandard},
+		routes.Route{Method: http.MethodPut, Path: "/api/v1/styles/:name", Name: "styles.put", Tag: "styles",
+			Summary: "Create or replace a map style", Handler: h.Styles.Put,
+			Body: handlers.SaveStyleRequest{}, Response: handlers.StyleResponse{},
+			Scope: routes.ScopeTenant, RateClass: routes.RateStandard},
+		routes.Route{Method: http.MethodDelete, Path: "/api/v1/styles/:name", Name: "styles.delete", Tag: "styles",
+			Summary: "Delete a map style", Handler: h.Styles.Delete,
+			Scope: routes.ScopeTenant, RateClass: routes.RateStandard, Status: http.StatusNoContent},
+		routes.Route{Method: http.MethodPost, Path: "/api/v1/share", Name: "share.create", Tag: "share",
+			Summary: "Save a parcel or area map view under a short link token", Handler: h.Shares.Create,
+			Body: handlers.CreateShareLinkRequest{}, Response: handlers.ShareLinkResponse{}, Status: http.StatusCreated,
+			Scope: routes.ScopePublic, RateClass: routes.RateStandard},
+		routes.Route{Method: http.MethodGet, Path: "/api/v1/share/:token", Name: "share.resolve", Tag: "share",
+			Summary: "Resolve a short link to its map view, counting a click", Handler: h.Shares.Resolve, Response: handlers.ShareLinkResponse{},
+			Scope: routes.ScopePublic, RateClass: routes.RateStandard, CachePolicy: handlers.ShareLinkCacheControl},
+		routes.Route{Method: http.MethodGet, Path: "/api/v1/api-keys", Name: "api_keys.list", Tag: "api-keys",
+			Summary: "List the tenant's API keys", Handler: h.APIKeys.List, Response: handlers.APIKeyListResponse{},
+			Scope: routes.ScopeTenant, RateClass: routes.RateStandard},
+		routes.Route{Method: http.MethodPost, Path: "/api/v1/api-keys", Name: "api_keys.create", Tag: "api-keys",
+			Summary: "Create an API key; the key is only returned once", Handler: h.APIKeys.Create,
+			Body: handlers.CreateAPIKeyRequest{}, Response: handlers.CreateAPIKeyResponse{}, Status: http.StatusCreated,
+			Scope: routes.ScopeTenant, RateClass: routes.RateStandard},
+		routes.Route{Method: http.MethodDelete, Path: "/api/v1/api-keys/:id", Name: "api_keys.revoke", Tag: "api-keys",
+			Summary: "Revoke an API key", Handler: h.APIKeys.Revoke,
+			Scope: routes.ScopeTenant, RateClass: routes.RateStandard, Status: http.StatusNoContent},
+		routes.Route{Method: http.MethodGet, Path: "/api/v1/stats/snapshots", Name: "stats.snapshots", Tag: "stats",
+			Summary: "Daily dataset statistics snapshots", Handler: h.Stats.Snapshots,
+			Query: handlers.SnapshotsRequest{}, Response: handlers.SnapshotsResponse{},
+			Scope: routes.ScopePublic, RateClass: routes.RateStandard},
+	)
+
+	if h.Geocode != nil {
+		registry.Add(
+			routes.Route{Method: http.MethodGet, Path: "/api/v1/geocode", Name: "geocode", Tag: "geocode",
+				Summary: "Addresses matching a free-form query, optionally with the parcel at the best match", Handler: h.Geocode.Geocode,
+				Query: handlers.GeocodeRequest{}, Response: handlers.GeocodeResponse{},
+				Scope: routes.ScopePublic, RateClass: routes.RateStandard},
+		)
+	}
+
+	if h.Widgets != nil {
+		registry.Add(
+			routes.Route{Method: http.MethodGet, Path: "/api/v1/widgets", Name: "widgets.list", Tag: "widgets",
+				Summary: "List the tenant's embeddable widgets", Handler: h.Widgets.List, Response: handlers.WidgetListResponse{},
+				Scope: routes.ScopeTenant, RateClass: routes.RateStandard},
+			routes.Route{Method: http.MethodGet, Path: "/api/v1/widgets/:name", Name: "widgets.get", Tag: "widgets",
+				Summary: "Get an embeddable widget", Handler: h.Widgets.Get, Response: handlers.WidgetResponse{},
+				Scope: routes.ScopeTenant, RateClass: routes.RateStandard},
+			routes.Route{Method: http.MethodPut, Path: "/api/v1/widgets/:name", Name: "widgets.put", Tag: "widgets",
+				Summary: "Create or replace an embeddable widget", Handler: h.Widgets.Put,
+				Body: handlers.SaveWidgetRequest{}, Response: handlers.WidgetResponse{},
+				Scope: routes.ScopeTenant, RateClass: routes.RateStandard},
+			routes.Route{Method: http.MethodPost, Path: "/api/v1/widgets/:name/token", Name: "widgets.token", Tag: "widgets",
+				Summary: "Issue a signed embed token for a widget", Handler: h.Widgets.IssueToken,
+				Response: handlers.EmbedTokenResponse{}, Status: http.StatusCreated,
+				Scope: routes.ScopeTenant, RateClass: routes.RateStandard},
+			routes.Route{Method: http.MethodGet, Path: embedPathPrefix + "/config", Name: "embed.config", Tag: "embed",
+				Summary: "Widget configuration for the embedded viewer", Handler: h.Widgets.EmbedConfig,
+				Query: handlers.EmbedTokenRequest{}, Response: handlers.EmbedConfigResponse{},
+				Scope: routes.ScopePartner, RateClass: routes.RateStandard},
+			routes.Route{Method: http.MethodGet, Path: embedPathPrefix + "/parcels/at-point", Name: "embed.at_point", Tag: "embed",
+				Summary: "Parcel at a point inside the widget's area, limited to its visible fields", Handler: h.Widgets.EmbedAtPoint,
+				Query: handlers.EmbedAtPointRequest{}, Response: handlers.EmbedParcelResponse{},
+				Scope: routes.ScopePartner, RateClass: routes.RateStandard},
+		)
+	}
+
+	if h.Users != nil {
+		registry.Add(
+			routes.Route{Method: http.MethodPost, Path: "/api/v1/auth/register", Name: "auth.register", Tag: "users",
+				Summary: "Create a user account and sign in", Handler: h.Users.Register,
+				Body: handlers.CredentialsRequest{}, Response: handlers.SessionResponse{}, Status: http.StatusCreated,
+				Scope: routes.ScopeOpen, RateClass: routes.RateStandard},
+			routes.Route{Method: http.MethodPost, Path: "/api/v1/auth/login", Name: "auth.login", Tag: "users",
+				Summary: "Sign in with email and password, returning a JWT", Handler: h.Users.Login,
+				Body: handlers.CredentialsRequest{}, Response: handlers.SessionResponse{},
+				Scope: routes.ScopeOpen, RateClass: routes.RateStandard},
+			routes.Route{Method: http.MethodGet, Path: "/api/v1/users/me", Name: "users.me", Tag: "users",
+				Summary: "The signed-in user's account", Handler: h.Users.Me, Response: models.User{},
+				Scope: routes.ScopeUser, RateClass: routes.RateStandard},
+			routes.Route{Method: http.MethodGet, Path: "/api/v1/users/me/searches", Name: "searches.list", Tag: "users",
+				Summary: "The signed-in user's saved searches with their match counts", Handler: h.SavedSearches.List,
+				Response: handlers.SavedSearchListResponse{}, Scope: routes.ScopeUser, RateClass: routes.RateStandard},
+			routes.Route{Method: http.MethodPost, Path: "/api/v1/users/me/searches", Name: "searches.create", Tag: "users",
+				Summary: "Save an area and parcel filters to be alerted of matching parcels changed by ingestion",
+				Handler: h.SavedSearches.Create, Body: handlers.CreateSavedSearchRequest{}, Response: handlers.SavedSearchResponse{},
+				Status: http.StatusCreated, Scope: routes.ScopeUser, RateClass: routes.RateStandard},
+			routes.Route{Method: http.MethodDelete, Path: "/api/v1/users/me/searches/:id", Name: "searches.delete", Tag: "users",
+				Summary: "Delete a saved search and its matches", Handler: h.SavedSearches.Delete,
+				Scope: routes.ScopeUser, RateClass: routes.RateStandard, Status: http.StatusNoContent},
+			routes.Route{Method: http.MethodGet, Path: "/api/v1/users/me/searches/:id/matches", Name: "searches.matches", Tag: "users",
+				Summary: "Parcels added, changed or deleted by ingestion runs that matched a saved search", Handler: h.SavedSearches.Matches,
+				Query: handlers.SavedSearchMatchesRequest{}, Response: handlers.SavedSearchMatchesResponse{},
+				Scope: routes.ScopeUser, RateClass: routes.RateStandard},
+			routes.Route{Method: http.MethodGet, Path: "/api/v1/users/me/favorites", Name: "favorites.list", Tag: "users",
+				Summary: "The signed-in user's bookmarked parcels", Handler: h.Favorites.List,
+				Response: handlers.FavoriteListResponse{}, Scope: routes.ScopeUser, RateClass: routes.RateStandard},
+			routes.Route{Method: http.MethodPost, Path: "/api/v1/users/me/favorites/:parcelId", Name: "favorites.save", Tag: "users",
+				Summary: "Bookmark a parcel, or update the note of a bookmarked parcel", Handler: h.Favorites.Save,
+				Body: handlers.SaveFavoriteRequest{}, Response: handlers.FavoriteResponse{},
+				Status: http.StatusCreated, Scope: routes.ScopeUser, RateClass: routes.RateStandard},
+			routes.Route{Method: http.MethodDelete, Path: "/api/v1/users/me/favorites/:parcelId", Name: "favorites.delete", Tag: "users",
+				Summary: "Remove a parcel bookmark", Handler: h.Favorites.Delete,
+				Scope: routes.ScopeUser, RateClass: routes.RateStandard, Status: http.StatusNoContent},
+			routes.Route{Method: http.MethodGet, Path: "/api/v1/annotations", Name: "annotations.list", Tag: "annotations",
+				Summary: "The signed-in user's annotations and shared ones, optionally on one parcel", Handler: h.Annotations.List,
+				Query: handlers.ListAnnotationsRequest{}, Response: handlers.AnnotationListResponse{},
+				Scope: routes.ScopeUser, RateClass: routes.RateStandard},
+			routes.Route{Method: http.MethodPost, Path: "/api/v1/annotations", Name: "annotations.create", Tag: "annotations",
+				Summary: "Attach a note, with optional point or area markup, to a parcel", Handler: h.Annotations.Create,
+				Body: handlers.AnnotationRequest{}, Response: handlers.AnnotationResponse{},
+				Status: http.StatusCreated, Scope: routes.ScopeUser, RateClass: routes.RateStandard},
+			routes.Route{Method: http.MethodGet, Path: "/api/v1/annotations/:id", Name: "annotations.get", Tag: "annotations",
+				Summary: "An annotation the signed-in user may read", Handler: h.Annotations.Get,
+				Response: handlers.AnnotationResponse{}, Scope: routes.ScopeUser, RateClass: routes.RateStandard},
+			routes.Route{Method: http.MethodPut, Path: "/api/v1/annotations/:id", Name: "annotations.update", Tag: "annotations",
+				Summary: "Replace the note, markup and visibility of the signed-in user's annotation", Handler: h.Annotations.Update,
+				Body: handlers.AnnotationRequest{}, Response: handlers.AnnotationResponse{},
+				Scope: routes.ScopeUser, RateClass: routes.RateStandard},
+			routes.Route{Method: http.MethodDelete, Path: "/api/v1/annotations/:id", Name: "annotations.delete", Tag: "annotations",
+				Summary: "Delete the signed-in user's annotation", Handler: h.Annotations.Delete,
+				Scope: routes.ScopeUser, RateClass: routes.RateStandard, Status: http.StatusNoContent},
+		)
+	}
+
+	if h.Webhooks != nil {
+		registry.Add(
+			routes.Route{Method: http.MethodGet, Path: "/api/v1/webhooks", Name: "webhooks.list", Tag: "webhooks",
+				Summary: "List the tenant's webhooks", Handler: h.Webhooks.List, Response: handlers.WebhookListResponse{},
+				Scope: routes.ScopeTenant, RateClass: routes.RateStandard},
+			routes.Route{Method: http.MethodPost, Path: "/api/v1/webhooks", Name: "webhooks.create", Tag: "webhooks",
+				Summary: "Register a URL to be sent signed notifications of parcels changed by ingestion", Handler: h.Webhooks.Create,
+				Body: handlers.CreateWebhookRequest{}, Response: handlers.CreateWebhookResponse{},
+				Status: http.StatusCreated, Scope: routes.ScopeTenant, RateClass: routes.RateStandard},
+			routes.Route{Method: http.MethodDelete, Path: "/api/v1/webhooks/:id", Name: "webhooks.delete", Tag: "webhooks",
+				Summary: "Delete a webhook and its deliveries", Handler: h.Webhooks.Delete,
+				Scope: routes.ScopeTenant, RateClass: routes.RateStandard, Status: http.StatusNoContent},
+			routes.Route{Method: http.MethodGet, Path: "/api/v1/webhooks/:id/deliveries", Name: "webhooks.deliveries", Tag: "webhooks",
+				Summary: "A webhook's deliveries with the outcome of their last attempt", Handler: h.Webhooks.Deliveries,
+				Query: handlers.WebhookDeliveriesRequest{}, Response: handlers.WebhookDeliveriesResponse{},
+				Scope: routes.ScopeTenant, RateClass: routes.RateStandard},
+		)
+	}
+
+	registry.Add(routes.Route{Method: http.MethodGet, Path: "/api/v1/admin/security", Name: "admin.security", Tag: "admin",
+		Summary: "Failed authentications, lockouts and the most suspicious clients of the last 24 hours", Handler: h.Security.Summary,
+		Response: models.SecuritySummary{}, Scope: routes.ScopeAdmin, RateClass: routes.RateStandard})
+
+	registry.Add(routes.Route{Method: http.MethodGet, Path: "/api/v1/admin/retention", Name: "admin.retention", Tag: "admin",
+		Summary: "Dry run of the retention job: rows of each table it would delete", Handler: h.Retention.Preview,
+		Response: models.RetentionReport{}, Scope: routes.ScopeAdmin, RateClass: routes.RateHeavy})
+	registry.Add(routes.Route{Method: http.MethodGet, Path: "/api/v1/admin/schema", Name: "admin.schema", Tag: "admin",
+		Summary: "Schema drift: pending migrations, missing indexes and columns, changed column types", Handler: h.Schema.Plan,
+		Response: models.SchemaReport{}, Scope: routes.ScopeAdmin, RateClass: routes.RateStandard})
+	registry.Add(routes.Route{Method: http.MethodGet, Path: "/api/v1/admin/stats", Name: "admin.stats", Tag: "admin",
+		Summary: "Connection pool, Go runtime and response cache statistics of this instance", Handler: h.Runtime.Stats,
+		Response: handlers.RuntimeStatsResponse{}, Scope: routes.ScopeAdmin, RateClass: routes.RateStandard})
+	registry.Add(routes.Route{Method: http.MethodGet, Path: "/api/v1/admin/parcels/sample", Name: "admin.parcels.sample", Tag: "admin",
+		Summary: "Random sample of parcels, stratified by county and tax year, with data quality flags", Handler: h.Parcels.Sample,
+		Query: handlers.SampleRequest{}, Response: handlers.SampleResponse{}, Middleware: h.queryAuditMiddleware,
+		Scope: routes.ScopeAdmin, RateClass: routes.RateHeavy})
+	registry.Add(
+		routes.Route{Method: http.MethodGet, Path: "/api/v1/admin/county-profiles", Name: "admin.county_profiles.list", Tag: "admin",
+			Summary: "List the per-county limits, defaults and source settings", Handler: h.Counties.ListProfiles,
+			Response: handlers.CountyProfileListResponse{}, Scope: routes.ScopeAdmin, RateClass: routes.RateStandard},
+		routes.Route{Method: http.MethodGet, Path: "/api/v1/admin/county-profiles/:county", Name: "admin.county_profiles.get", Tag: "admin",
+			Summary: "Get a county's profile", Handler: h.Counties.GetProfile,
+			Response: handlers.CountyProfileResponse{}, Scope: routes.ScopeAdmin, RateClass: routes.RateStandard},
+		routes.Route{Method: http.MethodPut, Path: "/api/v1/admin/county-profiles/:county", Name: "admin.county_profiles.put", Tag: "admin",
+			Summary: "Replace a county's profile", Handler: h.Counties.PutProfile,
+			Body: handlers.SaveCountyProfileRequest{}, Response: handlers.CountyProfileResponse{},
+			Scope: routes.ScopeAdmin, RateClass: routes.RateStandard},
+	)
+
+	if h.Queries != nil {
+		registry.Add(
+			routes.Route{Method: http.MethodGet, Path: "/api/v1/queries", Name: "queries.list", Tag: "queries",
+				Summary: "List the analyst SQL templates and their parameters", Handler: h.Queries.List, Response: handlers.QueryTemplatesResponse{},
+				Scope: routes.ScopeAdmin, RateClass: routes.RateStandard},
+			routes.Route{Method: http.MethodPost, Path: "/api/v1/queries/:name", Name: "queries.run", Tag: "queries",
+				Summary: "Run an analyst SQL template read-only on the replica", Handler: h.Queries.Run,
+				Body: handlers.RunQueryRequest{}, Response: handlers.QueryResultResponse{},
+				Scope: routes.ScopeAdmin, RateClass: routes.RateHeavy, CachePolicy: handlers.QueryCacheControl},
+		)
+	}
+
+	if h.Bundles != nil {
+		registry.Add(
+			routes.Route{Method: http.MethodPost, Path: "/api/v1/bundles", Name: "bundles.create", Tag: "bundles",
+				Summary: "Queue an offline sync bundle for an area", Handler: h.Bundles.Create,
+				Body: models.MultiPolygon{}, Response: handlers.BundleResponse{}, Status: http.StatusAccepted,
+				Scope: routes.ScopePublic, RateClass: routes.RateHeavy},
+			routes.Route{Method: http.MethodGet, Path: "/api/v1/bundles/:id", Name: "bundles.get", Tag: "bundles",
+				Summary: "Sync bundle job status", Handler: h.Bundles.Get, Response: handlers.BundleResponse{},
+				Scope: routes.ScopePublic, RateClass: routes.RateStandard},
+			routes.Route{Method: http.MethodGet, Path: "/api/v1/bundles/:id/download", Name: "bundles.download", Tag: "bundles",
+				Summary: "Download a finished sync bundle as GeoPackage", Handler: h.Bundles.Download,
+				Scope: routes.ScopePublic, RateClass: routes.RateHeavy},
+		)
+	}
+
+	if h.Results != nil {
+		registry.Add(
+			routes.Route{Method: http.MethodGet, Path: "/api/v1/results/:id", Name: "results.get", Tag: "results",
+				Summary: "Status of a query run with async=true", Handler: h.Results.Get, Response: handlers.ResultResponse{},
+				Scope: routes.ScopePublic, RateClass: routes.RateStandard},
+			routes.Route{Method: http.MethodGet, Path: "/api/v1/results/:id/data", Name: "results.data", Tag: "results",
+				Summary: "Response of a finished async query", Handler: h.Results.Data,
+				Scope: routes.ScopePublic, RateClass: routes.RateHeavy},
+		)
+	}
+
+	if h.Exports != nil {
+		registry.Add(
+			routes.Route{Method: http.MethodPost, Path: "/api/v1/exports", Name: "exports.create", Tag: "exports",
+				Summary: "Queue a Shapefile or GeoPackage export of the parcels in an area", Handler: h.Exports.Create,
+				Query: handlers.ExportRequest{}, Body: models.MultiPolygon{}, Response: handlers.ExportResponse{}, Status: http.StatusAccepted,
+				Scope: routes.ScopePublic, RateClass: routes.RateHeavy},
+			routes.Route{Method: http.MethodGet, Path: "/api/v1/exports/:id", Name: "exports.get", Tag: "exports",
+				Summary: "Export job status with a signed download URL once finished", Handler: h.Exports.Get, Response: handlers.ExportResponse{},
+				Scope: routes.ScopePublic, RateClass: routes.RateStandard},
+			// The signature in the URL authorizes the download, so GIS tools need no API key
+			routes.Route{Method: http.MethodGet, Path: "/api/v1/exports/:id/download", Name: "exports.download", Tag: "exports",
+				Summary: "Download a finished export through its signed URL", Handler: h.Exports.Download,
+				Query: handlers.DownloadRequest{}, Scope: routes.ScopeOpen, RateClass: routes.RateHeavy},
+		)
+	}
+
+	if h.BulkQueries != nil {
+		registry.Add(
+			routes.Route{Method: http.MethodPost, Path: "/api/v1/jobs/bulk-query", Name: "jobs.bulk_query", Tag: "jobs",
+				Summary: "Queue a bulk query resolving many points or a large area to parcels", Handler: h.BulkQueries.Create,
+				Body: handlers.BulkQueryRequest{}, Response: handlers.BulkQueryResponse{}, Status: http.StatusAccepted,
+				Scope: routes.ScopePublic, RateClass: routes.RateHeavy},
+			routes.Route{Method: http.MethodGet, Path: "/api/v1/jobs/:id", Name: "jobs.get", Tag: "jobs",
+				Summary: "Job status with a signed download URL once finished", Handler: h.BulkQueries.Get, Response: handlers.BulkQueryResponse{},
+				Scope: routes.ScopePublic, RateClass: routes.RateStandard},
+			// Like exports, the signature in the URL authorizes the download
+			routes.Route{Method: http.MethodGet, Path: "/api/v1/jobs/:id/download", Name: "jobs.download", Tag: "jobs",
+				Summary: "Download the JSON result of a finished job through its signed URL", Handler: h.BulkQueries.Download,
+				Query: handlers.DownloadRequest{}, Scope: routes.ScopeOpen, RateClass: routes.RateHeavy},
+		)
+	}
+}
+
+// concatMiddleware joins middleware lists into a new slice, leaving the lists unchanged.
+func concatMiddleware(lists ...[]gin.HandlerFunc) []gin.HandlerFunc {
+	var joined []gin.HandlerFunc
+	for _, list := range lists {
+		joined = append(joined, list...)
+	}
+	return joined
+}
@@ -0,0 +1,138 @@
+// Command worker runs the scheduled background jobs (daily stats snapshots and
+// cache warming after dataset switches) apart from the API, so they can be
+// scaled and deployed independently of the latency-sensitive API instances.
+// Set WORKER_ENABLED=true on the API so only the worker runs them.
+//
+// The worker serves only health checks, including leader status, and
+// Prometheus metrics on WORKER_PORT. Sync bundle jobs and spatial audit samples are queued in the
+// memory of the API instance that received the request, so they stay with the API.
+//
+// Configuration comes from the same environment variables, .env file and
+// config file (-config) as the API server.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stwalsh4118/atlas/api/internal/app"
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/routes"
+)
+
+const shutdownTimeout = 30 * time.Second
+
+func main() {
+	configFile := flag.String("config", "", "YAML or TOML config file (default: config.yaml, config.yml or config.toml if present)")
+	flag.Parse()
+
+	cfg, err := config.LoadFile(*configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	log := logger.New(cfg.Server.Env)
+	_ = log.SetLevel(cfg.Server.Level()) // validated by config.LoadFile
+	log.Info("Starting Atlas worker", map[string]interface{}{
+		"environment": cfg.Server.Env,
+		"port":        cfg.Worker.Port,
+	})
+	if !cfg.Worker.Enabled {
+		log.Warn("WORKER_ENABLED is false; API instances also run the scheduled jobs", nil)
+	}
+
+	ctx := context.Background()
+	a, err := app.New(ctx, cfg, log)
+	if err != nil {
+		log.Fatal("Failed to initialize application", err, nil)
+	}
+
+	if cfg.Server.Env == "production" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+	router := gin.New()
+	router.Use(middleware.RequestID())
+	if a.Services.HTTPMetrics != nil {
+		router.Use(middleware.Metrics(a.Services.HTTPMetrics))
+	}
+	router.Use(middleware.Logger(log))
+	router.Use(middleware.Recovery(log))
+
+	registry := routes.NewRegistry()
+	registry.Add(
+		routes.Route{Method: http.MethodGet, Path: "/health", Name: "health", Tag: "health",
+			Summary: "Liveness check", Handler: a.Handlers.Health.Health,
+			Scope: routes.ScopeOpen, RateClass: routes.RateExempt},
+		routes.Route{Method: http.MethodGet, Path: "/health/ready", Name: "health.ready", Tag: "health",
+			Summary: "Readiness with the status, latency and error of each dependency", Handler: a.Handlers.Health.Ready,
+			Scope: routes.ScopeOpen, RateClass: routes.RateExempt},
+		routes.Route{Method: http.MethodGet, Path: "/health/leaders", Name: "health.leaders", Tag: "health",
+			Summary: "Scheduled job roles and the instance leading each", Handler: a.Handlers.Leaders.Leaders,
+			Scope: routes.ScopeOpen, RateClass: routes.RateExempt},
+	)
+	if a.Handlers.Metrics != nil {
+		registry.Add(routes.Route{Method: http.MethodGet, Path: "/metrics", Name: "metrics", Tag: "health",
+			Summary: "Prometheus metrics of this instance", Handler: a.Handlers.Metrics.Metrics,
+			Scope: routes.ScopeOpen, RateClass: routes.RateExempt})
+	}
+	if err := registry.Mount(router); err != nil {
+		log.Fatal("Invalid route declarations", err, nil)
+	}
+
+	// Start the scheduled jobs; stopped during graceful shutdown
+	a.Append(a.ScheduledJobs())
+	// SIGHUP reloads the log level and provider rate limits and cache TTLs
+	a.Append(a.ReloadOnSignal(*configFile))
+	if err := a.Start(ctx); err != nil {
+		log.Fatal("Failed to start application", err, nil)
+	}
+
+	srv := &http.Server{
+		Addr:    fmt.Sprintf(":%s", cfg.Worker.Port),
+		Handler: router,
+	}
+
+	go func() {
+		log.Info("Worker health checks listening", map[string]interface{}{
+			"port": cfg.Worker.Port,
+			"addr": srv.Addr,
+		})
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal("Health server failed to start", err, nil)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Info("Shutting down worker...", nil)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Error("Health server forced to shutdown", err, map[string]interface{}{
+			"timeout": shutdownTimeout.String(),
+		})
+	}
+
+	// Stop the jobs, then close the database
+	if err := a.Stop(shutdownCtx); err != nil {
+		log.Error("Application forced to stop", err, map[string]interface{}{
+			"timeout": shutdownTimeout.String(),
+		})
+	}
+
+	log.Info("Worker exited", nil)
+}
@@ -0,0 +1,707 @@
+// Package app builds the Atlas components (config, logger, database,
+// repositories, services and handlers) in one place, so the API server, the
+// ingest CLI, the worker and tests share identical wiring. Entrypoints append
+// the lifecycle hooks of the background jobs they run (RequestJobs,
+// ScheduledJobs); Start and Stop run the hooks in order.
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stwalsh4118/atlas/api/internal/auth"
+	"github.com/stwalsh4118/atlas/api/internal/cache"
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/embed"
+	"github.com/stwalsh4118/atlas/api/internal/encryption"
+	"github.com/stwalsh4118/atlas/api/internal/handlers"
+	"github.com/stwalsh4118/atlas/api/internal/jobs"
+	"github.com/stwalsh4118/atlas/api/internal/leader"
+	"github.com/stwalsh4118/atlas/api/internal/location"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/metrics"
+	"github.com/stwalsh4118/atlas/api/internal/outbound"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+	"github.com/stwalsh4118/atlas/api/internal/siem"
+	"github.com/stwalsh4118/atlas/api/internal/tracing"
+	"github.com/stwalsh4118/atlas/api/migrations"
+)
+
+// App holds the wired components. Optional features that are disabled by
+// configuration leave their components nil.
+type App struct {
+	Config *config.Config
+	Log    *logger.Logger
+	DB     *database.Database
+	// Replica runs analyst queries; nil uses DB
+	Replica *database.Database
+	// Keyring encrypts owner contact columns; nil when encryption is disabled
+	Keyring      *encryption.Keyring
+	Repositories Repositories
+	Services     Services
+	Handlers     Handlers
+	hooks        []Hook
+	reloads      []func(cfg *config.Config)
+}
+
+// Repositories are the data access components.
+type Repositories struct {
+	Parcels   repository.ParcelRepository
+	Counties  repository.CountyRepository
+	Documents repository.DocumentRepository
+	History   repository.HistoryRepository
+	Styles    repository.StyleRepository
+	Shares    repository.ShareLinkRepository
+	APIKeys   repository.APIKeyRepository
+	Stats     repository.StatsRepository
+	Warming   repository.WarmingRepository
+	Security  repository.SecurityRepository
+	Retention repository.RetentionRepository
+	Schema    repository.SchemaRepository
+	// Layers also holds the zoning, flood zone and jurisdiction overlays
+	Layers      repository.LayerRepository
+	Sales       repository.SalesRepository
+	Assessments repository.AssessmentRepository
+	Owners      repository.OwnerRepository
+	// QueryAudit stores the query audit log
+	QueryAudit repository.QueryAuditRepository
+	// Encryption stores the data keys of Keyring
+	Encryption repository.EncryptionRepository
+	// Widgets is nil when embedding is disabled
+	Widgets repository.WidgetRepository
+	// Users is nil when user accounts are disabled
+	Users repository.UserRepository
+	// SavedSearches is nil when user accounts are disabled
+	SavedSearches repository.SavedSearchRepository
+	// Favorites is nil when user accounts are disabled
+	Favorites repository.FavoriteRepository
+	// Annotations is nil when user accounts are disabled
+	Annotations repository.AnnotationRepository
+	// Webhooks is nil when webhooks are disabled
+	Webhooks repository.WebhookRepository
+	// Queries is nil when analyst queries are disabled
+	Queries repository.QueryRepository
+	// Tasks is nil when the task queue is disabled
+	Tasks repository.TaskRepository
+}
+
+// Services are the business logic components.
+type Services struct {
+	// Jobs is nil when the background job manager is disabled
+	Jobs *jobs.Manager
+	// Tasks runs durable background tasks; nil when the task queue is disabled
+	Tasks *jobs.Queue
+	// Leader elects the instance running each scheduled job
+	Leader *leader.Elector
+	// Outbound collects the clients for external providers
+	Outbound *outbound.Registry
+	// Metrics is served on /metrics; nil when metrics are disabled
+	Metrics *metrics.Registry
+	// HTTPMetrics records requests per route on Metrics; nil when metrics are disabled
+	HTTPMetrics *metrics.HTTP
+	// Tracer exports request spans over OTLP; nil when tracing is disabled
+	Tracer *tracing.Tracer
+	// SIEM ships audit and security events; nil when SIEM export is disabled
+	SIEM *siem.Exporter
+	// ResponseCache holds the responses of the RESPONSE_CACHE_ROUTES; nil when response caching is disabled
+	ResponseCache cache.Cache
+	Parcels       services.ParcelService
+	Counties      services.CountyService
+	Documents     services.DocumentService
+	History       services.HistoryService
+	Assemblages   services.AssemblageService
+	Comparables   services.ComparableService
+	Styles        services.StyleService
+	Shares        services.ShareLinkService
+	APIKeys       services.APIKeyService
+	Stats         services.StatsService
+	Warming       services.WarmingService
+	Audit         services.AuditService
+	Security      services.SecurityService
+	Retention     services.RetentionService
+	Schema        services.SchemaService
+	Locations     services.LocationService
+	Layers        services.LayerService
+	Sales         services.SalesService
+	Assessments   services.AssessmentService
+	Owners        services.OwnerService
+	// Widgets is nil when embedding is disabled
+	Widgets services.WidgetService
+	// Bundles is nil when the background job manager is disabled
+	Bundles services.BundleService
+	// Exports is nil when the background job manager is disabled
+	Exports services.ExportService
+	// BulkQueries is nil when the background job manager is disabled
+	BulkQueries services.BulkQueryService
+	// Results runs queries in async mode; nil when the background job manager is disabled
+	Results services.ResultService
+	// Users is nil when user accounts are disabled
+	Users services.UserService
+	// SavedSearches is nil when user accounts are disabled
+	SavedSearches services.SavedSearchService
+	// Favorites is nil when user accounts are disabled
+	Favorites services.FavoriteService
+	// Annotations is nil when user accounts are disabled
+	Annotations services.AnnotationService
+	// Webhooks is nil when webhooks are disabled
+	Webhooks services.WebhookService
+	// Geocoding is nil when no geocoder is configured
+	Geocoding services.GeocodingService
+	// Tokens signs and verifies user JWTs; nil when user accounts are disabled
+	Tokens *auth.Signer
+	// Queries is nil when analyst queries are disabled
+	Queries services.QueryService
+	// QueryAudit records parcel queries for usage accounting; nil when the audit log is disabled
+	QueryAudit services.QueryAuditService
+}
+
+// Handlers are the HTTP handlers.
+type Handlers struct {
+	Health      *handlers.HealthHandler
+	Leaders     *handlers.LeaderHandler
+	Providers   *handlers.ProviderHandler
+	Parcels     *handlers.ParcelHandler
+	Counties    *handlers.CountyHandler
+	Documents   *handlers.DocumentHandler
+	History     *handlers.HistoryHandler
+	Assemblages *handlers.AssemblageHandler
+	Comparables *handlers.ComparableHandler
+	GraphQL     *handlers.GraphQLHandler
+	Styles      *handlers.StyleHandler
+	Shares      *handlers.ShareLinkHandler
+	APIKeys     *handlers.APIKeyHandler
+	Stats       *handlers.StatsHandler
+	Security    *handlers.SecurityHandler
+	Retention   *handlers.RetentionHandler
+	Schema      *handlers.SchemaHandler
+	Runtime     *handlers.RuntimeHandler
+	// Overlays serves zoning, flood zone and jurisdiction lookups
+	Overlays    *handlers.OverlayHandler
+	Layers      *handlers.LayerHandler
+	Sales       *handlers.SalesHandler
+	Assessments *handlers.AssessmentHandler
+	Owners      *handlers.OwnerHandler
+	// Metrics is nil when metrics are disabled
+	Metrics *handlers.MetricsHandler
+	// Widgets is nil when embedding is disabled
+	Widgets *handlers.WidgetHandler
+	// Bundles is nil when the background job manager is disabled
+	Bundles *handlers.BundleHandler
+	// Exports is nil when the background job manager is disabled
+	Exports *handlers.ExportHandler
+	// BulkQueries is nil when the background job manager is disabled
+	BulkQueries *handlers.BulkQueryHandler
+	// Results is nil when the background job manager is disabled
+	Results *handlers.ResultHandler
+	// Users is nil when user accounts are disabled
+	Users *handlers.UserHandler
+	// SavedSearches is nil when user accounts are disabled
+	SavedSearches *handlers.SavedSearchHandler
+	// Favorites is nil when user accounts are disabled
+	Favorites *handlers.FavoriteHandler
+	// Annotations is nil when user accounts are disabled
+	Annotations *handlers.AnnotationHandler
+	// Webhooks is nil when webhooks are disabled
+	Webhooks *handlers.WebhookHandler
+	// Queries is nil when analyst queries are disabled
+	Queries *handlers.QueryHandler
+	// Geocode is nil when no geocoder is configured
+	Geocode *handlers.GeocodeHandler
+}
+
+// Load reads the configuration from the environment and builds the App with New.
+func Load(ctx context.Context) (*App, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	log := logger.New(cfg.Server.Env)
+	_ = log.SetLevel(cfg.Server.Level()) // validated by config.Load
+	return New(ctx, cfg, log)
+}
+
+// New connects to the database and wires every component on top of it.
+// The database is closed by the last Stop hook.
+func New(ctx context.Context, cfg *config.Config, log *logger.Logger) (*App, error) {
+	db, err := database.NewPostgresPool(ctx, cfg.Database)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database %s: %w", cfg.Database.Address(), err)
+	}
+
+	conn := db.Pool.Config().ConnConfig
+	log.Info("Database connection established", map[string]interface{}{
+		"host":     conn.Host,
+		"port":     conn.Port,
+		"database": conn.Database,
+		"tls":      conn.TLSConfig != nil,
+		"pool_min": cfg.Database.PoolMin,
+		"pool_max": cfg.Database.PoolMax,
+		// Statement caching, tuned with DB_QUERY_EXEC_MODE and DB_STATEMENT_CACHE_CAPACITY
+		"query_exec_mode": conn.DefaultQueryExecMode.String(),
+		"statement_cache": conn.StatementCacheCapacity,
+	})
+
+	// Read-only parcel queries run on the read replicas, failing over to the primary
+	if err := useReadReplicas(ctx, cfg.Database, db, log); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	// A typo in a derived attribute would otherwise fail every parcel query
+	if err := repository.CheckDerivedAttributes(ctx, db, cfg.Derived.Attributes); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	// Owner contact columns are encrypted when master keys are configured
+	keyring, err := openKeyring(ctx, cfg.Encryption, db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	a := &App{Config: cfg, Log: log, DB: db, Keyring: keyring}
+	a.Append(Hook{
+		Name: "database",
+		OnStop: func(context.Context) error {
+			db.Close()
+			return nil
+		},
+	})
+
+	// Analyst queries run on a read replica when one is configured
+	if cfg.Queries.File != "" && cfg.Queries.ReplicaURL != "" {
+		replica, err := database.NewReplicaPool(ctx, cfg.Queries.ReplicaURL)
+		if err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to connect to analyst replica: %w", err)
+		}
+		a.Replica = replica
+		a.Append(Hook{
+			Name: "replica",
+			OnStop: func(context.Context) error {
+				replica.Close()
+				return nil
+			},
+		})
+	}
+
+	a.wire()
+	return a, nil
+}
+
+// useReadReplicas opens a pool per DB_REPLICA_URLS replica and makes db's
+// Read use them. Replicas are connected on first use.
+func useReadReplicas(ctx context.Context, cfg config.DatabaseConfig, db *database.Database, log *logger.Logger) error {
+	dsns, err := cfg.ReplicaDSNs()
+	if err != nil {
+		return err
+	}
+
+	replicas := make([]*database.Database, 0, len(dsns))
+	for i, dsn := range dsns {
+		replica, err := database.NewReadReplicaPool(ctx, dsn, cfg)
+		if err != nil {
+			for _, opened := range replicas {
+				opened.Close()
+			}
+			return fmt.Errorf("failed to open read replica %s: %w", config.DatabaseConfig{URL: cfg.ReplicaURLs[i]}.Address(), err)
+		}
+		replicas = append(replicas, replica)
+		log.Info("Read replica configured", map[string]interface{}{
+			"address": config.DatabaseConfig{URL: cfg.ReplicaURLs[i]}.Address(),
+		})
+	}
+	db.UseReplicas(cfg.ReplicaCooldown, replicas...)
+	return nil
+}
+
+// openKeyring unwraps the data keys with the configured master keys, creating the
+// first data key on first use. Returns nil when encryption is disabled.
+func openKeyring(ctx context.Context, cfg config.EncryptionConfig, db *database.Database) (*encryption.Keyring, error) {
+	if cfg.MasterKeys == "" {
+		return nil, nil
+	}
+	keys, err := encryption.ParseMasterKeys(cfg.MasterKeys)
+	if err != nil {
+		return nil, fmt.Errorf("ENCRYPTION_MASTER_KEYS: %w", err)
+	}
+	provider, err := encryption.NewStaticKeyProvider(keys, cfg.ActiveKey)
+	if err != nil {
+		return nil, fmt.Errorf("ENCRYPTION_ACTIVE_KEY: %w", err)
+	}
+	keyring, err := encryption.Open(ctx, repository.NewEncryptionRepository(db), provider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open encryption keyring: %w", err)
+	}
+	return keyring, nil
+}
+
+// Wire builds the components on an existing database without connecting or
+// registering a hook to close it. Tests pass a nil database to check the wiring
+// of components they never query.
+func Wire(cfg *config.Config, log *logger.Logger, db *database.Database) *App {
+	a := &App{Config: cfg, Log: log, DB: db}
+	a.wire()
+	return a
+}
+
+// wire constructs the repositories, services and handlers.
+func (a *App) wire() {
+	cfg, log, db := a.Config, a.Log, a.DB
+
+	a.Repositories = Repositories{
+		Parcels:     repository.NewParcelRepository(db, a.Keyring, cfg.Database.StatementTimeout, cfg.Derived.Attributes...),
+		Counties:    repository.NewCountyRepository(db),
+		Documents:   repository.NewDocumentRepository(db),
+		History:     repository.NewHistoryRepository(db, a.Keyring),
+		Styles:      repository.NewStyleRepository(db),
+		Shares:      repository.NewShareLinkRepository(db),
+		APIKeys:     repository.NewAPIKeyRepository(db),
+		Stats:       repository.NewStatsRepository(db),
+		Warming:     repository.NewWarmingRepository(db),
+		Security:    repository.NewSecurityRepository(db),
+		Retention:   repository.NewRetentionRepository(db),
+		Schema:      repository.NewSchemaRepository(db),
+		Layers:      repository.NewLayerRepository(db),
+		Sales:       repository.NewSalesRepository(db),
+		Assessments: repository.NewAssessmentRepository(db),
+		Owners:      repository.NewOwnerRepository(db),
+		Encryption:  repository.NewEncryptionRepository(db),
+		QueryAudit:  repository.NewQueryAuditRepository(db),
+	}
+	repos := a.Repositories
+
+	providers := outbound.NewRegistry()
+
+	// what3words lookups need an API key; plus codes are always decoded locally
+	var what3wordsClient location.What3WordsClient
+	if cfg.What3Words.APIKey != "" {
+		w3wHTTP := outbound.NewClient(location.What3WordsProvider, outbound.Options{
+			Log:       log,
+			Timeout:   cfg.What3Words.Timeout,
+			RateLimit: cfg.What3Words.RateLimit,
+			CacheTTL:  cfg.What3Words.CacheTTL,
+		})
+		providers.Register(w3wHTTP)
+		what3wordsClient = location.NewWhat3WordsClient(cfg.What3Words.APIKey, cfg.What3Words.BaseURL, w3wHTTP)
+	}
+
+	// Geocoding, and reverse geocoding parcels without a situs address, needs a configured provider
+	var geocoder location.Geocoder
+	if cfg.Geocoder.Provider != "" {
+		// Parcel addresses are optional and geocode clients can retry, so a retry would only delay the response
+		geocoderHTTP := outbound.NewClient(location.GeocoderProvider, outbound.Options{
+			Log:        log,
+			Timeout:    cfg.Geocoder.Timeout,
+			MaxRetries: -1,
+			RateLimit:  cfg.Geocoder.RateLimit,
+			CacheTTL:   cfg.Geocoder.CacheTTL,
+		})
+		providers.Register(geocoderHTTP)
+		if cfg.Geocoder.Provider == location.GeocoderPelias {
+			geocoder = location.NewPeliasGeocoder(cfg.Geocoder.BaseURL, cfg.Geocoder.APIKey, geocoderHTTP)
+		} else {
+			geocoder = location.NewNominatimGeocoder(cfg.Geocoder.BaseURL, cfg.Geocoder.UserAgent, geocoderHTTP)
+		}
+	}
+
+	// Security events are shipped to the SIEM only when a sink is configured
+	var siemExporter *siem.Exporter
+	var securityEvents services.EventPublisher
+	if cfg.SIEM.Sink != "" {
+		siemExporter = a.siemExporter(providers)
+		securityEvents = siemExporter
+	}
+
+	// Parcel queries limited to a county apply the county's profile
+	counties := services.NewCountyService(repos.Counties, log)
+
+	a.Services = Services{
+		Leader:      leader.NewElector(db, cfg.Leader, log),
+		Outbound:    providers,
+		SIEM:        siemExporter,
+		Parcels:     services.NewParcelService(repos.Parcels, counties, log),
+		Counties:    counties,
+		Documents:   services.NewDocumentService(repos.Documents, log),
+		History:     services.NewHistoryService(repos.History, log),
+		Assemblages: services.NewAssemblageService(repos.Parcels, log),
+		Comparables: services.NewComparableService(repos.Parcels, cfg.Comparables, log),
+		Styles:      services.NewStyleService(repos.Styles, log),
+		Shares:      services.NewShareLinkService(repos.Shares, log),
+		APIKeys:     services.NewAPIKeyService(repos.APIKeys, cfg.Auth.KeyCacheTTL, log),
+		Stats:       services.NewStatsService(repos.Stats, log),
+		Warming:     services.NewWarmingService(repos.Warming, repos.Parcels, cfg.Warming, log),
+		Audit:       services.NewAuditService(repos.Parcels, cfg.Audit.SampleRate, log),
+		Security:    services.NewSecurityService(repos.Security, cfg.Auth, securityEvents, log),
+		Retention:   services.NewRetentionService(repos.Retention, cfg.Retention, log),
+		Schema:      services.NewSchemaService(repos.Schema, migrations.FS),
+		Layers:      services.NewLayerService(repos.Layers, log),
+		Sales:       services.NewSalesService(repos.Sales, log),
+		Assessments: services.NewAssessmentService(repos.Assessments, log),
+		Owners:      services.NewOwnerService(repos.Owners, repos.Parcels, log),
+		Locations:   services.NewLocationService(what3wordsClient, log),
+	}
+
+	if geocoder != nil {
+		a.Services.Geocoding = services.NewGeocodingService(geocoder, log)
+	}
+
+	// Responses are cached in Redis when configured, so instances share them
+	if cfg.ResponseCache.TTL > 0 {
+		a.Services.ResponseCache = a.responseCache()
+	}
+
+	if cfg.QueryAudit.Enabled {
+		a.Services.QueryAudit = services.NewQueryAuditService(repos.QueryAudit, cfg.QueryAudit, log)
+	}
+
+	// Pool and provider statistics are read when Prometheus scrapes
+	if cfg.Metrics.Enabled {
+		a.Services.Metrics = metrics.NewRegistry()
+		a.Services.HTTPMetrics = metrics.NewHTTP(a.Services.Metrics)
+		metrics.RegisterProviders(a.Services.Metrics, providers)
+		if db != nil {
+			metrics.RegisterPool(a.Services.Metrics, db.Pool)
+			metrics.RegisterCircuit(a.Services.Metrics, db)
+		}
+	}
+
+	// Spans are exported only when an OTLP endpoint is configured
+	if cfg.Tracing.Endpoint != "" {
+		a.Services.Tracer = a.tracer()
+	}
+
+	// Analyst queries require configured templates
+	if cfg.Queries.File != "" {
+		replica := a.Replica
+		if replica == nil {
+			replica = db
+		}
+		a.Repositories.Queries = repository.NewQueryRepository(replica)
+		a.Services.Queries = services.NewQueryService(a.Repositories.Queries, cfg.Queries, log)
+	}
+
+	// Embeddable widgets require a signing secret
+	if cfg.Embed.SigningSecret != "" {
+		signer := embed.NewSigner(cfg.Embed.SigningSecret, cfg.Embed.TokenTTL)
+		a.Repositories.Widgets = repository.NewWidgetRepository(db)
+		a.Services.Widgets = services.NewWidgetService(a.Repositories.Widgets, signer, log)
+	}
+
+	// Services register their tasks on the queue, so it is created before them
+	if cfg.Tasks.Enabled {
+		a.Repositories.Tasks = repository.NewTaskRepository(db)
+		a.Services.Tasks = jobs.NewQueue(a.Repositories.Tasks, jobs.QueueOptions{
+			PollInterval: cfg.Tasks.PollInterval,
+			Timeout:      cfg.Tasks.Timeout,
+			RetryDelay:   cfg.Tasks.RetryDelay,
+			MaxAttempts:  cfg.Tasks.MaxAttempts,
+		}, log)
+	}
+
+	// User accounts require a JWT signing secret
+	if cfg.Auth.JWTSecret != "" {
+		a.Services.Tokens = auth.NewSigner(cfg.Auth.JWTSecret, cfg.Auth.JWTTTL)
+		a.Repositories.Users = repository.NewUserRepository(db)
+		a.Services.Users = services.NewUserService(a.Repositories.Users, a.Services.Tokens, log)
+		a.Repositories.SavedSearches = repository.NewSavedSearchRepository(db)
+		a.Services.SavedSearches = services.NewSavedSearchService(a.Repositories.SavedSearches, repos.Parcels, a.Services.Tasks, cfg.SavedSearches, log)
+		a.Repositories.Favorites = repository.NewFavoriteRepository(db)
+		a.Services.Favorites = services.NewFavoriteService(a.Repositories.Favorites, log)
+		a.Repositories.Annotations = repository.NewAnnotationRepository(db)
+		a.Services.Annotations = services.NewAnnotationService(a.Repositories.Annotations, repos.Parcels, log)
+	}
+
+	// Webhooks get their own client so one unreachable endpoint never opens a
+	// breaker for the others; failed deliveries are retried by the job, not the client
+	if cfg.Webhooks.Enabled {
+		deliveries := outbound.NewClient("webhooks", outbound.Options{Log: log, MaxRetries: -1, BreakerThreshold: -1})
+		a.Services.Outbound.Register(deliveries)
+		a.Repositories.Webhooks = repository.NewWebhookRepository(db)
+		a.Services.Webhooks = services.NewWebhookService(a.Repositories.Webhooks, repos.Parcels, deliveries, a.Services.Tasks, cfg.Webhooks, log)
+	}
+
+	// Long-running requests such as sync bundles and exports are queued on the job manager
+	if cfg.Jobs.Enabled {
+		a.Services.Jobs = jobs.NewManager(cfg.Jobs.QueueSize, cfg.Jobs.Timeout, cfg.Jobs.Retention, log)
+		a.Services.Bundles = services.NewBundleService(repos.Parcels, a.Services.Jobs, log)
+		a.Services.Exports = services.NewExportService(repos.Parcels, a.Services.Jobs, cfg.Jobs.DownloadURLTTL, log)
+		a.Services.BulkQueries = services.NewBulkQueryService(repos.Parcels, a.Services.Jobs, cfg.Jobs.DownloadURLTTL, log)
+
+		// One unreachable callback URL must not stop the callbacks of other clients
+		callbacks := outbound.NewClient("result-callbacks", outbound.Options{Log: log, BreakerThreshold: -1})
+		a.Services.Outbound.Register(callbacks)
+		a.Services.Results = services.NewResultService(a.Services.Jobs, callbacks, log)
+	}
+
+	a.Handlers = Handlers{
+		Health:      handlers.NewHealthHandler(db, cfg.Server.Env),
+		Leaders:     handlers.NewLeaderHandler(a.Services.Leader),
+		Providers:   handlers.NewProviderHandler(a.Services.Outbound),
+		Parcels:     handlers.NewParcelHandler(a.Services.Parcels, a.Services.Locations),
+		Counties:    handlers.NewCountyHandler(a.Services.Counties),
+		Documents:   handlers.NewDocumentHandler(a.Services.Documents),
+		History:     handlers.NewHistoryHandler(a.Services.History),
+		Assemblages: handlers.NewAssemblageHandler(a.Services.Assemblages),
+		Comparables: handlers.NewComparableHandler(a.Services.Comparables),
+		GraphQL:     handlers.NewGraphQLHandler(a.Services.Parcels),
+		Styles:      handlers.NewStyleHandler(a.Services.Styles),
+		Shares:      handlers.NewShareLinkHandler(a.Services.Shares),
+		APIKeys:     handlers.NewAPIKeyHandler(a.Services.APIKeys),
+		Stats:       handlers.NewStatsHandler(a.Services.Stats),
+		Security:    handlers.NewSecurityHandler(a.Services.Security),
+		Retention:   handlers.NewRetentionHandler(a.Services.Retention),
+		Schema:      handlers.NewSchemaHandler(a.Services.Schema),
+		Overlays:    handlers.NewOverlayHandler(a.Services.Layers),
+		Layers:      handlers.NewLayerHandler(a.Services.Layers),
+		Sales:       handlers.NewSalesHandler(a.Services.Sales),
+		Assessments: handlers.NewAssessmentHandler(a.Services.Assessments),
+		Owners:      handlers.NewOwnerHandler(a.Services.Owners),
+	}
+	// Readiness fails while the schema is behind this build; a shared cache being
+	// down only degrades it, since responses are then computed uncached
+	a.Handlers.Health.AddCheck(handlers.HealthCheck{Name: "migrations", Check: a.Services.Schema.CheckMigrations})
+	if pinger, ok := a.Services.ResponseCache.(interface{ Ping(context.Context) error }); ok {
+		a.Handlers.Health.AddCheck(handlers.HealthCheck{Name: "cache", Check: pinger.Ping, Optional: true})
+	}
+
+	// A nil database or cache must stay a nil interface for the runtime stats
+	var pool handlers.PoolStats
+	if db != nil {
+		pool = db
+	}
+	a.Handlers.Parcels.SetHistory(a.Services.History)
+	a.Handlers.Parcels.SetOverlays(a.Services.Layers)
+	if a.Services.Geocoding != nil {
+		a.Handlers.Parcels.SetGeocoding(a.Services.Geocoding)
+		a.Handlers.Geocode = handlers.NewGeocodeHandler(a.Services.Geocoding, a.Services.Parcels)
+	}
+	cacheStats, _ := a.Services.ResponseCache.(handlers.CacheStats)
+	a.Handlers.Runtime = handlers.NewRuntimeHandler(pool, cacheStats)
+	if a.Services.Metrics != nil {
+		a.Handlers.Metrics = handlers.NewMetricsHandler(a.Services.Metrics)
+	}
+	if a.Services.Widgets != nil {
+		a.Handlers.Widgets = handlers.NewWidgetHandler(a.Services.Widgets, a.Services.Parcels)
+	}
+	if a.Services.Bundles != nil {
+		a.Handlers.Bundles = handlers.NewBundleHandler(a.Services.Bundles)
+	}
+	if a.Services.Exports != nil {
+		a.Handlers.Exports = handlers.NewExportHandler(a.Services.Exports)
+	}
+	if a.Services.BulkQueries != nil {
+		a.Handlers.BulkQueries = handlers.NewBulkQueryHandler(a.Services.BulkQueries)
+	}
+	if a.Services.Results != nil {
+		a.Handlers.Results = handlers.NewResultHandler(a.Services.Results)
+		a.Handlers.Parcels.SetResults(a.Services.Results)
+		a.Handlers.Assemblages.SetResults(a.Services.Results)
+	}
+	if a.Services.Users != nil {
+		a.Handlers.Users = handlers.NewUserHandler(a.Services.Users)
+		a.Handlers.SavedSearches = handlers.NewSavedSearchHandler(a.Services.SavedSearches)
+		a.Handlers.Favorites = handlers.NewFavoriteHandler(a.Services.Favorites)
+		a.Handlers.Annotations = handlers.NewAnnotationHandler(a.Services.Annotations)
+	}
+	if a.Services.Webhooks != nil {
+		a.Handlers.Webhooks = handlers.NewWebhookHandler(a.Services.Webhooks)
+	}
+	if a.Services.Queries != nil {
+		a.Handlers.Queries = handlers.NewQueryHandler(a.Services.Queries)
+	}
+}
+
+// responseCache returns the Redis response cache, or an in-memory one when no
+// Redis URL is configured. The Redis client is closed by a Stop hook.
+func (a *App) responseCache() cache.Cache {
+	cfg := a.Config.ResponseCache
+	if cfg.RedisURL == "" {
+		return cache.NewMemory(cfg.Size)
+	}
+
+	opts, err := cache.ParseRedisURL(cfg.RedisURL)
+	if err != nil {
+		// Config.Validate rejects invalid URLs; a hand-built config falls back to memory
+		a.Log.Error("Invalid response cache Redis URL, caching in memory", err, nil)
+		return cache.NewMemory(cfg.Size)
+	}
+	redis := cache.NewRedis(opts)
+	a.Append(Hook{
+		Name: "response cache",
+		OnStop: func(context.Context) error {
+			return redis.Close()
+		},
+	})
+	a.Log.Info("Caching responses in Redis", map[string]interface{}{
+		"addr": opts.Addr,
+		"db":   opts.DB,
+		"ttl":  cfg.TTL.String(),
+	})
+	return redis
+}
+
+// tracer returns the span exporter. Its queued spans are flushed by a Stop hook.
+func (a *App) tracer() *tracing.Tracer {
+	cfg := a.Config.Tracing
+	headers, err := tracing.ParseHeaders(cfg.Headers)
+	if err != nil {
+		// Config.Validate rejects invalid headers; a hand-built config exports without them
+		a.Log.Error("Invalid OTLP headers, exporting without them", err, nil)
+	}
+	tracer := tracing.New(tracing.Options{
+		Log:         a.Log,
+		Headers:     headers,
+		Endpoint:    cfg.Endpoint,
+		ServiceName: cfg.ServiceName,
+		SampleRatio: cfg.SampleRatio,
+	})
+	a.Append(Hook{
+		Name:   "tracing",
+		OnStop: tracer.Shutdown,
+	})
+	a.Log.Info("Exporting traces", map[string]interface{}{
+		"endpoint":     cfg.Endpoint,
+		"service":      cfg.ServiceName,
+		"sample_ratio": cfg.SampleRatio,
+	})
+	return tracer
+}
+
+// siemExporter returns the SIEM event exporter, registering its client with
+// providers. Its buffered events are flushed by a Stop hook.
+func (a *App) siemExporter(providers *outbound.Registry) *siem.Exporter {
+	cfg := a.Config.SIEM
+	client := outbound.NewClient("siem", outbound.Options{
+		Log:     a.Log,
+		Timeout: cfg.Timeout,
+	})
+	providers.Register(client)
+
+	exporter := siem.New(siem.Options{
+		Log:           a.Log,
+		Client:        client,
+		Sink:          cfg.Sink,
+		URL:           cfg.URL,
+		Topic:         cfg.Topic,
+		Format:        cfg.Format,
+		Token:         cfg.Token,
+		QueueSize:     cfg.QueueSize,
+		BatchSize:     cfg.BatchSize,
+		FlushInterval: cfg.FlushInterval,
+		MaxRetryDelay: cfg.MaxRetryDelay,
+	})
+	a.Append(Hook{
+		Name:   "siem",
+		OnStop: exporter.Shutdown,
+	})
+	a.Log.Info("Exporting audit events to SIEM", map[string]interface{}{
+		"sink":   cfg.Sink,
+		"format": cfg.Format,
+		"topic":  cfg.Topic,
+	})
+	return exporter
+}
@@ -0,0 +1,294 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/cache"
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+)
+
+// testConfig returns a configuration with every optional feature disabled.
+func testConfig() *config.Config {
+	return &config.Config{Server: config.ServerConfig{Env: "test"}}
+}
+
+func TestWire(t *testing.T) {
+	t.Run("optional features disabled", func(t *testing.T) {
+		a := Wire(testConfig(), logger.New("test"), nil)
+
+		assert.NotNil(t, a.Services.Parcels)
+		assert.NotNil(t, a.Services.Locations)
+		assert.NotNil(t, a.Handlers.Health)
+		assert.NotNil(t, a.Handlers.Leaders)
+		assert.NotNil(t, a.Handlers.Providers)
+		assert.Empty(t, a.Services.Outbound.Stats())
+		assert.NotNil(t, a.Services.Leader)
+		assert.NotNil(t, a.Handlers.Parcels)
+		assert.NotNil(t, a.Handlers.Counties)
+		assert.NotNil(t, a.Handlers.Documents)
+		assert.NotNil(t, a.Handlers.History)
+		assert.NotNil(t, a.Handlers.Sales)
+		assert.NotNil(t, a.Handlers.Assessments)
+		assert.NotNil(t, a.Handlers.Owners)
+		assert.NotNil(t, a.Handlers.Assemblages)
+		assert.NotNil(t, a.Handlers.Comparables)
+		assert.NotNil(t, a.Handlers.Overlays)
+		assert.NotNil(t, a.Handlers.Layers)
+		assert.NotNil(t, a.Handlers.GraphQL)
+		assert.NotNil(t, a.Handlers.Styles)
+		assert.NotNil(t, a.Handlers.Shares)
+		assert.NotNil(t, a.Handlers.Stats)
+		assert.NotNil(t, a.Handlers.Retention)
+		assert.NotNil(t, a.Handlers.Runtime)
+		assert.Nil(t, a.Repositories.Widgets)
+		assert.Nil(t, a.Services.Widgets)
+		assert.Nil(t, a.Handlers.Widgets)
+		assert.Nil(t, a.Services.Jobs)
+		assert.Nil(t, a.Repositories.Tasks)
+		assert.Nil(t, a.Services.Tasks)
+		assert.Nil(t, a.Services.Bundles)
+		assert.Nil(t, a.Handlers.Bundles)
+		assert.Nil(t, a.Services.Exports)
+		assert.Nil(t, a.Handlers.Exports)
+		assert.Nil(t, a.Services.BulkQueries)
+		assert.Nil(t, a.Handlers.BulkQueries)
+		assert.Nil(t, a.Services.Results)
+		assert.Nil(t, a.Handlers.Results)
+		assert.Nil(t, a.Services.Users)
+		assert.Nil(t, a.Services.Tokens)
+		assert.Nil(t, a.Handlers.Users)
+		assert.Nil(t, a.Repositories.SavedSearches)
+		assert.Nil(t, a.Services.SavedSearches)
+		assert.Nil(t, a.Handlers.SavedSearches)
+		assert.Nil(t, a.Repositories.Favorites)
+		assert.Nil(t, a.Services.Favorites)
+		assert.Nil(t, a.Handlers.Favorites)
+		assert.Nil(t, a.Repositories.Annotations)
+		assert.Nil(t, a.Services.Annotations)
+		assert.Nil(t, a.Handlers.Annotations)
+		assert.Nil(t, a.Repositories.Webhooks)
+		assert.Nil(t, a.Services.Webhooks)
+		assert.Nil(t, a.Handlers.Webhooks)
+		assert.Nil(t, a.Services.Geocoding)
+		assert.Nil(t, a.Handlers.Geocode)
+		assert.Nil(t, a.Services.ResponseCache)
+		assert.Nil(t, a.Services.Metrics)
+		assert.Nil(t, a.Handlers.Metrics)
+		assert.Nil(t, a.Services.Tracer)
+		assert.Nil(t, a.Services.SIEM)
+		assert.Nil(t, a.Repositories.Queries)
+		assert.Nil(t, a.Services.Queries)
+		assert.Nil(t, a.Handlers.Queries)
+	})
+
+	t.Run("optional features enabled", func(t *testing.T) {
+		cfg := testConfig()
+		cfg.Embed = config.EmbedConfig{SigningSecret: "0123456789abcdef0123456789abcdef", TokenTTL: time.Hour}
+		cfg.Jobs = config.JobsConfig{Enabled: true, Workers: 1, QueueSize: 1, Timeout: time.Minute, Retention: time.Hour, DownloadURLTTL: time.Minute}
+		cfg.Auth = config.AuthConfig{JWTSecret: "0123456789abcdef0123456789abcdef", JWTTTL: time.Hour}
+		cfg.What3Words = config.What3WordsConfig{APIKey: "key", BaseURL: "https://api.what3words.com/v3", Timeout: time.Second}
+		cfg.Geocoder = config.GeocoderConfig{Provider: "pelias", BaseURL: "https://pelias.example.com", Timeout: time.Second}
+		cfg.ResponseCache = config.ResponseCacheConfig{TTL: time.Minute, Size: 10, Precision: 5}
+		cfg.Metrics = config.MetricsConfig{Enabled: true}
+		cfg.Webhooks = config.WebhooksConfig{Enabled: true, PollInterval: time.Second, MaxAttempts: 1, RetryDelay: time.Second}
+		cfg.Tasks = config.TasksConfig{Enabled: true, Workers: 1, PollInterval: time.Second, Timeout: time.Minute, MaxAttempts: 1, RetryDelay: time.Second}
+		cfg.Queries = config.QueriesConfig{File: "queries.json", RowLimit: 10, Timeout: time.Second,
+			Templates: []config.QueryTemplate{{Name: "county_totals", SQL: "SELECT 1"}}}
+
+		a := Wire(cfg, logger.New("test"), nil)
+
+		assert.NotNil(t, a.Repositories.Widgets)
+		assert.NotNil(t, a.Services.Widgets)
+		assert.NotNil(t, a.Handlers.Widgets)
+		assert.NotNil(t, a.Services.Jobs)
+		assert.NotNil(t, a.Repositories.Tasks)
+		assert.NotNil(t, a.Services.Tasks)
+		assert.NotNil(t, a.Services.Bundles)
+		assert.NotNil(t, a.Handlers.Bundles)
+		assert.NotNil(t, a.Services.Exports)
+		assert.NotNil(t, a.Handlers.Exports)
+		assert.NotNil(t, a.Services.BulkQueries)
+		assert.NotNil(t, a.Handlers.BulkQueries)
+		assert.NotNil(t, a.Services.Results)
+		assert.NotNil(t, a.Handlers.Results)
+		assert.NotNil(t, a.Repositories.Users)
+		assert.NotNil(t, a.Services.Users)
+		assert.NotNil(t, a.Services.Tokens)
+		assert.NotNil(t, a.Handlers.Users)
+		assert.NotNil(t, a.Repositories.SavedSearches)
+		assert.NotNil(t, a.Services.SavedSearches)
+		assert.NotNil(t, a.Handlers.SavedSearches)
+		assert.NotNil(t, a.Repositories.Favorites)
+		assert.NotNil(t, a.Services.Favorites)
+		assert.NotNil(t, a.Handlers.Favorites)
+		assert.NotNil(t, a.Repositories.Annotations)
+		assert.NotNil(t, a.Services.Annotations)
+		assert.NotNil(t, a.Handlers.Annotations)
+		assert.NotNil(t, a.Repositories.Webhooks)
+		assert.NotNil(t, a.Services.Webhooks)
+		assert.NotNil(t, a.Handlers.Webhooks)
+		assert.NotNil(t, a.Services.Geocoding)
+		assert.NotNil(t, a.Handlers.Geocode)
+		require.Len(t, a.Services.Outbound.Stats(), 4)
+		assert.Equal(t, "geocoder", a.Services.Outbound.Stats()[0].Name)
+		assert.Equal(t, "result-callbacks", a.Services.Outbound.Stats()[1].Name)
+		assert.Equal(t, "webhooks", a.Services.Outbound.Stats()[2].Name)
+		assert.Equal(t, "what3words", a.Services.Outbound.Stats()[3].Name)
+		assert.IsType(t, &cache.Memory{}, a.Services.ResponseCache)
+		assert.NotNil(t, a.Services.HTTPMetrics)
+		assert.NotNil(t, a.Handlers.Metrics)
+		assert.NotNil(t, a.Repositories.Queries)
+		require.NotNil(t, a.Services.Queries)
+		assert.Len(t, a.Services.Queries.Templates(), 1)
+		assert.NotNil(t, a.Handlers.Queries)
+		var out strings.Builder
+		require.NoError(t, a.Services.Metrics.Write(&out))
+		assert.Contains(t, out.String(), `atlas_outbound_requests_total{provider="what3words"} 0`)
+	})
+
+	t.Run("redis response cache", func(t *testing.T) {
+		cfg := testConfig()
+		cfg.ResponseCache = config.ResponseCacheConfig{TTL: time.Minute, Size: 10, Precision: 5, RedisURL: "redis://localhost:6379/1"}
+
+		a := Wire(cfg, logger.New("test"), nil)
+
+		assert.IsType(t, &cache.Redis{}, a.Services.ResponseCache)
+		assert.NoError(t, a.Stop(context.Background()), "the client is closed by a hook")
+	})
+
+	t.Run("tracing", func(t *testing.T) {
+		cfg := testConfig()
+		cfg.Tracing = config.TracingConfig{Endpoint: "http://localhost:4318", ServiceName: "atlas-api", SampleRatio: 1}
+
+		a := Wire(cfg, logger.New("test"), nil)
+
+		require.NotNil(t, a.Services.Tracer)
+		assert.NoError(t, a.Stop(context.Background()), "the exporter is shut down by a hook")
+	})
+
+	t.Run("SIEM", func(t *testing.T) {
+		cfg := testConfig()
+		cfg.SIEM = config.SIEMConfig{
+			Sink: "http", URL: "http://localhost:8088/ingest", Format: "json",
+			QueueSize: 100, BatchSize: 10, FlushInterval: time.Second, Timeout: time.Second, MaxRetryDelay: time.Minute,
+		}
+
+		a := Wire(cfg, logger.New("test"), nil)
+
+		require.NotNil(t, a.Services.SIEM)
+		stats := a.Services.Outbound.Stats()
+		require.Len(t, stats, 1)
+		assert.Equal(t, "siem", stats[0].Name, "the client is registered with the providers")
+		assert.NoError(t, a.Stop(context.Background()), "the exporter is shut down by a hook")
+	})
+}
+
+func TestLifecycle(t *testing.T) {
+	t.Run("starts in order and stops in reverse", func(t *testing.T) {
+		a := &App{}
+		var calls []string
+		for _, name := range []string{"first", "second"} {
+			a.Append(Hook{
+				Name:    name,
+				OnStart: func(context.Context) error { calls = append(calls, "start "+name); return nil },
+				OnStop:  func(context.Context) error { calls = append(calls, "stop "+name); return nil },
+			})
+		}
+
+		require.NoError(t, a.Start(context.Background()))
+		require.NoError(t, a.Stop(context.Background()))
+		assert.Equal(t, []string{"start first", "start second", "stop second", "stop first"}, calls)
+	})
+
+	t.Run("failed start stops the app", func(t *testing.T) {
+		a := &App{}
+		var stopped []string
+		errBoom := errors.New("boom")
+		a.Append(Hook{Name: "resource", OnStop: func(context.Context) error { stopped = append(stopped, "resource"); return nil }})
+		a.Append(Hook{Name: "broken", OnStart: func(context.Context) error { return errBoom }})
+
+		err := a.Start(context.Background())
+		assert.ErrorIs(t, err, errBoom)
+		assert.ErrorContains(t, err, "failed to start broken")
+		assert.Equal(t, []string{"resource"}, stopped)
+	})
+
+	t.Run("stop errors are joined", func(t *testing.T) {
+		a := &App{}
+		errFirst, errSecond := errors.New("first"), errors.New("second")
+		a.Append(Hook{Name: "a", OnStop: func(context.Context) error { return errFirst }})
+		a.Append(Hook{Name: "b", OnStop: func(context.Context) error { return errSecond }})
+
+		err := a.Stop(context.Background())
+		assert.ErrorIs(t, err, errFirst)
+		assert.ErrorIs(t, err, errSecond)
+	})
+}
+
+func TestReload(t *testing.T) {
+	cfg := testConfig()
+	cfg.CORS.Origins = []string{"http://localhost:3000"}
+	cfg.ResponseCache = config.ResponseCacheConfig{TTL: time.Minute, Size: 10, Routes: map[string]time.Duration{"parcels.nearby": time.Minute}}
+	cfg.What3Words = config.What3WordsConfig{APIKey: "key", BaseURL: "https://api.what3words.com/v3", Timeout: time.Second}
+	a := Wire(cfg, logger.New("test"), nil)
+	var applied *config.Config
+	a.OnReload(func(cfg *config.Config) { applied = cfg })
+
+	t.Run("reloadable settings need no restart", func(t *testing.T) {
+		next := *cfg
+		next.Server.LogLevel = "debug"
+		next.CORS.Origins = []string{"https://app.example.com"}
+		next.ResponseCache.TTL = 5 * time.Minute
+		next.ResponseCache.Routes = map[string]time.Duration{"parcels.nearby": 5 * time.Minute}
+		next.What3Words.RateLimit, next.What3Words.CacheTTL = 5, time.Hour
+
+		assert.Empty(t, a.Reload(&next))
+		assert.Same(t, &next, applied, "OnReload functions get the new configuration")
+	})
+
+	t.Run("other settings are reported by section", func(t *testing.T) {
+		next := *cfg
+		next.Server.Port = "9090"
+		next.ResponseCache.Routes = map[string]time.Duration{"parcels.search": time.Minute}
+		next.Jobs.Workers = 4
+
+		assert.Equal(t, []string{"Server", "ResponseCache", "Jobs"}, a.Reload(&next))
+	})
+}
+
+func TestJobs(t *testing.T) {
+	t.Run("runs the job manager until stopped", func(t *testing.T) {
+		cfg := testConfig()
+		cfg.Jobs = config.JobsConfig{Enabled: true, Workers: 2, QueueSize: 1, Timeout: time.Minute, Retention: time.Hour}
+		a := Wire(cfg, logger.New("test"), nil)
+		a.Append(a.RequestJobs())
+
+		require.NoError(t, a.Start(context.Background()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(t, a.Stop(ctx))
+	})
+
+	t.Run("scheduled jobs disabled", func(t *testing.T) {
+		a := Wire(testConfig(), logger.New("test"), nil)
+		a.Append(a.ScheduledJobs())
+
+		require.NoError(t, a.Start(context.Background()))
+		assert.NoError(t, a.Stop(context.Background()))
+	})
+
+	t.Run("stop without start", func(t *testing.T) {
+		a := Wire(testConfig(), logger.New("test"), nil)
+		a.Append(a.RequestJobs())
+		a.Append(a.ScheduledJobs())
+		assert.NoError(t, a.Stop(context.Background()))
+	})
+}
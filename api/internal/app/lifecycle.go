@@ -0,0 +1,185 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Hook is a component's lifecycle. OnStart must not block: long-running work
+// belongs in goroutines that OnStop ends. OnStop also runs when OnStart never
+// did, e.g. after another hook failed to start. Either function may be nil.
+type Hook struct {
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+	// Name identifies the hook in errors
+	Name string
+}
+
+// Append registers a hook. Hooks start in the order they were appended and
+// stop in reverse, so a component stops before the ones it was built on.
+func (a *App) Append(hook Hook) {
+	a.hooks = append(a.hooks, hook)
+}
+
+// Start runs the OnStart hooks in order. If one fails, the App is stopped and
+// the error is returned.
+func (a *App) Start(ctx context.Context) error {
+	for _, hook := range a.hooks {
+		if hook.OnStart == nil {
+			continue
+		}
+		if err := hook.OnStart(ctx); err != nil {
+			startErr := fmt.Errorf("failed to start %s: %w", hook.Name, err)
+			return errors.Join(startErr, a.Stop(ctx))
+		}
+	}
+	return nil
+}
+
+// Stop runs every OnStop hook in reverse order and returns their joined errors.
+// ctx bounds how long the hooks may take. Stopping an App that was never
+// started still releases what New acquired, such as the database pool.
+func (a *App) Stop(ctx context.Context) error {
+	var errs []error
+	for i := len(a.hooks) - 1; i >= 0; i-- {
+		hook := a.hooks[i]
+		if hook.OnStop == nil {
+			continue
+		}
+		if err := hook.OnStop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop %s: %w", hook.Name, err))
+		}
+	}
+	a.hooks = nil
+	return errors.Join(errs...)
+}
+
+// RequestJobs consumes the work queued by this process's HTTP requests: sync
+// bundle jobs, spatial audit samples, cache warming hit counts, security
+// events and query audit entries. Only processes serving the API run it, since
+// the queues are in memory.
+func (a *App) RequestJobs() Hook {
+	return a.jobsHook("request jobs", func(run func(job func(context.Context))) {
+		cfg, svc := a.Config, a.Services
+		if cfg.Warming.Enabled {
+			run(svc.Warming.RunFlush)
+		}
+		if cfg.Audit.SampleRate > 0 {
+			run(svc.Audit.Run)
+		} else {
+			a.Log.Info("Spatial audit sampling disabled", nil)
+		}
+		run(svc.Security.Run)
+		if svc.QueryAudit != nil {
+			run(svc.QueryAudit.Run)
+		} else {
+			a.Log.Info("Query audit log disabled", nil)
+		}
+		if svc.Jobs != nil {
+			run(func(ctx context.Context) { svc.Jobs.Run(ctx, cfg.Jobs.Workers) })
+		} else {
+			a.Log.Info("Background job manager disabled; sync bundle endpoints unavailable", nil)
+		}
+	})
+}
+
+// Leader roles of the scheduled jobs
+const (
+	RoleStatsSnapshots = "stats-snapshots"
+	RoleCacheWarming   = "cache-warming"
+	RoleRetention      = "retention"
+	RoleSavedSearches  = "saved-search-alerts"
+	RoleWebhooks       = "webhooks"
+)
+
+// ScheduledJobs runs the jobs driven by the database alone: daily stats
+// snapshots, cache warming after dataset switches, retention pruning, and saved
+// search alerts and webhook deliveries after ingestion runs. The API runs them unless
+// a worker is enabled, in which case only cmd/worker does. Either way each job
+// only runs on the instance elected leader of its role, except the task queue
+// workers, which run everywhere and share the tasks through the database.
+func (a *App) ScheduledJobs() Hook {
+	return a.jobsHook("scheduled jobs", func(run func(job func(context.Context))) {
+		cfg, svc := a.Config, a.Services
+		singleton := func(role string, job func(context.Context)) {
+			run(func(ctx context.Context) { svc.Leader.Run(ctx, role, job) })
+		}
+		if cfg.Stats.SnapshotEnabled {
+			singleton(RoleStatsSnapshots, svc.Stats.RunDailySnapshots)
+		} else {
+			a.Log.Info("Stats snapshot job disabled", nil)
+		}
+		if cfg.Warming.Enabled {
+			singleton(RoleCacheWarming, svc.Warming.RunWarming)
+		} else {
+			a.Log.Info("Cache warming job disabled", nil)
+		}
+		if cfg.Retention.Enabled {
+			singleton(RoleRetention, svc.Retention.Run)
+		} else {
+			a.Log.Info("Retention pruning job disabled", nil)
+		}
+		if cfg.SavedSearches.Enabled && svc.SavedSearches != nil {
+			singleton(RoleSavedSearches, svc.SavedSearches.RunAlerts)
+		} else {
+			a.Log.Info("Saved search alerts job disabled", nil)
+		}
+		if svc.Webhooks != nil {
+			singleton(RoleWebhooks, svc.Webhooks.Run)
+		} else {
+			a.Log.Info("Webhook delivery job disabled", nil)
+		}
+		if svc.Tasks != nil {
+			run(func(ctx context.Context) { svc.Tasks.Run(ctx, cfg.Tasks.Workers) })
+		} else {
+			a.Log.Info("Task queue disabled; background work runs in place", nil)
+		}
+	})
+}
+
+// jobsHook runs the jobs that start passes to run until Stop, which waits for
+// them to finish, e.g. for the hit flush job to write its last counts.
+func (a *App) jobsHook(name string, start func(run func(job func(context.Context)))) Hook {
+	var (
+		cancel context.CancelFunc
+		wg     sync.WaitGroup
+	)
+
+	return Hook{
+		Name: name,
+		OnStart: func(context.Context) error {
+			// Jobs outlive the start context, which only bounds startup
+			var jobsCtx context.Context
+			jobsCtx, cancel = context.WithCancel(context.Background())
+
+			start(func(job func(context.Context)) {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					job(jobsCtx)
+				}()
+			})
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			if cancel == nil {
+				return nil
+			}
+			cancel()
+
+			done := make(chan struct{})
+			go func() {
+				wg.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	}
+}
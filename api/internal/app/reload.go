@@ -0,0 +1,118 @@
+package app
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"reflect"
+	"syscall"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/location"
+)
+
+// OnReload registers a function that applies the reloadable settings of a
+// new configuration to a component the entrypoint built, such as the API
+// server's CORS policy.
+func (a *App) OnReload(apply func(cfg *config.Config)) {
+	a.reloads = append(a.reloads, apply)
+}
+
+// Reload applies the reloadable settings of cfg without a restart: the log
+// level, the rate limits and cache TTLs of the what3words and geocoder
+// clients, and what the OnReload functions apply (the API server's CORS
+// origins and response cache TTLs). It returns the sections of cfg, such as
+// "Database", whose other settings differ from those the App was built with;
+// they only apply after a restart.
+func (a *App) Reload(cfg *config.Config) []string {
+	_ = a.Log.SetLevel(cfg.Server.Level()) // validated by config.Load
+	if client := a.Services.Outbound.Client(location.What3WordsProvider); client != nil {
+		client.SetRateLimit(cfg.What3Words.RateLimit, 0)
+		client.SetCacheTTL(cfg.What3Words.CacheTTL)
+	}
+	if client := a.Services.Outbound.Client(location.GeocoderProvider); client != nil {
+		client.SetRateLimit(cfg.Geocoder.RateLimit, 0)
+		client.SetCacheTTL(cfg.Geocoder.CacheTTL)
+	}
+	for _, apply := range a.reloads {
+		apply(cfg)
+	}
+
+	var restart []string
+	current, next := reflect.ValueOf(withoutReloadable(a.Config)), reflect.ValueOf(withoutReloadable(cfg))
+	for i := range current.NumField() {
+		if !reflect.DeepEqual(current.Field(i).Interface(), next.Field(i).Interface()) {
+			restart = append(restart, current.Type().Field(i).Name)
+		}
+	}
+	return restart
+}
+
+// withoutReloadable returns a copy of cfg with the settings Reload applies
+// cleared. Whether the response cache is enabled, and which routes it
+// caches, still need a restart.
+func withoutReloadable(cfg *config.Config) config.Config {
+	c := *cfg
+	c.Server.LogLevel = ""
+	c.CORS.Origins = nil
+	if c.ResponseCache.TTL > 0 {
+		c.ResponseCache.TTL = time.Nanosecond
+	}
+	routes := make(map[string]time.Duration, len(c.ResponseCache.Routes))
+	for name := range c.ResponseCache.Routes {
+		routes[name] = 0
+	}
+	c.ResponseCache.Routes = routes
+	c.What3Words.RateLimit, c.What3Words.CacheTTL = 0, 0
+	c.Geocoder.RateLimit, c.Geocoder.CacheTTL = 0, 0
+	return c
+}
+
+// ReloadOnSignal reloads the configuration from the environment and the
+// config file at path (see config.LoadFile) on SIGHUP. A configuration that
+// fails to load is logged and the current one kept.
+func (a *App) ReloadOnSignal(path string) Hook {
+	hup := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	return Hook{
+		Name: "reload",
+		OnStart: func(context.Context) error {
+			signal.Notify(hup, syscall.SIGHUP)
+			go func() {
+				for {
+					select {
+					case <-hup:
+						a.reloadFile(path)
+					case <-done:
+						return
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			signal.Stop(hup)
+			close(done)
+			return nil
+		},
+	}
+}
+
+// reloadFile loads the configuration and reloads it.
+func (a *App) reloadFile(path string) {
+	cfg, err := config.LoadFile(path)
+	if err != nil {
+		a.Log.Error("Failed to reload configuration; keeping the current one", err, nil)
+		return
+	}
+	restart := a.Reload(cfg)
+	a.Log.Info("Configuration reloaded", map[string]interface{}{
+		"log_level": cfg.Server.Level(),
+	})
+	if len(restart) > 0 {
+		a.Log.Warn("Changed settings only apply after a restart", map[string]interface{}{
+			"sections": restart,
+		})
+	}
+}
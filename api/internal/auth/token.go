@@ -0,0 +1,145 @@
+// Package auth issues and verifies the JSON Web Tokens that authenticate users.
+// Tokens are HS256-signed with a shared secret, so any API instance with the
+// secret can verify them without a database lookup.
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Issuer is the iss claim of every token, so tokens of other services sharing
+// the secret are not accepted.
+const Issuer = "atlas"
+
+// Token errors
+var (
+	ErrMalformedToken   = errors.New("malformed token")
+	ErrInvalidSignature = errors.New("invalid token signature")
+	ErrExpiredToken     = errors.New("token has expired")
+)
+
+// header is the fixed JOSE header of issued tokens, base64url-encoded.
+var header = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Claims are the registered JWT claims of a user token.
+type Claims struct {
+	// Subject is the user ID in decimal
+	Subject   string `json:"sub"`
+	Email     string `json:"email"`
+	Issuer    string `json:"iss"`
+	IssuedAt  int64  `json:"iat"` // Unix seconds
+	ExpiresAt int64  `json:"exp"` // Unix seconds
+}
+
+// UserID returns the user ID in the subject claim.
+func (c *Claims) UserID() (uint, error) {
+	id, err := strconv.ParseUint(c.Subject, 10, 32)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("%w: subject %q is not a user id", ErrMalformedToken, c.Subject)
+	}
+	return uint(id), nil
+}
+
+// Signer issues and verifies HS256 JSON Web Tokens.
+type Signer struct {
+	now    func() time.Time
+	secret []byte
+	ttl    time.Duration
+}
+
+// NewSigner creates a Signer using the given secret and token lifetime.
+func NewSigner(secret string, ttl time.Duration) *Signer {
+	return &Signer{
+		secret: []byte(secret),
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+// Issue creates a signed token for the user.
+// It returns the token and its expiry time.
+func (s *Signer) Issue(userID uint, email string) (string, time.Time, error) {
+	now := s.now().Truncate(time.Second)
+	expiresAt := now.Add(s.ttl)
+
+	payload, err := json.Marshal(Claims{
+		Subject:   strconv.FormatUint(uint64(userID), 10),
+		Email:     email,
+		Issuer:    Issuer,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to marshal token claims: %w", err)
+	}
+
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(payload)
+	token := signingInput + "." + base64.RawURLEncoding.EncodeToString(s.sign(signingInput))
+
+	return token, expiresAt, nil
+}
+
+// Verify checks the token header, signature, issuer and expiry and returns its
+// claims. Only HS256 tokens are accepted, whatever algorithm the header names.
+func (s *Signer) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, ErrMalformedToken
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	if !hmac.Equal(sig, s.sign(parts[0]+"."+parts[1])) {
+		return nil, ErrInvalidSignature
+	}
+
+	// The signature covers the header, so a valid token names HS256
+	rawHeader, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	var jose struct {
+		Alg string `json:"alg"`
+	}
+	if err := json.Unmarshal(rawHeader, &jose); err != nil || jose.Alg != "HS256" {
+		return nil, ErrMalformedToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrMalformedToken
+	}
+	if claims.Issuer != Issuer {
+		return nil, ErrMalformedToken
+	}
+	if _, err := claims.UserID(); err != nil {
+		return nil, err
+	}
+
+	if s.now().Unix() >= claims.ExpiresAt {
+		return nil, ErrExpiredToken
+	}
+
+	return &claims, nil
+}
+
+// sign computes the HMAC-SHA256 of the signing input (header.payload).
+func (s *Signer) sign(signingInput string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(signingInput))
+	return mac.Sum(nil)
+}
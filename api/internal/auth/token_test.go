@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func TestSigner_IssueAndVerify(t *testing.T) {
+	signer := NewSigner(testSecret, time.Hour)
+
+	token, expiresAt, err := signer.Issue(42, "ada@example.com")
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(token, "."), 3)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, 2*time.Second)
+
+	claims, err := signer.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, "42", claims.Subject)
+	assert.Equal(t, "ada@example.com", claims.Email)
+	assert.Equal(t, Issuer, claims.Issuer)
+	assert.Equal(t, expiresAt.Unix(), claims.ExpiresAt)
+
+	userID, err := claims.UserID()
+	require.NoError(t, err)
+	assert.Equal(t, uint(42), userID)
+}
+
+func TestSigner_Verify_Expired(t *testing.T) {
+	signer := NewSigner(testSecret, time.Minute)
+	token, _, err := signer.Issue(42, "ada@example.com")
+	require.NoError(t, err)
+
+	// Advance the clock past the token lifetime
+	signer.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+
+	_, err = signer.Verify(token)
+	assert.ErrorIs(t, err, ErrExpiredToken)
+}
+
+func TestSigner_Verify_WrongSecret(t *testing.T) {
+	token, _, err := NewSigner(testSecret, time.Hour).Issue(42, "ada@example.com")
+	require.NoError(t, err)
+
+	_, err = NewSigner(strings.Repeat("x", 32), time.Hour).Verify(token)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestSigner_Verify_Tampered(t *testing.T) {
+	signer := NewSigner(testSecret, time.Hour)
+	token, _, err := signer.Issue(42, "ada@example.com")
+	require.NoError(t, err)
+
+	// Swap in claims for another user while keeping the original signature
+	other, _, err := signer.Issue(1, "root@example.com")
+	require.NoError(t, err)
+	parts := strings.Split(token, ".")
+	parts[1] = strings.Split(other, ".")[1]
+
+	_, err = signer.Verify(strings.Join(parts, "."))
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestSigner_Verify_UnsignedAlgorithm(t *testing.T) {
+	signer := NewSigner(testSecret, time.Hour)
+	token, _, err := signer.Issue(42, "ada@example.com")
+	require.NoError(t, err)
+
+	// An alg "none" token with an empty signature is never accepted
+	parts := strings.Split(token, ".")
+	parts[0] = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+	_, err = signer.Verify(parts[0] + "." + parts[1] + ".")
+	assert.ErrorIs(t, err, ErrMalformedToken)
+}
+
+func TestSigner_Verify_Malformed(t *testing.T) {
+	signer := NewSigner(testSecret, time.Hour)
+
+	for _, token := range []string{"", "abc", "a.b", "a.b.c.d", "a.b.!!!"} {
+		_, err := signer.Verify(token)
+		assert.ErrorIs(t, err, ErrMalformedToken, "token %q", token)
+	}
+}
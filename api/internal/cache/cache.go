@@ -0,0 +1,155 @@
+// Package cache stores byte values under string keys for a time to live. Redis
+// shares the cache between instances; Memory keeps it in the process, for single
+// instances and as the fallback when Redis is not configured.
+package cache
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultMemorySize bounds the entries of a Memory cache created with a non-positive size.
+const DefaultMemorySize = 10000
+
+// ErrUnavailable is returned while the cache backend cannot be reached.
+var ErrUnavailable = errors.New("cache unavailable")
+
+// Cache stores values by key. Implementations are safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored under key, or false if there is none or it has expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set stores value under key for ttl, replacing any previous value.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// Stats counts the lookups of a cache since it was created.
+type Stats struct {
+	Backend string `json:"backend"`
+	Hits    int64  `json:"hits"`
+	Misses  int64  `json:"misses"`
+	Errors  int64  `json:"errors"`
+	// Entries is the number of stored values, including expired ones not yet
+	// evicted; only Memory knows it
+	Entries int `json:"entries,omitempty"`
+}
+
+// lookups counts the results of Get.
+type lookups struct {
+	hits   atomic.Int64
+	misses atomic.Int64
+	errors atomic.Int64
+}
+
+// record counts one Get that found a value or not, or failed.
+func (l *lookups) record(found bool, err error) {
+	switch {
+	case err != nil:
+		l.errors.Add(1)
+	case found:
+		l.hits.Add(1)
+	default:
+		l.misses.Add(1)
+	}
+}
+
+// stats returns the counts as the Stats of backend.
+func (l *lookups) stats(backend string) Stats {
+	return Stats{Backend: backend, Hits: l.hits.Load(), Misses: l.misses.Load(), Errors: l.errors.Load()}
+}
+
+// Memory is an in-process Cache that evicts the least recently used entry
+// beyond its size.
+type Memory struct {
+	entries map[string]*list.Element
+	order   *list.List
+	now     func() time.Time
+	size    int
+	lookups lookups
+	mu      sync.Mutex
+}
+
+// memoryEntry is a cached value, an element of Memory.order.
+type memoryEntry struct {
+	expires time.Time
+	key     string
+	value   []byte
+}
+
+// NewMemory creates an empty Memory cache holding up to size entries.
+func NewMemory(size int) *Memory {
+	if size <= 0 {
+		size = DefaultMemorySize
+	}
+	return &Memory{
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+		now:     time.Now,
+		size:    size,
+	}
+}
+
+// Get returns the value stored under key. Expired entries are removed.
+func (m *Memory) Get(_ context.Context, key string) ([]byte, bool, error) {
+	value, found := m.get(key)
+	m.lookups.record(found, nil)
+	return value, found, nil
+}
+
+// get looks key up under the lock.
+func (m *Memory) get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*memoryEntry)
+	if !m.now().Before(entry.expires) {
+		m.order.Remove(elem)
+		delete(m.entries, key)
+		return nil, false
+	}
+	m.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+// Set stores a copy of value under key for ttl.
+func (m *Memory) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	entry := &memoryEntry{key: key, value: append([]byte(nil), value...), expires: m.now().Add(ttl)}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.entries[key]; ok {
+		elem.Value = entry
+		m.order.MoveToFront(elem)
+		return nil
+	}
+	m.entries[key] = m.order.PushFront(entry)
+	for m.order.Len() > m.size {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryEntry).key)
+	}
+	return nil
+}
+
+// Len returns the number of entries, including expired ones not yet evicted.
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+// Stats returns the lookup counts and the number of entries.
+func (m *Memory) Stats() Stats {
+	stats := m.lookups.stats("memory")
+	stats.Entries = m.Len()
+	return stats
+}
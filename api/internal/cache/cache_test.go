@@ -0,0 +1,52 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemory_GetSet(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory(2)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	_, ok, err := m.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	value := []byte("one")
+	require.NoError(t, m.Set(ctx, "a", value, time.Minute))
+	value[0] = 'X'
+
+	got, ok, err := m.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("one"), got, "values are copied")
+
+	now = now.Add(time.Minute)
+	_, ok, _ = m.Get(ctx, "a")
+	assert.False(t, ok, "entries expire after their ttl")
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, Stats{Backend: "memory", Hits: 1, Misses: 2}, m.Stats())
+}
+
+func TestMemory_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory(2)
+
+	require.NoError(t, m.Set(ctx, "a", []byte("1"), time.Minute))
+	require.NoError(t, m.Set(ctx, "b", []byte("2"), time.Minute))
+	_, _, _ = m.Get(ctx, "a")
+	require.NoError(t, m.Set(ctx, "c", []byte("3"), time.Minute))
+
+	_, ok, _ := m.Get(ctx, "b")
+	assert.False(t, ok, "b was least recently used")
+	_, ok, _ = m.Get(ctx, "a")
+	assert.True(t, ok)
+	assert.Equal(t, 2, m.Len())
+}
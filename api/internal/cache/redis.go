@@ -0,0 +1,353 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Redis client defaults
+const (
+	DefaultRedisPort     = "6379"
+	DefaultRedisTimeout  = 250 * time.Millisecond
+	DefaultRedisPoolSize = 10
+	// redisRetryAfter is how long Redis is reported unavailable after a connection
+	// failure before it is dialed again, so an outage does not add a timeout to every request
+	redisRetryAfter = 5 * time.Second
+)
+
+// ErrInvalidRedisURL is returned by ParseRedisURL for unusable URLs.
+var ErrInvalidRedisURL = errors.New("invalid Redis URL")
+
+// RedisOptions configures a Redis client.
+type RedisOptions struct {
+	// TLS is set for rediss:// URLs
+	TLS      *tls.Config
+	Addr     string
+	Username string
+	Password string
+	DB       int
+	// Timeout bounds dialing and each command; zero uses DefaultRedisTimeout
+	Timeout time.Duration
+	// PoolSize is how many idle connections are kept; zero uses DefaultRedisPoolSize
+	PoolSize int
+}
+
+// ParseRedisURL reads redis://[[username]:password@]host[:port][/db] URLs.
+// rediss:// connects over TLS.
+func ParseRedisURL(raw string) (RedisOptions, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return RedisOptions{}, fmt.Errorf("%w: %w", ErrInvalidRedisURL, err)
+	}
+
+	var opts RedisOptions
+	switch u.Scheme {
+	case "redis":
+	case "rediss":
+		opts.TLS = &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12}
+	default:
+		return RedisOptions{}, fmt.Errorf("%w: scheme must be redis or rediss, got %q", ErrInvalidRedisURL, u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return RedisOptions{}, fmt.Errorf("%w: host is required", ErrInvalidRedisURL)
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = DefaultRedisPort
+	}
+	opts.Addr = net.JoinHostPort(u.Hostname(), port)
+
+	if u.User != nil {
+		opts.Username = u.User.Username()
+		opts.Password, _ = u.User.Password()
+	}
+
+	if db := strings.Trim(u.Path, "/"); db != "" {
+		opts.DB, err = strconv.Atoi(db)
+		if err != nil || opts.DB < 0 {
+			return RedisOptions{}, fmt.Errorf("%w: database must be a non-negative number, got %q", ErrInvalidRedisURL, db)
+		}
+	}
+
+	return opts, nil
+}
+
+// Redis is a Cache backed by a Redis server, speaking RESP over a small pool of
+// connections. Connection failures return ErrUnavailable, and for redisRetryAfter
+// afterwards every call does so without dialing.
+type Redis struct {
+	opts      RedisOptions
+	idle      chan *redisConn
+	now       func() time.Time
+	downUntil time.Time
+	lookups   lookups
+	mu        sync.Mutex
+}
+
+// redisConn is a pooled connection.
+type redisConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+	w    *bufio.Writer
+}
+
+// redisError is an error reply from the server; the connection stays usable.
+type redisError string
+
+// Error returns the server's message.
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// NewRedis creates a Redis client. Connections are dialed on first use.
+func NewRedis(opts RedisOptions) *Redis {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultRedisTimeout
+	}
+	if opts.PoolSize <= 0 {
+		opts.PoolSize = DefaultRedisPoolSize
+	}
+	return &Redis{
+		opts: opts,
+		idle: make(chan *redisConn, opts.PoolSize),
+		now:  time.Now,
+	}
+}
+
+// Get returns the value stored under key.
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, found, err := r.get(ctx, key)
+	r.lookups.record(found, err)
+	return value, found, err
+}
+
+// get sends the GET command.
+func (r *Redis) get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := r.do(ctx, "GET", key)
+	if err != nil {
+		return nil, false, err
+	}
+	if reply == nil {
+		return nil, false, nil
+	}
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("redis: unexpected GET reply %T", reply)
+	}
+	return value, true, nil
+}
+
+// Set stores value under key for ttl, rounded up to whole milliseconds.
+func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	millis := (ttl + time.Millisecond - 1).Milliseconds()
+	if millis <= 0 {
+		return nil
+	}
+	_, err := r.do(ctx, "SET", key, value, "PX", strconv.FormatInt(millis, 10))
+	return err
+}
+
+// Stats returns the lookup counts of this client; the server's are not read.
+func (r *Redis) Stats() Stats {
+	return r.lookups.stats("redis")
+}
+
+// Ping checks that the server answers.
+func (r *Redis) Ping(ctx context.Context) error {
+	_, err := r.do(ctx, "PING")
+	return err
+}
+
+// Close closes the idle connections. Connections in use are closed when they are returned.
+func (r *Redis) Close() error {
+	for {
+		select {
+		case c := <-r.idle:
+			_ = c.conn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+// do sends one command and reads its reply. Arguments are strings or byte slices.
+func (r *Redis) do(ctx context.Context, args ...any) (any, error) {
+	if r.unavailable() {
+		return nil, ErrUnavailable
+	}
+
+	c, err := r.conn(ctx)
+	if err != nil {
+		r.markDown()
+		return nil, fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+
+	reply, err := c.roundTrip(ctx, r.opts.Timeout, args)
+	var replyErr redisError
+	if err != nil && !errors.As(err, &replyErr) {
+		_ = c.conn.Close()
+		r.markDown()
+		return nil, fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+
+	select {
+	case r.idle <- c:
+	default:
+		_ = c.conn.Close()
+	}
+	return reply, err
+}
+
+// conn returns an idle connection or dials a new one.
+func (r *Redis) conn(ctx context.Context) (*redisConn, error) {
+	select {
+	case c := <-r.idle:
+		return c, nil
+	default:
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.opts.Timeout)
+	defer cancel()
+
+	var conn net.Conn
+	var err error
+	if r.opts.TLS != nil {
+		dialer := &tls.Dialer{Config: r.opts.TLS}
+		conn, err = dialer.DialContext(ctx, "tcp", r.opts.Addr)
+	} else {
+		var dialer net.Dialer
+		conn, err = dialer.DialContext(ctx, "tcp", r.opts.Addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", r.opts.Addr, err)
+	}
+
+	c := &redisConn{conn: conn, r: bufio.NewReader(conn), w: bufio.NewWriter(conn)}
+	if r.opts.Password != "" {
+		auth := []any{"AUTH", r.opts.Password}
+		if r.opts.Username != "" {
+			auth = []any{"AUTH", r.opts.Username, r.opts.Password}
+		}
+		if _, err := c.roundTrip(ctx, r.opts.Timeout, auth); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+	if r.opts.DB != 0 {
+		if _, err := c.roundTrip(ctx, r.opts.Timeout, []any{"SELECT", strconv.Itoa(r.opts.DB)}); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("failed to select database %d: %w", r.opts.DB, err)
+		}
+	}
+	return c, nil
+}
+
+// unavailable reports whether a recent connection failure is still being waited out.
+func (r *Redis) unavailable() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.now().Before(r.downUntil)
+}
+
+// markDown reports Redis unavailable for redisRetryAfter.
+func (r *Redis) markDown() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.downUntil = r.now().Add(redisRetryAfter)
+}
+
+// roundTrip writes a command as an array of bulk strings and reads the reply,
+// within timeout or the context deadline, whichever is earlier.
+func (c *redisConn) roundTrip(ctx context.Context, timeout time.Duration, args []any) (any, error) {
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(c.w, "*%d\r\n", len(args))
+	for _, arg := range args {
+		var b []byte
+		switch v := arg.(type) {
+		case string:
+			b = []byte(v)
+		case []byte:
+			b = v
+		default:
+			return nil, fmt.Errorf("redis: unsupported argument %T", arg)
+		}
+		fmt.Fprintf(c.w, "$%d\r\n", len(b))
+		_, _ = c.w.Write(b)
+		_, _ = c.w.WriteString("\r\n")
+	}
+	if err := c.w.Flush(); err != nil {
+		return nil, err
+	}
+
+	return readReply(c.r)
+}
+
+// readReply reads one RESP reply: simple strings as string, integers as int64,
+// bulk strings as []byte, arrays as []any, nulls as nil and errors as redisError.
+func readReply(r *bufio.Reader) (any, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid bulk length %q", line[1:])
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid array length %q", line[1:])
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		items := make([]any, n)
+		for i := range items {
+			if items[i], err = readReply(r); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("redis: unexpected reply %q", line)
+	}
+}
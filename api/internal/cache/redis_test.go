@@ -0,0 +1,202 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRedis is a RESP server keeping values in memory. It records every
+// command it receives; values never expire.
+type fakeRedis struct {
+	listener net.Listener
+	values   map[string]string
+	commands [][]string
+	password string
+	mu       sync.Mutex
+}
+
+// newFakeRedis starts a fakeRedis on a loopback port, stopped when the test ends.
+func newFakeRedis(t *testing.T, password string) *fakeRedis {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	f := &fakeRedis{listener: listener, values: map[string]string{}, password: password}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f
+}
+
+// serve answers the commands of one connection.
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+	r := bufio.NewReader(conn)
+	authed := f.password == ""
+
+	for {
+		reply, err := readReply(r)
+		if err != nil {
+			return
+		}
+		var args []string
+		for _, arg := range reply.([]any) {
+			args = append(args, string(arg.([]byte)))
+		}
+
+		f.mu.Lock()
+		f.commands = append(f.commands, args)
+		var out string
+		switch {
+		case args[0] == "AUTH":
+			authed = args[len(args)-1] == f.password
+			out = "+OK\r\n"
+			if !authed {
+				out = "-WRONGPASS invalid password\r\n"
+			}
+		case !authed:
+			out = "-NOAUTH Authentication required\r\n"
+		case args[0] == "PING":
+			out = "+PONG\r\n"
+		case args[0] == "SELECT":
+			out = "+OK\r\n"
+		case args[0] == "SET":
+			f.values[args[1]] = args[2]
+			out = "+OK\r\n"
+		case args[0] == "GET":
+			value, ok := f.values[args[1]]
+			out = "$-1\r\n"
+			if ok {
+				out = fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+			}
+		default:
+			out = "-ERR unknown command\r\n"
+		}
+		f.mu.Unlock()
+
+		if _, err := conn.Write([]byte(out)); err != nil {
+			return
+		}
+	}
+}
+
+// received returns the commands received so far.
+func (f *fakeRedis) received() [][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([][]string(nil), f.commands...)
+}
+
+func TestRedis_GetSet(t *testing.T) {
+	server := newFakeRedis(t, "secret")
+	opts, err := ParseRedisURL("redis://:secret@" + server.listener.Addr().String() + "/2")
+	require.NoError(t, err)
+	r := NewRedis(opts)
+	defer func() { _ = r.Close() }()
+	ctx := context.Background()
+
+	_, ok, err := r.Get(ctx, "missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, r.Set(ctx, "key", []byte("value\r\nwith newline"), 1500*time.Microsecond))
+	value, ok, err := r.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "value\r\nwith newline", string(value))
+	require.NoError(t, r.Ping(ctx))
+
+	assert.Equal(t, [][]string{
+		{"AUTH", "secret"},
+		{"SELECT", "2"},
+		{"GET", "missing"},
+		{"SET", "key", "value\r\nwith newline", "PX", "2"},
+		{"GET", "key"},
+		{"PING"},
+	}, server.received(), "the connection is authenticated once and reused")
+	assert.Equal(t, Stats{Backend: "redis", Hits: 1, Misses: 1}, r.Stats())
+}
+
+func TestRedis_ErrorReply(t *testing.T) {
+	server := newFakeRedis(t, "secret")
+	r := NewRedis(RedisOptions{Addr: server.listener.Addr().String(), Password: "wrong"})
+
+	err := r.Ping(context.Background())
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.ErrorContains(t, err, "WRONGPASS")
+}
+
+func TestRedis_Unavailable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	r := NewRedis(RedisOptions{Addr: addr})
+	now := time.Now()
+	r.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, _, err = r.Get(ctx, "key")
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.ErrorContains(t, err, "failed to connect")
+
+	err = r.Set(ctx, "key", []byte("value"), time.Minute)
+	assert.Equal(t, ErrUnavailable, err, "no dial while the failure is waited out")
+
+	now = now.Add(redisRetryAfter)
+	_, _, err = r.Get(ctx, "key")
+	assert.ErrorContains(t, err, "failed to connect", "dialed again after redisRetryAfter")
+	assert.Equal(t, int64(2), r.Stats().Errors)
+}
+
+func TestParseRedisURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    RedisOptions
+		wantTLS bool
+		wantErr bool
+	}{
+		{name: "host only", raw: "redis://cache", want: RedisOptions{Addr: "cache:6379"}},
+		{name: "password and db", raw: "redis://:pw@cache:6380/3", want: RedisOptions{Addr: "cache:6380", Password: "pw", DB: 3}},
+		{name: "username", raw: "redis://atlas:pw@cache", want: RedisOptions{Addr: "cache:6379", Username: "atlas", Password: "pw"}},
+		{name: "tls", raw: "rediss://cache.example.com", want: RedisOptions{Addr: "cache.example.com:6379"}, wantTLS: true},
+		{name: "wrong scheme", raw: "http://cache", wantErr: true},
+		{name: "no host", raw: "redis:///1", wantErr: true},
+		{name: "invalid db", raw: "redis://cache/one", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := ParseRedisURL(tt.raw)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidRedisURL)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTLS, opts.TLS != nil)
+			if tt.wantTLS {
+				assert.Equal(t, strings.Split(tt.want.Addr, ":")[0], opts.TLS.ServerName)
+			}
+			opts.TLS = nil
+			assert.Equal(t, tt.want, opts)
+		})
+	}
+}
@@ -1,34 +1,129 @@
 package config
 
 import (
+	"compress/gzip"
 	"fmt"
+	"net/netip"
+	"net/url"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
+	"github.com/stwalsh4118/atlas/api/internal/cache"
+	"github.com/stwalsh4118/atlas/api/internal/encryption"
+	"github.com/stwalsh4118/atlas/api/internal/location"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/siem"
+	"github.com/stwalsh4118/atlas/api/internal/tracing"
 )
 
 // Config holds all application configuration.
 type Config struct {
-	Server   ServerConfig
-	CORS     CORSConfig
-	Database DatabaseConfig
+	Server        ServerConfig
+	CORS          CORSConfig
+	Database      DatabaseConfig
+	Embed         EmbedConfig
+	Stats         StatsConfig
+	Warming       WarmingConfig
+	Retention     RetentionConfig
+	SavedSearches SavedSearchesConfig
+	Webhooks      WebhooksConfig
+	ResponseCache ResponseCacheConfig
+	Audit         AuditConfig
+	QueryAudit    QueryAuditConfig
+	What3Words    What3WordsConfig
+	Geocoder      GeocoderConfig
+	Comparables   ComparablesConfig
+	Jobs          JobsConfig
+	Tasks         TasksConfig
+	Worker        WorkerConfig
+	Metrics       MetricsConfig
+	Tracing       TracingConfig
+	SIEM          SIEMConfig
+	Compression   CompressionConfig
+	Queries       QueriesConfig
+	Derived       DerivedConfig
+	Encryption    EncryptionConfig
+	Security      SecurityConfig
+	Leader        LeaderConfig
+	Auth          AuthConfig
 }
 
-// ServerConfig holds HTTP server configuration.
+// ServerConfig holds HTTP server configuration. LogLevel is one of
+// logger.Levels; empty keeps the level of Env (see logger.DefaultLevel).
 type ServerConfig struct {
-	Port string
-	Env  string
+	Port     string
+	Env      string
+	LogLevel string
+	// TLSCertFile and TLSKeyFile serve HTTPS on Port; empty serves plain HTTP
+	TLSCertFile string
+	TLSKeyFile  string
+	// HTTPPort binds a second, plain HTTP listener that serves HTTPMode; empty disables it
+	HTTPPort string
+	HTTPMode string
+}
+
+// Modes of the plain HTTP listener of HTTPPort.
+const (
+	// HTTPModeRedirect redirects every request to HTTPS on Port
+	HTTPModeRedirect = "redirect"
+	// HTTPModeHealth serves only the /health endpoints
+	HTTPModeHealth = "health"
+)
+
+// TLS reports whether Port serves HTTPS.
+func (s ServerConfig) TLS() bool {
+	return s.TLSCertFile != ""
 }
 
-// DatabaseConfig holds PostgreSQL connection configuration.
+// Level returns LogLevel, or the default level of Env when it is empty.
+func (s ServerConfig) Level() string {
+	if s.LogLevel == "" {
+		return logger.DefaultLevel(s.Env)
+	}
+	return s.LogLevel
+}
+
+// DatabaseConfig holds PostgreSQL connection configuration: a URL, such as
+// the DATABASE_URL of a managed provider, or the separate Host to Password
+// fields, plus TLS options and extra connection Params (see DSN). StatementTimeout
+// bounds each spatial parcel query so runaway scans give their connection back
+// to the pool; 0 leaves them unbounded. After BreakerThreshold consecutive
+// connection failures the circuit breaker fails requests fast with 503 for
+// BreakerCooldown, then probes with one connection; a threshold of 0 disables it.
+// Queries failing with a transient error run up to RetryAttempts times, waiting
+// a jittered backoff from RetryBaseDelay to RetryMaxDelay, and at most
+// RetryBudget retries per request (0 is unlimited); 1 attempt disables retries.
+// Read-only parcel queries run on the ReplicaURLs in turn, if any, and a replica
+// that cannot be reached is skipped for ReplicaCooldown, its reads failing over
+// to the other replicas and then the primary. QueryExecMode and
+// StatementCacheCapacity tune how pgx prepares statements (see DSN).
 type DatabaseConfig struct {
-	Host     string
-	Port     string
-	Name     string
-	User     string
-	Password string
-	PoolMin  int
-	PoolMax  int
+	URL              string
+	Host             string
+	Port             string
+	Name             string
+	User             string
+	Password         string
+	SSLMode          string
+	SSLRootCert      string
+	Params           string
+	PoolMin          int
+	PoolMax          int
+	StatementTimeout time.Duration
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+	RetryAttempts    int
+	RetryBaseDelay   time.Duration
+	RetryMaxDelay    time.Duration
+	RetryBudget      int
+	ReplicaURLs      []string
+	ReplicaCooldown  time.Duration
+	// QueryExecMode is a pgx default_query_exec_mode; empty keeps pgx's cache_statement
+	QueryExecMode string
+	// StatementCacheCapacity is the prepared statements kept per connection; 0 keeps pgx's 512
+	StatementCacheCapacity int
 }
 
 // CORSConfig holds CORS configuration.
@@ -36,30 +131,436 @@ type CORSConfig struct {
 	Origins []string
 }
 
-// Load reads configuration from environment variables and .env file.
-// It uses viper to read values and provides sensible defaults for development.
-// Priority: .env file values override defaults, but shell environment variables override both.
+// EmbedConfig holds configuration for signed embeddable widget tokens.
+// Widget endpoints are disabled when SigningSecret is empty.
+type EmbedConfig struct {
+	SigningSecret string
+	TokenTTL      time.Duration
+}
+
+// StatsConfig holds configuration for the daily dataset statistics snapshot job.
+// Disable SnapshotEnabled on replicas that should only serve traffic.
+type StatsConfig struct {
+	SnapshotEnabled bool
+}
+
+// WarmingConfig holds configuration for usage tracking and the cache warming job that
+// replays queries for high-traffic areas after a new dataset is activated.
+type WarmingConfig struct {
+	Enabled      bool
+	PollInterval time.Duration
+	Hotspots     int
+	Concurrency  int
+}
+
+// RetentionConfig holds configuration for the pruning job that deletes rows
+// older than each table's retention. A zero retention keeps a table's rows
+// forever. In DryRun mode the job only logs what it would delete.
+type RetentionConfig struct {
+	Enabled  bool
+	DryRun   bool
+	Interval time.Duration
+	// BatchSize bounds the rows deleted per statement, so pruning never holds
+	// long locks on a table that is being written
+	BatchSize      int
+	SecurityEvents time.Duration
+	QueryHotspots  time.Duration
+	IngestionRuns  time.Duration
+	QueryAudit     time.Duration
+	QueryReplay    time.Duration
+	// WebhookDeliveries is the retention of delivered and failed webhook
+	// deliveries; pending ones are never pruned
+	WebhookDeliveries time.Duration
+	// Tasks is the retention of succeeded and failed tasks; pending and running
+	// ones are never pruned
+	Tasks time.Duration
+	// ParcelVersions is the retention of prior parcel versions, by when they
+	// were replaced. Zero by default: history and as_of reach back to the
+	// first load
+	ParcelVersions time.Duration
+}
+
+// SavedSearchesConfig holds configuration for users' saved searches and the
+// alerts job that evaluates them after every new ingestion run. Saved searches
+// are available when user accounts are (JWT_SIGNING_SECRET is set).
+type SavedSearchesConfig struct {
+	Enabled      bool
+	PollInterval time.Duration
+	// MaxPerUser bounds the searches of each user, and with it the work of each
+	// evaluation; 0 allows any number
+	MaxPerUser int
+}
+
+// WebhooksConfig holds configuration for tenants' webhooks and the job that
+// queues their deliveries after every new ingestion run and sends them. Failed
+// attempts are retried after RetryDelay, doubled for each attempt, until a
+// delivery has been attempted MaxAttempts times.
+type WebhooksConfig struct {
+	Enabled      bool
+	PollInterval time.Duration
+	MaxAttempts  int
+	RetryDelay   time.Duration
+}
+
+// ResponseCacheConfig holds configuration for caching the responses of
+// deterministic GET routes; caching is disabled when TTL is zero. Routes holds
+// the TTL of each cached route by route name, TTL unless the route sets its own.
+// RedisURL shares the cache between instances; without it each instance caches
+// up to Size responses in memory. Precision is the decimal places coordinates
+// are rounded to in cache keys.
+type ResponseCacheConfig struct {
+	Routes    map[string]time.Duration
+	RedisURL  string
+	TTL       time.Duration
+	Size      int
+	Precision int
+}
+
+// MaxResponseCachePrecision is the finest coordinate rounding, about 1 cm.
+const MaxResponseCachePrecision = 7
+
+// AuditConfig holds configuration for spatial query audit sampling.
+// SampleRate is the fraction of at-point queries re-checked in Go; 0 disables auditing.
+type AuditConfig struct {
+	SampleRate float64
+}
+
+// QueryAuditConfig holds configuration for the query audit log, which records
+// every parcel query for usage accounting. Entries are queued in memory and
+// written every FlushInterval; when QueueSize entries are waiting, new ones
+// are dropped and counted in the logs. Replay capture rides on the same queue.
+type QueryAuditConfig struct {
+	Enabled       bool
+	QueueSize     int
+	FlushInterval time.Duration
+	// ReplaySampleRatio is the fraction of requests also captured, anonymized,
+	// to the replay log cmd/replay drives against staging; zero disables capture
+	ReplaySampleRatio float64
+}
+
+// What3WordsConfig holds configuration for resolving what3words addresses.
+// what3words lookups are disabled when APIKey is empty; plus codes never need a key.
+type What3WordsConfig struct {
+	APIKey  string
+	BaseURL string
+	Timeout time.Duration
+	// RateLimit caps requests per second to the API; zero is unlimited
+	RateLimit float64
+	// CacheTTL is how long resolved addresses are cached; zero disables caching
+	CacheTTL time.Duration
+}
+
+// GeocoderConfig holds configuration for the external geocoder that serves the
+// geocode endpoint and supplies addresses for parcels without a situs address.
+// Geocoding is disabled when Provider is empty. APIKey is only sent to Pelias,
+// for hosted services; UserAgent identifies the application to Nominatim.
+type GeocoderConfig struct {
+	Provider  string
+	BaseURL   string
+	APIKey    string
+	UserAgent string
+	Timeout   time.Duration
+	// RateLimit caps requests per second to the geocoder; zero is unlimited
+	RateLimit float64
+	// CacheTTL is how long geocoder responses are cached; zero disables caching
+	CacheTTL time.Duration
+}
+
+// ComparablesConfig holds the weights of the similarity score that ranks parcel
+// comparables: the weighted mean of a candidate's similarity to the subject in
+// distance, acreage, land use and year built. A zero weight leaves that input
+// out of the score; when all are zero the inputs are weighted equally.
+type ComparablesConfig struct {
+	WeightDistance  float64
+	WeightAcres     float64
+	WeightLandUse   float64
+	WeightYearBuilt float64
+}
+
+// JobsConfig holds configuration for the background jobs that build downloadable
+// results such as mobile sync bundles and exports. Job endpoints are disabled when
+// Enabled is false. DownloadURLTTL is the lifetime of signed export download URLs.
+type JobsConfig struct {
+	Enabled        bool
+	Workers        int
+	QueueSize      int
+	Timeout        time.Duration
+	Retention      time.Duration
+	DownloadURLTTL time.Duration
+}
+
+// TasksConfig holds configuration for the durable task queue (jobs.Queue), which
+// runs background work such as saved search evaluation on Workers workers of
+// every instance running the scheduled jobs. When Enabled is false that work
+// runs inline, without retries. Idle workers look for due tasks every PollInterval. A task may run for Timeout before another worker may claim
+// it; failed tasks are retried after RetryDelay, doubled for each attempt, until
+// they have been attempted MaxAttempts times.
+type TasksConfig struct {
+	Enabled      bool
+	Workers      int
+	PollInterval time.Duration
+	Timeout      time.Duration
+	MaxAttempts  int
+	RetryDelay   time.Duration
+}
+
+// WorkerConfig holds configuration for cmd/worker, which runs the scheduled jobs
+// (stats snapshots and cache warming) apart from the API. When Enabled, API
+// instances leave those jobs to the worker; Port serves its health checks.
+type WorkerConfig struct {
+	Port    string
+	Enabled bool
+}
+
+// MetricsConfig holds configuration for the Prometheus /metrics endpoint of the
+// API and worker. The endpoint is open, like the health checks, so restrict it
+// at the load balancer in production.
+type MetricsConfig struct {
+	Enabled bool
+}
+
+// TracingConfig holds configuration for exporting OpenTelemetry traces over
+// OTLP/HTTP, using the standard OTEL_* variable names. Tracing is disabled when
+// Endpoint is empty. Headers is the raw OTEL_EXPORTER_OTLP_HEADERS list.
+// SampleRatio is the fraction of new traces recorded.
+type TracingConfig struct {
+	Endpoint    string
+	Headers     string
+	ServiceName string
+	SampleRatio float64
+}
+
+// SIEMConfig holds configuration for shipping audit and security events to a
+// SIEM. Export is disabled when Sink is empty; otherwise it is siem.SinkHTTP,
+// posting batches to URL, or siem.SinkKafka, producing them to Topic through
+// the Kafka REST Proxy at URL. Format is siem.FormatJSON or siem.FormatCEF.
+// Token is sent as a bearer token when set.
+type SIEMConfig struct {
+	Sink          string
+	URL           string
+	Topic         string
+	Format        string
+	Token         string
+	QueueSize     int
+	BatchSize     int
+	FlushInterval time.Duration
+	Timeout       time.Duration
+	// MaxRetryDelay caps the backoff between attempts to send a failed batch
+	MaxRetryDelay time.Duration
+}
+
+// CompressionConfig holds configuration for gzip response compression. Bodies
+// smaller than MinSize bytes are sent uncompressed; Level is a compress/gzip level.
+type CompressionConfig struct {
+	Level   int
+	MinSize int
+	Enabled bool
+}
+
+// EncryptionConfig holds the master keys of the envelope encryption of owner
+// contact columns. MasterKeys lists comma-separated id:base64 pairs of 32-byte
+// keys; ActiveKey wraps new data keys, and the others are kept until the ingest
+// CLI has rewrapped every data key with it. Encryption is disabled when
+// MasterKeys is empty.
+type EncryptionConfig struct {
+	MasterKeys string
+	ActiveKey  string
+}
+
+// SecurityConfig holds the security headers and network restrictions of the
+// HTTP servers. HSTSMaxAge defaults to a year when ENV is production and to 0,
+// which omits the header, otherwise. Admin routes only accept clients in
+// AdminNetworks; nil allows any. X-Forwarded-For is only believed from
+// TrustedProxies, so the client IP cannot be spoofed; without them the
+// connection's address is the client IP.
+type SecurityConfig struct {
+	FrameAncestors string
+	ReferrerPolicy string
+	AdminNetworks  []netip.Prefix
+	TrustedProxies []netip.Prefix
+	HSTSMaxAge     time.Duration
+}
+
+// referrerPolicies are the valid Referrer-Policy values.
+var referrerPolicies = []string{
+	"no-referrer", "no-referrer-when-downgrade", "origin", "origin-when-cross-origin",
+	"same-origin", "strict-origin", "strict-origin-when-cross-origin", "unsafe-url",
+}
+
+// LeaderConfig holds configuration for the leader election that lets only one
+// instance run each scheduled job. InstanceID names this instance in leadership
+// status; empty uses the host name and process ID. Zero intervals use the
+// leader package defaults.
+type LeaderConfig struct {
+	InstanceID    string
+	RenewInterval time.Duration
+	RetryInterval time.Duration
+}
+
+// AuthConfig holds configuration for API key and user authentication. Keys are
+// always checked when presented; APIKeysRequired rejects requests without one
+// except on health checks, docs, sign-in, token-authorized embed routes and
+// user routes. Validated keys are cached for KeyCacheTTL, so a revoked key may
+// work that long on other instances. User accounts are disabled when JWTSecret
+// is empty; issued tokens are valid for JWTTTL.
+//
+// A client IP or credential with LockoutThreshold failed authentications within
+// LockoutWindow is locked out for LockoutDuration, doubling with each repeat up
+// to LockoutMax. A LockoutThreshold of 0 disables lockouts; failures are still
+// recorded for the security dashboard.
+type AuthConfig struct {
+	JWTSecret        string
+	KeyCacheTTL      time.Duration
+	JWTTTL           time.Duration
+	LockoutWindow    time.Duration
+	LockoutDuration  time.Duration
+	LockoutMax       time.Duration
+	LockoutThreshold int
+	APIKeysRequired  bool
+}
+
+// MinEmbedSecretLength is the minimum length of EMBED_SIGNING_SECRET (256 bits of ASCII).
+const MinEmbedSecretLength = 32
+
+// MinRetention is the shortest retention of a pruned table; the admin security
+// dashboard reads the last 24 hours of security events.
+const MinRetention = 24 * time.Hour
+
+// MinHotspotRetention is the shortest retention of query_hotspots, the 7 days
+// of usage the cache warming job ranks cells by.
+const MinHotspotRetention = 7 * 24 * time.Hour
+
+// MinJWTSecretLength is the minimum length of JWT_SIGNING_SECRET (256 bits of ASCII).
+const MinJWTSecretLength = 32
+
+// Load reads configuration from environment variables, the .env file and the
+// first config.yaml, config.yml or config.toml found, as LoadFile does.
 func Load() (*Config, error) {
+	return LoadFile("")
+}
+
+// LoadFile reads configuration from environment variables, the .env file and a
+// YAML or TOML config file of nested settings (see readConfigFile). An empty
+// path looks for config.yaml, config.yml or config.toml next to the .env file
+// and goes without one if there is none. It uses viper to read values and
+// provides sensible defaults for development.
+// Priority: defaults < .env file < config file < shell environment variables.
+func LoadFile(path string) (*Config, error) {
 	v := viper.New()
 
 	// Set defaults for development
 	v.SetDefault("PORT", "8080")
 	v.SetDefault("ENV", "development")
+	v.SetDefault("HTTP_MODE", HTTPModeRedirect)
 	v.SetDefault("DB_HOST", "host.docker.internal")
 	v.SetDefault("DB_PORT", "5432")
 	v.SetDefault("DB_NAME", "atlas")
 	v.SetDefault("DB_USER", "postgres")
 	v.SetDefault("DB_POOL_MIN", 2)
 	v.SetDefault("DB_POOL_MAX", 10)
+	v.SetDefault("DB_STATEMENT_TIMEOUT", "10s")
+	v.SetDefault("DB_BREAKER_THRESHOLD", 5)
+	v.SetDefault("DB_BREAKER_COOLDOWN", "10s")
+	v.SetDefault("DB_RETRY_ATTEMPTS", 3)
+	v.SetDefault("DB_RETRY_BASE_DELAY", "25ms")
+	v.SetDefault("DB_RETRY_MAX_DELAY", "500ms")
+	v.SetDefault("DB_RETRY_BUDGET", 5)
+	v.SetDefault("DB_REPLICA_COOLDOWN", "30s")
+	v.SetDefault("DB_QUERY_EXEC_MODE", "cache_statement")
+	v.SetDefault("DB_STATEMENT_CACHE_CAPACITY", 512)
 	v.SetDefault("CORS_ORIGINS", "http://localhost:3000,http://localhost:3001")
+	v.SetDefault("EMBED_TOKEN_TTL", "24h")
+	v.SetDefault("STATS_SNAPSHOT_ENABLED", true)
+	v.SetDefault("CACHE_WARMING_ENABLED", true)
+	v.SetDefault("CACHE_WARMING_POLL_INTERVAL", "1m")
+	v.SetDefault("CACHE_WARMING_HOTSPOTS", 200)
+	v.SetDefault("CACHE_WARMING_CONCURRENCY", 4)
+	v.SetDefault("RETENTION_ENABLED", true)
+	v.SetDefault("RETENTION_DRY_RUN", false)
+	v.SetDefault("RETENTION_INTERVAL", "24h")
+	v.SetDefault("RETENTION_BATCH_SIZE", 10000)
+	v.SetDefault("RETENTION_SECURITY_EVENTS", "2160h")
+	v.SetDefault("RETENTION_QUERY_HOTSPOTS", "2160h")
+	v.SetDefault("RETENTION_INGESTION_RUNS", "8760h")
+	v.SetDefault("RETENTION_QUERY_AUDIT", "8760h")
+	v.SetDefault("RETENTION_QUERY_REPLAY", "720h")
+	v.SetDefault("RETENTION_WEBHOOK_DELIVERIES", "720h")
+	v.SetDefault("RETENTION_TASKS", "168h")
+	v.SetDefault("RETENTION_PARCEL_VERSIONS", "0")
+	v.SetDefault("SAVED_SEARCH_ALERTS_ENABLED", true)
+	v.SetDefault("SAVED_SEARCH_POLL_INTERVAL", "1m")
+	v.SetDefault("SAVED_SEARCH_MAX_PER_USER", 25)
+	v.SetDefault("WEBHOOKS_ENABLED", true)
+	v.SetDefault("WEBHOOK_POLL_INTERVAL", "15s")
+	v.SetDefault("WEBHOOK_MAX_ATTEMPTS", 8)
+	v.SetDefault("WEBHOOK_RETRY_DELAY", "1m")
+	v.SetDefault("RESPONSE_CACHE_TTL", "5m")
+	v.SetDefault("RESPONSE_CACHE_SIZE", cache.DefaultMemorySize)
+	v.SetDefault("RESPONSE_CACHE_PRECISION", 5)
+	v.SetDefault("RESPONSE_CACHE_ROUTES", "parcels.at_point,parcels.nearby")
+	v.SetDefault("SPATIAL_AUDIT_SAMPLE_RATE", 0.01)
+	v.SetDefault("QUERY_AUDIT_ENABLED", true)
+	v.SetDefault("QUERY_AUDIT_QUEUE_SIZE", 10000)
+	v.SetDefault("QUERY_AUDIT_FLUSH_INTERVAL", "5s")
+	v.SetDefault("QUERY_AUDIT_REPLAY_SAMPLE_RATIO", 0.0)
+	v.SetDefault("WHAT3WORDS_API_URL", "https://api.what3words.com/v3")
+	v.SetDefault("WHAT3WORDS_TIMEOUT", "5s")
+	v.SetDefault("WHAT3WORDS_RATE_LIMIT", 10)
+	v.SetDefault("WHAT3WORDS_CACHE_TTL", "24h")
+	v.SetDefault("GEOCODER_USER_AGENT", "atlas-parcel-api")
+	v.SetDefault("GEOCODER_TIMEOUT", "3s")
+	v.SetDefault("GEOCODER_RATE_LIMIT", 1)
+	v.SetDefault("GEOCODER_CACHE_TTL", "24h")
+	v.SetDefault("COMPARABLES_WEIGHT_DISTANCE", 1)
+	v.SetDefault("COMPARABLES_WEIGHT_ACRES", 2)
+	v.SetDefault("COMPARABLES_WEIGHT_LAND_USE", 2)
+	v.SetDefault("COMPARABLES_WEIGHT_YEAR_BUILT", 1)
+	v.SetDefault("JOBS_ENABLED", true)
+	v.SetDefault("JOBS_WORKERS", 2)
+	v.SetDefault("JOBS_QUEUE_SIZE", 20)
+	v.SetDefault("JOBS_TIMEOUT", "5m")
+	v.SetDefault("JOBS_RETENTION", "1h")
+	v.SetDefault("JOBS_DOWNLOAD_URL_TTL", "15m")
+	v.SetDefault("TASKS_ENABLED", true)
+	v.SetDefault("TASKS_WORKERS", 2)
+	v.SetDefault("TASKS_POLL_INTERVAL", "5s")
+	v.SetDefault("TASKS_TIMEOUT", "15m")
+	v.SetDefault("TASKS_MAX_ATTEMPTS", 5)
+	v.SetDefault("TASKS_RETRY_DELAY", "30s")
+	v.SetDefault("WORKER_PORT", "8081")
+	v.SetDefault("WORKER_ENABLED", false)
+	v.SetDefault("METRICS_ENABLED", true)
+	v.SetDefault("COMPRESSION_ENABLED", true)
+	v.SetDefault("COMPRESSION_LEVEL", 6)
+	v.SetDefault("COMPRESSION_MIN_SIZE", 1024)
+	v.SetDefault("OTEL_SERVICE_NAME", "atlas-api")
+	v.SetDefault("OTEL_TRACES_SAMPLER_ARG", 1.0)
+	v.SetDefault("SIEM_FORMAT", siem.FormatJSON)
+	v.SetDefault("SIEM_QUEUE_SIZE", siem.DefaultQueueSize)
+	v.SetDefault("SIEM_BATCH_SIZE", siem.DefaultBatchSize)
+	v.SetDefault("SIEM_FLUSH_INTERVAL", "5s")
+	v.SetDefault("SIEM_TIMEOUT", "10s")
+	v.SetDefault("SIEM_MAX_RETRY_DELAY", "5m")
+	v.SetDefault("ANALYST_QUERY_ROW_LIMIT", 1000)
+	v.SetDefault("ANALYST_QUERY_TIMEOUT", "30s")
+	v.SetDefault("LEADER_RENEW_INTERVAL", "10s")
+	v.SetDefault("LEADER_RETRY_INTERVAL", "15s")
+	v.SetDefault("API_KEYS_REQUIRED", false)
+	v.SetDefault("API_KEY_CACHE_TTL", "30s")
+	v.SetDefault("JWT_TTL", "24h")
+	v.SetDefault("AUTH_LOCKOUT_THRESHOLD", 5)
+	v.SetDefault("AUTH_LOCKOUT_WINDOW", "15m")
+	v.SetDefault("AUTH_LOCKOUT_DURATION", "1m")
+	v.SetDefault("AUTH_LOCKOUT_MAX", "1h")
+	v.SetDefault("SECURITY_FRAME_ANCESTORS", "'none'")
+	v.SetDefault("SECURITY_REFERRER_POLICY", "no-referrer")
 
 	// Configure viper to read from .env file
 	v.SetConfigName(".env")
 	v.SetConfigType("env")
-	v.AddConfigPath(".")      // Look in current directory
-	v.AddConfigPath("./api")  // Look in api directory (for running from root)
-	v.AddConfigPath("../")    // Look in parent directory (for running from api/cmd/server)
-	v.AddConfigPath("../../") // Look two levels up
+	for _, dir := range configPaths {
+		v.AddConfigPath(dir)
+	}
 
 	// Try to read .env file (don't fail if it doesn't exist)
 	if err := v.ReadInConfig(); err != nil {
@@ -70,27 +571,259 @@ func Load() (*Config, error) {
 		// Config file not found; using defaults and environment variables only
 	}
 
+	// Nested settings of the config file override the .env file
+	if path == "" {
+		path = findConfigFile()
+	}
+	if path != "" {
+		settings, err := readConfigFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading config file %s: %w", path, err)
+		}
+		if err := v.MergeConfigMap(settings); err != nil {
+			return nil, fmt.Errorf("error merging config file %s: %w", path, err)
+		}
+	}
+
 	// Bind environment variables (these override .env file values)
 	v.AutomaticEnv()
 
+	// HSTS is only safe to default on where the API is always served over HTTPS
+	if v.GetString("ENV") == "production" {
+		v.SetDefault("SECURITY_HSTS_MAX_AGE", "8760h")
+	} else {
+		v.SetDefault("SECURITY_HSTS_MAX_AGE", "0s")
+	}
+	adminNetworks, err := parseNetworks(v.GetString("ADMIN_ALLOWED_NETWORKS"))
+	if err != nil {
+		return nil, fmt.Errorf("ADMIN_ALLOWED_NETWORKS: %w", err)
+	}
+	trustedProxies, err := parseNetworks(v.GetString("TRUSTED_PROXIES"))
+	if err != nil {
+		return nil, fmt.Errorf("TRUSTED_PROXIES: %w", err)
+	}
+	cacheRoutes, err := parseRouteTTLs(v.GetString("RESPONSE_CACHE_ROUTES"), v.GetDuration("RESPONSE_CACHE_TTL"))
+	if err != nil {
+		return nil, fmt.Errorf("RESPONSE_CACHE_ROUTES: %w", err)
+	}
+
 	// Build configuration
 	cfg := &Config{
 		Server: ServerConfig{
-			Port: v.GetString("PORT"),
-			Env:  v.GetString("ENV"),
+			Port:     v.GetString("PORT"),
+			Env:      v.GetString("ENV"),
+			LogLevel: strings.ToLower(v.GetString("LOG_LEVEL")),
+
+			TLSCertFile: v.GetString("TLS_CERT_FILE"),
+			TLSKeyFile:  v.GetString("TLS_KEY_FILE"),
+			HTTPPort:    v.GetString("HTTP_PORT"),
+			HTTPMode:    v.GetString("HTTP_MODE"),
 		},
 		Database: DatabaseConfig{
-			Host:     v.GetString("DB_HOST"),
-			Port:     v.GetString("DB_PORT"),
-			Name:     v.GetString("DB_NAME"),
-			User:     v.GetString("DB_USER"),
-			Password: v.GetString("DB_PASSWORD"),
-			PoolMin:  v.GetInt("DB_POOL_MIN"),
-			PoolMax:  v.GetInt("DB_POOL_MAX"),
+			URL:              v.GetString("DATABASE_URL"),
+			Host:             v.GetString("DB_HOST"),
+			Port:             v.GetString("DB_PORT"),
+			Name:             v.GetString("DB_NAME"),
+			User:             v.GetString("DB_USER"),
+			Password:         v.GetString("DB_PASSWORD"),
+			SSLMode:          v.GetString("DB_SSL_MODE"),
+			SSLRootCert:      v.GetString("DB_SSL_ROOT_CERT"),
+			Params:           v.GetString("DB_PARAMS"),
+			PoolMin:          v.GetInt("DB_POOL_MIN"),
+			PoolMax:          v.GetInt("DB_POOL_MAX"),
+			StatementTimeout: v.GetDuration("DB_STATEMENT_TIMEOUT"),
+			BreakerThreshold: v.GetInt("DB_BREAKER_THRESHOLD"),
+			BreakerCooldown:  v.GetDuration("DB_BREAKER_COOLDOWN"),
+			RetryAttempts:    v.GetInt("DB_RETRY_ATTEMPTS"),
+			RetryBaseDelay:   v.GetDuration("DB_RETRY_BASE_DELAY"),
+			RetryMaxDelay:    v.GetDuration("DB_RETRY_MAX_DELAY"),
+			RetryBudget:      v.GetInt("DB_RETRY_BUDGET"),
+			// Space-separated, as multi-host URLs contain commas
+			ReplicaURLs:     strings.Fields(v.GetString("DB_REPLICA_URLS")),
+			ReplicaCooldown: v.GetDuration("DB_REPLICA_COOLDOWN"),
+
+			QueryExecMode:          v.GetString("DB_QUERY_EXEC_MODE"),
+			StatementCacheCapacity: v.GetInt("DB_STATEMENT_CACHE_CAPACITY"),
 		},
 		CORS: CORSConfig{
 			Origins: parseOrigins(v.GetString("CORS_ORIGINS")),
 		},
+		Embed: EmbedConfig{
+			SigningSecret: v.GetString("EMBED_SIGNING_SECRET"),
+			TokenTTL:      v.GetDuration("EMBED_TOKEN_TTL"),
+		},
+		Stats: StatsConfig{
+			SnapshotEnabled: v.GetBool("STATS_SNAPSHOT_ENABLED"),
+		},
+		Warming: WarmingConfig{
+			Enabled:      v.GetBool("CACHE_WARMING_ENABLED"),
+			PollInterval: v.GetDuration("CACHE_WARMING_POLL_INTERVAL"),
+			Hotspots:     v.GetInt("CACHE_WARMING_HOTSPOTS"),
+			Concurrency:  v.GetInt("CACHE_WARMING_CONCURRENCY"),
+		},
+		Retention: RetentionConfig{
+			Enabled:        v.GetBool("RETENTION_ENABLED"),
+			DryRun:         v.GetBool("RETENTION_DRY_RUN"),
+			Interval:       v.GetDuration("RETENTION_INTERVAL"),
+			BatchSize:      v.GetInt("RETENTION_BATCH_SIZE"),
+			SecurityEvents: v.GetDuration("RETENTION_SECURITY_EVENTS"),
+			QueryHotspots:  v.GetDuration("RETENTION_QUERY_HOTSPOTS"),
+			IngestionRuns:  v.GetDuration("RETENTION_INGESTION_RUNS"),
+			QueryAudit:     v.GetDuration("RETENTION_QUERY_AUDIT"),
+			QueryReplay:    v.GetDuration("RETENTION_QUERY_REPLAY"),
+
+			WebhookDeliveries: v.GetDuration("RETENTION_WEBHOOK_DELIVERIES"),
+			Tasks:             v.GetDuration("RETENTION_TASKS"),
+			ParcelVersions:    v.GetDuration("RETENTION_PARCEL_VERSIONS"),
+		},
+		SavedSearches: SavedSearchesConfig{
+			Enabled:      v.GetBool("SAVED_SEARCH_ALERTS_ENABLED"),
+			PollInterval: v.GetDuration("SAVED_SEARCH_POLL_INTERVAL"),
+			MaxPerUser:   v.GetInt("SAVED_SEARCH_MAX_PER_USER"),
+		},
+		Webhooks: WebhooksConfig{
+			Enabled:      v.GetBool("WEBHOOKS_ENABLED"),
+			PollInterval: v.GetDuration("WEBHOOK_POLL_INTERVAL"),
+			MaxAttempts:  v.GetInt("WEBHOOK_MAX_ATTEMPTS"),
+			RetryDelay:   v.GetDuration("WEBHOOK_RETRY_DELAY"),
+		},
+		ResponseCache: ResponseCacheConfig{
+			RedisURL:  v.GetString("RESPONSE_CACHE_REDIS_URL"),
+			TTL:       v.GetDuration("RESPONSE_CACHE_TTL"),
+			Size:      v.GetInt("RESPONSE_CACHE_SIZE"),
+			Precision: v.GetInt("RESPONSE_CACHE_PRECISION"),
+			Routes:    cacheRoutes,
+		},
+		Audit: AuditConfig{
+			SampleRate: v.GetFloat64("SPATIAL_AUDIT_SAMPLE_RATE"),
+		},
+		QueryAudit: QueryAuditConfig{
+			Enabled:           v.GetBool("QUERY_AUDIT_ENABLED"),
+			QueueSize:         v.GetInt("QUERY_AUDIT_QUEUE_SIZE"),
+			FlushInterval:     v.GetDuration("QUERY_AUDIT_FLUSH_INTERVAL"),
+			ReplaySampleRatio: v.GetFloat64("QUERY_AUDIT_REPLAY_SAMPLE_RATIO"),
+		},
+		What3Words: What3WordsConfig{
+			APIKey:    v.GetString("WHAT3WORDS_API_KEY"),
+			BaseURL:   v.GetString("WHAT3WORDS_API_URL"),
+			Timeout:   v.GetDuration("WHAT3WORDS_TIMEOUT"),
+			RateLimit: v.GetFloat64("WHAT3WORDS_RATE_LIMIT"),
+			CacheTTL:  v.GetDuration("WHAT3WORDS_CACHE_TTL"),
+		},
+		Geocoder: GeocoderConfig{
+			Provider:  strings.ToLower(strings.TrimSpace(v.GetString("GEOCODER_PROVIDER"))),
+			BaseURL:   v.GetString("GEOCODER_URL"),
+			APIKey:    v.GetString("GEOCODER_API_KEY"),
+			UserAgent: v.GetString("GEOCODER_USER_AGENT"),
+			Timeout:   v.GetDuration("GEOCODER_TIMEOUT"),
+			RateLimit: v.GetFloat64("GEOCODER_RATE_LIMIT"),
+			CacheTTL:  v.GetDuration("GEOCODER_CACHE_TTL"),
+		},
+		Comparables: ComparablesConfig{
+			WeightDistance:  v.GetFloat64("COMPARABLES_WEIGHT_DISTANCE"),
+			WeightAcres:     v.GetFloat64("COMPARABLES_WEIGHT_ACRES"),
+			WeightLandUse:   v.GetFloat64("COMPARABLES_WEIGHT_LAND_USE"),
+			WeightYearBuilt: v.GetFloat64("COMPARABLES_WEIGHT_YEAR_BUILT"),
+		},
+		Jobs: JobsConfig{
+			Enabled:        v.GetBool("JOBS_ENABLED"),
+			Workers:        v.GetInt("JOBS_WORKERS"),
+			QueueSize:      v.GetInt("JOBS_QUEUE_SIZE"),
+			Timeout:        v.GetDuration("JOBS_TIMEOUT"),
+			Retention:      v.GetDuration("JOBS_RETENTION"),
+			DownloadURLTTL: v.GetDuration("JOBS_DOWNLOAD_URL_TTL"),
+		},
+		Tasks: TasksConfig{
+			Enabled:      v.GetBool("TASKS_ENABLED"),
+			Workers:      v.GetInt("TASKS_WORKERS"),
+			PollInterval: v.GetDuration("TASKS_POLL_INTERVAL"),
+			Timeout:      v.GetDuration("TASKS_TIMEOUT"),
+			MaxAttempts:  v.GetInt("TASKS_MAX_ATTEMPTS"),
+			RetryDelay:   v.GetDuration("TASKS_RETRY_DELAY"),
+		},
+		Worker: WorkerConfig{
+			Port:    v.GetString("WORKER_PORT"),
+			Enabled: v.GetBool("WORKER_ENABLED"),
+		},
+		Metrics: MetricsConfig{
+			Enabled: v.GetBool("METRICS_ENABLED"),
+		},
+		Compression: CompressionConfig{
+			Enabled: v.GetBool("COMPRESSION_ENABLED"),
+			Level:   v.GetInt("COMPRESSION_LEVEL"),
+			MinSize: v.GetInt("COMPRESSION_MIN_SIZE"),
+		},
+		Tracing: TracingConfig{
+			Endpoint:    strings.TrimSuffix(v.GetString("OTEL_EXPORTER_OTLP_ENDPOINT"), "/"),
+			Headers:     v.GetString("OTEL_EXPORTER_OTLP_HEADERS"),
+			ServiceName: v.GetString("OTEL_SERVICE_NAME"),
+			SampleRatio: v.GetFloat64("OTEL_TRACES_SAMPLER_ARG"),
+		},
+		SIEM: SIEMConfig{
+			Sink:          strings.ToLower(v.GetString("SIEM_SINK")),
+			URL:           strings.TrimSuffix(v.GetString("SIEM_URL"), "/"),
+			Topic:         v.GetString("SIEM_KAFKA_TOPIC"),
+			Format:        strings.ToLower(v.GetString("SIEM_FORMAT")),
+			Token:         v.GetString("SIEM_TOKEN"),
+			QueueSize:     v.GetInt("SIEM_QUEUE_SIZE"),
+			BatchSize:     v.GetInt("SIEM_BATCH_SIZE"),
+			FlushInterval: v.GetDuration("SIEM_FLUSH_INTERVAL"),
+			Timeout:       v.GetDuration("SIEM_TIMEOUT"),
+			MaxRetryDelay: v.GetDuration("SIEM_MAX_RETRY_DELAY"),
+		},
+		Queries: QueriesConfig{
+			File:       v.GetString("ANALYST_QUERIES_FILE"),
+			ReplicaURL: v.GetString("ANALYST_DB_URL"),
+			RowLimit:   v.GetInt("ANALYST_QUERY_ROW_LIMIT"),
+			Timeout:    v.GetDuration("ANALYST_QUERY_TIMEOUT"),
+		},
+		Derived: DerivedConfig{
+			File: v.GetString("DERIVED_ATTRIBUTES_FILE"),
+		},
+		Encryption: EncryptionConfig{
+			MasterKeys: v.GetString("ENCRYPTION_MASTER_KEYS"),
+			ActiveKey:  v.GetString("ENCRYPTION_ACTIVE_KEY"),
+		},
+		Security: SecurityConfig{
+			HSTSMaxAge:     v.GetDuration("SECURITY_HSTS_MAX_AGE"),
+			FrameAncestors: v.GetString("SECURITY_FRAME_ANCESTORS"),
+			ReferrerPolicy: v.GetString("SECURITY_REFERRER_POLICY"),
+			AdminNetworks:  adminNetworks,
+			TrustedProxies: trustedProxies,
+		},
+		Leader: LeaderConfig{
+			InstanceID:    v.GetString("INSTANCE_ID"),
+			RenewInterval: v.GetDuration("LEADER_RENEW_INTERVAL"),
+			RetryInterval: v.GetDuration("LEADER_RETRY_INTERVAL"),
+		},
+		Auth: AuthConfig{
+			APIKeysRequired:  v.GetBool("API_KEYS_REQUIRED"),
+			KeyCacheTTL:      v.GetDuration("API_KEY_CACHE_TTL"),
+			JWTSecret:        v.GetString("JWT_SIGNING_SECRET"),
+			JWTTTL:           v.GetDuration("JWT_TTL"),
+			LockoutThreshold: v.GetInt("AUTH_LOCKOUT_THRESHOLD"),
+			LockoutWindow:    v.GetDuration("AUTH_LOCKOUT_WINDOW"),
+			LockoutDuration:  v.GetDuration("AUTH_LOCKOUT_DURATION"),
+			LockoutMax:       v.GetDuration("AUTH_LOCKOUT_MAX"),
+		},
+	}
+
+	// Analyst query templates are read once at startup
+	if cfg.Queries.File != "" {
+		templates, err := LoadQueryTemplates(cfg.Queries.File)
+		if err != nil {
+			return nil, fmt.Errorf("ANALYST_QUERIES_FILE: %w", err)
+		}
+		cfg.Queries.Templates = templates
+	}
+
+	// Derived attributes are read once at startup, by the server and cmd/ingest alike
+	if cfg.Derived.File != "" {
+		attributes, err := LoadDerivedAttributes(cfg.Derived.File)
+		if err != nil {
+			return nil, fmt.Errorf("DERIVED_ATTRIBUTES_FILE: %w", err)
+		}
+		cfg.Derived.Attributes = attributes
 	}
 
 	// Validate required fields
@@ -107,22 +840,27 @@ func (c *Config) Validate() error {
 	if c.Server.Port == "" {
 		return fmt.Errorf("PORT is required")
 	}
-
-	// Validate database config
-	if c.Database.Host == "" {
-		return fmt.Errorf("DB_HOST is required")
-	}
-	if c.Database.Port == "" {
-		return fmt.Errorf("DB_PORT is required")
+	if c.Server.LogLevel != "" && !slices.Contains(logger.Levels, c.Server.LogLevel) {
+		return fmt.Errorf("LOG_LEVEL must be one of %s", strings.Join(logger.Levels, ", "))
 	}
-	if c.Database.Name == "" {
-		return fmt.Errorf("DB_NAME is required")
+	if (c.Server.TLSCertFile == "") != (c.Server.TLSKeyFile == "") {
+		return fmt.Errorf("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
 	}
-	if c.Database.User == "" {
-		return fmt.Errorf("DB_USER is required")
+	if c.Server.HTTPPort != "" {
+		if c.Server.HTTPPort == c.Server.Port {
+			return fmt.Errorf("HTTP_PORT must differ from PORT")
+		}
+		if c.Server.HTTPMode != HTTPModeRedirect && c.Server.HTTPMode != HTTPModeHealth {
+			return fmt.Errorf("HTTP_MODE must be %q or %q", HTTPModeRedirect, HTTPModeHealth)
+		}
+		if c.Server.HTTPMode == HTTPModeRedirect && !c.Server.TLS() {
+			return fmt.Errorf("HTTP_MODE %q requires TLS_CERT_FILE and TLS_KEY_FILE", HTTPModeRedirect)
+		}
 	}
-	if c.Database.Password == "" {
-		return fmt.Errorf("DB_PASSWORD is required")
+
+	// Validate database config
+	if err := c.Database.validate(); err != nil {
+		return err
 	}
 	if c.Database.PoolMin < 0 {
 		return fmt.Errorf("DB_POOL_MIN must be non-negative")
@@ -133,15 +871,446 @@ func (c *Config) Validate() error {
 	if c.Database.PoolMin > c.Database.PoolMax {
 		return fmt.Errorf("DB_POOL_MIN must be less than or equal to DB_POOL_MAX")
 	}
+	if c.Database.StatementTimeout < 0 {
+		return fmt.Errorf("DB_STATEMENT_TIMEOUT must not be negative")
+	}
+	if c.Database.BreakerThreshold < 0 {
+		return fmt.Errorf("DB_BREAKER_THRESHOLD must not be negative")
+	}
+	if c.Database.BreakerThreshold > 0 && c.Database.BreakerCooldown <= 0 {
+		return fmt.Errorf("DB_BREAKER_COOLDOWN must be positive")
+	}
+	if c.Database.RetryAttempts < 0 {
+		return fmt.Errorf("DB_RETRY_ATTEMPTS must not be negative")
+	}
+	if c.Database.RetryAttempts > 1 {
+		if c.Database.RetryBaseDelay <= 0 {
+			return fmt.Errorf("DB_RETRY_BASE_DELAY must be positive")
+		}
+		if c.Database.RetryMaxDelay < c.Database.RetryBaseDelay {
+			return fmt.Errorf("DB_RETRY_MAX_DELAY must be at least DB_RETRY_BASE_DELAY")
+		}
+	}
+	if c.Database.RetryBudget < 0 {
+		return fmt.Errorf("DB_RETRY_BUDGET must not be negative")
+	}
+	if len(c.Database.ReplicaURLs) > 0 && c.Database.ReplicaCooldown <= 0 {
+		return fmt.Errorf("DB_REPLICA_COOLDOWN must be positive")
+	}
 
 	// Validate CORS config
 	if len(c.CORS.Origins) == 0 {
 		return fmt.Errorf("CORS_ORIGINS is required")
 	}
 
+	// Validate embed config (only when widgets are enabled)
+	if c.Embed.SigningSecret != "" {
+		if len(c.Embed.SigningSecret) < MinEmbedSecretLength {
+			return fmt.Errorf("EMBED_SIGNING_SECRET must be at least %d characters", MinEmbedSecretLength)
+		}
+		if c.Embed.TokenTTL <= 0 {
+			return fmt.Errorf("EMBED_TOKEN_TTL must be a positive duration")
+		}
+	}
+
+	// Validate cache warming config (only when the job is enabled)
+	if c.Warming.Enabled {
+		if c.Warming.PollInterval <= 0 {
+			return fmt.Errorf("CACHE_WARMING_POLL_INTERVAL must be a positive duration")
+		}
+		if c.Warming.Hotspots < 1 {
+			return fmt.Errorf("CACHE_WARMING_HOTSPOTS must be at least 1")
+		}
+		if c.Warming.Concurrency < 1 {
+			return fmt.Errorf("CACHE_WARMING_CONCURRENCY must be at least 1")
+		}
+		if c.Warming.Concurrency >= c.Database.PoolMax {
+			return fmt.Errorf("CACHE_WARMING_CONCURRENCY must be less than DB_POOL_MAX")
+		}
+	}
+
+	// Validate retention config (only when the pruning job is enabled)
+	if c.Retention.Enabled {
+		if c.Retention.Interval <= 0 {
+			return fmt.Errorf("RETENTION_INTERVAL must be a positive duration")
+		}
+		if c.Retention.BatchSize < 1 {
+			return fmt.Errorf("RETENTION_BATCH_SIZE must be at least 1")
+		}
+		retentions := []struct {
+			name  string
+			value time.Duration
+			min   time.Duration
+		}{
+			{"RETENTION_SECURITY_EVENTS", c.Retention.SecurityEvents, MinRetention},
+			{"RETENTION_QUERY_HOTSPOTS", c.Retention.QueryHotspots, MinHotspotRetention},
+			{"RETENTION_INGESTION_RUNS", c.Retention.IngestionRuns, MinRetention},
+			{"RETENTION_QUERY_AUDIT", c.Retention.QueryAudit, MinRetention},
+			{"RETENTION_QUERY_REPLAY", c.Retention.QueryReplay, MinRetention},
+			{"RETENTION_WEBHOOK_DELIVERIES", c.Retention.WebhookDeliveries, MinRetention},
+			{"RETENTION_TASKS", c.Retention.Tasks, MinRetention},
+			{"RETENTION_PARCEL_VERSIONS", c.Retention.ParcelVersions, MinRetention},
+		}
+		for _, r := range retentions {
+			if r.value != 0 && r.value < r.min {
+				return fmt.Errorf("%s must be 0 (keep forever) or at least %s", r.name, r.min)
+			}
+		}
+	}
+
+	// Validate saved search config (the poll interval only when the alerts job is enabled)
+	if c.SavedSearches.MaxPerUser < 0 {
+		return fmt.Errorf("SAVED_SEARCH_MAX_PER_USER must not be negative")
+	}
+	if c.SavedSearches.Enabled && c.SavedSearches.PollInterval <= 0 {
+		return fmt.Errorf("SAVED_SEARCH_POLL_INTERVAL must be a positive duration")
+	}
+
+	// Validate webhook config (only when webhooks are enabled)
+	if c.Webhooks.Enabled {
+		if c.Webhooks.PollInterval <= 0 {
+			return fmt.Errorf("WEBHOOK_POLL_INTERVAL must be a positive duration")
+		}
+		if c.Webhooks.MaxAttempts < 1 {
+			return fmt.Errorf("WEBHOOK_MAX_ATTEMPTS must be at least 1")
+		}
+		if c.Webhooks.RetryDelay <= 0 {
+			return fmt.Errorf("WEBHOOK_RETRY_DELAY must be a positive duration")
+		}
+	}
+
+	// Validate response cache config (only when caching is enabled)
+	if c.ResponseCache.TTL < 0 {
+		return fmt.Errorf("RESPONSE_CACHE_TTL must not be negative")
+	}
+	if c.ResponseCache.TTL > 0 {
+		if c.ResponseCache.Size < 1 {
+			return fmt.Errorf("RESPONSE_CACHE_SIZE must be at least 1")
+		}
+		if c.ResponseCache.Precision < 0 || c.ResponseCache.Precision > MaxResponseCachePrecision {
+			return fmt.Errorf("RESPONSE_CACHE_PRECISION must be between 0 and %d", MaxResponseCachePrecision)
+		}
+		if c.ResponseCache.RedisURL != "" {
+			if _, err := cache.ParseRedisURL(c.ResponseCache.RedisURL); err != nil {
+				return fmt.Errorf("RESPONSE_CACHE_REDIS_URL: %w", err)
+			}
+		}
+		for name, ttl := range c.ResponseCache.Routes {
+			if ttl <= 0 {
+				return fmt.Errorf("RESPONSE_CACHE_ROUTES: TTL of %s must be positive", name)
+			}
+		}
+	}
+
+	// Validate spatial audit config
+	if c.Audit.SampleRate < 0 || c.Audit.SampleRate > 1 {
+		return fmt.Errorf("SPATIAL_AUDIT_SAMPLE_RATE must be between 0 and 1")
+	}
+
+	// Validate query audit config (only when the audit log is enabled)
+	if c.QueryAudit.Enabled {
+		if c.QueryAudit.QueueSize < 1 {
+			return fmt.Errorf("QUERY_AUDIT_QUEUE_SIZE must be at least 1")
+		}
+		if c.QueryAudit.FlushInterval <= 0 {
+			return fmt.Errorf("QUERY_AUDIT_FLUSH_INTERVAL must be a positive duration")
+		}
+		if c.QueryAudit.ReplaySampleRatio < 0 || c.QueryAudit.ReplaySampleRatio > 1 {
+			return fmt.Errorf("QUERY_AUDIT_REPLAY_SAMPLE_RATIO must be between 0 and 1")
+		}
+	}
+
+	// Validate what3words config (only when lookups are enabled)
+	if c.What3Words.APIKey != "" {
+		if c.What3Words.BaseURL == "" {
+			return fmt.Errorf("WHAT3WORDS_API_URL is required when WHAT3WORDS_API_KEY is set")
+		}
+		if c.What3Words.Timeout <= 0 {
+			return fmt.Errorf("WHAT3WORDS_TIMEOUT must be a positive duration")
+		}
+		if c.What3Words.RateLimit < 0 {
+			return fmt.Errorf("WHAT3WORDS_RATE_LIMIT must not be negative")
+		}
+		if c.What3Words.CacheTTL < 0 {
+			return fmt.Errorf("WHAT3WORDS_CACHE_TTL must not be negative")
+		}
+	}
+
+	// Validate geocoder config (only when geocoding is enabled)
+	if c.Geocoder.Provider != "" {
+		if c.Geocoder.Provider != location.GeocoderNominatim && c.Geocoder.Provider != location.GeocoderPelias {
+			return fmt.Errorf("GEOCODER_PROVIDER must be %q or %q", location.GeocoderNominatim, location.GeocoderPelias)
+		}
+		u, err := url.Parse(c.Geocoder.BaseURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("GEOCODER_URL must be an http or https URL")
+		}
+		if c.Geocoder.Provider == location.GeocoderNominatim && strings.TrimSpace(c.Geocoder.UserAgent) == "" {
+			return fmt.Errorf("GEOCODER_USER_AGENT is required when GEOCODER_PROVIDER is nominatim")
+		}
+		if c.Geocoder.Timeout <= 0 {
+			return fmt.Errorf("GEOCODER_TIMEOUT must be a positive duration")
+		}
+		if c.Geocoder.RateLimit < 0 {
+			return fmt.Errorf("GEOCODER_RATE_LIMIT must not be negative")
+		}
+		if c.Geocoder.CacheTTL < 0 {
+			return fmt.Errorf("GEOCODER_CACHE_TTL must not be negative")
+		}
+	}
+
+	// Validate comparables config
+	if c.Comparables.WeightDistance < 0 || c.Comparables.WeightAcres < 0 ||
+		c.Comparables.WeightLandUse < 0 || c.Comparables.WeightYearBuilt < 0 {
+		return fmt.Errorf("COMPARABLES_WEIGHT_DISTANCE, _ACRES, _LAND_USE and _YEAR_BUILT must not be negative")
+	}
+
+	// Validate background jobs config (only when jobs are enabled)
+	if c.Jobs.Enabled {
+		if c.Jobs.Workers < 1 {
+			return fmt.Errorf("JOBS_WORKERS must be at least 1")
+		}
+		if c.Jobs.Workers >= c.Database.PoolMax {
+			return fmt.Errorf("JOBS_WORKERS must be less than DB_POOL_MAX")
+		}
+		if c.Jobs.QueueSize < 1 {
+			return fmt.Errorf("JOBS_QUEUE_SIZE must be at least 1")
+		}
+		if c.Jobs.Timeout <= 0 {
+			return fmt.Errorf("JOBS_TIMEOUT must be a positive duration")
+		}
+		if c.Jobs.Retention <= 0 {
+			return fmt.Errorf("JOBS_RETENTION must be a positive duration")
+		}
+		if c.Jobs.DownloadURLTTL <= 0 {
+			return fmt.Errorf("JOBS_DOWNLOAD_URL_TTL must be a positive duration")
+		}
+	}
+
+	// Validate task queue config (only when the queue is enabled)
+	if c.Tasks.Enabled {
+		if c.Tasks.Workers < 1 {
+			return fmt.Errorf("TASKS_WORKERS must be at least 1")
+		}
+		if c.Tasks.Workers >= c.Database.PoolMax {
+			return fmt.Errorf("TASKS_WORKERS must be less than DB_POOL_MAX")
+		}
+		if c.Tasks.PollInterval <= 0 {
+			return fmt.Errorf("TASKS_POLL_INTERVAL must be a positive duration")
+		}
+		if c.Tasks.Timeout <= 0 {
+			return fmt.Errorf("TASKS_TIMEOUT must be a positive duration")
+		}
+		if c.Tasks.MaxAttempts < 1 {
+			return fmt.Errorf("TASKS_MAX_ATTEMPTS must be at least 1")
+		}
+		if c.Tasks.RetryDelay <= 0 {
+			return fmt.Errorf("TASKS_RETRY_DELAY must be a positive duration")
+		}
+	}
+
+	// Validate worker config (only when a worker runs the scheduled jobs)
+	if c.Worker.Enabled {
+		if c.Worker.Port == "" {
+			return fmt.Errorf("WORKER_PORT is required when WORKER_ENABLED is true")
+		}
+		if c.Worker.Port == c.Server.Port {
+			return fmt.Errorf("WORKER_PORT must differ from PORT")
+		}
+	}
+
+	// Validate compression config (only when enabled)
+	if c.Compression.Enabled {
+		if c.Compression.Level < gzip.BestSpeed || c.Compression.Level > gzip.BestCompression {
+			return fmt.Errorf("COMPRESSION_LEVEL must be between %d and %d", gzip.BestSpeed, gzip.BestCompression)
+		}
+		if c.Compression.MinSize < 0 {
+			return fmt.Errorf("COMPRESSION_MIN_SIZE must not be negative")
+		}
+	}
+
+	// Validate tracing config (only when an OTLP endpoint is set)
+	if c.Tracing.Endpoint != "" {
+		u, err := url.Parse(c.Tracing.Endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("OTEL_EXPORTER_OTLP_ENDPOINT must be an http or https URL")
+		}
+		if _, err := tracing.ParseHeaders(c.Tracing.Headers); err != nil {
+			return fmt.Errorf("OTEL_EXPORTER_OTLP_HEADERS: %w", err)
+		}
+		if c.Tracing.ServiceName == "" {
+			return fmt.Errorf("OTEL_SERVICE_NAME is required when OTEL_EXPORTER_OTLP_ENDPOINT is set")
+		}
+		if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+			return fmt.Errorf("OTEL_TRACES_SAMPLER_ARG must be between 0 and 1")
+		}
+	}
+
+	// Validate SIEM export config (only when a sink is set)
+	if c.SIEM.Sink != "" {
+		if c.SIEM.Sink != siem.SinkHTTP && c.SIEM.Sink != siem.SinkKafka {
+			return fmt.Errorf("SIEM_SINK must be %q or %q", siem.SinkHTTP, siem.SinkKafka)
+		}
+		u, err := url.Parse(c.SIEM.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("SIEM_URL must be an http or https URL")
+		}
+		if c.SIEM.Sink == siem.SinkKafka && c.SIEM.Topic == "" {
+			return fmt.Errorf("SIEM_KAFKA_TOPIC is required when SIEM_SINK is kafka")
+		}
+		if c.SIEM.Format != siem.FormatJSON && c.SIEM.Format != siem.FormatCEF {
+			return fmt.Errorf("SIEM_FORMAT must be %q or %q", siem.FormatJSON, siem.FormatCEF)
+		}
+		if c.SIEM.BatchSize < 1 {
+			return fmt.Errorf("SIEM_BATCH_SIZE must be at least 1")
+		}
+		if c.SIEM.QueueSize < c.SIEM.BatchSize {
+			return fmt.Errorf("SIEM_QUEUE_SIZE must be at least SIEM_BATCH_SIZE")
+		}
+		if c.SIEM.FlushInterval <= 0 {
+			return fmt.Errorf("SIEM_FLUSH_INTERVAL must be a positive duration")
+		}
+		if c.SIEM.Timeout <= 0 {
+			return fmt.Errorf("SIEM_TIMEOUT must be a positive duration")
+		}
+		if c.SIEM.MaxRetryDelay < c.SIEM.FlushInterval {
+			return fmt.Errorf("SIEM_MAX_RETRY_DELAY must be at least SIEM_FLUSH_INTERVAL")
+		}
+	}
+
+	// Validate analyst query config (only when templates are configured)
+	if c.Queries.File != "" {
+		if c.Queries.RowLimit < 1 || c.Queries.RowLimit > MaxQueryRowLimit {
+			return fmt.Errorf("ANALYST_QUERY_ROW_LIMIT must be between 1 and %d", MaxQueryRowLimit)
+		}
+		if c.Queries.Timeout <= 0 {
+			return fmt.Errorf("ANALYST_QUERY_TIMEOUT must be a positive duration")
+		}
+		if c.Queries.ReplicaURL != "" {
+			u, err := url.Parse(c.Queries.ReplicaURL)
+			if err != nil || (u.Scheme != "postgres" && u.Scheme != "postgresql") || u.Host == "" {
+				return fmt.Errorf("ANALYST_DB_URL must be a postgres:// URL")
+			}
+		}
+		if err := validateQueryTemplates(c.Queries.Templates); err != nil {
+			return err
+		}
+	}
+
+	// Validate derived attributes (only when a file is configured)
+	if c.Derived.File != "" {
+		if err := validateDerivedAttributes(c.Derived.Attributes); err != nil {
+			return err
+		}
+	}
+
+	// Validate encryption config (only when master keys are set)
+	if c.Encryption.MasterKeys != "" {
+		keys, err := encryption.ParseMasterKeys(c.Encryption.MasterKeys)
+		if err != nil {
+			return fmt.Errorf("ENCRYPTION_MASTER_KEYS: %w", err)
+		}
+		if _, ok := keys[c.Encryption.ActiveKey]; !ok {
+			return fmt.Errorf("ENCRYPTION_ACTIVE_KEY must name a key of ENCRYPTION_MASTER_KEYS")
+		}
+	}
+
+	// Validate security config; empty header values omit the header
+	if c.Security.HSTSMaxAge < 0 {
+		return fmt.Errorf("SECURITY_HSTS_MAX_AGE must not be negative")
+	}
+	if strings.ContainsAny(c.Security.FrameAncestors, ";,\r\n") {
+		return fmt.Errorf("SECURITY_FRAME_ANCESTORS must be a single source list, e.g. 'none' or https://partner.example")
+	}
+	if c.Security.ReferrerPolicy != "" && !slices.Contains(referrerPolicies, c.Security.ReferrerPolicy) {
+		return fmt.Errorf("SECURITY_REFERRER_POLICY must be one of %s", strings.Join(referrerPolicies, ", "))
+	}
+
+	// Validate leader election config
+	if c.Leader.RenewInterval < 0 {
+		return fmt.Errorf("LEADER_RENEW_INTERVAL must not be negative")
+	}
+	if c.Leader.RetryInterval < 0 {
+		return fmt.Errorf("LEADER_RETRY_INTERVAL must not be negative")
+	}
+
+	// Validate auth config; a zero TTL disables the key cache
+	if c.Auth.KeyCacheTTL < 0 {
+		return fmt.Errorf("API_KEY_CACHE_TTL must not be negative")
+	}
+
+	// Validate user auth config (only when user accounts are enabled)
+	if c.Auth.JWTSecret != "" {
+		if len(c.Auth.JWTSecret) < MinJWTSecretLength {
+			return fmt.Errorf("JWT_SIGNING_SECRET must be at least %d characters", MinJWTSecretLength)
+		}
+		if c.Auth.JWTTTL <= 0 {
+			return fmt.Errorf("JWT_TTL must be a positive duration")
+		}
+	}
+
+	// Validate lockout config (only when lockouts are enabled)
+	if c.Auth.LockoutThreshold < 0 {
+		return fmt.Errorf("AUTH_LOCKOUT_THRESHOLD must not be negative")
+	}
+	if c.Auth.LockoutThreshold > 0 {
+		if c.Auth.LockoutWindow <= 0 {
+			return fmt.Errorf("AUTH_LOCKOUT_WINDOW must be a positive duration")
+		}
+		if c.Auth.LockoutDuration <= 0 {
+			return fmt.Errorf("AUTH_LOCKOUT_DURATION must be a positive duration")
+		}
+		if c.Auth.LockoutMax < c.Auth.LockoutDuration {
+			return fmt.Errorf("AUTH_LOCKOUT_MAX must be at least AUTH_LOCKOUT_DURATION")
+		}
+	}
+
 	return nil
 }
 
+// parseNetworks parses a comma-separated list of CIDR prefixes and addresses,
+// e.g. "10.0.0.0/8, 203.0.113.7"; an address is a single-address prefix.
+// Returns nil for an empty list.
+func parseNetworks(list string) ([]netip.Prefix, error) {
+	var networks []netip.Prefix
+	for _, entry := range parseOrigins(list) {
+		network, err := netip.ParsePrefix(entry)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(entry)
+			if addrErr != nil {
+				return nil, fmt.Errorf("%q is not an IP address or CIDR prefix", entry)
+			}
+			addr = addr.Unmap()
+			network = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		networks = append(networks, network.Masked())
+	}
+	return networks, nil
+}
+
+// parseRouteTTLs parses a comma-separated list of route names, each optionally
+// followed by =TTL, e.g. "parcels.at_point, counties.list=10m"; routes without
+// a TTL get defaultTTL. Returns an empty map for an empty list.
+func parseRouteTTLs(list string, defaultTTL time.Duration) (map[string]time.Duration, error) {
+	ttls := make(map[string]time.Duration)
+	for _, entry := range parseOrigins(list) {
+		name, rawTTL, hasTTL := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		ttl := defaultTTL
+		if hasTTL {
+			var err error
+			if ttl, err = time.ParseDuration(strings.TrimSpace(rawTTL)); err != nil {
+				return nil, fmt.Errorf("invalid TTL of %s: %w", name, err)
+			}
+		}
+		if name == "" {
+			return nil, fmt.Errorf("%q has no route name", entry)
+		}
+		ttls[name] = ttl
+	}
+	return ttls, nil
+}
+
 // parseOrigins splits a comma-separated string of origins into a slice.
 func parseOrigins(origins string) []string {
 	if origins == "" {
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SSLModes are the accepted DB_SSL_MODE values, as in libpq.
+var SSLModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// QueryExecModes are the accepted DB_QUERY_EXEC_MODE values, as in pgx.
+// cache_statement prepares each statement once per connection and reuses it;
+// exec and simple_protocol prepare nothing, for PgBouncer in transaction mode.
+var QueryExecModes = []string{"cache_statement", "cache_describe", "describe_exec", "exec", "simple_protocol"}
+
+// DSN returns the postgres:// URL the API connects with. It is URL when set,
+// otherwise built from the separate fields with sslmode=disable; Params are
+// added to its query and SSLMode, SSLRootCert, QueryExecMode and
+// StatementCacheCapacity, when set, replace the sslmode, sslrootcert,
+// default_query_exec_mode and statement_cache_capacity parameters of either.
+func (c DatabaseConfig) DSN() (string, error) {
+	base := (&url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(c.User, c.Password),
+		Host:   net.JoinHostPort(c.Host, c.Port),
+		Path:   "/" + c.Name,
+	}).String()
+	query := url.Values{"sslmode": {"disable"}}
+
+	// The URL is not parsed as a whole: url.Parse rejects the host lists of
+	// multi-host URLs, which pgx supports
+	if c.URL != "" {
+		if !strings.HasPrefix(c.URL, "postgres://") && !strings.HasPrefix(c.URL, "postgresql://") {
+			return "", fmt.Errorf("DATABASE_URL must be a postgres:// URL")
+		}
+		var rawQuery string
+		base, rawQuery, _ = strings.Cut(c.URL, "?")
+		var err error
+		if query, err = url.ParseQuery(rawQuery); err != nil {
+			return "", fmt.Errorf("DATABASE_URL has an invalid query: %w", err)
+		}
+	}
+
+	params, err := url.ParseQuery(c.Params)
+	if err != nil {
+		return "", fmt.Errorf("DB_PARAMS must be key=value pairs separated by &: %w", err)
+	}
+	for key, values := range params {
+		query[key] = values
+	}
+	if c.SSLMode != "" {
+		query.Set("sslmode", c.SSLMode)
+	}
+	if c.SSLRootCert != "" {
+		query.Set("sslrootcert", c.SSLRootCert)
+	}
+	if c.QueryExecMode != "" {
+		query.Set("default_query_exec_mode", c.QueryExecMode)
+	}
+	if c.StatementCacheCapacity > 0 {
+		query.Set("statement_cache_capacity", strconv.Itoa(c.StatementCacheCapacity))
+	}
+	if len(query) == 0 {
+		return base, nil
+	}
+	return base + "?" + query.Encode(), nil
+}
+
+// ReplicaDSNs returns the postgres:// URLs of the read replicas, with Params,
+// SSLMode and SSLRootCert applied to each as in DSN.
+func (c DatabaseConfig) ReplicaDSNs() ([]string, error) {
+	dsns := make([]string, 0, len(c.ReplicaURLs))
+	for _, replicaURL := range c.ReplicaURLs {
+		if !strings.HasPrefix(replicaURL, "postgres://") && !strings.HasPrefix(replicaURL, "postgresql://") {
+			return nil, fmt.Errorf("DB_REPLICA_URLS must be postgres:// URLs separated by spaces")
+		}
+		replica := c
+		replica.URL = replicaURL
+		dsn, err := replica.DSN()
+		if err != nil {
+			return nil, err
+		}
+		dsns = append(dsns, dsn)
+	}
+	return dsns, nil
+}
+
+// Address returns the server and database of the DSN without credentials or
+// parameters, such as db.example.com:5432/atlas, for logs and errors.
+func (c DatabaseConfig) Address() string {
+	if c.URL == "" {
+		return net.JoinHostPort(c.Host, c.Port) + "/" + c.Name
+	}
+	_, address, _ := strings.Cut(c.URL, "://")
+	address, _, _ = strings.Cut(address, "?")
+	hosts, path, _ := strings.Cut(address, "/")
+	if i := strings.LastIndex(hosts, "@"); i >= 0 {
+		hosts = hosts[i+1:]
+	}
+	return hosts + "/" + path
+}
+
+// validate checks the connection settings: DATABASE_URL or the
+// separate fields, the TLS options and the parameters.
+func (c DatabaseConfig) validate() error {
+	if c.URL == "" {
+		if c.Host == "" {
+			return fmt.Errorf("DB_HOST is required")
+		}
+		if c.Port == "" {
+			return fmt.Errorf("DB_PORT is required")
+		}
+		if c.Name == "" {
+			return fmt.Errorf("DB_NAME is required")
+		}
+		if c.User == "" {
+			return fmt.Errorf("DB_USER is required")
+		}
+		if c.Password == "" {
+			return fmt.Errorf("DB_PASSWORD is required")
+		}
+	}
+	if c.SSLMode != "" && !slices.Contains(SSLModes, c.SSLMode) {
+		return fmt.Errorf("DB_SSL_MODE must be one of %s", strings.Join(SSLModes, ", "))
+	}
+	if c.QueryExecMode != "" && !slices.Contains(QueryExecModes, c.QueryExecMode) {
+		return fmt.Errorf("DB_QUERY_EXEC_MODE must be one of %s", strings.Join(QueryExecModes, ", "))
+	}
+	if c.StatementCacheCapacity < 0 {
+		return fmt.Errorf("DB_STATEMENT_CACHE_CAPACITY must not be negative")
+	}
+	if c.SSLRootCert != "" {
+		if _, err := os.Stat(c.SSLRootCert); err != nil {
+			return fmt.Errorf("DB_SSL_ROOT_CERT must be a readable file: %w", err)
+		}
+	}
+	if _, err := c.DSN(); err != nil {
+		return err
+	}
+	_, err := c.ReplicaDSNs()
+	return err
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// DerivedConfig holds the derived parcel attributes read from File at startup.
+// No attributes are computed when File is empty.
+type DerivedConfig struct {
+	File       string
+	Attributes []DerivedAttribute
+}
+
+// DerivedAttribute is a parcel attribute computed by a SQL expression over the
+// columns of a tax_parcels row, such as imprv_main_area / NULLIF(acres, 0).
+// Ingest-stage attributes are evaluated by cmd/ingest and stored with the
+// parcel; query-stage attributes are evaluated whenever a parcel is read.
+type DerivedAttribute struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	SQL         string       `json:"sql"`
+	Type        DerivedType  `json:"type"`
+	Stage       DerivedStage `json:"stage"`
+}
+
+// DerivedType is the type a derived attribute's expression is cast to.
+type DerivedType string
+
+// Derived attribute types
+const (
+	DerivedFloat DerivedType = "float"
+	DerivedInt   DerivedType = "int"
+	DerivedText  DerivedType = "text"
+	DerivedBool  DerivedType = "bool"
+)
+
+// Numeric reports whether values of the type can be compared as ranges.
+func (t DerivedType) Numeric() bool {
+	return t == DerivedFloat || t == DerivedInt
+}
+
+// SQLType returns the PostgreSQL type values of the type are cast to.
+func (t DerivedType) SQLType() string {
+	switch t {
+	case DerivedFloat:
+		return "float8"
+	case DerivedInt:
+		return "int8"
+	case DerivedBool:
+		return "bool"
+	default:
+		return "text"
+	}
+}
+
+// DerivedStage is when a derived attribute is evaluated.
+type DerivedStage string
+
+// Derived attribute stages
+const (
+	// DerivedAtIngest stores the value on every parcel load; use it for
+	// expensive expressions such as spatial joins
+	DerivedAtIngest DerivedStage = "ingest"
+	// DerivedAtQuery evaluates the expression in every parcel query
+	DerivedAtQuery DerivedStage = "query"
+)
+
+// MaxDerivedAttributes caps the attribute count; PostgreSQL's jsonb_build_object
+// takes at most 100 arguments, two per attribute.
+const MaxDerivedAttributes = 50
+
+// LoadDerivedAttributes reads a JSON array of derived attributes from path. A
+// missing stage defaults to DerivedAtQuery. Attributes are checked by Config.Validate.
+func LoadDerivedAttributes(path string) ([]DerivedAttribute, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is operator configuration
+	if err != nil {
+		return nil, fmt.Errorf("failed to read derived attributes: %w", err)
+	}
+	var attributes []DerivedAttribute
+	if err := json.Unmarshal(data, &attributes); err != nil {
+		return nil, fmt.Errorf("failed to parse derived attributes %s: %w", path, err)
+	}
+	for i := range attributes {
+		if attributes[i].Stage == "" {
+			attributes[i].Stage = DerivedAtQuery
+		}
+	}
+	return attributes, nil
+}
+
+// validateDerivedAttributes checks names, types, stages and expressions. An
+// expression must be a single value without statement separators, comments or
+// parameters, since it is inlined into parcel queries; whether it compiles
+// against tax_parcels is only known once the database is reached.
+func validateDerivedAttributes(attributes []DerivedAttribute) error {
+	if len(attributes) == 0 {
+		return fmt.Errorf("DERIVED_ATTRIBUTES_FILE has no attributes")
+	}
+	if len(attributes) > MaxDerivedAttributes {
+		return fmt.Errorf("DERIVED_ATTRIBUTES_FILE has %d attributes, at most %d are supported",
+			len(attributes), MaxDerivedAttributes)
+	}
+	names := make(map[string]bool, len(attributes))
+	for _, a := range attributes {
+		if !queryNamePattern.MatchString(a.Name) {
+			return fmt.Errorf("derived attribute name %q must be lowercase letters, digits and underscores", a.Name)
+		}
+		if names[a.Name] {
+			return fmt.Errorf("duplicate derived attribute %q", a.Name)
+		}
+		names[a.Name] = true
+
+		if !slices.Contains([]DerivedType{DerivedFloat, DerivedInt, DerivedText, DerivedBool}, a.Type) {
+			return fmt.Errorf("derived attribute %q has unknown type %q", a.Name, a.Type)
+		}
+		if a.Stage != DerivedAtIngest && a.Stage != DerivedAtQuery {
+			return fmt.Errorf("derived attribute %q has unknown stage %q", a.Name, a.Stage)
+		}
+
+		expression := strings.TrimSpace(a.SQL)
+		if expression == "" {
+			return fmt.Errorf("derived attribute %q has no sql", a.Name)
+		}
+		for _, forbidden := range []string{";", "--", "/*"} {
+			if strings.Contains(expression, forbidden) {
+				return fmt.Errorf("derived attribute %q sql must be a single expression without %q", a.Name, forbidden)
+			}
+		}
+		if placeholderPattern.MatchString(expression) {
+			return fmt.Errorf("derived attribute %q sql must not use parameters", a.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadDerivedAttributes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "derived.json")
+	data := `[{"name": "building_age", "sql": "2026 - imprv_actual_year_built", "type": "int"},
+		{"name": "compactness", "sql": "ST_Area(geom) / ST_Perimeter(geom)", "type": "float", "stage": "ingest"}]`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("Failed to write attributes: %v", err)
+	}
+
+	attributes, err := LoadDerivedAttributes(path)
+	if err != nil {
+		t.Fatalf("LoadDerivedAttributes() failed: %v", err)
+	}
+	if len(attributes) != 2 || attributes[0].Name != "building_age" || attributes[0].Type != DerivedInt {
+		t.Errorf("Unexpected attributes: %+v", attributes)
+	}
+	if attributes[0].Stage != DerivedAtQuery || attributes[1].Stage != DerivedAtIngest {
+		t.Errorf("Expected query stage by default and ingest when given, got %q and %q", attributes[0].Stage, attributes[1].Stage)
+	}
+
+	if _, err := LoadDerivedAttributes(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+	if err := os.WriteFile(path, []byte(`{"name": "not a list"}`), 0o600); err != nil {
+		t.Fatalf("Failed to write attributes: %v", err)
+	}
+	if _, err := LoadDerivedAttributes(path); err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("Expected a parse error naming the file, got %v", err)
+	}
+}
+
+func TestValidate_DerivedConfig(t *testing.T) {
+	valid := DerivedAttribute{
+		Name:  "improvement_ratio",
+		SQL:   "imprv_main_area / NULLIF(ST_Area(geom::geography) / 4046.8564224, 0)",
+		Type:  DerivedFloat,
+		Stage: DerivedAtQuery,
+	}
+	with := func(change func(*DerivedAttribute)) []DerivedAttribute {
+		a := valid
+		change(&a)
+		return []DerivedAttribute{a}
+	}
+	enabled := func(attributes []DerivedAttribute) DerivedConfig {
+		return DerivedConfig{File: "derived.json", Attributes: attributes}
+	}
+	tooMany := make([]DerivedAttribute, MaxDerivedAttributes+1)
+	for i := range tooMany {
+		tooMany[i] = valid
+		tooMany[i].Name = "attribute_" + strings.Repeat("x", i+1)
+	}
+
+	tests := []struct {
+		name    string
+		cfg     DerivedConfig
+		wantErr bool
+	}{
+		{"disabled", DerivedConfig{}, false},
+		{"valid", enabled([]DerivedAttribute{valid}), false},
+		{"ingest stage", enabled(with(func(a *DerivedAttribute) { a.Stage = DerivedAtIngest })), false},
+		{"no attributes", enabled(nil), true},
+		{"too many", enabled(tooMany), true},
+		{"duplicate name", enabled([]DerivedAttribute{valid, valid}), true},
+		{"invalid name", enabled(with(func(a *DerivedAttribute) { a.Name = "Value-Per-Acre" })), true},
+		{"unknown type", enabled(with(func(a *DerivedAttribute) { a.Type = "numeric" })), true},
+		{"unknown stage", enabled(with(func(a *DerivedAttribute) { a.Stage = "nightly" })), true},
+		{"empty sql", enabled(with(func(a *DerivedAttribute) { a.SQL = "  " })), true},
+		{"two statements", enabled(with(func(a *DerivedAttribute) { a.SQL = "1; DELETE FROM tax_parcels" })), true},
+		{"comment", enabled(with(func(a *DerivedAttribute) { a.SQL = "acres -- per parcel" })), true},
+		{"block comment", enabled(with(func(a *DerivedAttribute) { a.SQL = "acres /* per parcel */" })), true},
+		{"parameter", enabled(with(func(a *DerivedAttribute) { a.SQL = "imprv_main_area / $1" })), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Server: ServerConfig{Port: "8080", Env: "development"},
+				Database: DatabaseConfig{
+					Host: "localhost", Port: "5432", Name: "atlas",
+					User: "postgres", Password: "postgres", PoolMin: 2, PoolMax: 10,
+				},
+				CORS:    CORSConfig{Origins: []string{"http://localhost:3000"}},
+				Derived: tt.cfg,
+			}
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDerivedAttributesExample(t *testing.T) {
+	attributes, err := LoadDerivedAttributes("../../derived-attributes.example.json")
+	if err != nil {
+		t.Fatalf("LoadDerivedAttributes() failed: %v", err)
+	}
+	if err := validateDerivedAttributes(attributes); err != nil {
+		t.Errorf("Expected the example attributes to be valid: %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// configPaths are the directories searched for the .env and config files, in order.
+var configPaths = []string{
+	".",      // Current directory
+	"./api",  // api directory (for running from root)
+	"../",    // Parent directory (for running from api/cmd/server)
+	"../../", // Two levels up
+}
+
+// configFileNames are the config files LoadFile looks for when none is given.
+var configFileNames = []string{"config.yaml", "config.yml", "config.toml"}
+
+// fileSectionPrefixes map config file sections to the prefix of their settings'
+// environment variables: database.pool_max is DB_POOL_MAX. Other sections use
+// their own name as the prefix, so geocoder.provider is GEOCODER_PROVIDER.
+var fileSectionPrefixes = map[string]string{
+	"server":   "",
+	"database": "DB_",
+	"cache":    "RESPONSE_CACHE_",
+	"auth":     "AUTH_",
+	"cors":     "CORS_",
+}
+
+// fileKeyNames are the config file settings whose environment variable does
+// not follow from their section.
+var fileKeyNames = map[string]string{
+	"database.url":            "DATABASE_URL",
+	"auth.api_keys_required":  "API_KEYS_REQUIRED",
+	"auth.api_key_cache_ttl":  "API_KEY_CACHE_TTL",
+	"auth.jwt_signing_secret": "JWT_SIGNING_SECRET",
+	"auth.jwt_ttl":            "JWT_TTL",
+}
+
+// fileListSeparators join config file lists into the variables that are not
+// comma-separated; multi-host replica URLs contain commas.
+var fileListSeparators = map[string]string{
+	"DB_REPLICA_URLS": " ",
+}
+
+// findConfigFile returns the first of configFileNames in configPaths, or an
+// empty string when there is none.
+func findConfigFile() string {
+	for _, dir := range configPaths {
+		for _, name := range configFileNames {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+				return path
+			}
+		}
+	}
+	return ""
+}
+
+// readConfigFile reads a YAML or TOML config file, chosen by its extension,
+// into settings keyed by environment variable name. Sections nest settings,
+// e.g. server.port for PORT, and top-level keys name variables directly; lists
+// become comma-separated values.
+func readConfigFile(path string) (map[string]any, error) {
+	f := viper.New()
+	f.SetConfigFile(path)
+	if err := f.ReadInConfig(); err != nil {
+		return nil, err
+	}
+
+	settings := make(map[string]any)
+	for key, value := range f.AllSettings() {
+		flattenConfigFile([]string{key}, value, settings)
+	}
+	return settings, nil
+}
+
+// flattenConfigFile adds the setting at path, or the settings nested in it, to settings.
+func flattenConfigFile(path []string, value any, settings map[string]any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, nested := range v {
+			flattenConfigFile(append(slices.Clone(path), key), nested, settings)
+		}
+	case []any:
+		name := configFileKey(path)
+		separator, ok := fileListSeparators[name]
+		if !ok {
+			separator = ","
+		}
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = fmt.Sprint(item)
+		}
+		settings[name] = strings.Join(items, separator)
+	default:
+		settings[configFileKey(path)] = v
+	}
+}
+
+// configFileKey returns the environment variable name of the config file
+// setting at path, such as [database pool_max] for DB_POOL_MAX.
+func configFileKey(path []string) string {
+	if len(path) == 1 {
+		return strings.ToUpper(path[0])
+	}
+	if name, ok := fileKeyNames[strings.Join(path, ".")]; ok {
+		return name
+	}
+
+	prefix, ok := fileSectionPrefixes[path[0]]
+	if !ok {
+		prefix = strings.ToUpper(path[0]) + "_"
+	}
+	return prefix + strings.ToUpper(strings.Join(path[1:], "_"))
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QueriesConfig holds configuration for the admin endpoint running analyst SQL
+// templates. The endpoint is disabled when File is empty. Templates are read
+// from File at startup; queries run on the read replica at ReplicaURL, or on
+// the primary database when it is empty, always in a read-only transaction
+// limited to Timeout and RowLimit rows.
+type QueriesConfig struct {
+	File       string
+	ReplicaURL string
+	Templates  []QueryTemplate
+	RowLimit   int
+	Timeout    time.Duration
+}
+
+// QueryTemplate is a parameterized, read-only SQL statement analysts may run
+// by name. Params are bound to $1, $2, ... in order. RowLimit lowers the
+// configured row limit for this template; zero keeps it.
+type QueryTemplate struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	SQL         string       `json:"sql"`
+	Params      []QueryParam `json:"params"`
+	RowLimit    int          `json:"rowLimit,omitempty"`
+}
+
+// QueryParam is a required, typed template parameter.
+type QueryParam struct {
+	Name        string         `json:"name"`
+	Type        QueryParamType `json:"type"`
+	Description string         `json:"description,omitempty"`
+}
+
+// QueryParamType is the type a parameter value is converted to before binding.
+type QueryParamType string
+
+// Template parameter types
+const (
+	QueryParamText  QueryParamType = "text"
+	QueryParamInt   QueryParamType = "int"
+	QueryParamFloat QueryParamType = "float"
+	QueryParamBool  QueryParamType = "bool"
+	// QueryParamDate is a YYYY-MM-DD calendar date
+	QueryParamDate QueryParamType = "date"
+)
+
+// MaxQueryRowLimit caps ANALYST_QUERY_ROW_LIMIT; larger extracts belong in bulk exports.
+const MaxQueryRowLimit = 10000
+
+var (
+	// queryNamePattern matches template names, which appear in URLs
+	queryNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+	// placeholderPattern matches $1-style positional parameters
+	placeholderPattern = regexp.MustCompile(`\$(\d+)`)
+)
+
+// LoadQueryTemplates reads a JSON array of templates from path. Templates are
+// checked by Config.Validate.
+func LoadQueryTemplates(path string) ([]QueryTemplate, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is operator configuration
+	if err != nil {
+		return nil, fmt.Errorf("failed to read query templates: %w", err)
+	}
+	var templates []QueryTemplate
+	if err := json.Unmarshal(data, &templates); err != nil {
+		return nil, fmt.Errorf("failed to parse query templates %s: %w", path, err)
+	}
+	return templates, nil
+}
+
+// validateQueryTemplates checks template names, parameters and SQL. Only
+// single SELECT or WITH statements are accepted; the read-only transaction
+// they run in also rejects writes hidden in CTEs or functions.
+func validateQueryTemplates(templates []QueryTemplate) error {
+	if len(templates) == 0 {
+		return fmt.Errorf("ANALYST_QUERIES_FILE has no templates")
+	}
+	names := make(map[string]bool, len(templates))
+	for _, t := range templates {
+		if !queryNamePattern.MatchString(t.Name) {
+			return fmt.Errorf("query template name %q must be lowercase letters, digits and underscores", t.Name)
+		}
+		if names[t.Name] {
+			return fmt.Errorf("duplicate query template %q", t.Name)
+		}
+		names[t.Name] = true
+
+		statement := strings.TrimSuffix(strings.TrimSpace(t.SQL), ";")
+		keyword, _, _ := strings.Cut(strings.Join(strings.Fields(statement), " "), " ")
+		if keyword = strings.ToUpper(keyword); keyword != "SELECT" && keyword != "WITH" {
+			return fmt.Errorf("query template %q must be a SELECT or WITH statement", t.Name)
+		}
+		if strings.Contains(statement, ";") {
+			return fmt.Errorf("query template %q must be a single statement", t.Name)
+		}
+		if t.RowLimit < 0 {
+			return fmt.Errorf("query template %q rowLimit must not be negative", t.Name)
+		}
+
+		params := make(map[string]bool, len(t.Params))
+		for _, p := range t.Params {
+			if !queryNamePattern.MatchString(p.Name) || params[p.Name] {
+				return fmt.Errorf("query template %q has an invalid or duplicate parameter %q", t.Name, p.Name)
+			}
+			params[p.Name] = true
+			if !slices.Contains([]QueryParamType{QueryParamText, QueryParamInt, QueryParamFloat, QueryParamBool, QueryParamDate}, p.Type) {
+				return fmt.Errorf("query template %q parameter %q has unknown type %q", t.Name, p.Name, p.Type)
+			}
+		}
+
+		// Every parameter must be bound by a placeholder and every placeholder by a parameter
+		used := make(map[int]bool)
+		for _, m := range placeholderPattern.FindAllStringSubmatch(statement, -1) {
+			n, _ := strconv.Atoi(m[1])
+			if n < 1 || n > len(t.Params) {
+				return fmt.Errorf("query template %q uses $%d but declares %d parameters", t.Name, n, len(t.Params))
+			}
+			used[n] = true
+		}
+		if len(used) != len(t.Params) {
+			return fmt.Errorf("query template %q declares %d parameters but uses %d", t.Name, len(t.Params), len(used))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package database
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+)
+
+// ErrUnavailable is returned by new connections while the circuit breaker is
+// open, without dialing the server. Queries needing a connection fail with it
+// at once instead of each waiting out the connect timeout. Clients are asked
+// to retry after the default DB_BREAKER_COOLDOWN.
+var ErrUnavailable = domain.Unavailable("database is temporarily unavailable, try again shortly").WithRetryAfter(10 * time.Second)
+
+// Circuit breaker states, as reported by CircuitState
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open"
+)
+
+// breaker is a consecutive-failure circuit breaker over the pool's connections.
+// Failures are connection attempts that fail and queries that lose their
+// connection; SQL errors leave the connection usable and are not counted.
+// After threshold failures in a row it refuses new connections for cooldown,
+// then lets one probe connection through: success closes the circuit, failure
+// opens it again.
+type breaker struct {
+	openedAt  time.Time
+	now       func() time.Time
+	state     string
+	cooldown  time.Duration
+	threshold int
+	failures  int
+	rejected  atomic.Int64
+	mu        sync.Mutex
+	// probe is set while the half-open probe connection is being established
+	probe bool
+}
+
+// newBreaker creates a closed breaker.
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	return &breaker{state: CircuitClosed, threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// allowConnect reports whether a new connection may be dialed.
+// Returns ErrUnavailable while the circuit is open or its probe is in flight.
+func (b *breaker) allowConnect() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			b.rejected.Add(1)
+			return ErrUnavailable
+		}
+		b.state = CircuitHalfOpen
+		b.probe = true
+	case CircuitHalfOpen:
+		if b.probe {
+			b.rejected.Add(1)
+			return ErrUnavailable
+		}
+		b.probe = true
+	}
+	return nil
+}
+
+// record counts the outcome of a connection attempt or query.
+func (b *breaker) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probe = false
+	if success {
+		b.failures = 0
+		b.state = CircuitClosed
+		return
+	}
+
+	b.failures++
+	if b.state == CircuitClosed && b.failures < b.threshold {
+		return
+	}
+	b.state = CircuitOpen
+	b.openedAt = b.now()
+}
+
+// release ends a connection attempt without counting it, e.g. when the caller
+// cancelled it.
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probe = false
+}
+
+// status returns the circuit state.
+func (b *breaker) status() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// breakerTracer feeds the breaker from the pgx trace hooks and passes query
+// traces on to next.
+type breakerTracer struct {
+	next    pgx.QueryTracer
+	breaker *breaker
+}
+
+// TraceQueryStart passes the query on to the next tracer.
+func (t breakerTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return t.next.TraceQueryStart(ctx, conn, data)
+}
+
+// TraceQueryEnd counts a query that lost its connection as a failure and any
+// other completed query as a success. Queries cancelled by their caller, which
+// also close the connection, are not counted.
+func (t breakerTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	t.next.TraceQueryEnd(ctx, conn, data)
+
+	if ctx.Err() != nil {
+		return
+	}
+	t.breaker.record(data.Err == nil || conn == nil || !conn.IsClosed())
+}
+
+// TraceConnectStart implements pgx.ConnectTracer.
+func (t breakerTracer) TraceConnectStart(ctx context.Context, _ pgx.TraceConnectStartData) context.Context {
+	return ctx
+}
+
+// TraceConnectEnd counts the outcome of a connection attempt.
+func (t breakerTracer) TraceConnectEnd(ctx context.Context, data pgx.TraceConnectEndData) {
+	if data.Err != nil && ctx.Err() != nil {
+		t.breaker.release()
+		return
+	}
+	t.breaker.record(data.Err == nil)
+}
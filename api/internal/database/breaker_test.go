@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stwalsh4118/atlas/api/internal/tracing"
+)
+
+func TestBreaker(t *testing.T) {
+	now := time.Now()
+	b := newBreaker(2, 10*time.Second)
+	b.now = func() time.Time { return now }
+
+	assert.NoError(t, b.allowConnect())
+	b.record(false)
+	assert.Equal(t, CircuitClosed, b.status())
+	b.record(false)
+	assert.Equal(t, CircuitOpen, b.status(), "second consecutive failure opens the circuit")
+	assert.ErrorIs(t, b.allowConnect(), ErrUnavailable)
+	assert.Equal(t, int64(1), b.rejected.Load())
+
+	// One probe connection after the cooldown; others wait for its outcome
+	now = now.Add(10 * time.Second)
+	assert.NoError(t, b.allowConnect())
+	assert.Equal(t, CircuitHalfOpen, b.status())
+	assert.ErrorIs(t, b.allowConnect(), ErrUnavailable)
+
+	// A failed probe reopens the circuit at once
+	b.record(false)
+	assert.Equal(t, CircuitOpen, b.status())
+	assert.ErrorIs(t, b.allowConnect(), ErrUnavailable)
+
+	// A cancelled probe lets the next connection probe instead
+	now = now.Add(10 * time.Second)
+	assert.NoError(t, b.allowConnect())
+	b.release()
+	assert.NoError(t, b.allowConnect())
+
+	// A successful probe closes it
+	b.record(true)
+	assert.Equal(t, CircuitClosed, b.status())
+	assert.NoError(t, b.allowConnect())
+}
+
+func TestBreaker_SuccessResetsFailures(t *testing.T) {
+	b := newBreaker(2, time.Minute)
+
+	b.record(false)
+	b.record(true)
+	b.record(false)
+	assert.Equal(t, CircuitClosed, b.status(), "failures must be consecutive")
+}
+
+func TestBreakerTracer(t *testing.T) {
+	b := newBreaker(1, time.Minute)
+	tracer := breakerTracer{next: tracing.QueryTracer{}, breaker: b}
+	refused := errors.New("dial tcp: connection refused")
+
+	// Connection attempts cancelled by the caller are not counted
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tracer.TraceConnectEnd(cancelled, pgx.TraceConnectEndData{Err: context.Canceled})
+	assert.Equal(t, CircuitClosed, b.status())
+
+	// SQL errors on a live connection are not failures
+	tracer.TraceQueryEnd(context.Background(), nil, pgx.TraceQueryEndData{Err: errors.New("syntax error")})
+	assert.Equal(t, CircuitClosed, b.status())
+
+	tracer.TraceConnectEnd(context.Background(), pgx.TraceConnectEndData{Err: refused})
+	assert.Equal(t, CircuitOpen, b.status())
+}
+
+func TestDatabase_CircuitWithoutBreaker(t *testing.T) {
+	db := &Database{}
+	assert.Equal(t, CircuitClosed, db.CircuitState())
+	assert.Zero(t, db.RejectedConnects())
+}
@@ -3,31 +3,91 @@ package database
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/tracing"
 )
 
 // Database wraps the pgx connection pool and provides database operations.
 type Database struct {
-	Pool *pgxpool.Pool
+	Pool     *pgxpool.Pool
+	breaker  *breaker
+	replicas []*replica
+	retry    RetryPolicy
+	// replicaCooldown is how long Read skips a replica that failed
+	replicaCooldown time.Duration
+	retries         atomic.Int64
+	failovers       atomic.Int64
+	nextReplica     atomic.Uint64
 }
 
 // NewPostgresPool creates a new PostgreSQL connection pool using pgx.
 // It configures the pool based on the provided database configuration,
-// tests the connection, and returns a Database instance.
+// connecting to cfg.DSN(), tests the connection, and returns a Database instance.
+// With a BreakerThreshold, new connections fail with ErrUnavailable while the
+// circuit breaker is open (see breaker). Retry follows the RetryAttempts policy.
 func NewPostgresPool(ctx context.Context, cfg config.DatabaseConfig) (*Database, error) {
-	// Build connection string (DSN)
-	dsn := fmt.Sprintf(
-		"postgres://%s:%s@%s:%s/%s?sslmode=disable",
-		cfg.User,
-		cfg.Password,
-		cfg.Host,
-		cfg.Port,
-		cfg.Name,
-	)
+	// DATABASE_URL or the separate fields, with the TLS options and params
+	dsn, err := cfg.DSN()
+	if err != nil {
+		return nil, err
+	}
+
+	var b *breaker
+	if cfg.BreakerThreshold > 0 {
+		b = newBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown)
+	}
+	db, err := newPool(ctx, dsn, cfg.PoolMin, cfg.PoolMax, b)
+	if err != nil {
+		return nil, err
+	}
+	db.retry = RetryPolicy{Attempts: cfg.RetryAttempts, BaseDelay: cfg.RetryBaseDelay, MaxDelay: cfg.RetryMaxDelay}
+	return db, nil
+}
+
+// ReplicaPoolMax is the connection limit of read replica pools, which serve
+// occasional analyst queries rather than API traffic.
+const ReplicaPoolMax = 4
+
+// NewReplicaPool creates a connection pool to the read replica at the
+// postgres:// URL dsn, opening connections only when queries need them.
+// Its Retry never retries.
+func NewReplicaPool(ctx context.Context, dsn string) (*Database, error) {
+	return newPool(ctx, dsn, 0, ReplicaPoolMax, nil)
+}
+
+// NewReadReplicaPool creates a connection pool to the read replica at the
+// postgres:// URL dsn with the pool sizes of the primary's cfg, for the
+// primary's Read (see UseReplicas). The replica is not pinged: one that is down
+// at startup fails over on its first read and is tried again later.
+func NewReadReplicaPool(ctx context.Context, dsn string, cfg config.DatabaseConfig) (*Database, error) {
+	return openPool(ctx, dsn, cfg.PoolMin, cfg.PoolMax, nil)
+}
 
+// newPool creates and pings a pool for dsn with the given connection limits,
+// guarded by b unless it is nil.
+func newPool(ctx context.Context, dsn string, poolMin, poolMax int, b *breaker) (*Database, error) {
+	db, err := openPool(ctx, dsn, poolMin, poolMax, b)
+	if err != nil {
+		return nil, err
+	}
+
+	// Test the connection immediately
+	if err := db.Pool.Ping(ctx); err != nil {
+		db.Pool.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return db, nil
+}
+
+// openPool creates a pool for dsn with the given connection limits, guarded by
+// b unless it is nil, without connecting.
+func openPool(ctx context.Context, dsn string, poolMin, poolMax int, b *breaker) (*Database, error) {
 	// Parse connection string and create pool config
 	poolConfig, err := pgxpool.ParseConfig(dsn)
 	if err != nil {
@@ -36,14 +96,14 @@ func NewPostgresPool(ctx context.Context, cfg config.DatabaseConfig) (*Database,
 
 	// Configure connection pool settings
 	// Validate and convert pool sizes (should already be validated in config)
-	if cfg.PoolMin < 0 || cfg.PoolMin > 2147483647 {
-		return nil, fmt.Errorf("invalid pool min size: %d", cfg.PoolMin)
+	if poolMin < 0 || poolMin > 2147483647 {
+		return nil, fmt.Errorf("invalid pool min size: %d", poolMin)
 	}
-	if cfg.PoolMax < 0 || cfg.PoolMax > 2147483647 {
-		return nil, fmt.Errorf("invalid pool max size: %d", cfg.PoolMax)
+	if poolMax < 0 || poolMax > 2147483647 {
+		return nil, fmt.Errorf("invalid pool max size: %d", poolMax)
 	}
-	poolConfig.MinConns = int32(cfg.PoolMin) // #nosec G115
-	poolConfig.MaxConns = int32(cfg.PoolMax) // #nosec G115
+	poolConfig.MinConns = int32(poolMin) // #nosec G115
+	poolConfig.MaxConns = int32(poolMax) // #nosec G115
 
 	// Set connection timeouts
 	poolConfig.ConnConfig.ConnectTimeout = 5 * time.Second
@@ -53,19 +113,22 @@ func NewPostgresPool(ctx context.Context, cfg config.DatabaseConfig) (*Database,
 	// Health check period (how often to check idle connections)
 	poolConfig.HealthCheckPeriod = 1 * time.Minute
 
+	// Record a span per query of traced requests; a no-op when tracing is disabled
+	poolConfig.ConnConfig.Tracer = tracing.QueryTracer{}
+	if b != nil {
+		poolConfig.ConnConfig.Tracer = breakerTracer{next: tracing.QueryTracer{}, breaker: b}
+		poolConfig.BeforeConnect = func(context.Context, *pgx.ConnConfig) error {
+			return b.allowConnect()
+		}
+	}
+
 	// Create the connection pool
 	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create connection pool: %w", err)
 	}
 
-	// Test the connection immediately
-	if err := pool.Ping(ctx); err != nil {
-		pool.Close()
-		return nil, fmt.Errorf("failed to ping database: %w", err)
-	}
-
-	return &Database{Pool: pool}, nil
+	return &Database{Pool: pool, breaker: b}, nil
 }
 
 // Ping checks if the database connection is alive.
@@ -74,14 +137,34 @@ func (db *Database) Ping(ctx context.Context) error {
 	return db.Pool.Ping(ctx)
 }
 
-// Close gracefully closes the database connection pool.
+// Close gracefully closes the database connection pool and its replicas' pools.
 // It waits for all connections to be returned to the pool before closing.
 func (db *Database) Close() {
+	for _, r := range db.replicas {
+		r.db.Close()
+	}
 	if db.Pool != nil {
 		db.Pool.Close()
 	}
 }
 
+// CircuitState returns the state of the circuit breaker, one of the Circuit*
+// constants; CircuitClosed when the pool has none.
+func (db *Database) CircuitState() string {
+	if db.breaker == nil {
+		return CircuitClosed
+	}
+	return db.breaker.status()
+}
+
+// RejectedConnects returns how many new connections the circuit breaker refused.
+func (db *Database) RejectedConnects() int64 {
+	if db.breaker == nil {
+		return 0
+	}
+	return db.breaker.rejected.Load()
+}
+
 // Stats returns statistics about the connection pool.
 // This is useful for monitoring and debugging.
 func (db *Database) Stats() *pgxpool.Stat {
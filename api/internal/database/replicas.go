@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Querier runs SQL statements; *pgxpool.Pool implements it.
+type Querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// replica is a read replica pool of a primary.
+type replica struct {
+	db *Database
+	// downUntil is when, in Unix nanoseconds, the replica is tried again after failing
+	downUntil atomic.Int64
+}
+
+// UseReplicas makes Read run on the given read replica pools, in turn. A
+// replica that cannot be reached is skipped for cooldown, during which its
+// reads go to the other replicas or the primary. Close closes the replicas too.
+func (db *Database) UseReplicas(cooldown time.Duration, replicas ...*Database) {
+	db.replicaCooldown = cooldown
+	for _, r := range replicas {
+		db.replicas = append(db.replicas, &replica{db: r})
+	}
+}
+
+// Read runs the read-only fn on the next read replica that is not down. When
+// the replica cannot be reached (a transient error, see IsTransient, or its own
+// open circuit) it is marked down and fn runs on the next one; once no replica
+// is left, or without replicas, fn runs on the primary under Retry. Other
+// errors, such as a statement timeout or no rows, are returned as they are. fn
+// must only read, be safe to repeat, and reset its results on each run.
+//
+// Replicas lag the primary, so reads of rows the API has just written belong
+// on the primary's Pool rather than in Read.
+func (db *Database) Read(ctx context.Context, fn func(ctx context.Context, q Querier) error) error {
+	if n := uint64(len(db.replicas)); n > 0 {
+		start := db.nextReplica.Add(1)
+		for i := range n {
+			r := db.replicas[(start+i)%n]
+			if time.Now().UnixNano() < r.downUntil.Load() {
+				continue
+			}
+
+			err := fn(ctx, r.db.Pool)
+			if !IsTransient(err) && !errors.Is(err, ErrUnavailable) {
+				return err
+			}
+			r.downUntil.Store(time.Now().Add(db.replicaCooldown).UnixNano())
+			db.failovers.Add(1)
+		}
+	}
+
+	return db.Retry(ctx, func(ctx context.Context) error {
+		return fn(ctx, db.Pool)
+	})
+}
+
+// Failovers returns how many reads failed on a replica and moved on.
+func (db *Database) Failovers() int64 {
+	return db.failovers.Load()
+}
+
+// ReplicasUp returns how many replicas Read currently uses, of all replicas.
+func (db *Database) ReplicasUp() (up, total int) {
+	now := time.Now().UnixNano()
+	for _, r := range db.replicas {
+		if now >= r.downUntil.Load() {
+			up++
+		}
+	}
+	return up, len(db.replicas)
+}
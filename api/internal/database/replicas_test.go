@@ -0,0 +1,105 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withReplicas returns a primary with n replicas, each on a distinct pool that
+// is never connected; the tests only compare the pool fn runs on.
+func withReplicas(n int) *Database {
+	db := &Database{Pool: new(pgxpool.Pool)}
+	replicas := make([]*Database, n)
+	for i := range replicas {
+		replicas[i] = &Database{Pool: new(pgxpool.Pool)}
+	}
+	db.UseReplicas(time.Minute, replicas...)
+	return db
+}
+
+func TestRead(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("without replicas reads the primary", func(t *testing.T) {
+		db := &Database{Pool: new(pgxpool.Pool)}
+		var got Querier
+		require.NoError(t, db.Read(ctx, func(_ context.Context, q Querier) error {
+			got = q
+			return nil
+		}))
+		assert.Same(t, db.Pool, got)
+	})
+
+	t.Run("takes the replicas in turn", func(t *testing.T) {
+		db := withReplicas(2)
+		seen := map[Querier]int{}
+		for range 4 {
+			require.NoError(t, db.Read(ctx, func(_ context.Context, q Querier) error {
+				seen[q]++
+				return nil
+			}))
+		}
+		assert.Equal(t, 2, seen[db.replicas[0].db.Pool])
+		assert.Equal(t, 2, seen[db.replicas[1].db.Pool])
+		assert.Zero(t, seen[db.Pool])
+	})
+
+	t.Run("fails over to the primary and skips the down replica", func(t *testing.T) {
+		db := withReplicas(1)
+		var got []Querier
+		read := func(_ context.Context, q Querier) error {
+			got = append(got, q)
+			if q != Querier(db.Pool) {
+				return io.ErrUnexpectedEOF
+			}
+			return nil
+		}
+
+		require.NoError(t, db.Read(ctx, read))
+		assert.Equal(t, []Querier{db.replicas[0].db.Pool, db.Pool}, got)
+		assert.Equal(t, int64(1), db.Failovers())
+
+		got = nil
+		require.NoError(t, db.Read(ctx, read))
+		assert.Equal(t, []Querier{db.Pool}, got)
+		up, total := db.ReplicasUp()
+		assert.Equal(t, 0, up)
+		assert.Equal(t, 1, total)
+	})
+
+	t.Run("fails over on an open replica circuit", func(t *testing.T) {
+		db := withReplicas(2)
+		calls := 0
+		require.NoError(t, db.Read(ctx, func(_ context.Context, q Querier) error {
+			calls++
+			if calls == 1 {
+				return ErrUnavailable
+			}
+			return nil
+		}))
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, int64(1), db.Failovers())
+	})
+
+	t.Run("returns other errors without failing over", func(t *testing.T) {
+		db := withReplicas(2)
+		errNoRows := errors.New("no rows")
+		calls := 0
+		err := db.Read(ctx, func(context.Context, Querier) error {
+			calls++
+			return errNoRows
+		})
+		assert.ErrorIs(t, err, errNoRows)
+		assert.Equal(t, 1, calls)
+		assert.Zero(t, db.Failovers())
+		up, _ := db.ReplicasUp()
+		assert.Equal(t, 2, up)
+	})
+}
@@ -0,0 +1,113 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// RetryPolicy configures Retry. Attempts is the most times an operation runs,
+// retries included; 0 or 1 never retries. The wait before retry n is random
+// between 0 and BaseDelay*2^(n-1), capped at MaxDelay (full jitter), so
+// requests failing together do not retry together.
+type RetryPolicy struct {
+	Attempts  int
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+// retryableCodes are the SQLSTATEs of errors that a new attempt can succeed
+// after: the transaction lost a conflict, or the server was not accepting
+// connections.
+var retryableCodes = map[string]bool{
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+	"53300": true, // too_many_connections
+	"57P01": true, // admin_shutdown
+	"57P02": true, // crash_shutdown
+	"57P03": true, // cannot_connect_now
+}
+
+// retryBudgetKey is the context key of the retries left to a request.
+type retryBudgetKey struct{}
+
+// WithRetryBudget limits the retries of every Retry call made with ctx to n in
+// total, so a request cannot multiply its load on a struggling database.
+// Without a budget only the policy's attempts bound the retries.
+func WithRetryBudget(ctx context.Context, n int) context.Context {
+	budget := &atomic.Int64{}
+	budget.Store(int64(n))
+	return context.WithValue(ctx, retryBudgetKey{}, budget)
+}
+
+// takeRetry reports whether ctx's budget allows another retry, using it up.
+func takeRetry(ctx context.Context) bool {
+	budget, ok := ctx.Value(retryBudgetKey{}).(*atomic.Int64)
+	return !ok || budget.Add(-1) >= 0
+}
+
+// IsTransient reports whether err is a database error that a new attempt can
+// succeed after: a serialization failure or deadlock, a server shutting down
+// or out of connections, a connection that could not be established while
+// acquiring it from the pool, or one reset or closed mid-statement. Errors of
+// an open circuit breaker and cancelled or expired contexts are not transient.
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, ErrUnavailable) ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// Class 08 is connection_exception
+		return retryableCodes[pgErr.Code] || strings.HasPrefix(pgErr.Code, "08")
+	}
+	var connectErr *pgconn.ConnectError
+	return errors.As(err, &connectErr) || pgconn.SafeToRetry(err) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// Retry runs fn until it succeeds, fails with an error that is not transient,
+// or the policy's attempts or ctx's retry budget (WithRetryBudget) run out,
+// and returns fn's last error. fn must be safe to repeat: a statement whose
+// connection reset may have run.
+func (db *Database) Retry(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := fn(ctx)
+	for attempt := 1; attempt < db.retry.Attempts && IsTransient(err) && takeRetry(ctx); attempt++ {
+		timer := time.NewTimer(db.retry.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		db.retries.Add(1)
+		err = fn(ctx)
+	}
+	return err
+}
+
+// backoff returns the random wait before retry n, counting from 1.
+func (p RetryPolicy) backoff(n int) time.Duration {
+	ceiling := p.MaxDelay
+	if shift := n - 1; shift < 32 && p.BaseDelay<<shift < ceiling && p.BaseDelay<<shift > 0 {
+		ceiling = p.BaseDelay << shift
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling + 1)
+}
+
+// Retries returns how many times Retry ran an operation again.
+func (db *Database) Retries() int64 {
+	return db.retries.Load()
+}
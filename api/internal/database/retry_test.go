@@ -0,0 +1,130 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTransient(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"serialization failure", &pgconn.PgError{Code: "40001"}, true},
+		{"deadlock", fmt.Errorf("failed to query: %w", &pgconn.PgError{Code: "40P01"}), true},
+		{"connection exception", &pgconn.PgError{Code: "08006"}, true},
+		{"server shutting down", &pgconn.PgError{Code: "57P01"}, true},
+		{"syntax error", &pgconn.PgError{Code: "42601"}, false},
+		{"query canceled", &pgconn.PgError{Code: "57014"}, false},
+		{"connection reset", fmt.Errorf("read: %w", syscall.ECONNRESET), true},
+		{"unexpected EOF", io.ErrUnexpectedEOF, true},
+		{"connect failure", &pgconn.ConnectError{}, true},
+		{"circuit open", fmt.Errorf("%w: %w", ErrUnavailable, &pgconn.ConnectError{}), false},
+		{"deadline", fmt.Errorf("%w: %w", context.DeadlineExceeded, syscall.ECONNRESET), false},
+		{"other", errors.New("no rows"), false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsTransient(tt.err))
+		})
+	}
+}
+
+func TestRetry(t *testing.T) {
+	transient := &pgconn.PgError{Code: "40001"}
+
+	t.Run("retries transient errors until success", func(t *testing.T) {
+		db := &Database{retry: RetryPolicy{Attempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}}
+		calls := 0
+		err := db.Retry(context.Background(), func(context.Context) error {
+			calls++
+			if calls < 3 {
+				return transient
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, int64(2), db.Retries())
+	})
+
+	t.Run("stops after the attempts", func(t *testing.T) {
+		db := &Database{retry: RetryPolicy{Attempts: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}}
+		calls := 0
+		err := db.Retry(context.Background(), func(context.Context) error {
+			calls++
+			return transient
+		})
+		assert.ErrorIs(t, err, transient)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		db := &Database{retry: RetryPolicy{Attempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}}
+		calls := 0
+		_ = db.Retry(context.Background(), func(context.Context) error {
+			calls++
+			return errors.New("invalid input")
+		})
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("zero policy runs once", func(t *testing.T) {
+		db := &Database{}
+		calls := 0
+		_ = db.Retry(context.Background(), func(context.Context) error {
+			calls++
+			return transient
+		})
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("budget is shared by the calls of a request", func(t *testing.T) {
+		db := &Database{retry: RetryPolicy{Attempts: 5, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}}
+		ctx := WithRetryBudget(context.Background(), 3)
+		calls := 0
+		fail := func(context.Context) error {
+			calls++
+			return transient
+		}
+		_ = db.Retry(ctx, fail)
+		assert.Equal(t, 4, calls, "first call uses the whole budget")
+		_ = db.Retry(ctx, fail)
+		assert.Equal(t, 5, calls, "no retries are left")
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		db := &Database{retry: RetryPolicy{Attempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour}}
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		err := db.Retry(ctx, func(context.Context) error {
+			calls++
+			cancel()
+			return transient
+		})
+		assert.ErrorIs(t, err, transient)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 25 * time.Millisecond}
+
+	for range 100 {
+		assert.LessOrEqual(t, p.backoff(1), 10*time.Millisecond)
+		assert.LessOrEqual(t, p.backoff(2), 20*time.Millisecond)
+		assert.LessOrEqual(t, p.backoff(3), 25*time.Millisecond, "capped at MaxDelay")
+		assert.LessOrEqual(t, p.backoff(64), 25*time.Millisecond, "large shifts do not overflow")
+		assert.GreaterOrEqual(t, p.backoff(2), time.Duration(0))
+	}
+	assert.Equal(t, time.Duration(0), RetryPolicy{}.backoff(1))
+}
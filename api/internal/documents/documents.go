@@ -0,0 +1,166 @@
+// Package documents finds the recorded documents (deeds, plats, other clerk's
+// instruments) a parcel's records cite and links them to the county clerk's
+// records search. References are parsed from free text such as legal
+// descriptions; links are built from per-county URL templates.
+package documents
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// ErrInvalidTemplate is returned for unusable document URL templates.
+var ErrInvalidTemplate = errors.New("invalid document URL template")
+
+// Template placeholders, replaced by the query-escaped reference fields
+const (
+	PlaceholderVolume     = "{volume}"
+	PlaceholderPage       = "{page}"
+	PlaceholderInstrument = "{instrument}"
+	PlaceholderCabinet    = "{cabinet}"
+	PlaceholderSlide      = "{slide}"
+)
+
+// Citation patterns as written in Texas legal descriptions, e.g. "VOL 1234 PG 56",
+// "V. 12, P. 345", "CLERK'S FILE NO. 2019-012345", "INST# 2019012345", "CAB A SHEET 12"
+// and "CABINET Z, SLIDE 1234B".
+var (
+	volumePagePattern = regexp.MustCompile(`(?i)\b(?:VOLUME|VOL|V)\.?\s*(\d{1,6})\s*[,/]?\s*(?:PAGES?|PGS?|P)\.?\s*(\d{1,6})\b`)
+	instrumentPattern = regexp.MustCompile(`(?i)\b(?:INSTRUMENT|INST|DOCUMENT|DOC|CLERK'?S\s+FILE|CF|FILE)\s*(?:NUMBER|NBR|NO)?\.?\s*[#:]?\s*(\d[\d-]{3,}\d)\b`)
+	platPattern       = regexp.MustCompile(`(?i)\b(?:CABINET|CAB)\.?\s*([A-Z]{1,2}|\d{1,4})\s*[,/]?\s*(?:SHEETS?|SHTS?|SLIDES?|SL)\.?\s*(\d{1,5}[A-Z]?)\b`)
+	placeholderRegexp = regexp.MustCompile(`\{[^{}]*\}`)
+)
+
+// placeholders lists the placeholders of each document type.
+var placeholders = map[string][]string{
+	models.DocumentTypeVolumePage: {PlaceholderVolume, PlaceholderPage},
+	models.DocumentTypeInstrument: {PlaceholderInstrument},
+	models.DocumentTypePlat:       {PlaceholderCabinet, PlaceholderSlide},
+}
+
+// match is a reference found at a position in the text.
+type match struct {
+	ref   models.DocumentReference
+	start int
+}
+
+// Parse returns the document references cited in text, in the order they
+// appear, without duplicates. source is recorded on each reference.
+func Parse(text, source string) []models.DocumentReference {
+	var matches []match
+	for _, m := range volumePagePattern.FindAllStringSubmatchIndex(text, -1) {
+		matches = append(matches, match{start: m[0], ref: models.DocumentReference{
+			Type:   models.DocumentTypeVolumePage,
+			Volume: trimZeros(text[m[2]:m[3]]),
+			Page:   trimZeros(text[m[4]:m[5]]),
+			Text:   text[m[0]:m[1]],
+		}})
+	}
+	for _, m := range instrumentPattern.FindAllStringSubmatchIndex(text, -1) {
+		matches = append(matches, match{start: m[0], ref: models.DocumentReference{
+			Type:       models.DocumentTypeInstrument,
+			Instrument: text[m[2]:m[3]],
+			Text:       text[m[0]:m[1]],
+		}})
+	}
+	for _, m := range platPattern.FindAllStringSubmatchIndex(text, -1) {
+		matches = append(matches, match{start: m[0], ref: models.DocumentReference{
+			Type:    models.DocumentTypePlat,
+			Cabinet: strings.ToUpper(trimZeros(text[m[2]:m[3]])),
+			Slide:   strings.ToUpper(text[m[4]:m[5]]),
+			Text:    text[m[0]:m[1]],
+		}})
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].start < matches[j].start })
+
+	refs := make([]models.DocumentReference, 0, len(matches))
+	seen := make(map[models.DocumentReference]bool, len(matches))
+	for _, m := range matches {
+		key := m.ref
+		key.Text = ""
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		m.ref.Source = source
+		refs = append(refs, m.ref)
+	}
+	return refs
+}
+
+// Link sets the URL of each reference whose type has a template. References
+// of other types are left without a URL.
+func Link(refs []models.DocumentReference, templates map[string]string) {
+	for i := range refs {
+		template, ok := templates[refs[i].Type]
+		if !ok {
+			continue
+		}
+		refs[i].URL = strings.NewReplacer(
+			PlaceholderVolume, url.QueryEscape(refs[i].Volume),
+			PlaceholderPage, url.QueryEscape(refs[i].Page),
+			PlaceholderInstrument, url.QueryEscape(refs[i].Instrument),
+			PlaceholderCabinet, url.QueryEscape(refs[i].Cabinet),
+			PlaceholderSlide, url.QueryEscape(refs[i].Slide),
+		).Replace(template)
+	}
+}
+
+// ValidateTemplates checks a county's templates: each key must be a document
+// type, each template an absolute http(s) URL using only its type's placeholders.
+// Returns ErrInvalidTemplate describing the first problem.
+func ValidateTemplates(templates map[string]string) error {
+	types := make([]string, 0, len(templates))
+	for docType := range templates {
+		types = append(types, docType)
+	}
+	sort.Strings(types)
+
+	for _, docType := range types {
+		allowed, ok := placeholders[docType]
+		if !ok {
+			return fmt.Errorf("%w: unknown document type %q, expected %s, %s or %s", ErrInvalidTemplate, docType,
+				models.DocumentTypeVolumePage, models.DocumentTypeInstrument, models.DocumentTypePlat)
+		}
+
+		template := templates[docType]
+		for _, placeholder := range placeholderRegexp.FindAllString(template, -1) {
+			if !contains(allowed, placeholder) {
+				return fmt.Errorf("%w: %s template uses %s, expected %s", ErrInvalidTemplate, docType,
+					placeholder, strings.Join(allowed, " and "))
+			}
+		}
+
+		u, err := url.Parse(placeholderRegexp.ReplaceAllString(template, "x"))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: %s template must be an absolute http(s) URL", ErrInvalidTemplate, docType)
+		}
+	}
+	return nil
+}
+
+// trimZeros drops leading zeros from a number, keeping "0".
+func trimZeros(s string) string {
+	trimmed := strings.TrimLeft(s, "0")
+	if trimmed == "" && s != "" {
+		return "0"
+	}
+	return trimmed
+}
+
+// contains reports whether values includes value.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package documents
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []models.DocumentReference
+	}{
+		{
+			name: "volume and page",
+			text: "A0123 - SMITH SURVEY, TRACT 4, ACRES 2.5, VOL 01234 PG 056",
+			want: []models.DocumentReference{
+				{Type: models.DocumentTypeVolumePage, Volume: "1234", Page: "56", Text: "VOL 01234 PG 056"},
+			},
+		},
+		{
+			name: "abbreviated volume and page",
+			text: "BEING PART OF TRACT DESC IN V. 12, P. 345",
+			want: []models.DocumentReference{
+				{Type: models.DocumentTypeVolumePage, Volume: "12", Page: "345", Text: "V. 12, P. 345"},
+			},
+		},
+		{
+			name: "instrument numbers",
+			text: "LOT 7, CLERK'S FILE NO. 2019-012345 & INST# 2021098765",
+			want: []models.DocumentReference{
+				{Type: models.DocumentTypeInstrument, Instrument: "2019-012345", Text: "CLERK'S FILE NO. 2019-012345"},
+				{Type: models.DocumentTypeInstrument, Instrument: "2021098765", Text: "INST# 2021098765"},
+			},
+		},
+		{
+			name: "plat cabinet and slide",
+			text: "S1234 - LAKE ESTATES, CAB Z SHEET 1234b, BLOCK 2",
+			want: []models.DocumentReference{
+				{Type: models.DocumentTypePlat, Cabinet: "Z", Slide: "1234B", Text: "CAB Z SHEET 1234b"},
+			},
+		},
+		{
+			name: "mixed citations in order without duplicates",
+			text: "CABINET 3, SLIDE 45; VOL 10 PG 20; VOLUME 10 PAGE 20",
+			want: []models.DocumentReference{
+				{Type: models.DocumentTypePlat, Cabinet: "3", Slide: "45", Text: "CABINET 3, SLIDE 45"},
+				{Type: models.DocumentTypeVolumePage, Volume: "10", Page: "20", Text: "VOL 10 PG 20"},
+			},
+		},
+		{
+			name: "no citations",
+			text: "S1234 - LAKE ESTATES, BLOCK 2, LOT 7",
+			want: []models.DocumentReference{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := range tt.want {
+				tt.want[i].Source = models.DocumentSourceLegalDescription
+			}
+			assert.Equal(t, tt.want, Parse(tt.text, models.DocumentSourceLegalDescription))
+		})
+	}
+}
+
+func TestLink(t *testing.T) {
+	refs := []models.DocumentReference{
+		{Type: models.DocumentTypeVolumePage, Volume: "12", Page: "345"},
+		{Type: models.DocumentTypeInstrument, Instrument: "2019 012345"},
+		{Type: models.DocumentTypePlat, Cabinet: "Z", Slide: "12"},
+	}
+
+	Link(refs, map[string]string{
+		models.DocumentTypeVolumePage: "https://clerk.example.gov/search?vol={volume}&page={page}",
+		models.DocumentTypeInstrument: "https://clerk.example.gov/doc/{instrument}",
+	})
+
+	assert.Equal(t, "https://clerk.example.gov/search?vol=12&page=345", refs[0].URL)
+	assert.Equal(t, "https://clerk.example.gov/doc/2019+012345", refs[1].URL, "values are escaped")
+	assert.Empty(t, refs[2].URL, "types without a template are not linked")
+}
+
+func TestValidateTemplates(t *testing.T) {
+	tests := []struct {
+		name      string
+		templates map[string]string
+		wantErr   bool
+	}{
+		{"none", nil, false},
+		{"valid", map[string]string{
+			models.DocumentTypeVolumePage: "https://clerk.example.gov/search?vol={volume}&page={page}",
+			models.DocumentTypePlat:       "http://clerk.example.gov/plats/{cabinet}/{slide}",
+		}, false},
+		{"unknown type", map[string]string{"survey": "https://clerk.example.gov/{volume}"}, true},
+		{"placeholder of another type", map[string]string{
+			models.DocumentTypeInstrument: "https://clerk.example.gov/search?vol={volume}",
+		}, true},
+		{"relative URL", map[string]string{models.DocumentTypeInstrument: "/doc/{instrument}"}, true},
+		{"javascript URL", map[string]string{models.DocumentTypeInstrument: "javascript:alert({instrument})"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTemplates(tt.templates)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidTemplate)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+// Package domain defines the kinds of errors services report, so handlers
+// translate them to HTTP responses in one place (errors.Respond) instead of
+// checking every sentinel error. A service declares its sentinel errors with
+// the constructor of their kind:
+//
+//	ErrParcelNotFound = domain.NotFound("parcel not found")
+//
+// and may wrap them with details, as in fmt.Errorf("%w: id %d",
+// ErrParcelNotFound, id); errors.Is matches both the sentinel and its kind.
+// Errors of other packages are classified with Mark.
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Error kinds. Client kinds describe a request the caller can correct;
+// ErrUnavailable and ErrTimeout describe the server's state.
+var (
+	ErrValidation   = errors.New("invalid request")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrUnavailable  = errors.New("unavailable")
+	ErrTimeout      = errors.New("timed out")
+)
+
+// kinds are the error kinds in the order KindOf checks them.
+var kinds = []error{
+	ErrValidation, ErrNotFound, ErrConflict, ErrUnauthorized,
+	ErrForbidden, ErrRateLimited, ErrUnavailable, ErrTimeout,
+}
+
+// Error is an error of a kind. Its message is safe to show to clients.
+type Error struct {
+	kind       error
+	err        error
+	message    string
+	retryAfter time.Duration
+}
+
+// New returns an error of kind with message.
+func New(kind error, message string) *Error {
+	return &Error{kind: kind, message: message}
+}
+
+// Validation returns an error of a request with invalid parameters.
+func Validation(message string) *Error { return New(ErrValidation, message) }
+
+// NotFound returns an error of a resource that does not exist.
+func NotFound(message string) *Error { return New(ErrNotFound, message) }
+
+// Conflict returns an error of a request conflicting with a resource's state.
+func Conflict(message string) *Error { return New(ErrConflict, message) }
+
+// Unauthorized returns an error of missing or invalid credentials.
+func Unauthorized(message string) *Error { return New(ErrUnauthorized, message) }
+
+// Forbidden returns an error of credentials not allowing a request.
+func Forbidden(message string) *Error { return New(ErrForbidden, message) }
+
+// RateLimited returns an error of a caller sending too many requests.
+func RateLimited(message string) *Error { return New(ErrRateLimited, message) }
+
+// Unavailable returns an error of a dependency or queue unable to serve now.
+func Unavailable(message string) *Error { return New(ErrUnavailable, message) }
+
+// Timeout returns an error of an operation exceeding its time limit.
+func Timeout(message string) *Error { return New(ErrTimeout, message) }
+
+// Error returns the message.
+func (e *Error) Error() string { return e.message }
+
+// Unwrap returns the error Mark or Reword classified, if any.
+func (e *Error) Unwrap() error { return e.err }
+
+// Is reports whether target is e's kind.
+func (e *Error) Is(target error) bool { return target == e.kind }
+
+// Kind returns e's kind, one of the Err* kinds.
+func (e *Error) Kind() error { return e.kind }
+
+// WithRetryAfter returns a copy of e suggesting clients retry after d, for
+// ErrRateLimited and ErrUnavailable errors.
+func (e *Error) WithRetryAfter(d time.Duration) *Error {
+	c := *e
+	c.retryAfter = d
+	return &c
+}
+
+// Mark classifies err, of a package without domain errors, as kind, keeping
+// its message. It returns nil for a nil err.
+func Mark(kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{kind: kind, err: err, message: err.Error()}
+}
+
+// Reword replaces the client message of err when it is of kind, so a handler
+// can describe a service's error in its own terms (e.g. "bundle not found" for
+// a missing job); errors.Is still matches err. Other errors are returned as is.
+func Reword(err, kind error, message string) error {
+	if !errors.Is(err, kind) {
+		return err
+	}
+	return &Error{kind: kind, err: err, message: message, retryAfter: RetryAfter(err)}
+}
+
+// KindOf returns the kind of err, or nil when it has none, as for unexpected
+// failures.
+func KindOf(err error) error {
+	for _, kind := range kinds {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+	return nil
+}
+
+// Message returns the message of the domain error err wraps, without the
+// details of the errors wrapping it, or "" when it wraps none.
+func Message(err error) string {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr.message
+	}
+	return ""
+}
+
+// RetryAfter returns the retry delay of the domain error err wraps, 0 when it
+// suggests none.
+func RetryAfter(err error) time.Duration {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr.retryAfter
+	}
+	return 0
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError(t *testing.T) {
+	errMissing := NotFound("parcel not found")
+	wrapped := fmt.Errorf("%w: id 7", errMissing)
+
+	assert.ErrorIs(t, wrapped, errMissing)
+	assert.ErrorIs(t, wrapped, ErrNotFound)
+	assert.NotErrorIs(t, wrapped, ErrValidation)
+	assert.Equal(t, ErrNotFound, KindOf(wrapped))
+	assert.Equal(t, "parcel not found: id 7", wrapped.Error())
+	assert.Equal(t, "parcel not found", Message(wrapped))
+	assert.NotErrorIs(t, NotFound("parcel not found"), errMissing, "sentinels match by identity")
+}
+
+func TestKindOf(t *testing.T) {
+	assert.Nil(t, KindOf(nil))
+	assert.Nil(t, KindOf(errors.New("connection refused")))
+	for _, kind := range kinds {
+		assert.Equal(t, kind, KindOf(New(kind, "message")))
+	}
+}
+
+func TestMark(t *testing.T) {
+	cause := errors.New("invalid cursor")
+	err := Mark(ErrValidation, cause)
+
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, ErrValidation, KindOf(err))
+	assert.Equal(t, "invalid cursor", err.Error())
+	assert.Nil(t, Mark(ErrValidation, nil))
+}
+
+func TestReword(t *testing.T) {
+	errQueueFull := Unavailable("job queue is full").WithRetryAfter(30 * time.Second)
+	err := Reword(fmt.Errorf("submit: %w", errQueueFull), ErrUnavailable, "too many bundles are being built")
+
+	assert.ErrorIs(t, err, errQueueFull)
+	assert.Equal(t, "too many bundles are being built", err.Error())
+	assert.Equal(t, 30*time.Second, RetryAfter(err))
+
+	other := errors.New("boom")
+	assert.Equal(t, other, Reword(other, ErrNotFound, "missing"))
+}
+
+func TestWithRetryAfter(t *testing.T) {
+	base := RateLimited("slow down")
+	limited := base.WithRetryAfter(time.Minute)
+
+	assert.Equal(t, time.Minute, RetryAfter(limited))
+	assert.Equal(t, time.Duration(0), RetryAfter(base), "the original is unchanged")
+	assert.Equal(t, time.Duration(0), RetryAfter(errors.New("boom")))
+}
@@ -0,0 +1,102 @@
+package embed
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Token errors
+var (
+	ErrMalformedToken   = errors.New("malformed embed token")
+	ErrInvalidSignature = errors.New("invalid embed token signature")
+	ErrExpiredToken     = errors.New("embed token has expired")
+)
+
+// Claims identifies the widget an embed token grants access to.
+type Claims struct {
+	TenantID  string `json:"tid"`
+	Widget    string `json:"wid"`
+	ExpiresAt int64  `json:"exp"` // Unix seconds
+}
+
+// Signer issues and verifies HMAC-SHA256 signed embed tokens.
+// Tokens have the form base64url(claims JSON) + "." + base64url(signature).
+type Signer struct {
+	now    func() time.Time
+	secret []byte
+	ttl    time.Duration
+}
+
+// NewSigner creates a Signer using the given secret and token lifetime.
+func NewSigner(secret string, ttl time.Duration) *Signer {
+	return &Signer{
+		secret: []byte(secret),
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+// Issue creates a signed token for the tenant's widget.
+// It returns the token and its expiry time.
+func (s *Signer) Issue(tenantID, widget string) (string, time.Time, error) {
+	expiresAt := s.now().Add(s.ttl).Truncate(time.Second)
+
+	payload, err := json.Marshal(Claims{
+		TenantID:  tenantID,
+		Widget:    widget,
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to marshal embed claims: %w", err)
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	token := encoded + "." + base64.RawURLEncoding.EncodeToString(s.sign(encoded))
+
+	return token, expiresAt, nil
+}
+
+// Verify checks the token signature and expiry and returns its claims.
+func (s *Signer) Verify(token string) (*Claims, error) {
+	encoded, signature, found := strings.Cut(token, ".")
+	if !found || encoded == "" || signature == "" {
+		return nil, ErrMalformedToken
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	if !hmac.Equal(sig, s.sign(encoded)) {
+		return nil, ErrInvalidSignature
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	if s.now().Unix() >= claims.ExpiresAt {
+		return nil, ErrExpiredToken
+	}
+
+	return &claims, nil
+}
+
+// sign computes the HMAC-SHA256 of the encoded claims.
+func (s *Signer) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
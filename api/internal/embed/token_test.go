@@ -0,0 +1,71 @@
+package embed
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func TestSigner_IssueAndVerify(t *testing.T) {
+	signer := NewSigner(testSecret, time.Hour)
+
+	token, expiresAt, err := signer.Issue("acme", "county-viewer")
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, 2*time.Second)
+
+	claims, err := signer.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", claims.TenantID)
+	assert.Equal(t, "county-viewer", claims.Widget)
+	assert.Equal(t, expiresAt.Unix(), claims.ExpiresAt)
+}
+
+func TestSigner_Verify_Expired(t *testing.T) {
+	signer := NewSigner(testSecret, time.Minute)
+	token, _, err := signer.Issue("acme", "county-viewer")
+	require.NoError(t, err)
+
+	// Advance the clock past the token lifetime
+	signer.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+
+	_, err = signer.Verify(token)
+	assert.ErrorIs(t, err, ErrExpiredToken)
+}
+
+func TestSigner_Verify_WrongSecret(t *testing.T) {
+	token, _, err := NewSigner(testSecret, time.Hour).Issue("acme", "county-viewer")
+	require.NoError(t, err)
+
+	_, err = NewSigner(strings.Repeat("x", 32), time.Hour).Verify(token)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestSigner_Verify_Tampered(t *testing.T) {
+	signer := NewSigner(testSecret, time.Hour)
+	token, _, err := signer.Issue("acme", "county-viewer")
+	require.NoError(t, err)
+
+	// Swap in claims for another tenant while keeping the original signature
+	other, _, err := signer.Issue("other", "county-viewer")
+	require.NoError(t, err)
+	otherClaims, _, _ := strings.Cut(other, ".")
+	_, signature, _ := strings.Cut(token, ".")
+
+	_, err = signer.Verify(otherClaims + "." + signature)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestSigner_Verify_Malformed(t *testing.T) {
+	signer := NewSigner(testSecret, time.Hour)
+
+	for _, token := range []string{"", "no-dot", ".sig", "claims.", "claims.!!!"} {
+		_, err := signer.Verify(token)
+		assert.ErrorIs(t, err, ErrMalformedToken, token)
+	}
+}
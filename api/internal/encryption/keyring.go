@@ -0,0 +1,296 @@
+package encryption
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// Prefix marks an encrypted column value: enc:v1:<data key ID>:<base64 ciphertext>.
+// Values without it are plaintext written before encryption was enabled.
+const Prefix = "enc:v1:"
+
+// Keyring errors
+var (
+	ErrUnknownDataKey  = errors.New("unknown data key")
+	ErrMalformedValue  = errors.New("malformed encrypted value")
+	ErrNoKeyring       = errors.New("encryption is not configured")
+	ErrDecryptionFails = errors.New("failed to decrypt value")
+)
+
+// Subkey labels; each data key derives one key for AES-GCM and one for nonces.
+var (
+	cipherKeyLabel = []byte("atlas column cipher")
+	nonceKeyLabel  = []byte("atlas column nonce")
+)
+
+// DataKeyStore persists wrapped data keys. repository.EncryptionRepository implements it.
+type DataKeyStore interface {
+	// ListDataKeys returns every data key ordered by ID.
+	ListDataKeys(ctx context.Context) ([]models.DataKey, error)
+
+	// CreateDataKey stores a data key wrapped by the master key masterKeyID.
+	CreateDataKey(ctx context.Context, masterKeyID string, wrapped []byte) (*models.DataKey, error)
+
+	// RewrapDataKey replaces the wrapped form of the data key id.
+	RewrapDataKey(ctx context.Context, id uint, masterKeyID string, wrapped []byte) error
+}
+
+// dataKey is an unwrapped data key ready to encrypt and decrypt values.
+type dataKey struct {
+	aead     cipher.AEAD
+	nonceKey []byte
+}
+
+// Keyring holds the unwrapped data keys. The newest key encrypts; every key decrypts.
+//
+// Encryption is deterministic: the nonce is an HMAC of the plaintext, so equal
+// values encrypt to equal ciphertexts under the same data key. Ingest relies on
+// this to detect unchanged rows by content hash, at the cost of revealing which
+// parcels share a value. A nil *Keyring leaves values in plaintext.
+type Keyring struct {
+	store    DataKeyStore
+	provider KeyProvider
+	keys     map[uint]*dataKey
+	mu       sync.RWMutex
+	active   uint
+}
+
+// Open loads and unwraps the data keys, creating the first one if none exist.
+func Open(ctx context.Context, store DataKeyStore, provider KeyProvider) (*Keyring, error) {
+	k := &Keyring{store: store, provider: provider}
+	if err := k.load(ctx); err != nil {
+		return nil, err
+	}
+	if k.active == 0 {
+		if _, err := k.RotateDataKey(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// load replaces the keys with the stored data keys.
+func (k *Keyring) load(ctx context.Context) error {
+	stored, err := k.store.ListDataKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list data keys: %w", err)
+	}
+
+	keys := make(map[uint]*dataKey, len(stored))
+	var active uint
+	for _, s := range stored {
+		raw, err := k.provider.Unwrap(ctx, s.MasterKeyID, s.WrappedKey)
+		if err != nil {
+			return fmt.Errorf("data key %d: %w", s.ID, err)
+		}
+		key, err := newDataKey(raw)
+		if err != nil {
+			return fmt.Errorf("data key %d: %w", s.ID, err)
+		}
+		keys[s.ID] = key
+		if s.ID > active {
+			active = s.ID
+		}
+	}
+
+	k.mu.Lock()
+	k.keys, k.active = keys, active
+	k.mu.Unlock()
+	return nil
+}
+
+// newDataKey derives the cipher and nonce subkeys of a raw data key.
+func newDataKey(raw []byte) (*dataKey, error) {
+	if len(raw) != KeySize {
+		return nil, fmt.Errorf("%w: must be %d bytes", ErrMalformedKey, KeySize)
+	}
+	aead, err := newAEAD(subkey(raw, cipherKeyLabel))
+	if err != nil {
+		return nil, err
+	}
+	return &dataKey{aead: aead, nonceKey: subkey(raw, nonceKeyLabel)}, nil
+}
+
+// subkey derives a KeySize key from a data key for one purpose.
+func subkey(key, label []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(label)
+	return mac.Sum(nil)
+}
+
+// RotateDataKey creates a data key wrapped by the provider's active master key
+// and makes it the key that encrypts new values. Other processes pick it up
+// when they first read a value encrypted with it.
+func (k *Keyring) RotateDataKey(ctx context.Context) (uint, error) {
+	raw := make([]byte, KeySize)
+	if _, err := rand.Read(raw); err != nil {
+		return 0, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	key, err := newDataKey(raw)
+	if err != nil {
+		return 0, err
+	}
+
+	masterKeyID := k.provider.ActiveKeyID()
+	wrapped, err := k.provider.Wrap(ctx, masterKeyID, raw)
+	if err != nil {
+		return 0, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	stored, err := k.store.CreateDataKey(ctx, masterKeyID, wrapped)
+	if err != nil {
+		return 0, fmt.Errorf("failed to store data key: %w", err)
+	}
+
+	k.mu.Lock()
+	if k.keys == nil {
+		k.keys = make(map[uint]*dataKey)
+	}
+	k.keys[stored.ID] = key
+	if stored.ID > k.active {
+		k.active = stored.ID
+	}
+	k.mu.Unlock()
+	return stored.ID, nil
+}
+
+// RewrapDataKeys rewraps the data keys wrapped by any master key other than the
+// provider's active one, so retired master keys can be removed. Values are not
+// re-encrypted. Returns the number of data keys rewrapped.
+func (k *Keyring) RewrapDataKeys(ctx context.Context) (int, error) {
+	stored, err := k.store.ListDataKeys(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list data keys: %w", err)
+	}
+
+	active := k.provider.ActiveKeyID()
+	rewrapped := 0
+	for _, s := range stored {
+		if s.MasterKeyID == active {
+			continue
+		}
+		raw, err := k.provider.Unwrap(ctx, s.MasterKeyID, s.WrappedKey)
+		if err != nil {
+			return rewrapped, fmt.Errorf("data key %d: %w", s.ID, err)
+		}
+		wrapped, err := k.provider.Wrap(ctx, active, raw)
+		if err != nil {
+			return rewrapped, fmt.Errorf("data key %d: %w", s.ID, err)
+		}
+		if err := k.store.RewrapDataKey(ctx, s.ID, active, wrapped); err != nil {
+			return rewrapped, fmt.Errorf("failed to store data key %d: %w", s.ID, err)
+		}
+		rewrapped++
+	}
+	return rewrapped, nil
+}
+
+// ActiveKeyID returns the ID of the data key that encrypts new values.
+func (k *Keyring) ActiveKeyID() uint {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.active
+}
+
+// ActivePrefix returns the prefix of values encrypted with the active data key.
+func (k *Keyring) ActivePrefix() string {
+	return Prefix + strconv.FormatUint(uint64(k.ActiveKeyID()), 10) + ":"
+}
+
+// key returns the data key id, or nil if it is not loaded.
+func (k *Keyring) key(id uint) *dataKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.keys[id]
+}
+
+// Encrypt encrypts a value with the active data key. A nil Keyring returns the
+// value unchanged.
+func (k *Keyring) Encrypt(value string) (string, error) {
+	if k == nil {
+		return value, nil
+	}
+	k.mu.RLock()
+	id, key := k.active, k.keys[k.active]
+	k.mu.RUnlock()
+	if key == nil {
+		return "", ErrUnknownDataKey
+	}
+
+	mac := hmac.New(sha256.New, key.nonceKey)
+	mac.Write([]byte(value))
+	nonce := mac.Sum(nil)[:key.aead.NonceSize()]
+
+	sealed := key.aead.Seal(nonce, nonce, []byte(value), nil)
+	return Prefix + strconv.FormatUint(uint64(id), 10) + ":" +
+		base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// EncryptText encrypts an optional value; nil stays nil.
+func (k *Keyring) EncryptText(value *string) (*string, error) {
+	if value == nil || k == nil {
+		return value, nil
+	}
+	encrypted, err := k.Encrypt(*value)
+	if err != nil {
+		return nil, err
+	}
+	return &encrypted, nil
+}
+
+// Decrypt decrypts a value written by Encrypt. Plaintext values are returned
+// unchanged. A data key created by another process since the keys were loaded
+// is loaded on first use.
+func (k *Keyring) Decrypt(ctx context.Context, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	if k == nil {
+		return "", ErrNoKeyring
+	}
+
+	idText, encoded, ok := strings.Cut(strings.TrimPrefix(value, Prefix), ":")
+	id, err := strconv.ParseUint(idText, 10, 32)
+	if !ok || err != nil {
+		return "", ErrMalformedValue
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", ErrMalformedValue
+	}
+
+	key := k.key(uint(id))
+	if key == nil {
+		if err := k.load(ctx); err != nil {
+			return "", err
+		}
+		if key = k.key(uint(id)); key == nil {
+			return "", fmt.Errorf("%w %d", ErrUnknownDataKey, id)
+		}
+	}
+	if len(sealed) < key.aead.NonceSize() {
+		return "", ErrMalformedValue
+	}
+
+	nonce, ciphertext := sealed[:key.aead.NonceSize()], sealed[key.aead.NonceSize():]
+	plaintext, err := key.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("%w with data key %d", ErrDecryptionFails, id)
+	}
+	return string(plaintext), nil
+}
+
+// IsEncrypted reports whether a column value was written by Encrypt.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
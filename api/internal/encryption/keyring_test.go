@@ -0,0 +1,192 @@
+package encryption
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// memoryStore is an in-memory DataKeyStore.
+type memoryStore struct {
+	keys []models.DataKey
+}
+
+func (s *memoryStore) ListDataKeys(context.Context) ([]models.DataKey, error) {
+	return append([]models.DataKey(nil), s.keys...), nil
+}
+
+func (s *memoryStore) CreateDataKey(_ context.Context, masterKeyID string, wrapped []byte) (*models.DataKey, error) {
+	key := models.DataKey{
+		ID:          uint(len(s.keys) + 1),
+		MasterKeyID: masterKeyID,
+		WrappedKey:  wrapped,
+		CreatedAt:   time.Now(),
+	}
+	s.keys = append(s.keys, key)
+	return &key, nil
+}
+
+func (s *memoryStore) RewrapDataKey(_ context.Context, id uint, masterKeyID string, wrapped []byte) error {
+	s.keys[id-1].MasterKeyID = masterKeyID
+	s.keys[id-1].WrappedKey = wrapped
+	return nil
+}
+
+// openTestKeyring opens a keyring on store with master keys "a" and "b", "a" active.
+func openTestKeyring(t *testing.T, store *memoryStore, active string) *Keyring {
+	t.Helper()
+	provider, err := NewStaticKeyProvider(map[string][]byte{"a": testKey(1), "b": testKey(2)}, active)
+	require.NoError(t, err)
+	keyring, err := Open(context.Background(), store, provider)
+	require.NoError(t, err)
+	return keyring
+}
+
+func TestKeyring_EncryptDecrypt(t *testing.T) {
+	ctx := context.Background()
+	store := &memoryStore{}
+	keyring := openTestKeyring(t, store, "a")
+
+	// Open creates the first data key
+	require.Len(t, store.keys, 1)
+	assert.Equal(t, uint(1), keyring.ActiveKeyID())
+
+	encrypted, err := keyring.Encrypt("100 Main St")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encrypted, keyring.ActivePrefix()))
+	assert.NotContains(t, encrypted, "Main")
+
+	// Deterministic, so unchanged rows keep their content hash
+	again, err := keyring.Encrypt("100 Main St")
+	require.NoError(t, err)
+	assert.Equal(t, encrypted, again)
+
+	other, err := keyring.Encrypt("200 Main St")
+	require.NoError(t, err)
+	assert.NotEqual(t, encrypted, other)
+
+	decrypted, err := keyring.Decrypt(ctx, encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "100 Main St", decrypted)
+
+	// Plaintext written before encryption was enabled passes through
+	plain, err := keyring.Decrypt(ctx, "100 Main St")
+	require.NoError(t, err)
+	assert.Equal(t, "100 Main St", plain)
+}
+
+func TestKeyring_Decrypt_Invalid(t *testing.T) {
+	ctx := context.Background()
+	keyring := openTestKeyring(t, &memoryStore{}, "a")
+	encrypted, err := keyring.Encrypt("100 Main St")
+	require.NoError(t, err)
+
+	_, err = keyring.Decrypt(ctx, Prefix+"x:abc")
+	assert.ErrorIs(t, err, ErrMalformedValue)
+
+	_, err = keyring.Decrypt(ctx, Prefix+"7:abc")
+	assert.ErrorIs(t, err, ErrUnknownDataKey)
+
+	// Change a character in the middle of the ciphertext
+	i := len(encrypted) - 8
+	replacement := "A"
+	if encrypted[i] == 'A' {
+		replacement = "B"
+	}
+	tampered := encrypted[:i] + replacement + encrypted[i+1:]
+	_, err = keyring.Decrypt(ctx, tampered)
+	assert.ErrorIs(t, err, ErrDecryptionFails)
+
+	var disabled *Keyring
+	_, err = disabled.Decrypt(ctx, encrypted)
+	assert.ErrorIs(t, err, ErrNoKeyring)
+}
+
+func TestKeyring_Nil(t *testing.T) {
+	var keyring *Keyring
+
+	encrypted, err := keyring.Encrypt("100 Main St")
+	require.NoError(t, err)
+	assert.Equal(t, "100 Main St", encrypted)
+
+	value := "100 Main St"
+	text, err := keyring.EncryptText(&value)
+	require.NoError(t, err)
+	assert.Equal(t, &value, text)
+}
+
+func TestKeyring_RotateDataKey(t *testing.T) {
+	ctx := context.Background()
+	store := &memoryStore{}
+	keyring := openTestKeyring(t, store, "a")
+
+	before, err := keyring.Encrypt("100 Main St")
+	require.NoError(t, err)
+
+	id, err := keyring.RotateDataKey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), id)
+	assert.Equal(t, uint(2), keyring.ActiveKeyID())
+
+	after, err := keyring.Encrypt("100 Main St")
+	require.NoError(t, err)
+	assert.NotEqual(t, before, after)
+
+	// Both keys still decrypt
+	for _, value := range []string{before, after} {
+		decrypted, err := keyring.Decrypt(ctx, value)
+		require.NoError(t, err)
+		assert.Equal(t, "100 Main St", decrypted)
+	}
+}
+
+func TestKeyring_Decrypt_LoadsNewDataKey(t *testing.T) {
+	ctx := context.Background()
+	store := &memoryStore{}
+	reader := openTestKeyring(t, store, "a")
+
+	// Another process rotates the data key and encrypts with it
+	writer := openTestKeyring(t, store, "a")
+	_, err := writer.RotateDataKey(ctx)
+	require.NoError(t, err)
+	encrypted, err := writer.Encrypt("100 Main St")
+	require.NoError(t, err)
+
+	decrypted, err := reader.Decrypt(ctx, encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "100 Main St", decrypted)
+}
+
+func TestKeyring_RewrapDataKeys(t *testing.T) {
+	ctx := context.Background()
+	store := &memoryStore{}
+	keyring := openTestKeyring(t, store, "a")
+	encrypted, err := keyring.Encrypt("100 Main St")
+	require.NoError(t, err)
+
+	// Master key b becomes active
+	rotated := openTestKeyring(t, store, "b")
+	count, err := rotated.RewrapDataKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "b", store.keys[0].MasterKeyID)
+
+	// Rewrapping again is a no-op
+	count, err = rotated.RewrapDataKeys(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	// Master key a can be retired; values still decrypt
+	provider, err := NewStaticKeyProvider(map[string][]byte{"b": testKey(2)}, "b")
+	require.NoError(t, err)
+	retired, err := Open(ctx, store, provider)
+	require.NoError(t, err)
+	decrypted, err := retired.Decrypt(ctx, encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "100 Main St", decrypted)
+}
@@ -0,0 +1,155 @@
+// Package encryption encrypts owner contact columns (owner_address, and future
+// phone and email enrichment fields) at rest with envelope encryption: values
+// are encrypted with AES-256 data keys stored in the database, and the data
+// keys are stored wrapped by a master key that never leaves the KeyProvider.
+// Rotating the master key rewraps the data keys; rotating the data key adds a
+// new one that encrypts new values while older keys keep decrypting old ones.
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// KeySize is the size of master and data keys in bytes (AES-256).
+const KeySize = 32
+
+// Key provider errors
+var (
+	ErrUnknownMasterKey = errors.New("unknown master key")
+	ErrMalformedKey     = errors.New("malformed key")
+)
+
+// KeyProvider wraps data keys with master keys it holds, so master keys are never
+// stored with the data. StaticKeyProvider reads them from the configuration; a
+// provider backed by a secrets manager or KMS implements the same interface.
+type KeyProvider interface {
+	// ActiveKeyID returns the ID of the master key that wraps new data keys.
+	ActiveKeyID() string
+
+	// Wrap encrypts a data key with the master key keyID.
+	Wrap(ctx context.Context, keyID string, dataKey []byte) ([]byte, error)
+
+	// Unwrap decrypts a data key wrapped by the master key keyID.
+	// Returns ErrUnknownMasterKey if the provider does not hold the key.
+	Unwrap(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// StaticKeyProvider holds master keys in memory, wrapping data keys with AES-256-GCM.
+type StaticKeyProvider struct {
+	keys   map[string]cipher.AEAD
+	active string
+}
+
+// ParseMasterKeys parses ENCRYPTION_MASTER_KEYS: comma-separated id:key pairs
+// where each key is KeySize bytes, base64-encoded.
+func ParseMasterKeys(value string) (map[string][]byte, error) {
+	keys := make(map[string][]byte)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		id, encoded, ok := strings.Cut(pair, ":")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("%w: expected id:base64", ErrMalformedKey)
+		}
+		if _, dup := keys[id]; dup {
+			return nil, fmt.Errorf("%w: duplicate key %q", ErrMalformedKey, id)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("%w: key %q is not base64", ErrMalformedKey, id)
+		}
+		if len(key) != KeySize {
+			return nil, fmt.Errorf("%w: key %q must be %d bytes", ErrMalformedKey, id, KeySize)
+		}
+		keys[id] = key
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: no keys", ErrMalformedKey)
+	}
+	return keys, nil
+}
+
+// NewStaticKeyProvider creates a StaticKeyProvider that wraps new data keys with
+// the master key active. Every key must be KeySize bytes.
+func NewStaticKeyProvider(keys map[string][]byte, active string) (*StaticKeyProvider, error) {
+	if _, ok := keys[active]; !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownMasterKey, active)
+	}
+	p := &StaticKeyProvider{keys: make(map[string]cipher.AEAD, len(keys)), active: active}
+	for id, key := range keys {
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("master key %q: %w", id, err)
+		}
+		p.keys[id] = aead
+	}
+	return p, nil
+}
+
+// ActiveKeyID returns the ID of the master key that wraps new data keys.
+func (p *StaticKeyProvider) ActiveKeyID() string {
+	return p.active
+}
+
+// KeyIDs returns the IDs of the master keys held, sorted.
+func (p *StaticKeyProvider) KeyIDs() []string {
+	ids := make([]string, 0, len(p.keys))
+	for id := range p.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Wrap encrypts a data key with the master key keyID. The random nonce is
+// prepended to the ciphertext.
+func (p *StaticKeyProvider) Wrap(_ context.Context, keyID string, dataKey []byte) ([]byte, error) {
+	aead, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownMasterKey, keyID)
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, dataKey, []byte(keyID)), nil
+}
+
+// Unwrap decrypts a data key wrapped by Wrap with the master key keyID.
+func (p *StaticKeyProvider) Unwrap(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	aead, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownMasterKey, keyID)
+	}
+	if len(wrapped) < aead.NonceSize() {
+		return nil, fmt.Errorf("%w: wrapped data key is truncated", ErrMalformedKey)
+	}
+	nonce, ciphertext := wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():]
+	dataKey, err := aead.Open(nil, nonce, ciphertext, []byte(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key with master key %q: %w", keyID, err)
+	}
+	return dataKey, nil
+}
+
+// newAEAD returns AES-256-GCM for a KeySize key.
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("%w: must be %d bytes", ErrMalformedKey, KeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
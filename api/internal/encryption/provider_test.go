@@ -0,0 +1,68 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testKey returns a KeySize key filled with b.
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, KeySize)
+}
+
+func TestParseMasterKeys(t *testing.T) {
+	one := base64.StdEncoding.EncodeToString(testKey(1))
+	two := base64.StdEncoding.EncodeToString(testKey(2))
+
+	keys, err := ParseMasterKeys("2024:" + one + ", 2025:" + two)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"2024": testKey(1), "2025": testKey(2)}, keys)
+
+	for name, value := range map[string]string{
+		"empty":     "",
+		"no id":     ":" + one,
+		"no colon":  one,
+		"not b64":   "a:not-base64!",
+		"too short": "a:" + base64.StdEncoding.EncodeToString([]byte("short")),
+		"duplicate": "a:" + one + ",a:" + two,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseMasterKeys(value)
+			assert.ErrorIs(t, err, ErrMalformedKey)
+		})
+	}
+}
+
+func TestStaticKeyProvider_WrapUnwrap(t *testing.T) {
+	ctx := context.Background()
+	provider, err := NewStaticKeyProvider(map[string][]byte{"old": testKey(1), "new": testKey(2)}, "new")
+	require.NoError(t, err)
+	assert.Equal(t, "new", provider.ActiveKeyID())
+	assert.Equal(t, []string{"new", "old"}, provider.KeyIDs())
+
+	wrapped, err := provider.Wrap(ctx, "old", testKey(9))
+	require.NoError(t, err)
+	assert.NotContains(t, string(wrapped), string(testKey(9)))
+
+	unwrapped, err := provider.Unwrap(ctx, "old", wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, testKey(9), unwrapped)
+
+	// The key ID is authenticated, so a key wrapped by one master key cannot be
+	// unwrapped as another's
+	_, err = provider.Unwrap(ctx, "new", wrapped)
+	assert.Error(t, err)
+
+	_, err = provider.Unwrap(ctx, "gone", wrapped)
+	assert.ErrorIs(t, err, ErrUnknownMasterKey)
+}
+
+func TestNewStaticKeyProvider_UnknownActive(t *testing.T) {
+	_, err := NewStaticKeyProvider(map[string][]byte{"a": testKey(1)}, "b")
+	assert.ErrorIs(t, err, ErrUnknownMasterKey)
+}
@@ -0,0 +1,79 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+)
+
+// Batch endpoints process several items per request (e.g. the parcels of a
+// comparison). An item that fails, because it does not exist or its data is
+// corrupt, is reported as an ItemError next to the items that succeeded, and
+// the response is 207 Multi-Status; see BatchStatus. Errors of the request as
+// a whole (invalid parameters, an unreachable database) still use the error
+// responses of errors.go.
+
+// ErrItemPanic is reported by Isolate for an item whose processing panicked.
+var ErrItemPanic = stderrors.New("item processing panicked")
+
+// ItemError is the error of one item of a batch request. Index is the item's
+// position in the request, Code one of the Err* codes.
+type ItemError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Index   int    `json:"index"`
+}
+
+// NewItemError returns the error of the item at index.
+func NewItemError(index int, code, message string) ItemError {
+	return ItemError{Index: index, Code: code, Message: message}
+}
+
+// InternalItemError logs err and returns a generic error for the item at index;
+// like InternalServerError, the details are not exposed to the client.
+func InternalItemError(c *gin.Context, index int, message string, err error) ItemError {
+	if log := middleware.GetLogger(c); log != nil {
+		log.Error("Batch item failed", err, map[string]interface{}{
+			"message":    message,
+			"index":      index,
+			"request_id": middleware.GetRequestID(c),
+			"path":       c.Request.URL.Path,
+		})
+	}
+	return NewItemError(index, ErrInternalServer, message)
+}
+
+// BatchStatus returns the status of a batch response: 200 OK when no item
+// failed, otherwise 207 Multi-Status, even when every item failed, so clients
+// read the per-item errors in both cases.
+func BatchStatus(itemErrors []ItemError) int {
+	if len(itemErrors) == 0 {
+		return http.StatusOK
+	}
+	return http.StatusMultiStatus
+}
+
+// Isolate runs the processing of one batch item and turns a panic into an
+// error wrapping ErrItemPanic, logged with its stack, so one corrupt item
+// cannot fail the whole request.
+func Isolate(c *gin.Context, fn func() error) (err error) {
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+		err = fmt.Errorf("%w: %v", ErrItemPanic, recovered)
+		if log := middleware.GetLogger(c); log != nil {
+			log.Error("Panic recovered in batch item", err, map[string]interface{}{
+				"request_id": middleware.GetRequestID(c),
+				"path":       c.Request.URL.Path,
+				"stack":      string(debug.Stack()),
+			})
+		}
+	}()
+	return fn()
+}
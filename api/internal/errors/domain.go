@@ -0,0 +1,60 @@
+package errors
+
+import (
+	"math"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+)
+
+// defaultRetryAfterSeconds is the Retry-After of rate limited and unavailable
+// responses whose error suggests no delay.
+const defaultRetryAfterSeconds = 10
+
+// Respond writes the error response of err by its domain kind (see package
+// domain), the one translation of service errors to HTTP:
+//
+//	domain.ErrValidation   400 BAD_REQUEST
+//	domain.ErrUnauthorized 401 UNAUTHORIZED
+//	domain.ErrForbidden    403 FORBIDDEN
+//	domain.ErrNotFound     404 NOT_FOUND
+//	domain.ErrConflict     409 CONFLICT
+//	domain.ErrRateLimited  429 TOO_MANY_REQUESTS, with Retry-After
+//	domain.ErrUnavailable  503 SERVICE_UNAVAILABLE, with Retry-After
+//	domain.ErrTimeout      504 TIMEOUT
+//
+// Client kinds answer with err's message, details of the wrapping errors
+// included; server kinds only with the domain error's own message, as the
+// errors wrapping it may describe internals. Errors of no kind are
+// InternalServerError with message.
+func Respond(c *gin.Context, message string, err error) {
+	switch domain.KindOf(err) {
+	case domain.ErrValidation:
+		BadRequest(c, err.Error(), nil)
+	case domain.ErrUnauthorized:
+		Unauthorized(c, err.Error())
+	case domain.ErrForbidden:
+		Forbidden(c, err.Error())
+	case domain.ErrNotFound:
+		NotFound(c, err.Error())
+	case domain.ErrConflict:
+		Conflict(c, err.Error())
+	case domain.ErrRateLimited:
+		TooManyRequests(c, err.Error(), retryAfterSeconds(err))
+	case domain.ErrUnavailable:
+		ServiceUnavailable(c, domain.Message(err), retryAfterSeconds(err))
+	case domain.ErrTimeout:
+		GatewayTimeout(c, domain.Message(err))
+	default:
+		InternalServerError(c, message, err)
+	}
+}
+
+// retryAfterSeconds returns the retry delay err suggests in whole seconds,
+// rounded up, or defaultRetryAfterSeconds.
+func retryAfterSeconds(err error) int {
+	if d := domain.RetryAfter(err); d > 0 {
+		return int(math.Ceil(d.Seconds()))
+	}
+	return defaultRetryAfterSeconds
+}
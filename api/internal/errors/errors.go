@@ -1,10 +1,13 @@
 package errors
 
 import (
+	stderrors "errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/stwalsh4118/atlas/api/internal/domain"
 	"github.com/stwalsh4118/atlas/api/internal/middleware"
 )
 
@@ -15,6 +18,12 @@ const (
 	ErrInternalServer     = "INTERNAL_SERVER_ERROR"
 	ErrValidation         = "VALIDATION_ERROR"
 	ErrDatabaseConnection = "DATABASE_CONNECTION_ERROR"
+	ErrUnauthorized       = "UNAUTHORIZED"
+	ErrForbidden          = "FORBIDDEN"
+	ErrConflict           = "CONFLICT"
+	ErrUnavailable        = "SERVICE_UNAVAILABLE"
+	ErrTimeout            = "TIMEOUT"
+	ErrTooManyRequests    = "TOO_MANY_REQUESTS"
 )
 
 // ErrorResponse is the top-level error response structure.
@@ -82,10 +91,158 @@ func BadRequest(c *gin.Context, message string, details map[string]interface{})
 	})
 }
 
+// Unauthorized returns a 401 Unauthorized error response.
+// It is used when credentials are missing, malformed, or expired.
+func Unauthorized(c *gin.Context, message string) {
+	log := middleware.GetLogger(c)
+	requestID := middleware.GetRequestID(c)
+
+	if log != nil {
+		log.Warn("Unauthorized request", map[string]interface{}{
+			"message":    message,
+			"request_id": requestID,
+			"path":       c.Request.URL.Path,
+		})
+	}
+
+	c.JSON(http.StatusUnauthorized, ErrorResponse{
+		Error: ErrorDetail{
+			Code:      ErrUnauthorized,
+			Message:   message,
+			RequestID: requestID,
+		},
+	})
+}
+
+// Forbidden returns a 403 Forbidden error response.
+// It is used when valid credentials do not grant access to the requested resource.
+func Forbidden(c *gin.Context, message string) {
+	log := middleware.GetLogger(c)
+	requestID := middleware.GetRequestID(c)
+
+	if log != nil {
+		log.Warn("Forbidden request", map[string]interface{}{
+			"message":    message,
+			"request_id": requestID,
+			"path":       c.Request.URL.Path,
+		})
+	}
+
+	c.JSON(http.StatusForbidden, ErrorResponse{
+		Error: ErrorDetail{
+			Code:      ErrForbidden,
+			Message:   message,
+			RequestID: requestID,
+		},
+	})
+}
+
+// Conflict returns a 409 Conflict error response.
+// It is used when the resource exists but is not in a state that allows the request.
+func Conflict(c *gin.Context, message string) {
+	log := middleware.GetLogger(c)
+	requestID := middleware.GetRequestID(c)
+
+	if log != nil {
+		log.Warn("Conflicting request", map[string]interface{}{
+			"message":    message,
+			"request_id": requestID,
+			"path":       c.Request.URL.Path,
+		})
+	}
+
+	c.JSON(http.StatusConflict, ErrorResponse{
+		Error: ErrorDetail{
+			Code:      ErrConflict,
+			Message:   message,
+			RequestID: requestID,
+		},
+	})
+}
+
+// ServiceUnavailable returns a 503 Service Unavailable error response with a
+// Retry-After header in seconds. It is used when the server is temporarily at capacity.
+func ServiceUnavailable(c *gin.Context, message string, retryAfterSeconds int) {
+	log := middleware.GetLogger(c)
+	requestID := middleware.GetRequestID(c)
+
+	if log != nil {
+		log.Error("Service unavailable", nil, map[string]interface{}{
+			"message":    message,
+			"request_id": requestID,
+			"path":       c.Request.URL.Path,
+		})
+	}
+
+	c.Header("Retry-After", strconv.Itoa(retryAfterSeconds))
+	c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+		Error: ErrorDetail{
+			Code:      ErrUnavailable,
+			Message:   message,
+			RequestID: requestID,
+		},
+	})
+}
+
+// TooManyRequests returns a 429 Too Many Requests error response with a
+// Retry-After header in seconds. It is used when a caller exceeds a rate limit.
+func TooManyRequests(c *gin.Context, message string, retryAfterSeconds int) {
+	log := middleware.GetLogger(c)
+	requestID := middleware.GetRequestID(c)
+
+	if log != nil {
+		log.Warn("Rate limited request", map[string]interface{}{
+			"message":    message,
+			"request_id": requestID,
+			"path":       c.Request.URL.Path,
+		})
+	}
+
+	c.Header("Retry-After", strconv.Itoa(retryAfterSeconds))
+	c.JSON(http.StatusTooManyRequests, ErrorResponse{
+		Error: ErrorDetail{
+			Code:      ErrTooManyRequests,
+			Message:   message,
+			RequestID: requestID,
+		},
+	})
+}
+
+// GatewayTimeout returns a 504 Gateway Timeout error response.
+// It is used when a database statement exceeds its time limit.
+func GatewayTimeout(c *gin.Context, message string) {
+	log := middleware.GetLogger(c)
+	requestID := middleware.GetRequestID(c)
+
+	if log != nil {
+		log.Warn("Request timed out", map[string]interface{}{
+			"message":    message,
+			"request_id": requestID,
+			"path":       c.Request.URL.Path,
+		})
+	}
+
+	c.JSON(http.StatusGatewayTimeout, ErrorResponse{
+		Error: ErrorDetail{
+			Code:      ErrTimeout,
+			Message:   message,
+			RequestID: requestID,
+		},
+	})
+}
+
 // InternalServerError returns a 500 Internal Server Error response.
 // It logs the error with full context and sends a generic error message to the client.
 // The actual error details are not exposed to the client for security reasons.
+// Errors of kind domain.ErrUnavailable, such as those of an open database
+// circuit breaker (database.ErrUnavailable), return 503 Service Unavailable
+// instead, so clients back off during an outage.
 func InternalServerError(c *gin.Context, message string, err error) {
+	if stderrors.Is(err, domain.ErrUnavailable) {
+		ServiceUnavailable(c, domain.Message(err), retryAfterSeconds(err))
+		return
+	}
+
 	log := middleware.GetLogger(c)
 	requestID := middleware.GetRequestID(c)
 
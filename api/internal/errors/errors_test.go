@@ -4,16 +4,20 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/domain"
 	"github.com/stwalsh4118/atlas/api/internal/logger"
 	"github.com/stwalsh4118/atlas/api/internal/middleware"
 )
@@ -103,6 +107,83 @@ func TestBadRequest(t *testing.T) {
 	})
 }
 
+func TestUnauthorized(t *testing.T) {
+	c, w := setupTestContext()
+
+	Unauthorized(c, "Missing token")
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "Expected status 401 Unauthorized")
+
+	response := parseErrorResponse(t, w.Body)
+	assert.Equal(t, ErrUnauthorized, response.Error.Code, "Expected UNAUTHORIZED error code")
+	assert.Equal(t, "Missing token", response.Error.Message, "Expected correct error message")
+	assert.Equal(t, "test-request-id", response.Error.RequestID, "Expected request ID in response")
+}
+
+func TestForbidden(t *testing.T) {
+	c, w := setupTestContext()
+
+	Forbidden(c, "Access denied")
+
+	assert.Equal(t, http.StatusForbidden, w.Code, "Expected status 403 Forbidden")
+
+	response := parseErrorResponse(t, w.Body)
+	assert.Equal(t, ErrForbidden, response.Error.Code, "Expected FORBIDDEN error code")
+	assert.Equal(t, "Access denied", response.Error.Message, "Expected correct error message")
+	assert.Equal(t, "test-request-id", response.Error.RequestID, "Expected request ID in response")
+}
+
+func TestConflict(t *testing.T) {
+	c, w := setupTestContext()
+
+	Conflict(c, "Bundle is not ready")
+
+	assert.Equal(t, http.StatusConflict, w.Code, "Expected status 409 Conflict")
+
+	response := parseErrorResponse(t, w.Body)
+	assert.Equal(t, ErrConflict, response.Error.Code, "Expected CONFLICT error code")
+	assert.Equal(t, "Bundle is not ready", response.Error.Message, "Expected correct error message")
+	assert.Equal(t, "test-request-id", response.Error.RequestID, "Expected request ID in response")
+}
+
+func TestServiceUnavailable(t *testing.T) {
+	c, w := setupTestContext()
+
+	ServiceUnavailable(c, "Too many bundles are being built", 30)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code, "Expected status 503 Service Unavailable")
+	assert.Equal(t, "30", w.Header().Get("Retry-After"), "Expected Retry-After header")
+
+	response := parseErrorResponse(t, w.Body)
+	assert.Equal(t, ErrUnavailable, response.Error.Code, "Expected SERVICE_UNAVAILABLE error code")
+	assert.Equal(t, "Too many bundles are being built", response.Error.Message, "Expected correct error message")
+}
+
+func TestTooManyRequests(t *testing.T) {
+	c, w := setupTestContext()
+
+	TooManyRequests(c, "Slow down", 5)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code, "Expected status 429 Too Many Requests")
+	assert.Equal(t, "5", w.Header().Get("Retry-After"), "Expected Retry-After header")
+
+	response := parseErrorResponse(t, w.Body)
+	assert.Equal(t, ErrTooManyRequests, response.Error.Code, "Expected TOO_MANY_REQUESTS error code")
+	assert.Equal(t, "Slow down", response.Error.Message, "Expected correct error message")
+}
+
+func TestGatewayTimeout(t *testing.T) {
+	c, w := setupTestContext()
+
+	GatewayTimeout(c, "Query exceeded its timeout")
+
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code, "Expected status 504 Gateway Timeout")
+
+	response := parseErrorResponse(t, w.Body)
+	assert.Equal(t, ErrTimeout, response.Error.Code, "Expected TIMEOUT error code")
+	assert.Equal(t, "Query exceeded its timeout", response.Error.Message, "Expected correct error message")
+}
+
 func TestInternalServerError(t *testing.T) {
 	c, w := setupTestContext()
 
@@ -118,6 +199,63 @@ func TestInternalServerError(t *testing.T) {
 	assert.Nil(t, response.Error.Details, "Expected no details for InternalServerError")
 }
 
+func TestInternalServerError_DatabaseUnavailable(t *testing.T) {
+	c, w := setupTestContext()
+
+	err := fmt.Errorf("failed to query parcel: %w", database.ErrUnavailable)
+	InternalServerError(c, "Failed to query parcel", err)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code, "Expected status 503 while the database circuit is open")
+	assert.Equal(t, "10", w.Header().Get("Retry-After"), "Expected Retry-After header")
+
+	response := parseErrorResponse(t, w.Body)
+	assert.Equal(t, ErrUnavailable, response.Error.Code, "Expected SERVICE_UNAVAILABLE error code")
+}
+
+func TestRespond(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		status     int
+		code       string
+		message    string
+		retryAfter string
+	}{
+		{"validation", fmt.Errorf("%w: radius 0", domain.Validation("invalid radius")),
+			http.StatusBadRequest, ErrBadRequest, "invalid radius: radius 0", ""},
+		{"unauthorized", domain.Unauthorized("invalid credentials"),
+			http.StatusUnauthorized, ErrUnauthorized, "invalid credentials", ""},
+		{"forbidden", domain.Forbidden("link expired"),
+			http.StatusForbidden, ErrForbidden, "link expired", ""},
+		{"not found", domain.NotFound("style not found"),
+			http.StatusNotFound, ErrNotFound, "style not found", ""},
+		{"conflict", domain.Conflict("email taken"),
+			http.StatusConflict, ErrConflict, "email taken", ""},
+		{"rate limited", domain.RateLimited("slow down").WithRetryAfter(1500 * time.Millisecond),
+			http.StatusTooManyRequests, ErrTooManyRequests, "slow down", "2"},
+		{"unavailable hides wrapping details", fmt.Errorf("pool exhausted on db-1: %w", domain.Unavailable("try again shortly")),
+			http.StatusServiceUnavailable, ErrUnavailable, "try again shortly", "10"},
+		{"timeout hides wrapping details", fmt.Errorf("statement on db-1: %w", domain.Timeout("query timed out")),
+			http.StatusGatewayTimeout, ErrTimeout, "query timed out", ""},
+		{"no kind", errors.New("connection refused"),
+			http.StatusInternalServerError, ErrInternalServer, "Failed to load", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, w := setupTestContext()
+
+			Respond(c, "Failed to load", tt.err)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, tt.retryAfter, w.Header().Get("Retry-After"))
+			response := parseErrorResponse(t, w.Body)
+			assert.Equal(t, tt.code, response.Error.Code)
+			assert.Equal(t, tt.message, response.Error.Message)
+		})
+	}
+}
+
 func TestValidationError(t *testing.T) {
 	c, w := setupTestContext()
 
@@ -284,6 +422,43 @@ func TestErrorConstants(t *testing.T) {
 	assert.Equal(t, "INTERNAL_SERVER_ERROR", ErrInternalServer)
 	assert.Equal(t, "VALIDATION_ERROR", ErrValidation)
 	assert.Equal(t, "DATABASE_CONNECTION_ERROR", ErrDatabaseConnection)
+	assert.Equal(t, "UNAUTHORIZED", ErrUnauthorized)
+	assert.Equal(t, "FORBIDDEN", ErrForbidden)
+	assert.Equal(t, "CONFLICT", ErrConflict)
+	assert.Equal(t, "SERVICE_UNAVAILABLE", ErrUnavailable)
+	assert.Equal(t, "TIMEOUT", ErrTimeout)
+	assert.Equal(t, "TOO_MANY_REQUESTS", ErrTooManyRequests)
+}
+
+func TestBatchStatus(t *testing.T) {
+	assert.Equal(t, http.StatusOK, BatchStatus(nil))
+	assert.Equal(t, http.StatusMultiStatus, BatchStatus([]ItemError{NewItemError(1, ErrNotFound, "Parcel 9 not found")}))
+}
+
+func TestIsolate(t *testing.T) {
+	c, _ := setupTestContext()
+
+	assert.NoError(t, Isolate(c, func() error { return nil }))
+
+	failure := errors.New("bad coordinate")
+	assert.ErrorIs(t, Isolate(c, func() error { return failure }), failure)
+
+	err := Isolate(c, func() error {
+		var parcels []int
+		_ = parcels[3]
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrItemPanic)
+	assert.Contains(t, err.Error(), "index out of range")
+}
+
+func TestInternalItemError(t *testing.T) {
+	c, w := setupTestContext()
+
+	itemErr := InternalItemError(c, 2, "Parcel 7 could not be read", errors.New("corrupt geometry"))
+
+	assert.Equal(t, ItemError{Index: 2, Code: ErrInternalServer, Message: "Parcel 7 could not be read"}, itemErr)
+	assert.Zero(t, w.Body.Len(), "item errors do not write a response")
 }
 
 // mockFieldError is a mock implementation of validator.FieldError for testing.
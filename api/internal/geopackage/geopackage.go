@@ -0,0 +1,379 @@
+// Package geopackage writes OGC GeoPackage (.gpkg) files holding a single
+// MultiPolygon feature table in WGS84, the format QGIS, ArcGIS and mobile field
+// apps open natively. The SQLite database is produced directly in Go, so no
+// SQLite driver or cgo toolchain is needed.
+package geopackage
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// ContentType is the registered media type for GeoPackage files.
+const ContentType = "application/geopackage+sqlite3"
+
+// GeoPackage header values
+const (
+	applicationID = 0x47504B47 // "GPKG"
+	userVersion   = 10400      // GeoPackage 1.4
+	srsID         = 4326
+
+	// wgs84Definition is the OGC WKT definition of EPSG:4326 from the GeoPackage specification
+	wgs84Definition = `GEOGCS["WGS 84",DATUM["WGS_1984",SPHEROID["WGS 84",6378137,298.257223563,` +
+		`AUTHORITY["EPSG","7030"]],AUTHORITY["EPSG","6326"]],PRIMEM["Greenwich",0,AUTHORITY["EPSG","8901"]],` +
+		`UNIT["degree",0.0174532925199433,AUTHORITY["EPSG","9122"]],AUTHORITY["EPSG","4326"]]`
+
+	lastChangeLayout = "2006-01-02T15:04:05.000Z"
+)
+
+// Errors returned by the writer
+var (
+	ErrDuplicateFeature = errors.New("duplicate feature id")
+	ErrColumnMismatch   = errors.New("feature values do not match the columns")
+)
+
+// ColumnType is the declared SQLite type of an attribute column.
+type ColumnType string
+
+// Attribute column types
+const (
+	ColumnText    ColumnType = "TEXT"
+	ColumnInteger ColumnType = "INTEGER"
+	ColumnReal    ColumnType = "REAL"
+)
+
+// Column describes an attribute column of the feature table.
+type Column struct {
+	Name string
+	Type ColumnType
+}
+
+// Writer collects features in memory and writes them as a GeoPackage.
+// Features may be added in any order; they are stored sorted by ID.
+type Writer struct {
+	table       string
+	description string
+	columns     []Column
+	features    []feature
+	bounds      envelope
+}
+
+// feature is an added feature with its geometry already encoded.
+type feature struct {
+	geometry []byte
+	values   []any
+	id       int64
+}
+
+// NewWriter returns a writer for a feature table with the given name, description
+// and attribute columns. The table also has the fid primary key and geom columns.
+func NewWriter(table, description string, columns []Column) *Writer {
+	return &Writer{
+		table:       table,
+		description: description,
+		columns:     columns,
+		bounds:      emptyEnvelope(),
+	}
+}
+
+// Add adds a feature. values holds one entry per column; nil, string, int64 and
+// float64 are stored as is, and nil *string, *int and *float64 pointers become NULL.
+// Returns ErrColumnMismatch if the number or types of values do not match the columns.
+func (w *Writer) Add(id int64, geometry models.MultiPolygon, values ...any) error {
+	if len(values) != len(w.columns) {
+		return fmt.Errorf("%w: got %d values for %d columns", ErrColumnMismatch, len(values), len(w.columns))
+	}
+
+	row := make([]any, len(values))
+	for i, value := range values {
+		normalized, err := normalizeValue(value)
+		if err != nil {
+			return fmt.Errorf("%w: column %s: %w", ErrColumnMismatch, w.columns[i].Name, err)
+		}
+		row[i] = normalized
+	}
+
+	blob, bounds := encodeGeometry(geometry)
+	w.bounds.extend(bounds)
+	w.features = append(w.features, feature{id: id, geometry: blob, values: row})
+	return nil
+}
+
+// Len returns the number of features added.
+func (w *Writer) Len() int {
+	return len(w.features)
+}
+
+// WriteTo writes the GeoPackage to out. It implements io.WriterTo.
+// Returns ErrDuplicateFeature if two features share an ID.
+func (w *Writer) WriteTo(out io.Writer) (int64, error) {
+	data, err := w.build(time.Now().UTC())
+	if err != nil {
+		return 0, err
+	}
+	n, err := out.Write(data)
+	return int64(n), err
+}
+
+// build assembles the database file. Each table is written as a b-tree and
+// registered in sqlite_master, with the automatic indexes SQLite expects for the
+// PRIMARY KEY and UNIQUE constraints of the metadata tables.
+func (w *Writer) build(lastChange time.Time) ([]byte, error) {
+	slices.SortFunc(w.features, func(a, b feature) int {
+		return cmp.Compare(a.id, b.id)
+	})
+	for i := 1; i < len(w.features); i++ {
+		if w.features[i].id == w.features[i-1].id {
+			return nil, fmt.Errorf("%w: %d", ErrDuplicateFeature, w.features[i].id)
+		}
+	}
+
+	file := newDBFile()
+	var schema []tableRow
+	addSchema := func(kind, name, table string, root uint32, sql any) error {
+		record, err := encodeRecord(kind, name, table, int64(root), sql)
+		if err != nil {
+			return err
+		}
+		schema = append(schema, tableRow{rowid: int64(len(schema) + 1), record: record})
+		return nil
+	}
+	addIndex := func(table string, n int, keys ...any) error {
+		key, err := encodeRecord(keys...)
+		if err != nil {
+			return err
+		}
+		root, err := file.buildIndex([][]byte{key})
+		if err != nil {
+			return err
+		}
+		return addSchema("index", fmt.Sprintf("sqlite_autoindex_%s_%d", table, n), table, root, nil)
+	}
+
+	// gpkg_spatial_ref_sys: srs_id is the rowid, so its record column is NULL
+	srsRows := []struct {
+		name, organization, definition, description string
+		id                                          int64
+	}{
+		{id: -1, name: "Undefined cartesian SRS", organization: "NONE", definition: "undefined",
+			description: "undefined cartesian coordinate reference system"},
+		{id: 0, name: "Undefined geographic SRS", organization: "NONE", definition: "undefined",
+			description: "undefined geographic coordinate reference system"},
+		{id: srsID, name: "WGS 84 geodetic", organization: "EPSG", definition: wgs84Definition,
+			description: "longitude/latitude coordinates in decimal degrees on the WGS 84 spheroid"},
+	}
+	rows := make([]tableRow, 0, len(srsRows))
+	for _, srs := range srsRows {
+		record, err := encodeRecord(srs.name, nil, srs.organization, srs.id, srs.definition, srs.description)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, tableRow{rowid: srs.id, record: record})
+	}
+	if err := addSchema("table", "gpkg_spatial_ref_sys", "gpkg_spatial_ref_sys", file.buildTable(rows), createSpatialRefSys); err != nil {
+		return nil, err
+	}
+
+	// gpkg_contents: one row describing the feature table
+	var minX, minY, maxX, maxY any
+	if !w.bounds.isEmpty() {
+		minX, minY, maxX, maxY = w.bounds.minX, w.bounds.minY, w.bounds.maxX, w.bounds.maxY
+	}
+	record, err := encodeRecord(w.table, "features", w.table, w.description,
+		lastChange.Format(lastChangeLayout), minX, minY, maxX, maxY, int64(srsID))
+	if err != nil {
+		return nil, err
+	}
+	if err := addSchema("table", "gpkg_contents", "gpkg_contents",
+		file.buildTable([]tableRow{{rowid: 1, record: record}}), createContents); err != nil {
+		return nil, err
+	}
+	if err := addIndex("gpkg_contents", 1, w.table, int64(1)); err != nil {
+		return nil, err
+	}
+	if err := addIndex("gpkg_contents", 2, w.table, int64(1)); err != nil {
+		return nil, err
+	}
+
+	// gpkg_geometry_columns: the geom column of the feature table
+	record, err = encodeRecord(w.table, "geom", "MULTIPOLYGON", int64(srsID), int64(0), int64(0))
+	if err != nil {
+		return nil, err
+	}
+	if err := addSchema("table", "gpkg_geometry_columns", "gpkg_geometry_columns",
+		file.buildTable([]tableRow{{rowid: 1, record: record}}), createGeometryColumns); err != nil {
+		return nil, err
+	}
+	if err := addIndex("gpkg_geometry_columns", 1, w.table, "geom", int64(1)); err != nil {
+		return nil, err
+	}
+	if err := addIndex("gpkg_geometry_columns", 2, w.table, int64(1)); err != nil {
+		return nil, err
+	}
+
+	// The feature table: fid is the rowid, so its record column is NULL
+	rows = make([]tableRow, 0, len(w.features))
+	for _, f := range w.features {
+		values := append([]any{nil, f.geometry}, f.values...)
+		record, err := encodeRecord(values...)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, tableRow{rowid: f.id, record: record})
+	}
+	if err := addSchema("table", w.table, w.table, file.buildTable(rows), w.createFeatureTable()); err != nil {
+		return nil, err
+	}
+
+	if err := file.buildSchema(schema); err != nil {
+		return nil, err
+	}
+	return file.bytes(userVersion, applicationID), nil
+}
+
+// Table definitions from the GeoPackage specification
+const (
+	createSpatialRefSys = `CREATE TABLE gpkg_spatial_ref_sys (srs_name TEXT NOT NULL, ` +
+		`srs_id INTEGER PRIMARY KEY, organization TEXT NOT NULL, ` +
+		`organization_coordsys_id INTEGER NOT NULL, definition TEXT NOT NULL, description TEXT)`
+	createContents = `CREATE TABLE gpkg_contents (table_name TEXT NOT NULL PRIMARY KEY, ` +
+		`data_type TEXT NOT NULL, identifier TEXT UNIQUE, description TEXT DEFAULT '', ` +
+		`last_change DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')), ` +
+		`min_x DOUBLE, min_y DOUBLE, max_x DOUBLE, max_y DOUBLE, srs_id INTEGER, ` +
+		`CONSTRAINT fk_gc_r_srs_id FOREIGN KEY (srs_id) REFERENCES gpkg_spatial_ref_sys(srs_id))`
+	createGeometryColumns = `CREATE TABLE gpkg_geometry_columns (table_name TEXT NOT NULL, ` +
+		`column_name TEXT NOT NULL, geometry_type_name TEXT NOT NULL, srs_id INTEGER NOT NULL, ` +
+		`z TINYINT NOT NULL, m TINYINT NOT NULL, ` +
+		`CONSTRAINT pk_geom_cols PRIMARY KEY (table_name, column_name), ` +
+		`CONSTRAINT uk_gc_table_name UNIQUE (table_name), ` +
+		`CONSTRAINT fk_gc_tn FOREIGN KEY (table_name) REFERENCES gpkg_contents(table_name), ` +
+		`CONSTRAINT fk_gc_srs FOREIGN KEY (srs_id) REFERENCES gpkg_spatial_ref_sys (srs_id))`
+)
+
+// createFeatureTable returns the CREATE TABLE statement of the feature table.
+func (w *Writer) createFeatureTable() string {
+	sql := `CREATE TABLE "` + w.table + `" (fid INTEGER PRIMARY KEY NOT NULL, geom MULTIPOLYGON`
+	for _, column := range w.columns {
+		sql += `, "` + column.Name + `" ` + string(column.Type)
+	}
+	return sql + ")"
+}
+
+// normalizeValue converts a feature value to a record value.
+func normalizeValue(value any) (any, error) {
+	switch v := value.(type) {
+	case nil, string, int64, float64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case *string:
+		if v == nil {
+			return nil, nil
+		}
+		return *v, nil
+	case *int:
+		if v == nil {
+			return nil, nil
+		}
+		return int64(*v), nil
+	case *float64:
+		if v == nil {
+			return nil, nil
+		}
+		return *v, nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", value)
+	}
+}
+
+// WKB geometry type codes
+const (
+	wkbPolygon      = 3
+	wkbMultiPolygon = 6
+)
+
+// encodeGeometry encodes a MultiPolygon as a GeoPackage geometry blob: the "GP"
+// header with the SRS ID and an XY envelope, followed by little-endian WKB.
+func encodeGeometry(geometry models.MultiPolygon) ([]byte, envelope) {
+	bounds := emptyEnvelope()
+	for _, polygon := range geometry.Coordinates {
+		for _, ring := range polygon {
+			for _, position := range ring {
+				bounds.add(position[0], position[1])
+			}
+		}
+	}
+
+	flags := byte(0x01) // Little-endian header values
+	if bounds.isEmpty() {
+		flags |= 0x10 // Empty geometry, no envelope
+	} else {
+		flags |= 0x01 << 1 // Envelope is [minx, maxx, miny, maxy]
+	}
+
+	blob := []byte{'G', 'P', 0, flags}
+	blob = binary.LittleEndian.AppendUint32(blob, srsID)
+	if !bounds.isEmpty() {
+		for _, v := range []float64{bounds.minX, bounds.maxX, bounds.minY, bounds.maxY} {
+			blob = binary.LittleEndian.AppendUint64(blob, math.Float64bits(v))
+		}
+	}
+
+	blob = appendWKBHeader(blob, wkbMultiPolygon, len(geometry.Coordinates))
+	for _, polygon := range geometry.Coordinates {
+		blob = appendWKBHeader(blob, wkbPolygon, len(polygon))
+		for _, ring := range polygon {
+			blob = binary.LittleEndian.AppendUint32(blob, uint32(len(ring))) // #nosec G115 -- ring sizes fit uint32
+			for _, position := range ring {
+				blob = binary.LittleEndian.AppendUint64(blob, math.Float64bits(position[0]))
+				blob = binary.LittleEndian.AppendUint64(blob, math.Float64bits(position[1]))
+			}
+		}
+	}
+
+	return blob, bounds
+}
+
+// appendWKBHeader appends a little-endian WKB geometry header with its element count.
+func appendWKBHeader(blob []byte, geometryType uint32, count int) []byte {
+	blob = append(blob, 1) // Little-endian
+	blob = binary.LittleEndian.AppendUint32(blob, geometryType)
+	return binary.LittleEndian.AppendUint32(blob, uint32(count)) // #nosec G115 -- element counts fit uint32
+}
+
+// envelope is an XY bounding box; an empty envelope has min > max.
+type envelope struct {
+	minX, minY, maxX, maxY float64
+}
+
+// emptyEnvelope returns an envelope that contains nothing.
+func emptyEnvelope() envelope {
+	return envelope{minX: math.Inf(1), minY: math.Inf(1), maxX: math.Inf(-1), maxY: math.Inf(-1)}
+}
+
+func (e envelope) isEmpty() bool {
+	return e.minX > e.maxX
+}
+
+// add extends the envelope to contain the position.
+func (e *envelope) add(x, y float64) {
+	e.minX, e.maxX = math.Min(e.minX, x), math.Max(e.maxX, x)
+	e.minY, e.maxY = math.Min(e.minY, y), math.Max(e.maxY, y)
+}
+
+// extend extends the envelope to contain other.
+func (e *envelope) extend(other envelope) {
+	if other.isEmpty() {
+		return
+	}
+	e.add(other.minX, other.minY)
+	e.add(other.maxX, other.maxY)
+}
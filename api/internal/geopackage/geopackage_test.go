@@ -0,0 +1,122 @@
+package geopackage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// testSquare is a small closed square near Conroe, TX.
+var testSquare = models.MultiPolygon{Coordinates: [][][][2]float64{{{
+	{-95.4510, 30.3470}, {-95.4490, 30.3470}, {-95.4490, 30.3485}, {-95.4510, 30.3485}, {-95.4510, 30.3470},
+}}}}
+
+func TestPutVarint(t *testing.T) {
+	tests := []struct {
+		want  []byte
+		value uint64
+	}{
+		{value: 0, want: []byte{0x00}},
+		{value: 127, want: []byte{0x7f}},
+		{value: 128, want: []byte{0x81, 0x00}},
+		{value: 16383, want: []byte{0xff, 0x7f}},
+		{value: 1 << 56, want: []byte{0x80, 0xc0, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x00}},
+		{value: math.MaxUint64, want: bytes.Repeat([]byte{0xff}, 9)},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, putVarint(nil, tt.value), "value %d", tt.value)
+	}
+}
+
+func TestEncodeRecord(t *testing.T) {
+	record, err := encodeRecord(nil, int64(0), int64(1), int64(-2), int64(300), 1.5, "ab", []byte{0xff})
+	require.NoError(t, err)
+
+	// Header: size, NULL, 0, 1, int8, int16, float, text(2), blob(1)
+	assert.Equal(t, []byte{9, 0, 8, 9, 1, 2, 7, 17, 14}, record[:9])
+	body := record[9:]
+	assert.Equal(t, []byte{0xfe, 0x01, 0x2c}, body[:3])
+	assert.Equal(t, 1.5, math.Float64frombits(binary.BigEndian.Uint64(body[3:11])))
+	assert.Equal(t, []byte("ab\xff"), body[11:])
+
+	_, err = encodeRecord(struct{}{})
+	assert.Error(t, err)
+}
+
+func TestEncodeGeometry(t *testing.T) {
+	blob, bounds := encodeGeometry(testSquare)
+
+	assert.Equal(t, []byte{'G', 'P', 0, 0x03}, blob[:4], "little-endian with an XY envelope")
+	assert.Equal(t, uint32(srsID), binary.LittleEndian.Uint32(blob[4:8]))
+
+	envelope := make([]float64, 4)
+	for i := range envelope {
+		envelope[i] = math.Float64frombits(binary.LittleEndian.Uint64(blob[8+i*8:]))
+	}
+	assert.Equal(t, []float64{-95.4510, -95.4490, 30.3470, 30.3485}, envelope, "[minx, maxx, miny, maxy]")
+	assert.Equal(t, -95.4510, bounds.minX)
+
+	wkb := blob[40:]
+	assert.Equal(t, byte(1), wkb[0])
+	assert.Equal(t, uint32(wkbMultiPolygon), binary.LittleEndian.Uint32(wkb[1:5]))
+	assert.Equal(t, uint32(1), binary.LittleEndian.Uint32(wkb[5:9]), "polygons")
+	assert.Equal(t, uint32(wkbPolygon), binary.LittleEndian.Uint32(wkb[10:14]))
+	assert.Equal(t, uint32(1), binary.LittleEndian.Uint32(wkb[14:18]), "rings")
+	assert.Equal(t, uint32(5), binary.LittleEndian.Uint32(wkb[18:22]), "points")
+	assert.Equal(t, -95.4510, math.Float64frombits(binary.LittleEndian.Uint64(wkb[22:30])), "x is longitude")
+	assert.Len(t, wkb, 22+5*16)
+}
+
+func TestWriter_Build(t *testing.T) {
+	w := NewWriter("parcels", "Test bundle", []Column{
+		{Name: "owner_name", Type: ColumnText},
+		{Name: "year_built", Type: ColumnInteger},
+	})
+
+	owner := "Test Owner"
+	var yearBuilt *int
+	// Enough features with large geometries to need overflow and interior pages
+	for id := int64(300); id > 0; id-- {
+		square := testSquare
+		square.Coordinates = [][][][2]float64{{make([][2]float64, 0, 400)}}
+		for i := 0; i < 399; i++ {
+			square.Coordinates[0][0] = append(square.Coordinates[0][0], [2]float64{-95.45 + float64(i)*1e-6, 30.34})
+		}
+		square.Coordinates[0][0] = append(square.Coordinates[0][0], square.Coordinates[0][0][0])
+		require.NoError(t, w.Add(id, square, &owner, yearBuilt))
+	}
+	assert.Equal(t, 300, w.Len())
+
+	data, err := w.build(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+	require.NoError(t, err)
+
+	require.Zero(t, len(data)%pageSize)
+	assert.Equal(t, "SQLite format 3\x00", string(data[:16]))
+	assert.Equal(t, uint16(pageSize), binary.BigEndian.Uint16(data[16:18]))
+	assert.Equal(t, uint32(len(data)/pageSize), binary.BigEndian.Uint32(data[28:32]), "database size in pages")
+	assert.Equal(t, uint32(userVersion), binary.BigEndian.Uint32(data[60:64]))
+	assert.Equal(t, []byte("GPKG"), data[68:72], "application id")
+	assert.Equal(t, byte(pageTableLeaf), data[fileHeaderSize], "sqlite_master is a single leaf")
+	assert.Equal(t, uint16(8), binary.BigEndian.Uint16(data[fileHeaderSize+3:]), "4 tables and 4 automatic indexes")
+	assert.Contains(t, string(data), `CREATE TABLE "parcels" (fid INTEGER PRIMARY KEY NOT NULL, geom MULTIPOLYGON, "owner_name" TEXT, "year_built" INTEGER)`)
+	assert.Contains(t, string(data), "2025-01-02T03:04:05.000Z")
+}
+
+func TestWriter_Errors(t *testing.T) {
+	w := NewWriter("parcels", "", []Column{{Name: "owner_name", Type: ColumnText}})
+
+	assert.ErrorIs(t, w.Add(1, testSquare), ErrColumnMismatch)
+	assert.ErrorIs(t, w.Add(1, testSquare, true), ErrColumnMismatch)
+
+	require.NoError(t, w.Add(1, testSquare, "First"))
+	require.NoError(t, w.Add(1, testSquare, "Second"))
+	_, err := w.WriteTo(&bytes.Buffer{})
+	assert.ErrorIs(t, err, ErrDuplicateFeature)
+}
@@ -0,0 +1,354 @@
+package geopackage
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// SQLite file format constants (https://www.sqlite.org/fileformat2.html).
+// The writer produces a complete database in one pass, so it only needs the
+// subset of the format used by a freshly built, never-modified file: no freelist,
+// no WAL, and every page fully packed in key order.
+const (
+	pageSize        = 4096
+	fileHeaderSize  = 100
+	sqliteVersion   = 3045000 // SQLITE_VERSION_NUMBER recorded as the last writer
+	schemaFormat    = 4       // Enables serial types 8 and 9 (integer constants 0 and 1)
+	textEncodingUTF = 1
+
+	// B-tree page types
+	pageIndexLeaf     = 0x0a
+	pageTableInterior = 0x05
+	pageTableLeaf     = 0x0d
+
+	leafHeaderSize     = 8
+	interiorHeaderSize = 12
+	cellPointerSize    = 2
+	pageNumberSize     = 4
+
+	// Payload spill thresholds for overflow pages, derived from the usable page size
+	tableMaxLocal = pageSize - 35
+	indexMaxLocal = (pageSize-12)*64/255 - 23
+	minLocal      = (pageSize-12)*32/255 - 23
+)
+
+// errSchemaTooLarge is returned when sqlite_master does not fit on the first page.
+var errSchemaTooLarge = errors.New("schema does not fit on the first database page")
+
+// dbFile accumulates the pages of a SQLite database. Page numbers are 1-based and
+// page 1 is reserved for the file header and the sqlite_master table.
+type dbFile struct {
+	pages [][]byte
+}
+
+// newDBFile returns a file with page 1 reserved.
+func newDBFile() *dbFile {
+	return &dbFile{pages: [][]byte{make([]byte, pageSize)}}
+}
+
+// allocPage appends an empty page and returns it with its page number.
+func (f *dbFile) allocPage() (uint32, []byte) {
+	page := make([]byte, pageSize)
+	f.pages = append(f.pages, page)
+	return uint32(len(f.pages)), page // #nosec G115 -- page counts stay far below 2^32
+}
+
+// tableRow is one row of a table b-tree: the rowid and the encoded record.
+type tableRow struct {
+	record []byte
+	rowid  int64
+}
+
+// buildTable writes a table b-tree holding rows, which must be sorted by rowid,
+// and returns its root page number.
+func (f *dbFile) buildTable(rows []tableRow) uint32 {
+	type child struct {
+		page   uint32
+		maxKey int64
+	}
+
+	// Pack the leaf level
+	var level []child
+	var cells [][]byte
+	used := leafHeaderSize
+	flush := func(maxKey int64) {
+		number, page := f.allocPage()
+		writeBTreePage(page, 0, pageTableLeaf, cells, 0)
+		level = append(level, child{page: number, maxKey: maxKey})
+		cells, used = nil, leafHeaderSize
+	}
+	for i, row := range rows {
+		cell := f.tableLeafCell(row)
+		if len(cells) > 0 && used+len(cell)+cellPointerSize > pageSize {
+			flush(rows[i-1].rowid)
+		}
+		cells = append(cells, cell)
+		used += len(cell) + cellPointerSize
+	}
+	if len(cells) > 0 || len(level) == 0 {
+		var maxKey int64
+		if len(rows) > 0 {
+			maxKey = rows[len(rows)-1].rowid
+		}
+		flush(maxKey)
+	}
+
+	// Add interior levels until a single root remains. Each interior page points at a
+	// run of children: all but the last through cells keyed by their largest rowid,
+	// and the last through the right-most pointer.
+	for len(level) > 1 {
+		var parents []child
+		for start := 0; start < len(level); {
+			end := start
+			used := interiorHeaderSize
+			var cells [][]byte
+			for end < len(level)-1 {
+				cell := interiorCell(level[end].page, level[end].maxKey)
+				if used+len(cell)+cellPointerSize > pageSize {
+					break
+				}
+				cells = append(cells, cell)
+				used += len(cell) + cellPointerSize
+				end++
+			}
+			right := level[end]
+			number, page := f.allocPage()
+			writeBTreePage(page, 0, pageTableInterior, cells, right.page)
+			parents = append(parents, child{page: number, maxKey: right.maxKey})
+			start = end + 1
+		}
+		level = parents
+	}
+
+	return level[0].page
+}
+
+// buildIndex writes a single-page index b-tree holding the given encoded keys, which
+// must be sorted, and returns its page number. The GeoPackage metadata tables hold one
+// row per feature table, so their indexes never need more than one page.
+func (f *dbFile) buildIndex(keys [][]byte) (uint32, error) {
+	cells := make([][]byte, 0, len(keys))
+	used := leafHeaderSize
+	for _, key := range keys {
+		cell := f.payloadCell(nil, key, indexMaxLocal)
+		cells = append(cells, cell)
+		used += len(cell) + cellPointerSize
+	}
+	if used > pageSize {
+		return 0, fmt.Errorf("index of %d keys does not fit on one page", len(keys))
+	}
+
+	number, page := f.allocPage()
+	writeBTreePage(page, 0, pageIndexLeaf, cells, 0)
+	return number, nil
+}
+
+// buildSchema writes the sqlite_master rows to page 1, after the file header.
+func (f *dbFile) buildSchema(rows []tableRow) error {
+	cells := make([][]byte, 0, len(rows))
+	used := fileHeaderSize + leafHeaderSize
+	for _, row := range rows {
+		cell := f.tableLeafCell(row)
+		cells = append(cells, cell)
+		used += len(cell) + cellPointerSize
+	}
+	if used > pageSize {
+		return errSchemaTooLarge
+	}
+
+	writeBTreePage(f.pages[0], fileHeaderSize, pageTableLeaf, cells, 0)
+	return nil
+}
+
+// bytes returns the finished database file. userVersion and applicationID are stored
+// in the header for file formats built on SQLite, such as GeoPackage.
+func (f *dbFile) bytes(userVersion, applicationID uint32) []byte {
+	header := f.pages[0][:fileHeaderSize]
+	copy(header, "SQLite format 3\x00")
+	binary.BigEndian.PutUint16(header[16:], pageSize)
+	header[18] = 1 // File format write version: legacy rollback journal
+	header[19] = 1 // File format read version
+	header[20] = 0 // Reserved bytes per page
+	header[21] = 64
+	header[22] = 32
+	header[23] = 32
+	binary.BigEndian.PutUint32(header[24:], 1)                    // File change counter
+	binary.BigEndian.PutUint32(header[28:], uint32(len(f.pages))) // #nosec G115 -- database size in pages
+	binary.BigEndian.PutUint32(header[40:], 1)                    // Schema cookie
+	binary.BigEndian.PutUint32(header[44:], schemaFormat)
+	binary.BigEndian.PutUint32(header[56:], textEncodingUTF)
+	binary.BigEndian.PutUint32(header[60:], userVersion)
+	binary.BigEndian.PutUint32(header[68:], applicationID)
+	binary.BigEndian.PutUint32(header[92:], 1) // Version-valid-for matches the change counter
+	binary.BigEndian.PutUint32(header[96:], sqliteVersion)
+
+	data := make([]byte, 0, len(f.pages)*pageSize)
+	for _, page := range f.pages {
+		data = append(data, page...)
+	}
+	return data
+}
+
+// tableLeafCell encodes a table b-tree leaf cell: payload size, rowid, and payload.
+func (f *dbFile) tableLeafCell(row tableRow) []byte {
+	prefix := putVarint(nil, uint64(len(row.record)))
+	prefix = putVarint(prefix, uint64(row.rowid)) // #nosec G115 -- rowids are stored as two's complement
+	return f.payloadCell(prefix, row.record, tableMaxLocal)
+}
+
+// payloadCell appends payload to prefix, moving the part that does not fit locally
+// to a chain of overflow pages as described in the file format's cell payload rules.
+func (f *dbFile) payloadCell(prefix, payload []byte, maxLocal int) []byte {
+	if prefix == nil {
+		prefix = putVarint(nil, uint64(len(payload)))
+	}
+	if len(payload) <= maxLocal {
+		return append(prefix, payload...)
+	}
+
+	local := minLocal + (len(payload)-minLocal)%(pageSize-pageNumberSize)
+	if local > maxLocal {
+		local = minLocal
+	}
+
+	cell := append(prefix, payload[:local]...)
+	cell = binary.BigEndian.AppendUint32(cell, f.writeOverflow(payload[local:]))
+	return cell
+}
+
+// writeOverflow stores data in a chain of overflow pages and returns the first page number.
+func (f *dbFile) writeOverflow(data []byte) uint32 {
+	first, page := f.allocPage()
+	for {
+		n := copy(page[pageNumberSize:], data)
+		data = data[n:]
+		if len(data) == 0 {
+			return first
+		}
+		next, nextPage := f.allocPage()
+		binary.BigEndian.PutUint32(page, next)
+		page = nextPage
+	}
+}
+
+// interiorCell encodes a table b-tree interior cell: left child page and its largest rowid.
+func interiorCell(child uint32, key int64) []byte {
+	cell := binary.BigEndian.AppendUint32(nil, child)
+	return putVarint(cell, uint64(key)) // #nosec G115 -- rowids are stored as two's complement
+}
+
+// writeBTreePage lays out a b-tree page whose header starts at offset (100 on page 1).
+// Cell contents are packed at the end of the page in reverse order, with the cell
+// pointer array following the page header.
+func writeBTreePage(page []byte, offset int, pageType byte, cells [][]byte, rightPointer uint32) {
+	headerSize := leafHeaderSize
+	if pageType == pageTableInterior {
+		headerSize = interiorHeaderSize
+		binary.BigEndian.PutUint32(page[offset+8:], rightPointer)
+	}
+
+	content := len(page)
+	for i, cell := range cells {
+		content -= len(cell)
+		copy(page[content:], cell)
+		binary.BigEndian.PutUint16(page[offset+headerSize+i*cellPointerSize:], uint16(content)) // #nosec G115 -- offsets fit the page
+	}
+
+	page[offset] = pageType
+	binary.BigEndian.PutUint16(page[offset+3:], uint16(len(cells))) // #nosec G115 -- cells fit the page
+	binary.BigEndian.PutUint16(page[offset+5:], uint16(content))    // #nosec G115 -- offsets fit the page
+}
+
+// encodeRecord encodes values in the SQLite record format. Supported values are
+// nil (NULL), int64, float64, string (TEXT) and []byte (BLOB).
+func encodeRecord(values ...any) ([]byte, error) {
+	var types, body []byte
+	for i, value := range values {
+		switch v := value.(type) {
+		case nil:
+			types = putVarint(types, 0)
+		case int64:
+			serialType, size := integerSerialType(v)
+			types = putVarint(types, serialType)
+			for shift := (size - 1) * 8; shift >= 0; shift -= 8 {
+				body = append(body, byte(v>>shift))
+			}
+		case float64:
+			types = putVarint(types, 7)
+			body = binary.BigEndian.AppendUint64(body, math.Float64bits(v))
+		case string:
+			types = putVarint(types, uint64(len(v))*2+13)
+			body = append(body, v...)
+		case []byte:
+			types = putVarint(types, uint64(len(v))*2+12)
+			body = append(body, v...)
+		default:
+			return nil, fmt.Errorf("unsupported record value %d of type %T", i, value)
+		}
+	}
+
+	// The header size includes its own varint, whose length can depend on the total
+	headerSize := len(types) + 1
+	for len(putVarint(nil, uint64(headerSize)))+len(types) != headerSize {
+		headerSize = len(putVarint(nil, uint64(headerSize))) + len(types)
+	}
+
+	record := putVarint(make([]byte, 0, headerSize+len(body)), uint64(headerSize))
+	record = append(record, types...)
+	return append(record, body...), nil
+}
+
+// integerSerialType returns the smallest serial type holding v and its body size in bytes.
+func integerSerialType(v int64) (serialType uint64, size int) {
+	switch {
+	case v == 0:
+		return 8, 0
+	case v == 1:
+		return 9, 0
+	case v >= math.MinInt8 && v <= math.MaxInt8:
+		return 1, 1
+	case v >= math.MinInt16 && v <= math.MaxInt16:
+		return 2, 2
+	case v >= -1<<23 && v < 1<<23:
+		return 3, 3
+	case v >= math.MinInt32 && v <= math.MaxInt32:
+		return 4, 4
+	case v >= -1<<47 && v < 1<<47:
+		return 5, 6
+	default:
+		return 6, 8
+	}
+}
+
+// putVarint appends v as a SQLite varint: big-endian groups of 7 bits with the high
+// bit set on all but the last byte, except that a ninth byte carries a full 8 bits.
+func putVarint(buf []byte, v uint64) []byte {
+	if v > 1<<56-1 {
+		var b [9]byte
+		b[8] = byte(v)
+		v >>= 8
+		for i := 7; i >= 0; i-- {
+			b[i] = byte(v&0x7f) | 0x80
+			v >>= 7
+		}
+		return append(buf, b[:]...)
+	}
+
+	var b [9]byte
+	n := 0
+	for {
+		b[n] = byte(v&0x7f) | 0x80
+		n++
+		v >>= 7
+		if v == 0 {
+			break
+		}
+	}
+	b[0] &= 0x7f // The least significant group ends the varint
+	for i := n - 1; i >= 0; i-- {
+		buf = append(buf, b[i])
+	}
+	return buf
+}
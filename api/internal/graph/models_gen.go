@@ -0,0 +1,54 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package graph
+
+// A parcel matched by address search with its relevance score
+type AddressMatch struct {
+	Score  *float64 `json:"score,omitempty"`
+	Parcel *Parcel  `json:"parcel"`
+}
+
+// One page of address matches ordered by score; nextCursor is null on the last page
+type AddressSearchPage struct {
+	Results    []*AddressMatch `json:"results"`
+	TotalCount int             `json:"totalCount"`
+	NextCursor *string         `json:"nextCursor,omitempty"`
+}
+
+// One page of parcels ordered by distance; nextCursor is null on the last page
+type NearbyPage struct {
+	Results    []*NearbyParcel `json:"results"`
+	TotalCount int             `json:"totalCount"`
+	NextCursor *string         `json:"nextCursor,omitempty"`
+}
+
+// A parcel with its distance from the query point
+type NearbyParcel struct {
+	DistanceMeters *float64 `json:"distanceMeters,omitempty"`
+	Parcel         *Parcel  `json:"parcel"`
+}
+
+// A tax parcel. Fields follow the role visibility matrix of the REST
+// responses; fields hidden from the caller resolve to null.
+type Parcel struct {
+	ID                  int            `json:"id"`
+	ParcelID            *string        `json:"parcelId,omitempty"`
+	OwnerName           *string        `json:"ownerName,omitempty"`
+	OwnerAddress        *string        `json:"ownerAddress,omitempty"`
+	SitusAddress        *string        `json:"situsAddress,omitempty"`
+	PropType            *string        `json:"propType,omitempty"`
+	LandUse             *string        `json:"landUse,omitempty"`
+	CountyName          string         `json:"countyName"`
+	Acres               *float64       `json:"acres,omitempty"`
+	Waterfront          *bool          `json:"waterfront,omitempty"`
+	WaterFrontageMeters *float64       `json:"waterFrontageMeters,omitempty"`
+	WaterfrontType      *string        `json:"waterfrontType,omitempty"`
+	WaterBody           *string        `json:"waterBody,omitempty"`
+	Centroid            []float64      `json:"centroid,omitempty"`
+	Bbox                []float64      `json:"bbox,omitempty"`
+	Attributes          map[string]any `json:"attributes,omitempty"`
+	Geometry            map[string]any `json:"geometry,omitempty"`
+}
+
+type Query struct {
+}
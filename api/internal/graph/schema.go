@@ -0,0 +1,11 @@
+// Package graph holds the GraphQL schema of the parcel API and the executor
+// gqlgen generates from it (make generate-graphql). The resolvers live in
+// the handlers package, next to the REST handlers they share mapping with.
+package graph
+
+import _ "embed"
+
+// SDL is the schema in the GraphQL schema definition language.
+//
+//go:embed parcels.graphqls
+var SDL string
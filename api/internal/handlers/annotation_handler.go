@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// Annotation constants
+const (
+	// DefaultAnnotationsLimit is the number of annotations returned without a limit
+	DefaultAnnotationsLimit = 100
+)
+
+// AnnotationHandler handles the notes users attach to parcels.
+// All routes are user-scoped.
+type AnnotationHandler struct {
+	service services.AnnotationService
+}
+
+// NewAnnotationHandler creates a new AnnotationHandler instance.
+func NewAnnotationHandler(service services.AnnotationService) *AnnotationHandler {
+	return &AnnotationHandler{
+		service: service,
+	}
+}
+
+// AnnotationRequest represents the request body for writing an annotation.
+// Markup is an optional GeoJSON Point, Polygon or MultiPolygon; Visibility is
+// "private" (the default) or "shared". ParcelID is required when creating and
+// ignored when updating, since an annotation stays on its parcel.
+type AnnotationRequest struct {
+	Markup     json.RawMessage `json:"markup"`
+	Body       string          `json:"body" binding:"required"`
+	Visibility string          `json:"visibility" binding:"omitempty,oneof=private shared"`
+	ParcelID   uint            `json:"parcelId"`
+}
+
+// ListAnnotationsRequest represents the query parameters for the annotation list endpoint.
+type ListAnnotationsRequest struct {
+	ParcelID uint `form:"parcelId"`                                // only annotations on this parcel
+	Limit    int  `form:"limit" binding:"omitempty,min=1,max=500"` // default: 100
+}
+
+// AnnotationResponse represents the response for a single annotation.
+type AnnotationResponse struct {
+	Annotation *models.Annotation `json:"annotation"`
+}
+
+// AnnotationListResponse represents the response for the annotation list endpoint.
+type AnnotationListResponse struct {
+	Annotations []models.Annotation `json:"annotations"`
+	Count       int                 `json:"count"`
+}
+
+// Create handles POST /api/v1/annotations endpoint.
+// Responds 201 Created with the annotation.
+func (h *AnnotationHandler) Create(c *gin.Context) {
+	annotation, ok := bindAnnotation(c)
+	if !ok {
+		return
+	}
+	if annotation.ParcelID == 0 {
+		apierrors.BadRequest(c, "parcelId is required", nil)
+		return
+	}
+
+	stored, err := h.service.CreateAnnotation(c.Request.Context(), *annotation)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, AnnotationResponse{Annotation: stored})
+}
+
+// List handles GET /api/v1/annotations endpoint.
+// It returns the user's annotations and shared ones, newest first.
+func (h *AnnotationHandler) List(c *gin.Context) {
+	var req ListAnnotationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		apierrors.BadRequest(c, "Invalid query parameters", nil)
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = DefaultAnnotationsLimit
+	}
+
+	annotations, err := h.service.ListAnnotations(c.Request.Context(), middleware.GetUserID(c), req.ParcelID, req.Limit)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	middleware.SetResultCount(c, len(annotations))
+
+	c.JSON(http.StatusOK, AnnotationListResponse{
+		Annotations: annotations,
+		Count:       len(annotations),
+	})
+}
+
+// Get handles GET /api/v1/annotations/:id endpoint.
+func (h *AnnotationHandler) Get(c *gin.Context) {
+	id, ok := annotationID(c)
+	if !ok {
+		return
+	}
+
+	annotation, err := h.service.GetAnnotation(c.Request.Context(), middleware.GetUserID(c), id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, AnnotationResponse{Annotation: annotation})
+}
+
+// Update handles PUT /api/v1/annotations/:id endpoint.
+// The body replaces the annotation's body, markup and visibility; omitting the
+// markup removes it.
+func (h *AnnotationHandler) Update(c *gin.Context) {
+	id, ok := annotationID(c)
+	if !ok {
+		return
+	}
+	annotation, ok := bindAnnotation(c)
+	if !ok {
+		return
+	}
+	annotation.ID = id
+
+	stored, err := h.service.UpdateAnnotation(c.Request.Context(), *annotation)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, AnnotationResponse{Annotation: stored})
+}
+
+// Delete handles DELETE /api/v1/annotations/:id endpoint.
+func (h *AnnotationHandler) Delete(c *gin.Context) {
+	id, ok := annotationID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteAnnotation(c.Request.Context(), middleware.GetUserID(c), id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// handleError maps annotation service errors to HTTP responses.
+func (h *AnnotationHandler) handleError(c *gin.Context, err error) {
+	apierrors.Respond(c, "Failed to process annotation request", err)
+}
+
+// bindAnnotation binds an AnnotationRequest into an annotation of the signed-in
+// user, writing the error response if the body is invalid.
+func bindAnnotation(c *gin.Context) (*models.Annotation, bool) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxAreaBodyBytes)
+
+	var req AnnotationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// Check if it's a validation error
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return nil, false
+		}
+		// Malformed JSON, wrong field types or a body over MaxAreaBodyBytes
+		apierrors.BadRequest(c, "Invalid request body", nil)
+		return nil, false
+	}
+
+	annotation := &models.Annotation{
+		UserID:     middleware.GetUserID(c),
+		ParcelID:   req.ParcelID,
+		Body:       req.Body,
+		Visibility: req.Visibility,
+	}
+	if len(req.Markup) > 0 && string(req.Markup) != "null" {
+		markup, err := models.ParseMarkupGeoJSON(req.Markup)
+		if err != nil {
+			apierrors.BadRequest(c, "markup must be a GeoJSON Point, Polygon or MultiPolygon", map[string]interface{}{
+				"reason": err.Error(),
+			})
+			return nil, false
+		}
+		annotation.Markup = markup
+	}
+
+	return annotation, true
+}
+
+// annotationID parses the :id parameter, writing the error response if it is invalid.
+func annotationID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		apierrors.BadRequest(c, "annotation id must be a positive integer", nil)
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MockAnnotationService is a mock implementation of AnnotationService for testing
+type MockAnnotationService struct {
+	mock.Mock
+}
+
+func (m *MockAnnotationService) CreateAnnotation(ctx context.Context, annotation models.Annotation) (*models.Annotation, error) {
+	args := m.Called(ctx, annotation)
+	stored, _ := args.Get(0).(*models.Annotation)
+	return stored, args.Error(1)
+}
+
+func (m *MockAnnotationService) GetAnnotation(ctx context.Context, userID, id uint) (*models.Annotation, error) {
+	args := m.Called(ctx, userID, id)
+	annotation, _ := args.Get(0).(*models.Annotation)
+	return annotation, args.Error(1)
+}
+
+func (m *MockAnnotationService) ListAnnotations(ctx context.Context, userID, parcelID uint, limit int) ([]models.Annotation, error) {
+	args := m.Called(ctx, userID, parcelID, limit)
+	annotations, _ := args.Get(0).([]models.Annotation)
+	return annotations, args.Error(1)
+}
+
+func (m *MockAnnotationService) UpdateAnnotation(ctx context.Context, annotation models.Annotation) (*models.Annotation, error) {
+	args := m.Called(ctx, annotation)
+	stored, _ := args.Get(0).(*models.Annotation)
+	return stored, args.Error(1)
+}
+
+func (m *MockAnnotationService) DeleteAnnotation(ctx context.Context, userID, id uint) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+// setupAnnotationTestRouter creates a test router with annotation handlers,
+// signed in as user 7.
+func setupAnnotationTestRouter(handler *AnnotationHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, uint(7))
+		c.Next()
+	})
+
+	router.GET("/api/v1/annotations", handler.List)
+	router.POST("/api/v1/annotations", handler.Create)
+	router.GET("/api/v1/annotations/:id", handler.Get)
+	router.PUT("/api/v1/annotations/:id", handler.Update)
+	router.DELETE("/api/v1/annotations/:id", handler.Delete)
+
+	return router
+}
+
+func TestAnnotationHandler_Create(t *testing.T) {
+	mockService := new(MockAnnotationService)
+	router := setupAnnotationTestRouter(NewAnnotationHandler(mockService))
+
+	var received models.Annotation
+	mockService.On("CreateAnnotation", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		received = args.Get(1).(models.Annotation)
+	}).Return(&models.Annotation{ID: 5, ParcelID: 42, Body: "Culvert washed out"}, nil)
+
+	body := `{"parcelId": 42, "body": "Culvert washed out", "visibility": "shared",
+		"markup": {"type": "Point", "coordinates": [-95.45, 30.35]}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/annotations", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var response AnnotationResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, uint(5), response.Annotation.ID)
+	assert.Equal(t, uint(7), received.UserID)
+	assert.Equal(t, uint(42), received.ParcelID)
+	assert.Equal(t, models.AnnotationVisibilityShared, received.Visibility)
+	require.NotNil(t, received.Markup)
+	assert.Equal(t, &models.LatLng{Lat: 30.35, Lng: -95.45}, received.Markup.Point)
+	mockService.AssertExpectations(t)
+}
+
+func TestAnnotationHandler_Create_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		serviceErr error
+		wantStatus int
+	}{
+		{name: "missing parcel", body: `{"body": "note"}`, wantStatus: http.StatusBadRequest},
+		{name: "missing body", body: `{"parcelId": 42}`, wantStatus: http.StatusBadRequest},
+		{name: "unknown visibility", body: `{"parcelId": 42, "body": "note", "visibility": "public"}`, wantStatus: http.StatusBadRequest},
+		{name: "line markup", body: `{"parcelId": 42, "body": "note", "markup": {"type": "LineString", "coordinates": [[0, 0], [1, 1]]}}`,
+			wantStatus: http.StatusBadRequest},
+		{name: "parcel not found", body: `{"parcelId": 42, "body": "note"}`, serviceErr: services.ErrParcelNotFound, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAnnotationService)
+			router := setupAnnotationTestRouter(NewAnnotationHandler(mockService))
+
+			if tt.serviceErr != nil {
+				mockService.On("CreateAnnotation", mock.Anything, mock.Anything).Return(nil, tt.serviceErr)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/annotations", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAnnotationHandler_List(t *testing.T) {
+	mockService := new(MockAnnotationService)
+	router := setupAnnotationTestRouter(NewAnnotationHandler(mockService))
+
+	mockService.On("ListAnnotations", mock.Anything, uint(7), uint(42), DefaultAnnotationsLimit).Return([]models.Annotation{{ID: 5}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/annotations?parcelId=42", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response AnnotationListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Count)
+	mockService.AssertExpectations(t)
+}
+
+func TestAnnotationHandler_Get(t *testing.T) {
+	mockService := new(MockAnnotationService)
+	router := setupAnnotationTestRouter(NewAnnotationHandler(mockService))
+
+	mockService.On("GetAnnotation", mock.Anything, uint(7), uint(5)).Return(nil, services.ErrAnnotationNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/annotations/5", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestAnnotationHandler_Update(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{name: "updated", wantStatus: http.StatusOK},
+		{name: "another user's shared annotation", serviceErr: services.ErrAnnotationForbidden, wantStatus: http.StatusForbidden},
+		{name: "not found", serviceErr: services.ErrAnnotationNotFound, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAnnotationService)
+			router := setupAnnotationTestRouter(NewAnnotationHandler(mockService))
+
+			var stored *models.Annotation
+			if tt.serviceErr == nil {
+				stored = &models.Annotation{ID: 5, Body: "Repaired"}
+			}
+			mockService.On("UpdateAnnotation", mock.Anything, models.Annotation{ID: 5, UserID: 7, Body: "Repaired"}).Return(stored, tt.serviceErr)
+
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/annotations/5", strings.NewReader(`{"body": "Repaired"}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAnnotationHandler_Delete(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		serviceErr error
+		wantStatus int
+	}{
+		{name: "deleted", path: "/api/v1/annotations/5", wantStatus: http.StatusNoContent},
+		{name: "another user's shared annotation", path: "/api/v1/annotations/5", serviceErr: services.ErrAnnotationForbidden, wantStatus: http.StatusForbidden},
+		{name: "invalid id", path: "/api/v1/annotations/abc", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAnnotationService)
+			router := setupAnnotationTestRouter(NewAnnotationHandler(mockService))
+
+			if tt.wantStatus != http.StatusBadRequest {
+				mockService.On("DeleteAnnotation", mock.Anything, uint(7), uint(5)).Return(tt.serviceErr)
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// APIKeyHandler handles API key management HTTP requests.
+// All routes are tenant-scoped; the tenant comes from the caller's admin API
+// key, or from the X-Tenant-ID header when keys are not required.
+type APIKeyHandler struct {
+	service services.APIKeyService
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler instance.
+func NewAPIKeyHandler(service services.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		service: service,
+	}
+}
+
+// CreateAPIKeyRequest represents the request body for creating a key.
+// Role is "public" (the default) or "admin".
+type CreateAPIKeyRequest struct {
+	Name string `json:"name" binding:"required"`
+	Role string `json:"role"`
+}
+
+// CreateAPIKeyResponse is returned when a key is created. Key is the only copy
+// of the key; it cannot be retrieved again.
+type CreateAPIKeyResponse struct {
+	APIKey *models.APIKey `json:"apiKey"`
+	Key    string         `json:"key"`
+}
+
+// APIKeyListResponse represents the response for the key list endpoint.
+type APIKeyListResponse struct {
+	APIKeys []models.APIKey `json:"apiKeys"`
+	Count   int             `json:"count"`
+}
+
+// Create handles POST /api/v1/api-keys endpoint.
+func (h *APIKeyHandler) Create(c *gin.Context) {
+	tenantID, ok := requireTenant(c)
+	if !ok {
+		return
+	}
+
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// Check if it's a validation error
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		// Malformed JSON or wrong field types
+		apierrors.BadRequest(c, "Invalid request body", nil)
+		return
+	}
+
+	key, secret, err := h.service.CreateKey(c.Request.Context(), tenantID, req.Name, req.Role)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, CreateAPIKeyResponse{APIKey: key, Key: secret})
+}
+
+// List handles GET /api/v1/api-keys endpoint.
+func (h *APIKeyHandler) List(c *gin.Context) {
+	tenantID, ok := requireTenant(c)
+	if !ok {
+		return
+	}
+
+	keys, err := h.service.ListKeys(c.Request.Context(), tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIKeyListResponse{
+		APIKeys: keys,
+		Count:   len(keys),
+	})
+}
+
+// Revoke handles DELETE /api/v1/api-keys/:id endpoint.
+func (h *APIKeyHandler) Revoke(c *gin.Context) {
+	tenantID, ok := requireTenant(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		apierrors.BadRequest(c, "API key id must be a positive integer", nil)
+		return
+	}
+
+	if err := h.service.RevokeKey(c.Request.Context(), tenantID, uint(id)); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// handleError maps API key service errors to HTTP responses.
+func (h *APIKeyHandler) handleError(c *gin.Context, err error) {
+	apierrors.Respond(c, "Failed to process API key request", err)
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MockAPIKeyService is a mock implementation of APIKeyService for testing
+type MockAPIKeyService struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyService) CreateKey(ctx context.Context, tenantID, name, role string) (*models.APIKey, string, error) {
+	args := m.Called(ctx, tenantID, name, role)
+	key, _ := args.Get(0).(*models.APIKey)
+	return key, args.String(1), args.Error(2)
+}
+
+func (m *MockAPIKeyService) ListKeys(ctx context.Context, tenantID string) ([]models.APIKey, error) {
+	args := m.Called(ctx, tenantID)
+	keys, _ := args.Get(0).([]models.APIKey)
+	return keys, args.Error(1)
+}
+
+func (m *MockAPIKeyService) RevokeKey(ctx context.Context, tenantID string, id uint) error {
+	args := m.Called(ctx, tenantID, id)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyService) Authenticate(ctx context.Context, key string) (*models.APIKey, error) {
+	args := m.Called(ctx, key)
+	found, _ := args.Get(0).(*models.APIKey)
+	return found, args.Error(1)
+}
+
+// setupAPIKeyTestRouter creates a test router with tenant middleware and API key handlers.
+func setupAPIKeyTestRouter(handler *APIKeyHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+	router.Use(middleware.Tenant())
+
+	keys := router.Group("/api/v1/api-keys")
+	{
+		keys.GET("", handler.List)
+		keys.POST("", handler.Create)
+		keys.DELETE("/:id", handler.Revoke)
+	}
+
+	return router
+}
+
+// serveAPIKeyRequest serves a request for the acme tenant.
+func serveAPIKeyRequest(router *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.TenantIDHeader, "acme")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAPIKeyHandler_Create(t *testing.T) {
+	t.Run("returns the key once", func(t *testing.T) {
+		mockService := new(MockAPIKeyService)
+		router := setupAPIKeyTestRouter(NewAPIKeyHandler(mockService))
+
+		created := &models.APIKey{ID: 1, TenantID: "acme", Name: "mobile", Prefix: "atlas_abc123", KeyHash: "hash", Role: "public"}
+		mockService.On("CreateKey", mock.Anything, "acme", "mobile", "").Return(created, "atlas_abc123secret", nil)
+
+		w := serveAPIKeyRequest(router, http.MethodPost, "/api/v1/api-keys", `{"name":"mobile"}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response CreateAPIKeyResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "atlas_abc123secret", response.Key)
+		assert.Equal(t, "atlas_abc123", response.APIKey.Prefix)
+		assert.NotContains(t, w.Body.String(), "hash")
+	})
+
+	t.Run("service validation error maps to 400", func(t *testing.T) {
+		mockService := new(MockAPIKeyService)
+		router := setupAPIKeyTestRouter(NewAPIKeyHandler(mockService))
+		mockService.On("CreateKey", mock.Anything, "acme", "mobile", "owner").Return(nil, "", services.ErrInvalidAPIKey)
+
+		w := serveAPIKeyRequest(router, http.MethodPost, "/api/v1/api-keys", `{"name":"mobile","role":"owner"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAPIKeyHandler_List(t *testing.T) {
+	mockService := new(MockAPIKeyService)
+	router := setupAPIKeyTestRouter(NewAPIKeyHandler(mockService))
+	mockService.On("ListKeys", mock.Anything, "acme").Return([]models.APIKey{{ID: 1, Name: "mobile"}}, nil)
+
+	w := serveAPIKeyRequest(router, http.MethodGet, "/api/v1/api-keys", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response APIKeyListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Count)
+}
+
+func TestAPIKeyHandler_Revoke(t *testing.T) {
+	mockService := new(MockAPIKeyService)
+	router := setupAPIKeyTestRouter(NewAPIKeyHandler(mockService))
+	mockService.On("RevokeKey", mock.Anything, "acme", uint(1)).Return(nil)
+	mockService.On("RevokeKey", mock.Anything, "acme", uint(2)).Return(services.ErrAPIKeyNotFound)
+
+	assert.Equal(t, http.StatusNoContent, serveAPIKeyRequest(router, http.MethodDelete, "/api/v1/api-keys/1", "").Code)
+	assert.Equal(t, http.StatusNotFound, serveAPIKeyRequest(router, http.MethodDelete, "/api/v1/api-keys/2", "").Code)
+	assert.Equal(t, http.StatusBadRequest, serveAPIKeyRequest(router, http.MethodDelete, "/api/v1/api-keys/abc", "").Code)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// ParcelAsOfData describes the version of a parcel returned for an as_of date.
+// ValidTo is omitted while the version is still the current one.
+type ParcelAsOfData struct {
+	ValidFrom time.Time  `json:"valid_from"`
+	ValidTo   *time.Time `json:"valid_to,omitempty"`
+	Date      string     `json:"date"` // the requested as_of, YYYY-MM-DD
+}
+
+// checkAsOf writes a 400 response (ok=false) for as_of requests the parcel
+// versions cannot answer: included data, such as amenities, zoning, flood zones
+// and jurisdictions, and GPS accuracy candidates are only known for the current
+// parcels.
+func (h *ParcelHandler) checkAsOf(c *gin.Context, include string, accuracy float64) bool {
+	switch {
+	case h.history == nil:
+		apierrors.BadRequest(c, "as_of is not enabled on this server", nil)
+	case include != "":
+		apierrors.BadRequest(c, "as_of cannot be combined with include", nil)
+	case accuracy > 0:
+		apierrors.BadRequest(c, "as_of cannot be combined with accuracy", nil)
+	default:
+		return true
+	}
+	return false
+}
+
+// renderAsOf renders the parcel version found for an as_of request like the
+// current parcel, in the requested format. Past versions have no waterfront or
+// derived attributes, so those members are omitted.
+func (h *ParcelHandler) renderAsOf(c *gin.Context, asOf *repository.ParcelAsOf, date time.Time, format string, fields []string) {
+	middleware.SetResultCount(c, 1)
+
+	dto := mapTaxParcelToDTO(&asOf.Parcel)
+
+	switch format {
+	case FormatGeoJSON:
+		renderFeatureCollection(c, []*ParcelData{dto}, fields)
+		return
+	case FormatKML:
+		renderKML(c, []*ParcelData{dto}, fields)
+		return
+	}
+
+	dto.Links = h.links.For(dto.ID)
+	response := ParcelResponse{
+		Parcel: dto,
+		AsOf: &ParcelAsOfData{
+			Date:      date.Format(time.DateOnly),
+			ValidFrom: asOf.ValidFrom,
+			ValidTo:   asOf.ValidTo,
+		},
+	}
+
+	renderSelectedJSON(c, response, fields, "parcel")
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// parcelAsOf returns parcel 42 as owned by owner from validFrom until validTo.
+func parcelAsOf(owner string, validFrom time.Time, validTo *time.Time) *repository.ParcelAsOf {
+	return &repository.ParcelAsOf{
+		Parcel:    models.TaxParcel{ID: 42, PIN: 7, OwnerName: &owner, CountyName: "Montgomery"},
+		ValidFrom: validFrom,
+		ValidTo:   validTo,
+	}
+}
+
+func TestParcelHandler_GetAsOf(t *testing.T) {
+	closing := time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC)
+	loaded := time.Date(2023, 1, 5, 0, 0, 0, 0, time.UTC)
+	replaced := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	mockHistory := new(MockHistoryService)
+	handler := NewParcelHandler(nil, nil)
+	handler.SetHistory(mockHistory)
+	router := setupParcelTestRouter(handler, logger.New("test"))
+
+	mockHistory.On("GetParcelAsOf", mock.Anything, uint(42), closing).Return(parcelAsOf("DOE JANE", loaded, &replaced), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/parcels/42?as_of=2024-06-14", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response ParcelResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "DOE JANE", response.Parcel.OwnerName)
+	require.NotNil(t, response.AsOf)
+	assert.Equal(t, "2024-06-14", response.AsOf.Date)
+	assert.Equal(t, loaded, response.AsOf.ValidFrom)
+	assert.Equal(t, replaced, *response.AsOf.ValidTo)
+	mockHistory.AssertExpectations(t)
+}
+
+func TestParcelHandler_AtPointAsOf(t *testing.T) {
+	closing := time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC)
+
+	mockHistory := new(MockHistoryService)
+	handler := NewParcelHandler(nil, nil)
+	handler.SetHistory(mockHistory)
+	router := setupParcelTestRouter(handler, logger.New("test"))
+
+	point := models.LatLng{Lat: 30.3, Lng: -95.5}
+	mockHistory.On("GetParcelAtPointAsOf", mock.Anything, point, closing).
+		Return(parcelAsOf("DOE JANE", closing, nil), nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/parcels/at-point?lat=30.3&lng=-95.5&as_of=2024-06-14", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response ParcelResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, uint(42), response.Parcel.ID)
+	assert.Nil(t, response.AsOf.ValidTo, "the version is still current")
+
+	mockHistory.On("GetParcelAtPointAsOf", mock.Anything, point, closing).Return(nil, services.ErrParcelNotFound)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/parcels/at-point?lat=30.3&lng=-95.5&as_of=2024-06-14", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "on that date")
+}
+
+func TestParcelHandler_AsOfErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		noHistory  bool
+		wantStatus int
+	}{
+		{name: "invalid date", path: "/api/v1/parcels/42?as_of=14-06-2024", wantStatus: http.StatusBadRequest},
+		{name: "with amenities", path: "/api/v1/parcels/42?as_of=2024-06-14&include=amenities", wantStatus: http.StatusBadRequest},
+		{name: "with accuracy", path: "/api/v1/parcels/at-point?lat=30.3&lng=-95.5&accuracy=10&as_of=2024-06-14", wantStatus: http.StatusBadRequest},
+		{name: "without history", path: "/api/v1/parcels/42?as_of=2024-06-14", noHistory: true, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHistory := new(MockHistoryService)
+			handler := NewParcelHandler(nil, nil)
+			if !tt.noHistory {
+				handler.SetHistory(mockHistory)
+			}
+			router := setupParcelTestRouter(handler, logger.New("test"))
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockHistory.AssertExpectations(t)
+		})
+	}
+}
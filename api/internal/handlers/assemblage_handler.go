@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// defaultAssemblageLimit is the number of candidates returned when limit is omitted.
+const defaultAssemblageLimit = 10
+
+// AssemblageHandler handles development site assemblage HTTP requests.
+type AssemblageHandler struct {
+	service services.AssemblageService
+	// results runs searches in async mode; nil without the job manager
+	results services.ResultService
+}
+
+// NewAssemblageHandler creates a new AssemblageHandler instance.
+func NewAssemblageHandler(service services.AssemblageService) *AssemblageHandler {
+	return &AssemblageHandler{
+		service: service,
+	}
+}
+
+// SetResults enables async mode, in which searches run as jobs of results.
+func (h *AssemblageHandler) SetResults(results services.ResultService) {
+	h.results = results
+}
+
+// AssemblageRequest represents the query parameters for the assemblages endpoint.
+// The area of interest is the GeoJSON Polygon or MultiPolygon request body.
+// With Async the search runs in the background and CallbackURL, if set, is
+// notified when it finishes.
+type AssemblageRequest struct {
+	County      string  `form:"county"`
+	CallbackURL string  `form:"callback_url" binding:"omitempty,max=2048"`
+	TargetAcres float64 `form:"target_acres" binding:"required,gt=0,max=10000"`
+	Limit       int     `form:"limit" binding:"omitempty,min=1,max=50"` // default: 10
+	SameOwner   bool    `form:"same_owner"`
+	Async       bool    `form:"async"`
+}
+
+// AssemblageCandidate is one group of contiguous parcels meeting the target.
+// Its outline can be drawn with the dissolve endpoint.
+type AssemblageCandidate struct {
+	ParcelIDs   []uint   `json:"parcel_ids"`
+	Owners      []string `json:"owners"`
+	Acres       float64  `json:"acres"`
+	ParcelCount int      `json:"parcel_count"`
+	OwnerCount  int      `json:"owner_count"`
+}
+
+// AssemblagesResponse represents the response for the assemblages endpoint.
+// Assemblages are ranked by parcel count, then owner count.
+type AssemblagesResponse struct {
+	Assemblages []AssemblageCandidate `json:"assemblages"`
+	TargetAcres float64               `json:"target_acres"`
+	Count       int                   `json:"count"`
+}
+
+// Find handles POST /api/v1/analysis/assemblages endpoint.
+// It returns candidate assemblages of neighboring parcels in the area whose
+// combined acres reach target_acres, or with async=true a 202 with the ID of
+// the result.
+func (h *AssemblageHandler) Find(c *gin.Context) {
+	log := middleware.GetLogger(c)
+
+	// Bind and validate query parameters
+	var req AssemblageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		apierrors.BadRequest(c, "Invalid query parameters", nil)
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultAssemblageLimit
+	}
+	if !checkAsync(c, req.Async, req.CallbackURL) {
+		return
+	}
+
+	// Read and parse the GeoJSON body
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, MaxAreaBodyBytes))
+	if err != nil {
+		apierrors.BadRequest(c, "Request body is too large or unreadable", nil)
+		return
+	}
+	area, err := models.ParseAreaGeoJSON(body)
+	if err != nil {
+		apierrors.BadRequest(c, "Request body must be a GeoJSON Polygon or MultiPolygon", map[string]interface{}{
+			"reason": err.Error(),
+		})
+		return
+	}
+
+	if log != nil {
+		log.Info("Processing assemblage request", map[string]interface{}{
+			"target_acres": req.TargetAcres,
+			"same_owner":   req.SameOwner,
+			"limit":        req.Limit,
+		})
+	}
+
+	ctx := repository.WithCounty(c.Request.Context(), req.County)
+	opts := services.AssemblageOptions{
+		TargetAcres: req.TargetAcres,
+		Limit:       req.Limit,
+		SameOwner:   req.SameOwner,
+	}
+
+	if req.Async {
+		submitAsync(c, ctx, h.results, "assemblages", req.CallbackURL, func(ctx context.Context) (any, error) {
+			assemblages, err := h.service.FindAssemblages(ctx, area, opts)
+			if err != nil {
+				return nil, err
+			}
+			return newAssemblagesResponse(assemblages, req.TargetAcres), nil
+		})
+		return
+	}
+
+	assemblages, err := h.service.FindAssemblages(ctx, area, opts)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	middleware.SetResultCount(c, len(assemblages))
+
+	c.JSON(http.StatusOK, newAssemblagesResponse(assemblages, req.TargetAcres))
+}
+
+// newAssemblagesResponse maps the assemblages found for targetAcres to the response.
+func newAssemblagesResponse(assemblages []services.Assemblage, targetAcres float64) AssemblagesResponse {
+	candidates := make([]AssemblageCandidate, 0, len(assemblages))
+	for _, a := range assemblages {
+		owners := a.Owners
+		if owners == nil {
+			owners = []string{}
+		}
+		candidates = append(candidates, AssemblageCandidate{
+			ParcelIDs:   a.ParcelIDs,
+			Owners:      owners,
+			Acres:       a.Acres,
+			ParcelCount: len(a.ParcelIDs),
+			OwnerCount:  a.OwnerCount,
+		})
+	}
+
+	return AssemblagesResponse{
+		Assemblages: candidates,
+		TargetAcres: targetAcres,
+		Count:       len(candidates),
+	}
+}
+
+// handleError maps assemblage service errors to HTTP responses.
+func (h *AssemblageHandler) handleError(c *gin.Context, err error) {
+	queryFailed(c, "Failed to find assemblages", err)
+}
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/jobs"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MockAssemblageService is a mock implementation of AssemblageService for testing
+type MockAssemblageService struct {
+	mock.Mock
+}
+
+func (m *MockAssemblageService) FindAssemblages(ctx context.Context, area *models.MultiPolygon, opts services.AssemblageOptions) ([]services.Assemblage, error) {
+	args := m.Called(ctx, area, opts)
+	result, _ := args.Get(0).([]services.Assemblage)
+	return result, args.Error(1)
+}
+
+// setupAssemblageTestRouter creates a test router with assemblage handlers.
+func setupAssemblageTestRouter(handler *AssemblageHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+
+	router.POST("/api/v1/analysis/assemblages", handler.Find)
+
+	return router
+}
+
+// testAreaBody is a GeoJSON Polygon near Conroe, TX.
+const testAreaBody = `{"type":"Polygon","coordinates":[[[-95.46,30.34],[-95.44,30.34],[-95.44,30.36],[-95.46,30.36],[-95.46,30.34]]]}`
+
+func TestAssemblageHandler_Find(t *testing.T) {
+	mockService := new(MockAssemblageService)
+	router := setupAssemblageTestRouter(NewAssemblageHandler(mockService))
+
+	mockService.On("FindAssemblages", mock.Anything, mock.Anything, services.AssemblageOptions{TargetAcres: 5, Limit: 10, SameOwner: true}).
+		Return([]services.Assemblage{
+			{ParcelIDs: []uint{2, 3}, Owners: []string{"ACME LAND LLC"}, Acres: 5.5, OwnerCount: 1},
+			{ParcelIDs: []uint{7}, Acres: 6, OwnerCount: 1},
+		}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/analysis/assemblages?target_acres=5&same_owner=true", strings.NewReader(testAreaBody))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response AssemblagesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Count)
+	assert.Equal(t, 5.0, response.TargetAcres)
+	assert.Equal(t, AssemblageCandidate{ParcelIDs: []uint{2, 3}, Owners: []string{"ACME LAND LLC"}, Acres: 5.5, ParcelCount: 2, OwnerCount: 1}, response.Assemblages[0])
+	assert.Contains(t, w.Body.String(), `"owners":[]`, "owners is an empty array, not null")
+	mockService.AssertExpectations(t)
+}
+
+func TestAssemblageHandler_FindInvalidRequest(t *testing.T) {
+	testCases := []struct {
+		name string
+		url  string
+		body string
+	}{
+		{"missing target", "/api/v1/analysis/assemblages", testAreaBody},
+		{"negative target", "/api/v1/analysis/assemblages?target_acres=-1", testAreaBody},
+		{"limit too large", "/api/v1/analysis/assemblages?target_acres=5&limit=51", testAreaBody},
+		{"not an area", "/api/v1/analysis/assemblages?target_acres=5", `{"type":"Point","coordinates":[-95.45,30.35]}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockAssemblageService)
+			router := setupAssemblageTestRouter(NewAssemblageHandler(mockService))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tc.url, strings.NewReader(tc.body)))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockService.AssertNotCalled(t, "FindAssemblages")
+		})
+	}
+}
+
+func TestAssemblageHandler_FindErrors(t *testing.T) {
+	testCases := []struct {
+		err    error
+		name   string
+		status int
+	}{
+		{services.ErrAssemblageAreaTooLarge, "area too large", http.StatusBadRequest},
+		{services.ErrInvalidGeometry, "invalid geometry", http.StatusBadRequest},
+		{fmt.Errorf("failed to query parcel adjacency: %w", repository.ErrQueryTimeout), "statement timeout", http.StatusGatewayTimeout},
+		{errors.New("connection refused"), "database error", http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockAssemblageService)
+			router := setupAssemblageTestRouter(NewAssemblageHandler(mockService))
+			mockService.On("FindAssemblages", mock.Anything, mock.Anything, mock.Anything).Return(nil, tc.err)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/analysis/assemblages?target_acres=5", strings.NewReader(testAreaBody)))
+
+			assert.Equal(t, tc.status, w.Code)
+		})
+	}
+}
+
+func TestAssemblageHandler_FindAsync(t *testing.T) {
+	t.Run("queues the search and stores its response", func(t *testing.T) {
+		mockService := new(MockAssemblageService)
+		mockResults := new(MockResultService)
+		handler := NewAssemblageHandler(mockService)
+		handler.SetResults(mockResults)
+		router := setupAssemblageTestRouter(handler)
+
+		mockResults.On("Submit", "assemblages", "https://hooks.example.com/done").
+			Return(jobs.Job{ID: "abc", Kind: services.ResultJobKindPrefix + "assemblages", Status: jobs.StatusQueued}, nil)
+
+		req := httptest.NewRequest(http.MethodPost,
+			"/api/v1/analysis/assemblages?target_acres=5&county=montgomery&async=true&callback_url=https://hooks.example.com/done",
+			strings.NewReader(testAreaBody))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusAccepted, w.Code)
+		assert.Equal(t, "/api/v1/results/abc", w.Header().Get("Location"))
+		var response ResultResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "abc", response.Result.ID)
+		assert.Equal(t, "assemblages", response.Result.Operation)
+		mockService.AssertNotCalled(t, "FindAssemblages", mock.Anything, mock.Anything, mock.Anything)
+
+		// The job keeps the request's county filter
+		mockService.On("FindAssemblages", mock.MatchedBy(func(ctx context.Context) bool {
+			return repository.CountyFromContext(ctx) == "montgomery"
+		}), mock.Anything, services.AssemblageOptions{TargetAcres: 5, Limit: 10}).
+			Return([]services.Assemblage{{ParcelIDs: []uint{2}, Acres: 5.5, OwnerCount: 1}}, nil)
+
+		value, err := mockResults.submitted(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, value.(AssemblagesResponse).Count)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("async mode without the job manager is a bad request", func(t *testing.T) {
+		router := setupAssemblageTestRouter(NewAssemblageHandler(new(MockAssemblageService)))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/analysis/assemblages?target_acres=5&async=true", strings.NewReader(testAreaBody))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Async mode is not enabled")
+	})
+
+	t.Run("callback without async is a bad request", func(t *testing.T) {
+		handler := NewAssemblageHandler(new(MockAssemblageService))
+		handler.SetResults(new(MockResultService))
+		router := setupAssemblageTestRouter(handler)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/analysis/assemblages?target_acres=5&callback_url=https://hooks.example.com/done", strings.NewReader(testAreaBody))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "callback_url requires async=true")
+	})
+
+	t.Run("invalid callback url is a validation error", func(t *testing.T) {
+		mockResults := new(MockResultService)
+		handler := NewAssemblageHandler(new(MockAssemblageService))
+		handler.SetResults(mockResults)
+		router := setupAssemblageTestRouter(handler)
+
+		mockResults.On("Submit", "assemblages", "http://10.0.0.5/done").Return(jobs.Job{}, services.ErrInvalidCallbackURL)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/analysis/assemblages?target_acres=5&async=true&callback_url=http://10.0.0.5/done", strings.NewReader(testAreaBody))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "callback_url must be an https URL")
+	})
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// AssessmentHandler handles parcel assessment history HTTP requests.
+type AssessmentHandler struct {
+	service services.AssessmentService
+}
+
+// NewAssessmentHandler creates a new AssessmentHandler instance.
+func NewAssessmentHandler(service services.AssessmentService) *AssessmentHandler {
+	return &AssessmentHandler{
+		service: service,
+	}
+}
+
+// ParcelAssessmentsResponse represents the response for the parcel assessments
+// endpoint. Assessments are newest year first, and empty where none are recorded.
+type ParcelAssessmentsResponse struct {
+	Assessments []ParcelAssessmentData `json:"assessments"`
+	ParcelID    uint                   `json:"parcel_id"`
+	Count       int                    `json:"count"`
+}
+
+// ParcelAssessmentData represents the certified values of a parcel for one tax
+// year, in whole dollars. Change is omitted for the oldest recorded year.
+type ParcelAssessmentData struct {
+	Change           *AssessmentChangeData `json:"change,omitempty"`
+	TaxYear          int                   `json:"tax_year"`
+	LandValue        int64                 `json:"land_value"`
+	ImprovementValue int64                 `json:"improvement_value"`
+	AgUseValue       int64                 `json:"ag_use_value"`
+	MarketValue      int64                 `json:"market_value"`
+	AppraisedValue   int64                 `json:"appraised_value"`
+	AssessedValue    int64                 `json:"assessed_value"`
+}
+
+// AssessmentChangeData is the change of the values from PreviousYear, the
+// closest earlier year recorded.
+type AssessmentChangeData struct {
+	MarketValue    ValueChangeData `json:"market_value"`
+	AppraisedValue ValueChangeData `json:"appraised_value"`
+	AssessedValue  ValueChangeData `json:"assessed_value"`
+	PreviousYear   int             `json:"previous_year"`
+}
+
+// ValueChangeData is the change of a value in whole dollars. Percent is rounded
+// to one decimal and omitted where the previous value was zero.
+type ValueChangeData struct {
+	Percent *float64 `json:"percent,omitempty"`
+	Amount  int64    `json:"amount"`
+}
+
+// List handles GET /api/v1/parcels/:id/assessments endpoint.
+// It returns the assessments of the parcel recorded from its county's appraisal
+// rolls, newest year first, with year-over-year changes.
+func (h *AssessmentHandler) List(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		apierrors.BadRequest(c, "parcel id must be a positive integer", nil)
+		return
+	}
+
+	assessments, err := h.service.GetParcelAssessments(c.Request.Context(), uint(id))
+	if err != nil {
+		apierrors.Respond(c, "Failed to retrieve parcel assessments", err)
+		return
+	}
+
+	middleware.SetResultCount(c, len(assessments))
+
+	response := ParcelAssessmentsResponse{
+		ParcelID:    uint(id),
+		Assessments: make([]ParcelAssessmentData, 0, len(assessments)),
+		Count:       len(assessments),
+	}
+	for i := range assessments {
+		response.Assessments = append(response.Assessments, mapParcelAssessmentToDTO(&assessments[i]))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// mapParcelAssessmentToDTO converts a ParcelAssessmentChange to a ParcelAssessmentData DTO.
+func mapParcelAssessmentToDTO(change *services.ParcelAssessmentChange) ParcelAssessmentData {
+	assessment := &change.Assessment
+	dto := ParcelAssessmentData{
+		TaxYear:          assessment.Year,
+		LandValue:        assessment.Land,
+		ImprovementValue: assessment.Improvement,
+		AgUseValue:       assessment.AgUse,
+		MarketValue:      assessment.Market,
+		AppraisedValue:   assessment.Appraised,
+		AssessedValue:    assessment.Assessed,
+	}
+	if change.Change != nil {
+		dto.Change = &AssessmentChangeData{
+			PreviousYear:   change.Change.PreviousYear,
+			MarketValue:    mapValueChangeToDTO(change.Change.Market),
+			AppraisedValue: mapValueChangeToDTO(change.Change.Appraised),
+			AssessedValue:  mapValueChangeToDTO(change.Change.Assessed),
+		}
+	}
+	return dto
+}
+
+// mapValueChangeToDTO converts a ValueChange to a ValueChangeData DTO.
+func mapValueChangeToDTO(change services.ValueChange) ValueChangeData {
+	dto := ValueChangeData{Amount: change.Amount}
+	if change.Percent != nil {
+		percent := math.Round(*change.Percent*10) / 10
+		dto.Percent = &percent
+	}
+	return dto
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MockAssessmentService is a mock implementation of AssessmentService for testing
+type MockAssessmentService struct {
+	mock.Mock
+}
+
+func (m *MockAssessmentService) GetParcelAssessments(ctx context.Context, parcelID uint) ([]services.ParcelAssessmentChange, error) {
+	args := m.Called(ctx, parcelID)
+	assessments, _ := args.Get(0).([]services.ParcelAssessmentChange)
+	return assessments, args.Error(1)
+}
+
+// setupAssessmentTestRouter creates a test router with assessment handlers.
+func setupAssessmentTestRouter(handler *AssessmentHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+
+	router.GET("/api/v1/parcels/:id/assessments", handler.List)
+
+	return router
+}
+
+func TestAssessmentHandler_List(t *testing.T) {
+	mockService := new(MockAssessmentService)
+	router := setupAssessmentTestRouter(NewAssessmentHandler(mockService))
+
+	percent := 100.0 / 3
+	mockService.On("GetParcelAssessments", mock.Anything, uint(42)).Return([]services.ParcelAssessmentChange{
+		{
+			Assessment: models.ParcelAssessment{Year: 2024, Land: 100000, Improvement: 300000, Market: 400000, Appraised: 400000, Assessed: 242000},
+			Change: &services.AssessmentChange{
+				PreviousYear: 2023,
+				Market:       services.ValueChange{Amount: 100000, Percent: &percent},
+				Appraised:    services.ValueChange{Amount: 400000},
+				Assessed:     services.ValueChange{Amount: 0, Percent: new(float64)},
+			},
+		},
+		{Assessment: models.ParcelAssessment{Year: 2023, Land: 300000, AgUse: 2000, Market: 300000, Assessed: 242000}},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/parcels/42/assessments", nil))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.JSONEq(t, `{
+		"parcel_id": 42,
+		"assessments": [
+			{
+				"tax_year": 2024, "land_value": 100000, "improvement_value": 300000, "ag_use_value": 0,
+				"market_value": 400000, "appraised_value": 400000, "assessed_value": 242000,
+				"change": {
+					"previous_year": 2023,
+					"market_value": {"amount": 100000, "percent": 33.3},
+					"appraised_value": {"amount": 400000},
+					"assessed_value": {"amount": 0, "percent": 0}
+				}
+			},
+			{
+				"tax_year": 2023, "land_value": 300000, "improvement_value": 0, "ag_use_value": 2000,
+				"market_value": 300000, "appraised_value": 0, "assessed_value": 242000
+			}
+		],
+		"count": 2
+	}`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestAssessmentHandler_ListErrors(t *testing.T) {
+	testCases := []struct {
+		err    error
+		name   string
+		url    string
+		status int
+	}{
+		{nil, "invalid id", "/api/v1/parcels/abc/assessments", http.StatusBadRequest},
+		{fmt.Errorf("%w: id 42", services.ErrParcelNotFound), "parcel not found", "/api/v1/parcels/42/assessments", http.StatusNotFound},
+		{errors.New("connection refused"), "database error", "/api/v1/parcels/42/assessments", http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockAssessmentService)
+			router := setupAssessmentTestRouter(NewAssessmentHandler(mockService))
+			if tc.err != nil {
+				mockService.On("GetParcelAssessments", mock.Anything, mock.Anything).Return(nil, tc.err)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.url, nil))
+
+			assert.Equal(t, tc.status, w.Code)
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/jobs"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MaxBulkQueryBodyBytes limits the size of a bulk query request body, enough for
+// services.MaxBulkQueryPoints points written with full float precision.
+const MaxBulkQueryBodyBytes = 8 << 20
+
+// BulkQueryHandler handles bulk query job HTTP requests.
+// Bulk queries resolve large point lists or areas to parcels in the background:
+// clients create one, poll its status, and download the JSON result through the
+// signed URL in the status once it has succeeded.
+type BulkQueryHandler struct {
+	service services.BulkQueryService
+}
+
+// NewBulkQueryHandler creates a new BulkQueryHandler instance.
+func NewBulkQueryHandler(service services.BulkQueryService) *BulkQueryHandler {
+	return &BulkQueryHandler{
+		service: service,
+	}
+}
+
+// BulkQueryPoint represents one point of a bulk query request.
+type BulkQueryPoint struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// BulkQueryRequest represents the request body for creating a bulk query.
+// Exactly one of Points, resolved to the parcel containing each point, and Area,
+// a GeoJSON Polygon or MultiPolygon resolved to every parcel intersecting it,
+// must be set. County limits the parcels matched.
+type BulkQueryRequest struct {
+	Area   json.RawMessage  `json:"area"`
+	County string           `json:"county" binding:"omitempty,max=100"`
+	Points []BulkQueryPoint `json:"points"`
+}
+
+// BulkQueryJob represents the status of a bulk query job.
+// DownloadURL and DownloadExpiresAt are set once the query has succeeded; Error
+// once it has failed. The URL is signed and needs no credentials; each status
+// request returns a fresh one.
+type BulkQueryJob struct {
+	CreatedAt         time.Time   `json:"created_at"`
+	FinishedAt        *time.Time  `json:"finished_at,omitempty"`
+	DownloadExpiresAt *time.Time  `json:"download_expires_at,omitempty"`
+	ID                string      `json:"id"`
+	Kind              string      `json:"kind"`
+	Status            jobs.Status `json:"status"`
+	Error             string      `json:"error,omitempty"`
+	DownloadURL       string      `json:"download_url,omitempty"`
+}
+
+// BulkQueryResponse represents the response for the bulk query job endpoints.
+type BulkQueryResponse struct {
+	Job *BulkQueryJob `json:"job"`
+}
+
+// Create handles POST /api/v1/jobs/bulk-query endpoint.
+// Responds 202 Accepted with the queued job.
+func (h *BulkQueryHandler) Create(c *gin.Context) {
+	log := middleware.GetLogger(c)
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxBulkQueryBodyBytes)
+
+	var req BulkQueryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// Check if it's a validation error
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		// Malformed JSON, wrong field types or a body over MaxBulkQueryBodyBytes
+		apierrors.BadRequest(c, "Invalid request body", nil)
+		return
+	}
+
+	query := services.BulkQuery{County: req.County}
+	if len(req.Area) > 0 && string(req.Area) != "null" {
+		area, err := models.ParseAreaGeoJSON(req.Area)
+		if err != nil {
+			apierrors.BadRequest(c, "area must be a GeoJSON Polygon or MultiPolygon", map[string]interface{}{
+				"reason": err.Error(),
+			})
+			return
+		}
+		query.Area = area
+	}
+	if len(req.Points) > 0 {
+		query.Points = make([]models.LatLng, len(req.Points))
+		for i, point := range req.Points {
+			query.Points[i] = models.LatLng{Lat: point.Lat, Lng: point.Lng}
+		}
+	}
+
+	job, err := h.service.CreateBulkQuery(c.Request.Context(), query)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	if log != nil {
+		log.Info("Bulk query requested", map[string]interface{}{
+			"job_id": job.ID,
+			"points": len(query.Points),
+			"area":   query.Area != nil,
+		})
+	}
+
+	c.Header("Location", jobPath(job.ID))
+	c.JSON(http.StatusAccepted, BulkQueryResponse{Job: h.mapJobToBulkQuery(job)})
+}
+
+// Get handles GET /api/v1/jobs/:id endpoint.
+func (h *BulkQueryHandler) Get(c *gin.Context) {
+	job, err := h.service.GetBulkQuery(c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, BulkQueryResponse{Job: h.mapJobToBulkQuery(job)})
+}
+
+// Download handles GET /api/v1/jobs/:id/download endpoint.
+// The expires and signature parameters authorize the request in place of
+// credentials. Responds 403 Forbidden if they are invalid or expired, and 409
+// Conflict while the query is still running or if it failed.
+func (h *BulkQueryHandler) Download(c *gin.Context) {
+	var req DownloadRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		apierrors.Forbidden(c, "Download link is missing its signature")
+		return
+	}
+
+	job, err := h.service.VerifyDownload(c.Param("id"), req.Expires, req.Signature)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	if job.Status != jobs.StatusSucceeded || job.Result == nil {
+		apierrors.Conflict(c, fmt.Sprintf("Job is %s, not ready for download", job.Status))
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": job.Result.Filename,
+	}))
+	c.Data(http.StatusOK, job.Result.ContentType, job.Result.Data)
+}
+
+// handleError maps bulk query service errors to HTTP responses.
+func (h *BulkQueryHandler) handleError(c *gin.Context, err error) {
+	err = domain.Reword(err, domain.ErrNotFound, "Job not found or expired")
+	err = domain.Reword(err, domain.ErrUnavailable, "Too many jobs are running in the background, try again shortly")
+	apierrors.Respond(c, "Failed to process bulk query request", err)
+}
+
+// jobPath returns the status URL of a job.
+func jobPath(id string) string {
+	return "/api/v1/jobs/" + id
+}
+
+// mapJobToBulkQuery converts a job snapshot into the job status response,
+// signing a download URL for a succeeded query.
+func (h *BulkQueryHandler) mapJobToBulkQuery(job jobs.Job) *BulkQueryJob {
+	status := &BulkQueryJob{
+		ID:         job.ID,
+		Kind:       job.Kind,
+		Status:     job.Status,
+		CreatedAt:  job.CreatedAt,
+		FinishedAt: job.FinishedAt,
+		Error:      job.Error,
+	}
+	if job.Status == jobs.StatusSucceeded {
+		signature, expires := h.service.SignDownload(job.ID)
+		query := url.Values{}
+		query.Set("expires", strconv.FormatInt(expires.Unix(), 10))
+		query.Set("signature", signature)
+		status.DownloadURL = jobPath(job.ID) + "/download?" + query.Encode()
+		status.DownloadExpiresAt = &expires
+	}
+	return status
+}
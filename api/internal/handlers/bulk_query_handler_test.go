@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/jobs"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MockBulkQueryService is a mock implementation of BulkQueryService for testing
+type MockBulkQueryService struct {
+	mock.Mock
+}
+
+func (m *MockBulkQueryService) CreateBulkQuery(ctx context.Context, query services.BulkQuery) (jobs.Job, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(jobs.Job), args.Error(1)
+}
+
+func (m *MockBulkQueryService) GetBulkQuery(id string) (jobs.Job, error) {
+	args := m.Called(id)
+	return args.Get(0).(jobs.Job), args.Error(1)
+}
+
+func (m *MockBulkQueryService) SignDownload(id string) (string, time.Time) {
+	args := m.Called(id)
+	return args.String(0), args.Get(1).(time.Time)
+}
+
+func (m *MockBulkQueryService) VerifyDownload(id, expires, signature string) (jobs.Job, error) {
+	args := m.Called(id, expires, signature)
+	return args.Get(0).(jobs.Job), args.Error(1)
+}
+
+// setupBulkQueryTestRouter creates a test router with bulk query handlers.
+func setupBulkQueryTestRouter(handler *BulkQueryHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+
+	jobsGroup := router.Group("/api/v1/jobs")
+	{
+		jobsGroup.POST("/bulk-query", handler.Create)
+		jobsGroup.GET("/:id", handler.Get)
+		jobsGroup.GET("/:id/download", handler.Download)
+	}
+
+	return router
+}
+
+func TestBulkQueryHandler_Create(t *testing.T) {
+	t.Run("queues points", func(t *testing.T) {
+		mockService := new(MockBulkQueryService)
+		router := setupBulkQueryTestRouter(NewBulkQueryHandler(mockService))
+
+		mockService.On("CreateBulkQuery", mock.Anything, services.BulkQuery{
+			County: "montgomery",
+			Points: []models.LatLng{{Lat: 30.35, Lng: -95.45}, {Lat: 30.36, Lng: -95.46}},
+		}).Return(jobs.Job{ID: "abc", Kind: services.BulkQueryJobKind, Status: jobs.StatusQueued}, nil)
+
+		body := `{"county":"montgomery","points":[{"lat":30.35,"lng":-95.45},{"lat":30.36,"lng":-95.46}]}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/jobs/bulk-query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Equal(t, "/api/v1/jobs/abc", w.Header().Get("Location"))
+
+		var response BulkQueryResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, jobs.StatusQueued, response.Job.Status)
+		assert.Equal(t, services.BulkQueryJobKind, response.Job.Kind)
+		assert.Empty(t, response.Job.DownloadURL)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("queues area", func(t *testing.T) {
+		mockService := new(MockBulkQueryService)
+		router := setupBulkQueryTestRouter(NewBulkQueryHandler(mockService))
+
+		mockService.On("CreateBulkQuery", mock.Anything, mock.MatchedBy(func(q services.BulkQuery) bool {
+			return q.Area != nil && q.Points == nil
+		})).Return(jobs.Job{ID: "abc", Status: jobs.StatusQueued}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/jobs/bulk-query", strings.NewReader(`{"area":`+testBundleArea+`}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		for name, body := range map[string]string{
+			"malformed body": `{"points":`,
+			"invalid area":   `{"area":{"type":"Point","coordinates":[-95.5,30.3]}}`,
+			"wrong types":    `{"points":[{"lat":"north"}]}`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				mockService := new(MockBulkQueryService)
+				router := setupBulkQueryTestRouter(NewBulkQueryHandler(mockService))
+
+				req := httptest.NewRequest(http.MethodPost, "/api/v1/jobs/bulk-query", strings.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+				mockService.AssertNotCalled(t, "CreateBulkQuery")
+			})
+		}
+	})
+
+	t.Run("service validation error maps to 400", func(t *testing.T) {
+		mockService := new(MockBulkQueryService)
+		router := setupBulkQueryTestRouter(NewBulkQueryHandler(mockService))
+
+		mockService.On("CreateBulkQuery", mock.Anything, mock.Anything).Return(jobs.Job{}, services.ErrInvalidBulkQuery)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/jobs/bulk-query", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("full queue maps to 503", func(t *testing.T) {
+		mockService := new(MockBulkQueryService)
+		router := setupBulkQueryTestRouter(NewBulkQueryHandler(mockService))
+
+		mockService.On("CreateBulkQuery", mock.Anything, mock.Anything).Return(jobs.Job{}, jobs.ErrQueueFull)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/jobs/bulk-query", strings.NewReader(`{"points":[{"lat":30.35,"lng":-95.45}]}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+}
+
+func TestBulkQueryHandler_Get(t *testing.T) {
+	mockService := new(MockBulkQueryService)
+	router := setupBulkQueryTestRouter(NewBulkQueryHandler(mockService))
+
+	expires := time.Unix(1700000900, 0).UTC()
+	mockService.On("GetBulkQuery", "abc").Return(jobs.Job{ID: "abc", Status: jobs.StatusSucceeded}, nil)
+	mockService.On("GetBulkQuery", "gone").Return(jobs.Job{}, jobs.ErrJobNotFound)
+	mockService.On("SignDownload", "abc").Return("c2ln", expires)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/jobs/abc", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response BulkQueryResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "/api/v1/jobs/abc/download?expires=1700000900&signature=c2ln", response.Job.DownloadURL)
+	require.NotNil(t, response.Job.DownloadExpiresAt)
+	assert.True(t, expires.Equal(*response.Job.DownloadExpiresAt))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/jobs/gone", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestBulkQueryHandler_Download(t *testing.T) {
+	tests := []struct {
+		err  error
+		job  jobs.Job
+		name string
+		url  string
+		code int
+	}{
+		{
+			name: "succeeded",
+			url:  "/api/v1/jobs/abc/download?expires=1&signature=s",
+			job: jobs.Job{ID: "abc", Status: jobs.StatusSucceeded, Result: &jobs.Result{
+				ContentType: services.BulkQueryContentType, Filename: services.BulkQueryFilename, Data: []byte(`{"count":0}`),
+			}},
+			code: http.StatusOK,
+		},
+		{name: "failed", url: "/api/v1/jobs/abc/download?expires=1&signature=s", job: jobs.Job{ID: "abc", Status: jobs.StatusFailed}, code: http.StatusConflict},
+		{name: "unsigned", url: "/api/v1/jobs/abc/download", code: http.StatusForbidden},
+		{name: "expired", url: "/api/v1/jobs/abc/download?expires=1&signature=s", err: services.ErrDownloadLinkExpired, code: http.StatusForbidden},
+		{name: "not found", url: "/api/v1/jobs/abc/download?expires=1&signature=s", err: jobs.ErrJobNotFound, code: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockBulkQueryService)
+			router := setupBulkQueryTestRouter(NewBulkQueryHandler(mockService))
+			mockService.On("VerifyDownload", "abc", "1", "s").Return(tt.job, tt.err)
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.code, w.Code)
+			if tt.code == http.StatusOK {
+				assert.Equal(t, "attachment; filename=bulk-query.json", w.Header().Get("Content-Disposition"))
+				assert.JSONEq(t, `{"count":0}`, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/jobs"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// BundleHandler handles offline sync bundle HTTP requests.
+// Bundles are built in the background: clients create one, poll its status, and
+// download the GeoPackage once it has succeeded.
+type BundleHandler struct {
+	service services.BundleService
+}
+
+// NewBundleHandler creates a new BundleHandler instance.
+func NewBundleHandler(service services.BundleService) *BundleHandler {
+	return &BundleHandler{
+		service: service,
+	}
+}
+
+// BundleJob represents the status of a bundle job.
+// DownloadURL is set once the bundle has succeeded; Error once it has failed.
+type BundleJob struct {
+	CreatedAt   time.Time   `json:"created_at"`
+	FinishedAt  *time.Time  `json:"finished_at,omitempty"`
+	ID          string      `json:"id"`
+	Status      jobs.Status `json:"status"`
+	Error       string      `json:"error,omitempty"`
+	DownloadURL string      `json:"download_url,omitempty"`
+}
+
+// BundleResponse represents the response for the bundle endpoints.
+type BundleResponse struct {
+	Bundle *BundleJob `json:"bundle"`
+}
+
+// Create handles POST /api/v1/bundles endpoint.
+// The body is the area of interest as a GeoJSON Polygon or MultiPolygon, like the
+// intersects endpoint. Responds 202 Accepted with the queued job.
+func (h *BundleHandler) Create(c *gin.Context) {
+	log := middleware.GetLogger(c)
+
+	// Read and parse the GeoJSON body
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, MaxAreaBodyBytes))
+	if err != nil {
+		apierrors.BadRequest(c, "Request body is too large or unreadable", nil)
+		return
+	}
+	area, err := models.ParseAreaGeoJSON(body)
+	if err != nil {
+		apierrors.BadRequest(c, "Request body must be a GeoJSON Polygon or MultiPolygon", map[string]interface{}{
+			"reason": err.Error(),
+		})
+		return
+	}
+
+	job, err := h.service.CreateBundle(c.Request.Context(), area)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	if log != nil {
+		log.Info("Bundle requested", map[string]interface{}{
+			"job_id":   job.ID,
+			"polygons": len(area.Coordinates),
+		})
+	}
+
+	c.Header("Location", bundlePath(job.ID))
+	c.JSON(http.StatusAccepted, BundleResponse{Bundle: mapJobToBundle(job)})
+}
+
+// Get handles GET /api/v1/bundles/:id endpoint.
+func (h *BundleHandler) Get(c *gin.Context) {
+	job, err := h.service.GetBundle(c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, BundleResponse{Bundle: mapJobToBundle(job)})
+}
+
+// Download handles GET /api/v1/bundles/:id/download endpoint.
+// Responds 409 Conflict while the bundle is still being built or if it failed.
+func (h *BundleHandler) Download(c *gin.Context) {
+	job, err := h.service.GetBundle(c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	if job.Status != jobs.StatusSucceeded || job.Result == nil {
+		apierrors.Conflict(c, fmt.Sprintf("Bundle is %s, not ready for download", job.Status))
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": job.Result.Filename,
+	}))
+	c.Data(http.StatusOK, job.Result.ContentType, job.Result.Data)
+}
+
+// handleError maps bundle service errors to HTTP responses.
+func (h *BundleHandler) handleError(c *gin.Context, err error) {
+	err = domain.Reword(err, domain.ErrNotFound, "Bundle not found or expired")
+	err = domain.Reword(err, domain.ErrUnavailable, "Too many bundles are being built, try again shortly")
+	apierrors.Respond(c, "Failed to process bundle request", err)
+}
+
+// bundlePath returns the status URL of a bundle job.
+func bundlePath(id string) string {
+	return "/api/v1/bundles/" + id
+}
+
+// mapJobToBundle converts a job snapshot into the bundle status response.
+func mapJobToBundle(job jobs.Job) *BundleJob {
+	bundle := &BundleJob{
+		ID:         job.ID,
+		Status:     job.Status,
+		CreatedAt:  job.CreatedAt,
+		FinishedAt: job.FinishedAt,
+		Error:      job.Error,
+	}
+	if job.Status == jobs.StatusSucceeded {
+		bundle.DownloadURL = bundlePath(job.ID) + "/download"
+	}
+	return bundle
+}
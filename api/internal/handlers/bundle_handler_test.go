@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/jobs"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// testBundleArea is a small closed GeoJSON polygon near Conroe, TX.
+const testBundleArea = `{"type":"Polygon","coordinates":[[[-95.46,30.34],[-95.44,30.34],[-95.44,30.36],[-95.46,30.36],[-95.46,30.34]]]}`
+
+// MockBundleService is a mock implementation of BundleService for testing
+type MockBundleService struct {
+	mock.Mock
+}
+
+func (m *MockBundleService) CreateBundle(ctx context.Context, area *models.MultiPolygon) (jobs.Job, error) {
+	args := m.Called(ctx, area)
+	return args.Get(0).(jobs.Job), args.Error(1)
+}
+
+func (m *MockBundleService) GetBundle(id string) (jobs.Job, error) {
+	args := m.Called(id)
+	return args.Get(0).(jobs.Job), args.Error(1)
+}
+
+// setupBundleTestRouter creates a test router with bundle handlers.
+func setupBundleTestRouter(handler *BundleHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+
+	bundles := router.Group("/api/v1/bundles")
+	{
+		bundles.POST("", handler.Create)
+		bundles.GET("/:id", handler.Get)
+		bundles.GET("/:id/download", handler.Download)
+	}
+
+	return router
+}
+
+func TestBundleHandler_Create(t *testing.T) {
+	t.Run("queues bundle for area", func(t *testing.T) {
+		mockService := new(MockBundleService)
+		router := setupBundleTestRouter(NewBundleHandler(mockService))
+
+		mockService.On("CreateBundle", mock.Anything, mock.MatchedBy(func(area *models.MultiPolygon) bool {
+			return len(area.Coordinates) == 1
+		})).Return(jobs.Job{ID: "abc", Kind: services.BundleJobKind, Status: jobs.StatusQueued}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/bundles", strings.NewReader(testBundleArea))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Equal(t, "/api/v1/bundles/abc", w.Header().Get("Location"))
+
+		var response BundleResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "abc", response.Bundle.ID)
+		assert.Equal(t, jobs.StatusQueued, response.Bundle.Status)
+		assert.Empty(t, response.Bundle.DownloadURL)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("malformed body is a bad request", func(t *testing.T) {
+		mockService := new(MockBundleService)
+		router := setupBundleTestRouter(NewBundleHandler(mockService))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/bundles", strings.NewReader(`{"type":"Point"}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "CreateBundle")
+	})
+
+	t.Run("service errors", func(t *testing.T) {
+		tests := []struct {
+			err  error
+			name string
+			code int
+		}{
+			{name: "invalid geometry", err: services.ErrInvalidGeometry, code: http.StatusBadRequest},
+			{name: "queue full", err: jobs.ErrQueueFull, code: http.StatusServiceUnavailable},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockService := new(MockBundleService)
+				router := setupBundleTestRouter(NewBundleHandler(mockService))
+				mockService.On("CreateBundle", mock.Anything, mock.Anything).Return(jobs.Job{}, tt.err)
+
+				req := httptest.NewRequest(http.MethodPost, "/api/v1/bundles", strings.NewReader(testBundleArea))
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				assert.Equal(t, tt.code, w.Code)
+			})
+		}
+	})
+}
+
+func TestBundleHandler_Get(t *testing.T) {
+	mockService := new(MockBundleService)
+	router := setupBundleTestRouter(NewBundleHandler(mockService))
+
+	finished := time.Now().UTC()
+	mockService.On("GetBundle", "abc").Return(jobs.Job{ID: "abc", Status: jobs.StatusSucceeded, FinishedAt: &finished}, nil)
+	mockService.On("GetBundle", "missing").Return(jobs.Job{}, jobs.ErrJobNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/bundles/abc", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response BundleResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "/api/v1/bundles/abc/download", response.Bundle.DownloadURL)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/bundles/missing", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestBundleHandler_Download(t *testing.T) {
+	mockService := new(MockBundleService)
+	router := setupBundleTestRouter(NewBundleHandler(mockService))
+
+	mockService.On("GetBundle", "done").Return(jobs.Job{
+		ID:     "done",
+		Status: jobs.StatusSucceeded,
+		Result: &jobs.Result{ContentType: "application/geopackage+sqlite3", Filename: "parcels.gpkg", Data: []byte("gpkg")},
+	}, nil)
+	mockService.On("GetBundle", "running").Return(jobs.Job{ID: "running", Status: jobs.StatusRunning}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/bundles/done/download", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/geopackage+sqlite3", w.Header().Get("Content-Type"))
+	assert.Equal(t, "attachment; filename=parcels.gpkg", w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "gpkg", w.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/bundles/running/download", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var response apierrors.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, apierrors.ErrConflict, response.Error.Code)
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// defaultComparablesLimit is the number of comparables returned when limit is omitted.
+const defaultComparablesLimit = 10
+
+// ComparableHandler handles parcel comparables HTTP requests.
+type ComparableHandler struct {
+	service services.ComparableService
+}
+
+// NewComparableHandler creates a new ComparableHandler instance.
+func NewComparableHandler(service services.ComparableService) *ComparableHandler {
+	return &ComparableHandler{
+		service: service,
+	}
+}
+
+// ComparablesRequest represents the query parameters for the comparables endpoint.
+type ComparablesRequest struct {
+	Fields string `form:"fields"`
+	Radius int    `form:"radius" binding:"omitempty,min=1,max=5000"` // default: 1600
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=50"`    // default: 10
+}
+
+// ComparablesResponse represents the response for the comparables endpoint.
+// Comparables are ranked by score, then distance from the subject.
+type ComparablesResponse struct {
+	Subject     *ComparableSubject `json:"subject"`
+	Comparables []ComparableParcel `json:"comparables"`
+	Count       int                `json:"count"`
+}
+
+// ComparableSubject is the parcel comparables are found for, with the year
+// built they are compared on.
+type ComparableSubject struct {
+	*ParcelData
+	YearBuilt *int `json:"year_built,omitempty"`
+}
+
+// ComparableParcel is a parcel similar to the subject. Score is the weighted
+// mean of its Similarity inputs, between 0 and 1; Distance is measured from
+// the subject's centroid.
+type ComparableParcel struct {
+	*ParcelData
+	YearBuilt  *int           `json:"year_built,omitempty"`
+	Similarity SimilarityData `json:"similarity"`
+	Distance   float64        `json:"distance_meters"`
+	Score      float64        `json:"score"`
+}
+
+// SimilarityData holds a comparable's similarity to the subject in each scored
+// input, between 0 and 1. LandUse and YearBuilt are omitted when the subject
+// has no value to compare with, and then do not count toward the score.
+type SimilarityData struct {
+	LandUse   *float64 `json:"land_use,omitempty"`
+	YearBuilt *float64 `json:"year_built,omitempty"`
+	Distance  float64  `json:"distance"`
+	Acres     float64  `json:"acres"`
+}
+
+// List handles GET /api/v1/parcels/:id/comparables endpoint.
+// It returns the nearby parcels most similar to the parcel in acreage, land
+// use and year built, the comps appraisers value it against.
+func (h *ComparableHandler) List(c *gin.Context) {
+	log := middleware.GetLogger(c)
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		apierrors.BadRequest(c, "parcel id must be a positive integer", nil)
+		return
+	}
+
+	var req ComparablesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		apierrors.BadRequest(c, "Invalid query parameters", nil)
+		return
+	}
+	if req.Radius == 0 {
+		req.Radius = services.DefaultComparablesRadiusMeters
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultComparablesLimit
+	}
+
+	ctx, fields, ok := parseFieldSelection(c, req.Fields, ComparableParcel{})
+	if !ok {
+		return
+	}
+
+	if log != nil {
+		log.Info("Processing comparables request", map[string]interface{}{
+			"parcel_id": id,
+			"radius":    req.Radius,
+			"limit":     req.Limit,
+		})
+	}
+
+	comparables, err := h.service.FindComparables(ctx, uint(id), services.ComparablesOptions{
+		RadiusMeters: req.Radius,
+		Limit:        req.Limit,
+	})
+	if err != nil {
+		queryFailed(c, "Failed to find comparables", err)
+		return
+	}
+
+	response := ComparablesResponse{
+		Subject: &ComparableSubject{
+			ParcelData: mapTaxParcelToDTO(&comparables.Subject),
+			YearBuilt:  comparables.Subject.ImprvActualYearBuilt,
+		},
+		Comparables: make([]ComparableParcel, 0, len(comparables.Parcels)),
+		Count:       len(comparables.Parcels),
+	}
+	for i := range comparables.Parcels {
+		found := &comparables.Parcels[i]
+		response.Comparables = append(response.Comparables, ComparableParcel{
+			ParcelData: mapTaxParcelToDTO(&found.Parcel),
+			YearBuilt:  found.Parcel.ImprvActualYearBuilt,
+			Similarity: SimilarityData{
+				LandUse:   found.Similarity.LandUse,
+				YearBuilt: found.Similarity.YearBuilt,
+				Distance:  found.Similarity.Distance,
+				Acres:     found.Similarity.Acres,
+			},
+			Distance: found.Distance,
+			Score:    found.Score,
+		})
+	}
+
+	middleware.SetResultCount(c, response.Count)
+
+	renderSelectedJSON(c, response, fields, "subject", "comparables")
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MockComparableService is a mock implementation of ComparableService for testing
+type MockComparableService struct {
+	mock.Mock
+}
+
+func (m *MockComparableService) FindComparables(ctx context.Context, id uint, opts services.ComparablesOptions) (*services.Comparables, error) {
+	args := m.Called(ctx, id, opts)
+	comparables, _ := args.Get(0).(*services.Comparables)
+	return comparables, args.Error(1)
+}
+
+// setupComparableTestRouter creates a test router with comparables handlers.
+func setupComparableTestRouter(handler *ComparableHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+
+	router.GET("/api/v1/parcels/:id/comparables", handler.List)
+
+	return router
+}
+
+// testComparables is a subject built in 2004 with one comparable.
+func testComparables() *services.Comparables {
+	subjectYear, comparableYear := 2004, 2001
+	landUse, yearBuilt := 1.0, 0.88
+	return &services.Comparables{
+		Subject: models.TaxParcel{ID: 42, Acres: 1.5, ImprvActualYearBuilt: &subjectYear},
+		Parcels: []services.Comparable{{
+			Parcel:   models.TaxParcel{ID: 43, Acres: 1.2, ImprvActualYearBuilt: &comparableYear},
+			Distance: 180.5,
+			Score:    0.9,
+			Similarity: services.ComparableSimilarity{
+				LandUse: &landUse, YearBuilt: &yearBuilt, Distance: 0.89, Acres: 0.8,
+			},
+		}},
+	}
+}
+
+func TestComparableHandler_List(t *testing.T) {
+	mockService := new(MockComparableService)
+	router := setupComparableTestRouter(NewComparableHandler(mockService))
+
+	mockService.On("FindComparables", mock.Anything, uint(42),
+		services.ComparablesOptions{RadiusMeters: services.DefaultComparablesRadiusMeters, Limit: 10}).Return(testComparables(), nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/parcels/42/comparables", nil))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response ComparablesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, uint(42), response.Subject.ID)
+	assert.Equal(t, 2004, *response.Subject.YearBuilt)
+	require.Equal(t, 1, response.Count)
+	first := response.Comparables[0]
+	assert.Equal(t, uint(43), first.ID)
+	assert.Equal(t, 2001, *first.YearBuilt)
+	assert.Equal(t, 180.5, first.Distance)
+	assert.Equal(t, 0.9, first.Score)
+	assert.Equal(t, 0.8, first.Similarity.Acres)
+	assert.Equal(t, 0.88, *first.Similarity.YearBuilt)
+	mockService.AssertExpectations(t)
+}
+
+func TestComparableHandler_ListFieldSelection(t *testing.T) {
+	mockService := new(MockComparableService)
+	router := setupComparableTestRouter(NewComparableHandler(mockService))
+
+	mockService.On("FindComparables", mock.Anything, uint(42), services.ComparablesOptions{RadiusMeters: 800, Limit: 5}).
+		Return(testComparables(), nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/parcels/42/comparables?radius=800&limit=5&fields=score,acres", nil))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, map[string]interface{}{"id": 42.0, "acres": 1.5}, body["subject"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": 43.0, "acres": 1.2, "score": 0.9}}, body["comparables"])
+}
+
+func TestComparableHandler_ListInvalidRequest(t *testing.T) {
+	for name, url := range map[string]string{
+		"invalid id":       "/api/v1/parcels/abc/comparables",
+		"radius too large": "/api/v1/parcels/42/comparables?radius=5001",
+		"limit too large":  "/api/v1/parcels/42/comparables?limit=51",
+		"unknown field":    "/api/v1/parcels/42/comparables?fields=bogus",
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockService := new(MockComparableService)
+			router := setupComparableTestRouter(NewComparableHandler(mockService))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockService.AssertNotCalled(t, "FindComparables", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestComparableHandler_ListErrors(t *testing.T) {
+	testCases := []struct {
+		err    error
+		name   string
+		status int
+	}{
+		{fmt.Errorf("%w: id 42", services.ErrParcelNotFound), "not found", http.StatusNotFound},
+		{errors.New("connection refused"), "database error", http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockComparableService)
+			router := setupComparableTestRouter(NewComparableHandler(mockService))
+			mockService.On("FindComparables", mock.Anything, mock.Anything, mock.Anything).Return(nil, tc.err)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/parcels/42/comparables", nil))
+
+			assert.Equal(t, tc.status, w.Code)
+		})
+	}
+}
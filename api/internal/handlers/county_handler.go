@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// CountyHandler handles county HTTP requests.
+type CountyHandler struct {
+	service services.CountyService
+}
+
+// NewCountyHandler creates a new CountyHandler instance.
+func NewCountyHandler(service services.CountyService) *CountyHandler {
+	return &CountyHandler{
+		service: service,
+	}
+}
+
+// CountyListResponse represents the response for the county list endpoint.
+type CountyListResponse struct {
+	Counties []models.County `json:"counties"`
+	Count    int             `json:"count"`
+}
+
+// SaveCountyProfileRequest represents the request body replacing a county
+// profile. Omitted limits fall back to the global defaults.
+type SaveCountyProfileRequest struct {
+	DefaultRadiusMeters *int                `json:"defaultRadiusMeters" binding:"omitempty,min=1,max=5000"`
+	MaxRadiusMeters     *int                `json:"maxRadiusMeters" binding:"omitempty,min=1,max=5000"`
+	SimplifyTolerance   *float64            `json:"simplifyTolerance" binding:"omitempty,min=0,max=0.01"`
+	Attributes          map[string]bool     `json:"attributes"`
+	Source              models.CountySource `json:"source"`
+}
+
+// CountyProfileResponse represents the response for a single county profile.
+type CountyProfileResponse struct {
+	Profile *models.CountyProfile `json:"profile"`
+}
+
+// CountyProfileListResponse represents the response for the county profile list endpoint.
+type CountyProfileListResponse struct {
+	Profiles []models.CountyProfile `json:"profiles"`
+	Count    int                    `json:"count"`
+}
+
+// List handles GET /api/v1/counties endpoint.
+// It returns the counties whose slugs the parcel endpoints accept as county filter.
+func (h *CountyHandler) List(c *gin.Context) {
+	counties, err := h.service.ListCounties(c.Request.Context())
+	if err != nil {
+		apierrors.InternalServerError(c, "Failed to list counties", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, CountyListResponse{
+		Counties: counties,
+		Count:    len(counties),
+	})
+}
+
+// ListProfiles handles GET /api/v1/admin/county-profiles endpoint.
+// Every county is listed; counties without a saved profile have an empty one.
+func (h *CountyHandler) ListProfiles(c *gin.Context) {
+	profiles, err := h.service.ListProfiles(c.Request.Context())
+	if err != nil {
+		h.handleProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, CountyProfileListResponse{
+		Profiles: profiles,
+		Count:    len(profiles),
+	})
+}
+
+// GetProfile handles GET /api/v1/admin/county-profiles/:county endpoint.
+func (h *CountyHandler) GetProfile(c *gin.Context) {
+	profile, err := h.service.GetProfile(c.Request.Context(), c.Param("county"))
+	if err != nil {
+		h.handleProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, CountyProfileResponse{Profile: profile})
+}
+
+// PutProfile handles PUT /api/v1/admin/county-profiles/:county endpoint.
+// It replaces the whole profile; the parcel services apply it within
+// services.CountyProfileCacheTTL on every instance.
+func (h *CountyHandler) PutProfile(c *gin.Context) {
+	var req SaveCountyProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// Check if it's a validation error
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		// Malformed JSON or wrong field types
+		apierrors.BadRequest(c, "Invalid request body", nil)
+		return
+	}
+
+	profile, err := h.service.SaveProfile(c.Request.Context(), c.Param("county"), &models.CountyProfile{
+		DefaultRadiusMeters: req.DefaultRadiusMeters,
+		MaxRadiusMeters:     req.MaxRadiusMeters,
+		SimplifyTolerance:   req.SimplifyTolerance,
+		Attributes:          req.Attributes,
+		Source:              req.Source,
+	})
+	if err != nil {
+		h.handleProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, CountyProfileResponse{Profile: profile})
+}
+
+// handleProfileError maps county profile service errors to HTTP responses.
+func (h *CountyHandler) handleProfileError(c *gin.Context, err error) {
+	apierrors.Respond(c, "Failed to process county profile request", err)
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MockCountyService is a mock implementation of CountyService for testing
+type MockCountyService struct {
+	mock.Mock
+}
+
+func (m *MockCountyService) ListCounties(ctx context.Context) ([]models.County, error) {
+	args := m.Called(ctx)
+	counties, _ := args.Get(0).([]models.County)
+	return counties, args.Error(1)
+}
+
+func (m *MockCountyService) Profile(ctx context.Context, slug string) (*models.CountyProfile, error) {
+	args := m.Called(ctx, slug)
+	profile, _ := args.Get(0).(*models.CountyProfile)
+	return profile, args.Error(1)
+}
+
+func (m *MockCountyService) ListProfiles(ctx context.Context) ([]models.CountyProfile, error) {
+	args := m.Called(ctx)
+	profiles, _ := args.Get(0).([]models.CountyProfile)
+	return profiles, args.Error(1)
+}
+
+func (m *MockCountyService) GetProfile(ctx context.Context, slug string) (*models.CountyProfile, error) {
+	args := m.Called(ctx, slug)
+	profile, _ := args.Get(0).(*models.CountyProfile)
+	return profile, args.Error(1)
+}
+
+func (m *MockCountyService) SaveProfile(ctx context.Context, slug string, profile *models.CountyProfile) (*models.CountyProfile, error) {
+	args := m.Called(ctx, slug, profile)
+	stored, _ := args.Get(0).(*models.CountyProfile)
+	return stored, args.Error(1)
+}
+
+// setupCountyTestRouter creates a test router with county handlers.
+func setupCountyTestRouter(handler *CountyHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+
+	router.GET("/api/v1/counties", handler.List)
+	router.GET("/api/v1/admin/county-profiles/:county", handler.GetProfile)
+	router.PUT("/api/v1/admin/county-profiles/:county", handler.PutProfile)
+
+	return router
+}
+
+func TestCountyHandler_List(t *testing.T) {
+	mockService := new(MockCountyService)
+	router := setupCountyTestRouter(NewCountyHandler(mockService))
+
+	source := "OBJECTID"
+	mockService.On("ListCounties", mock.Anything).Return([]models.County{
+		{ID: 1, Slug: "montgomery-tx", Name: "Montgomery", State: "TX", FieldMappings: map[string]*string{"object_id": &source}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/counties", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response CountyListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Count)
+	assert.Equal(t, "montgomery-tx", response.Counties[0].Slug)
+	assert.Equal(t, "OBJECTID", *response.Counties[0].FieldMappings["object_id"])
+	mockService.AssertExpectations(t)
+}
+
+func TestCountyHandler_List_ServiceError(t *testing.T) {
+	mockService := new(MockCountyService)
+	router := setupCountyTestRouter(NewCountyHandler(mockService))
+
+	mockService.On("ListCounties", mock.Anything).Return(nil, errors.New("database connection failed"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/counties", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestCountyHandler_PutProfile(t *testing.T) {
+	t.Run("saves the profile", func(t *testing.T) {
+		mockService := new(MockCountyService)
+		router := setupCountyTestRouter(NewCountyHandler(mockService))
+
+		maxRadius := 2000
+		mockService.On("SaveProfile", mock.Anything, "montgomery-tx", mock.MatchedBy(func(p *models.CountyProfile) bool {
+			return *p.MaxRadiusMeters == maxRadius && !p.Attributes["year_built"] &&
+				p.Source.ArcGISURL == "https://gis.example.gov/FeatureServer/0"
+		})).Return(&models.CountyProfile{CountyID: 1, County: "montgomery-tx", MaxRadiusMeters: &maxRadius}, nil)
+
+		body := `{"maxRadiusMeters": 2000, "attributes": {"year_built": false},
+			"source": {"arcgisUrl": "https://gis.example.gov/FeatureServer/0"}}`
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/county-profiles/montgomery-tx", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response CountyProfileResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, 2000, *response.Profile.MaxRadiusMeters)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("radius out of range", func(t *testing.T) {
+		mockService := new(MockCountyService)
+		router := setupCountyTestRouter(NewCountyHandler(mockService))
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/county-profiles/montgomery-tx", strings.NewReader(`{"maxRadiusMeters": 9000}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "SaveProfile", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid profile", func(t *testing.T) {
+		mockService := new(MockCountyService)
+		router := setupCountyTestRouter(NewCountyHandler(mockService))
+		mockService.On("SaveProfile", mock.Anything, "montgomery-tx", mock.Anything).Return(nil, services.ErrInvalidProfile)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/county-profiles/montgomery-tx", strings.NewReader(`{"attributes": {"pool": false}}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestCountyHandler_GetProfile_NotFound(t *testing.T) {
+	mockService := new(MockCountyService)
+	router := setupCountyTestRouter(NewCountyHandler(mockService))
+	mockService.On("GetProfile", mock.Anything, "nowhere-tx").Return(nil, services.ErrCountyNotFound)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/county-profiles/nowhere-tx", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
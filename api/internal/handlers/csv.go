@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+)
+
+// CSV export constants
+const (
+	// CSVContentType is the media type of CSV responses (RFC 4180)
+	CSVContentType = "text/csv; charset=utf-8"
+	// CSVFilename is the download name of CSV responses
+	CSVFilename = "parcels.csv"
+	// csvGeometryColumn holds the parcel geometry as WKT when geometry is selected
+	csvGeometryColumn = "geometry_wkt"
+	// csvFlushRows is how many rows are written between flushes to the client
+	csvFlushRows = 500
+)
+
+// renderCSV streams the given DTOs as a CSV download, one row per DTO after a
+// header row. Columns match the GeoPackage export: the id first, then one
+// column per property in name order, with positions such as the centroid split
+// into <name>_lng and <name>_lat and each derived attribute in an attr_<name>
+// column. The geometry becomes a trailing geometry_wkt column when selected.
+// Field selection and role visibility apply as in renderFeatureCollection.
+// Rows are flushed in chunks, so large exports start downloading at once.
+func renderCSV[T any](c *gin.Context, dtos []T, fields []string) {
+	role := middleware.GetRole(c)
+	features := make([]Feature, 0, len(dtos))
+	wkt := make([]string, 0, len(dtos))
+	withGeometry := false
+	for _, dto := range dtos {
+		feature, err := toFeature(dto)
+		if err != nil {
+			apierrors.InternalServerError(c, "Failed to encode CSV response", err)
+			return
+		}
+		shapeFeature(&feature, fields, role)
+		flattenAttributes(&feature)
+
+		// Convert geometries before the header is written, so a failure is still a 500
+		geometry := ""
+		if feature.Geometry != nil {
+			area, err := featureGeometry(feature.Geometry)
+			if err != nil {
+				apierrors.InternalServerError(c, "Failed to encode CSV response", err)
+				return
+			}
+			geometry = area.WKT()
+			withGeometry = true
+		}
+		features = append(features, feature)
+		wkt = append(wkt, geometry)
+	}
+
+	layout := geoPackageLayout(features)
+	header := []string{fieldID}
+	for _, col := range layout {
+		for _, column := range col.columns {
+			header = append(header, column.Name)
+		}
+	}
+	if withGeometry {
+		header = append(header, csvGeometryColumn)
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": CSVFilename,
+	}))
+	c.Header("Content-Type", CSVContentType)
+	c.Status(http.StatusOK)
+
+	log := middleware.GetLogger(c)
+	writer := csv.NewWriter(c.Writer)
+	if err := writer.Write(header); err != nil {
+		logCSVAbort(log, err)
+		return
+	}
+	for i := range features {
+		feature := &features[i]
+		row := make([]string, 0, len(header))
+		row = append(row, csvCells(feature.Properties[fieldID], 1)...)
+		for _, col := range layout {
+			row = append(row, csvCells(feature.Properties[col.property], len(col.columns))...)
+		}
+		if withGeometry {
+			row = append(row, wkt[i])
+		}
+		if err := writer.Write(row); err != nil {
+			logCSVAbort(log, err)
+			return
+		}
+
+		if (i+1)%csvFlushRows == 0 {
+			writer.Flush()
+			c.Writer.Flush()
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		logCSVAbort(log, err)
+	}
+}
+
+// logCSVAbort logs a CSV response cut short, typically by the client
+// disconnecting; the status is already sent, so no error body can follow.
+func logCSVAbort(log *logger.Logger, err error) {
+	if log == nil {
+		return
+	}
+	log.Warn("CSV response aborted", map[string]interface{}{
+		"error": err.Error(),
+	})
+}
+
+// csvCells formats a property as the cells of its width columns, following
+// geoPackageValues: positions fill two columns and other nested values are
+// JSON. Text that a spreadsheet would evaluate as a formula is prefixed with
+// an apostrophe.
+func csvCells(value any, width int) []string {
+	if width == 2 {
+		if position, ok := value.([]interface{}); ok && isPosition(position) {
+			return []string{csvNumber(position[0].(float64)), csvNumber(position[1].(float64))}
+		}
+		return []string{"", ""}
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return []string{""}
+	case string:
+		return []string{csvText(v)}
+	case float64:
+		return []string{csvNumber(v)}
+	case bool:
+		return []string{strconv.FormatBool(v)}
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return []string{""}
+		}
+		return []string{string(data)}
+	}
+}
+
+// csvNumber formats a number without exponent or trailing zeros.
+func csvNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// csvText neutralizes text starting with a formula character, so an owner
+// name like "=HYPERLINK(...)" is shown rather than evaluated.
+func csvText(v string) string {
+	if v == "" {
+		return v
+	}
+	switch v[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + v
+	}
+	return v
+}
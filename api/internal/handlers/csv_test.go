@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderCSV(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("renders a CSV attachment", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		renderCSV(c, testRouteParcels(), nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, CSVContentType, w.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename=parcels.csv`, w.Header().Get("Content-Disposition"))
+
+		records, err := csv.NewReader(strings.NewReader(w.Body.String())).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		assert.Equal(t, []string{
+			"id", "centroid_lng", "centroid_lat", "county_name", "distance_meters", "owner_name", "station_meters", "geometry_wkt",
+		}, records[0])
+		assert.Equal(t, []string{
+			"2", "-95.445", "30.345", "Montgomery", "12.5", "Test Owner", "100",
+			"MULTIPOLYGON (((-95.45 30.34, -95.44 30.34, -95.44 30.35, -95.45 30.34)))",
+		}, records[1])
+		assert.Equal(t, []string{"1", "0", "0", "Montgomery", "0", "", "40", ""}, records[2])
+	})
+
+	t.Run("field selection drops the geometry column", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		renderCSV(c, testRouteParcels(), []string{"owner_name"})
+
+		assert.Equal(t, "id,owner_name\n2,Test Owner\n1,\n", w.Body.String())
+	})
+
+	t.Run("empty input renders the header only", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		renderCSV(c, []*ParcelData{}, nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "id\n", w.Body.String())
+	})
+
+	t.Run("unsupported geometry fails before streaming", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/parcels/nearby?format=csv", nil)
+
+		dtos := []*ParcelData{{ID: 1, Geometry: map[string]interface{}{"type": "Point", "coordinates": []float64{-95.45, 30.34}}}}
+		renderCSV(c, dtos, nil)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.NotEqual(t, CSVContentType, w.Header().Get("Content-Type"))
+	})
+}
+
+func TestCSVCells(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  []string
+		width int
+	}{
+		{name: "null", value: nil, width: 1, want: []string{""}},
+		{name: "text", value: "Test Owner", width: 1, want: []string{"Test Owner"}},
+		{name: "formula text", value: "=HYPERLINK(\"x\")", width: 1, want: []string{"'=HYPERLINK(\"x\")"}},
+		{name: "leading minus text", value: "-1+1", width: 1, want: []string{"'-1+1"}},
+		{name: "negative number", value: -95.5, width: 1, want: []string{"-95.5"}},
+		{name: "large number", value: 12345678.0, width: 1, want: []string{"12345678"}},
+		{name: "bool", value: true, width: 1, want: []string{"true"}},
+		{name: "object", value: map[string]interface{}{"school": 120.5}, width: 1, want: []string{`{"school":120.5}`}},
+		{name: "position", value: []interface{}{-95.4, 30.3}, width: 2, want: []string{"-95.4", "30.3"}},
+		{name: "missing position", value: nil, width: 2, want: []string{"", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, csvCells(tt.value, tt.width))
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// DocumentHandler handles parcel document reference HTTP requests.
+type DocumentHandler struct {
+	service services.DocumentService
+}
+
+// NewDocumentHandler creates a new DocumentHandler instance.
+func NewDocumentHandler(service services.DocumentService) *DocumentHandler {
+	return &DocumentHandler{
+		service: service,
+	}
+}
+
+// DocumentListResponse represents the response for the parcel documents endpoint.
+type DocumentListResponse struct {
+	Documents []models.DocumentReference `json:"documents"`
+	ParcelID  uint                       `json:"parcelId"`
+	Count     int                        `json:"count"`
+}
+
+// List handles GET /api/v1/parcels/:id/documents endpoint.
+// It returns the deeds, plats and instruments cited by the parcel's records,
+// with a link to the county clerk's records search where one is configured.
+func (h *DocumentHandler) List(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		apierrors.BadRequest(c, "parcel id must be a positive integer", nil)
+		return
+	}
+
+	result, err := h.service.GetParcelDocuments(c.Request.Context(), uint(id))
+	if err != nil {
+		apierrors.Respond(c, "Failed to list parcel documents", err)
+		return
+	}
+
+	middleware.SetResultCount(c, len(result.Documents))
+
+	c.JSON(http.StatusOK, DocumentListResponse{
+		ParcelID:  result.ParcelID,
+		Documents: result.Documents,
+		Count:     len(result.Documents),
+	})
+}
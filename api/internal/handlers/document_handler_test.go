@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MockDocumentService is a mock implementation of DocumentService for testing
+type MockDocumentService struct {
+	mock.Mock
+}
+
+func (m *MockDocumentService) GetParcelDocuments(ctx context.Context, parcelID uint) (*services.ParcelDocuments, error) {
+	args := m.Called(ctx, parcelID)
+	result, _ := args.Get(0).(*services.ParcelDocuments)
+	return result, args.Error(1)
+}
+
+// setupDocumentTestRouter creates a test router with document handlers.
+func setupDocumentTestRouter(handler *DocumentHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+
+	router.GET("/api/v1/parcels/:id/documents", handler.List)
+
+	return router
+}
+
+func TestDocumentHandler_List(t *testing.T) {
+	mockService := new(MockDocumentService)
+	router := setupDocumentTestRouter(NewDocumentHandler(mockService))
+
+	mockService.On("GetParcelDocuments", mock.Anything, uint(42)).Return(&services.ParcelDocuments{
+		ParcelID: 42,
+		Documents: []models.DocumentReference{{
+			Type:   models.DocumentTypeVolumePage,
+			Volume: "1234",
+			Page:   "56",
+			Source: models.DocumentSourceLegalDescription,
+			Text:   "VOL 1234 PG 56",
+			URL:    "https://clerk.example.gov/search?vol=1234&page=56",
+		}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/parcels/42/documents", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response DocumentListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, uint(42), response.ParcelID)
+	assert.Equal(t, 1, response.Count)
+	assert.Equal(t, "https://clerk.example.gov/search?vol=1234&page=56", response.Documents[0].URL)
+	mockService.AssertExpectations(t)
+}
+
+func TestDocumentHandler_List_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"invalid id", "/api/v1/parcels/abc/documents", nil, http.StatusBadRequest},
+		{"negative id", "/api/v1/parcels/-1/documents", nil, http.StatusBadRequest},
+		{"not found", "/api/v1/parcels/7/documents", services.ErrParcelNotFound, http.StatusNotFound},
+		{"service error", "/api/v1/parcels/7/documents", errors.New("database connection failed"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockDocumentService)
+			router := setupDocumentTestRouter(NewDocumentHandler(mockService))
+			if tt.serviceErr != nil {
+				mockService.On("GetParcelDocuments", mock.Anything, uint(7)).Return(nil, tt.serviceErr)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
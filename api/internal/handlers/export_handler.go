@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/jobs"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// ExportHandler handles GIS export HTTP requests.
+// Exports are built in the background: clients create one, poll its status, and
+// download the file through the signed URL in the status once it has succeeded.
+type ExportHandler struct {
+	service services.ExportService
+}
+
+// NewExportHandler creates a new ExportHandler instance.
+func NewExportHandler(service services.ExportService) *ExportHandler {
+	return &ExportHandler{
+		service: service,
+	}
+}
+
+// ExportRequest represents the query parameters for creating an export.
+type ExportRequest struct {
+	Format string `form:"format" binding:"required,oneof=shp gpkg"`
+	County string `form:"county" binding:"omitempty,max=100"`
+}
+
+// DownloadRequest represents the signature query parameters of a download URL.
+type DownloadRequest struct {
+	Expires   string `form:"expires" binding:"required"`
+	Signature string `form:"signature" binding:"required"`
+}
+
+// ExportJob represents the status of an export job.
+// DownloadURL and DownloadExpiresAt are set once the export has succeeded; Error
+// once it has failed. The URL is signed and needs no credentials, so it can be
+// opened from QGIS or ArcGIS; each status request returns a fresh one.
+type ExportJob struct {
+	CreatedAt         time.Time   `json:"created_at"`
+	FinishedAt        *time.Time  `json:"finished_at,omitempty"`
+	DownloadExpiresAt *time.Time  `json:"download_expires_at,omitempty"`
+	ID                string      `json:"id"`
+	Status            jobs.Status `json:"status"`
+	Error             string      `json:"error,omitempty"`
+	DownloadURL       string      `json:"download_url,omitempty"`
+}
+
+// ExportResponse represents the response for the export endpoints.
+type ExportResponse struct {
+	Export *ExportJob `json:"export"`
+}
+
+// Create handles POST /api/v1/exports endpoint.
+// The body is the area of interest as a GeoJSON Polygon or MultiPolygon, like the
+// intersects endpoint; format is shp (zipped Shapefile) or gpkg (GeoPackage).
+// Responds 202 Accepted with the queued job.
+func (h *ExportHandler) Create(c *gin.Context) {
+	log := middleware.GetLogger(c)
+
+	// Bind and validate query parameters
+	var req ExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		apierrors.BadRequest(c, "Invalid query parameters", nil)
+		return
+	}
+
+	// Read and parse the GeoJSON body
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, MaxAreaBodyBytes))
+	if err != nil {
+		apierrors.BadRequest(c, "Request body is too large or unreadable", nil)
+		return
+	}
+	area, err := models.ParseAreaGeoJSON(body)
+	if err != nil {
+		apierrors.BadRequest(c, "Request body must be a GeoJSON Polygon or MultiPolygon", map[string]interface{}{
+			"reason": err.Error(),
+		})
+		return
+	}
+
+	job, err := h.service.CreateExport(c.Request.Context(), area, req.Format, req.County)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	if log != nil {
+		log.Info("Export requested", map[string]interface{}{
+			"job_id":   job.ID,
+			"format":   req.Format,
+			"polygons": len(area.Coordinates),
+		})
+	}
+
+	c.Header("Location", exportPath(job.ID))
+	c.JSON(http.StatusAccepted, ExportResponse{Export: h.mapJobToExport(job)})
+}
+
+// Get handles GET /api/v1/exports/:id endpoint.
+func (h *ExportHandler) Get(c *gin.Context) {
+	job, err := h.service.GetExport(c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ExportResponse{Export: h.mapJobToExport(job)})
+}
+
+// Download handles GET /api/v1/exports/:id/download endpoint.
+// The expires and signature parameters authorize the request in place of
+// credentials. Responds 403 Forbidden if they are invalid or expired, and 409
+// Conflict while the export is still being built or if it failed.
+func (h *ExportHandler) Download(c *gin.Context) {
+	var req DownloadRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		apierrors.Forbidden(c, "Download link is missing its signature")
+		return
+	}
+
+	job, err := h.service.VerifyDownload(c.Param("id"), req.Expires, req.Signature)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	if job.Status != jobs.StatusSucceeded || job.Result == nil {
+		apierrors.Conflict(c, fmt.Sprintf("Export is %s, not ready for download", job.Status))
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": job.Result.Filename,
+	}))
+	c.Data(http.StatusOK, job.Result.ContentType, job.Result.Data)
+}
+
+// handleError maps export service errors to HTTP responses.
+func (h *ExportHandler) handleError(c *gin.Context, err error) {
+	err = domain.Reword(err, domain.ErrNotFound, "Export not found or expired")
+	err = domain.Reword(err, domain.ErrUnavailable, "Too many exports are being built, try again shortly")
+	apierrors.Respond(c, "Failed to process export request", err)
+}
+
+// exportPath returns the status URL of an export job.
+func exportPath(id string) string {
+	return "/api/v1/exports/" + id
+}
+
+// mapJobToExport converts a job snapshot into the export status response,
+// signing a download URL for a succeeded export.
+func (h *ExportHandler) mapJobToExport(job jobs.Job) *ExportJob {
+	export := &ExportJob{
+		ID:         job.ID,
+		Status:     job.Status,
+		CreatedAt:  job.CreatedAt,
+		FinishedAt: job.FinishedAt,
+		Error:      job.Error,
+	}
+	if job.Status == jobs.StatusSucceeded {
+		signature, expires := h.service.SignDownload(job.ID)
+		query := url.Values{}
+		query.Set("expires", strconv.FormatInt(expires.Unix(), 10))
+		query.Set("signature", signature)
+		export.DownloadURL = exportPath(job.ID) + "/download?" + query.Encode()
+		export.DownloadExpiresAt = &expires
+	}
+	return export
+}
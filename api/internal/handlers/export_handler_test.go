@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/jobs"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MockExportService is a mock implementation of ExportService for testing
+type MockExportService struct {
+	mock.Mock
+}
+
+func (m *MockExportService) CreateExport(ctx context.Context, area *models.MultiPolygon, format, county string) (jobs.Job, error) {
+	args := m.Called(ctx, area, format, county)
+	return args.Get(0).(jobs.Job), args.Error(1)
+}
+
+func (m *MockExportService) GetExport(id string) (jobs.Job, error) {
+	args := m.Called(id)
+	return args.Get(0).(jobs.Job), args.Error(1)
+}
+
+func (m *MockExportService) SignDownload(id string) (string, time.Time) {
+	args := m.Called(id)
+	return args.String(0), args.Get(1).(time.Time)
+}
+
+func (m *MockExportService) VerifyDownload(id, expires, signature string) (jobs.Job, error) {
+	args := m.Called(id, expires, signature)
+	return args.Get(0).(jobs.Job), args.Error(1)
+}
+
+// setupExportTestRouter creates a test router with export handlers.
+func setupExportTestRouter(handler *ExportHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+
+	exports := router.Group("/api/v1/exports")
+	{
+		exports.POST("", handler.Create)
+		exports.GET("/:id", handler.Get)
+		exports.GET("/:id/download", handler.Download)
+	}
+
+	return router
+}
+
+func TestExportHandler_Create(t *testing.T) {
+	t.Run("queues export for area", func(t *testing.T) {
+		mockService := new(MockExportService)
+		router := setupExportTestRouter(NewExportHandler(mockService))
+
+		mockService.On("CreateExport", mock.Anything, mock.Anything, services.ExportFormatShapefile, "montgomery").
+			Return(jobs.Job{ID: "abc", Kind: services.ExportJobKind, Status: jobs.StatusQueued}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/exports?format=shp&county=montgomery", strings.NewReader(testBundleArea))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Equal(t, "/api/v1/exports/abc", w.Header().Get("Location"))
+
+		var response ExportResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, jobs.StatusQueued, response.Export.Status)
+		assert.Empty(t, response.Export.DownloadURL)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		tests := []struct {
+			name string
+			url  string
+			body string
+		}{
+			{name: "missing format", url: "/api/v1/exports", body: testBundleArea},
+			{name: "unknown format", url: "/api/v1/exports?format=kml", body: testBundleArea},
+			{name: "malformed body", url: "/api/v1/exports?format=gpkg", body: `{"type":"Point"}`},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockService := new(MockExportService)
+				router := setupExportTestRouter(NewExportHandler(mockService))
+
+				req := httptest.NewRequest(http.MethodPost, tt.url, strings.NewReader(tt.body))
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+				mockService.AssertNotCalled(t, "CreateExport")
+			})
+		}
+	})
+}
+
+func TestExportHandler_Get(t *testing.T) {
+	mockService := new(MockExportService)
+	router := setupExportTestRouter(NewExportHandler(mockService))
+
+	expires := time.Unix(1700000900, 0).UTC()
+	mockService.On("GetExport", "abc").Return(jobs.Job{ID: "abc", Status: jobs.StatusSucceeded}, nil)
+	mockService.On("SignDownload", "abc").Return("c2ln", expires)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/exports/abc", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response ExportResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "/api/v1/exports/abc/download?expires=1700000900&signature=c2ln", response.Export.DownloadURL)
+	require.NotNil(t, response.Export.DownloadExpiresAt)
+	assert.True(t, expires.Equal(*response.Export.DownloadExpiresAt))
+}
+
+func TestExportHandler_Download(t *testing.T) {
+	tests := []struct {
+		err  error
+		job  jobs.Job
+		name string
+		url  string
+		code int
+	}{
+		{
+			name: "succeeded",
+			url:  "/api/v1/exports/abc/download?expires=1&signature=s",
+			job: jobs.Job{ID: "abc", Status: jobs.StatusSucceeded, Result: &jobs.Result{
+				ContentType: "application/zip", Filename: "parcels.zip", Data: []byte("zip"),
+			}},
+			code: http.StatusOK,
+		},
+		{name: "running", url: "/api/v1/exports/abc/download?expires=1&signature=s", job: jobs.Job{ID: "abc", Status: jobs.StatusRunning}, code: http.StatusConflict},
+		{name: "unsigned", url: "/api/v1/exports/abc/download", code: http.StatusForbidden},
+		{name: "invalid signature", url: "/api/v1/exports/abc/download?expires=1&signature=s", err: services.ErrInvalidDownloadLink, code: http.StatusForbidden},
+		{name: "expired", url: "/api/v1/exports/abc/download?expires=1&signature=s", err: services.ErrDownloadLinkExpired, code: http.StatusForbidden},
+		{name: "not found", url: "/api/v1/exports/abc/download?expires=1&signature=s", err: jobs.ErrJobNotFound, code: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockExportService)
+			router := setupExportTestRouter(NewExportHandler(mockService))
+			mockService.On("VerifyDownload", "abc", "1", "s").Return(tt.job, tt.err)
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.code, w.Code)
+			if tt.code == http.StatusOK {
+				assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+				assert.Equal(t, "attachment; filename=parcels.zip", w.Header().Get("Content-Disposition"))
+				assert.Equal(t, "zip", w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// maxFavoriteBodyBytes bounds the favorite request body, a note of at most
+// services.MaxFavoriteNoteLength characters
+const maxFavoriteBodyBytes = 8 << 10
+
+// FavoriteHandler handles the signed-in user's bookmarked parcels.
+// All routes are user-scoped.
+type FavoriteHandler struct {
+	service services.FavoriteService
+}
+
+// NewFavoriteHandler creates a new FavoriteHandler instance.
+func NewFavoriteHandler(service services.FavoriteService) *FavoriteHandler {
+	return &FavoriteHandler{
+		service: service,
+	}
+}
+
+// SaveFavoriteRequest represents the optional request body for bookmarking a
+// parcel. Without a note, the note of an existing favorite is kept; an empty
+// note clears it.
+type SaveFavoriteRequest struct {
+	Note *string `json:"note"`
+}
+
+// FavoriteResponse represents the response for a single favorite.
+type FavoriteResponse struct {
+	Favorite *models.Favorite `json:"favorite"`
+}
+
+// FavoriteListResponse represents the response for the favorite list endpoint.
+type FavoriteListResponse struct {
+	Favorites []models.Favorite `json:"favorites"`
+	Count     int               `json:"count"`
+}
+
+// Save handles POST /api/v1/users/me/favorites/:parcelId endpoint.
+// Responds 201 Created when the parcel is bookmarked, or 200 OK when an
+// existing favorite's note is updated.
+func (h *FavoriteHandler) Save(c *gin.Context) {
+	parcelID, ok := favoriteParcelID(c)
+	if !ok {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxFavoriteBodyBytes)
+
+	var req SaveFavoriteRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			// Malformed JSON, wrong field types or a body over maxFavoriteBodyBytes
+			apierrors.BadRequest(c, "Invalid request body", nil)
+			return
+		}
+	}
+
+	favorite, created, err := h.service.SaveFavorite(c.Request.Context(), middleware.GetUserID(c), parcelID, req.Note)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, FavoriteResponse{Favorite: favorite})
+}
+
+// List handles GET /api/v1/users/me/favorites endpoint.
+func (h *FavoriteHandler) List(c *gin.Context) {
+	favorites, err := h.service.ListFavorites(c.Request.Context(), middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	middleware.SetResultCount(c, len(favorites))
+
+	c.JSON(http.StatusOK, FavoriteListResponse{
+		Favorites: favorites,
+		Count:     len(favorites),
+	})
+}
+
+// Delete handles DELETE /api/v1/users/me/favorites/:parcelId endpoint.
+func (h *FavoriteHandler) Delete(c *gin.Context) {
+	parcelID, ok := favoriteParcelID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteFavorite(c.Request.Context(), middleware.GetUserID(c), parcelID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// handleError maps favorite service errors to HTTP responses.
+func (h *FavoriteHandler) handleError(c *gin.Context, err error) {
+	apierrors.Respond(c, "Failed to process favorite request", err)
+}
+
+// favoriteParcelID parses the :parcelId parameter, writing the error response if it is invalid.
+func favoriteParcelID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("parcelId"), 10, 32)
+	if err != nil {
+		apierrors.BadRequest(c, "parcel id must be a positive integer", nil)
+		return 0, false
+	}
+	return uint(id), true
+}
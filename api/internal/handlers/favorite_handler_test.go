@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MockFavoriteService is a mock implementation of FavoriteService for testing
+type MockFavoriteService struct {
+	mock.Mock
+}
+
+func (m *MockFavoriteService) SaveFavorite(ctx context.Context, userID, parcelID uint, note *string) (*models.Favorite, bool, error) {
+	args := m.Called(ctx, userID, parcelID, note)
+	favorite, _ := args.Get(0).(*models.Favorite)
+	return favorite, args.Bool(1), args.Error(2)
+}
+
+func (m *MockFavoriteService) ListFavorites(ctx context.Context, userID uint) ([]models.Favorite, error) {
+	args := m.Called(ctx, userID)
+	favorites, _ := args.Get(0).([]models.Favorite)
+	return favorites, args.Error(1)
+}
+
+func (m *MockFavoriteService) DeleteFavorite(ctx context.Context, userID, parcelID uint) error {
+	args := m.Called(ctx, userID, parcelID)
+	return args.Error(0)
+}
+
+// setupFavoriteTestRouter creates a test router with favorite handlers,
+// signed in as user 7.
+func setupFavoriteTestRouter(handler *FavoriteHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, uint(7))
+		c.Next()
+	})
+
+	router.GET("/api/v1/users/me/favorites", handler.List)
+	router.POST("/api/v1/users/me/favorites/:parcelId", handler.Save)
+	router.DELETE("/api/v1/users/me/favorites/:parcelId", handler.Delete)
+
+	return router
+}
+
+func TestFavoriteHandler_Save(t *testing.T) {
+	note := "call the owner"
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		note       *string
+		created    bool
+		serviceErr error
+		wantStatus int
+	}{
+		{name: "created with note", path: "/api/v1/users/me/favorites/42", body: `{"note": "call the owner"}`, note: &note, created: true, wantStatus: http.StatusCreated},
+		{name: "created without body", path: "/api/v1/users/me/favorites/42", created: true, wantStatus: http.StatusCreated},
+		{name: "note updated", path: "/api/v1/users/me/favorites/42", body: `{"note": "call the owner"}`, note: &note, wantStatus: http.StatusOK},
+		{name: "parcel not found", path: "/api/v1/users/me/favorites/42", serviceErr: services.ErrParcelNotFound, wantStatus: http.StatusNotFound},
+		{name: "note too long", path: "/api/v1/users/me/favorites/42", body: `{"note": "call the owner"}`, note: &note, serviceErr: services.ErrInvalidFavorite, wantStatus: http.StatusBadRequest},
+		{name: "invalid parcel id", path: "/api/v1/users/me/favorites/abc", wantStatus: http.StatusBadRequest},
+		{name: "malformed json", path: "/api/v1/users/me/favorites/42", body: `{"note":`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockFavoriteService)
+			router := setupFavoriteTestRouter(NewFavoriteHandler(mockService))
+
+			if tt.wantStatus != http.StatusBadRequest || tt.serviceErr != nil {
+				var favorite *models.Favorite
+				if tt.serviceErr == nil {
+					favorite = &models.Favorite{ID: 1, ParcelID: 42, Note: tt.note}
+				}
+				mockService.On("SaveFavorite", mock.Anything, uint(7), uint(42), tt.note).Return(favorite, tt.created, tt.serviceErr)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus < http.StatusBadRequest {
+				var response FavoriteResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, uint(42), response.Favorite.ParcelID)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFavoriteHandler_List(t *testing.T) {
+	mockService := new(MockFavoriteService)
+	router := setupFavoriteTestRouter(NewFavoriteHandler(mockService))
+
+	mockService.On("ListFavorites", mock.Anything, uint(7)).Return([]models.Favorite{
+		{ID: 2, ParcelID: 43, PIN: 1002},
+		{ID: 1, ParcelID: 42, PIN: 1001, Removed: true},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me/favorites", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response FavoriteListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Count)
+	assert.True(t, response.Favorites[1].Removed)
+	mockService.AssertExpectations(t)
+}
+
+func TestFavoriteHandler_Delete(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		serviceErr error
+		wantStatus int
+	}{
+		{name: "deleted", path: "/api/v1/users/me/favorites/42", wantStatus: http.StatusNoContent},
+		{name: "not found", path: "/api/v1/users/me/favorites/42", serviceErr: services.ErrFavoriteNotFound, wantStatus: http.StatusNotFound},
+		{name: "invalid parcel id", path: "/api/v1/users/me/favorites/abc", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockFavoriteService)
+			router := setupFavoriteTestRouter(NewFavoriteHandler(mockService))
+
+			if tt.wantStatus != http.StatusBadRequest {
+				mockService.On("DeleteFavorite", mock.Anything, uint(7), uint(42)).Return(tt.serviceErr)
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
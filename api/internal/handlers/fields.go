@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+)
+
+// Response members with special meaning for field selection.
+// The id member is always kept so clients can correlate sparse results, the
+// owner address is only decrypted when it is selected and visible, and the
+// geocoded address is only looked up when it is selected.
+const (
+	fieldID              = "id"
+	fieldGeometry        = "geometry"
+	fieldOwnerAddress    = "owner_address"
+	fieldGeocodedAddress = "geocoded_address"
+)
+
+// selectFields serializes a response DTO and keeps only the requested JSON members.
+// Members listed in always are kept regardless of fields. Unknown field names are ignored.
+func selectFields(dto interface{}, fields []string, always ...string) (map[string]interface{}, error) {
+	data, err := json.Marshal(dto)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response fields: %w", err)
+	}
+
+	var all map[string]interface{}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response fields: %w", err)
+	}
+
+	return pickFields(all, fields, always...), nil
+}
+
+// pickFields returns the members of all named in always or fields.
+func pickFields(all map[string]interface{}, fields []string, always ...string) map[string]interface{} {
+	selected := make(map[string]interface{}, len(fields)+len(always))
+	for _, names := range [][]string{always, fields} {
+		for _, name := range names {
+			if value, ok := all[name]; ok {
+				selected[name] = value
+			}
+		}
+	}
+	return selected
+}
+
+// parseFields parses a comma-separated fields= selection such as "id,owner_name,geometry".
+// Each name must be a JSON member of dto. Returns nil for an empty selection, meaning all fields.
+func parseFields(raw string, dto interface{}) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	allowed := jsonFieldNames(dto)
+	known := make(map[string]bool, len(allowed))
+	for _, name := range allowed {
+		known[name] = true
+	}
+
+	parts := strings.Split(raw, ",")
+	fields := make([]string, 0, len(parts))
+	for _, part := range parts {
+		name := strings.TrimSpace(part)
+		if !known[name] {
+			return nil, fmt.Errorf("unknown field %q, allowed fields are: %s", name, strings.Join(allowed, ","))
+		}
+		fields = append(fields, name)
+	}
+
+	return fields, nil
+}
+
+// jsonFieldNames returns the sorted JSON member names of a struct (or pointer to struct) DTO.
+// Members of untagged embedded structs are included, as encoding/json promotes them.
+func jsonFieldNames(dto interface{}) []string {
+	names := appendJSONFieldNames(nil, reflect.TypeOf(dto))
+	sort.Strings(names)
+
+	return names
+}
+
+// appendJSONFieldNames appends the JSON member names of struct type t to names.
+func appendJSONFieldNames(names []string, t reflect.Type) []string {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" && field.Anonymous {
+			names = appendJSONFieldNames(names, field.Type)
+			continue
+		}
+		if name == "" || name == "-" {
+			continue
+		}
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// wantsField reports whether a selection includes name. An empty selection includes every field.
+func wantsField(fields []string, name string) bool {
+	if len(fields) == 0 {
+		return true
+	}
+	for _, field := range fields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// renderSelectedJSON writes response as JSON with the field selection and the
+// caller's role visibility applied to the members named in itemsKeys, each holding
+// either a single DTO or an array of DTOs. Other members (count, next_cursor, ...)
+// are written unchanged.
+func renderSelectedJSON(c *gin.Context, response interface{}, fields []string, itemsKeys ...string) {
+	renderSelectedJSONStatus(c, http.StatusOK, response, fields, itemsKeys...)
+}
+
+// renderSelectedJSONStatus is renderSelectedJSON with another status than 200,
+// e.g. the 207 of a batch response with failed items.
+func renderSelectedJSONStatus(c *gin.Context, status int, response interface{}, fields []string, itemsKeys ...string) {
+	body, err := shapeSelectedJSON(response, fields, middleware.GetRole(c), itemsKeys...)
+	if err != nil {
+		apierrors.InternalServerError(c, "Failed to encode response", err)
+		return
+	}
+
+	c.JSON(status, body)
+}
+
+// shapeSelectedJSON returns response with the field selection and the
+// visibility of role applied to the items under itemsKeys, as
+// renderSelectedJSON renders it; response itself when nothing is removed.
+func shapeSelectedJSON(response interface{}, fields []string, role middleware.Role, itemsKeys ...string) (interface{}, error) {
+	if len(fields) == 0 && seesAllFields(role) {
+		return response, nil
+	}
+
+	body, err := selectFields(response, jsonFieldNames(response))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, itemsKey := range itemsKeys {
+		switch items := body[itemsKey].(type) {
+		case map[string]interface{}:
+			body[itemsKey] = shapeItem(items, fields, role)
+		case []interface{}:
+			for i, item := range items {
+				if dto, ok := item.(map[string]interface{}); ok {
+					items[i] = shapeItem(dto, fields, role)
+				}
+			}
+		}
+	}
+
+	return body, nil
+}
+
+// shapeItem applies the field selection (keeping the id) and role visibility to a serialized DTO.
+func shapeItem(dto map[string]interface{}, fields []string, role middleware.Role) map[string]interface{} {
+	if len(fields) > 0 {
+		dto = pickFields(dto, fields, fieldID)
+	}
+	return redactFields(dto, role)
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectFields(t *testing.T) {
+	dto := &ParcelData{
+		ID:           9,
+		OwnerName:    "Test Owner",
+		SitusAddress: "123 Test St",
+		CountyName:   "Montgomery",
+		Geometry:     map[string]interface{}{"type": "MultiPolygon"},
+	}
+
+	t.Run("keeps requested and always fields", func(t *testing.T) {
+		selected, err := selectFields(dto, []string{"owner_name"}, "id", "geometry")
+		require.NoError(t, err)
+
+		assert.Len(t, selected, 3)
+		assert.Equal(t, "Test Owner", selected["owner_name"])
+		assert.Equal(t, float64(9), selected["id"])
+		assert.Contains(t, selected, "geometry")
+	})
+
+	t.Run("ignores unknown and omitted fields", func(t *testing.T) {
+		// land_use is omitempty and unset, so it is absent from the serialized DTO
+		selected, err := selectFields(dto, []string{"land_use", "not_a_field"})
+		require.NoError(t, err)
+
+		assert.Empty(t, selected)
+	})
+}
+
+func TestParseFields(t *testing.T) {
+	t.Run("empty selection means all fields", func(t *testing.T) {
+		fields, err := parseFields("  ", ParcelData{})
+		require.NoError(t, err)
+		assert.Nil(t, fields)
+	})
+
+	t.Run("trims names", func(t *testing.T) {
+		fields, err := parseFields("id, owner_name ,geometry", &ParcelData{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"id", "owner_name", "geometry"}, fields)
+	})
+
+	t.Run("rejects unknown fields", func(t *testing.T) {
+		_, err := parseFields("id,distance_meters", ParcelData{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown field "distance_meters"`)
+		assert.Contains(t, err.Error(), "owner_name")
+	})
+
+	t.Run("accepts DTO specific fields", func(t *testing.T) {
+		fields, err := parseFields("distance_meters", ParcelWithDistance{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"distance_meters"}, fields)
+	})
+
+	t.Run("accepts fields of embedded DTOs", func(t *testing.T) {
+		fields, err := parseFields("owner_name,probability", PointCandidate{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"owner_name", "probability"}, fields)
+	})
+}
+
+func TestWantsField(t *testing.T) {
+	assert.True(t, wantsField(nil, fieldGeometry), "empty selection includes everything")
+	assert.True(t, wantsField([]string{"id", "geometry"}, fieldGeometry))
+	assert.False(t, wantsField([]string{"id", "owner_name"}, fieldGeometry))
+}
+
+func TestRenderSelectedJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	response := NearbyResponse{
+		NextCursor: "abc",
+		Parcels: []ParcelWithDistance{
+			{ID: 1, OwnerName: "First", CountyName: "Montgomery", Distance: 10},
+			{ID: 2, OwnerName: "Second", CountyName: "Montgomery", Distance: 20},
+		},
+		Count:      2,
+		TotalCount: 5,
+	}
+
+	t.Run("selects fields on each item and keeps envelope", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		renderSelectedJSON(c, response, []string{"owner_name"}, "parcels")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{
+			"next_cursor": "abc",
+			"parcels": [{"id": 1, "owner_name": "First"}, {"id": 2, "owner_name": "Second"}],
+			"count": 2,
+			"total_count": 5
+		}`, w.Body.String())
+	})
+
+	t.Run("single item member", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		renderSelectedJSON(c, ParcelResponse{Parcel: &ParcelData{ID: 9, CountyName: "Montgomery"}}, []string{"county_name"}, "parcel")
+
+		assert.JSONEq(t, `{"parcel": {"id": 9, "county_name": "Montgomery"}}`, w.Body.String())
+	})
+
+	t.Run("multiple item members", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		parcel := &ParcelData{ID: 9, OwnerName: "First", CountyName: "Montgomery"}
+		response := ParcelResponse{
+			Parcel: parcel,
+			Candidates: []PointCandidate{
+				{ParcelData: parcel, Probability: 0.75, ContainsPoint: true},
+				{ParcelData: &ParcelData{ID: 10, OwnerName: "Second"}, Probability: 0.25},
+			},
+		}
+		renderSelectedJSON(c, response, []string{"owner_name", "probability"}, "parcel", "candidates")
+
+		assert.JSONEq(t, `{
+			"parcel": {"id": 9, "owner_name": "First"},
+			"candidates": [
+				{"id": 9, "owner_name": "First", "probability": 0.75},
+				{"id": 10, "owner_name": "Second", "probability": 0.25}
+			]
+		}`, w.Body.String())
+	})
+
+	t.Run("no selection writes the full response", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		renderSelectedJSON(c, response, nil, "parcels")
+
+		var decoded NearbyResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &decoded))
+		assert.Equal(t, response.Parcels[1].Distance, decoded.Parcels[1].Distance)
+	})
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/location"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// ResolveParcel chains a geocode into an at-point lookup of its best match.
+const ResolveParcel = "parcel"
+
+// GeocodeHandler handles forward geocoding HTTP requests.
+type GeocodeHandler struct {
+	service services.GeocodingService
+	parcels services.ParcelService
+}
+
+// NewGeocodeHandler creates a new GeocodeHandler instance. parcels resolves
+// the parcel at the best match when resolve=parcel is requested.
+func NewGeocodeHandler(service services.GeocodingService, parcels services.ParcelService) *GeocodeHandler {
+	return &GeocodeHandler{
+		service: service,
+		parcels: parcels,
+	}
+}
+
+// GeocodeRequest represents the query parameters for the geocode endpoint.
+// Fields and County apply to the parcel looked up with Resolve.
+type GeocodeRequest struct {
+	Query   string `form:"q" binding:"required,max=200"`
+	Resolve string `form:"resolve" binding:"omitempty,oneof=parcel"`
+	Fields  string `form:"fields"`
+	County  string `form:"county" binding:"omitempty,max=100"`
+	Limit   int    `form:"limit" binding:"omitempty,min=1,max=10"` // default: 5
+}
+
+// GeocodeResult is one address matching a geocode query. Location is the
+// [lng, lat] of the address, in GeoJSON order, and Score the provider's
+// relevance between 0 and 1.
+type GeocodeResult struct {
+	Address  GeocodedAddressData `json:"address"`
+	Location [2]float64          `json:"location"`
+	Score    float64             `json:"score"`
+}
+
+// GeocodeResponse represents the response for the geocode endpoint. Parcel
+// is the parcel at the first result, present with resolve=parcel when one
+// contains it.
+type GeocodeResponse struct {
+	Parcel  *ParcelData     `json:"parcel,omitempty"`
+	Results []GeocodeResult `json:"results"`
+	Count   int             `json:"count"`
+}
+
+// Geocode handles GET /api/v1/geocode endpoint.
+// It returns the addresses matching the free-form query q, best first, and
+// with resolve=parcel the parcel containing the best match.
+func (h *GeocodeHandler) Geocode(c *gin.Context) {
+	log := middleware.GetLogger(c)
+
+	// Bind and validate query parameters
+	var req GeocodeRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		apierrors.BadRequest(c, "Invalid query parameters", nil)
+		return
+	}
+
+	ctx, fields, ok := parseFieldSelection(c, req.Fields, ParcelData{})
+	if !ok {
+		return
+	}
+	ctx = repository.WithCounty(ctx, req.County)
+
+	if log != nil {
+		log.Info("Processing geocode request", map[string]interface{}{
+			"limit":   req.Limit,
+			"resolve": req.Resolve,
+		})
+	}
+
+	matches, err := h.service.Geocode(ctx, req.Query, req.Limit)
+	if err != nil {
+		apierrors.Respond(c, "Failed to geocode query", err)
+		return
+	}
+
+	response := GeocodeResponse{
+		Results: make([]GeocodeResult, 0, len(matches)),
+		Count:   len(matches),
+	}
+	for _, match := range matches {
+		response.Results = append(response.Results, GeocodeResult{
+			Address:  *mapAddressToDTO(&match.Address),
+			Location: match.Point.GeoJSONPosition(),
+			Score:    match.Score,
+		})
+	}
+
+	if req.Resolve == ResolveParcel && len(matches) > 0 {
+		parcel, err := h.parcels.GetParcelAtPoint(ctx, matches[0].Point)
+		switch {
+		case domain.KindOf(err) == domain.ErrNotFound:
+			// The address lies outside every parcel loaded; the results still stand
+		case err != nil:
+			queryFailed(c, "Failed to query parcel data", err)
+			return
+		default:
+			response.Parcel = mapTaxParcelToDTO(parcel)
+		}
+	}
+
+	middleware.SetResultCount(c, response.Count)
+
+	renderSelectedJSON(c, response, fields, "parcel")
+}
+
+// mapAddressToDTO converts a geocoded address to a GeocodedAddressData DTO.
+func mapAddressToDTO(address *location.Address) *GeocodedAddressData {
+	return &GeocodedAddressData{
+		Label:       address.Label,
+		HouseNumber: address.HouseNumber,
+		Street:      address.Street,
+		City:        address.City,
+		County:      address.County,
+		State:       address.State,
+		PostalCode:  address.PostalCode,
+	}
+}
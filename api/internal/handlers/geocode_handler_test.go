@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/location"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// setupGeocodeTestRouter creates a test router with the geocode handler.
+func setupGeocodeTestRouter(handler *GeocodeHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+
+	router.GET("/api/v1/geocode", handler.Geocode)
+
+	return router
+}
+
+// testGeocodeMatches are the matches for "301 n main st conroe", best first.
+var testGeocodeMatches = []location.Match{
+	{
+		Address: location.Address{Label: "301 N Main St, Conroe, TX 77301", HouseNumber: "301", Street: "N Main St", City: "Conroe", State: "TX", PostalCode: "77301"},
+		Point:   models.LatLng{Lat: 30.3119, Lng: -95.4561},
+		Score:   0.9,
+	},
+	{
+		Address: location.Address{Label: "301 S Main St, Conroe, TX 77301", HouseNumber: "301", Street: "S Main St", City: "Conroe", State: "TX", PostalCode: "77301"},
+		Point:   models.LatLng{Lat: 30.3001, Lng: -95.4560},
+		Score:   0.6,
+	},
+}
+
+func TestGeocodeHandler_Geocode(t *testing.T) {
+	geocoding := new(MockGeocodingService)
+	parcels := new(MockGraphQLParcelService)
+	router := setupGeocodeTestRouter(NewGeocodeHandler(geocoding, parcels))
+
+	geocoding.On("Geocode", mock.Anything, "301 N Main St Conroe", 0).Return(testGeocodeMatches, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/geocode?q=301+N+Main+St+Conroe", nil))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response GeocodeResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Count)
+	assert.Equal(t, "301 N Main St, Conroe, TX 77301", response.Results[0].Address.Label)
+	assert.Equal(t, [2]float64{-95.4561, 30.3119}, response.Results[0].Location, "GeoJSON order")
+	assert.Equal(t, 0.9, response.Results[0].Score)
+	assert.Nil(t, response.Parcel)
+	assert.NotContains(t, w.Body.String(), `"parcel"`)
+	parcels.AssertNotCalled(t, "GetParcelAtPoint", mock.Anything, mock.Anything)
+}
+
+func TestGeocodeHandler_GeocodeNoResults(t *testing.T) {
+	geocoding := new(MockGeocodingService)
+	parcels := new(MockGraphQLParcelService)
+	router := setupGeocodeTestRouter(NewGeocodeHandler(geocoding, parcels))
+
+	geocoding.On("Geocode", mock.Anything, "nowhere", 0).Return([]location.Match{}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/geocode?q=nowhere&resolve=parcel", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"results":[],"count":0}`, w.Body.String())
+	parcels.AssertNotCalled(t, "GetParcelAtPoint", mock.Anything, mock.Anything)
+}
+
+func TestGeocodeHandler_GeocodeResolveParcel(t *testing.T) {
+	geocoding := new(MockGeocodingService)
+	parcels := new(MockGraphQLParcelService)
+	router := setupGeocodeTestRouter(NewGeocodeHandler(geocoding, parcels))
+
+	owner := "ACME LAND LLC"
+	geocoding.On("Geocode", mock.Anything, "301 n main st", 2).Return(testGeocodeMatches, nil)
+	parcels.On("GetParcelAtPoint", mock.Anything, testGeocodeMatches[0].Point).Return(&models.TaxParcel{ID: 42, OwnerName: &owner}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/geocode?q=301+n+main+st&limit=2&resolve=parcel&fields=owner_name", nil))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, map[string]interface{}{"id": 42.0, "owner_name": owner}, body["parcel"], "fields select the parcel's members")
+	assert.Len(t, body["results"], 2)
+	parcels.AssertExpectations(t)
+}
+
+func TestGeocodeHandler_GeocodeResolveParcelNotFound(t *testing.T) {
+	geocoding := new(MockGeocodingService)
+	parcels := new(MockGraphQLParcelService)
+	router := setupGeocodeTestRouter(NewGeocodeHandler(geocoding, parcels))
+
+	geocoding.On("Geocode", mock.Anything, mock.Anything, mock.Anything).Return(testGeocodeMatches, nil)
+	parcels.On("GetParcelAtPoint", mock.Anything, mock.Anything).Return(nil, services.ErrParcelNotFound)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/geocode?q=301+n+main+st&resolve=parcel", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response GeocodeResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Nil(t, response.Parcel)
+	assert.Equal(t, 2, response.Count)
+}
+
+func TestGeocodeHandler_GeocodeInvalidRequest(t *testing.T) {
+	testCases := []struct {
+		name string
+		url  string
+	}{
+		{"missing query", "/api/v1/geocode"},
+		{"limit too large", "/api/v1/geocode?q=conroe&limit=11"},
+		{"unknown resolve", "/api/v1/geocode?q=conroe&resolve=county"},
+		{"unknown field", "/api/v1/geocode?q=conroe&fields=bogus"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			geocoding := new(MockGeocodingService)
+			router := setupGeocodeTestRouter(NewGeocodeHandler(geocoding, new(MockGraphQLParcelService)))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.url, nil))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			geocoding.AssertNotCalled(t, "Geocode", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGeocodeHandler_GeocodeErrors(t *testing.T) {
+	testCases := []struct {
+		err    error
+		name   string
+		status int
+	}{
+		{services.ErrInvalidGeocodeQuery, "blank query", http.StatusBadRequest},
+		{services.ErrGeocoderUnavailable, "geocoder down", http.StatusServiceUnavailable},
+		{errors.New("unexpected"), "unexpected error", http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			geocoding := new(MockGeocodingService)
+			router := setupGeocodeTestRouter(NewGeocodeHandler(geocoding, new(MockGraphQLParcelService)))
+			geocoding.On("Geocode", mock.Anything, mock.Anything, mock.Anything).Return(nil, tc.err)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/geocode?q=+", nil))
+
+			assert.Equal(t, tc.status, w.Code)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/location"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MockGeocodingService is a mock implementation of GeocodingService for testing
+type MockGeocodingService struct {
+	mock.Mock
+}
+
+func (m *MockGeocodingService) ReverseGeocode(ctx context.Context, point models.LatLng) (*location.Address, error) {
+	args := m.Called(ctx, point)
+	address, _ := args.Get(0).(*location.Address)
+	return address, args.Error(1)
+}
+
+func (m *MockGeocodingService) Geocode(ctx context.Context, query string, limit int) ([]location.Match, error) {
+	args := m.Called(ctx, query, limit)
+	matches, _ := args.Get(0).([]location.Match)
+	return matches, args.Error(1)
+}
+
+// newGeocodingTestRouter returns a router whose at-point parcel is parcel,
+// with geocoding answered by geocoding.
+func newGeocodingTestRouter(parcel *models.TaxParcel, geocoding *MockGeocodingService) http.Handler {
+	log := logger.New("test")
+	parcels := new(MockGraphQLParcelService)
+	parcels.On("GetParcelAtPoint", mock.Anything, mock.Anything).Return(parcel, nil)
+
+	handler := NewParcelHandler(parcels, services.NewLocationService(nil, log))
+	handler.SetGeocoding(geocoding)
+	return setupParcelTestRouter(handler, log)
+}
+
+// getAtPoint requests the at-point endpoint with the given extra query and decodes the response.
+func getAtPoint(t *testing.T, router http.Handler, query string) ParcelResponse {
+	t.Helper()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/parcels/at-point?lat=30.3477&lng=-95.45"+query, nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response ParcelResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.NotNil(t, response.Parcel)
+	return response
+}
+
+func TestParcelHandler_AtPointGeocodesMissingSitus(t *testing.T) {
+	geocoding := new(MockGeocodingService)
+	router := newGeocodingTestRouter(&models.TaxParcel{ID: 42, CentroidLat: 30.35, CentroidLng: -95.45}, geocoding)
+
+	geocoding.On("ReverseGeocode", mock.Anything, models.LatLng{Lat: 30.35, Lng: -95.45}).Return(&location.Address{
+		Label: "301 N Main St, Conroe, TX 77301", HouseNumber: "301", Street: "N Main St",
+		City: "Conroe", State: "TX", PostalCode: "77301",
+	}, nil)
+
+	response := getAtPoint(t, router, "")
+
+	require.NotNil(t, response.Parcel.GeocodedAddress, "looked up at the parcel centroid")
+	assert.Equal(t, "301 N Main St, Conroe, TX 77301", response.Parcel.GeocodedAddress.Label)
+	assert.Equal(t, "TX", response.Parcel.GeocodedAddress.State)
+	assert.Empty(t, response.Parcel.SitusAddress)
+	geocoding.AssertExpectations(t)
+}
+
+func TestParcelHandler_AtPointSkipsGeocoding(t *testing.T) {
+	situs := "123 TEST ST"
+
+	t.Run("situs present", func(t *testing.T) {
+		geocoding := new(MockGeocodingService)
+		router := newGeocodingTestRouter(&models.TaxParcel{ID: 42, Situs: &situs}, geocoding)
+
+		response := getAtPoint(t, router, "")
+
+		assert.Nil(t, response.Parcel.GeocodedAddress)
+		geocoding.AssertNotCalled(t, "ReverseGeocode", mock.Anything, mock.Anything)
+	})
+
+	t.Run("field not selected", func(t *testing.T) {
+		geocoding := new(MockGeocodingService)
+		router := newGeocodingTestRouter(&models.TaxParcel{ID: 42}, geocoding)
+
+		response := getAtPoint(t, router, "&fields=owner_name")
+
+		assert.Nil(t, response.Parcel.GeocodedAddress)
+		geocoding.AssertNotCalled(t, "ReverseGeocode", mock.Anything, mock.Anything)
+	})
+}
+
+func TestParcelHandler_AtPointGeocodingFailureOmitsAddress(t *testing.T) {
+	geocoding := new(MockGeocodingService)
+	router := newGeocodingTestRouter(&models.TaxParcel{ID: 42, OwnerName: new(string)}, geocoding)
+
+	geocoding.On("ReverseGeocode", mock.Anything, mock.Anything).Return(nil, errors.New("geocoder: circuit breaker open"))
+
+	response := getAtPoint(t, router, "")
+
+	assert.Equal(t, uint(42), response.Parcel.ID)
+	assert.Nil(t, response.Parcel.GeocodedAddress)
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+)
+
+// Response format values accepted by the format query parameter.
+const (
+	FormatJSON       = "json"
+	FormatGeoJSON    = "geojson"
+	FormatGeoPackage = "gpkg"
+	FormatCSV        = "csv"
+	FormatKML        = "kml"
+)
+
+// GeoJSONContentType is the registered media type for GeoJSON (RFC 7946).
+const GeoJSONContentType = "application/geo+json"
+
+// FeatureCollection is an RFC 7946 GeoJSON FeatureCollection.
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// Feature is an RFC 7946 GeoJSON Feature.
+// Geometry is null when the source DTO carries no geometry.
+type Feature struct {
+	ID         interface{}            `json:"id,omitempty"`
+	Geometry   interface{}            `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+	Type       string                 `json:"type"`
+}
+
+// toFeature converts a response DTO into a GeoJSON Feature.
+// The DTO is serialized with its regular JSON tags; the "geometry" member becomes the
+// Feature geometry, "id" becomes the Feature id, and every other member is a property.
+// This keeps GeoJSON properties identical to the default response shape.
+func toFeature(dto interface{}) (Feature, error) {
+	data, err := json.Marshal(dto)
+	if err != nil {
+		return Feature{}, fmt.Errorf("failed to marshal feature properties: %w", err)
+	}
+
+	var properties map[string]interface{}
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return Feature{}, fmt.Errorf("failed to unmarshal feature properties: %w", err)
+	}
+
+	feature := Feature{
+		Type:       "Feature",
+		ID:         properties["id"],
+		Geometry:   properties["geometry"],
+		Properties: properties,
+	}
+	delete(properties, "geometry")
+
+	return feature, nil
+}
+
+// renderFeatureCollection writes the given DTOs as a GeoJSON FeatureCollection
+// with the application/geo+json content type. A non-empty fields selection limits
+// the feature properties (the id is always kept) and nulls the geometry unless
+// "geometry" is selected; members the caller's role may not see are dropped.
+func renderFeatureCollection[T any](c *gin.Context, dtos []T, fields []string) {
+	collection := FeatureCollection{
+		Type:     "FeatureCollection",
+		Features: make([]Feature, 0, len(dtos)),
+	}
+
+	role := middleware.GetRole(c)
+	for _, dto := range dtos {
+		feature, err := toFeature(dto)
+		if err != nil {
+			apierrors.InternalServerError(c, "Failed to encode GeoJSON response", err)
+			return
+		}
+		shapeFeature(&feature, fields, role)
+		collection.Features = append(collection.Features, feature)
+	}
+
+	data, err := json.Marshal(collection)
+	if err != nil {
+		apierrors.InternalServerError(c, "Failed to encode GeoJSON response", err)
+		return
+	}
+
+	c.Data(http.StatusOK, GeoJSONContentType, data)
+}
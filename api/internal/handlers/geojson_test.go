@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToFeature_ParcelData(t *testing.T) {
+	dto := &ParcelData{
+		ID:           42,
+		OwnerName:    "Test Owner",
+		SitusAddress: "123 Test St",
+		CountyName:   "Montgomery",
+		Geometry: map[string]interface{}{
+			"type":        "MultiPolygon",
+			"coordinates": [][][][2]float64{{{{-95.45, 30.34}, {-95.44, 30.34}, {-95.44, 30.35}, {-95.45, 30.34}}}},
+		},
+	}
+
+	feature, err := toFeature(dto)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Feature", feature.Type)
+	assert.Equal(t, float64(42), feature.ID)
+
+	geometry, ok := feature.Geometry.(map[string]interface{})
+	require.True(t, ok, "geometry should be a GeoJSON object")
+	assert.Equal(t, "MultiPolygon", geometry["type"])
+
+	// Geometry is lifted out of properties; everything else stays
+	assert.NotContains(t, feature.Properties, "geometry")
+	assert.Equal(t, "Test Owner", feature.Properties["owner_name"])
+	assert.Equal(t, "123 Test St", feature.Properties["situs_address"])
+	assert.Equal(t, "Montgomery", feature.Properties["county_name"])
+	assert.Equal(t, float64(42), feature.Properties["id"])
+}
+
+func TestToFeature_NilGeometry(t *testing.T) {
+	feature, err := toFeature(ParcelWithDistance{ID: 7, CountyName: "Montgomery"})
+	require.NoError(t, err)
+
+	assert.Nil(t, feature.Geometry)
+
+	// RFC 7946 requires the geometry member even when null
+	data, err := json.Marshal(feature)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"geometry":null`)
+}
+
+func TestRenderFeatureCollection(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("renders features with geo+json content type", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		dtos := []ParcelWithDistance{
+			{ID: 1, CountyName: "Montgomery", Distance: 10.5},
+			{ID: 2, CountyName: "Montgomery", Distance: 20.25},
+		}
+		renderFeatureCollection(c, dtos, nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, GeoJSONContentType, w.Header().Get("Content-Type"))
+
+		var collection FeatureCollection
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &collection))
+		assert.Equal(t, "FeatureCollection", collection.Type)
+		require.Len(t, collection.Features, 2)
+		assert.Equal(t, 20.25, collection.Features[1].Properties["distance_meters"])
+	})
+
+	t.Run("empty input renders empty features array", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		renderFeatureCollection(c, []AddressCandidate{}, nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"type":"FeatureCollection","features":[]}`, w.Body.String())
+	})
+	t.Run("field selection limits properties and drops geometry", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		dtos := []AddressCandidate{{
+			ID:           3,
+			SitusAddress: "123 Test St",
+			OwnerName:    "Test Owner",
+			CountyName:   "Montgomery",
+			Geometry:     map[string]interface{}{"type": "MultiPolygon"},
+		}}
+		renderFeatureCollection(c, dtos, []string{"owner_name"})
+
+		var collection FeatureCollection
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &collection))
+		require.Len(t, collection.Features, 1)
+		assert.Nil(t, collection.Features[0].Geometry)
+		assert.Equal(t, map[string]interface{}{"id": float64(3), "owner_name": "Test Owner"},
+			collection.Features[0].Properties)
+	})
+}
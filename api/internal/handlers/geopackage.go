@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/geopackage"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// GeoPackage export constants
+const (
+	geoPackageTable       = "parcels"
+	geoPackageDescription = "Atlas parcel export"
+	// GeoPackageFilename is the download name of GeoPackage responses
+	GeoPackageFilename = "parcels.gpkg"
+)
+
+// geoPackageColumn maps a feature property to one or more GeoPackage columns.
+// Positions such as the centroid are split into <name>_lng and <name>_lat.
+type geoPackageColumn struct {
+	property string
+	columns  []geopackage.Column
+}
+
+// renderGeoPackage writes the given DTOs as a GeoPackage file download. Features
+// carry the same properties as renderFeatureCollection, one column per property,
+// so exports match the GeoJSON format; the id becomes the feature id. Derived
+// attributes are the exception: each gets a column of its own (see
+// flattenAttributes). Field selection and role visibility apply as in
+// renderFeatureCollection; features without a geometry are written with an empty one.
+func renderGeoPackage[T any](c *gin.Context, dtos []T, fields []string) {
+	role := middleware.GetRole(c)
+	features := make([]Feature, 0, len(dtos))
+	for _, dto := range dtos {
+		feature, err := toFeature(dto)
+		if err != nil {
+			apierrors.InternalServerError(c, "Failed to encode GeoPackage response", err)
+			return
+		}
+		shapeFeature(&feature, fields, role)
+		flattenAttributes(&feature)
+		features = append(features, feature)
+	}
+
+	data, err := encodeGeoPackage(features)
+	if err != nil {
+		apierrors.InternalServerError(c, "Failed to encode GeoPackage response", err)
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": GeoPackageFilename,
+	}))
+	c.Data(http.StatusOK, geopackage.ContentType, data)
+}
+
+// encodeGeoPackage writes features into a single-table GeoPackage.
+func encodeGeoPackage(features []Feature) ([]byte, error) {
+	layout := geoPackageLayout(features)
+	var columns []geopackage.Column
+	for _, col := range layout {
+		columns = append(columns, col.columns...)
+	}
+
+	writer := geopackage.NewWriter(geoPackageTable, geoPackageDescription, columns)
+	for i := range features {
+		feature := &features[i]
+
+		id, ok := feature.ID.(float64)
+		if !ok {
+			return nil, fmt.Errorf("feature %d has no numeric id", i)
+		}
+
+		geometry, err := featureGeometry(feature.Geometry)
+		if err != nil {
+			return nil, fmt.Errorf("feature %v: %w", feature.ID, err)
+		}
+
+		values := make([]any, 0, len(columns))
+		for _, col := range layout {
+			values = append(values, geoPackageValues(feature.Properties[col.property], len(col.columns))...)
+		}
+
+		if err := writer.Add(int64(id), geometry, values...); err != nil {
+			return nil, fmt.Errorf("feature %v: %w", feature.ID, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if _, err := writer.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// attributeColumnPrefix starts the column names of derived attributes in exports.
+const attributeColumnPrefix = "attr_"
+
+// flattenAttributes replaces the attributes property of a feature with one
+// property per derived attribute, named attr_<name>, so each attribute is a
+// typed column rather than JSON text.
+func flattenAttributes(feature *Feature) {
+	attributes, ok := feature.Properties["attributes"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	delete(feature.Properties, "attributes")
+	for name, value := range attributes {
+		feature.Properties[attributeColumnPrefix+name] = value
+	}
+}
+
+// geoPackageLayout derives the columns from the properties of all features, in
+// name order. A property's type comes from its first non-null value; properties
+// that are null in every feature become TEXT columns.
+func geoPackageLayout(features []Feature) []geoPackageColumn {
+	types := make(map[string]any)
+	for _, feature := range features {
+		for name, value := range feature.Properties {
+			if name == fieldID {
+				continue
+			}
+			if current, seen := types[name]; !seen || current == nil {
+				types[name] = value
+			}
+		}
+	}
+
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	layout := make([]geoPackageColumn, 0, len(names))
+	for _, name := range names {
+		col := geoPackageColumn{property: name}
+		switch value := types[name].(type) {
+		case float64:
+			col.columns = []geopackage.Column{{Name: name, Type: geopackage.ColumnReal}}
+		case bool:
+			col.columns = []geopackage.Column{{Name: name, Type: geopackage.ColumnInteger}}
+		case []interface{}:
+			if isPosition(value) {
+				col.columns = []geopackage.Column{
+					{Name: name + "_lng", Type: geopackage.ColumnReal},
+					{Name: name + "_lat", Type: geopackage.ColumnReal},
+				}
+				break
+			}
+			col.columns = []geopackage.Column{{Name: name, Type: geopackage.ColumnText}}
+		default:
+			col.columns = []geopackage.Column{{Name: name, Type: geopackage.ColumnText}}
+		}
+		layout = append(layout, col)
+	}
+
+	return layout
+}
+
+// geoPackageValues converts a property into the values of its width columns.
+// Nested values other than positions are stored as JSON text.
+func geoPackageValues(value any, width int) []any {
+	if width == 2 {
+		if position, ok := value.([]interface{}); ok && isPosition(position) {
+			return []any{position[0], position[1]}
+		}
+		return []any{nil, nil}
+	}
+
+	switch v := value.(type) {
+	case nil, string, float64:
+		return []any{v}
+	case bool:
+		if v {
+			return []any{int64(1)}
+		}
+		return []any{int64(0)}
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return []any{nil}
+		}
+		return []any{string(data)}
+	}
+}
+
+// isPosition reports whether value is a [lng, lat] GeoJSON position.
+func isPosition(value []interface{}) bool {
+	if len(value) != 2 {
+		return false
+	}
+	_, lngOK := value[0].(float64)
+	_, latOK := value[1].(float64)
+	return lngOK && latOK
+}
+
+// featureGeometry converts a decoded GeoJSON geometry into a MultiPolygon.
+// A null geometry becomes an empty MultiPolygon.
+func featureGeometry(geometry interface{}) (models.MultiPolygon, error) {
+	if geometry == nil {
+		return models.MultiPolygon{}, nil
+	}
+
+	data, err := json.Marshal(geometry)
+	if err != nil {
+		return models.MultiPolygon{}, fmt.Errorf("failed to marshal geometry: %w", err)
+	}
+	area, err := models.ParseAreaGeoJSON(data)
+	if err != nil {
+		return models.MultiPolygon{}, err
+	}
+	return *area, nil
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/geopackage"
+)
+
+func testRouteParcels() []RouteParcel {
+	return []RouteParcel{
+		{
+			ParcelData: &ParcelData{
+				ID:         2,
+				OwnerName:  "Test Owner",
+				CountyName: "Montgomery",
+				Centroid:   [2]float64{-95.445, 30.345},
+				Geometry: map[string]interface{}{
+					"type":        "MultiPolygon",
+					"coordinates": [][][][2]float64{{{{-95.45, 30.34}, {-95.44, 30.34}, {-95.44, 30.35}, {-95.45, 30.34}}}},
+				},
+			},
+			Distance: 12.5,
+			Station:  100,
+		},
+		{
+			ParcelData: &ParcelData{ID: 1, CountyName: "Montgomery"},
+			Station:    40,
+		},
+	}
+}
+
+func TestRenderGeoPackage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("renders a GeoPackage attachment", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		renderGeoPackage(c, testRouteParcels(), nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, geopackage.ContentType, w.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename=parcels.gpkg`, w.Header().Get("Content-Disposition"))
+		assert.Equal(t, "SQLite format 3\x00", w.Body.String()[:16])
+	})
+
+	t.Run("empty input renders an empty table", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		renderGeoPackage(c, []*ParcelData{}, nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, geopackage.ContentType, w.Header().Get("Content-Type"))
+	})
+
+	t.Run("unsupported geometry fails", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/parcels/intersects?format=gpkg", nil)
+
+		dtos := []*ParcelData{{ID: 1, Geometry: map[string]interface{}{"type": "Point", "coordinates": []float64{-95.45, 30.34}}}}
+		renderGeoPackage(c, dtos, nil)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestGeoPackageLayout(t *testing.T) {
+	features := make([]Feature, 0, 2)
+	for _, dto := range testRouteParcels() {
+		feature, err := toFeature(dto)
+		require.NoError(t, err)
+		features = append(features, feature)
+	}
+
+	layout := geoPackageLayout(features)
+
+	var columns []geopackage.Column
+	for _, col := range layout {
+		columns = append(columns, col.columns...)
+	}
+	assert.Equal(t, []geopackage.Column{
+		{Name: "centroid_lng", Type: geopackage.ColumnReal},
+		{Name: "centroid_lat", Type: geopackage.ColumnReal},
+		{Name: "county_name", Type: geopackage.ColumnText},
+		{Name: "distance_meters", Type: geopackage.ColumnReal},
+		{Name: "owner_name", Type: geopackage.ColumnText},
+		{Name: "station_meters", Type: geopackage.ColumnReal},
+	}, columns, "properties missing from some features still get a column; id is the feature id")
+}
+
+func TestFlattenAttributes(t *testing.T) {
+	feature, err := toFeature(&ParcelData{
+		ID:         7,
+		CountyName: "Montgomery",
+		Attributes: map[string]any{"improvement_ratio": 1875.5, "homestead": true},
+	})
+	require.NoError(t, err)
+
+	flattenAttributes(&feature)
+
+	assert.NotContains(t, feature.Properties, "attributes")
+	assert.Equal(t, 1875.5, feature.Properties["attr_improvement_ratio"])
+	assert.Equal(t, true, feature.Properties["attr_homestead"])
+	assert.Equal(t, "Montgomery", feature.Properties["county_name"])
+}
+
+func TestGeoPackageValues(t *testing.T) {
+	assert.Equal(t, []any{-95.4, 30.3}, geoPackageValues([]interface{}{-95.4, 30.3}, 2))
+	assert.Equal(t, []any{nil, nil}, geoPackageValues(nil, 2))
+	assert.Equal(t, []any{"text"}, geoPackageValues("text", 1))
+	assert.Equal(t, []any{int64(1)}, geoPackageValues(true, 1))
+	assert.Equal(t, []any{`{"a":1}`}, geoPackageValues(map[string]interface{}{"a": 1}, 1))
+}
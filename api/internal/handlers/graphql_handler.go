@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/executor"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/graph"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// GraphQL request limits
+const (
+	// maxGraphQLBodyBytes caps POST bodies, which only carry a query and variables
+	maxGraphQLBodyBytes = 64 << 10
+	// maxGraphQLRootFields caps the root fields of one request; each runs a parcel query
+	maxGraphQLRootFields = 5
+)
+
+// GraphQLHandler serves parcel queries over GraphQL with the gqlgen executor
+// generated from internal/graph/parcels.graphqls.
+type GraphQLHandler struct {
+	executor *executor.Executor
+}
+
+// NewGraphQLHandler creates a new GraphQLHandler over the parcel service.
+func NewGraphQLHandler(service services.ParcelService) *GraphQLHandler {
+	exec := executor.New(graph.NewExecutableSchema(graph.Config{
+		Resolvers: &graphQLResolver{service: service},
+	}))
+	exec.Use(rootFieldLimit(maxGraphQLRootFields))
+	return &GraphQLHandler{
+		executor: exec,
+	}
+}
+
+// GraphQLRequest represents the JSON body of a POST GraphQL request.
+type GraphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// GraphQLQueryRequest represents the query parameters of a GET GraphQL request.
+// Variables is a JSON object encoded as a string.
+type GraphQLQueryRequest struct {
+	Query         string `form:"query" binding:"required"`
+	OperationName string `form:"operationName"`
+	Variables     string `form:"variables"`
+}
+
+// Execute handles GET and POST /api/v1/graphql endpoint.
+// POST requests carry a JSON body with query, operationName and variables; GET
+// requests carry the same as query parameters. Responses follow the GraphQL
+// response format: 200 once execution starts, even with field errors, and 400
+// with errors only when the request cannot be executed.
+func (h *GraphQLHandler) Execute(c *gin.Context) {
+	var req GraphQLRequest
+	if c.Request.Method == http.MethodGet {
+		var query GraphQLQueryRequest
+		if err := c.ShouldBindQuery(&query); err != nil {
+			if validationErrors, ok := err.(validator.ValidationErrors); ok {
+				apierrors.ValidationError(c, validationErrors)
+				return
+			}
+			apierrors.BadRequest(c, "Invalid query parameters", nil)
+			return
+		}
+		req = GraphQLRequest{Query: query.Query, OperationName: query.OperationName}
+		if query.Variables != "" {
+			if err := json.Unmarshal([]byte(query.Variables), &req.Variables); err != nil {
+				apierrors.BadRequest(c, "variables must be a JSON object", nil)
+				return
+			}
+		}
+	} else {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxGraphQLBodyBytes)
+		if err := c.ShouldBindJSON(&req); err != nil {
+			// Malformed JSON, wrong member types or a body over maxGraphQLBodyBytes
+			apierrors.BadRequest(c, "Invalid request body", nil)
+			return
+		}
+		if req.Query == "" {
+			apierrors.BadRequest(c, "query is required", nil)
+			return
+		}
+	}
+
+	ctx := context.WithValue(graphql.StartOperationTrace(c.Request.Context()), graphQLCallerKey{}, graphQLCaller{
+		log:  middleware.GetLogger(c),
+		role: middleware.GetRole(c),
+	})
+	operation, errs := h.executor.CreateOperationContext(ctx, &graphql.RawParams{
+		Query:         req.Query,
+		OperationName: req.OperationName,
+		Variables:     req.Variables,
+	})
+	if errs != nil {
+		// Parse, validation and variable errors, and operations over the limits
+		c.JSON(http.StatusBadRequest, h.executor.DispatchError(graphql.WithOperationContext(ctx, operation), errs))
+		return
+	}
+
+	responses, ctx := h.executor.DispatchOperation(ctx, operation)
+	c.JSON(http.StatusOK, responses(ctx))
+}
+
+// Schema handles GET /api/v1/graphql/schema endpoint.
+// It returns the schema in the GraphQL schema definition language for client code generation.
+func (h *GraphQLHandler) Schema(c *gin.Context) {
+	c.String(http.StatusOK, graph.SDL)
+}
+
+// rootFieldLimit rejects operations selecting more root fields than the limit,
+// aliases included, before any resolver runs.
+type rootFieldLimit int
+
+// ExtensionName implements graphql.HandlerExtension.
+func (l rootFieldLimit) ExtensionName() string {
+	return "RootFieldLimit"
+}
+
+// Validate implements graphql.HandlerExtension.
+func (l rootFieldLimit) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+// MutateOperationContext implements graphql.OperationContextMutator.
+func (l rootFieldLimit) MutateOperationContext(ctx context.Context, operation *graphql.OperationContext) *gqlerror.Error {
+	if n := len(graphql.CollectFields(operation, operation.Operation.SelectionSet, nil)); n > int(l) {
+		return gqlerror.Errorf("Query selects %d root fields, at most %d are allowed.", n, int(l))
+	}
+	return nil
+}
+
+// graphQLCallerKey is the context key of the graphQLCaller of a request.
+type graphQLCallerKey struct{}
+
+// graphQLCaller carries the request state resolvers need: the role for field
+// visibility and the request logger for internal errors.
+type graphQLCaller struct {
+	log  *logger.Logger
+	role middleware.Role
+}
+
+// callerFrom returns the caller stored by the handler; a bare context is public.
+func callerFrom(ctx context.Context) graphQLCaller {
+	if caller, ok := ctx.Value(graphQLCallerKey{}).(graphQLCaller); ok {
+		return caller
+	}
+	return graphQLCaller{role: middleware.RolePublic}
+}
+
+// graphQLParcel maps a parcel to the GraphQL Parcel type. Fields follow the
+// members of ParcelData and the same role visibility matrix; fields hidden
+// from the caller and empty strings are null.
+func graphQLParcel(ctx context.Context, parcel *models.TaxParcel) *graph.Parcel {
+	role := callerFrom(ctx).role
+	data := mapTaxParcelToDTO(parcel)
+	return &graph.Parcel{
+		// id and county_name are visible to every role
+		ID:                  int(data.ID),
+		CountyName:          data.CountyName,
+		ParcelID:            visible(role, "parcel_id", optionalString(data.ParcelID)),
+		OwnerName:           visible(role, "owner_name", optionalString(data.OwnerName)),
+		OwnerAddress:        visible(role, fieldOwnerAddress, optionalString(data.OwnerAddress)),
+		SitusAddress:        visible(role, "situs_address", optionalString(data.SitusAddress)),
+		PropType:            visible(role, "prop_type", optionalString(data.PropType)),
+		LandUse:             visible(role, "land_use", optionalString(data.LandUse)),
+		Acres:               visible(role, "acres", &data.Acres),
+		Waterfront:          visible(role, "waterfront", data.Waterfront),
+		WaterFrontageMeters: visible(role, "water_frontage_meters", data.WaterFrontageMeters),
+		WaterfrontType:      visible(role, "waterfront_type", optionalString(data.WaterfrontType)),
+		WaterBody:           visible(role, "water_body", optionalString(data.WaterBody)),
+		Centroid:            visible(role, "centroid", data.Centroid[:]),
+		Bbox:                visible(role, "bbox", data.BBox),
+		Attributes:          visible(role, "attributes", data.Attributes),
+		Geometry:            visible(role, fieldGeometry, data.Geometry),
+	}
+}
+
+// visible returns v when role may see the DTO member, else its zero value,
+// which GraphQL serializes as null.
+func visible[T any](role middleware.Role, member string, v T) T {
+	if !fieldVisible(role, member) {
+		var zero T
+		return zero
+	}
+	return v
+}
+
+// optionalString maps empty DTO strings to null.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// parcelQueryContext applies the county argument, skips loading geometries when
+// the field at geometryPath is not selected and decrypts owner contact data when
+// its sibling ownerAddress is selected and visible to the caller.
+func parcelQueryContext(ctx context.Context, county *string, geometryPath ...string) context.Context {
+	ctx = repository.WithCounty(ctx, stringValue(county))
+	if !selects(ctx, geometryPath...) {
+		ctx = repository.WithoutGeometry(ctx)
+	}
+	addressPath := append(slices.Clone(geometryPath[:len(geometryPath)-1]), "ownerAddress")
+	if fieldVisible(callerFrom(ctx).role, fieldOwnerAddress) && selects(ctx, addressPath...) {
+		ctx = repository.WithContactAccess(ctx)
+	}
+	return ctx
+}
+
+// selects reports whether the field being resolved selects the field at path,
+// following fragments and every alias of each field on the path.
+func selects(ctx context.Context, path ...string) bool {
+	operation := graphql.GetOperationContext(ctx)
+	selections := graphql.GetFieldContext(ctx).Field.Selections
+	for _, name := range path {
+		var next ast.SelectionSet
+		found := false
+		for _, field := range graphql.CollectFields(operation, selections, nil) {
+			if field.Name == name {
+				next = append(next, field.Selections...)
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+		selections = next
+	}
+	return true
+}
+
+// resolverError passes client errors through and hides the cause of internal
+// ones behind message, logging them with the request logger.
+func resolverError(ctx context.Context, err error, message string) error {
+	if errors.Is(err, domain.ErrValidation) {
+		return err
+	}
+	if log := callerFrom(ctx).log; log != nil {
+		log.Error("GraphQL resolver failed", err, map[string]interface{}{
+			"message": message,
+		})
+	}
+	return errors.New(message)
+}
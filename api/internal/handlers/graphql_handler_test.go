@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/pagination"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MockGraphQLParcelService mocks the parcel service methods the GraphQL schema
+// calls; the embedded interface panics on any other method.
+type MockGraphQLParcelService struct {
+	services.ParcelService
+	mock.Mock
+}
+
+func (m *MockGraphQLParcelService) GetParcelAtPoint(ctx context.Context, point models.LatLng) (*models.TaxParcel, error) {
+	args := m.Called(ctx, point)
+	result, _ := args.Get(0).(*models.TaxParcel)
+	return result, args.Error(1)
+}
+
+func (m *MockGraphQLParcelService) GetNearbyParcels(ctx context.Context, point models.LatLng, radiusMeters, limit int, cursor string, filter repository.NearbyFilter) (*services.NearbyPage, error) {
+	args := m.Called(ctx, point, radiusMeters, limit, cursor, filter)
+	result, _ := args.Get(0).(*services.NearbyPage)
+	return result, args.Error(1)
+}
+
+func (m *MockGraphQLParcelService) DefaultNearbyRadius(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockGraphQLParcelService) SearchByAddress(ctx context.Context, address string, limit int, cursor string) (*services.AddressSearchPage, error) {
+	args := m.Called(ctx, address, limit, cursor)
+	result, _ := args.Get(0).(*services.AddressSearchPage)
+	return result, args.Error(1)
+}
+
+// setupGraphQLTestRouter creates a test router with the GraphQL handlers; role
+// is set on every request as the auth middleware would.
+func setupGraphQLTestRouter(handler *GraphQLHandler, role middleware.Role) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.RoleKey, role)
+	})
+
+	router.GET("/api/v1/graphql", handler.Execute)
+	router.POST("/api/v1/graphql", handler.Execute)
+	router.GET("/api/v1/graphql/schema", handler.Schema)
+
+	return router
+}
+
+// testGraphQLParcel is a parcel near Conroe, TX.
+func testGraphQLParcel() models.TaxParcel {
+	owner, situs := "ACME LAND LLC", "100 MAIN ST"
+	return models.TaxParcel{
+		ID:          42,
+		OwnerName:   &owner,
+		Situs:       &situs,
+		CountyName:  "Montgomery",
+		CentroidLng: -95.45,
+		CentroidLat: 30.35,
+		Acres:       2.5,
+	}
+}
+
+// postGraphQL sends a POST GraphQL request and returns the recorder.
+func postGraphQL(router *gin.Engine, query string, variables map[string]any) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(map[string]any{"query": query, "variables": variables})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/graphql", strings.NewReader(string(body))))
+	return w
+}
+
+func TestGraphQLHandler_AtPoint(t *testing.T) {
+	mockService := new(MockGraphQLParcelService)
+	router := setupGraphQLTestRouter(NewGraphQLHandler(mockService), middleware.RolePublic)
+
+	parcel := testGraphQLParcel()
+	mockService.On("GetParcelAtPoint", mock.Anything, models.LatLng{Lat: 30.35, Lng: -95.45}).Return(&parcel, nil)
+
+	w := postGraphQL(router, `query ($lat: Float!) { atPoint(lat: $lat, lng: -95.45) { id ownerName situsAddress centroid landUse waterfront } }`,
+		map[string]any{"lat": 30.35})
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"data":{"atPoint":{"id":42,"ownerName":"ACME LAND LLC","situsAddress":"100 MAIN ST","centroid":[-95.45,30.35],"landUse":null,"waterfront":null}}}`, w.Body.String(), "waterfront is null until the county has a hydrology layer")
+	mockService.AssertExpectations(t)
+}
+
+func TestGraphQLHandler_AtPointNotFound(t *testing.T) {
+	mockService := new(MockGraphQLParcelService)
+	router := setupGraphQLTestRouter(NewGraphQLHandler(mockService), middleware.RolePublic)
+	mockService.On("GetParcelAtPoint", mock.Anything, mock.Anything).Return(nil, services.ErrParcelNotFound)
+
+	w := postGraphQL(router, `{ atPoint(lat: 30.35, lng: -95.45) { id } }`, nil)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"data":{"atPoint":null}}`, w.Body.String())
+}
+
+func TestGraphQLHandler_Nearby(t *testing.T) {
+	mockService := new(MockGraphQLParcelService)
+	router := setupGraphQLTestRouter(NewGraphQLHandler(mockService), middleware.RolePublic)
+
+	minAcres, waterfront, within := 1.0, true, 3218.7
+	parcel := testGraphQLParcel()
+	frontage, lake := 42.5, "Lake Conroe"
+	parcel.WaterFrontageMeters, parcel.WaterBodyName = &frontage, &lake
+	mockService.On("GetNearbyParcels", mock.Anything, models.LatLng{Lat: 30.35, Lng: -95.45}, 500, 20, "abc",
+		repository.NearbyFilter{MinAcres: &minAcres, LandUse: "A1", Waterfront: &waterfront, Amenity: "fire_station", AmenityWithinMeters: &within}).
+		Return(&services.NearbyPage{
+			Parcels:    []repository.ParcelWithDistance{{Parcel: parcel, Distance: 12.5}},
+			TotalCount: 3,
+			NextCursor: "def",
+		}, nil)
+
+	w := postGraphQL(router, `{
+		nearby(lat: 30.35, lng: -95.45, radius: 500, after: "abc", minAcres: 1, landUse: "A1", waterfront: true,
+			amenity: "fire_station", amenityWithin: 3218.7) {
+			totalCount nextCursor results { distanceMeters parcel { id acres waterfront waterFrontageMeters waterBody } }
+		}
+	}`, nil)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"data":{"nearby":{"totalCount":3,"nextCursor":"def","results":[{"distanceMeters":12.5,
+		"parcel":{"id":42,"acres":2.5,"waterfront":true,"waterFrontageMeters":42.5,"waterBody":"Lake Conroe"}}]}}}`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestGraphQLHandler_SearchOverGET(t *testing.T) {
+	mockService := new(MockGraphQLParcelService)
+	router := setupGraphQLTestRouter(NewGraphQLHandler(mockService), middleware.RolePublic)
+	mockService.On("SearchByAddress", mock.Anything, "100 main", 10, "").
+		Return(&services.AddressSearchPage{
+			Matches:    []repository.ParcelWithScore{{Parcel: testGraphQLParcel(), Score: 0.8}},
+			TotalCount: 1,
+		}, nil)
+
+	params := url.Values{
+		"query":     {`query Search($q: String!) { search(address: $q) { nextCursor results { score parcel { situsAddress } } } }`},
+		"variables": {`{"q":"100 main"}`},
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/graphql?"+params.Encode(), nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"data":{"search":{"nextCursor":null,"results":[{"score":0.8,"parcel":{"situsAddress":"100 MAIN ST"}}]}}}`, w.Body.String())
+}
+
+func TestGraphQLHandler_RoleVisibility(t *testing.T) {
+	mockService := new(MockGraphQLParcelService)
+	router := setupGraphQLTestRouter(NewGraphQLHandler(mockService), middleware.RolePartner)
+
+	parcel := testGraphQLParcel()
+	mockService.On("GetParcelAtPoint", mock.Anything, mock.Anything).Return(&parcel, nil)
+
+	w := postGraphQL(router, `{ atPoint(lat: 30.35, lng: -95.45) { id centroid } }`, nil)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"data":{"atPoint":{"id":42,"centroid":null}}}`, w.Body.String(), "centroid is hidden from partners")
+}
+
+func TestGraphQLHandler_ServiceErrors(t *testing.T) {
+	testCases := []struct {
+		err     error
+		name    string
+		message string
+	}{
+		{services.ErrInvalidCoordinates, "client error", services.ErrInvalidCoordinates.Error()},
+		{pagination.ErrInvalidCursor, "invalid cursor", pagination.ErrInvalidCursor.Error()},
+		{errors.New("connection refused"), "internal error", "Failed to query nearby parcels"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockGraphQLParcelService)
+			router := setupGraphQLTestRouter(NewGraphQLHandler(mockService), middleware.RolePublic)
+			mockService.On("DefaultNearbyRadius", mock.Anything).Return(services.DefaultRadiusMeters, nil)
+			mockService.On("GetNearbyParcels", mock.Anything, mock.Anything, services.DefaultRadiusMeters, mock.Anything, mock.Anything, mock.Anything).
+				Return(nil, tc.err)
+
+			w := postGraphQL(router, `{ nearby(lat: 30.35, lng: -95.45) { totalCount } }`, nil)
+
+			require.Equal(t, http.StatusOK, w.Code, "field errors are part of a 200 response")
+			var response struct {
+				Data   map[string]any `json:"data"`
+				Errors []struct {
+					Message string `json:"message"`
+					Path    []any  `json:"path"`
+				} `json:"errors"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Nil(t, response.Data, "a failed non-null root nulls data")
+			require.Len(t, response.Errors, 1)
+			assert.Equal(t, tc.message, response.Errors[0].Message)
+			assert.Equal(t, []any{"nearby"}, response.Errors[0].Path)
+		})
+	}
+}
+
+func TestGraphQLHandler_RequestErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"invalid JSON", `{"query":`, http.StatusBadRequest},
+		{"missing query", `{"variables":{}}`, http.StatusBadRequest},
+		{"unknown field", `{"query":"{ atPoint(lat: 1, lng: 1) { taxes } }"}`, http.StatusBadRequest},
+		{"too many root fields", `{"query":"{ a: atPoint(lat: 1, lng: 1) { id } b: atPoint(lat: 1, lng: 1) { id } c: atPoint(lat: 1, lng: 1) { id } d: atPoint(lat: 1, lng: 1) { id } e: atPoint(lat: 1, lng: 1) { id } f: atPoint(lat: 1, lng: 1) { id } }"}`, http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockGraphQLParcelService)
+			router := setupGraphQLTestRouter(NewGraphQLHandler(mockService), middleware.RolePublic)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/graphql", strings.NewReader(tc.body)))
+
+			assert.Equal(t, tc.status, w.Code)
+			mockService.AssertNotCalled(t, "GetParcelAtPoint")
+		})
+	}
+}
+
+func TestGraphQLHandler_Schema(t *testing.T) {
+	router := setupGraphQLTestRouter(NewGraphQLHandler(new(MockGraphQLParcelService)), middleware.RolePublic)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/graphql/schema", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "atPoint(lat: Float!, lng: Float!, county: String): Parcel\n")
+	assert.Contains(t, w.Body.String(), "type Parcel {\n")
+	assert.Contains(t, w.Body.String(), "scalar GeoJSON\n")
+}
@@ -0,0 +1,99 @@
+package handlers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.81
+
+import (
+	"context"
+	"errors"
+
+	"github.com/stwalsh4118/atlas/api/internal/graph"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// AtPoint is the resolver for the atPoint field.
+func (r *queryGraphQLResolver) AtPoint(ctx context.Context, lat float64, lng float64, county *string) (*graph.Parcel, error) {
+	ctx = parcelQueryContext(ctx, county, "geometry")
+	parcel, err := r.service.GetParcelAtPoint(ctx, models.LatLng{Lat: lat, Lng: lng})
+	if errors.Is(err, services.ErrParcelNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, resolverError(ctx, err, "Failed to query parcel")
+	}
+	return graphQLParcel(ctx, parcel), nil
+}
+
+// Nearby is the resolver for the nearby field.
+func (r *queryGraphQLResolver) Nearby(ctx context.Context, lat float64, lng float64, radius *int, limit int, after *string, county *string, minAcres *float64, maxAcres *float64, waterfront *bool, landUse *string, ownerName *string, amenity *string, amenityWithin *float64) (*graph.NearbyPage, error) {
+	ctx = parcelQueryContext(ctx, county, "results", "parcel", "geometry")
+	filter := repository.NearbyFilter{
+		MinAcres:            minAcres,
+		MaxAcres:            maxAcres,
+		Waterfront:          waterfront,
+		LandUse:             stringValue(landUse),
+		OwnerName:           stringValue(ownerName),
+		Amenity:             stringValue(amenity),
+		AmenityWithinMeters: amenityWithin,
+	}
+
+	var radiusMeters int
+	if radius != nil {
+		radiusMeters = *radius
+	} else {
+		var err error
+		if radiusMeters, err = r.service.DefaultNearbyRadius(ctx); err != nil {
+			return nil, resolverError(ctx, err, "Failed to query nearby parcels")
+		}
+	}
+
+	page, err := r.service.GetNearbyParcels(ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, limit, stringValue(after), filter)
+	if err != nil {
+		return nil, resolverError(ctx, err, "Failed to query nearby parcels")
+	}
+
+	role := callerFrom(ctx).role
+	results := make([]*graph.NearbyParcel, len(page.Parcels))
+	for i := range page.Parcels {
+		results[i] = &graph.NearbyParcel{
+			DistanceMeters: visible(role, "distance_meters", &page.Parcels[i].Distance),
+			Parcel:         graphQLParcel(ctx, &page.Parcels[i].Parcel),
+		}
+	}
+	return &graph.NearbyPage{
+		Results:    results,
+		TotalCount: page.TotalCount,
+		NextCursor: optionalString(page.NextCursor),
+	}, nil
+}
+
+// Search is the resolver for the search field.
+func (r *queryGraphQLResolver) Search(ctx context.Context, address string, limit int, after *string, county *string) (*graph.AddressSearchPage, error) {
+	ctx = parcelQueryContext(ctx, county, "results", "parcel", "geometry")
+	page, err := r.service.SearchByAddress(ctx, address, limit, stringValue(after))
+	if err != nil {
+		return nil, resolverError(ctx, err, "Failed to search parcels by address")
+	}
+
+	role := callerFrom(ctx).role
+	results := make([]*graph.AddressMatch, len(page.Matches))
+	for i := range page.Matches {
+		results[i] = &graph.AddressMatch{
+			Score:  visible(role, "score", &page.Matches[i].Score),
+			Parcel: graphQLParcel(ctx, &page.Matches[i].Parcel),
+		}
+	}
+	return &graph.AddressSearchPage{
+		Results:    results,
+		TotalCount: page.TotalCount,
+		NextCursor: optionalString(page.NextCursor),
+	}, nil
+}
+
+// Query returns graph.QueryResolver implementation.
+func (r *graphQLResolver) Query() graph.QueryResolver { return &queryGraphQLResolver{r} }
+
+type queryGraphQLResolver struct{ *graphQLResolver }
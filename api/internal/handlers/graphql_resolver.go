@@ -0,0 +1,12 @@
+package handlers
+
+// This file will not be regenerated automatically.
+//
+// It serves as dependency injection for your app, add any dependencies you require here.
+
+import "github.com/stwalsh4118/atlas/api/internal/services"
+
+// graphQLResolver resolves the schema in internal/graph over the parcel service.
+type graphQLResolver struct {
+	service services.ParcelService
+}
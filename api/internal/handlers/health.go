@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -14,24 +16,55 @@ import (
 const (
 	// APIVersion is the current version of the API
 	APIVersion = "0.1.0"
-	// HealthCheckTimeout is the timeout for database health checks
+	// HealthCheckTimeout is the timeout for each dependency check
 	HealthCheckTimeout = 2 * time.Second
 )
 
+// Readiness and dependency statuses
+const (
+	ReadyStatus    = "ready"
+	DegradedStatus = "degraded"
+	NotReadyStatus = "not_ready"
+	UpStatus       = "up"
+	DownStatus     = "down"
+)
+
+// HealthCheck is a dependency the readiness check reports on.
+type HealthCheck struct {
+	// Check returns nil while the dependency is usable
+	Check func(ctx context.Context) error
+	Name  string
+	// Optional dependencies being down degrade readiness without failing it,
+	// such as a shared cache the API can work without
+	Optional bool
+}
+
 // HealthHandler handles health check and readiness endpoints.
 type HealthHandler struct {
 	db        *database.Database
 	startTime time.Time
 	env       string
+	checks    []HealthCheck
 }
 
-// NewHealthHandler creates a new HealthHandler instance.
+// NewHealthHandler creates a new HealthHandler instance checking the database
+// connection. Further dependencies are added with AddCheck.
 func NewHealthHandler(db *database.Database, env string) *HealthHandler {
-	return &HealthHandler{
+	h := &HealthHandler{
 		db:        db,
 		startTime: time.Now(),
 		env:       env,
 	}
+	if db != nil {
+		h.AddCheck(HealthCheck{Name: "database", Check: db.Ping})
+	}
+	return h
+}
+
+// AddCheck adds a dependency to the readiness check. Checks must be added
+// before the handler serves requests.
+func (h *HealthHandler) AddCheck(check HealthCheck) {
+	h.checks = append(h.checks, check)
 }
 
 // HealthResponse represents the basic health check response.
@@ -40,9 +73,20 @@ type HealthResponse struct {
 }
 
 // ReadyResponse represents the readiness check response.
+// Status is ready, degraded (an optional dependency is down) or not_ready.
+// Database keeps the connected/disconnected summary of earlier versions.
 type ReadyResponse struct {
-	Status   string `json:"status"`
-	Database string `json:"database"`
+	Checks   map[string]DependencyStatus `json:"checks,omitempty"`
+	Status   string                      `json:"status"`
+	Database string                      `json:"database"`
+}
+
+// DependencyStatus represents the result of one dependency check.
+type DependencyStatus struct {
+	Status    string  `json:"status"`
+	Error     string  `json:"error,omitempty"`
+	LatencyMS float64 `json:"latency_ms"`
+	Optional  bool    `json:"optional,omitempty"`
 }
 
 // InfoResponse represents the API information response.
@@ -62,33 +106,83 @@ func (h *HealthHandler) Health(c *gin.Context) {
 }
 
 // Ready handles GET /health/ready endpoint.
-// This is a readiness check that verifies the database connection is available.
-// Returns 200 OK if the database is connected, 503 Service Unavailable otherwise.
+// This is a readiness check that runs every dependency check concurrently, each
+// under HealthCheckTimeout, and reports their status, latency and error.
+// Returns 200 OK when every required dependency is up, 503 Service Unavailable otherwise.
 func (h *HealthHandler) Ready(c *gin.Context) {
-	// Create context with timeout for database ping
-	ctx, cancel := context.WithTimeout(c.Request.Context(), HealthCheckTimeout)
-	defer cancel()
+	checks := h.runChecks(c.Request.Context())
+
+	response := ReadyResponse{Status: ReadyStatus, Database: "connected", Checks: checks}
+	for _, check := range h.checks {
+		result := checks[check.Name]
+		if result.Status == UpStatus {
+			continue
+		}
 
-	// Check database connectivity
-	if err := h.db.Ping(ctx); err != nil {
 		// Get logger from context (set by logger middleware)
 		if log := middleware.GetLogger(c); log != nil {
-			log.Error("Database health check failed", err, map[string]interface{}{
-				"timeout": HealthCheckTimeout.String(),
+			log.Error("Dependency health check failed", errors.New(result.Error), map[string]interface{}{
+				"dependency": check.Name,
+				"optional":   check.Optional,
+				"timeout":    HealthCheckTimeout.String(),
 			})
 		}
+		if check.Name == "database" {
+			response.Database = "disconnected"
+		}
+		if !check.Optional {
+			response.Status = NotReadyStatus
+		} else if response.Status == ReadyStatus {
+			response.Status = DegradedStatus
+		}
+	}
 
-		c.JSON(http.StatusServiceUnavailable, ReadyResponse{
-			Status:   "not_ready",
-			Database: "disconnected",
-		})
-		return
+	status := http.StatusOK
+	if response.Status == NotReadyStatus {
+		status = http.StatusServiceUnavailable
 	}
+	c.JSON(status, response)
+}
 
-	c.JSON(http.StatusOK, ReadyResponse{
-		Status:   "ready",
-		Database: "connected",
-	})
+// runChecks runs the dependency checks concurrently and returns their results by name.
+func (h *HealthHandler) runChecks(ctx context.Context) map[string]DependencyStatus {
+	results := make(map[string]DependencyStatus, len(h.checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := runCheck(ctx, check)
+			mu.Lock()
+			results[check.Name] = result
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// runCheck runs one dependency check under HealthCheckTimeout.
+func runCheck(ctx context.Context, check HealthCheck) DependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, HealthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Check(ctx)
+	result := DependencyStatus{
+		Status:    UpStatus,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+		Optional:  check.Optional,
+	}
+	if err != nil {
+		result.Status = DownStatus
+		result.Error = err.Error()
+		if errors.Is(err, context.DeadlineExceeded) {
+			result.Error = "timed out after " + HealthCheckTimeout.String()
+		}
+	}
+	return result
 }
 
 // Info handles GET /api/v1/info endpoint.
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -98,6 +99,88 @@ func TestHealthHandler_Ready_DatabaseConnected(t *testing.T) {
 	})
 }
 
+func TestHealthHandler_Ready(t *testing.T) {
+	up := func(context.Context) error { return nil }
+	down := func(context.Context) error { return errors.New("connection refused") }
+	slow := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	tests := []struct {
+		name           string
+		checks         []HealthCheck
+		expectedStatus int
+		expectedReady  string
+		expectedDB     string
+	}{
+		{
+			name:           "all dependencies up",
+			checks:         []HealthCheck{{Name: "database", Check: up}, {Name: "migrations", Check: up}, {Name: "cache", Check: up, Optional: true}},
+			expectedStatus: http.StatusOK,
+			expectedReady:  ReadyStatus,
+			expectedDB:     "connected",
+		},
+		{
+			name:           "optional dependency down degrades",
+			checks:         []HealthCheck{{Name: "database", Check: up}, {Name: "cache", Check: down, Optional: true}},
+			expectedStatus: http.StatusOK,
+			expectedReady:  DegradedStatus,
+			expectedDB:     "connected",
+		},
+		{
+			name:           "required dependency down is not ready",
+			checks:         []HealthCheck{{Name: "database", Check: up}, {Name: "migrations", Check: down}, {Name: "cache", Check: down, Optional: true}},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedReady:  NotReadyStatus,
+			expectedDB:     "connected",
+		},
+		{
+			name:           "database down",
+			checks:         []HealthCheck{{Name: "database", Check: down}},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedReady:  NotReadyStatus,
+			expectedDB:     "disconnected",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHealthHandler(nil, "test")
+			for _, check := range tt.checks {
+				handler.AddCheck(check)
+			}
+			router := setupTestRouter(handler)
+			router.GET("/health/ready", handler.Ready)
+
+			req := httptest.NewRequest(http.MethodGet, "/health/ready", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var response ReadyResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+			assert.Equal(t, tt.expectedReady, response.Status)
+			assert.Equal(t, tt.expectedDB, response.Database)
+			assert.Len(t, response.Checks, len(tt.checks))
+		})
+	}
+
+	t.Run("reports each dependency's error and times out slow checks", func(t *testing.T) {
+		handler := NewHealthHandler(nil, "test")
+		handler.AddCheck(HealthCheck{Name: "migrations", Check: down})
+		handler.AddCheck(HealthCheck{Name: "cache", Check: slow, Optional: true})
+
+		checks := handler.runChecks(context.Background())
+
+		assert.Equal(t, DependencyStatus{Status: DownStatus, Error: "connection refused", LatencyMS: checks["migrations"].LatencyMS}, checks["migrations"])
+		assert.Equal(t, DownStatus, checks["cache"].Status)
+		assert.Equal(t, "timed out after "+HealthCheckTimeout.String(), checks["cache"].Error)
+		assert.True(t, checks["cache"].Optional)
+		assert.GreaterOrEqual(t, checks["cache"].LatencyMS, float64(HealthCheckTimeout.Milliseconds()))
+	})
+}
+
 func TestHealthHandler_Info(t *testing.T) {
 	tests := []struct {
 		startTime   time.Time
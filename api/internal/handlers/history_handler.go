@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// Parcel history constants
+const (
+	// DefaultHistoryLimit is the number of versions returned without a limit
+	DefaultHistoryLimit = 20
+)
+
+// HistoryHandler handles parcel history HTTP requests.
+type HistoryHandler struct {
+	service services.HistoryService
+}
+
+// NewHistoryHandler creates a new HistoryHandler instance.
+func NewHistoryHandler(service services.HistoryService) *HistoryHandler {
+	return &HistoryHandler{
+		service: service,
+	}
+}
+
+// HistoryRequest represents the query parameters for the parcel history endpoint.
+// Boundaries are returned only with Geometry, since every version carries one;
+// geometry_hash tells boundary changes apart without them.
+type HistoryRequest struct {
+	Limit    int  `form:"limit" binding:"omitempty,min=1,max=100"` // default: 20
+	Geometry bool `form:"geometry"`
+}
+
+// ParcelHistoryResponse represents the response for the parcel history endpoint.
+// Versions are newest first, starting with the current one; Deleted reports
+// whether the parcel was soft-deleted by a sync load.
+type ParcelHistoryResponse struct {
+	Versions []ParcelVersionData `json:"versions"`
+	ParcelID uint                `json:"parcel_id"`
+	Count    int                 `json:"count"`
+	Deleted  bool                `json:"deleted"`
+}
+
+// ParcelVersionData represents one version of a parcel in the API response.
+// ValidTo is omitted for the current version; Changed lists the members that
+// differ from the version before, and is omitted for the oldest recorded one.
+// Field order is optimized for memory alignment.
+type ParcelVersionData struct {
+	Geometry         map[string]interface{} `json:"geometry,omitempty"` // only with geometry=true
+	ValidTo          *time.Time             `json:"valid_to,omitempty"`
+	PID              *int                   `json:"pid,omitempty"`
+	Block            *int                   `json:"block,omitempty"`
+	YearBuilt        *int                   `json:"year_built,omitempty"`
+	MainArea         *int                   `json:"main_area,omitempty"`
+	TaxYear          *int                   `json:"tax_year,omitempty"`
+	RollVersion      *int                   `json:"roll_version,omitempty"`
+	RollCorrection   *int                   `json:"roll_correction,omitempty"`
+	ValidFrom        time.Time              `json:"valid_from"`
+	Changed          []string               `json:"changed,omitempty"`
+	OwnerName        string                 `json:"owner_name,omitempty"`
+	OwnerAddress     string                 `json:"owner_address,omitempty"` // mailing address, admin only
+	SitusAddress     string                 `json:"situs_address,omitempty"`
+	LandUse          string                 `json:"land_use,omitempty"`
+	LegalDescription string                 `json:"legal_description,omitempty"`
+	StateCd          string                 `json:"state_cd,omitempty"`
+	Lot              string                 `json:"lot,omitempty"`
+	Tract            string                 `json:"tract,omitempty"`
+	MarketArea       string                 `json:"market_area,omitempty"`
+	TaxingUnits      string                 `json:"taxing_units,omitempty"`
+	Exemptions       string                 `json:"exemptions,omitempty"`
+	GeometryHash     string                 `json:"geometry_hash"`
+	Acres            float64                `json:"acres"`
+	PIN              int                    `json:"pin"`
+	Deleted          bool                   `json:"deleted"`
+}
+
+// List handles GET /api/v1/parcels/:id/history endpoint.
+// It returns the versions of the parcel recorded as ingestion changed its
+// attributes or boundary, including those of soft-deleted parcels.
+func (h *HistoryHandler) List(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		apierrors.BadRequest(c, "parcel id must be a positive integer", nil)
+		return
+	}
+
+	var req HistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		apierrors.BadRequest(c, "Invalid query parameters", nil)
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = DefaultHistoryLimit
+	}
+
+	ctx := c.Request.Context()
+	if !req.Geometry {
+		ctx = repository.WithoutGeometry(ctx)
+	}
+	if fieldVisible(middleware.GetRole(c), fieldOwnerAddress) {
+		ctx = repository.WithContactAccess(ctx)
+	}
+
+	history, err := h.service.GetParcelHistory(ctx, uint(id), req.Limit)
+	if err != nil {
+		apierrors.Respond(c, "Failed to retrieve parcel history", err)
+		return
+	}
+
+	middleware.SetResultCount(c, len(history.Versions))
+
+	versions := make([]ParcelVersionData, 0, len(history.Versions))
+	for _, change := range history.Versions {
+		versions = append(versions, mapParcelVersionToDTO(change, req.Geometry))
+	}
+
+	c.JSON(http.StatusOK, ParcelHistoryResponse{
+		ParcelID: history.ParcelID,
+		Versions: versions,
+		Count:    len(versions),
+		Deleted:  history.Deleted,
+	})
+}
+
+// mapParcelVersionToDTO converts a parcel version into its response.
+func mapParcelVersionToDTO(change services.ParcelVersionChange, geometry bool) ParcelVersionData {
+	v := change.Version
+	dto := ParcelVersionData{
+		ValidFrom:        v.ValidFrom,
+		ValidTo:          v.ValidTo,
+		Changed:          change.Changed,
+		PIN:              v.PIN,
+		PID:              v.PID,
+		Block:            v.Block,
+		YearBuilt:        v.ImprvActualYearBuilt,
+		MainArea:         v.ImprvMainArea,
+		TaxYear:          v.PYear,
+		RollVersion:      v.PVersion,
+		RollCorrection:   v.PRollCorr,
+		OwnerName:        stringValue(v.OwnerName),
+		OwnerAddress:     stringValue(v.OwnerAddress),
+		SitusAddress:     stringValue(v.Situs),
+		LandUse:          stringValue(v.AsCode),
+		LegalDescription: stringValue(v.LegalDescription),
+		StateCd:          stringValue(v.StateCd),
+		Lot:              stringValue(v.Lot),
+		Tract:            stringValue(v.Tract),
+		MarketArea:       stringValue(v.MarketArea),
+		TaxingUnits:      stringValue(v.TaxingUnits),
+		Exemptions:       stringValue(v.Exemptions),
+		GeometryHash:     v.GeometryHash,
+		Acres:            v.Acres,
+		Deleted:          v.Deleted,
+	}
+	if geometry {
+		dto.Geometry = map[string]interface{}{
+			"type":        "MultiPolygon",
+			"coordinates": v.Geom.Coordinates,
+		}
+	}
+	return dto
+}
+
+// stringValue returns the value of an optional string, or "" when it is nil.
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MockHistoryService is a mock implementation of HistoryService for testing
+type MockHistoryService struct {
+	mock.Mock
+}
+
+func (m *MockHistoryService) GetParcelHistory(ctx context.Context, parcelID uint, limit int) (*services.ParcelHistory, error) {
+	args := m.Called(ctx, parcelID, limit)
+	result, _ := args.Get(0).(*services.ParcelHistory)
+	return result, args.Error(1)
+}
+
+func (m *MockHistoryService) GetParcelAsOf(ctx context.Context, parcelID uint, date time.Time) (*repository.ParcelAsOf, error) {
+	args := m.Called(ctx, parcelID, date)
+	result, _ := args.Get(0).(*repository.ParcelAsOf)
+	return result, args.Error(1)
+}
+
+func (m *MockHistoryService) GetParcelAtPointAsOf(ctx context.Context, point models.LatLng, date time.Time) (*repository.ParcelAsOf, error) {
+	args := m.Called(ctx, point, date)
+	result, _ := args.Get(0).(*repository.ParcelAsOf)
+	return result, args.Error(1)
+}
+
+// setupHistoryTestRouter creates a test router with history handlers.
+func setupHistoryTestRouter(handler *HistoryHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+
+	router.GET("/api/v1/parcels/:id/history", handler.List)
+
+	return router
+}
+
+func TestHistoryHandler_List(t *testing.T) {
+	owner, previousOwner := "SMITH JOHN", "DOE JANE"
+	replaced := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	history := &services.ParcelHistory{
+		ParcelID: 42,
+		Versions: []services.ParcelVersionChange{
+			{
+				Version: &models.ParcelVersion{ParcelID: 42, PIN: 7, OwnerName: &owner, GeometryHash: "b", ValidFrom: replaced},
+				Changed: []string{"owner_name", "geometry"},
+			},
+			{
+				Version: &models.ParcelVersion{ID: 3, ParcelID: 42, PIN: 7, OwnerName: &previousOwner, GeometryHash: "a", ValidTo: &replaced},
+			},
+		},
+	}
+
+	t.Run("lists versions newest first without geometry", func(t *testing.T) {
+		mockService := new(MockHistoryService)
+		router := setupHistoryTestRouter(NewHistoryHandler(mockService))
+
+		mockService.On("GetParcelHistory", mock.Anything, uint(42), DefaultHistoryLimit).Return(history, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/parcels/42/history", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response ParcelHistoryResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, uint(42), response.ParcelID)
+		assert.Equal(t, 2, response.Count)
+		assert.False(t, response.Deleted)
+		assert.Equal(t, "SMITH JOHN", response.Versions[0].OwnerName)
+		assert.Nil(t, response.Versions[0].ValidTo, "the current version has no end")
+		assert.Equal(t, []string{"owner_name", "geometry"}, response.Versions[0].Changed)
+		assert.Equal(t, "DOE JANE", response.Versions[1].OwnerName)
+		assert.Equal(t, replaced, *response.Versions[1].ValidTo)
+		assert.Nil(t, response.Versions[1].Changed)
+		assert.NotContains(t, w.Body.String(), `"coordinates"`)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("returns geometry on request", func(t *testing.T) {
+		mockService := new(MockHistoryService)
+		router := setupHistoryTestRouter(NewHistoryHandler(mockService))
+
+		mockService.On("GetParcelHistory", mock.Anything, uint(42), 5).Return(history, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/parcels/42/history?limit=5&geometry=true", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"type":"MultiPolygon"`)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHistoryHandler_List_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		serviceErr error
+		wantStatus int
+	}{
+		{name: "invalid id", path: "/api/v1/parcels/abc/history", wantStatus: http.StatusBadRequest},
+		{name: "limit too large", path: "/api/v1/parcels/42/history?limit=101", wantStatus: http.StatusBadRequest},
+		{name: "parcel not found", path: "/api/v1/parcels/42/history", serviceErr: services.ErrParcelNotFound, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockHistoryService)
+			router := setupHistoryTestRouter(NewHistoryHandler(mockService))
+
+			if tt.serviceErr != nil {
+				mockService.On("GetParcelHistory", mock.Anything, uint(42), DefaultHistoryLimit).Return(nil, tt.serviceErr)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// KML export constants
+const (
+	// KMLContentType is the registered media type of KML documents
+	KMLContentType = "application/vnd.google-earth.kml+xml"
+	// KMLFilename is the download name of KML responses
+	KMLFilename = "parcels.kml"
+	// kmlNamespace is the OGC KML 2.2 namespace
+	kmlNamespace = "http://www.opengis.net/kml/2.2"
+	// kmlNameProperty names a placemark when the feature has it
+	kmlNameProperty = "situs_address"
+)
+
+// kmlDocument is a KML file with one Placemark per feature.
+type kmlDocument struct {
+	XMLName    xml.Name       `xml:"kml"`
+	Namespace  string         `xml:"xmlns,attr"`
+	Name       string         `xml:"Document>name"`
+	Placemarks []kmlPlacemark `xml:"Document>Placemark"`
+}
+
+// kmlPlacemark is a feature: its properties as ExtendedData and its
+// MultiPolygon as a MultiGeometry of Polygons.
+type kmlPlacemark struct {
+	Geometry *kmlMultiGeometry `xml:"MultiGeometry,omitempty"`
+	ID       string            `xml:"id,attr,omitempty"`
+	Name     string            `xml:"name"`
+	Data     []kmlData         `xml:"ExtendedData>Data"`
+}
+
+// kmlData is one untyped ExtendedData value.
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+// kmlMultiGeometry holds the polygons of a MultiPolygon.
+type kmlMultiGeometry struct {
+	Polygons []kmlPolygon `xml:"Polygon"`
+}
+
+// kmlPolygon is a polygon with its first ring as the outer boundary.
+type kmlPolygon struct {
+	Outer string   `xml:"outerBoundaryIs>LinearRing>coordinates"`
+	Inner []string `xml:"innerBoundaryIs>LinearRing>coordinates"`
+}
+
+// renderKML writes the given DTOs as a KML download for Google Earth, one
+// Placemark per DTO named by its situs address, or "Parcel <id>" without one.
+// Properties become ExtendedData in name order, with derived attributes as
+// attr_<name> and nested values as JSON; null properties are left out. Field
+// selection and role visibility apply as in renderFeatureCollection, and a
+// placemark has no geometry unless geometry is selected.
+func renderKML[T any](c *gin.Context, dtos []T, fields []string) {
+	document := kmlDocument{
+		Namespace:  kmlNamespace,
+		Name:       "Parcels",
+		Placemarks: make([]kmlPlacemark, 0, len(dtos)),
+	}
+
+	role := middleware.GetRole(c)
+	for _, dto := range dtos {
+		feature, err := toFeature(dto)
+		if err != nil {
+			apierrors.InternalServerError(c, "Failed to encode KML response", err)
+			return
+		}
+		shapeFeature(&feature, fields, role)
+		flattenAttributes(&feature)
+
+		placemark, err := kmlFeature(feature)
+		if err != nil {
+			apierrors.InternalServerError(c, "Failed to encode KML response", err)
+			return
+		}
+		document.Placemarks = append(document.Placemarks, placemark)
+	}
+
+	data, err := xml.Marshal(document)
+	if err != nil {
+		apierrors.InternalServerError(c, "Failed to encode KML response", err)
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": KMLFilename,
+	}))
+	c.Data(http.StatusOK, KMLContentType, append([]byte(xml.Header), data...))
+}
+
+// kmlFeature converts a shaped feature into a Placemark.
+func kmlFeature(feature Feature) (kmlPlacemark, error) {
+	id := kmlValue(feature.Properties[fieldID])
+	placemark := kmlPlacemark{
+		Name: "Parcel " + id,
+		Data: []kmlData{},
+	}
+	if id != "" {
+		placemark.ID = "parcel-" + id
+	}
+	if name, ok := feature.Properties[kmlNameProperty].(string); ok && name != "" {
+		placemark.Name = name
+	}
+
+	names := make([]string, 0, len(feature.Properties))
+	for name, value := range feature.Properties {
+		if value != nil {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		placemark.Data = append(placemark.Data, kmlData{Name: name, Value: kmlValue(feature.Properties[name])})
+	}
+
+	if feature.Geometry != nil {
+		area, err := featureGeometry(feature.Geometry)
+		if err != nil {
+			return kmlPlacemark{}, fmt.Errorf("failed to convert geometry: %w", err)
+		}
+		placemark.Geometry = kmlGeometry(area)
+	}
+
+	return placemark, nil
+}
+
+// kmlGeometry converts a MultiPolygon into a MultiGeometry.
+func kmlGeometry(area models.MultiPolygon) *kmlMultiGeometry {
+	geometry := &kmlMultiGeometry{Polygons: make([]kmlPolygon, 0, len(area.Coordinates))}
+	for _, polygon := range area.Coordinates {
+		if len(polygon) == 0 {
+			continue
+		}
+		p := kmlPolygon{Outer: kmlCoordinates(polygon[0])}
+		for _, ring := range polygon[1:] {
+			p.Inner = append(p.Inner, kmlCoordinates(ring))
+		}
+		geometry.Polygons = append(geometry.Polygons, p)
+	}
+	return geometry
+}
+
+// kmlCoordinates formats a ring as space-separated lng,lat tuples.
+func kmlCoordinates(ring [][2]float64) string {
+	tuples := make([]string, len(ring))
+	for i, position := range ring {
+		tuples[i] = csvNumber(position[0]) + "," + csvNumber(position[1])
+	}
+	return strings.Join(tuples, " ")
+}
+
+// kmlValue formats a property as ExtendedData text: numbers without exponent,
+// and nested values such as the centroid as JSON.
+func kmlValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return csvNumber(v)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	}
+}
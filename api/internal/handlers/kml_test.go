@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderKML(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("renders a KML attachment", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		renderKML(c, testRouteParcels(), nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, KMLContentType, w.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename=parcels.kml`, w.Header().Get("Content-Disposition"))
+		assert.True(t, strings.HasPrefix(w.Body.String(), xml.Header))
+		assert.Contains(t, w.Body.String(), `<kml xmlns="http://www.opengis.net/kml/2.2"><Document><name>Parcels</name>`)
+
+		var document kmlDocument
+		require.NoError(t, xml.Unmarshal(w.Body.Bytes(), &document))
+		require.Len(t, document.Placemarks, 2)
+
+		first := document.Placemarks[0]
+		assert.Equal(t, "parcel-2", first.ID)
+		assert.Equal(t, "Parcel 2", first.Name)
+		assert.Equal(t, []kmlData{
+			{Name: "centroid", Value: "[-95.445,30.345]"},
+			{Name: "county_name", Value: "Montgomery"},
+			{Name: "distance_meters", Value: "12.5"},
+			{Name: "id", Value: "2"},
+			{Name: "owner_name", Value: "Test Owner"},
+			{Name: "station_meters", Value: "100"},
+		}, first.Data)
+		require.NotNil(t, first.Geometry)
+		require.Len(t, first.Geometry.Polygons, 1)
+		assert.Equal(t, "-95.45,30.34 -95.44,30.34 -95.44,30.35 -95.45,30.34", first.Geometry.Polygons[0].Outer)
+		assert.Empty(t, first.Geometry.Polygons[0].Inner)
+
+		assert.Nil(t, document.Placemarks[1].Geometry, "a parcel without geometry has none")
+	})
+
+	t.Run("names placemarks by situs address and keeps holes", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		dtos := []*ParcelData{{
+			ID:           7,
+			SitusAddress: "123 Main St",
+			Geometry: map[string]interface{}{
+				"type": "MultiPolygon",
+				"coordinates": [][][][2]float64{{
+					{{0, 0}, {4, 0}, {4, 4}, {0, 0}},
+					{{1, 1}, {2, 1}, {2, 2}, {1, 1}},
+				}},
+			},
+		}}
+		renderKML(c, dtos, nil)
+
+		var document kmlDocument
+		require.NoError(t, xml.Unmarshal(w.Body.Bytes(), &document))
+		require.Len(t, document.Placemarks, 1)
+		assert.Equal(t, "123 Main St", document.Placemarks[0].Name)
+		assert.Equal(t, []string{"1,1 2,1 2,2 1,1"}, document.Placemarks[0].Geometry.Polygons[0].Inner)
+	})
+
+	t.Run("field selection drops the geometry", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		renderKML(c, testRouteParcels(), []string{"owner_name"})
+
+		var document kmlDocument
+		require.NoError(t, xml.Unmarshal(w.Body.Bytes(), &document))
+		assert.Nil(t, document.Placemarks[0].Geometry)
+		assert.Equal(t, []kmlData{{Name: "id", Value: "2"}, {Name: "owner_name", Value: "Test Owner"}}, document.Placemarks[0].Data)
+		assert.NotContains(t, w.Body.String(), "MultiGeometry")
+	})
+
+	t.Run("escapes text", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		renderKML(c, []*ParcelData{{ID: 1, OwnerName: "Smith & <Sons>"}}, nil)
+
+		assert.Contains(t, w.Body.String(), "Smith &amp; &lt;Sons&gt;")
+		var document kmlDocument
+		require.NoError(t, xml.Unmarshal(w.Body.Bytes(), &document))
+	})
+
+	t.Run("unsupported geometry fails", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/parcels/nearby?format=kml", nil)
+
+		dtos := []*ParcelData{{ID: 1, Geometry: map[string]interface{}{"type": "Point", "coordinates": []float64{-95.45, 30.34}}}}
+		renderKML(c, dtos, nil)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.NotEqual(t, KMLContentType, w.Header().Get("Content-Type"))
+	})
+}
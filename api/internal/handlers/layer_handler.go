@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// LayerHandler handles generic spatial layer HTTP requests.
+type LayerHandler struct {
+	service services.LayerService
+}
+
+// NewLayerHandler creates a new LayerHandler instance.
+func NewLayerHandler(service services.LayerService) *LayerHandler {
+	return &LayerHandler{
+		service: service,
+	}
+}
+
+// LayerAtPointRequest represents the query parameters for the layer at-point endpoint.
+type LayerAtPointRequest struct {
+	County string  `form:"county" binding:"omitempty,max=100"`
+	Lat    float64 `form:"lat" binding:"required,min=-90,max=90"`
+	Lng    float64 `form:"lng" binding:"required,min=-180,max=180"`
+	Radius float64 `form:"radius" binding:"omitempty,min=0,max=1000"`
+}
+
+// LayerInBBoxRequest represents the query parameters for the layer in-bbox
+// endpoint. BBox is minLng,minLat,maxLng,maxLat.
+type LayerInBBoxRequest struct {
+	BBox   string `form:"bbox" binding:"required"`
+	County string `form:"county" binding:"omitempty,max=100"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=5000"`
+}
+
+// LayerListResponse represents the response for the layer list endpoint.
+type LayerListResponse struct {
+	Layers []LayerData `json:"layers"`
+	Count  int         `json:"count"`
+}
+
+// LayerData is a registered layer.
+type LayerData struct {
+	UpdatedAt    time.Time `json:"updated_at"`
+	Name         string    `json:"name"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description,omitempty"`
+	FeatureCount int64     `json:"feature_count"`
+}
+
+// LayerFeaturesResponse represents the response for the layer at-point and
+// in-bbox endpoints. Features is empty where the layer has no matching features.
+type LayerFeaturesResponse struct {
+	Layer     string             `json:"layer"`
+	Features  []LayerFeatureData `json:"features"`
+	Count     int                `json:"count"`
+	Truncated bool               `json:"truncated,omitempty"`
+}
+
+// LayerFeatureData is a feature of a layer with its GeoJSON geometry and the
+// properties kept when the layer was loaded.
+type LayerFeatureData struct {
+	Properties map[string]any  `json:"properties"`
+	Geometry   json.RawMessage `json:"geometry"`
+	CountyName string          `json:"county_name"`
+	ID         uint            `json:"id"`
+}
+
+// List handles GET /api/v1/layers endpoint.
+// It returns the registered layers ordered by name.
+func (h *LayerHandler) List(c *gin.Context) {
+	layers, err := h.service.ListLayers(c.Request.Context())
+	if err != nil {
+		apierrors.Respond(c, "Failed to list layers", err)
+		return
+	}
+
+	middleware.SetResultCount(c, len(layers))
+
+	response := LayerListResponse{
+		Layers: make([]LayerData, 0, len(layers)),
+		Count:  len(layers),
+	}
+	for i := range layers {
+		response.Layers = append(response.Layers, mapLayerToDTO(&layers[i]))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// AtPoint handles GET /api/v1/layers/:name/at-point endpoint.
+// It returns the features of the layer containing the given lat/lng point, or
+// within radius meters of it, ordered by ID.
+func (h *LayerHandler) AtPoint(c *gin.Context) {
+	log := middleware.GetLogger(c)
+
+	var req LayerAtPointRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		apierrors.BadRequest(c, "Invalid query parameters", nil)
+		return
+	}
+
+	if log != nil {
+		log.Info("Processing layer at-point request", map[string]interface{}{
+			"layer":  c.Param("name"),
+			"lat":    req.Lat,
+			"lng":    req.Lng,
+			"radius": req.Radius,
+		})
+	}
+
+	ctx := repository.WithCounty(c.Request.Context(), req.County)
+	result, err := h.service.GetFeaturesAtPoint(ctx, c.Param("name"), models.LatLng{Lat: req.Lat, Lng: req.Lng}, req.Radius)
+	if err != nil {
+		apierrors.Respond(c, "Failed to query layer", err)
+		return
+	}
+
+	h.respondFeatures(c, result)
+}
+
+// InBBox handles GET /api/v1/layers/:name/in-bbox endpoint.
+// It returns up to limit features of the layer intersecting the bounding box,
+// ordered by ID, with truncated set when more matched.
+func (h *LayerHandler) InBBox(c *gin.Context) {
+	log := middleware.GetLogger(c)
+
+	var req LayerInBBoxRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		apierrors.BadRequest(c, "Invalid query parameters", nil)
+		return
+	}
+
+	bbox, ok := parseBBox(req.BBox)
+	if !ok {
+		apierrors.BadRequest(c, "bbox must be minLng,minLat,maxLng,maxLat", nil)
+		return
+	}
+
+	if log != nil {
+		log.Info("Processing layer in-bbox request", map[string]interface{}{
+			"layer": c.Param("name"),
+			"bbox":  req.BBox,
+			"limit": req.Limit,
+		})
+	}
+
+	ctx := repository.WithCounty(c.Request.Context(), req.County)
+	result, err := h.service.GetFeaturesInBBox(ctx, c.Param("name"), bbox, req.Limit)
+	if err != nil {
+		apierrors.Respond(c, "Failed to query layer", err)
+		return
+	}
+
+	h.respondFeatures(c, result)
+}
+
+// respondFeatures writes a page of layer features.
+func (h *LayerHandler) respondFeatures(c *gin.Context, result *services.LayerFeatures) {
+	middleware.SetResultCount(c, len(result.Features))
+
+	response := LayerFeaturesResponse{
+		Layer:     result.Layer.Name,
+		Features:  make([]LayerFeatureData, 0, len(result.Features)),
+		Count:     len(result.Features),
+		Truncated: result.Truncated,
+	}
+	for i := range result.Features {
+		response.Features = append(response.Features, mapLayerFeatureToDTO(&result.Features[i]))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// parseBBox parses a minLng,minLat,maxLng,maxLat bounding box. Ranges are
+// checked by the service.
+func parseBBox(raw string) (models.BoundingBox, bool) {
+	parts := strings.Split(raw, ",")
+	if len(parts) != 4 {
+		return models.BoundingBox{}, false
+	}
+
+	var values [4]float64
+	for i, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return models.BoundingBox{}, false
+		}
+		values[i] = value
+	}
+	return models.BoundingBox{MinLng: values[0], MinLat: values[1], MaxLng: values[2], MaxLat: values[3]}, true
+}
+
+// mapLayerToDTO converts a Layer model to a LayerData DTO.
+func mapLayerToDTO(layer *models.Layer) LayerData {
+	dto := LayerData{
+		Name:         layer.Name,
+		Title:        layer.Title,
+		FeatureCount: layer.FeatureCount,
+		UpdatedAt:    layer.UpdatedAt,
+	}
+	if layer.Description != nil {
+		dto.Description = *layer.Description
+	}
+	return dto
+}
+
+// mapLayerFeatureToDTO converts a LayerFeature model to a LayerFeatureData DTO.
+func mapLayerFeatureToDTO(feature *models.LayerFeature) LayerFeatureData {
+	dto := LayerFeatureData{
+		ID:         feature.ID,
+		Properties: feature.Properties,
+		Geometry:   feature.Geometry,
+		CountyName: feature.CountyName,
+	}
+	if dto.Properties == nil {
+		dto.Properties = map[string]any{}
+	}
+	return dto
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MockLayerService is a mock implementation of LayerService for testing
+type MockLayerService struct {
+	mock.Mock
+}
+
+func (m *MockLayerService) ListLayers(ctx context.Context) ([]models.Layer, error) {
+	args := m.Called(ctx)
+	layers, _ := args.Get(0).([]models.Layer)
+	return layers, args.Error(1)
+}
+
+func (m *MockLayerService) GetFeaturesAtPoint(ctx context.Context, name string, point models.LatLng, radiusMeters float64) (*services.LayerFeatures, error) {
+	args := m.Called(ctx, name, point, radiusMeters)
+	result, _ := args.Get(0).(*services.LayerFeatures)
+	return result, args.Error(1)
+}
+
+func (m *MockLayerService) GetFeaturesInBBox(ctx context.Context, name string, bbox models.BoundingBox, limit int) (*services.LayerFeatures, error) {
+	args := m.Called(ctx, name, bbox, limit)
+	result, _ := args.Get(0).(*services.LayerFeatures)
+	return result, args.Error(1)
+}
+
+func (m *MockLayerService) GetOverlayAtPoint(ctx context.Context, overlay repository.Overlay, point models.LatLng, filter map[string]any) ([]models.LayerFeature, error) {
+	args := m.Called(ctx, overlay, point, filter)
+	features, _ := args.Get(0).([]models.LayerFeature)
+	return features, args.Error(1)
+}
+
+func (m *MockLayerService) GetParcelOverlays(ctx context.Context, overlay repository.Overlay, ids []uint) (map[uint][]repository.ParcelOverlay, error) {
+	args := m.Called(ctx, overlay, ids)
+	matches, _ := args.Get(0).(map[uint][]repository.ParcelOverlay)
+	return matches, args.Error(1)
+}
+
+// setupLayerTestRouter creates a test router with layer handlers.
+func setupLayerTestRouter(handler *LayerHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+
+	router.GET("/api/v1/layers", handler.List)
+	router.GET("/api/v1/layers/:name/at-point", handler.AtPoint)
+	router.GET("/api/v1/layers/:name/in-bbox", handler.InBBox)
+
+	return router
+}
+
+func TestLayerHandler_List(t *testing.T) {
+	mockService := new(MockLayerService)
+	router := setupLayerTestRouter(NewLayerHandler(mockService))
+
+	description := "National Wetlands Inventory"
+	mockService.On("ListLayers", mock.Anything).Return([]models.Layer{
+		{ID: 4, Name: "easements", Title: "Easements", FeatureCount: 120},
+		{ID: 3, Name: "wetlands", Title: "Wetlands", Description: &description, FeatureCount: 2400},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/layers", nil))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response LayerListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Count)
+	assert.Equal(t, "easements", response.Layers[0].Name)
+	assert.Equal(t, description, response.Layers[1].Description)
+	assert.Equal(t, int64(2400), response.Layers[1].FeatureCount)
+}
+
+func TestLayerHandler_AtPoint(t *testing.T) {
+	mockService := new(MockLayerService)
+	router := setupLayerTestRouter(NewLayerHandler(mockService))
+
+	mockService.On("GetFeaturesAtPoint", mock.MatchedBy(func(ctx context.Context) bool {
+		return repository.CountyFromContext(ctx) == "montgomery-tx"
+	}), "wetlands", models.LatLng{Lat: 30.3477, Lng: -95.45}, 25.0).Return(&services.LayerFeatures{
+		Layer: &models.Layer{ID: 3, Name: "wetlands"},
+		Features: []models.LayerFeature{{
+			ID:         11,
+			Properties: map[string]any{"WETLAND_TYPE": "Freshwater Pond"},
+			Geometry:   json.RawMessage(`{"type":"Point","coordinates":[-95.45,30.3477]}`),
+			CountyName: "Montgomery",
+		}},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/layers/wetlands/at-point?lat=30.3477&lng=-95.45&radius=25&county=montgomery-tx", nil))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.JSONEq(t, `{
+		"layer": "wetlands",
+		"features": [{
+			"id": 11,
+			"properties": {"WETLAND_TYPE": "Freshwater Pond"},
+			"geometry": {"type": "Point", "coordinates": [-95.45, 30.3477]},
+			"county_name": "Montgomery"
+		}],
+		"count": 1
+	}`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestLayerHandler_InBBox(t *testing.T) {
+	mockService := new(MockLayerService)
+	router := setupLayerTestRouter(NewLayerHandler(mockService))
+
+	bbox := models.BoundingBox{MinLng: -95.6, MinLat: 30.2, MaxLng: -95.4, MaxLat: 30.4}
+	mockService.On("GetFeaturesInBBox", mock.Anything, "easements", bbox, 1).Return(&services.LayerFeatures{
+		Layer:     &models.Layer{ID: 4, Name: "easements"},
+		Features:  []models.LayerFeature{{ID: 7, Geometry: json.RawMessage(`{"type":"Point","coordinates":[-95.5,30.3]}`)}},
+		Truncated: true,
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/layers/easements/in-bbox?bbox=-95.6,30.2,-95.4,30.4&limit=1", nil))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response LayerFeaturesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Count)
+	assert.True(t, response.Truncated)
+	assert.Equal(t, map[string]any{}, response.Features[0].Properties)
+	mockService.AssertExpectations(t)
+}
+
+func TestLayerHandler_Errors(t *testing.T) {
+	testCases := []struct {
+		err    error
+		name   string
+		url    string
+		status int
+	}{
+		{nil, "missing lng", "/api/v1/layers/wetlands/at-point?lat=30.3477", http.StatusBadRequest},
+		{nil, "radius too large", "/api/v1/layers/wetlands/at-point?lat=30.3477&lng=-95.45&radius=1001", http.StatusBadRequest},
+		{nil, "missing bbox", "/api/v1/layers/wetlands/in-bbox", http.StatusBadRequest},
+		{nil, "malformed bbox", "/api/v1/layers/wetlands/in-bbox?bbox=-95.6,30.2,-95.4", http.StatusBadRequest},
+		{nil, "limit too large", "/api/v1/layers/wetlands/in-bbox?bbox=-95.6,30.2,-95.4,30.4&limit=5001", http.StatusBadRequest},
+		{services.ErrLayerNotFound, "unknown layer", "/api/v1/layers/utilities/at-point?lat=30.3477&lng=-95.45", http.StatusNotFound},
+		{services.ErrInvalidLayerQuery, "inverted bbox", "/api/v1/layers/wetlands/in-bbox?bbox=-95.4,30.2,-95.6,30.4", http.StatusBadRequest},
+		{errors.New("connection refused"), "database error", "/api/v1/layers/wetlands/in-bbox?bbox=-95.6,30.2,-95.4,30.4", http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockLayerService)
+			router := setupLayerTestRouter(NewLayerHandler(mockService))
+			if tc.err != nil {
+				mockService.On("GetFeaturesAtPoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, tc.err)
+				mockService.On("GetFeaturesInBBox", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, tc.err)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.url, nil))
+
+			assert.Equal(t, tc.status, w.Code, w.Body.String())
+		})
+	}
+}
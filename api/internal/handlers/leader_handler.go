@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/leader"
+)
+
+// Leadership reports the leader election state of this instance.
+// *leader.Elector implements it.
+type Leadership interface {
+	Instance() string
+	Status(ctx context.Context) ([]leader.Status, error)
+}
+
+// LeaderHandler reports which instance holds each singleton role.
+type LeaderHandler struct {
+	leadership Leadership
+}
+
+// NewLeaderHandler creates a new LeaderHandler instance.
+func NewLeaderHandler(leadership Leadership) *LeaderHandler {
+	return &LeaderHandler{
+		leadership: leadership,
+	}
+}
+
+// LeadersResponse represents the response for the leaders endpoint.
+type LeadersResponse struct {
+	Instance string          `json:"instance"`
+	Roles    []leader.Status `json:"roles"`
+}
+
+// Leaders handles GET /health/leaders endpoint.
+// It lists the roles this instance campaigns for, whether it leads each one and
+// which instance does. Instances not running scheduled jobs list no roles.
+func (h *LeaderHandler) Leaders(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), HealthCheckTimeout)
+	defer cancel()
+
+	roles, err := h.leadership.Status(ctx)
+	if err != nil {
+		apierrors.InternalServerError(c, "Failed to read leader status", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, LeadersResponse{
+		Instance: h.leadership.Instance(),
+		Roles:    roles,
+	})
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/leader"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+)
+
+// MockLeadership is a mock implementation of Leadership for testing
+type MockLeadership struct {
+	mock.Mock
+}
+
+func (m *MockLeadership) Instance() string {
+	return m.Called().String(0)
+}
+
+func (m *MockLeadership) Status(ctx context.Context) ([]leader.Status, error) {
+	args := m.Called(ctx)
+	statuses, _ := args.Get(0).([]leader.Status)
+	return statuses, args.Error(1)
+}
+
+// setupLeaderTestRouter creates a test router with the leader handler.
+func setupLeaderTestRouter(handler *LeaderHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+
+	router.GET("/health/leaders", handler.Leaders)
+
+	return router
+}
+
+func TestLeaderHandler_Leaders(t *testing.T) {
+	mockLeadership := new(MockLeadership)
+	router := setupLeaderTestRouter(NewLeaderHandler(mockLeadership))
+
+	mockLeadership.On("Instance").Return("worker-1")
+	mockLeadership.On("Status", mock.Anything).Return([]leader.Status{
+		{Role: "cache-warming", Holder: "worker-2"},
+		{Role: "stats-snapshots", Holder: "worker-1", Leader: true},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/health/leaders", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response LeadersResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "worker-1", response.Instance)
+	require.Len(t, response.Roles, 2)
+	assert.Equal(t, "worker-2", response.Roles[0].Holder)
+	assert.True(t, response.Roles[1].Leader)
+}
+
+func TestLeaderHandler_Leaders_Error(t *testing.T) {
+	mockLeadership := new(MockLeadership)
+	router := setupLeaderTestRouter(NewLeaderHandler(mockLeadership))
+
+	mockLeadership.On("Status", mock.Anything).Return(nil, errors.New("database unavailable"))
+
+	req := httptest.NewRequest(http.MethodGet, "/health/leaders", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+)
+
+// fieldLinks is the response member holding a parcel's links.
+const fieldLinks = "links"
+
+// ParcelLinks are the path templates of the routes parcel responses link to, by
+// relation, e.g. "documents": "/api/v1/parcels/:id/documents". The route
+// registry builds them from the parcel routes declared with a link relation, so
+// a parcel links exactly the related routes this instance serves.
+type ParcelLinks map[string]string
+
+// For returns the links of the parcel with the given ID, nil without templates.
+func (l ParcelLinks) For(id uint) map[string]string {
+	if len(l) == 0 {
+		return nil
+	}
+
+	param := strconv.FormatUint(uint64(id), 10)
+	links := make(map[string]string, len(l))
+	for rel, path := range l {
+		segments := strings.Split(path, "/")
+		for i, segment := range segments {
+			if segment == ":id" {
+				segments[i] = param
+			}
+		}
+		links[rel] = strings.Join(segments, "/")
+	}
+	return links
+}
@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParcelLinks_For(t *testing.T) {
+	links := ParcelLinks{
+		"self":      "/api/v1/parcels/:id",
+		"documents": "/api/v1/parcels/:id/documents",
+	}
+
+	assert.Equal(t, map[string]string{
+		"self":      "/api/v1/parcels/42",
+		"documents": "/api/v1/parcels/42/documents",
+	}, links.For(42))
+	assert.Nil(t, ParcelLinks(nil).For(42), "no templates, no links")
+}
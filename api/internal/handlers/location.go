@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// resolvePoint returns the query point of a point request: lat/lng, or the location named
+// by plus_code or w3w when one of those is set. It writes a 400 response (ok=false) for
+// conflicting or unresolvable references and a 500 when the what3words lookup fails.
+func (h *ParcelHandler) resolvePoint(c *gin.Context, lat, lng float64, plusCode, what3words string) (models.LatLng, bool) {
+	if plusCode == "" && what3words == "" {
+		return models.LatLng{Lat: lat, Lng: lng}, true
+	}
+
+	if c.Query("lat") != "" || c.Query("lng") != "" {
+		apierrors.BadRequest(c, "lat/lng cannot be combined with plus_code or w3w", nil)
+		return models.LatLng{}, false
+	}
+
+	// An unencoded "+" in a query string decodes to a space
+	plusCode = strings.ReplaceAll(strings.TrimSpace(plusCode), " ", "+")
+
+	point, err := h.locations.Resolve(c.Request.Context(), plusCode, what3words)
+	if err != nil {
+		apierrors.Respond(c, "Failed to resolve location", err)
+		return models.LatLng{}, false
+	}
+
+	return point, true
+}
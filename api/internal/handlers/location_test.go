@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+func TestResolvePoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := NewParcelHandler(nil, services.NewLocationService(nil, logger.New("test")))
+
+	newContext := func(url string) (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, url, nil)
+		return c, w
+	}
+
+	t.Run("lat/lng passes through", func(t *testing.T) {
+		c, _ := newContext("/?lat=30.3477&lng=-95.4502")
+
+		point, ok := handler.resolvePoint(c, 30.3477, -95.4502, "", "")
+
+		require.True(t, ok)
+		assert.Equal(t, models.LatLng{Lat: 30.3477, Lng: -95.4502}, point)
+	})
+
+	t.Run("plus code with unencoded separator", func(t *testing.T) {
+		c, _ := newContext("/?plus_code=8FVC9G8F+6X")
+
+		point, ok := handler.resolvePoint(c, 0, 0, c.Query("plus_code"), "")
+
+		require.True(t, ok)
+		assert.InDelta(t, 47.3655625, point.Lat, 1e-9)
+		assert.InDelta(t, 8.5249375, point.Lng, 1e-9)
+	})
+
+	t.Run("rejected references", func(t *testing.T) {
+		for _, tt := range []struct {
+			name       string
+			url        string
+			plusCode   string
+			what3words string
+		}{
+			{name: "combined with lat/lng", url: "/?lat=30.3477&plus_code=8FVC9G8F%2B6X", plusCode: "8FVC9G8F+6X"},
+			{name: "invalid plus code", url: "/?plus_code=nope", plusCode: "nope"},
+			{name: "what3words disabled", url: "/?w3w=filled.count.soap", what3words: "filled.count.soap"},
+		} {
+			c, w := newContext(tt.url)
+
+			_, ok := handler.resolvePoint(c, 0, 0, tt.plusCode, tt.what3words)
+
+			assert.False(t, ok, tt.name)
+			assert.Equal(t, http.StatusBadRequest, w.Code, tt.name)
+
+			var response apierrors.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, apierrors.ErrBadRequest, response.Error.Code, tt.name)
+		}
+	})
+}
+
+func TestPointRequestBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, tt := range []struct {
+		url     string
+		wantErr bool
+	}{
+		{url: "/?lat=30.3477&lng=-95.4502"},
+		{url: "/?plus_code=8FVC9G8F%2B6X"},
+		{url: "/?w3w=filled.count.soap"},
+		{url: "/?lng=-95.4502", wantErr: true},
+		{url: "/?fields=id", wantErr: true},
+	} {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+		var atPoint AtPointRequest
+		var nearby NearbyRequest
+		assert.Equal(t, tt.wantErr, c.ShouldBindQuery(&atPoint) != nil, "at-point %s", tt.url)
+		assert.Equal(t, tt.wantErr, c.ShouldBindQuery(&nearby) != nil, "nearby %s", tt.url)
+	}
+}
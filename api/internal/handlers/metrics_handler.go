@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stwalsh4118/atlas/api/internal/metrics"
+)
+
+// MetricsHandler serves the instance's metrics to Prometheus.
+type MetricsHandler struct {
+	registry *metrics.Registry
+}
+
+// NewMetricsHandler creates a new MetricsHandler instance.
+func NewMetricsHandler(registry *metrics.Registry) *MetricsHandler {
+	return &MetricsHandler{
+		registry: registry,
+	}
+}
+
+// Metrics handles GET /metrics endpoint.
+// It writes every registered metric in the Prometheus text exposition format.
+func (h *MetricsHandler) Metrics(c *gin.Context) {
+	h.registry.ServeHTTP(c.Writer, c.Request)
+}
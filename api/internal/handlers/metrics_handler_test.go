@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stwalsh4118/atlas/api/internal/metrics"
+)
+
+func TestMetricsHandler_Metrics(t *testing.T) {
+	registry := metrics.NewRegistry()
+	registry.Counter("atlas_test_total", "Test counter.", "route").Inc("health")
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/metrics", NewMetricsHandler(registry).Metrics)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, metrics.ContentType, w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `atlas_test_total{route="health"} 1`)
+}
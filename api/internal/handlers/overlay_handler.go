@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// OverlayHandler handles the zoning, flood zone and jurisdiction HTTP
+// requests, answered from their layers (see models.OverlayLayers).
+type OverlayHandler struct {
+	service services.LayerService
+}
+
+// NewOverlayHandler creates a new OverlayHandler instance.
+func NewOverlayHandler(service services.LayerService) *OverlayHandler {
+	return &OverlayHandler{
+		service: service,
+	}
+}
+
+// ZoningAtPointRequest represents the query parameters for the zoning at-point endpoint.
+type ZoningAtPointRequest struct {
+	County string  `form:"county" binding:"omitempty,max=100"`
+	Lat    float64 `form:"lat" binding:"required,min=-90,max=90"`
+	Lng    float64 `form:"lng" binding:"required,min=-180,max=180"`
+}
+
+// ZoningAtPointResponse represents the response for the zoning at-point
+// endpoint. Districts is empty where no zoning is loaded or the point is unzoned.
+type ZoningAtPointResponse struct {
+	Districts []ZoningDistrictData `json:"districts"`
+	Count     int                  `json:"count"`
+}
+
+// ZoningDistrictData is a zoning district containing the queried point.
+type ZoningDistrictData struct {
+	Code       string `json:"code"`
+	Name       string `json:"name,omitempty"`
+	CountyName string `json:"county_name"`
+	ID         uint   `json:"id"`
+}
+
+// FloodZonesAtPointRequest represents the query parameters for the flood zones at-point endpoint.
+type FloodZonesAtPointRequest struct {
+	County string  `form:"county" binding:"omitempty,max=100"`
+	Lat    float64 `form:"lat" binding:"required,min=-90,max=90"`
+	Lng    float64 `form:"lng" binding:"required,min=-180,max=180"`
+}
+
+// FloodZonesAtPointResponse represents the response for the flood zones
+// at-point endpoint. Zones is empty where no flood zones are loaded or FEMA has
+// not mapped the point; SFHA is true if any zone is a Special Flood Hazard Area.
+type FloodZonesAtPointResponse struct {
+	Zones []FloodHazardAreaData `json:"zones"`
+	Count int                   `json:"count"`
+	SFHA  bool                  `json:"sfha"`
+}
+
+// FloodHazardAreaData is a flood hazard area containing the queried point.
+type FloodHazardAreaData struct {
+	Zone       string `json:"zone"`
+	Subtype    string `json:"subtype,omitempty"`
+	CountyName string `json:"county_name"`
+	ID         uint   `json:"id"`
+	SFHA       bool   `json:"sfha"`
+}
+
+// JurisdictionsAtPointRequest represents the query parameters for the jurisdictions at-point endpoint.
+type JurisdictionsAtPointRequest struct {
+	County string  `form:"county" binding:"omitempty,max=100"`
+	Kind   string  `form:"kind" binding:"omitempty,oneof=school_district taxing_unit"`
+	Lat    float64 `form:"lat" binding:"required,min=-90,max=90"`
+	Lng    float64 `form:"lng" binding:"required,min=-180,max=180"`
+}
+
+// JurisdictionsAtPointResponse represents the response for the jurisdictions
+// at-point endpoint. Jurisdictions is empty where none are loaded or the point
+// lies outside them.
+type JurisdictionsAtPointResponse struct {
+	Jurisdictions []JurisdictionBoundaryData `json:"jurisdictions"`
+	Count         int                        `json:"count"`
+}
+
+// JurisdictionBoundaryData is a jurisdiction containing the queried point.
+type JurisdictionBoundaryData struct {
+	Kind       string `json:"kind"`
+	Code       string `json:"code,omitempty"`
+	Name       string `json:"name"`
+	CountyName string `json:"county_name"`
+	ID         uint   `json:"id"`
+}
+
+// ZoningAtPoint handles GET /api/v1/zoning/at-point endpoint.
+// It returns the zoning districts containing the given lat/lng point, base and
+// overlay districts alike, ordered by code.
+func (h *OverlayHandler) ZoningAtPoint(c *gin.Context) {
+	var req ZoningAtPointRequest
+	if !bindOverlayQuery(c, &req) {
+		return
+	}
+
+	districts, ok := h.atPoint(c, repository.ZoningOverlay, req.County, models.LatLng{Lat: req.Lat, Lng: req.Lng}, nil)
+	if !ok {
+		return
+	}
+
+	response := ZoningAtPointResponse{
+		Districts: make([]ZoningDistrictData, 0, len(districts)),
+		Count:     len(districts),
+	}
+	for i := range districts {
+		response.Districts = append(response.Districts, mapZoningDistrictToDTO(&districts[i]))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// FloodZonesAtPoint handles GET /api/v1/flood-zones/at-point endpoint.
+// It returns the FEMA flood zones containing the given lat/lng point, ordered by zone.
+func (h *OverlayHandler) FloodZonesAtPoint(c *gin.Context) {
+	var req FloodZonesAtPointRequest
+	if !bindOverlayQuery(c, &req) {
+		return
+	}
+
+	zones, ok := h.atPoint(c, repository.FloodZoneOverlay, req.County, models.LatLng{Lat: req.Lat, Lng: req.Lng}, nil)
+	if !ok {
+		return
+	}
+
+	response := FloodZonesAtPointResponse{
+		Zones: make([]FloodHazardAreaData, 0, len(zones)),
+		Count: len(zones),
+	}
+	for i := range zones {
+		zone := mapFloodHazardAreaToDTO(&zones[i])
+		response.Zones = append(response.Zones, zone)
+		response.SFHA = response.SFHA || zone.SFHA
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// JurisdictionsAtPoint handles GET /api/v1/jurisdictions/at-point endpoint.
+// It returns the school districts and taxing units containing the given lat/lng
+// point, or those of one kind, ordered by kind and name.
+func (h *OverlayHandler) JurisdictionsAtPoint(c *gin.Context) {
+	var req JurisdictionsAtPointRequest
+	if !bindOverlayQuery(c, &req) {
+		return
+	}
+
+	var filter map[string]any
+	if req.Kind != "" {
+		filter = map[string]any{"kind": req.Kind}
+	}
+	jurisdictions, ok := h.atPoint(c, repository.JurisdictionOverlay, req.County, models.LatLng{Lat: req.Lat, Lng: req.Lng}, filter)
+	if !ok {
+		return
+	}
+
+	response := JurisdictionsAtPointResponse{
+		Jurisdictions: make([]JurisdictionBoundaryData, 0, len(jurisdictions)),
+		Count:         len(jurisdictions),
+	}
+	for i := range jurisdictions {
+		response.Jurisdictions = append(response.Jurisdictions, mapJurisdictionBoundaryToDTO(&jurisdictions[i]))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// bindOverlayQuery binds the query parameters of an at-point request. It
+// writes a 400 response and returns false when they are invalid.
+func bindOverlayQuery(c *gin.Context, req any) bool {
+	if err := c.ShouldBindQuery(req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return false
+		}
+		apierrors.BadRequest(c, "Invalid query parameters", nil)
+		return false
+	}
+	return true
+}
+
+// atPoint looks up the features of the overlay containing the point, limited
+// to county when it is not empty. It writes an error response and returns
+// false on failure.
+func (h *OverlayHandler) atPoint(c *gin.Context, overlay repository.Overlay, county string, point models.LatLng, filter map[string]any) ([]models.LayerFeature, bool) {
+	if log := middleware.GetLogger(c); log != nil {
+		log.Info("Processing overlay at-point request", map[string]interface{}{
+			"layer":  overlay.Layer,
+			"lat":    point.Lat,
+			"lng":    point.Lng,
+			"filter": filter,
+		})
+	}
+
+	ctx := repository.WithCounty(c.Request.Context(), county)
+	features, err := h.service.GetOverlayAtPoint(ctx, overlay, point, filter)
+	if err != nil {
+		queryFailed(c, "Failed to query "+overlay.Layer, err)
+		return nil, false
+	}
+
+	middleware.SetResultCount(c, len(features))
+	return features, true
+}
+
+// mapZoningDistrictToDTO converts a zoning layer feature to a ZoningDistrictData DTO.
+func mapZoningDistrictToDTO(feature *models.LayerFeature) ZoningDistrictData {
+	return ZoningDistrictData{
+		ID:         feature.ID,
+		Code:       stringProperty(feature, "code"),
+		Name:       stringProperty(feature, "name"),
+		CountyName: feature.CountyName,
+	}
+}
+
+// mapFloodHazardAreaToDTO converts a flood_zones layer feature to a FloodHazardAreaData DTO.
+func mapFloodHazardAreaToDTO(feature *models.LayerFeature) FloodHazardAreaData {
+	sfha, _ := feature.Properties["sfha"].(bool)
+	return FloodHazardAreaData{
+		ID:         feature.ID,
+		Zone:       stringProperty(feature, "zone"),
+		Subtype:    stringProperty(feature, "subtype"),
+		SFHA:       sfha,
+		CountyName: feature.CountyName,
+	}
+}
+
+// mapJurisdictionBoundaryToDTO converts a jurisdictions layer feature to a JurisdictionBoundaryData DTO.
+func mapJurisdictionBoundaryToDTO(feature *models.LayerFeature) JurisdictionBoundaryData {
+	return JurisdictionBoundaryData{
+		ID:         feature.ID,
+		Kind:       stringProperty(feature, "kind"),
+		Code:       stringProperty(feature, "code"),
+		Name:       stringProperty(feature, "name"),
+		CountyName: feature.CountyName,
+	}
+}
+
+// stringProperty returns the feature's text property key, empty when it is
+// missing; overlay loads store text properties as JSON strings.
+func stringProperty(feature *models.LayerFeature, key string) string {
+	value, _ := feature.Properties[key].(string)
+	return value
+}
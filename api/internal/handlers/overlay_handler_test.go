@@ -0,0 +1,319 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// setupOverlayTestRouter creates a test router with overlay handlers.
+func setupOverlayTestRouter(handler *OverlayHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+
+	router.GET("/api/v1/zoning/at-point", handler.ZoningAtPoint)
+	router.GET("/api/v1/flood-zones/at-point", handler.FloodZonesAtPoint)
+	router.GET("/api/v1/jurisdictions/at-point", handler.JurisdictionsAtPoint)
+
+	return router
+}
+
+// testOverlayPoint is the point of the at-point test requests.
+var testOverlayPoint = models.LatLng{Lat: 30.3477, Lng: -95.45}
+
+func TestOverlayHandler_ZoningAtPoint(t *testing.T) {
+	mockService := new(MockLayerService)
+	router := setupOverlayTestRouter(NewOverlayHandler(mockService))
+
+	mockService.On("GetOverlayAtPoint", mock.MatchedBy(func(ctx context.Context) bool {
+		return repository.CountyFromContext(ctx) == "montgomery-tx"
+	}), repository.ZoningOverlay, testOverlayPoint, map[string]any(nil)).Return([]models.LayerFeature{
+		{ID: 8, Properties: map[string]any{"code": "HD"}, CountyName: "Montgomery"},
+		{ID: 7, Properties: map[string]any{"code": "R-1", "name": "Single Family Residential"}, CountyName: "Montgomery"},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/zoning/at-point?lat=30.3477&lng=-95.45&county=montgomery-tx", nil))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response ZoningAtPointResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, ZoningAtPointResponse{
+		Districts: []ZoningDistrictData{
+			{ID: 8, Code: "HD", CountyName: "Montgomery"},
+			{ID: 7, Code: "R-1", Name: "Single Family Residential", CountyName: "Montgomery"},
+		},
+		Count: 2,
+	}, response)
+	mockService.AssertExpectations(t)
+}
+
+func TestOverlayHandler_FloodZonesAtPoint(t *testing.T) {
+	mockService := new(MockLayerService)
+	router := setupOverlayTestRouter(NewOverlayHandler(mockService))
+
+	mockService.On("GetOverlayAtPoint", mock.Anything, repository.FloodZoneOverlay, testOverlayPoint, map[string]any(nil)).Return([]models.LayerFeature{
+		{ID: 3, Properties: map[string]any{"zone": "AE", "subtype": "FLOODWAY", "sfha": true}, CountyName: "Montgomery"},
+		{ID: 4, Properties: map[string]any{"zone": "X", "sfha": false}, CountyName: "Montgomery"},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/flood-zones/at-point?lat=30.3477&lng=-95.45", nil))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response FloodZonesAtPointResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, FloodZonesAtPointResponse{
+		Zones: []FloodHazardAreaData{
+			{ID: 3, Zone: "AE", Subtype: "FLOODWAY", SFHA: true, CountyName: "Montgomery"},
+			{ID: 4, Zone: "X", CountyName: "Montgomery"},
+		},
+		Count: 2,
+		SFHA:  true,
+	}, response)
+	mockService.AssertExpectations(t)
+}
+
+func TestOverlayHandler_JurisdictionsAtPoint(t *testing.T) {
+	testCases := []struct {
+		filter   map[string]any
+		name     string
+		query    string
+		features []models.LayerFeature
+		expected []JurisdictionBoundaryData
+	}{
+		{
+			name:  "all kinds",
+			query: "",
+			features: []models.LayerFeature{
+				{ID: 4, Properties: map[string]any{"kind": "school_district", "code": "170902", "name": "Conroe ISD"}, CountyName: "Montgomery"},
+				{ID: 9, Properties: map[string]any{"kind": "taxing_unit", "name": "Montgomery County"}, CountyName: "Montgomery"},
+			},
+			expected: []JurisdictionBoundaryData{
+				{ID: 4, Kind: "school_district", Code: "170902", Name: "Conroe ISD", CountyName: "Montgomery"},
+				{ID: 9, Kind: "taxing_unit", Name: "Montgomery County", CountyName: "Montgomery"},
+			},
+		},
+		{
+			filter:   map[string]any{"kind": models.JurisdictionTaxingUnit},
+			name:     "one kind",
+			query:    "&kind=taxing_unit",
+			features: []models.LayerFeature{},
+			expected: []JurisdictionBoundaryData{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockLayerService)
+			router := setupOverlayTestRouter(NewOverlayHandler(mockService))
+			mockService.On("GetOverlayAtPoint", mock.Anything, repository.JurisdictionOverlay, testOverlayPoint, tc.filter).Return(tc.features, nil)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/jurisdictions/at-point?lat=30.3477&lng=-95.45"+tc.query, nil))
+
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			var response JurisdictionsAtPointResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, JurisdictionsAtPointResponse{Jurisdictions: tc.expected, Count: len(tc.expected)}, response)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOverlayHandler_AtPointEmpty(t *testing.T) {
+	testCases := []struct {
+		url      string
+		expected string
+	}{
+		{"/api/v1/zoning/at-point?lat=30.3477&lng=-95.45", `{"districts": [], "count": 0}`},
+		{"/api/v1/flood-zones/at-point?lat=30.3477&lng=-95.45", `{"zones": [], "count": 0, "sfha": false}`},
+		{"/api/v1/jurisdictions/at-point?lat=30.3477&lng=-95.45", `{"jurisdictions": [], "count": 0}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.url, func(t *testing.T) {
+			mockService := new(MockLayerService)
+			router := setupOverlayTestRouter(NewOverlayHandler(mockService))
+			mockService.On("GetOverlayAtPoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]models.LayerFeature{}, nil)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.url, nil))
+
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.JSONEq(t, tc.expected, w.Body.String())
+		})
+	}
+}
+
+func TestOverlayHandler_AtPointErrors(t *testing.T) {
+	testCases := []struct {
+		err    error
+		name   string
+		url    string
+		status int
+	}{
+		{nil, "zoning missing lng", "/api/v1/zoning/at-point?lat=30.3477", http.StatusBadRequest},
+		{nil, "zoning latitude out of range", "/api/v1/zoning/at-point?lat=91&lng=-95.45", http.StatusBadRequest},
+		{errors.New("connection refused"), "zoning database error", "/api/v1/zoning/at-point?lat=30.3477&lng=-95.45", http.StatusInternalServerError},
+		{nil, "flood zones missing lat", "/api/v1/flood-zones/at-point?lng=-95.45", http.StatusBadRequest},
+		{nil, "flood zones longitude out of range", "/api/v1/flood-zones/at-point?lat=30.3477&lng=181", http.StatusBadRequest},
+		{errors.New("connection refused"), "flood zones database error", "/api/v1/flood-zones/at-point?lat=30.3477&lng=-95.45", http.StatusInternalServerError},
+		{nil, "jurisdictions missing lat", "/api/v1/jurisdictions/at-point?lng=-95.45", http.StatusBadRequest},
+		{nil, "jurisdictions unknown kind", "/api/v1/jurisdictions/at-point?lat=30.3477&lng=-95.45&kind=city", http.StatusBadRequest},
+		{errors.New("connection refused"), "jurisdictions database error", "/api/v1/jurisdictions/at-point?lat=30.3477&lng=-95.45", http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockLayerService)
+			router := setupOverlayTestRouter(NewOverlayHandler(mockService))
+			if tc.err != nil {
+				mockService.On("GetOverlayAtPoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, tc.err)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.url, nil))
+
+			assert.Equal(t, tc.status, w.Code)
+		})
+	}
+}
+
+// newOverlayParcelTestRouter returns a parcel router whose at-point parcel is
+// parcel 42, with the overlay include values answered by overlays when it is
+// not nil.
+func newOverlayParcelTestRouter(overlays services.LayerService) http.Handler {
+	log := logger.New("test")
+	parcels := new(MockGraphQLParcelService)
+	parcels.On("GetParcelAtPoint", mock.Anything, mock.Anything).Return(&models.TaxParcel{ID: 42}, nil)
+
+	handler := NewParcelHandler(parcels, services.NewLocationService(nil, log))
+	if overlays != nil {
+		handler.SetOverlays(overlays)
+	}
+	return setupParcelTestRouter(handler, log)
+}
+
+func TestParcelHandler_AtPointIncludesZoning(t *testing.T) {
+	overlays := new(MockLayerService)
+	router := newOverlayParcelTestRouter(overlays)
+
+	overlays.On("GetParcelOverlays", mock.Anything, repository.ZoningOverlay, []uint{42}).Return(map[uint][]repository.ParcelOverlay{
+		42: {
+			{Feature: models.LayerFeature{ID: 7, Properties: map[string]any{"code": "R-1", "name": "Single Family Residential"}}, Coverage: 0.8},
+			{Feature: models.LayerFeature{ID: 9, Properties: map[string]any{"code": "C-2"}}, Coverage: 0.2},
+		},
+	}, nil)
+
+	response := getAtPoint(t, router, "&include=zoning")
+
+	assert.Equal(t, []ZoningData{
+		{ID: 7, Code: "R-1", Name: "Single Family Residential", Coverage: 0.8},
+		{ID: 9, Code: "C-2", Coverage: 0.2},
+	}, response.Parcel.Zoning)
+	assert.Nil(t, response.Parcel.FloodZones)
+	overlays.AssertExpectations(t)
+}
+
+func TestParcelHandler_AtPointWithoutOverlayInclude(t *testing.T) {
+	overlays := new(MockLayerService)
+	router := newOverlayParcelTestRouter(overlays)
+
+	response := getAtPoint(t, router, "")
+
+	assert.Nil(t, response.Parcel.Zoning)
+	assert.Nil(t, response.Parcel.FloodZones)
+	assert.Nil(t, response.Parcel.Jurisdictions)
+	overlays.AssertNotCalled(t, "GetParcelOverlays", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestParcelHandler_AtPointIncludesOverlays(t *testing.T) {
+	overlays := new(MockLayerService)
+	router := newOverlayParcelTestRouter(overlays)
+
+	overlays.On("GetParcelOverlays", mock.Anything, repository.ZoningOverlay, []uint{42}).Return(map[uint][]repository.ParcelOverlay{
+		42: {{Feature: models.LayerFeature{ID: 7, Properties: map[string]any{"code": "R-1"}}, Coverage: 1}},
+	}, nil)
+	overlays.On("GetParcelOverlays", mock.Anything, repository.FloodZoneOverlay, []uint{42}).Return(map[uint][]repository.ParcelOverlay{
+		42: {
+			{Feature: models.LayerFeature{Properties: map[string]any{"zone": "X", "subtype": "0.2 PCT ANNUAL CHANCE FLOOD HAZARD", "sfha": false}}, Coverage: 0.66666},
+			{Feature: models.LayerFeature{Properties: map[string]any{"zone": "AE", "sfha": true}}, Coverage: 0.33334},
+		},
+	}, nil)
+	overlays.On("GetParcelOverlays", mock.Anything, repository.JurisdictionOverlay, []uint{42}).Return(map[uint][]repository.ParcelOverlay{
+		42: {
+			{Feature: models.LayerFeature{ID: 4, Properties: map[string]any{"kind": "school_district", "code": "170902", "name": "Conroe ISD"}}},
+			{Feature: models.LayerFeature{ID: 9, Properties: map[string]any{"kind": "taxing_unit", "name": "Montgomery County"}}},
+		},
+	}, nil)
+
+	response := getAtPoint(t, router, "&include=zoning,flood_zone,jurisdictions")
+
+	assert.Equal(t, []ZoningData{{ID: 7, Code: "R-1", Coverage: 1}}, response.Parcel.Zoning)
+	assert.Equal(t, []FloodZoneData{
+		{Zone: "X", Subtype: "0.2 PCT ANNUAL CHANCE FLOOD HAZARD", CoveragePercent: 66.7},
+		{Zone: "AE", SFHA: true, CoveragePercent: 33.3},
+	}, response.Parcel.FloodZones)
+	assert.Equal(t, []JurisdictionData{
+		{ID: 4, Kind: "school_district", Code: "170902", Name: "Conroe ISD"},
+		{ID: 9, Kind: "taxing_unit", Name: "Montgomery County"},
+	}, response.Parcel.Jurisdictions)
+	overlays.AssertExpectations(t)
+}
+
+func TestParcelHandler_InvalidInclude(t *testing.T) {
+	testCases := []struct {
+		overlays services.LayerService
+		name     string
+		query    string
+	}{
+		{new(MockLayerService), "unknown value", "&include=zoning,schools"},
+		{new(MockLayerService), "empty value", "&include=zoning,"},
+		{nil, "zoning disabled", "&include=zoning"},
+		{nil, "flood zones disabled", "&include=flood_zone"},
+		{nil, "jurisdictions disabled", "&include=jurisdictions"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newOverlayParcelTestRouter(tc.overlays)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/parcels/at-point?lat=30.3477&lng=-95.45"+tc.query, nil))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		})
+	}
+}
+
+func TestParcelHandler_OverlayIncludeFailure(t *testing.T) {
+	for _, include := range []string{IncludeZoning, IncludeFloodZone, IncludeJurisdictions} {
+		t.Run(include, func(t *testing.T) {
+			overlays := new(MockLayerService)
+			router := newOverlayParcelTestRouter(overlays)
+			overlays.On("GetParcelOverlays", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/parcels/at-point?lat=30.3477&lng=-95.45&include="+include, nil))
+
+			assert.Equal(t, http.StatusInternalServerError, w.Code)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// OwnerHandler handles owner entity HTTP requests.
+type OwnerHandler struct {
+	service services.OwnerService
+}
+
+// NewOwnerHandler creates a new OwnerHandler instance.
+func NewOwnerHandler(service services.OwnerService) *OwnerHandler {
+	return &OwnerHandler{
+		service: service,
+	}
+}
+
+// OwnerParcelsRequest represents the query parameters for the owner parcels endpoint.
+type OwnerParcelsRequest struct {
+	Fields string `form:"fields"`
+	County string `form:"county" binding:"omitempty,max=100"`
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=1000"` // default: 100
+}
+
+// OwnerParcelsResponse represents the response for the owner parcels endpoint.
+// NextCursor is omitted on the last page; TotalCount covers all pages.
+type OwnerParcelsResponse struct {
+	Owner      OwnerData     `json:"owner"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+	Parcels    []*ParcelData `json:"parcels"`
+	Count      int           `json:"count"`
+	TotalCount int           `json:"total_count"`
+}
+
+// OwnerData is an owner entity with the spellings of its name found on parcels.
+type OwnerData struct {
+	Name  string   `json:"name"`
+	Names []string `json:"names"`
+	ID    uint     `json:"id"`
+}
+
+// Parcels handles GET /api/v1/owners/:id/parcels endpoint.
+// It returns a page of the parcels held by the owner under any spelling of its
+// name, across counties, ordered by ID.
+func (h *OwnerHandler) Parcels(c *gin.Context) {
+	log := middleware.GetLogger(c)
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		apierrors.BadRequest(c, "owner id must be a positive integer", nil)
+		return
+	}
+
+	var req OwnerParcelsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		apierrors.BadRequest(c, "Invalid query parameters", nil)
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = services.DefaultOwnerParcelsLimit
+	}
+
+	ctx, fields, ok := parseFieldSelection(c, req.Fields, ParcelData{})
+	if !ok {
+		return
+	}
+	ctx = repository.WithCounty(ctx, req.County)
+
+	if log != nil {
+		log.Info("Processing owner parcels request", map[string]interface{}{
+			"owner_id": id,
+			"limit":    req.Limit,
+		})
+	}
+
+	page, err := h.service.GetOwnerParcels(ctx, uint(id), req.Limit, req.Cursor)
+	if err != nil {
+		queryFailed(c, "Failed to retrieve owner parcels", err)
+		return
+	}
+
+	middleware.SetResultCount(c, len(page.Parcels))
+
+	response := OwnerParcelsResponse{
+		Owner: OwnerData{
+			ID:    page.Owner.ID,
+			Name:  page.Owner.Name,
+			Names: page.Owner.Names,
+		},
+		NextCursor: page.NextCursor,
+		Parcels:    make([]*ParcelData, 0, len(page.Parcels)),
+		Count:      len(page.Parcels),
+		TotalCount: page.TotalCount,
+	}
+	if response.Owner.Names == nil {
+		response.Owner.Names = []string{}
+	}
+	for i := range page.Parcels {
+		response.Parcels = append(response.Parcels, mapTaxParcelToDTO(&page.Parcels[i]))
+	}
+
+	renderSelectedJSON(c, response, fields, "parcels")
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/pagination"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MockOwnerService is a mock implementation of OwnerService for testing
+type MockOwnerService struct {
+	mock.Mock
+}
+
+func (m *MockOwnerService) GetOwnerParcels(ctx context.Context, ownerID uint, limit int, cursor string) (*services.OwnerParcelsPage, error) {
+	args := m.Called(ctx, ownerID, limit, cursor)
+	page, _ := args.Get(0).(*services.OwnerParcelsPage)
+	return page, args.Error(1)
+}
+
+// setupOwnerTestRouter creates a test router with owner handlers.
+func setupOwnerTestRouter(handler *OwnerHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+
+	router.GET("/api/v1/owners/:id/parcels", handler.Parcels)
+
+	return router
+}
+
+func TestOwnerHandler_Parcels(t *testing.T) {
+	mockService := new(MockOwnerService)
+	router := setupOwnerTestRouter(NewOwnerHandler(mockService))
+
+	ownerID := uint(7)
+	spellings := []string{"LONE STAR HOLDINGS L L C", "LONE STAR HOLDINGS LLC"}
+	mockService.On("GetOwnerParcels", mock.Anything, uint(7), 1, "").Return(&services.OwnerParcelsPage{
+		Owner:      &models.Owner{ID: 7, Name: "LONE STAR HOLDINGS LLC", Names: spellings},
+		Parcels:    []models.TaxParcel{{ID: 4, OwnerName: &spellings[0], OwnerID: &ownerID, CountyName: "Montgomery"}},
+		NextCursor: pagination.Cursor{ID: 4}.Encode(),
+		TotalCount: 2,
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/owners/7/parcels?limit=1&fields=id,owner_name,owner_id", nil))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, map[string]interface{}{
+		"id":    float64(7),
+		"name":  "LONE STAR HOLDINGS LLC",
+		"names": []interface{}{"LONE STAR HOLDINGS L L C", "LONE STAR HOLDINGS LLC"},
+	}, response["owner"])
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"id":         float64(4),
+		"owner_name": "LONE STAR HOLDINGS L L C",
+		"owner_id":   float64(7),
+	}}, response["parcels"])
+	assert.Equal(t, float64(1), response["count"])
+	assert.Equal(t, float64(2), response["total_count"])
+	assert.NotEmpty(t, response["next_cursor"])
+	mockService.AssertExpectations(t)
+}
+
+func TestOwnerHandler_ParcelsErrors(t *testing.T) {
+	testCases := []struct {
+		err    error
+		name   string
+		url    string
+		status int
+	}{
+		{nil, "invalid id", "/api/v1/owners/abc/parcels", http.StatusBadRequest},
+		{nil, "limit too large", "/api/v1/owners/7/parcels?limit=1001", http.StatusBadRequest},
+		{nil, "unknown field", "/api/v1/owners/7/parcels?fields=nope", http.StatusBadRequest},
+		{pagination.ErrInvalidCursor, "invalid cursor", "/api/v1/owners/7/parcels?cursor=x", http.StatusBadRequest},
+		{fmt.Errorf("%w: id 7", services.ErrOwnerNotFound), "owner not found", "/api/v1/owners/7/parcels", http.StatusNotFound},
+		{errors.New("connection refused"), "database error", "/api/v1/owners/7/parcels", http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockOwnerService)
+			router := setupOwnerTestRouter(NewOwnerHandler(mockService))
+			if tc.err != nil {
+				mockService.On("GetOwnerParcels", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, tc.err)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.url, nil))
+
+			assert.Equal(t, tc.status, w.Code)
+		})
+	}
+}
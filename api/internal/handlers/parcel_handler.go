@@ -1,11 +1,22 @@
 package handlers
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/stwalsh4118/atlas/api/internal/domain"
 	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
 	"github.com/stwalsh4118/atlas/api/internal/middleware"
 	"github.com/stwalsh4118/atlas/api/internal/models"
@@ -13,76 +24,1234 @@ import (
 	"github.com/stwalsh4118/atlas/api/internal/services"
 )
 
+// MaxAreaBodyBytes caps the GeoJSON body accepted by the intersects and along-route endpoints.
+// It comfortably fits services.MaxAreaVertices or services.MaxRouteVertices positions.
+const MaxAreaBodyBytes = 1 << 20
+
+// IdentifyCacheControl is the Cache-Control policy for identify responses.
+// Hover tooltips re-request the same parcel repeatedly as the cursor moves, and
+// the summary fields change rarely, so clients and CDNs may cache them longer
+// than the full at-point response.
+const IdentifyCacheControl = "public, max-age=300"
+
+// Pagination headers carry the page metadata for GeoJSON responses, whose
+// FeatureCollection body has no place for it.
+const (
+	NextCursorHeader = "X-Next-Cursor"
+	TotalCountHeader = "X-Total-Count"
+)
+
+// Include parameter values, comma-separated. Each adds data to the parcels of
+// a response that costs an extra query, so it is only looked up on request.
+const (
+	// IncludeAmenities adds each parcel's nearest amenities
+	IncludeAmenities = "amenities"
+	// IncludeZoning adds the zoning districts covering each parcel
+	IncludeZoning = "zoning"
+	// IncludeFloodZone adds the FEMA flood zones covering each parcel
+	IncludeFloodZone = "flood_zone"
+	// IncludeJurisdictions adds the school district and taxing units of each parcel
+	IncludeJurisdictions = "jurisdictions"
+)
+
+// includeValues lists the include parameter values in the order errors name them.
+var includeValues = []string{IncludeAmenities, IncludeZoning, IncludeFloodZone, IncludeJurisdictions}
+
+// includes is the set of include parameter values of a request.
+type includes map[string]bool
+
 // ParcelHandler handles parcel-related HTTP requests.
 type ParcelHandler struct {
-	service services.ParcelService
+	service   services.ParcelService
+	locations services.LocationService
+	links     ParcelLinks
+	// results runs intersects queries in async mode; nil without the job manager
+	results services.ResultService
+	// history answers as_of queries from parcel versions; nil disables them
+	history services.HistoryService
+	// geocoding fills the geocoded address of at-point parcels without a situs
+	// address; nil disables it
+	geocoding services.GeocodingService
+	// overlays answers include=zoning, flood_zone and jurisdictions; nil disables them
+	overlays services.LayerService
+}
+
+// NewParcelHandler creates a new ParcelHandler instance.
+// locations resolves the plus_code and w3w parameters of point queries.
+func NewParcelHandler(service services.ParcelService, locations services.LocationService) *ParcelHandler {
+	return &ParcelHandler{
+		service:   service,
+		locations: locations,
+	}
+}
+
+// SetLinks sets the related routes linked from the parcels of JSON responses.
+// The routes are declared after the handlers exist, so the server sets them once
+// its route registry is complete; without links, parcels have none.
+func (h *ParcelHandler) SetLinks(links ParcelLinks) {
+	h.links = links
+}
+
+// SetResults enables async mode, in which intersects queries run as jobs of results.
+func (h *ParcelHandler) SetResults(results services.ResultService) {
+	h.results = results
+}
+
+// SetHistory enables the as_of parameter of the get and at-point endpoints,
+// answered by history from the parcel versions.
+func (h *ParcelHandler) SetHistory(history services.HistoryService) {
+	h.history = history
+}
+
+// SetGeocoding enables the geocoded_address of at-point responses, looked up
+// with geocoding for parcels whose situs address is empty.
+func (h *ParcelHandler) SetGeocoding(geocoding services.GeocodingService) {
+	h.geocoding = geocoding
+}
+
+// SetOverlays enables include=zoning, include=flood_zone and
+// include=jurisdictions, answered by overlays from the overlay layers.
+func (h *ParcelHandler) SetOverlays(overlays services.LayerService) {
+	h.overlays = overlays
+}
+
+// GetRequest represents the query parameters for the parcel endpoint.
+// AsOf returns the parcel as it was at the end of that day (UTC).
+type GetRequest struct {
+	AsOf              time.Time `form:"as_of" time_format:"2006-01-02" time_utc:"1"`
+	Zoom              *int      `form:"zoom" binding:"omitempty,min=0,max=22"`
+	Fields            string    `form:"fields"`
+	Format            string    `form:"format" binding:"omitempty,oneof=json geojson kml"`
+	Include           string    `form:"include"`
+	SimplifyTolerance float64   `form:"simplify_tolerance" binding:"omitempty,min=0,max=0.01"`
+	SRID              int       `form:"srid"`
+}
+
+// AtPointRequest represents the query parameters for the at-point endpoint.
+// The point is given as lat/lng, a full plus code, or a what3words address.
+// Accuracy is the GPS accuracy radius in meters reported by mobile clients.
+// AsOf returns the parcel that contained the point at the end of that day (UTC),
+// as it was then.
+type AtPointRequest struct {
+	AsOf              time.Time `form:"as_of" time_format:"2006-01-02" time_utc:"1"`
+	Zoom              *int      `form:"zoom" binding:"omitempty,min=0,max=22"`
+	Fields            string    `form:"fields"`
+	Format            string    `form:"format" binding:"omitempty,oneof=json geojson kml"`
+	Include           string    `form:"include"`
+	County            string    `form:"county" binding:"omitempty,max=100"`
+	PlusCode          string    `form:"plus_code"`
+	What3Words        string    `form:"w3w"`
+	SimplifyTolerance float64   `form:"simplify_tolerance" binding:"omitempty,min=0,max=0.01"`
+	Accuracy          float64   `form:"accuracy" binding:"omitempty,gt=0,max=100"`
+	Lat               float64   `form:"lat" binding:"required_without_all=PlusCode What3Words,min=-90,max=90"`
+	Lng               float64   `form:"lng" binding:"required_without_all=PlusCode What3Words,min=-180,max=180"`
+	SRID              int       `form:"srid"`
+}
+
+// IdentifyRequest represents the query parameters for the identify endpoint.
+type IdentifyRequest struct {
+	County string  `form:"county" binding:"omitempty,max=100"`
+	Lat    float64 `form:"lat" binding:"required,min=-90,max=90"`
+	Lng    float64 `form:"lng" binding:"required,min=-180,max=180"`
+}
+
+// NearbyRequest represents the query parameters for the nearby endpoint.
+// The center is given as lat/lng, a full plus code, or a what3words address.
+// AmenityWithin is the distance in meters within which an amenity of category
+// Amenity must lie.
+type NearbyRequest struct {
+	Zoom              *int     `form:"zoom" binding:"omitempty,min=0,max=22"`
+	MinAcres          *float64 `form:"min_acres" binding:"omitempty,min=0"`
+	MaxAcres          *float64 `form:"max_acres" binding:"omitempty,min=0"`
+	MinYearBuilt      *int     `form:"min_year_built"`
+	MaxYearBuilt      *int     `form:"max_year_built"`
+	Waterfront        *bool    `form:"waterfront"`
+	AmenityWithin     *float64 `form:"amenity_within"`
+	LandUse           string   `form:"land_use"`
+	OwnerName         string   `form:"owner_name"`
+	Amenity           string   `form:"amenity"`
+	Fields            string   `form:"fields"`
+	Format            string   `form:"format" binding:"omitempty,oneof=json geojson csv kml"`
+	Include           string   `form:"include"`
+	County            string   `form:"county" binding:"omitempty,max=100"`
+	PlusCode          string   `form:"plus_code"`
+	What3Words        string   `form:"w3w"`
+	SimplifyTolerance float64  `form:"simplify_tolerance" binding:"omitempty,min=0,max=0.01"`
+	Lat               float64  `form:"lat" binding:"required_without_all=PlusCode What3Words,min=-90,max=90"`
+	Lng               float64  `form:"lng" binding:"required_without_all=PlusCode What3Words,min=-180,max=180"`
+	Cursor            string   `form:"cursor"`
+	Radius            int      `form:"radius,omitempty,min=1,max=5000"`
+	Limit             int      `form:"limit" binding:"omitempty,min=1,max=100"`
+	SRID              int      `form:"srid"`
+}
+
+// SearchAddressRequest represents the query parameters for the search-address endpoint.
+type SearchAddressRequest struct {
+	Zoom              *int    `form:"zoom" binding:"omitempty,min=0,max=22"`
+	Query             string  `form:"q" binding:"required"`
+	Fields            string  `form:"fields"`
+	Format            string  `form:"format" binding:"omitempty,oneof=json geojson csv kml"`
+	County            string  `form:"county" binding:"omitempty,max=100"`
+	SimplifyTolerance float64 `form:"simplify_tolerance" binding:"omitempty,min=0,max=0.01"`
+	Cursor            string  `form:"cursor"`
+	Limit             int     `form:"limit" binding:"omitempty,min=1,max=50"`
+	SRID              int     `form:"srid"`
+}
+
+// CompareRequest represents the query parameters for the compare endpoint.
+// IDs is a comma-separated list such as "1,2,3".
+type CompareRequest struct {
+	IDs string `form:"ids" binding:"required"`
+}
+
+// DissolveRequest represents the request body for the dissolve endpoint.
+type DissolveRequest struct {
+	IDs []uint `json:"ids" binding:"required"`
+}
+
+// IntersectsRequest represents the query parameters for the intersects endpoint.
+// The area itself is the GeoJSON Polygon or MultiPolygon request body. With
+// Async the query runs in the background (JSON format only) and CallbackURL, if
+// set, is notified when it finishes.
+type IntersectsRequest struct {
+	Zoom              *int    `form:"zoom" binding:"omitempty,min=0,max=22"`
+	Fields            string  `form:"fields"`
+	Format            string  `form:"format" binding:"omitempty,oneof=json geojson gpkg csv kml"`
+	County            string  `form:"county" binding:"omitempty,max=100"`
+	CallbackURL       string  `form:"callback_url" binding:"omitempty,max=2048"`
+	SimplifyTolerance float64 `form:"simplify_tolerance" binding:"omitempty,min=0,max=0.01"`
+	Limit             int     `form:"limit" binding:"omitempty,min=1,max=500"`
+	SRID              int     `form:"srid"`
+	Async             bool    `form:"async"`
+}
+
+// AlongRouteRequest represents the query parameters for the along-route endpoint.
+// The route itself is the GeoJSON LineString request body; Buffer is the corridor
+// half-width in meters.
+type AlongRouteRequest struct {
+	Zoom              *int    `form:"zoom" binding:"omitempty,min=0,max=22"`
+	Fields            string  `form:"fields"`
+	Format            string  `form:"format" binding:"omitempty,oneof=json geojson gpkg csv kml"`
+	County            string  `form:"county" binding:"omitempty,max=100"`
+	Buffer            float64 `form:"buffer" binding:"required,min=1,max=1000"`
+	SimplifyTolerance float64 `form:"simplify_tolerance" binding:"omitempty,min=0,max=0.01"`
+	Limit             int     `form:"limit" binding:"omitempty,min=1,max=500"`
+	SRID              int     `form:"srid"`
+}
+
+// SampleRequest represents the query parameters for the admin parcel sample endpoint.
+// N is the sample size, 50 when omitted.
+type SampleRequest struct {
+	Fields string `form:"fields"`
+	Format string `form:"format" binding:"omitempty,oneof=json geojson gpkg csv kml"`
+	County string `form:"county" binding:"omitempty,max=100"`
+	N      int    `form:"n" binding:"omitempty,min=1,max=500"`
+}
+
+// ParcelResponse represents the response for parcel endpoints.
+// Candidates is only present for at-point queries with an accuracy whose
+// accuracy circle crosses a parcel boundary.
+type ParcelResponse struct {
+	Parcel     *ParcelData      `json:"parcel"`
+	AsOf       *ParcelAsOfData  `json:"as_of,omitempty"` // only with as_of
+	Candidates []PointCandidate `json:"candidates,omitempty"`
+}
+
+// PointCandidate is a parcel that may contain a point reported with limited accuracy.
+// Probability is the share of the accuracy circle covered by the parcel.
+type PointCandidate struct {
+	*ParcelData
+	Probability   float64 `json:"probability"`
+	ContainsPoint bool    `json:"contains_point"`
+}
+
+// ParcelData represents the parcel data in the API response.
+// This DTO includes only the fields needed by the frontend.
+// Field order is optimized for memory alignment.
+type ParcelData struct {
+	Geometry            map[string]interface{} `json:"geometry"`
+	Attributes          map[string]any         `json:"attributes,omitempty"`    // derived attributes by name, omitted when none are configured
+	Amenities           []AmenityData          `json:"amenities,omitempty"`     // only with include=amenities
+	Zoning              []ZoningData           `json:"zoning,omitempty"`        // only with include=zoning
+	FloodZones          []FloodZoneData        `json:"flood_zones,omitempty"`   // only with include=flood_zone
+	Jurisdictions       []JurisdictionData     `json:"jurisdictions,omitempty"` // only with include=jurisdictions
+	BBox                []float64              `json:"bbox,omitempty"`          // [minLng, minLat, maxLng, maxLat], WGS84 even with srid=
+	Links               map[string]string      `json:"links,omitempty"`         // related routes by relation, JSON responses only
+	Waterfront          *bool                  `json:"waterfront,omitempty"`    // omitted until the county has a hydrology layer
+	WaterFrontageMeters *float64               `json:"water_frontage_meters,omitempty"`
+	GeocodedAddress     *GeocodedAddressData   `json:"geocoded_address,omitempty"` // at-point only, when situs_address is empty
+	OwnerID             *uint                  `json:"owner_id,omitempty"`         // see /api/v1/owners/:id/parcels
+	ParcelID            string                 `json:"parcel_id,omitempty"`
+	OwnerName           string                 `json:"owner_name,omitempty"`
+	OwnerAddress        string                 `json:"owner_address,omitempty"` // mailing address, admin only
+	SitusAddress        string                 `json:"situs_address,omitempty"`
+	PropType            string                 `json:"prop_type,omitempty"`
+	LandUse             string                 `json:"land_use,omitempty"`
+	WaterfrontType      string                 `json:"waterfront_type,omitempty"` // lake or river
+	WaterBody           string                 `json:"water_body,omitempty"`
+	CountyName          string                 `json:"county_name"`
+	Centroid            [2]float64             `json:"centroid"` // [lng, lat], GeoJSON position order
+	Acres               float64                `json:"acres,omitempty"`
+	ID                  uint                   `json:"id"`
+}
+
+// GeocodedAddressData is the address a geocoder reports nearest a parcel's
+// centroid. It stands in for a missing situs address, so it may name a
+// neighbouring address rather than the parcel's own.
+type GeocodedAddressData struct {
+	Label       string `json:"label"`
+	HouseNumber string `json:"house_number,omitempty"`
+	Street      string `json:"street,omitempty"`
+	City        string `json:"city,omitempty"`
+	County      string `json:"county,omitempty"`
+	State       string `json:"state,omitempty"`
+	PostalCode  string `json:"postal_code,omitempty"`
+}
+
+// NearbyResponse represents the response for the nearby endpoint.
+// NextCursor is omitted on the last page; TotalCount covers all pages.
+type NearbyResponse struct {
+	NextCursor string               `json:"next_cursor,omitempty"`
+	Parcels    []ParcelWithDistance `json:"parcels"`
+	Count      int                  `json:"count"`
+	TotalCount int                  `json:"total_count"`
+}
+
+// ParcelWithDistance represents a parcel with its distance from the query point.
+// Field order is optimized for memory alignment.
+type ParcelWithDistance struct {
+	Geometry            map[string]interface{} `json:"geometry"`
+	Attributes          map[string]any         `json:"attributes,omitempty"`    // derived attributes by name, omitted when none are configured
+	Amenities           []AmenityData          `json:"amenities,omitempty"`     // only with include=amenities
+	Zoning              []ZoningData           `json:"zoning,omitempty"`        // only with include=zoning
+	FloodZones          []FloodZoneData        `json:"flood_zones,omitempty"`   // only with include=flood_zone
+	Jurisdictions       []JurisdictionData     `json:"jurisdictions,omitempty"` // only with include=jurisdictions
+	BBox                []float64              `json:"bbox,omitempty"`          // [minLng, minLat, maxLng, maxLat], WGS84 even with srid=
+	Links               map[string]string      `json:"links,omitempty"`         // related routes by relation, JSON responses only
+	Waterfront          *bool                  `json:"waterfront,omitempty"`    // omitted until the county has a hydrology layer
+	WaterFrontageMeters *float64               `json:"water_frontage_meters,omitempty"`
+	OwnerID             *uint                  `json:"owner_id,omitempty"` // see /api/v1/owners/:id/parcels
+	ParcelID            string                 `json:"parcel_id,omitempty"`
+	OwnerName           string                 `json:"owner_name,omitempty"`
+	OwnerAddress        string                 `json:"owner_address,omitempty"` // mailing address, admin only
+	CountyName          string                 `json:"county_name"`
+	Centroid            [2]float64             `json:"centroid"` // [lng, lat], GeoJSON position order
+	Acres               float64                `json:"acres,omitempty"`
+	Distance            float64                `json:"distance_meters"`
+	ID                  uint                   `json:"id"`
+}
+
+// AmenityData is the nearest amenity of one category to a parcel.
+// Distance is measured from the parcel boundary, so it is 0 inside the parcel.
+type AmenityData struct {
+	Category string     `json:"category"`
+	Name     string     `json:"name,omitempty"`
+	Location [2]float64 `json:"location"` // [lng, lat], GeoJSON position order
+	Distance float64    `json:"distance_meters"`
+}
+
+// ZoningData is a zoning district covering part of a parcel. Coverage is the
+// share of the parcel's area inside the district; districts covering less than
+// repository.ZoningSliverCoverage of it are left out.
+type ZoningData struct {
+	Code     string  `json:"code"`
+	Name     string  `json:"name,omitempty"`
+	Coverage float64 `json:"coverage"`
+	ID       uint    `json:"id"`
+}
+
+// FloodZoneData is a FEMA flood zone covering part of a parcel. CoveragePercent
+// is the percentage of the parcel's area in the zone, to one decimal; zones
+// covering less than repository.FloodSliverCoverage of it are left out.
+type FloodZoneData struct {
+	Zone            string  `json:"zone"`
+	Subtype         string  `json:"subtype,omitempty"`
+	CoveragePercent float64 `json:"coverage_percent"`
+	SFHA            bool    `json:"sfha"` // Special Flood Hazard Area, where lenders require flood insurance
+}
+
+// JurisdictionData is a school district or taxing unit a parcel lies in, by the
+// boundary containing a point on the parcel's surface. Unlike the parcel's
+// taxing_units attribute, it is known wherever the boundaries are loaded.
+type JurisdictionData struct {
+	Kind string `json:"kind"` // school_district or taxing_unit
+	Code string `json:"code,omitempty"`
+	Name string `json:"name"`
+	ID   uint   `json:"id"`
+}
+
+// IdentifyResponse represents the response for the identify endpoint.
+type IdentifyResponse struct {
+	Parcel *IdentifyData `json:"parcel"`
+}
+
+// IdentifyData is the minimal parcel payload used for hover tooltips.
+// It intentionally omits geometry to keep the response small.
+type IdentifyData struct {
+	OwnerName    string  `json:"owner_name,omitempty"`
+	SitusAddress string  `json:"situs_address,omitempty"`
+	Acres        float64 `json:"acres"`
+	ID           uint    `json:"id"`
+	PIN          int     `json:"pin"`
+}
+
+// SearchAddressResponse represents the response for the search-address endpoint.
+// NextCursor is omitted on the last page; TotalCount covers all pages.
+type SearchAddressResponse struct {
+	NextCursor string             `json:"next_cursor,omitempty"`
+	Candidates []AddressCandidate `json:"candidates"`
+	Count      int                `json:"count"`
+	TotalCount int                `json:"total_count"`
+}
+
+// AddressCandidate represents a parcel matched by address search with its relevance score.
+// Field order is optimized for memory alignment.
+type AddressCandidate struct {
+	Geometry     map[string]interface{} `json:"geometry"`
+	SitusAddress string                 `json:"situs_address"`
+	OwnerName    string                 `json:"owner_name,omitempty"`
+	CountyName   string                 `json:"county_name"`
+	Score        float64                `json:"score"`
+	ID           uint                   `json:"id"`
+}
+
+// DissolveResponse represents the response for the dissolve endpoint.
+// Geometry is the GeoJSON MultiPolygon outline of the merged parcels.
+type DissolveResponse struct {
+	Geometry        models.MultiPolygon `json:"geometry"`
+	ParcelIDs       []uint              `json:"parcel_ids"`
+	Acres           float64             `json:"acres"`
+	PerimeterMeters float64             `json:"perimeter_meters"`
+	Count           int                 `json:"count"`
+}
+
+// IntersectsResponse represents the response for the intersects endpoint.
+// Truncated is true when more parcels intersect the area than the limit allowed.
+type IntersectsResponse struct {
+	Parcels   []*ParcelData `json:"parcels"`
+	Count     int           `json:"count"`
+	Truncated bool          `json:"truncated"`
+}
+
+// AlongRouteResponse represents the response for the along-route endpoint.
+// Parcels are ordered by station; Truncated is true when more parcels are in the
+// corridor than the limit allowed.
+type AlongRouteResponse struct {
+	Parcels   []RouteParcel `json:"parcels"`
+	Count     int           `json:"count"`
+	Truncated bool          `json:"truncated"`
+}
+
+// RouteParcel is a parcel in a route corridor.
+// Station is the distance along the route to its point closest to the parcel.
+type RouteParcel struct {
+	*ParcelData
+	Distance float64 `json:"distance_meters"`
+	Station  float64 `json:"station_meters"`
+}
+
+// SampleResponse represents the response for the admin parcel sample endpoint.
+// Parcels are in random order; Count is below the requested size only when
+// fewer parcels exist.
+type SampleResponse struct {
+	Parcels []SampledParcel `json:"parcels"`
+	Count   int             `json:"count"`
+}
+
+// SampledParcel is a randomly sampled parcel with its data quality flags
+// (e.g. invalid_geometry, missing_owner), empty when it has no issues.
+type SampledParcel struct {
+	*ParcelData
+	QualityFlags []string `json:"quality_flags"`
 }
 
-// NewParcelHandler creates a new ParcelHandler instance.
-func NewParcelHandler(service services.ParcelService) *ParcelHandler {
-	return &ParcelHandler{
-		service: service,
+// CompareResponse represents the response for the compare endpoint.
+// Errors lists the requested parcels left out, by position in ids; the
+// response is 207 Multi-Status when there are any.
+type CompareResponse struct {
+	Parcels     []ComparedParcel      `json:"parcels"`
+	Differences []FieldDiff           `json:"differences"`
+	Errors      []apierrors.ItemError `json:"errors"`
+	Count       int                   `json:"count"`
+}
+
+// ComparedParcel is one column of the side-by-side comparison.
+// Field order is optimized for memory alignment.
+type ComparedParcel struct {
+	OwnerName    string          `json:"owner_name,omitempty"`
+	SitusAddress string          `json:"situs_address,omitempty"`
+	LandUse      string          `json:"land_use,omitempty"`
+	CountyName   string          `json:"county_name"`
+	Assessment   AssessmentData  `json:"assessment"`
+	Shape        GeometrySummary `json:"geometry_summary"`
+	ID           uint            `json:"id"`
+	PIN          int             `json:"pin"`
+}
+
+// AssessmentData holds the appraisal roll attributes of a compared parcel.
+type AssessmentData struct {
+	TaxYear     *int   `json:"tax_year,omitempty"`
+	YearBuilt   *int   `json:"year_built,omitempty"`
+	MainArea    *int   `json:"main_area,omitempty"`
+	StateCd     string `json:"state_cd,omitempty"`
+	MarketArea  string `json:"market_area,omitempty"`
+	Exemptions  string `json:"exemptions,omitempty"`
+	TaxingUnits string `json:"taxing_units,omitempty"`
+}
+
+// GeometrySummary describes a parcel's shape without its full boundary.
+type GeometrySummary struct {
+	Centroid        [2]float64 `json:"centroid"` // [lng, lat], GeoJSON position order
+	Acres           float64    `json:"acres"`
+	PerimeterMeters float64    `json:"perimeter_meters"`
+}
+
+// FieldDiff describes one attribute across all compared parcels.
+// Deltas are relative to the first parcel and only present for numeric fields.
+type FieldDiff struct {
+	Values  []interface{} `json:"values"`
+	Deltas  []*float64    `json:"deltas,omitempty"`
+	Field   string        `json:"field"`
+	Differs bool          `json:"differs"`
+}
+
+// Get handles GET /api/v1/parcels/:id endpoint.
+// It retrieves a parcel by ID, the target of the self link of parcel responses.
+func (h *ParcelHandler) Get(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		apierrors.BadRequest(c, "parcel id must be a positive integer", nil)
+		return
+	}
+
+	var req GetRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		apierrors.BadRequest(c, "Invalid query parameters", nil)
+		return
+	}
+
+	include, ok := h.parseInclude(c, req.Include)
+	if !ok {
+		return
+	}
+
+	// Validate the field selection and geometry simplification; queries skip geometry when it is not selected
+	ctx, fields, ok := parseFieldSelection(c, req.Fields, ParcelData{})
+	if !ok {
+		return
+	}
+	ctx, ok = applySimplification(ctx, c, req.SimplifyTolerance, req.Zoom)
+	if !ok {
+		return
+	}
+	ctx, ok = applyOutputSRID(ctx, c, req.SRID, req.Format)
+	if !ok {
+		return
+	}
+
+	if !req.AsOf.IsZero() {
+		if !h.checkAsOf(c, req.Include, 0) {
+			return
+		}
+		asOf, err := h.history.GetParcelAsOf(ctx, uint(id), req.AsOf)
+		if err != nil {
+			queryFailed(c, "Failed to query parcel data", err)
+			return
+		}
+		h.renderAsOf(c, asOf, req.AsOf, req.Format, fields)
+		return
+	}
+
+	parcel, err := h.service.GetParcel(ctx, uint(id))
+	if err != nil {
+		queryFailed(c, "Failed to query parcel data", err)
+		return
+	}
+
+	middleware.SetResultCount(c, 1)
+
+	dto := mapTaxParcelToDTO(parcel)
+	included, ok := h.lookupIncluded(ctx, c, include, []uint{dto.ID})
+	if !ok {
+		return
+	}
+	included.apply(dto)
+
+	switch req.Format {
+	case FormatGeoJSON:
+		renderFeatureCollection(c, []*ParcelData{dto}, fields)
+		return
+	case FormatKML:
+		renderKML(c, []*ParcelData{dto}, fields)
+		return
+	}
+
+	dto.Links = h.links.For(dto.ID)
+	renderSelectedJSON(c, ParcelResponse{Parcel: dto}, fields, "parcel")
+}
+
+// AtPoint handles GET /api/v1/parcels/at-point endpoint.
+// It retrieves the parcel that contains the given lat/lng point. With an accuracy,
+// it also lists candidate parcels when the point may lie in a neighbouring parcel.
+func (h *ParcelHandler) AtPoint(c *gin.Context) {
+	log := middleware.GetLogger(c)
+
+	// Bind and validate query parameters
+	var req AtPointRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		// Check if it's a validation error
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		// Generic bad request for other binding errors
+		apierrors.BadRequest(c, "Invalid query parameters", nil)
+		return
+	}
+
+	include, ok := h.parseInclude(c, req.Include)
+	if !ok {
+		return
+	}
+
+	// Validate the field selection and geometry simplification; queries skip geometry when it is not selected
+	var selectable interface{} = ParcelData{}
+	if req.Accuracy > 0 {
+		selectable = PointCandidate{}
+	}
+	ctx, fields, ok := parseFieldSelection(c, req.Fields, selectable)
+	if !ok {
+		return
+	}
+	ctx, ok = applySimplification(ctx, c, req.SimplifyTolerance, req.Zoom)
+	if !ok {
+		return
+	}
+	ctx, ok = applyOutputSRID(ctx, c, req.SRID, req.Format)
+	if !ok {
+		return
+	}
+	ctx = repository.WithCounty(ctx, req.County)
+
+	point, ok := h.resolvePoint(c, req.Lat, req.Lng, req.PlusCode, req.What3Words)
+	if !ok {
+		return
+	}
+
+	if log != nil {
+		log.Info("Processing at-point request", map[string]interface{}{
+			"lat":      point.Lat,
+			"lng":      point.Lng,
+			"accuracy": req.Accuracy,
+		})
+	}
+
+	if !req.AsOf.IsZero() {
+		if !h.checkAsOf(c, req.Include, req.Accuracy) {
+			return
+		}
+		asOf, err := h.history.GetParcelAtPointAsOf(ctx, point, req.AsOf)
+		if err != nil {
+			queryFailed(c, "Failed to query parcel data", domain.Reword(err, domain.ErrNotFound, "No property found at this location on that date"))
+			return
+		}
+		h.renderAsOf(c, asOf, req.AsOf, req.Format, fields)
+		return
+	}
+
+	if req.Accuracy > 0 {
+		h.atPointWithAccuracy(ctx, c, point, req.Accuracy, req.Format, include, fields)
+		return
+	}
+
+	// Call service layer
+	parcel, err := h.service.GetParcelAtPoint(ctx, point)
+	if err != nil {
+		queryFailed(c, "Failed to query parcel data", domain.Reword(err, domain.ErrNotFound, "No property found at this location"))
+		return
+	}
+
+	middleware.SetResultCount(c, 1)
+
+	// Map TaxParcel model to ParcelData DTO
+	dto := mapTaxParcelToDTO(parcel)
+
+	included, ok := h.lookupIncluded(ctx, c, include, []uint{dto.ID})
+	if !ok {
+		return
+	}
+	included.apply(dto)
+	h.geocodeMissingSitus(ctx, dto, fields)
+
+	switch req.Format {
+	case FormatGeoJSON:
+		renderFeatureCollection(c, []*ParcelData{dto}, fields)
+		return
+	case FormatKML:
+		renderKML(c, []*ParcelData{dto}, fields)
+		return
+	}
+
+	dto.Links = h.links.For(dto.ID)
+	response := ParcelResponse{
+		Parcel: dto,
+	}
+
+	renderSelectedJSON(c, response, fields, "parcel")
+}
+
+// atPointWithAccuracy completes an at-point request that carries a GPS accuracy.
+// The GeoJSON and KML formats render the candidates as features when there are any.
+func (h *ParcelHandler) atPointWithAccuracy(ctx context.Context, c *gin.Context, point models.LatLng, accuracy float64, format string, include includes, fields []string) {
+	result, err := h.service.GetParcelCandidatesAtPoint(ctx, point, accuracy)
+	if err != nil {
+		queryFailed(c, "Failed to query parcel data", domain.Reword(err, domain.ErrNotFound, "No property found at this location"))
+		return
+	}
+
+	// The parcel is one of the candidates when the match is uncertain
+	middleware.SetResultCount(c, max(1, len(result.Candidates)))
+
+	response := ParcelResponse{
+		Parcel: mapTaxParcelToDTO(result.Parcel),
+	}
+	for i := range result.Candidates {
+		candidate := &result.Candidates[i]
+		response.Candidates = append(response.Candidates, PointCandidate{
+			ParcelData:    mapTaxParcelToDTO(&candidate.Parcel),
+			Probability:   candidate.Probability,
+			ContainsPoint: candidate.ContainsPoint,
+		})
+	}
+
+	ids := []uint{response.Parcel.ID}
+	for _, candidate := range response.Candidates {
+		ids = append(ids, candidate.ID)
+	}
+	included, ok := h.lookupIncluded(ctx, c, include, ids)
+	if !ok {
+		return
+	}
+	included.apply(response.Parcel)
+	for _, candidate := range response.Candidates {
+		included.apply(candidate.ParcelData)
+	}
+	h.geocodeMissingSitus(ctx, response.Parcel, fields)
+
+	switch format {
+	case FormatGeoJSON:
+		if len(response.Candidates) > 0 {
+			renderFeatureCollection(c, response.Candidates, fields)
+			return
+		}
+		renderFeatureCollection(c, []*ParcelData{response.Parcel}, fields)
+		return
+	case FormatKML:
+		if len(response.Candidates) > 0 {
+			renderKML(c, response.Candidates, fields)
+			return
+		}
+		renderKML(c, []*ParcelData{response.Parcel}, fields)
+		return
+	}
+
+	response.Parcel.Links = h.links.For(response.Parcel.ID)
+	for _, candidate := range response.Candidates {
+		candidate.Links = h.links.For(candidate.ID)
+	}
+
+	renderSelectedJSON(c, response, fields, "parcel", "candidates")
+}
+
+// Nearby handles GET /api/v1/parcels/nearby endpoint.
+// It retrieves parcels within the specified radius of the given lat/lng point.
+func (h *ParcelHandler) Nearby(c *gin.Context) {
+	log := middleware.GetLogger(c)
+
+	// Bind and validate query parameters
+	var req NearbyRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		// Check if it's a validation error
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		// Generic bad request for other binding errors
+		apierrors.BadRequest(c, "Invalid query parameters", nil)
+		return
+	}
+
+	// Set defaults if not provided; the radius default is the county's, looked up below
+	const defaultNearbyLimit = 20
+	if req.Limit == 0 {
+		req.Limit = defaultNearbyLimit
+	}
+
+	include, ok := h.parseInclude(c, req.Include)
+	if !ok {
+		return
+	}
+
+	// Validate the field selection and geometry simplification; queries skip geometry when it is not selected
+	ctx, fields, ok := parseFieldSelection(c, req.Fields, ParcelWithDistance{})
+	if !ok {
+		return
+	}
+	ctx, ok = applySimplification(ctx, c, req.SimplifyTolerance, req.Zoom)
+	if !ok {
+		return
+	}
+	ctx, ok = applyOutputSRID(ctx, c, req.SRID, req.Format)
+	if !ok {
+		return
+	}
+	ctx = repository.WithCounty(ctx, req.County)
+
+	// Only an absent radius is defaulted; radius=0 is rejected by the service
+	if _, ok := c.GetQuery("radius"); !ok {
+		radius, err := h.service.DefaultNearbyRadius(ctx)
+		if err != nil {
+			queryFailed(c, "Failed to query nearby parcels", err)
+			return
+		}
+		req.Radius = radius
+	}
+
+	point, ok := h.resolvePoint(c, req.Lat, req.Lng, req.PlusCode, req.What3Words)
+	if !ok {
+		return
+	}
+
+	if log != nil {
+		log.Info("Processing nearby request", map[string]interface{}{
+			"lat":    point.Lat,
+			"lng":    point.Lng,
+			"radius": req.Radius,
+			"limit":  req.Limit,
+		})
+	}
+
+	// Call service layer
+	filter := repository.NearbyFilter{
+		MinAcres:            req.MinAcres,
+		MaxAcres:            req.MaxAcres,
+		MinYearBuilt:        req.MinYearBuilt,
+		MaxYearBuilt:        req.MaxYearBuilt,
+		Waterfront:          req.Waterfront,
+		LandUse:             req.LandUse,
+		OwnerName:           req.OwnerName,
+		Amenity:             req.Amenity,
+		AmenityWithinMeters: req.AmenityWithin,
+		Attributes:          attributeFilters(c.Request.URL.Query()),
+	}
+	page, err := h.service.GetNearbyParcels(ctx, point, req.Radius, req.Limit, req.Cursor, filter)
+	if err != nil {
+		queryFailed(c, "Failed to query nearby parcels", err)
+		return
+	}
+
+	middleware.SetResultCount(c, len(page.Parcels))
+
+	// Map repository results to response DTOs
+	responseParcels := make([]ParcelWithDistance, 0, len(page.Parcels))
+	for _, p := range page.Parcels {
+		responseParcels = append(responseParcels, mapParcelWithDistanceToDTO(&p))
+	}
+
+	ids := make([]uint, 0, len(responseParcels))
+	for _, p := range responseParcels {
+		ids = append(ids, p.ID)
+	}
+	included, ok := h.lookupIncluded(ctx, c, include, ids)
+	if !ok {
+		return
+	}
+	for i := range responseParcels {
+		id := responseParcels[i].ID
+		responseParcels[i].Amenities = included.amenities[id]
+		responseParcels[i].Zoning = included.zoning[id]
+		responseParcels[i].FloodZones = included.floodZones[id]
+		responseParcels[i].Jurisdictions = included.jurisdictions[id]
+	}
+
+	switch req.Format {
+	case FormatGeoJSON:
+		setPaginationHeaders(c, page.NextCursor, page.TotalCount)
+		renderFeatureCollection(c, responseParcels, fields)
+		return
+	case FormatCSV:
+		setPaginationHeaders(c, page.NextCursor, page.TotalCount)
+		renderCSV(c, responseParcels, fields)
+		return
+	case FormatKML:
+		setPaginationHeaders(c, page.NextCursor, page.TotalCount)
+		renderKML(c, responseParcels, fields)
+		return
+	}
+
+	for i := range responseParcels {
+		responseParcels[i].Links = h.links.For(responseParcels[i].ID)
+	}
+	response := NearbyResponse{
+		NextCursor: page.NextCursor,
+		Parcels:    responseParcels,
+		Count:      len(responseParcels),
+		TotalCount: page.TotalCount,
+	}
+
+	renderSelectedJSON(c, response, fields, "parcels")
+}
+
+// Identify handles GET /api/v1/parcels/identify endpoint.
+// It returns a geometry-free summary of the parcel at the given lat/lng point.
+func (h *ParcelHandler) Identify(c *gin.Context) {
+	// Bind and validate query parameters
+	var req IdentifyRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		// Check if it's a validation error
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		// Generic bad request for other binding errors
+		apierrors.BadRequest(c, "Invalid query parameters", nil)
+		return
+	}
+
+	// Call service layer
+	ctx := repository.WithCounty(c.Request.Context(), req.County)
+	summary, err := h.service.IdentifyParcelAtPoint(ctx, models.LatLng{Lat: req.Lat, Lng: req.Lng})
+	if err != nil {
+		queryFailed(c, "Failed to identify parcel", domain.Reword(err, domain.ErrNotFound, "No property found at this location"))
+		return
+	}
+
+	middleware.SetResultCount(c, 1)
+
+	renderSelectedJSON(c, IdentifyResponse{
+		Parcel: mapParcelSummaryToDTO(summary),
+	}, nil, "parcel")
+}
+
+// SearchAddress handles GET /api/v1/parcels/search-address endpoint.
+// It returns parcels whose situs address fuzzily matches the q parameter, ranked by score.
+func (h *ParcelHandler) SearchAddress(c *gin.Context) {
+	log := middleware.GetLogger(c)
+
+	// Bind and validate query parameters
+	var req SearchAddressRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		// Check if it's a validation error
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		// Generic bad request for other binding errors
+		apierrors.BadRequest(c, "Invalid query parameters", nil)
+		return
+	}
+
+	// Set default limit if not provided
+	const defaultSearchLimit = 10
+	if req.Limit == 0 {
+		req.Limit = defaultSearchLimit
+	}
+
+	// Validate the field selection and geometry simplification; queries skip geometry when it is not selected
+	ctx, fields, ok := parseFieldSelection(c, req.Fields, AddressCandidate{})
+	if !ok {
+		return
+	}
+	ctx, ok = applySimplification(ctx, c, req.SimplifyTolerance, req.Zoom)
+	if !ok {
+		return
+	}
+	ctx, ok = applyOutputSRID(ctx, c, req.SRID, req.Format)
+	if !ok {
+		return
+	}
+	ctx = repository.WithCounty(ctx, req.County)
+
+	if log != nil {
+		log.Info("Processing search-address request", map[string]interface{}{
+			"q":     req.Query,
+			"limit": req.Limit,
+		})
+	}
+
+	// Call service layer
+	page, err := h.service.SearchByAddress(ctx, req.Query, req.Limit, req.Cursor)
+	if err != nil {
+		queryFailed(c, "Failed to search parcels by address", err)
+		return
+	}
+
+	middleware.SetResultCount(c, len(page.Matches))
+
+	// Map repository results to response DTOs
+	candidates := make([]AddressCandidate, 0, len(page.Matches))
+	for _, m := range page.Matches {
+		candidates = append(candidates, mapParcelWithScoreToDTO(&m))
+	}
+
+	switch req.Format {
+	case FormatGeoJSON:
+		setPaginationHeaders(c, page.NextCursor, page.TotalCount)
+		renderFeatureCollection(c, candidates, fields)
+		return
+	case FormatCSV:
+		setPaginationHeaders(c, page.NextCursor, page.TotalCount)
+		renderCSV(c, candidates, fields)
+		return
+	case FormatKML:
+		setPaginationHeaders(c, page.NextCursor, page.TotalCount)
+		renderKML(c, candidates, fields)
+		return
+	}
+
+	response := SearchAddressResponse{
+		NextCursor: page.NextCursor,
+		Candidates: candidates,
+		Count:      len(candidates),
+		TotalCount: page.TotalCount,
+	}
+
+	renderSelectedJSON(c, response, fields, "candidates")
+}
+
+// setPaginationHeaders writes page metadata as response headers.
+// The next cursor header is omitted on the last page.
+func setPaginationHeaders(c *gin.Context, nextCursor string, totalCount int) {
+	if nextCursor != "" {
+		c.Header(NextCursorHeader, nextCursor)
+	}
+	c.Header(TotalCountHeader, strconv.Itoa(totalCount))
+}
+
+// Compare handles GET /api/v1/parcels/compare endpoint.
+// It returns the requested parcels side by side with computed attribute differences.
+func (h *ParcelHandler) Compare(c *gin.Context) {
+	log := middleware.GetLogger(c)
+
+	// Bind and validate query parameters
+	var req CompareRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		// Check if it's a validation error
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		// Generic bad request for other binding errors
+		apierrors.BadRequest(c, "Invalid query parameters", nil)
+		return
+	}
+
+	ids, err := parseIDList(req.IDs)
+	if err != nil {
+		apierrors.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	if log != nil {
+		log.Info("Processing compare request", map[string]interface{}{
+			"ids": ids,
+		})
+	}
+
+	// Call service layer
+	comparison, err := h.service.CompareParcels(c.Request.Context(), ids)
+	if err != nil {
+		queryFailed(c, "Failed to compare parcels", err)
+		return
+	}
+
+	response := mapComparisonToDTO(c, ids, comparison)
+	middleware.SetResultCount(c, response.Count)
+
+	// Missing or unreadable parcels make a 207 listing them in errors
+	renderSelectedJSONStatus(c, apierrors.BatchStatus(response.Errors), response, nil, "parcels")
+}
+
+// Dissolve handles POST /api/v1/geo/dissolve endpoint.
+// It returns the outer boundary of the requested parcels merged into one shape,
+// e.g. an assemblage outline or a notification area for a multi-parcel project.
+func (h *ParcelHandler) Dissolve(c *gin.Context) {
+	log := middleware.GetLogger(c)
+
+	// Bind and validate the request body
+	var req DissolveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		apierrors.BadRequest(c, "Request body must be a JSON object with an ids array", nil)
+		return
+	}
+
+	if log != nil {
+		log.Info("Processing dissolve request", map[string]interface{}{
+			"count": len(req.IDs),
+		})
+	}
+
+	// Call service layer
+	area, err := h.service.DissolveParcels(c.Request.Context(), req.IDs)
+	if err != nil {
+		queryFailed(c, "Failed to dissolve parcels", err)
+		return
+	}
+
+	middleware.SetResultCount(c, len(area.ParcelIDs))
+
+	c.JSON(http.StatusOK, DissolveResponse{
+		Geometry:        area.Outline,
+		ParcelIDs:       area.ParcelIDs,
+		Acres:           area.Acres,
+		PerimeterMeters: area.PerimeterMeters,
+		Count:           len(area.ParcelIDs),
+	})
+}
+
+// Intersects handles POST /api/v1/parcels/intersects endpoint.
+// It returns parcels intersecting the GeoJSON Polygon or MultiPolygon in the request body.
+func (h *ParcelHandler) Intersects(c *gin.Context) {
+	log := middleware.GetLogger(c)
+
+	// Bind and validate query parameters
+	var req IntersectsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		// Check if it's a validation error
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		// Generic bad request for other binding errors
+		apierrors.BadRequest(c, "Invalid query parameters", nil)
+		return
+	}
+
+	// Set default limit if not provided
+	const defaultIntersectLimit = 100
+	if req.Limit == 0 {
+		req.Limit = defaultIntersectLimit
+	}
+	if !checkAsync(c, req.Async, req.CallbackURL) {
+		return
+	}
+	if req.Async && req.Format != "" && req.Format != FormatJSON {
+		apierrors.BadRequest(c, "async=true requires the json format", nil)
+		return
+	}
+
+	// Validate the field selection and geometry simplification; queries skip geometry when it is not selected
+	ctx, fields, ok := parseFieldSelection(c, req.Fields, ParcelData{})
+	if !ok {
+		return
+	}
+	ctx, ok = applySimplification(ctx, c, req.SimplifyTolerance, req.Zoom)
+	if !ok {
+		return
+	}
+	ctx, ok = applyOutputSRID(ctx, c, req.SRID, req.Format)
+	if !ok {
+		return
+	}
+	ctx = repository.WithCounty(ctx, req.County)
+
+	// Read and parse the GeoJSON body
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, MaxAreaBodyBytes))
+	if err != nil {
+		apierrors.BadRequest(c, "Request body is too large or unreadable", nil)
+		return
+	}
+	area, err := models.ParseAreaGeoJSON(body)
+	if err != nil {
+		apierrors.BadRequest(c, "Request body must be a GeoJSON Polygon or MultiPolygon", map[string]interface{}{
+			"reason": err.Error(),
+		})
+		return
+	}
+
+	if log != nil {
+		log.Info("Processing intersects request", map[string]interface{}{
+			"polygons": len(area.Coordinates),
+			"limit":    req.Limit,
+		})
 	}
-}
 
-// AtPointRequest represents the query parameters for the at-point endpoint.
-type AtPointRequest struct {
-	Lat float64 `form:"lat" binding:"required,min=-90,max=90"`
-	Lng float64 `form:"lng" binding:"required,min=-180,max=180"`
-}
+	if req.Async {
+		role := middleware.GetRole(c)
+		submitAsync(c, ctx, h.results, "intersects", req.CallbackURL, func(ctx context.Context) (any, error) {
+			parcels, truncated, err := h.service.FindParcelsInArea(ctx, area, req.Limit)
+			if err != nil {
+				return nil, err
+			}
+			dtos := make([]*ParcelData, 0, len(parcels))
+			for i := range parcels {
+				dtos = append(dtos, mapTaxParcelToDTO(&parcels[i]))
+			}
+			return shapeSelectedJSON(h.intersectsResponse(dtos, truncated), fields, role, "parcels")
+		})
+		return
+	}
 
-// NearbyRequest represents the query parameters for the nearby endpoint.
-type NearbyRequest struct {
-	Lat    float64 `form:"lat" binding:"required,min=-90,max=90"`
-	Lng    float64 `form:"lng" binding:"required,min=-180,max=180"`
-	Radius int     `form:"radius,omitempty,min=1,max=5000"`
-}
+	// Call service layer
+	parcels, truncated, err := h.service.FindParcelsInArea(ctx, area, req.Limit)
+	if err != nil {
+		queryFailed(c, "Failed to query parcels in area", err)
+		return
+	}
 
-// ParcelResponse represents the response for parcel endpoints.
-type ParcelResponse struct {
-	Parcel *ParcelData `json:"parcel"`
-}
+	middleware.SetResultCount(c, len(parcels))
 
-// ParcelData represents the parcel data in the API response.
-// This DTO includes only the fields needed by the frontend.
-// Field order is optimized for memory alignment.
-type ParcelData struct {
-	Geometry     map[string]interface{} `json:"geometry"`
-	ParcelID     string                 `json:"parcel_id,omitempty"`
-	OwnerName    string                 `json:"owner_name,omitempty"`
-	SitusAddress string                 `json:"situs_address,omitempty"`
-	PropType     string                 `json:"prop_type,omitempty"`
-	LandUse      string                 `json:"land_use,omitempty"`
-	CountyName   string                 `json:"county_name"`
-	Acres        float64                `json:"acres,omitempty"`
-	ID           uint                   `json:"id"`
-}
+	// Map TaxParcel models to ParcelData DTOs
+	dtos := make([]*ParcelData, 0, len(parcels))
+	for i := range parcels {
+		dtos = append(dtos, mapTaxParcelToDTO(&parcels[i]))
+	}
 
-// NearbyResponse represents the response for the nearby endpoint.
-type NearbyResponse struct {
-	Parcels []ParcelWithDistance `json:"parcels"`
-	Count   int                  `json:"count"`
+	switch req.Format {
+	case FormatGeoJSON:
+		renderFeatureCollection(c, dtos, fields)
+		return
+	case FormatGeoPackage:
+		renderGeoPackage(c, dtos, fields)
+		return
+	case FormatCSV:
+		renderCSV(c, dtos, fields)
+		return
+	case FormatKML:
+		renderKML(c, dtos, fields)
+		return
+	}
+
+	renderSelectedJSON(c, h.intersectsResponse(dtos, truncated), fields, "parcels")
 }
 
-// ParcelWithDistance represents a parcel with its distance from the query point.
-// Field order is optimized for memory alignment.
-type ParcelWithDistance struct {
-	Geometry   map[string]interface{} `json:"geometry"`
-	ParcelID   string                 `json:"parcel_id,omitempty"`
-	OwnerName  string                 `json:"owner_name,omitempty"`
-	CountyName string                 `json:"county_name"`
-	Acres      float64                `json:"acres,omitempty"`
-	Distance   float64                `json:"distance_meters"`
-	ID         uint                   `json:"id"`
+// intersectsResponse links the parcels of an intersects JSON response.
+func (h *ParcelHandler) intersectsResponse(dtos []*ParcelData, truncated bool) IntersectsResponse {
+	for _, dto := range dtos {
+		dto.Links = h.links.For(dto.ID)
+	}
+	return IntersectsResponse{
+		Parcels:   dtos,
+		Count:     len(dtos),
+		Truncated: truncated,
+	}
 }
 
-// AtPoint handles GET /api/v1/parcels/at-point endpoint.
-// It retrieves the parcel that contains the given lat/lng point.
-func (h *ParcelHandler) AtPoint(c *gin.Context) {
+// AlongRoute handles POST /api/v1/parcels/along-route endpoint.
+// It retrieves the parcels within a buffer of the GeoJSON LineString request body.
+func (h *ParcelHandler) AlongRoute(c *gin.Context) {
 	log := middleware.GetLogger(c)
 
 	// Bind and validate query parameters
-	var req AtPointRequest
+	var req AlongRouteRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
 		// Check if it's a validation error
 		if validationErrors, ok := err.(validator.ValidationErrors); ok {
@@ -94,45 +1263,103 @@ func (h *ParcelHandler) AtPoint(c *gin.Context) {
 		return
 	}
 
+	// Set default limit if not provided
+	const defaultAlongRouteLimit = 100
+	if req.Limit == 0 {
+		req.Limit = defaultAlongRouteLimit
+	}
+
+	// Validate the field selection and geometry simplification; queries skip geometry when it is not selected
+	ctx, fields, ok := parseFieldSelection(c, req.Fields, RouteParcel{})
+	if !ok {
+		return
+	}
+	ctx, ok = applySimplification(ctx, c, req.SimplifyTolerance, req.Zoom)
+	if !ok {
+		return
+	}
+	ctx, ok = applyOutputSRID(ctx, c, req.SRID, req.Format)
+	if !ok {
+		return
+	}
+	ctx = repository.WithCounty(ctx, req.County)
+
+	// Read and parse the GeoJSON body
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, MaxAreaBodyBytes))
+	if err != nil {
+		apierrors.BadRequest(c, "Request body is too large or unreadable", nil)
+		return
+	}
+	route, err := models.ParseRouteGeoJSON(body)
+	if err != nil {
+		apierrors.BadRequest(c, "Request body must be a GeoJSON LineString", map[string]interface{}{
+			"reason": err.Error(),
+		})
+		return
+	}
+
 	if log != nil {
-		log.Info("Processing at-point request", map[string]interface{}{
-			"lat": req.Lat,
-			"lng": req.Lng,
+		log.Info("Processing along-route request", map[string]interface{}{
+			"positions": len(route.Coordinates),
+			"buffer":    req.Buffer,
+			"limit":     req.Limit,
 		})
 	}
 
 	// Call service layer
-	parcel, err := h.service.GetParcelAtPoint(c.Request.Context(), req.Lat, req.Lng)
+	parcels, truncated, err := h.service.FindParcelsAlongRoute(ctx, route, req.Buffer, req.Limit)
 	if err != nil {
-		// Handle service-level errors
-		if errors.Is(err, services.ErrInvalidCoordinates) {
-			apierrors.BadRequest(c, err.Error(), nil)
-			return
-		}
-		if errors.Is(err, services.ErrParcelNotFound) {
-			apierrors.NotFound(c, "No property found at this location")
-			return
-		}
-		// Database or other unexpected errors
-		apierrors.InternalServerError(c, "Failed to query parcel data", err)
+		queryFailed(c, "Failed to query parcels along route", err)
 		return
 	}
 
-	// Map TaxParcel model to ParcelData DTO
-	response := ParcelResponse{
-		Parcel: mapTaxParcelToDTO(parcel),
+	middleware.SetResultCount(c, len(parcels))
+
+	// Map repository results to RouteParcel DTOs
+	dtos := make([]RouteParcel, 0, len(parcels))
+	for i := range parcels {
+		dtos = append(dtos, RouteParcel{
+			ParcelData: mapTaxParcelToDTO(&parcels[i].Parcel),
+			Distance:   parcels[i].Distance,
+			Station:    parcels[i].Station,
+		})
+	}
+
+	switch req.Format {
+	case FormatGeoJSON:
+		renderFeatureCollection(c, dtos, fields)
+		return
+	case FormatGeoPackage:
+		renderGeoPackage(c, dtos, fields)
+		return
+	case FormatCSV:
+		renderCSV(c, dtos, fields)
+		return
+	case FormatKML:
+		renderKML(c, dtos, fields)
+		return
+	}
+
+	for _, dto := range dtos {
+		dto.Links = h.links.For(dto.ID)
+	}
+	response := AlongRouteResponse{
+		Parcels:   dtos,
+		Count:     len(dtos),
+		Truncated: truncated,
 	}
 
-	c.JSON(http.StatusOK, response)
+	renderSelectedJSON(c, response, fields, "parcels")
 }
 
-// Nearby handles GET /api/v1/parcels/nearby endpoint.
-// It retrieves parcels within the specified radius of the given lat/lng point.
-func (h *ParcelHandler) Nearby(c *gin.Context) {
+// Sample handles GET /api/v1/admin/parcels/sample endpoint.
+// It returns a random sample of parcels, stratified by county and tax year, with
+// their data quality flags, so the QA team can spot-check an ingested roll.
+func (h *ParcelHandler) Sample(c *gin.Context) {
 	log := middleware.GetLogger(c)
 
 	// Bind and validate query parameters
-	var req NearbyRequest
+	var req SampleRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
 		// Check if it's a validation error
 		if validationErrors, ok := err.(validator.ValidationErrors); ok {
@@ -144,49 +1371,251 @@ func (h *ParcelHandler) Nearby(c *gin.Context) {
 		return
 	}
 
-	// Set default radius if not provided
-	const defaultRadiusMeters = 1000
-	if req.Radius == 0 {
-		req.Radius = defaultRadiusMeters
+	// Set default sample size if not provided
+	const defaultSampleSize = 50
+	if req.N == 0 {
+		req.N = defaultSampleSize
 	}
 
+	// Validate the field selection; the query skips geometry when it is not selected
+	ctx, fields, ok := parseFieldSelection(c, req.Fields, SampledParcel{})
+	if !ok {
+		return
+	}
+	ctx = repository.WithCounty(ctx, req.County)
+
 	if log != nil {
-		log.Info("Processing nearby request", map[string]interface{}{
-			"lat":    req.Lat,
-			"lng":    req.Lng,
-			"radius": req.Radius,
+		log.Info("Processing parcel sample request", map[string]interface{}{
+			"county": req.County,
+			"n":      req.N,
 		})
 	}
 
 	// Call service layer
-	parcels, err := h.service.GetNearbyParcels(c.Request.Context(), req.Lat, req.Lng, req.Radius)
+	samples, err := h.service.SampleParcels(ctx, req.N)
 	if err != nil {
-		// Handle service-level errors
-		if errors.Is(err, services.ErrInvalidCoordinates) {
-			apierrors.BadRequest(c, err.Error(), nil)
-			return
+		queryFailed(c, "Failed to sample parcels", err)
+		return
+	}
+
+	middleware.SetResultCount(c, len(samples))
+
+	// Map repository results to SampledParcel DTOs
+	dtos := make([]SampledParcel, 0, len(samples))
+	for i := range samples {
+		dtos = append(dtos, SampledParcel{
+			ParcelData:   mapTaxParcelToDTO(&samples[i].Parcel),
+			QualityFlags: samples[i].QualityFlags,
+		})
+	}
+
+	switch req.Format {
+	case FormatGeoJSON:
+		renderFeatureCollection(c, dtos, fields)
+		return
+	case FormatGeoPackage:
+		renderGeoPackage(c, dtos, fields)
+		return
+	case FormatCSV:
+		renderCSV(c, dtos, fields)
+		return
+	case FormatKML:
+		renderKML(c, dtos, fields)
+		return
+	}
+
+	for _, dto := range dtos {
+		dto.Links = h.links.For(dto.ID)
+	}
+	response := SampleResponse{
+		Parcels: dtos,
+		Count:   len(dtos),
+	}
+
+	renderSelectedJSON(c, response, fields, "parcels")
+}
+
+// queryFailed writes the response of a failed parcel request by the error's
+// domain kind (apierrors.Respond): 400 for invalid parameters, 504 with a hint
+// when the query exceeded its statement timeout, and 500 with message for
+// other database failures.
+func queryFailed(c *gin.Context, message string, err error) {
+	apierrors.Respond(c, message, domain.Reword(err, domain.ErrTimeout, "Parcel query exceeded its time limit; try a smaller radius or area"))
+}
+
+// parseFieldSelection validates the fields query parameter against the response DTO.
+// It returns the request context, marked to skip geometry in parcel queries when the
+// selection omits it and to decrypt owner contact data when the DTO has it, the
+// selection includes it and the caller's role may see it. It writes a 400
+// response (ok=false) for unknown fields.
+func parseFieldSelection(c *gin.Context, raw string, dto interface{}) (ctx context.Context, fields []string, ok bool) {
+	fields, err := parseFields(raw, dto)
+	if err != nil {
+		apierrors.BadRequest(c, err.Error(), nil)
+		return nil, nil, false
+	}
+
+	ctx = c.Request.Context()
+	if !wantsField(fields, fieldGeometry) {
+		ctx = repository.WithoutGeometry(ctx)
+	}
+	if wantsField(fields, fieldOwnerAddress) && fieldVisible(middleware.GetRole(c), fieldOwnerAddress) &&
+		slices.Contains(jsonFieldNames(dto), fieldOwnerAddress) {
+		ctx = repository.WithContactAccess(ctx)
+	}
+
+	return ctx, fields, true
+}
+
+// parseIDList parses a comma-separated list of positive parcel IDs.
+// Whitespace around each ID is ignored; empty entries are rejected.
+func parseIDList(raw string) ([]uint, error) {
+	parts := strings.Split(raw, ",")
+	ids := make([]uint, 0, len(parts))
+	for _, part := range parts {
+		id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 32)
+		if err != nil || id == 0 {
+			return nil, errors.New("ids must be a comma-separated list of positive integers")
 		}
-		if errors.Is(err, services.ErrInvalidRadius) {
-			apierrors.BadRequest(c, err.Error(), nil)
-			return
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
+}
+
+// mapComparisonToDTO converts a service ParcelComparison to a CompareResponse DTO.
+// Parcels that failed in the service, or whose mapping panics, are reported in
+// Errors by their position in ids; a dropped parcel's column is also removed
+// from the differences.
+func mapComparisonToDTO(c *gin.Context, ids []uint, comparison *services.ParcelComparison) CompareResponse {
+	response := CompareResponse{
+		Parcels:     make([]ComparedParcel, 0, len(comparison.Parcels)),
+		Differences: make([]FieldDiff, 0, len(comparison.Differences)),
+		Errors:      make([]apierrors.ItemError, 0, len(comparison.Failed)),
+	}
+
+	for _, f := range comparison.Failed {
+		if errors.Is(f.Err, services.ErrParcelNotFound) {
+			response.Errors = append(response.Errors, apierrors.NewItemError(f.Index, apierrors.ErrNotFound,
+				fmt.Sprintf("Parcel %d not found", f.ID)))
+			continue
 		}
-		// Database or other unexpected errors
-		apierrors.InternalServerError(c, "Failed to query nearby parcels", err)
-		return
+		response.Errors = append(response.Errors, apierrors.InternalItemError(c, f.Index,
+			fmt.Sprintf("Parcel %d could not be read", f.ID), f.Err))
 	}
 
-	// Map repository results to response DTOs
-	responseParcels := make([]ParcelWithDistance, 0, len(parcels))
-	for _, p := range parcels {
-		responseParcels = append(responseParcels, mapParcelWithDistanceToDTO(&p))
+	kept := make([]int, 0, len(comparison.Parcels))
+	for i := range comparison.Parcels {
+		p := &comparison.Parcels[i]
+		var dto ComparedParcel
+		if err := apierrors.Isolate(c, func() error {
+			dto = mapComparedParcelToDTO(p)
+			return nil
+		}); err != nil {
+			response.Errors = append(response.Errors, apierrors.InternalItemError(c, slices.Index(ids, p.Parcel.ID),
+				fmt.Sprintf("Parcel %d could not be compared", p.Parcel.ID), err))
+			continue
+		}
+		response.Parcels = append(response.Parcels, dto)
+		kept = append(kept, i)
 	}
+	response.Count = len(response.Parcels)
+	slices.SortFunc(response.Errors, func(a, b apierrors.ItemError) int { return a.Index - b.Index })
 
-	response := NearbyResponse{
-		Parcels: responseParcels,
-		Count:   len(responseParcels),
+	for _, d := range comparison.Differences {
+		diff := FieldDiff{
+			Field:   d.Field,
+			Values:  d.Values,
+			Deltas:  d.Deltas,
+			Differs: d.Differs,
+		}
+		if len(kept) < len(comparison.Parcels) {
+			diff = keepDiffColumns(diff, kept)
+		}
+		response.Differences = append(response.Differences, diff)
+	}
+
+	return response
+}
+
+// mapComparedParcelToDTO converts a compared parcel to its column of the comparison.
+func mapComparedParcelToDTO(p *repository.ParcelWithMetrics) ComparedParcel {
+	dto := ComparedParcel{
+		ID:         p.Parcel.ID,
+		PIN:        p.Parcel.PIN,
+		CountyName: p.Parcel.CountyName,
+		Assessment: AssessmentData{
+			TaxYear:   p.Parcel.PYear,
+			YearBuilt: p.Parcel.ImprvActualYearBuilt,
+			MainArea:  p.Parcel.ImprvMainArea,
+		},
+		Shape: GeometrySummary{
+			Centroid:        [2]float64{p.Parcel.CentroidLng, p.Parcel.CentroidLat},
+			Acres:           p.Parcel.Acres,
+			PerimeterMeters: p.PerimeterMeters,
+		},
+	}
+
+	// Handle optional string fields
+	if p.Parcel.OwnerName != nil {
+		dto.OwnerName = *p.Parcel.OwnerName
+	}
+	if p.Parcel.Situs != nil {
+		dto.SitusAddress = *p.Parcel.Situs
+	}
+	if p.Parcel.AsCode != nil {
+		dto.LandUse = *p.Parcel.AsCode
 	}
+	if p.Parcel.StateCd != nil {
+		dto.Assessment.StateCd = *p.Parcel.StateCd
+	}
+	if p.Parcel.MarketArea != nil {
+		dto.Assessment.MarketArea = *p.Parcel.MarketArea
+	}
+	if p.Parcel.Exemptions != nil {
+		dto.Assessment.Exemptions = *p.Parcel.Exemptions
+	}
+	if p.Parcel.TaxingUnits != nil {
+		dto.Assessment.TaxingUnits = *p.Parcel.TaxingUnits
+	}
+
+	return dto
+}
+
+// keepDiffColumns keeps the kept columns of a difference, recomputing whether
+// the values differ and the deltas against the new first column.
+func keepDiffColumns(diff FieldDiff, kept []int) FieldDiff {
+	values := make([]interface{}, len(kept))
+	for i, column := range kept {
+		values[i] = diff.Values[column]
+	}
+	result := FieldDiff{Field: diff.Field, Values: values}
+	for _, v := range values[min(1, len(values)):] {
+		if v != values[0] {
+			result.Differs = true
+		}
+	}
+
+	if diff.Deltas != nil {
+		result.Deltas = make([]*float64, len(values))
+		base, baseOK := firstFloat(values)
+		for i, v := range values {
+			if f, ok := v.(float64); ok && baseOK {
+				delta := f - base
+				result.Deltas[i] = &delta
+			}
+		}
+	}
+	return result
+}
 
-	c.JSON(http.StatusOK, response)
+// firstFloat returns the first of values as a float64, false when there is
+// none or it is unset.
+func firstFloat(values []interface{}) (float64, bool) {
+	if len(values) == 0 {
+		return 0, false
+	}
+	f, ok := values[0].(float64)
+	return f, ok
 }
 
 // mapTaxParcelToDTO converts a TaxParcel model to a ParcelData DTO.
@@ -199,22 +1628,36 @@ func mapTaxParcelToDTO(parcel *models.TaxParcel) *ParcelData {
 	dto := &ParcelData{
 		ID:         parcel.ID,
 		CountyName: parcel.CountyName,
+		Centroid:   [2]float64{parcel.CentroidLng, parcel.CentroidLat},
+		Acres:      parcel.Acres,
+		Attributes: parcel.DerivedAttributes,
+		OwnerID:    parcel.OwnerID,
+		BBox:       parcel.BBox,
 	}
 
 	// Handle optional string fields
 	if parcel.OwnerName != nil {
 		dto.OwnerName = *parcel.OwnerName
 	}
+	if parcel.OwnerAddress != nil {
+		dto.OwnerAddress = *parcel.OwnerAddress
+	}
 	if parcel.Situs != nil {
 		dto.SitusAddress = *parcel.Situs
 	}
 	if parcel.AsCode != nil {
 		dto.LandUse = *parcel.AsCode
 	}
+	dto.Waterfront, dto.WaterFrontageMeters = waterfront(parcel)
+	if parcel.WaterfrontType != nil {
+		dto.WaterfrontType = *parcel.WaterfrontType
+	}
+	if parcel.WaterBodyName != nil {
+		dto.WaterBody = *parcel.WaterBodyName
+	}
 
 	// Note: The current database schema doesn't have all fields from the PRD
 	// - ParcelID: Could use PIN or ObjectID when needed
-	// - Acres: Would need to be calculated from geometry or added to schema
 	// - PropType: Not yet in schema
 	// For now, leaving these as zero values
 
@@ -234,13 +1677,22 @@ func mapParcelWithDistanceToDTO(pwd *repository.ParcelWithDistance) ParcelWithDi
 	dto := ParcelWithDistance{
 		ID:         pwd.Parcel.ID,
 		CountyName: pwd.Parcel.CountyName,
+		Centroid:   [2]float64{pwd.Parcel.CentroidLng, pwd.Parcel.CentroidLat},
+		Acres:      pwd.Parcel.Acres,
 		Distance:   pwd.Distance,
+		Attributes: pwd.Parcel.DerivedAttributes,
+		OwnerID:    pwd.Parcel.OwnerID,
+		BBox:       pwd.Parcel.BBox,
 	}
 
 	// Handle optional string fields
 	if pwd.Parcel.OwnerName != nil {
 		dto.OwnerName = *pwd.Parcel.OwnerName
 	}
+	if pwd.Parcel.OwnerAddress != nil {
+		dto.OwnerAddress = *pwd.Parcel.OwnerAddress
+	}
+	dto.Waterfront, dto.WaterFrontageMeters = waterfront(&pwd.Parcel)
 
 	// Convert geometry to GeoJSON map
 	geojson := make(map[string]interface{})
@@ -251,3 +1703,262 @@ func mapParcelWithDistanceToDTO(pwd *repository.ParcelWithDistance) ParcelWithDi
 
 	return dto
 }
+
+// attributeFilterPrefix starts the query parameters that filter by a derived attribute:
+// attr.<name>=<value> matches a value and attr.<name>.min or attr.<name>.max bound
+// a numeric attribute.
+const attributeFilterPrefix = "attr."
+
+// attributeFilters returns the derived attribute filters among the query
+// parameters, ordered by parameter name. Values are parsed by the service, which
+// knows the attribute types.
+func attributeFilters(query url.Values) []repository.AttributeFilter {
+	var filters []repository.AttributeFilter
+	for _, key := range slices.Sorted(maps.Keys(query)) {
+		name, ok := strings.CutPrefix(key, attributeFilterPrefix)
+		if !ok {
+			continue
+		}
+		op := repository.AttributeEquals
+		if base, found := strings.CutSuffix(name, ".min"); found {
+			name, op = base, repository.AttributeMin
+		} else if base, found := strings.CutSuffix(name, ".max"); found {
+			name, op = base, repository.AttributeMax
+		}
+		filters = append(filters, repository.AttributeFilter{Name: name, Op: op, Value: query.Get(key)})
+	}
+	return filters
+}
+
+// parseInclude parses the comma-separated include parameter. It writes a 400
+// response and returns false for unknown values and for include=zoning,
+// include=flood_zone or include=jurisdictions without the service answering it.
+func (h *ParcelHandler) parseInclude(c *gin.Context, raw string) (includes, bool) {
+	include := includes{}
+	if raw == "" {
+		return include, true
+	}
+	for _, value := range strings.Split(raw, ",") {
+		value = strings.TrimSpace(value)
+		if !slices.Contains(includeValues, value) {
+			apierrors.BadRequest(c, fmt.Sprintf("include must be a comma-separated list of %s, got %q",
+				strings.Join(includeValues, ", "), value), nil)
+			return nil, false
+		}
+		include[value] = true
+	}
+	for _, value := range []string{IncludeZoning, IncludeFloodZone, IncludeJurisdictions} {
+		if include[value] && h.overlays == nil {
+			apierrors.BadRequest(c, "include="+value+" is not enabled on this server", nil)
+			return nil, false
+		}
+	}
+	return include, true
+}
+
+// includedData holds the data of the include parameter values of a request,
+// keyed by parcel ID. Maps of values not requested are nil.
+type includedData struct {
+	amenities     map[uint][]AmenityData
+	zoning        map[uint][]ZoningData
+	floodZones    map[uint][]FloodZoneData
+	jurisdictions map[uint][]JurisdictionData
+}
+
+// apply sets the included data of the parcel; members not requested stay nil.
+func (d *includedData) apply(dto *ParcelData) {
+	dto.Amenities = d.amenities[dto.ID]
+	dto.Zoning = d.zoning[dto.ID]
+	dto.FloodZones = d.floodZones[dto.ID]
+	dto.Jurisdictions = d.jurisdictions[dto.ID]
+}
+
+// lookupIncluded looks up the data of the requested include values for the
+// parcels. It writes an error response and returns false on failure.
+func (h *ParcelHandler) lookupIncluded(ctx context.Context, c *gin.Context, include includes, ids []uint) (*includedData, bool) {
+	var included includedData
+	var ok bool
+	if include[IncludeAmenities] {
+		if included.amenities, ok = h.nearestAmenities(ctx, c, ids); !ok {
+			return nil, false
+		}
+	}
+	if include[IncludeZoning] {
+		if included.zoning, ok = parcelOverlays(ctx, c, h.overlays, repository.ZoningOverlay, ids, mapParcelZoningToDTO); !ok {
+			return nil, false
+		}
+	}
+	if include[IncludeFloodZone] {
+		if included.floodZones, ok = parcelOverlays(ctx, c, h.overlays, repository.FloodZoneOverlay, ids, mapParcelFloodZoneToDTO); !ok {
+			return nil, false
+		}
+	}
+	if include[IncludeJurisdictions] {
+		if included.jurisdictions, ok = parcelOverlays(ctx, c, h.overlays, repository.JurisdictionOverlay, ids, mapJurisdictionToDTO); !ok {
+			return nil, false
+		}
+	}
+	return &included, true
+}
+
+// parcelOverlays looks up the features of the overlay matched to the parcels
+// for an include value and converts them with toDTO, keyed by parcel ID. It
+// writes an error response and returns false on failure.
+func parcelOverlays[T any](ctx context.Context, c *gin.Context, overlays services.LayerService, overlay repository.Overlay,
+	ids []uint, toDTO func(*repository.ParcelOverlay) T) (map[uint][]T, bool) {
+	found, err := overlays.GetParcelOverlays(ctx, overlay, ids)
+	if err != nil {
+		queryFailed(c, "Failed to query parcel "+overlay.Layer, err)
+		return nil, false
+	}
+
+	included := make(map[uint][]T, len(found))
+	for id, matches := range found {
+		dtos := make([]T, 0, len(matches))
+		for i := range matches {
+			dtos = append(dtos, toDTO(&matches[i]))
+		}
+		included[id] = dtos
+	}
+	return included, true
+}
+
+// nearestAmenities looks up the nearest amenities of the parcels for
+// include=amenities, keyed by parcel ID. It writes an error response and
+// returns false on failure.
+func (h *ParcelHandler) nearestAmenities(ctx context.Context, c *gin.Context, ids []uint) (map[uint][]AmenityData, bool) {
+	found, err := h.service.GetNearestAmenities(ctx, ids)
+	if err != nil {
+		queryFailed(c, "Failed to query nearest amenities", err)
+		return nil, false
+	}
+
+	amenities := make(map[uint][]AmenityData, len(found))
+	for id, nearest := range found {
+		dtos := make([]AmenityData, 0, len(nearest))
+		for i := range nearest {
+			dtos = append(dtos, mapNearestAmenityToDTO(&nearest[i]))
+		}
+		amenities[id] = dtos
+	}
+	return amenities, true
+}
+
+// geocodeMissingSitus sets the geocoded address of a parcel without a situs
+// address from its centroid, unless geocoding is disabled or the field is not
+// selected. The address is best effort: a failed lookup, already logged by the
+// service, leaves it out rather than failing the request.
+func (h *ParcelHandler) geocodeMissingSitus(ctx context.Context, dto *ParcelData, fields []string) {
+	if h.geocoding == nil || dto.SitusAddress != "" || !wantsField(fields, fieldGeocodedAddress) {
+		return
+	}
+
+	address, err := h.geocoding.ReverseGeocode(ctx, models.LatLng{Lat: dto.Centroid[1], Lng: dto.Centroid[0]})
+	if err != nil || address == nil {
+		return
+	}
+	dto.GeocodedAddress = mapAddressToDTO(address)
+}
+
+// mapNearestAmenityToDTO converts a repository NearestAmenity to an AmenityData DTO.
+func mapNearestAmenityToDTO(amenity *repository.NearestAmenity) AmenityData {
+	dto := AmenityData{
+		Category: amenity.Category,
+		Location: [2]float64{amenity.Location.Lng, amenity.Location.Lat},
+		Distance: amenity.Distance,
+	}
+	if amenity.Name != nil {
+		dto.Name = *amenity.Name
+	}
+	return dto
+}
+
+// mapParcelZoningToDTO converts a zoning ParcelOverlay to a ZoningData DTO.
+func mapParcelZoningToDTO(zoning *repository.ParcelOverlay) ZoningData {
+	return ZoningData{
+		ID:       zoning.Feature.ID,
+		Code:     stringProperty(&zoning.Feature, "code"),
+		Name:     stringProperty(&zoning.Feature, "name"),
+		Coverage: zoning.Coverage,
+	}
+}
+
+// mapParcelFloodZoneToDTO converts a flood_zones ParcelOverlay to a FloodZoneData DTO.
+func mapParcelFloodZoneToDTO(zone *repository.ParcelOverlay) FloodZoneData {
+	sfha, _ := zone.Feature.Properties["sfha"].(bool)
+	return FloodZoneData{
+		Zone:            stringProperty(&zone.Feature, "zone"),
+		Subtype:         stringProperty(&zone.Feature, "subtype"),
+		CoveragePercent: math.Round(zone.Coverage*1000) / 10,
+		SFHA:            sfha,
+	}
+}
+
+// mapJurisdictionToDTO converts a jurisdictions ParcelOverlay to a JurisdictionData DTO.
+func mapJurisdictionToDTO(jurisdiction *repository.ParcelOverlay) JurisdictionData {
+	return JurisdictionData{
+		ID:   jurisdiction.Feature.ID,
+		Kind: stringProperty(&jurisdiction.Feature, "kind"),
+		Code: stringProperty(&jurisdiction.Feature, "code"),
+		Name: stringProperty(&jurisdiction.Feature, "name"),
+	}
+}
+
+// waterfront returns the waterfront flag and frontage of a parcel, both nil
+// when frontage has not been computed for its county.
+func waterfront(parcel *models.TaxParcel) (*bool, *float64) {
+	if parcel.WaterFrontageMeters == nil {
+		return nil, nil
+	}
+	isWaterfront := *parcel.WaterFrontageMeters > 0
+	return &isWaterfront, parcel.WaterFrontageMeters
+}
+
+// mapParcelWithScoreToDTO converts a repository ParcelWithScore to an AddressCandidate DTO.
+func mapParcelWithScoreToDTO(pws *repository.ParcelWithScore) AddressCandidate {
+	dto := AddressCandidate{
+		ID:         pws.Parcel.ID,
+		CountyName: pws.Parcel.CountyName,
+		Score:      pws.Score,
+	}
+
+	// Handle optional string fields
+	if pws.Parcel.Situs != nil {
+		dto.SitusAddress = *pws.Parcel.Situs
+	}
+	if pws.Parcel.OwnerName != nil {
+		dto.OwnerName = *pws.Parcel.OwnerName
+	}
+
+	// Convert geometry to GeoJSON map
+	geojson := make(map[string]interface{})
+	geojson["type"] = "MultiPolygon"
+	geojson["coordinates"] = pws.Parcel.Geom.Coordinates
+
+	dto.Geometry = geojson
+
+	return dto
+}
+
+// mapParcelSummaryToDTO converts a repository ParcelSummary to an IdentifyData DTO.
+func mapParcelSummaryToDTO(summary *repository.ParcelSummary) *IdentifyData {
+	if summary == nil {
+		return nil
+	}
+
+	dto := &IdentifyData{
+		ID:    summary.ID,
+		PIN:   summary.PIN,
+		Acres: summary.Acres,
+	}
+
+	// Handle optional string fields
+	if summary.OwnerName != nil {
+		dto.OwnerName = *summary.OwnerName
+	}
+	if summary.Situs != nil {
+		dto.SitusAddress = *summary.Situs
+	}
+
+	return dto
+}
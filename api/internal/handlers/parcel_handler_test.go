@@ -2,10 +2,15 @@ package handlers
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -35,9 +40,17 @@ func setupParcelTestRouter(handler *ParcelHandler, log *logger.Logger) *gin.Engi
 	{
 		parcels := v1.Group("/parcels")
 		{
+			parcels.POST("/along-route", handler.AlongRoute)
 			parcels.GET("/at-point", handler.AtPoint)
+			parcels.GET("/compare", handler.Compare)
+			parcels.GET("/identify", middleware.CacheControl(IdentifyCacheControl), handler.Identify)
+			parcels.POST("/intersects", handler.Intersects)
 			parcels.GET("/nearby", handler.Nearby)
+			parcels.GET("/search-address", handler.SearchAddress)
+			parcels.GET("/:id", handler.Get)
 		}
+		v1.POST("/geo/dissolve", handler.Dissolve)
+		v1.GET("/admin/parcels/sample", handler.Sample)
 	}
 
 	return router
@@ -136,9 +149,9 @@ func TestAtPoint_Success(t *testing.T) {
 	defer cleanupTestParcel(t, db, testParcel.ObjectID)
 
 	log := logger.New("test")
-	repo := repository.NewParcelRepository(db)
-	service := services.NewParcelService(repo, log)
-	handler := NewParcelHandler(service)
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
 	router := setupParcelTestRouter(handler, log)
 
 	// Make request with coordinates inside the test parcel
@@ -163,20 +176,76 @@ func TestAtPoint_Success(t *testing.T) {
 	assert.Equal(t, "Montgomery", response.Parcel.CountyName)
 	assert.NotNil(t, response.Parcel.Geometry)
 	assert.Equal(t, "MultiPolygon", response.Parcel.Geometry["type"])
+	assert.Greater(t, response.Parcel.Acres, 0.0)
+	assert.InDelta(t, -95.4500, response.Parcel.Centroid[0], 0.001, "centroid is [lng, lat]")
+	assert.InDelta(t, 30.3477, response.Parcel.Centroid[1], 0.001, "centroid is [lng, lat]")
 
 	// Verify response headers
 	assert.NotEmpty(t, w.Header().Get("X-Request-ID"))
 }
 
+func TestGet_Success(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	testParcel := insertTestParcel(t, db)
+	defer cleanupTestParcel(t, db, testParcel.ObjectID)
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	handler.SetLinks(ParcelLinks{"self": "/api/v1/parcels/:id"})
+	router := setupParcelTestRouter(handler, log)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/parcels/%d", testParcel.ID), nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response ParcelResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.NotNil(t, response.Parcel)
+	assert.Equal(t, testParcel.ID, response.Parcel.ID)
+	assert.Equal(t, "Test Owner", response.Parcel.OwnerName)
+	assert.Equal(t, map[string]string{"self": fmt.Sprintf("/api/v1/parcels/%d", testParcel.ID)}, response.Parcel.Links)
+}
+
+func TestGet_NotFound(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	router := setupParcelTestRouter(handler, log)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/parcels/4000000000", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGet_InvalidID(t *testing.T) {
+	log := logger.New("test")
+	router := setupParcelTestRouter(NewParcelHandler(nil, nil), log)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/parcels/abc", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func TestAtPoint_NotFound(t *testing.T) {
 	// Setup
 	db := setupTestDB(t)
 	defer db.Close()
 
 	log := logger.New("test")
-	repo := repository.NewParcelRepository(db)
-	service := services.NewParcelService(repo, log)
-	handler := NewParcelHandler(service)
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
 	router := setupParcelTestRouter(handler, log)
 
 	// Make request with coordinates far from any parcels
@@ -205,9 +274,9 @@ func TestAtPoint_MissingLatitude(t *testing.T) {
 	defer db.Close()
 
 	log := logger.New("test")
-	repo := repository.NewParcelRepository(db)
-	service := services.NewParcelService(repo, log)
-	handler := NewParcelHandler(service)
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
 	router := setupParcelTestRouter(handler, log)
 
 	// Make request without lat parameter
@@ -234,9 +303,9 @@ func TestAtPoint_MissingLongitude(t *testing.T) {
 	defer db.Close()
 
 	log := logger.New("test")
-	repo := repository.NewParcelRepository(db)
-	service := services.NewParcelService(repo, log)
-	handler := NewParcelHandler(service)
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
 	router := setupParcelTestRouter(handler, log)
 
 	// Make request without lng parameter
@@ -263,9 +332,9 @@ func TestAtPoint_InvalidLatitude_TooLow(t *testing.T) {
 	defer db.Close()
 
 	log := logger.New("test")
-	repo := repository.NewParcelRepository(db)
-	service := services.NewParcelService(repo, log)
-	handler := NewParcelHandler(service)
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
 	router := setupParcelTestRouter(handler, log)
 
 	// Make request with latitude < -90
@@ -291,9 +360,9 @@ func TestAtPoint_InvalidLatitude_TooHigh(t *testing.T) {
 	defer db.Close()
 
 	log := logger.New("test")
-	repo := repository.NewParcelRepository(db)
-	service := services.NewParcelService(repo, log)
-	handler := NewParcelHandler(service)
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
 	router := setupParcelTestRouter(handler, log)
 
 	// Make request with latitude > 90
@@ -319,9 +388,9 @@ func TestAtPoint_InvalidLongitude_TooLow(t *testing.T) {
 	defer db.Close()
 
 	log := logger.New("test")
-	repo := repository.NewParcelRepository(db)
-	service := services.NewParcelService(repo, log)
-	handler := NewParcelHandler(service)
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
 	router := setupParcelTestRouter(handler, log)
 
 	// Make request with longitude < -180
@@ -347,9 +416,9 @@ func TestAtPoint_InvalidLongitude_TooHigh(t *testing.T) {
 	defer db.Close()
 
 	log := logger.New("test")
-	repo := repository.NewParcelRepository(db)
-	service := services.NewParcelService(repo, log)
-	handler := NewParcelHandler(service)
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
 	router := setupParcelTestRouter(handler, log)
 
 	// Make request with longitude > 180
@@ -375,9 +444,9 @@ func TestAtPoint_InvalidParameterType(t *testing.T) {
 	defer db.Close()
 
 	log := logger.New("test")
-	repo := repository.NewParcelRepository(db)
-	service := services.NewParcelService(repo, log)
-	handler := NewParcelHandler(service)
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
 	router := setupParcelTestRouter(handler, log)
 
 	// Make request with non-numeric latitude
@@ -407,9 +476,9 @@ func TestAtPoint_ResponseFormat(t *testing.T) {
 	defer cleanupTestParcel(t, db, testParcel.ObjectID)
 
 	log := logger.New("test")
-	repo := repository.NewParcelRepository(db)
-	service := services.NewParcelService(repo, log)
-	handler := NewParcelHandler(service)
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
 	router := setupParcelTestRouter(handler, log)
 
 	// Make request
@@ -436,15 +505,105 @@ func TestAtPoint_ResponseFormat(t *testing.T) {
 	assert.NotEmpty(t, response.Parcel.Geometry["coordinates"])
 }
 
+func TestAtPoint_WithAccuracy(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer db.Close()
+
+	testParcel := insertTestParcel(t, db)
+	defer cleanupTestParcel(t, db, testParcel.ObjectID)
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	router := setupParcelTestRouter(handler, log)
+
+	t.Run("accuracy circle inside the parcel", func(t *testing.T) {
+		// ~78m from the nearest edge of the test polygon
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/parcels/at-point?lat=30.3477&lng=-95.4500&accuracy=10", nil)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response ParcelResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.NotNil(t, response.Parcel)
+		assert.Equal(t, testParcel.ID, response.Parcel.ID)
+		assert.Empty(t, response.Candidates)
+	})
+
+	t.Run("accuracy circle crossing the boundary", func(t *testing.T) {
+		// ~5m inside the southern edge of the test polygon
+		req, err := http.NewRequest(http.MethodGet,
+			"/api/v1/parcels/at-point?lat=30.34705&lng=-95.4500&accuracy=20&fields=id,probability,contains_point", nil)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response ParcelResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.NotEmpty(t, response.Candidates)
+
+		var found bool
+		for _, candidate := range response.Candidates {
+			if candidate.ParcelData != nil && candidate.ID == testParcel.ID {
+				found = true
+				assert.True(t, candidate.ContainsPoint)
+				assert.Greater(t, candidate.Probability, 0.5)
+				assert.Less(t, candidate.Probability, 1.0)
+			}
+		}
+		assert.True(t, found, "test parcel should be a candidate")
+	})
+
+	t.Run("accuracy out of range", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/parcels/at-point?lat=30.3477&lng=-95.4500&accuracy=500", nil)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAtPointRequest_AccuracyBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, tt := range []struct {
+		url     string
+		wantErr bool
+	}{
+		{url: "/?lat=30.3477&lng=-95.4502"},
+		{url: "/?lat=30.3477&lng=-95.4502&accuracy=12.5"},
+		{url: "/?lat=30.3477&lng=-95.4502&accuracy=100"},
+		{url: "/?lat=30.3477&lng=-95.4502&accuracy=-1", wantErr: true},
+		{url: "/?lat=30.3477&lng=-95.4502&accuracy=100.1", wantErr: true},
+	} {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+		var req AtPointRequest
+		assert.Equal(t, tt.wantErr, c.ShouldBindQuery(&req) != nil, tt.url)
+	}
+}
+
 func TestAtPoint_RequestIDHeader(t *testing.T) {
 	// Setup
 	db := setupTestDB(t)
 	defer db.Close()
 
 	log := logger.New("test")
-	repo := repository.NewParcelRepository(db)
-	service := services.NewParcelService(repo, log)
-	handler := NewParcelHandler(service)
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
 	router := setupParcelTestRouter(handler, log)
 
 	// Make request
@@ -472,9 +631,9 @@ func TestAtPoint_Logging(t *testing.T) {
 	defer cleanupTestParcel(t, db, testParcel.ObjectID)
 
 	log := logger.New("test")
-	repo := repository.NewParcelRepository(db)
-	service := services.NewParcelService(repo, log)
-	handler := NewParcelHandler(service)
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
 	router := setupParcelTestRouter(handler, log)
 
 	// Make request
@@ -561,9 +720,9 @@ func TestNearby_SuccessWithDefaultRadius(t *testing.T) {
 	defer cleanupTestParcel(t, db, testParcel2.ObjectID)
 
 	log := logger.New("test")
-	repo := repository.NewParcelRepository(db)
-	service := services.NewParcelService(repo, log)
-	handler := NewParcelHandler(service)
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
 	router := setupParcelTestRouter(handler, log)
 
 	// Make request without radius (should use default 1000m)
@@ -609,9 +768,9 @@ func TestNearby_SuccessWithCustomRadius(t *testing.T) {
 	defer cleanupTestParcel(t, db, testParcel2.ObjectID)
 
 	log := logger.New("test")
-	repo := repository.NewParcelRepository(db)
-	service := services.NewParcelService(repo, log)
-	handler := NewParcelHandler(service)
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
 	router := setupParcelTestRouter(handler, log)
 
 	// Make request with custom radius of 300m (should find only parcel 1)
@@ -640,9 +799,9 @@ func TestNearby_EmptyResults(t *testing.T) {
 	defer db.Close()
 
 	log := logger.New("test")
-	repo := repository.NewParcelRepository(db)
-	service := services.NewParcelService(repo, log)
-	handler := NewParcelHandler(service)
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
 	router := setupParcelTestRouter(handler, log)
 
 	// Make request in the middle of the Pacific Ocean with small radius (far from any parcels)
@@ -670,9 +829,9 @@ func TestNearby_MissingLatitude(t *testing.T) {
 	defer db.Close()
 
 	log := logger.New("test")
-	repo := repository.NewParcelRepository(db)
-	service := services.NewParcelService(repo, log)
-	handler := NewParcelHandler(service)
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
 	router := setupParcelTestRouter(handler, log)
 
 	// Make request without lat parameter
@@ -699,9 +858,9 @@ func TestNearby_MissingLongitude(t *testing.T) {
 	defer db.Close()
 
 	log := logger.New("test")
-	repo := repository.NewParcelRepository(db)
-	service := services.NewParcelService(repo, log)
-	handler := NewParcelHandler(service)
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
 	router := setupParcelTestRouter(handler, log)
 
 	// Make request without lng parameter
@@ -728,9 +887,9 @@ func TestNearby_InvalidCoordinates(t *testing.T) {
 	defer db.Close()
 
 	log := logger.New("test")
-	repo := repository.NewParcelRepository(db)
-	service := services.NewParcelService(repo, log)
-	handler := NewParcelHandler(service)
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
 	router := setupParcelTestRouter(handler, log)
 
 	testCases := []struct {
@@ -768,9 +927,9 @@ func TestNearby_InvalidRadius(t *testing.T) {
 	defer db.Close()
 
 	log := logger.New("test")
-	repo := repository.NewParcelRepository(db)
-	service := services.NewParcelService(repo, log)
-	handler := NewParcelHandler(service)
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
 	router := setupParcelTestRouter(handler, log)
 
 	testCases := []struct {
@@ -778,6 +937,7 @@ func TestNearby_InvalidRadius(t *testing.T) {
 		url  string
 	}{
 		{"Radius too large", "/api/v1/parcels/nearby?lat=30.3477&lng=-95.4500&radius=5001"},
+		{"Zero radius", "/api/v1/parcels/nearby?lat=30.3477&lng=-95.4500&radius=0"},
 		{"Negative radius", "/api/v1/parcels/nearby?lat=30.3477&lng=-95.4500&radius=-100"},
 	}
 
@@ -801,6 +961,49 @@ func TestNearby_InvalidRadius(t *testing.T) {
 	}
 }
 
+func TestNearby_InvalidFilter(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer db.Close()
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	router := setupParcelTestRouter(handler, log)
+
+	testCases := []struct {
+		name string
+		url  string
+	}{
+		{"Negative acres", "/api/v1/parcels/nearby?lat=30.3477&lng=-95.4500&min_acres=-1"},
+		{"Inverted acres", "/api/v1/parcels/nearby?lat=30.3477&lng=-95.4500&min_acres=5&max_acres=1"},
+		{"Inverted year built", "/api/v1/parcels/nearby?lat=30.3477&lng=-95.4500&min_year_built=2010&max_year_built=1990"},
+		{"Invalid waterfront", "/api/v1/parcels/nearby?lat=30.3477&lng=-95.4500&waterfront=lake"},
+		{"Amenity without distance", "/api/v1/parcels/nearby?lat=30.3477&lng=-95.4500&amenity=fire_station"},
+		{"Unknown amenity", "/api/v1/parcels/nearby?lat=30.3477&lng=-95.4500&amenity=library&amenity_within=3000"},
+		{"Unknown include", "/api/v1/parcels/nearby?lat=30.3477&lng=-95.4500&include=schools"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var response apierrors.ErrorResponse
+			err = json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Contains(t, []string{apierrors.ErrValidation, apierrors.ErrBadRequest}, response.Error.Code)
+		})
+	}
+}
+
 func TestNearby_DistanceOrdering(t *testing.T) {
 	// Setup
 	db := setupTestDB(t)
@@ -817,9 +1020,9 @@ func TestNearby_DistanceOrdering(t *testing.T) {
 	defer cleanupTestParcel(t, db, testParcel3.ObjectID)
 
 	log := logger.New("test")
-	repo := repository.NewParcelRepository(db)
-	service := services.NewParcelService(repo, log)
-	handler := NewParcelHandler(service)
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
 	router := setupParcelTestRouter(handler, log)
 
 	// Make request
@@ -854,9 +1057,9 @@ func TestNearby_ResponseFormat(t *testing.T) {
 	defer cleanupTestParcel(t, db, testParcel.ObjectID)
 
 	log := logger.New("test")
-	repo := repository.NewParcelRepository(db)
-	service := services.NewParcelService(repo, log)
-	handler := NewParcelHandler(service)
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
 	router := setupParcelTestRouter(handler, log)
 
 	// Make request
@@ -891,41 +1094,872 @@ func TestNearby_ResponseFormat(t *testing.T) {
 	}
 }
 
-// Benchmark test for performance validation
-func BenchmarkAtPoint(b *testing.B) {
+func TestNearby_FieldSelection(t *testing.T) {
 	// Setup
-	cfg := config.DatabaseConfig{
-		Host:     "host.docker.internal",
-		Port:     "5432",
-		Name:     "atlas",
-		User:     "postgres",
-		Password: "postgres",
-		PoolMin:  2,
-		PoolMax:  10,
+	db := setupTestDB(t)
+	defer db.Close()
+
+	testParcel := insertTestParcelAtLocation(t, db, 900032, 30.3495, -95.4500)
+	defer cleanupTestParcel(t, db, testParcel.ObjectID)
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	router := setupParcelTestRouter(handler, log)
+
+	// Request attributes only - geometry is skipped in the query and the response
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/parcels/nearby?lat=30.3477&lng=-95.4500&fields=owner_name,distance_meters", nil)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Parcels []map[string]interface{} `json:"parcels"`
+		Count   int                      `json:"count"`
 	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.NotEmpty(t, response.Parcels)
 
-	ctx := context.Background()
-	db, err := database.NewPostgresPool(ctx, cfg)
-	if err != nil {
-		b.Fatalf("Failed to connect to database: %v", err)
+	for _, p := range response.Parcels {
+		assert.Contains(t, p, "id")
+		assert.Contains(t, p, "distance_meters")
+		assert.NotContains(t, p, "geometry")
+		assert.NotContains(t, p, "county_name")
 	}
+}
+
+func TestNearby_CSVFormat(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
 	defer db.Close()
 
+	testParcel := insertTestParcelAtLocation(t, db, 900033, 30.3495, -95.4500)
+	defer cleanupTestParcel(t, db, testParcel.ObjectID)
+
 	log := logger.New("test")
-	repo := repository.NewParcelRepository(db)
-	service := services.NewParcelService(repo, log)
-	handler := NewParcelHandler(service)
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
 	router := setupParcelTestRouter(handler, log)
 
-	// Reset timer after setup
-	b.ResetTimer()
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/parcels/nearby?lat=30.3477&lng=-95.4500&format=csv&fields=owner_name,geometry", nil)
+	require.NoError(t, err)
 
-	for i := 0; i < b.N; i++ {
-		req, err := http.NewRequest(http.MethodGet, "/api/v1/parcels/at-point?lat=30.3477&lng=-95.4500", nil)
-		if err != nil {
-			b.Fatal(err)
-		}
-		w := httptest.NewRecorder()
-		router.ServeHTTP(w, req)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, CSVContentType, w.Header().Get("Content-Type"))
+	assert.NotEmpty(t, w.Header().Get(TotalCountHeader))
+
+	records, err := csv.NewReader(w.Body).ReadAll()
+	require.NoError(t, err)
+	require.Greater(t, len(records), 1)
+	assert.Equal(t, []string{"id", "owner_name", "geometry_wkt"}, records[0])
+	for _, record := range records[1:] {
+		assert.True(t, strings.HasPrefix(record[2], "MULTIPOLYGON ("), "geometry %q", record[2])
+	}
+}
+
+func TestNearby_KMLFormat(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer db.Close()
+
+	testParcel := insertTestParcelAtLocation(t, db, 900034, 30.3495, -95.4500)
+	defer cleanupTestParcel(t, db, testParcel.ObjectID)
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	router := setupParcelTestRouter(handler, log)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/parcels/nearby?lat=30.3477&lng=-95.4500&format=kml&fields=owner_name,geometry", nil)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, KMLContentType, w.Header().Get("Content-Type"))
+	assert.NotEmpty(t, w.Header().Get(TotalCountHeader))
+
+	var document kmlDocument
+	require.NoError(t, xml.Unmarshal(w.Body.Bytes(), &document))
+	require.NotEmpty(t, document.Placemarks)
+	for _, placemark := range document.Placemarks {
+		require.NotNil(t, placemark.Geometry)
+		assert.NotEmpty(t, placemark.Geometry.Polygons)
 	}
 }
+
+func TestNearby_UnknownField(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer db.Close()
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	router := setupParcelTestRouter(handler, log)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/parcels/nearby?lat=30.3477&lng=-95.4500&fields=id,bogus", nil)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "bogus")
+}
+
+func TestAtPoint_GeoJSONFormat(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer db.Close()
+
+	testParcel := insertTestParcel(t, db)
+	defer cleanupTestParcel(t, db, testParcel.ObjectID)
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	router := setupParcelTestRouter(handler, log)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/parcels/at-point?lat=30.3477&lng=-95.4500&format=geojson", nil)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, GeoJSONContentType, w.Header().Get("Content-Type"))
+
+	var collection FeatureCollection
+	err = json.Unmarshal(w.Body.Bytes(), &collection)
+	require.NoError(t, err)
+
+	assert.Equal(t, "FeatureCollection", collection.Type)
+	require.Len(t, collection.Features, 1)
+	assert.Equal(t, "Feature", collection.Features[0].Type)
+	assert.Equal(t, "Test Owner", collection.Features[0].Properties["owner_name"])
+	assert.NotNil(t, collection.Features[0].Geometry)
+}
+
+func TestAtPoint_InvalidFormat(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer db.Close()
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	router := setupParcelTestRouter(handler, log)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/parcels/at-point?lat=30.3477&lng=-95.4500&format=kml", nil)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response apierrors.ErrorResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, apierrors.ErrValidation, response.Error.Code)
+	assert.Contains(t, response.Error.Details, "Format")
+}
+
+func TestIdentify_Success(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer db.Close()
+
+	testParcel := insertTestParcel(t, db)
+	defer cleanupTestParcel(t, db, testParcel.ObjectID)
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	router := setupParcelTestRouter(handler, log)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/parcels/identify?lat=30.3477&lng=-95.4500", nil)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, IdentifyCacheControl, w.Header().Get("Cache-Control"))
+
+	var response IdentifyResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	require.NotNil(t, response.Parcel)
+	assert.Equal(t, testParcel.ID, response.Parcel.ID)
+	assert.Equal(t, testParcel.PIN, response.Parcel.PIN)
+	assert.Equal(t, "Test Owner", response.Parcel.OwnerName)
+	assert.Equal(t, "123 Test St, Montgomery, TX", response.Parcel.SitusAddress)
+	assert.Greater(t, response.Parcel.Acres, 0.0)
+
+	// Geometry must never be included in identify responses
+	assert.NotContains(t, w.Body.String(), "geometry")
+}
+
+func TestIdentify_NotFound(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer db.Close()
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	router := setupParcelTestRouter(handler, log)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/parcels/identify?lat=0.0&lng=0.0", nil)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions - errors must not carry the identify cache policy
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, w.Header().Get("Cache-Control"))
+}
+
+func TestSearchAddress_Success(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer db.Close()
+
+	// Situs is "900021 Test St, Montgomery, TX"
+	testParcel := insertTestParcelAtLocation(t, db, 900021, 30.3495, -95.4500)
+	defer cleanupTestParcel(t, db, testParcel.ObjectID)
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	router := setupParcelTestRouter(handler, log)
+
+	// Make request with a partial, lower-case address
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/parcels/search-address?q=900021+test+st", nil)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response SearchAddressResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	require.GreaterOrEqual(t, response.Count, 1)
+	assert.Equal(t, response.Count, len(response.Candidates))
+	assert.Equal(t, testParcel.ID, response.Candidates[0].ID)
+	assert.Equal(t, "900021 Test St, Montgomery, TX", response.Candidates[0].SitusAddress)
+	assert.Greater(t, response.Candidates[0].Score, 0.0)
+	assert.Equal(t, "MultiPolygon", response.Candidates[0].Geometry["type"])
+}
+
+func TestSearchAddress_MissingQuery(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer db.Close()
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	router := setupParcelTestRouter(handler, log)
+
+	// Make request without q parameter
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/parcels/search-address", nil)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response apierrors.ErrorResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, apierrors.ErrValidation, response.Error.Code)
+}
+
+func TestSearchAddress_QueryTooShort(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer db.Close()
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	router := setupParcelTestRouter(handler, log)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/parcels/search-address?q=ab", nil)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response apierrors.ErrorResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, apierrors.ErrBadRequest, response.Error.Code)
+	assert.Contains(t, response.Error.Message, "address query")
+}
+
+// Benchmark test for performance validation
+func BenchmarkAtPoint(b *testing.B) {
+	// Setup
+	cfg := config.DatabaseConfig{
+		Host:     "host.docker.internal",
+		Port:     "5432",
+		Name:     "atlas",
+		User:     "postgres",
+		Password: "postgres",
+		PoolMin:  2,
+		PoolMax:  10,
+	}
+
+	ctx := context.Background()
+	db, err := database.NewPostgresPool(ctx, cfg)
+	if err != nil {
+		b.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	router := setupParcelTestRouter(handler, log)
+
+	// Reset timer after setup
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/parcels/at-point?lat=30.3477&lng=-95.4500", nil)
+		if err != nil {
+			b.Fatal(err)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+	}
+}
+
+func TestCompare_Success(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer db.Close()
+
+	testParcel1 := insertTestParcelAtLocation(t, db, 900041, 30.3495, -95.4500)
+	defer cleanupTestParcel(t, db, testParcel1.ObjectID)
+	testParcel2 := insertTestParcelAtLocation(t, db, 900042, 30.3522, -95.4500)
+	defer cleanupTestParcel(t, db, testParcel2.ObjectID)
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	router := setupParcelTestRouter(handler, log)
+
+	// Request in reverse id order to verify request order is preserved
+	url := fmt.Sprintf("/api/v1/parcels/compare?ids=%d,%d", testParcel2.ID, testParcel1.ID)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response CompareResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	require.Equal(t, 2, response.Count)
+	assert.Equal(t, testParcel2.ID, response.Parcels[0].ID)
+	assert.Equal(t, testParcel1.ID, response.Parcels[1].ID)
+	assert.Greater(t, response.Parcels[0].Shape.Acres, 0.0)
+	assert.NotEmpty(t, response.Differences)
+}
+
+func TestDissolve_Success(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer db.Close()
+
+	// Two 20m squares sharing an edge, and a third one apart from them
+	west := insertTestParcelAtLocation(t, db, 900051, 30.3600, -95.4500)
+	defer cleanupTestParcel(t, db, west.ObjectID)
+	east := insertTestParcelAtLocation(t, db, 900052, 30.3600, -95.4498)
+	defer cleanupTestParcel(t, db, east.ObjectID)
+	apart := insertTestParcelAtLocation(t, db, 900053, 30.3650, -95.4500)
+	defer cleanupTestParcel(t, db, apart.ObjectID)
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	router := setupParcelTestRouter(handler, log)
+
+	body := fmt.Sprintf(`{"ids":[%d,%d,%d]}`, apart.ID, east.ID, west.ID)
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/geo/dissolve", strings.NewReader(body))
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Geometry struct {
+			Type        string           `json:"type"`
+			Coordinates [][][][2]float64 `json:"coordinates"`
+		} `json:"geometry"`
+		DissolveResponse
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+	assert.Equal(t, 3, response.Count)
+	assert.Equal(t, "MultiPolygon", response.Geometry.Type)
+	// The adjacent pair merges into one polygon without holes
+	require.Len(t, response.Geometry.Coordinates, 2)
+	for _, polygon := range response.Geometry.Coordinates {
+		assert.Len(t, polygon, 1)
+	}
+	assert.Greater(t, response.Acres, 0.0)
+	assert.Greater(t, response.PerimeterMeters, 0.0)
+}
+
+func TestDissolve_InvalidRequest(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer db.Close()
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	router := setupParcelTestRouter(handler, log)
+
+	testCases := []struct {
+		body   string
+		status int
+	}{
+		{`{}`, http.StatusBadRequest},
+		{`{"ids":[]}`, http.StatusBadRequest},
+		{`{"ids":[1,1]}`, http.StatusBadRequest},
+		{`{"ids":["abc"]}`, http.StatusBadRequest},
+		{`{"ids":[2147483000]}`, http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.body, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "/api/v1/geo/dissolve", strings.NewReader(tc.body))
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.status, w.Code)
+		})
+	}
+}
+
+func TestCompare_InvalidIDs(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer db.Close()
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	router := setupParcelTestRouter(handler, log)
+
+	testCases := []string{
+		"/api/v1/parcels/compare",
+		"/api/v1/parcels/compare?ids=1",
+		"/api/v1/parcels/compare?ids=1,abc",
+		"/api/v1/parcels/compare?ids=1,1",
+		"/api/v1/parcels/compare?ids=1,2,3,4,5,6",
+	}
+
+	for _, url := range testCases {
+		t.Run(url, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestParseIDList(t *testing.T) {
+	ids, err := parseIDList(" 3, 1 ,2")
+	require.NoError(t, err)
+	assert.Equal(t, []uint{3, 1, 2}, ids)
+
+	for _, raw := range []string{"", "1,", "1,-2", "0,1", "1.5,2"} {
+		_, err := parseIDList(raw)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestMapComparisonToDTO_PartialFailure(t *testing.T) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/parcels/compare?ids=9,1,2,3", nil)
+	delta := func(f float64) *float64 { return &f }
+	comparison := &services.ParcelComparison{
+		Parcels: []repository.ParcelWithMetrics{
+			{Parcel: models.TaxParcel{ID: 1, Acres: 1}},
+			{Parcel: models.TaxParcel{ID: 3, Acres: 4}},
+		},
+		Failed: []services.CompareFailure{
+			{Index: 0, ID: 9, Err: fmt.Errorf("%w: id 9", services.ErrParcelNotFound)},
+			{Index: 2, ID: 2, Err: errors.New("failed to parse geometry for parcel 2")},
+		},
+		Differences: []services.AttributeDifference{
+			{Field: "acres", Values: []interface{}{1.0, 4.0}, Deltas: []*float64{delta(0), delta(3)}, Differs: true},
+		},
+	}
+
+	response := mapComparisonToDTO(c, []uint{9, 1, 2, 3}, comparison)
+
+	assert.Equal(t, 2, response.Count)
+	assert.Equal(t, []apierrors.ItemError{
+		{Index: 0, Code: apierrors.ErrNotFound, Message: "Parcel 9 not found"},
+		{Index: 2, Code: apierrors.ErrInternalServer, Message: "Parcel 2 could not be read"},
+	}, response.Errors)
+	assert.Equal(t, http.StatusMultiStatus, apierrors.BatchStatus(response.Errors))
+	require.Len(t, response.Differences, 1)
+	assert.Len(t, response.Differences[0].Values, 2)
+}
+
+func TestKeepDiffColumns(t *testing.T) {
+	delta := func(f float64) *float64 { return &f }
+	diff := FieldDiff{
+		Field:   "acres",
+		Values:  []interface{}{1.0, 4.0, 4.0},
+		Deltas:  []*float64{delta(0), delta(3), delta(3)},
+		Differs: true,
+	}
+
+	kept := keepDiffColumns(diff, []int{1, 2})
+
+	assert.Equal(t, []interface{}{4.0, 4.0}, kept.Values)
+	assert.False(t, kept.Differs)
+	require.Len(t, kept.Deltas, 2)
+	assert.Equal(t, 0.0, *kept.Deltas[1], "deltas are against the new first column")
+
+	text := keepDiffColumns(FieldDiff{Field: "owner_name", Values: []interface{}{"A", "B"}, Differs: true}, []int{1})
+	assert.Equal(t, []interface{}{"B"}, text.Values)
+	assert.Nil(t, text.Deltas)
+
+	none := keepDiffColumns(diff, []int{})
+	assert.Empty(t, none.Values)
+	assert.Len(t, none.Deltas, 0)
+}
+
+func TestIntersects_Success(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer db.Close()
+
+	testParcel := insertTestParcelAtLocation(t, db, 900051, 30.3495, -95.4500)
+	defer cleanupTestParcel(t, db, testParcel.ObjectID)
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	router := setupParcelTestRouter(handler, log)
+
+	// Draw a square around the test parcel
+	body := `{"type":"Polygon","coordinates":[[[-95.46,30.34],[-95.44,30.34],[-95.44,30.36],[-95.46,30.36],[-95.46,30.34]]]}`
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/parcels/intersects", strings.NewReader(body))
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response IntersectsResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	require.GreaterOrEqual(t, response.Count, 1)
+	found := false
+	for _, p := range response.Parcels {
+		if p.ID == testParcel.ID {
+			found = true
+		}
+	}
+	assert.True(t, found, "test parcel should intersect the drawn area")
+}
+
+func TestIntersects_InvalidBody(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer db.Close()
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	router := setupParcelTestRouter(handler, log)
+
+	testCases := map[string]string{
+		"point":          `{"type":"Point","coordinates":[-95.45,30.35]}`,
+		"open ring":      `{"type":"Polygon","coordinates":[[[-95.46,30.34],[-95.44,30.34],[-95.44,30.36],[-95.46,30.36]]]}`,
+		"self-intersect": `{"type":"Polygon","coordinates":[[[-95.46,30.34],[-95.44,30.36],[-95.44,30.34],[-95.46,30.36],[-95.46,30.34]]]}`,
+		"not json":       `not json`,
+	}
+
+	for name, body := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "/api/v1/parcels/intersects", strings.NewReader(body))
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestAlongRoute_Success(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer db.Close()
+
+	testParcel := insertTestParcelAtLocation(t, db, 900052, 30.3495, -95.4500)
+	defer cleanupTestParcel(t, db, testParcel.ObjectID)
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	router := setupParcelTestRouter(handler, log)
+
+	// Draw a road passing through the test parcel
+	body := `{"type":"LineString","coordinates":[[-95.46,30.3495],[-95.44,30.3495]]}`
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/parcels/along-route?buffer=20", strings.NewReader(body))
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response AlongRouteResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	require.GreaterOrEqual(t, response.Count, 1)
+	found := false
+	for _, p := range response.Parcels {
+		if p.ID == testParcel.ID {
+			found = true
+			assert.InDelta(t, 0, p.Distance, 0.01, "route crosses the test parcel")
+		}
+	}
+	assert.True(t, found, "test parcel should be in the route corridor")
+}
+
+func TestAlongRoute_InvalidRequest(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer db.Close()
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	router := setupParcelTestRouter(handler, log)
+
+	route := `{"type":"LineString","coordinates":[[-95.46,30.35],[-95.44,30.35]]}`
+	testCases := []struct {
+		name  string
+		query string
+		body  string
+	}{
+		{"missing buffer", "", route},
+		{"buffer too large", "?buffer=5000", route},
+		{"polygon body", "?buffer=20", `{"type":"Polygon","coordinates":[[[-95.46,30.34],[-95.44,30.34],[-95.44,30.36],[-95.46,30.34]]]}`},
+		{"single position", "?buffer=20", `{"type":"LineString","coordinates":[[-95.46,30.35]]}`},
+		{"not json", "?buffer=20", `not json`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "/api/v1/parcels/along-route"+tc.query, strings.NewReader(tc.body))
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestSample_Success(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer db.Close()
+
+	testParcel := insertTestParcelAtLocation(t, db, 900053, 30.3495, -95.4500)
+	defer cleanupTestParcel(t, db, testParcel.ObjectID)
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	router := setupParcelTestRouter(handler, log)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/admin/parcels/sample?n=5", nil)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response SampleResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	require.GreaterOrEqual(t, response.Count, 1)
+	assert.LessOrEqual(t, response.Count, 5)
+	assert.Len(t, response.Parcels, response.Count)
+	for _, p := range response.Parcels {
+		assert.NotNil(t, p.QualityFlags, "flags should be an empty list rather than null")
+		assert.NotNil(t, p.Geometry)
+	}
+}
+
+func TestSample_InvalidRequest(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer db.Close()
+
+	log := logger.New("test")
+	repo := repository.NewParcelRepository(db, nil, 0)
+	service := services.NewParcelService(repo, nil, log)
+	handler := NewParcelHandler(service, services.NewLocationService(nil, log))
+	router := setupParcelTestRouter(handler, log)
+
+	testCases := map[string]string{
+		"n too large":    "?n=501",
+		"n not a number": "?n=ten",
+		"unknown field":  "?fields=bogus",
+		"unknown format": "?format=shp",
+	}
+
+	for name, query := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/api/v1/admin/parcels/sample"+query, nil)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestAttributeFilters(t *testing.T) {
+	query, err := url.ParseQuery("lat=30.35&attr.improvement_ratio.min=1500&attr.improvement_ratio.max=3000&attr.homestead=true")
+	require.NoError(t, err)
+
+	assert.Equal(t, []repository.AttributeFilter{
+		{Name: "homestead", Op: repository.AttributeEquals, Value: "true"},
+		{Name: "improvement_ratio", Op: repository.AttributeMax, Value: "3000"},
+		{Name: "improvement_ratio", Op: repository.AttributeMin, Value: "1500"},
+	}, attributeFilters(query))
+	assert.Empty(t, attributeFilters(url.Values{"lat": {"30.35"}}))
+}
+
+func TestMapTaxParcelToDTO_ComputedMetrics(t *testing.T) {
+	parcel := &models.TaxParcel{
+		ID:          5,
+		CountyName:  "Montgomery",
+		Acres:       2.5,
+		CentroidLat: 30.35,
+		CentroidLng: -95.45,
+	}
+
+	dto := mapTaxParcelToDTO(parcel)
+
+	assert.Equal(t, 2.5, dto.Acres)
+	assert.Equal(t, [2]float64{-95.45, 30.35}, dto.Centroid)
+
+	withDistance := mapParcelWithDistanceToDTO(&repository.ParcelWithDistance{Parcel: *parcel, Distance: 12})
+	assert.Equal(t, 2.5, withDistance.Acres)
+	assert.Equal(t, [2]float64{-95.45, 30.35}, withDistance.Centroid)
+}
+
+func TestMapTaxParcelToDTO_DerivedAttributes(t *testing.T) {
+	attributes := map[string]any{"improvement_ratio": 1875.5, "homestead": true}
+	parcel := &models.TaxParcel{ID: 5, CountyName: "Montgomery", DerivedAttributes: attributes}
+
+	assert.Equal(t, attributes, mapTaxParcelToDTO(parcel).Attributes)
+	assert.Equal(t, attributes, mapParcelWithDistanceToDTO(&repository.ParcelWithDistance{Parcel: *parcel}).Attributes)
+
+	body, err := json.Marshal(mapTaxParcelToDTO(&models.TaxParcel{ID: 6}))
+	require.NoError(t, err)
+	assert.NotContains(t, string(body), "attributes")
+}
+
+func TestMapTaxParcelToDTO_BBox(t *testing.T) {
+	bbox := []float64{-95.46, 30.34, -95.44, 30.36}
+	parcel := &models.TaxParcel{ID: 5, CountyName: "Montgomery", BBox: bbox}
+
+	assert.Equal(t, bbox, mapTaxParcelToDTO(parcel).BBox)
+	assert.Equal(t, bbox, mapParcelWithDistanceToDTO(&repository.ParcelWithDistance{Parcel: *parcel}).BBox)
+
+	body, err := json.Marshal(mapTaxParcelToDTO(&models.TaxParcel{ID: 6}))
+	require.NoError(t, err)
+	assert.NotContains(t, string(body), "bbox", "omitted for empty geometries")
+}
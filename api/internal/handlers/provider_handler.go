@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stwalsh4118/atlas/api/internal/outbound"
+)
+
+// ProviderStats reports the outbound client counters of this instance.
+// *outbound.Registry implements it.
+type ProviderStats interface {
+	Stats() []outbound.Stats
+}
+
+// ProviderHandler reports the health of the external providers Atlas calls.
+type ProviderHandler struct {
+	providers ProviderStats
+}
+
+// NewProviderHandler creates a new ProviderHandler instance.
+func NewProviderHandler(providers ProviderStats) *ProviderHandler {
+	return &ProviderHandler{
+		providers: providers,
+	}
+}
+
+// ProvidersResponse represents the response for the providers endpoint.
+type ProvidersResponse struct {
+	Providers []outbound.Stats `json:"providers"`
+	Count     int              `json:"count"`
+}
+
+// Providers handles GET /health/providers endpoint.
+// It lists each configured provider with its circuit breaker state and request
+// counters since this instance started.
+func (h *ProviderHandler) Providers(c *gin.Context) {
+	stats := h.providers.Stats()
+	c.JSON(http.StatusOK, ProvidersResponse{
+		Providers: stats,
+		Count:     len(stats),
+	})
+}
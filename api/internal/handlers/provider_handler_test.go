@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/outbound"
+)
+
+func TestProviderHandler_Providers(t *testing.T) {
+	registry := outbound.NewRegistry()
+	registry.Register(outbound.NewClient("what3words", outbound.Options{}))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+	router.GET("/health/providers", NewProviderHandler(registry).Providers)
+
+	req := httptest.NewRequest(http.MethodGet, "/health/providers", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response ProvidersResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Count)
+	require.Len(t, response.Providers, 1)
+	assert.Equal(t, "what3words", response.Providers[0].Name)
+	assert.Equal(t, outbound.CircuitClosed, response.Providers[0].Circuit)
+}
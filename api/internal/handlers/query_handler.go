@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// QueryCacheControl is the Cache-Control policy for analyst query results,
+// which may hold fields hidden from other callers.
+const QueryCacheControl = "no-store"
+
+// maxQueryBodyBytes caps query request bodies, which only carry parameters.
+const maxQueryBodyBytes = 64 << 10
+
+// QueryHandler handles analyst query HTTP requests.
+type QueryHandler struct {
+	service services.QueryService
+}
+
+// NewQueryHandler creates a new QueryHandler instance.
+func NewQueryHandler(service services.QueryService) *QueryHandler {
+	return &QueryHandler{
+		service: service,
+	}
+}
+
+// RunQueryRequest represents the request body for running a query template.
+// Params holds a value for every parameter the template declares.
+type RunQueryRequest struct {
+	Params map[string]any `json:"params"`
+}
+
+// QueryTemplatesResponse represents the response for listing query templates.
+type QueryTemplatesResponse struct {
+	Queries []config.QueryTemplate `json:"queries"`
+}
+
+// QueryResultResponse represents the response for a query run.
+type QueryResultResponse struct {
+	Result *models.QueryResult `json:"result"`
+	Query  string              `json:"query"`
+}
+
+// List handles GET /api/v1/queries endpoint.
+func (h *QueryHandler) List(c *gin.Context) {
+	c.JSON(http.StatusOK, QueryTemplatesResponse{Queries: h.service.Templates()})
+}
+
+// Run handles POST /api/v1/queries/:name endpoint.
+// The body may be omitted for templates without parameters.
+func (h *QueryHandler) Run(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxQueryBodyBytes)
+
+	var req RunQueryRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			// Malformed JSON, wrong field types or a body over maxQueryBodyBytes
+			apierrors.BadRequest(c, "Invalid request body", nil)
+			return
+		}
+	}
+
+	var caller string
+	if key := middleware.GetAPIKey(c); key != nil {
+		caller = key.Prefix
+	}
+
+	name := c.Param("name")
+	result, err := h.service.Run(c.Request.Context(), name, req.Params, caller)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, QueryResultResponse{Query: name, Result: result})
+}
+
+// handleError maps query service errors to HTTP responses.
+func (h *QueryHandler) handleError(c *gin.Context, err error) {
+	apierrors.Respond(c, "Failed to run query", err)
+}
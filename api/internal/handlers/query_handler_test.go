@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MockQueryService is a mock implementation of QueryService for testing
+type MockQueryService struct {
+	mock.Mock
+}
+
+func (m *MockQueryService) Templates() []config.QueryTemplate {
+	args := m.Called()
+	templates, _ := args.Get(0).([]config.QueryTemplate)
+	return templates
+}
+
+func (m *MockQueryService) Run(ctx context.Context, name string, params map[string]any, caller string) (*models.QueryResult, error) {
+	args := m.Called(ctx, name, params, caller)
+	result, _ := args.Get(0).(*models.QueryResult)
+	return result, args.Error(1)
+}
+
+// setupQueryTestRouter creates a test router whose requests carry an admin API key.
+func setupQueryTestRouter(handler *QueryHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.APIKeyKey, &models.APIKey{ID: 2, TenantID: "acme", Prefix: "atlas_adm", Role: models.APIKeyRoleAdmin})
+		c.Next()
+	})
+
+	router.GET("/api/v1/queries", handler.List)
+	router.POST("/api/v1/queries/:name", handler.Run)
+
+	return router
+}
+
+func TestQueryHandler_List(t *testing.T) {
+	mockService := new(MockQueryService)
+	router := setupQueryTestRouter(NewQueryHandler(mockService))
+	mockService.On("Templates").Return([]config.QueryTemplate{{
+		Name: "county_totals", Description: "Parcels per county", SQL: "SELECT 1",
+		Params: []config.QueryParam{{Name: "state_fips", Type: config.QueryParamText}},
+	}})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/queries", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response QueryTemplatesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Queries, 1)
+	assert.Equal(t, "county_totals", response.Queries[0].Name)
+	assert.Equal(t, config.QueryParamText, response.Queries[0].Params[0].Type)
+}
+
+func TestQueryHandler_Run(t *testing.T) {
+	t.Run("returns the result", func(t *testing.T) {
+		mockService := new(MockQueryService)
+		router := setupQueryTestRouter(NewQueryHandler(mockService))
+		mockService.On("Run", mock.Anything, "county_totals", map[string]any{"state_fips": "48", "min_acres": 1.5}, "atlas_adm").
+			Return(&models.QueryResult{Columns: []string{"county_name", "count"}, Rows: [][]any{{"Travis", 12}}, RowCount: 1}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/queries/county_totals",
+			strings.NewReader(`{"params":{"state_fips":"48","min_acres":1.5}}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"query":"county_totals","result":{"columns":["county_name","count"],"rows":[["Travis",12]],"rowCount":1,"truncated":false}}`, w.Body.String())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("body is optional", func(t *testing.T) {
+		mockService := new(MockQueryService)
+		router := setupQueryTestRouter(NewQueryHandler(mockService))
+		mockService.On("Run", mock.Anything, "county_totals", map[string]any(nil), "atlas_adm").
+			Return(&models.QueryResult{Columns: []string{}, Rows: [][]any{}}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/queries/county_totals", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		mockService := new(MockQueryService)
+		router := setupQueryTestRouter(NewQueryHandler(mockService))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/queries/county_totals", strings.NewReader(`{"params":["48"]}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Run")
+	})
+
+	errorCases := []struct {
+		err    error
+		name   string
+		status int
+	}{
+		{services.ErrQueryNotFound, "unknown template", http.StatusNotFound},
+		{fmt.Errorf("%w: state_fips is required", services.ErrInvalidQueryParams), "invalid params", http.StatusBadRequest},
+		{fmt.Errorf("%w after 30s", services.ErrQueryTimeout), "timeout", http.StatusGatewayTimeout},
+		{errors.New("connection refused"), "database error", http.StatusInternalServerError},
+	}
+	for _, tt := range errorCases {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockQueryService)
+			router := setupQueryTestRouter(NewQueryHandler(mockService))
+			mockService.On("Run", mock.Anything, "county_totals", mock.Anything, "atlas_adm").Return(nil, tt.err)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/queries/county_totals", strings.NewReader(`{}`)))
+
+			assert.Equal(t, tt.status, w.Code)
+			if tt.status == http.StatusInternalServerError {
+				assert.NotContains(t, w.Body.String(), "connection refused")
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/jobs"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// ResultHandler handles async result HTTP requests.
+// Expensive queries called with async=true answer 202 with a result ID; clients
+// poll the result's status, or wait for its callback, and then fetch its data.
+type ResultHandler struct {
+	service services.ResultService
+}
+
+// NewResultHandler creates a new ResultHandler instance.
+func NewResultHandler(service services.ResultService) *ResultHandler {
+	return &ResultHandler{
+		service: service,
+	}
+}
+
+// ResultJob represents the status of an async result.
+// DataURL is set once the result has succeeded; Error once it has failed.
+type ResultJob struct {
+	CreatedAt  time.Time   `json:"created_at"`
+	FinishedAt *time.Time  `json:"finished_at,omitempty"`
+	ID         string      `json:"id"`
+	Operation  string      `json:"operation"`
+	Status     jobs.Status `json:"status"`
+	Error      string      `json:"error,omitempty"`
+	DataURL    string      `json:"data_url,omitempty"`
+}
+
+// ResultResponse represents the response for the result endpoints and the 202
+// of queries run in async mode.
+type ResultResponse struct {
+	Result *ResultJob `json:"result"`
+}
+
+// Get handles GET /api/v1/results/:id endpoint.
+func (h *ResultHandler) Get(c *gin.Context) {
+	job, err := h.service.GetResult(c.Param("id"))
+	if err != nil {
+		handleResultError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ResultResponse{Result: mapJobToResult(job)})
+}
+
+// Data handles GET /api/v1/results/:id/data endpoint.
+// It returns the stored response of the query, as the query would have answered
+// it synchronously. Responds 409 Conflict while the query is still running or
+// if it failed.
+func (h *ResultHandler) Data(c *gin.Context) {
+	job, err := h.service.GetResult(c.Param("id"))
+	if err != nil {
+		handleResultError(c, err)
+		return
+	}
+
+	if job.Status != jobs.StatusSucceeded || job.Result == nil {
+		apierrors.Conflict(c, "Result is "+string(job.Status)+", not ready")
+		return
+	}
+
+	c.Data(http.StatusOK, job.Result.ContentType, job.Result.Data)
+}
+
+// submitAsync queues fn as an async result of the given operation and responds
+// 202 Accepted with its status. fn runs with the values of ctx, such as the
+// county filter and the field selection, but under the job's timeout rather
+// than the request's. Responds 400 when results is nil: async mode needs the
+// job manager.
+func submitAsync(c *gin.Context, ctx context.Context, results services.ResultService, operation, callbackURL string, fn func(ctx context.Context) (any, error)) {
+	if results == nil {
+		apierrors.BadRequest(c, "Async mode is not enabled on this server", nil)
+		return
+	}
+
+	job, err := results.Submit(operation, callbackURL, func(jobCtx context.Context) (any, error) {
+		return fn(asyncContext{Context: jobCtx, values: ctx})
+	})
+	if err != nil {
+		handleResultError(c, err)
+		return
+	}
+
+	if log := middleware.GetLogger(c); log != nil {
+		log.Info("Async result requested", map[string]interface{}{
+			"job_id":    job.ID,
+			"operation": operation,
+		})
+	}
+
+	c.Header("Location", resultPath(job.ID))
+	c.JSON(http.StatusAccepted, ResultResponse{Result: mapJobToResult(job)})
+}
+
+// checkAsync writes a 400 response (ok=false) for a callback_url without async=true.
+func checkAsync(c *gin.Context, async bool, callbackURL string) bool {
+	if callbackURL != "" && !async {
+		apierrors.BadRequest(c, "callback_url requires async=true", nil)
+		return false
+	}
+	return true
+}
+
+// asyncContext carries the values of the request that queued a job with the
+// deadline and cancellation of the job, which outlives the request.
+type asyncContext struct {
+	context.Context
+	values context.Context
+}
+
+// Value returns the request's value for key.
+func (c asyncContext) Value(key any) any {
+	return c.values.Value(key)
+}
+
+// handleResultError maps async result service errors to HTTP responses.
+func handleResultError(c *gin.Context, err error) {
+	err = domain.Reword(err, domain.ErrNotFound, "Result not found or expired")
+	err = domain.Reword(err, domain.ErrUnavailable, "Too many queries are running in the background, try again shortly")
+	apierrors.Respond(c, "Failed to process result request", err)
+}
+
+// resultPath returns the status URL of an async result.
+func resultPath(id string) string {
+	return "/api/v1/results/" + id
+}
+
+// mapJobToResult converts a job snapshot into the result status response.
+func mapJobToResult(job jobs.Job) *ResultJob {
+	result := &ResultJob{
+		ID:         job.ID,
+		Operation:  strings.TrimPrefix(job.Kind, services.ResultJobKindPrefix),
+		Status:     job.Status,
+		CreatedAt:  job.CreatedAt,
+		FinishedAt: job.FinishedAt,
+		Error:      job.Error,
+	}
+	if job.Status == jobs.StatusSucceeded {
+		result.DataURL = resultPath(job.ID) + "/data"
+	}
+	return result
+}
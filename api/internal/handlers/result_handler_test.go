@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/jobs"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MockResultService is a mock implementation of ResultService for testing.
+// Submit records the submitted function so tests can run the job themselves.
+type MockResultService struct {
+	mock.Mock
+	submitted func(ctx context.Context) (any, error)
+}
+
+func (m *MockResultService) Submit(operation, callbackURL string, fn func(ctx context.Context) (any, error)) (jobs.Job, error) {
+	m.submitted = fn
+	args := m.Called(operation, callbackURL)
+	return args.Get(0).(jobs.Job), args.Error(1)
+}
+
+func (m *MockResultService) GetResult(id string) (jobs.Job, error) {
+	args := m.Called(id)
+	return args.Get(0).(jobs.Job), args.Error(1)
+}
+
+// setupResultTestRouter creates a test router with result handlers.
+func setupResultTestRouter(handler *ResultHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+
+	results := router.Group("/api/v1/results")
+	{
+		results.GET("/:id", handler.Get)
+		results.GET("/:id/data", handler.Data)
+	}
+
+	return router
+}
+
+func TestResultHandler_Get(t *testing.T) {
+	t.Run("succeeded result links its data", func(t *testing.T) {
+		mockService := new(MockResultService)
+		router := setupResultTestRouter(NewResultHandler(mockService))
+
+		finished := time.Now().UTC()
+		mockService.On("GetResult", "abc").Return(jobs.Job{
+			ID: "abc", Kind: services.ResultJobKindPrefix + "assemblages", Status: jobs.StatusSucceeded, FinishedAt: &finished,
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/results/abc", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response ResultResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "assemblages", response.Result.Operation)
+		assert.Equal(t, jobs.StatusSucceeded, response.Result.Status)
+		assert.Equal(t, "/api/v1/results/abc/data", response.Result.DataURL)
+	})
+
+	t.Run("unknown result is not found", func(t *testing.T) {
+		mockService := new(MockResultService)
+		router := setupResultTestRouter(NewResultHandler(mockService))
+
+		mockService.On("GetResult", "gone").Return(jobs.Job{}, jobs.ErrJobNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/results/gone", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "Result not found or expired")
+	})
+}
+
+func TestResultHandler_Data(t *testing.T) {
+	t.Run("serves the stored response", func(t *testing.T) {
+		mockService := new(MockResultService)
+		router := setupResultTestRouter(NewResultHandler(mockService))
+
+		mockService.On("GetResult", "abc").Return(jobs.Job{
+			ID: "abc", Status: jobs.StatusSucceeded,
+			Result: &jobs.Result{ContentType: services.ResultContentType, Data: []byte(`{"count":0}`)},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/results/abc/data", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, services.ResultContentType, w.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"count":0}`, w.Body.String())
+	})
+
+	t.Run("running result is a conflict", func(t *testing.T) {
+		mockService := new(MockResultService)
+		router := setupResultTestRouter(NewResultHandler(mockService))
+
+		mockService.On("GetResult", "abc").Return(jobs.Job{ID: "abc", Status: jobs.StatusRunning}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/results/abc/data", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestAsyncContext(t *testing.T) {
+	request, cancelRequest := context.WithCancel(repository.WithCounty(context.Background(), "montgomery"))
+	cancelRequest()
+	job, cancelJob := context.WithTimeout(context.Background(), time.Minute)
+	defer cancelJob()
+
+	ctx := asyncContext{Context: job, values: request}
+	assert.NoError(t, ctx.Err(), "the request's cancellation does not stop the job")
+	_, hasDeadline := ctx.Deadline()
+	assert.True(t, hasDeadline)
+	assert.Equal(t, "montgomery", repository.CountyFromContext(ctx))
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// RetentionHandler handles the admin data retention HTTP requests.
+type RetentionHandler struct {
+	service services.RetentionService
+}
+
+// NewRetentionHandler creates a new RetentionHandler instance.
+func NewRetentionHandler(service services.RetentionService) *RetentionHandler {
+	return &RetentionHandler{
+		service: service,
+	}
+}
+
+// Preview handles GET /api/v1/admin/retention endpoint.
+// It runs the pruning pass as a dry run, reporting how many rows of each table
+// the next run of the retention job would delete. Nothing is deleted.
+func (h *RetentionHandler) Preview(c *gin.Context) {
+	report, err := h.service.Prune(c.Request.Context(), true)
+	if err != nil {
+		apierrors.InternalServerError(c, "Failed to count expired rows", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// MockRetentionService is a mock implementation of RetentionService for testing
+type MockRetentionService struct {
+	mock.Mock
+}
+
+func (m *MockRetentionService) Prune(ctx context.Context, dryRun bool) (*models.RetentionReport, error) {
+	args := m.Called(ctx, dryRun)
+	report, _ := args.Get(0).(*models.RetentionReport)
+	return report, args.Error(1)
+}
+
+func (m *MockRetentionService) Run(ctx context.Context) {
+	m.Called(ctx)
+}
+
+// setupRetentionTestRouter creates a test router with the retention handler.
+func setupRetentionTestRouter(handler *RetentionHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+
+	router.GET("/api/v1/admin/retention", handler.Preview)
+
+	return router
+}
+
+func TestRetentionHandler_Preview(t *testing.T) {
+	t.Run("reports a dry run", func(t *testing.T) {
+		mockService := new(MockRetentionService)
+		router := setupRetentionTestRouter(NewRetentionHandler(mockService))
+
+		started := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+		mockService.On("Prune", mock.Anything, true).Return(&models.RetentionReport{
+			StartedAt: started,
+			DryRun:    true,
+			Tables: []models.RetentionResult{
+				{Table: "security_events", Retention: "2160h0m0s", Before: started.Add(-2160 * time.Hour), Rows: 42},
+			},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/retention", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var report models.RetentionReport
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		assert.True(t, report.DryRun)
+		require.Len(t, report.Tables, 1)
+		assert.Equal(t, int64(42), report.Tables[0].Rows)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("database failure", func(t *testing.T) {
+		mockService := new(MockRetentionService)
+		router := setupRetentionTestRouter(NewRetentionHandler(mockService))
+		mockService.On("Prune", mock.Anything, true).Return(&models.RetentionReport{}, errors.New("db down"))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/retention", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
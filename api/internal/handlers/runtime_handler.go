@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"net/http"
+	"runtime"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stwalsh4118/atlas/api/internal/cache"
+)
+
+// PoolStats reports the primary connection pool and circuit breaker.
+// *database.Database implements it; Stats returns nil without a pool.
+type PoolStats interface {
+	Stats() *pgxpool.Stat
+	CircuitState() string
+}
+
+// CacheStats reports the lookups of the response cache. *cache.Memory and
+// *cache.Redis implement it.
+type CacheStats interface {
+	Stats() cache.Stats
+}
+
+// RuntimeHandler reports the connection pool, Go runtime and cache statistics
+// of this instance for operators.
+type RuntimeHandler struct {
+	pool      PoolStats
+	cache     CacheStats
+	startTime time.Time
+}
+
+// NewRuntimeHandler creates a new RuntimeHandler instance. cache is nil when
+// response caching is disabled.
+func NewRuntimeHandler(pool PoolStats, cache CacheStats) *RuntimeHandler {
+	return &RuntimeHandler{
+		pool:      pool,
+		cache:     cache,
+		startTime: time.Now(),
+	}
+}
+
+// RuntimeStatsResponse represents the response for the admin stats endpoint.
+// Pool is omitted without a database connection, Cache without a response cache.
+type RuntimeStatsResponse struct {
+	Pool          *PoolStatsResponse `json:"pool,omitempty"`
+	Cache         *cache.Stats       `json:"cache,omitempty"`
+	Uptime        string             `json:"uptime"`
+	Runtime       GoRuntimeStats     `json:"runtime"`
+	UptimeSeconds float64            `json:"uptime_seconds"`
+}
+
+// PoolStatsResponse represents the pgxpool.Stat of the primary pool.
+// Counters and durations are totals since the pool was created.
+type PoolStatsResponse struct {
+	Circuit                string  `json:"circuit"`
+	AcquireCount           int64   `json:"acquire_count"`
+	AcquireDurationMS      float64 `json:"acquire_duration_ms"`
+	CanceledAcquireCount   int64   `json:"canceled_acquire_count"`
+	EmptyAcquireCount      int64   `json:"empty_acquire_count"`
+	EmptyAcquireWaitTimeMS float64 `json:"empty_acquire_wait_time_ms"`
+	NewConnsCount          int64   `json:"new_conns_count"`
+	MaxLifetimeDestroys    int64   `json:"max_lifetime_destroy_count"`
+	MaxIdleDestroys        int64   `json:"max_idle_destroy_count"`
+	AcquiredConns          int32   `json:"acquired_conns"`
+	ConstructingConns      int32   `json:"constructing_conns"`
+	IdleConns              int32   `json:"idle_conns"`
+	TotalConns             int32   `json:"total_conns"`
+	MaxConns               int32   `json:"max_conns"`
+}
+
+// GoRuntimeStats represents the goroutines and memory of the process.
+// Memory figures are bytes, from runtime.MemStats.
+type GoRuntimeStats struct {
+	LastGC          *time.Time `json:"last_gc,omitempty"`
+	GoVersion       string     `json:"go_version"`
+	Goroutines      int        `json:"goroutines"`
+	NumCPU          int        `json:"num_cpu"`
+	GOMAXPROCS      int        `json:"gomaxprocs"`
+	HeapAllocBytes  uint64     `json:"heap_alloc_bytes"`
+	HeapInuseBytes  uint64     `json:"heap_inuse_bytes"`
+	HeapObjects     uint64     `json:"heap_objects"`
+	TotalAllocBytes uint64     `json:"total_alloc_bytes"`
+	SysBytes        uint64     `json:"sys_bytes"`
+	PauseTotalMS    float64    `json:"gc_pause_total_ms"`
+	NumGC           uint32     `json:"num_gc"`
+}
+
+// Stats handles GET /api/v1/admin/stats endpoint.
+// Reading the memory statistics briefly stops the world, so the endpoint is
+// for operators rather than frequent scraping; /metrics serves monitoring.
+func (h *RuntimeHandler) Stats(c *gin.Context) {
+	uptime := time.Since(h.startTime)
+	response := RuntimeStatsResponse{
+		Uptime:        formatUptime(uptime),
+		UptimeSeconds: uptime.Seconds(),
+		Runtime:       readGoRuntimeStats(),
+	}
+	if h.pool != nil {
+		if stat := h.pool.Stats(); stat != nil {
+			response.Pool = mapPoolStats(stat, h.pool.CircuitState())
+		}
+	}
+	if h.cache != nil {
+		stats := h.cache.Stats()
+		response.Cache = &stats
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// mapPoolStats converts a pool snapshot into its response.
+func mapPoolStats(stat *pgxpool.Stat, circuit string) *PoolStatsResponse {
+	return &PoolStatsResponse{
+		Circuit:                circuit,
+		AcquireCount:           stat.AcquireCount(),
+		AcquireDurationMS:      float64(stat.AcquireDuration().Microseconds()) / 1000,
+		CanceledAcquireCount:   stat.CanceledAcquireCount(),
+		EmptyAcquireCount:      stat.EmptyAcquireCount(),
+		EmptyAcquireWaitTimeMS: float64(stat.EmptyAcquireWaitTime().Microseconds()) / 1000,
+		NewConnsCount:          stat.NewConnsCount(),
+		MaxLifetimeDestroys:    stat.MaxLifetimeDestroyCount(),
+		MaxIdleDestroys:        stat.MaxIdleDestroyCount(),
+		AcquiredConns:          stat.AcquiredConns(),
+		ConstructingConns:      stat.ConstructingConns(),
+		IdleConns:              stat.IdleConns(),
+		TotalConns:             stat.TotalConns(),
+		MaxConns:               stat.MaxConns(),
+	}
+}
+
+// readGoRuntimeStats reads the goroutine count and memory statistics.
+func readGoRuntimeStats() GoRuntimeStats {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	stats := GoRuntimeStats{
+		GoVersion:       runtime.Version(),
+		Goroutines:      runtime.NumGoroutine(),
+		NumCPU:          runtime.NumCPU(),
+		GOMAXPROCS:      runtime.GOMAXPROCS(0),
+		HeapAllocBytes:  mem.HeapAlloc,
+		HeapInuseBytes:  mem.HeapInuse,
+		HeapObjects:     mem.HeapObjects,
+		TotalAllocBytes: mem.TotalAlloc,
+		SysBytes:        mem.Sys,
+		PauseTotalMS:    float64(mem.PauseTotalNs) / float64(time.Millisecond),
+		NumGC:           mem.NumGC,
+	}
+	if mem.LastGC > 0 {
+		lastGC := time.Unix(0, int64(mem.LastGC)).UTC()
+		stats.LastGC = &lastGC
+	}
+	return stats
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/cache"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+)
+
+func TestRuntimeHandler_Stats(t *testing.T) {
+	get := func(handler *RuntimeHandler) RuntimeStatsResponse {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.GET("/api/v1/admin/stats", handler.Stats)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/stats", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response RuntimeStatsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	t.Run("reports the runtime and cache", func(t *testing.T) {
+		store := cache.NewMemory(10)
+		_, _, _ = store.Get(t.Context(), "missing")
+
+		response := get(NewRuntimeHandler(nil, store))
+
+		assert.Nil(t, response.Pool)
+		assert.Positive(t, response.Runtime.Goroutines)
+		assert.Positive(t, response.Runtime.HeapAllocBytes)
+		assert.NotEmpty(t, response.Runtime.GoVersion)
+		assert.NotEmpty(t, response.Uptime)
+		require.NotNil(t, response.Cache)
+		assert.Equal(t, cache.Stats{Backend: "memory", Misses: 1}, *response.Cache)
+	})
+
+	t.Run("omits the pool before it is connected", func(t *testing.T) {
+		response := get(NewRuntimeHandler(&database.Database{}, nil))
+
+		assert.Nil(t, response.Pool)
+		assert.Nil(t, response.Cache)
+	})
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// SalesHandler handles parcel sales history HTTP requests.
+type SalesHandler struct {
+	service services.SalesService
+}
+
+// NewSalesHandler creates a new SalesHandler instance.
+func NewSalesHandler(service services.SalesService) *SalesHandler {
+	return &SalesHandler{
+		service: service,
+	}
+}
+
+// ParcelSalesResponse represents the response for the parcel sales endpoint.
+// Sales are newest first, and empty where none are recorded.
+type ParcelSalesResponse struct {
+	Sales    []ParcelSaleData `json:"sales"`
+	ParcelID uint             `json:"parcel_id"`
+	Count    int              `json:"count"`
+}
+
+// ParcelSaleData represents one recorded sale of a parcel in the API response.
+// Price is omitted where the sale price is not disclosed.
+type ParcelSaleData struct {
+	Price      *int64 `json:"price,omitempty"`
+	SaleDate   string `json:"sale_date"` // YYYY-MM-DD
+	DeedType   string `json:"deed_type,omitempty"`
+	Grantor    string `json:"grantor,omitempty"`
+	Grantee    string `json:"grantee,omitempty"`
+	Instrument string `json:"instrument,omitempty"`
+	ID         uint   `json:"id"`
+}
+
+// List handles GET /api/v1/parcels/:id/sales endpoint.
+// It returns the sales of the parcel loaded from its county's deed or sales
+// export, newest first.
+func (h *SalesHandler) List(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		apierrors.BadRequest(c, "parcel id must be a positive integer", nil)
+		return
+	}
+
+	sales, err := h.service.GetParcelSales(c.Request.Context(), uint(id))
+	if err != nil {
+		apierrors.Respond(c, "Failed to retrieve parcel sales", err)
+		return
+	}
+
+	middleware.SetResultCount(c, len(sales))
+
+	response := ParcelSalesResponse{
+		ParcelID: uint(id),
+		Sales:    make([]ParcelSaleData, 0, len(sales)),
+		Count:    len(sales),
+	}
+	for i := range sales {
+		response.Sales = append(response.Sales, mapParcelSaleToDTO(&sales[i]))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// mapParcelSaleToDTO converts a ParcelSale model to a ParcelSaleData DTO.
+func mapParcelSaleToDTO(sale *models.ParcelSale) ParcelSaleData {
+	return ParcelSaleData{
+		ID:         sale.ID,
+		SaleDate:   sale.SaleDate.Format(time.DateOnly),
+		Price:      sale.Price,
+		DeedType:   stringValue(sale.DeedType),
+		Grantor:    stringValue(sale.Grantor),
+		Grantee:    stringValue(sale.Grantee),
+		Instrument: stringValue(sale.Instrument),
+	}
+}
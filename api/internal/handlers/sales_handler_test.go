@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MockSalesService is a mock implementation of SalesService for testing
+type MockSalesService struct {
+	mock.Mock
+}
+
+func (m *MockSalesService) GetParcelSales(ctx context.Context, parcelID uint) ([]models.ParcelSale, error) {
+	args := m.Called(ctx, parcelID)
+	sales, _ := args.Get(0).([]models.ParcelSale)
+	return sales, args.Error(1)
+}
+
+// setupSalesTestRouter creates a test router with sales handlers.
+func setupSalesTestRouter(handler *SalesHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+
+	router.GET("/api/v1/parcels/:id/sales", handler.List)
+
+	return router
+}
+
+func TestSalesHandler_List(t *testing.T) {
+	mockService := new(MockSalesService)
+	router := setupSalesTestRouter(NewSalesHandler(mockService))
+
+	price := int64(385000)
+	deedType, grantor, grantee := "WD", "DOE JANE", "SMITH JOHN"
+	mockService.On("GetParcelSales", mock.Anything, uint(42)).Return([]models.ParcelSale{
+		{ID: 2, SaleDate: time.Date(2019, 6, 14, 0, 0, 0, 0, time.UTC), Price: &price, DeedType: &deedType, Grantor: &grantor, Grantee: &grantee},
+		{ID: 1, SaleDate: time.Date(2008, 3, 2, 0, 0, 0, 0, time.UTC), Grantee: &grantor},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/parcels/42/sales", nil))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.JSONEq(t, `{
+		"parcel_id": 42,
+		"sales": [
+			{"id": 2, "sale_date": "2019-06-14", "price": 385000, "deed_type": "WD", "grantor": "DOE JANE", "grantee": "SMITH JOHN"},
+			{"id": 1, "sale_date": "2008-03-02", "grantee": "DOE JANE"}
+		],
+		"count": 2
+	}`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestSalesHandler_ListNoSales(t *testing.T) {
+	mockService := new(MockSalesService)
+	router := setupSalesTestRouter(NewSalesHandler(mockService))
+
+	mockService.On("GetParcelSales", mock.Anything, uint(42)).Return([]models.ParcelSale{}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/parcels/42/sales", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"parcel_id": 42, "sales": [], "count": 0}`, w.Body.String())
+}
+
+func TestSalesHandler_ListErrors(t *testing.T) {
+	testCases := []struct {
+		err    error
+		name   string
+		url    string
+		status int
+	}{
+		{nil, "invalid id", "/api/v1/parcels/abc/sales", http.StatusBadRequest},
+		{fmt.Errorf("%w: id 42", services.ErrParcelNotFound), "parcel not found", "/api/v1/parcels/42/sales", http.StatusNotFound},
+		{errors.New("connection refused"), "database error", "/api/v1/parcels/42/sales", http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockSalesService)
+			router := setupSalesTestRouter(NewSalesHandler(mockService))
+			if tc.err != nil {
+				mockService.On("GetParcelSales", mock.Anything, mock.Anything).Return(nil, tc.err)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.url, nil))
+
+			assert.Equal(t, tc.status, w.Code)
+		})
+	}
+}
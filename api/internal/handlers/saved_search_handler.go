@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// Saved search constants
+const (
+	// DefaultSavedSearchMatchesLimit is the number of matches returned without a limit
+	DefaultSavedSearchMatchesLimit = 100
+)
+
+// SavedSearchHandler handles the signed-in user's saved searches and their
+// matches. All routes are user-scoped.
+type SavedSearchHandler struct {
+	service services.SavedSearchService
+}
+
+// NewSavedSearchHandler creates a new SavedSearchHandler instance.
+func NewSavedSearchHandler(service services.SavedSearchService) *SavedSearchHandler {
+	return &SavedSearchHandler{
+		service: service,
+	}
+}
+
+// CreateSavedSearchRequest represents the request body for saving a search.
+// Area is a GeoJSON Polygon or MultiPolygon; Filters take the nearby
+// endpoint's filters, and County limits the search to one county.
+type CreateSavedSearchRequest struct {
+	Filters models.SavedSearchFilters `json:"filters"`
+	Area    json.RawMessage           `json:"area" binding:"required"`
+	Name    string                    `json:"name" binding:"required"`
+	County  string                    `json:"county" binding:"omitempty,max=100"`
+}
+
+// SavedSearchResponse represents the response for a single saved search.
+type SavedSearchResponse struct {
+	SavedSearch *models.SavedSearch `json:"savedSearch"`
+}
+
+// SavedSearchListResponse represents the response for the saved search list endpoint.
+type SavedSearchListResponse struct {
+	SavedSearches []models.SavedSearch `json:"savedSearches"`
+	Count         int                  `json:"count"`
+}
+
+// SavedSearchMatchesRequest represents the query parameters for the matches endpoint.
+type SavedSearchMatchesRequest struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=500"` // default: 100
+}
+
+// SavedSearchMatchesResponse represents the response for the matches endpoint.
+type SavedSearchMatchesResponse struct {
+	Matches []models.SavedSearchMatch `json:"matches"`
+	Count   int                       `json:"count"`
+}
+
+// Create handles POST /api/v1/users/me/searches endpoint.
+// Responds 201 Created with the search; it matches parcels changed by later
+// ingestion runs.
+func (h *SavedSearchHandler) Create(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxAreaBodyBytes)
+
+	var req CreateSavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// Check if it's a validation error
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		// Malformed JSON, wrong field types or a body over MaxAreaBodyBytes
+		apierrors.BadRequest(c, "Invalid request body", nil)
+		return
+	}
+
+	area, err := models.ParseAreaGeoJSON(req.Area)
+	if err != nil {
+		apierrors.BadRequest(c, "area must be a GeoJSON Polygon or MultiPolygon", map[string]interface{}{
+			"reason": err.Error(),
+		})
+		return
+	}
+
+	search := models.SavedSearch{
+		UserID:  middleware.GetUserID(c),
+		Name:    req.Name,
+		Area:    *area,
+		Filters: req.Filters,
+	}
+	if req.County != "" {
+		search.County = &req.County
+	}
+
+	stored, err := h.service.CreateSearch(c.Request.Context(), search)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, SavedSearchResponse{SavedSearch: stored})
+}
+
+// List handles GET /api/v1/users/me/searches endpoint.
+func (h *SavedSearchHandler) List(c *gin.Context) {
+	searches, err := h.service.ListSearches(c.Request.Context(), middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SavedSearchListResponse{
+		SavedSearches: searches,
+		Count:         len(searches),
+	})
+}
+
+// Delete handles DELETE /api/v1/users/me/searches/:id endpoint.
+func (h *SavedSearchHandler) Delete(c *gin.Context) {
+	id, ok := savedSearchID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteSearch(c.Request.Context(), middleware.GetUserID(c), id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Matches handles GET /api/v1/users/me/searches/:id/matches endpoint.
+// It returns the parcels ingestion runs added, changed or deleted that matched
+// the search, newest first.
+func (h *SavedSearchHandler) Matches(c *gin.Context) {
+	id, ok := savedSearchID(c)
+	if !ok {
+		return
+	}
+
+	var req SavedSearchMatchesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		apierrors.BadRequest(c, "Invalid query parameters", nil)
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = DefaultSavedSearchMatchesLimit
+	}
+
+	matches, err := h.service.ListMatches(c.Request.Context(), middleware.GetUserID(c), id, req.Limit)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	middleware.SetResultCount(c, len(matches))
+
+	c.JSON(http.StatusOK, SavedSearchMatchesResponse{
+		Matches: matches,
+		Count:   len(matches),
+	})
+}
+
+// handleError maps saved search service errors to HTTP responses.
+func (h *SavedSearchHandler) handleError(c *gin.Context, err error) {
+	apierrors.Respond(c, "Failed to process saved search request", err)
+}
+
+// savedSearchID parses the :id parameter, writing the error response if it is invalid.
+func savedSearchID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		apierrors.BadRequest(c, "saved search id must be a positive integer", nil)
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MockSavedSearchService is a mock implementation of SavedSearchService for testing
+type MockSavedSearchService struct {
+	mock.Mock
+}
+
+func (m *MockSavedSearchService) CreateSearch(ctx context.Context, search models.SavedSearch) (*models.SavedSearch, error) {
+	args := m.Called(ctx, search)
+	stored, _ := args.Get(0).(*models.SavedSearch)
+	return stored, args.Error(1)
+}
+
+func (m *MockSavedSearchService) ListSearches(ctx context.Context, userID uint) ([]models.SavedSearch, error) {
+	args := m.Called(ctx, userID)
+	searches, _ := args.Get(0).([]models.SavedSearch)
+	return searches, args.Error(1)
+}
+
+func (m *MockSavedSearchService) DeleteSearch(ctx context.Context, userID, id uint) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockSavedSearchService) ListMatches(ctx context.Context, userID, id uint, limit int) ([]models.SavedSearchMatch, error) {
+	args := m.Called(ctx, userID, id, limit)
+	matches, _ := args.Get(0).([]models.SavedSearchMatch)
+	return matches, args.Error(1)
+}
+
+func (m *MockSavedSearchService) EvaluateAll(ctx context.Context, runID int64) (int64, error) {
+	args := m.Called(ctx, runID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSavedSearchService) RunAlerts(ctx context.Context) {
+	m.Called(ctx)
+}
+
+// setupSavedSearchTestRouter creates a test router with saved search handlers,
+// signed in as user 7.
+func setupSavedSearchTestRouter(handler *SavedSearchHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, uint(7))
+		c.Next()
+	})
+
+	router.GET("/api/v1/users/me/searches", handler.List)
+	router.POST("/api/v1/users/me/searches", handler.Create)
+	router.DELETE("/api/v1/users/me/searches/:id", handler.Delete)
+	router.GET("/api/v1/users/me/searches/:id/matches", handler.Matches)
+
+	return router
+}
+
+const savedSearchBody = `{
+	"name": "Lake lots",
+	"county": "montgomery-tx",
+	"filters": {"minAcres": 5, "waterfront": true},
+	"area": {"type": "Polygon", "coordinates": [[[-95.5, 30.3], [-95.4, 30.3], [-95.4, 30.4], [-95.5, 30.3]]]}
+}`
+
+func TestSavedSearchHandler_Create(t *testing.T) {
+	mockService := new(MockSavedSearchService)
+	router := setupSavedSearchTestRouter(NewSavedSearchHandler(mockService))
+
+	var received models.SavedSearch
+	mockService.On("CreateSearch", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		received = args.Get(1).(models.SavedSearch)
+	}).Return(&models.SavedSearch{ID: 3, Name: "Lake lots"}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/me/searches", strings.NewReader(savedSearchBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var response SavedSearchResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, uint(3), response.SavedSearch.ID)
+	assert.Equal(t, uint(7), received.UserID)
+	assert.Equal(t, "montgomery-tx", *received.County)
+	assert.Equal(t, 5.0, *received.Filters.MinAcres)
+	assert.True(t, *received.Filters.Waterfront)
+	assert.Len(t, received.Area.Coordinates, 1)
+	mockService.AssertExpectations(t)
+}
+
+func TestSavedSearchHandler_Create_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		serviceErr error
+		wantStatus int
+	}{
+		{name: "missing area", body: `{"name": "s"}`, wantStatus: http.StatusBadRequest},
+		{name: "not an area", body: `{"name": "s", "area": {"type": "Point", "coordinates": [0, 0]}}`, wantStatus: http.StatusBadRequest},
+		{name: "malformed json", body: `{"name":`, wantStatus: http.StatusBadRequest},
+		{name: "too many searches", body: savedSearchBody, serviceErr: services.ErrTooManySavedSearches, wantStatus: http.StatusConflict},
+		{name: "invalid filter", body: savedSearchBody, serviceErr: services.ErrInvalidFilter, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSavedSearchService)
+			router := setupSavedSearchTestRouter(NewSavedSearchHandler(mockService))
+
+			if tt.serviceErr != nil {
+				mockService.On("CreateSearch", mock.Anything, mock.Anything).Return(nil, tt.serviceErr)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/users/me/searches", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSavedSearchHandler_List(t *testing.T) {
+	mockService := new(MockSavedSearchService)
+	router := setupSavedSearchTestRouter(NewSavedSearchHandler(mockService))
+
+	mockService.On("ListSearches", mock.Anything, uint(7)).Return([]models.SavedSearch{{ID: 3, MatchCount: 4}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me/searches", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response SavedSearchListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Count)
+	assert.Equal(t, int64(4), response.SavedSearches[0].MatchCount)
+	mockService.AssertExpectations(t)
+}
+
+func TestSavedSearchHandler_Delete(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		serviceErr error
+		wantStatus int
+	}{
+		{name: "deleted", path: "/api/v1/users/me/searches/3", wantStatus: http.StatusNoContent},
+		{name: "not found", path: "/api/v1/users/me/searches/3", serviceErr: services.ErrSavedSearchNotFound, wantStatus: http.StatusNotFound},
+		{name: "invalid id", path: "/api/v1/users/me/searches/abc", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSavedSearchService)
+			router := setupSavedSearchTestRouter(NewSavedSearchHandler(mockService))
+
+			if tt.wantStatus != http.StatusBadRequest {
+				mockService.On("DeleteSearch", mock.Anything, uint(7), uint(3)).Return(tt.serviceErr)
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSavedSearchHandler_Matches(t *testing.T) {
+	situs := "12 LAKE DR"
+	matches := []models.SavedSearchMatch{{ID: 9, SavedSearchID: 3, ParcelID: 42, PIN: 7, Situs: &situs, Change: models.ParcelChangeAdded}}
+
+	t.Run("default limit", func(t *testing.T) {
+		mockService := new(MockSavedSearchService)
+		router := setupSavedSearchTestRouter(NewSavedSearchHandler(mockService))
+
+		mockService.On("ListMatches", mock.Anything, uint(7), uint(3), DefaultSavedSearchMatchesLimit).Return(matches, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me/searches/3/matches", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response SavedSearchMatchesResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, 1, response.Count)
+		assert.Equal(t, "added", response.Matches[0].Change)
+		assert.Equal(t, uint(42), response.Matches[0].ParcelID)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("limit out of range", func(t *testing.T) {
+		mockService := new(MockSavedSearchService)
+		router := setupSavedSearchTestRouter(NewSavedSearchHandler(mockService))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me/searches/3/matches?limit=501", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ListMatches")
+	})
+}
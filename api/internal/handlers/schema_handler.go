@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// SchemaHandler handles the admin schema drift HTTP requests.
+type SchemaHandler struct {
+	service services.SchemaService
+}
+
+// NewSchemaHandler creates a new SchemaHandler instance.
+func NewSchemaHandler(service services.SchemaService) *SchemaHandler {
+	return &SchemaHandler{
+		service: service,
+	}
+}
+
+// Plan handles GET /api/v1/admin/schema endpoint.
+// It reports the drift between the database and the migrations and models:
+// pending migrations, missing indexes and columns, and changed column types.
+// Drift is reported with 200 and drift true; monitoring alerts on the field.
+func (h *SchemaHandler) Plan(c *gin.Context) {
+	report, err := h.service.Plan(c.Request.Context())
+	if err != nil {
+		apierrors.InternalServerError(c, "Failed to compare the database schema", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
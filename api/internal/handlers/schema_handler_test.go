@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// MockSchemaService is a mock implementation of SchemaService for testing
+type MockSchemaService struct {
+	mock.Mock
+}
+
+func (m *MockSchemaService) Plan(ctx context.Context) (*models.SchemaReport, error) {
+	args := m.Called(ctx)
+	report, _ := args.Get(0).(*models.SchemaReport)
+	return report, args.Error(1)
+}
+
+func (m *MockSchemaService) CheckMigrations(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+// setupSchemaTestRouter creates a test router with the schema handler.
+func setupSchemaTestRouter(handler *SchemaHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+
+	router.GET("/api/v1/admin/schema", handler.Plan)
+
+	return router
+}
+
+func TestSchemaHandler_Plan(t *testing.T) {
+	t.Run("reports drift with 200", func(t *testing.T) {
+		mockService := new(MockSchemaService)
+		router := setupSchemaTestRouter(NewSchemaHandler(mockService))
+
+		mockService.On("Plan", mock.Anything).Return(&models.SchemaReport{
+			CurrentVersion: 22,
+			LatestVersion:  22,
+			MissingIndexes: []string{"idx_parcels_situs_trgm"},
+			Drift:          true,
+		}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/schema", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var report models.SchemaReport
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		assert.True(t, report.Drift)
+		assert.Equal(t, []string{"idx_parcels_situs_trgm"}, report.MissingIndexes)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("database failure", func(t *testing.T) {
+		mockService := new(MockSchemaService)
+		router := setupSchemaTestRouter(NewSchemaHandler(mockService))
+		mockService.On("Plan", mock.Anything).Return(nil, errors.New("db down"))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/schema", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
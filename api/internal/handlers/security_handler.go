@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// SecurityHandler handles the admin security dashboard HTTP requests.
+type SecurityHandler struct {
+	service services.SecurityService
+}
+
+// NewSecurityHandler creates a new SecurityHandler instance.
+func NewSecurityHandler(service services.SecurityService) *SecurityHandler {
+	return &SecurityHandler{
+		service: service,
+	}
+}
+
+// Summary handles GET /api/v1/admin/security endpoint.
+// It summarizes the failed authentications and lockouts of the last 24 hours
+// across instances, with the lockouts active on the instance that answered.
+func (h *SecurityHandler) Summary(c *gin.Context) {
+	summary, err := h.service.Summary(c.Request.Context())
+	if err != nil {
+		apierrors.InternalServerError(c, "Failed to summarize security events", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
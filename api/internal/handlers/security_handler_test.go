@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// MockSecurityService is a mock implementation of SecurityService for testing
+type MockSecurityService struct {
+	mock.Mock
+}
+
+func (m *MockSecurityService) LockedFor(ip, subject string) time.Duration {
+	return m.Called(ip, subject).Get(0).(time.Duration)
+}
+
+func (m *MockSecurityService) RecordFailure(ip, subject, route string) {
+	m.Called(ip, subject, route)
+}
+
+func (m *MockSecurityService) RecordSuccess(subject string) {
+	m.Called(subject)
+}
+
+func (m *MockSecurityService) Summary(ctx context.Context) (*models.SecuritySummary, error) {
+	args := m.Called(ctx)
+	summary, _ := args.Get(0).(*models.SecuritySummary)
+	return summary, args.Error(1)
+}
+
+func (m *MockSecurityService) Run(ctx context.Context) {
+	m.Called(ctx)
+}
+
+// setupSecurityTestRouter creates a test router with the security handler.
+func setupSecurityTestRouter(handler *SecurityHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+
+	router.GET("/api/v1/admin/security", handler.Summary)
+
+	return router
+}
+
+func TestSecurityHandler_Summary(t *testing.T) {
+	mockService := new(MockSecurityService)
+	router := setupSecurityTestRouter(NewSecurityHandler(mockService))
+
+	until := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	mockService.On("Summary", mock.Anything).Return(&models.SecuritySummary{
+		Since:    until.Add(-24 * time.Hour),
+		Until:    until,
+		Failures: 12,
+		Lockouts: 1,
+		TopClients: []models.SecurityClient{
+			{ClientIP: "203.0.113.7", Failures: 12, Lockouts: 1, Subjects: 9, LastSeen: until},
+		},
+		ActiveLockouts: []models.SecurityLockout{{Key: "ip:203.0.113.7", Until: until.Add(time.Minute)}},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/security", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, float64(12), body["failures"])
+	assert.Equal(t, "203.0.113.7", body["topClients"].([]interface{})[0].(map[string]interface{})["clientIp"])
+	assert.Equal(t, "ip:203.0.113.7", body["activeLockouts"].([]interface{})[0].(map[string]interface{})["key"])
+}
+
+func TestSecurityHandler_SummaryError(t *testing.T) {
+	mockService := new(MockSecurityService)
+	router := setupSecurityTestRouter(NewSecurityHandler(mockService))
+
+	mockService.On("Summary", mock.Anything).Return(nil, errors.New("connection refused"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/security", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NotContains(t, w.Body.String(), "connection refused")
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// shareLinkPathPrefix is the path of the share link endpoints.
+const shareLinkPathPrefix = "/api/v1/share"
+
+// ShareLinkCacheControl is the Cache-Control policy for resolved share links.
+// Every resolution counts a click, so responses must not be reused.
+const ShareLinkCacheControl = "no-store"
+
+// ShareLinkHandler handles share link HTTP requests.
+type ShareLinkHandler struct {
+	service services.ShareLinkService
+}
+
+// NewShareLinkHandler creates a new ShareLinkHandler instance.
+func NewShareLinkHandler(service services.ShareLinkService) *ShareLinkHandler {
+	return &ShareLinkHandler{
+		service: service,
+	}
+}
+
+// CreateShareLinkRequest represents the request body for creating a share link.
+// Exactly one of ParcelID or Area is required; Area is a GeoJSON Polygon or MultiPolygon.
+// ExpiresInDays defaults to services.DefaultShareLinkDays.
+type CreateShareLinkRequest struct {
+	ParcelID      *uint                    `json:"parcelId"`
+	Style         *models.LayerStyleConfig `json:"style"`
+	Zoom          *int                     `json:"zoom"`
+	Area          json.RawMessage          `json:"area"`
+	ExpiresInDays int                      `json:"expiresInDays"`
+}
+
+// ShareLinkResponse represents the response for a single share link.
+type ShareLinkResponse struct {
+	ShareLink *models.ShareLink `json:"shareLink"`
+}
+
+// Create handles POST /api/v1/share endpoint.
+// The link is attributed to the X-Tenant-ID tenant when the header is sent.
+// Responds 201 Created with the link and its token.
+func (h *ShareLinkHandler) Create(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxAreaBodyBytes)
+
+	var req CreateShareLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// Check if it's a validation error
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		// Malformed JSON, wrong field types or a body over MaxAreaBodyBytes
+		apierrors.BadRequest(c, "Invalid request body", nil)
+		return
+	}
+
+	view := models.ShareView{ParcelID: req.ParcelID, Style: req.Style, Zoom: req.Zoom}
+	if len(req.Area) > 0 && string(req.Area) != "null" {
+		area, err := models.ParseAreaGeoJSON(req.Area)
+		if err != nil {
+			apierrors.BadRequest(c, "area must be a GeoJSON Polygon or MultiPolygon", map[string]interface{}{
+				"reason": err.Error(),
+			})
+			return
+		}
+		view.Area = area
+	}
+
+	link, err := h.service.CreateShareLink(c.Request.Context(), view, middleware.GetTenantID(c), req.ExpiresInDays)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Header("Location", shareLinkPathPrefix+"/"+link.Token)
+	c.JSON(http.StatusCreated, ShareLinkResponse{ShareLink: link})
+}
+
+// Resolve handles GET /api/v1/share/:token endpoint.
+// Every successful request counts a click; see ShareLinkCacheControl.
+func (h *ShareLinkHandler) Resolve(c *gin.Context) {
+	link, err := h.service.ResolveShareLink(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ShareLinkResponse{ShareLink: link})
+}
+
+// handleError maps share link service errors to HTTP responses.
+func (h *ShareLinkHandler) handleError(c *gin.Context, err error) {
+	apierrors.Respond(c, "Failed to process share link request", err)
+}
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MockShareLinkService is a mock implementation of ShareLinkService for testing
+type MockShareLinkService struct {
+	mock.Mock
+}
+
+func (m *MockShareLinkService) CreateShareLink(ctx context.Context, view models.ShareView, tenantID string, expiresInDays int) (*models.ShareLink, error) {
+	args := m.Called(ctx, view, tenantID, expiresInDays)
+	link, _ := args.Get(0).(*models.ShareLink)
+	return link, args.Error(1)
+}
+
+func (m *MockShareLinkService) ResolveShareLink(ctx context.Context, token string) (*models.ShareLink, error) {
+	args := m.Called(ctx, token)
+	link, _ := args.Get(0).(*models.ShareLink)
+	return link, args.Error(1)
+}
+
+// setupShareLinkTestRouter creates a test router with tenant middleware and share link handlers.
+func setupShareLinkTestRouter(handler *ShareLinkHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+	router.Use(middleware.Tenant())
+
+	router.POST("/api/v1/share", handler.Create)
+	router.GET("/api/v1/share/:token", handler.Resolve)
+
+	return router
+}
+
+func TestShareLinkHandler_Create(t *testing.T) {
+	t.Run("parcel view", func(t *testing.T) {
+		mockService := new(MockShareLinkService)
+		router := setupShareLinkTestRouter(NewShareLinkHandler(mockService))
+
+		mockService.On("CreateShareLink", mock.Anything, mock.MatchedBy(func(v models.ShareView) bool {
+			return v.ParcelID != nil && *v.ParcelID == 42 && v.Area == nil && v.Zoom != nil && *v.Zoom == 18
+		}), "acme", 7).Return(&models.ShareLink{
+			ID: 1, Token: "AbC123_-xyz0", ExpiresAt: time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC),
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/share",
+			strings.NewReader(`{"parcelId":42,"zoom":18,"expiresInDays":7}`))
+		req.Header.Set(middleware.TenantIDHeader, "acme")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "/api/v1/share/AbC123_-xyz0", w.Header().Get("Location"))
+
+		var response ShareLinkResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "AbC123_-xyz0", response.ShareLink.Token)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("polygon area is wrapped as a multipolygon", func(t *testing.T) {
+		mockService := new(MockShareLinkService)
+		router := setupShareLinkTestRouter(NewShareLinkHandler(mockService))
+
+		mockService.On("CreateShareLink", mock.Anything, mock.MatchedBy(func(v models.ShareView) bool {
+			return v.ParcelID == nil && v.Area != nil && len(v.Area.Coordinates) == 1
+		}), "", 0).Return(&models.ShareLink{ID: 2, Token: "tok"}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/share", strings.NewReader(
+			`{"area":{"type":"Polygon","coordinates":[[[-95.5,30.3],[-95.4,30.3],[-95.4,30.4],[-95.5,30.3]]]}}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid area geometry", func(t *testing.T) {
+		mockService := new(MockShareLinkService)
+		router := setupShareLinkTestRouter(NewShareLinkHandler(mockService))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/share",
+			strings.NewReader(`{"area":{"type":"Point","coordinates":[-95.5,30.3]}}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "CreateShareLink")
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		mockService := new(MockShareLinkService)
+		router := setupShareLinkTestRouter(NewShareLinkHandler(mockService))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/share", strings.NewReader(`{"parcelId":"42"`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "CreateShareLink")
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		mockService := new(MockShareLinkService)
+		router := setupShareLinkTestRouter(NewShareLinkHandler(mockService))
+
+		mockService.On("CreateShareLink", mock.Anything, mock.Anything, "", 0).
+			Return(nil, services.ErrInvalidShareLink)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/share", strings.NewReader(`{}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestShareLinkHandler_Resolve(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		mockService := new(MockShareLinkService)
+		router := setupShareLinkTestRouter(NewShareLinkHandler(mockService))
+
+		parcelID := uint(42)
+		tenantID := "acme"
+		mockService.On("ResolveShareLink", mock.Anything, "tok").Return(&models.ShareLink{
+			ID: 1, Token: "tok", Clicks: 3, TenantID: &tenantID,
+			View: models.ShareView{ParcelID: &parcelID},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/share/tok", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "acme", "the creating tenant is not exposed")
+
+		var response ShareLinkResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, int64(3), response.ShareLink.Clicks)
+		require.NotNil(t, response.ShareLink.View.ParcelID)
+		assert.Equal(t, uint(42), *response.ShareLink.View.ParcelID)
+	})
+
+	t.Run("not found or expired", func(t *testing.T) {
+		mockService := new(MockShareLinkService)
+		router := setupShareLinkTestRouter(NewShareLinkHandler(mockService))
+
+		mockService.On("ResolveShareLink", mock.Anything, "gone").Return(nil, services.ErrShareLinkNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/share/gone", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		mockService := new(MockShareLinkService)
+		router := setupShareLinkTestRouter(NewShareLinkHandler(mockService))
+
+		mockService.On("ResolveShareLink", mock.Anything, "tok").Return(nil, errors.New("connection refused"))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/share/tok", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
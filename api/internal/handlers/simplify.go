@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"context"
+	"math"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// Geometry simplification constants.
+// Tolerances are in degrees, the units of the SRID 4326 parcel geometries.
+const (
+	// MaxSimplifyTolerance (roughly 1 km) keeps simplified parcels recognizable
+	MaxSimplifyTolerance = 0.01
+	// MaxSimplifyZoom is the highest web map zoom level accepted by zoom=
+	MaxSimplifyZoom = 22
+	// tileSizePixels is the web map tile size used to derive a tolerance from a zoom level
+	tileSizePixels = 256
+)
+
+// toleranceForZoom returns the width of one screen pixel in degrees at the given web
+// map zoom level, capped at MaxSimplifyTolerance. Detail smaller than a pixel is not
+// visible, so simplifying to this tolerance does not change the rendered map.
+func toleranceForZoom(zoom int) float64 {
+	tolerance := 360 / (tileSizePixels * math.Pow(2, float64(zoom)))
+	return math.Min(tolerance, MaxSimplifyTolerance)
+}
+
+// applySimplification marks ctx to simplify returned geometries when the request sets
+// simplify_tolerance or zoom. It writes a 400 response (ok=false) when both are set.
+func applySimplification(ctx context.Context, c *gin.Context, tolerance float64, zoom *int) (context.Context, bool) {
+	if tolerance > 0 && zoom != nil {
+		apierrors.BadRequest(c, "simplify_tolerance and zoom cannot be combined", nil)
+		return nil, false
+	}
+
+	if zoom != nil {
+		tolerance = toleranceForZoom(*zoom)
+	}
+	if tolerance > 0 {
+		ctx = repository.WithSimplifyTolerance(ctx, tolerance)
+	}
+
+	return ctx, true
+}
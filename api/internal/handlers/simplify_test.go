@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToleranceForZoom(t *testing.T) {
+	// One 256px tile spans 360 degrees at zoom 0, so each level halves the pixel width
+	assert.InDelta(t, 360.0/256/(1<<16), toleranceForZoom(16), 1e-12)
+	assert.InDelta(t, toleranceForZoom(15)/2, toleranceForZoom(16), 1e-12)
+
+	// Low zoom levels are capped so parcels stay recognizable
+	assert.Equal(t, MaxSimplifyTolerance, toleranceForZoom(0))
+	assert.Equal(t, MaxSimplifyTolerance, toleranceForZoom(7))
+	assert.Less(t, toleranceForZoom(8), MaxSimplifyTolerance)
+}
+
+func TestApplySimplification(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	zoom := 12
+
+	t.Run("no parameters keeps the context", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		ctx := context.Background()
+
+		got, ok := applySimplification(ctx, c, 0, nil)
+
+		require.True(t, ok)
+		assert.Equal(t, ctx, got)
+	})
+
+	t.Run("tolerance or zoom marks the context", func(t *testing.T) {
+		for _, tt := range []struct {
+			zoom      *int
+			name      string
+			tolerance float64
+		}{
+			{name: "tolerance", tolerance: 0.0001},
+			{name: "zoom", zoom: &zoom},
+		} {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			ctx := context.Background()
+
+			got, ok := applySimplification(ctx, c, tt.tolerance, tt.zoom)
+
+			require.True(t, ok, tt.name)
+			assert.NotEqual(t, ctx, got, tt.name)
+		}
+	})
+
+	t.Run("rejects tolerance combined with zoom", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/parcels/nearby?simplify_tolerance=0.0001&zoom=12", nil)
+
+		_, ok := applySimplification(context.Background(), c, 0.0001, &zoom)
+
+		assert.False(t, ok)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
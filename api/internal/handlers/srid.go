@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/projection"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// applyOutputSRID marks ctx to return geometries in the SRID the request sets with
+// srid=, e.g. 3857 for clients rendering Web Mercator tiles. Zero and 4326 leave
+// them WGS84. It writes a 400 response (ok=false) for SRIDs outside
+// projection.SRIDs and for projected output in a format that must be WGS84
+// (KML, GeoPackage and CSV).
+func applyOutputSRID(ctx context.Context, c *gin.Context, srid int, format string) (context.Context, bool) {
+	if srid == 0 || srid == projection.WGS84 {
+		return ctx, true
+	}
+	if !projection.Supported(srid) {
+		apierrors.BadRequest(c, fmt.Sprintf("srid must be one of %v", projection.SRIDs), nil)
+		return nil, false
+	}
+	if format != "" && format != FormatJSON && format != FormatGeoJSON {
+		apierrors.BadRequest(c, "srid other than 4326 is only supported for json and geojson formats", nil)
+		return nil, false
+	}
+	return repository.WithOutputSRID(ctx, srid), true
+}
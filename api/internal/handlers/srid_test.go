@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyOutputSRID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("WGS84 keeps the context", func(t *testing.T) {
+		for _, srid := range []int{0, 4326} {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			ctx := context.Background()
+
+			got, ok := applyOutputSRID(ctx, c, srid, FormatKML)
+
+			require.True(t, ok, srid)
+			assert.Equal(t, ctx, got, srid)
+		}
+	})
+
+	t.Run("projected SRID marks the context", func(t *testing.T) {
+		for _, format := range []string{"", FormatJSON, FormatGeoJSON} {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			ctx := context.Background()
+
+			got, ok := applyOutputSRID(ctx, c, 3857, format)
+
+			require.True(t, ok, format)
+			assert.NotEqual(t, ctx, got, format)
+		}
+	})
+
+	t.Run("rejects unsupported SRIDs and WGS84-only formats", func(t *testing.T) {
+		for _, tt := range []struct {
+			name   string
+			format string
+			srid   int
+		}{
+			{name: "unsupported", srid: 32614},
+			{name: "kml", srid: 2278, format: FormatKML},
+			{name: "gpkg", srid: 3857, format: FormatGeoPackage},
+			{name: "csv", srid: 3857, format: FormatCSV},
+		} {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/parcels/nearby?srid=3857", nil)
+
+			_, ok := applyOutputSRID(context.Background(), c, tt.srid, tt.format)
+
+			assert.False(t, ok, tt.name)
+			assert.Equal(t, http.StatusBadRequest, w.Code, tt.name)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// DefaultSnapshotRangeDays is the time series window returned when from is omitted.
+const DefaultSnapshotRangeDays = 30
+
+// StatsHandler handles dataset statistics HTTP requests.
+type StatsHandler struct {
+	service services.StatsService
+}
+
+// NewStatsHandler creates a new StatsHandler instance.
+func NewStatsHandler(service services.StatsService) *StatsHandler {
+	return &StatsHandler{
+		service: service,
+	}
+}
+
+// SnapshotsRequest represents the query parameters for the snapshots endpoint.
+// Dates are YYYY-MM-DD (UTC); to defaults to today and from to DefaultSnapshotRangeDays before to.
+type SnapshotsRequest struct {
+	From   time.Time `form:"from" time_format:"2006-01-02" time_utc:"1"`
+	To     time.Time `form:"to" time_format:"2006-01-02" time_utc:"1"`
+	County string    `form:"county"`
+}
+
+// SnapshotsResponse represents the response for the snapshots endpoint.
+type SnapshotsResponse struct {
+	Snapshots []models.StatsSnapshot `json:"snapshots"`
+	Count     int                    `json:"count"`
+}
+
+// Snapshots handles GET /api/v1/stats/snapshots endpoint.
+// It returns precomputed daily per-county statistics as a time series.
+func (h *StatsHandler) Snapshots(c *gin.Context) {
+	// Bind and validate query parameters
+	var req SnapshotsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		// Check if it's a validation error
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		// Unparseable dates and other binding errors
+		apierrors.BadRequest(c, "Invalid query parameters", nil)
+		return
+	}
+
+	// Apply default range
+	if req.To.IsZero() {
+		req.To = time.Now().UTC()
+	}
+	if req.From.IsZero() {
+		req.From = req.To.AddDate(0, 0, -(DefaultSnapshotRangeDays - 1))
+	}
+
+	snapshots, err := h.service.GetSnapshots(c.Request.Context(), req.County, req.From, req.To)
+	if err != nil {
+		apierrors.Respond(c, "Failed to load stats snapshots", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SnapshotsResponse{
+		Snapshots: snapshots,
+		Count:     len(snapshots),
+	})
+}
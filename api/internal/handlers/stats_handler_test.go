@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MockStatsService is a mock implementation of StatsService for testing
+type MockStatsService struct {
+	mock.Mock
+}
+
+func (m *MockStatsService) CaptureSnapshot(ctx context.Context, at time.Time) (int64, error) {
+	args := m.Called(ctx, at)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockStatsService) GetSnapshots(ctx context.Context, county string, from, to time.Time) ([]models.StatsSnapshot, error) {
+	args := m.Called(ctx, county, from, to)
+	snapshots, _ := args.Get(0).([]models.StatsSnapshot)
+	return snapshots, args.Error(1)
+}
+
+func (m *MockStatsService) RunDailySnapshots(ctx context.Context) {
+	m.Called(ctx)
+}
+
+// setupStatsTestRouter creates a test router with stats handlers.
+func setupStatsTestRouter(handler *StatsHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+
+	router.GET("/api/v1/stats/snapshots", handler.Snapshots)
+
+	return router
+}
+
+func TestStatsHandler_Snapshots(t *testing.T) {
+	mockService := new(MockStatsService)
+	router := setupStatsTestRouter(NewStatsHandler(mockService))
+
+	from := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 10, 2, 0, 0, 0, 0, time.UTC)
+	mockService.On("GetSnapshots", mock.Anything, "Montgomery", from, to).Return([]models.StatsSnapshot{
+		{SnapshotDate: from, CountyName: "Montgomery", ParcelCount: 250000, TotalAcres: 640000},
+		{SnapshotDate: to, CountyName: "Montgomery", ParcelCount: 250012, TotalAcres: 640010},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stats/snapshots?county=Montgomery&from=2025-10-01&to=2025-10-02", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response SnapshotsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Count)
+	assert.Equal(t, int64(250012), response.Snapshots[1].ParcelCount)
+	mockService.AssertExpectations(t)
+}
+
+func TestStatsHandler_Snapshots_DefaultRange(t *testing.T) {
+	mockService := new(MockStatsService)
+	router := setupStatsTestRouter(NewStatsHandler(mockService))
+
+	var gotFrom, gotTo time.Time
+	mockService.On("GetSnapshots", mock.Anything, "", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			gotFrom = args.Get(2).(time.Time)
+			gotTo = args.Get(3).(time.Time)
+		}).
+		Return([]models.StatsSnapshot{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stats/snapshots", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, (DefaultSnapshotRangeDays-1)*24*time.Hour, gotTo.Sub(gotFrom))
+}
+
+func TestStatsHandler_Snapshots_Errors(t *testing.T) {
+	testCases := []struct {
+		name       string
+		query      string
+		serviceErr error
+		wantStatus int
+	}{
+		{"unparseable date", "from=10/01/2025", nil, http.StatusBadRequest},
+		{"invalid range", "from=2025-10-02&to=2025-10-01", fmt.Errorf("%w: reversed", services.ErrInvalidDateRange), http.StatusBadRequest},
+		{"database error", "from=2025-10-01&to=2025-10-02", fmt.Errorf("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockStatsService)
+			router := setupStatsTestRouter(NewStatsHandler(mockService))
+
+			if tc.serviceErr != nil {
+				mockService.On("GetSnapshots", mock.Anything, "", mock.Anything, mock.Anything).Return(nil, tc.serviceErr)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/stats/snapshots?"+tc.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// StyleHandler handles saved map style HTTP requests.
+// All routes are tenant-scoped via the X-Tenant-ID header.
+type StyleHandler struct {
+	service services.StyleService
+}
+
+// NewStyleHandler creates a new StyleHandler instance.
+func NewStyleHandler(service services.StyleService) *StyleHandler {
+	return &StyleHandler{
+		service: service,
+	}
+}
+
+// SaveStyleRequest represents the request body for creating or replacing a style.
+type SaveStyleRequest struct {
+	Description *string                  `json:"description"`
+	Config      *models.LayerStyleConfig `json:"config" binding:"required"`
+}
+
+// StyleResponse represents the response for a single style.
+type StyleResponse struct {
+	Style *models.MapStyle `json:"style"`
+}
+
+// StyleListResponse represents the response for the style list endpoint.
+type StyleListResponse struct {
+	Styles []models.MapStyle `json:"styles"`
+	Count  int               `json:"count"`
+}
+
+// List handles GET /api/v1/styles endpoint.
+func (h *StyleHandler) List(c *gin.Context) {
+	tenantID, ok := requireTenant(c)
+	if !ok {
+		return
+	}
+
+	styles, err := h.service.ListStyles(c.Request.Context(), tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, StyleListResponse{
+		Styles: styles,
+		Count:  len(styles),
+	})
+}
+
+// Get handles GET /api/v1/styles/:name endpoint.
+func (h *StyleHandler) Get(c *gin.Context) {
+	tenantID, ok := requireTenant(c)
+	if !ok {
+		return
+	}
+
+	style, err := h.service.GetStyle(c.Request.Context(), tenantID, c.Param("name"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, StyleResponse{Style: style})
+}
+
+// Put handles PUT /api/v1/styles/:name endpoint.
+// It creates the style or replaces an existing style with the same name.
+func (h *StyleHandler) Put(c *gin.Context) {
+	tenantID, ok := requireTenant(c)
+	if !ok {
+		return
+	}
+
+	var req SaveStyleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// Check if it's a validation error
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		// Malformed JSON or wrong field types
+		apierrors.BadRequest(c, "Invalid request body", nil)
+		return
+	}
+
+	style, err := h.service.SaveStyle(c.Request.Context(), &models.MapStyle{
+		TenantID:    tenantID,
+		Name:        c.Param("name"),
+		Description: req.Description,
+		Config:      *req.Config,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, StyleResponse{Style: style})
+}
+
+// Delete handles DELETE /api/v1/styles/:name endpoint.
+func (h *StyleHandler) Delete(c *gin.Context) {
+	tenantID, ok := requireTenant(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteStyle(c.Request.Context(), tenantID, c.Param("name")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// handleError maps style service errors to HTTP responses.
+func (h *StyleHandler) handleError(c *gin.Context, err error) {
+	apierrors.Respond(c, "Failed to process style request", err)
+}
+
+// requireTenant returns the tenant ID from the context, writing a 400 response if it is missing.
+func requireTenant(c *gin.Context) (string, bool) {
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		apierrors.BadRequest(c, "Missing "+middleware.TenantIDHeader+" header", nil)
+		return "", false
+	}
+	return tenantID, true
+}
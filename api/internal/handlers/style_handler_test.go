@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MockStyleService is a mock implementation of StyleService for testing
+type MockStyleService struct {
+	mock.Mock
+}
+
+func (m *MockStyleService) ListStyles(ctx context.Context, tenantID string) ([]models.MapStyle, error) {
+	args := m.Called(ctx, tenantID)
+	styles, _ := args.Get(0).([]models.MapStyle)
+	return styles, args.Error(1)
+}
+
+func (m *MockStyleService) GetStyle(ctx context.Context, tenantID, name string) (*models.MapStyle, error) {
+	args := m.Called(ctx, tenantID, name)
+	style, _ := args.Get(0).(*models.MapStyle)
+	return style, args.Error(1)
+}
+
+func (m *MockStyleService) SaveStyle(ctx context.Context, style *models.MapStyle) (*models.MapStyle, error) {
+	args := m.Called(ctx, style)
+	stored, _ := args.Get(0).(*models.MapStyle)
+	return stored, args.Error(1)
+}
+
+func (m *MockStyleService) DeleteStyle(ctx context.Context, tenantID, name string) error {
+	args := m.Called(ctx, tenantID, name)
+	return args.Error(0)
+}
+
+// setupStyleTestRouter creates a test router with tenant middleware and style handlers.
+func setupStyleTestRouter(handler *StyleHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+	router.Use(middleware.Tenant())
+
+	styles := router.Group("/api/v1/styles")
+	{
+		styles.GET("", handler.List)
+		styles.GET("/:name", handler.Get)
+		styles.PUT("/:name", handler.Put)
+		styles.DELETE("/:name", handler.Delete)
+	}
+
+	return router
+}
+
+func TestStyleHandler_MissingTenant(t *testing.T) {
+	mockService := new(MockStyleService)
+	router := setupStyleTestRouter(NewStyleHandler(mockService))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/styles", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "ListStyles")
+}
+
+func TestStyleHandler_List(t *testing.T) {
+	mockService := new(MockStyleService)
+	router := setupStyleTestRouter(NewStyleHandler(mockService))
+
+	mockService.On("ListStyles", mock.Anything, "acme").Return([]models.MapStyle{
+		{ID: 1, TenantID: "acme", Name: "default"},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/styles", nil)
+	req.Header.Set(middleware.TenantIDHeader, "acme")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response StyleListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Count)
+	assert.Equal(t, "default", response.Styles[0].Name)
+	mockService.AssertExpectations(t)
+}
+
+func TestStyleHandler_Put(t *testing.T) {
+	t.Run("saves style from path and body", func(t *testing.T) {
+		mockService := new(MockStyleService)
+		router := setupStyleTestRouter(NewStyleHandler(mockService))
+
+		mockService.On("SaveStyle", mock.Anything, mock.MatchedBy(func(s *models.MapStyle) bool {
+			return s.TenantID == "acme" && s.Name == "land-use" && s.Config.Fill.DefaultColor == "#cccccc"
+		})).Return(&models.MapStyle{ID: 3, TenantID: "acme", Name: "land-use"}, nil)
+
+		body := `{"config":{"fill":{"defaultColor":"#cccccc","opacity":0.5},"labels":[],"visibility":{"minZoom":10,"maxZoom":22}}}`
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/styles/land-use", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(middleware.TenantIDHeader, "acme")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("missing config is a validation error", func(t *testing.T) {
+		mockService := new(MockStyleService)
+		router := setupStyleTestRouter(NewStyleHandler(mockService))
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/styles/land-use", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(middleware.TenantIDHeader, "acme")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var response apierrors.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, apierrors.ErrValidation, response.Error.Code)
+	})
+
+	t.Run("service validation error maps to 400", func(t *testing.T) {
+		mockService := new(MockStyleService)
+		router := setupStyleTestRouter(NewStyleHandler(mockService))
+
+		mockService.On("SaveStyle", mock.Anything, mock.Anything).Return(nil, services.ErrInvalidStyle)
+
+		body := `{"config":{"fill":{"defaultColor":"red"},"visibility":{"minZoom":0,"maxZoom":22}}}`
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/styles/land-use", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(middleware.TenantIDHeader, "acme")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestStyleHandler_GetAndDelete_NotFound(t *testing.T) {
+	mockService := new(MockStyleService)
+	router := setupStyleTestRouter(NewStyleHandler(mockService))
+
+	mockService.On("GetStyle", mock.Anything, "acme", "missing").Return(nil, services.ErrStyleNotFound)
+	mockService.On("DeleteStyle", mock.Anything, "acme", "missing").Return(services.ErrStyleNotFound)
+
+	for _, method := range []string{http.MethodGet, http.MethodDelete} {
+		req := httptest.NewRequest(method, "/api/v1/styles/missing", nil)
+		req.Header.Set(middleware.TenantIDHeader, "acme")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code, method)
+	}
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// UserHandler handles user registration, login and account HTTP requests.
+type UserHandler struct {
+	service services.UserService
+}
+
+// NewUserHandler creates a new UserHandler instance.
+func NewUserHandler(service services.UserService) *UserHandler {
+	return &UserHandler{
+		service: service,
+	}
+}
+
+// CredentialsRequest represents the request body for registering and logging in.
+type CredentialsRequest struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// SessionResponse is returned by register and login. Token is sent back as
+// "Authorization: Bearer <token>" until ExpiresAt.
+type SessionResponse struct {
+	ExpiresAt time.Time    `json:"expiresAt"`
+	User      *models.User `json:"user"`
+	Token     string       `json:"token"`
+}
+
+// Register handles POST /api/v1/auth/register endpoint.
+func (h *UserHandler) Register(c *gin.Context) {
+	req, ok := bindCredentials(c)
+	if !ok {
+		return
+	}
+
+	session, err := h.service.Register(c.Request.Context(), req.Email, req.Password)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, sessionResponse(session))
+}
+
+// Login handles POST /api/v1/auth/login endpoint.
+func (h *UserHandler) Login(c *gin.Context) {
+	req, ok := bindCredentials(c)
+	if !ok {
+		return
+	}
+	// Failed logins count against the account as well as the client IP
+	if !middleware.GuardSubject(c, "user:"+strings.ToLower(strings.TrimSpace(req.Email))) {
+		return
+	}
+
+	session, err := h.service.Login(c.Request.Context(), req.Email, req.Password)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sessionResponse(session))
+}
+
+// Me handles GET /api/v1/users/me endpoint.
+func (h *UserHandler) Me(c *gin.Context) {
+	user, err := h.service.GetUser(c.Request.Context(), middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// handleError maps user service errors to HTTP responses.
+func (h *UserHandler) handleError(c *gin.Context, err error) {
+	// The token outlived its account
+	if errors.Is(err, services.ErrUserNotFound) {
+		apierrors.Unauthorized(c, "User no longer exists")
+		return
+	}
+	apierrors.Respond(c, "Failed to process user request", err)
+}
+
+// bindCredentials binds the credentials request body, writing the error
+// response if it is invalid.
+func bindCredentials(c *gin.Context) (*CredentialsRequest, bool) {
+	var req CredentialsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// Check if it's a validation error
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return nil, false
+		}
+		// Malformed JSON or wrong field types
+		apierrors.BadRequest(c, "Invalid request body", nil)
+		return nil, false
+	}
+	return &req, true
+}
+
+// sessionResponse converts a service session to its response body.
+func sessionResponse(session *services.UserSession) SessionResponse {
+	return SessionResponse{
+		ExpiresAt: session.ExpiresAt,
+		User:      session.User,
+		Token:     session.Token,
+	}
+}
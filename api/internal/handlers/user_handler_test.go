@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MockUserService is a mock implementation of UserService for testing
+type MockUserService struct {
+	mock.Mock
+}
+
+func (m *MockUserService) Register(ctx context.Context, email, password string) (*services.UserSession, error) {
+	args := m.Called(ctx, email, password)
+	session, _ := args.Get(0).(*services.UserSession)
+	return session, args.Error(1)
+}
+
+func (m *MockUserService) Login(ctx context.Context, email, password string) (*services.UserSession, error) {
+	args := m.Called(ctx, email, password)
+	session, _ := args.Get(0).(*services.UserSession)
+	return session, args.Error(1)
+}
+
+func (m *MockUserService) GetUser(ctx context.Context, id uint) (*models.User, error) {
+	args := m.Called(ctx, id)
+	user, _ := args.Get(0).(*models.User)
+	return user, args.Error(1)
+}
+
+// setupUserTestRouter creates a test router with the user handlers. Requests
+// with an X-Test-User header are treated as signed in as user 7.
+func setupUserTestRouter(handler *UserHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+	router.Use(func(c *gin.Context) {
+		if c.GetHeader("X-Test-User") != "" {
+			c.Set(middleware.UserIDKey, uint(7))
+		}
+		c.Next()
+	})
+
+	router.POST("/api/v1/auth/register", handler.Register)
+	router.POST("/api/v1/auth/login", handler.Login)
+	router.GET("/api/v1/users/me", handler.Me)
+
+	return router
+}
+
+// serveUserRequest serves a JSON request.
+func serveUserRequest(router *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Test-User", "1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestUserHandler_Register(t *testing.T) {
+	session := &services.UserSession{
+		User:      &models.User{ID: 7, Email: "ada@example.com", PasswordHash: "hash"},
+		Token:     "header.payload.signature",
+		ExpiresAt: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("returns the user and token", func(t *testing.T) {
+		mockService := new(MockUserService)
+		router := setupUserTestRouter(NewUserHandler(mockService))
+		mockService.On("Register", mock.Anything, "ada@example.com", "correct horse").Return(session, nil)
+
+		w := serveUserRequest(router, http.MethodPost, "/api/v1/auth/register", `{"email":"ada@example.com","password":"correct horse"}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response SessionResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "header.payload.signature", response.Token)
+		assert.Equal(t, uint(7), response.User.ID)
+		assert.NotContains(t, w.Body.String(), "hash")
+	})
+
+	tests := []struct {
+		err        error
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{name: "missing password", body: `{"email":"ada@example.com"}`, wantStatus: http.StatusBadRequest},
+		{name: "malformed body", body: `{"email":`, wantStatus: http.StatusBadRequest},
+		{name: "invalid user", body: `{"email":"ada","password":"x"}`, err: services.ErrInvalidUser, wantStatus: http.StatusBadRequest},
+		{name: "email taken", body: `{"email":"ada","password":"x"}`, err: services.ErrEmailTaken, wantStatus: http.StatusConflict},
+		{name: "store failure", body: `{"email":"ada","password":"x"}`, err: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockUserService)
+			router := setupUserTestRouter(NewUserHandler(mockService))
+			if tt.err != nil {
+				mockService.On("Register", mock.Anything, mock.Anything, mock.Anything).Return(nil, tt.err)
+			}
+
+			w := serveUserRequest(router, http.MethodPost, "/api/v1/auth/register", tt.body)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestUserHandler_Login(t *testing.T) {
+	t.Run("valid credentials", func(t *testing.T) {
+		mockService := new(MockUserService)
+		router := setupUserTestRouter(NewUserHandler(mockService))
+		mockService.On("Login", mock.Anything, "ada@example.com", "correct horse").
+			Return(&services.UserSession{User: &models.User{ID: 7}, Token: "token"}, nil)
+
+		w := serveUserRequest(router, http.MethodPost, "/api/v1/auth/login", `{"email":"ada@example.com","password":"correct horse"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"token":"token"`)
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		mockService := new(MockUserService)
+		router := setupUserTestRouter(NewUserHandler(mockService))
+		mockService.On("Login", mock.Anything, mock.Anything, mock.Anything).Return(nil, services.ErrInvalidCredentials)
+
+		w := serveUserRequest(router, http.MethodPost, "/api/v1/auth/login", `{"email":"ada@example.com","password":"wrong"}`)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestUserHandler_Me(t *testing.T) {
+	t.Run("returns the signed-in user", func(t *testing.T) {
+		mockService := new(MockUserService)
+		router := setupUserTestRouter(NewUserHandler(mockService))
+		mockService.On("GetUser", mock.Anything, uint(7)).Return(&models.User{ID: 7, Email: "ada@example.com"}, nil)
+
+		w := serveUserRequest(router, http.MethodGet, "/api/v1/users/me", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"email":"ada@example.com"`)
+	})
+
+	t.Run("deleted user", func(t *testing.T) {
+		mockService := new(MockUserService)
+		router := setupUserTestRouter(NewUserHandler(mockService))
+		mockService.On("GetUser", mock.Anything, uint(7)).Return(nil, services.ErrUserNotFound)
+
+		w := serveUserRequest(router, http.MethodGet, "/api/v1/users/me", "")
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+)
+
+// Role sets used in the visibility matrix.
+var (
+	allRoles       = []middleware.Role{middleware.RolePublic, middleware.RolePartner, middleware.RoleAdmin}
+	publicAndAdmin = []middleware.Role{middleware.RolePublic, middleware.RoleAdmin}
+	adminOnly      = []middleware.Role{middleware.RoleAdmin}
+)
+
+// parcelDTOFields are the members listed in the matrix, sorted.
+var parcelDTOFields = slices.Sorted(maps.Keys(fieldVisibility))
+
+// fieldVisibility is the role × field matrix for parcel response DTOs, keyed by
+// JSON member name. Every member of a parcel DTO must be listed here; members
+// missing from the matrix are hidden from every role. Partner visibility is the
+// upper bound for embed widgets, which narrow it further with their visible fields.
+var fieldVisibility = map[string][]middleware.Role{
+	fieldID:       allRoles,
+	fieldGeometry: allRoles,
+	"bbox":        allRoles, // envelope of the geometry
+
+	// Parcel attributes
+	"parcel_id":     allRoles,
+	"owner_name":    allRoles,
+	"owner_id":      allRoles,
+	"owner_address": adminOnly, // encrypted at rest, decrypted only for these roles
+	"situs_address": allRoles,
+	// Reverse geocoded for at-point parcels without a situs address
+	"geocoded_address": allRoles,
+	"prop_type":        allRoles,
+	"land_use":         allRoles,
+	"county_name":      allRoles,
+	"acres":            allRoles,
+	"centroid":         publicAndAdmin,
+	"pin":              publicAndAdmin,
+
+	// Waterfront, computed from the county's hydrology layer
+	"waterfront":            allRoles,
+	"water_frontage_meters": allRoles,
+	"waterfront_type":       allRoles,
+	"water_body":            allRoles,
+
+	// Nearest amenities, added with include=amenities
+	"amenities": allRoles,
+
+	// Zoning districts covering the parcel, added with include=zoning
+	"zoning": allRoles,
+
+	// FEMA flood zones covering the parcel, added with include=flood_zone
+	"flood_zones": allRoles,
+
+	// School district and taxing units of the parcel, added with include=jurisdictions
+	"jurisdictions": allRoles,
+
+	// Related routes; embed widgets only reach their own routes
+	fieldLinks: publicAndAdmin,
+
+	// Derived attributes; their expressions may read columns no DTO member exposes
+	"attributes": publicAndAdmin,
+
+	// Query results
+	"distance_meters": publicAndAdmin,
+	"station_meters":  publicAndAdmin,
+	"score":           publicAndAdmin,
+	"probability":     publicAndAdmin,
+	"contains_point":  publicAndAdmin,
+
+	// Comparison
+	"assessment":       publicAndAdmin,
+	"geometry_summary": publicAndAdmin,
+
+	// Comparables
+	"year_built": publicAndAdmin,
+	"similarity": publicAndAdmin,
+
+	// Admin parcel sample
+	"quality_flags": adminOnly,
+}
+
+// fieldVisible reports whether role may see the DTO member name.
+func fieldVisible(role middleware.Role, name string) bool {
+	return slices.Contains(fieldVisibility[name], role)
+}
+
+// visibleFields returns the sorted DTO members role may see.
+func visibleFields(role middleware.Role) []string {
+	fields := make([]string, 0, len(parcelDTOFields))
+	for _, name := range parcelDTOFields {
+		if fieldVisible(role, name) {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// seesAllFields reports whether role may see every DTO member, so responses
+// can be written without filtering.
+func seesAllFields(role middleware.Role) bool {
+	return len(visibleFields(role)) == len(parcelDTOFields)
+}
+
+// redactFields removes the members of a serialized DTO that role may not see.
+func redactFields(dto map[string]interface{}, role middleware.Role) map[string]interface{} {
+	for name := range dto {
+		if !fieldVisible(role, name) {
+			delete(dto, name)
+		}
+	}
+	return dto
+}
+
+// shapeFeature applies the field selection and the role's visibility to a feature.
+// A non-empty fields selection limits the properties (the id is always kept); the
+// geometry is nulled when it is not selected or not visible.
+func shapeFeature(feature *Feature, fields []string, role middleware.Role) {
+	if len(fields) > 0 {
+		feature.Properties = pickFields(feature.Properties, fields, fieldID)
+	}
+	feature.Properties = redactFields(feature.Properties, role)
+	if !wantsField(fields, fieldGeometry) || !fieldVisible(role, fieldGeometry) {
+		feature.Geometry = nil
+	}
+}
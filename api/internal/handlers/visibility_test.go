@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+func TestFieldVisibility_CoversParcelDTOs(t *testing.T) {
+	dtos := []interface{}{
+		ParcelData{}, PointCandidate{}, ParcelWithDistance{}, IdentifyData{},
+		AddressCandidate{}, RouteParcel{}, ComparedParcel{}, SampledParcel{}, ComparableSubject{}, ComparableParcel{},
+	}
+
+	for _, dto := range dtos {
+		for _, name := range jsonFieldNames(dto) {
+			assert.Contains(t, fieldVisibility, name, "%T.%s needs a visibility entry", dto, name)
+		}
+	}
+}
+
+func TestFieldVisibility_Roles(t *testing.T) {
+	public := []string{
+		"acres", "amenities", "assessment", "attributes", "bbox", "centroid", "contains_point", "county_name", "distance_meters", "flood_zones", "geocoded_address",
+		"geometry", "geometry_summary", "id", "jurisdictions", "land_use", "links", "owner_id", "owner_name", "parcel_id", "pin", "probability",
+		"prop_type", "score", "similarity", "situs_address", "station_meters", "water_body", "water_frontage_meters", "waterfront", "waterfront_type",
+		"year_built", "zoning",
+	}
+	everything := []string{
+		"acres", "amenities", "assessment", "attributes", "bbox", "centroid", "contains_point", "county_name", "distance_meters", "flood_zones", "geocoded_address",
+		"geometry", "geometry_summary", "id", "jurisdictions", "land_use", "links", "owner_address", "owner_id", "owner_name", "parcel_id", "pin", "probability", "prop_type",
+		"quality_flags", "score", "similarity", "situs_address", "station_meters", "water_body", "water_frontage_meters", "waterfront",
+		"waterfront_type", "year_built", "zoning",
+	}
+
+	tests := []struct {
+		role    middleware.Role
+		visible []string
+		all     bool
+	}{
+		{role: middleware.RolePublic, visible: public},
+		{role: middleware.RoleAdmin, visible: everything, all: true},
+		{
+			role: middleware.RolePartner,
+			visible: []string{
+				"acres", "amenities", "bbox", "county_name", "flood_zones", "geocoded_address", "geometry", "id", "jurisdictions", "land_use", "owner_id", "owner_name",
+				"parcel_id", "prop_type", "situs_address", "water_body", "water_frontage_meters", "waterfront", "waterfront_type", "zoning",
+			},
+		},
+		{role: middleware.Role("unknown"), visible: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role), func(t *testing.T) {
+			assert.Equal(t, tt.visible, visibleFields(tt.role))
+			assert.Equal(t, tt.all, seesAllFields(tt.role))
+		})
+	}
+}
+
+func TestFieldVisibility_PartnerCoversWidgetFields(t *testing.T) {
+	// Widgets may only offer fields their partner audience is allowed to see
+	for name := range services.WidgetFields {
+		assert.True(t, fieldVisible(middleware.RolePartner, name), name)
+	}
+}
+
+func TestRenderSelectedJSON_Role(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	response := IntersectsResponse{
+		Parcels: []*ParcelData{{ID: 1, OwnerName: "Test Owner", CountyName: "Montgomery", Centroid: [2]float64{-95.4, 30.3}}},
+		Count:   1,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set(middleware.RoleKey, middleware.RolePartner)
+	renderSelectedJSON(c, response, nil, "parcels")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		Parcels []map[string]interface{} `json:"parcels"`
+		Count   int                      `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, 1, body.Count, "non-item members are unchanged")
+	require.Len(t, body.Parcels, 1)
+	assert.Equal(t, "Test Owner", body.Parcels[0]["owner_name"])
+	assert.NotContains(t, body.Parcels[0], "centroid", "partners do not see centroids")
+}
+
+func TestShapeFeature_Role(t *testing.T) {
+	feature, err := toFeature(RouteParcel{ParcelData: &ParcelData{ID: 1, CountyName: "Montgomery"}, Station: 40})
+	require.NoError(t, err)
+
+	shapeFeature(&feature, nil, middleware.RolePartner)
+
+	assert.Contains(t, feature.Properties, "county_name")
+	assert.NotContains(t, feature.Properties, "station_meters")
+	assert.NotContains(t, feature.Properties, "centroid")
+}
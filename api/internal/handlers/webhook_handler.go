@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// Webhook constants
+const (
+	// DefaultWebhookDeliveriesLimit is the number of deliveries returned without a limit
+	DefaultWebhookDeliveriesLimit = 100
+)
+
+// WebhookHandler handles the tenant's webhooks and their deliveries.
+// All routes are tenant-scoped via the X-Tenant-ID header.
+type WebhookHandler struct {
+	service services.WebhookService
+}
+
+// NewWebhookHandler creates a new WebhookHandler instance.
+func NewWebhookHandler(service services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		service: service,
+	}
+}
+
+// CreateWebhookRequest represents the request body for registering a webhook.
+// URL must be https. Area, a GeoJSON Polygon or MultiPolygon, and County
+// limit the parcels delivered; without them every changed parcel is.
+type CreateWebhookRequest struct {
+	Area   json.RawMessage `json:"area"`
+	URL    string          `json:"url" binding:"required"`
+	County string          `json:"county" binding:"omitempty,max=100"`
+}
+
+// CreateWebhookResponse represents the response for a registered webhook.
+// Secret signs its deliveries and is only returned here.
+type CreateWebhookResponse struct {
+	Webhook *models.Webhook `json:"webhook"`
+	Secret  string          `json:"secret"`
+}
+
+// WebhookListResponse represents the response for the webhook list endpoint.
+type WebhookListResponse struct {
+	Webhooks []models.Webhook `json:"webhooks"`
+	Count    int              `json:"count"`
+}
+
+// WebhookDeliveriesRequest represents the query parameters for the deliveries endpoint.
+type WebhookDeliveriesRequest struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=500"` // default: 100
+}
+
+// WebhookDeliveriesResponse represents the response for the deliveries endpoint.
+type WebhookDeliveriesResponse struct {
+	Deliveries []models.WebhookDelivery `json:"deliveries"`
+	Count      int                      `json:"count"`
+}
+
+// Create handles POST /api/v1/webhooks endpoint.
+// Responds 201 Created with the webhook and its signing secret.
+func (h *WebhookHandler) Create(c *gin.Context) {
+	tenantID, ok := requireTenant(c)
+	if !ok {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxAreaBodyBytes)
+
+	var req CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// Check if it's a validation error
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		// Malformed JSON, wrong field types or a body over MaxAreaBodyBytes
+		apierrors.BadRequest(c, "Invalid request body", nil)
+		return
+	}
+
+	webhook := models.Webhook{
+		TenantID: tenantID,
+		URL:      req.URL,
+	}
+	if len(req.Area) > 0 && string(req.Area) != "null" {
+		area, err := models.ParseAreaGeoJSON(req.Area)
+		if err != nil {
+			apierrors.BadRequest(c, "area must be a GeoJSON Polygon or MultiPolygon", map[string]interface{}{
+				"reason": err.Error(),
+			})
+			return
+		}
+		webhook.Area = area
+	}
+	if req.County != "" {
+		webhook.County = &req.County
+	}
+
+	stored, secret, err := h.service.CreateWebhook(c.Request.Context(), webhook)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, CreateWebhookResponse{Webhook: stored, Secret: secret})
+}
+
+// List handles GET /api/v1/webhooks endpoint.
+func (h *WebhookHandler) List(c *gin.Context) {
+	tenantID, ok := requireTenant(c)
+	if !ok {
+		return
+	}
+
+	webhooks, err := h.service.ListWebhooks(c.Request.Context(), tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, WebhookListResponse{
+		Webhooks: webhooks,
+		Count:    len(webhooks),
+	})
+}
+
+// Delete handles DELETE /api/v1/webhooks/:id endpoint.
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	tenantID, ok := requireTenant(c)
+	if !ok {
+		return
+	}
+	id, ok := webhookID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteWebhook(c.Request.Context(), tenantID, id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Deliveries handles GET /api/v1/webhooks/:id/deliveries endpoint.
+// It returns the webhook's deliveries with the outcome of their last attempt,
+// newest first.
+func (h *WebhookHandler) Deliveries(c *gin.Context) {
+	tenantID, ok := requireTenant(c)
+	if !ok {
+		return
+	}
+	id, ok := webhookID(c)
+	if !ok {
+		return
+	}
+
+	var req WebhookDeliveriesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		apierrors.BadRequest(c, "Invalid query parameters", nil)
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = DefaultWebhookDeliveriesLimit
+	}
+
+	deliveries, err := h.service.ListDeliveries(c.Request.Context(), tenantID, id, req.Limit)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	middleware.SetResultCount(c, len(deliveries))
+
+	c.JSON(http.StatusOK, WebhookDeliveriesResponse{
+		Deliveries: deliveries,
+		Count:      len(deliveries),
+	})
+}
+
+// handleError maps webhook service errors to HTTP responses.
+func (h *WebhookHandler) handleError(c *gin.Context, err error) {
+	apierrors.Respond(c, "Failed to process webhook request", err)
+}
+
+// webhookID parses the :id parameter, writing the error response if it is invalid.
+func webhookID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		apierrors.BadRequest(c, "webhook id must be a positive integer", nil)
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// MockWebhookService is a mock implementation of WebhookService for testing
+type MockWebhookService struct {
+	mock.Mock
+}
+
+func (m *MockWebhookService) CreateWebhook(ctx context.Context, webhook models.Webhook) (*models.Webhook, string, error) {
+	args := m.Called(ctx, webhook)
+	stored, _ := args.Get(0).(*models.Webhook)
+	return stored, args.String(1), args.Error(2)
+}
+
+func (m *MockWebhookService) ListWebhooks(ctx context.Context, tenantID string) ([]models.Webhook, error) {
+	args := m.Called(ctx, tenantID)
+	webhooks, _ := args.Get(0).([]models.Webhook)
+	return webhooks, args.Error(1)
+}
+
+func (m *MockWebhookService) DeleteWebhook(ctx context.Context, tenantID string, id uint) error {
+	args := m.Called(ctx, tenantID, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) ListDeliveries(ctx context.Context, tenantID string, id uint, limit int) ([]models.WebhookDelivery, error) {
+	args := m.Called(ctx, tenantID, id, limit)
+	deliveries, _ := args.Get(0).([]models.WebhookDelivery)
+	return deliveries, args.Error(1)
+}
+
+func (m *MockWebhookService) EnqueueAll(ctx context.Context, runID int64) (int64, error) {
+	args := m.Called(ctx, runID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockWebhookService) DeliverDue(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockWebhookService) Run(ctx context.Context) {
+	m.Called(ctx)
+}
+
+// setupWebhookTestRouter creates a test router with tenant middleware and webhook handlers.
+func setupWebhookTestRouter(handler *WebhookHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger.New("test")))
+	router.Use(middleware.Tenant())
+
+	webhooks := router.Group("/api/v1/webhooks")
+	{
+		webhooks.GET("", handler.List)
+		webhooks.POST("", handler.Create)
+		webhooks.DELETE("/:id", handler.Delete)
+		webhooks.GET("/:id/deliveries", handler.Deliveries)
+	}
+
+	return router
+}
+
+func TestWebhookHandler_MissingTenant(t *testing.T) {
+	mockService := new(MockWebhookService)
+	router := setupWebhookTestRouter(NewWebhookHandler(mockService))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/webhooks", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "ListWebhooks")
+}
+
+func TestWebhookHandler_Create(t *testing.T) {
+	t.Run("returns the webhook with its secret", func(t *testing.T) {
+		mockService := new(MockWebhookService)
+		router := setupWebhookTestRouter(NewWebhookHandler(mockService))
+
+		mockService.On("CreateWebhook", mock.Anything, mock.MatchedBy(func(w models.Webhook) bool {
+			return w.TenantID == "acme" && w.URL == "https://hooks.example.com" &&
+				w.County != nil && *w.County == "montgomery-tx" && w.Area != nil
+		})).Return(&models.Webhook{ID: 3, TenantID: "acme", URL: "https://hooks.example.com", Secret: "whsec_abc"}, "whsec_abc", nil)
+
+		body := `{"url":"https://hooks.example.com","county":"montgomery-tx",` +
+			`"area":{"type":"Polygon","coordinates":[[[-95.5,30.3],[-95.4,30.3],[-95.4,30.4],[-95.5,30.3]]]}}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(middleware.TenantIDHeader, "acme")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var response map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.JSONEq(t, `"whsec_abc"`, string(response["secret"]))
+		assert.NotContains(t, string(response["webhook"]), "whsec_abc", "the secret is not serialized with the webhook")
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("area is optional", func(t *testing.T) {
+		mockService := new(MockWebhookService)
+		router := setupWebhookTestRouter(NewWebhookHandler(mockService))
+
+		mockService.On("CreateWebhook", mock.Anything, mock.MatchedBy(func(w models.Webhook) bool {
+			return w.Area == nil && w.County == nil
+		})).Return(&models.Webhook{ID: 3}, "whsec_abc", nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", strings.NewReader(`{"url":"https://hooks.example.com"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(middleware.TenantIDHeader, "acme")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid area", func(t *testing.T) {
+		mockService := new(MockWebhookService)
+		router := setupWebhookTestRouter(NewWebhookHandler(mockService))
+
+		body := `{"url":"https://hooks.example.com","area":{"type":"Point","coordinates":[-95.5,30.3]}}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(middleware.TenantIDHeader, "acme")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "CreateWebhook")
+	})
+
+	t.Run("service validation error maps to 400", func(t *testing.T) {
+		mockService := new(MockWebhookService)
+		router := setupWebhookTestRouter(NewWebhookHandler(mockService))
+
+		mockService.On("CreateWebhook", mock.Anything, mock.Anything).Return(nil, "", services.ErrInvalidWebhook)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", strings.NewReader(`{"url":"http://localhost"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(middleware.TenantIDHeader, "acme")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestWebhookHandler_Deliveries(t *testing.T) {
+	t.Run("default limit", func(t *testing.T) {
+		mockService := new(MockWebhookService)
+		router := setupWebhookTestRouter(NewWebhookHandler(mockService))
+
+		mockService.On("ListDeliveries", mock.Anything, "acme", uint(3), DefaultWebhookDeliveriesLimit).Return([]models.WebhookDelivery{
+			{ID: 21, WebhookID: 3, Status: models.WebhookDeliveryDelivered},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/webhooks/3/deliveries", nil)
+		req.Header.Set(middleware.TenantIDHeader, "acme")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response WebhookDeliveriesResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, 1, response.Count)
+		assert.Equal(t, int64(21), response.Deliveries[0].ID)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("webhook not found", func(t *testing.T) {
+		mockService := new(MockWebhookService)
+		router := setupWebhookTestRouter(NewWebhookHandler(mockService))
+
+		mockService.On("ListDeliveries", mock.Anything, "acme", uint(4), 10).Return(nil, services.ErrWebhookNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/webhooks/4/deliveries?limit=10", nil)
+		req.Header.Set(middleware.TenantIDHeader, "acme")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestWebhookHandler_Delete(t *testing.T) {
+	mockService := new(MockWebhookService)
+	router := setupWebhookTestRouter(NewWebhookHandler(mockService))
+
+	mockService.On("DeleteWebhook", mock.Anything, "acme", uint(3)).Return(nil)
+	mockService.On("DeleteWebhook", mock.Anything, "acme", uint(4)).Return(services.ErrWebhookNotFound)
+
+	for path, want := range map[string]int{
+		"/api/v1/webhooks/3":   http.StatusNoContent,
+		"/api/v1/webhooks/4":   http.StatusNotFound,
+		"/api/v1/webhooks/abc": http.StatusBadRequest,
+	} {
+		req := httptest.NewRequest(http.MethodDelete, path, nil)
+		req.Header.Set(middleware.TenantIDHeader, "acme")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, want, w.Code, path)
+	}
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/services"
+)
+
+// WidgetHandler handles embeddable widget HTTP requests.
+// Management routes are tenant-scoped via the X-Tenant-ID header; embed routes
+// are public and authorized by a signed embed token instead.
+type WidgetHandler struct {
+	widgets services.WidgetService
+	parcels services.ParcelService
+}
+
+// NewWidgetHandler creates a new WidgetHandler instance.
+func NewWidgetHandler(widgets services.WidgetService, parcels services.ParcelService) *WidgetHandler {
+	return &WidgetHandler{
+		widgets: widgets,
+		parcels: parcels,
+	}
+}
+
+// SaveWidgetRequest represents the request body for creating or replacing a widget.
+type SaveWidgetRequest struct {
+	AllowedBBox   *models.BoundingBox   `json:"allowedBbox" binding:"required"`
+	Branding      models.WidgetBranding `json:"branding"`
+	VisibleFields []string              `json:"visibleFields" binding:"required"`
+}
+
+// EmbedTokenRequest carries the signed token for public embed endpoints.
+type EmbedTokenRequest struct {
+	Token string `form:"token" binding:"required"`
+}
+
+// EmbedAtPointRequest represents the query parameters for the embed at-point endpoint.
+type EmbedAtPointRequest struct {
+	Token string  `form:"token" binding:"required"`
+	Lat   float64 `form:"lat" binding:"required,min=-90,max=90"`
+	Lng   float64 `form:"lng" binding:"required,min=-180,max=180"`
+}
+
+// WidgetResponse represents the response for a single widget.
+type WidgetResponse struct {
+	Widget *models.EmbedWidget `json:"widget"`
+}
+
+// WidgetListResponse represents the response for the widget list endpoint.
+type WidgetListResponse struct {
+	Widgets []models.EmbedWidget `json:"widgets"`
+	Count   int                  `json:"count"`
+}
+
+// EmbedTokenResponse is returned when a token is issued.
+// Config is the same payload the viewer script receives from the embed config endpoint.
+type EmbedTokenResponse struct {
+	ExpiresAt time.Time    `json:"expires_at"`
+	Config    *EmbedConfig `json:"config"`
+	Token     string       `json:"token"`
+}
+
+// EmbedConfig is the public widget configuration consumed by the viewer script.
+// It omits tenant and bookkeeping fields.
+type EmbedConfig struct {
+	Branding      models.WidgetBranding `json:"branding"`
+	Widget        string                `json:"widget"`
+	VisibleFields []string              `json:"visible_fields"`
+	AllowedBBox   models.BoundingBox    `json:"allowed_bbox"`
+}
+
+// EmbedConfigResponse represents the response for the embed config endpoint.
+type EmbedConfigResponse struct {
+	Config *EmbedConfig `json:"config"`
+}
+
+// EmbedParcelResponse represents a parcel restricted to the widget's visible fields.
+type EmbedParcelResponse struct {
+	Parcel map[string]interface{} `json:"parcel"`
+}
+
+// List handles GET /api/v1/widgets endpoint.
+func (h *WidgetHandler) List(c *gin.Context) {
+	tenantID, ok := requireTenant(c)
+	if !ok {
+		return
+	}
+
+	widgets, err := h.widgets.ListWidgets(c.Request.Context(), tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, WidgetListResponse{
+		Widgets: widgets,
+		Count:   len(widgets),
+	})
+}
+
+// Get handles GET /api/v1/widgets/:name endpoint.
+func (h *WidgetHandler) Get(c *gin.Context) {
+	tenantID, ok := requireTenant(c)
+	if !ok {
+		return
+	}
+
+	widget, err := h.widgets.GetWidget(c.Request.Context(), tenantID, c.Param("name"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, WidgetResponse{Widget: widget})
+}
+
+// Put handles PUT /api/v1/widgets/:name endpoint.
+// It creates the widget or replaces an existing widget with the same name.
+func (h *WidgetHandler) Put(c *gin.Context) {
+	tenantID, ok := requireTenant(c)
+	if !ok {
+		return
+	}
+
+	var req SaveWidgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// Check if it's a validation error
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		// Malformed JSON or wrong field types
+		apierrors.BadRequest(c, "Invalid request body", nil)
+		return
+	}
+
+	widget, err := h.widgets.SaveWidget(c.Request.Context(), &models.EmbedWidget{
+		TenantID:      tenantID,
+		Name:          c.Param("name"),
+		AllowedBBox:   *req.AllowedBBox,
+		VisibleFields: req.VisibleFields,
+		Branding:      req.Branding,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, WidgetResponse{Widget: widget})
+}
+
+// IssueToken handles POST /api/v1/widgets/:name/token endpoint.
+// The returned token is embedded in the partner page alongside the viewer script.
+func (h *WidgetHandler) IssueToken(c *gin.Context) {
+	tenantID, ok := requireTenant(c)
+	if !ok {
+		return
+	}
+
+	grant, err := h.widgets.IssueEmbedToken(c.Request.Context(), tenantID, c.Param("name"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, EmbedTokenResponse{
+		Token:     grant.Token,
+		ExpiresAt: grant.ExpiresAt,
+		Config:    mapWidgetToEmbedConfig(grant.Widget),
+	})
+}
+
+// EmbedConfig handles GET /api/v1/embed/config endpoint.
+// It is public; the token query parameter identifies and authorizes the widget.
+func (h *WidgetHandler) EmbedConfig(c *gin.Context) {
+	var req EmbedTokenRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		apierrors.Unauthorized(c, "Missing embed token")
+		return
+	}
+
+	widget, err := h.widgets.ResolveEmbedToken(c.Request.Context(), req.Token)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, EmbedConfigResponse{Config: mapWidgetToEmbedConfig(widget)})
+}
+
+// EmbedAtPoint handles GET /api/v1/embed/parcels/at-point endpoint.
+// It behaves like the at-point endpoint but only for points inside the widget's
+// allowed bounding box, and only returns the widget's visible fields.
+func (h *WidgetHandler) EmbedAtPoint(c *gin.Context) {
+	var req EmbedAtPointRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		// Check if it's a validation error
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			apierrors.ValidationError(c, validationErrors)
+			return
+		}
+		// Generic bad request for other binding errors
+		apierrors.BadRequest(c, "Invalid query parameters", nil)
+		return
+	}
+
+	widget, err := h.widgets.ResolveEmbedToken(c.Request.Context(), req.Token)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	point := models.LatLng{Lat: req.Lat, Lng: req.Lng}
+	if !widget.AllowedBBox.Contains(point) {
+		apierrors.Forbidden(c, "Location is outside the area allowed for this widget")
+		return
+	}
+
+	parcel, err := h.parcels.GetParcelAtPoint(c.Request.Context(), point)
+	if err != nil {
+		queryFailed(c, "Failed to query parcel data", domain.Reword(err, domain.ErrNotFound, "No property found at this location"))
+		return
+	}
+
+	fields, err := selectFields(mapTaxParcelToDTO(parcel), widget.VisibleFields, "id", "geometry")
+	if err != nil {
+		apierrors.InternalServerError(c, "Failed to encode parcel data", err)
+		return
+	}
+
+	// The widget's fields are further limited by what the embed route's role may see
+	c.JSON(http.StatusOK, EmbedParcelResponse{Parcel: redactFields(fields, middleware.GetRole(c))})
+}
+
+// handleError maps widget service errors to HTTP responses.
+func (h *WidgetHandler) handleError(c *gin.Context, err error) {
+	// The token's verification error is not for clients
+	err = domain.Reword(err, domain.ErrUnauthorized, "Invalid or expired embed token")
+	apierrors.Respond(c, "Failed to process widget request", err)
+}
+
+// mapWidgetToEmbedConfig converts a stored widget into the public embed configuration.
+func mapWidgetToEmbedConfig(widget *models.EmbedWidget) *EmbedConfig {
+	if widget == nil {
+		return nil
+	}
+
+	return &EmbedConfig{
+		Widget:        widget.Name,
+		AllowedBBox:   widget.AllowedBBox,
+		VisibleFields: widget.VisibleFields,
+		Branding:      widget.Branding,
+	}
+}
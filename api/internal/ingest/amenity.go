@@ -0,0 +1,243 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// ModeAmenities names amenity loads in the ingest lock and pg_stat_activity.
+const ModeAmenities = "amenities"
+
+// DefaultAmenityNameField is the attribute holding an amenity's name in HIFLD
+// layers, the usual source for schools, hospitals and fire stations.
+const DefaultAmenityNameField = "NAME"
+
+// amenityStagingTable receives the amenity layer; it is dropped when the transaction ends.
+const amenityStagingTable = "amenity_staging"
+
+// AmenityOptions controls an amenity load.
+type AmenityOptions struct {
+	// Category is one of models.AmenityCategories
+	Category string
+	// NameField is the feature property holding the amenity name; DefaultAmenityNameField if empty
+	NameField string
+	// MaxInvalid is how many invalid features are skipped before the load is aborted
+	MaxInvalid int
+	// LockTimeout is how long to wait for a running load of the same county
+	LockTimeout time.Duration
+	// DryRun validates every feature without touching the database
+	DryRun bool
+}
+
+// AmenitySummary reports the outcome of an amenity load.
+type AmenitySummary struct {
+	Read    int
+	Invalid int
+	Loaded  int64
+}
+
+// LoadAmenities replaces the amenities of opts.Category in the mapping's county
+// with those from source. Features must be points or polygons in WGS84; polygons,
+// such as hospital campuses, are stored as a point on their surface. Nothing is
+// precomputed for amenities themselves: nearest-amenity distances are searched
+// per request, so they reflect the new layer as soon as the load commits. The
+// county's ingest-stage derived attributes are recomputed, since they may read
+// the layer; other counties' parcels are not.
+// Returns ErrTooManyInvalid, ErrCountyLocked or ErrUnknownCounty.
+func (l *Loader) LoadAmenities(ctx context.Context, source Source, opts AmenityOptions) (*AmenitySummary, error) {
+	if !models.IsAmenityCategory(opts.Category) {
+		return nil, fmt.Errorf("invalid amenity category %q, expected one of %s",
+			opts.Category, strings.Join(models.AmenityCategories, ", "))
+	}
+	if opts.NameField == "" {
+		opts.NameField = DefaultAmenityNameField
+	}
+	if opts.LockTimeout < 0 {
+		return nil, fmt.Errorf("invalid lock timeout %s, must not be negative", opts.LockTimeout)
+	}
+
+	rows := &amenitySource{ctx: ctx, source: source, log: l.log, nameField: opts.NameField, maxInvalid: opts.MaxInvalid}
+	if opts.DryRun {
+		for rows.Next() {
+			// Validation happens in Next
+		}
+		return rows.summary(), rows.Err()
+	}
+
+	tx, err := l.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		// No-op once committed
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err := l.lockCounty(ctx, tx, ModeAmenities, opts.LockTimeout); err != nil {
+		return nil, err
+	}
+
+	var countyID int
+	err = tx.QueryRow(ctx, `SELECT id FROM counties WHERE slug = $1`, l.mapping.County).Scan(&countyID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCounty, l.mapping.County)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up county %s: %w", l.mapping.County, err)
+	}
+
+	createStaging := `
+		CREATE TEMP TABLE ` + amenityStagingTable + ` (
+			name VARCHAR(255),
+			geom_json TEXT NOT NULL
+		) ON COMMIT DROP
+	`
+	if _, err := tx.Exec(ctx, createStaging); err != nil {
+		return nil, fmt.Errorf("failed to create amenity staging table: %w", err)
+	}
+
+	copied, err := tx.CopyFrom(ctx, pgx.Identifier{amenityStagingTable}, []string{"name", "geom_json"}, rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy amenities into staging table: %w", err)
+	}
+	l.log.Info("Amenities staged", map[string]interface{}{
+		"staged":  copied,
+		"invalid": rows.invalid,
+	})
+
+	if _, err := tx.Exec(ctx, `DELETE FROM amenities WHERE county_id = $1 AND category = $2`, countyID, opts.Category); err != nil {
+		return nil, fmt.Errorf("failed to clear %s amenities of county %s: %w", opts.Category, l.mapping.County, err)
+	}
+
+	// ST_PointOnSurface returns points unchanged and keeps polygon anchors inside the footprint
+	insert := `
+		INSERT INTO amenities (county_id, category, name, geom)
+		SELECT $1, $2, name, ST_PointOnSurface(ST_Force2D(ST_SetSRID(ST_GeomFromGeoJSON(geom_json), 4326)))
+		FROM ` + amenityStagingTable
+	summary := rows.summary()
+	tag, err := tx.Exec(ctx, insert, countyID, opts.Category)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert amenities: %w", err)
+	}
+	summary.Loaded = tag.RowsAffected()
+
+	// Derived attributes may measure distances to amenities
+	if _, err := l.updateDerived(ctx, tx, countyID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit amenity load: %w", err)
+	}
+	return summary, nil
+}
+
+// amenitySource adapts a Source to pgx.CopyFromSource, skipping invalid amenities.
+type amenitySource struct {
+	ctx        context.Context
+	source     Source
+	log        *logger.Logger
+	err        error
+	nameField  string
+	row        []any
+	read       int
+	invalid    int
+	maxInvalid int
+}
+
+// Next reads features until a valid one is found, the layer ends or loading must stop.
+func (s *amenitySource) Next() bool {
+	for {
+		var name *string
+		feature, err := s.source.Next(s.ctx)
+		if errors.Is(err, io.EOF) {
+			return false
+		}
+		if err != nil && !errors.Is(err, ErrInvalidFeature) {
+			s.err = err
+			return false
+		}
+		s.read++
+
+		if err == nil {
+			name, err = featureName(feature, s.nameField)
+		}
+		if err == nil {
+			if err = checkAmenityGeometry(feature.Geometry); err != nil {
+				err = fmt.Errorf("%w %d: %v", ErrInvalidFeature, feature.Number, err)
+			}
+		}
+		if err != nil {
+			s.invalid++
+			s.log.Warn("Skipping invalid amenity", map[string]interface{}{
+				"error": err.Error(),
+			})
+			if s.invalid > s.maxInvalid {
+				s.err = fmt.Errorf("%w: more than %d", ErrTooManyInvalid, s.maxInvalid)
+				return false
+			}
+			continue
+		}
+
+		s.row = []any{name, string(feature.Geometry)}
+		return true
+	}
+}
+
+// Values returns the current row.
+func (s *amenitySource) Values() ([]any, error) {
+	return s.row, nil
+}
+
+// Err returns the error that stopped the source, if any.
+func (s *amenitySource) Err() error {
+	return s.err
+}
+
+// summary returns the counts so far.
+func (s *amenitySource) summary() *AmenitySummary {
+	return &AmenitySummary{Read: s.read, Invalid: s.invalid}
+}
+
+// checkAmenityGeometry checks that an amenity geometry is a Point within WGS84
+// bounds or a Polygon or MultiPolygon, parsed as parcel geometries are.
+func checkAmenityGeometry(raw json.RawMessage) error {
+	if len(raw) == 0 || string(raw) == "null" {
+		return errors.New("geometry is missing")
+	}
+
+	var geom struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+	if err := json.Unmarshal(raw, &geom); err != nil {
+		return fmt.Errorf("failed to unmarshal geometry: %w", err)
+	}
+
+	switch geom.Type {
+	case "Polygon", "MultiPolygon":
+		_, err := parseGeometry(raw)
+		return err
+	case "Point":
+		var pos []float64
+		if err := json.Unmarshal(geom.Coordinates, &pos); err != nil {
+			return fmt.Errorf("failed to unmarshal point coordinates: %w", err)
+		}
+		if len(pos) < 2 {
+			return errors.New("point has fewer than 2 coordinates")
+		}
+		// GeoJSON positions are [lng, lat]
+		return models.LatLng{Lat: pos[1], Lng: pos[0]}.Validate()
+	default:
+		return fmt.Errorf("expected Point, Polygon or MultiPolygon type, got %q", geom.Type)
+	}
+}
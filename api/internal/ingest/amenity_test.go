@@ -0,0 +1,71 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+)
+
+// testAmenities has a station point, a hospital campus polygon, a line and a
+// point with too long a name.
+var testAmenities = `{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"NAME": "Station 4"}, "geometry": {"type": "Point", "coordinates": [-95.45, 30.35]}},
+	{"type": "Feature", "properties": {"NAME": "Conroe Regional"}, "geometry": ` + testPolygon + `},
+	{"type": "Feature", "properties": {"NAME": "Route"}, "geometry": {"type": "LineString", "coordinates": [[-95.5, 30.3], [-95.4, 30.2]]}},
+	{"type": "Feature", "properties": {"NAME": "` + strings.Repeat("x", maxFeatureNameLength+1) + `"},
+		"geometry": {"type": "Point", "coordinates": [-95.45, 30.35]}}
+]}`
+
+func TestLoader_AmenitiesDryRun(t *testing.T) {
+	loader := NewLoader(nil, DefaultMapping(), logger.New("test"))
+
+	summary, err := loader.LoadAmenities(context.Background(), NewReader(strings.NewReader(testAmenities)), AmenityOptions{
+		Category:   "fire_station",
+		MaxInvalid: 2,
+		DryRun:     true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, &AmenitySummary{Read: 4, Invalid: 2}, summary)
+
+	_, err = loader.LoadAmenities(context.Background(), NewReader(strings.NewReader(testAmenities)), AmenityOptions{
+		Category:   "fire_station",
+		MaxInvalid: 1,
+		DryRun:     true,
+	})
+	assert.ErrorIs(t, err, ErrTooManyInvalid)
+}
+
+func TestLoader_AmenitiesInvalidCategory(t *testing.T) {
+	loader := NewLoader(nil, DefaultMapping(), logger.New("test"))
+
+	_, err := loader.LoadAmenities(context.Background(), NewReader(strings.NewReader(testAmenities)), AmenityOptions{Category: "library"})
+
+	assert.ErrorContains(t, err, "invalid amenity category")
+}
+
+func TestCheckAmenityGeometry(t *testing.T) {
+	tests := map[string]string{
+		`{"type": "Point", "coordinates": [-95.45, 30.35]}`:     "",
+		`{"type": "Point", "coordinates": [-95.45, 30.35, 12]}`: "",
+		testPolygon: "",
+		`{"type": "Point", "coordinates": [-95.45]}`:                            "fewer than 2 coordinates",
+		`{"type": "Point", "coordinates": [-95.45, 95]}`:                        "latitude",
+		`{"type": "LineString", "coordinates": [[-95.5, 30.3], [-95.4, 30.2]]}`: "got \"LineString\"",
+		`null`: "geometry is missing",
+	}
+
+	for geometry, wantErr := range tests {
+		err := checkAmenityGeometry(json.RawMessage(geometry))
+		if wantErr == "" {
+			assert.NoError(t, err, geometry)
+		} else {
+			assert.ErrorContains(t, err, wantErr, geometry)
+		}
+	}
+}
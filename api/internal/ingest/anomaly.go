@@ -0,0 +1,100 @@
+package ingest
+
+import (
+	"fmt"
+	"math"
+)
+
+// RunMetrics are the dataset metrics recorded in ingestion_runs for a parcel
+// load. A nil null rate is an attribute the load's mapping does not map.
+type RunMetrics struct {
+	OwnerNameNullRate *float64
+	SitusNullRate     *float64
+	YearBuiltNullRate *float64
+	RecordCount       int64
+	TotalAcres        float64
+}
+
+// Baseline is the average of the metrics of a county's recent accepted
+// (activated or forced) runs. Runs is zero before the county's first run.
+type Baseline struct {
+	RunMetrics
+	Runs int
+}
+
+// AnomalyThresholds bound how far a load may deviate from its baseline.
+type AnomalyThresholds struct {
+	// BaselineRuns is how many recent accepted runs are averaged into the baseline
+	BaselineRuns int
+	// MaxCountChangePct is the max record count change, in percent either direction
+	MaxCountChangePct float64
+	// MaxAreaChangePct is the max total acreage change, in percent either direction
+	MaxAreaChangePct float64
+	// MaxNullRateIncrease is the max absolute increase of a null rate (0-1)
+	MaxNullRateIncrease float64
+}
+
+// DefaultAnomalyThresholds returns the thresholds of loads that set none.
+func DefaultAnomalyThresholds() AnomalyThresholds {
+	return AnomalyThresholds{
+		BaselineRuns:        5,
+		MaxCountChangePct:   10,
+		MaxAreaChangePct:    10,
+		MaxNullRateIncrease: 0.05,
+	}
+}
+
+// validate rejects negative thresholds.
+func (t AnomalyThresholds) validate() error {
+	if t.BaselineRuns < 0 || t.MaxCountChangePct < 0 || t.MaxAreaChangePct < 0 || t.MaxNullRateIncrease < 0 {
+		return fmt.Errorf("invalid anomaly thresholds %+v, must not be negative", t)
+	}
+	return nil
+}
+
+// CheckAnomalies compares the metrics of a load against its baseline and
+// returns a description of each one outside the thresholds. A county without
+// previous runs has no anomalies: its first run becomes the baseline.
+func CheckAnomalies(run RunMetrics, baseline Baseline, thresholds AnomalyThresholds) []string {
+	if baseline.Runs == 0 {
+		return nil
+	}
+
+	var anomalies []string
+	if change := pctChange(float64(run.RecordCount), float64(baseline.RecordCount)); change > thresholds.MaxCountChangePct {
+		anomalies = append(anomalies, fmt.Sprintf("record count %d differs %.2f%% from baseline %d",
+			run.RecordCount, change, baseline.RecordCount))
+	}
+	if change := pctChange(run.TotalAcres, baseline.TotalAcres); change > thresholds.MaxAreaChangePct {
+		anomalies = append(anomalies, fmt.Sprintf("total acres %.2f differs %.2f%% from baseline %.2f",
+			run.TotalAcres, change, baseline.TotalAcres))
+	}
+
+	nullRates := []struct {
+		current, baseline *float64
+		label             string
+	}{
+		{run.OwnerNameNullRate, baseline.OwnerNameNullRate, "owner_name"},
+		{run.SitusNullRate, baseline.SitusNullRate, "situs"},
+		{run.YearBuiltNullRate, baseline.YearBuiltNullRate, "year_built"},
+	}
+	for _, rate := range nullRates {
+		if rate.current == nil || rate.baseline == nil {
+			continue
+		}
+		if increase := *rate.current - *rate.baseline; increase > thresholds.MaxNullRateIncrease {
+			anomalies = append(anomalies, fmt.Sprintf("%s null rate %.4f is %.4f above baseline %.4f",
+				rate.label, *rate.current, increase, *rate.baseline))
+		}
+	}
+	return anomalies
+}
+
+// pctChange returns the absolute change of a relative to b in percent, or 0
+// when b is 0.
+func pctChange(a, b float64) float64 {
+	if b == 0 {
+		return 0
+	}
+	return math.Abs((a - b) / b * 100)
+}
@@ -0,0 +1,83 @@
+package ingest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckAnomalies(t *testing.T) {
+	rate := func(r float64) *float64 { return &r }
+	baseline := Baseline{
+		RunMetrics: RunMetrics{
+			RecordCount:       100000,
+			TotalAcres:        250000,
+			OwnerNameNullRate: rate(0.01),
+			SitusNullRate:     rate(0.1),
+			YearBuiltNullRate: rate(0.4),
+		},
+		Runs: 5,
+	}
+
+	tests := []struct {
+		name      string
+		run       RunMetrics
+		baseline  Baseline
+		anomalies []string
+	}{
+		{
+			name: "within thresholds",
+			run: RunMetrics{
+				RecordCount:       108000,
+				TotalAcres:        232000,
+				OwnerNameNullRate: rate(0.05),
+				SitusNullRate:     rate(0.1),
+				YearBuiltNullRate: rate(0.3),
+			},
+			baseline: baseline,
+		},
+		{
+			name: "truncated file",
+			run: RunMetrics{
+				RecordCount:       40000,
+				TotalAcres:        100000,
+				OwnerNameNullRate: rate(0.01),
+				SitusNullRate:     rate(0.1),
+				YearBuiltNullRate: rate(0.4),
+			},
+			baseline: baseline,
+			anomalies: []string{
+				"record count 40000 differs 60.00% from baseline 100000",
+				"total acres 100000.00 differs 60.00% from baseline 250000.00",
+			},
+		},
+		{
+			name: "dropped column",
+			run: RunMetrics{
+				RecordCount:       100000,
+				TotalAcres:        250000,
+				OwnerNameNullRate: rate(0.01),
+				SitusNullRate:     rate(1),
+				YearBuiltNullRate: rate(0.4),
+			},
+			baseline:  baseline,
+			anomalies: []string{"situs null rate 1.0000 is 0.9000 above baseline 0.1000"},
+		},
+		{
+			name:     "unmapped null rate is not compared",
+			run:      RunMetrics{RecordCount: 100000, TotalAcres: 250000},
+			baseline: baseline,
+		},
+		{
+			name:     "first run has no baseline",
+			run:      RunMetrics{RecordCount: 10, TotalAcres: 1, SitusNullRate: rate(1)},
+			baseline: Baseline{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.anomalies, CheckAnomalies(tt.run, tt.baseline, DefaultAnomalyThresholds()))
+		})
+	}
+}
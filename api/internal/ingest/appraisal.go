@@ -0,0 +1,502 @@
+package ingest
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Appraisal roll errors
+var (
+	// ErrInvalidAppraisalRecord is returned for roll records that cannot be parsed.
+	// Invalid records are skipped, up to the configured limit.
+	ErrInvalidAppraisalRecord = errors.New("invalid appraisal record")
+	// ErrInvalidAppraisalLayout is returned when a layout file is unusable.
+	ErrInvalidAppraisalLayout = errors.New("invalid appraisal layout")
+)
+
+// Property type codes of the appraisal roll
+const (
+	PropertyTypeReal       = "R"
+	PropertyTypePersonal   = "P"
+	PropertyTypeMineral    = "MN"
+	PropertyTypeMobileHome = "MH"
+	PropertyTypeAuto       = "A"
+)
+
+// maxAppraisalLineBytes bounds a fixed-width roll line; the full record is about 9 KB.
+const maxAppraisalLineBytes = 1 << 20
+
+// Appraisal roll field names, as named by the Texas appraisal export layout
+// (APPRAISAL_INFO.TXT) and expected in the header of delimited exports.
+const (
+	fieldPropID            = "prop_id"
+	fieldPropType          = "prop_type_cd"
+	fieldYear              = "prop_val_yr"
+	fieldGeoID             = "geo_id"
+	fieldOwnerID           = "py_owner_id"
+	fieldOwnerName         = "py_owner_name"
+	fieldAddrLine1         = "py_addr_line1"
+	fieldAddrLine2         = "py_addr_line2"
+	fieldAddrLine3         = "py_addr_line3"
+	fieldAddrCity          = "py_addr_city"
+	fieldAddrState         = "py_addr_state"
+	fieldAddrZip           = "py_addr_zip"
+	fieldSitusNum          = "situs_num"
+	fieldSitusPrefix       = "situs_street_prefx"
+	fieldSitusStreet       = "situs_street"
+	fieldSitusSuffix       = "situs_street_suffix"
+	fieldSitusUnit         = "situs_unit"
+	fieldSitusCity         = "situs_city"
+	fieldSitusZip          = "situs_zip"
+	fieldLegalDesc         = "legal_desc"
+	fieldLegalDesc2        = "legal_desc2"
+	fieldLegalAcreage      = "legal_acreage"
+	fieldBlock             = "block"
+	fieldTractOrLot        = "tract_or_lot"
+	fieldLandHomestead     = "land_hstd_val"
+	fieldLandNonHomestead  = "land_non_hstd_val"
+	fieldImprvHomestead    = "imprv_hstd_val"
+	fieldImprvNonHomestead = "imprv_non_hstd_val"
+	fieldAgUse             = "ag_use_val"
+	fieldAgMarket          = "ag_market"
+	fieldTimberUse         = "timber_use"
+	fieldTimberMarket      = "timber_market"
+	fieldAppraised         = "appraised_val"
+	fieldAssessed          = "assessed_val"
+)
+
+// legalAcreageDecimals is the number of implied decimals of fixed-width legal_acreage.
+const legalAcreageDecimals = 4
+
+// appraisalFields lists the fields a layout may define.
+var appraisalFields = []string{
+	fieldPropID, fieldPropType, fieldYear, fieldGeoID, fieldOwnerID, fieldOwnerName,
+	fieldAddrLine1, fieldAddrLine2, fieldAddrLine3, fieldAddrCity, fieldAddrState, fieldAddrZip,
+	fieldSitusNum, fieldSitusPrefix, fieldSitusStreet, fieldSitusSuffix, fieldSitusUnit, fieldSitusCity, fieldSitusZip,
+	fieldLegalDesc, fieldLegalDesc2, fieldLegalAcreage, fieldBlock, fieldTractOrLot,
+	fieldLandHomestead, fieldLandNonHomestead, fieldImprvHomestead, fieldImprvNonHomestead,
+	fieldAgUse, fieldAgMarket, fieldTimberUse, fieldTimberMarket, fieldAppraised, fieldAssessed,
+}
+
+// AppraisalRecord is one property of a county appraisal district roll.
+// Line is its 1-based line in the file.
+type AppraisalRecord struct {
+	Owner            AppraisalOwner
+	GeoID            string
+	PropertyType     string
+	Situs            string
+	LegalDescription string
+	Block            string
+	TractOrLot       string
+	Values           AppraisalValues
+	Acreage          float64
+	PropID           int
+	Year             int
+	Line             int
+}
+
+// AppraisalOwner is the owner of record on the roll.
+// Address is the mailing address on one line, e.g. "PO BOX 1, CONROE, TX 77305".
+type AppraisalOwner struct {
+	Name    string
+	Address string
+	ID      int
+}
+
+// AppraisalValues are the certified values of a property, in whole dollars.
+// Market is the land, improvement, agricultural and timber market value;
+// Appraised applies productivity (ag and timber use) values; Assessed applies
+// the homestead cap.
+type AppraisalValues struct {
+	Land        int64
+	Improvement int64
+	AgUse       int64
+	Market      int64
+	Appraised   int64
+	Assessed    int64
+}
+
+// RealProperty reports whether the record describes land or buildings, which
+// are what parcels outline. Delimited exports without a type column count as real.
+func (r *AppraisalRecord) RealProperty() bool {
+	return r.PropertyType == "" || r.PropertyType == PropertyTypeReal || r.PropertyType == PropertyTypeMobileHome
+}
+
+// AppraisalSpan is the position of a field in a fixed-width record:
+// Start is 1-based, as in the published layouts.
+type AppraisalSpan struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// AppraisalLayout maps appraisal roll field names to their fixed-width spans.
+type AppraisalLayout struct {
+	Fields map[string]AppraisalSpan `json:"fields"`
+}
+
+// DefaultAppraisalLayout returns the APPRAISAL_INFO.TXT spans of the Texas
+// appraisal export layout (version 8.0), used by True Automation/PACS districts.
+// Districts on other layout versions can supply their own with LoadAppraisalLayout.
+func DefaultAppraisalLayout() *AppraisalLayout {
+	return &AppraisalLayout{Fields: map[string]AppraisalSpan{
+		fieldPropID:            {Start: 1, Length: 12},
+		fieldPropType:          {Start: 13, Length: 5},
+		fieldYear:              {Start: 18, Length: 5},
+		fieldGeoID:             {Start: 546, Length: 50},
+		fieldOwnerID:           {Start: 596, Length: 12},
+		fieldOwnerName:         {Start: 609, Length: 70},
+		fieldAddrLine1:         {Start: 694, Length: 60},
+		fieldAddrLine2:         {Start: 754, Length: 60},
+		fieldAddrLine3:         {Start: 814, Length: 60},
+		fieldAddrCity:          {Start: 874, Length: 50},
+		fieldAddrState:         {Start: 924, Length: 50},
+		fieldAddrZip:           {Start: 979, Length: 5},
+		fieldSitusPrefix:       {Start: 1040, Length: 10},
+		fieldSitusStreet:       {Start: 1050, Length: 50},
+		fieldSitusSuffix:       {Start: 1100, Length: 10},
+		fieldSitusCity:         {Start: 1110, Length: 30},
+		fieldSitusZip:          {Start: 1140, Length: 10},
+		fieldLegalDesc:         {Start: 1150, Length: 255},
+		fieldLegalDesc2:        {Start: 1405, Length: 255},
+		fieldLegalAcreage:      {Start: 1660, Length: 16},
+		fieldBlock:             {Start: 1696, Length: 50},
+		fieldTractOrLot:        {Start: 1746, Length: 50},
+		fieldLandHomestead:     {Start: 1796, Length: 15},
+		fieldLandNonHomestead:  {Start: 1811, Length: 15},
+		fieldImprvHomestead:    {Start: 1826, Length: 15},
+		fieldImprvNonHomestead: {Start: 1841, Length: 15},
+		fieldAgUse:             {Start: 1856, Length: 15},
+		fieldAgMarket:          {Start: 1871, Length: 15},
+		fieldTimberUse:         {Start: 1886, Length: 15},
+		fieldTimberMarket:      {Start: 1901, Length: 15},
+		fieldAppraised:         {Start: 1916, Length: 15},
+		fieldAssessed:          {Start: 1946, Length: 15},
+		fieldSitusNum:          {Start: 4460, Length: 15},
+		fieldSitusUnit:         {Start: 4475, Length: 5},
+	}}
+}
+
+// LoadAppraisalLayout reads a layout file of the form
+// {"fields": {"prop_id": {"start": 1, "length": 12}, ...}}.
+// Returns ErrInvalidAppraisalLayout if prop_id is missing, a field is unknown
+// or a span does not start at 1 or later with a positive length.
+func LoadAppraisalLayout(path string) (*AppraisalLayout, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- operator-supplied CLI argument
+	if err != nil {
+		return nil, fmt.Errorf("failed to read appraisal layout file: %w", err)
+	}
+
+	var layout AppraisalLayout
+	if err := json.Unmarshal(data, &layout); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAppraisalLayout, err)
+	}
+	if err := layout.validate(); err != nil {
+		return nil, err
+	}
+	return &layout, nil
+}
+
+// validate checks the layout against the known fields.
+func (l *AppraisalLayout) validate() error {
+	if _, ok := l.Fields[fieldPropID]; !ok {
+		return fmt.Errorf("%w: %s must be defined", ErrInvalidAppraisalLayout, fieldPropID)
+	}
+
+	var unknown []string
+	for name, span := range l.Fields {
+		if !knownAppraisalField(name) {
+			unknown = append(unknown, name)
+			continue
+		}
+		if span.Start < 1 || span.Length < 1 {
+			return fmt.Errorf("%w: %s has start %d and length %d", ErrInvalidAppraisalLayout, name, span.Start, span.Length)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%w: unknown fields %v", ErrInvalidAppraisalLayout, unknown)
+	}
+	return nil
+}
+
+// AppraisalReader streams records out of an appraisal roll export, either the
+// fixed-width layout or a delimited file with a header row of field names.
+type AppraisalReader struct {
+	lines  *bufio.Scanner
+	layout *AppraisalLayout
+	csv    *csv.Reader
+	header map[string]int
+	line   int
+	fixed  bool
+}
+
+// NewFixedWidthAppraisalReader creates a reader over a fixed-width roll such as
+// APPRAISAL_INFO.TXT. Lines shorter than the layout leave the missing fields blank.
+func NewFixedWidthAppraisalReader(r io.Reader, layout *AppraisalLayout) *AppraisalReader {
+	lines := bufio.NewScanner(r)
+	lines.Buffer(make([]byte, 0, 64*1024), maxAppraisalLineBytes)
+	return &AppraisalReader{lines: lines, layout: layout, fixed: true}
+}
+
+// NewDelimitedAppraisalReader creates a reader over a delimited roll, e.g. a
+// CSV (',') or pipe-delimited ('|') export. The header row names the columns
+// with the layout field names, case-insensitively; unknown columns are ignored.
+func NewDelimitedAppraisalReader(r io.Reader, delimiter rune) *AppraisalReader {
+	reader := csv.NewReader(r)
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.ReuseRecord = true
+	return &AppraisalReader{csv: reader}
+}
+
+// Next returns the next record, or io.EOF after the last one. Records that
+// cannot be parsed return ErrInvalidAppraisalRecord; reading can continue after them.
+// Blank lines are skipped.
+func (r *AppraisalReader) Next(ctx context.Context) (*AppraisalRecord, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	fields, err := r.nextFields()
+	if err != nil {
+		return nil, err
+	}
+	return parseAppraisalRecord(fields, r.line, r.fixed)
+}
+
+// nextFields reads the next non-blank record as field values by name.
+func (r *AppraisalReader) nextFields() (map[string]string, error) {
+	if r.fixed {
+		for r.lines.Scan() {
+			r.line++
+			line := r.lines.Text()
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			return r.layout.slice(line), nil
+		}
+		if err := r.lines.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read appraisal roll line %d: %w", r.line+1, err)
+		}
+		return nil, io.EOF
+	}
+
+	if r.header == nil {
+		if err := r.readHeader(); err != nil {
+			return nil, err
+		}
+	}
+	for {
+		record, err := r.csv.Read()
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			r.line = parseErr.StartLine
+			return nil, fmt.Errorf("%w %d: %w", ErrInvalidAppraisalRecord, r.line, parseErr.Err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read appraisal roll: %w", err)
+		}
+		r.line, _ = r.csv.FieldPos(0)
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+
+		fields := make(map[string]string, len(r.header))
+		for name, index := range r.header {
+			if index < len(record) {
+				fields[name] = record[index]
+			}
+		}
+		return fields, nil
+	}
+}
+
+// readHeader maps the known field names of the header row to column indexes.
+func (r *AppraisalReader) readHeader() error {
+	header, err := r.csv.Read()
+	if errors.Is(err, io.EOF) {
+		return io.EOF
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read appraisal roll header: %w", err)
+	}
+
+	r.header = make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if knownAppraisalField(name) {
+			r.header[name] = i
+		}
+	}
+	if _, ok := r.header[fieldPropID]; !ok {
+		return fmt.Errorf("%w: header has no %s column", ErrInvalidAppraisalLayout, fieldPropID)
+	}
+	return nil
+}
+
+// slice cuts the layout's fields out of a fixed-width line.
+func (l *AppraisalLayout) slice(line string) map[string]string {
+	fields := make(map[string]string, len(l.Fields))
+	for name, span := range l.Fields {
+		start := span.Start - 1
+		if start >= len(line) {
+			continue
+		}
+		end := min(start+span.Length, len(line))
+		fields[name] = line[start:end]
+	}
+	return fields
+}
+
+// parseAppraisalRecord converts raw field values into a record. Fixed-width
+// numbers may carry implied decimals; delimited ones are written out.
+func parseAppraisalRecord(fields map[string]string, line int, fixed bool) (*AppraisalRecord, error) {
+	p := &appraisalParser{fields: fields}
+
+	record := &AppraisalRecord{
+		Line:             line,
+		PropID:           p.integer(fieldPropID),
+		PropertyType:     strings.ToUpper(p.text(fieldPropType)),
+		Year:             p.integer(fieldYear),
+		GeoID:            p.text(fieldGeoID),
+		Situs:            joinNonEmpty(", ", situsStreet(p), joinNonEmpty(" ", p.text(fieldSitusCity), p.text(fieldSitusZip))),
+		LegalDescription: joinNonEmpty(" ", p.text(fieldLegalDesc), p.text(fieldLegalDesc2)),
+		Block:            p.text(fieldBlock),
+		TractOrLot:       p.text(fieldTractOrLot),
+		Owner: AppraisalOwner{
+			ID:   p.integer(fieldOwnerID),
+			Name: p.text(fieldOwnerName),
+			Address: joinNonEmpty(", ",
+				p.text(fieldAddrLine1), p.text(fieldAddrLine2), p.text(fieldAddrLine3), p.text(fieldAddrCity),
+				joinNonEmpty(" ", p.text(fieldAddrState), p.text(fieldAddrZip))),
+		},
+	}
+
+	acreageDecimals := 0
+	if fixed {
+		acreageDecimals = legalAcreageDecimals
+	}
+	record.Acreage = p.decimal(fieldLegalAcreage, acreageDecimals)
+
+	landMarket := p.dollars(fieldLandHomestead) + p.dollars(fieldLandNonHomestead)
+	agMarket := p.dollars(fieldAgMarket) + p.dollars(fieldTimberMarket)
+	record.Values = AppraisalValues{
+		Land:        landMarket,
+		Improvement: p.dollars(fieldImprvHomestead) + p.dollars(fieldImprvNonHomestead),
+		AgUse:       p.dollars(fieldAgUse) + p.dollars(fieldTimberUse),
+		Appraised:   p.dollars(fieldAppraised),
+		Assessed:    p.dollars(fieldAssessed),
+	}
+	record.Values.Market = landMarket + record.Values.Improvement + agMarket
+
+	if p.err != nil {
+		return nil, fmt.Errorf("%w %d: %w", ErrInvalidAppraisalRecord, line, p.err)
+	}
+	if record.PropID <= 0 {
+		return nil, fmt.Errorf("%w %d: %s is required", ErrInvalidAppraisalRecord, line, fieldPropID)
+	}
+	return record, nil
+}
+
+// situsStreet joins the street number, prefix, name, suffix and unit of the situs address.
+func situsStreet(p *appraisalParser) string {
+	street := joinNonEmpty(" ", p.text(fieldSitusNum), p.text(fieldSitusPrefix), p.text(fieldSitusStreet), p.text(fieldSitusSuffix))
+	if unit := p.text(fieldSitusUnit); unit != "" {
+		street = joinNonEmpty(" ", street, "#"+unit)
+	}
+	return street
+}
+
+// appraisalParser reads typed field values, keeping the first error.
+type appraisalParser struct {
+	fields map[string]string
+	err    error
+}
+
+// text returns the trimmed field value, with runs of spaces collapsed.
+func (p *appraisalParser) text(name string) string {
+	return strings.Join(strings.Fields(p.fields[name]), " ")
+}
+
+// integer parses a whole number that fits an INTEGER column; blank is 0.
+func (p *appraisalParser) integer(name string) int {
+	n := p.number(name, math.MaxInt32)
+	return int(n)
+}
+
+// dollars parses a value in whole dollars; blank is 0. Delimited exports may
+// write cents or thousands separators, which are dropped.
+func (p *appraisalParser) dollars(name string) int64 {
+	return p.number(name, math.MaxInt64)
+}
+
+// number parses a non-negative whole number up to limit.
+func (p *appraisalParser) number(name string, limit int64) int64 {
+	value := strings.ReplaceAll(p.text(name), ",", "")
+	value = strings.TrimPrefix(value, "$")
+	if whole, _, ok := strings.Cut(value, "."); ok {
+		value = whole
+	}
+	if value == "" || p.err != nil {
+		return 0
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < 0 || n > limit {
+		p.err = fmt.Errorf("%s: expected a whole number, got %q", name, p.fields[name])
+		return 0
+	}
+	return n
+}
+
+// decimal parses a decimal number. Values without a decimal point are divided
+// by 10^impliedDecimals, as fixed-width layouts store them.
+func (p *appraisalParser) decimal(name string, impliedDecimals int) float64 {
+	value := p.text(name)
+	if value == "" || p.err != nil {
+		return 0
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f < 0 || math.IsInf(f, 0) {
+		p.err = fmt.Errorf("%s: expected a decimal number, got %q", name, p.fields[name])
+		return 0
+	}
+	if !strings.Contains(value, ".") {
+		f /= math.Pow10(impliedDecimals)
+	}
+	return f
+}
+
+// knownAppraisalField reports whether name is a field layouts may define.
+func knownAppraisalField(name string) bool {
+	for _, field := range appraisalFields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// joinNonEmpty joins the non-empty parts with sep.
+func joinNonEmpty(sep string, parts ...string) string {
+	kept := parts[:0:0]
+	for _, part := range parts {
+		if part != "" {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, sep)
+}
@@ -0,0 +1,172 @@
+package ingest
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+)
+
+// fixedWidthLine builds a fixed-width roll line from field values placed at
+// their DefaultAppraisalLayout spans.
+func fixedWidthLine(t *testing.T, values map[string]string) string {
+	t.Helper()
+	layout := DefaultAppraisalLayout()
+	width := 0
+	for name := range values {
+		span := layout.Fields[name]
+		width = max(width, span.Start-1+span.Length)
+	}
+
+	line := []byte(strings.Repeat(" ", width))
+	for name, value := range values {
+		span, ok := layout.Fields[name]
+		require.True(t, ok, name)
+		require.LessOrEqual(t, len(value), span.Length, name)
+		copy(line[span.Start-1:], value)
+	}
+	return string(line)
+}
+
+func TestAppraisalReader_FixedWidth(t *testing.T) {
+	line := fixedWidthLine(t, map[string]string{
+		"prop_id":             "000000012345",
+		"prop_type_cd":        "R",
+		"prop_val_yr":         "2025",
+		"geo_id":              "1234-00-00100",
+		"py_owner_id":         "000000000077",
+		"py_owner_name":       "SMITH  JOHN",
+		"py_addr_line1":       "PO BOX 1",
+		"py_addr_city":        "CONROE",
+		"py_addr_state":       "TX",
+		"py_addr_zip":         "77305",
+		"situs_num":           "123",
+		"situs_street_prefx":  "N",
+		"situs_street":        "MAIN",
+		"situs_street_suffix": "ST",
+		"situs_city":          "CONROE",
+		"legal_desc":          "S1234 - LAKE ESTATES",
+		"legal_desc2":         "BLOCK 2 LOT 7",
+		"legal_acreage":       "0000000000012500",
+		"land_hstd_val":       "000000000050000",
+		"imprv_hstd_val":      "000000000200000",
+		"ag_market":           "000000000010000",
+		"ag_use_val":          "000000000000500",
+		"appraised_val":       "000000000250500",
+		"assessed_val":        "000000000240000",
+	})
+	reader := NewFixedWidthAppraisalReader(strings.NewReader("\n"+line+"\n"), DefaultAppraisalLayout())
+
+	record, err := reader.Next(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, record.Line, "blank lines are skipped but counted")
+	assert.Equal(t, 12345, record.PropID)
+	assert.Equal(t, 2025, record.Year)
+	assert.True(t, record.RealProperty())
+	assert.Equal(t, "1234-00-00100", record.GeoID)
+	assert.Equal(t, AppraisalOwner{ID: 77, Name: "SMITH JOHN", Address: "PO BOX 1, CONROE, TX 77305"}, record.Owner)
+	assert.Equal(t, "123 N MAIN ST, CONROE", record.Situs)
+	assert.Equal(t, "S1234 - LAKE ESTATES BLOCK 2 LOT 7", record.LegalDescription)
+	assert.InDelta(t, 1.25, record.Acreage, 1e-9, "fixed-width acreage has four implied decimals")
+	assert.Equal(t, AppraisalValues{
+		Land:        50000,
+		Improvement: 200000,
+		AgUse:       500,
+		Market:      260000,
+		Appraised:   250500,
+		Assessed:    240000,
+	}, record.Values)
+
+	_, err = reader.Next(context.Background())
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestAppraisalReader_Delimited(t *testing.T) {
+	input := "PROP_ID|prop_type_cd|py_owner_name|legal_acreage|assessed_val|unrelated\n" +
+		"101|R|DOE JANE|2.5|\"1,234,567\"|x\n" +
+		"\n" +
+		"102|P|ACME INC|||\n" +
+		"abc|R|BAD ROW|||\n"
+	reader := NewDelimitedAppraisalReader(strings.NewReader(input), '|')
+	ctx := context.Background()
+
+	first, err := reader.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 101, first.PropID)
+	assert.Equal(t, "DOE JANE", first.Owner.Name)
+	assert.InDelta(t, 2.5, first.Acreage, 1e-9)
+	assert.Equal(t, int64(1234567), first.Values.Assessed)
+
+	second, err := reader.Next(ctx)
+	require.NoError(t, err)
+	assert.False(t, second.RealProperty(), "personal property accounts have no parcel")
+
+	_, err = reader.Next(ctx)
+	assert.ErrorIs(t, err, ErrInvalidAppraisalRecord)
+	assert.ErrorContains(t, err, "record 5", "errors name the file line")
+
+	_, err = reader.Next(ctx)
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestAppraisalReader_DelimitedWithoutPropID(t *testing.T) {
+	reader := NewDelimitedAppraisalReader(strings.NewReader("owner,acres\nDOE,1\n"), ',')
+
+	_, err := reader.Next(context.Background())
+	assert.ErrorIs(t, err, ErrInvalidAppraisalLayout)
+}
+
+func TestLoadAppraisalLayout(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"valid", `{"fields": {"prop_id": {"start": 1, "length": 12}, "py_owner_name": {"start": 13, "length": 70}}}`, false},
+		{"missing prop_id", `{"fields": {"py_owner_name": {"start": 13, "length": 70}}}`, true},
+		{"unknown field", `{"fields": {"prop_id": {"start": 1, "length": 12}, "owner": {"start": 13, "length": 70}}}`, true},
+		{"zero start", `{"fields": {"prop_id": {"start": 0, "length": 12}}}`, true},
+		{"malformed", `{"fields": [`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "layout.json")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			layout, err := LoadAppraisalLayout(path)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidAppraisalLayout)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, AppraisalSpan{Start: 13, Length: 70}, layout.Fields["py_owner_name"])
+		})
+	}
+}
+
+func TestLoader_RefreshAppraisalDryRun(t *testing.T) {
+	input := "prop_id,prop_type_cd,prop_val_yr,py_owner_name,appraised_val\n" +
+		"101,R,2024,DOE JANE,250000\n" +
+		"101,R,2024,DOE JOHN,250000\n" +
+		"102,MN,2024,MINERAL CO,1000\n" +
+		"103,R,,ROE RICHARD,\n" +
+		"x,R,2024,BAD ROW,\n"
+	loader := NewLoader(nil, DefaultMapping(), logger.New("test"))
+
+	summary, err := loader.RefreshAppraisal(context.Background(), NewDelimitedAppraisalReader(strings.NewReader(input), ','),
+		RefreshOptions{MaxInvalid: 1, DryRun: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, &RefreshSummary{Read: 5, Invalid: 1, Skipped: 2, Assessments: 1}, summary)
+
+	_, err = loader.RefreshAppraisal(context.Background(), NewDelimitedAppraisalReader(strings.NewReader(input), ','),
+		RefreshOptions{DryRun: true})
+	assert.ErrorIs(t, err, ErrTooManyInvalid)
+}
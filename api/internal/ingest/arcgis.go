@@ -0,0 +1,266 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/outbound"
+)
+
+// ArcGIS source defaults
+const (
+	// DefaultArcGISPageSize is used when the layer does not report a smaller maxRecordCount
+	DefaultArcGISPageSize = 1000
+	// DefaultArcGISRetries is how many times a failed page request is retried
+	DefaultArcGISRetries = 3
+	// DefaultArcGISRetryDelay is the delay before the first retry; it doubles on each attempt
+	DefaultArcGISRetryDelay = 2 * time.Second
+	// DefaultArcGISTimeout bounds each page request
+	DefaultArcGISTimeout = 60 * time.Second
+
+	// maxArcGISResponseBytes bounds a single page; 1000 detailed parcels are a few MiB
+	maxArcGISResponseBytes = 64 << 20
+	esriPolygonType        = "esriGeometryPolygon"
+	wgs84WKID              = 4326
+)
+
+// ErrArcGISRequest is returned when an ArcGIS REST request fails after all retries.
+var ErrArcGISRequest = errors.New("ArcGIS request failed")
+
+// ArcGISOptions controls how an ArcGISSource pages through a layer.
+// Zero values select the defaults.
+type ArcGISOptions struct {
+	// Where filters the features, e.g. "CNTY_NM = 'Montgomery'"; default "1=1"
+	Where    string
+	PageSize int
+	// MaxRetries is the number of retries per request; negative disables retrying
+	MaxRetries int
+	RetryDelay time.Duration
+	Timeout    time.Duration
+	// RateLimit caps requests per second to spare shared county servers; zero is unlimited
+	RateLimit float64
+}
+
+// ArcGISSource pages through the features of an ArcGIS REST FeatureServer or
+// MapServer layer, converting Esri JSON polygons to GeoJSON MultiPolygons.
+// Features are requested in object ID order and reprojected to WGS84 by the server.
+type ArcGISSource struct {
+	http     *outbound.Client
+	layerURL string
+	where    string
+	oidField string
+	page     []esriFeature
+	offset   int
+	read     int
+	pageSize int
+	started  bool
+	done     bool
+}
+
+// NewArcGISSource creates a source for the layer at layerURL, for example
+// https://gis.example.gov/arcgis/rest/services/Parcels/FeatureServer/0.
+func NewArcGISSource(layerURL string, opts ArcGISOptions) *ArcGISSource {
+	if opts.Where == "" {
+		opts.Where = "1=1"
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = DefaultArcGISPageSize
+	}
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = DefaultArcGISRetries
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = DefaultArcGISRetryDelay
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultArcGISTimeout
+	}
+
+	return &ArcGISSource{
+		http: outbound.NewClient("arcgis", outbound.Options{
+			RetryIf:          arcgisOverloaded,
+			Timeout:          opts.Timeout,
+			MaxRetries:       opts.MaxRetries,
+			RetryDelay:       opts.RetryDelay,
+			RateLimit:        opts.RateLimit,
+			MaxResponseBytes: maxArcGISResponseBytes,
+		}),
+		layerURL: strings.TrimRight(layerURL, "/"),
+		where:    opts.Where,
+		pageSize: opts.PageSize,
+	}
+}
+
+// arcgisError is the error member ArcGIS returns, usually with HTTP status 200.
+type arcgisError struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+// arcgisLayerInfo is the subset of the layer metadata we read.
+type arcgisLayerInfo struct {
+	Error         *arcgisError `json:"error"`
+	ObjectIDField string       `json:"objectIdField"`
+	GeometryType  string       `json:"geometryType"`
+	Fields        []struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"fields"`
+	MaxRecordCount            int `json:"maxRecordCount"`
+	AdvancedQueryCapabilities struct {
+		SupportsPagination bool `json:"supportsPagination"`
+	} `json:"advancedQueryCapabilities"`
+}
+
+// arcgisQueryResponse is the subset of a query response we read.
+type arcgisQueryResponse struct {
+	Error            *arcgisError `json:"error"`
+	SpatialReference struct {
+		WKID       int `json:"wkid"`
+		LatestWKID int `json:"latestWkid"`
+	} `json:"spatialReference"`
+	Features              []esriFeature `json:"features"`
+	ExceededTransferLimit bool          `json:"exceededTransferLimit"`
+}
+
+// Next returns the next feature, or io.EOF after the last one.
+// Pages are fetched as they are needed; each page request is retried with
+// exponential backoff on network errors, HTTP 429 and 5xx responses.
+func (s *ArcGISSource) Next(ctx context.Context) (*Feature, error) {
+	if !s.started {
+		if err := s.describe(ctx); err != nil {
+			return nil, err
+		}
+		s.started = true
+	}
+
+	for len(s.page) == 0 {
+		if s.done {
+			return nil, io.EOF
+		}
+		if err := s.fetchPage(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	esri := s.page[0]
+	s.page = s.page[1:]
+	s.read++
+	return esri.toFeature(s.read)
+}
+
+// describe reads the layer metadata: the object ID field used to order pages,
+// the geometry type and the server's page size limit.
+func (s *ArcGISSource) describe(ctx context.Context) error {
+	info, err := arcgisGet(ctx, s, s.layerURL, url.Values{"f": {"json"}}, func(info *arcgisLayerInfo) *arcgisError {
+		return info.Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read layer metadata: %w", err)
+	}
+
+	if info.GeometryType != esriPolygonType {
+		return fmt.Errorf("layer geometry type is %q, expected %s", info.GeometryType, esriPolygonType)
+	}
+	if !info.AdvancedQueryCapabilities.SupportsPagination {
+		return errors.New("layer does not support paginated queries")
+	}
+
+	s.oidField = info.ObjectIDField
+	if s.oidField == "" {
+		// Older servers only list the OID field among the fields
+		for _, field := range info.Fields {
+			if field.Type == "esriFieldTypeOID" {
+				s.oidField = field.Name
+			}
+		}
+	}
+	if s.oidField == "" {
+		return errors.New("layer has no object ID field to order pages by")
+	}
+
+	if info.MaxRecordCount > 0 && info.MaxRecordCount < s.pageSize {
+		s.pageSize = info.MaxRecordCount
+	}
+	return nil
+}
+
+// fetchPage requests the next page of features in WGS84.
+func (s *ArcGISSource) fetchPage(ctx context.Context) error {
+	query := url.Values{
+		"where":             {s.where},
+		"outFields":         {"*"},
+		"returnGeometry":    {"true"},
+		"outSR":             {strconv.Itoa(wgs84WKID)},
+		"orderByFields":     {s.oidField},
+		"resultOffset":      {strconv.Itoa(s.offset)},
+		"resultRecordCount": {strconv.Itoa(s.pageSize)},
+		"f":                 {"json"},
+	}
+
+	page, err := arcgisGet(ctx, s, s.layerURL+"/query", query, func(page *arcgisQueryResponse) *arcgisError {
+		return page.Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query features at offset %d: %w", s.offset, err)
+	}
+
+	if len(page.Features) > 0 && page.SpatialReference.WKID != wgs84WKID && page.SpatialReference.LatestWKID != wgs84WKID {
+		return fmt.Errorf("%w: server returned wkid %d", ErrUnsupportedCRS, page.SpatialReference.WKID)
+	}
+
+	s.page = page.Features
+	s.offset += len(page.Features)
+	// Servers set exceededTransferLimit while more features remain
+	s.done = !page.ExceededTransferLimit || len(page.Features) == 0
+	return nil
+}
+
+// arcgisGet requests rawURL and decodes the JSON response. Transient failures
+// are retried by the outbound client. errOf returns the error member of a
+// decoded response, if any.
+func arcgisGet[T any](ctx context.Context, s *ArcGISSource, rawURL string, query url.Values, errOf func(*T) *arcgisError) (*T, error) {
+	resp, err := s.http.Get(ctx, rawURL+"?"+query.Encode())
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("%w: %w", ErrArcGISRequest, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: status %d", ErrArcGISRequest, resp.StatusCode)
+	}
+
+	out := new(T)
+	dec := json.NewDecoder(bytes.NewReader(resp.Body))
+	// Keep large integer attributes exact, as the GeoJSON reader does
+	dec.UseNumber()
+	if err := dec.Decode(out); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode response: %w", ErrArcGISRequest, err)
+	}
+	if e := errOf(out); e != nil {
+		return nil, fmt.Errorf("%w: error %d: %s", ErrArcGISRequest, e.Code, e.Message)
+	}
+	return out, nil
+}
+
+// arcgisOverloaded reports whether a response carries a transient ArcGIS error.
+// ArcGIS reports overloaded services in the body with HTTP status 200.
+func arcgisOverloaded(resp *outbound.Response) bool {
+	var body struct {
+		Error *arcgisError `json:"error"`
+	}
+	if json.Unmarshal(resp.Body, &body) != nil || body.Error == nil {
+		return false
+	}
+	return body.Error.Code == http.StatusTooManyRequests || body.Error.Code >= http.StatusInternalServerError
+}
@@ -0,0 +1,187 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+)
+
+const testLayerInfo = `{
+	"geometryType": "esriGeometryPolygon",
+	"objectIdField": "OBJECTID",
+	"maxRecordCount": 2,
+	"advancedQueryCapabilities": {"supportsPagination": true}
+}`
+
+// testEsriSquare is testPolygon as a clockwise Esri ring.
+const testEsriSquare = `{"rings": [[[-95.451,30.347],[-95.451,30.3485],[-95.449,30.3485],[-95.449,30.347],[-95.451,30.347]]]}`
+
+// testEsriPage returns a query response with features for the given object IDs.
+func testEsriPage(exceeded bool, ids ...int) string {
+	features := make([]string, 0, len(ids))
+	for _, id := range ids {
+		features = append(features, `{"attributes": {"OBJECTID": `+strconv.Itoa(id)+`, "PIN": 10}, "geometry": `+testEsriSquare+`}`)
+	}
+	limit := "false"
+	if exceeded {
+		limit = "true"
+	}
+	return `{"spatialReference": {"wkid": 4326}, "exceededTransferLimit": ` + limit + `, "features": [` + strings.Join(features, ",") + `]}`
+}
+
+// newTestArcGISServer serves the layer metadata and the query responses in order.
+func newTestArcGISServer(t *testing.T, pages ...string) *httptest.Server {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/FeatureServer/0":
+			_, _ = w.Write([]byte(testLayerInfo))
+		case "/FeatureServer/0/query":
+			assert.Equal(t, "OBJECTID", r.URL.Query().Get("orderByFields"))
+			assert.Equal(t, "4326", r.URL.Query().Get("outSR"))
+			assert.Equal(t, "2", r.URL.Query().Get("resultRecordCount"), "page size is capped at maxRecordCount")
+			n := int(calls.Add(1)) - 1
+			require.Less(t, n, len(pages), "unexpected page request")
+			if pages[n] == "" {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte(pages[n]))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// readAll drains a source.
+func readAll(t *testing.T, source Source) ([]*Feature, error) {
+	t.Helper()
+	var features []*Feature
+	for {
+		feature, err := source.Next(context.Background())
+		if errors.Is(err, io.EOF) {
+			return features, nil
+		}
+		if err != nil {
+			return features, err
+		}
+		features = append(features, feature)
+	}
+}
+
+func TestArcGISSource_Pages(t *testing.T) {
+	server := newTestArcGISServer(t, testEsriPage(true, 1, 2), "", testEsriPage(false, 3))
+	source := NewArcGISSource(server.URL+"/FeatureServer/0/", ArcGISOptions{RetryDelay: time.Millisecond})
+
+	features, err := readAll(t, source)
+	require.NoError(t, err)
+	require.Len(t, features, 3, "the failed page is retried")
+
+	assert.Equal(t, 3, features[2].Number)
+	assert.Equal(t, json.Number("3"), features[2].Properties["OBJECTID"])
+
+	parcel, err := DefaultMapping().Parcel(features[0])
+	require.NoError(t, err)
+	assert.Equal(t, 1, parcel.ObjectID)
+	require.Len(t, parcel.Geom.Coordinates, 1)
+	assert.Greater(t, signedArea(parcel.Geom.Coordinates[0][0]), 0.0, "exterior rings are counter-clockwise")
+}
+
+func TestArcGISSource_Errors(t *testing.T) {
+	tests := []struct {
+		wantErr error
+		name    string
+		page    string
+		retries int
+	}{
+		{name: "service error", page: `{"error": {"code": 400, "message": "Invalid query"}}`, wantErr: ErrArcGISRequest},
+		{name: "retries exhausted", page: "", retries: -1, wantErr: ErrArcGISRequest},
+		{name: "projected response", page: `{"spatialReference": {"wkid": 2278}, "features": [{"attributes": {}}]}`, wantErr: ErrUnsupportedCRS},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestArcGISServer(t, tt.page)
+			source := NewArcGISSource(server.URL+"/FeatureServer/0", ArcGISOptions{
+				MaxRetries: tt.retries,
+				RetryDelay: time.Millisecond,
+			})
+
+			_, err := readAll(t, source)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestArcGISSource_RetriesOverloadedService(t *testing.T) {
+	overloaded := `{"error": {"code": 503, "message": "Service busy"}}`
+	server := newTestArcGISServer(t, overloaded, testEsriPage(false, 1))
+	source := NewArcGISSource(server.URL+"/FeatureServer/0", ArcGISOptions{RetryDelay: time.Millisecond})
+
+	features, err := readAll(t, source)
+	require.NoError(t, err)
+	assert.Len(t, features, 1, "errors reported with status 200 are retried")
+}
+
+func TestArcGISSource_LayerNotPolygons(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"geometryType": "esriGeometryPoint", "objectIdField": "OBJECTID"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := NewArcGISSource(server.URL, ArcGISOptions{}).Next(context.Background())
+	assert.ErrorContains(t, err, "esriGeometryPoint")
+}
+
+func TestEsriRingsToMultiPolygon(t *testing.T) {
+	outer := [][]float64{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}}         // clockwise
+	hole := [][]float64{{2, 2}, {4, 2}, {4, 4}, {2, 4}, {2, 2}}              // counter-clockwise
+	second := [][]float64{{20, 0}, {20, 5, 1}, {25, 5, 1}, {25, 0}, {20, 0}} // clockwise, with z values
+	stray := [][]float64{{40, 0}, {45, 0}, {45, 5}, {40, 5}, {40, 0}}        // counter-clockwise, outside every exterior
+
+	geom, err := esriRingsToMultiPolygon([][][]float64{hole, outer, second, stray})
+	require.NoError(t, err)
+	require.Len(t, geom.Coordinates, 3)
+
+	assert.Len(t, geom.Coordinates[0], 2, "the hole belongs to the exterior containing it")
+	assert.Greater(t, signedArea(geom.Coordinates[0][0]), 0.0, "exteriors are counter-clockwise")
+	assert.Less(t, signedArea(geom.Coordinates[0][1]), 0.0, "holes are clockwise")
+	assert.Equal(t, [2]float64{20, 5}, geom.Coordinates[1][0][3], "z values are dropped")
+	assert.Len(t, geom.Coordinates[2], 1, "a stray hole becomes a polygon")
+
+	_, err = esriRingsToMultiPolygon([][][]float64{{{1}}})
+	assert.Error(t, err)
+}
+
+func TestLoader_InvalidEsriGeometry(t *testing.T) {
+	page := `{"spatialReference": {"latestWkid": 4326}, "features": [
+		{"attributes": {"OBJECTID": 1, "PIN": 10}, "geometry": {"rings": [[[1]]]}},
+		{"attributes": {"OBJECTID": 2, "PIN": 20}, "geometry": ` + testEsriSquare + `}
+	]}`
+	server := newTestArcGISServer(t, page)
+	source := NewArcGISSource(server.URL+"/FeatureServer/0", ArcGISOptions{})
+
+	summary, err := NewLoader(nil, DefaultMapping(), logger.New("test")).Load(context.Background(), source, Options{
+		Mode:       ModeAppend,
+		MaxInvalid: 1,
+		DryRun:     true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, &Summary{Read: 2, Invalid: 1}, summary)
+}
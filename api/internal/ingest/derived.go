@@ -0,0 +1,112 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stwalsh4118/atlas/api/internal/config"
+)
+
+// ModeDerived names derived attribute recomputes in the ingest lock and pg_stat_activity.
+const ModeDerived = "derived"
+
+// DerivedOptions controls a derived attribute recompute.
+type DerivedOptions struct {
+	// LockTimeout is how long to wait for a running load of the same county
+	LockTimeout time.Duration
+}
+
+// WithDerivedAttributes sets the derived attributes whose ingest-stage values
+// every load stores with the county's parcels, and returns l.
+func (l *Loader) WithDerivedAttributes(attributes []config.DerivedAttribute) *Loader {
+	l.derived = attributes
+	return l
+}
+
+// RecomputeDerived stores the ingest-stage derived attributes of the mapping's
+// county again without loading anything, for use after the attributes change.
+// Attributes no longer configured are removed from the parcels.
+// Returns how many parcels changed, or ErrCountyLocked or ErrUnknownCounty.
+func (l *Loader) RecomputeDerived(ctx context.Context, opts DerivedOptions) (int64, error) {
+	if opts.LockTimeout < 0 {
+		return 0, fmt.Errorf("invalid lock timeout %s, must not be negative", opts.LockTimeout)
+	}
+
+	tx, err := l.db.Pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		// No-op once committed
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err := l.lockCounty(ctx, tx, ModeDerived, opts.LockTimeout); err != nil {
+		return 0, err
+	}
+
+	var countyID int
+	err = tx.QueryRow(ctx, `SELECT id FROM counties WHERE slug = $1`, l.mapping.County).Scan(&countyID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownCounty, l.mapping.County)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up county %s: %w", l.mapping.County, err)
+	}
+
+	updated, err := l.updateDerived(ctx, tx, countyID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit derived attribute recompute: %w", err)
+	}
+	return updated, nil
+}
+
+// updateDerived stores the ingest-stage derived attributes of the county's live
+// parcels in derived_attributes and returns how many parcels changed. Like the
+// waterfront columns, rows whose values would not change are not written and
+// updated_at is left alone.
+func (l *Loader) updateDerived(ctx context.Context, tx pgx.Tx, countyID int) (int64, error) {
+	// The expressions read tax_parcels columns unqualified, so the inner
+	// select must not alias the table
+	update := `
+		UPDATE tax_parcels p SET derived_attributes = d.value
+		FROM (
+			SELECT id, ` + storedDerivedObject(l.derived) + ` AS value
+			FROM tax_parcels
+			WHERE county_id = $1
+				AND deleted_at IS NULL
+		) d
+		WHERE p.id = d.id
+			AND p.derived_attributes IS DISTINCT FROM d.value
+	`
+	tag, err := tx.Exec(ctx, update, countyID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to compute derived attributes: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// storedDerivedObject returns the SQL JSON object of the ingest-stage attributes,
+// each expression cast to the attribute's type. Names and expressions are
+// checked by config.Validate, so they are safe to inline.
+func storedDerivedObject(attributes []config.DerivedAttribute) string {
+	pairs := make([]string, 0, len(attributes))
+	for _, a := range attributes {
+		if a.Stage != config.DerivedAtIngest {
+			continue
+		}
+		pairs = append(pairs, "'"+a.Name+"', ("+a.SQL+")::"+a.Type.SQLType())
+	}
+	if len(pairs) == 0 {
+		return "'{}'::jsonb"
+	}
+	return "jsonb_build_object(" + strings.Join(pairs, ", ") + ")"
+}
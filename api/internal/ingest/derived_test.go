@@ -0,0 +1,33 @@
+package ingest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+)
+
+func TestStoredDerivedObject(t *testing.T) {
+	attributes := []config.DerivedAttribute{
+		{Name: "building_age", SQL: "2026 - imprv_actual_year_built", Type: config.DerivedInt, Stage: config.DerivedAtIngest},
+		{Name: "homestead", SQL: "exemptions ILIKE '%HS%'", Type: config.DerivedBool, Stage: config.DerivedAtQuery},
+		{Name: "compactness", SQL: "ST_Area(geom) / ST_Perimeter(geom)", Type: config.DerivedFloat, Stage: config.DerivedAtIngest},
+	}
+
+	assert.Equal(t,
+		"jsonb_build_object('building_age', (2026 - imprv_actual_year_built)::int8, 'compactness', (ST_Area(geom) / ST_Perimeter(geom))::float8)",
+		storedDerivedObject(attributes), "query-stage attributes are not stored")
+	assert.Equal(t, "'{}'::jsonb", storedDerivedObject(attributes[1:2]), "no ingest-stage attributes clears stored values")
+	assert.Equal(t, "'{}'::jsonb", storedDerivedObject(nil))
+}
+
+func TestLoader_RecomputeDerivedNegativeLockTimeout(t *testing.T) {
+	loader := NewLoader(nil, DefaultMapping(), logger.New("test"))
+
+	_, err := loader.RecomputeDerived(context.Background(), DerivedOptions{LockTimeout: -time.Second})
+
+	assert.ErrorContains(t, err, "invalid lock timeout")
+}
@@ -0,0 +1,120 @@
+package ingest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// esriFeature is a feature in an ArcGIS REST query response.
+type esriFeature struct {
+	Attributes map[string]any `json:"attributes"`
+	Geometry   *esriPolygon   `json:"geometry"`
+}
+
+// esriPolygon is an Esri JSON polygon. Positions may carry z and m values after x and y.
+type esriPolygon struct {
+	Rings [][][]float64 `json:"rings"`
+}
+
+// toFeature converts an Esri feature into a Feature with a GeoJSON MultiPolygon geometry.
+// A missing or empty geometry is left null, so the feature is reported as invalid.
+func (f *esriFeature) toFeature(number int) (*Feature, error) {
+	feature := &Feature{Properties: f.Attributes, Number: number}
+	if f.Geometry == nil || len(f.Geometry.Rings) == 0 {
+		return feature, nil
+	}
+
+	geom, err := esriRingsToMultiPolygon(f.Geometry.Rings)
+	if err != nil {
+		return nil, fmt.Errorf("%w %d: %w", ErrInvalidFeature, number, err)
+	}
+	feature.Geometry, err = json.Marshal(geom)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode geometry of feature %d: %w", number, err)
+	}
+	return feature, nil
+}
+
+// esriRingsToMultiPolygon groups Esri polygon rings into a MultiPolygon.
+// Esri JSON has no polygon nesting: exterior rings are clockwise and holes are
+// counter-clockwise, in any order. Each hole is assigned to the first exterior ring
+// containing it; a hole outside every exterior ring is kept as a polygon of its own.
+// Rings are re-oriented to the RFC 7946 right-hand rule (exteriors counter-clockwise).
+func esriRingsToMultiPolygon(rings [][][]float64) (*models.MultiPolygon, error) {
+	var exteriors, holes [][][2]float64
+	for i, raw := range rings {
+		ring := make([][2]float64, 0, len(raw))
+		for _, pos := range raw {
+			if len(pos) < 2 {
+				return nil, fmt.Errorf("ring %d has a position with fewer than 2 coordinates", i)
+			}
+			ring = append(ring, [2]float64{pos[0], pos[1]})
+		}
+		if len(ring) == 0 {
+			continue
+		}
+
+		if signedArea(ring) > 0 {
+			holes = append(holes, ring)
+		} else {
+			exteriors = append(exteriors, ring)
+		}
+	}
+
+	if len(exteriors) == 0 && len(holes) == 0 {
+		return nil, errors.New("geometry has no rings")
+	}
+
+	polygons := make([][][][2]float64, 0, len(exteriors))
+	for _, ring := range exteriors {
+		slices.Reverse(ring)
+		polygons = append(polygons, [][][2]float64{ring})
+	}
+
+	for _, hole := range holes {
+		owner := -1
+		for i, polygon := range polygons {
+			if containsPoint(polygon[0], hole[0]) {
+				owner = i
+				break
+			}
+		}
+		if owner < 0 {
+			// Counter-clockwise already, as an exterior must be
+			polygons = append(polygons, [][][2]float64{hole})
+			continue
+		}
+		slices.Reverse(hole)
+		polygons[owner] = append(polygons[owner], hole)
+	}
+
+	return &models.MultiPolygon{Coordinates: polygons, SRID: 4326}, nil
+}
+
+// signedArea returns twice the shoelace area of a ring: positive when the ring is
+// counter-clockwise, negative when clockwise.
+func signedArea(ring [][2]float64) float64 {
+	var area float64
+	for i := range ring {
+		next := ring[(i+1)%len(ring)]
+		area += ring[i][0]*next[1] - next[0]*ring[i][1]
+	}
+	return area
+}
+
+// containsPoint reports whether pt lies inside ring, using the even-odd rule.
+func containsPoint(ring [][2]float64, pt [2]float64) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a[1] > pt[1]) != (b[1] > pt[1]) &&
+			pt[0] < (b[0]-a[0])*(pt[1]-a[1])/(b[1]-a[1])+a[0] {
+			inside = !inside
+		}
+	}
+	return inside
+}
@@ -0,0 +1,108 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// ModeFloodZones names flood zone loads in the ingest lock and pg_stat_activity.
+const ModeFloodZones = "flood_zones"
+
+// NFHL flood hazard area attributes. FEMA publishes every county's National
+// Flood Hazard Layer with the same schema, so the fields are fixed.
+const (
+	// FloodZoneField holds the flood zone designation, e.g. AE or X
+	FloodZoneField = "FLD_ZONE"
+	// FloodSubtypeField holds the zone subtype, e.g. FLOODWAY
+	FloodSubtypeField = "ZONE_SUBTY"
+	// FloodSFHAField is T for Special Flood Hazard Areas and F otherwise
+	FloodSFHAField = "SFHA_TF"
+)
+
+// maxFloodZoneLength is the longest flood zone designation a load accepts.
+const maxFloodZoneLength = 20
+
+// FloodZoneOptions controls a flood zone load.
+type FloodZoneOptions struct {
+	// MaxInvalid is how many invalid features are skipped before the load is aborted
+	MaxInvalid int
+	// LockTimeout is how long to wait for a running load of the same county
+	LockTimeout time.Duration
+	// DryRun validates every feature without touching the database
+	DryRun bool
+}
+
+// LoadFloodZones replaces the features of the flood zone layer
+// (models.LayerFloodZones) in the mapping's county with the flood hazard areas
+// (NFHL S_FLD_HAZ_AR) from source. Features must be polygons in WGS84 with a
+// FLD_ZONE; others are skipped as invalid. As with zoning, parcels are
+// intersected with the zones per request, and the county's ingest-stage derived
+// attributes are recomputed, since they may read the layer.
+// Returns ErrTooManyInvalid, ErrCountyLocked or ErrUnknownCounty.
+func (l *Loader) LoadFloodZones(ctx context.Context, source Source, opts FloodZoneOptions) (*LayerSummary, error) {
+	return l.loadLayer(ctx, source, layerLoad{
+		mode:  ModeFloodZones,
+		name:  models.LayerFloodZones,
+		title: "FEMA flood zones",
+		properties: func(feature *Feature) ([]byte, error) {
+			zone, err := floodZone(feature)
+			if err != nil {
+				return nil, err
+			}
+			subtype, err := featureText(feature, FloodSubtypeField, maxFeatureNameLength, false)
+			if err != nil {
+				return nil, err
+			}
+			sfha, err := floodSFHA(feature, *zone)
+			if err != nil {
+				return nil, err
+			}
+			properties := map[string]any{"zone": *zone, "sfha": sfha}
+			if subtype != nil {
+				properties["subtype"] = *subtype
+			}
+			return encodeProperties(properties)
+		},
+		checkGeometry: checkAreaGeometry,
+		polygons:      true,
+		maxInvalid:    opts.MaxInvalid,
+		lockTimeout:   opts.LockTimeout,
+		dryRun:        opts.DryRun,
+	})
+}
+
+// floodZone returns the feature's FLD_ZONE, which is required, in upper case.
+func floodZone(feature *Feature) (*string, error) {
+	zone, err := featureText(feature, FloodZoneField, maxFloodZoneLength, true)
+	if err != nil {
+		return nil, err
+	}
+	upper := strings.ToUpper(*zone)
+	return &upper, nil
+}
+
+// floodSFHA returns whether the feature is a Special Flood Hazard Area from its
+// SFHA_TF. Extracts that drop the attribute or leave it blank fall back to the
+// zone: A and V zones are the special flood hazard areas.
+func floodSFHA(feature *Feature, zone string) (bool, error) {
+	var flag *string
+	var err error
+	if raw, ok := feature.Properties[FloodSFHAField]; ok && raw != nil {
+		flag, err = parseText(raw)
+	}
+	switch {
+	case err != nil:
+		return false, fmt.Errorf("%w %d: %s: %v", ErrInvalidFeature, feature.Number, FloodSFHAField, err)
+	case flag == nil:
+		return (strings.HasPrefix(zone, "A") || strings.HasPrefix(zone, "V")) && zone != "AREA NOT INCLUDED", nil
+	case strings.EqualFold(*flag, "T") || strings.EqualFold(*flag, "true"):
+		return true, nil
+	case strings.EqualFold(*flag, "F") || strings.EqualFold(*flag, "false"):
+		return false, nil
+	}
+	return false, fmt.Errorf("%w %d: %s must be T or F, got %q", ErrInvalidFeature, feature.Number, FloodSFHAField, *flag)
+}
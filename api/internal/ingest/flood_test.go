@@ -0,0 +1,55 @@
+package ingest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testFloodZones has a floodway, a minimal hazard zone, an area without a zone,
+// a line and an area with an unknown SFHA flag.
+var testFloodZones = `{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"FLD_ZONE": "AE", "ZONE_SUBTY": "FLOODWAY", "SFHA_TF": "T"}, "geometry": ` + testPolygon + `},
+	{"type": "Feature", "properties": {"FLD_ZONE": "X", "ZONE_SUBTY": "AREA OF MINIMAL FLOOD HAZARD", "SFHA_TF": "F"}, "geometry": ` + testPolygon + `},
+	{"type": "Feature", "properties": {"FLD_ZONE": null, "SFHA_TF": "F"}, "geometry": ` + testPolygon + `},
+	{"type": "Feature", "properties": {"FLD_ZONE": "A", "SFHA_TF": "T"}, "geometry": {"type": "LineString", "coordinates": [[-95.5, 30.3], [-95.4, 30.2]]}},
+	{"type": "Feature", "properties": {"FLD_ZONE": "VE", "SFHA_TF": "Y"}, "geometry": ` + testPolygon + `}
+]}`
+
+func TestFloodZone(t *testing.T) {
+	zone, err := floodZone(testFeature(t, `{"FLD_ZONE": "ae"}`, testPolygon))
+	require.NoError(t, err)
+	assert.Equal(t, "AE", *zone)
+
+	for _, properties := range []string{`{}`, `{"FLD_ZONE": " "}`, `{"FLD_ZONE": "` + strings.Repeat("A", maxFloodZoneLength+1) + `"}`} {
+		_, err := floodZone(testFeature(t, properties, testPolygon))
+		assert.ErrorIs(t, err, ErrInvalidFeature, properties)
+	}
+}
+
+func TestFloodSFHA(t *testing.T) {
+	tests := []struct {
+		properties string
+		zone       string
+		want       bool
+	}{
+		{`{"SFHA_TF": "T"}`, "X", true},
+		{`{"SFHA_TF": "f"}`, "AE", false},
+		{`{"SFHA_TF": true}`, "X", true},
+		{`{}`, "AE", true},
+		{`{"SFHA_TF": ""}`, "VE", true},
+		{`{}`, "X", false},
+		{`{}`, "AREA NOT INCLUDED", false},
+	}
+
+	for _, tt := range tests {
+		got, err := floodSFHA(testFeature(t, tt.properties, testPolygon), tt.zone)
+		require.NoError(t, err, tt.properties)
+		assert.Equal(t, tt.want, got, "%s in zone %s", tt.properties, tt.zone)
+	}
+
+	_, err := floodSFHA(testFeature(t, `{"SFHA_TF": "Y"}`, testPolygon), "AE")
+	assert.ErrorIs(t, err, ErrInvalidFeature)
+}
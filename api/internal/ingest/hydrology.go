@@ -0,0 +1,366 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// ModeHydrology names hydrology loads in the ingest lock and pg_stat_activity.
+const ModeHydrology = "hydrology"
+
+// Water feature kinds
+const (
+	// WaterLake is a lake, pond or reservoir polygon
+	WaterLake = "lake"
+	// WaterRiver is a river or stream, as a polygon or a centerline
+	WaterRiver = "river"
+)
+
+// DefaultWaterNameField is the NHD attribute holding a water feature's name.
+const DefaultWaterNameField = "GNIS_Name"
+
+// WaterfrontToleranceMeters is how close a parcel boundary must run to a water
+// feature to count as frontage. Parcel and hydrology layers are digitized
+// separately, so shorelines rarely coincide with parcel lines.
+const WaterfrontToleranceMeters = 15.0
+
+// hydrologyStagingTable receives the hydrology layer; it is dropped when the transaction ends.
+const hydrologyStagingTable = "hydrology_staging"
+
+// maxFeatureNameLength is the size of water_features.name, amenities.name and
+// the other descriptive columns of the layers.
+const maxFeatureNameLength = 255
+
+// HydrologyOptions controls a hydrology load.
+type HydrologyOptions struct {
+	// Kind is WaterLake or WaterRiver
+	Kind string
+	// NameField is the feature property holding the water body name; DefaultWaterNameField if empty
+	NameField string
+	// MaxInvalid is how many invalid features are skipped before the load is aborted
+	MaxInvalid int
+	// LockTimeout is how long to wait for a running load of the same county
+	LockTimeout time.Duration
+	// DryRun validates every feature without touching the database
+	DryRun bool
+}
+
+// HydrologySummary reports the outcome of a hydrology load. Waterfront counts
+// the county's live parcels with frontage once frontage has been recomputed.
+type HydrologySummary struct {
+	Read       int
+	Invalid    int
+	Loaded     int64
+	Updated    int64
+	Waterfront int64
+}
+
+// LoadHydrology replaces the water features of opts.Kind in the mapping's county
+// with those from source and recomputes the waterfront columns of the county's
+// live parcels (see updateFrontage), then their ingest-stage derived attributes. Features must be polygons or line strings
+// in WGS84; others are skipped as invalid. The load runs in one transaction
+// holding the county's ingest lock, so parcels never show frontage against a
+// half-loaded layer.
+// Returns ErrTooManyInvalid, ErrCountyLocked or ErrUnknownCounty.
+func (l *Loader) LoadHydrology(ctx context.Context, source Source, opts HydrologyOptions) (*HydrologySummary, error) {
+	if opts.Kind != WaterLake && opts.Kind != WaterRiver {
+		return nil, fmt.Errorf("invalid water kind %q, expected %s or %s", opts.Kind, WaterLake, WaterRiver)
+	}
+	if opts.NameField == "" {
+		opts.NameField = DefaultWaterNameField
+	}
+	if opts.LockTimeout < 0 {
+		return nil, fmt.Errorf("invalid lock timeout %s, must not be negative", opts.LockTimeout)
+	}
+
+	rows := &waterSource{ctx: ctx, source: source, log: l.log, nameField: opts.NameField, maxInvalid: opts.MaxInvalid}
+	if opts.DryRun {
+		for rows.Next() {
+			// Validation happens in Next
+		}
+		return rows.summary(), rows.Err()
+	}
+
+	tx, err := l.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		// No-op once committed
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err := l.lockCounty(ctx, tx, ModeHydrology, opts.LockTimeout); err != nil {
+		return nil, err
+	}
+
+	var countyID int
+	err = tx.QueryRow(ctx, `SELECT id FROM counties WHERE slug = $1`, l.mapping.County).Scan(&countyID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCounty, l.mapping.County)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up county %s: %w", l.mapping.County, err)
+	}
+
+	createStaging := `
+		CREATE TEMP TABLE ` + hydrologyStagingTable + ` (
+			name VARCHAR(255),
+			geom_json TEXT NOT NULL
+		) ON COMMIT DROP
+	`
+	if _, err := tx.Exec(ctx, createStaging); err != nil {
+		return nil, fmt.Errorf("failed to create hydrology staging table: %w", err)
+	}
+
+	copied, err := tx.CopyFrom(ctx, pgx.Identifier{hydrologyStagingTable}, []string{"name", "geom_json"}, rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy water features into staging table: %w", err)
+	}
+	l.log.Info("Water features staged", map[string]interface{}{
+		"staged":  copied,
+		"invalid": rows.invalid,
+	})
+
+	if _, err := tx.Exec(ctx, `DELETE FROM water_features WHERE county_id = $1 AND kind = $2`, countyID, opts.Kind); err != nil {
+		return nil, fmt.Errorf("failed to clear %s features of county %s: %w", opts.Kind, l.mapping.County, err)
+	}
+
+	// Shorelines from NHD are often self-touching; repair them so buffering never fails
+	insert := `
+		INSERT INTO water_features (county_id, kind, name, geom)
+		SELECT $1, $2, name, ST_MakeValid(ST_Force2D(ST_SetSRID(ST_GeomFromGeoJSON(geom_json), 4326)))
+		FROM ` + hydrologyStagingTable
+	summary := rows.summary()
+	tag, err := tx.Exec(ctx, insert, countyID, opts.Kind)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert water features: %w", err)
+	}
+	summary.Loaded = tag.RowsAffected()
+
+	if summary.Updated, err = updateFrontage(ctx, tx, countyID); err != nil {
+		return nil, err
+	}
+	// Derived attributes may read the waterfront columns
+	if _, err := l.updateDerived(ctx, tx, countyID); err != nil {
+		return nil, err
+	}
+	waterfront := `
+		SELECT COUNT(*) FROM tax_parcels
+		WHERE county_id = $1
+			AND water_frontage_meters > 0
+			AND deleted_at IS NULL
+	`
+	if err := tx.QueryRow(ctx, waterfront, countyID).Scan(&summary.Waterfront); err != nil {
+		return nil, fmt.Errorf("failed to count waterfront parcels: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit hydrology load: %w", err)
+	}
+	return summary, nil
+}
+
+// updateFrontage recomputes the waterfront columns of the county's live parcels
+// and returns how many changed. water_frontage_meters is the length of the
+// parcel boundary within WaterfrontToleranceMeters of any of the county's water
+// features, so a parcel touching a lake and the river feeding it is not counted
+// twice; waterfront_type and water_body_name come from the nearest feature of a
+// parcel with frontage. Counties without water features get NULL frontage, meaning not
+// computed, rather than 0. Rows whose values would not change are not written,
+// and updated_at is left alone since no parcel attribute changed.
+func updateFrontage(ctx context.Context, tx pgx.Tx, countyID int) (int64, error) {
+	update := `
+		WITH frontage AS (
+			SELECT p.id,
+				ST_Length(ST_Intersection(
+					ST_Boundary(p.geom),
+					ST_Union(ST_Buffer(w.geom::geography, $2)::geometry)
+				)::geography) AS meters
+			FROM tax_parcels p
+			JOIN water_features w ON w.county_id = p.county_id
+				AND ST_DWithin(p.geom::geography, w.geom::geography, $2)
+			WHERE p.county_id = $1
+				AND p.deleted_at IS NULL
+			GROUP BY p.id, p.geom
+		),
+		nearest AS (
+			SELECT DISTINCT ON (p.id) p.id, w.kind, w.name
+			FROM tax_parcels p
+			JOIN water_features w ON w.county_id = p.county_id
+				AND ST_DWithin(p.geom::geography, w.geom::geography, $2)
+			WHERE p.county_id = $1
+				AND p.deleted_at IS NULL
+			ORDER BY p.id, ST_Distance(p.geom::geography, w.geom::geography), w.id
+		),
+		computed AS (
+			SELECT p.id,
+				CASE WHEN EXISTS (SELECT 1 FROM water_features WHERE county_id = $1)
+					THEN COALESCE(f.meters, 0) END AS meters,
+				CASE WHEN f.meters > 0 THEN n.kind END AS kind,
+				CASE WHEN f.meters > 0 THEN n.name END AS name
+			FROM tax_parcels p
+			LEFT JOIN frontage f ON f.id = p.id
+			LEFT JOIN nearest n ON n.id = p.id
+			WHERE p.county_id = $1
+				AND p.deleted_at IS NULL
+		)
+		UPDATE tax_parcels p SET
+			water_frontage_meters = c.meters,
+			waterfront_type = c.kind,
+			water_body_name = c.name
+		FROM computed c
+		WHERE p.id = c.id
+			AND (p.water_frontage_meters, p.waterfront_type, p.water_body_name)
+				IS DISTINCT FROM (c.meters, c.kind, c.name)
+	`
+	tag, err := tx.Exec(ctx, update, countyID, WaterfrontToleranceMeters)
+	if err != nil {
+		return 0, fmt.Errorf("failed to compute water frontage: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// waterSource adapts a Source to pgx.CopyFromSource, skipping invalid water features.
+type waterSource struct {
+	ctx        context.Context
+	source     Source
+	log        *logger.Logger
+	err        error
+	nameField  string
+	row        []any
+	read       int
+	invalid    int
+	maxInvalid int
+}
+
+// Next reads features until a valid one is found, the layer ends or loading must stop.
+func (s *waterSource) Next() bool {
+	for {
+		var name *string
+		feature, err := s.source.Next(s.ctx)
+		if errors.Is(err, io.EOF) {
+			return false
+		}
+		if err != nil && !errors.Is(err, ErrInvalidFeature) {
+			s.err = err
+			return false
+		}
+		s.read++
+
+		if err == nil {
+			name, err = featureName(feature, s.nameField)
+		}
+		if err == nil {
+			if err = checkWaterGeometry(feature.Geometry); err != nil {
+				err = fmt.Errorf("%w %d: %v", ErrInvalidFeature, feature.Number, err)
+			}
+		}
+		if err != nil {
+			s.invalid++
+			s.log.Warn("Skipping invalid water feature", map[string]interface{}{
+				"error": err.Error(),
+			})
+			if s.invalid > s.maxInvalid {
+				s.err = fmt.Errorf("%w: more than %d", ErrTooManyInvalid, s.maxInvalid)
+				return false
+			}
+			continue
+		}
+
+		s.row = []any{name, string(feature.Geometry)}
+		return true
+	}
+}
+
+// Values returns the current row.
+func (s *waterSource) Values() ([]any, error) {
+	return s.row, nil
+}
+
+// Err returns the error that stopped the source, if any.
+func (s *waterSource) Err() error {
+	return s.err
+}
+
+// summary returns the counts so far.
+func (s *waterSource) summary() *HydrologySummary {
+	return &HydrologySummary{Read: s.read, Invalid: s.invalid}
+}
+
+// featureName returns the feature's name property, nil when it is missing or blank.
+func featureName(feature *Feature, field string) (*string, error) {
+	raw, ok := feature.Properties[field]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	name, err := parseText(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w %d: %s: %v", ErrInvalidFeature, feature.Number, field, err)
+	}
+	if name != nil && utf8.RuneCountInString(*name) > maxFeatureNameLength {
+		return nil, fmt.Errorf("%w %d: %s exceeds %d characters", ErrInvalidFeature, feature.Number, field, maxFeatureNameLength)
+	}
+	return name, nil
+}
+
+// checkWaterGeometry checks that a water geometry is a non-empty Polygon,
+// MultiPolygon, LineString or MultiLineString within WGS84 bounds. Polygons are
+// parsed as parcel geometries are; lines need at least two positions.
+func checkWaterGeometry(raw json.RawMessage) error {
+	if len(raw) == 0 || string(raw) == "null" {
+		return errors.New("geometry is missing")
+	}
+
+	var geom struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+	if err := json.Unmarshal(raw, &geom); err != nil {
+		return fmt.Errorf("failed to unmarshal geometry: %w", err)
+	}
+
+	var lines [][][2]float64
+	switch geom.Type {
+	case "Polygon", "MultiPolygon":
+		_, err := parseGeometry(raw)
+		return err
+	case "LineString":
+		var line [][2]float64
+		if err := json.Unmarshal(geom.Coordinates, &line); err != nil {
+			return fmt.Errorf("failed to unmarshal linestring coordinates: %w", err)
+		}
+		lines = [][][2]float64{line}
+	case "MultiLineString":
+		if err := json.Unmarshal(geom.Coordinates, &lines); err != nil {
+			return fmt.Errorf("failed to unmarshal multilinestring coordinates: %w", err)
+		}
+		if len(lines) == 0 {
+			return errors.New("geometry is empty")
+		}
+	default:
+		return fmt.Errorf("expected Polygon, MultiPolygon, LineString or MultiLineString type, got %q", geom.Type)
+	}
+
+	for i, line := range lines {
+		if len(line) < 2 {
+			return fmt.Errorf("line %d has fewer than 2 positions", i)
+		}
+		for _, pos := range line {
+			// GeoJSON positions are [lng, lat]
+			if err := (models.LatLng{Lat: pos[1], Lng: pos[0]}).Validate(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+)
+
+// testHydrology has a lake polygon, a river centerline, a point and an unnamed
+// line with a single position.
+const testHydrology = `{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"GNIS_Name": "Lake Conroe"}, "geometry": ` + testPolygon + `},
+	{"type": "Feature", "properties": {"GNIS_Name": "West Fork San Jacinto River"},
+		"geometry": {"type": "MultiLineString", "coordinates": [[[-95.5, 30.3], [-95.4, 30.2]]]}},
+	{"type": "Feature", "properties": {"GNIS_Name": "Spring"}, "geometry": {"type": "Point", "coordinates": [-95.5, 30.3]}},
+	{"type": "Feature", "properties": {}, "geometry": {"type": "LineString", "coordinates": [[-95.5, 30.3]]}}
+]}`
+
+func TestLoader_HydrologyDryRun(t *testing.T) {
+	loader := NewLoader(nil, DefaultMapping(), logger.New("test"))
+
+	summary, err := loader.LoadHydrology(context.Background(), NewReader(strings.NewReader(testHydrology)), HydrologyOptions{
+		Kind:       WaterLake,
+		MaxInvalid: 2,
+		DryRun:     true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, &HydrologySummary{Read: 4, Invalid: 2}, summary)
+
+	_, err = loader.LoadHydrology(context.Background(), NewReader(strings.NewReader(testHydrology)), HydrologyOptions{
+		Kind:       WaterLake,
+		MaxInvalid: 1,
+		DryRun:     true,
+	})
+	assert.ErrorIs(t, err, ErrTooManyInvalid)
+}
+
+func TestLoader_HydrologyInvalidKind(t *testing.T) {
+	loader := NewLoader(nil, DefaultMapping(), logger.New("test"))
+
+	_, err := loader.LoadHydrology(context.Background(), NewReader(strings.NewReader(testHydrology)), HydrologyOptions{Kind: "ocean"})
+
+	assert.ErrorContains(t, err, "invalid water kind")
+}
+
+func TestCheckWaterGeometry(t *testing.T) {
+	tests := map[string]string{
+		`{"type": "LineString", "coordinates": [[-95.5, 30.3], [-95.4, 30.2]]}`:        "",
+		`{"type": "MultiLineString", "coordinates": [[[-95.5, 30.3], [-95.4, 30.2]]]}`: "",
+		testPolygon: "",
+		`{"type": "MultiLineString", "coordinates": []}`:                    "geometry is empty",
+		`{"type": "LineString", "coordinates": [[-95.5, 30.3]]}`:            "fewer than 2 positions",
+		`{"type": "LineString", "coordinates": [[-95.5, 95], [-95.4, 30]]}`: "latitude",
+		`{"type": "Point", "coordinates": [-95.5, 30.3]}`:                   "got \"Point\"",
+		`null`: "geometry is missing",
+	}
+
+	for geometry, wantErr := range tests {
+		err := checkWaterGeometry(json.RawMessage(geometry))
+		if wantErr == "" {
+			assert.NoError(t, err, geometry)
+		} else {
+			assert.ErrorContains(t, err, wantErr, geometry)
+		}
+	}
+}
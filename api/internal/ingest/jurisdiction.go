@@ -0,0 +1,88 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// ModeJurisdictions names jurisdiction loads in the ingest lock and pg_stat_activity.
+const ModeJurisdictions = "jurisdictions"
+
+// Jurisdiction attribute defaults. School district (TEA) and appraisal district
+// taxing unit layers name their attributes differently, so loads often set them.
+const (
+	// DefaultJurisdictionNameField is the attribute holding a jurisdiction's name
+	DefaultJurisdictionNameField = "NAME"
+	// DefaultJurisdictionCodeField is the attribute holding its identifier
+	DefaultJurisdictionCodeField = "CODE"
+)
+
+// maxJurisdictionCodeLength is the longest jurisdiction code a load accepts.
+const maxJurisdictionCodeLength = 50
+
+// JurisdictionOptions controls a jurisdiction load.
+type JurisdictionOptions struct {
+	// Kind is one of models.JurisdictionKinds
+	Kind string
+	// NameField is the feature property holding the name; DefaultJurisdictionNameField if empty
+	NameField string
+	// CodeField is the feature property holding the identifier; DefaultJurisdictionCodeField if empty
+	CodeField string
+	// MaxInvalid is how many invalid features are skipped before the load is aborted
+	MaxInvalid int
+	// LockTimeout is how long to wait for a running load of the same county
+	LockTimeout time.Duration
+	// DryRun validates every feature without touching the database
+	DryRun bool
+}
+
+// LoadJurisdictions replaces the features of opts.Kind in the jurisdiction
+// layer (models.LayerJurisdictions) in the mapping's county with the boundaries
+// from source. Features must be polygons in WGS84 with a name; codes are
+// optional. As with zoning, parcels are matched to the jurisdictions per
+// request, and the county's ingest-stage derived attributes are recomputed,
+// since they may read the layer.
+// Returns ErrTooManyInvalid, ErrCountyLocked or ErrUnknownCounty.
+func (l *Loader) LoadJurisdictions(ctx context.Context, source Source, opts JurisdictionOptions) (*LayerSummary, error) {
+	if !models.IsJurisdictionKind(opts.Kind) {
+		return nil, fmt.Errorf("invalid jurisdiction kind %q, expected one of %s",
+			opts.Kind, strings.Join(models.JurisdictionKinds, ", "))
+	}
+	if opts.NameField == "" {
+		opts.NameField = DefaultJurisdictionNameField
+	}
+	if opts.CodeField == "" {
+		opts.CodeField = DefaultJurisdictionCodeField
+	}
+
+	return l.loadLayer(ctx, source, layerLoad{
+		mode:  ModeJurisdictions,
+		name:  models.LayerJurisdictions,
+		title: "School districts and taxing units",
+		scope: map[string]any{"kind": opts.Kind},
+		properties: func(feature *Feature) ([]byte, error) {
+			name, err := featureText(feature, opts.NameField, maxFeatureNameLength, true)
+			if err != nil {
+				return nil, err
+			}
+			code, err := featureText(feature, opts.CodeField, maxJurisdictionCodeLength, false)
+			if err != nil {
+				return nil, err
+			}
+			properties := map[string]any{"kind": opts.Kind, "name": *name}
+			if code != nil {
+				properties["code"] = *code
+			}
+			return encodeProperties(properties)
+		},
+		checkGeometry: checkAreaGeometry,
+		polygons:      true,
+		maxInvalid:    opts.MaxInvalid,
+		lockTimeout:   opts.LockTimeout,
+		dryRun:        opts.DryRun,
+	})
+}
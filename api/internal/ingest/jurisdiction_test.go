@@ -0,0 +1,50 @@
+package ingest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// testJurisdictions has a district with a numeric code, a district without a
+// code, a district without a name, a point and a code too long for the column.
+var testJurisdictions = `{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"NAME": "Conroe ISD", "CODE": 170902}, "geometry": ` + testPolygon + `},
+	{"type": "Feature", "properties": {"NAME": "Willis ISD"}, "geometry": ` + testPolygon + `},
+	{"type": "Feature", "properties": {"NAME": " ", "CODE": "170908"}, "geometry": ` + testPolygon + `},
+	{"type": "Feature", "properties": {"NAME": "Magnolia ISD"}, "geometry": {"type": "Point", "coordinates": [-95.5, 30.3]}},
+	{"type": "Feature", "properties": {"NAME": "Montgomery ISD", "CODE": "` + strings.Repeat("x", maxJurisdictionCodeLength+1) + `"}, "geometry": ` + testPolygon + `}
+]}`
+
+func TestLoader_JurisdictionsCustomFields(t *testing.T) {
+	loader := NewLoader(nil, DefaultMapping(), logger.New("test"))
+	layer := `{"type": "FeatureCollection", "features": [
+		{"type": "Feature", "properties": {"UNIT_NAME": "Montgomery County MUD 8", "UNIT_CD": "M08"}, "geometry": ` + testPolygon + `}
+	]}`
+
+	summary, err := loader.LoadJurisdictions(context.Background(), NewReader(strings.NewReader(layer)), JurisdictionOptions{
+		Kind:      models.JurisdictionTaxingUnit,
+		NameField: "UNIT_NAME",
+		CodeField: "UNIT_CD",
+		DryRun:    true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, &LayerSummary{Read: 1}, summary)
+}
+
+func TestLoader_JurisdictionsInvalidKind(t *testing.T) {
+	loader := NewLoader(nil, DefaultMapping(), logger.New("test"))
+
+	_, err := loader.LoadJurisdictions(context.Background(), NewReader(strings.NewReader(testJurisdictions)), JurisdictionOptions{
+		Kind:   "city",
+		DryRun: true,
+	})
+
+	assert.ErrorContains(t, err, "invalid jurisdiction kind")
+}
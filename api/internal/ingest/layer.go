@@ -0,0 +1,401 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// ModeLayers names generic layer loads in the ingest lock and pg_stat_activity.
+const ModeLayers = "layers"
+
+// layerStagingTable receives the layer's features; it is dropped when the transaction ends.
+const layerStagingTable = "layer_staging"
+
+// maxLayerTitleLength is the size of layers.title.
+const maxLayerTitleLength = 255
+
+// LayerOptions controls a generic layer load.
+type LayerOptions struct {
+	// Name is the layer's URL name, see models.IsLayerName
+	Name string
+	// Title is the layer's display name; Name if empty. Updated on every load
+	Title string
+	// Description is stored with the layer when not empty
+	Description string
+	// Properties are the feature properties kept; all of them if empty
+	Properties []string
+	// MaxInvalid is how many invalid features are skipped before the load is aborted
+	MaxInvalid int
+	// LockTimeout is how long to wait for a running load of the same county
+	LockTimeout time.Duration
+	// DryRun validates every feature without touching the database
+	DryRun bool
+}
+
+// LayerSummary reports the outcome of a generic layer load.
+type LayerSummary struct {
+	Read    int
+	Invalid int
+	Loaded  int64
+}
+
+// LoadLayer replaces the features of the layer opts.Name in the mapping's
+// county with those from source, registering the layer on its first load.
+// Features may be points, lines or polygons in WGS84; their properties are
+// stored as JSON, so a new dataset needs no code or schema change. The county's
+// ingest-stage derived attributes are recomputed, since they may read the layer.
+// The overlay layers (models.OverlayLayers) have their own loads, which check
+// their properties.
+// Returns ErrTooManyInvalid, ErrCountyLocked or ErrUnknownCounty.
+func (l *Loader) LoadLayer(ctx context.Context, source Source, opts LayerOptions) (*LayerSummary, error) {
+	if !models.IsLayerName(opts.Name) {
+		return nil, fmt.Errorf("invalid layer name %q, expected lower-case letters, digits and underscores starting with a letter", opts.Name)
+	}
+	if models.IsOverlayLayer(opts.Name) {
+		return nil, fmt.Errorf("invalid layer name %q, reserved for the overlay load of that name", opts.Name)
+	}
+	if opts.Title == "" {
+		opts.Title = opts.Name
+	}
+	if utf8.RuneCountInString(opts.Title) > maxLayerTitleLength {
+		return nil, fmt.Errorf("invalid layer title, exceeds %d characters", maxLayerTitleLength)
+	}
+
+	return l.loadLayer(ctx, source, layerLoad{
+		mode:        ModeLayers,
+		name:        opts.Name,
+		title:       opts.Title,
+		description: opts.Description,
+		properties: func(feature *Feature) ([]byte, error) {
+			properties, err := layerProperties(feature, opts.Properties)
+			if err != nil {
+				return nil, fmt.Errorf("%w %d: %v", ErrInvalidFeature, feature.Number, err)
+			}
+			return properties, nil
+		},
+		checkGeometry: checkLayerGeometry,
+		maxInvalid:    opts.MaxInvalid,
+		lockTimeout:   opts.LockTimeout,
+		dryRun:        opts.DryRun,
+	})
+}
+
+// layerLoad describes how loadLayer validates and stores a layer's features.
+type layerLoad struct {
+	// properties validates the feature's attributes and returns the JSON object
+	// stored as its properties; errors wrap ErrInvalidFeature
+	properties func(feature *Feature) ([]byte, error)
+	// checkGeometry validates the feature's GeoJSON geometry
+	checkGeometry func(raw json.RawMessage) error
+	// scope holds the properties of the features the load replaces, such as one
+	// jurisdiction kind; all of the county's features of the layer when empty
+	scope map[string]any
+	// mode names the load in the ingest lock and pg_stat_activity
+	mode        string
+	name        string
+	title       string
+	description string
+	maxInvalid  int
+	lockTimeout time.Duration
+	// polygons keeps only the polygonal part of the geometries, for overlays
+	// whose areas are measured
+	polygons bool
+	dryRun   bool
+}
+
+// loadLayer replaces the features of the layer in the mapping's county, those
+// matching load.scope, with the valid features from source, registering the
+// layer on its first load.
+func (l *Loader) loadLayer(ctx context.Context, source Source, load layerLoad) (*LayerSummary, error) {
+	if load.lockTimeout < 0 {
+		return nil, fmt.Errorf("invalid lock timeout %s, must not be negative", load.lockTimeout)
+	}
+	scope := load.scope
+	if scope == nil {
+		scope = map[string]any{}
+	}
+	encodedScope, err := json.Marshal(scope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode layer scope: %w", err)
+	}
+
+	rows := &layerSource{ctx: ctx, source: source, log: l.log, layer: load.name,
+		properties: load.properties, checkGeometry: load.checkGeometry, maxInvalid: load.maxInvalid}
+	if load.dryRun {
+		for rows.Next() {
+			// Validation happens in Next
+		}
+		return rows.summary(), rows.Err()
+	}
+
+	tx, err := l.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		// No-op once committed
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err := l.lockCounty(ctx, tx, load.mode, load.lockTimeout); err != nil {
+		return nil, err
+	}
+
+	var countyID int
+	err = tx.QueryRow(ctx, `SELECT id FROM counties WHERE slug = $1`, l.mapping.County).Scan(&countyID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCounty, l.mapping.County)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up county %s: %w", l.mapping.County, err)
+	}
+
+	// Layers are shared by counties; the latest load sets the title and description
+	register := `
+		INSERT INTO layers (name, title, description)
+		VALUES ($1, $2, NULLIF($3, ''))
+		ON CONFLICT (name) DO UPDATE
+		SET title = EXCLUDED.title,
+			description = COALESCE(EXCLUDED.description, layers.description),
+			updated_at = NOW()
+		RETURNING id
+	`
+	var layerID int
+	if err := tx.QueryRow(ctx, register, load.name, load.title, load.description).Scan(&layerID); err != nil {
+		return nil, fmt.Errorf("failed to register layer %s: %w", load.name, err)
+	}
+
+	createStaging := `
+		CREATE TEMP TABLE ` + layerStagingTable + ` (
+			properties TEXT NOT NULL,
+			geom_json TEXT NOT NULL
+		) ON COMMIT DROP
+	`
+	if _, err := tx.Exec(ctx, createStaging); err != nil {
+		return nil, fmt.Errorf("failed to create layer staging table: %w", err)
+	}
+
+	copied, err := tx.CopyFrom(ctx, pgx.Identifier{layerStagingTable}, []string{"properties", "geom_json"}, rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy layer features into staging table: %w", err)
+	}
+	l.log.Info("Layer features staged", map[string]interface{}{
+		"layer":   load.name,
+		"staged":  copied,
+		"invalid": rows.invalid,
+	})
+
+	clear := `DELETE FROM layer_features WHERE layer_id = $1 AND county_id = $2 AND properties @> $3::jsonb`
+	if _, err := tx.Exec(ctx, clear, layerID, countyID, string(encodedScope)); err != nil {
+		return nil, fmt.Errorf("failed to clear layer %s features of county %s: %w", load.name, l.mapping.County, err)
+	}
+
+	// Invalid polygons are repaired, which may turn them into collections.
+	// Overlay layers are often digitized with slivers and self-touching rings;
+	// they keep only the polygonal part so area overlays never fail, while other
+	// geometries keep their type
+	geom := `ST_MakeValid(ST_Force2D(ST_SetSRID(ST_GeomFromGeoJSON(geom_json), 4326)))`
+	if load.polygons {
+		geom = `ST_Multi(ST_CollectionExtract(` + geom + `, 3))`
+	}
+	insert := `
+		INSERT INTO layer_features (layer_id, county_id, properties, geom)
+		SELECT $1, $2, properties::jsonb, ` + geom + `
+		FROM ` + layerStagingTable
+	summary := rows.summary()
+	tag, err := tx.Exec(ctx, insert, layerID, countyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert layer features: %w", err)
+	}
+	summary.Loaded = tag.RowsAffected()
+
+	// Derived attributes may read the layer's features
+	if _, err := l.updateDerived(ctx, tx, countyID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit layer load: %w", err)
+	}
+	return summary, nil
+}
+
+// layerSource adapts a Source to pgx.CopyFromSource, skipping invalid features.
+type layerSource struct {
+	ctx           context.Context
+	source        Source
+	log           *logger.Logger
+	err           error
+	properties    func(feature *Feature) ([]byte, error)
+	checkGeometry func(raw json.RawMessage) error
+	layer         string
+	row           []any
+	read          int
+	invalid       int
+	maxInvalid    int
+}
+
+// Next reads features until a valid one is found, the layer ends or loading must stop.
+func (s *layerSource) Next() bool {
+	for {
+		var properties []byte
+		feature, err := s.source.Next(s.ctx)
+		if errors.Is(err, io.EOF) {
+			return false
+		}
+		if err != nil && !errors.Is(err, ErrInvalidFeature) {
+			s.err = err
+			return false
+		}
+		s.read++
+
+		if err == nil {
+			properties, err = s.properties(feature)
+		}
+		if err == nil {
+			if err = s.checkGeometry(feature.Geometry); err != nil {
+				err = fmt.Errorf("%w %d: %v", ErrInvalidFeature, feature.Number, err)
+			}
+		}
+		if err != nil {
+			s.invalid++
+			s.log.Warn("Skipping invalid layer feature", map[string]interface{}{
+				"layer": s.layer,
+				"error": err.Error(),
+			})
+			if s.invalid > s.maxInvalid {
+				s.err = fmt.Errorf("%w: more than %d", ErrTooManyInvalid, s.maxInvalid)
+				return false
+			}
+			continue
+		}
+
+		s.row = []any{string(properties), string(feature.Geometry)}
+		return true
+	}
+}
+
+// Values returns the current row.
+func (s *layerSource) Values() ([]any, error) {
+	return s.row, nil
+}
+
+// Err returns the error that stopped the source, if any.
+func (s *layerSource) Err() error {
+	return s.err
+}
+
+// summary returns the counts so far.
+func (s *layerSource) summary() *LayerSummary {
+	return &LayerSummary{Read: s.read, Invalid: s.invalid}
+}
+
+// layerProperties encodes the feature's properties as a JSON object, only those
+// named in keep when it is not empty. Properties missing from the feature are left out.
+func layerProperties(feature *Feature, keep []string) ([]byte, error) {
+	properties := feature.Properties
+	if len(keep) > 0 {
+		properties = make(map[string]any, len(keep))
+		for _, key := range keep {
+			if value, ok := feature.Properties[key]; ok {
+				properties[key] = value
+			}
+		}
+	}
+	return encodeProperties(properties)
+}
+
+// encodeProperties encodes feature properties as a JSON object.
+func encodeProperties(properties map[string]any) ([]byte, error) {
+	if properties == nil {
+		properties = map[string]any{}
+	}
+
+	encoded, err := json.Marshal(properties)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode properties: %w", err)
+	}
+	return encoded, nil
+}
+
+// featureText returns the feature's text property field, at most maxLength
+// characters; blank values are missing, which is invalid when required. The
+// overlay loads read their properties with it.
+func featureText(feature *Feature, field string, maxLength int, required bool) (*string, error) {
+	var text *string
+	var err error
+	if raw, ok := feature.Properties[field]; ok && raw != nil {
+		text, err = parseText(raw)
+	}
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("%w %d: %s: %v", ErrInvalidFeature, feature.Number, field, err)
+	case text == nil && required:
+		return nil, fmt.Errorf("%w %d: %s is missing", ErrInvalidFeature, feature.Number, field)
+	case text != nil && utf8.RuneCountInString(*text) > maxLength:
+		return nil, fmt.Errorf("%w %d: %s exceeds %d characters", ErrInvalidFeature, feature.Number, field, maxLength)
+	}
+	return text, nil
+}
+
+// checkAreaGeometry checks that a geometry is a polygon or multipolygon
+// parseGeometry accepts.
+func checkAreaGeometry(raw json.RawMessage) error {
+	_, err := parseGeometry(raw)
+	return err
+}
+
+// checkLayerGeometry checks that a layer geometry is a non-empty Point or
+// MultiPoint within WGS84 bounds, or a line or polygon as checkWaterGeometry
+// accepts them.
+func checkLayerGeometry(raw json.RawMessage) error {
+	if len(raw) == 0 || string(raw) == "null" {
+		return errors.New("geometry is missing")
+	}
+
+	var geom struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+	if err := json.Unmarshal(raw, &geom); err != nil {
+		return fmt.Errorf("failed to unmarshal geometry: %w", err)
+	}
+
+	var points [][2]float64
+	switch geom.Type {
+	case "Point":
+		var point [2]float64
+		if err := json.Unmarshal(geom.Coordinates, &point); err != nil {
+			return fmt.Errorf("failed to unmarshal point coordinates: %w", err)
+		}
+		points = [][2]float64{point}
+	case "MultiPoint":
+		if err := json.Unmarshal(geom.Coordinates, &points); err != nil {
+			return fmt.Errorf("failed to unmarshal multipoint coordinates: %w", err)
+		}
+		if len(points) == 0 {
+			return errors.New("geometry is empty")
+		}
+	case "LineString", "MultiLineString", "Polygon", "MultiPolygon":
+		return checkWaterGeometry(raw)
+	default:
+		return fmt.Errorf("expected Point, MultiPoint, LineString, MultiLineString, Polygon or MultiPolygon type, got %q", geom.Type)
+	}
+
+	for _, pos := range points {
+		// GeoJSON positions are [lng, lat]
+		if err := (models.LatLng{Lat: pos[1], Lng: pos[0]}).Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
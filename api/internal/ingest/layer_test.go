@@ -0,0 +1,137 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// testLayer has a polygon, a point, a line, a multipoint without properties,
+// a point out of range and a feature without geometry.
+var testLayer = `{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"WETLAND_TYPE": "Freshwater Pond", "ACRES": 1.25}, "geometry": ` + testPolygon + `},
+	{"type": "Feature", "properties": {"POLE_ID": "P-1001"}, "geometry": {"type": "Point", "coordinates": [-95.5, 30.3]}},
+	{"type": "Feature", "properties": {"EASEMENT": "Pipeline"}, "geometry": {"type": "LineString", "coordinates": [[-95.5, 30.3], [-95.4, 30.4]]}},
+	{"type": "Feature", "properties": null, "geometry": {"type": "MultiPoint", "coordinates": [[-95.5, 30.3], [-95.4, 30.4]]}},
+	{"type": "Feature", "properties": {"POLE_ID": "P-1002"}, "geometry": {"type": "Point", "coordinates": [-195.5, 30.3]}},
+	{"type": "Feature", "properties": {"POLE_ID": "P-1003"}, "geometry": null}
+]}`
+
+func TestLoader_LayerDryRun(t *testing.T) {
+	loader := NewLoader(nil, DefaultMapping(), logger.New("test"))
+
+	summary, err := loader.LoadLayer(context.Background(), NewReader(strings.NewReader(testLayer)), LayerOptions{
+		Name:       "wetlands",
+		MaxInvalid: 2,
+		DryRun:     true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, &LayerSummary{Read: 6, Invalid: 2}, summary)
+
+	_, err = loader.LoadLayer(context.Background(), NewReader(strings.NewReader(testLayer)), LayerOptions{
+		Name:       "wetlands",
+		MaxInvalid: 1,
+		DryRun:     true,
+	})
+	assert.ErrorIs(t, err, ErrTooManyInvalid)
+}
+
+func TestLoader_LayerInvalidName(t *testing.T) {
+	loader := NewLoader(nil, DefaultMapping(), logger.New("test"))
+
+	for _, name := range []string{"", "Wetlands", "1wetlands", "wet-lands", strings.Repeat("x", 64), models.LayerZoning, models.LayerJurisdictions} {
+		_, err := loader.LoadLayer(context.Background(), NewReader(strings.NewReader(testLayer)), LayerOptions{
+			Name:   name,
+			DryRun: true,
+		})
+
+		assert.ErrorContains(t, err, "invalid layer name", name)
+	}
+}
+
+func TestLoader_OverlayDryRun(t *testing.T) {
+	loader := NewLoader(nil, DefaultMapping(), logger.New("test"))
+	tests := map[string]struct {
+		source string
+		load   func(ctx context.Context, source Source, maxInvalid int) (*LayerSummary, error)
+	}{
+		models.LayerZoning: {testZoning, func(ctx context.Context, source Source, maxInvalid int) (*LayerSummary, error) {
+			return loader.LoadZoning(ctx, source, ZoningOptions{MaxInvalid: maxInvalid, DryRun: true})
+		}},
+		models.LayerFloodZones: {testFloodZones, func(ctx context.Context, source Source, maxInvalid int) (*LayerSummary, error) {
+			return loader.LoadFloodZones(ctx, source, FloodZoneOptions{MaxInvalid: maxInvalid, DryRun: true})
+		}},
+		models.LayerJurisdictions: {testJurisdictions, func(ctx context.Context, source Source, maxInvalid int) (*LayerSummary, error) {
+			return loader.LoadJurisdictions(ctx, source, JurisdictionOptions{
+				Kind: models.JurisdictionSchoolDistrict, MaxInvalid: maxInvalid, DryRun: true,
+			})
+		}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Each fixture has 5 features, 3 of them invalid
+			summary, err := tc.load(context.Background(), NewReader(strings.NewReader(tc.source)), 3)
+			require.NoError(t, err)
+			assert.Equal(t, &LayerSummary{Read: 5, Invalid: 3}, summary)
+
+			_, err = tc.load(context.Background(), NewReader(strings.NewReader(tc.source)), 2)
+			assert.ErrorIs(t, err, ErrTooManyInvalid)
+		})
+	}
+}
+
+func TestLayerProperties(t *testing.T) {
+	feature := &Feature{Properties: map[string]any{
+		"WETLAND_TYPE": "Freshwater Pond",
+		"ACRES":        json.Number("1.25"),
+		"OBJECTID":     json.Number("17"),
+	}}
+
+	all, err := layerProperties(feature, nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"WETLAND_TYPE": "Freshwater Pond", "ACRES": 1.25, "OBJECTID": 17}`, string(all))
+
+	kept, err := layerProperties(feature, []string{"WETLAND_TYPE", "ACRES", "MISSING"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"WETLAND_TYPE": "Freshwater Pond", "ACRES": 1.25}`, string(kept))
+
+	none, err := layerProperties(&Feature{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, `{}`, string(none))
+}
+
+func TestFeatureText(t *testing.T) {
+	tests := []struct {
+		properties string
+		required   bool
+		wantErr    string
+	}{
+		{`{"ZONING": "R-1"}`, true, ""},
+		{`{"ZONING": 12}`, true, ""},
+		{`{}`, false, ""},
+		{`{"ZONING": " "}`, false, ""},
+		{`{}`, true, "ZONING is missing"},
+		{`{"ZONING": null}`, true, "ZONING is missing"},
+		{`{"ZONING": ""}`, true, "ZONING is missing"},
+		{`{"ZONING": [1, 2]}`, false, "expected text"},
+		{`{"ZONING": "` + strings.Repeat("x", maxZoningCodeLength+1) + `"}`, false, "exceeds 50 characters"},
+	}
+
+	for _, tt := range tests {
+		_, err := featureText(testFeature(t, tt.properties, testPolygon), DefaultZoningCodeField, maxZoningCodeLength, tt.required)
+		if tt.wantErr == "" {
+			assert.NoError(t, err, tt.properties)
+		} else {
+			assert.ErrorContains(t, err, tt.wantErr, tt.properties)
+			assert.ErrorIs(t, err, ErrInvalidFeature, tt.properties)
+		}
+	}
+}
@@ -0,0 +1,621 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/encryption"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// Load modes
+const (
+	// ModeReplace swaps the county's parcels for the file, in one transaction
+	ModeReplace = "replace"
+	// ModeAppend adds the file's parcels to the existing ones
+	ModeAppend = "append"
+	// ModeSync diffs the file against the county's parcels by object_id and content
+	// hash: new parcels are inserted, changed ones updated and missing ones soft-deleted
+	ModeSync = "sync"
+)
+
+// stagingTable receives the COPY stream; it is dropped when the transaction ends.
+const stagingTable = "ingest_staging"
+
+// contentHashExpression hashes a staged row's attributes and GeoJSON geometry.
+// Sync loads compare it with tax_parcels.content_hash to skip unchanged parcels.
+var contentHashExpression = "md5(ROW(" + strings.Join(stagingColumns(), ", ") + ")::text)"
+
+// ErrTooManyInvalid is returned when more features are invalid than Options.MaxInvalid allows.
+var ErrTooManyInvalid = errors.New("too many invalid features")
+
+// ErrAnomaliesBlocked is returned when a replace or sync load deviates from the
+// county's baseline beyond the anomaly thresholds and Options.AllowAnomalies is not set.
+var ErrAnomaliesBlocked = errors.New("load blocked by anomalies")
+
+// Options controls a load.
+type Options struct {
+	// Mode is ModeReplace, ModeAppend or ModeSync
+	Mode string
+	// SourceFile is recorded in ingestion_runs
+	SourceFile string
+	// MaxInvalid is how many invalid features are skipped before the load is aborted
+	MaxInvalid int
+	// LockTimeout is how long to wait for a running load of the same county;
+	// zero fails at once if one is running
+	LockTimeout time.Duration
+	// DryRun validates every feature without touching the database
+	DryRun bool
+	// AllowAnomalies commits a replace or sync load that fails the anomaly
+	// check, recording it as forced
+	AllowAnomalies bool
+	// Thresholds bound the anomaly check; the zero value is DefaultAnomalyThresholds
+	Thresholds AnomalyThresholds
+}
+
+// Source yields the features to load. Reader and ArcGISSource implement it.
+type Source interface {
+	// Next returns the next feature, or io.EOF after the last one.
+	Next(ctx context.Context) (*Feature, error)
+}
+
+// Summary reports the outcome of a load. Updated, Unchanged and Deleted are
+// only counted by sync loads.
+type Summary struct {
+	Read      int
+	Inserted  int64
+	Updated   int64
+	Unchanged int64
+	Deleted   int64
+	Invalid   int
+}
+
+// Loader bulk-inserts parcels with COPY.
+type Loader struct {
+	db      *database.Database
+	mapping *Mapping
+	log     *logger.Logger
+	keyring *encryption.Keyring
+	derived []config.DerivedAttribute
+}
+
+// NewLoader creates a Loader. db may be nil for dry runs.
+func NewLoader(db *database.Database, mapping *Mapping, log *logger.Logger) *Loader {
+	return &Loader{
+		db:      db,
+		mapping: mapping,
+		log:     log,
+	}
+}
+
+// WithKeyring sets the keyring that encrypts owner contact columns before they
+// are staged, and returns l. Without one they are stored in plaintext.
+func (l *Loader) WithKeyring(keyring *encryption.Keyring) *Loader {
+	l.keyring = keyring
+	return l
+}
+
+// Load streams the features from source into tax_parcels for the mapping's county.
+// The county is created from the mapping if needed and its field mappings and
+// document links are updated. Parcels are copied into a temporary staging table and inserted into
+// tax_parcels in the same transaction, so a failed load never leaves the county
+// partly replaced; other counties' parcels are never touched.
+// Waterfront columns are recomputed against the county's water features (see
+// LoadHydrology), then the ingest-stage derived attributes (see
+// WithDerivedAttributes), and owner names not seen before are resolved to owners
+// (see OwnerKey). Owner contact columns are encrypted with the keyring
+// (see WithKeyring). In replace and sync mode the county's parcels are
+// compared with its recent runs before the transaction commits, with
+// opts.Thresholds (see CheckAnomalies), and the run is
+// recorded in ingestion_runs, which triggers cache warming. Loads of the same county, including import-parcels.sh runs,
+// never overlap: the transaction holds the county's advisory lock.
+// Returns ErrTooManyInvalid if more than opts.MaxInvalid features are invalid,
+// ErrAnomaliesBlocked if the load was rolled back and recorded as blocked,
+// or ErrCountyLocked if another load of the county outlasts opts.LockTimeout.
+func (l *Loader) Load(ctx context.Context, source Source, opts Options) (*Summary, error) {
+	if opts.Mode != ModeReplace && opts.Mode != ModeAppend && opts.Mode != ModeSync {
+		return nil, fmt.Errorf("invalid mode %q, expected %s, %s or %s", opts.Mode, ModeReplace, ModeAppend, ModeSync)
+	}
+	if opts.LockTimeout < 0 {
+		return nil, fmt.Errorf("invalid lock timeout %s, must not be negative", opts.LockTimeout)
+	}
+	if err := opts.Thresholds.validate(); err != nil {
+		return nil, err
+	}
+
+	rows := &parcelSource{ctx: ctx, source: source, mapping: l.mapping, log: l.log, keyring: l.keyring, maxInvalid: opts.MaxInvalid}
+	if opts.DryRun {
+		for rows.Next() {
+			// Mapping and validation happen in Next
+		}
+		return rows.summary(), rows.Err()
+	}
+
+	tx, err := l.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		// No-op once committed
+		_ = tx.Rollback(ctx)
+	}()
+
+	// Taken before anything is read from the source, so a waiting load does not
+	// hold a half-consumed stream
+	if err := l.lockCounty(ctx, tx, opts.Mode, opts.LockTimeout); err != nil {
+		return nil, err
+	}
+
+	countyID, countyName, err := l.upsertCounty(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// object_id is unique in the file, and the key sync loads join on
+	createStaging := `
+		CREATE TEMP TABLE ` + stagingTable + ` (
+			object_id INTEGER PRIMARY KEY,
+			pin INTEGER NOT NULL,
+			pid INTEGER,
+			state_cd VARCHAR(10),
+			block INTEGER,
+			lot VARCHAR(50),
+			tract VARCHAR(50),
+			owner_name VARCHAR(500),
+			owner_address TEXT,
+			situs VARCHAR(500),
+			as_code VARCHAR(50),
+			legal_description TEXT,
+			imprv_actual_year_built INTEGER,
+			imprv_main_area INTEGER,
+			p_year INTEGER,
+			p_version INTEGER,
+			p_roll_corr INTEGER,
+			taxing_units VARCHAR(255),
+			exemptions VARCHAR(255),
+			market_area VARCHAR(50),
+			geom_json TEXT NOT NULL
+		) ON COMMIT DROP
+	`
+	if _, err := tx.Exec(ctx, createStaging); err != nil {
+		return nil, fmt.Errorf("failed to create staging table: %w", err)
+	}
+
+	copied, err := tx.CopyFrom(ctx, pgx.Identifier{stagingTable}, stagingColumns(), rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy parcels into staging table: %w", err)
+	}
+	l.log.Info("Parcels staged", map[string]interface{}{
+		"staged":  copied,
+		"invalid": rows.invalid,
+	})
+
+	if opts.Mode == ModeReplace {
+		if _, err := tx.Exec(ctx, `DELETE FROM tax_parcels WHERE county_id = $1`, countyID); err != nil {
+			return nil, fmt.Errorf("failed to clear parcels of county %s: %w", l.mapping.County, err)
+		}
+	}
+
+	summary := rows.summary()
+	if opts.Mode == ModeSync {
+		if err := l.sync(ctx, tx, countyID, countyName, summary); err != nil {
+			return nil, err
+		}
+		summary.Unchanged = copied - summary.Inserted - summary.Updated
+	} else if summary.Inserted, err = l.insert(ctx, tx, countyID, countyName); err != nil {
+		return nil, err
+	}
+
+	// Loaded parcels have no frontage yet against the county's hydrology layer
+	if _, err := updateFrontage(ctx, tx, countyID); err != nil {
+		return nil, err
+	}
+	if _, err := l.updateDerived(ctx, tx, countyID); err != nil {
+		return nil, err
+	}
+	if _, err := resolveOwners(ctx, tx, countyID); err != nil {
+		return nil, err
+	}
+
+	if opts.Mode != ModeAppend {
+		if err := l.checkRun(ctx, tx, countyID, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit load: %w", err)
+	}
+	return summary, nil
+}
+
+// insert copies every staged parcel into tax_parcels and returns how many were inserted.
+func (l *Loader) insert(ctx context.Context, tx pgx.Tx, countyID int, countyName string) (int64, error) {
+	attrs := strings.Join(attributeNames(), ", ")
+	insert := `
+		INSERT INTO tax_parcels (` + attrs + `, county_id, county_name, geom, content_hash, created_at, updated_at)
+		SELECT ` + attrs + `, $1, $2,
+			ST_Multi(ST_SetSRID(ST_GeomFromGeoJSON(geom_json), 4326)), ` + contentHashExpression + `, NOW(), NOW()
+		FROM ` + stagingTable + `
+		ORDER BY object_id
+	`
+	tag, err := tx.Exec(ctx, insert, countyID, countyName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert parcels: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// sync applies the staged parcels to the county's parcels: staged parcels that
+// are new or whose content hash changed are upserted (restoring soft-deleted
+// ones), and live parcels missing from the staging table are soft-deleted.
+// Unchanged rows are not written, so their updated_at keeps the last change.
+func (l *Loader) sync(ctx context.Context, tx pgx.Tx, countyID int, countyName string, summary *Summary) error {
+	attrs := attributeNames()
+	assignments := make([]string, 0, len(attrs))
+	for _, name := range attrs {
+		assignments = append(assignments, name+" = EXCLUDED."+name)
+	}
+
+	// xmax is 0 for rows the INSERT created and set for rows it updated
+	upsert := `
+		WITH upserted AS (
+			INSERT INTO tax_parcels (` + strings.Join(attrs, ", ") + `, county_id, county_name, geom, content_hash, created_at, updated_at)
+			SELECT ` + strings.Join(attrs, ", ") + `, $1, $2,
+				ST_Multi(ST_SetSRID(ST_GeomFromGeoJSON(geom_json), 4326)), ` + contentHashExpression + `, NOW(), NOW()
+			FROM ` + stagingTable + `
+			ORDER BY object_id
+			ON CONFLICT (county_id, object_id) DO UPDATE SET
+				` + strings.Join(assignments, ",\n\t\t\t\t") + `,
+				county_name = EXCLUDED.county_name,
+				geom = EXCLUDED.geom,
+				content_hash = EXCLUDED.content_hash,
+				deleted_at = NULL,
+				updated_at = NOW()
+			WHERE tax_parcels.content_hash IS DISTINCT FROM EXCLUDED.content_hash
+				OR tax_parcels.deleted_at IS NOT NULL
+			RETURNING (xmax = 0) AS inserted
+		)
+		SELECT COUNT(*) FILTER (WHERE inserted), COUNT(*) FILTER (WHERE NOT inserted)
+		FROM upserted
+	`
+	if err := tx.QueryRow(ctx, upsert, countyID, countyName).Scan(&summary.Inserted, &summary.Updated); err != nil {
+		return fmt.Errorf("failed to upsert parcels: %w", err)
+	}
+
+	softDelete := `
+		UPDATE tax_parcels SET deleted_at = NOW(), updated_at = NOW()
+		WHERE county_id = $1
+			AND deleted_at IS NULL
+			AND NOT EXISTS (
+				SELECT 1 FROM ` + stagingTable + ` staged
+				WHERE staged.object_id = tax_parcels.object_id
+			)
+	`
+	tag, err := tx.Exec(ctx, softDelete, countyID)
+	if err != nil {
+		return fmt.Errorf("failed to soft-delete missing parcels: %w", err)
+	}
+	summary.Deleted = tag.RowsAffected()
+
+	l.log.Info("Parcels synced", map[string]interface{}{
+		"inserted": summary.Inserted,
+		"updated":  summary.Updated,
+		"deleted":  summary.Deleted,
+	})
+	return nil
+}
+
+// upsertCounty creates the mapping's county or updates its field mappings and
+// document URL templates, and returns its id and name. An existing county keeps
+// its name and state.
+func (l *Loader) upsertCounty(ctx context.Context, tx pgx.Tx) (int, string, error) {
+	fieldMappings, err := json.Marshal(l.mapping.Fields)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to encode field mappings: %w", err)
+	}
+	documentLinks := l.mapping.DocumentLinks
+	if documentLinks == nil {
+		documentLinks = map[string]string{}
+	}
+	urlTemplates, err := json.Marshal(documentLinks)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to encode document links: %w", err)
+	}
+
+	query := `
+		INSERT INTO counties (slug, name, state, field_mappings, document_url_templates)
+		VALUES ($1, $2, $3, $4::jsonb, $5::jsonb)
+		ON CONFLICT (slug) DO UPDATE SET
+			field_mappings = EXCLUDED.field_mappings,
+			document_url_templates = EXCLUDED.document_url_templates,
+			updated_at = NOW()
+		RETURNING id, name
+	`
+
+	var id int
+	var name string
+	err = tx.QueryRow(ctx, query, l.mapping.County, l.mapping.countyName(), strings.ToUpper(l.mapping.State),
+		string(fieldMappings), string(urlTemplates)).Scan(&id, &name)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to register county %s: %w", l.mapping.County, err)
+	}
+	return id, name, nil
+}
+
+// Ingestion run statuses
+const (
+	runActivated = "activated"
+	runForced    = "forced"
+	runBlocked   = "blocked"
+)
+
+// execer runs a statement in a transaction or on the pool.
+type execer interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+// checkRun measures the county's live parcels and compares them with the
+// baseline of its recent accepted runs (see CheckAnomalies). A run within the
+// thresholds is recorded as activated in tx, and one outside them as forced if
+// opts.AllowAnomalies is set. Otherwise tx is rolled back, a blocked run is
+// recorded in its place and ErrAnomaliesBlocked returned.
+func (l *Loader) checkRun(ctx context.Context, tx pgx.Tx, countyID int, opts Options) error {
+	metrics, err := l.measureRun(ctx, tx, countyID)
+	if err != nil {
+		return err
+	}
+	thresholds := opts.Thresholds
+	if thresholds == (AnomalyThresholds{}) {
+		thresholds = DefaultAnomalyThresholds()
+	}
+	baseline, err := l.baseline(ctx, tx, thresholds.BaselineRuns)
+	if err != nil {
+		return err
+	}
+
+	anomalies := CheckAnomalies(metrics, baseline, thresholds)
+	if baseline.Runs == 0 {
+		l.log.Info("No previous runs for the county; this run becomes the baseline", map[string]interface{}{
+			"county": l.mapping.County,
+		})
+	}
+	for _, anomaly := range anomalies {
+		l.log.Warn("Anomaly: "+anomaly, map[string]interface{}{
+			"county": l.mapping.County,
+		})
+	}
+
+	status := runActivated
+	if len(anomalies) > 0 {
+		if !opts.AllowAnomalies {
+			if err := tx.Rollback(ctx); err != nil {
+				return fmt.Errorf("failed to roll back blocked load: %w", err)
+			}
+			if err := l.recordRun(ctx, l.db.Pool, opts.SourceFile, metrics, runBlocked, anomalies); err != nil {
+				return err
+			}
+			return fmt.Errorf("%w: %s", ErrAnomaliesBlocked, strings.Join(anomalies, "; "))
+		}
+		status = runForced
+	}
+	return l.recordRun(ctx, tx, opts.SourceFile, metrics, status, anomalies)
+}
+
+// measureRun returns the metrics of the county's live parcels, with the null
+// rates of attributes the mapping leaves unmapped left nil.
+func (l *Loader) measureRun(ctx context.Context, tx pgx.Tx, countyID int) (RunMetrics, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			ROUND((COALESCE(SUM(ST_Area(geom::geography)), 0) / $2)::numeric, 2)::float8,
+			ROUND(AVG(CASE WHEN NULLIF(TRIM(owner_name), '') IS NULL THEN 1.0 ELSE 0.0 END), 4)::float8,
+			ROUND(AVG(CASE WHEN NULLIF(TRIM(situs), '') IS NULL THEN 1.0 ELSE 0.0 END), 4)::float8,
+			ROUND(AVG(CASE WHEN imprv_actual_year_built IS NULL THEN 1.0 ELSE 0.0 END), 4)::float8
+		FROM tax_parcels
+		WHERE county_id = $1
+			AND deleted_at IS NULL
+	`
+
+	var m RunMetrics
+	if err := tx.QueryRow(ctx, query, countyID, repository.SquareMetersPerAcre).Scan(&m.RecordCount, &m.TotalAcres,
+		&m.OwnerNameNullRate, &m.SitusNullRate, &m.YearBuiltNullRate); err != nil {
+		return RunMetrics{}, fmt.Errorf("failed to measure parcels of county %s: %w", l.mapping.County, err)
+	}
+	if l.mapping.source("owner_name") == "" {
+		m.OwnerNameNullRate = nil
+	}
+	if l.mapping.source("situs") == "" {
+		m.SitusNullRate = nil
+	}
+	if l.mapping.source("imprv_actual_year_built") == "" {
+		m.YearBuiltNullRate = nil
+	}
+	return m, nil
+}
+
+// baseline averages the metrics of the county's last accepted runs, at most runs of them.
+func (l *Loader) baseline(ctx context.Context, tx pgx.Tx, runs int) (Baseline, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COALESCE(ROUND(AVG(record_count)), 0)::int8,
+			COALESCE(AVG(total_acres), 0),
+			AVG(owner_name_null_rate),
+			AVG(situs_null_rate),
+			AVG(year_built_null_rate)
+		FROM (
+			SELECT * FROM ingestion_runs
+			WHERE county = $1
+				AND status IN ('activated', 'forced')
+			ORDER BY created_at DESC
+			LIMIT $2
+		) recent
+	`
+
+	var b Baseline
+	if err := tx.QueryRow(ctx, query, l.mapping.County, runs).Scan(&b.Runs, &b.RecordCount, &b.TotalAcres,
+		&b.OwnerNameNullRate, &b.SitusNullRate, &b.YearBuiltNullRate); err != nil {
+		return Baseline{}, fmt.Errorf("failed to read the baseline of county %s: %w", l.mapping.County, err)
+	}
+	return b, nil
+}
+
+// recordRun records an ingestion run of the county with the metrics its later
+// loads average into their anomaly baseline.
+func (l *Loader) recordRun(ctx context.Context, q execer, sourceFile string, m RunMetrics, status string, anomalies []string) error {
+	query := `
+		INSERT INTO ingestion_runs (county, source_file, record_count, total_acres,
+			owner_name_null_rate, situs_null_rate, year_built_null_rate, status, anomalies)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	if anomalies == nil {
+		anomalies = []string{}
+	}
+	if _, err := q.Exec(ctx, query, l.mapping.County, sourceFile, m.RecordCount, m.TotalAcres,
+		m.OwnerNameNullRate, m.SitusNullRate, m.YearBuiltNullRate, status, anomalies); err != nil {
+		return fmt.Errorf("failed to record ingestion run: %w", err)
+	}
+	return nil
+}
+
+// attributeNames returns the attribute column names in insert order.
+func attributeNames() []string {
+	names := make([]string, 0, len(columns)+1)
+	for _, col := range columns {
+		names = append(names, col.name)
+	}
+	return names
+}
+
+// stagingColumns returns the COPY column names: the attributes, then geom_json.
+func stagingColumns() []string {
+	return append(attributeNames(), "geom_json")
+}
+
+// parcelSource adapts a Source to pgx.CopyFromSource, skipping invalid features.
+// CopyFromSource has no context parameter, so the load's context is kept here.
+type parcelSource struct {
+	ctx        context.Context
+	source     Source
+	mapping    *Mapping
+	log        *logger.Logger
+	keyring    *encryption.Keyring
+	err        error
+	row        []any
+	seen       map[int]int
+	read       int
+	invalid    int
+	maxInvalid int
+}
+
+// Next reads features until a valid one is found, the file ends or loading must stop.
+func (s *parcelSource) Next() bool {
+	if s.seen == nil {
+		s.seen = make(map[int]int)
+	}
+
+	for {
+		var parcel *models.TaxParcel
+		feature, err := s.source.Next(s.ctx)
+		if errors.Is(err, io.EOF) {
+			return false
+		}
+		// Sources report features they cannot convert as invalid, and keep going
+		if err != nil && !errors.Is(err, ErrInvalidFeature) {
+			s.err = err
+			return false
+		}
+		s.read++
+
+		if err == nil {
+			parcel, err = s.mapping.Parcel(feature)
+		}
+		if err == nil {
+			// object_id is UNIQUE per county; a duplicate would abort the whole insert
+			if first, dup := s.seen[parcel.ObjectID]; dup {
+				err = fmt.Errorf("%w %d: duplicate object_id %d (first in feature %d)",
+					ErrInvalidFeature, feature.Number, parcel.ObjectID, first)
+			} else {
+				s.seen[parcel.ObjectID] = feature.Number
+			}
+		}
+		if err != nil {
+			s.invalid++
+			s.log.Warn("Skipping invalid feature", map[string]interface{}{
+				"error": err.Error(),
+			})
+			if s.invalid > s.maxInvalid {
+				s.err = fmt.Errorf("%w: more than %d", ErrTooManyInvalid, s.maxInvalid)
+				return false
+			}
+			continue
+		}
+
+		// Encrypted before staging, so the content hash covers the ciphertext
+		if parcel.OwnerAddress, s.err = s.keyring.EncryptText(parcel.OwnerAddress); s.err != nil {
+			return false
+		}
+		s.row, s.err = stagingRow(parcel)
+		return s.err == nil
+	}
+}
+
+// Values returns the current row.
+func (s *parcelSource) Values() ([]any, error) {
+	return s.row, nil
+}
+
+// Err returns the error that stopped the source, if any.
+func (s *parcelSource) Err() error {
+	return s.err
+}
+
+// summary returns the counts so far.
+func (s *parcelSource) summary() *Summary {
+	return &Summary{Read: s.read, Invalid: s.invalid}
+}
+
+// stagingRow returns the COPY values for a parcel, in stagingColumns order.
+func stagingRow(parcel *models.TaxParcel) ([]any, error) {
+	geoJSON, err := parcel.Geom.Value()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode geometry of object_id %d: %w", parcel.ObjectID, err)
+	}
+
+	return []any{
+		parcel.ObjectID,
+		parcel.PIN,
+		parcel.PID,
+		parcel.StateCd,
+		parcel.Block,
+		parcel.Lot,
+		parcel.Tract,
+		parcel.OwnerName,
+		parcel.OwnerAddress,
+		parcel.Situs,
+		parcel.AsCode,
+		parcel.LegalDescription,
+		parcel.ImprvActualYearBuilt,
+		parcel.ImprvMainArea,
+		parcel.PYear,
+		parcel.PVersion,
+		parcel.PRollCorr,
+		parcel.TaxingUnits,
+		parcel.Exemptions,
+		parcel.MarketArea,
+		geoJSON,
+	}, nil
+}
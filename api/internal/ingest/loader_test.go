@@ -0,0 +1,116 @@
+package ingest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+)
+
+// testCollection has two valid features, one duplicate and one without a geometry.
+const testCollection = `{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"OBJECTID": 1, "PIN": 10}, "geometry": ` + testPolygon + `},
+	{"type": "Feature", "properties": {"OBJECTID": 2, "PIN": 20}, "geometry": ` + testPolygon + `},
+	{"type": "Feature", "properties": {"OBJECTID": 1, "PIN": 30}, "geometry": ` + testPolygon + `},
+	{"type": "Feature", "properties": {"OBJECTID": 3, "PIN": 40}, "geometry": null}
+]}`
+
+func TestLoader_DryRun(t *testing.T) {
+	loader := NewLoader(nil, DefaultMapping(), logger.New("test"))
+
+	summary, err := loader.Load(context.Background(), NewReader(strings.NewReader(testCollection)), Options{
+		Mode:       ModeReplace,
+		MaxInvalid: 2,
+		DryRun:     true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, &Summary{Read: 4, Invalid: 2}, summary)
+}
+
+func TestLoader_DryRunSync(t *testing.T) {
+	loader := NewLoader(nil, DefaultMapping(), logger.New("test"))
+
+	summary, err := loader.Load(context.Background(), NewReader(strings.NewReader(testCollection)), Options{
+		Mode:       ModeSync,
+		MaxInvalid: 2,
+		DryRun:     true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, &Summary{Read: 4, Invalid: 2}, summary)
+}
+
+func TestLoader_NegativeLockTimeout(t *testing.T) {
+	loader := NewLoader(nil, DefaultMapping(), logger.New("test"))
+
+	_, err := loader.Load(context.Background(), NewReader(strings.NewReader(testCollection)), Options{
+		Mode:        ModeReplace,
+		LockTimeout: -time.Second,
+	})
+
+	assert.ErrorContains(t, err, "lock timeout")
+}
+
+func TestLockHolder_String(t *testing.T) {
+	since := time.Date(2026, 10, 16, 3, 0, 0, 0, time.UTC)
+	holder := &lockHolder{ApplicationName: "atlas-ingest sync montgomery-tx", PID: 4242, ClientAddr: "10.0.0.7", Since: &since}
+
+	assert.Equal(t, `"atlas-ingest sync montgomery-tx" (pid 4242, client 10.0.0.7) running since 2026-10-16T03:00:00Z`, holder.String())
+	assert.Equal(t, `"unnamed session" (pid 7)`, (&lockHolder{PID: 7}).String())
+	assert.Equal(t, "a session that has since ended", (*lockHolder)(nil).String())
+}
+
+func TestContentHashExpression(t *testing.T) {
+	// A column left out of the hash would let sync loads skip changes to it
+	for _, name := range stagingColumns() {
+		assert.Contains(t, contentHashExpression, name)
+	}
+}
+
+func TestLoader_TooManyInvalid(t *testing.T) {
+	loader := NewLoader(nil, DefaultMapping(), logger.New("test"))
+
+	summary, err := loader.Load(context.Background(), NewReader(strings.NewReader(testCollection)), Options{
+		Mode:       ModeReplace,
+		MaxInvalid: 1,
+		DryRun:     true,
+	})
+
+	assert.ErrorIs(t, err, ErrTooManyInvalid)
+	assert.Equal(t, 4, summary.Read)
+}
+
+func TestLoader_InvalidMode(t *testing.T) {
+	loader := NewLoader(nil, DefaultMapping(), logger.New("test"))
+
+	_, err := loader.Load(context.Background(), NewReader(strings.NewReader(testCollection)), Options{Mode: "merge"})
+	assert.Error(t, err)
+}
+
+func TestLoader_InvalidThresholds(t *testing.T) {
+	loader := NewLoader(nil, DefaultMapping(), logger.New("test"))
+
+	_, err := loader.Load(context.Background(), NewReader(strings.NewReader(testCollection)), Options{
+		Mode:       ModeReplace,
+		Thresholds: AnomalyThresholds{MaxCountChangePct: -1},
+		DryRun:     true,
+	})
+	assert.ErrorContains(t, err, "invalid anomaly thresholds")
+}
+
+func TestStagingRow(t *testing.T) {
+	parcel, err := DefaultMapping().Parcel(testFeature(t, `{"OBJECTID": 1, "PIN": 10, "ownerName": "SMITH"}`, testPolygon))
+	require.NoError(t, err)
+
+	row, err := stagingRow(parcel)
+	require.NoError(t, err)
+
+	require.Len(t, row, len(stagingColumns()))
+	assert.Equal(t, 1, row[0])
+	assert.Contains(t, row[len(row)-1], `"MultiPolygon"`)
+}
@@ -0,0 +1,140 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// DefaultLockTimeout is how long cmd/ingest waits for a running load of the same county.
+const DefaultLockTimeout = 30 * time.Second
+
+// lockRetryInterval is how often a waiting load retries the county lock.
+const lockRetryInterval = time.Second
+
+// lockNamespace prefixes county slugs before they are hashed into advisory lock
+// keys. import-parcels.sh derives the same key, so the two importers exclude
+// each other.
+const lockNamespace = "atlas:ingest:"
+
+// applicationNamePrefix marks ingest sessions in pg_stat_activity, which is
+// where a blocked load finds out what it is waiting for.
+const applicationNamePrefix = "atlas-ingest"
+
+// ErrCountyLocked is returned when another load of the same county is still
+// running after Options.LockTimeout.
+var ErrCountyLocked = errors.New("another ingest of the county is running")
+
+// lockHolder describes the session holding a county lock.
+type lockHolder struct {
+	Since           *time.Time
+	ApplicationName string
+	ClientAddr      string
+	PID             int
+}
+
+// String describes the holder for failure messages.
+func (h *lockHolder) String() string {
+	if h == nil {
+		return "a session that has since ended"
+	}
+	name := h.ApplicationName
+	if name == "" {
+		name = "unnamed session"
+	}
+	desc := fmt.Sprintf("%q (pid %d", name, h.PID)
+	if h.ClientAddr != "" {
+		desc += ", client " + h.ClientAddr
+	}
+	desc += ")"
+	if h.Since != nil {
+		desc += " running since " + h.Since.UTC().Format(time.RFC3339)
+	}
+	return desc
+}
+
+// lockCounty takes the county's transaction-level advisory lock, retrying every
+// lockRetryInterval until timeout. The lock is released when tx ends. Waiting
+// without blocking in PostgreSQL keeps the wait cancellable and lets a timed-out
+// load report who holds the lock.
+// Returns ErrCountyLocked naming the holder if the lock is still held at timeout.
+func (l *Loader) lockCounty(ctx context.Context, tx pgx.Tx, mode string, timeout time.Duration) error {
+	// Name the session so a load waiting for this one can report it
+	if _, err := tx.Exec(ctx, `SELECT set_config('application_name', $1, true)`,
+		fmt.Sprintf("%s %s %s", applicationNamePrefix, mode, l.mapping.County)); err != nil {
+		return fmt.Errorf("failed to name ingest session: %w", err)
+	}
+
+	key := lockNamespace + l.mapping.County
+	deadline := time.Now().Add(timeout)
+	waited := false
+	for {
+		var acquired bool
+		if err := tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock(hashtextextended($1, 0))`, key).Scan(&acquired); err != nil {
+			return fmt.Errorf("failed to take ingest lock of county %s: %w", l.mapping.County, err)
+		}
+		if acquired {
+			if waited {
+				l.log.Info("Ingest lock acquired", map[string]interface{}{
+					"county": l.mapping.County,
+				})
+			}
+			return nil
+		}
+
+		if !time.Now().Before(deadline) {
+			holder, err := l.lockHolder(ctx, tx, key)
+			if err != nil {
+				return fmt.Errorf("%w (county %s); holder lookup failed: %v", ErrCountyLocked, l.mapping.County, err)
+			}
+			return fmt.Errorf("%w: county %s is being loaded by %s; gave up after %s",
+				ErrCountyLocked, l.mapping.County, holder, timeout)
+		}
+		if !waited {
+			waited = true
+			l.log.Warn("Waiting for a running ingest of the county", map[string]interface{}{
+				"county":  l.mapping.County,
+				"timeout": timeout.String(),
+			})
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(min(lockRetryInterval, time.Until(deadline))):
+		}
+	}
+}
+
+// lockHolder finds the session holding the lock with the given key. A bigint
+// advisory lock key is split into classid (high 32 bits) and objid (low 32
+// bits) with objsubid 1. Returns nil, nil if the lock has been released.
+func (l *Loader) lockHolder(ctx context.Context, tx pgx.Tx, key string) (*lockHolder, error) {
+	query := `
+		WITH lock_key AS (SELECT hashtextextended($1, 0) AS k)
+		SELECT a.pid, COALESCE(a.application_name, ''), COALESCE(host(a.client_addr), ''),
+			COALESCE(a.xact_start, a.backend_start)
+		FROM pg_locks l
+		JOIN pg_stat_activity a ON a.pid = l.pid
+		CROSS JOIN lock_key
+		WHERE l.locktype = 'advisory'
+			AND l.granted
+			AND l.objsubid = 1
+			AND l.classid = ((lock_key.k >> 32) & 4294967295)::oid
+			AND l.objid = (lock_key.k & 4294967295)::oid
+		LIMIT 1
+	`
+
+	var holder lockHolder
+	err := tx.QueryRow(ctx, query, key).Scan(&holder.PID, &holder.ApplicationName, &holder.ClientAddr, &holder.Since)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &holder, nil
+}
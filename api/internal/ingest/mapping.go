@@ -0,0 +1,186 @@
+// Package ingest loads county parcel GeoJSON into the tax_parcels table.
+// Features are streamed from the file, validated and mapped into models.TaxParcel
+// one at a time, so files far larger than memory (Montgomery County is ~840 MB)
+// can be loaded. Field names come from the same mapping files the import script
+// uses (scripts/mappings/*.json). Appraisal district rolls refresh the owner and
+// address attributes of loaded parcels (see RefreshAppraisal).
+package ingest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/stwalsh4118/atlas/api/internal/documents"
+)
+
+// ErrInvalidMapping is returned when a mapping file is unusable.
+var ErrInvalidMapping = errors.New("invalid field mapping")
+
+// columnKind is how a tax_parcels column is parsed from a GeoJSON property.
+type columnKind int
+
+const (
+	kindInteger columnKind = iota
+	kindText
+)
+
+// column describes a mappable tax_parcels column.
+// maxLength is the VARCHAR size for text columns, 0 for TEXT.
+type column struct {
+	name      string
+	kind      columnKind
+	maxLength int
+	required  bool
+}
+
+// columns lists the tax_parcels attribute columns in insert order.
+var columns = []column{
+	{name: "object_id", kind: kindInteger, required: true},
+	{name: "pin", kind: kindInteger, required: true},
+	{name: "pid", kind: kindInteger},
+	{name: "state_cd", kind: kindText, maxLength: 10},
+	{name: "block", kind: kindInteger},
+	{name: "lot", kind: kindText, maxLength: 50},
+	{name: "tract", kind: kindText, maxLength: 50},
+	{name: "owner_name", kind: kindText, maxLength: 500},
+	{name: "owner_address", kind: kindText},
+	{name: "situs", kind: kindText, maxLength: 500},
+	{name: "as_code", kind: kindText, maxLength: 50},
+	{name: "legal_description", kind: kindText},
+	{name: "imprv_actual_year_built", kind: kindInteger},
+	{name: "imprv_main_area", kind: kindInteger},
+	{name: "p_year", kind: kindInteger},
+	{name: "p_version", kind: kindInteger},
+	{name: "p_roll_corr", kind: kindInteger},
+	{name: "taxing_units", kind: kindText, maxLength: 255},
+	{name: "exemptions", kind: kindText, maxLength: 255},
+	{name: "market_area", kind: kindText, maxLength: 50},
+}
+
+// Mapping maps tax_parcels columns to source property names for one county.
+// A nil or missing source leaves the column NULL. County is the slug of the
+// counties row the parcels are loaded for; CountyName and State describe it when
+// the county is loaded for the first time. DocumentLinks maps document reference
+// types to the county clerk's search URL template (see package documents).
+type Mapping struct {
+	Fields        map[string]*string `json:"field_mappings"`
+	DocumentLinks map[string]string  `json:"document_links"`
+	County        string             `json:"county"`
+	CountyName    string             `json:"county_name"`
+	State         string             `json:"state"`
+}
+
+// DefaultMapping returns the mapping for Montgomery County, TX, matching
+// scripts/mappings/montgomery-tx.json.
+func DefaultMapping() *Mapping {
+	fields := map[string]string{
+		"object_id":               "OBJECTID",
+		"pin":                     "PIN",
+		"pid":                     "pid",
+		"state_cd":                "stateCd",
+		"block":                   "Block",
+		"lot":                     "Lot",
+		"tract":                   "Tract",
+		"owner_name":              "ownerName",
+		"owner_address":           "ownerAddress",
+		"situs":                   "situs",
+		"as_code":                 "asCode",
+		"legal_description":       "legalDescription",
+		"imprv_actual_year_built": "imprvActualYearBuilt",
+		"imprv_main_area":         "imprvMainArea",
+		"p_year":                  "pYear",
+		"p_version":               "pVersion",
+		"p_roll_corr":             "pRollCorr",
+		"taxing_units":            "taxingUnits",
+		"exemptions":              "exemptions",
+		"market_area":             "marketArea",
+	}
+
+	mapping := &Mapping{
+		County:     "montgomery-tx",
+		CountyName: "Montgomery County",
+		State:      "TX",
+		Fields:     make(map[string]*string, len(fields)),
+	}
+	for col, source := range fields {
+		mapping.Fields[col] = &source
+	}
+	return mapping
+}
+
+// LoadMapping reads a mapping file in the scripts/mappings format.
+// Returns ErrInvalidMapping if the county, county name or two-letter state is
+// missing, a column is unknown, a required column (object_id, pin) is not mapped,
+// or a document link template is invalid.
+func LoadMapping(path string) (*Mapping, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- operator-supplied CLI argument
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping file: %w", err)
+	}
+
+	var mapping Mapping
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMapping, err)
+	}
+	if err := mapping.validate(); err != nil {
+		return nil, err
+	}
+
+	return &mapping, nil
+}
+
+// validate checks the mapping against the known columns.
+func (m *Mapping) validate() error {
+	if m.County == "" {
+		return fmt.Errorf("%w: county is required", ErrInvalidMapping)
+	}
+	if m.countyName() == "" {
+		return fmt.Errorf("%w: county_name is required", ErrInvalidMapping)
+	}
+	if len(m.State) != 2 {
+		return fmt.Errorf("%w: state must be a two-letter code, got %q", ErrInvalidMapping, m.State)
+	}
+
+	known := make(map[string]bool, len(columns))
+	for _, col := range columns {
+		known[col.name] = true
+		if col.required && m.source(col.name) == "" {
+			return fmt.Errorf("%w: %s must be mapped", ErrInvalidMapping, col.name)
+		}
+	}
+
+	if err := documents.ValidateTemplates(m.DocumentLinks); err != nil {
+		return fmt.Errorf("%w: document_links: %w", ErrInvalidMapping, err)
+	}
+
+	var unknown []string
+	for name := range m.Fields {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%w: unknown columns %v", ErrInvalidMapping, unknown)
+	}
+
+	return nil
+}
+
+// source returns the property name mapped to the column, or "" if unmapped.
+func (m *Mapping) source(col string) string {
+	if source := m.Fields[col]; source != nil {
+		return *source
+	}
+	return ""
+}
+
+// countyName returns the bare county name stored in counties.name and
+// tax_parcels.county_name, e.g. "Montgomery" for "Montgomery County".
+func (m *Mapping) countyName() string {
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(m.CountyName), " County"))
+}
@@ -0,0 +1,177 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// OwnerSimilarity is the pg_trgm similarity from which an owner key is taken
+// for a spelling variant of an owner's key, e.g. "SMITH JOHN A" of "SMITH JOHN".
+const OwnerSimilarity = 0.8
+
+// ownerBatchSize is how many owner keys are resolved per round trip.
+const ownerBatchSize = 1000
+
+// ownerSuffixes abbreviates the entity suffixes appraisal rolls spell out.
+var ownerSuffixes = map[string]string{
+	"INCORPORATED": "INC",
+	"CORPORATION":  "CORP",
+	"COMPANY":      "CO",
+	"LIMITED":      "LTD",
+	"PARTNERSHIP":  "PTNSHP",
+	"TRUSTEE":      "TR",
+	"TRUSTEES":     "TR",
+	"TRSTE":        "TR",
+	"ESTATE":       "EST",
+}
+
+// ownerInitialisms are the entity suffixes rolls may spell out letter by letter, e.g. "L L C".
+var ownerInitialisms = []string{"LLC", "LLP", "LP", "PC", "PLLC"}
+
+// ownerNoise lists the words of an owner name that do not identify the owner:
+// et al (and others), et ux (and wife) and et vir (and husband).
+var ownerNoise = []string{"ETAL", "ETUX", "ETVIR"}
+
+// OwnerKey normalizes an owner name into the key owners are deduplicated on:
+// upper case, "&" spelled AND, periods, commas and apostrophes dropped (so
+// "L.L.C." is LLC), other punctuation a space, spaced-out suffixes such as
+// "L L C" joined, entity suffixes abbreviated and ET AL, ET UX and ET VIR
+// dropped. Returns "" for names without letters or digits.
+func OwnerKey(name string) string {
+	name = strings.ToUpper(strings.ReplaceAll(name, "&", " AND "))
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r == '.' || r == ',' || r == '\'' || r == '’':
+			return -1
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return r
+		default:
+			return ' '
+		}
+	}, name)
+
+	words := strings.Fields(name)
+	key := make([]string, 0, len(words))
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		// Single letters in a row may be an entity suffix; others are initials
+		if len(word) == 1 {
+			j := i
+			for j+1 < len(words) && len(words[j+1]) == 1 {
+				j++
+			}
+			if joined := strings.Join(words[i:j+1], ""); slices.Contains(ownerInitialisms, joined) {
+				word, i = joined, j
+			}
+		}
+		if word == "ET" && i+1 < len(words) && slices.Contains(ownerNoise, "ET"+words[i+1]) {
+			i++
+			continue
+		}
+		if slices.Contains(ownerNoise, word) {
+			continue
+		}
+		if short, ok := ownerSuffixes[word]; ok {
+			word = short
+		}
+		key = append(key, word)
+	}
+	return strings.Join(key, " ")
+}
+
+// resolveOwnerQuery links the owner names of $2 to the owner whose key is $1,
+// or the closest whose key has the same first word and is at least
+// pg_trgm.similarity_threshold similar, creating an owner named $3 when none
+// is. Names already linked keep their owner. Statements of a batch run in
+// order, so variants resolved earlier in the batch are matched too.
+const resolveOwnerQuery = `
+	WITH matched AS (
+		SELECT id FROM owners
+		WHERE owner_key % $1
+			AND split_part(owner_key, ' ', 1) = split_part($1, ' ', 1)
+		ORDER BY owner_key = $1 DESC, similarity(owner_key, $1) DESC, id
+		LIMIT 1
+	), created AS (
+		INSERT INTO owners (name, owner_key)
+		SELECT $3, $1
+		WHERE NOT EXISTS (SELECT 1 FROM matched)
+		ON CONFLICT (owner_key) DO UPDATE SET owner_key = EXCLUDED.owner_key
+		RETURNING id
+	), resolved AS (
+		SELECT id FROM matched
+		UNION ALL
+		SELECT id FROM created
+	)
+	INSERT INTO owner_names (name, owner_id)
+	SELECT name, resolved.id FROM unnest($2::text[]) AS name, resolved
+	ON CONFLICT (name) DO NOTHING
+`
+
+// resolveOwners links the owner names of the county's live parcels that are
+// not linked yet to their owners (see OwnerKey and OwnerSimilarity), and
+// returns how many names were linked. Names are resolved in key order, so a
+// load resolves the same names to the same owners whatever the file order.
+func resolveOwners(ctx context.Context, tx pgx.Tx, countyID int) (int64, error) {
+	unlinked := `
+		SELECT DISTINCT owner_name FROM tax_parcels p
+		WHERE county_id = $1
+			AND owner_name IS NOT NULL
+			AND deleted_at IS NULL
+			AND NOT EXISTS (SELECT 1 FROM owner_names n WHERE n.name = p.owner_name)
+	`
+	rows, err := tx.Query(ctx, unlinked, countyID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query unlinked owner names: %w", err)
+	}
+	names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return 0, fmt.Errorf("failed to read unlinked owner names: %w", err)
+	}
+	if len(names) == 0 {
+		return 0, nil
+	}
+
+	byKey := make(map[string][]string)
+	for _, name := range names {
+		if key := OwnerKey(name); key != "" {
+			byKey[key] = append(byKey[key], name)
+		}
+	}
+	keys := slices.Sorted(maps.Keys(byKey))
+
+	threshold := strconv.FormatFloat(OwnerSimilarity, 'f', -1, 64)
+	if _, err := tx.Exec(ctx, `SELECT set_config('pg_trgm.similarity_threshold', $1, true)`, threshold); err != nil {
+		return 0, fmt.Errorf("failed to set owner similarity threshold: %w", err)
+	}
+
+	var linked int64
+	for chunk := range slices.Chunk(keys, ownerBatchSize) {
+		batch := &pgx.Batch{}
+		for _, key := range chunk {
+			variants := byKey[key]
+			slices.Sort(variants)
+			batch.Queue(resolveOwnerQuery, key, variants, variants[0])
+		}
+
+		results := tx.SendBatch(ctx, batch)
+		for range chunk {
+			tag, err := results.Exec()
+			if err != nil {
+				_ = results.Close()
+				return 0, fmt.Errorf("failed to resolve owners: %w", err)
+			}
+			linked += tag.RowsAffected()
+		}
+		if err := results.Close(); err != nil {
+			return 0, fmt.Errorf("failed to resolve owners: %w", err)
+		}
+	}
+	return linked, nil
+}
@@ -0,0 +1,33 @@
+package ingest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOwnerKey(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Smith, John A.", "SMITH JOHN A"},
+		{"SMITH JOHN A ET AL", "SMITH JOHN A"},
+		{"SMITH JOHN A ETUX", "SMITH JOHN A"},
+		{"Lone Star Holdings, L.L.C.", "LONE STAR HOLDINGS LLC"},
+		{"LONE STAR HOLDINGS L L C", "LONE STAR HOLDINGS LLC"},
+		{"LONE-STAR HOLDINGS  LLC", "LONE STAR HOLDINGS LLC"},
+		{"ACME Incorporated", "ACME INC"},
+		{"DOE JOHN & MARY", "DOE JOHN AND MARY"},
+		{"DOE J M", "DOE J M"},
+		{"O'BRIEN PATRICK TRUSTEE", "OBRIEN PATRICK TR"},
+		{"ET ALIA LAND CO", "ET ALIA LAND CO"},
+		{" -- ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, OwnerKey(tt.name))
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package ingest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// ErrInvalidFeature is returned for features that cannot be loaded. Invalid
+// features are skipped, up to the configured limit.
+var ErrInvalidFeature = errors.New("invalid feature")
+
+// minRingPositions is the smallest closed GeoJSON linear ring.
+const minRingPositions = 4
+
+// Parcel validates the feature and maps it into a TaxParcel.
+// The geometry is always returned as a MultiPolygon; Polygon features are wrapped.
+// Returns ErrInvalidFeature if the geometry is missing or malformed, a required
+// attribute is missing, or an attribute does not fit its column.
+func (m *Mapping) Parcel(feature *Feature) (*models.TaxParcel, error) {
+	geom, err := parseGeometry(feature.Geometry)
+	if err != nil {
+		return nil, fmt.Errorf("%w %d: %w", ErrInvalidFeature, feature.Number, err)
+	}
+
+	values := make(map[string]any, len(columns))
+	for _, col := range columns {
+		value, err := m.value(col, feature.Properties)
+		if err != nil {
+			return nil, fmt.Errorf("%w %d: %s: %w", ErrInvalidFeature, feature.Number, col.name, err)
+		}
+		values[col.name] = value
+	}
+
+	parcel := &models.TaxParcel{
+		Geom:                 *geom,
+		PID:                  intValue(values["pid"]),
+		StateCd:              textValue(values["state_cd"]),
+		Block:                intValue(values["block"]),
+		Lot:                  textValue(values["lot"]),
+		Tract:                textValue(values["tract"]),
+		OwnerName:            textValue(values["owner_name"]),
+		OwnerAddress:         textValue(values["owner_address"]),
+		Situs:                textValue(values["situs"]),
+		AsCode:               textValue(values["as_code"]),
+		LegalDescription:     textValue(values["legal_description"]),
+		ImprvActualYearBuilt: intValue(values["imprv_actual_year_built"]),
+		ImprvMainArea:        intValue(values["imprv_main_area"]),
+		PYear:                intValue(values["p_year"]),
+		PVersion:             intValue(values["p_version"]),
+		PRollCorr:            intValue(values["p_roll_corr"]),
+		TaxingUnits:          textValue(values["taxing_units"]),
+		Exemptions:           textValue(values["exemptions"]),
+		MarketArea:           textValue(values["market_area"]),
+	}
+	// Required columns are never nil
+	parcel.ObjectID = *intValue(values["object_id"])
+	parcel.PIN = *intValue(values["pin"])
+
+	return parcel, nil
+}
+
+// value reads the property mapped to col, converted to *int or *string.
+// Missing properties, JSON nulls and blank strings are NULL.
+func (m *Mapping) value(col column, properties map[string]any) (any, error) {
+	source := m.source(col.name)
+	raw, ok := properties[source]
+	if source == "" || !ok || raw == nil {
+		if col.required {
+			return nil, errors.New("value is required")
+		}
+		return nil, nil
+	}
+
+	switch col.kind {
+	case kindInteger:
+		n, err := parseInteger(raw)
+		if err != nil {
+			return nil, err
+		}
+		if n == nil && col.required {
+			return nil, errors.New("value is required")
+		}
+		return n, nil
+	default:
+		s, err := parseText(raw)
+		if err != nil {
+			return nil, err
+		}
+		if s == nil && col.required {
+			return nil, errors.New("value is required")
+		}
+		if s != nil && col.maxLength > 0 && utf8.RuneCountInString(*s) > col.maxLength {
+			return nil, fmt.Errorf("value exceeds %d characters", col.maxLength)
+		}
+		return s, nil
+	}
+}
+
+// parseInteger accepts JSON numbers with no fractional part and numeric strings,
+// since exports often write IDs as "123" or 123.0. The result must fit an INTEGER column.
+func parseInteger(raw any) (*int, error) {
+	var f float64
+	switch v := raw.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return int32Value(n)
+		}
+		parsed, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", v)
+		}
+		f = parsed
+	case string:
+		trimmed := strings.TrimSpace(v)
+		if trimmed == "" {
+			return nil, nil
+		}
+		if n, err := strconv.ParseInt(trimmed, 10, 64); err == nil {
+			return int32Value(n)
+		}
+		parsed, err := strconv.ParseFloat(trimmed, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected an integer, got %q", v)
+		}
+		f = parsed
+	default:
+		return nil, fmt.Errorf("expected an integer, got %T", raw)
+	}
+
+	if f != math.Trunc(f) || math.IsInf(f, 0) || math.IsNaN(f) {
+		return nil, fmt.Errorf("expected an integer, got %v", f)
+	}
+	return int32Value(int64(f))
+}
+
+// int32Value checks that n fits a PostgreSQL INTEGER column.
+func int32Value(n int64) (*int, error) {
+	if n < math.MinInt32 || n > math.MaxInt32 {
+		return nil, fmt.Errorf("%d is out of range for an integer column", n)
+	}
+	i := int(n)
+	return &i, nil
+}
+
+// parseText accepts strings, trimmed, and numbers in their source form.
+func parseText(raw any) (*string, error) {
+	var s string
+	switch v := raw.(type) {
+	case string:
+		s = strings.TrimSpace(v)
+	case json.Number:
+		s = v.String()
+	case bool:
+		s = strconv.FormatBool(v)
+	default:
+		return nil, fmt.Errorf("expected text, got %T", raw)
+	}
+
+	if s == "" {
+		return nil, nil
+	}
+	return &s, nil
+}
+
+// parseGeometry parses a Polygon or MultiPolygon and checks every ring is closed,
+// has enough positions and lies within WGS84 bounds. Topology (self-intersections)
+// is left to PostGIS and validate-geometries.sh, as with the import script.
+func parseGeometry(raw json.RawMessage) (*models.MultiPolygon, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, errors.New("geometry is missing")
+	}
+
+	geom, err := models.ParseAreaGeoJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+	if len(geom.Coordinates) == 0 {
+		return nil, errors.New("geometry is empty")
+	}
+
+	for p, polygon := range geom.Coordinates {
+		if len(polygon) == 0 {
+			return nil, fmt.Errorf("polygon %d has no rings", p)
+		}
+		for r, ring := range polygon {
+			if len(ring) < minRingPositions {
+				return nil, fmt.Errorf("polygon %d ring %d has %d positions, need at least %d", p, r, len(ring), minRingPositions)
+			}
+			if ring[0] != ring[len(ring)-1] {
+				return nil, fmt.Errorf("polygon %d ring %d is not closed", p, r)
+			}
+			for _, pos := range ring {
+				// GeoJSON positions are [lng, lat]
+				if err := (models.LatLng{Lat: pos[1], Lng: pos[0]}).Validate(); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return geom, nil
+}
+
+// intValue converts a mapped value to *int, nil for NULL.
+func intValue(value any) *int {
+	n, _ := value.(*int)
+	return n
+}
+
+// textValue converts a mapped value to *string, nil for NULL.
+func textValue(value any) *string {
+	s, _ := value.(*string)
+	return s
+}
@@ -0,0 +1,127 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPolygon is a small closed square near Conroe, TX.
+const testPolygon = `{"type":"Polygon","coordinates":[[[-95.451,30.347],[-95.449,30.347],[-95.449,30.3485],[-95.451,30.3485],[-95.451,30.347]]]}`
+
+// testFeature builds a feature with the given properties and geometry.
+func testFeature(t *testing.T, properties, geometry string) *Feature {
+	t.Helper()
+	raw := `{"type":"Feature","properties":` + properties + `,"geometry":` + geometry + `}`
+	feature, err := NewReader(strings.NewReader(`{"features":[` + raw + `]}`)).Next(context.Background())
+	require.NoError(t, err)
+	return feature
+}
+
+func TestMapping_Parcel(t *testing.T) {
+	feature := testFeature(t, `{
+		"OBJECTID": 31045120, "PIN": "12345", "pid": 678.0, "stateCd": "A1", "Block": null,
+		"ownerName": "  SMITH JOHN  ", "situs": "", "imprvActualYearBuilt": 1998, "marketArea": 12
+	}`, testPolygon)
+
+	parcel, err := DefaultMapping().Parcel(feature)
+	require.NoError(t, err)
+
+	assert.Equal(t, 31045120, parcel.ObjectID)
+	assert.Equal(t, 12345, parcel.PIN, "numeric strings are accepted")
+	require.NotNil(t, parcel.PID)
+	assert.Equal(t, 678, *parcel.PID, "integral floats are accepted")
+	assert.Nil(t, parcel.Block, "null is NULL")
+	require.NotNil(t, parcel.OwnerName)
+	assert.Equal(t, "SMITH JOHN", *parcel.OwnerName, "text is trimmed")
+	assert.Nil(t, parcel.Situs, "blank text is NULL")
+	assert.Nil(t, parcel.Lot, "missing properties are NULL")
+	require.NotNil(t, parcel.MarketArea)
+	assert.Equal(t, "12", *parcel.MarketArea, "numbers map to text columns")
+	require.Len(t, parcel.Geom.Coordinates, 1, "polygons are wrapped as multipolygons")
+}
+
+func TestMapping_ParcelInvalid(t *testing.T) {
+	valid := `{"OBJECTID": 1, "PIN": 2}`
+	long := strings.Repeat("x", 11)
+
+	tests := []struct {
+		name       string
+		properties string
+		geometry   string
+	}{
+		{"missing geometry", valid, `null`},
+		{"point geometry", valid, `{"type":"Point","coordinates":[-95.45,30.35]}`},
+		{"open ring", valid, `{"type":"Polygon","coordinates":[[[-95.451,30.347],[-95.449,30.347],[-95.449,30.3485],[-95.451,30.3485]]]}`},
+		{"projected coordinates", valid, `{"type":"Polygon","coordinates":[[[3000000,10000000],[3000100,10000000],[3000100,10000100],[3000000,10000000]]]}`},
+		{"missing object id", `{"PIN": 2}`, testPolygon},
+		{"blank pin", `{"OBJECTID": 1, "PIN": " "}`, testPolygon},
+		{"fractional pin", `{"OBJECTID": 1, "PIN": 2.5}`, testPolygon},
+		{"pin out of range", `{"OBJECTID": 1, "PIN": 3000000000}`, testPolygon},
+		{"text too long", `{"OBJECTID": 1, "PIN": 2, "stateCd": "` + long + `"}`, testPolygon},
+		{"object as text", `{"OBJECTID": 1, "PIN": 2, "ownerName": {"first": "JOHN"}}`, testPolygon},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DefaultMapping().Parcel(testFeature(t, tt.properties, tt.geometry))
+			assert.ErrorIs(t, err, ErrInvalidFeature)
+		})
+	}
+}
+
+func TestLoadMapping(t *testing.T) {
+	// The built-in mapping must stay in sync with the Montgomery mapping file
+	loaded, err := LoadMapping(filepath.Join("..", "..", "..", "scripts", "mappings", "montgomery-tx.json"))
+	require.NoError(t, err)
+	assert.Equal(t, DefaultMapping(), loaded)
+
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, "mapping.json")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	tests := map[string]string{
+		"not json":        `county: x`,
+		"missing county":  `{"field_mappings": {"object_id": "OBJECTID", "pin": "PIN"}}`,
+		"missing name":    `{"county": "x", "state": "TX", "field_mappings": {"object_id": "OBJECTID", "pin": "PIN"}}`,
+		"invalid state":   `{"county": "x", "county_name": "X County", "state": "Texas", "field_mappings": {"object_id": "OBJECTID", "pin": "PIN"}}`,
+		"unmapped pin":    `{"county": "x", "county_name": "X", "state": "TX", "field_mappings": {"object_id": "OBJECTID", "pin": null}}`,
+		"unknown column":  `{"county": "x", "county_name": "X", "state": "TX", "field_mappings": {"object_id": "OBJECTID", "pin": "PIN", "zoning": "ZONE"}}`,
+		"missing mapping": `{"county": "x", "county_name": "X", "state": "TX"}`,
+		"invalid link":    `{"county": "x", "county_name": "X", "state": "TX", "field_mappings": {"object_id": "OBJECTID", "pin": "PIN"}, "document_links": {"deed": "https://clerk.example.gov/{volume}"}}`,
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := LoadMapping(write(content))
+			assert.ErrorIs(t, err, ErrInvalidMapping)
+		})
+	}
+}
+
+func TestMapping_CountyName(t *testing.T) {
+	assert.Equal(t, "Montgomery", DefaultMapping().countyName())
+	assert.Equal(t, "Harris", (&Mapping{CountyName: " Harris County "}).countyName())
+	assert.Equal(t, "Orleans", (&Mapping{CountyName: "Orleans"}).countyName())
+}
+
+func TestParseInteger(t *testing.T) {
+	n, err := parseInteger(json.Number("-42"))
+	require.NoError(t, err)
+	assert.Equal(t, -42, *n)
+
+	n, err = parseInteger(" 7 ")
+	require.NoError(t, err)
+	assert.Equal(t, 7, *n)
+
+	_, err = parseInteger(true)
+	assert.Error(t, err)
+}
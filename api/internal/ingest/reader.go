@@ -0,0 +1,172 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/stwalsh4118/atlas/api/internal/projection"
+)
+
+// ErrUnsupportedCRS is returned when a FeatureCollection declares a CRS other than
+// WGS84 or a projection.SRIDs projection.
+var ErrUnsupportedCRS = errors.New("unsupported CRS, reproject the file to EPSG:4326 first")
+
+// Feature is one GeoJSON feature read from the source file.
+// Number is its 1-based position in the file, used in error messages.
+type Feature struct {
+	Properties map[string]any  `json:"properties"`
+	Geometry   json.RawMessage `json:"geometry"`
+	Number     int             `json:"-"`
+}
+
+// Reader streams features out of a GeoJSON FeatureCollection without holding the
+// whole collection in memory. Features of a collection in a projected CRS
+// (EPSG:2278 or EPSG:3857) are reprojected to WGS84 as they are read.
+type Reader struct {
+	dec     *json.Decoder
+	srid    int
+	read    int
+	started bool
+}
+
+// NewReader creates a Reader over a GeoJSON FeatureCollection.
+// Numbers are kept as json.Number so large integer IDs are not rounded.
+func NewReader(r io.Reader) *Reader {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	return &Reader{dec: dec}
+}
+
+// WithSRID sets the SRID of collections that declare no crs member, which
+// GeoJSON otherwise takes as WGS84. Collections with a crs member keep theirs.
+// Next returns ErrUnsupportedCRS for SRIDs not in projection.SRIDs.
+func (r *Reader) WithSRID(srid int) *Reader {
+	r.srid = srid
+	return r
+}
+
+// Next returns the next feature, or io.EOF after the last one.
+// Members after the features array are not read.
+func (r *Reader) Next(ctx context.Context) (*Feature, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if !r.started {
+		if err := r.seekFeatures(); err != nil {
+			return nil, err
+		}
+		r.started = true
+	}
+
+	if !r.dec.More() {
+		return nil, io.EOF
+	}
+
+	var feature Feature
+	if err := r.dec.Decode(&feature); err != nil {
+		return nil, fmt.Errorf("failed to decode feature %d: %w", r.read+1, err)
+	}
+	r.read++
+	feature.Number = r.read
+
+	if r.srid != 0 && r.srid != projection.WGS84 && len(feature.Geometry) > 0 {
+		geometry, err := projection.Transform(feature.Geometry, r.srid, projection.WGS84)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reproject feature %d: %w", r.read, err)
+		}
+		feature.Geometry = geometry
+	}
+
+	return &feature, nil
+}
+
+// seekFeatures advances the decoder into the features array, checking the
+// collection type and CRS members it passes on the way.
+func (r *Reader) seekFeatures() error {
+	if err := r.expectDelim('{'); err != nil {
+		return err
+	}
+	if r.srid != 0 && !projection.Supported(r.srid) {
+		return fmt.Errorf("%w: EPSG:%d", ErrUnsupportedCRS, r.srid)
+	}
+
+	for r.dec.More() {
+		token, err := r.dec.Token()
+		if err != nil {
+			return fmt.Errorf("failed to read FeatureCollection: %w", err)
+		}
+		key, _ := token.(string)
+
+		switch key {
+		case "features":
+			return r.expectDelim('[')
+		case "type":
+			var kind string
+			if err := r.dec.Decode(&kind); err != nil {
+				return fmt.Errorf("failed to read FeatureCollection type: %w", err)
+			}
+			if kind != "FeatureCollection" {
+				return fmt.Errorf("expected FeatureCollection, got %q", kind)
+			}
+		case "crs":
+			var crs struct {
+				Properties struct {
+					Name string `json:"name"`
+				} `json:"properties"`
+			}
+			if err := r.dec.Decode(&crs); err != nil {
+				return fmt.Errorf("failed to read crs: %w", err)
+			}
+			srid, ok := crsSRID(crs.Properties.Name)
+			if !ok || !projection.Supported(srid) {
+				return fmt.Errorf("%w: %s", ErrUnsupportedCRS, crs.Properties.Name)
+			}
+			r.srid = srid
+		default:
+			// name, bbox and other foreign members are skipped
+			var skip json.RawMessage
+			if err := r.dec.Decode(&skip); err != nil {
+				return fmt.Errorf("failed to read FeatureCollection member %q: %w", key, err)
+			}
+		}
+	}
+
+	return errors.New("FeatureCollection has no features array")
+}
+
+// expectDelim reads the next token and checks that it is the given delimiter.
+func (r *Reader) expectDelim(want json.Delim) error {
+	token, err := r.dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to read GeoJSON: %w", err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("malformed GeoJSON: expected %q, got %v", want, token)
+	}
+	return nil
+}
+
+// crsSRID returns the SRID a legacy GeoJSON crs name denotes, as written by
+// ogr2ogr and ArcGIS exports: "urn:ogc:def:crs:EPSG::2278", "EPSG:2278", or
+// CRS84 (or no name) for WGS84 longitude/latitude. ok is false for other names.
+func crsSRID(name string) (srid int, ok bool) {
+	switch {
+	case name == "", strings.HasSuffix(name, "CRS84"):
+		return projection.WGS84, true
+	}
+
+	_, code, found := strings.Cut(name, "EPSG:")
+	if !found {
+		return 0, false
+	}
+	srid, err := strconv.Atoi(strings.TrimPrefix(code, ":"))
+	if err != nil {
+		return 0, false
+	}
+	return srid, true
+}
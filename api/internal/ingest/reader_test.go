@@ -0,0 +1,117 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader_Next(t *testing.T) {
+	input := `{
+		"type": "FeatureCollection",
+		"name": "parcels",
+		"crs": {"type": "name", "properties": {"name": "urn:ogc:def:crs:OGC:1.3:CRS84"}},
+		"features": [
+			{"type": "Feature", "properties": {"OBJECTID": 31045120, "ownerName": "SMITH JOHN"}, "geometry": null},
+			{"type": "Feature", "properties": {"OBJECTID": 2}, "geometry": {"type": "Polygon", "coordinates": []}}
+		]
+	}`
+	reader := NewReader(strings.NewReader(input))
+
+	first, err := reader.Next(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, first.Number)
+	assert.Equal(t, json.Number("31045120"), first.Properties["OBJECTID"], "numbers keep their exact digits")
+	assert.Equal(t, "SMITH JOHN", first.Properties["ownerName"])
+
+	second, err := reader.Next(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, second.Number)
+	assert.JSONEq(t, `{"type": "Polygon", "coordinates": []}`, string(second.Geometry))
+
+	_, err = reader.Next(context.Background())
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestReader_Errors(t *testing.T) {
+	tests := []struct {
+		wantErr error
+		name    string
+		input   string
+	}{
+		{name: "not an object", input: `[1, 2]`},
+		{name: "wrong type", input: `{"type": "Feature", "features": []}`},
+		{name: "no features", input: `{"type": "FeatureCollection"}`},
+		{name: "truncated", input: `{"type": "FeatureCollection", "features": [{"type": "Feature"`},
+		{
+			name:    "unsupported crs",
+			input:   `{"crs": {"type": "name", "properties": {"name": "urn:ogc:def:crs:EPSG::32614"}}, "features": []}`,
+			wantErr: ErrUnsupportedCRS,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewReader(strings.NewReader(tt.input)).Next(context.Background())
+			require.Error(t, err)
+			assert.NotErrorIs(t, err, io.EOF)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCRSSRID(t *testing.T) {
+	tests := map[string]int{
+		"":                              4326,
+		"urn:ogc:def:crs:OGC:1.3:CRS84": 4326,
+		"urn:ogc:def:crs:EPSG::4326":    4326,
+		"EPSG:4326":                     4326,
+		"urn:ogc:def:crs:EPSG::2278":    2278,
+		"EPSG:3857":                     3857,
+	}
+	for name, want := range tests {
+		srid, ok := crsSRID(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, want, srid, name)
+	}
+
+	for _, name := range []string{"urn:ogc:def:crs:EPSG::", "NAD83 / Texas South Central"} {
+		_, ok := crsSRID(name)
+		assert.False(t, ok, name)
+	}
+}
+
+func TestReader_Reprojects(t *testing.T) {
+	// The same point in EPSG:3857, declared and given with WithSRID
+	point := `{"type": "Feature", "properties": {}, "geometry": {"type": "Point", "coordinates": [5009377.085697312, 5621521.486192066]}}`
+	declared := `{"crs": {"type": "name", "properties": {"name": "urn:ogc:def:crs:EPSG::3857"}}, "features": [` + point + `]}`
+	undeclared := `{"features": [` + point + `]}`
+
+	for name, reader := range map[string]*Reader{
+		"crs member":               NewReader(strings.NewReader(declared)),
+		"WithSRID":                 NewReader(strings.NewReader(undeclared)).WithSRID(3857),
+		"crs member over WithSRID": NewReader(strings.NewReader(declared)).WithSRID(2278),
+	} {
+		t.Run(name, func(t *testing.T) {
+			feature, err := reader.Next(context.Background())
+			require.NoError(t, err)
+
+			var geom struct {
+				Coordinates [2]float64 `json:"coordinates"`
+			}
+			require.NoError(t, json.Unmarshal(feature.Geometry, &geom))
+			assert.InDelta(t, 45, geom.Coordinates[0], 1e-9)
+			assert.InDelta(t, 45, geom.Coordinates[1], 1e-9)
+		})
+	}
+
+	_, err := NewReader(strings.NewReader(undeclared)).WithSRID(32614).Next(context.Background())
+	assert.ErrorIs(t, err, ErrUnsupportedCRS)
+}
@@ -0,0 +1,354 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stwalsh4118/atlas/api/internal/encryption"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+)
+
+// ModeAppraisal names appraisal refreshes in the ingest lock and pg_stat_activity.
+const ModeAppraisal = "appraisal"
+
+// appraisalStagingTable receives the appraisal roll; it is dropped when the transaction ends.
+const appraisalStagingTable = "appraisal_staging"
+
+// ErrUnknownCounty is returned when an appraisal roll is refreshed into a county
+// whose parcels have never been loaded.
+var ErrUnknownCounty = errors.New("county has no parcels loaded")
+
+// RefreshOptions controls an appraisal refresh.
+type RefreshOptions struct {
+	// MaxInvalid is how many invalid records are skipped before the refresh is aborted
+	MaxInvalid int
+	// LockTimeout is how long to wait for a running load of the same county
+	LockTimeout time.Duration
+	// DryRun parses every record without touching the database
+	DryRun bool
+}
+
+// RefreshSummary reports the outcome of an appraisal refresh. Skipped counts
+// records that are not real property or repeat a prop_id; Unmatched counts
+// real property with no live parcel of that pid; Assessments counts the
+// yearly values recorded.
+type RefreshSummary struct {
+	Read        int
+	Invalid     int
+	Skipped     int
+	Assessments int
+	Updated     int64
+	Unchanged   int64
+	Unmatched   int64
+}
+
+// AppraisalSource yields appraisal roll records. AppraisalReader implements it.
+type AppraisalSource interface {
+	// Next returns the next record, or io.EOF after the last one.
+	Next(ctx context.Context) (*AppraisalRecord, error)
+}
+
+// RefreshAppraisal updates the owner and address attributes of the mapping's
+// county from its appraisal roll: owner_name, owner_address, situs,
+// legal_description and p_year of each live parcel are replaced by those of the
+// roll record whose prop_id equals the parcel's pid. Blank roll values keep the
+// parcel's value, and parcels missing from the roll are left alone. Geometry
+// and content_hash are not touched, so the next sync load only overwrites the
+// refreshed attributes of parcels whose GIS record changed. The ingest-stage
+// derived attributes are recomputed afterwards and new owner names resolved to
+// owners (see OwnerKey). The certified values of each record with a year and a
+// non-zero value are recorded in parcel_assessments for that year, replacing
+// those of an earlier refresh with the same year's roll, whether or not a
+// parcel matches yet. The refresh runs in one transaction holding the county's ingest lock.
+// Returns ErrTooManyInvalid, ErrCountyLocked or ErrUnknownCounty.
+func (l *Loader) RefreshAppraisal(ctx context.Context, source AppraisalSource, opts RefreshOptions) (*RefreshSummary, error) {
+	if opts.LockTimeout < 0 {
+		return nil, fmt.Errorf("invalid lock timeout %s, must not be negative", opts.LockTimeout)
+	}
+
+	rows := &appraisalRows{ctx: ctx, source: source, log: l.log, keyring: l.keyring, maxInvalid: opts.MaxInvalid}
+	if opts.DryRun {
+		for rows.Next() {
+			// Parsing and validation happen in Next
+		}
+		return rows.summary(), rows.Err()
+	}
+
+	tx, err := l.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		// No-op once committed
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err := l.lockCounty(ctx, tx, ModeAppraisal, opts.LockTimeout); err != nil {
+		return nil, err
+	}
+
+	var countyID int
+	err = tx.QueryRow(ctx, `SELECT id FROM counties WHERE slug = $1`, l.mapping.County).Scan(&countyID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCounty, l.mapping.County)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up county %s: %w", l.mapping.County, err)
+	}
+
+	createStaging := `
+		CREATE TEMP TABLE ` + appraisalStagingTable + ` (
+			prop_id INTEGER PRIMARY KEY,
+			owner_name VARCHAR(500),
+			owner_address TEXT,
+			situs VARCHAR(500),
+			legal_description TEXT,
+			p_year INTEGER,
+			land_value BIGINT,
+			improvement_value BIGINT,
+			ag_use_value BIGINT,
+			market_value BIGINT,
+			appraised_value BIGINT,
+			assessed_value BIGINT
+		) ON COMMIT DROP
+	`
+	if _, err := tx.Exec(ctx, createStaging); err != nil {
+		return nil, fmt.Errorf("failed to create appraisal staging table: %w", err)
+	}
+
+	copied, err := tx.CopyFrom(ctx, pgx.Identifier{appraisalStagingTable},
+		[]string{"prop_id", "owner_name", "owner_address", "situs", "legal_description", "p_year",
+			"land_value", "improvement_value", "ag_use_value", "market_value", "appraised_value", "assessed_value"}, rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy appraisal roll into staging table: %w", err)
+	}
+	l.log.Info("Appraisal roll staged", map[string]interface{}{
+		"staged":  copied,
+		"invalid": rows.invalid,
+		"skipped": rows.skipped,
+	})
+
+	summary := rows.summary()
+
+	// Rows whose values would not change are not written, so updated_at keeps the last change
+	update := `
+		WITH refreshed AS (
+			SELECT p.id,
+				COALESCE(s.owner_name, p.owner_name) AS owner_name,
+				COALESCE(s.owner_address, p.owner_address) AS owner_address,
+				COALESCE(s.situs, p.situs) AS situs,
+				COALESCE(s.legal_description, p.legal_description) AS legal_description,
+				COALESCE(s.p_year, p.p_year) AS p_year
+			FROM tax_parcels p
+			JOIN ` + appraisalStagingTable + ` s ON s.prop_id = p.pid
+			WHERE p.county_id = $1
+				AND p.deleted_at IS NULL
+		)
+		UPDATE tax_parcels p SET
+			owner_name = r.owner_name,
+			owner_address = r.owner_address,
+			situs = r.situs,
+			legal_description = r.legal_description,
+			p_year = r.p_year,
+			updated_at = NOW()
+		FROM refreshed r
+		WHERE p.id = r.id
+			AND (p.owner_name, p.owner_address, p.situs, p.legal_description, p.p_year)
+				IS DISTINCT FROM (r.owner_name, r.owner_address, r.situs, r.legal_description, r.p_year)
+	`
+	tag, err := tx.Exec(ctx, update, countyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh parcel attributes: %w", err)
+	}
+	summary.Updated = tag.RowsAffected()
+
+	matched := `
+		SELECT COUNT(*) FROM tax_parcels p
+		JOIN ` + appraisalStagingTable + ` s ON s.prop_id = p.pid
+		WHERE p.county_id = $1
+			AND p.deleted_at IS NULL
+	`
+	var parcels int64
+	if err := tx.QueryRow(ctx, matched, countyID).Scan(&parcels); err != nil {
+		return nil, fmt.Errorf("failed to count refreshed parcels: %w", err)
+	}
+	summary.Unchanged = parcels - summary.Updated
+
+	unmatched := `
+		SELECT COUNT(*) FROM ` + appraisalStagingTable + ` s
+		WHERE NOT EXISTS (
+			SELECT 1 FROM tax_parcels p
+			WHERE p.county_id = $1
+				AND p.pid = s.prop_id
+				AND p.deleted_at IS NULL
+		)
+	`
+	if err := tx.QueryRow(ctx, unmatched, countyID).Scan(&summary.Unmatched); err != nil {
+		return nil, fmt.Errorf("failed to count unmatched appraisal records: %w", err)
+	}
+
+	// Staged values are NULL for records without a year or values
+	assessments := `
+		INSERT INTO parcel_assessments (county_id, prop_id, tax_year, land_value, improvement_value,
+			ag_use_value, market_value, appraised_value, assessed_value)
+		SELECT $1, prop_id, p_year, land_value, improvement_value,
+			ag_use_value, market_value, appraised_value, assessed_value
+		FROM ` + appraisalStagingTable + `
+		WHERE market_value IS NOT NULL
+		ON CONFLICT (county_id, prop_id, tax_year) DO UPDATE SET
+			land_value = EXCLUDED.land_value,
+			improvement_value = EXCLUDED.improvement_value,
+			ag_use_value = EXCLUDED.ag_use_value,
+			market_value = EXCLUDED.market_value,
+			appraised_value = EXCLUDED.appraised_value,
+			assessed_value = EXCLUDED.assessed_value,
+			updated_at = NOW()
+	`
+	if _, err := tx.Exec(ctx, assessments, countyID); err != nil {
+		return nil, fmt.Errorf("failed to record parcel assessments: %w", err)
+	}
+
+	// Derived attributes may read the refreshed columns
+	if _, err := l.updateDerived(ctx, tx, countyID); err != nil {
+		return nil, err
+	}
+	if _, err := resolveOwners(ctx, tx, countyID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit appraisal refresh: %w", err)
+	}
+	return summary, nil
+}
+
+// appraisalRows adapts an appraisal record source to pgx.CopyFromSource,
+// skipping invalid records, property other than real property and repeated prop_ids.
+type appraisalRows struct {
+	ctx        context.Context
+	source     AppraisalSource
+	log        *logger.Logger
+	keyring    *encryption.Keyring
+	err        error
+	row        []any
+	seen       map[int]bool
+	read       int
+	invalid    int
+	skipped    int
+	assessed   int
+	maxInvalid int
+}
+
+// Next reads records until a usable one is found, the roll ends or the refresh must stop.
+func (s *appraisalRows) Next() bool {
+	if s.seen == nil {
+		s.seen = make(map[int]bool)
+	}
+
+	for {
+		record, err := s.source.Next(s.ctx)
+		if errors.Is(err, io.EOF) {
+			return false
+		}
+		if err != nil && !errors.Is(err, ErrInvalidAppraisalRecord) {
+			s.err = err
+			return false
+		}
+		s.read++
+
+		if err == nil {
+			err = checkAppraisalRecord(record)
+		}
+		if err != nil {
+			s.invalid++
+			s.log.Warn("Skipping invalid appraisal record", map[string]interface{}{
+				"error": err.Error(),
+			})
+			if s.invalid > s.maxInvalid {
+				s.err = fmt.Errorf("%w: more than %d", ErrTooManyInvalid, s.maxInvalid)
+				return false
+			}
+			continue
+		}
+
+		// Rolls list personal and mineral accounts too, and one row per owner of
+		// partially owned property; the first owner is kept
+		if !record.RealProperty() {
+			s.skipped++
+			continue
+		}
+		if s.seen[record.PropID] {
+			s.skipped++
+			continue
+		}
+		s.seen[record.PropID] = true
+
+		ownerAddress, err := s.keyring.EncryptText(nullIfEmpty(record.Owner.Address))
+		if err != nil {
+			s.err = err
+			return false
+		}
+
+		s.row = []any{
+			record.PropID,
+			nullIfEmpty(record.Owner.Name),
+			ownerAddress,
+			nullIfEmpty(record.Situs),
+			nullIfEmpty(record.LegalDescription),
+			nullIfZero(record.Year),
+			nil, nil, nil, nil, nil, nil,
+		}
+		// Rolls without value columns parse as all zero, which is not an assessment
+		if values := record.Values; record.Year > 0 && (values.Market > 0 || values.Appraised > 0 || values.Assessed > 0) {
+			s.assessed++
+			s.row = append(s.row[:6], values.Land, values.Improvement, values.AgUse, values.Market, values.Appraised, values.Assessed)
+		}
+		return true
+	}
+}
+
+// Values returns the current row.
+func (s *appraisalRows) Values() ([]any, error) {
+	return s.row, nil
+}
+
+// Err returns the error that stopped the source, if any.
+func (s *appraisalRows) Err() error {
+	return s.err
+}
+
+// summary returns the counts so far.
+func (s *appraisalRows) summary() *RefreshSummary {
+	return &RefreshSummary{Read: s.read, Invalid: s.invalid, Skipped: s.skipped, Assessments: s.assessed}
+}
+
+// checkAppraisalRecord checks that the refreshed values fit their tax_parcels columns.
+func checkAppraisalRecord(record *AppraisalRecord) error {
+	for name, value := range map[string]string{"owner_name": record.Owner.Name, "situs": record.Situs} {
+		if utf8.RuneCountInString(value) > 500 {
+			return fmt.Errorf("%w %d: %s exceeds 500 characters", ErrInvalidAppraisalRecord, record.Line, name)
+		}
+	}
+	return nil
+}
+
+// nullIfEmpty returns nil for an empty string, so COPY writes NULL.
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// nullIfZero returns nil for zero, so COPY writes NULL.
+func nullIfZero(n int) *int {
+	if n == 0 {
+		return nil
+	}
+	return &n
+}
@@ -0,0 +1,431 @@
+package ingest
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+)
+
+// ModeSales names sales loads in the ingest lock and pg_stat_activity.
+const ModeSales = "sales"
+
+// ErrInvalidSaleRecord is returned for sales export records that cannot be
+// parsed. Invalid records are skipped, up to the configured limit.
+var ErrInvalidSaleRecord = errors.New("invalid sale record")
+
+// salesStagingTable receives the sales export; it is dropped when the transaction ends.
+const salesStagingTable = "sales_staging"
+
+// Sales export column names, matched case-insensitively against the header row.
+// Deed and sales exports of appraisal districts and county clerks name their
+// columns differently, so exports are usually renamed to these first.
+const (
+	saleFieldPropID     = "prop_id"
+	saleFieldDate       = "sale_date"
+	saleFieldPrice      = "sale_price"
+	saleFieldDeedType   = "deed_type"
+	saleFieldGrantor    = "grantor"
+	saleFieldGrantee    = "grantee"
+	saleFieldInstrument = "instrument"
+)
+
+// saleFields lists the columns a sales export may have.
+var saleFields = []string{
+	saleFieldPropID, saleFieldDate, saleFieldPrice, saleFieldDeedType,
+	saleFieldGrantor, saleFieldGrantee, saleFieldInstrument,
+}
+
+// Sizes of the parcel_sales text columns
+const (
+	maxDeedTypeLength   = 50
+	maxPartyLength      = 500
+	maxInstrumentLength = 100
+)
+
+// saleDateLayouts are the date formats accepted in sale_date; a time after the
+// date is ignored.
+var saleDateLayouts = []string{time.DateOnly, "1/2/2006", "20060102"}
+
+// minSaleYear rejects dates mistyped or defaulted to the epoch of another system.
+const minSaleYear = 1800
+
+// SalesOptions controls a sales load.
+type SalesOptions struct {
+	// MaxInvalid is how many invalid records are skipped before the load is aborted
+	MaxInvalid int
+	// LockTimeout is how long to wait for a running load of the same county
+	LockTimeout time.Duration
+	// DryRun validates every record without touching the database
+	DryRun bool
+}
+
+// SalesSummary reports the outcome of a sales load. Unmatched counts the
+// loaded sales with no live parcel of that pid; they are kept for later loads.
+type SalesSummary struct {
+	Read      int
+	Invalid   int
+	Loaded    int64
+	Unmatched int64
+}
+
+// SaleRecord is one sale (deed transfer) of a property. Price is 0 where the
+// sale price is not disclosed. Line is its 1-based line in the file.
+type SaleRecord struct {
+	Date       time.Time
+	DeedType   string
+	Grantor    string
+	Grantee    string
+	Instrument string
+	Price      int64
+	PropID     int
+	Line       int
+}
+
+// SalesSource yields sales records. SalesReader implements it.
+type SalesSource interface {
+	// Next returns the next record, or io.EOF after the last one.
+	Next(ctx context.Context) (*SaleRecord, error)
+}
+
+// LoadSales replaces the sales history of the mapping's county with the sales
+// of a deed or sales export. Sales are keyed by prop_id, which is matched to
+// the pid of parcels when they are read, so they survive parcel reloads. The
+// county's ingest-stage derived attributes are recomputed, since they may read
+// the sales.
+// Returns ErrTooManyInvalid, ErrCountyLocked or ErrUnknownCounty.
+func (l *Loader) LoadSales(ctx context.Context, source SalesSource, opts SalesOptions) (*SalesSummary, error) {
+	if opts.LockTimeout < 0 {
+		return nil, fmt.Errorf("invalid lock timeout %s, must not be negative", opts.LockTimeout)
+	}
+
+	rows := &salesRows{ctx: ctx, source: source, log: l.log, maxInvalid: opts.MaxInvalid}
+	if opts.DryRun {
+		for rows.Next() {
+			// Parsing and validation happen in Next
+		}
+		return rows.summary(), rows.Err()
+	}
+
+	tx, err := l.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		// No-op once committed
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err := l.lockCounty(ctx, tx, ModeSales, opts.LockTimeout); err != nil {
+		return nil, err
+	}
+
+	var countyID int
+	err = tx.QueryRow(ctx, `SELECT id FROM counties WHERE slug = $1`, l.mapping.County).Scan(&countyID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCounty, l.mapping.County)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up county %s: %w", l.mapping.County, err)
+	}
+
+	createStaging := `
+		CREATE TEMP TABLE ` + salesStagingTable + ` (
+			prop_id INTEGER NOT NULL,
+			sale_date DATE NOT NULL,
+			price BIGINT,
+			deed_type VARCHAR(50),
+			grantor VARCHAR(500),
+			grantee VARCHAR(500),
+			instrument VARCHAR(100)
+		) ON COMMIT DROP
+	`
+	if _, err := tx.Exec(ctx, createStaging); err != nil {
+		return nil, fmt.Errorf("failed to create sales staging table: %w", err)
+	}
+
+	copied, err := tx.CopyFrom(ctx, pgx.Identifier{salesStagingTable},
+		[]string{"prop_id", "sale_date", "price", "deed_type", "grantor", "grantee", "instrument"}, rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy sales into staging table: %w", err)
+	}
+	l.log.Info("Sales staged", map[string]interface{}{
+		"staged":  copied,
+		"invalid": rows.invalid,
+	})
+
+	if _, err := tx.Exec(ctx, `DELETE FROM parcel_sales WHERE county_id = $1`, countyID); err != nil {
+		return nil, fmt.Errorf("failed to clear sales of county %s: %w", l.mapping.County, err)
+	}
+
+	insert := `
+		INSERT INTO parcel_sales (county_id, prop_id, sale_date, price, deed_type, grantor, grantee, instrument)
+		SELECT $1, prop_id, sale_date, price, deed_type, grantor, grantee, instrument
+		FROM ` + salesStagingTable
+	summary := rows.summary()
+	tag, err := tx.Exec(ctx, insert, countyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert sales: %w", err)
+	}
+	summary.Loaded = tag.RowsAffected()
+
+	unmatched := `
+		SELECT COUNT(*) FROM ` + salesStagingTable + ` s
+		WHERE NOT EXISTS (
+			SELECT 1 FROM tax_parcels p
+			WHERE p.county_id = $1
+				AND p.pid = s.prop_id
+				AND p.deleted_at IS NULL
+		)
+	`
+	if err := tx.QueryRow(ctx, unmatched, countyID).Scan(&summary.Unmatched); err != nil {
+		return nil, fmt.Errorf("failed to count unmatched sales: %w", err)
+	}
+
+	// Derived attributes may read the sales of a parcel
+	if _, err := l.updateDerived(ctx, tx, countyID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit sales load: %w", err)
+	}
+	return summary, nil
+}
+
+// salesRows adapts a sales source to pgx.CopyFromSource, skipping invalid records.
+type salesRows struct {
+	ctx        context.Context
+	source     SalesSource
+	log        *logger.Logger
+	err        error
+	row        []any
+	read       int
+	invalid    int
+	maxInvalid int
+}
+
+// Next reads records until a valid one is found, the export ends or loading must stop.
+func (s *salesRows) Next() bool {
+	for {
+		record, err := s.source.Next(s.ctx)
+		if errors.Is(err, io.EOF) {
+			return false
+		}
+		if err != nil && !errors.Is(err, ErrInvalidSaleRecord) {
+			s.err = err
+			return false
+		}
+		s.read++
+
+		if err == nil {
+			err = checkSaleRecord(record)
+		}
+		if err != nil {
+			s.invalid++
+			s.log.Warn("Skipping invalid sale record", map[string]interface{}{
+				"error": err.Error(),
+			})
+			if s.invalid > s.maxInvalid {
+				s.err = fmt.Errorf("%w: more than %d", ErrTooManyInvalid, s.maxInvalid)
+				return false
+			}
+			continue
+		}
+
+		var price *int64
+		if record.Price > 0 {
+			price = &record.Price
+		}
+		s.row = []any{
+			record.PropID,
+			record.Date,
+			price,
+			nullIfEmpty(record.DeedType),
+			nullIfEmpty(record.Grantor),
+			nullIfEmpty(record.Grantee),
+			nullIfEmpty(record.Instrument),
+		}
+		return true
+	}
+}
+
+// Values returns the current row.
+func (s *salesRows) Values() ([]any, error) {
+	return s.row, nil
+}
+
+// Err returns the error that stopped the source, if any.
+func (s *salesRows) Err() error {
+	return s.err
+}
+
+// summary returns the counts so far.
+func (s *salesRows) summary() *SalesSummary {
+	return &SalesSummary{Read: s.read, Invalid: s.invalid}
+}
+
+// checkSaleRecord checks that the sale's text values fit their parcel_sales columns.
+func checkSaleRecord(record *SaleRecord) error {
+	for _, field := range []struct {
+		name  string
+		value string
+		max   int
+	}{
+		{saleFieldDeedType, record.DeedType, maxDeedTypeLength},
+		{saleFieldGrantor, record.Grantor, maxPartyLength},
+		{saleFieldGrantee, record.Grantee, maxPartyLength},
+		{saleFieldInstrument, record.Instrument, maxInstrumentLength},
+	} {
+		if utf8.RuneCountInString(field.value) > field.max {
+			return fmt.Errorf("%w %d: %s exceeds %d characters", ErrInvalidSaleRecord, record.Line, field.name, field.max)
+		}
+	}
+	return nil
+}
+
+// SalesReader streams records out of a delimited sales export with a header
+// row of column names.
+type SalesReader struct {
+	csv    *csv.Reader
+	header map[string]int
+	line   int
+}
+
+// NewSalesReader creates a reader over a delimited sales export, e.g. a CSV
+// (',') or pipe-delimited ('|') file. The header row names the columns,
+// case-insensitively: prop_id and sale_date are required, sale_price,
+// deed_type, grantor, grantee and instrument are optional and other columns
+// are ignored.
+func NewSalesReader(r io.Reader, delimiter rune) *SalesReader {
+	reader := csv.NewReader(r)
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.ReuseRecord = true
+	return &SalesReader{csv: reader}
+}
+
+// Next returns the next record, or io.EOF after the last one. Records that
+// cannot be parsed return ErrInvalidSaleRecord; reading can continue after them.
+// Blank lines are skipped.
+func (r *SalesReader) Next(ctx context.Context) (*SaleRecord, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if r.header == nil {
+		if err := r.readHeader(); err != nil {
+			return nil, err
+		}
+	}
+	for {
+		record, err := r.csv.Read()
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			r.line = parseErr.StartLine
+			return nil, fmt.Errorf("%w %d: %w", ErrInvalidSaleRecord, r.line, parseErr.Err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sales export: %w", err)
+		}
+		r.line, _ = r.csv.FieldPos(0)
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+
+		fields := make(map[string]string, len(r.header))
+		for name, index := range r.header {
+			if index < len(record) {
+				fields[name] = record[index]
+			}
+		}
+		return parseSaleRecord(fields, r.line)
+	}
+}
+
+// readHeader maps the known column names of the header row to column indexes.
+func (r *SalesReader) readHeader() error {
+	header, err := r.csv.Read()
+	if errors.Is(err, io.EOF) {
+		return io.EOF
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read sales export header: %w", err)
+	}
+
+	r.header = make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if slices.Contains(saleFields, name) {
+			r.header[name] = i
+		}
+	}
+	for _, required := range []string{saleFieldPropID, saleFieldDate} {
+		if _, ok := r.header[required]; !ok {
+			return fmt.Errorf("sales export header has no %s column", required)
+		}
+	}
+	return nil
+}
+
+// parseSaleRecord converts raw column values into a record.
+func parseSaleRecord(fields map[string]string, line int) (*SaleRecord, error) {
+	p := &appraisalParser{fields: fields}
+
+	record := &SaleRecord{
+		Line:       line,
+		PropID:     p.integer(saleFieldPropID),
+		Price:      p.dollars(saleFieldPrice),
+		DeedType:   strings.ToUpper(p.text(saleFieldDeedType)),
+		Grantor:    p.text(saleFieldGrantor),
+		Grantee:    p.text(saleFieldGrantee),
+		Instrument: p.text(saleFieldInstrument),
+	}
+	if p.err != nil {
+		return nil, fmt.Errorf("%w %d: %w", ErrInvalidSaleRecord, line, p.err)
+	}
+	if record.PropID <= 0 {
+		return nil, fmt.Errorf("%w %d: %s is required", ErrInvalidSaleRecord, line, saleFieldPropID)
+	}
+
+	date, err := parseSaleDate(p.text(saleFieldDate))
+	if err != nil {
+		return nil, fmt.Errorf("%w %d: %s: %w", ErrInvalidSaleRecord, line, saleFieldDate, err)
+	}
+	record.Date = date
+	return record, nil
+}
+
+// parseSaleDate parses a date in one of saleDateLayouts, ignoring a time of
+// day after it. Dates before minSaleYear or after today (UTC) are rejected.
+func parseSaleDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, errors.New("is required")
+	}
+	day, _, _ := strings.Cut(value, " ")
+	day, _, _ = strings.Cut(day, "T")
+
+	for _, layout := range saleDateLayouts {
+		date, err := time.Parse(layout, day)
+		if err != nil {
+			continue
+		}
+		if date.Year() < minSaleYear || date.After(time.Now().UTC()) {
+			return time.Time{}, fmt.Errorf("%s is out of range", day)
+		}
+		return date, nil
+	}
+	return time.Time{}, fmt.Errorf("expected YYYY-MM-DD, M/D/YYYY or YYYYMMDD, got %q", value)
+}
@@ -0,0 +1,95 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+)
+
+// testSales has a full sale, an undisclosed price with a M/D/YYYY date and
+// extra columns, a missing prop_id, a bad date and a future date.
+const testSales = "Prop_ID,Sale_Date,Sale_Price,Deed_Type,Grantor,Grantee,Instrument,Notes\n" +
+	"12345,2019-06-14 00:00:00,\"$385,000.00\",wd,DOE JANE,SMITH JOHN,2019055123,\n" +
+	"12345,3/2/2008,,SWD,BUILDER LLC,DOE JANE,,new construction\n" +
+	"\n" +
+	",2010-01-01,100000,WD,A,B,,\n" +
+	"23456,2010-13-01,100000,WD,A,B,,\n" +
+	"23456,2999-01-01,100000,WD,A,B,,\n"
+
+func readSales(t *testing.T, export string) ([]*SaleRecord, []error) {
+	t.Helper()
+	reader := NewSalesReader(strings.NewReader(export), ',')
+
+	var records []*SaleRecord
+	var errs []error
+	for {
+		record, err := reader.Next(context.Background())
+		if errors.Is(err, io.EOF) {
+			return records, errs
+		}
+		if err != nil {
+			require.ErrorIs(t, err, ErrInvalidSaleRecord)
+			errs = append(errs, err)
+			continue
+		}
+		records = append(records, record)
+	}
+}
+
+func TestSalesReader(t *testing.T) {
+	records, errs := readSales(t, testSales)
+
+	require.Len(t, records, 2)
+	assert.Equal(t, &SaleRecord{
+		Date:       time.Date(2019, 6, 14, 0, 0, 0, 0, time.UTC),
+		DeedType:   "WD",
+		Grantor:    "DOE JANE",
+		Grantee:    "SMITH JOHN",
+		Instrument: "2019055123",
+		Price:      385000,
+		PropID:     12345,
+		Line:       2,
+	}, records[0])
+	assert.Equal(t, time.Date(2008, 3, 2, 0, 0, 0, 0, time.UTC), records[1].Date)
+	assert.Zero(t, records[1].Price)
+	assert.Equal(t, "BUILDER LLC", records[1].Grantor)
+
+	require.Len(t, errs, 3)
+	assert.ErrorContains(t, errs[0], "prop_id is required")
+	assert.ErrorContains(t, errs[1], "expected YYYY-MM-DD")
+	assert.ErrorContains(t, errs[2], "out of range")
+}
+
+func TestSalesReader_MissingColumn(t *testing.T) {
+	reader := NewSalesReader(strings.NewReader("prop_id|price\n12345|100000\n"), '|')
+
+	_, err := reader.Next(context.Background())
+
+	assert.ErrorContains(t, err, "no sale_date column")
+}
+
+func TestLoader_SalesDryRun(t *testing.T) {
+	loader := NewLoader(nil, DefaultMapping(), logger.New("test"))
+	export := testSales + "34567,2020-01-01,1,WD," + strings.Repeat("x", maxPartyLength+1) + ",B,,\n"
+
+	summary, err := loader.LoadSales(context.Background(), NewSalesReader(strings.NewReader(export), ','), SalesOptions{
+		MaxInvalid: 4,
+		DryRun:     true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, &SalesSummary{Read: 6, Invalid: 4}, summary)
+
+	_, err = loader.LoadSales(context.Background(), NewSalesReader(strings.NewReader(export), ','), SalesOptions{
+		MaxInvalid: 3,
+		DryRun:     true,
+	})
+	assert.ErrorIs(t, err, ErrTooManyInvalid)
+}
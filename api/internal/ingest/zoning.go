@@ -0,0 +1,81 @@
+package ingest
+
+import (
+	"context"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// ModeZoning names zoning loads in the ingest lock and pg_stat_activity.
+const ModeZoning = "zoning"
+
+// Zoning attribute defaults. County and city zoning layers name their
+// attributes differently, so most loads set the fields explicitly.
+const (
+	// DefaultZoningCodeField is the attribute holding a district's zoning designation
+	DefaultZoningCodeField = "ZONING"
+	// DefaultZoningNameField is the attribute describing the designation
+	DefaultZoningNameField = "ZONE_DESC"
+)
+
+// maxZoningCodeLength is the longest zoning code a load accepts.
+const maxZoningCodeLength = 50
+
+// ZoningOptions controls a zoning load.
+type ZoningOptions struct {
+	// CodeField is the feature property holding the zoning designation; DefaultZoningCodeField if empty
+	CodeField string
+	// NameField is the feature property describing the designation; DefaultZoningNameField if empty
+	NameField string
+	// MaxInvalid is how many invalid features are skipped before the load is aborted
+	MaxInvalid int
+	// LockTimeout is how long to wait for a running load of the same county
+	LockTimeout time.Duration
+	// DryRun validates every feature without touching the database
+	DryRun bool
+}
+
+// LoadZoning replaces the features of the zoning layer (models.LayerZoning) in
+// the mapping's county with the districts from source. Features must be
+// polygons in WGS84 with a zoning code; others are skipped as invalid. Nothing
+// is precomputed for parcels: they are joined to the districts they intersect
+// per request, so zoning follows the new layer as soon as the load commits. The
+// county's ingest-stage derived attributes are recomputed, since they may read
+// the layer.
+// Returns ErrTooManyInvalid, ErrCountyLocked or ErrUnknownCounty.
+func (l *Loader) LoadZoning(ctx context.Context, source Source, opts ZoningOptions) (*LayerSummary, error) {
+	if opts.CodeField == "" {
+		opts.CodeField = DefaultZoningCodeField
+	}
+	if opts.NameField == "" {
+		opts.NameField = DefaultZoningNameField
+	}
+
+	return l.loadLayer(ctx, source, layerLoad{
+		mode:  ModeZoning,
+		name:  models.LayerZoning,
+		title: "Zoning districts",
+		properties: func(feature *Feature) ([]byte, error) {
+			// A district without a designation says nothing about the parcels it covers
+			code, err := featureText(feature, opts.CodeField, maxZoningCodeLength, true)
+			if err != nil {
+				return nil, err
+			}
+			name, err := featureText(feature, opts.NameField, maxFeatureNameLength, false)
+			if err != nil {
+				return nil, err
+			}
+			properties := map[string]any{"code": *code}
+			if name != nil {
+				properties["name"] = *name
+			}
+			return encodeProperties(properties)
+		},
+		checkGeometry: checkAreaGeometry,
+		polygons:      true,
+		maxInvalid:    opts.MaxInvalid,
+		lockTimeout:   opts.LockTimeout,
+		dryRun:        opts.DryRun,
+	})
+}
@@ -0,0 +1,36 @@
+package ingest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+)
+
+// testZoning has a district, a numeric code without a description, a district
+// without a code, a line and a code too long for the column.
+var testZoning = `{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"ZONING": "R-1", "ZONE_DESC": "Single Family Residential"}, "geometry": ` + testPolygon + `},
+	{"type": "Feature", "properties": {"ZONING": 12}, "geometry": ` + testPolygon + `},
+	{"type": "Feature", "properties": {"ZONING": " ", "ZONE_DESC": "Unzoned"}, "geometry": ` + testPolygon + `},
+	{"type": "Feature", "properties": {"ZONING": "C-2"}, "geometry": {"type": "LineString", "coordinates": [[-95.5, 30.3], [-95.4, 30.2]]}},
+	{"type": "Feature", "properties": {"ZONING": "` + strings.Repeat("x", maxZoningCodeLength+1) + `"}, "geometry": ` + testPolygon + `}
+]}`
+
+func TestLoader_ZoningCustomFields(t *testing.T) {
+	loader := NewLoader(nil, DefaultMapping(), logger.New("test"))
+	layer := `{"type": "FeatureCollection", "features": [
+		{"type": "Feature", "properties": {"ZONE_CODE": "PUD"}, "geometry": ` + testPolygon + `}
+	]}`
+
+	summary, err := loader.LoadZoning(context.Background(), NewReader(strings.NewReader(layer)), ZoningOptions{
+		CodeField: "ZONE_CODE",
+		DryRun:    true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, &LayerSummary{Read: 1}, summary)
+}
@@ -0,0 +1,252 @@
+// Package jobs runs long requests, such as bundle generation, in the background.
+// Clients submit work, receive a job ID right away, and poll the job until its
+// result is ready. Jobs and their results are held in memory for a retention period
+// and are lost on restart, so results must be reproducible by resubmitting.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+)
+
+// pruneInterval is how often finished jobs are checked against the retention period.
+const pruneInterval = time.Minute
+
+// Errors returned by the manager
+var (
+	ErrQueueFull   = domain.Unavailable("job queue is full").WithRetryAfter(30 * time.Second)
+	ErrJobNotFound = domain.NotFound("job not found")
+)
+
+// Status is the lifecycle state of a job.
+type Status string
+
+// Job statuses; succeeded and failed are final.
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+// Result is the downloadable output of a successful job.
+type Result struct {
+	ContentType string
+	Filename    string
+	Data        []byte
+}
+
+// Func performs the work of a job. ctx is cancelled when the job times out or the
+// manager shuts down.
+type Func func(ctx context.Context) (*Result, error)
+
+// Job is a snapshot of a submitted job.
+// Result is set once the job succeeded; Error holds the failure message.
+type Job struct {
+	CreatedAt  time.Time
+	FinishedAt *time.Time
+	Result     *Result
+	ID         string
+	Kind       string
+	Status     Status
+	Error      string
+}
+
+// Manager queues jobs and runs them on a fixed number of workers.
+type Manager struct {
+	jobs      map[string]*Job
+	queue     chan queuedJob
+	log       *logger.Logger
+	finished  []func(Job)
+	timeout   time.Duration
+	retention time.Duration
+	mu        sync.Mutex
+}
+
+// queuedJob pairs a job ID with its work.
+type queuedJob struct {
+	fn Func
+	id string
+}
+
+// NewManager creates a manager holding up to queueSize waiting jobs. Each job may run
+// for at most timeout, and finished jobs are kept for retention before being dropped.
+func NewManager(queueSize int, timeout, retention time.Duration, log *logger.Logger) *Manager {
+	return &Manager{
+		jobs:      make(map[string]*Job),
+		queue:     make(chan queuedJob, queueSize),
+		log:       log,
+		timeout:   timeout,
+		retention: retention,
+	}
+}
+
+// Submit queues fn as a job of the given kind and returns the queued job.
+// Returns ErrQueueFull if the queue has no room; the job is not recorded.
+func (m *Manager) Submit(kind string, fn Func) (Job, error) {
+	job := &Job{
+		ID:        uuid.NewString(),
+		Kind:      kind,
+		Status:    StatusQueued,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	select {
+	case m.queue <- queuedJob{id: job.ID, fn: fn}:
+	default:
+		return Job{}, ErrQueueFull
+	}
+	m.jobs[job.ID] = job
+
+	return *job, nil
+}
+
+// OnFinish registers fn to be called with every job once it has succeeded or
+// failed. It is called on the worker that ran the job, so it should return
+// quickly. Register listeners before Run.
+func (m *Manager) OnFinish(fn func(Job)) {
+	m.finished = append(m.finished, fn)
+}
+
+// Get returns a snapshot of the job with the given ID.
+// Returns ErrJobNotFound if the ID is unknown or the job was pruned.
+func (m *Manager) Get(id string) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.jobs[id]
+	if !ok {
+		return Job{}, ErrJobNotFound
+	}
+	return *job, nil
+}
+
+// Run starts workers goroutines that execute queued jobs and prunes finished jobs,
+// blocking until ctx is cancelled; run it in its own goroutine.
+// Jobs still queued at shutdown are dropped.
+func (m *Manager) Run(ctx context.Context, workers int) {
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.work(ctx)
+		}()
+	}
+
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case now := <-ticker.C:
+			m.prune(now)
+		}
+	}
+}
+
+// work executes queued jobs until ctx is cancelled.
+func (m *Manager) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case queued := <-m.queue:
+			m.execute(ctx, queued)
+		}
+	}
+}
+
+// execute runs one job and records its outcome. Panics in the job fail it instead
+// of crashing the worker.
+func (m *Manager) execute(ctx context.Context, queued queuedJob) {
+	kind := m.update(queued.id, func(job *Job) {
+		job.Status = StatusRunning
+	})
+
+	jobCtx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	start := time.Now()
+	result, err := runSafely(jobCtx, queued.fn)
+	fields := map[string]interface{}{
+		"job_id":      queued.id,
+		"kind":        kind,
+		"duration_ms": time.Since(start).Milliseconds(),
+	}
+	if err == nil && result == nil {
+		err = errors.New("job returned no result")
+	}
+
+	var snapshot Job
+	m.update(queued.id, func(job *Job) {
+		defer func() { snapshot = *job }()
+		finished := time.Now().UTC()
+		job.FinishedAt = &finished
+		if err != nil {
+			job.Status = StatusFailed
+			job.Error = err.Error()
+			return
+		}
+		job.Status = StatusSucceeded
+		job.Result = result
+	})
+
+	if err != nil {
+		m.log.Error("Job failed", err, fields)
+	} else {
+		fields["bytes"] = len(result.Data)
+		m.log.Info("Job succeeded", fields)
+	}
+
+	for _, fn := range m.finished {
+		fn(snapshot)
+	}
+}
+
+// update applies fn to the job with the given ID, if it still exists, and returns its kind.
+func (m *Manager) update(id string, fn func(job *Job)) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.jobs[id]
+	if !ok {
+		return ""
+	}
+	fn(job)
+	return job.Kind
+}
+
+// prune drops jobs that finished more than the retention period before now.
+func (m *Manager) prune(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, job := range m.jobs {
+		if job.FinishedAt != nil && now.Sub(*job.FinishedAt) > m.retention {
+			delete(m.jobs, id)
+		}
+	}
+}
+
+// runSafely calls fn, converting a panic into an error.
+func runSafely(ctx context.Context, fn Func) (result *Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
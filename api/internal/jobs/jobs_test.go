@@ -0,0 +1,154 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+)
+
+// waitFinished polls the manager until the job reaches a final status.
+func waitFinished(t *testing.T, m *Manager, id string) Job {
+	t.Helper()
+	var job Job
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = m.Get(id)
+		require.NoError(t, err)
+		return job.Status == StatusSucceeded || job.Status == StatusFailed
+	}, 2*time.Second, 5*time.Millisecond)
+	return job
+}
+
+// startManager runs a manager with one worker until the test ends.
+func startManager(t *testing.T, queueSize int) *Manager {
+	t.Helper()
+	m := NewManager(queueSize, 200*time.Millisecond, time.Hour, logger.New("test"))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go m.Run(ctx, 1)
+	return m
+}
+
+func TestManager_Succeeded(t *testing.T) {
+	m := startManager(t, 1)
+
+	job, err := m.Submit("test", func(ctx context.Context) (*Result, error) {
+		return &Result{ContentType: "text/plain", Filename: "out.txt", Data: []byte("done")}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, StatusQueued, job.Status)
+	assert.Equal(t, "test", job.Kind)
+
+	job = waitFinished(t, m, job.ID)
+	assert.Equal(t, StatusSucceeded, job.Status)
+	require.NotNil(t, job.Result)
+	assert.Equal(t, []byte("done"), job.Result.Data)
+	assert.NotNil(t, job.FinishedAt)
+	assert.Empty(t, job.Error)
+}
+
+func TestManager_Failed(t *testing.T) {
+	m := startManager(t, 3)
+
+	tests := map[string]Func{
+		"error": func(ctx context.Context) (*Result, error) {
+			return nil, errors.New("area too large")
+		},
+		"panic": func(ctx context.Context) (*Result, error) {
+			panic("boom")
+		},
+		"timeout": func(ctx context.Context) (*Result, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+
+	for name, fn := range tests {
+		t.Run(name, func(t *testing.T) {
+			job, err := m.Submit("test", fn)
+			require.NoError(t, err)
+
+			job = waitFinished(t, m, job.ID)
+			assert.Equal(t, StatusFailed, job.Status)
+			assert.NotEmpty(t, job.Error)
+			assert.Nil(t, job.Result)
+		})
+	}
+}
+
+func TestManager_QueueFull(t *testing.T) {
+	// Not running, so queued jobs stay queued
+	m := NewManager(1, time.Second, time.Hour, logger.New("test"))
+	noop := func(ctx context.Context) (*Result, error) { return &Result{}, nil }
+
+	_, err := m.Submit("test", noop)
+	require.NoError(t, err)
+
+	_, err = m.Submit("test", noop)
+	assert.ErrorIs(t, err, ErrQueueFull)
+}
+
+func TestManager_Prune(t *testing.T) {
+	m := NewManager(1, time.Second, time.Minute, logger.New("test"))
+	job, err := m.Submit("test", func(ctx context.Context) (*Result, error) { return &Result{}, nil })
+	require.NoError(t, err)
+
+	// Queued jobs are never pruned
+	m.prune(time.Now().Add(time.Hour))
+	_, err = m.Get(job.ID)
+	require.NoError(t, err)
+
+	m.execute(context.Background(), <-m.queue)
+
+	m.prune(time.Now())
+	_, err = m.Get(job.ID)
+	require.NoError(t, err, "kept within retention")
+
+	m.prune(time.Now().Add(2 * time.Minute))
+	_, err = m.Get(job.ID)
+	assert.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestManager_GetUnknown(t *testing.T) {
+	m := NewManager(1, time.Second, time.Minute, logger.New("test"))
+
+	_, err := m.Get("missing")
+	assert.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestManager_OnFinish(t *testing.T) {
+	m := NewManager(2, 200*time.Millisecond, time.Hour, logger.New("test"))
+	finished := make(chan Job, 2)
+	m.OnFinish(func(job Job) { finished <- job })
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go m.Run(ctx, 1)
+
+	succeeded, err := m.Submit("test", func(ctx context.Context) (*Result, error) {
+		return &Result{Data: []byte("done")}, nil
+	})
+	require.NoError(t, err)
+	failed, err := m.Submit("test", func(ctx context.Context) (*Result, error) {
+		return nil, errors.New("area too large")
+	})
+	require.NoError(t, err)
+
+	for _, want := range []struct {
+		id     string
+		status Status
+	}{{succeeded.ID, StatusSucceeded}, {failed.ID, StatusFailed}} {
+		select {
+		case job := <-finished:
+			assert.Equal(t, want.id, job.ID)
+			assert.Equal(t, want.status, job.Status)
+			assert.NotNil(t, job.FinishedAt)
+		case <-time.After(2 * time.Second):
+			t.Fatal("OnFinish was not called")
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+)
+
+// MaxTaskRetryDelay caps the backoff between attempts of a task.
+const MaxTaskRetryDelay = time.Hour
+
+// taskRecordTimeout bounds recording the outcome of a task, which happens after
+// the task's own timeout may have expired.
+const taskRecordTimeout = 10 * time.Second
+
+// Task is a unit of durable background work. Unlike a Job, a task is stored in
+// the database, so it survives restarts and may run on any instance; its work is
+// looked up by Kind, so the task itself only carries a JSON Payload.
+type Task struct {
+	Payload json.RawMessage
+	Kind    string
+	// Key deduplicates tasks of a kind; empty for tasks that are never deduplicated
+	Key         string
+	ID          int64
+	Attempts    int
+	MaxAttempts int
+}
+
+// TaskFunc performs the work of a task of one kind. ctx is cancelled when the
+// task times out. Returning an error retries the task after a backoff.
+type TaskFunc func(ctx context.Context, payload json.RawMessage) error
+
+// TaskStore persists tasks; repository.TaskRepository implements it on the
+// tasks table.
+type TaskStore interface {
+	// Enqueue stores a pending task due now.
+	// Returns false, nil if a task of the same kind and key already exists.
+	Enqueue(ctx context.Context, task Task) (bool, error)
+
+	// Claim marks the oldest due task of one of kinds running for lease and
+	// counts the attempt. Tasks whose lease expired are due again.
+	// Returns nil, nil if no task is due.
+	Claim(ctx context.Context, kinds []string, lease time.Duration) (*Task, error)
+
+	// Complete marks a task succeeded.
+	Complete(ctx context.Context, id int64) error
+
+	// Retry makes a task pending again after delay, recording the failed attempt.
+	Retry(ctx context.Context, id int64, delay time.Duration, reason string) error
+
+	// Fail marks a task failed for good, recording the last attempt.
+	Fail(ctx context.Context, id int64, reason string) error
+}
+
+// QueueOptions configures a Queue. Workers look for due tasks every
+// PollInterval while the queue is empty. A task may run for at most Timeout,
+// which is also the lease after which another worker may claim it. Failed tasks
+// are retried after RetryDelay, doubled for each attempt up to
+// MaxTaskRetryDelay, until they have been attempted MaxAttempts times.
+type QueueOptions struct {
+	PollInterval time.Duration
+	Timeout      time.Duration
+	RetryDelay   time.Duration
+	MaxAttempts  int
+}
+
+// Queue runs durable tasks from a TaskStore on a fixed number of workers. It
+// complements Manager, whose jobs hold their results in memory for the client
+// that submitted them: tasks have no result and are retried until they succeed.
+type Queue struct {
+	store    TaskStore
+	log      *logger.Logger
+	handlers map[string]TaskFunc
+	kinds    []string
+	opts     QueueOptions
+}
+
+// NewQueue creates a queue of the tasks in store.
+func NewQueue(store TaskStore, opts QueueOptions, log *logger.Logger) *Queue {
+	return &Queue{
+		store:    store,
+		log:      log,
+		handlers: make(map[string]TaskFunc),
+		opts:     opts,
+	}
+}
+
+// Handle registers fn as the work of tasks of the given kind. The queue only
+// claims kinds with a handler, so instances running different versions leave
+// each other's unknown tasks alone. Register handlers before Run.
+func (q *Queue) Handle(kind string, fn TaskFunc) {
+	if _, ok := q.handlers[kind]; !ok {
+		q.kinds = append(q.kinds, kind)
+	}
+	q.handlers[kind] = fn
+}
+
+// Enqueue stores a task of the given kind with payload encoded as JSON. A
+// non-empty key makes the task unique for its kind, so the same work can be
+// enqueued by several instances or after a restart and still only runs once.
+// Returns false, nil if a task with the key already exists.
+func (q *Queue) Enqueue(ctx context.Context, kind, key string, payload any) (bool, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode %s task payload: %w", kind, err)
+	}
+
+	created, err := q.store.Enqueue(ctx, Task{
+		Kind:        kind,
+		Key:         key,
+		Payload:     data,
+		MaxAttempts: q.opts.MaxAttempts,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to enqueue %s task: %w", kind, err)
+	}
+	return created, nil
+}
+
+// Run starts workers goroutines that run due tasks, blocking until ctx is
+// cancelled; run it in its own goroutine. Cancelling ctx stops claiming tasks
+// but lets running ones finish within their timeout, so a graceful shutdown
+// does not waste an attempt; Run returns once they have.
+func (q *Queue) Run(ctx context.Context, workers int) {
+	if len(q.kinds) == 0 {
+		q.log.Info("No task handlers registered; task queue idle", nil)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.work(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+// work runs due tasks until ctx is cancelled, polling while none are due.
+func (q *Queue) work(ctx context.Context) {
+	ticker := time.NewTicker(q.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Drain the due tasks before waiting for the next poll
+		for ctx.Err() == nil && q.runNext(ctx) {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runNext claims and runs one due task and reports whether it found one.
+// Store errors are logged and reported as no task, so the worker backs off
+// until the next poll.
+func (q *Queue) runNext(ctx context.Context) bool {
+	task, err := q.store.Claim(ctx, q.kinds, q.opts.Timeout)
+	if err != nil {
+		if ctx.Err() == nil {
+			q.log.Error("Failed to claim task", err, nil)
+		}
+		return false
+	}
+	if task == nil {
+		return false
+	}
+
+	// The task outlives a shutdown of the worker, bounded by its own timeout
+	q.execute(context.WithoutCancel(ctx), task)
+	return true
+}
+
+// execute runs a claimed task and records its outcome. Panics in the task fail
+// the attempt instead of crashing the worker.
+func (q *Queue) execute(ctx context.Context, task *Task) {
+	fields := map[string]interface{}{
+		"task_id": task.ID,
+		"kind":    task.Kind,
+		"attempt": task.Attempts,
+	}
+
+	var err error
+	if task.Attempts > task.MaxAttempts {
+		// The lease of the last attempt expired, most likely with its worker
+		err = errors.New("task lease expired on its last attempt")
+	} else {
+		taskCtx, cancel := context.WithTimeout(ctx, q.opts.Timeout)
+		start := time.Now()
+		err = runTaskSafely(taskCtx, q.handlers[task.Kind], task.Payload)
+		fields["duration_ms"] = time.Since(start).Milliseconds()
+		cancel()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, taskRecordTimeout)
+	defer cancel()
+
+	var storeErr error
+	switch {
+	case err == nil:
+		q.log.Info("Task succeeded", fields)
+		storeErr = q.store.Complete(ctx, task.ID)
+	case task.Attempts >= task.MaxAttempts:
+		q.log.Error("Task failed, giving up", err, fields)
+		storeErr = q.store.Fail(ctx, task.ID, err.Error())
+	default:
+		delay := TaskRetryDelay(q.opts.RetryDelay, task.Attempts)
+		fields["retry_in"] = delay.String()
+		fields["error"] = err.Error()
+		q.log.Warn("Task failed, retrying", fields)
+		storeErr = q.store.Retry(ctx, task.ID, delay, err.Error())
+	}
+	if storeErr != nil {
+		// The lease expires and another worker retries the task
+		q.log.Error("Failed to record task outcome", storeErr, fields)
+	}
+}
+
+// TaskRetryDelay returns the wait before retrying a task that failed its
+// attempt-th attempt: base doubled per earlier attempt, capped at MaxTaskRetryDelay.
+func TaskRetryDelay(base time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < MaxTaskRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, MaxTaskRetryDelay)
+}
+
+// runTaskSafely calls fn, converting a panic into an error.
+func runTaskSafely(ctx context.Context, fn TaskFunc, payload json.RawMessage) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("task panicked: %v", r)
+		}
+	}()
+	return fn(ctx, payload)
+}
@@ -0,0 +1,243 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+)
+
+// storedTask is a task in memoryTaskStore with its bookkeeping.
+type storedTask struct {
+	Task
+	status string
+	delay  time.Duration
+	reason string
+}
+
+// memoryTaskStore is an in-memory TaskStore; retried tasks are due again at once.
+type memoryTaskStore struct {
+	tasks []*storedTask
+	mu    sync.Mutex
+}
+
+func (s *memoryTaskStore) Enqueue(_ context.Context, task Task) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, stored := range s.tasks {
+		if task.Key != "" && stored.Kind == task.Kind && stored.Key == task.Key {
+			return false, nil
+		}
+	}
+	task.ID = int64(len(s.tasks) + 1)
+	s.tasks = append(s.tasks, &storedTask{Task: task, status: "pending"})
+	return true, nil
+}
+
+func (s *memoryTaskStore) Claim(_ context.Context, kinds []string, _ time.Duration) (*Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, stored := range s.tasks {
+		for _, kind := range kinds {
+			if stored.status == "pending" && stored.Kind == kind {
+				stored.status = "running"
+				stored.Attempts++
+				task := stored.Task
+				return &task, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (s *memoryTaskStore) Complete(_ context.Context, id int64) error {
+	return s.update(id, func(task *storedTask) { task.status = "succeeded" })
+}
+
+func (s *memoryTaskStore) Retry(_ context.Context, id int64, delay time.Duration, reason string) error {
+	return s.update(id, func(task *storedTask) {
+		task.status, task.delay, task.reason = "pending", delay, reason
+	})
+}
+
+func (s *memoryTaskStore) Fail(_ context.Context, id int64, reason string) error {
+	return s.update(id, func(task *storedTask) { task.status, task.reason = "failed", reason })
+}
+
+func (s *memoryTaskStore) update(id int64, fn func(task *storedTask)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(s.tasks[id-1])
+	return nil
+}
+
+// get returns a copy of the stored task with the given ID.
+func (s *memoryTaskStore) get(id int64) storedTask {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.tasks[id-1]
+}
+
+// newTestQueue returns a queue on an in-memory store that polls every millisecond.
+func newTestQueue(maxAttempts int) (*Queue, *memoryTaskStore) {
+	store := &memoryTaskStore{}
+	queue := NewQueue(store, QueueOptions{
+		PollInterval: time.Millisecond,
+		Timeout:      time.Second,
+		RetryDelay:   time.Minute,
+		MaxAttempts:  maxAttempts,
+	}, logger.New("test"))
+	return queue, store
+}
+
+// startQueue runs the queue with one worker until the test ends.
+func startQueue(t *testing.T, queue *Queue) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		queue.Run(ctx, 1)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+// waitStatus polls the store until the task reaches the given status.
+func waitStatus(t *testing.T, store *memoryTaskStore, id int64, status string) storedTask {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		return store.get(id).status == status
+	}, 2*time.Second, time.Millisecond)
+	return store.get(id)
+}
+
+func TestQueue_Succeeded(t *testing.T) {
+	queue, store := newTestQueue(3)
+
+	received := make(chan int64, 1)
+	queue.Handle("evaluate", func(ctx context.Context, payload json.RawMessage) error {
+		var p struct {
+			RunID int64 `json:"run_id"`
+		}
+		require.NoError(t, json.Unmarshal(payload, &p))
+		received <- p.RunID
+		return nil
+	})
+	startQueue(t, queue)
+
+	created, err := queue.Enqueue(context.Background(), "evaluate", "run:7", map[string]int64{"run_id": 7})
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	task := waitStatus(t, store, 1, "succeeded")
+	assert.Equal(t, 1, task.Attempts)
+	assert.Equal(t, int64(7), <-received)
+}
+
+func TestQueue_EnqueueDeduplicatesKeys(t *testing.T) {
+	queue, store := newTestQueue(3)
+	ctx := context.Background()
+
+	for _, key := range []string{"run:7", "run:7", "run:8"} {
+		_, err := queue.Enqueue(ctx, "evaluate", key, nil)
+		require.NoError(t, err)
+	}
+	created, err := queue.Enqueue(ctx, "evaluate", "run:7", nil)
+	require.NoError(t, err)
+	assert.False(t, created)
+
+	// Tasks without a key are never deduplicated
+	for range 2 {
+		created, err = queue.Enqueue(ctx, "evaluate", "", nil)
+		require.NoError(t, err)
+		assert.True(t, created)
+	}
+	assert.Len(t, store.tasks, 4)
+}
+
+func TestQueue_RetriesUntilMaxAttempts(t *testing.T) {
+	queue, store := newTestQueue(3)
+
+	queue.Handle("flaky", func(ctx context.Context, payload json.RawMessage) error {
+		return errors.New("replica unavailable")
+	})
+	queue.Handle("panics", func(ctx context.Context, payload json.RawMessage) error {
+		panic("boom")
+	})
+	startQueue(t, queue)
+
+	_, err := queue.Enqueue(context.Background(), "flaky", "", nil)
+	require.NoError(t, err)
+	_, err = queue.Enqueue(context.Background(), "panics", "", nil)
+	require.NoError(t, err)
+
+	flaky := waitStatus(t, store, 1, "failed")
+	assert.Equal(t, 3, flaky.Attempts)
+	assert.Equal(t, "replica unavailable", flaky.reason)
+	assert.Equal(t, 2*time.Minute, flaky.delay, "the last retry waited twice the base delay")
+
+	panicked := waitStatus(t, store, 2, "failed")
+	assert.Contains(t, panicked.reason, "task panicked: boom")
+}
+
+func TestQueue_ExpiredLastLeaseFails(t *testing.T) {
+	queue, store := newTestQueue(2)
+
+	ran := false
+	queue.Handle("evaluate", func(ctx context.Context, payload json.RawMessage) error {
+		ran = true
+		return nil
+	})
+
+	_, err := queue.Enqueue(context.Background(), "evaluate", "", nil)
+	require.NoError(t, err)
+
+	// The task is claimed again after its worker died on the last attempt
+	queue.execute(context.Background(), &Task{ID: 1, Kind: "evaluate", Attempts: 3, MaxAttempts: 2})
+
+	assert.False(t, ran)
+	task := store.get(1)
+	assert.Equal(t, "failed", task.status)
+	assert.Equal(t, "task lease expired on its last attempt", task.reason)
+}
+
+func TestQueue_ShutdownFinishesRunningTask(t *testing.T) {
+	queue, store := newTestQueue(3)
+
+	started := make(chan struct{})
+	queue.Handle("slow", func(ctx context.Context, payload json.RawMessage) error {
+		close(started)
+		time.Sleep(50 * time.Millisecond)
+		return ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		queue.Run(ctx, 1)
+		close(done)
+	}()
+
+	_, err := queue.Enqueue(context.Background(), "slow", "", nil)
+	require.NoError(t, err)
+	<-started
+	cancel()
+	<-done
+
+	assert.Equal(t, "succeeded", store.get(1).status)
+}
+
+func TestTaskRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Minute, TaskRetryDelay(time.Minute, 1))
+	assert.Equal(t, 4*time.Minute, TaskRetryDelay(time.Minute, 3))
+	assert.Equal(t, MaxTaskRetryDelay, TaskRetryDelay(time.Minute, 30))
+}
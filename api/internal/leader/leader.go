@@ -0,0 +1,256 @@
+// Package leader elects one instance to run each singleton background task, such
+// as the daily stats snapshot, when several API or worker replicas are deployed.
+// Leadership of a role is a PostgreSQL session-level advisory lock held on a
+// dedicated connection. The lease is renewed by checking that connection every
+// renew interval: if the check fails the task is cancelled, and the lock is freed
+// once PostgreSQL ends the session, so another instance can take over.
+package leader
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+)
+
+// Defaults used when the configured intervals are zero.
+const (
+	DefaultRenewInterval = 10 * time.Second
+	DefaultRetryInterval = 15 * time.Second
+)
+
+// lockNamespace prefixes role names before hashing them into advisory lock keys,
+// so the keys do not collide with other users of advisory locks.
+const lockNamespace = "atlas:leader:"
+
+// Status describes one role as seen from this instance.
+// Holder is the instance holding the role anywhere in the deployment, "" when
+// no instance does.
+type Status struct {
+	Since       *time.Time `json:"since,omitempty"`
+	LastRenewal *time.Time `json:"lastRenewal,omitempty"`
+	Role        string     `json:"role"`
+	Holder      string     `json:"holder"`
+	Leader      bool       `json:"leader"`
+}
+
+// session is a dedicated connection holding advisory locks.
+type session interface {
+	// TryLock takes the lock without waiting and reports whether it was taken.
+	TryLock(ctx context.Context, key int64) (bool, error)
+	// Ping checks that the session, and with it every lock it holds, is alive.
+	Ping(ctx context.Context) error
+	// Close ends the session, releasing its locks.
+	Close(ctx context.Context)
+}
+
+// backend opens sessions and looks up lock holders.
+type backend interface {
+	Session(ctx context.Context, instance string) (session, error)
+	// Holder returns the instance holding the lock, "" when it is free.
+	Holder(ctx context.Context, key int64) (string, error)
+}
+
+// Elector runs tasks on the instance holding their role.
+type Elector struct {
+	backend       backend
+	log           *logger.Logger
+	roles         map[string]*Status
+	instance      string
+	renewInterval time.Duration
+	retryInterval time.Duration
+	mu            sync.Mutex
+}
+
+// NewElector creates an Elector campaigning on db. An empty cfg.InstanceID
+// defaults to the host name and process ID.
+func NewElector(db *database.Database, cfg config.LeaderConfig, log *logger.Logger) *Elector {
+	return newElector(&postgresBackend{db: db}, cfg, log)
+}
+
+// newElector creates an Elector on the given backend.
+func newElector(b backend, cfg config.LeaderConfig, log *logger.Logger) *Elector {
+	e := &Elector{
+		backend:       b,
+		log:           log,
+		roles:         make(map[string]*Status),
+		instance:      cfg.InstanceID,
+		renewInterval: cfg.RenewInterval,
+		retryInterval: cfg.RetryInterval,
+	}
+	if e.instance == "" {
+		host, err := os.Hostname()
+		if err != nil {
+			host = "unknown"
+		}
+		e.instance = fmt.Sprintf("%s-%d", host, os.Getpid())
+	}
+	if e.renewInterval <= 0 {
+		e.renewInterval = DefaultRenewInterval
+	}
+	if e.retryInterval <= 0 {
+		e.retryInterval = DefaultRetryInterval
+	}
+	return e
+}
+
+// Instance returns the name this instance holds roles under.
+func (e *Elector) Instance() string {
+	return e.instance
+}
+
+// Run campaigns for role until ctx is cancelled and runs task while this
+// instance is the leader. task must return once its context is cancelled, which
+// happens when the lease cannot be renewed. If task returns on its own, the role
+// is released and the campaign starts over. Run blocks; call it in its own goroutine.
+func (e *Elector) Run(ctx context.Context, role string, task func(ctx context.Context)) {
+	e.mu.Lock()
+	if _, ok := e.roles[role]; !ok {
+		e.roles[role] = &Status{Role: role}
+	}
+	e.mu.Unlock()
+
+	for {
+		if err := e.campaign(ctx, role, task); err != nil && ctx.Err() == nil {
+			e.log.Error("Leader election failed", err, map[string]interface{}{
+				"role":     role,
+				"instance": e.instance,
+			})
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(e.retryInterval):
+		}
+	}
+}
+
+// campaign tries once to take the role and, if it did, runs task until the
+// lease is lost, task returns or ctx is cancelled.
+func (e *Elector) campaign(ctx context.Context, role string, task func(ctx context.Context)) error {
+	sess, err := e.backend.Session(ctx, e.instance)
+	if err != nil {
+		return fmt.Errorf("failed to open leader session: %w", err)
+	}
+	// Also releases the lock; ctx may already be cancelled
+	defer sess.Close(context.Background())
+
+	acquired, err := sess.TryLock(ctx, lockKey(role))
+	if err != nil {
+		return fmt.Errorf("failed to take leader lock: %w", err)
+	}
+	if !acquired {
+		return nil
+	}
+
+	e.setLeader(role, true)
+	defer e.setLeader(role, false)
+	e.log.Info("Acquired leadership", map[string]interface{}{
+		"role":     role,
+		"instance": e.instance,
+	})
+
+	taskCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		task(taskCtx)
+	}()
+
+	ticker := time.NewTicker(e.renewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			cancel()
+			<-done
+			return nil
+		case <-done:
+			e.log.Warn("Leader task returned, releasing leadership", map[string]interface{}{
+				"role": role,
+			})
+			return nil
+		case <-ticker.C:
+		}
+
+		// A renewal may take at most one interval, so a deposed leader stops
+		// within two intervals of losing its session
+		renewCtx, renewCancel := context.WithTimeout(ctx, e.renewInterval)
+		err := sess.Ping(renewCtx)
+		renewCancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				continue
+			}
+			cancel()
+			<-done
+			return fmt.Errorf("lost leadership of %s: %w", role, err)
+		}
+		e.renewed(role)
+	}
+}
+
+// setLeader records whether this instance holds role.
+func (e *Elector) setLeader(role string, leader bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	status := e.roles[role]
+	status.Leader = leader
+	status.Since, status.LastRenewal = nil, nil
+	if leader {
+		now := time.Now().UTC()
+		status.Since, status.LastRenewal = &now, &now
+	}
+}
+
+// renewed records a successful lease renewal.
+func (e *Elector) renewed(role string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := time.Now().UTC()
+	e.roles[role].LastRenewal = &now
+}
+
+// Status reports the roles this instance campaigns for, ordered by name, with
+// the instance currently holding each one.
+func (e *Elector) Status(ctx context.Context) ([]Status, error) {
+	e.mu.Lock()
+	statuses := make([]Status, 0, len(e.roles))
+	for _, status := range e.roles {
+		statuses = append(statuses, *status)
+	}
+	e.mu.Unlock()
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Role < statuses[j].Role })
+	for i := range statuses {
+		if statuses[i].Leader {
+			statuses[i].Holder = e.instance
+			continue
+		}
+		holder, err := e.backend.Holder(ctx, lockKey(statuses[i].Role))
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up holder of %s: %w", statuses[i].Role, err)
+		}
+		statuses[i].Holder = holder
+	}
+	return statuses, nil
+}
+
+// lockKey derives the advisory lock key of a role.
+func lockKey(role string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(lockNamespace + role))
+	return int64(h.Sum64()) // #nosec G115 -- any 64-bit value is a valid key
+}
@@ -0,0 +1,189 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+)
+
+// fakeBackend grants each lock to the first live session asking for it.
+type fakeBackend struct {
+	holders map[int64]*fakeSession
+	mu      sync.Mutex
+}
+
+func newFakeBackend() *fakeBackend {
+	return &fakeBackend{holders: make(map[int64]*fakeSession)}
+}
+
+func (b *fakeBackend) Session(_ context.Context, instance string) (session, error) {
+	return &fakeSession{backend: b, instance: instance}, nil
+}
+
+func (b *fakeBackend) Holder(_ context.Context, key int64) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if s, ok := b.holders[key]; ok {
+		return s.instance, nil
+	}
+	return "", nil
+}
+
+// fakeSession fails Ping once broken, like a connection PostgreSQL dropped.
+type fakeSession struct {
+	backend  *fakeBackend
+	instance string
+	broken   bool
+}
+
+func (s *fakeSession) TryLock(_ context.Context, key int64) (bool, error) {
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+	if _, held := s.backend.holders[key]; held {
+		return false, nil
+	}
+	s.backend.holders[key] = s
+	return true, nil
+}
+
+func (s *fakeSession) Ping(context.Context) error {
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+	if s.broken {
+		return errors.New("connection reset")
+	}
+	return nil
+}
+
+func (s *fakeSession) Close(context.Context) {
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+	for key, holder := range s.backend.holders {
+		if holder == s {
+			delete(s.backend.holders, key)
+		}
+	}
+}
+
+// breakHolder breaks the session holding the role's lock.
+func (b *fakeBackend) breakHolder(role string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.holders[lockKey(role)].broken = true
+}
+
+var testLeaderConfig = config.LeaderConfig{RenewInterval: 10 * time.Millisecond, RetryInterval: 10 * time.Millisecond}
+
+// startElector runs task for role on a new elector until the test ends.
+func startElector(t *testing.T, b backend, instance, role string, task func(ctx context.Context)) *Elector {
+	t.Helper()
+	cfg := testLeaderConfig
+	cfg.InstanceID = instance
+	e := newElector(b, cfg, logger.New("test"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		e.Run(ctx, role, task)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return e
+}
+
+// blockingTask counts running instances of a task that runs until cancelled.
+func blockingTask(running *sync.Map, instance string) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		running.Store(instance, true)
+		<-ctx.Done()
+		running.Delete(instance)
+	}
+}
+
+func countRunning(running *sync.Map) int {
+	n := 0
+	running.Range(func(any, any) bool { n++; return true })
+	return n
+}
+
+func TestElector_SingleLeader(t *testing.T) {
+	b := newFakeBackend()
+	var running sync.Map
+	first := startElector(t, b, "a", "snapshots", blockingTask(&running, "a"))
+	require.Eventually(t, func() bool { return countRunning(&running) == 1 }, time.Second, time.Millisecond)
+	second := startElector(t, b, "b", "snapshots", blockingTask(&running, "b"))
+
+	// The follower keeps retrying without taking over
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 1, countRunning(&running))
+
+	for _, e := range []*Elector{first, second} {
+		statuses, err := e.Status(context.Background())
+		require.NoError(t, err)
+		require.Len(t, statuses, 1)
+		assert.Equal(t, "snapshots", statuses[0].Role)
+		assert.Equal(t, "a", statuses[0].Holder)
+		assert.Equal(t, e == first, statuses[0].Leader)
+	}
+}
+
+func TestElector_FailoverOnLostLease(t *testing.T) {
+	b := newFakeBackend()
+	var running sync.Map
+	startElector(t, b, "a", "warming", blockingTask(&running, "a"))
+	require.Eventually(t, func() bool { _, ok := running.Load("a"); return ok }, time.Second, time.Millisecond)
+	startElector(t, b, "b", "warming", blockingTask(&running, "b"))
+
+	b.breakHolder("warming")
+
+	// a's task is cancelled when renewal fails and b takes over
+	require.Eventually(t, func() bool {
+		_, a := running.Load("a")
+		_, bRunning := running.Load("b")
+		return !a && bRunning
+	}, time.Second, time.Millisecond)
+}
+
+func TestElector_TaskReturnReleasesRole(t *testing.T) {
+	b := newFakeBackend()
+	var mu sync.Mutex
+	count := 0
+	startElector(t, b, "a", "once", func(context.Context) {
+		mu.Lock()
+		count++
+		mu.Unlock()
+	})
+
+	// Released after each return, so the role is campaigned for again
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return count >= 2
+	}, time.Second, time.Millisecond)
+}
+
+func TestElector_StatusBeforeCampaign(t *testing.T) {
+	e := newElector(newFakeBackend(), config.LeaderConfig{}, logger.New("test"))
+
+	statuses, err := e.Status(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, statuses)
+	assert.NotEmpty(t, e.Instance())
+	assert.Equal(t, DefaultRenewInterval, e.renewInterval)
+	assert.Equal(t, DefaultRetryInterval, e.retryInterval)
+}
+
+func TestLockKey(t *testing.T) {
+	assert.Equal(t, lockKey("stats-snapshots"), lockKey("stats-snapshots"))
+	assert.NotEqual(t, lockKey("stats-snapshots"), lockKey("cache-warming"))
+}
@@ -0,0 +1,84 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+)
+
+// applicationNamePrefix marks leader sessions in pg_stat_activity; the rest of
+// the application name is the instance, which is how holders are found.
+const applicationNamePrefix = "atlas-leader:"
+
+// postgresBackend holds advisory locks on connections taken out of the pool.
+type postgresBackend struct {
+	db *database.Database
+}
+
+// Session hijacks a pooled connection, so the lock outlives pool resets and the
+// pool can replace the connection for queries.
+func (b *postgresBackend) Session(ctx context.Context, instance string) (session, error) {
+	pooled, err := b.db.Pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conn := pooled.Hijack()
+
+	if _, err := conn.Exec(ctx, `SELECT set_config('application_name', $1, false)`, applicationNamePrefix+instance); err != nil {
+		_ = conn.Close(context.Background())
+		return nil, fmt.Errorf("failed to name leader session: %w", err)
+	}
+	return &postgresSession{conn: conn}, nil
+}
+
+// Holder finds the session holding the lock. A bigint advisory lock key is
+// split into classid (high 32 bits) and objid (low 32 bits) with objsubid 1.
+func (b *postgresBackend) Holder(ctx context.Context, key int64) (string, error) {
+	query := `
+		SELECT a.application_name
+		FROM pg_locks l
+		JOIN pg_stat_activity a ON a.pid = l.pid
+		WHERE l.locktype = 'advisory'
+			AND l.granted
+			AND l.objsubid = 1
+			AND l.classid = $1::bigint::oid
+			AND l.objid = $2::bigint::oid
+		LIMIT 1
+	`
+
+	var name string
+	err := b.db.Pool.QueryRow(ctx, query, int64(uint32(key>>32)), int64(uint32(key))).Scan(&name) // #nosec G115
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(name, applicationNamePrefix), nil
+}
+
+// postgresSession is a dedicated connection holding session-level advisory locks.
+type postgresSession struct {
+	conn *pgx.Conn
+}
+
+// TryLock takes the lock with pg_try_advisory_lock.
+func (s *postgresSession) TryLock(ctx context.Context, key int64) (bool, error) {
+	var acquired bool
+	err := s.conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, key).Scan(&acquired)
+	return acquired, err
+}
+
+// Ping round-trips a query; session-level locks live as long as the session.
+func (s *postgresSession) Ping(ctx context.Context) error {
+	return s.conn.Ping(ctx)
+}
+
+// Close ends the session, which releases its locks.
+func (s *postgresSession) Close(ctx context.Context) {
+	_ = s.conn.Close(ctx)
+}
@@ -0,0 +1,80 @@
+package location
+
+import (
+	"context"
+	"strings"
+
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// Geocoder providers, selected with GEOCODER_PROVIDER
+const (
+	GeocoderNominatim = "nominatim"
+	GeocoderPelias    = "pelias"
+)
+
+// GeocoderProvider names the geocoder client in outbound stats.
+const GeocoderProvider = "geocoder"
+
+// Address is a postal address normalized from a geocoder's response, so that
+// clients see the same shape whichever provider is configured. State is the
+// two-letter code when the provider reports one.
+type Address struct {
+	Label       string
+	HouseNumber string
+	Street      string
+	City        string
+	County      string
+	State       string
+	PostalCode  string
+}
+
+// Match is an address found by a forward geocoding search, with its location
+// and the provider's relevance Score between 0 and 1.
+type Match struct {
+	Address Address
+	Point   models.LatLng
+	Score   float64
+}
+
+// Geocoder looks up addresses with an external geocoding service.
+// Implementations call an external service, so they must honour ctx cancellation.
+type Geocoder interface {
+	// Reverse returns the address nearest point.
+	// Returns nil, nil if the service knows no address there.
+	// Returns error for network or service failures.
+	Reverse(ctx context.Context, point models.LatLng) (*Address, error)
+
+	// Search returns up to limit addresses matching the free-form query, best
+	// first. Results that name no street or city are left out.
+	// Returns error for network or service failures.
+	Search(ctx context.Context, query string, limit int) ([]Match, error)
+}
+
+// normalize trims the components of an address and builds its label as a
+// single line, e.g. "123 Main St, Conroe, TX 77301". An address without a
+// street or city is nil: it names an area, not a place to show for a parcel.
+func (a Address) normalize() *Address {
+	a.HouseNumber = strings.TrimSpace(a.HouseNumber)
+	a.Street = strings.TrimSpace(a.Street)
+	a.City = strings.TrimSpace(a.City)
+	a.County = strings.TrimSpace(a.County)
+	a.State = strings.TrimSpace(strings.TrimPrefix(a.State, "US-"))
+	a.PostalCode = strings.TrimSpace(a.PostalCode)
+	if a.Street == "" && a.City == "" {
+		return nil
+	}
+
+	var parts []string
+	if line := strings.TrimSpace(a.HouseNumber + " " + a.Street); line != "" {
+		parts = append(parts, line)
+	}
+	if a.City != "" {
+		parts = append(parts, a.City)
+	}
+	if region := strings.TrimSpace(a.State + " " + a.PostalCode); region != "" {
+		parts = append(parts, region)
+	}
+	a.Label = strings.Join(parts, ", ")
+	return &a
+}
@@ -0,0 +1,205 @@
+package location
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/outbound"
+)
+
+// testGeocoderHTTP returns an outbound client that does not retry.
+func testGeocoderHTTP() *outbound.Client {
+	return outbound.NewClient(GeocoderProvider, outbound.Options{Timeout: time.Second, MaxRetries: -1})
+}
+
+// newTestGeocoderServer serves a fixed status and body, checking each request with check.
+func newTestGeocoderServer(t *testing.T, status int, body string, check func(r *http.Request)) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		check(r)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// conroe is a point in downtown Conroe, TX.
+var conroe = models.LatLng{Lat: 30.3119, Lng: -95.4561}
+
+func TestNominatimGeocoder_Reverse(t *testing.T) {
+	server := newTestGeocoderServer(t, http.StatusOK, `{
+		"display_name": "301, North Main Street, Conroe, Montgomery County, Texas, 77301, United States",
+		"address": {"house_number": "301", "road": "North Main Street", "city": "Conroe",
+			"county": "Montgomery County", "state": "Texas", "ISO3166-2-lvl4": "US-TX", "postcode": "77301"}
+	}`, func(r *http.Request) {
+		assert.Equal(t, "/reverse", r.URL.Path)
+		assert.Equal(t, "30.3119", r.URL.Query().Get("lat"))
+		assert.Equal(t, "-95.4561", r.URL.Query().Get("lon"))
+		assert.Equal(t, "jsonv2", r.URL.Query().Get("format"))
+		assert.Equal(t, "atlas-test", r.Header.Get("User-Agent"))
+	})
+	geocoder := NewNominatimGeocoder(server.URL+"/", "atlas-test", testGeocoderHTTP())
+
+	address, err := geocoder.Reverse(context.Background(), conroe)
+
+	require.NoError(t, err)
+	require.NotNil(t, address)
+	assert.Equal(t, "301 North Main Street, Conroe, TX 77301", address.Label)
+	assert.Equal(t, "TX", address.State)
+	assert.Equal(t, "Montgomery County", address.County)
+}
+
+func TestNominatimGeocoder_ReverseTownWithoutStateCode(t *testing.T) {
+	server := newTestGeocoderServer(t, http.StatusOK,
+		`{"address": {"road": "FM 1097", "town": "Willis", "state": "Texas"}}`, func(*http.Request) {})
+	geocoder := NewNominatimGeocoder(server.URL, "atlas-test", testGeocoderHTTP())
+
+	address, err := geocoder.Reverse(context.Background(), conroe)
+
+	require.NoError(t, err)
+	require.NotNil(t, address)
+	assert.Equal(t, "FM 1097, Willis, Texas", address.Label)
+}
+
+func TestNominatimGeocoder_ReverseNoAddress(t *testing.T) {
+	for name, body := range map[string]string{
+		"unable to geocode": `{"error": "Unable to geocode"}`,
+		"area only":         `{"address": {"county": "Montgomery County", "state": "Texas"}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := newTestGeocoderServer(t, http.StatusOK, body, func(*http.Request) {})
+			geocoder := NewNominatimGeocoder(server.URL, "atlas-test", testGeocoderHTTP())
+
+			address, err := geocoder.Reverse(context.Background(), conroe)
+
+			require.NoError(t, err)
+			assert.Nil(t, address)
+		})
+	}
+}
+
+func TestNominatimGeocoder_ReverseServiceError(t *testing.T) {
+	server := newTestGeocoderServer(t, http.StatusForbidden, `<html>Access blocked</html>`, func(*http.Request) {})
+	geocoder := NewNominatimGeocoder(server.URL, "atlas-test", testGeocoderHTTP())
+
+	_, err := geocoder.Reverse(context.Background(), conroe)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 403")
+}
+
+func TestPeliasGeocoder_Reverse(t *testing.T) {
+	server := newTestGeocoderServer(t, http.StatusOK, `{"features": [{"properties": {
+		"housenumber": "301", "street": "N Main St", "locality": "Conroe", "county": "Montgomery County",
+		"region": "Texas", "region_a": "TX", "postalcode": "77301"}}]}`, func(r *http.Request) {
+		assert.Equal(t, "/v1/reverse", r.URL.Path)
+		assert.Equal(t, "30.3119", r.URL.Query().Get("point.lat"))
+		assert.Equal(t, "-95.4561", r.URL.Query().Get("point.lon"))
+		assert.Equal(t, "test-key", r.URL.Query().Get("api_key"))
+	})
+	geocoder := NewPeliasGeocoder(server.URL, "test-key", testGeocoderHTTP())
+
+	address, err := geocoder.Reverse(context.Background(), conroe)
+
+	require.NoError(t, err)
+	require.NotNil(t, address)
+	assert.Equal(t, "301 N Main St, Conroe, TX 77301", address.Label)
+}
+
+func TestPeliasGeocoder_ReverseNoAddress(t *testing.T) {
+	server := newTestGeocoderServer(t, http.StatusOK, `{"features": []}`, func(r *http.Request) {
+		assert.False(t, r.URL.Query().Has("api_key"), "self-hosted servers get no key")
+	})
+	geocoder := NewPeliasGeocoder(server.URL, "", testGeocoderHTTP())
+
+	address, err := geocoder.Reverse(context.Background(), conroe)
+
+	require.NoError(t, err)
+	assert.Nil(t, address)
+}
+
+func TestPeliasGeocoder_ReverseServiceError(t *testing.T) {
+	server := newTestGeocoderServer(t, http.StatusUnauthorized,
+		`{"geocoding": {"errors": ["invalid api_key value"]}}`, func(*http.Request) {})
+	geocoder := NewPeliasGeocoder(server.URL, "test-key", testGeocoderHTTP())
+
+	_, err := geocoder.Reverse(context.Background(), conroe)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid api_key value")
+}
+
+func TestNominatimGeocoder_Search(t *testing.T) {
+	server := newTestGeocoderServer(t, http.StatusOK, `[
+		{"lat": "30.3119", "lon": "-95.4561", "importance": 0.42,
+			"address": {"house_number": "301", "road": "North Main Street", "city": "Conroe",
+				"state": "Texas", "ISO3166-2-lvl4": "US-TX", "postcode": "77301"}},
+		{"lat": "30.5", "lon": "-95.5", "importance": 0.61,
+			"address": {"county": "Montgomery County", "state": "Texas"}}
+	]`, func(r *http.Request) {
+		assert.Equal(t, "/search", r.URL.Path)
+		assert.Equal(t, "301 n main st conroe", r.URL.Query().Get("q"))
+		assert.Equal(t, "3", r.URL.Query().Get("limit"))
+		assert.Equal(t, "1", r.URL.Query().Get("addressdetails"))
+		assert.Equal(t, "atlas-test", r.Header.Get("User-Agent"))
+	})
+	geocoder := NewNominatimGeocoder(server.URL, "atlas-test", testGeocoderHTTP())
+
+	matches, err := geocoder.Search(context.Background(), "301 n main st conroe", 3)
+
+	require.NoError(t, err)
+	require.Len(t, matches, 1, "the county-only result names no place")
+	assert.Equal(t, "301 North Main Street, Conroe, TX 77301", matches[0].Address.Label)
+	assert.Equal(t, conroe, matches[0].Point)
+	assert.Equal(t, 0.42, matches[0].Score)
+}
+
+func TestNominatimGeocoder_SearchNoResults(t *testing.T) {
+	server := newTestGeocoderServer(t, http.StatusOK, `[]`, func(*http.Request) {})
+	geocoder := NewNominatimGeocoder(server.URL, "atlas-test", testGeocoderHTTP())
+
+	matches, err := geocoder.Search(context.Background(), "nowhere", 5)
+
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestPeliasGeocoder_Search(t *testing.T) {
+	server := newTestGeocoderServer(t, http.StatusOK, `{"features": [{
+		"geometry": {"type": "Point", "coordinates": [-95.4561, 30.3119]},
+		"properties": {"housenumber": "301", "street": "N Main St", "locality": "Conroe",
+			"region_a": "TX", "postalcode": "77301", "confidence": 0.9}}]}`, func(r *http.Request) {
+		assert.Equal(t, "/v1/search", r.URL.Path)
+		assert.Equal(t, "301 n main st conroe", r.URL.Query().Get("text"))
+		assert.Equal(t, "5", r.URL.Query().Get("size"))
+		assert.Equal(t, "test-key", r.URL.Query().Get("api_key"))
+	})
+	geocoder := NewPeliasGeocoder(server.URL, "test-key", testGeocoderHTTP())
+
+	matches, err := geocoder.Search(context.Background(), "301 n main st conroe", 5)
+
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "301 N Main St, Conroe, TX 77301", matches[0].Address.Label)
+	assert.Equal(t, conroe, matches[0].Point, "GeoJSON positions are lng, lat")
+	assert.Equal(t, 0.9, matches[0].Score)
+}
+
+func TestPeliasGeocoder_SearchServiceError(t *testing.T) {
+	server := newTestGeocoderServer(t, http.StatusBadRequest,
+		`{"geocoding": {"errors": ["invalid param 'text': text length, must be >0"]}}`, func(*http.Request) {})
+	geocoder := NewPeliasGeocoder(server.URL, "", testGeocoderHTTP())
+
+	_, err := geocoder.Search(context.Background(), "x", 5)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 400")
+}
@@ -0,0 +1,159 @@
+package location
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/outbound"
+)
+
+// nominatimGeocoder is a Geocoder backed by a Nominatim server.
+type nominatimGeocoder struct {
+	http      *outbound.Client
+	baseURL   string
+	userAgent string
+}
+
+// NewNominatimGeocoder creates a Geocoder for the Nominatim API at baseURL
+// (e.g. https://nominatim.openstreetmap.org or a self-hosted server).
+// userAgent identifies the application, as the public server's usage policy
+// requires; client applies the rate limit, retries and response cache.
+func NewNominatimGeocoder(baseURL, userAgent string, client *outbound.Client) Geocoder {
+	return &nominatimGeocoder{
+		http:      client,
+		baseURL:   strings.TrimRight(baseURL, "/"),
+		userAgent: userAgent,
+	}
+}
+
+// nominatimAddress is the subset of a Nominatim address breakdown we read.
+type nominatimAddress struct {
+	HouseNumber string `json:"house_number"`
+	Road        string `json:"road"`
+	City        string `json:"city"`
+	Town        string `json:"town"`
+	Village     string `json:"village"`
+	Hamlet      string `json:"hamlet"`
+	County      string `json:"county"`
+	State       string `json:"state"`
+	StateCode   string `json:"ISO3166-2-lvl4"`
+	Postcode    string `json:"postcode"`
+}
+
+// normalize converts the breakdown to an Address; nil if it names no street or city.
+func (a *nominatimAddress) normalize() *Address {
+	return Address{
+		HouseNumber: a.HouseNumber,
+		Street:      a.Road,
+		City:        firstNonEmpty(a.City, a.Town, a.Village, a.Hamlet),
+		County:      a.County,
+		State:       firstNonEmpty(a.StateCode, a.State),
+		PostalCode:  a.Postcode,
+	}.normalize()
+}
+
+// nominatimReverseResponse is the subset of the jsonv2 reverse response we read.
+type nominatimReverseResponse struct {
+	Address *nominatimAddress `json:"address"`
+	Error   string            `json:"error"`
+}
+
+// nominatimPlace is the subset of a jsonv2 search result we read; Nominatim
+// encodes coordinates as strings.
+type nominatimPlace struct {
+	Address    *nominatimAddress `json:"address"`
+	Lat        string            `json:"lat"`
+	Lon        string            `json:"lon"`
+	Importance float64           `json:"importance"`
+}
+
+// Reverse calls GET /reverse at building zoom. Nominatim reports points
+// without an address as an error in a 200 response.
+func (g *nominatimGeocoder) Reverse(ctx context.Context, point models.LatLng) (*Address, error) {
+	var body nominatimReverseResponse
+	err := g.get(ctx, "/reverse", url.Values{
+		"lat":  {strconv.FormatFloat(point.Lat, 'f', -1, 64)},
+		"lon":  {strconv.FormatFloat(point.Lng, 'f', -1, 64)},
+		"zoom": {"18"},
+	}, &body)
+	if err != nil {
+		return nil, err
+	}
+	if body.Error != "" || body.Address == nil {
+		return nil, nil
+	}
+
+	return body.Address.normalize(), nil
+}
+
+// Search calls GET /search. Importance, Nominatim's estimate of how
+// prominent a place is, stands in for the relevance score.
+func (g *nominatimGeocoder) Search(ctx context.Context, query string, limit int) ([]Match, error) {
+	var places []nominatimPlace
+	err := g.get(ctx, "/search", url.Values{
+		"q":     {query},
+		"limit": {strconv.Itoa(limit)},
+	}, &places)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]Match, 0, len(places))
+	for _, place := range places {
+		if place.Address == nil {
+			continue
+		}
+		address := place.Address.normalize()
+		if address == nil {
+			continue
+		}
+		lat, latErr := strconv.ParseFloat(place.Lat, 64)
+		lng, lngErr := strconv.ParseFloat(place.Lon, 64)
+		point, err := models.NewLatLng(lat, lng)
+		if latErr != nil || lngErr != nil || err != nil {
+			return nil, fmt.Errorf("invalid nominatim coordinates %q, %q", place.Lat, place.Lon)
+		}
+		matches = append(matches, Match{Address: *address, Point: point, Score: place.Importance})
+	}
+	return matches, nil
+}
+
+// get requests path with the jsonv2 format and address breakdown added to
+// query and decodes the response into body.
+func (g *nominatimGeocoder) get(ctx context.Context, path string, query url.Values, body any) error {
+	query.Set("format", "jsonv2")
+	query.Set("addressdetails", "1")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.baseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to build nominatim request: %w", err)
+	}
+	req.Header.Set("User-Agent", g.userAgent)
+
+	resp, err := g.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("nominatim request failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected nominatim response (status %d)", resp.StatusCode)
+	}
+	if err := json.Unmarshal(resp.Body, body); err != nil {
+		return fmt.Errorf("failed to decode nominatim response: %w", err)
+	}
+	return nil
+}
+
+// firstNonEmpty returns the first of values that is not empty.
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
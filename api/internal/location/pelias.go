@@ -0,0 +1,144 @@
+package location
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/outbound"
+)
+
+// peliasGeocoder is a Geocoder backed by a Pelias server.
+type peliasGeocoder struct {
+	http    *outbound.Client
+	baseURL string
+	apiKey  string
+}
+
+// NewPeliasGeocoder creates a Geocoder for the Pelias API at baseURL, the
+// server root without /v1. apiKey is sent as api_key for hosted Pelias
+// services and omitted when empty, as self-hosted servers need none.
+func NewPeliasGeocoder(baseURL, apiKey string, client *outbound.Client) Geocoder {
+	return &peliasGeocoder{
+		http:    client,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+	}
+}
+
+// peliasResponse is the subset of the GeoJSON response we read.
+type peliasResponse struct {
+	Geocoding struct {
+		Errors []string `json:"errors"`
+	} `json:"geocoding"`
+	Features []peliasFeature `json:"features"`
+}
+
+// peliasFeature is the subset of a GeoJSON feature we read.
+type peliasFeature struct {
+	Geometry struct {
+		Coordinates []float64 `json:"coordinates"`
+	} `json:"geometry"`
+	Properties struct {
+		HouseNumber string  `json:"housenumber"`
+		Street      string  `json:"street"`
+		Locality    string  `json:"locality"`
+		County      string  `json:"county"`
+		Region      string  `json:"region"`
+		RegionAbbr  string  `json:"region_a"`
+		PostalCode  string  `json:"postalcode"`
+		Confidence  float64 `json:"confidence"`
+	} `json:"properties"`
+}
+
+// address converts the feature's properties to an Address; nil if they name
+// no street or city.
+func (f *peliasFeature) address() *Address {
+	p := f.Properties
+	return Address{
+		HouseNumber: p.HouseNumber,
+		Street:      p.Street,
+		City:        p.Locality,
+		County:      p.County,
+		State:       firstNonEmpty(p.RegionAbbr, p.Region),
+		PostalCode:  p.PostalCode,
+	}.normalize()
+}
+
+// Reverse calls GET /v1/reverse for the nearest address point.
+func (g *peliasGeocoder) Reverse(ctx context.Context, point models.LatLng) (*Address, error) {
+	body, err := g.get(ctx, "/v1/reverse", url.Values{
+		"point.lat": {strconv.FormatFloat(point.Lat, 'f', -1, 64)},
+		"point.lon": {strconv.FormatFloat(point.Lng, 'f', -1, 64)},
+		"layers":    {"address"},
+		"size":      {"1"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(body.Features) == 0 {
+		return nil, nil
+	}
+	return body.Features[0].address(), nil
+}
+
+// Search calls GET /v1/search; Pelias' confidence is the relevance score.
+func (g *peliasGeocoder) Search(ctx context.Context, query string, limit int) ([]Match, error) {
+	body, err := g.get(ctx, "/v1/search", url.Values{
+		"text": {query},
+		"size": {strconv.Itoa(limit)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return body.matches()
+}
+
+// get requests path with query, adding the API key when one is configured.
+func (g *peliasGeocoder) get(ctx context.Context, path string, query url.Values) (*peliasResponse, error) {
+	if g.apiKey != "" {
+		query.Set("api_key", g.apiKey)
+	}
+	// Errors from the outbound client never include the URL, which may hold the API key
+	resp, err := g.http.Get(ctx, g.baseURL+path+"?"+query.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("pelias request failed: %w", err)
+	}
+
+	var body peliasResponse
+	if err := json.Unmarshal(resp.Body, &body); err != nil {
+		return nil, fmt.Errorf("failed to decode pelias response (status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("pelias error (status %d): %s", resp.StatusCode, strings.Join(body.Geocoding.Errors, "; "))
+	}
+	return &body, nil
+}
+
+// matches converts the features to matches, leaving out those that name no
+// street or city.
+func (r *peliasResponse) matches() ([]Match, error) {
+	matches := make([]Match, 0, len(r.Features))
+	for _, feature := range r.Features {
+		address := feature.address()
+		if address == nil {
+			continue
+		}
+		coordinates := feature.Geometry.Coordinates
+		if len(coordinates) < 2 {
+			return nil, fmt.Errorf("pelias feature without point coordinates")
+		}
+		point, err := models.NewLatLng(coordinates[1], coordinates[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid pelias coordinates: %w", err)
+		}
+		matches = append(matches, Match{Address: *address, Point: point, Score: feature.Properties.Confidence})
+	}
+	return matches, nil
+}
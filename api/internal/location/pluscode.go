@@ -0,0 +1,110 @@
+// Package location resolves location references that field crews report instead of raw
+// coordinates: Open Location Codes (plus codes) and what3words addresses.
+package location
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// Open Location Code constants
+const (
+	plusCodeAlphabet  = "23456789CFGHJMPQRVWX"
+	plusCodeSeparator = '+'
+	plusCodePadding   = '0'
+	// plusCodeSeparatorPosition is the index of the separator in a full code
+	plusCodeSeparatorPosition = 8
+	// plusCodePairLength is the number of digits encoded as lat/lng pairs; later digits use the 4x5 grid
+	plusCodePairLength = 10
+	// plusCodeMaxLength caps the digits read; further digits are below a millimetre
+	plusCodeMaxLength = 15
+	gridRows          = 5
+	gridColumns       = 4
+)
+
+// Plus code errors
+var (
+	ErrInvalidPlusCode = errors.New("invalid plus code")
+	ErrShortPlusCode   = errors.New("short plus codes need a locality; use the full code (e.g. 8FVC9G8F+6X)")
+)
+
+// DecodePlusCode decodes a full plus code (Open Location Code) to the center of its area.
+// Codes are case-insensitive; padded codes such as "8FVC0000+" decode to the center of
+// the larger area.
+// Returns ErrShortPlusCode for codes shortened relative to a locality and
+// ErrInvalidPlusCode for anything else that is not a valid full code.
+func DecodePlusCode(code string) (models.LatLng, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if err := validatePlusCode(code); err != nil {
+		return models.LatLng{}, err
+	}
+
+	digits := strings.NewReplacer(string(plusCodeSeparator), "", string(plusCodePadding), "").Replace(code)
+	if len(digits) > plusCodeMaxLength {
+		digits = digits[:plusCodeMaxLength]
+	}
+
+	// Each pair narrows the area 20x in both axes, starting from 20 degree cells
+	lat, lng := models.MinLatitude, models.MinLongitude
+	latRes, lngRes := 400.0, 400.0
+	for i := 0; i < len(digits) && i < plusCodePairLength; i += 2 {
+		latRes /= 20
+		lngRes /= 20
+		lat += float64(strings.IndexByte(plusCodeAlphabet, digits[i])) * latRes
+		lng += float64(strings.IndexByte(plusCodeAlphabet, digits[i+1])) * lngRes
+	}
+
+	// Grid digits pick one of 4 columns and 5 rows of the current area
+	for i := plusCodePairLength; i < len(digits); i++ {
+		latRes /= gridRows
+		lngRes /= gridColumns
+		value := strings.IndexByte(plusCodeAlphabet, digits[i])
+		lat += float64(value/gridColumns) * latRes
+		lng += float64(value%gridColumns) * lngRes
+	}
+
+	return models.NewLatLng(lat+latRes/2, lng+lngRes/2)
+}
+
+// validatePlusCode checks the separator, padding and digits of an upper-cased code.
+func validatePlusCode(code string) error {
+	sep := strings.IndexByte(code, plusCodeSeparator)
+	if sep < 0 || strings.LastIndexByte(code, plusCodeSeparator) != sep {
+		return fmt.Errorf("%w: %q must contain exactly one %q", ErrInvalidPlusCode, code, plusCodeSeparator)
+	}
+	if sep > plusCodeSeparatorPosition || sep%2 != 0 {
+		return fmt.Errorf("%w: %q has a misplaced separator", ErrInvalidPlusCode, code)
+	}
+	if sep < plusCodeSeparatorPosition {
+		return fmt.Errorf("%w: %q", ErrShortPlusCode, code)
+	}
+
+	suffix := code[sep+1:]
+	if len(suffix) == 1 {
+		return fmt.Errorf("%w: %q needs at least two digits after the separator", ErrInvalidPlusCode, code)
+	}
+
+	prefix := code[:sep]
+	if pad := strings.IndexByte(prefix, plusCodePadding); pad >= 0 {
+		if pad == 0 || pad%2 != 0 || strings.Trim(prefix[pad:], string(plusCodePadding)) != "" || suffix != "" {
+			return fmt.Errorf("%w: %q has invalid padding", ErrInvalidPlusCode, code)
+		}
+		prefix = prefix[:pad]
+	}
+
+	for _, r := range prefix + suffix {
+		if !strings.ContainsRune(plusCodeAlphabet, r) {
+			return fmt.Errorf("%w: %q contains invalid character %q", ErrInvalidPlusCode, code, r)
+		}
+	}
+
+	// The first pair covers 9 latitude and 18 longitude bands of 20 degrees
+	if strings.IndexByte(plusCodeAlphabet, prefix[0]) >= 9 || strings.IndexByte(plusCodeAlphabet, prefix[1]) >= 18 {
+		return fmt.Errorf("%w: %q is outside the valid coordinate range", ErrInvalidPlusCode, code)
+	}
+
+	return nil
+}
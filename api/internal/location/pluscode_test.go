@@ -0,0 +1,70 @@
+package location
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodePlusCode(t *testing.T) {
+	tests := []struct {
+		code string
+		lat  float64
+		lng  float64
+	}{
+		// Zurich, from the Open Location Code test data
+		{code: "8FVC9G8F+6X", lat: 47.3655625, lng: 8.5249375},
+		{code: "8fvc9g8f+6x", lat: 47.3655625, lng: 8.5249375},
+		{code: " 8FVC9G8F+6X ", lat: 47.3655625, lng: 8.5249375},
+		// A grid digit (Q = row 3, column 3) narrows the area further
+		{code: "8FVC9G8F+6XQ", lat: 47.3655875, lng: 8.524984375},
+		// Padded codes decode to the center of the larger area
+		{code: "8FVC0000+", lat: 47.5, lng: 8.5},
+		{code: "8FVC9G00+", lat: 47.375, lng: 8.525},
+		// Montgomery County, TX
+		{code: "86268GXX+3W", lat: 30.3476875, lng: -95.4501875},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			point, err := DecodePlusCode(tt.code)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.lat, point.Lat, 1e-9)
+			assert.InDelta(t, tt.lng, point.Lng, 1e-9)
+		})
+	}
+}
+
+func TestDecodePlusCode_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+	}{
+		{"empty", ""},
+		{"no separator", "8FVC9G8F6X"},
+		{"two separators", "8FVC9G8F+6X+"},
+		{"separator too late", "8FVC9G8F6+X"},
+		{"odd separator", "8FVC9G8+F6X"},
+		{"one digit after separator", "8FVC9G8F+6"},
+		{"invalid character", "8FVC9G8A+6X"},
+		{"padding then digits", "8FVC0000+6X"},
+		{"odd padding", "8FVC9000+"},
+		{"padding in the middle", "8FVC00G8+"},
+		{"padding at the start", "00000000+"},
+		{"latitude out of range", "F2000000+"},
+		{"longitude out of range", "2X000000+"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodePlusCode(tt.code)
+			assert.ErrorIs(t, err, ErrInvalidPlusCode)
+		})
+	}
+}
+
+func TestDecodePlusCode_Short(t *testing.T) {
+	_, err := DecodePlusCode("9G8F+6X")
+	assert.ErrorIs(t, err, ErrShortPlusCode)
+}
@@ -0,0 +1,111 @@
+package location
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/outbound"
+)
+
+// DefaultWhat3WordsBaseURL is the what3words v3 API root.
+const DefaultWhat3WordsBaseURL = "https://api.what3words.com/v3"
+
+// What3WordsProvider names the what3words client in outbound stats.
+const What3WordsProvider = "what3words"
+
+// what3words errors
+var (
+	ErrInvalidWhat3Words = errors.New("invalid what3words address")
+	ErrUnknownWhat3Words = errors.New("what3words address does not exist")
+)
+
+// what3wordsPattern matches three dot-separated words; words may use any script.
+var what3wordsPattern = regexp.MustCompile(`^\p{L}+\.\p{L}+\.\p{L}+$`)
+
+// What3WordsClient converts what3words addresses to coordinates.
+// Implementations call an external service, so they must honour ctx cancellation.
+type What3WordsClient interface {
+	// ConvertToCoordinates returns the center of the 3 m square named by words
+	// (e.g. "filled.count.soap", already normalized by NormalizeWhat3Words).
+	// Returns ErrUnknownWhat3Words if the address does not exist.
+	// Returns error for network or service failures.
+	ConvertToCoordinates(ctx context.Context, words string) (models.LatLng, error)
+}
+
+// NormalizeWhat3Words trims the optional "///" prefix and surrounding space and
+// lower-cases the address.
+// Returns ErrInvalidWhat3Words if the result is not three dot-separated words.
+func NormalizeWhat3Words(words string) (string, error) {
+	words = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(words), "///"))
+	if !what3wordsPattern.MatchString(words) {
+		return "", fmt.Errorf("%w: %q must be three words separated by dots", ErrInvalidWhat3Words, words)
+	}
+	return words, nil
+}
+
+// httpWhat3WordsClient is a What3WordsClient backed by the what3words REST API.
+type httpWhat3WordsClient struct {
+	http    *outbound.Client
+	baseURL string
+	apiKey  string
+}
+
+// NewWhat3WordsClient creates a What3WordsClient for the what3words REST API.
+// baseURL is the API root (DefaultWhat3WordsBaseURL in production); client
+// applies the rate limit, retries and response cache. Addresses never move,
+// so responses can be cached for long.
+func NewWhat3WordsClient(apiKey, baseURL string, client *outbound.Client) What3WordsClient {
+	return &httpWhat3WordsClient{
+		http:    client,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+	}
+}
+
+// what3wordsResponse is the subset of the convert-to-coordinates response we read.
+type what3wordsResponse struct {
+	Coordinates *struct {
+		Lat float64 `json:"lat"`
+		Lng float64 `json:"lng"`
+	} `json:"coordinates"`
+	Error *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// ConvertToCoordinates calls GET /convert-to-coordinates. The API reports unknown
+// addresses as a BadWords error.
+func (c *httpWhat3WordsClient) ConvertToCoordinates(ctx context.Context, words string) (models.LatLng, error) {
+	query := url.Values{"words": {words}, "key": {c.apiKey}}
+	// Errors from the outbound client never include the URL, which holds the API key
+	resp, err := c.http.Get(ctx, c.baseURL+"/convert-to-coordinates?"+query.Encode())
+	if err != nil {
+		return models.LatLng{}, fmt.Errorf("what3words request failed: %w", err)
+	}
+
+	var body what3wordsResponse
+	if err := json.Unmarshal(resp.Body, &body); err != nil {
+		return models.LatLng{}, fmt.Errorf("failed to decode what3words response (status %d): %w", resp.StatusCode, err)
+	}
+
+	if body.Error != nil {
+		if body.Error.Code == "BadWords" {
+			return models.LatLng{}, fmt.Errorf("%w: %s", ErrUnknownWhat3Words, words)
+		}
+		return models.LatLng{}, fmt.Errorf("what3words error %s (status %d): %s",
+			body.Error.Code, resp.StatusCode, body.Error.Message)
+	}
+	if resp.StatusCode != http.StatusOK || body.Coordinates == nil {
+		return models.LatLng{}, fmt.Errorf("unexpected what3words response (status %d)", resp.StatusCode)
+	}
+
+	return models.NewLatLng(body.Coordinates.Lat, body.Coordinates.Lng)
+}
@@ -0,0 +1,97 @@
+package location
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/outbound"
+)
+
+func TestNormalizeWhat3Words(t *testing.T) {
+	for input, want := range map[string]string{
+		"filled.count.soap":     "filled.count.soap",
+		"///Filled.Count.Soap":  "filled.count.soap",
+		"  filled.count.soap  ": "filled.count.soap",
+		"écoute.maison.vélo":    "écoute.maison.vélo",
+	} {
+		got, err := NormalizeWhat3Words(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got)
+	}
+
+	for _, input := range []string{"", "filled.count", "filled.count.soap.extra", "filled count soap", "filled.c0unt.soap"} {
+		_, err := NormalizeWhat3Words(input)
+		assert.ErrorIs(t, err, ErrInvalidWhat3Words, input)
+	}
+}
+
+// testWhat3WordsHTTP returns an outbound client that does not retry.
+func testWhat3WordsHTTP() *outbound.Client {
+	return outbound.NewClient(What3WordsProvider, outbound.Options{Timeout: time.Second, MaxRetries: -1})
+}
+
+// newTestWhat3WordsServer serves a fixed status and body for convert-to-coordinates.
+func newTestWhat3WordsServer(t *testing.T, status int, body string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/convert-to-coordinates", r.URL.Path)
+		assert.Equal(t, "filled.count.soap", r.URL.Query().Get("words"))
+		assert.Equal(t, "test-key", r.URL.Query().Get("key"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWhat3WordsClient_ConvertToCoordinates(t *testing.T) {
+	server := newTestWhat3WordsServer(t, http.StatusOK,
+		`{"words":"filled.count.soap","coordinates":{"lng":-0.195543,"lat":51.520847}}`)
+	client := NewWhat3WordsClient("test-key", server.URL+"/v3/", testWhat3WordsHTTP())
+
+	point, err := client.ConvertToCoordinates(context.Background(), "filled.count.soap")
+
+	require.NoError(t, err)
+	assert.Equal(t, 51.520847, point.Lat)
+	assert.Equal(t, -0.195543, point.Lng)
+}
+
+func TestWhat3WordsClient_UnknownAddress(t *testing.T) {
+	server := newTestWhat3WordsServer(t, http.StatusBadRequest,
+		`{"error":{"code":"BadWords","message":"Invalid or non-existent 3 word address"}}`)
+	client := NewWhat3WordsClient("test-key", server.URL+"/v3", testWhat3WordsHTTP())
+
+	_, err := client.ConvertToCoordinates(context.Background(), "filled.count.soap")
+
+	assert.ErrorIs(t, err, ErrUnknownWhat3Words)
+}
+
+func TestWhat3WordsClient_ServiceError(t *testing.T) {
+	server := newTestWhat3WordsServer(t, http.StatusUnauthorized,
+		`{"error":{"code":"InvalidKey","message":"Authentication failed; invalid API key"}}`)
+	client := NewWhat3WordsClient("test-key", server.URL+"/v3", testWhat3WordsHTTP())
+
+	_, err := client.ConvertToCoordinates(context.Background(), "filled.count.soap")
+
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrUnknownWhat3Words)
+	assert.Contains(t, err.Error(), "InvalidKey")
+	assert.NotContains(t, err.Error(), "test-key")
+}
+
+func TestWhat3WordsClient_RequestErrorHidesKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+	client := NewWhat3WordsClient("test-key", server.URL+"/v3", testWhat3WordsHTTP())
+
+	_, err := client.ConvertToCoordinates(context.Background(), "filled.count.soap")
+
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "test-key")
+}
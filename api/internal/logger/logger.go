@@ -1,16 +1,24 @@
 package logger
 
 import (
+	"fmt"
 	"io"
 	"os"
+	"sync/atomic"
 	"time"
 
 	"github.com/rs/zerolog"
 )
 
+// Levels are the log levels accepted by SetLevel, most verbose first.
+var Levels = []string{"debug", "info", "warn", "error"}
+
 // Logger wraps zerolog.Logger and provides structured logging capabilities.
 type Logger struct {
 	zlog zerolog.Logger
+	// level is shared with the loggers derived by With and WithRequestID, so
+	// SetLevel applies to them too; nil leaves filtering to zlog
+	level *atomic.Int32
 }
 
 // New creates a new Logger instance configured for the given environment.
@@ -34,25 +42,52 @@ func New(env string) *Logger {
 	// Configure global settings
 	zerolog.TimeFieldFormat = time.RFC3339
 
-	// Set log level based on environment
-	level := zerolog.InfoLevel
-	if env == "development" {
-		level = zerolog.DebugLevel
-	}
-
-	// Create logger
+	// Create logger; the level is checked by event so SetLevel can change it
 	zlog := zerolog.New(output).
-		Level(level).
 		With().
 		Timestamp().
 		Logger()
 
-	return &Logger{zlog: zlog}
+	l := &Logger{zlog: zlog, level: new(atomic.Int32)}
+	_ = l.SetLevel(DefaultLevel(env))
+	return l
+}
+
+// DefaultLevel returns the log level of env: debug in development, info otherwise.
+func DefaultLevel(env string) string {
+	if env == "development" {
+		return "debug"
+	}
+	return "info"
+}
+
+// SetLevel changes the level of l and of every logger derived from it, e.g. to
+// debug an incident without a restart. level is one of Levels.
+func (l *Logger) SetLevel(level string) error {
+	parsed, err := zerolog.ParseLevel(level)
+	if err != nil || parsed < zerolog.DebugLevel || parsed > zerolog.ErrorLevel {
+		return fmt.Errorf("unknown log level %q", level)
+	}
+	if l.level == nil {
+		l.zlog = l.zlog.Level(parsed)
+		return nil
+	}
+	l.level.Store(int32(parsed))
+	return nil
+}
+
+// event starts an event at level, or returns nil, which discards the event,
+// below the logger's level.
+func (l *Logger) event(level zerolog.Level) *zerolog.Event {
+	if l.level != nil && level < zerolog.Level(l.level.Load()) {
+		return nil
+	}
+	return l.zlog.WithLevel(level)
 }
 
 // Debug logs a debug message with optional fields.
 func (l *Logger) Debug(msg string, fields map[string]interface{}) {
-	event := l.zlog.Debug()
+	event := l.event(zerolog.DebugLevel)
 	for key, value := range fields {
 		event = event.Interface(key, value)
 	}
@@ -61,7 +96,7 @@ func (l *Logger) Debug(msg string, fields map[string]interface{}) {
 
 // Info logs an info message with optional fields.
 func (l *Logger) Info(msg string, fields map[string]interface{}) {
-	event := l.zlog.Info()
+	event := l.event(zerolog.InfoLevel)
 	for key, value := range fields {
 		event = event.Interface(key, value)
 	}
@@ -70,7 +105,7 @@ func (l *Logger) Info(msg string, fields map[string]interface{}) {
 
 // Warn logs a warning message with optional fields.
 func (l *Logger) Warn(msg string, fields map[string]interface{}) {
-	event := l.zlog.Warn()
+	event := l.event(zerolog.WarnLevel)
 	for key, value := range fields {
 		event = event.Interface(key, value)
 	}
@@ -79,7 +114,7 @@ func (l *Logger) Warn(msg string, fields map[string]interface{}) {
 
 // Error logs an error message with an error and optional fields.
 func (l *Logger) Error(msg string, err error, fields map[string]interface{}) {
-	event := l.zlog.Error().Err(err)
+	event := l.event(zerolog.ErrorLevel).Err(err)
 	for key, value := range fields {
 		event = event.Interface(key, value)
 	}
@@ -102,17 +137,19 @@ func (l *Logger) With(fields map[string]interface{}) *Logger {
 	for key, value := range fields {
 		ctx = ctx.Interface(key, value)
 	}
-	return &Logger{zlog: ctx.Logger()}
+	return &Logger{zlog: ctx.Logger(), level: l.level}
 }
 
 // WithRequestID creates a child logger with a request ID field.
 func (l *Logger) WithRequestID(requestID string) *Logger {
 	return &Logger{
-		zlog: l.zlog.With().Str("request_id", requestID).Logger(),
+		zlog:  l.zlog.With().Str("request_id", requestID).Logger(),
+		level: l.level,
 	}
 }
 
 // GetZerolog returns the underlying zerolog.Logger for advanced usage.
+// Events logged through it directly are not filtered by SetLevel.
 func (l *Logger) GetZerolog() *zerolog.Logger {
 	return &l.zlog
 }
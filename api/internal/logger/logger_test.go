@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/rs/zerolog"
@@ -233,3 +234,34 @@ func TestNilFields(t *testing.T) {
 		t.Error("Expected message to be logged even with nil fields")
 	}
 }
+
+func TestSetLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &Logger{zlog: zerolog.New(&buf), level: new(atomic.Int32)}
+	child := logger.WithRequestID("req-1")
+
+	if err := logger.SetLevel("warn"); err != nil {
+		t.Fatalf("SetLevel() failed: %v", err)
+	}
+	logger.Info("hidden info", nil)
+	child.Info("hidden child info", nil)
+	child.Warn("child warning", nil)
+	if output := buf.String(); strings.Contains(output, "hidden") || !strings.Contains(output, "child warning") {
+		t.Errorf("Expected only the warning at warn level, got %s", output)
+	}
+
+	buf.Reset()
+	if err := logger.SetLevel("debug"); err != nil {
+		t.Fatalf("SetLevel() failed: %v", err)
+	}
+	child.Debug("child debug", nil)
+	if !strings.Contains(buf.String(), "child debug") {
+		t.Error("Expected derived loggers to follow the new level")
+	}
+
+	for _, level := range []string{"verbose", "fatal", ""} {
+		if err := logger.SetLevel(level); err == nil {
+			t.Errorf("Expected an error for level %q", level)
+		}
+	}
+}
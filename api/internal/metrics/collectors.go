@@ -0,0 +1,147 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/outbound"
+)
+
+// HTTP records request counts, latencies and response cache results per route.
+type HTTP struct {
+	requests *CounterVec
+	duration *HistogramVec
+	cache    *CounterVec
+}
+
+// NewHTTP registers the HTTP request metrics on r.
+func NewHTTP(r *Registry) *HTTP {
+	return &HTTP{
+		requests: r.Counter("atlas_http_requests_total",
+			"HTTP requests by route name and status code.", "route", "status"),
+		duration: r.Histogram("atlas_http_request_duration_seconds",
+			"HTTP request latency by route name.", DefaultBuckets, "route"),
+		cache: r.Counter("atlas_response_cache_requests_total",
+			"Requests of cached routes by route name and result (hit or miss).", "route", "result"),
+	}
+}
+
+// Observe records a finished request.
+func (h *HTTP) Observe(route string, status int, elapsed time.Duration) {
+	h.requests.Inc(route, strconv.Itoa(status))
+	h.duration.Observe(elapsed.Seconds(), route)
+}
+
+// ObserveCache records whether a response cache served the request.
+func (h *HTTP) ObserveCache(route, result string) {
+	h.cache.Inc(route, result)
+}
+
+// RegisterPool registers the statistics of a pgx connection pool on r, read on every scrape.
+func RegisterPool(r *Registry, pool *pgxpool.Pool) {
+	gauge := func(name, help string, value func(s *pgxpool.Stat) float64) {
+		r.Func(name, help, KindGauge, nil, func() []Sample {
+			return []Sample{{Value: value(pool.Stat())}}
+		})
+	}
+	counter := func(name, help string, value func(s *pgxpool.Stat) float64) {
+		r.Func(name, help, KindCounter, nil, func() []Sample {
+			return []Sample{{Value: value(pool.Stat())}}
+		})
+	}
+
+	gauge("atlas_db_pool_acquired_connections", "Connections currently checked out of the pool.",
+		func(s *pgxpool.Stat) float64 { return float64(s.AcquiredConns()) })
+	gauge("atlas_db_pool_idle_connections", "Idle connections in the pool.",
+		func(s *pgxpool.Stat) float64 { return float64(s.IdleConns()) })
+	gauge("atlas_db_pool_total_connections", "Open connections, including those being established.",
+		func(s *pgxpool.Stat) float64 { return float64(s.TotalConns()) })
+	gauge("atlas_db_pool_max_connections", "Maximum size of the pool.",
+		func(s *pgxpool.Stat) float64 { return float64(s.MaxConns()) })
+	counter("atlas_db_pool_acquires_total", "Connections acquired from the pool.",
+		func(s *pgxpool.Stat) float64 { return float64(s.AcquireCount()) })
+	counter("atlas_db_pool_empty_acquires_total", "Acquires that waited because no idle connection was available.",
+		func(s *pgxpool.Stat) float64 { return float64(s.EmptyAcquireCount()) })
+	counter("atlas_db_pool_acquire_wait_seconds_total", "Time spent waiting for a connection by acquires that found the pool empty.",
+		func(s *pgxpool.Stat) float64 { return s.EmptyAcquireWaitTime().Seconds() })
+	counter("atlas_db_pool_canceled_acquires_total", "Acquires canceled by their context while waiting.",
+		func(s *pgxpool.Stat) float64 { return float64(s.CanceledAcquireCount()) })
+}
+
+// CircuitStats reports the database circuit breaker, retries and read replica
+// failovers; *database.Database implements it.
+type CircuitStats interface {
+	CircuitState() string
+	RejectedConnects() int64
+	Retries() int64
+	Failovers() int64
+	ReplicasUp() (up, total int)
+}
+
+// RegisterCircuit registers the state of the database circuit breaker, the
+// retries of transient errors and the read replicas on r, read on every scrape.
+func RegisterCircuit(r *Registry, circuit CircuitStats) {
+	r.Func("atlas_db_circuit_open", "1 while the database circuit breaker is open or half-open.", KindGauge, nil,
+		func() []Sample {
+			open := 0.0
+			if circuit.CircuitState() != database.CircuitClosed {
+				open = 1
+			}
+			return []Sample{{Value: open}}
+		})
+	r.Func("atlas_db_rejected_connects_total", "New connections refused while the database circuit was open.", KindCounter, nil,
+		func() []Sample { return []Sample{{Value: float64(circuit.RejectedConnects())}} })
+	r.Func("atlas_db_retries_total", "Queries run again after a transient database error.", KindCounter, nil,
+		func() []Sample { return []Sample{{Value: float64(circuit.Retries())}} })
+	r.Func("atlas_db_replica_failovers_total", "Reads moved off a read replica that could not be reached.", KindCounter, nil,
+		func() []Sample { return []Sample{{Value: float64(circuit.Failovers())}} })
+	r.Func("atlas_db_replicas_up", "Read replicas serving reads; replicas that failed are skipped for DB_REPLICA_COOLDOWN.", KindGauge, nil,
+		func() []Sample {
+			up, _ := circuit.ReplicasUp()
+			return []Sample{{Value: float64(up)}}
+		})
+}
+
+// ProviderStats reports the outbound client counters; *outbound.Registry implements it.
+type ProviderStats interface {
+	Stats() []outbound.Stats
+}
+
+// RegisterProviders registers the counters of the outbound provider clients on r,
+// labeled by provider, read on every scrape.
+func RegisterProviders(r *Registry, providers ProviderStats) {
+	counter := func(name, help string, value func(s outbound.Stats) int64) {
+		r.Func(name, help, KindCounter, []string{"provider"}, func() []Sample {
+			stats := providers.Stats()
+			samples := make([]Sample, 0, len(stats))
+			for _, s := range stats {
+				samples = append(samples, Sample{Labels: []string{s.Name}, Value: float64(value(s))})
+			}
+			return samples
+		})
+	}
+
+	counter("atlas_outbound_requests_total", "Calls to an external provider, including cache hits and rejections.",
+		func(s outbound.Stats) int64 { return s.Requests })
+	counter("atlas_outbound_cache_hits_total", "Provider calls answered from the client cache.",
+		func(s outbound.Stats) int64 { return s.CacheHits })
+	counter("atlas_outbound_failures_total", "Provider calls that failed after retries.",
+		func(s outbound.Stats) int64 { return s.Failures })
+	counter("atlas_outbound_rejected_total", "Provider calls refused while the circuit was open.",
+		func(s outbound.Stats) int64 { return s.Rejected })
+	r.Func("atlas_outbound_circuit_open", "1 while the provider's circuit breaker is open or half-open.", KindGauge,
+		[]string{"provider"}, func() []Sample {
+			stats := providers.Stats()
+			samples := make([]Sample, 0, len(stats))
+			for _, s := range stats {
+				open := 0.0
+				if s.Circuit != outbound.CircuitClosed {
+					open = 1
+				}
+				samples = append(samples, Sample{Labels: []string{s.Name}, Value: open})
+			}
+			return samples
+		})
+}
@@ -0,0 +1,310 @@
+// Package metrics keeps counters, histograms and scrape-time collectors and
+// writes them in the Prometheus text exposition format (version 0.0.4).
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the media type of the text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets are latency histogram bounds in seconds, from 5 ms to 10 s.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Kind is the Prometheus metric type of a collector.
+type Kind string
+
+// Metric kinds of collectors
+const (
+	KindCounter Kind = "counter"
+	KindGauge   Kind = "gauge"
+)
+
+// Sample is a value read by a collector, with one label value per label name.
+type Sample struct {
+	Labels []string
+	Value  float64
+}
+
+// Registry holds the metric families of a process. Families are written in the
+// order they were registered; registering a name twice panics.
+type Registry struct {
+	families []family
+	names    map[string]bool
+	mu       sync.Mutex
+}
+
+// family is a registered metric family.
+type family interface {
+	write(w *bufio.Writer)
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{names: make(map[string]bool)}
+}
+
+// register adds a family, panicking on a duplicate name.
+func (r *Registry) register(name string, f family) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.names[name] {
+		panic(fmt.Sprintf("metrics: %s registered twice", name))
+	}
+	r.names[name] = true
+	r.families = append(r.families, f)
+}
+
+// Counter registers a counter with the given label names.
+func (r *Registry) Counter(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{desc: desc{name: name, help: help, labels: labels}, values: make(map[string]*counterValue)}
+	r.register(name, c)
+	return c
+}
+
+// Histogram registers a histogram with the given upper bounds, in increasing
+// order, and label names.
+func (r *Registry) Histogram(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	h := &HistogramVec{desc: desc{name: name, help: help, labels: labels}, buckets: buckets, values: make(map[string]*histogramValue)}
+	r.register(name, h)
+	return h
+}
+
+// Func registers a collector called on every scrape, for values kept elsewhere
+// such as connection pool statistics. collect returns one sample per label set.
+func (r *Registry) Func(name, help string, kind Kind, labels []string, collect func() []Sample) {
+	r.register(name, &funcFamily{desc: desc{name: name, help: help, labels: labels}, kind: kind, collect: collect})
+}
+
+// Write writes every family in the text exposition format.
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	families := append([]family(nil), r.families...)
+	r.mu.Unlock()
+
+	bw := bufio.NewWriter(w)
+	for _, f := range families {
+		f.write(bw)
+	}
+	return bw.Flush()
+}
+
+// ServeHTTP serves the metrics to a Prometheus scrape.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	_ = r.Write(w)
+}
+
+// desc is the name, help text and label names of a family.
+type desc struct {
+	name   string
+	help   string
+	labels []string
+}
+
+// header writes the HELP and TYPE lines.
+func (d *desc) header(w *bufio.Writer, kind string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", d.name, helpEscaper.Replace(d.help), d.name, kind)
+}
+
+// key joins label values into a map key; label values never contain NUL.
+func (d *desc) key(values []string) string {
+	if len(values) != len(d.labels) {
+		panic(fmt.Sprintf("metrics: %s has %d labels, got %d values", d.name, len(d.labels), len(values)))
+	}
+	return strings.Join(values, "\x00")
+}
+
+// sample writes one sample line; extra is an additional label such as le.
+func (d *desc) sample(w *bufio.Writer, suffix string, values []string, extra string, value float64) {
+	w.WriteString(d.name + suffix)
+	if len(values) > 0 || extra != "" {
+		w.WriteByte('{')
+		for i, name := range d.labels {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(name + `="` + labelEscaper.Replace(values[i]) + `"`)
+		}
+		if extra != "" {
+			if len(values) > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(extra)
+		}
+		w.WriteByte('}')
+	}
+	w.WriteByte(' ')
+	w.WriteString(formatValue(value))
+	w.WriteByte('\n')
+}
+
+// CounterVec is a counter partitioned by label values.
+type CounterVec struct {
+	values map[string]*counterValue
+	desc
+	mu sync.Mutex
+}
+
+// counterValue is the count of one label set.
+type counterValue struct {
+	labels []string
+	value  float64
+}
+
+// Inc adds one to the counter of the label values.
+func (c *CounterVec) Inc(values ...string) {
+	c.Add(1, values...)
+}
+
+// Add adds delta, which must not be negative, to the counter of the label values.
+func (c *CounterVec) Add(delta float64, values ...string) {
+	key := c.key(values)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.values[key]
+	if !ok {
+		v = &counterValue{labels: append([]string(nil), values...)}
+		c.values[key] = v
+	}
+	v.value += delta
+}
+
+// Value returns the counter of the label values.
+func (c *CounterVec) Value(values ...string) float64 {
+	key := c.key(values)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if v, ok := c.values[key]; ok {
+		return v.value
+	}
+	return 0
+}
+
+// write writes the counters sorted by label values.
+func (c *CounterVec) write(w *bufio.Writer) {
+	c.header(w, string(KindCounter))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range sortedKeys(c.values) {
+		v := c.values[key]
+		c.sample(w, "", v.labels, "", v.value)
+	}
+}
+
+// HistogramVec is a histogram partitioned by label values.
+type HistogramVec struct {
+	values  map[string]*histogramValue
+	buckets []float64
+	desc
+	mu sync.Mutex
+}
+
+// histogramValue is the distribution of one label set. counts[i] holds the
+// observations in bucket i alone; they are summed when written.
+type histogramValue struct {
+	labels []string
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// Observe records value in the histogram of the label values.
+func (h *HistogramVec) Observe(value float64, values ...string) {
+	key := h.key(values)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	v, ok := h.values[key]
+	if !ok {
+		v = &histogramValue{labels: append([]string(nil), values...), counts: make([]uint64, len(h.buckets))}
+		h.values[key] = v
+	}
+	if i := sort.SearchFloat64s(h.buckets, value); i < len(h.buckets) {
+		v.counts[i]++
+	}
+	v.sum += value
+	v.count++
+}
+
+// Count returns the number of observations of the label values.
+func (h *HistogramVec) Count(values ...string) uint64 {
+	key := h.key(values)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if v, ok := h.values[key]; ok {
+		return v.count
+	}
+	return 0
+}
+
+// write writes the cumulative buckets, sum and count of each label set.
+func (h *HistogramVec) write(w *bufio.Writer) {
+	h.header(w, "histogram")
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, key := range sortedKeys(h.values) {
+		v := h.values[key]
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += v.counts[i]
+			h.sample(w, "_bucket", v.labels, `le="`+formatValue(bound)+`"`, float64(cumulative))
+		}
+		h.sample(w, "_bucket", v.labels, `le="+Inf"`, float64(v.count))
+		h.sample(w, "_sum", v.labels, "", v.sum)
+		h.sample(w, "_count", v.labels, "", float64(v.count))
+	}
+}
+
+// funcFamily is a family read from a collector on every scrape.
+type funcFamily struct {
+	collect func() []Sample
+	kind    Kind
+	desc
+}
+
+// write calls the collector and writes its samples.
+func (f *funcFamily) write(w *bufio.Writer) {
+	f.header(w, string(f.kind))
+	for _, s := range f.collect() {
+		f.key(s.Labels)
+		f.sample(w, "", s.Labels, "", s.Value)
+	}
+}
+
+// sortedKeys returns the keys of m in order, so output is stable between scrapes.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatValue formats a sample value the way Prometheus parses it.
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// labelEscaper escapes backslashes, quotes and newlines in label values.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// helpEscaper escapes backslashes and newlines in help text.
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
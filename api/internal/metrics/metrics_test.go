@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/outbound"
+)
+
+func TestRegistry_Write(t *testing.T) {
+	r := NewRegistry()
+	requests := r.Counter("test_requests_total", "Requests.\nBy route.", "route")
+	latency := r.Histogram("test_latency_seconds", "Latency.", []float64{0.1, 1}, "route")
+	r.Func("test_up", "Always up.", KindGauge, nil, func() []Sample { return []Sample{{Value: 1}} })
+
+	requests.Inc("b")
+	requests.Add(2, `a"\`)
+	latency.Observe(0.05, "a")
+	latency.Observe(0.5, "a")
+	latency.Observe(5, "a")
+
+	var out strings.Builder
+	require.NoError(t, r.Write(&out))
+	assert.Equal(t, `# HELP test_requests_total Requests.\nBy route.
+# TYPE test_requests_total counter
+test_requests_total{route="a\"\\"} 2
+test_requests_total{route="b"} 1
+# HELP test_latency_seconds Latency.
+# TYPE test_latency_seconds histogram
+test_latency_seconds_bucket{route="a",le="0.1"} 1
+test_latency_seconds_bucket{route="a",le="1"} 2
+test_latency_seconds_bucket{route="a",le="+Inf"} 3
+test_latency_seconds_sum{route="a"} 5.55
+test_latency_seconds_count{route="a"} 3
+# HELP test_up Always up.
+# TYPE test_up gauge
+test_up 1
+`, out.String())
+
+	assert.Equal(t, 1.0, requests.Value("b"))
+	assert.Equal(t, uint64(3), latency.Count("a"))
+}
+
+func TestRegistry_DuplicateName(t *testing.T) {
+	r := NewRegistry()
+	r.Counter("test_total", "Test.")
+	assert.Panics(t, func() { r.Counter("test_total", "Test.") })
+}
+
+func TestRegistry_ServeHTTP(t *testing.T) {
+	r := NewRegistry()
+	NewHTTP(r).Observe("health", 200, 20*time.Millisecond)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	assert.Equal(t, ContentType, w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `atlas_http_requests_total{route="health",status="200"} 1`)
+	assert.Contains(t, w.Body.String(), `atlas_http_request_duration_seconds_bucket{route="health",le="0.025"} 1`)
+}
+
+// openCircuit is a CircuitStats with an open circuit.
+type openCircuit struct{}
+
+func (openCircuit) CircuitState() string    { return database.CircuitOpen }
+func (openCircuit) RejectedConnects() int64 { return 3 }
+func (openCircuit) Retries() int64          { return 2 }
+func (openCircuit) Failovers() int64        { return 4 }
+func (openCircuit) ReplicasUp() (int, int)  { return 1, 2 }
+
+func TestRegisterCircuit(t *testing.T) {
+	r := NewRegistry()
+	RegisterCircuit(r, openCircuit{})
+
+	var out strings.Builder
+	require.NoError(t, r.Write(&out))
+	assert.Contains(t, out.String(), "atlas_db_circuit_open 1")
+	assert.Contains(t, out.String(), "atlas_db_rejected_connects_total 3")
+	assert.Contains(t, out.String(), "atlas_db_retries_total 2")
+	assert.Contains(t, out.String(), "atlas_db_replica_failovers_total 4")
+	assert.Contains(t, out.String(), "atlas_db_replicas_up 1")
+}
+
+func TestRegisterProviders(t *testing.T) {
+	providers := outbound.NewRegistry()
+	providers.Register(outbound.NewClient("what3words", outbound.Options{}))
+	r := NewRegistry()
+	RegisterProviders(r, providers)
+
+	var out strings.Builder
+	require.NoError(t, r.Write(&out))
+	assert.Contains(t, out.String(), `atlas_outbound_requests_total{provider="what3words"} 0`)
+	assert.Contains(t, out.String(), `atlas_outbound_circuit_open{provider="what3words"} 0`)
+}
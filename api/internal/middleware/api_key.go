@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+const (
+	// APIKeyKey is the context key for the authenticated API key
+	APIKeyKey = "api_key"
+	// APIKeyHeader is the HTTP header name clients send their API key in
+	APIKeyHeader = "X-API-Key"
+)
+
+// APIKeyValidator resolves the API key a client presented.
+type APIKeyValidator interface {
+	// Authenticate returns nil, nil if the key is unknown or revoked.
+	Authenticate(ctx context.Context, key string) (*models.APIKey, error)
+}
+
+// APIKeyAuth validates the X-API-Key header and stores the key in the context.
+// The key's tenant replaces the X-Tenant-ID header, and admin keys get the
+// admin role. Requests without a key pass through anonymously; routes that
+// need a key enforce it. Unknown or revoked keys, and keys sent with another
+// tenant's header, are rejected.
+//
+// It writes error responses itself, in the standard error shape, because the
+// errors package depends on this package. Mount it after Tenant.
+func APIKeyAuth(validator APIKeyValidator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		presented := strings.TrimSpace(c.GetHeader(APIKeyHeader))
+		if presented == "" {
+			c.Next()
+			return
+		}
+
+		key, err := validator.Authenticate(c.Request.Context(), presented)
+		if err != nil {
+			if log := GetLogger(c); log != nil {
+				log.Error("API key lookup failed", err, nil)
+			}
+			abortWithError(c, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "API key could not be verified")
+			return
+		}
+		if key == nil {
+			abortWithError(c, http.StatusUnauthorized, "UNAUTHORIZED", "Invalid API key")
+			return
+		}
+		if tenantID := GetTenantID(c); tenantID != "" && tenantID != key.TenantID {
+			abortWithError(c, http.StatusForbidden, "FORBIDDEN", "API key does not belong to tenant "+tenantID)
+			return
+		}
+
+		c.Set(APIKeyKey, key)
+		c.Set(TenantIDKey, key.TenantID)
+		if key.Role == models.APIKeyRoleAdmin {
+			c.Set(RoleKey, RoleAdmin)
+		}
+
+		// Attribute handler logs to the key
+		if log := GetLogger(c); log != nil {
+			c.Set("logger", log.With(apiKeyLogFields(key)))
+		}
+
+		c.Next()
+	}
+}
+
+// GetAPIKey retrieves the authenticated API key from the Gin context.
+// Returns nil if the request did not present a valid key.
+func GetAPIKey(c *gin.Context) *models.APIKey {
+	if key, exists := c.Get(APIKeyKey); exists {
+		if k, ok := key.(*models.APIKey); ok {
+			return k
+		}
+	}
+	return nil
+}
+
+// apiKeyLogFields identifies a key in logs without revealing it.
+func apiKeyLogFields(key *models.APIKey) map[string]interface{} {
+	return map[string]interface{}{
+		"api_key_id": key.ID,
+		"api_key":    key.Prefix,
+		"tenant_id":  key.TenantID,
+	}
+}
+
+// abortWithError writes an error response and stops the handler chain.
+func abortWithError(c *gin.Context, status int, code, message string) {
+	c.AbortWithStatusJSON(status, gin.H{
+		"error": gin.H{
+			"code":       code,
+			"message":    message,
+			"request_id": GetRequestID(c),
+		},
+	})
+}
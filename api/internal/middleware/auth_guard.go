@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// authGuardKey is the context key for the AuthGuard of BruteForceGuard
+	authGuardKey = "auth_guard"
+	// AuthSubjectKey is the context key for the credential the request attempted
+	AuthSubjectKey = "auth_subject"
+	// apiKeySubjectLength is the length of the key prefix naming an API key
+	// subject, matching the display prefix stored with keys
+	apiKeySubjectLength = 12
+	// maxAuthSubjectLength matches the security_events.subject column
+	maxAuthSubjectLength = 300
+)
+
+// AuthGuard tracks failed authentications per client IP and subject, the
+// credential attempted, and locks out those that keep failing.
+type AuthGuard interface {
+	// LockedFor returns how much longer the client IP or subject is locked out, or 0.
+	LockedFor(ip, subject string) time.Duration
+	// RecordFailure counts a failed authentication; subject may be empty.
+	RecordFailure(ip, subject, route string)
+	// RecordSuccess clears the failures of subject.
+	RecordSuccess(subject string)
+}
+
+// BruteForceGuard rejects locked out clients with 429 and a Retry-After header,
+// and reports the 401 responses of requests that presented credentials (an API
+// key, a bearer token, or a subject named with GuardSubject) to guard as
+// failures. Requests without credentials are not counted, so a client that
+// forgot its key is not locked out. Mount it before APIKeyAuth and UserAuth,
+// so locked out clients never reach the credential checks.
+func BruteForceGuard(guard AuthGuard) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ip := c.ClientIP()
+		subject := apiKeySubject(c.GetHeader(APIKeyHeader))
+		if wait := guard.LockedFor(ip, subject); wait > 0 {
+			abortLockedOut(c, wait)
+			return
+		}
+
+		c.Set(authGuardKey, guard)
+		if subject != "" {
+			c.Set(AuthSubjectKey, subject)
+		}
+
+		c.Next()
+
+		// A handler may have named the subject, e.g. the email of a login
+		subject = c.GetString(AuthSubjectKey)
+		status := c.Writer.Status()
+		switch {
+		case status == http.StatusUnauthorized && (subject != "" || c.GetHeader(AuthorizationHeader) != ""):
+			route := GetRoute(c)
+			if route == "" {
+				route = c.FullPath()
+			}
+			guard.RecordFailure(ip, subject, route)
+		case status < http.StatusBadRequest && subject != "":
+			guard.RecordSuccess(subject)
+		}
+	}
+}
+
+// GuardSubject names the credential a handler is about to check, e.g.
+// "user:"+email for a login, so a failure counts against it as well as the
+// client IP. It returns false after writing a 429 response if the subject is
+// locked out. Without BruteForceGuard it only returns true.
+func GuardSubject(c *gin.Context, subject string) bool {
+	if len(subject) > maxAuthSubjectLength {
+		subject = subject[:maxAuthSubjectLength]
+	}
+	value, exists := c.Get(authGuardKey)
+	if !exists {
+		return true
+	}
+	if guard, ok := value.(AuthGuard); ok {
+		if wait := guard.LockedFor("", subject); wait > 0 {
+			abortLockedOut(c, wait)
+			return false
+		}
+	}
+	c.Set(AuthSubjectKey, subject)
+	return true
+}
+
+// apiKeySubject names a presented API key by its prefix, so failures of a
+// revoked or mistyped key count against it without storing the key.
+func apiKeySubject(presented string) string {
+	presented = strings.TrimSpace(presented)
+	if presented == "" {
+		return ""
+	}
+	if len(presented) > apiKeySubjectLength {
+		presented = presented[:apiKeySubjectLength]
+	}
+	return "api_key:" + presented
+}
+
+// abortLockedOut writes the 429 response of a locked out client.
+func abortLockedOut(c *gin.Context, wait time.Duration) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	abortWithError(c, http.StatusTooManyRequests, "TOO_MANY_REQUESTS",
+		"Too many failed authentication attempts; retry after "+wait.Round(time.Second).String())
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// CacheControl sets the Cache-Control header to policy on successful responses.
+// Error responses are left uncached so clients retry them.
+func CacheControl(policy string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer = &cacheControlWriter{ResponseWriter: c.Writer, policy: policy}
+		c.Next()
+	}
+}
+
+// cacheControlWriter adds the Cache-Control header when the status is set,
+// before any header is written.
+type cacheControlWriter struct {
+	gin.ResponseWriter
+	policy string
+}
+
+// WriteHeader sets Cache-Control for statuses below 400.
+func (w *cacheControlWriter) WriteHeader(code int) {
+	if code < 400 {
+		w.Header().Set("Cache-Control", w.policy)
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
@@ -0,0 +1,204 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// compressibleTypes are the media types worth compressing. Images, archives
+// and bundles are already compressed.
+var compressibleTypes = map[string]bool{
+	"application/json":               true,
+	"application/geo+json":           true,
+	"application/problem+json":       true,
+	"application/javascript":         true,
+	"application/xml":                true,
+	"application/geopackage+sqlite3": true,
+	"image/svg+xml":                  true,
+}
+
+// Compression gzip-encodes responses for clients that accept it. A response is
+// compressed when its Content-Type is text or in compressibleTypes, it sets no
+// Content-Encoding of its own and its body reaches minSize bytes; smaller bodies
+// are sent as they are, since gzip framing would outweigh the savings. Every
+// response of a compressible type varies on Accept-Encoding so shared caches
+// keep the encodings apart. level is a compress/gzip level.
+// Mount it before ResponseCache, which then caches and replays uncompressed
+// bodies, and after Logger, which then logs the compressed size.
+func Compression(level, minSize int) gin.HandlerFunc {
+	// Writers are pooled per middleware; each holds about 256 KB of deflate state
+	pool := &sync.Pool{New: func() any {
+		w, _ := gzip.NewWriterLevel(nil, level)
+		return w
+	}}
+
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodHead || !acceptsGzip(c.GetHeader("Accept-Encoding")) {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		writer := &compressWriter{ResponseWriter: original, pool: pool, minSize: minSize}
+		c.Writer = writer
+		defer func() {
+			writer.finish()
+			c.Writer = original
+		}()
+
+		c.Next()
+	}
+}
+
+// acceptsGzip reports whether an Accept-Encoding header allows gzip, either by
+// name or through *, with a non-zero quality.
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding != "gzip" && coding != "*" {
+			continue
+		}
+		q, found := strings.CutPrefix(strings.TrimSpace(params), "q=")
+		if !found {
+			return true
+		}
+		if quality, err := strconv.ParseFloat(q, 64); err == nil && quality > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// compressible reports whether a response with these headers and status should
+// be compressed once it is large enough.
+func compressible(header http.Header, status int) bool {
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		return false
+	}
+	if header.Get("Content-Encoding") != "" || header.Get("Content-Range") != "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "text/") || compressibleTypes[mediaType]
+}
+
+// compressWriter holds the body back until it reaches minSize bytes, then
+// decides between gzip and the plain body. Headers are sent with that decision,
+// so handlers may set them until their first minSize bytes are written.
+type compressWriter struct {
+	gin.ResponseWriter
+	pool    *sync.Pool
+	gz      *gzip.Writer
+	buf     bytes.Buffer
+	minSize int
+	decided bool
+}
+
+// Write buffers or compresses b.
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if !w.decided {
+		if !compressible(w.Header(), w.Status()) {
+			w.decide(false)
+		} else {
+			w.buf.Write(b)
+			if w.buf.Len() < w.minSize {
+				return len(b), nil
+			}
+			if err := w.start(); err != nil {
+				return 0, err
+			}
+			return len(b), nil
+		}
+	}
+	if w.gz != nil {
+		return w.gz.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// WriteString buffers or compresses s.
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// WriteHeaderNow is deferred until the encoding is decided.
+func (w *compressWriter) WriteHeaderNow() {
+	if w.decided {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+// Written reports whether the handler has written a body or sent the headers.
+func (w *compressWriter) Written() bool {
+	return w.buf.Len() > 0 || w.ResponseWriter.Written()
+}
+
+// Flush sends what has been written so far. A streamed body is compressed even
+// below minSize, since more is likely to follow.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		if compressible(w.Header(), w.Status()) {
+			_ = w.start()
+		} else {
+			w.decide(false)
+		}
+	}
+	if w.gz != nil {
+		_ = w.gz.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// start switches to gzip and compresses the buffered body.
+func (w *compressWriter) start() error {
+	w.decide(true)
+	_, err := w.gz.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+// decide fixes the encoding and sends the headers.
+func (w *compressWriter) decide(compress bool) {
+	w.decided = true
+	header := w.Header()
+	if compressible(header, w.Status()) {
+		header.Add("Vary", "Accept-Encoding")
+	}
+	if compress {
+		header.Set("Content-Encoding", "gzip")
+		header.Del("Content-Length")
+		w.gz = w.pool.Get().(*gzip.Writer)
+		w.gz.Reset(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+// finish sends a body that stayed below minSize as it is, or completes the gzip
+// stream, and returns the gzip writer to the pool.
+func (w *compressWriter) finish() {
+	if !w.decided {
+		if w.buf.Len() == 0 && !w.ResponseWriter.Written() && w.Status() == http.StatusOK {
+			// Nothing was written; leave the headers to gin, which may still change the status
+			return
+		}
+		w.decide(false)
+		_, _ = w.ResponseWriter.Write(w.buf.Bytes())
+		w.buf.Reset()
+	}
+	if w.gz != nil {
+		_ = w.gz.Close()
+		w.pool.Put(w.gz)
+		w.gz = nil
+	}
+}
@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-contrib/cors"
@@ -9,15 +11,60 @@ import (
 
 // CORS creates a middleware that handles Cross-Origin Resource Sharing (CORS).
 // It uses the official gin-contrib/cors package with configuration for the allowed origins.
-func CORS(allowedOrigins []string) gin.HandlerFunc {
-	config := cors.Config{
+//
+// Requests whose path starts with one of publicPrefixes (e.g. embeddable widget
+// endpoints loaded from partner sites) use a read-only policy that allows any origin
+// without credentials instead.
+func CORS(allowedOrigins []string, publicPrefixes ...string) gin.HandlerFunc {
+	return NewCORSPolicy(allowedOrigins, publicPrefixes...).Handler()
+}
+
+// CORSPolicy is the CORS middleware with allowed origins that can be replaced
+// while the server runs, e.g. on a configuration reload.
+type CORSPolicy struct {
+	restricted     atomic.Pointer[gin.HandlerFunc]
+	public         gin.HandlerFunc
+	publicPrefixes []string
+}
+
+// NewCORSPolicy creates the policy of CORS.
+func NewCORSPolicy(allowedOrigins []string, publicPrefixes ...string) *CORSPolicy {
+	p := &CORSPolicy{publicPrefixes: publicPrefixes}
+	p.SetOrigins(allowedOrigins)
+	if len(publicPrefixes) > 0 {
+		p.public = cors.New(cors.Config{
+			AllowAllOrigins: true,
+			AllowMethods:    []string{"GET", "OPTIONS"},
+			AllowHeaders:    []string{"Origin", "Content-Type", "Accept", "X-Request-ID"},
+			ExposeHeaders:   []string{"X-Request-ID"},
+			MaxAge:          24 * time.Hour,
+		})
+	}
+	return p
+}
+
+// SetOrigins replaces the allowed origins of the requests that follow.
+func (p *CORSPolicy) SetOrigins(allowedOrigins []string) {
+	restricted := cors.New(cors.Config{
 		AllowOrigins:     allowedOrigins,
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Request-ID"},
-		ExposeHeaders:    []string{"X-Request-ID"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Request-ID", "X-Tenant-ID"},
+		ExposeHeaders:    []string{"X-Request-ID", "X-Next-Cursor", "X-Total-Count"},
 		AllowCredentials: true,
 		MaxAge:           24 * time.Hour,
-	}
+	})
+	p.restricted.Store(&restricted)
+}
 
-	return cors.New(config)
+// Handler returns the middleware applying the policy.
+func (p *CORSPolicy) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, prefix := range p.publicPrefixes {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				p.public(c)
+				return
+			}
+		}
+		(*p.restricted.Load())(c)
+	}
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/tracing"
 )
 
 // Logger creates a middleware that logs HTTP requests using structured logging.
@@ -39,6 +40,29 @@ func Logger(log *logger.Logger) gin.HandlerFunc {
 			"user_agent":  c.Request.UserAgent(),
 		}
 
+		// Label the request with its registered route, if any
+		if route := GetRoute(c); route != "" {
+			fields["route"] = route
+			fields["rate_class"] = c.GetString(RateClassKey)
+		}
+
+		// Attribute the request to the API key it authenticated with, if any
+		if key := GetAPIKey(c); key != nil {
+			for field, value := range apiKeyLogFields(key) {
+				fields[field] = value
+			}
+		}
+
+		// Link the request to its trace, if sampled
+		if span := tracing.SpanFromContext(c.Request.Context()); span != nil {
+			fields[TraceIDKey] = span.TraceID()
+		}
+
+		// Attribute the request to the signed-in user, if any
+		if userID := GetUserID(c); userID != 0 {
+			fields["user_id"] = userID
+		}
+
 		// Add query parameters if present
 		if len(c.Request.URL.RawQuery) > 0 {
 			fields["query"] = c.Request.URL.RawQuery
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stwalsh4118/atlas/api/internal/metrics"
+)
+
+// unmatchedRoute labels requests that matched no declared route, so unknown
+// paths do not each add a label value.
+const unmatchedRoute = "unmatched"
+
+// Metrics records the count, latency and response cache result of every request
+// by route name. Mount it before the routes so the route name is set when the
+// request finishes.
+func Metrics(m *metrics.HTTP) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := GetRoute(c)
+		if route == "" {
+			route = unmatchedRoute
+		}
+		m.Observe(route, c.Writer.Status(), time.Since(start))
+
+		// Set by ResponseCache to HIT or MISS
+		switch c.Writer.Header().Get(ResponseCacheHeader) {
+		case "HIT":
+			m.ObserveCache(route, "hit")
+		case "MISS":
+			m.ObserveCache(route, "miss")
+		}
+	}
+}
@@ -1,12 +1,27 @@
 package middleware
 
 import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/stwalsh4118/atlas/api/internal/auth"
+	"github.com/stwalsh4118/atlas/api/internal/cache"
 	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/metrics"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/siem"
+	"github.com/stwalsh4118/atlas/api/internal/tracing"
 )
 
 func init() {
@@ -152,6 +167,76 @@ func TestCORS(t *testing.T) {
 	})
 }
 
+// TestCORS_PublicPrefixes tests the read-only public CORS policy
+func TestCORS_PublicPrefixes(t *testing.T) {
+	allowedOrigins := []string{"http://localhost:3000"}
+
+	router := gin.New()
+	router.Use(CORS(allowedOrigins, "/public"))
+	router.GET("/public/config", func(c *gin.Context) {
+		c.String(200, "OK")
+	})
+	router.GET("/private", func(c *gin.Context) {
+		c.String(200, "OK")
+	})
+
+	t.Run("allows any origin on public prefix", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/public/config", nil)
+		req.Header.Set("Origin", "https://partner.example.com")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != 200 {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+		if w.Header().Get("Access-Control-Allow-Origin") != "*" {
+			t.Errorf("Expected wildcard origin, got %q", w.Header().Get("Access-Control-Allow-Origin"))
+		}
+		if w.Header().Get("Access-Control-Allow-Credentials") != "" {
+			t.Error("Expected no credentials header on public prefix")
+		}
+	})
+
+	t.Run("still restricts other paths", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/private", nil)
+		req.Header.Set("Origin", "https://partner.example.com")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != 403 {
+			t.Errorf("Expected status 403 for disallowed origin, got %d", w.Code)
+		}
+	})
+}
+
+// TestCORSPolicy_SetOrigins tests origins replaced after the router is built
+func TestCORSPolicy_SetOrigins(t *testing.T) {
+	policy := NewCORSPolicy([]string{"http://localhost:3000"})
+	router := gin.New()
+	router.Use(policy.Handler())
+	router.GET("/test", func(c *gin.Context) {
+		c.String(200, "OK")
+	})
+	get := func(origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/test", nil)
+		req.Header.Set("Origin", origin)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := get("https://app.example.com"); w.Code != 403 {
+		t.Errorf("Expected status 403 before the origin is allowed, got %d", w.Code)
+	}
+	policy.SetOrigins([]string{"https://app.example.com"})
+	if w := get("https://app.example.com"); w.Header().Get("Access-Control-Allow-Origin") != "https://app.example.com" {
+		t.Errorf("Expected the new origin to be allowed, got %q", w.Header().Get("Access-Control-Allow-Origin"))
+	}
+	if w := get("http://localhost:3000"); w.Code != 403 {
+		t.Errorf("Expected status 403 for the replaced origin, got %d", w.Code)
+	}
+}
+
 // TestLogger tests the Logger middleware
 func TestLogger(t *testing.T) {
 	t.Run("logs successful request", func(t *testing.T) {
@@ -267,6 +352,1036 @@ func TestRecovery(t *testing.T) {
 	})
 }
 
+// TestTenant tests the Tenant middleware
+func TestTenant(t *testing.T) {
+	t.Run("stores normalized tenant ID from header", func(t *testing.T) {
+		router := gin.New()
+		router.Use(Tenant())
+		router.GET("/test", func(c *gin.Context) {
+			c.String(200, GetTenantID(c))
+		})
+
+		req := httptest.NewRequest("GET", "/test", nil)
+		req.Header.Set(TenantIDHeader, "  Acme  ")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Body.String() != "acme" {
+			t.Errorf("Expected tenant ID acme, got %s", w.Body.String())
+		}
+	})
+
+	t.Run("leaves tenant unset when header missing", func(t *testing.T) {
+		router := gin.New()
+		router.Use(Tenant())
+		router.GET("/test", func(c *gin.Context) {
+			if _, exists := c.Get(TenantIDKey); exists {
+				t.Error("Expected tenant ID not to be set")
+			}
+			c.String(200, GetTenantID(c))
+		})
+
+		req := httptest.NewRequest("GET", "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Body.String() != "" {
+			t.Errorf("Expected empty tenant ID, got %s", w.Body.String())
+		}
+	})
+}
+
+func TestWithRole(t *testing.T) {
+	t.Run("assigns the group role", func(t *testing.T) {
+		router := gin.New()
+		router.GET("/test", WithRole(RolePartner), func(c *gin.Context) {
+			c.String(200, string(GetRole(c)))
+		})
+
+		req := httptest.NewRequest("GET", "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Body.String() != string(RolePartner) {
+			t.Errorf("Expected role partner, got %s", w.Body.String())
+		}
+	})
+
+	t.Run("defaults to public", func(t *testing.T) {
+		router := gin.New()
+		router.GET("/test", func(c *gin.Context) {
+			c.String(200, string(GetRole(c)))
+		})
+
+		req := httptest.NewRequest("GET", "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Body.String() != string(RolePublic) {
+			t.Errorf("Expected role public, got %s", w.Body.String())
+		}
+	})
+}
+
+// TestCacheControl tests the CacheControl middleware
+func TestCacheControl(t *testing.T) {
+	router := gin.New()
+	router.GET("/test", CacheControl("public, max-age=60"), func(c *gin.Context) {
+		if c.Query("fail") != "" {
+			c.JSON(400, gin.H{"error": "bad"})
+			return
+		}
+		c.JSON(200, gin.H{"ok": true})
+	})
+
+	t.Run("sets the policy on success", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if got := w.Header().Get("Cache-Control"); got != "public, max-age=60" {
+			t.Errorf("Expected Cache-Control public, max-age=60, got %q", got)
+		}
+	})
+
+	t.Run("leaves errors uncached", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/test?fail=1", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if got := w.Header().Get("Cache-Control"); got != "" {
+			t.Errorf("Expected no Cache-Control on errors, got %q", got)
+		}
+	})
+}
+
+// TestRoute tests the Route middleware
+func TestRoute(t *testing.T) {
+	router := gin.New()
+	router.GET("/test/:id", Route("things.get", "standard"), func(c *gin.Context) {
+		c.String(200, GetRoute(c)+" "+c.GetString(RateClassKey))
+	})
+
+	req := httptest.NewRequest("GET", "/test/42", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Body.String() != "things.get standard" {
+		t.Errorf("Expected route label things.get standard, got %s", w.Body.String())
+	}
+}
+
+// hitCounter is a HitRecorder that keeps every recorded point
+type hitCounter struct {
+	points []models.LatLng
+}
+
+func (h *hitCounter) RecordHit(point models.LatLng) {
+	h.points = append(h.points, point)
+}
+
+// TestUsageTracker tests the UsageTracker middleware
+func TestUsageTracker(t *testing.T) {
+	tests := []struct {
+		name   string
+		url    string
+		status int
+		want   int
+	}{
+		{name: "records successful point query", url: "/test?lat=30.35&lng=-95.45", status: 200, want: 1},
+		{name: "ignores failed request", url: "/test?lat=30.35&lng=-95.45", status: 404, want: 0},
+		{name: "ignores missing coordinates", url: "/test?q=main", status: 200, want: 0},
+		{name: "ignores unparseable coordinates", url: "/test?lat=abc&lng=-95.45", status: 200, want: 0},
+		{name: "ignores out of range latitude", url: "/test?lat=95.45&lng=30.35", status: 200, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &hitCounter{}
+			router := gin.New()
+			router.Use(UsageTracker(recorder))
+			router.GET("/test", func(c *gin.Context) {
+				c.String(tt.status, "OK")
+			})
+
+			req := httptest.NewRequest("GET", tt.url, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if len(recorder.points) != tt.want {
+				t.Fatalf("Expected %d recorded hits, got %d", tt.want, len(recorder.points))
+			}
+			if tt.want == 1 && recorder.points[0] != (models.LatLng{Lat: 30.35, Lng: -95.45}) {
+				t.Errorf("Expected hit at 30.35,-95.45, got %v", recorder.points[0])
+			}
+		})
+	}
+}
+
+// auditRecorder is an AuditPublisher that keeps every published event
+type auditRecorder struct {
+	events []siem.Event
+}
+
+func (a *auditRecorder) Publish(event siem.Event) {
+	a.events = append(a.events, event)
+}
+
+// TestAuditTrail tests the AuditTrail middleware
+func TestAuditTrail(t *testing.T) {
+	validator := &keyValidator{keys: map[string]*models.APIKey{
+		"atlas_public": {ID: 1, TenantID: "acme", Prefix: "atlas_pub", Role: models.APIKeyRolePublic},
+		"atlas_admin":  {ID: 2, TenantID: "acme", Prefix: "atlas_adm", Role: models.APIKeyRoleAdmin},
+	}}
+
+	tests := []struct {
+		name         string
+		method       string
+		key          string
+		status       int
+		wantEvent    bool
+		wantSubject  string
+		wantSeverity int
+	}{
+		{name: "anonymous read is not audited", method: "GET", status: 200},
+		{name: "public key read is not audited", method: "GET", key: "atlas_public", status: 200},
+		{name: "write is audited", method: "POST", key: "atlas_public", status: 201, wantEvent: true, wantSubject: "api_key:atlas_pub", wantSeverity: 3},
+		{name: "admin read is audited", method: "GET", key: "atlas_admin", status: 200, wantEvent: true, wantSubject: "api_key:atlas_adm", wantSeverity: 3},
+		{name: "forbidden read is audited", method: "GET", status: 403, wantEvent: true, wantSeverity: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher := &auditRecorder{}
+			router := gin.New()
+			router.Use(RequestID())
+			router.Use(Tenant())
+			router.Use(APIKeyAuth(validator))
+			router.Use(AuditTrail(publisher))
+			router.Handle(tt.method, "/test", Route("test.route", ""), func(c *gin.Context) {
+				c.String(tt.status, "OK")
+			})
+
+			req := httptest.NewRequest(tt.method, "/test?signature=secret", nil)
+			if tt.key != "" {
+				req.Header.Set(APIKeyHeader, tt.key)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if !tt.wantEvent {
+				if len(publisher.events) != 0 {
+					t.Fatalf("Expected no audit event, got %+v", publisher.events)
+				}
+				return
+			}
+			if len(publisher.events) != 1 {
+				t.Fatalf("Expected 1 audit event, got %d", len(publisher.events))
+			}
+			event := publisher.events[0]
+			if event.Category != siem.CategoryAudit || event.Type != siem.TypeRequest {
+				t.Errorf("Expected audit request event, got %s %s", event.Category, event.Type)
+			}
+			if event.Subject != tt.wantSubject {
+				t.Errorf("Expected subject %q, got %q", tt.wantSubject, event.Subject)
+			}
+			if event.Path != "/test" {
+				t.Errorf("Expected path without query, got %q", event.Path)
+			}
+			if event.Route != "test.route" || event.Method != tt.method || event.Status != tt.status {
+				t.Errorf("Unexpected route, method or status: %+v", event)
+			}
+			if event.Severity != tt.wantSeverity {
+				t.Errorf("Expected severity %d, got %d", tt.wantSeverity, event.Severity)
+			}
+			if event.RequestID != w.Header().Get(RequestIDHeader) {
+				t.Errorf("Expected request ID %q, got %q", w.Header().Get(RequestIDHeader), event.RequestID)
+			}
+		})
+	}
+}
+
+// queryRecorder is a QueryRecorder that keeps every entry
+type queryRecorder struct {
+	entries []models.QueryAudit
+}
+
+func (r *queryRecorder) Record(entry models.QueryAudit) {
+	r.entries = append(r.entries, entry)
+}
+
+func TestQueryAudit(t *testing.T) {
+	validator := &keyValidator{keys: map[string]*models.APIKey{
+		"atlas_public": {ID: 1, TenantID: "acme", Prefix: "atlas_pub", Role: models.APIKeyRolePublic},
+	}}
+
+	tests := []struct {
+		name       string
+		key        string
+		status     int
+		count      int
+		wantPrefix string
+		wantTenant string
+		wantCount  bool
+	}{
+		{name: "records key, tenant and result count", key: "atlas_public", status: 200, count: 3,
+			wantPrefix: "atlas_pub", wantTenant: "acme", wantCount: true},
+		{name: "records anonymous request", status: 200, count: 0, wantCount: true},
+		{name: "records failed request without count", key: "atlas_public", status: 400,
+			wantPrefix: "atlas_pub", wantTenant: "acme"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &queryRecorder{}
+			router := gin.New()
+			router.Use(RequestID())
+			router.Use(Tenant())
+			router.Use(APIKeyAuth(validator))
+			router.GET("/test", Route("parcels.nearby", ""), QueryAudit(recorder, 0), func(c *gin.Context) {
+				if tt.wantCount {
+					SetResultCount(c, tt.count)
+				}
+				c.String(tt.status, "OK")
+			})
+
+			req := httptest.NewRequest("GET", "/test?lat=30.35&lng=-95.45&land_use=A1&land_use=A2", nil)
+			if tt.key != "" {
+				req.Header.Set(APIKeyHeader, tt.key)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if len(recorder.entries) != 1 {
+				t.Fatalf("Expected 1 query audit entry, got %d", len(recorder.entries))
+			}
+			entry := recorder.entries[0]
+			if entry.Route != "parcels.nearby" || entry.Method != "GET" || entry.Status != tt.status {
+				t.Errorf("Unexpected route, method or status: %+v", entry)
+			}
+			if entry.APIKeyPrefix != tt.wantPrefix || entry.TenantID != tt.wantTenant {
+				t.Errorf("Expected key %q of tenant %q, got %q of %q", tt.wantPrefix, tt.wantTenant, entry.APIKeyPrefix, entry.TenantID)
+			}
+			if entry.Params["lat"] != "30.35" || entry.Params["land_use"] != "A1,A2" {
+				t.Errorf("Expected query parameters, got %v", entry.Params)
+			}
+			if entry.RequestID != w.Header().Get(RequestIDHeader) {
+				t.Errorf("Expected request ID %q, got %q", w.Header().Get(RequestIDHeader), entry.RequestID)
+			}
+			if !tt.wantCount {
+				if entry.ResultCount != nil {
+					t.Errorf("Expected no result count, got %d", *entry.ResultCount)
+				}
+				return
+			}
+			if entry.ResultCount == nil || *entry.ResultCount != tt.count {
+				t.Errorf("Expected result count %d, got %v", tt.count, entry.ResultCount)
+			}
+		})
+	}
+}
+
+func TestQueryAudit_BoundsParams(t *testing.T) {
+	recorder := &queryRecorder{}
+	router := gin.New()
+	router.GET("/test", QueryAudit(recorder, 0), func(c *gin.Context) {
+		c.String(http.StatusOK, "OK")
+	})
+
+	query := make([]string, 0, maxAuditParams+10)
+	for i := 0; i < maxAuditParams+10; i++ {
+		query = append(query, fmt.Sprintf("p%d=%d", i, i))
+	}
+	query = append(query, "q="+strings.Repeat("a", maxAuditParamLength+100))
+	req := httptest.NewRequest("GET", "/test?"+strings.Join(query, "&"), nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if len(recorder.entries) != 1 {
+		t.Fatalf("Expected 1 query audit entry, got %d", len(recorder.entries))
+	}
+	params := recorder.entries[0].Params
+	if len(params) != maxAuditParams {
+		t.Errorf("Expected %d params, got %d", maxAuditParams, len(params))
+	}
+	for name, value := range params {
+		if len(value) > maxAuditParamLength {
+			t.Errorf("Expected %s truncated to %d characters, got %d", name, maxAuditParamLength, len(value))
+		}
+	}
+}
+
+func TestQueryAudit_Replay(t *testing.T) {
+	validator := &keyValidator{keys: map[string]*models.APIKey{
+		"atlas_public": {ID: 1, TenantID: "acme", Prefix: "atlas_pub", Role: models.APIKeyRolePublic},
+	}}
+	recorder := &queryRecorder{}
+	router := gin.New()
+	router.Use(Tenant())
+	router.Use(APIKeyAuth(validator))
+	router.POST("/test", Route("parcels.in-area", ""), QueryAudit(recorder, 1), func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		SetResultCount(c, len(body))
+		c.String(http.StatusOK, "OK")
+	})
+
+	t.Run("captures the request anonymized", func(t *testing.T) {
+		recorder.entries = nil
+		req := httptest.NewRequest("POST", "/test?token=secret&limit=10", strings.NewReader(`{"type":"Polygon"}`))
+		req.Header.Set(APIKeyHeader, "atlas_public")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Header().Get(ResultCountHeader) != "18" {
+			t.Errorf("Expected the handler to read the whole body and report 18, got %q", w.Header().Get(ResultCountHeader))
+		}
+		if len(recorder.entries) != 1 || recorder.entries[0].Replay == nil {
+			t.Fatalf("Expected 1 entry with a replay capture, got %+v", recorder.entries)
+		}
+		replay := recorder.entries[0].Replay
+		want := models.ReplayQuery{
+			CreatedAt: recorder.entries[0].CreatedAt, Route: "parcels.in-area", Method: "POST", Path: "/test",
+			Query: "limit=10", Body: `{"type":"Polygon"}`, Status: http.StatusOK,
+			LatencyMS: recorder.entries[0].LatencyMS, ResultCount: recorder.entries[0].ResultCount,
+		}
+		if !reflect.DeepEqual(*replay, want) {
+			t.Errorf("Expected capture %+v, got %+v", want, *replay)
+		}
+	})
+
+	t.Run("skips large bodies", func(t *testing.T) {
+		recorder.entries = nil
+		body := strings.Repeat("a", maxReplayBodyBytes+1)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/test", strings.NewReader(body)))
+
+		if w.Header().Get(ResultCountHeader) != strconv.Itoa(len(body)) {
+			t.Errorf("Expected the handler to read the whole body, got %s bytes", w.Header().Get(ResultCountHeader))
+		}
+		if len(recorder.entries) != 1 || recorder.entries[0].Replay != nil {
+			t.Errorf("Expected 1 entry without a replay capture, got %+v", recorder.entries)
+		}
+	})
+}
+
+// failingCache is a cache.Cache whose every call fails with err
+type failingCache struct {
+	err error
+}
+
+func (f failingCache) Get(context.Context, string) ([]byte, bool, error) {
+	return nil, false, f.err
+}
+
+func (f failingCache) Set(context.Context, string, []byte, time.Duration) error {
+	return f.err
+}
+
+// TestResponseCache tests the ResponseCache middleware
+func TestResponseCache(t *testing.T) {
+	newRouter := func(store cache.Cache, calls *int) *gin.Engine {
+		router := gin.New()
+		router.Use(RequestID())
+		router.GET("/test", ResponseCache(store, time.Minute, 4, logger.New("test")), func(c *gin.Context) {
+			*calls++
+			if c.Query("fail") != "" {
+				c.JSON(404, gin.H{"error": "not found"})
+				return
+			}
+			c.Header("X-Total-Count", "1")
+			c.String(200, "call %d", *calls)
+		})
+		return router
+	}
+	get := func(router *gin.Engine, url string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", url, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("serves repeated requests in the same cell from the cache", func(t *testing.T) {
+		calls := 0
+		router := newRouter(cache.NewMemory(10), &calls)
+
+		first := get(router, "/test?lat=30.12341&lng=-95.45&radius=100")
+		if first.Header().Get(ResponseCacheHeader) != "MISS" {
+			t.Errorf("Expected first request to miss, got %q", first.Header().Get(ResponseCacheHeader))
+		}
+
+		second := get(router, "/test?radius=100&lng=-95.45001&lat=30.12339")
+		if calls != 1 {
+			t.Fatalf("Expected handler to run once, ran %d times", calls)
+		}
+		if second.Header().Get(ResponseCacheHeader) != "HIT" || second.Body.String() != "call 1" {
+			t.Errorf("Expected cached call 1, got %q (%s)", second.Body.String(), second.Header().Get(ResponseCacheHeader))
+		}
+		if second.Header().Get("X-Total-Count") != "1" {
+			t.Error("Expected handler headers to be cached")
+		}
+		if second.Header().Get(RequestIDHeader) == first.Header().Get(RequestIDHeader) {
+			t.Error("Expected request ID not to be cached")
+		}
+
+		get(router, "/test?lat=30.1235&lng=-95.45&radius=100")
+		get(router, "/test?lat=30.12341&lng=-95.45&radius=200")
+		if calls != 3 {
+			t.Errorf("Expected other cells and parameters to miss, handler ran %d times", calls)
+		}
+	})
+
+	t.Run("keys on the path of routes with path parameters", func(t *testing.T) {
+		calls := 0
+		router := gin.New()
+		router.GET("/items/:id", ResponseCache(cache.NewMemory(10), time.Minute, 4, logger.New("test")), func(c *gin.Context) {
+			calls++
+			c.String(200, "item %s", c.Param("id"))
+		})
+
+		get(router, "/items/1")
+		second := get(router, "/items/2")
+		if calls != 2 || second.Body.String() != "item 2" {
+			t.Errorf("Expected each item to be served by the handler, got %q after %d calls", second.Body.String(), calls)
+		}
+		if get(router, "/items/1").Header().Get(ResponseCacheHeader) != "HIT" {
+			t.Error("Expected the repeated item to hit")
+		}
+	})
+
+	t.Run("does not cache errors", func(t *testing.T) {
+		calls := 0
+		router := newRouter(cache.NewMemory(10), &calls)
+
+		get(router, "/test?lat=30&lng=-95&fail=1")
+		get(router, "/test?lat=30&lng=-95&fail=1")
+		if calls != 2 {
+			t.Errorf("Expected handler to run twice, ran %d times", calls)
+		}
+	})
+
+	t.Run("serves requests when the cache fails", func(t *testing.T) {
+		calls := 0
+		router := newRouter(failingCache{err: cache.ErrUnavailable}, &calls)
+
+		w := get(router, "/test?lat=30&lng=-95")
+		if w.Code != 200 || calls != 1 {
+			t.Errorf("Expected handler response, got status %d after %d calls", w.Code, calls)
+		}
+	})
+}
+
+// TestReloadableResponseCache tests route TTLs replaced after the router is built
+func TestReloadableResponseCache(t *testing.T) {
+	ttls := NewRouteTTLs(map[string]time.Duration{"items": time.Minute})
+	calls := 0
+	router := gin.New()
+	router.GET("/items", Route("items", ""), ReloadableResponseCache(cache.NewMemory(10), ttls, 4, logger.New("test")), func(c *gin.Context) {
+		calls++
+		c.String(200, "call %d", calls)
+	})
+	get := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		return w
+	}
+
+	get("/items?page=1")
+	if w := get("/items?page=1"); w.Header().Get(ResponseCacheHeader) != "HIT" || calls != 1 {
+		t.Errorf("Expected the repeated request to hit, got %q after %d calls", w.Header().Get(ResponseCacheHeader), calls)
+	}
+
+	ttls.Set(map[string]time.Duration{})
+	if w := get("/items?page=1"); w.Header().Get(ResponseCacheHeader) != "" || calls != 2 {
+		t.Errorf("Expected a route without TTL to skip the cache, got %q after %d calls", w.Header().Get(ResponseCacheHeader), calls)
+	}
+}
+
+// TestCompression tests gzip negotiation, the size threshold and the content types left alone
+func TestCompression(t *testing.T) {
+	large := strings.Repeat(`{"type":"Feature"}`, 100)
+	router := gin.New()
+	router.Use(Compression(gzip.DefaultCompression, 1024))
+	router.GET("/geojson", func(c *gin.Context) {
+		c.Data(200, "application/geo+json", []byte(large))
+	})
+	router.GET("/small", func(c *gin.Context) {
+		c.JSON(200, gin.H{"ok": true})
+	})
+	router.GET("/png", func(c *gin.Context) {
+		c.Data(200, "image/png", []byte(large))
+	})
+	router.GET("/cached", ResponseCache(cache.NewMemory(10), time.Minute, 4, logger.New("test")), func(c *gin.Context) {
+		c.Data(200, "application/json", []byte(large))
+	})
+	get := func(url, acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", url, nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	decompress := func(t *testing.T, w *httptest.ResponseRecorder) string {
+		t.Helper()
+		reader, err := gzip.NewReader(w.Body)
+		if err != nil {
+			t.Fatalf("Expected a gzip body: %v", err)
+		}
+		body, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("Failed to decompress body: %v", err)
+		}
+		return string(body)
+	}
+
+	t.Run("compresses large responses for gzip clients", func(t *testing.T) {
+		w := get("/geojson", "br;q=1.0, gzip;q=0.8")
+
+		if w.Header().Get("Content-Encoding") != "gzip" || w.Header().Get("Vary") != "Accept-Encoding" {
+			t.Fatalf("Expected gzip varying on Accept-Encoding, got %v", w.Header())
+		}
+		if w.Body.Len() >= len(large) {
+			t.Errorf("Expected a smaller body, got %d bytes", w.Body.Len())
+		}
+		if body := decompress(t, w); body != large {
+			t.Errorf("Expected the original body after decompression, got %d bytes", len(body))
+		}
+	})
+
+	t.Run("leaves responses alone for other clients", func(t *testing.T) {
+		for _, acceptEncoding := range []string{"", "identity", "br", "gzip;q=0"} {
+			w := get("/geojson", acceptEncoding)
+			if w.Header().Get("Content-Encoding") != "" || w.Body.String() != large {
+				t.Errorf("Expected an uncompressed body for %q, got encoding %q", acceptEncoding, w.Header().Get("Content-Encoding"))
+			}
+		}
+	})
+
+	t.Run("sends small bodies uncompressed", func(t *testing.T) {
+		w := get("/small", "gzip")
+
+		if w.Header().Get("Content-Encoding") != "" || w.Body.String() != `{"ok":true}` {
+			t.Errorf("Expected the plain body, got %q (%q)", w.Body.String(), w.Header().Get("Content-Encoding"))
+		}
+		if w.Header().Get("Vary") != "Accept-Encoding" {
+			t.Error("Expected small compressible responses to vary on Accept-Encoding")
+		}
+	})
+
+	t.Run("skips compressed media types", func(t *testing.T) {
+		w := get("/png", "gzip")
+
+		if w.Header().Get("Content-Encoding") != "" || w.Header().Get("Vary") != "" || w.Body.String() != large {
+			t.Errorf("Expected the image untouched, got %v", w.Header())
+		}
+	})
+
+	t.Run("compresses cached responses per request", func(t *testing.T) {
+		get("/cached?lat=30&lng=-95", "gzip")
+		plain := get("/cached?lat=30&lng=-95", "")
+		if plain.Header().Get(ResponseCacheHeader) != "HIT" || plain.Header().Get("Content-Encoding") != "" || plain.Body.String() != large {
+			t.Fatalf("Expected an uncompressed cache hit, got %q", plain.Header().Get(ResponseCacheHeader))
+		}
+
+		compressed := get("/cached?lat=30&lng=-95", "gzip")
+		if compressed.Header().Get(ResponseCacheHeader) != "HIT" || decompress(t, compressed) != large {
+			t.Errorf("Expected a compressed cache hit, got %q", compressed.Header().Get(ResponseCacheHeader))
+		}
+	})
+}
+
+// TestSecurityHeaders tests the headers set, the headers omitted and server header stripping
+func TestSecurityHeaders(t *testing.T) {
+	serve := func(opts SecurityOptions, handler gin.HandlerFunc) *httptest.ResponseRecorder {
+		router := gin.New()
+		router.Use(SecurityHeaders(opts))
+		router.GET("/test", handler)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/test", nil))
+		return w
+	}
+
+	t.Run("sets configured headers", func(t *testing.T) {
+		w := serve(SecurityOptions{HSTSMaxAge: 31536000, FrameAncestors: "'none'", ReferrerPolicy: "no-referrer"}, func(c *gin.Context) {
+			c.JSON(200, gin.H{"ok": true})
+		})
+
+		expected := map[string]string{
+			"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
+			"X-Content-Type-Options":    "nosniff",
+			"Content-Security-Policy":   "frame-ancestors 'none'",
+			"Referrer-Policy":           "no-referrer",
+		}
+		for name, value := range expected {
+			if got := w.Header().Get(name); got != value {
+				t.Errorf("Expected %s %q, got %q", name, value, got)
+			}
+		}
+	})
+
+	t.Run("omits empty options", func(t *testing.T) {
+		w := serve(SecurityOptions{}, func(c *gin.Context) {
+			c.Status(204)
+		})
+
+		for _, name := range []string{"Strict-Transport-Security", "Content-Security-Policy", "Referrer-Policy"} {
+			if got := w.Header().Get(name); got != "" {
+				t.Errorf("Expected no %s, got %q", name, got)
+			}
+		}
+		if w.Header().Get("X-Content-Type-Options") != "nosniff" {
+			t.Error("Expected nosniff regardless of options")
+		}
+	})
+
+	t.Run("strips server headers", func(t *testing.T) {
+		w := serve(SecurityOptions{}, func(c *gin.Context) {
+			c.Header("Server", "upstream/1.0")
+			c.Header("X-Powered-By", "Express")
+			c.String(200, "proxied")
+		})
+
+		if w.Header().Get("Server") != "" || w.Header().Get("X-Powered-By") != "" {
+			t.Errorf("Expected server headers stripped, got %v", w.Header())
+		}
+		if w.Body.String() != "proxied" {
+			t.Errorf("Expected the handler body, got %q", w.Body.String())
+		}
+	})
+}
+
+// TestMetrics tests that requests are counted by route name, status and cache result
+func TestMetrics(t *testing.T) {
+	registry := metrics.NewRegistry()
+	m := metrics.NewHTTP(registry)
+
+	router := gin.New()
+	router.Use(Metrics(m))
+	router.GET("/parcels/at-point", Route("parcels.at_point", "standard"),
+		ResponseCache(cache.NewMemory(10), time.Minute, 5, logger.New("test")),
+		func(c *gin.Context) { c.String(200, "ok") })
+
+	for _, url := range []string{"/parcels/at-point?lat=30&lng=-95", "/parcels/at-point?lat=30&lng=-95", "/missing"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", url, nil))
+	}
+
+	var out strings.Builder
+	if err := registry.Write(&out); err != nil {
+		t.Fatalf("Expected metrics to be written: %v", err)
+	}
+	for _, want := range []string{
+		`atlas_http_requests_total{route="parcels.at_point",status="200"} 2`,
+		`atlas_http_requests_total{route="unmatched",status="404"} 1`,
+		`atlas_http_request_duration_seconds_count{route="parcels.at_point"} 2`,
+		`atlas_response_cache_requests_total{route="parcels.at_point",result="hit"} 1`,
+		`atlas_response_cache_requests_total{route="parcels.at_point",result="miss"} 1`,
+	} {
+		if !strings.Contains(out.String(), want+"\n") {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestTracing(t *testing.T) {
+	exported := make(chan string, 1)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		exported <- string(body)
+	}))
+	defer collector.Close()
+	tracer := tracing.New(tracing.Options{Endpoint: collector.URL, ServiceName: "atlas-test", SampleRatio: 1})
+
+	var traceID string
+	var logged bool
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("logger", logger.New("test"))
+		c.Next()
+	})
+	router.Use(Tracing(tracer))
+	router.GET("/parcels/:id", Route("parcels.get", "standard"), func(c *gin.Context) {
+		span := tracing.SpanFromContext(c.Request.Context())
+		traceID = span.TraceID()
+		logged = GetLogger(c) != nil
+		c.AbortWithStatus(500)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/parcels/42", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(w, req)
+
+	if traceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Expected the handler to continue the caller's trace, got %q", traceID)
+	}
+	if !logged {
+		t.Error("Expected the request logger to be kept")
+	}
+	if got := w.Header().Get("traceparent"); !strings.HasPrefix(got, "00-4bf92f3577b34da6a3ce929d0e0e4736-") {
+		t.Errorf("Expected traceparent response header in the caller's trace, got %q", got)
+	}
+
+	if err := tracer.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Expected the spans to be exported: %v", err)
+	}
+	body := <-exported
+	for _, want := range []string{`"name":"GET /parcels/:id"`, `"parentSpanId":"00f067aa0ba902b7"`, `"stringValue":"parcels.get"`, `"code":2`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected exported span to contain %s, got %s", want, body)
+		}
+	}
+}
+
+// keyValidator resolves the keys in its map; anything else is unknown.
+type keyValidator struct {
+	err  error
+	keys map[string]*models.APIKey
+}
+
+func (v *keyValidator) Authenticate(_ context.Context, key string) (*models.APIKey, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+	return v.keys[key], nil
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	validator := &keyValidator{keys: map[string]*models.APIKey{
+		"atlas_public": {ID: 1, TenantID: "acme", Prefix: "atlas_pub", Role: models.APIKeyRolePublic},
+		"atlas_admin":  {ID: 2, TenantID: "acme", Prefix: "atlas_adm", Role: models.APIKeyRoleAdmin},
+	}}
+
+	tests := []struct {
+		name       string
+		key        string
+		tenant     string
+		validator  *keyValidator
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "anonymous request passes", wantStatus: 200, wantBody: "- public"},
+		{name: "key sets tenant", key: "atlas_public", wantStatus: 200, wantBody: "1 acme public"},
+		{name: "admin key sets admin role", key: "atlas_admin", wantStatus: 200, wantBody: "2 acme admin"},
+		{name: "matching tenant header", key: "atlas_public", tenant: "ACME", wantStatus: 200, wantBody: "1 acme public"},
+		{name: "unknown key", key: "atlas_nope", wantStatus: 401},
+		{name: "other tenant header", key: "atlas_public", tenant: "globex", wantStatus: 403},
+		{name: "lookup failure", key: "atlas_public", validator: &keyValidator{err: errors.New("db down")}, wantStatus: 503},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := validator
+			if tt.validator != nil {
+				v = tt.validator
+			}
+			router := gin.New()
+			router.Use(RequestID())
+			router.Use(Logger(logger.New("test")))
+			router.Use(Tenant())
+			router.Use(APIKeyAuth(v))
+			router.GET("/test", func(c *gin.Context) {
+				key := GetAPIKey(c)
+				if key == nil {
+					c.String(200, "- "+string(GetRole(c)))
+					return
+				}
+				c.String(200, fmt.Sprintf("%d %s %s", key.ID, GetTenantID(c), GetRole(c)))
+			})
+
+			req := httptest.NewRequest("GET", "/test", nil)
+			if tt.key != "" {
+				req.Header.Set(APIKeyHeader, tt.key)
+			}
+			if tt.tenant != "" {
+				req.Header.Set(TenantIDHeader, tt.tenant)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("Expected body %q, got %q", tt.wantBody, w.Body.String())
+			}
+			if tt.wantStatus != 200 && !strings.Contains(w.Body.String(), `"request_id"`) {
+				t.Errorf("Expected standard error response, got %s", w.Body.String())
+			}
+		})
+	}
+}
+
+// tokenVerifier accepts "valid" as user 7 and "expired" as an expired token.
+type tokenVerifier struct{}
+
+func (tokenVerifier) Verify(token string) (*auth.Claims, error) {
+	switch token {
+	case "valid":
+		return &auth.Claims{Subject: "7"}, nil
+	case "expired":
+		return nil, auth.ErrExpiredToken
+	}
+	return nil, auth.ErrInvalidSignature
+}
+
+func TestUserAuth(t *testing.T) {
+	tests := []struct {
+		name       string
+		header     string
+		wantBody   string
+		wantStatus int
+	}{
+		{name: "anonymous request passes", wantStatus: 200, wantBody: "0"},
+		{name: "valid token sets user", header: "Bearer valid", wantStatus: 200, wantBody: "7"},
+		{name: "scheme is case-insensitive", header: "bearer valid", wantStatus: 200, wantBody: "7"},
+		{name: "expired token", header: "Bearer expired", wantStatus: 401, wantBody: "Token has expired"},
+		{name: "forged token", header: "Bearer forged", wantStatus: 401, wantBody: "Invalid token"},
+		{name: "basic auth", header: "Basic dXNlcjpwYXNz", wantStatus: 401},
+		{name: "missing token", header: "Bearer", wantStatus: 401},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(RequestID())
+			router.Use(Logger(logger.New("test")))
+			router.Use(UserAuth(tokenVerifier{}))
+			router.GET("/test", func(c *gin.Context) {
+				c.String(200, fmt.Sprintf("%d", GetUserID(c)))
+			})
+
+			req := httptest.NewRequest("GET", "/test", nil)
+			if tt.header != "" {
+				req.Header.Set(AuthorizationHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantBody != "" && !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("Expected body containing %q, got %q", tt.wantBody, w.Body.String())
+			}
+			if tt.wantStatus != 200 && !strings.Contains(w.Body.String(), `"request_id"`) {
+				t.Errorf("Expected standard error response, got %s", w.Body.String())
+			}
+		})
+	}
+}
+
+// recordingGuard records the calls of BruteForceGuard and locks out the keys in locked.
+type recordingGuard struct {
+	locked    map[string]time.Duration
+	failures  []string
+	successes []string
+}
+
+func (g *recordingGuard) LockedFor(ip, subject string) time.Duration {
+	return max(g.locked["ip:"+ip], g.locked[subject])
+}
+
+func (g *recordingGuard) RecordFailure(ip, subject, route string) {
+	g.failures = append(g.failures, ip+" "+subject+" "+route)
+}
+
+func (g *recordingGuard) RecordSuccess(subject string) {
+	g.successes = append(g.successes, subject)
+}
+
+// TestBruteForceGuard tests lockouts and which responses count as failures
+func TestBruteForceGuard(t *testing.T) {
+	tests := []struct {
+		name          string
+		key           string
+		login         string
+		header        string
+		locked        map[string]time.Duration
+		wantFailures  []string
+		wantSuccesses []string
+		wantStatus    int
+	}{
+		{name: "anonymous request is not counted", wantStatus: 200},
+		{name: "valid key is a success", key: "atlas_public", wantStatus: 200, wantSuccesses: []string{"api_key:atlas_public"}},
+		{name: "unknown key is a failure", key: "atlas_unknown_key", wantStatus: 401,
+			wantFailures: []string{"192.0.2.1 api_key:atlas_unknow test"}},
+		{name: "bad token is a failure without subject", header: "Bearer forged", wantStatus: 401,
+			wantFailures: []string{"192.0.2.1  test"}},
+		{name: "failed login counts against the account", login: "a@example.com", wantStatus: 401,
+			wantFailures: []string{"192.0.2.1 user:a@example.com test"}},
+		{name: "locked out IP", key: "atlas_public", locked: map[string]time.Duration{"ip:192.0.2.1": 1500 * time.Millisecond},
+			wantStatus: 429},
+		{name: "locked out key", key: "atlas_public", locked: map[string]time.Duration{"api_key:atlas_public": time.Minute},
+			wantStatus: 429},
+		{name: "locked out account", login: "a@example.com", locked: map[string]time.Duration{"user:a@example.com": time.Minute},
+			wantStatus: 429},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guard := &recordingGuard{locked: tt.locked}
+			router := gin.New()
+			router.Use(RequestID())
+			router.Use(BruteForceGuard(guard))
+			router.Use(Route("test", ""))
+			router.Use(APIKeyAuth(&keyValidator{keys: map[string]*models.APIKey{
+				"atlas_public": {ID: 1, TenantID: "acme", Role: models.APIKeyRolePublic},
+			}}))
+			router.Use(UserAuth(tokenVerifier{}))
+			router.GET("/test", func(c *gin.Context) {
+				if tt.login != "" {
+					if !GuardSubject(c, "user:"+tt.login) {
+						return
+					}
+					abortWithError(c, http.StatusUnauthorized, "UNAUTHORIZED", "Invalid email or password")
+					return
+				}
+				c.String(200, "ok")
+			})
+
+			req := httptest.NewRequest("GET", "/test", nil)
+			req.RemoteAddr = "192.0.2.1:1234"
+			if tt.key != "" {
+				req.Header.Set(APIKeyHeader, tt.key)
+			}
+			if tt.header != "" {
+				req.Header.Set(AuthorizationHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if fmt.Sprint(guard.failures) != fmt.Sprint(tt.wantFailures) {
+				t.Errorf("Expected failures %q, got %q", tt.wantFailures, guard.failures)
+			}
+			if fmt.Sprint(guard.successes) != fmt.Sprint(tt.wantSuccesses) {
+				t.Errorf("Expected successes %q, got %q", tt.wantSuccesses, guard.successes)
+			}
+			if tt.wantStatus == 429 && w.Header().Get("Retry-After") == "" {
+				t.Error("Expected Retry-After header on lockout")
+			}
+		})
+	}
+
+	t.Run("retry after rounds up", func(t *testing.T) {
+		guard := &recordingGuard{locked: map[string]time.Duration{"ip:192.0.2.1": 1500 * time.Millisecond}}
+		router := gin.New()
+		router.Use(BruteForceGuard(guard))
+		router.GET("/test", func(c *gin.Context) { c.String(200, "ok") })
+
+		req := httptest.NewRequest("GET", "/test", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if got := w.Header().Get("Retry-After"); got != "2" {
+			t.Errorf("Expected Retry-After 2, got %q", got)
+		}
+	})
+}
+
 // TestMiddlewareStack tests that all middleware work together
 func TestMiddlewareStack(t *testing.T) {
 	log := logger.New("test")
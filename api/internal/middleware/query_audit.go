@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+const (
+	// ResultCountKey is the context key for the number of parcels a response carries
+	ResultCountKey = "result_count"
+	// ResultCountHeader carries the result count on responses, so replays can
+	// compare counts without parsing every format
+	ResultCountHeader = "X-Result-Count"
+	// maxAuditParams and maxAuditParamLength bound the query parameters kept per entry
+	maxAuditParams      = 50
+	maxAuditParamLength = 500
+	// maxReplayBodyBytes bounds the request bodies captured for replay; requests
+	// with larger bodies are not captured
+	maxReplayBodyBytes = 64 << 10
+)
+
+// replayRedactedParams are query parameters that carry credentials. They are
+// removed from replay captures, which replay with the operator's own API key.
+var replayRedactedParams = []string{"token", "api_key"}
+
+// QueryRecorder receives the entries of QueryAudit. Record must not block.
+type QueryRecorder interface {
+	Record(entry models.QueryAudit)
+}
+
+// QueryAudit records every request to the query audit log: the route, the
+// query string parameters, the caller's API key and tenant, the status, the
+// latency and the number of results the handler reported with SetResultCount.
+// Request bodies are not recorded. Mount it after APIKeyAuth so the key is
+// known, and before the response cache so cached responses are counted too.
+//
+// A replaySampleRatio fraction of requests is also captured for replay, see
+// models.ReplayQuery; zero disables capture.
+func QueryAudit(recorder QueryRecorder, replaySampleRatio float64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		var replay *models.ReplayQuery
+		if replaySampleRatio > 0 && rand.Float64() < replaySampleRatio {
+			replay = captureReplay(c)
+		}
+
+		c.Next()
+
+		entry := models.QueryAudit{
+			CreatedAt: start.UTC(),
+			Route:     GetRoute(c),
+			Method:    c.Request.Method,
+			Params:    auditParams(c),
+			TenantID:  GetTenantID(c),
+			RequestID: GetRequestID(c),
+			Status:    c.Writer.Status(),
+			LatencyMS: int(time.Since(start).Milliseconds()),
+		}
+		if key := GetAPIKey(c); key != nil {
+			entry.APIKeyPrefix = key.Prefix
+		}
+		if count, ok := GetResultCount(c); ok {
+			entry.ResultCount = &count
+		}
+		if replay != nil {
+			replay.CreatedAt = entry.CreatedAt
+			replay.Route = entry.Route
+			replay.Status = entry.Status
+			replay.LatencyMS = entry.LatencyMS
+			replay.ResultCount = entry.ResultCount
+			entry.Replay = replay
+		}
+		recorder.Record(entry)
+	}
+}
+
+// captureReplay captures the request before the handler reads it. The body is
+// read up to maxReplayBodyBytes and put back in front of the rest; nil is
+// returned for a larger or unreadable body.
+func captureReplay(c *gin.Context) *models.ReplayQuery {
+	replay := &models.ReplayQuery{
+		Method: c.Request.Method,
+		Path:   c.Request.URL.Path,
+		Query:  replayQuery(c.Request.URL.Query()),
+	}
+	if c.Request.Body == nil || c.Request.Body == http.NoBody {
+		return replay
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxReplayBodyBytes+1))
+	c.Request.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), c.Request.Body), c.Request.Body}
+	if err != nil || len(body) > maxReplayBodyBytes {
+		return nil
+	}
+	replay.Body = string(body)
+	return replay
+}
+
+// replayQuery encodes the query string without its credential parameters.
+func replayQuery(query url.Values) string {
+	for _, name := range replayRedactedParams {
+		query.Del(name)
+	}
+	return query.Encode()
+}
+
+// auditParams returns the query string parameters, multiple values joined by
+// commas. Parameters beyond maxAuditParams and characters beyond
+// maxAuditParamLength are dropped so one request cannot bloat the log.
+func auditParams(c *gin.Context) map[string]string {
+	query := c.Request.URL.Query()
+	params := make(map[string]string, min(len(query), maxAuditParams))
+	for name, values := range query {
+		if len(params) == maxAuditParams {
+			break
+		}
+		value := strings.Join(values, ",")
+		if len(value) > maxAuditParamLength {
+			value = value[:maxAuditParamLength]
+		}
+		params[name] = value
+	}
+	return params
+}
+
+// SetResultCount records the number of parcels the response carries for the
+// query audit log and sets the X-Result-Count header. Handlers call it once
+// their query succeeded, before writing the response.
+func SetResultCount(c *gin.Context, count int) {
+	c.Set(ResultCountKey, count)
+	c.Header(ResultCountHeader, strconv.Itoa(count))
+}
+
+// GetResultCount retrieves the result count set by the handler.
+// Returns false if the handler did not set one.
+func GetResultCount(c *gin.Context) (int, bool) {
+	count, ok := c.Get(ResultCountKey)
+	if !ok {
+		return 0, false
+	}
+	n, ok := count.(int)
+	return n, ok
+}
@@ -0,0 +1,203 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+	"math"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stwalsh4118/atlas/api/internal/cache"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+)
+
+// ResponseCacheHeader reports whether a response was served from the response cache.
+const ResponseCacheHeader = "X-Cache"
+
+// responseCacheKeyPrefix namespaces response cache keys in a shared Redis.
+const responseCacheKeyPrefix = "atlas:response:"
+
+// cachedResponse is a successful response as stored in the cache. Header holds
+// the headers the handler set, not those of earlier middleware such as X-Request-ID.
+type cachedResponse struct {
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+}
+
+// ResponseCache serves repeated GET requests of a route from store for ttl.
+// Requests are keyed by a hash of the caller role, path and query parameters, with the lat
+// and lng parameters rounded to precision decimal places, so requests within
+// the same grid cell (5 places is about 1 m) share the response of the first.
+// Only 200 responses are cached. When the cache fails, the request is served
+// by the handler.
+func ResponseCache(store cache.Cache, ttl time.Duration, precision int, log *logger.Logger) gin.HandlerFunc {
+	return responseCache(store, func(*gin.Context) time.Duration { return ttl }, precision, log)
+}
+
+// RouteTTLs are the response cache TTLs by route name. They can be replaced
+// while the server runs, e.g. on a configuration reload.
+type RouteTTLs struct {
+	ttls atomic.Pointer[map[string]time.Duration]
+}
+
+// NewRouteTTLs creates the route TTLs of ttls.
+func NewRouteTTLs(ttls map[string]time.Duration) *RouteTTLs {
+	r := &RouteTTLs{}
+	r.Set(ttls)
+	return r
+}
+
+// Set replaces the TTLs of the requests that follow.
+func (r *RouteTTLs) Set(ttls map[string]time.Duration) {
+	ttls = maps.Clone(ttls)
+	r.ttls.Store(&ttls)
+}
+
+// get returns the TTL of route, zero when it is not cached.
+func (r *RouteTTLs) get(route string) time.Duration {
+	return (*r.ttls.Load())[route]
+}
+
+// ReloadableResponseCache is ResponseCache with the TTL of the request's route
+// (see GetRoute) looked up in ttls. Routes without a TTL are not cached.
+func ReloadableResponseCache(store cache.Cache, ttls *RouteTTLs, precision int, log *logger.Logger) gin.HandlerFunc {
+	return responseCache(store, func(c *gin.Context) time.Duration { return ttls.get(GetRoute(c)) }, precision, log)
+}
+
+// responseCache serves repeated GET requests from store for the TTL ttl returns.
+func responseCache(store cache.Cache, ttlOf func(*gin.Context) time.Duration, precision int, log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ttl := ttlOf(c)
+		if c.Request.Method != http.MethodGet || ttl <= 0 {
+			c.Next()
+			return
+		}
+		key, ok := responseCacheKey(c, precision)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		if data, found, err := store.Get(c.Request.Context(), key); err != nil {
+			logCacheError(log, "Failed to read response cache", err, c)
+		} else if found {
+			var cached cachedResponse
+			if err := json.Unmarshal(data, &cached); err == nil {
+				for name, values := range cached.Header {
+					c.Writer.Header()[name] = values
+				}
+				c.Header(ResponseCacheHeader, "HIT")
+				c.Status(http.StatusOK)
+				_, _ = c.Writer.Write(cached.Body)
+				c.Abort()
+				return
+			}
+		}
+
+		c.Header(ResponseCacheHeader, "MISS")
+		preset := make(map[string]bool, len(c.Writer.Header()))
+		for name := range c.Writer.Header() {
+			preset[name] = true
+		}
+		writer := &responseCacheWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		if writer.Status() != http.StatusOK || c.IsAborted() {
+			return
+		}
+		cached := cachedResponse{Header: http.Header{}, Body: writer.body.Bytes()}
+		for name, values := range writer.handlerHeader() {
+			if !preset[name] {
+				cached.Header[name] = values
+			}
+		}
+		data, err := json.Marshal(cached)
+		if err != nil {
+			return
+		}
+		if err := store.Set(c.Request.Context(), key, data, ttl); err != nil {
+			logCacheError(log, "Failed to write response cache", err, c)
+		}
+	}
+}
+
+// responseCacheKey builds the cache key of the request. Requests with
+// unparseable coordinates are not cached; the handler rejects them.
+func responseCacheKey(c *gin.Context, precision int) (string, bool) {
+	query := c.Request.URL.Query()
+	scale := math.Pow10(precision)
+	for _, name := range []string{"lat", "lng"} {
+		raw := query.Get(name)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			return "", false
+		}
+		query.Set(name, strconv.FormatFloat(math.Round(value*scale)/scale, 'f', precision, 64))
+	}
+
+	// Encode sorts parameters by name, so their order does not matter; the path
+	// tells apart the resources of routes with path parameters
+	sum := sha256.Sum256([]byte(string(GetRole(c)) + "\n" + c.Request.URL.Path + "\n" + query.Encode()))
+	return responseCacheKeyPrefix + c.FullPath() + ":" + hex.EncodeToString(sum[:]), true
+}
+
+// logCacheError logs a cache failure. The bare ErrUnavailable returned while an
+// outage is waited out is skipped; the failure that started it was logged.
+func logCacheError(log *logger.Logger, msg string, err error, c *gin.Context) {
+	if err == cache.ErrUnavailable {
+		return
+	}
+	log.Warn(msg, map[string]interface{}{
+		"error": err.Error(),
+		"path":  c.FullPath(),
+	})
+}
+
+// responseCacheWriter keeps a copy of the response body and of the headers as
+// the handler set them. Later middleware such as Compression adds headers that
+// only apply to this response's encoding once the body is written.
+type responseCacheWriter struct {
+	gin.ResponseWriter
+	header http.Header
+	body   bytes.Buffer
+}
+
+// Write copies b and writes it to the response.
+func (w *responseCacheWriter) Write(b []byte) (int, error) {
+	w.snapshotHeader()
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// WriteString copies s and writes it to the response.
+func (w *responseCacheWriter) WriteString(s string) (int, error) {
+	w.snapshotHeader()
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// snapshotHeader copies the headers before the first write passes them on.
+func (w *responseCacheWriter) snapshotHeader() {
+	if w.header == nil {
+		w.header = w.Header().Clone()
+	}
+}
+
+// handlerHeader returns the headers the handler set.
+func (w *responseCacheWriter) handlerHeader() http.Header {
+	if w.header == nil {
+		return w.Header()
+	}
+	return w.header
+}
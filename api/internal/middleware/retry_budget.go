@@ -0,0 +1,15 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+)
+
+// RetryBudget limits the retries of transient database errors to n per
+// request (see database.WithRetryBudget), across all of the request's queries.
+func RetryBudget(n int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(database.WithRetryBudget(c.Request.Context(), n))
+		c.Next()
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// RoleKey is the context key for the caller's role
+const RoleKey = "role"
+
+// Role is the audience a response is rendered for. It decides which parcel
+// fields the caller may see.
+type Role string
+
+// Caller roles
+const (
+	// RolePublic is the anonymous API caller, the default
+	RolePublic Role = "public"
+	// RolePartner is a third-party site embedding a widget; its visitors are anonymous,
+	// so it sees the fewest fields
+	RolePartner Role = "partner"
+	// RoleAdmin is an authenticated operator of a tenant
+	RoleAdmin Role = "admin"
+)
+
+// WithRole assigns a fixed role to every request of a route group, e.g. the
+// token-authorized embed routes.
+func WithRole(role Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(RoleKey, role)
+		c.Next()
+	}
+}
+
+// GetRole retrieves the caller's role from the Gin context.
+// Returns RolePublic if no role was assigned.
+func GetRole(c *gin.Context) Role {
+	if role, exists := c.Get(RoleKey); exists {
+		if r, ok := role.(Role); ok {
+			return r
+		}
+	}
+	return RolePublic
+}
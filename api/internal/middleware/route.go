@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// RouteKey is the context key for the name of the matched route
+	RouteKey = "route"
+	// RateClassKey is the context key for the rate-limit class of the matched route
+	RateClassKey = "rate_class"
+)
+
+// Route labels the request with its route name and rate-limit class, so request
+// logs and metrics group by route rather than by raw path.
+func Route(name, rateClass string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(RouteKey, name)
+		c.Set(RateClassKey, rateClass)
+		c.Next()
+	}
+}
+
+// GetRoute retrieves the route name from the Gin context.
+// Returns an empty string if the route was not registered with a name.
+func GetRoute(c *gin.Context) string {
+	return c.GetString(RouteKey)
+}
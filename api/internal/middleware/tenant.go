@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// TenantIDKey is the context key for the tenant ID
+	TenantIDKey = "tenant_id"
+	// TenantIDHeader is the HTTP header name for the tenant ID
+	TenantIDHeader = "X-Tenant-ID"
+)
+
+// Tenant reads the tenant ID from the request header and stores it in the context.
+// Tenant-scoped handlers are responsible for rejecting requests without a tenant.
+func Tenant() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := strings.ToLower(strings.TrimSpace(c.GetHeader(TenantIDHeader)))
+		if tenantID != "" {
+			c.Set(TenantIDKey, tenantID)
+		}
+
+		c.Next()
+	}
+}
+
+// GetTenantID retrieves the tenant ID from the Gin context.
+// Returns an empty string if not found.
+func GetTenantID(c *gin.Context) string {
+	if tenantID, exists := c.Get(TenantIDKey); exists {
+		if id, ok := tenantID.(string); ok {
+			return id
+		}
+	}
+	return ""
+}
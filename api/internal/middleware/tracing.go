@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stwalsh4118/atlas/api/internal/tracing"
+)
+
+// TraceIDKey is the log field of the request's trace ID.
+const TraceIDKey = "trace_id"
+
+// Tracing starts a server span per request, continuing the caller's trace when
+// it sends a traceparent header, and carries it in the request context for the
+// spans of services and queries. The span is named by the matched route and
+// marked failed on 5xx responses. The trace ID is added to the request logger
+// and returned in the traceparent response header.
+func Tracing(tracer *tracing.Tracer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, span := tracer.StartServer(c.Request.Context(), c.Request.Method+" "+c.Request.URL.Path,
+			c.GetHeader(tracing.TraceparentHeader),
+			tracing.String("http.request.method", c.Request.Method),
+			tracing.String("url.path", c.Request.URL.Path),
+			tracing.String("request.id", GetRequestID(c)),
+		)
+		if span == nil {
+			c.Next()
+			return
+		}
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Header(tracing.TraceparentHeader, span.Traceparent())
+		if log := GetLogger(c); log != nil {
+			c.Set("logger", log.With(map[string]interface{}{TraceIDKey: span.TraceID()}))
+		}
+
+		c.Next()
+
+		// Route names are bounded; raw paths are not
+		if route := c.FullPath(); route != "" {
+			span.SetName(c.Request.Method + " " + route)
+			span.SetAttributes(tracing.String("http.route", route))
+		}
+		if name := GetRoute(c); name != "" {
+			span.SetAttributes(tracing.String("atlas.route", name))
+		}
+		status := c.Writer.Status()
+		span.SetAttributes(tracing.Int("http.response.status_code", int64(status)))
+		if status >= 500 {
+			span.SetError(c.Errors.String())
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// HitRecorder receives the location of successful point queries.
+type HitRecorder interface {
+	RecordHit(point models.LatLng)
+}
+
+// UsageTracker records the lat/lng query parameters of requests that completed
+// successfully. Requests without parseable, in-range coordinates are ignored, so it can be
+// applied to a whole route group.
+func UsageTracker(recorder HitRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if c.Writer.Status() >= 400 {
+			return
+		}
+
+		lat, err := strconv.ParseFloat(c.Query("lat"), 64)
+		if err != nil {
+			return
+		}
+		lng, err := strconv.ParseFloat(c.Query("lng"), 64)
+		if err != nil {
+			return
+		}
+
+		point, err := models.NewLatLng(lat, lng)
+		if err != nil {
+			return
+		}
+
+		recorder.RecordHit(point)
+	}
+}
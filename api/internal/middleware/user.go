@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stwalsh4118/atlas/api/internal/auth"
+)
+
+const (
+	// UserIDKey is the context key for the authenticated user's ID
+	UserIDKey = "user_id"
+	// AuthorizationHeader carries user tokens as "Bearer <token>"
+	AuthorizationHeader = "Authorization"
+)
+
+// UserTokenVerifier verifies user JWTs.
+type UserTokenVerifier interface {
+	Verify(token string) (*auth.Claims, error)
+}
+
+// UserAuth validates a bearer token in the Authorization header and stores the
+// user's ID in the context. Requests without the header pass through
+// anonymously; routes that need a user enforce it. Malformed, forged and
+// expired tokens are rejected.
+//
+// It writes error responses itself, in the standard error shape, because the
+// errors package depends on this package.
+func UserAuth(verifier UserTokenVerifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := strings.TrimSpace(c.GetHeader(AuthorizationHeader))
+		if header == "" {
+			c.Next()
+			return
+		}
+
+		scheme, token, found := strings.Cut(header, " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+			abortWithError(c, http.StatusUnauthorized, "UNAUTHORIZED", "Authorization header must be a bearer token")
+			return
+		}
+
+		claims, err := verifier.Verify(strings.TrimSpace(token))
+		if err != nil {
+			message := "Invalid token"
+			if errors.Is(err, auth.ErrExpiredToken) {
+				message = "Token has expired"
+			}
+			abortWithError(c, http.StatusUnauthorized, "UNAUTHORIZED", message)
+			return
+		}
+		// Verify only accepts tokens with a valid subject
+		userID, _ := claims.UserID()
+
+		c.Set(UserIDKey, userID)
+
+		// Attribute handler logs to the user
+		if log := GetLogger(c); log != nil {
+			c.Set("logger", log.With(map[string]interface{}{"user_id": userID}))
+		}
+
+		c.Next()
+	}
+}
+
+// GetUserID retrieves the authenticated user's ID from the Gin context.
+// Returns 0 if the request did not present a valid token.
+func GetUserID(c *gin.Context) uint {
+	if userID, exists := c.Get(UserIDKey); exists {
+		if id, ok := userID.(uint); ok {
+			return id
+		}
+	}
+	return 0
+}
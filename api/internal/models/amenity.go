@@ -0,0 +1,19 @@
+package models
+
+import "slices"
+
+// Amenity categories
+const (
+	AmenitySchool      = "school"
+	AmenityHospital    = "hospital"
+	AmenityFireStation = "fire_station"
+)
+
+// AmenityCategories lists every amenity category in the order nearest-amenity
+// results report them.
+var AmenityCategories = []string{AmenitySchool, AmenityHospital, AmenityFireStation}
+
+// IsAmenityCategory reports whether category is one of AmenityCategories.
+func IsAmenityCategory(category string) bool {
+	return slices.Contains(AmenityCategories, category)
+}
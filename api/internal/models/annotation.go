@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+)
+
+// Annotation visibilities: who besides the author can read an annotation.
+const (
+	AnnotationVisibilityPrivate = "private"
+	AnnotationVisibilityShared  = "shared"
+)
+
+// Annotation is a note a user attached to a parcel, optionally with a point or
+// area drawn on the map. Private annotations are read by their author only,
+// shared ones by every signed-in user; only the author changes them. Like a
+// Favorite, it is keyed by the parcel's county and object ID, and ParcelID is
+// the parcel's current ID when read.
+type Annotation struct {
+	CreatedAt  time.Time `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt  time.Time `gorm:"column:updated_at" json:"updatedAt"`
+	Markup     *Markup   `gorm:"type:geometry(Geometry,4326);column:markup" json:"markup,omitempty"`
+	Body       string    `gorm:"size:5000;not null;column:body" json:"body"`
+	Visibility string    `gorm:"size:10;not null;column:visibility" json:"visibility"`
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	UserID     uint      `gorm:"not null;column:user_id" json:"userId"`
+	ParcelID   uint      `gorm:"not null;column:parcel_id" json:"parcelId"`
+	CountyID   uint      `gorm:"not null;column:county_id" json:"-"`
+	ObjectID   int       `gorm:"not null;column:object_id" json:"-"`
+}
+
+// TableName specifies the table name for GORM.
+func (Annotation) TableName() string {
+	return "annotations"
+}
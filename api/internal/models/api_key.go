@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+// API key roles; the values match the middleware caller roles they grant.
+const (
+	APIKeyRolePublic = "public"
+	APIKeyRoleAdmin  = "admin"
+)
+
+// APIKey is a key a tenant's clients authenticate with. Only the SHA-256 hash
+// of the key is stored; Prefix identifies the key in listings and logs.
+// Role is APIKeyRolePublic for keys calling the parcel API or APIKeyRoleAdmin
+// for keys that also manage the tenant's configuration.
+type APIKey struct {
+	CreatedAt  time.Time  `gorm:"column:created_at" json:"createdAt"`
+	LastUsedAt *time.Time `gorm:"column:last_used_at" json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at" json:"revokedAt,omitempty"`
+	TenantID   string     `gorm:"size:100;not null;column:tenant_id" json:"tenantId"`
+	Name       string     `gorm:"size:100;not null;column:name" json:"name"`
+	Prefix     string     `gorm:"size:16;not null;column:prefix" json:"prefix"`
+	KeyHash    string     `gorm:"size:64;uniqueIndex;not null;column:key_hash" json:"-"`
+	Role       string     `gorm:"size:20;not null;column:role" json:"role"`
+	ID         uint       `gorm:"primaryKey" json:"id"`
+}
+
+// TableName specifies the table name for GORM.
+func (APIKey) TableName() string {
+	return "api_keys"
+}
@@ -0,0 +1,14 @@
+package models
+
+// BoundingBox is an axis-aligned lat/lng rectangle in SRID 4326 (WGS84).
+type BoundingBox struct {
+	MinLng float64 `json:"minLng"`
+	MinLat float64 `json:"minLat"`
+	MaxLng float64 `json:"maxLng"`
+	MaxLat float64 `json:"maxLat"`
+}
+
+// Contains reports whether the point lies inside the box (edges inclusive).
+func (b BoundingBox) Contains(point LatLng) bool {
+	return point.Lat >= b.MinLat && point.Lat <= b.MaxLat && point.Lng >= b.MinLng && point.Lng <= b.MaxLng
+}
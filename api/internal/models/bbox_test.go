@@ -0,0 +1,28 @@
+package models
+
+import "testing"
+
+func TestBoundingBoxContains(t *testing.T) {
+	bbox := BoundingBox{MinLng: -95.9, MinLat: 30.0, MaxLng: -95.0, MaxLat: 30.6}
+
+	tests := []struct {
+		name string
+		lat  float64
+		lng  float64
+		want bool
+	}{
+		{"inside", 30.3, -95.5, true},
+		{"on corner", 30.0, -95.9, true},
+		{"north of box", 30.7, -95.5, false},
+		{"east of box", 30.3, -94.9, false},
+		{"swapped lat/lng", -95.5, 30.3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bbox.Contains(LatLng{Lat: tt.lat, Lng: tt.lng}); got != tt.want {
+				t.Errorf("Contains(%f, %f) = %v, want %v", tt.lat, tt.lng, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+// County is a county whose parcels are loaded into tax_parcels.
+// FieldMappings maps tax_parcels columns to the property names of the county's
+// source data; a nil source leaves the column empty. DocumentURLTemplates maps
+// document reference types to the county clerk's search URL (see package documents).
+type County struct {
+	CreatedAt            time.Time          `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt            time.Time          `gorm:"column:updated_at" json:"updatedAt"`
+	FieldMappings        map[string]*string `gorm:"type:jsonb;not null;column:field_mappings" json:"fieldMappings"`
+	DocumentURLTemplates map[string]string  `gorm:"type:jsonb;not null;column:document_url_templates" json:"documentUrlTemplates"`
+	Slug                 string             `gorm:"size:100;uniqueIndex;not null;column:slug" json:"slug"`
+	Name                 string             `gorm:"size:100;not null;column:name" json:"name"`
+	State                string             `gorm:"size:2;not null;column:state" json:"state"`
+	ID                   uint               `gorm:"primaryKey" json:"id"`
+}
+
+// TableName specifies the table name for GORM.
+func (County) TableName() string {
+	return "counties"
+}
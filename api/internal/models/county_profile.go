@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+)
+
+// CountyProfile holds the settings of one county that override the global
+// defaults. Nil limits use the defaults. Attributes flags the attributes the
+// county's data provides; an attribute that is not listed is available.
+type CountyProfile struct {
+	UpdatedAt           time.Time       `gorm:"column:updated_at" json:"updatedAt"`
+	Attributes          map[string]bool `gorm:"type:jsonb;not null;column:attributes" json:"attributes"`
+	DefaultRadiusMeters *int            `gorm:"column:default_radius_meters" json:"defaultRadiusMeters,omitempty"`
+	MaxRadiusMeters     *int            `gorm:"column:max_radius_meters" json:"maxRadiusMeters,omitempty"`
+	SimplifyTolerance   *float64        `gorm:"column:simplify_tolerance" json:"simplifyTolerance,omitempty"`
+	County              string          `gorm:"-" json:"county"`
+	Source              CountySource    `gorm:"type:jsonb;not null;column:source" json:"source"`
+	CountyID            uint            `gorm:"primaryKey;column:county_id" json:"countyId"`
+}
+
+// CountySource is where the county's parcels are loaded from: the ArcGIS
+// REST layer and the options cmd/ingest takes as -arcgis-* flags, and the
+// field mapping file.
+type CountySource struct {
+	ArcGISURL       string  `json:"arcgisUrl,omitempty"`
+	ArcGISWhere     string  `json:"arcgisWhere,omitempty"`
+	Mapping         string  `json:"mapping,omitempty"`
+	ArcGISPageSize  int     `json:"arcgisPageSize,omitempty"`
+	ArcGISRateLimit float64 `json:"arcgisRateLimit,omitempty"`
+}
+
+// Available reports whether the county's data provides the attribute.
+func (p *CountyProfile) Available(attribute string) bool {
+	if p == nil {
+		return true
+	}
+	available, ok := p.Attributes[attribute]
+	return !ok || available
+}
+
+// TableName specifies the table name for GORM.
+func (CountyProfile) TableName() string {
+	return "county_profiles"
+}
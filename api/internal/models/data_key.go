@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+// DataKey is a data encryption key for owner contact columns, stored wrapped
+// (encrypted) by the master key MasterKeyID of the key provider. The newest
+// data key encrypts new values; older keys are kept to decrypt existing ones.
+type DataKey struct {
+	CreatedAt   time.Time `gorm:"column:created_at" json:"createdAt"`
+	MasterKeyID string    `gorm:"size:100;not null;column:master_key_id" json:"masterKeyId"`
+	WrappedKey  []byte    `gorm:"type:bytea;not null;column:wrapped_key" json:"-"`
+	ID          uint      `gorm:"primaryKey" json:"id"`
+}
+
+// TableName specifies the table name for GORM.
+func (DataKey) TableName() string {
+	return "data_keys"
+}
@@ -0,0 +1,34 @@
+package models
+
+// Document reference types
+const (
+	// DocumentTypeVolumePage is a deed record cited by volume and page
+	DocumentTypeVolumePage = "volume_page"
+	// DocumentTypeInstrument is a recorded document cited by its instrument (clerk's file) number
+	DocumentTypeInstrument = "instrument"
+	// DocumentTypePlat is a subdivision plat cited by plat cabinet and slide (or sheet)
+	DocumentTypePlat = "plat"
+)
+
+// Document reference sources
+const (
+	// DocumentSourceLegalDescription marks references parsed from the parcel's legal description
+	DocumentSourceLegalDescription = "legal_description"
+)
+
+// DocumentReference is a recorded deed, plat or other instrument a parcel's
+// records cite. Only the fields of its Type are set. URL deep-links to the
+// county clerk's records search when the county has a template for the type.
+type DocumentReference struct {
+	Type       string `json:"type"`
+	Volume     string `json:"volume,omitempty"`
+	Page       string `json:"page,omitempty"`
+	Instrument string `json:"instrument,omitempty"`
+	Cabinet    string `json:"cabinet,omitempty"`
+	Slide      string `json:"slide,omitempty"`
+	// Source is where the reference was found, e.g. DocumentSourceLegalDescription
+	Source string `json:"source"`
+	// Text is the cited text as it appears in the source
+	Text string `json:"text"`
+	URL  string `json:"url,omitempty"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+)
+
+// EmbedWidget is a tenant's configuration for the embeddable parcel viewer.
+// Partners receive a signed token for a widget; the token grants read access
+// to parcels inside AllowedBBox, exposing only VisibleFields.
+type EmbedWidget struct {
+	CreatedAt     time.Time      `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt     time.Time      `gorm:"column:updated_at" json:"updatedAt"`
+	Branding      WidgetBranding `gorm:"type:jsonb;column:branding" json:"branding"`
+	TenantID      string         `gorm:"size:100;not null;column:tenant_id" json:"tenantId"`
+	Name          string         `gorm:"size:100;not null;column:name" json:"name"`
+	VisibleFields []string       `gorm:"type:text[];column:visible_fields" json:"visibleFields"`
+	AllowedBBox   BoundingBox    `gorm:"embedded" json:"allowedBbox"`
+	ID            uint           `gorm:"primaryKey" json:"id"`
+}
+
+// TableName specifies the table name for GORM.
+func (EmbedWidget) TableName() string {
+	return "embed_widgets"
+}
+
+// WidgetBranding customizes the look of the embedded viewer.
+type WidgetBranding struct {
+	Title        string `json:"title,omitempty"`
+	LogoURL      string `json:"logoUrl,omitempty"`
+	PrimaryColor string `json:"primaryColor,omitempty"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+// Favorite is a parcel a user bookmarked, with an optional note. It is keyed
+// by the parcel's county and object ID, so it follows the parcel across replace
+// loads, which reissue parcel IDs. ParcelID is the parcel's current ID when
+// read; Situs and CountyName are read from the parcel, and Removed is set when
+// the parcel is no longer in the dataset, leaving ParcelID and PIN as saved.
+type Favorite struct {
+	CreatedAt  time.Time `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt  time.Time `gorm:"column:updated_at" json:"updatedAt"`
+	Note       *string   `gorm:"size:1000;column:note" json:"note,omitempty"`
+	Situs      *string   `gorm:"-" json:"situs,omitempty"`
+	CountyName *string   `gorm:"-" json:"countyName,omitempty"`
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	UserID     uint      `gorm:"not null;column:user_id" json:"-"`
+	ParcelID   uint      `gorm:"not null;column:parcel_id" json:"parcelId"`
+	CountyID   uint      `gorm:"not null;column:county_id" json:"-"`
+	ObjectID   int       `gorm:"not null;column:object_id" json:"-"`
+	PIN        int       `gorm:"not null;column:pin" json:"pin"`
+	Removed    bool      `gorm:"-" json:"removed"`
+}
+
+// TableName specifies the table name for GORM.
+func (Favorite) TableName() string {
+	return "favorites"
+}
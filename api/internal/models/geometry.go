@@ -4,6 +4,8 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 // Polygon represents a PostGIS Polygon geometry.
@@ -105,6 +107,33 @@ func (p *Polygon) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Contains reports whether the point lies inside the polygon, excluding holes.
+// It uses planar even-odd ray casting on the GeoJSON [lon,lat] coordinates, independent
+// of PostGIS. Points exactly on an edge may be reported either way.
+func (p Polygon) Contains(point LatLng) bool {
+	inside := false
+	for _, ring := range p.Coordinates {
+		if ringCrossesOddly(ring, point.Lat, point.Lng) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// ringCrossesOddly reports whether a ray cast east from the point crosses the ring an odd number of times.
+func ringCrossesOddly(ring [][2]float64, lat, lng float64) bool {
+	odd := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		lngI, latI := ring[i][0], ring[i][1]
+		lngJ, latJ := ring[j][0], ring[j][1]
+		if (latI > lat) != (latJ > lat) &&
+			lng < (lngJ-lngI)*(lat-latI)/(latJ-latI)+lngI {
+			odd = !odd
+		}
+	}
+	return odd
+}
+
 // MultiPolygon represents a PostGIS MultiPolygon geometry.
 // It stores coordinates in GeoJSON format: [polygons][rings][points][lon,lat]
 // SRID 4326 (WGS84) is used for lat/lng coordinates.
@@ -127,6 +156,11 @@ func (mp *MultiPolygon) Scan(value interface{}) error {
 		return fmt.Errorf("failed to scan MultiPolygon: expected []byte, got %T", value)
 	}
 
+	// Queries that skip geometry select NULL, which arrives as an empty slice
+	if len(bytes) == 0 {
+		return nil
+	}
+
 	// Parse GeoJSON geometry structure
 	var geom struct {
 		Type        string           `json:"type"`
@@ -203,3 +237,522 @@ func (mp *MultiPolygon) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// Contains reports whether the lat/lng point lies inside any member polygon.
+// See Polygon.Contains for the method and its edge behavior.
+func (mp MultiPolygon) Contains(point LatLng) bool {
+	for _, rings := range mp.Coordinates {
+		if (Polygon{Coordinates: rings}).Contains(point) {
+			return true
+		}
+	}
+	return false
+}
+
+// WKT returns the multipolygon as OGC Well-Known Text, e.g.
+// "MULTIPOLYGON (((-77.1 39.1, -77.0 39.1, -77.0 39.2, -77.1 39.1)))", with
+// coordinates in lon lat order. A multipolygon without members is "MULTIPOLYGON EMPTY".
+func (mp MultiPolygon) WKT() string {
+	if len(mp.Coordinates) == 0 {
+		return "MULTIPOLYGON EMPTY"
+	}
+
+	var b strings.Builder
+	b.WriteString("MULTIPOLYGON (")
+	for i, rings := range mp.Coordinates {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteByte('(')
+		for j, ring := range rings {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteByte('(')
+			for k, position := range ring {
+				if k > 0 {
+					b.WriteString(", ")
+				}
+				b.WriteString(strconv.FormatFloat(position[0], 'f', -1, 64))
+				b.WriteByte(' ')
+				b.WriteString(strconv.FormatFloat(position[1], 'f', -1, 64))
+			}
+			b.WriteByte(')')
+		}
+		b.WriteByte(')')
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// ParseAreaGeoJSON parses a GeoJSON Polygon or MultiPolygon geometry object into a MultiPolygon.
+// A Polygon is wrapped as a single-member MultiPolygon so callers can treat user-drawn
+// areas uniformly. Other geometry types are rejected.
+func ParseAreaGeoJSON(data []byte) (*MultiPolygon, error) {
+	var geom struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+
+	if err := json.Unmarshal(data, &geom); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal geometry: %w", err)
+	}
+
+	switch geom.Type {
+	case "Polygon":
+		var rings [][][2]float64
+		if err := json.Unmarshal(geom.Coordinates, &rings); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal polygon coordinates: %w", err)
+		}
+		return &MultiPolygon{Coordinates: [][][][2]float64{rings}, SRID: 4326}, nil
+	case "MultiPolygon":
+		var polygons [][][][2]float64
+		if err := json.Unmarshal(geom.Coordinates, &polygons); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal multipolygon coordinates: %w", err)
+		}
+		return &MultiPolygon{Coordinates: polygons, SRID: 4326}, nil
+	default:
+		return nil, fmt.Errorf("expected Polygon or MultiPolygon type, got %q", geom.Type)
+	}
+}
+
+// LineString represents a GeoJSON LineString such as a user-drawn route.
+// It stores coordinates in GeoJSON format: [points][lon,lat]
+// SRID 4326 (WGS84) is used for lat/lng coordinates.
+type LineString struct {
+	Coordinates [][2]float64 // GeoJSON coordinate structure for LineString
+	SRID        int          // Spatial Reference ID (default: 4326)
+}
+
+// Value implements driver.Valuer interface for writing linestring geometry to database.
+// Returns GeoJSON string to be used with ST_GeomFromGeoJSON in raw SQL queries.
+func (ls LineString) Value() (driver.Value, error) {
+	if len(ls.Coordinates) == 0 {
+		return nil, nil
+	}
+
+	// Convert to GeoJSON format
+	geom := map[string]interface{}{
+		"type":        "LineString",
+		"coordinates": ls.Coordinates,
+	}
+
+	geoJSON, err := json.Marshal(geom)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal linestring to GeoJSON: %w", err)
+	}
+
+	// Return as string for use with ST_GeomFromGeoJSON
+	return string(geoJSON), nil
+}
+
+// Scan implements sql.Scanner interface for reading linestring geometry from ST_AsGeoJSON.
+func (ls *LineString) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("failed to scan LineString: expected []byte, got %T", value)
+	}
+
+	// Queries that skip geometry select NULL, which arrives as an empty slice
+	if len(bytes) == 0 {
+		return nil
+	}
+
+	var geom struct {
+		Type        string       `json:"type"`
+		Coordinates [][2]float64 `json:"coordinates"`
+	}
+
+	if err := json.Unmarshal(bytes, &geom); err != nil {
+		return fmt.Errorf("failed to unmarshal linestring geometry: %w", err)
+	}
+
+	if geom.Type != "LineString" {
+		return fmt.Errorf("expected LineString type, got %s", geom.Type)
+	}
+
+	ls.Coordinates = geom.Coordinates
+	ls.SRID = 4326
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for API responses.
+// Returns GeoJSON-compliant format for frontend consumption.
+func (ls LineString) MarshalJSON() ([]byte, error) {
+	geom := struct {
+		Type        string       `json:"type"`
+		Coordinates [][2]float64 `json:"coordinates"`
+	}{
+		Type:        "LineString",
+		Coordinates: ls.Coordinates,
+	}
+	return json.Marshal(geom)
+}
+
+// UnmarshalJSON implements json.Unmarshaler for parsing GeoJSON input.
+func (ls *LineString) UnmarshalJSON(data []byte) error {
+	var geom struct {
+		Type        string       `json:"type"`
+		Coordinates [][2]float64 `json:"coordinates"`
+	}
+
+	if err := json.Unmarshal(data, &geom); err != nil {
+		return fmt.Errorf("failed to unmarshal linestring: %w", err)
+	}
+
+	if geom.Type != "" && geom.Type != "LineString" {
+		return fmt.Errorf("expected LineString type, got %s", geom.Type)
+	}
+
+	ls.Coordinates = geom.Coordinates
+	ls.SRID = 4326
+
+	return nil
+}
+
+// ParseRouteGeoJSON parses a GeoJSON LineString geometry object into a LineString.
+// Other geometry types are rejected.
+func ParseRouteGeoJSON(data []byte) (*LineString, error) {
+	var geom struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+
+	if err := json.Unmarshal(data, &geom); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal geometry: %w", err)
+	}
+
+	if geom.Type != "LineString" {
+		return nil, fmt.Errorf("expected LineString type, got %q", geom.Type)
+	}
+
+	var positions [][2]float64
+	if err := json.Unmarshal(geom.Coordinates, &positions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal linestring coordinates: %w", err)
+	}
+	return &LineString{Coordinates: positions, SRID: 4326}, nil
+}
+
+// Point represents a PostGIS Point geometry such as an address point.
+// It stores its position in GeoJSON format: [lon,lat]
+// SRID 4326 (WGS84) is used for lat/lng coordinates.
+type Point struct {
+	Coordinates [2]float64 // GeoJSON position
+	SRID        int        // Spatial Reference ID (default: 4326)
+}
+
+// NewPoint returns the point at the lat/lng position.
+func NewPoint(position LatLng) Point {
+	return Point{Coordinates: position.GeoJSONPosition(), SRID: 4326}
+}
+
+// LatLng returns the point's position in latitude, longitude order.
+func (p Point) LatLng() LatLng {
+	return LatLng{Lat: p.Coordinates[1], Lng: p.Coordinates[0]}
+}
+
+// Scan implements sql.Scanner interface for reading point geometry from ST_AsGeoJSON.
+func (p *Point) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("failed to scan Point: expected []byte, got %T", value)
+	}
+
+	// Queries that skip geometry select NULL, which arrives as an empty slice
+	if len(bytes) == 0 {
+		return nil
+	}
+
+	var geom struct {
+		Type        string     `json:"type"`
+		Coordinates [2]float64 `json:"coordinates"`
+	}
+
+	if err := json.Unmarshal(bytes, &geom); err != nil {
+		return fmt.Errorf("failed to unmarshal point geometry: %w", err)
+	}
+
+	if geom.Type != "Point" {
+		return fmt.Errorf("expected Point type, got %s", geom.Type)
+	}
+
+	p.Coordinates = geom.Coordinates
+	p.SRID = 4326
+
+	return nil
+}
+
+// Value implements driver.Valuer interface for writing point geometry to database.
+// Returns GeoJSON string to be used with ST_GeomFromGeoJSON in raw SQL queries.
+// Every Point has a position, so write a nil *Point for NULL.
+func (p Point) Value() (driver.Value, error) {
+	geoJSON, err := json.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal point to GeoJSON: %w", err)
+	}
+
+	// Return as string for use with ST_GeomFromGeoJSON
+	return string(geoJSON), nil
+}
+
+// MarshalJSON implements json.Marshaler for API responses.
+// Returns GeoJSON-compliant format for frontend consumption.
+func (p Point) MarshalJSON() ([]byte, error) {
+	geom := struct {
+		Type        string     `json:"type"`
+		Coordinates [2]float64 `json:"coordinates"`
+	}{
+		Type:        "Point",
+		Coordinates: p.Coordinates,
+	}
+	return json.Marshal(geom)
+}
+
+// UnmarshalJSON implements json.Unmarshaler for parsing GeoJSON input.
+// Coordinates are not range-checked; see LatLng.Validate.
+func (p *Point) UnmarshalJSON(data []byte) error {
+	var geom struct {
+		Type        string     `json:"type"`
+		Coordinates [2]float64 `json:"coordinates"`
+	}
+
+	if err := json.Unmarshal(data, &geom); err != nil {
+		return fmt.Errorf("failed to unmarshal point: %w", err)
+	}
+
+	if geom.Type != "" && geom.Type != "Point" {
+		return fmt.Errorf("expected Point type, got %s", geom.Type)
+	}
+
+	p.Coordinates = geom.Coordinates
+	p.SRID = 4326
+
+	return nil
+}
+
+// Geometry is a GeoJSON geometry of any supported type, such as the features
+// of road, utility line or address point layers. Exactly one of Point,
+// LineString, Polygon and MultiPolygon is set; the zero Geometry is NULL.
+type Geometry struct {
+	Point        *Point
+	LineString   *LineString
+	Polygon      *Polygon
+	MultiPolygon *MultiPolygon
+}
+
+// Type returns the GeoJSON type of the geometry, or "" when none is set.
+func (g Geometry) Type() string {
+	switch {
+	case g.Point != nil:
+		return "Point"
+	case g.LineString != nil:
+		return "LineString"
+	case g.Polygon != nil:
+		return "Polygon"
+	case g.MultiPolygon != nil:
+		return "MultiPolygon"
+	default:
+		return ""
+	}
+}
+
+// Scan implements sql.Scanner interface for reading geometry from ST_AsGeoJSON.
+func (g *Geometry) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("failed to scan Geometry: expected []byte, got %T", value)
+	}
+
+	// Queries that skip geometry select NULL, which arrives as an empty slice
+	if len(bytes) == 0 {
+		return nil
+	}
+
+	return g.UnmarshalJSON(bytes)
+}
+
+// Value implements driver.Valuer interface for writing geometry to database.
+// Returns GeoJSON string to be used with ST_GeomFromGeoJSON in raw SQL queries.
+func (g Geometry) Value() (driver.Value, error) {
+	switch {
+	case g.Point != nil:
+		return g.Point.Value()
+	case g.LineString != nil:
+		return g.LineString.Value()
+	case g.Polygon != nil:
+		return g.Polygon.Value()
+	case g.MultiPolygon != nil:
+		return g.MultiPolygon.Value()
+	default:
+		return nil, nil
+	}
+}
+
+// MarshalJSON implements json.Marshaler for API responses.
+// Returns the GeoJSON geometry of the set type, or null.
+func (g Geometry) MarshalJSON() ([]byte, error) {
+	switch {
+	case g.Point != nil:
+		return g.Point.MarshalJSON()
+	case g.LineString != nil:
+		return g.LineString.MarshalJSON()
+	case g.Polygon != nil:
+		return g.Polygon.MarshalJSON()
+	case g.MultiPolygon != nil:
+		return g.MultiPolygon.MarshalJSON()
+	default:
+		return []byte("null"), nil
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler for parsing GeoJSON input.
+// It dispatches on the geometry's type; other types, including
+// MultiLineString, MultiPoint and GeometryCollection, are rejected.
+func (g *Geometry) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var geom struct {
+		Type string `json:"type"`
+	}
+
+	if err := json.Unmarshal(data, &geom); err != nil {
+		return fmt.Errorf("failed to unmarshal geometry: %w", err)
+	}
+
+	var parsed Geometry
+	var target json.Unmarshaler
+	switch geom.Type {
+	case "Point":
+		parsed.Point = &Point{}
+		target = parsed.Point
+	case "LineString":
+		parsed.LineString = &LineString{}
+		target = parsed.LineString
+	case "Polygon":
+		parsed.Polygon = &Polygon{}
+		target = parsed.Polygon
+	case "MultiPolygon":
+		parsed.MultiPolygon = &MultiPolygon{}
+		target = parsed.MultiPolygon
+	default:
+		return fmt.Errorf("expected Point, LineString, Polygon or MultiPolygon type, got %q", geom.Type)
+	}
+
+	if err := target.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*g = parsed
+	return nil
+}
+
+// Markup is a point or area drawn on the map, such as the markup of an
+// annotation: a GeoJSON Point, Polygon or MultiPolygon. Exactly one of Point and
+// Area is set; a Polygon is held as a single-member MultiPolygon.
+type Markup struct {
+	Point *LatLng
+	Area  *MultiPolygon
+}
+
+// Scan implements sql.Scanner interface for reading markup from ST_AsGeoJSON.
+func (m *Markup) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("failed to scan Markup: expected []byte, got %T", value)
+	}
+
+	parsed, err := ParseMarkupGeoJSON(bytes)
+	if err != nil {
+		return err
+	}
+	*m = *parsed
+	return nil
+}
+
+// Value implements driver.Valuer interface for writing markup to database.
+// Returns GeoJSON string to be used with ST_GeomFromGeoJSON in raw SQL queries.
+func (m Markup) Value() (driver.Value, error) {
+	if m.Area != nil {
+		return m.Area.Value()
+	}
+	if m.Point == nil {
+		return nil, nil
+	}
+
+	geoJSON, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return string(geoJSON), nil
+}
+
+// MarshalJSON implements json.Marshaler for API responses.
+// Returns a GeoJSON Point or MultiPolygon geometry.
+func (m Markup) MarshalJSON() ([]byte, error) {
+	if m.Area != nil {
+		return m.Area.MarshalJSON()
+	}
+	if m.Point == nil {
+		return []byte("null"), nil
+	}
+
+	geom := struct {
+		Type        string     `json:"type"`
+		Coordinates [2]float64 `json:"coordinates"`
+	}{
+		Type:        "Point",
+		Coordinates: m.Point.GeoJSONPosition(),
+	}
+	return json.Marshal(geom)
+}
+
+// ParseMarkupGeoJSON parses a GeoJSON Point, Polygon or MultiPolygon geometry
+// object into a Markup. Other geometry types are rejected; coordinates are
+// not range-checked.
+func ParseMarkupGeoJSON(data []byte) (*Markup, error) {
+	var geom struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+
+	if err := json.Unmarshal(data, &geom); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal geometry: %w", err)
+	}
+
+	switch geom.Type {
+	case "Point":
+	case "Polygon", "MultiPolygon":
+		area, err := ParseAreaGeoJSON(data)
+		if err != nil {
+			return nil, err
+		}
+		return &Markup{Area: area}, nil
+	default:
+		return nil, fmt.Errorf("expected Point, Polygon or MultiPolygon type, got %q", geom.Type)
+	}
+
+	var position [2]float64
+	if err := json.Unmarshal(geom.Coordinates, &position); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal point coordinates: %w", err)
+	}
+	// GeoJSON positions are [lng, lat]
+	return &Markup{Point: &LatLng{Lat: position[1], Lng: position[0]}}, nil
+}
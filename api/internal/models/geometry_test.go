@@ -145,6 +145,56 @@ func TestPolygonScan(t *testing.T) {
 	}
 }
 
+// TestMultiPolygonScan tests the Scan method including geometry-free rows
+func TestMultiPolygonScan(t *testing.T) {
+	tests := []struct {
+		input     interface{}
+		name      string
+		wantError bool
+		wantEmpty bool
+	}{
+		{
+			name:      "nil value",
+			input:     nil,
+			wantEmpty: true,
+		},
+		{
+			name:      "NULL geometry column",
+			input:     []byte(nil),
+			wantEmpty: true,
+		},
+		{
+			name:  "valid GeoJSON",
+			input: []byte(`{"type":"MultiPolygon","coordinates":[[[[-95.5,30.2],[-95.4,30.2],[-95.4,30.3],[-95.5,30.2]]]]}`),
+		},
+		{
+			name:      "wrong type",
+			input:     []byte(`{"type":"Polygon","coordinates":[]}`),
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mp MultiPolygon
+			err := mp.Scan(tt.input)
+
+			if tt.wantError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := len(mp.Coordinates) == 0; got != tt.wantEmpty {
+				t.Errorf("empty coordinates = %v, want %v", got, tt.wantEmpty)
+			}
+		})
+	}
+}
+
 // TestPolygonJSON tests JSON marshaling/unmarshaling
 func TestPolygonJSON(t *testing.T) {
 	original := Polygon{
@@ -175,3 +225,389 @@ func TestPolygonJSON(t *testing.T) {
 		t.Errorf("SRID mismatch: got %d, want %d", decoded.SRID, original.SRID)
 	}
 }
+
+// TestParseAreaGeoJSON tests parsing user-drawn Polygon/MultiPolygon areas
+func TestParseAreaGeoJSON(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		wantPolygons int
+		wantErr      bool
+	}{
+		{
+			name:         "polygon is wrapped",
+			input:        `{"type":"Polygon","coordinates":[[[-95.5,30.2],[-95.4,30.2],[-95.4,30.3],[-95.5,30.2]]]}`,
+			wantPolygons: 1,
+		},
+		{
+			name:         "multipolygon",
+			input:        `{"type":"MultiPolygon","coordinates":[[[[-95.5,30.2],[-95.4,30.2],[-95.4,30.3],[-95.5,30.2]]],[[[-95.3,30.2],[-95.2,30.2],[-95.2,30.3],[-95.3,30.2]]]]}`,
+			wantPolygons: 2,
+		},
+		{
+			name:    "point rejected",
+			input:   `{"type":"Point","coordinates":[-95.5,30.2]}`,
+			wantErr: true,
+		},
+		{
+			name:    "wrong coordinate nesting",
+			input:   `{"type":"Polygon","coordinates":[-95.5,30.2]}`,
+			wantErr: true,
+		},
+		{
+			name:    "not json",
+			input:   `POLYGON((-95.5 30.2))`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mp, err := ParseAreaGeoJSON([]byte(tt.input))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(mp.Coordinates) != tt.wantPolygons {
+				t.Errorf("expected %d polygons, got %d", tt.wantPolygons, len(mp.Coordinates))
+			}
+			if mp.SRID != 4326 {
+				t.Errorf("expected SRID 4326, got %d", mp.SRID)
+			}
+		})
+	}
+}
+
+// TestParseRouteGeoJSON tests parsing user-drawn LineString routes
+func TestParseRouteGeoJSON(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		wantPositions int
+		wantErr       bool
+	}{
+		{
+			name:          "linestring",
+			input:         `{"type":"LineString","coordinates":[[-95.5,30.2],[-95.4,30.2],[-95.4,30.3]]}`,
+			wantPositions: 3,
+		},
+		{
+			name:    "polygon rejected",
+			input:   `{"type":"Polygon","coordinates":[[[-95.5,30.2],[-95.4,30.2],[-95.4,30.3],[-95.5,30.2]]]}`,
+			wantErr: true,
+		},
+		{
+			name:    "wrong coordinate nesting",
+			input:   `{"type":"LineString","coordinates":[-95.5,30.2]}`,
+			wantErr: true,
+		},
+		{
+			name:    "not json",
+			input:   `LINESTRING(-95.5 30.2, -95.4 30.2)`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route, err := ParseRouteGeoJSON([]byte(tt.input))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(route.Coordinates) != tt.wantPositions {
+				t.Errorf("expected %d positions, got %d", tt.wantPositions, len(route.Coordinates))
+			}
+			value, err := route.Value()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if value != `{"coordinates":[[-95.5,30.2],[-95.4,30.2],[-95.4,30.3]],"type":"LineString"}` {
+				t.Errorf("unexpected GeoJSON: %v", value)
+			}
+		})
+	}
+}
+
+// TestParseMarkupGeoJSON tests parsing annotation markup and writing it back as GeoJSON
+func TestParseMarkupGeoJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantValue string
+		wantErr   bool
+	}{
+		{
+			name:      "point",
+			input:     `{"type":"Point","coordinates":[-95.5,30.2]}`,
+			wantValue: `{"type":"Point","coordinates":[-95.5,30.2]}`,
+		},
+		{
+			name:      "polygon wrapped as multipolygon",
+			input:     `{"type":"Polygon","coordinates":[[[-95.5,30.2],[-95.4,30.2],[-95.4,30.3],[-95.5,30.2]]]}`,
+			wantValue: `{"coordinates":[[[[-95.5,30.2],[-95.4,30.2],[-95.4,30.3],[-95.5,30.2]]]],"type":"MultiPolygon"}`,
+		},
+		{
+			name:    "linestring rejected",
+			input:   `{"type":"LineString","coordinates":[[-95.5,30.2],[-95.4,30.2]]}`,
+			wantErr: true,
+		},
+		{
+			name:    "wrong coordinate nesting",
+			input:   `{"type":"Point","coordinates":[[-95.5,30.2]]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			markup, err := ParseMarkupGeoJSON([]byte(tt.input))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			value, err := markup.Value()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if value != tt.wantValue {
+				t.Errorf("unexpected GeoJSON: %v", value)
+			}
+
+			// Scanning the stored GeoJSON reads back the same markup
+			var scanned Markup
+			if err := scanned.Scan([]byte(value.(string))); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if rescanned, _ := scanned.Value(); rescanned != value {
+				t.Errorf("expected %v after scanning, got %v", value, rescanned)
+			}
+		})
+	}
+}
+
+// TestMultiPolygonContains tests the planar point-in-polygon check
+func TestMultiPolygonContains(t *testing.T) {
+	// A 1x1 degree square with a hole in the middle, plus a separate square to the east
+	outer := [][2]float64{{-96, 30}, {-95, 30}, {-95, 31}, {-96, 31}, {-96, 30}}
+	hole := [][2]float64{{-95.6, 30.4}, {-95.4, 30.4}, {-95.4, 30.6}, {-95.6, 30.6}, {-95.6, 30.4}}
+	east := [][2]float64{{-94, 30}, {-93, 30}, {-93, 31}, {-94, 31}, {-94, 30}}
+	mp := MultiPolygon{Coordinates: [][][][2]float64{{outer, hole}, {east}}}
+
+	tests := []struct {
+		name string
+		lat  float64
+		lng  float64
+		want bool
+	}{
+		{name: "inside first polygon", lat: 30.1, lng: -95.9, want: true},
+		{name: "inside hole", lat: 30.5, lng: -95.5, want: false},
+		{name: "inside second polygon", lat: 30.5, lng: -93.5, want: true},
+		{name: "between polygons", lat: 30.5, lng: -94.5, want: false},
+		{name: "swapped axis order", lat: -95.9, lng: 30.1, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mp.Contains(LatLng{Lat: tt.lat, Lng: tt.lng}); got != tt.want {
+				t.Errorf("Contains(%v, %v) = %v, want %v", tt.lat, tt.lng, got, tt.want)
+			}
+		})
+	}
+
+	if (MultiPolygon{}).Contains(LatLng{Lat: 30.5, Lng: -95.5}) {
+		t.Error("Expected empty MultiPolygon to contain nothing")
+	}
+}
+
+func TestMultiPolygonWKT(t *testing.T) {
+	square := [][2]float64{{-96, 30}, {-95.5, 30}, {-95.5, 30.25}, {-96, 30}}
+	hole := [][2]float64{{-95.9, 30.1}, {-95.8, 30.1}, {-95.8, 30.2}, {-95.9, 30.1}}
+	east := [][2]float64{{-94, 30}, {-93, 30}, {-93, 31}, {-94, 30}}
+
+	tests := []struct {
+		name string
+		mp   MultiPolygon
+		want string
+	}{
+		{name: "empty", mp: MultiPolygon{}, want: "MULTIPOLYGON EMPTY"},
+		{
+			name: "polygon with hole",
+			mp:   MultiPolygon{Coordinates: [][][][2]float64{{square, hole}}},
+			want: "MULTIPOLYGON (((-96 30, -95.5 30, -95.5 30.25, -96 30), (-95.9 30.1, -95.8 30.1, -95.8 30.2, -95.9 30.1)))",
+		},
+		{
+			name: "two polygons",
+			mp:   MultiPolygon{Coordinates: [][][][2]float64{{square}, {east}}},
+			want: "MULTIPOLYGON (((-96 30, -95.5 30, -95.5 30.25, -96 30)), ((-94 30, -93 30, -93 31, -94 30)))",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.mp.WKT(); got != tt.want {
+				t.Errorf("WKT() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestLineStringScan tests reading linestrings including geometry-free rows
+func TestLineStringScan(t *testing.T) {
+	tests := []struct {
+		input         interface{}
+		name          string
+		wantPositions int
+		wantError     bool
+	}{
+		{name: "nil value", input: nil},
+		{name: "NULL geometry column", input: []byte(nil)},
+		{
+			name:          "valid GeoJSON",
+			input:         []byte(`{"type":"LineString","coordinates":[[-95.5,30.2],[-95.4,30.2]]}`),
+			wantPositions: 2,
+		},
+		{name: "wrong type", input: []byte(`{"type":"Point","coordinates":[-95.5,30.2]}`), wantError: true},
+		{name: "unsupported input type", input: "not a byte slice", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ls LineString
+			err := ls.Scan(tt.input)
+
+			if tt.wantError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(ls.Coordinates) != tt.wantPositions {
+				t.Errorf("expected %d positions, got %d", tt.wantPositions, len(ls.Coordinates))
+			}
+		})
+	}
+}
+
+// TestPointJSON tests point GeoJSON round trips and the lat/lng conversion
+func TestPointJSON(t *testing.T) {
+	point := NewPoint(LatLng{Lat: 30.2, Lng: -95.5})
+
+	data, err := json.Marshal(point)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != `{"type":"Point","coordinates":[-95.5,30.2]}` {
+		t.Errorf("unexpected GeoJSON: %s", data)
+	}
+
+	var scanned Point
+	if err := scanned.Scan(data); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if scanned.LatLng() != (LatLng{Lat: 30.2, Lng: -95.5}) {
+		t.Errorf("LatLng() = %v", scanned.LatLng())
+	}
+	if scanned.SRID != 4326 {
+		t.Errorf("expected SRID 4326, got %d", scanned.SRID)
+	}
+
+	value, err := scanned.Value()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value != string(data) {
+		t.Errorf("Value() = %v, want %s", value, data)
+	}
+
+	if err := json.Unmarshal([]byte(`{"type":"LineString","coordinates":[[0,0],[1,1]]}`), &scanned); err == nil {
+		t.Error("expected error for LineString but got none")
+	}
+}
+
+// TestGeometryJSON tests dispatching GeoJSON geometries on their type
+func TestGeometryJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantType string
+		wantErr  bool
+	}{
+		{name: "point", input: `{"type":"Point","coordinates":[-95.5,30.2]}`, wantType: "Point"},
+		{name: "linestring", input: `{"type":"LineString","coordinates":[[-95.5,30.2],[-95.4,30.2]]}`, wantType: "LineString"},
+		{
+			name:     "polygon",
+			input:    `{"type":"Polygon","coordinates":[[[-95.5,30.2],[-95.4,30.2],[-95.4,30.3],[-95.5,30.2]]]}`,
+			wantType: "Polygon",
+		},
+		{
+			name:     "multipolygon",
+			input:    `{"type":"MultiPolygon","coordinates":[[[[-95.5,30.2],[-95.4,30.2],[-95.4,30.3],[-95.5,30.2]]]]}`,
+			wantType: "MultiPolygon",
+		},
+		{name: "unsupported type", input: `{"type":"MultiLineString","coordinates":[[[0,0],[1,1]]]}`, wantErr: true},
+		{name: "mismatched coordinates", input: `{"type":"Point","coordinates":[[0,0],[1,1]]}`, wantErr: true},
+		{name: "not json", input: `POINT(-95.5 30.2)`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var g Geometry
+			err := json.Unmarshal([]byte(tt.input), &g)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if g.Type() != tt.wantType {
+				t.Errorf("Type() = %q, want %q", g.Type(), tt.wantType)
+			}
+
+			// Marshaling and scanning give back the same geometry
+			data, err := json.Marshal(g)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			var scanned Geometry
+			if err := scanned.Scan(data); err != nil {
+				t.Fatalf("Scan failed: %v", err)
+			}
+			if scanned.Type() != tt.wantType {
+				t.Errorf("scanned Type() = %q, want %q", scanned.Type(), tt.wantType)
+			}
+			if value, err := scanned.Value(); err != nil || value == nil {
+				t.Errorf("Value() = %v, %v", value, err)
+			}
+		})
+	}
+
+	var empty Geometry
+	if data, _ := json.Marshal(empty); string(data) != "null" {
+		t.Errorf("expected null for the zero Geometry, got %s", data)
+	}
+	if value, _ := empty.Value(); value != nil {
+		t.Errorf("expected nil value for the zero Geometry, got %v", value)
+	}
+}
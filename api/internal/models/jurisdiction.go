@@ -0,0 +1,18 @@
+package models
+
+import "slices"
+
+// Jurisdiction kinds: the boundary of a school district or of a taxing unit (a
+// county, city, utility district or other unit levying property tax).
+const (
+	JurisdictionSchoolDistrict = "school_district"
+	JurisdictionTaxingUnit     = "taxing_unit"
+)
+
+// JurisdictionKinds lists every jurisdiction kind in the order results report them.
+var JurisdictionKinds = []string{JurisdictionSchoolDistrict, JurisdictionTaxingUnit}
+
+// IsJurisdictionKind reports whether kind is one of JurisdictionKinds.
+func IsJurisdictionKind(kind string) bool {
+	return slices.Contains(JurisdictionKinds, kind)
+}
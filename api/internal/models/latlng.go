@@ -0,0 +1,67 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+)
+
+// Coordinate range constants for WGS84 (SRID 4326)
+const (
+	MinLatitude  = -90.0
+	MaxLatitude  = 90.0
+	MinLongitude = -180.0
+	MaxLongitude = 180.0
+)
+
+// ErrInvalidCoordinates is returned for a latitude or longitude outside the WGS84 range.
+var ErrInvalidCoordinates = domain.Validation("invalid coordinates")
+
+// LatLng is a WGS84 (SRID 4326) point in conversational order: latitude first.
+// PostGIS and GeoJSON order positions the other way round (x = longitude, y = latitude),
+// so convert with ToPostGISOrder or GeoJSONPosition instead of passing the fields
+// positionally. Prefer NewLatLng, which validates the ranges, over a struct literal.
+type LatLng struct {
+	Lat float64
+	Lng float64
+}
+
+// NewLatLng returns a validated LatLng.
+// Returns ErrInvalidCoordinates if lat is outside -90..90 or lng is outside -180..180.
+func NewLatLng(lat, lng float64) (LatLng, error) {
+	point := LatLng{Lat: lat, Lng: lng}
+	if err := point.Validate(); err != nil {
+		return LatLng{}, err
+	}
+	return point, nil
+}
+
+// Validate reports whether the point is within the WGS84 range.
+// Returns ErrInvalidCoordinates (wrapped with the offending value) if it is not.
+func (p LatLng) Validate() error {
+	if p.Lat < MinLatitude || p.Lat > MaxLatitude {
+		return fmt.Errorf("%w: latitude must be between %f and %f, got %f",
+			ErrInvalidCoordinates, MinLatitude, MaxLatitude, p.Lat)
+	}
+	if p.Lng < MinLongitude || p.Lng > MaxLongitude {
+		return fmt.Errorf("%w: longitude must be between %f and %f, got %f",
+			ErrInvalidCoordinates, MinLongitude, MaxLongitude, p.Lng)
+	}
+	return nil
+}
+
+// ToPostGISOrder returns the point as (x, y) = (longitude, latitude), the argument
+// order of ST_MakePoint.
+func (p LatLng) ToPostGISOrder() (x, y float64) {
+	return p.Lng, p.Lat
+}
+
+// GeoJSONPosition returns the point as a GeoJSON [longitude, latitude] position.
+func (p LatLng) GeoJSONPosition() [2]float64 {
+	return [2]float64{p.Lng, p.Lat}
+}
+
+// String formats the point as "lat,lng".
+func (p LatLng) String() string {
+	return fmt.Sprintf("%f,%f", p.Lat, p.Lng)
+}
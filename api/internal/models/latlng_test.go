@@ -0,0 +1,58 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewLatLng(t *testing.T) {
+	tests := []struct {
+		name    string
+		lat     float64
+		lng     float64
+		wantErr bool
+	}{
+		{"valid", 30.3477, -95.4502, false},
+		{"boundaries", 90, -180, false},
+		{"latitude too high", 90.1, 0, true},
+		{"latitude too low", -90.1, 0, true},
+		{"longitude too high", 0, 180.1, true},
+		{"longitude too low", 0, -180.1, true},
+		{"swapped lat/lng", -95.4502, 30.3477, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			point, err := NewLatLng(tt.lat, tt.lng)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCoordinates) {
+					t.Errorf("NewLatLng(%f, %f) error = %v, want ErrInvalidCoordinates", tt.lat, tt.lng, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewLatLng(%f, %f) returned error: %v", tt.lat, tt.lng, err)
+			}
+			if point.Lat != tt.lat || point.Lng != tt.lng {
+				t.Errorf("NewLatLng(%f, %f) = %+v", tt.lat, tt.lng, point)
+			}
+		})
+	}
+}
+
+func TestLatLngAxisOrder(t *testing.T) {
+	point := LatLng{Lat: 30.3477, Lng: -95.4502}
+
+	x, y := point.ToPostGISOrder()
+	if x != -95.4502 || y != 30.3477 {
+		t.Errorf("ToPostGISOrder() = (%f, %f), want (-95.4502, 30.3477)", x, y)
+	}
+
+	if got := point.GeoJSONPosition(); got != [2]float64{-95.4502, 30.3477} {
+		t.Errorf("GeoJSONPosition() = %v, want [-95.4502 30.3477]", got)
+	}
+
+	if got := point.String(); got != "30.347700,-95.450200" {
+		t.Errorf("String() = %q", got)
+	}
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"time"
+)
+
+// layerNamePattern matches layer names: a lower-case letter followed by up to 62
+// lower-case letters, digits or underscores, so names are safe in URLs.
+var layerNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+// Overlay layers: polygon layers parcels are matched to per request, loaded by
+// their own ingest modes with a fixed set of properties.
+const (
+	// LayerZoning holds zoning districts; properties code and, when the layer
+	// describes it, name
+	LayerZoning = "zoning"
+	// LayerFloodZones holds FEMA flood hazard areas; properties zone, sfha and,
+	// when FEMA refines the zone, subtype
+	LayerFloodZones = "flood_zones"
+	// LayerJurisdictions holds school district and taxing unit boundaries;
+	// properties kind (see JurisdictionKinds), name and, when published, code
+	LayerJurisdictions = "jurisdictions"
+)
+
+// OverlayLayers lists the overlay layers.
+var OverlayLayers = []string{LayerZoning, LayerFloodZones, LayerJurisdictions}
+
+// IsOverlayLayer reports whether name is one of OverlayLayers.
+func IsOverlayLayer(name string) bool {
+	return slices.Contains(OverlayLayers, name)
+}
+
+// IsLayerName reports whether name is a valid layer name.
+func IsLayerName(name string) bool {
+	return layerNamePattern.MatchString(name)
+}
+
+// Layer is a registered spatial dataset, such as wetlands or easements, whose
+// features are stored as generic geometries with their source attributes.
+// FeatureCount is the number of features loaded across counties.
+type Layer struct {
+	Description  *string
+	UpdatedAt    time.Time
+	Name         string
+	Title        string
+	FeatureCount int64
+	ID           uint
+}
+
+// LayerFeature is one feature of a layer. Geometry is the feature's GeoJSON
+// geometry of any type; Properties are the attributes kept at load.
+type LayerFeature struct {
+	Properties map[string]any
+	Geometry   json.RawMessage
+	CountyName string
+	ID         uint
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+)
+
+// MapStyle is a named layer style configuration owned by a tenant.
+// Embedding applications fetch styles by name so they render parcels consistently.
+type MapStyle struct {
+	CreatedAt   time.Time        `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt   time.Time        `gorm:"column:updated_at" json:"updatedAt"`
+	Description *string          `gorm:"type:text;column:description" json:"description,omitempty"`
+	TenantID    string           `gorm:"size:100;not null;column:tenant_id" json:"tenantId"`
+	Name        string           `gorm:"size:100;not null;column:name" json:"name"`
+	Config      LayerStyleConfig `gorm:"type:jsonb;not null;column:config" json:"config"`
+	ID          uint             `gorm:"primaryKey" json:"id"`
+}
+
+// TableName specifies the table name for GORM.
+func (MapStyle) TableName() string {
+	return "map_styles"
+}
+
+// LayerStyleConfig describes how the parcel layer is drawn.
+type LayerStyleConfig struct {
+	Fill       FillStyle   `json:"fill"`
+	Labels     []LabelRule `json:"labels"`
+	Visibility ZoomRange   `json:"visibility"`
+}
+
+// FillStyle controls parcel polygon fill colors.
+// ByLandUse maps land use codes (as_code) to a color; unmatched parcels use DefaultColor.
+type FillStyle struct {
+	ByLandUse    map[string]string `json:"byLandUse,omitempty"`
+	DefaultColor string            `json:"defaultColor"`
+	Opacity      float64           `json:"opacity"`
+}
+
+// LabelRule renders a parcel attribute as a label from MinZoom onward.
+type LabelRule struct {
+	Field   string `json:"field"`
+	MinZoom int    `json:"minZoom"`
+}
+
+// ZoomRange is an inclusive range of web map zoom levels.
+type ZoomRange struct {
+	MinZoom int `json:"minZoom"`
+	MaxZoom int `json:"maxZoom"`
+}
@@ -0,0 +1,10 @@
+package models
+
+// Owner is an owner entity: the owner names of parcels that are spelling
+// variants of one another, resolved by the ingest CLI. Name is the display
+// name; Names are the spellings found on parcels, sorted.
+type Owner struct {
+	Name  string
+	Names []string
+	ID    uint
+}
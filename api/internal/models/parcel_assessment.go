@@ -0,0 +1,15 @@
+package models
+
+// ParcelAssessment is the certified appraisal of a parcel for one tax year, in
+// whole dollars. Market is the land, improvement, agricultural and timber
+// market value; Appraised applies productivity (ag and timber use) values;
+// Assessed applies the homestead cap.
+type ParcelAssessment struct {
+	Year        int
+	Land        int64
+	Improvement int64
+	AgUse       int64
+	Market      int64
+	Appraised   int64
+	Assessed    int64
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// ParcelSale is a recorded sale (deed transfer) of a parcel. Price is nil
+// where the sale price is not disclosed, as in Texas and other non-disclosure
+// states; DeedType is the export's code, e.g. WD for a warranty deed.
+type ParcelSale struct {
+	SaleDate   time.Time
+	Price      *int64
+	DeedType   *string
+	Grantor    *string
+	Grantee    *string
+	Instrument *string
+	ID         uint
+}
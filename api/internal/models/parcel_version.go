@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+)
+
+// ParcelVersion is a version of a tax parcel: its attributes and boundary from
+// ValidFrom until ValidTo. Prior versions are rows of parcel_versions, recorded
+// by a database trigger when ingest changes the parcel; the current version is
+// read from tax_parcels and has a nil ValidTo. Deleted is set when the parcel
+// was soft-deleted by a sync load during the version.
+// OwnerAddress is nil unless the reader has contact access (see
+// repository.WithContactAccess). Acres and GeometryHash are computed when the
+// version is read; GeometryHash tells boundary changes apart without comparing
+// the geometries.
+type ParcelVersion struct {
+	ValidFrom            time.Time    `gorm:"not null;column:valid_from" json:"validFrom"`
+	ValidTo              *time.Time   `gorm:"not null;column:valid_to" json:"validTo,omitempty"`
+	StateCd              *string      `gorm:"size:10;column:state_cd" json:"stateCd,omitempty"`
+	Block                *int         `gorm:"column:block" json:"block,omitempty"`
+	Lot                  *string      `gorm:"size:100;column:lot" json:"lot,omitempty"`
+	Tract                *string      `gorm:"size:50;column:tract" json:"tract,omitempty"`
+	OwnerName            *string      `gorm:"size:500;column:owner_name" json:"ownerName,omitempty"`
+	OwnerAddress         *string      `gorm:"type:text;column:owner_address" json:"ownerAddress,omitempty"`
+	Situs                *string      `gorm:"size:500;column:situs" json:"situs,omitempty"`
+	AsCode               *string      `gorm:"size:50;column:as_code" json:"asCode,omitempty"`
+	LegalDescription     *string      `gorm:"type:text;column:legal_description" json:"legalDescription,omitempty"`
+	ImprvActualYearBuilt *int         `gorm:"column:imprv_actual_year_built" json:"imprvActualYearBuilt,omitempty"`
+	ImprvMainArea        *int         `gorm:"column:imprv_main_area" json:"imprvMainArea,omitempty"`
+	PID                  *int         `gorm:"column:pid" json:"pid,omitempty"`
+	PYear                *int         `gorm:"column:p_year" json:"pYear,omitempty"`
+	PVersion             *int         `gorm:"column:p_version" json:"pVersion,omitempty"`
+	PRollCorr            *int         `gorm:"column:p_roll_corr" json:"pRollCorr,omitempty"`
+	TaxingUnits          *string      `gorm:"size:255;column:taxing_units" json:"taxingUnits,omitempty"`
+	Exemptions           *string      `gorm:"size:255;column:exemptions" json:"exemptions,omitempty"`
+	MarketArea           *string      `gorm:"size:50;column:market_area" json:"marketArea,omitempty"`
+	Geom                 MultiPolygon `gorm:"type:geometry(MultiPolygon,4326);not null;column:geom" json:"geometry"`
+	GeometryHash         string       `gorm:"-" json:"geometryHash"`
+	Acres                float64      `gorm:"-" json:"acres"`
+	ID                   int64        `gorm:"primaryKey" json:"id"`
+	ParcelID             uint         `gorm:"not null;column:parcel_id" json:"parcelId"`
+	PIN                  int          `gorm:"not null;column:pin" json:"pin"`
+	Deleted              bool         `gorm:"not null;column:deleted" json:"deleted"`
+}
+
+// TableName specifies the table name for GORM.
+func (ParcelVersion) TableName() string {
+	return "parcel_versions"
+}
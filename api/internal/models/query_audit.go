@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+// QueryAudit records one parcel query for usage accounting. Params are the
+// query string parameters; APIKeyPrefix and TenantID are empty for anonymous
+// requests. ResultCount is nil when the response carried no parcel count:
+// errors and responses served from the response cache. Replay is the
+// request's capture for the replay log, nil when it was not sampled.
+type QueryAudit struct {
+	CreatedAt    time.Time         `gorm:"column:created_at" json:"createdAt"`
+	Params       map[string]string `gorm:"type:jsonb;not null;column:params" json:"params"`
+	Replay       *ReplayQuery      `gorm:"-" json:"-"`
+	ResultCount  *int              `gorm:"column:result_count" json:"resultCount,omitempty"`
+	Route        string            `gorm:"size:100;not null;column:route" json:"route"`
+	Method       string            `gorm:"size:10;not null;column:method" json:"method"`
+	APIKeyPrefix string            `gorm:"size:16;not null;column:api_key_prefix" json:"apiKeyPrefix,omitempty"`
+	TenantID     string            `gorm:"not null;column:tenant_id" json:"tenantId,omitempty"`
+	RequestID    string            `gorm:"not null;column:request_id" json:"requestId,omitempty"`
+	ID           int64             `gorm:"primaryKey" json:"id"`
+	LatencyMS    int               `gorm:"not null;column:latency_ms" json:"latencyMs"`
+	Status       int               `gorm:"not null;column:status" json:"status"`
+}
+
+// TableName specifies the table name for GORM.
+func (QueryAudit) TableName() string {
+	return "query_audit"
+}
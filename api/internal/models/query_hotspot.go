@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+// QueryHotspot is the number of point queries that landed in one grid cell on one UTC day.
+// CellLat and CellLng are the cell center, rounded to the grid size.
+type QueryHotspot struct {
+	HitDate time.Time `gorm:"type:date;primaryKey;column:hit_date" json:"hitDate"`
+	CellLat float64   `gorm:"primaryKey;column:cell_lat" json:"cellLat"`
+	CellLng float64   `gorm:"primaryKey;column:cell_lng" json:"cellLng"`
+	Hits    int64     `gorm:"column:hits" json:"hits"`
+}
+
+// TableName specifies the table name for GORM.
+func (QueryHotspot) TableName() string {
+	return "query_hotspots"
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+// ReplayQuery is a sampled parcel request captured for replaying against
+// another deployment. It is anonymized: the API key, tenant, user, client
+// address and request ID are not kept, and credential parameters are removed
+// from Query. Body is the JSON request body, empty for GET requests.
+// ResultCount is nil when the response carried no parcel count.
+type ReplayQuery struct {
+	CreatedAt   time.Time `gorm:"column:created_at" json:"createdAt"`
+	ResultCount *int      `gorm:"column:result_count" json:"resultCount,omitempty"`
+	Route       string    `gorm:"size:100;not null;column:route" json:"route"`
+	Method      string    `gorm:"size:10;not null;column:method" json:"method"`
+	Path        string    `gorm:"not null;column:path" json:"path"`
+	Query       string    `gorm:"not null;column:query" json:"query,omitempty"`
+	Body        string    `gorm:"not null;column:body" json:"body,omitempty"`
+	ID          int64     `gorm:"primaryKey" json:"id"`
+	LatencyMS   int       `gorm:"not null;column:latency_ms" json:"latencyMs"`
+	Status      int       `gorm:"not null;column:status" json:"status"`
+}
+
+// TableName specifies the table name for GORM.
+func (ReplayQuery) TableName() string {
+	return "query_replay"
+}
@@ -0,0 +1,11 @@
+package models
+
+// QueryResult is the output of an analyst query template: column names and
+// rows of values in column order. Truncated is set when the query returned
+// more rows than the row limit allowed.
+type QueryResult struct {
+	Columns   []string `json:"columns"`
+	Rows      [][]any  `json:"rows"`
+	RowCount  int      `json:"rowCount"`
+	Truncated bool     `json:"truncated"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+// RetentionReport is the outcome of one pruning pass over the tables with a
+// retention. In a dry run nothing is deleted and Rows counts what would be.
+type RetentionReport struct {
+	StartedAt time.Time         `json:"startedAt"`
+	Tables    []RetentionResult `json:"tables"`
+	DryRun    bool              `json:"dryRun"`
+}
+
+// RetentionResult is the pruning of one table: the rows created before Before,
+// which is the pass's start minus Retention. Error is set if pruning failed,
+// in which case Rows counts the rows deleted before the failure.
+type RetentionResult struct {
+	Before    time.Time `json:"before"`
+	Table     string    `json:"table"`
+	Retention string    `json:"retention"`
+	Error     string    `json:"error,omitempty"`
+	Rows      int64     `json:"rows"`
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+)
+
+// Parcel changes: how an ingestion run changed a parcel matched by a saved
+// search or sent to a webhook.
+const (
+	ParcelChangeAdded   = "added"
+	ParcelChangeChanged = "changed"
+	ParcelChangeDeleted = "deleted"
+)
+
+// SavedSearch is an area and parcel filters a user monitors. After every new
+// ingestion run, the parcels added, changed or deleted since EvaluatedAt that
+// intersect the area and match the filters are recorded as matches. County
+// limits the search to one county when set. MatchCount is computed when
+// searches are listed.
+type SavedSearch struct {
+	CreatedAt   time.Time          `gorm:"column:created_at" json:"createdAt"`
+	EvaluatedAt time.Time          `gorm:"not null;column:evaluated_at" json:"evaluatedAt"`
+	County      *string            `gorm:"size:100;column:county" json:"county,omitempty"`
+	Name        string             `gorm:"size:100;not null;column:name" json:"name"`
+	Area        MultiPolygon       `gorm:"type:geometry(MultiPolygon,4326);not null;column:area" json:"area"`
+	Filters     SavedSearchFilters `gorm:"type:jsonb;not null;column:filters" json:"filters"`
+	MatchCount  int64              `gorm:"-" json:"matchCount"`
+	ID          uint               `gorm:"primaryKey" json:"id"`
+	UserID      uint               `gorm:"not null;column:user_id" json:"-"`
+}
+
+// TableName specifies the table name for GORM.
+func (SavedSearch) TableName() string {
+	return "saved_searches"
+}
+
+// SavedSearchFilters are the parcel attribute filters of a saved search, with
+// the meaning of the nearby endpoint's filters of the same name. Nil and empty
+// fields are not applied. AmenityWithin is in meters.
+type SavedSearchFilters struct {
+	MinAcres      *float64 `json:"minAcres,omitempty"`
+	MaxAcres      *float64 `json:"maxAcres,omitempty"`
+	MinYearBuilt  *int     `json:"minYearBuilt,omitempty"`
+	MaxYearBuilt  *int     `json:"maxYearBuilt,omitempty"`
+	Waterfront    *bool    `json:"waterfront,omitempty"`
+	AmenityWithin *float64 `json:"amenityWithin,omitempty"`
+	LandUse       string   `json:"landUse,omitempty"`
+	OwnerName     string   `json:"ownerName,omitempty"`
+	Amenity       string   `json:"amenity,omitempty"`
+}
+
+// SavedSearchMatch is a parcel an ingestion run added, changed or deleted that
+// matched a saved search. PIN and Situs are copied from the parcel, since
+// replace loads recreate a county's parcels under new IDs.
+type SavedSearchMatch struct {
+	MatchedAt      time.Time `gorm:"not null;column:matched_at" json:"matchedAt"`
+	IngestionRunID *int64    `gorm:"column:ingestion_run_id" json:"ingestionRunId,omitempty"`
+	Situs          *string   `gorm:"size:500;column:situs" json:"situs,omitempty"`
+	Change         string    `gorm:"size:10;not null;column:change" json:"change"`
+	ID             int64     `gorm:"primaryKey" json:"id"`
+	SavedSearchID  uint      `gorm:"not null;column:saved_search_id" json:"savedSearchId"`
+	ParcelID       uint      `gorm:"not null;column:parcel_id" json:"parcelId"`
+	PIN            int       `gorm:"not null;column:pin" json:"pin"`
+}
+
+// TableName specifies the table name for GORM.
+func (SavedSearchMatch) TableName() string {
+	return "saved_search_matches"
+}
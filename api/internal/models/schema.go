@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+)
+
+// SchemaReport compares the live database schema with the migrations and the
+// models. Drift is true when the database is behind or dirty, or is missing an
+// index or column, or a column has another type.
+type SchemaReport struct {
+	CheckedAt time.Time `json:"checkedAt"`
+	// Pending are the migrations newer than the applied version
+	Pending        []SchemaMigration `json:"pending"`
+	MissingIndexes []string          `json:"missingIndexes"`
+	MissingColumns []SchemaColumn    `json:"missingColumns"`
+	TypeMismatches []SchemaColumn    `json:"typeMismatches"`
+	// CurrentVersion is the applied migration version, 0 before any migration
+	CurrentVersion uint `json:"currentVersion"`
+	LatestVersion  uint `json:"latestVersion"`
+	// Dirty is set when a migration failed halfway and needs migrate force
+	Dirty bool `json:"dirty"`
+	Drift bool `json:"drift"`
+}
+
+// SchemaMigration is a migration file, e.g. 13 add_parcel_sync_columns.
+type SchemaMigration struct {
+	Name    string `json:"name"`
+	Version uint   `json:"version"`
+}
+
+// SchemaColumn is a model column that is missing or has another type. Actual
+// is the type in the database, empty when the column is missing.
+type SchemaColumn struct {
+	Table    string `json:"table"`
+	Column   string `json:"column"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual,omitempty"`
+}
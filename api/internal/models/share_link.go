@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+)
+
+// ShareLink is a map view saved under a short token. Partners share the token
+// instead of the full map state; every resolution counts a click. The creating
+// tenant is recorded but not shown to whoever opens the link.
+type ShareLink struct {
+	CreatedAt     time.Time  `gorm:"column:created_at" json:"createdAt"`
+	ExpiresAt     time.Time  `gorm:"not null;column:expires_at" json:"expiresAt"`
+	LastClickedAt *time.Time `gorm:"column:last_clicked_at" json:"lastClickedAt,omitempty"`
+	TenantID      *string    `gorm:"size:100;column:tenant_id" json:"-"`
+	Token         string     `gorm:"size:32;uniqueIndex;not null;column:token" json:"token"`
+	View          ShareView  `gorm:"type:jsonb;not null;column:view" json:"view"`
+	Clicks        int64      `gorm:"not null;default:0;column:clicks" json:"clicks"`
+	ID            uint       `gorm:"primaryKey" json:"id"`
+}
+
+// TableName specifies the table name for GORM.
+func (ShareLink) TableName() string {
+	return "share_links"
+}
+
+// ShareView is the map view a share link opens: either a parcel or an area of
+// interest, optionally with the layer style and zoom the sender was using.
+type ShareView struct {
+	ParcelID *uint             `json:"parcelId,omitempty"`
+	Area     *MultiPolygon     `json:"area,omitempty"`
+	Style    *LayerStyleConfig `json:"style,omitempty"`
+	Zoom     *int              `json:"zoom,omitempty"`
+}
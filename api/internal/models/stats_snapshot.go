@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+)
+
+// StatsSnapshot is a precomputed daily aggregate of the parcel dataset for one county.
+// Medians are nil when the county has no parcels with the underlying value.
+type StatsSnapshot struct {
+	SnapshotDate         time.Time `gorm:"type:date;primaryKey;column:snapshot_date" json:"snapshotDate"`
+	CreatedAt            time.Time `gorm:"column:created_at" json:"createdAt"`
+	MedianAcres          *float64  `gorm:"column:median_acres" json:"medianAcres,omitempty"`
+	MedianMainArea       *float64  `gorm:"column:median_main_area" json:"medianMainArea,omitempty"`
+	CountyName           string    `gorm:"size:100;primaryKey;column:county_name" json:"countyName"`
+	TotalAcres           float64   `gorm:"column:total_acres" json:"totalAcres"`
+	ParcelCount          int64     `gorm:"column:parcel_count" json:"parcelCount"`
+	NewConstructionCount int64     `gorm:"column:new_construction_count" json:"newConstructionCount"`
+}
+
+// TableName specifies the table name for GORM.
+func (StatsSnapshot) TableName() string {
+	return "parcel_stats_snapshots"
+}
@@ -5,34 +5,53 @@ import (
 )
 
 // TaxParcel represents a property tax parcel with boundary geometry.
-// Based on Montgomery County, Texas GeoJSON data format.
+// Columns follow the Montgomery County, Texas GeoJSON data format; other counties
+// are mapped onto them (see County). CountyName is copied from the county.
+// Rows soft-deleted by sync loads (deleted_at) are never read into a TaxParcel.
 // All nullable fields use pointers to distinguish between zero values and NULL.
+// Acres and the centroid are computed by PostGIS when the parcel is read; they are not columns.
+// BBox is the envelope [minLng, minLat, maxLng, maxLat] from the generated bbox_* columns,
+// nil for an empty geometry.
+// The waterfront fields are computed by ingest from the county's hydrology layer;
+// WaterFrontageMeters is nil until the county has one.
+// DerivedAttributes holds the configured derived attributes by name, whether
+// stored by ingest or computed when the parcel is read; it is nil when none are configured.
 type TaxParcel struct {
-	CreatedAt            time.Time    `gorm:"column:created_at" json:"createdAt"`
-	UpdatedAt            time.Time    `gorm:"column:updated_at" json:"updatedAt"`
-	LegalDescription     *string      `gorm:"type:text;column:legal_description" json:"legalDescription,omitempty"`
-	Situs                *string      `gorm:"size:500;index;column:situs" json:"situs,omitempty"`
-	StateCd              *string      `gorm:"size:10;column:state_cd" json:"stateCd,omitempty"`
-	Block                *int         `gorm:"column:block" json:"block,omitempty"`
-	Lot                  *string      `gorm:"size:50;column:lot" json:"lot,omitempty"`
-	Tract                *string      `gorm:"size:50;column:tract" json:"tract,omitempty"`
-	OwnerName            *string      `gorm:"size:500;index;column:owner_name" json:"ownerName,omitempty"`
-	ImprvMainArea        *int         `gorm:"column:imprv_main_area" json:"imprvMainArea,omitempty"`
-	ImprvActualYearBuilt *int         `gorm:"column:imprv_actual_year_built" json:"imprvActualYearBuilt,omitempty"`
-	AsCode               *string      `gorm:"size:50;column:as_code" json:"asCode,omitempty"`
-	PID                  *int         `gorm:"column:pid" json:"pid,omitempty"`
-	MarketArea           *string      `gorm:"size:50;column:market_area" json:"marketArea,omitempty"`
-	OwnerAddress         *string      `gorm:"type:text;column:owner_address" json:"ownerAddress,omitempty"`
-	PYear                *int         `gorm:"column:p_year" json:"pYear,omitempty"`
-	PVersion             *int         `gorm:"column:p_version" json:"pVersion,omitempty"`
-	PRollCorr            *int         `gorm:"column:p_roll_corr" json:"pRollCorr,omitempty"`
-	TaxingUnits          *string      `gorm:"size:255;column:taxing_units" json:"taxingUnits,omitempty"`
-	Exemptions           *string      `gorm:"size:255;column:exemptions" json:"exemptions,omitempty"`
-	CountyName           string       `gorm:"size:100;default:'Montgomery';index;column:county_name" json:"countyName"`
-	Geom                 MultiPolygon `gorm:"type:geometry(MultiPolygon,4326);not null;column:geom" json:"geometry"`
-	ID                   uint         `gorm:"primaryKey" json:"id"`
-	PIN                  int          `gorm:"index;not null;column:pin" json:"pin"`
-	ObjectID             int          `gorm:"uniqueIndex;not null;column:object_id" json:"objectId"`
+	CreatedAt            time.Time      `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt            time.Time      `gorm:"column:updated_at" json:"updatedAt"`
+	LegalDescription     *string        `gorm:"type:text;column:legal_description" json:"legalDescription,omitempty"`
+	Situs                *string        `gorm:"size:500;index;column:situs" json:"situs,omitempty"`
+	StateCd              *string        `gorm:"size:10;column:state_cd" json:"stateCd,omitempty"`
+	Block                *int           `gorm:"column:block" json:"block,omitempty"`
+	Lot                  *string        `gorm:"size:100;column:lot" json:"lot,omitempty"`
+	Tract                *string        `gorm:"size:50;column:tract" json:"tract,omitempty"`
+	OwnerName            *string        `gorm:"size:500;index;column:owner_name" json:"ownerName,omitempty"`
+	OwnerID              *uint          `gorm:"-" json:"ownerId,omitempty"` // owner entity of OwnerName, see owner_names
+	ImprvMainArea        *int           `gorm:"column:imprv_main_area" json:"imprvMainArea,omitempty"`
+	ImprvActualYearBuilt *int           `gorm:"column:imprv_actual_year_built" json:"imprvActualYearBuilt,omitempty"`
+	AsCode               *string        `gorm:"size:50;column:as_code" json:"asCode,omitempty"`
+	PID                  *int           `gorm:"column:pid" json:"pid,omitempty"`
+	MarketArea           *string        `gorm:"size:50;column:market_area" json:"marketArea,omitempty"`
+	OwnerAddress         *string        `gorm:"type:text;column:owner_address" json:"ownerAddress,omitempty"`
+	PYear                *int           `gorm:"column:p_year" json:"pYear,omitempty"`
+	PVersion             *int           `gorm:"column:p_version" json:"pVersion,omitempty"`
+	PRollCorr            *int           `gorm:"column:p_roll_corr" json:"pRollCorr,omitempty"`
+	TaxingUnits          *string        `gorm:"size:255;column:taxing_units" json:"taxingUnits,omitempty"`
+	Exemptions           *string        `gorm:"size:255;column:exemptions" json:"exemptions,omitempty"`
+	DerivedAttributes    map[string]any `gorm:"-" json:"derivedAttributes,omitempty"`
+	BBox                 []float64      `gorm:"-" json:"bbox,omitempty"`
+	WaterFrontageMeters  *float64       `gorm:"column:water_frontage_meters" json:"waterFrontageMeters,omitempty"`
+	WaterfrontType       *string        `gorm:"size:20;column:waterfront_type" json:"waterfrontType,omitempty"`
+	WaterBodyName        *string        `gorm:"size:255;column:water_body_name" json:"waterBodyName,omitempty"`
+	CountyName           string         `gorm:"size:100;index;column:county_name" json:"countyName"`
+	Geom                 MultiPolygon   `gorm:"type:geometry(MultiPolygon,4326);not null;column:geom" json:"geometry"`
+	Acres                float64        `gorm:"-" json:"acres"`
+	CentroidLat          float64        `gorm:"-" json:"centroidLat"`
+	CentroidLng          float64        `gorm:"-" json:"centroidLng"`
+	ID                   uint           `gorm:"primaryKey" json:"id"`
+	PIN                  int            `gorm:"index;not null;column:pin" json:"pin"`
+	CountyID             uint           `gorm:"uniqueIndex:uq_parcels_county_object_id;not null;column:county_id" json:"countyId"`
+	ObjectID             int            `gorm:"uniqueIndex:uq_parcels_county_object_id;not null;column:object_id" json:"objectId"`
 }
 
 // TableName specifies the table name for GORM.
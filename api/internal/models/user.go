@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+// User is an account that signs in with email and password. Only the bcrypt
+// hash of the password is stored, and it is never serialized.
+type User struct {
+	CreatedAt    time.Time  `gorm:"column:created_at" json:"createdAt"`
+	LastLoginAt  *time.Time `gorm:"column:last_login_at" json:"lastLoginAt,omitempty"`
+	Email        string     `gorm:"size:254;uniqueIndex;not null;column:email" json:"email"`
+	PasswordHash string     `gorm:"size:72;not null;column:password_hash" json:"-"`
+	ID           uint       `gorm:"primaryKey" json:"id"`
+}
+
+// TableName specifies the table name for GORM.
+func (User) TableName() string {
+	return "users"
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+)
+
+// WebhookEventParcelsChanged is the event of deliveries listing the parcels an
+// ingestion run added, changed or deleted.
+const WebhookEventParcelsChanged = "parcels.changed"
+
+// Webhook delivery statuses
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// Webhook is a URL a tenant registered to be notified of parcel changes. After
+// every new ingestion run, the parcels added, changed or deleted since
+// EvaluatedAt are queued for delivery, limited to County and Area when set.
+// Deliveries are signed with Secret, which is never serialized.
+type Webhook struct {
+	CreatedAt   time.Time     `gorm:"column:created_at" json:"createdAt"`
+	EvaluatedAt time.Time     `gorm:"not null;column:evaluated_at" json:"evaluatedAt"`
+	County      *string       `gorm:"size:100;column:county" json:"county,omitempty"`
+	Area        *MultiPolygon `gorm:"type:geometry(MultiPolygon,4326);column:area" json:"area,omitempty"`
+	TenantID    string        `gorm:"size:100;not null;column:tenant_id" json:"tenantId"`
+	URL         string        `gorm:"size:2048;not null;column:url" json:"url"`
+	Secret      string        `gorm:"size:100;not null;column:secret" json:"-"`
+	ID          uint          `gorm:"primaryKey" json:"id"`
+}
+
+// TableName specifies the table name for GORM.
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// WebhookDelivery is one notification of a webhook: queued as pending, then
+// delivered, or failed once its attempts are exhausted. ResponseStatus and
+// LastError describe the last failed attempt.
+type WebhookDelivery struct {
+	CreatedAt      time.Time      `gorm:"not null;column:created_at" json:"createdAt"`
+	NextAttemptAt  time.Time      `gorm:"not null;column:next_attempt_at" json:"nextAttemptAt"`
+	DeliveredAt    *time.Time     `gorm:"column:delivered_at" json:"deliveredAt,omitempty"`
+	IngestionRunID *int64         `gorm:"column:ingestion_run_id" json:"ingestionRunId,omitempty"`
+	ResponseStatus *int           `gorm:"column:response_status" json:"responseStatus,omitempty"`
+	LastError      *string        `gorm:"size:500;column:last_error" json:"lastError,omitempty"`
+	Event          string         `gorm:"size:50;not null;column:event" json:"event"`
+	Status         string         `gorm:"size:10;not null;column:status" json:"status"`
+	Payload        WebhookPayload `gorm:"type:jsonb;not null;column:payload" json:"payload"`
+	ID             int64          `gorm:"primaryKey" json:"id"`
+	WebhookID      uint           `gorm:"not null;column:webhook_id" json:"webhookId"`
+	Attempts       int            `gorm:"not null;column:attempts" json:"attempts"`
+}
+
+// TableName specifies the table name for GORM.
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// WebhookPayload is the JSON body POSTed to a webhook URL. A run changing more
+// parcels than fit one delivery is split over several, each with a share of Parcels.
+type WebhookPayload struct {
+	Event          string                `json:"event"`
+	Parcels        []WebhookParcelChange `json:"parcels"`
+	WebhookID      uint                  `json:"webhookId"`
+	IngestionRunID int64                 `json:"ingestionRunId"`
+}
+
+// WebhookParcelChange is a parcel of a webhook payload and how the ingestion run
+// changed it: ParcelChangeAdded, ParcelChangeChanged or ParcelChangeDeleted.
+type WebhookParcelChange struct {
+	CountyName string `json:"countyName"`
+	Change     string `json:"change"`
+	ParcelID   uint   `json:"parcelId"`
+	PIN        int    `json:"pin"`
+}
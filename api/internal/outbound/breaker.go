@@ -0,0 +1,96 @@
+package outbound
+
+import (
+	"sync"
+	"time"
+)
+
+// Circuit breaker states
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open"
+)
+
+// breaker is a consecutive-failure circuit breaker. After threshold failed
+// requests in a row it rejects requests for cooldown, then lets one trial
+// request through: success closes the circuit, failure opens it again.
+type breaker struct {
+	openedAt  time.Time
+	state     string
+	cooldown  time.Duration
+	threshold int
+	failures  int
+	mu        sync.Mutex
+	// trial is set while the half-open trial request is in flight
+	trial bool
+}
+
+// newBreaker creates a closed breaker; a threshold below 1 never opens.
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	return &breaker{state: CircuitClosed, threshold: threshold, cooldown: cooldown}
+}
+
+// allow reports whether a request may be sent.
+// Returns ErrCircuitOpen while the circuit is open or its trial request is in flight.
+func (b *breaker) allow(now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if now.Sub(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		b.state = CircuitHalfOpen
+		b.trial = true
+	case CircuitHalfOpen:
+		if b.trial {
+			return ErrCircuitOpen
+		}
+		b.trial = true
+	}
+	return nil
+}
+
+// record counts the outcome of an allowed request and reports whether it
+// opened the circuit.
+func (b *breaker) record(success bool, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+	if success {
+		b.failures = 0
+		b.state = CircuitClosed
+		return false
+	}
+
+	b.failures++
+	if b.threshold < 1 || (b.state == CircuitClosed && b.failures < b.threshold) {
+		return false
+	}
+	b.state = CircuitOpen
+	b.openedAt = now
+	return true
+}
+
+// release ends an allowed request without counting it, e.g. when the caller
+// cancelled it.
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+}
+
+// status returns the state and, unless closed, when the circuit last opened.
+func (b *breaker) status() (string, *time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitClosed {
+		return b.state, nil
+	}
+	openedAt := b.openedAt
+	return b.state, &openedAt
+}
@@ -0,0 +1,57 @@
+package outbound
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker(t *testing.T) {
+	now := time.Now()
+	b := newBreaker(2, time.Minute)
+
+	assert.NoError(t, b.allow(now))
+	assert.False(t, b.record(false, now))
+	assert.True(t, b.record(false, now), "second consecutive failure opens the circuit")
+	assert.ErrorIs(t, b.allow(now.Add(time.Second)), ErrCircuitOpen)
+
+	// One trial request after the cooldown; others wait for its outcome
+	later := now.Add(time.Minute)
+	assert.NoError(t, b.allow(later))
+	assert.ErrorIs(t, b.allow(later), ErrCircuitOpen)
+	state, _ := b.status()
+	assert.Equal(t, CircuitHalfOpen, state)
+
+	// A failed trial reopens the circuit at once
+	assert.True(t, b.record(false, later))
+	assert.ErrorIs(t, b.allow(later.Add(time.Second)), ErrCircuitOpen)
+
+	// A successful trial closes it
+	assert.NoError(t, b.allow(later.Add(time.Minute)))
+	assert.False(t, b.record(true, later.Add(time.Minute)))
+	state, openedAt := b.status()
+	assert.Equal(t, CircuitClosed, state)
+	assert.Nil(t, openedAt)
+}
+
+func TestBreaker_Disabled(t *testing.T) {
+	b := newBreaker(-1, time.Minute)
+	for range 10 {
+		assert.False(t, b.record(false, time.Now()))
+	}
+	assert.NoError(t, b.allow(time.Now()))
+}
+
+func TestLimiter(t *testing.T) {
+	now := time.Now()
+	l := newLimiter(10, 2)
+
+	assert.Zero(t, l.reserve(now))
+	assert.Zero(t, l.reserve(now), "burst allows two requests at once")
+	assert.Equal(t, 100*time.Millisecond, l.reserve(now))
+	assert.Equal(t, 200*time.Millisecond, l.reserve(now), "waiting requests queue")
+
+	// Tokens refill at the rate
+	assert.Zero(t, l.reserve(now.Add(time.Second)))
+}
@@ -0,0 +1,90 @@
+package outbound
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// cache holds successful responses by URL for ttl, evicting the least
+// recently used response beyond size entries.
+type cache struct {
+	entries map[string]*list.Element
+	order   *list.List
+	ttl     time.Duration
+	size    int
+	mu      sync.Mutex
+}
+
+// cacheEntry is a cached response, an element of cache.order.
+type cacheEntry struct {
+	expires time.Time
+	resp    *Response
+	key     string
+}
+
+// newCache creates an empty cache.
+func newCache(ttl time.Duration, size int) *cache {
+	return &cache{
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+		ttl:     ttl,
+		size:    size,
+	}
+}
+
+// get returns the cached response for key, or nil if there is none or it has expired.
+func (c *cache) get(key string, now time.Time) *Response {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	entry := elem.Value.(*cacheEntry)
+	if !now.Before(entry.expires) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return nil
+	}
+	c.order.MoveToFront(elem)
+	return entry.resp
+}
+
+// put caches a copy of resp marked as cached.
+func (c *cache) put(key string, resp *Response, now time.Time) {
+	cached := *resp
+	cached.Cached = true
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &cacheEntry{key: key, resp: &cached, expires: now.Add(c.ttl)}
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// setTTL changes the lifetime of responses cached from now on.
+func (c *cache) setTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttl = ttl
+}
+
+// len returns the number of cached responses, including expired ones not yet evicted.
+func (c *cache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,389 @@
+// Package outbound is the shared HTTP client for calls to external providers
+// (what3words, ArcGIS layers, geocoders). Each provider gets its own Client
+// with rate limiting, retries with exponential backoff, a circuit breaker and
+// an optional response cache, and reports its counters as Stats.
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+)
+
+// Client defaults
+const (
+	DefaultTimeout          = 10 * time.Second
+	DefaultMaxRetries       = 2
+	DefaultRetryDelay       = 500 * time.Millisecond
+	DefaultBreakerThreshold = 5
+	DefaultBreakerCooldown  = 30 * time.Second
+	DefaultCacheSize        = 1000
+	DefaultMaxResponseBytes = 10 << 20
+
+	// maxRetryAfter caps the Retry-After delay a provider can ask for
+	maxRetryAfter = time.Minute
+)
+
+// Client errors
+var (
+	// ErrRequestFailed is returned when a request fails with a network error or
+	// a transient status (429, 5xx) after all retries.
+	ErrRequestFailed = errors.New("outbound request failed")
+	// ErrCircuitOpen is returned without calling the provider while its circuit
+	// breaker is open.
+	ErrCircuitOpen = errors.New("circuit breaker open")
+	// ErrResponseTooLarge is returned when a response body exceeds MaxResponseBytes.
+	ErrResponseTooLarge = errors.New("response too large")
+)
+
+// Options configures a Client. Zero values select the defaults.
+type Options struct {
+	// Log receives retry and circuit breaker warnings; nil disables them
+	Log *logger.Logger
+	// RetryIf reports whether a response should be retried in addition to HTTP
+	// 429 and 5xx, e.g. providers reporting overload in a 200 response body
+	RetryIf func(*Response) bool
+	// Timeout bounds each attempt
+	Timeout time.Duration
+	// MaxRetries is the number of retries per request; negative disables retrying
+	MaxRetries int
+	// RetryDelay is the delay before the first retry; it doubles on each attempt.
+	// A longer Retry-After header takes precedence
+	RetryDelay time.Duration
+	// RateLimit is the maximum requests per second, retries included; zero is unlimited
+	RateLimit float64
+	// RateBurst is how many requests may be sent at once; default the rate rounded up
+	RateBurst int
+	// BreakerThreshold is the number of consecutive failed requests that open
+	// the circuit; negative disables the breaker
+	BreakerThreshold int
+	// BreakerCooldown is how long the circuit stays open before one trial request
+	BreakerCooldown time.Duration
+	// CacheTTL is how long successful GET responses are cached; zero disables caching
+	CacheTTL time.Duration
+	// CacheSize bounds the number of cached responses
+	CacheSize int
+	// MaxResponseBytes bounds the response body read
+	MaxResponseBytes int64
+}
+
+// Response is a completed HTTP response with its body read. Cached responses
+// are shared between callers and must not be modified.
+type Response struct {
+	Header     http.Header
+	Body       []byte
+	StatusCode int
+	// Cached reports whether the response was served from the cache
+	Cached bool
+}
+
+// Client calls one external provider.
+type Client struct {
+	http    *http.Client
+	log     *logger.Logger
+	retryIf func(*Response) bool
+	breaker *breaker
+	name    string
+	stats   counters
+
+	// limiter and cache are nil when disabled; SetRateLimit and SetCacheTTL replace them
+	limiter   atomic.Pointer[limiter]
+	cache     atomic.Pointer[cache]
+	cacheSize int
+
+	maxRetries       int
+	retryDelay       time.Duration
+	maxResponseBytes int64
+}
+
+// counters are the Client's request counters.
+type counters struct {
+	requests  atomic.Int64
+	cacheHits atomic.Int64
+	attempts  atomic.Int64
+	retries   atomic.Int64
+	failures  atomic.Int64
+	rejected  atomic.Int64
+	throttled atomic.Int64
+}
+
+// NewClient creates a Client for the provider name, which labels errors, logs and stats.
+func NewClient(name string, opts Options) *Client {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	switch {
+	case opts.MaxRetries == 0:
+		opts.MaxRetries = DefaultMaxRetries
+	case opts.MaxRetries < 0:
+		opts.MaxRetries = 0
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = DefaultRetryDelay
+	}
+	if opts.BreakerThreshold == 0 {
+		opts.BreakerThreshold = DefaultBreakerThreshold
+	}
+	if opts.BreakerCooldown <= 0 {
+		opts.BreakerCooldown = DefaultBreakerCooldown
+	}
+	if opts.CacheSize <= 0 {
+		opts.CacheSize = DefaultCacheSize
+	}
+	if opts.MaxResponseBytes <= 0 {
+		opts.MaxResponseBytes = DefaultMaxResponseBytes
+	}
+
+	c := &Client{
+		http:             &http.Client{Timeout: opts.Timeout},
+		log:              opts.Log,
+		retryIf:          opts.RetryIf,
+		breaker:          newBreaker(opts.BreakerThreshold, opts.BreakerCooldown),
+		name:             name,
+		cacheSize:        opts.CacheSize,
+		maxRetries:       opts.MaxRetries,
+		retryDelay:       opts.RetryDelay,
+		maxResponseBytes: opts.MaxResponseBytes,
+	}
+	c.SetRateLimit(opts.RateLimit, opts.RateBurst)
+	c.SetCacheTTL(opts.CacheTTL)
+	return c
+}
+
+// SetRateLimit replaces the rate limit, in requests per second with a burst as
+// in Options, e.g. on a configuration reload; zero removes it. Requests already
+// waiting keep their delay.
+func (c *Client) SetRateLimit(rate float64, burst int) {
+	if rate <= 0 {
+		c.limiter.Store(nil)
+		return
+	}
+	c.limiter.Store(newLimiter(rate, burst))
+}
+
+// SetCacheTTL changes how long successful GET responses are cached, e.g. on a
+// configuration reload. Cached responses keep their expiry; zero disables the
+// cache and drops them.
+func (c *Client) SetCacheTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		c.cache.Store(nil)
+		return
+	}
+	if responses := c.cache.Load(); responses != nil {
+		responses.setTTL(ttl)
+		return
+	}
+	c.cache.CompareAndSwap(nil, newCache(ttl, c.cacheSize))
+}
+
+// Name returns the provider name.
+func (c *Client) Name() string {
+	return c.name
+}
+
+// Get requests rawURL, answering from the cache when it can.
+func (c *Client) Get(ctx context.Context, rawURL string) (*Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build %s request: %w", c.name, err)
+	}
+	return c.Do(req)
+}
+
+// Do sends the request, retrying network errors, HTTP 429 and 5xx responses
+// and responses matched by Options.RetryIf with exponential backoff. Other
+// responses, including 4xx, are returned for the caller to interpret. Request
+// bodies must be replayable (http.NewRequest sets GetBody for in-memory bodies).
+//
+// Errors never include the request URL, which may carry API keys.
+// Returns ErrCircuitOpen while the breaker is open and ErrRequestFailed once retries are exhausted.
+func (c *Client) Do(req *http.Request) (*Response, error) {
+	ctx := req.Context()
+	c.stats.requests.Add(1)
+
+	cacheKey := ""
+	responses := c.cache.Load()
+	if responses != nil && req.Method == http.MethodGet {
+		cacheKey = req.URL.String()
+		if resp := responses.get(cacheKey, time.Now()); resp != nil {
+			c.stats.cacheHits.Add(1)
+			return resp, nil
+		}
+	}
+
+	if err := c.breaker.allow(time.Now()); err != nil {
+		c.stats.rejected.Add(1)
+		return nil, fmt.Errorf("%s: %w", c.name, err)
+	}
+
+	resp, err := c.send(ctx, req)
+	// Cancelled requests and oversized responses say nothing about the provider's health
+	if ctx.Err() != nil {
+		c.breaker.release()
+	} else if c.breaker.record(!errors.Is(err, ErrRequestFailed), time.Now()) {
+		c.warn("Circuit breaker opened", map[string]interface{}{
+			"cooldown": c.breaker.cooldown.String(),
+		})
+	}
+	if err != nil {
+		c.stats.failures.Add(1)
+		return nil, err
+	}
+
+	if cacheKey != "" && resp.StatusCode == http.StatusOK {
+		responses.put(cacheKey, resp, time.Now())
+	}
+	return resp, nil
+}
+
+// send performs the attempts of one request.
+func (c *Client) send(ctx context.Context, req *http.Request) (*Response, error) {
+	backoff := c.retryDelay
+	var lastErr error
+	var retryAfter time.Duration
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			delay := max(backoff, retryAfter)
+			c.stats.retries.Add(1)
+			c.warn("Retrying request", map[string]interface{}{
+				"attempt": attempt + 1,
+				"delay":   delay.String(),
+				"error":   lastErr.Error(),
+			})
+			if err := sleep(ctx, delay); err != nil {
+				return nil, err
+			}
+			backoff *= 2
+		}
+
+		if err := c.wait(ctx); err != nil {
+			return nil, err
+		}
+
+		resp, after, err := c.attempt(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if resp != nil && !c.retryable(resp) {
+			// Permanent failures, e.g. oversized bodies
+			return nil, err
+		}
+		lastErr = err
+		retryAfter = after
+	}
+
+	return nil, fmt.Errorf("%w after %d attempts", lastErr, c.maxRetries+1)
+}
+
+// attempt sends the request once and reads the response. It returns an error
+// for network failures and retryable responses; retryAfter is the delay the
+// provider asked for, if any.
+func (c *Client) attempt(ctx context.Context, req *http.Request) (*Response, time.Duration, error) {
+	c.stats.attempts.Add(1)
+
+	attemptReq := req.Clone(ctx)
+	if req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to replay %s request body: %w", c.name, err)
+		}
+		attemptReq.Body = body
+	}
+
+	httpResp, err := c.http.Do(attemptReq)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %s: %w", ErrRequestFailed, c.name, unwrapURLError(err))
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(httpResp.Body, c.maxResponseBytes+1))
+	if err != nil {
+		// Truncated bodies from dropped connections are worth retrying
+		return nil, 0, fmt.Errorf("%w: %s: failed to read response: %w", ErrRequestFailed, c.name, err)
+	}
+	resp := &Response{Header: httpResp.Header, Body: body, StatusCode: httpResp.StatusCode}
+	if int64(len(body)) > c.maxResponseBytes {
+		return resp, 0, fmt.Errorf("%w: %s response exceeds %d bytes", ErrResponseTooLarge, c.name, c.maxResponseBytes)
+	}
+
+	if c.retryable(resp) {
+		return resp, parseRetryAfter(httpResp.Header), fmt.Errorf("%w: %s: transient response (status %d)", ErrRequestFailed, c.name, resp.StatusCode)
+	}
+	return resp, 0, nil
+}
+
+// retryable reports whether a response is a transient failure.
+func (c *Client) retryable(resp *Response) bool {
+	if int64(len(resp.Body)) > c.maxResponseBytes {
+		return false
+	}
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+		return true
+	}
+	return c.retryIf != nil && c.retryIf(resp)
+}
+
+// wait blocks until the rate limiter admits a request.
+func (c *Client) wait(ctx context.Context) error {
+	limiter := c.limiter.Load()
+	if limiter == nil {
+		return nil
+	}
+	delay := limiter.reserve(time.Now())
+	if delay <= 0 {
+		return nil
+	}
+	c.stats.throttled.Add(1)
+	return sleep(ctx, delay)
+}
+
+// warn logs a warning labelled with the provider, if the client has a logger.
+func (c *Client) warn(msg string, fields map[string]interface{}) {
+	if c.log == nil {
+		return
+	}
+	fields["provider"] = c.name
+	c.log.Warn(msg, fields)
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// parseRetryAfter parses a Retry-After header in seconds, capped at maxRetryAfter.
+// HTTP dates are ignored; providers send seconds.
+func parseRetryAfter(header http.Header) time.Duration {
+	seconds, err := strconv.Atoi(header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return min(time.Duration(seconds)*time.Second, maxRetryAfter)
+}
+
+// unwrapURLError drops the request URL from transport errors.
+func unwrapURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
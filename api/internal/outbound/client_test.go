@@ -0,0 +1,251 @@
+package outbound
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer answers each request with the next status, repeating the last one.
+func newTestServer(t *testing.T, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := int(calls.Add(1)) - 1
+		w.WriteHeader(statuses[min(n, len(statuses)-1)])
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestClient_RetriesTransientFailures(t *testing.T) {
+	server, calls := newTestServer(t, http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK)
+	client := NewClient("test", Options{RetryDelay: time.Millisecond})
+
+	resp, err := client.Get(context.Background(), server.URL)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"ok":true}`, string(resp.Body))
+	assert.Equal(t, int32(3), calls.Load())
+
+	stats := client.Stats()
+	assert.Equal(t, int64(1), stats.Requests)
+	assert.Equal(t, int64(3), stats.Attempts)
+	assert.Equal(t, int64(2), stats.Retries)
+	assert.Equal(t, int64(0), stats.Failures)
+}
+
+func TestClient_ReturnsClientErrors(t *testing.T) {
+	server, calls := newTestServer(t, http.StatusBadRequest)
+	client := NewClient("test", Options{RetryDelay: time.Millisecond})
+
+	resp, err := client.Get(context.Background(), server.URL)
+
+	require.NoError(t, err, "4xx responses are for the caller to interpret")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, CircuitClosed, client.Stats().Circuit)
+}
+
+func TestClient_RetriesExhausted(t *testing.T) {
+	server, calls := newTestServer(t, http.StatusBadGateway)
+	client := NewClient("test", Options{MaxRetries: 1, RetryDelay: time.Millisecond})
+
+	_, err := client.Get(context.Background(), server.URL+"?key=secret")
+
+	assert.ErrorIs(t, err, ErrRequestFailed)
+	assert.Contains(t, err.Error(), "(status 502) after 2 attempts")
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, int64(1), client.Stats().Failures)
+}
+
+func TestClient_RetryIf(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			_, _ = w.Write([]byte(`{"error":"busy"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(server.Close)
+	client := NewClient("test", Options{
+		RetryDelay: time.Millisecond,
+		RetryIf:    func(resp *Response) bool { return strings.Contains(string(resp.Body), "busy") },
+	})
+
+	resp, err := client.Get(context.Background(), server.URL)
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"ok":true}`, string(resp.Body))
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestClient_NetworkErrorHidesURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	server.Close()
+	client := NewClient("test", Options{MaxRetries: -1})
+
+	_, err := client.Get(context.Background(), server.URL+"?key=secret")
+
+	assert.ErrorIs(t, err, ErrRequestFailed)
+	assert.NotContains(t, err.Error(), "secret")
+}
+
+func TestClient_ResponseTooLarge(t *testing.T) {
+	server, calls := newTestServer(t, http.StatusOK)
+	client := NewClient("test", Options{MaxResponseBytes: 4, RetryDelay: time.Millisecond})
+
+	_, err := client.Get(context.Background(), server.URL)
+
+	assert.ErrorIs(t, err, ErrResponseTooLarge)
+	assert.Equal(t, int32(1), calls.Load(), "oversized responses are not retried")
+	assert.Equal(t, CircuitClosed, client.Stats().Circuit)
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	server, calls := newTestServer(t, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusOK)
+	client := NewClient("test", Options{MaxRetries: -1, BreakerThreshold: 2, BreakerCooldown: 20 * time.Millisecond})
+	ctx := context.Background()
+
+	for range 2 {
+		_, err := client.Get(ctx, server.URL)
+		require.ErrorIs(t, err, ErrRequestFailed)
+	}
+
+	_, err := client.Get(ctx, server.URL)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(2), calls.Load(), "open circuit does not call the provider")
+	stats := client.Stats()
+	assert.Equal(t, CircuitOpen, stats.Circuit)
+	assert.NotNil(t, stats.CircuitOpenedAt)
+	assert.Equal(t, int64(1), stats.Rejected)
+
+	// After the cooldown a trial request closes the circuit
+	time.Sleep(30 * time.Millisecond)
+	_, err = client.Get(ctx, server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, CircuitClosed, client.Stats().Circuit)
+}
+
+func TestClient_Cache(t *testing.T) {
+	server, calls := newTestServer(t, http.StatusOK)
+	client := NewClient("test", Options{CacheTTL: time.Minute})
+	ctx := context.Background()
+
+	first, err := client.Get(ctx, server.URL+"?q=1")
+	require.NoError(t, err)
+	assert.False(t, first.Cached)
+
+	second, err := client.Get(ctx, server.URL+"?q=1")
+	require.NoError(t, err)
+	assert.True(t, second.Cached)
+	assert.Equal(t, first.Body, second.Body)
+
+	_, err = client.Get(ctx, server.URL+"?q=2")
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(2), calls.Load())
+	stats := client.Stats()
+	assert.Equal(t, int64(3), stats.Requests)
+	assert.Equal(t, int64(1), stats.CacheHits)
+	assert.Equal(t, 2, stats.CachedResponses)
+}
+
+func TestClient_CacheSkipsFailures(t *testing.T) {
+	server, calls := newTestServer(t, http.StatusNotFound)
+	client := NewClient("test", Options{CacheTTL: time.Minute})
+
+	for range 2 {
+		_, err := client.Get(context.Background(), server.URL)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestClient_RateLimit(t *testing.T) {
+	server, _ := newTestServer(t, http.StatusOK)
+	client := NewClient("test", Options{RateLimit: 50, RateBurst: 1})
+
+	start := time.Now()
+	for range 3 {
+		_, err := client.Get(context.Background(), server.URL)
+		require.NoError(t, err)
+	}
+
+	// The second and third requests wait 20ms each for a token
+	assert.GreaterOrEqual(t, time.Since(start), 35*time.Millisecond)
+	assert.Equal(t, int64(2), client.Stats().Throttled)
+}
+
+func TestClient_SetRateLimitAndCacheTTL(t *testing.T) {
+	server, calls := newTestServer(t, http.StatusOK)
+	client := NewClient("test", Options{})
+	ctx := context.Background()
+
+	client.SetCacheTTL(time.Minute)
+	for range 2 {
+		_, err := client.Get(ctx, server.URL)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), calls.Load(), "cached once enabled")
+
+	client.SetCacheTTL(0)
+	_, err := client.Get(ctx, server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load(), "disabling drops the cache")
+	assert.Zero(t, client.Stats().CachedResponses)
+
+	client.SetRateLimit(50, 1)
+	for range 2 {
+		_, err := client.Get(ctx, server.URL)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int64(1), client.Stats().Throttled)
+
+	client.SetRateLimit(0, 0)
+	_, err = client.Get(ctx, server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), client.Stats().Throttled, "no limit once removed")
+}
+
+func TestClient_ContextCancelled(t *testing.T) {
+	server, _ := newTestServer(t, http.StatusServiceUnavailable)
+	client := NewClient("test", Options{RetryDelay: time.Hour, BreakerThreshold: 1})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := client.Get(ctx, server.URL)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, CircuitClosed, client.Stats().Circuit, "cancelled requests do not trip the breaker")
+}
+
+func TestRegistry_Stats(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(NewClient("zeta", Options{}), NewClient("alpha", Options{}))
+
+	stats := registry.Stats()
+	require.Len(t, stats, 2)
+	assert.Equal(t, "alpha", stats[0].Name)
+	assert.Equal(t, "zeta", stats[1].Name)
+	assert.Equal(t, CircuitClosed, stats[0].Circuit)
+}
+
+func TestRegistry_Client(t *testing.T) {
+	registry := NewRegistry()
+	geocoder := NewClient("geocoder", Options{})
+	registry.Register(geocoder)
+
+	assert.Same(t, geocoder, registry.Client("geocoder"))
+	assert.Nil(t, registry.Client("what3words"))
+}
@@ -0,0 +1,42 @@
+package outbound
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// limiter is a token bucket refilled at rate tokens per second up to burst.
+type limiter struct {
+	last   time.Time
+	rate   float64
+	burst  float64
+	tokens float64
+	mu     sync.Mutex
+}
+
+// newLimiter creates a full bucket; a burst below 1 defaults to the rate rounded up.
+func newLimiter(rate float64, burst int) *limiter {
+	if burst < 1 {
+		burst = int(math.Ceil(rate))
+	}
+	return &limiter{rate: rate, burst: float64(burst), tokens: float64(burst)}
+}
+
+// reserve takes a token and returns how long the caller must wait before using
+// it. Waiting callers queue behind each other by driving the bucket negative.
+func (l *limiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.last.IsZero() {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
@@ -0,0 +1,96 @@
+package outbound
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Stats are a Client's counters since it was created.
+type Stats struct {
+	// CircuitOpenedAt is when the circuit last opened; nil while closed
+	CircuitOpenedAt *time.Time `json:"circuitOpenedAt,omitempty"`
+	Name            string     `json:"name"`
+	// Circuit is CircuitClosed, CircuitOpen or CircuitHalfOpen
+	Circuit string `json:"circuit"`
+	// Requests counts calls to Do, including cache hits and rejections
+	Requests  int64 `json:"requests"`
+	CacheHits int64 `json:"cacheHits"`
+	// CachedResponses is the number of responses in the cache
+	CachedResponses int `json:"cachedResponses"`
+	// Attempts counts HTTP requests sent to the provider, retries included
+	Attempts int64 `json:"attempts"`
+	Retries  int64 `json:"retries"`
+	// Failures counts requests that failed after retries
+	Failures int64 `json:"failures"`
+	// Rejected counts requests refused while the circuit was open
+	Rejected int64 `json:"rejected"`
+	// Throttled counts attempts delayed by the rate limit
+	Throttled int64 `json:"throttled"`
+}
+
+// Stats returns the client's counters.
+func (c *Client) Stats() Stats {
+	circuit, openedAt := c.breaker.status()
+	stats := Stats{
+		CircuitOpenedAt: openedAt,
+		Name:            c.name,
+		Circuit:         circuit,
+		Requests:        c.stats.requests.Load(),
+		CacheHits:       c.stats.cacheHits.Load(),
+		Attempts:        c.stats.attempts.Load(),
+		Retries:         c.stats.retries.Load(),
+		Failures:        c.stats.failures.Load(),
+		Rejected:        c.stats.rejected.Load(),
+		Throttled:       c.stats.throttled.Load(),
+	}
+	if responses := c.cache.Load(); responses != nil {
+		stats.CachedResponses = responses.len()
+	}
+	return stats
+}
+
+// Registry collects the clients of a process so their stats can be reported together.
+type Registry struct {
+	clients []*Client
+	mu      sync.Mutex
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds clients to the registry.
+func (r *Registry) Register(clients ...*Client) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clients = append(r.clients, clients...)
+}
+
+// Client returns the registered client named name, or nil if there is none.
+func (r *Registry) Client(name string) *Client {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, client := range r.clients {
+		if client.name == name {
+			return client
+		}
+	}
+	return nil
+}
+
+// Stats returns the stats of every registered client ordered by name.
+func (r *Registry) Stats() []Stats {
+	r.mu.Lock()
+	clients := slices.Clone(r.clients)
+	r.mu.Unlock()
+
+	stats := make([]Stats, 0, len(clients))
+	for _, client := range clients {
+		stats = append(stats, client.Stats())
+	}
+	slices.SortFunc(stats, func(a, b Stats) int { return cmp.Compare(a.Name, b.Name) })
+	return stats
+}
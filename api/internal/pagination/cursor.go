@@ -0,0 +1,51 @@
+// Package pagination provides opaque keyset cursors for list endpoints.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+)
+
+// ErrInvalidCursor is returned when a cursor cannot be decoded.
+var ErrInvalidCursor = domain.Validation("invalid cursor")
+
+// Cursor marks the position after the last row of a page.
+// Key is the primary sort value (e.g. distance or score) and ID breaks ties,
+// so rows with equal keys are neither skipped nor repeated across pages.
+type Cursor struct {
+	Key float64 `json:"k"`
+	ID  uint    `json:"id"`
+}
+
+// Encode returns the cursor as an opaque URL-safe string.
+func (c Cursor) Encode() string {
+	// Marshaling a struct of numbers cannot fail
+	payload, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// Decode parses a cursor produced by Encode.
+// An empty string returns nil, nil meaning "first page".
+func Decode(s string) (*Cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	if c.ID == 0 {
+		return nil, fmt.Errorf("%w: missing id", ErrInvalidCursor)
+	}
+
+	return &c, nil
+}
@@ -0,0 +1,39 @@
+package pagination
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	original := Cursor{Key: 123.456789012345, ID: 42}
+
+	decoded, err := Decode(original.Encode())
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if *decoded != original {
+		t.Errorf("round trip mismatch: got %+v, want %+v", *decoded, original)
+	}
+}
+
+func TestDecodeEmpty(t *testing.T) {
+	c, err := Decode("")
+	if err != nil || c != nil {
+		t.Errorf("expected nil, nil for empty cursor, got %+v, %v", c, err)
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	tests := []string{
+		"not base64!",
+		"bm90IGpzb24",  // "not json"
+		"eyJrIjoxLjV9", // {"k":1.5} without id
+	}
+
+	for _, s := range tests {
+		if _, err := Decode(s); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Decode(%q) error = %v, want ErrInvalidCursor", s, err)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+// Package projection converts coordinates between WGS84 longitude/latitude
+// (EPSG:4326) and the projected coordinate systems county data arrives in or
+// clients render with: NAD83 / Texas South Central in US survey feet
+// (EPSG:2278) and Web Mercator (EPSG:3857). NAD83 is taken as WGS84; the datums
+// differ by about a meter, below the accuracy of county parcel data.
+package projection
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Supported SRIDs
+const (
+	WGS84             = 4326
+	WebMercator       = 3857
+	TexasSouthCentral = 2278
+)
+
+// SRIDs lists the supported SRIDs.
+var SRIDs = []int{WGS84, WebMercator, TexasSouthCentral}
+
+// ErrUnsupportedSRID is returned for an SRID that is not in SRIDs.
+var ErrUnsupportedSRID = errors.New("unsupported SRID")
+
+// Projection converts between WGS84 longitude/latitude in degrees and the x, y
+// coordinates of a coordinate system, in its units.
+type Projection interface {
+	Forward(lng, lat float64) (x, y float64)
+	Inverse(x, y float64) (lng, lat float64)
+}
+
+// Supported reports whether srid is one of SRIDs.
+func Supported(srid int) bool {
+	return slices.Contains(SRIDs, srid)
+}
+
+// For returns the projection of srid; WGS84 leaves coordinates unchanged.
+// Returns ErrUnsupportedSRID for SRIDs not in SRIDs.
+func For(srid int) (Projection, error) {
+	switch srid {
+	case WGS84:
+		return identity{}, nil
+	case WebMercator:
+		return webMercator{}, nil
+	case TexasSouthCentral:
+		return texasSouthCentral, nil
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedSRID, srid)
+	}
+}
+
+// identity is the projection of WGS84 itself.
+type identity struct{}
+
+func (identity) Forward(lng, lat float64) (float64, float64) { return lng, lat }
+func (identity) Inverse(x, y float64) (float64, float64)     { return x, y }
+
+// Web Mercator constants
+const (
+	// mercatorRadius is the sphere radius of EPSG:3857, the WGS84 semi-major axis
+	mercatorRadius = 6378137.0
+	// MaxMercatorLatitude is the latitude at which Web Mercator maps become square;
+	// Forward clamps latitudes beyond it, whose y grows without bound
+	MaxMercatorLatitude = 85.05112877980659
+)
+
+// webMercator is the spherical Mercator projection of EPSG:3857, in meters.
+type webMercator struct{}
+
+func (webMercator) Forward(lng, lat float64) (float64, float64) {
+	lat = math.Max(-MaxMercatorLatitude, math.Min(MaxMercatorLatitude, lat))
+	x := mercatorRadius * radians(lng)
+	y := mercatorRadius * math.Log(math.Tan(math.Pi/4+radians(lat)/2))
+	return x, y
+}
+
+func (webMercator) Inverse(x, y float64) (float64, float64) {
+	lng := degrees(x / mercatorRadius)
+	lat := degrees(2*math.Atan(math.Exp(y/mercatorRadius)) - math.Pi/2)
+	return lng, lat
+}
+
+// usSurveyFoot is the US survey foot in meters, the unit of the Texas state plane zones.
+const usSurveyFoot = 1200.0 / 3937.0
+
+// texasSouthCentral is EPSG:2278, NAD83 / Texas South Central (ftUS): a Lambert
+// conformal conic on the GRS80 ellipsoid.
+var texasSouthCentral = newLambertConformalConic(lambertParams{
+	semiMajorAxis:     6378137.0,
+	inverseFlattening: 298.257222101,
+	standardParallel1: 30 + 17.0/60,
+	standardParallel2: 28 + 23.0/60,
+	originLatitude:    27 + 50.0/60,
+	centralMeridian:   -99,
+	falseEasting:      600000,
+	falseNorthing:     4000000,
+	unit:              usSurveyFoot,
+})
+
+// lambertParams define a Lambert conformal conic projection with two standard
+// parallels (EPSG method 9802). Angles are in degrees, the false origin in
+// meters and unit is the length of the coordinate unit in meters.
+type lambertParams struct {
+	semiMajorAxis     float64
+	inverseFlattening float64
+	standardParallel1 float64
+	standardParallel2 float64
+	originLatitude    float64
+	centralMeridian   float64
+	falseEasting      float64
+	falseNorthing     float64
+	unit              float64
+}
+
+// lambertConformalConic implements the projection with the constants of
+// lambertParams precomputed, after Snyder, Map Projections: A Working Manual
+// (USGS Professional Paper 1395), pp. 107-109.
+type lambertConformalConic struct {
+	params       lambertParams
+	eccentricity float64
+	n            float64 // cone constant
+	aF           float64 // semi-major axis times F
+	rho0         float64 // radius of the origin latitude
+}
+
+func newLambertConformalConic(p lambertParams) *lambertConformalConic {
+	f := 1 / p.inverseFlattening
+	e := math.Sqrt(2*f - f*f)
+	phi1, phi2 := radians(p.standardParallel1), radians(p.standardParallel2)
+
+	m1, m2 := lambertM(phi1, e), lambertM(phi2, e)
+	t1, t2 := lambertT(phi1, e), lambertT(phi2, e)
+	n := (math.Log(m1) - math.Log(m2)) / (math.Log(t1) - math.Log(t2))
+	aF := p.semiMajorAxis * m1 / (n * math.Pow(t1, n))
+
+	return &lambertConformalConic{
+		params:       p,
+		eccentricity: e,
+		n:            n,
+		aF:           aF,
+		rho0:         aF * math.Pow(lambertT(radians(p.originLatitude), e), n),
+	}
+}
+
+func (l *lambertConformalConic) Forward(lng, lat float64) (float64, float64) {
+	rho := l.aF * math.Pow(lambertT(radians(lat), l.eccentricity), l.n)
+	theta := l.n * radians(lng-l.params.centralMeridian)
+	x := l.params.falseEasting + rho*math.Sin(theta)
+	y := l.params.falseNorthing + l.rho0 - rho*math.Cos(theta)
+	return x / l.params.unit, y / l.params.unit
+}
+
+func (l *lambertConformalConic) Inverse(x, y float64) (float64, float64) {
+	dx := x*l.params.unit - l.params.falseEasting
+	dy := l.rho0 - (y*l.params.unit - l.params.falseNorthing)
+	sign := math.Copysign(1, l.n)
+	rho := sign * math.Hypot(dx, dy)
+	theta := math.Atan2(sign*dx, sign*dy)
+	t := math.Pow(rho/l.aF, 1/l.n)
+
+	// The latitude has no closed form; iterate from its spherical value
+	e := l.eccentricity
+	phi := math.Pi/2 - 2*math.Atan(t)
+	for range 15 {
+		sinPhi := e * math.Sin(phi)
+		next := math.Pi/2 - 2*math.Atan(t*math.Pow((1-sinPhi)/(1+sinPhi), e/2))
+		if math.Abs(next-phi) < 1e-12 {
+			phi = next
+			break
+		}
+		phi = next
+	}
+
+	return degrees(theta/l.n) + l.params.centralMeridian, degrees(phi)
+}
+
+// lambertM is Snyder's m, the radius of the parallel at phi over the semi-major axis.
+func lambertM(phi, e float64) float64 {
+	sinPhi := math.Sin(phi)
+	return math.Cos(phi) / math.Sqrt(1-e*e*sinPhi*sinPhi)
+}
+
+// lambertT is Snyder's t, the isometric latitude term of phi.
+func lambertT(phi, e float64) float64 {
+	sinPhi := e * math.Sin(phi)
+	return math.Tan(math.Pi/4-phi/2) / math.Pow((1-sinPhi)/(1+sinPhi), e/2)
+}
+
+func radians(degrees float64) float64 { return degrees * math.Pi / 180 }
+func degrees(radians float64) float64 { return radians * 180 / math.Pi }
+
+// Transform returns the GeoJSON geometry with its coordinates converted from
+// the from SRID to the to SRID. Any geometry type is accepted, including
+// GeometryCollection; positions keep their elevation. A null geometry is
+// returned unchanged. Returns ErrUnsupportedSRID for SRIDs not in SRIDs.
+func Transform(geometry []byte, from, to int) ([]byte, error) {
+	source, err := For(from)
+	if err != nil {
+		return nil, err
+	}
+	target, err := For(to)
+	if err != nil {
+		return nil, err
+	}
+	if from == to {
+		return geometry, nil
+	}
+
+	var geom map[string]any
+	if err := json.Unmarshal(geometry, &geom); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal geometry: %w", err)
+	}
+	if geom == nil {
+		return geometry, nil
+	}
+
+	transformGeometry(geom, func(x, y float64) (float64, float64) {
+		return target.Forward(source.Inverse(x, y))
+	})
+	return json.Marshal(geom)
+}
+
+// transformGeometry converts the coordinates of a decoded GeoJSON geometry in place.
+func transformGeometry(geom map[string]any, convert func(x, y float64) (float64, float64)) {
+	if geometries, ok := geom["geometries"].([]any); ok {
+		for _, member := range geometries {
+			if member, ok := member.(map[string]any); ok {
+				transformGeometry(member, convert)
+			}
+		}
+	}
+	transformCoordinates(geom["coordinates"], convert)
+}
+
+// transformCoordinates converts a position, or the positions nested in an
+// array of any depth, in place. Values that are not positions are left alone.
+func transformCoordinates(coordinates any, convert func(x, y float64) (float64, float64)) {
+	values, ok := coordinates.([]any)
+	if !ok || len(values) == 0 {
+		return
+	}
+
+	if _, isNumber := values[0].(float64); !isNumber {
+		for _, value := range values {
+			transformCoordinates(value, convert)
+		}
+		return
+	}
+
+	if len(values) < 2 {
+		return
+	}
+	x, okX := values[0].(float64)
+	y, okY := values[1].(float64)
+	if okX && okY {
+		values[0], values[1] = convert(x, y)
+	}
+}
@@ -0,0 +1,100 @@
+package projection
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLambertConformalConic_EPSGExample(t *testing.T) {
+	// EPSG Guidance Note 7-2 example for method 9802: NAD27 / Texas South Central
+	nad27 := newLambertConformalConic(lambertParams{
+		semiMajorAxis:     6378206.4,
+		inverseFlattening: 294.9786982,
+		standardParallel1: 28 + 23.0/60,
+		standardParallel2: 30 + 17.0/60,
+		originLatitude:    27 + 50.0/60,
+		centralMeridian:   -99,
+		falseEasting:      2000000 * usSurveyFoot,
+		falseNorthing:     0,
+		unit:              usSurveyFoot,
+	})
+
+	x, y := nad27.Forward(-96, 28.5)
+	assert.InDelta(t, 2963503.91, x, 0.01)
+	assert.InDelta(t, 254759.80, y, 0.01)
+
+	lng, lat := nad27.Inverse(2963503.91, 254759.80)
+	assert.InDelta(t, -96, lng, 1e-8)
+	assert.InDelta(t, 28.5, lat, 1e-8)
+}
+
+func TestWebMercator(t *testing.T) {
+	x, y := webMercator{}.Forward(45, 45)
+	assert.InDelta(t, 5009377.085697312, x, 1e-6)
+	assert.InDelta(t, 5621521.486192066, y, 1e-6)
+
+	_, clamped := webMercator{}.Forward(0, 90)
+	_, edge := webMercator{}.Forward(0, MaxMercatorLatitude)
+	assert.Equal(t, edge, clamped, "latitudes beyond the square map are clamped")
+}
+
+func TestFor_RoundTrip(t *testing.T) {
+	// Conroe, TX
+	const lng, lat = -95.4560512, 30.3118769
+
+	for _, srid := range SRIDs {
+		t.Run(strconv.Itoa(srid), func(t *testing.T) {
+			p, err := For(srid)
+			require.NoError(t, err)
+
+			x, y := p.Forward(lng, lat)
+			gotLng, gotLat := p.Inverse(x, y)
+			assert.InDelta(t, lng, gotLng, 1e-9)
+			assert.InDelta(t, lat, gotLat, 1e-9)
+		})
+	}
+
+	_, err := For(32614)
+	assert.ErrorIs(t, err, ErrUnsupportedSRID)
+	assert.False(t, Supported(32614))
+}
+
+func TestTransform(t *testing.T) {
+	wgs84 := `{"type":"Polygon","coordinates":[[[-95.5,30.2],[-95.4,30.2,12.5],[-95.4,30.3],[-95.5,30.2]]]}`
+
+	projected, err := Transform([]byte(wgs84), WGS84, TexasSouthCentral)
+	require.NoError(t, err)
+
+	var geom struct {
+		Type        string        `json:"type"`
+		Coordinates [][][]float64 `json:"coordinates"`
+	}
+	require.NoError(t, json.Unmarshal(projected, &geom))
+	assert.Equal(t, "Polygon", geom.Type)
+	// State plane feet are millions, far outside the degree range
+	assert.Greater(t, geom.Coordinates[0][0][0], 3e6)
+	assert.Greater(t, geom.Coordinates[0][0][1], 1e7)
+	assert.Equal(t, 12.5, geom.Coordinates[0][1][2], "elevation is kept")
+
+	back, err := Transform(projected, TexasSouthCentral, WGS84)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(back, &geom))
+	assert.InDelta(t, -95.5, geom.Coordinates[0][0][0], 1e-9)
+	assert.InDelta(t, 30.2, geom.Coordinates[0][0][1], 1e-9)
+
+	collection := `{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[45,45]}]}`
+	mercator, err := Transform([]byte(collection), WGS84, WebMercator)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[5009377.085697311,5621521.486192066]}]}`, string(mercator))
+
+	null, err := Transform([]byte("null"), TexasSouthCentral, WGS84)
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(null))
+
+	_, err = Transform([]byte(wgs84), WGS84, 32614)
+	assert.ErrorIs(t, err, ErrUnsupportedSRID)
+}
@@ -0,0 +1,297 @@
+// Package replay drives requests captured in production (see
+// models.ReplayQuery) against another deployment, usually staging running a
+// schema or query change, and compares the statuses, result counts and
+// latencies with the ones production saw.
+package replay
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// maxMismatches bounds the mismatching requests a report lists; all are counted.
+const maxMismatches = 100
+
+// Read parses a replay log: one JSON-encoded capture per line, blank lines
+// ignored.
+func Read(r io.Reader) ([]models.ReplayQuery, error) {
+	var captures []models.ReplayQuery
+	scanner := bufio.NewScanner(r)
+	// Captured bodies are up to 64 KiB, and escaping can double them
+	scanner.Buffer(make([]byte, 0, 64<<10), 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var capture models.ReplayQuery
+		if err := json.Unmarshal([]byte(text), &capture); err != nil {
+			return nil, fmt.Errorf("invalid capture on line %d: %w", line, err)
+		}
+		captures = append(captures, capture)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read replay log: %w", err)
+	}
+	return captures, nil
+}
+
+// Write writes captures in the format Read parses.
+func Write(w io.Writer, captures []models.ReplayQuery) error {
+	enc := json.NewEncoder(w)
+	for _, capture := range captures {
+		if err := enc.Encode(capture); err != nil {
+			return fmt.Errorf("failed to write capture %d: %w", capture.ID, err)
+		}
+	}
+	return nil
+}
+
+// Runner replays captures against Target, the base URL of a deployment
+// (e.g. https://staging.example.com). Requests send APIKey, if set, in place
+// of the production caller's key, which captures do not keep.
+type Runner struct {
+	Client      *http.Client
+	Target      string
+	APIKey      string
+	Concurrency int
+}
+
+// Outcome is a deployment's response to a replayed capture. ResultCount is
+// nil when the response had no X-Result-Count header; Err is set when no
+// response arrived.
+type Outcome struct {
+	Err         error
+	ResultCount *int
+	Status      int
+	Latency     time.Duration
+}
+
+// Run replays every capture, Concurrency at a time, and compares the outcomes.
+// It stops early, with a partial report, when ctx is cancelled.
+func (r *Runner) Run(ctx context.Context, captures []models.ReplayQuery) *Report {
+	outcomes := make([]Outcome, len(captures))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range max(1, r.Concurrency) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				outcomes[i] = r.send(ctx, captures[i])
+			}
+		}()
+	}
+
+	sent := len(captures)
+	for i := range captures {
+		if ctx.Err() != nil {
+			sent = i
+			break
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return Compare(captures[:sent], outcomes[:sent])
+}
+
+// send replays one capture.
+func (r *Runner) send(ctx context.Context, capture models.ReplayQuery) Outcome {
+	target := strings.TrimSuffix(r.Target, "/") + capture.Path
+	if capture.Query != "" {
+		target += "?" + capture.Query
+	}
+	var body io.Reader
+	if capture.Body != "" {
+		body = strings.NewReader(capture.Body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, capture.Method, target, body)
+	if err != nil {
+		return Outcome{Err: err}
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if r.APIKey != "" {
+		req.Header.Set(middleware.APIKeyHeader, r.APIKey)
+	}
+
+	client := r.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return Outcome{Err: err}
+	}
+	defer resp.Body.Close()
+	// Latency includes the body, as it does for the server's own measurement
+	_, err = io.Copy(io.Discard, resp.Body)
+	outcome := Outcome{Err: err, Status: resp.StatusCode, Latency: time.Since(start)}
+
+	if header := resp.Header.Get(middleware.ResultCountHeader); header != "" {
+		if count, err := strconv.Atoi(header); err == nil {
+			outcome.ResultCount = &count
+		}
+	}
+	return outcome
+}
+
+// Report compares a replay with production, overall and per route.
+type Report struct {
+	Routes []RouteReport `json:"routes"`
+	// Mismatches are the first requests whose status or result count differed,
+	// or that failed
+	Mismatches []Mismatch  `json:"mismatches"`
+	Summary    RouteReport `json:"summary"`
+}
+
+// RouteReport counts the replayed requests of a route and their mismatches.
+// Baseline latencies are production's; latency percentiles leave out failed
+// requests.
+type RouteReport struct {
+	Route            string        `json:"route"`
+	Requests         int           `json:"requests"`
+	StatusMismatches int           `json:"statusMismatches"`
+	CountMismatches  int           `json:"countMismatches"`
+	Errors           int           `json:"errors"`
+	BaselineP50      time.Duration `json:"baselineP50"`
+	BaselineP95      time.Duration `json:"baselineP95"`
+	TargetP50        time.Duration `json:"targetP50"`
+	TargetP95        time.Duration `json:"targetP95"`
+}
+
+// Mismatch is a replayed request whose outcome differed from production.
+type Mismatch struct {
+	Capture     models.ReplayQuery `json:"capture"`
+	ResultCount *int               `json:"resultCount,omitempty"`
+	Error       string             `json:"error,omitempty"`
+	Status      int                `json:"status"`
+}
+
+// Compare builds the report of captures and their outcomes, in the same order.
+// Result counts are compared only when the statuses match and both responses
+// carried a count: production's cached responses have none.
+func Compare(captures []models.ReplayQuery, outcomes []Outcome) *Report {
+	report := &Report{Routes: []RouteReport{}, Mismatches: []Mismatch{}, Summary: RouteReport{Route: "all"}}
+	byRoute := make(map[string]*latencies)
+	all := &latencies{}
+
+	for i, capture := range captures {
+		outcome := outcomes[i]
+		route := routeReport(report, capture.Route)
+		if byRoute[capture.Route] == nil {
+			byRoute[capture.Route] = &latencies{}
+		}
+		route.Requests++
+		report.Summary.Requests++
+
+		mismatched := true
+		switch {
+		case outcome.Err != nil:
+			route.Errors++
+			report.Summary.Errors++
+		case outcome.Status != capture.Status:
+			route.StatusMismatches++
+			report.Summary.StatusMismatches++
+		case capture.ResultCount != nil && outcome.ResultCount != nil && *capture.ResultCount != *outcome.ResultCount:
+			route.CountMismatches++
+			report.Summary.CountMismatches++
+		default:
+			mismatched = false
+		}
+
+		if outcome.Err == nil {
+			byRoute[capture.Route].add(capture, outcome)
+			all.add(capture, outcome)
+		}
+		if mismatched && len(report.Mismatches) < maxMismatches {
+			mismatch := Mismatch{Capture: capture, Status: outcome.Status, ResultCount: outcome.ResultCount}
+			if outcome.Err != nil {
+				mismatch.Error = outcome.Err.Error()
+			}
+			report.Mismatches = append(report.Mismatches, mismatch)
+		}
+	}
+
+	for i := range report.Routes {
+		byRoute[report.Routes[i].Route].fill(&report.Routes[i])
+	}
+	all.fill(&report.Summary)
+	slices.SortFunc(report.Routes, func(a, b RouteReport) int { return strings.Compare(a.Route, b.Route) })
+	return report
+}
+
+// Failed reports whether any request failed or mismatched.
+func (r *Report) Failed() bool {
+	return r.Summary.Errors+r.Summary.StatusMismatches+r.Summary.CountMismatches > 0
+}
+
+// LatencyRegressions returns the routes whose p95 latency on the target is
+// more than factor times production's.
+func (r *Report) LatencyRegressions(factor float64) []RouteReport {
+	var regressed []RouteReport
+	for _, route := range r.Routes {
+		if route.BaselineP95 > 0 && float64(route.TargetP95) > factor*float64(route.BaselineP95) {
+			regressed = append(regressed, route)
+		}
+	}
+	return regressed
+}
+
+// routeReport returns the report of route, adding it on first use.
+func routeReport(report *Report, route string) *RouteReport {
+	for i := range report.Routes {
+		if report.Routes[i].Route == route {
+			return &report.Routes[i]
+		}
+	}
+	report.Routes = append(report.Routes, RouteReport{Route: route})
+	return &report.Routes[len(report.Routes)-1]
+}
+
+// latencies collects the production and target latencies of a set of requests.
+type latencies struct {
+	baseline []time.Duration
+	target   []time.Duration
+}
+
+// add collects the latencies of a replayed request.
+func (l *latencies) add(capture models.ReplayQuery, outcome Outcome) {
+	l.baseline = append(l.baseline, time.Duration(capture.LatencyMS)*time.Millisecond)
+	l.target = append(l.target, outcome.Latency)
+}
+
+// fill sets the latency percentiles of report.
+func (l *latencies) fill(report *RouteReport) {
+	report.BaselineP50, report.BaselineP95 = percentile(l.baseline, 0.5), percentile(l.baseline, 0.95)
+	report.TargetP50, report.TargetP95 = percentile(l.target, 0.5), percentile(l.target, 0.95)
+}
+
+// percentile returns the nearest-rank percentile p of durations, 0 when empty.
+// It sorts durations in place.
+func percentile(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	slices.Sort(durations)
+	rank := int(math.Ceil(p * float64(len(durations))))
+	return durations[max(0, rank-1)]
+}
@@ -0,0 +1,163 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func TestReadWrite(t *testing.T) {
+	captures := []models.ReplayQuery{
+		{ID: 1, Route: "parcels.nearby", Method: "GET", Path: "/api/v1/parcels/nearby", Query: "lat=30.35&lng=-95.45",
+			Status: 200, LatencyMS: 12, ResultCount: intPtr(3)},
+		{ID: 2, Route: "parcels.in-area", Method: "POST", Path: "/api/v1/parcels/in-area",
+			Body: `{"type":"Polygon"}`, Status: 400, LatencyMS: 1},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, captures))
+	assert.Equal(t, 2, strings.Count(buf.String(), "\n"), "one capture per line")
+
+	read, err := Read(strings.NewReader(buf.String() + "\n"))
+	require.NoError(t, err)
+	assert.Equal(t, captures, read)
+
+	_, err = Read(strings.NewReader("{\"id\":1}\nnot json\n"))
+	assert.ErrorContains(t, err, "line 2")
+}
+
+func TestCompare(t *testing.T) {
+	captures := []models.ReplayQuery{
+		{Route: "parcels.nearby", Status: 200, LatencyMS: 10, ResultCount: intPtr(3)},
+		{Route: "parcels.nearby", Status: 200, LatencyMS: 20, ResultCount: intPtr(3)},
+		{Route: "parcels.nearby", Status: 200, LatencyMS: 30},
+		{Route: "parcels.search", Status: 200, LatencyMS: 5, ResultCount: intPtr(1)},
+		{Route: "parcels.search", Status: 400, LatencyMS: 1},
+	}
+	outcomes := []Outcome{
+		{Status: 200, ResultCount: intPtr(3), Latency: 15 * time.Millisecond},
+		{Status: 200, ResultCount: intPtr(4), Latency: 25 * time.Millisecond},
+		{Status: 200, ResultCount: intPtr(7), Latency: 35 * time.Millisecond}, // production's was cached
+		{Status: 500, Latency: 2 * time.Millisecond},
+		{Err: errors.New("connection refused")},
+	}
+
+	report := Compare(captures, outcomes)
+
+	assert.Equal(t, RouteReport{Route: "all", Requests: 5, StatusMismatches: 1, CountMismatches: 1, Errors: 1,
+		BaselineP50: 10 * time.Millisecond, BaselineP95: 30 * time.Millisecond,
+		TargetP50: 15 * time.Millisecond, TargetP95: 35 * time.Millisecond}, report.Summary)
+	require.Len(t, report.Routes, 2)
+	assert.Equal(t, "parcels.nearby", report.Routes[0].Route)
+	assert.Equal(t, 1, report.Routes[0].CountMismatches)
+	assert.Equal(t, 20*time.Millisecond, report.Routes[0].BaselineP50)
+	assert.Equal(t, 25*time.Millisecond, report.Routes[0].TargetP50)
+	assert.Equal(t, 1, report.Routes[1].StatusMismatches)
+	assert.Equal(t, 1, report.Routes[1].Errors)
+
+	require.Len(t, report.Mismatches, 3)
+	assert.Equal(t, 4, *report.Mismatches[0].ResultCount)
+	assert.Equal(t, 500, report.Mismatches[1].Status)
+	assert.Equal(t, "connection refused", report.Mismatches[2].Error)
+	assert.True(t, report.Failed())
+}
+
+func TestCompare_Match(t *testing.T) {
+	report := Compare(
+		[]models.ReplayQuery{{Route: "parcels.nearby", Status: 200, ResultCount: intPtr(2)}},
+		[]Outcome{{Status: 200, ResultCount: intPtr(2)}},
+	)
+
+	assert.False(t, report.Failed())
+	assert.Empty(t, report.Mismatches)
+}
+
+func TestReport_LatencyRegressions(t *testing.T) {
+	report := &Report{Routes: []RouteReport{
+		{Route: "parcels.nearby", BaselineP95: 10 * time.Millisecond, TargetP95: 25 * time.Millisecond},
+		{Route: "parcels.search", BaselineP95: 10 * time.Millisecond, TargetP95: 15 * time.Millisecond},
+		{Route: "parcels.identify", TargetP95: 15 * time.Millisecond},
+	}}
+
+	regressed := report.LatencyRegressions(2)
+
+	require.Len(t, regressed, 1)
+	assert.Equal(t, "parcels.nearby", regressed[0].Route)
+}
+
+func TestPercentile(t *testing.T) {
+	assert.Equal(t, time.Duration(0), percentile(nil, 0.5))
+	durations := []time.Duration{5, 1, 4, 2, 3}
+	assert.Equal(t, time.Duration(3), percentile(durations, 0.5))
+	assert.Equal(t, time.Duration(5), percentile(durations, 0.95))
+	assert.Equal(t, time.Duration(1), percentile(durations, 0))
+}
+
+func TestRunner_Run(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(middleware.APIKeyHeader) != "atlas_staging" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v1/parcels/nearby":
+			if r.URL.Query().Get("lat") != "30.35" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Header().Set(middleware.ResultCountHeader, "3")
+		case "/api/v1/parcels/in-area":
+			body, _ := io.ReadAll(r.Body)
+			if r.Method != http.MethodPost || string(body) != `{"type":"Polygon"}` ||
+				r.Header.Get("Content-Type") != "application/json" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Header().Set(middleware.ResultCountHeader, "5")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	captures := []models.ReplayQuery{
+		{Route: "parcels.nearby", Method: "GET", Path: "/api/v1/parcels/nearby", Query: "lat=30.35&lng=-95.45",
+			Status: 200, ResultCount: intPtr(3)},
+		{Route: "parcels.in-area", Method: "POST", Path: "/api/v1/parcels/in-area", Body: `{"type":"Polygon"}`,
+			Status: 200, ResultCount: intPtr(4)},
+	}
+	runner := &Runner{Target: server.URL + "/", APIKey: "atlas_staging", Concurrency: 2}
+
+	report := runner.Run(context.Background(), captures)
+
+	assert.Equal(t, 2, report.Summary.Requests)
+	assert.Equal(t, 0, report.Summary.StatusMismatches)
+	assert.Equal(t, 1, report.Summary.CountMismatches)
+	require.Len(t, report.Mismatches, 1)
+	assert.Equal(t, "parcels.in-area", report.Mismatches[0].Capture.Route)
+	assert.Equal(t, 5, *report.Mismatches[0].ResultCount)
+}
+
+func TestRunner_RunCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	runner := &Runner{Target: "http://127.0.0.1:0", Concurrency: 1}
+
+	report := runner.Run(ctx, []models.ReplayQuery{{Route: "parcels.nearby", Method: "GET", Path: "/"}})
+
+	assert.Equal(t, 0, report.Summary.Requests, "nothing is sent after cancellation")
+}
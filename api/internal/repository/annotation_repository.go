@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// AnnotationRepository defines the interface for annotation data access operations.
+// Reads return the annotations the user may read: their own and shared ones.
+type AnnotationRepository interface {
+	// Create stores a new annotation on the parcel and returns it with its ID
+	// and timestamps.
+	// Returns nil, nil if the parcel doesn't exist or was deleted (not an error).
+	Create(ctx context.Context, annotation *models.Annotation) (*models.Annotation, error)
+
+	// Get returns the annotation if the user may read it.
+	// Returns nil, nil if there is no such annotation or it is another user's private one (not an error).
+	Get(ctx context.Context, userID, id uint) (*models.Annotation, error)
+
+	// List returns up to limit annotations the user may read, newest first,
+	// only those on the parcel when parcelID is not 0.
+	// Returns an empty slice if there are none (not an error).
+	List(ctx context.Context, userID, parcelID uint, limit int) ([]models.Annotation, error)
+
+	// Update replaces the body, markup and visibility of the user's annotation.
+	// Returns nil, nil if the user didn't write such an annotation (not an error).
+	Update(ctx context.Context, annotation *models.Annotation) (*models.Annotation, error)
+
+	// Delete deletes the user's annotation.
+	// Returns false if the user didn't write such an annotation (not an error).
+	Delete(ctx context.Context, userID, id uint) (bool, error)
+}
+
+// annotationRepository is the concrete implementation of AnnotationRepository.
+type annotationRepository struct {
+	db *database.Database
+}
+
+// NewAnnotationRepository creates a new instance of AnnotationRepository.
+func NewAnnotationRepository(db *database.Database) AnnotationRepository {
+	return &annotationRepository{
+		db: db,
+	}
+}
+
+// annotationSelect selects the annotations of the relation a, joined to their
+// parcel by county and object ID to report its current ID. Scan the rows with
+// scanAnnotation.
+const annotationSelect = `
+	SELECT a.id, a.user_id, COALESCE(p.id, a.parcel_id), a.county_id, a.object_id, a.body,
+		ST_AsGeoJSON(a.markup), a.visibility, a.created_at, a.updated_at
+	FROM %s a
+	LEFT JOIN tax_parcels p ON p.county_id = a.county_id AND p.object_id = a.object_id
+`
+
+// annotationReadable restricts annotationSelect to the annotations user $1 may read.
+const annotationReadable = ` (a.user_id = $1 OR a.visibility = '` + models.AnnotationVisibilityShared + `')`
+
+// scanAnnotation scans a row selected with annotationSelect into an Annotation.
+func scanAnnotation(row pgx.Row) (*models.Annotation, error) {
+	var a models.Annotation
+	var markupJSON []byte
+
+	if err := row.Scan(&a.ID, &a.UserID, &a.ParcelID, &a.CountyID, &a.ObjectID, &a.Body,
+		&markupJSON, &a.Visibility, &a.CreatedAt, &a.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	if markupJSON != nil {
+		a.Markup = &models.Markup{}
+		if err := a.Markup.Scan(markupJSON); err != nil {
+			return nil, fmt.Errorf("failed to parse markup for annotation %d: %w", a.ID, err)
+		}
+	}
+
+	return &a, nil
+}
+
+// markupValue returns the GeoJSON of the markup for ST_GeomFromGeoJSON, or nil without markup.
+func markupValue(markup *models.Markup) (any, error) {
+	if markup == nil {
+		return nil, nil
+	}
+	return markup.Value()
+}
+
+// Create inserts the annotation with the county and object ID of the parcel,
+// which must not be deleted.
+func (r *annotationRepository) Create(ctx context.Context, annotation *models.Annotation) (*models.Annotation, error) {
+	markup, err := markupValue(annotation.Markup)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		WITH created AS (
+			INSERT INTO annotations (user_id, parcel_id, county_id, object_id, body, markup, visibility, created_at, updated_at)
+			SELECT $1, id, county_id, object_id, $3, ST_SetSRID(ST_GeomFromGeoJSON($4::text), 4326), $5, NOW(), NOW()
+			FROM tax_parcels
+			WHERE id = $2 AND deleted_at IS NULL
+			RETURNING *
+		)` + fmt.Sprintf(annotationSelect, "created")
+
+	stored, err := scanAnnotation(r.db.Pool.QueryRow(ctx, query,
+		annotation.UserID, annotation.ParcelID, annotation.Body, markup, annotation.Visibility))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to create annotation (user=%d, parcel=%d): %w", annotation.UserID, annotation.ParcelID, err)
+	}
+
+	return stored, nil
+}
+
+// Get queries one readable annotation.
+func (r *annotationRepository) Get(ctx context.Context, userID, id uint) (*models.Annotation, error) {
+	query := fmt.Sprintf(annotationSelect, "annotations") + ` WHERE a.id = $2 AND` + annotationReadable
+
+	annotation, err := scanAnnotation(r.db.Pool.QueryRow(ctx, query, userID, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get annotation (user=%d, id=%d): %w", userID, id, err)
+	}
+
+	return annotation, nil
+}
+
+// List queries readable annotations. A parcel's annotations are matched by the
+// county and object ID of the parcel with the given ID, deleted or not.
+func (r *annotationRepository) List(ctx context.Context, userID, parcelID uint, limit int) ([]models.Annotation, error) {
+	query := fmt.Sprintf(annotationSelect, "annotations") + ` WHERE` + annotationReadable + `
+		AND ($2 = 0 OR (a.county_id, a.object_id) = (SELECT county_id, object_id FROM tax_parcels WHERE id = $2))
+		ORDER BY a.id DESC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, userID, parcelID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list annotations (user=%d, parcel=%d): %w", userID, parcelID, err)
+	}
+	defer rows.Close()
+
+	annotations := []models.Annotation{}
+	for rows.Next() {
+		annotation, err := scanAnnotation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan annotation row: %w", err)
+		}
+		annotations = append(annotations, *annotation)
+	}
+
+	// Check for errors during iteration
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating annotation rows: %w", err)
+	}
+
+	return annotations, nil
+}
+
+// Update rewrites the annotation if the user wrote it.
+func (r *annotationRepository) Update(ctx context.Context, annotation *models.Annotation) (*models.Annotation, error) {
+	markup, err := markupValue(annotation.Markup)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		WITH updated AS (
+			UPDATE annotations
+			SET body = $3, markup = ST_SetSRID(ST_GeomFromGeoJSON($4::text), 4326), visibility = $5, updated_at = NOW()
+			WHERE user_id = $1 AND id = $2
+			RETURNING *
+		)` + fmt.Sprintf(annotationSelect, "updated")
+
+	stored, err := scanAnnotation(r.db.Pool.QueryRow(ctx, query,
+		annotation.UserID, annotation.ID, annotation.Body, markup, annotation.Visibility))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to update annotation (user=%d, id=%d): %w", annotation.UserID, annotation.ID, err)
+	}
+
+	return stored, nil
+}
+
+// Delete removes the annotation if the user wrote it.
+func (r *annotationRepository) Delete(ctx context.Context, userID, id uint) (bool, error) {
+	tag, err := r.db.Pool.Exec(ctx, `DELETE FROM annotations WHERE user_id = $1 AND id = $2`, userID, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete annotation (user=%d, id=%d): %w", userID, id, err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
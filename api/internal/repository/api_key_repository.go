@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// APIKeyRepository defines the interface for API key data access operations.
+type APIKeyRepository interface {
+	// Create stores a new key and returns it with its ID and creation time.
+	Create(ctx context.Context, key *models.APIKey) (*models.APIKey, error)
+
+	// FindByHash finds the key with the given SHA-256 hash, including revoked keys.
+	// Returns nil, nil if no key is found (not an error).
+	FindByHash(ctx context.Context, hash string) (*models.APIKey, error)
+
+	// List returns all keys of the tenant, including revoked keys, newest first.
+	// Returns an empty slice if the tenant has no keys (not an error).
+	List(ctx context.Context, tenantID string) ([]models.APIKey, error)
+
+	// Revoke marks the tenant's key as revoked.
+	// Returns false if the tenant has no such unrevoked key (not an error).
+	Revoke(ctx context.Context, tenantID string, id uint) (bool, error)
+
+	// TouchLastUsed records that the key was just used.
+	TouchLastUsed(ctx context.Context, id uint) error
+}
+
+// apiKeyRepository is the concrete implementation of APIKeyRepository.
+type apiKeyRepository struct {
+	db *database.Database
+}
+
+// NewAPIKeyRepository creates a new instance of APIKeyRepository.
+func NewAPIKeyRepository(db *database.Database) APIKeyRepository {
+	return &apiKeyRepository{
+		db: db,
+	}
+}
+
+// apiKeyColumns is the column list selected for a full APIKey row.
+const apiKeyColumns = `id, tenant_id, name, prefix, key_hash, role, created_at, last_used_at, revoked_at`
+
+// scanAPIKey scans a row selected with apiKeyColumns into an APIKey.
+func scanAPIKey(row pgx.Row) (*models.APIKey, error) {
+	var key models.APIKey
+	err := row.Scan(
+		&key.ID,
+		&key.TenantID,
+		&key.Name,
+		&key.Prefix,
+		&key.KeyHash,
+		&key.Role,
+		&key.CreatedAt,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// Create inserts a key row.
+func (r *apiKeyRepository) Create(ctx context.Context, key *models.APIKey) (*models.APIKey, error) {
+	query := `
+		INSERT INTO api_keys (tenant_id, name, prefix, key_hash, role, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		RETURNING ` + apiKeyColumns
+
+	stored, err := scanAPIKey(r.db.Pool.QueryRow(ctx, query, key.TenantID, key.Name, key.Prefix, key.KeyHash, key.Role))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api key (tenant=%s, name=%s): %w", key.TenantID, key.Name, err)
+	}
+
+	return stored, nil
+}
+
+// FindByHash queries a single key by the unique hash.
+func (r *apiKeyRepository) FindByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1`
+
+	key, err := scanAPIKey(r.db.Pool.QueryRow(ctx, query, hash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query api key: %w", err)
+	}
+
+	return key, nil
+}
+
+// List queries all keys belonging to the tenant.
+func (r *apiKeyRepository) List(ctx context.Context, tenantID string) ([]models.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE tenant_id = $1 ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.Pool.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys (tenant=%s): %w", tenantID, err)
+	}
+	defer rows.Close()
+
+	keys := []models.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key row: %w", err)
+		}
+		keys = append(keys, *key)
+	}
+
+	// Check for errors during iteration
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating api key rows: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Revoke sets revoked_at on an unrevoked key of the tenant.
+func (r *apiKeyRepository) Revoke(ctx context.Context, tenantID string, id uint) (bool, error) {
+	query := `UPDATE api_keys SET revoked_at = NOW() WHERE tenant_id = $1 AND id = $2 AND revoked_at IS NULL`
+
+	tag, err := r.db.Pool.Exec(ctx, query, tenantID, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke api key (tenant=%s, id=%d): %w", tenantID, id, err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// TouchLastUsed sets last_used_at to now.
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id uint) error {
+	if _, err := r.db.Pool.Exec(ctx, `UPDATE api_keys SET last_used_at = NOW() WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to update api key last use (id=%d): %w", id, err)
+	}
+	return nil
+}
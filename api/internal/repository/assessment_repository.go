@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// AssessmentRepository defines the interface for parcel assessment history data access operations.
+type AssessmentRepository interface {
+	// FindForParcel returns the yearly assessments of the live parcel with the
+	// given id, newest year first: those of its county whose prop_id is the
+	// parcel's pid. Returns an empty slice if the parcel has no recorded
+	// assessments, and nil if no live parcel has the id (neither is an error).
+	FindForParcel(ctx context.Context, parcelID uint) ([]models.ParcelAssessment, error)
+}
+
+// assessmentRepository is the concrete implementation of AssessmentRepository.
+type assessmentRepository struct {
+	db *database.Database
+}
+
+// NewAssessmentRepository creates a new instance of AssessmentRepository.
+func NewAssessmentRepository(db *database.Database) AssessmentRepository {
+	return &assessmentRepository{
+		db: db,
+	}
+}
+
+// FindForParcel left joins the parcel to its assessments, so a parcel without
+// assessments yields one row of NULL assessment columns and a missing parcel none.
+func (r *assessmentRepository) FindForParcel(ctx context.Context, parcelID uint) ([]models.ParcelAssessment, error) {
+	query := `
+		SELECT a.tax_year, a.land_value, a.improvement_value, a.ag_use_value,
+			a.market_value, a.appraised_value, a.assessed_value
+		FROM tax_parcels p
+		LEFT JOIN parcel_assessments a ON a.county_id = p.county_id AND a.prop_id = p.pid
+		WHERE p.id = $1` + liveParcelClause + `
+		ORDER BY a.tax_year DESC
+	`
+
+	var assessments []models.ParcelAssessment
+	err := r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		rows, err := q.Query(ctx, query, parcelID)
+		if err != nil {
+			return fmt.Errorf("failed to query parcel assessments (parcel=%d): %w", parcelID, err)
+		}
+		defer rows.Close()
+
+		assessments = nil
+		for rows.Next() {
+			if assessments == nil {
+				assessments = []models.ParcelAssessment{}
+			}
+
+			var year, land, improvement, agUse, market, appraised, assessed *int64
+			if err := rows.Scan(&year, &land, &improvement, &agUse, &market, &appraised, &assessed); err != nil {
+				return fmt.Errorf("failed to scan parcel assessment row: %w", err)
+			}
+			if year == nil {
+				continue
+			}
+			assessments = append(assessments, models.ParcelAssessment{
+				Year:        int(*year),
+				Land:        *land,
+				Improvement: *improvement,
+				AgUse:       *agUse,
+				Market:      *market,
+				Appraised:   *appraised,
+				Assessed:    *assessed,
+			})
+		}
+
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating parcel assessment rows: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return assessments, nil
+}
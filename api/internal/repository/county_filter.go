@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+)
+
+// countyKey is the context key set by WithCounty.
+type countyKey struct{}
+
+// WithCounty returns a context that limits parcel queries to the county with the
+// given slug (e.g. montgomery-tx). An empty slug leaves queries across all
+// counties; an unknown slug matches no parcels. FindByIDs ignores the county,
+// since the IDs already identify the parcels.
+func WithCounty(ctx context.Context, slug string) context.Context {
+	return context.WithValue(ctx, countyKey{}, slug)
+}
+
+// CountyFromContext returns the county slug set by WithCounty, or "" when
+// queries span all counties.
+func CountyFromContext(ctx context.Context) string {
+	slug, _ := ctx.Value(countyKey{}).(string)
+	return slug
+}
+
+// countyClause returns the SQL condition limiting tax_parcels to the county in
+// parameter n. A NULL parameter disables it, so the statement text is the same
+// with and without a county; the value comes from countyParam.
+func countyClause(n int) string {
+	return fmt.Sprintf(`
+			AND ($%[1]d::text IS NULL OR county_id = (SELECT id FROM counties WHERE slug = $%[1]d))`, n)
+}
+
+// countyParam returns the county slug set by WithCounty, or nil for all counties.
+func countyParam(ctx context.Context) *string {
+	if slug, _ := ctx.Value(countyKey{}).(string); slug != "" {
+		return &slug
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// CountyRepository defines the interface for county data access operations.
+type CountyRepository interface {
+	// List returns all counties ordered by slug.
+	// Returns an empty slice if no counties exist (not an error).
+	List(ctx context.Context) ([]models.County, error)
+
+	// ListProfiles returns the profile of every county ordered by slug; counties
+	// without a stored profile have an empty one.
+	ListProfiles(ctx context.Context) ([]models.CountyProfile, error)
+
+	// UpsertProfile creates or replaces the profile of the county with the given
+	// slug and returns the stored profile.
+	// Returns nil, nil if no county has the slug (not an error).
+	UpsertProfile(ctx context.Context, slug string, profile *models.CountyProfile) (*models.CountyProfile, error)
+}
+
+// countyRepository is the concrete implementation of CountyRepository.
+type countyRepository struct {
+	db *database.Database
+}
+
+// NewCountyRepository creates a new instance of CountyRepository.
+func NewCountyRepository(db *database.Database) CountyRepository {
+	return &countyRepository{
+		db: db,
+	}
+}
+
+// List queries all counties.
+func (r *countyRepository) List(ctx context.Context) ([]models.County, error) {
+	query := `
+		SELECT id, slug, name, state, field_mappings, document_url_templates, created_at, updated_at
+		FROM counties
+		ORDER BY slug
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list counties: %w", err)
+	}
+	defer rows.Close()
+
+	counties := []models.County{}
+	for rows.Next() {
+		var county models.County
+		var mappingsJSON, templatesJSON []byte
+
+		err := rows.Scan(
+			&county.ID,
+			&county.Slug,
+			&county.Name,
+			&county.State,
+			&mappingsJSON,
+			&templatesJSON,
+			&county.CreatedAt,
+			&county.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan county row: %w", err)
+		}
+
+		if err := json.Unmarshal(mappingsJSON, &county.FieldMappings); err != nil {
+			return nil, fmt.Errorf("failed to parse field mappings for county %s: %w", county.Slug, err)
+		}
+
+		if err := json.Unmarshal(templatesJSON, &county.DocumentURLTemplates); err != nil {
+			return nil, fmt.Errorf("failed to parse document URL templates for county %s: %w", county.Slug, err)
+		}
+
+		counties = append(counties, county)
+	}
+
+	// Check for errors during iteration
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating county rows: %w", err)
+	}
+
+	return counties, nil
+}
+
+// scanProfile scans a row of county id, slug, and the profile columns, which
+// are NULL for a county without a profile.
+func scanProfile(row pgx.Row) (*models.CountyProfile, error) {
+	var profile models.CountyProfile
+	var attributesJSON, sourceJSON []byte
+	var updatedAt *time.Time
+
+	err := row.Scan(
+		&profile.CountyID,
+		&profile.County,
+		&profile.DefaultRadiusMeters,
+		&profile.MaxRadiusMeters,
+		&profile.SimplifyTolerance,
+		&attributesJSON,
+		&sourceJSON,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	profile.Attributes = map[string]bool{}
+	if attributesJSON != nil {
+		if err := json.Unmarshal(attributesJSON, &profile.Attributes); err != nil {
+			return nil, fmt.Errorf("failed to parse attributes for county %s: %w", profile.County, err)
+		}
+	}
+	if sourceJSON != nil {
+		if err := json.Unmarshal(sourceJSON, &profile.Source); err != nil {
+			return nil, fmt.Errorf("failed to parse source for county %s: %w", profile.County, err)
+		}
+	}
+	if updatedAt != nil {
+		profile.UpdatedAt = *updatedAt
+	}
+
+	return &profile, nil
+}
+
+// ListProfiles left joins the profiles onto the counties.
+func (r *countyRepository) ListProfiles(ctx context.Context) ([]models.CountyProfile, error) {
+	query := `
+		SELECT c.id, c.slug, p.default_radius_meters, p.max_radius_meters, p.simplify_tolerance,
+			p.attributes, p.source, p.updated_at
+		FROM counties c
+		LEFT JOIN county_profiles p ON p.county_id = c.id
+		ORDER BY c.slug
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list county profiles: %w", err)
+	}
+	defer rows.Close()
+
+	profiles := []models.CountyProfile{}
+	for rows.Next() {
+		profile, err := scanProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan county profile row: %w", err)
+		}
+		profiles = append(profiles, *profile)
+	}
+
+	// Check for errors during iteration
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating county profile rows: %w", err)
+	}
+
+	return profiles, nil
+}
+
+// UpsertProfile resolves the slug in the insert, so an unknown county inserts
+// nothing and returns no row.
+func (r *countyRepository) UpsertProfile(ctx context.Context, slug string, profile *models.CountyProfile) (*models.CountyProfile, error) {
+	attributesJSON, err := json.Marshal(profile.Attributes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal profile attributes: %w", err)
+	}
+	sourceJSON, err := json.Marshal(profile.Source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal profile source: %w", err)
+	}
+
+	query := `
+		INSERT INTO county_profiles (county_id, default_radius_meters, max_radius_meters, simplify_tolerance,
+			attributes, source, updated_at)
+		SELECT id, $2, $3, $4, $5, $6, NOW() FROM counties WHERE slug = $1
+		ON CONFLICT (county_id) DO UPDATE SET
+			default_radius_meters = EXCLUDED.default_radius_meters,
+			max_radius_meters = EXCLUDED.max_radius_meters,
+			simplify_tolerance = EXCLUDED.simplify_tolerance,
+			attributes = EXCLUDED.attributes,
+			source = EXCLUDED.source,
+			updated_at = NOW()
+		RETURNING county_id, $1::text, default_radius_meters, max_radius_meters, simplify_tolerance,
+			attributes, source, updated_at
+	`
+
+	stored, err := scanProfile(r.db.Pool.QueryRow(ctx, query, slug, profile.DefaultRadiusMeters,
+		profile.MaxRadiusMeters, profile.SimplifyTolerance, attributesJSON, sourceJSON))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to upsert county profile (county=%s): %w", slug, err)
+	}
+
+	return stored, nil
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+)
+
+// parcelAttributesColumn is the derived attributes column in parcelColumns. It is
+// NULL until parcelRepository.withAttributes replaces it with the configured attributes.
+const parcelAttributesColumn = "NULL::jsonb as attributes"
+
+// Attribute filter operators
+const (
+	AttributeEquals AttributeOp = "equals"
+	AttributeMin    AttributeOp = "min"
+	AttributeMax    AttributeOp = "max"
+)
+
+// AttributeOp is how an AttributeFilter compares a derived attribute to its value.
+// AttributeMin and AttributeMax are inclusive and only apply to numeric attributes.
+type AttributeOp string
+
+// AttributeFilter matches parcels by a derived attribute (see config.DerivedAttribute).
+// Parcels whose attribute is NULL never match.
+type AttributeFilter struct {
+	// Value is a float64, int64, string or bool matching the attribute's type
+	Value any
+	Name  string
+	Op    AttributeOp
+}
+
+// derivedAttributesColumn returns the column selecting the attributes as one JSON
+// object keyed by name, or parcelAttributesColumn when there are none. Ingest-stage
+// values are read from derived_attributes and query-stage expressions are inlined.
+func derivedAttributesColumn(attributes []config.DerivedAttribute) string {
+	if len(attributes) == 0 {
+		return parcelAttributesColumn
+	}
+	pairs := make([]string, 0, len(attributes))
+	for _, a := range attributes {
+		pairs = append(pairs, "'"+a.Name+"', "+derivedValue(a))
+	}
+	return "jsonb_build_object(" + strings.Join(pairs, ", ") + ") as attributes"
+}
+
+// derivedValue returns the SQL value of an attribute cast to its type. Names and
+// expressions are checked by config.Validate, so they are safe to inline.
+// The statement must select from tax_parcels without an alias.
+func derivedValue(a config.DerivedAttribute) string {
+	if a.Stage == config.DerivedAtIngest {
+		return "(derived_attributes->>'" + a.Name + "')::" + a.Type.SQLType()
+	}
+	return "((" + a.SQL + ")::" + a.Type.SQLType() + ")"
+}
+
+// withAttributes returns columns, a parcel column list, selecting the configured attributes.
+func (r *parcelRepository) withAttributes(columns string) string {
+	return strings.Replace(columns, parcelAttributesColumn, r.attributesColumn, 1)
+}
+
+// attributeFilterClause returns the SQL conditions for filters, numbering their
+// parameters from first, along with the parameter values in order.
+// Returns an error for a filter on an attribute that is not configured.
+func (r *parcelRepository) attributeFilterClause(filters []AttributeFilter, first int) (string, []any, error) {
+	var clause strings.Builder
+	params := make([]any, 0, len(filters))
+	for _, f := range filters {
+		attribute, ok := r.derived[f.Name]
+		if !ok {
+			return "", nil, fmt.Errorf("unknown derived attribute %q", f.Name)
+		}
+		operator := "="
+		switch f.Op {
+		case AttributeMin:
+			operator = ">="
+		case AttributeMax:
+			operator = "<="
+		}
+		fmt.Fprintf(&clause, `
+			AND %s %s $%d::%s`, derivedValue(attribute), operator, first+len(params), attribute.Type.SQLType())
+		params = append(params, f.Value)
+	}
+	return clause.String(), params, nil
+}
+
+// CheckDerivedAttributes compiles the expressions of the attributes, whatever
+// their stage, against tax_parcels without reading a row, so a typo or a type
+// mismatch is reported at startup instead of by every parcel query.
+func CheckDerivedAttributes(ctx context.Context, db *database.Database, attributes []config.DerivedAttribute) error {
+	for _, a := range attributes {
+		query := `SELECT (` + a.SQL + `)::` + a.Type.SQLType() + ` FROM tax_parcels LIMIT 0`
+		rows, err := db.Pool.Query(ctx, query)
+		if err == nil {
+			rows.Close()
+			err = rows.Err()
+		}
+		if err != nil {
+			return fmt.Errorf("invalid derived attribute %q: %w", a.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/config"
+)
+
+var testDerivedAttributes = []config.DerivedAttribute{
+	{Name: "improvement_ratio", SQL: "imprv_main_area / 2.0", Type: config.DerivedFloat, Stage: config.DerivedAtQuery},
+	{Name: "building_age", SQL: "2026 - imprv_actual_year_built", Type: config.DerivedInt, Stage: config.DerivedAtIngest},
+}
+
+func TestDerivedAttributesColumn(t *testing.T) {
+	assert.Equal(t, parcelAttributesColumn, derivedAttributesColumn(nil))
+	assert.Equal(t,
+		"jsonb_build_object('improvement_ratio', ((imprv_main_area / 2.0)::float8), "+
+			"'building_age', (derived_attributes->>'building_age')::int8) as attributes",
+		derivedAttributesColumn(testDerivedAttributes))
+}
+
+func TestWithAttributes(t *testing.T) {
+	repo := NewParcelRepository(nil, nil, 0, testDerivedAttributes...).(*parcelRepository)
+
+	columns := repo.withAttributes(parcelColumns)
+	assert.NotContains(t, columns, parcelAttributesColumn)
+	assert.Contains(t, columns, "jsonb_build_object('improvement_ratio'")
+	assert.Equal(t, testDerivedAttributes, repo.DerivedAttributes())
+
+	plain := NewParcelRepository(nil, nil, 0).(*parcelRepository)
+	assert.Equal(t, parcelColumns, plain.withAttributes(parcelColumns))
+}
+
+func TestAttributeFilterClause(t *testing.T) {
+	repo := NewParcelRepository(nil, nil, 0, testDerivedAttributes...).(*parcelRepository)
+
+	clause, params, err := repo.attributeFilterClause([]AttributeFilter{
+		{Name: "improvement_ratio", Op: AttributeMin, Value: 1500.0},
+		{Name: "building_age", Op: AttributeEquals, Value: int64(20)},
+	}, 14)
+	require.NoError(t, err)
+	assert.Equal(t, []any{1500.0, int64(20)}, params)
+	assert.Equal(t, []string{
+		"AND ((imprv_main_area / 2.0)::float8) >= $14::float8",
+		"AND (derived_attributes->>'building_age')::int8 = $15::int8",
+	}, strings.Split(strings.TrimSpace(strings.ReplaceAll(clause, "\t", "")), "\n"))
+
+	clause, params, err = repo.attributeFilterClause(nil, 14)
+	require.NoError(t, err)
+	assert.Empty(t, clause)
+	assert.Empty(t, params)
+
+	_, _, err = repo.attributeFilterClause([]AttributeFilter{{Name: "value_per_acre", Op: AttributeEquals, Value: 1.0}}, 14)
+	assert.ErrorContains(t, err, "unknown derived attribute")
+}
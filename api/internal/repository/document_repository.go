@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+)
+
+// ParcelDocumentSources holds the parcel records document references are parsed
+// from, together with the document URL templates of the parcel's county.
+type ParcelDocumentSources struct {
+	LegalDescription *string
+	URLTemplates     map[string]string
+	ParcelID         uint
+}
+
+// DocumentRepository defines the interface for document reference data access operations.
+type DocumentRepository interface {
+	// FindParcelSources returns the document sources of the live parcel with the given id.
+	// Returns nil, nil if no parcel is found (not an error).
+	FindParcelSources(ctx context.Context, parcelID uint) (*ParcelDocumentSources, error)
+}
+
+// documentRepository is the concrete implementation of DocumentRepository.
+type documentRepository struct {
+	db *database.Database
+}
+
+// NewDocumentRepository creates a new instance of DocumentRepository.
+func NewDocumentRepository(db *database.Database) DocumentRepository {
+	return &documentRepository{
+		db: db,
+	}
+}
+
+// FindParcelSources queries the parcel's legal description and its county's templates.
+func (r *documentRepository) FindParcelSources(ctx context.Context, parcelID uint) (*ParcelDocumentSources, error) {
+	query := `
+		SELECT id, legal_description,
+			(SELECT document_url_templates FROM counties WHERE counties.id = tax_parcels.county_id)
+		FROM tax_parcels
+		WHERE id = $1` + liveParcelClause
+
+	var sources ParcelDocumentSources
+	var templatesJSON []byte
+	err := r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		return q.QueryRow(ctx, query, parcelID).Scan(&sources.ParcelID, &sources.LegalDescription, &templatesJSON)
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query document sources (parcel=%d): %w", parcelID, err)
+	}
+
+	// Parcels without a county have no templates
+	if templatesJSON != nil {
+		if err := json.Unmarshal(templatesJSON, &sources.URLTemplates); err != nil {
+			return nil, fmt.Errorf("failed to parse document URL templates (parcel=%d): %w", parcelID, err)
+		}
+	}
+
+	return &sources, nil
+}
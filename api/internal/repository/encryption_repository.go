@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/encryption"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// encryptedParcelColumns are the tax_parcels columns holding owner contact data,
+// encrypted with the keyring when encryption is configured. Phone and email
+// enrichment columns are added here when they are introduced.
+var encryptedParcelColumns = []string{"owner_address"}
+
+// encryptedTables are the tables holding encryptedParcelColumns: the parcels and
+// their prior versions.
+var encryptedTables = []string{"tax_parcels", "parcel_versions"}
+
+// EncryptionRepository defines the interface for the data keys of column
+// encryption and for re-encrypting the encrypted columns after a rotation.
+type EncryptionRepository interface {
+	encryption.DataKeyStore
+
+	// ReencryptContacts rewrites up to batchSize values of each encrypted parcel
+	// column that are not encrypted with the keyring's active data key, including
+	// plaintext written before encryption was enabled. Returns the number of values
+	// rewritten; callers repeat until it returns 0.
+	ReencryptContacts(ctx context.Context, keyring *encryption.Keyring, batchSize int) (int64, error)
+}
+
+// encryptionRepository is the concrete implementation of EncryptionRepository.
+type encryptionRepository struct {
+	db *database.Database
+}
+
+// NewEncryptionRepository creates a new instance of EncryptionRepository.
+func NewEncryptionRepository(db *database.Database) EncryptionRepository {
+	return &encryptionRepository{
+		db: db,
+	}
+}
+
+// ListDataKeys queries all data keys ordered by ID.
+func (r *encryptionRepository) ListDataKeys(ctx context.Context) ([]models.DataKey, error) {
+	query := `SELECT id, master_key_id, wrapped_key, created_at FROM data_keys ORDER BY id`
+
+	rows, err := r.db.Pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list data keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []models.DataKey{}
+	for rows.Next() {
+		var key models.DataKey
+		if err := rows.Scan(&key.ID, &key.MasterKeyID, &key.WrappedKey, &key.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan data key row: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	// Check for errors during iteration
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating data key rows: %w", err)
+	}
+
+	return keys, nil
+}
+
+// CreateDataKey inserts a data key row.
+func (r *encryptionRepository) CreateDataKey(ctx context.Context, masterKeyID string, wrapped []byte) (*models.DataKey, error) {
+	query := `
+		INSERT INTO data_keys (master_key_id, wrapped_key, created_at)
+		VALUES ($1, $2, NOW())
+		RETURNING id, master_key_id, wrapped_key, created_at`
+
+	var key models.DataKey
+	err := r.db.Pool.QueryRow(ctx, query, masterKeyID, wrapped).
+		Scan(&key.ID, &key.MasterKeyID, &key.WrappedKey, &key.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create data key (master_key=%s): %w", masterKeyID, err)
+	}
+
+	return &key, nil
+}
+
+// RewrapDataKey replaces the wrapped key and its master key ID.
+func (r *encryptionRepository) RewrapDataKey(ctx context.Context, id uint, masterKeyID string, wrapped []byte) error {
+	query := `UPDATE data_keys SET master_key_id = $2, wrapped_key = $3 WHERE id = $1`
+
+	if _, err := r.db.Pool.Exec(ctx, query, id, masterKeyID, wrapped); err != nil {
+		return fmt.Errorf("failed to rewrap data key %d: %w", id, err)
+	}
+
+	return nil
+}
+
+// ReencryptContacts decrypts each stale value with its data key and writes it
+// back encrypted with the active one. Soft-deleted parcels and the versions in
+// parcel_versions are included, since they are stored too. Rows are rewritten by
+// ID, so a concurrent ingest of the same parcels wins; owner_address is not a
+// versioned column, so rewriting it records no parcel version.
+func (r *encryptionRepository) ReencryptContacts(ctx context.Context, keyring *encryption.Keyring, batchSize int) (int64, error) {
+	var total int64
+	for _, table := range encryptedTables {
+		for _, column := range encryptedParcelColumns {
+			count, err := r.reencryptColumn(ctx, keyring, table, column, batchSize)
+			total += count
+			if err != nil {
+				return total, err
+			}
+		}
+	}
+
+	return total, nil
+}
+
+// reencryptColumn rewrites up to batchSize stale values of one encrypted column.
+func (r *encryptionRepository) reencryptColumn(ctx context.Context, keyring *encryption.Keyring, table, column string, batchSize int) (int64, error) {
+	// The table and column names are constants, so they are safe to inline
+	query := `
+		SELECT id, ` + column + `
+		FROM ` + table + `
+		WHERE ` + column + ` IS NOT NULL AND ` + column + ` NOT LIKE $1
+		ORDER BY id
+		LIMIT $2`
+
+	rows, err := r.db.Pool.Query(ctx, query, keyring.ActivePrefix()+"%", batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query stale %s.%s values: %w", table, column, err)
+	}
+
+	var ids []int64
+	var values []string
+	for rows.Next() {
+		var id int64
+		var value string
+		if err := rows.Scan(&id, &value); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan %s.%s row: %w", table, column, err)
+		}
+
+		plaintext, err := keyring.Decrypt(ctx, value)
+		if err == nil {
+			value, err = keyring.Encrypt(plaintext)
+		}
+		if err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to re-encrypt %s of %s row %d: %w", column, table, id, err)
+		}
+		ids = append(ids, id)
+		values = append(values, value)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating %s.%s rows: %w", table, column, err)
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	update := `
+		UPDATE ` + table + ` AS t
+		SET ` + column + ` = v.value
+		FROM unnest($1::bigint[], $2::text[]) AS v(id, value)
+		WHERE t.id = v.id`
+	tag, err := r.db.Pool.Exec(ctx, update, ids, values)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update %s.%s values: %w", table, column, err)
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// FavoriteRepository defines the interface for favorite data access operations.
+type FavoriteRepository interface {
+	// Save bookmarks the parcel for the user, or updates the note of the
+	// existing favorite. A nil note keeps the existing note; an empty one clears it.
+	// Returns the favorite and whether it was created.
+	// Returns nil, false, nil if the parcel doesn't exist or was deleted (not an error).
+	Save(ctx context.Context, userID, parcelID uint, note *string) (*models.Favorite, bool, error)
+
+	// List returns the user's favorites with their parcels' current IDs, newest first.
+	// Returns an empty slice if the user has no favorites (not an error).
+	List(ctx context.Context, userID uint) ([]models.Favorite, error)
+
+	// Delete removes the user's favorite of the parcel, by its current ID or the
+	// ID it was saved under.
+	// Returns false if the user has no such favorite (not an error).
+	Delete(ctx context.Context, userID, parcelID uint) (bool, error)
+}
+
+// favoriteRepository is the concrete implementation of FavoriteRepository.
+type favoriteRepository struct {
+	db *database.Database
+}
+
+// NewFavoriteRepository creates a new instance of FavoriteRepository.
+func NewFavoriteRepository(db *database.Database) FavoriteRepository {
+	return &favoriteRepository{
+		db: db,
+	}
+}
+
+// Save upserts on the user's county and object ID, so saving a parcel twice
+// keeps one favorite. xmax is 0 only for rows the statement inserted.
+func (r *favoriteRepository) Save(ctx context.Context, userID, parcelID uint, note *string) (*models.Favorite, bool, error) {
+	query := `
+		WITH parcel AS (
+			SELECT id, county_id, object_id, pin, situs, county_name
+			FROM tax_parcels
+			WHERE id = $2 AND deleted_at IS NULL
+		), saved AS (
+			INSERT INTO favorites (user_id, parcel_id, county_id, object_id, pin, note, created_at, updated_at)
+			SELECT $1, id, county_id, object_id, pin, NULLIF($3::text, ''), NOW(), NOW()
+			FROM parcel
+			ON CONFLICT (user_id, county_id, object_id) DO UPDATE SET
+				parcel_id = EXCLUDED.parcel_id,
+				pin = EXCLUDED.pin,
+				note = CASE WHEN $3::text IS NULL THEN favorites.note ELSE EXCLUDED.note END,
+				updated_at = NOW()
+			RETURNING id, user_id, parcel_id, county_id, object_id, pin, note, created_at, updated_at, xmax = 0 AS created
+		)
+		SELECT saved.id, saved.user_id, saved.parcel_id, saved.county_id, saved.object_id, saved.pin, saved.note,
+			saved.created_at, saved.updated_at, parcel.situs, parcel.county_name, saved.created
+		FROM saved, parcel
+	`
+
+	var f models.Favorite
+	var created bool
+	err := r.db.Pool.QueryRow(ctx, query, userID, parcelID, note).Scan(
+		&f.ID, &f.UserID, &f.ParcelID, &f.CountyID, &f.ObjectID, &f.PIN, &f.Note,
+		&f.CreatedAt, &f.UpdatedAt, &f.Situs, &f.CountyName, &created,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to save favorite (user=%d, parcel=%d): %w", userID, parcelID, err)
+	}
+
+	return &f, created, nil
+}
+
+// List joins each favorite to the parcel with its county and object ID,
+// soft-deleted or not; the favorite is removed when there is none or it is deleted.
+func (r *favoriteRepository) List(ctx context.Context, userID uint) ([]models.Favorite, error) {
+	query := `
+		SELECT f.id, f.user_id, COALESCE(p.id, f.parcel_id), f.county_id, f.object_id, COALESCE(p.pin, f.pin), f.note,
+			f.created_at, f.updated_at, p.situs, p.county_name, p.id IS NULL OR p.deleted_at IS NOT NULL
+		FROM favorites f
+		LEFT JOIN tax_parcels p ON p.county_id = f.county_id AND p.object_id = f.object_id
+		WHERE f.user_id = $1
+		ORDER BY f.id DESC
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list favorites (user=%d): %w", userID, err)
+	}
+	defer rows.Close()
+
+	favorites := []models.Favorite{}
+	for rows.Next() {
+		var f models.Favorite
+		if err := rows.Scan(
+			&f.ID, &f.UserID, &f.ParcelID, &f.CountyID, &f.ObjectID, &f.PIN, &f.Note,
+			&f.CreatedAt, &f.UpdatedAt, &f.Situs, &f.CountyName, &f.Removed,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan favorite row: %w", err)
+		}
+		favorites = append(favorites, f)
+	}
+
+	// Check for errors during iteration
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating favorite rows: %w", err)
+	}
+
+	return favorites, nil
+}
+
+// Delete matches the saved parcel ID as well as the parcel's current county and
+// object ID, so the IDs List returns delete the favorite before and after a replace load.
+func (r *favoriteRepository) Delete(ctx context.Context, userID, parcelID uint) (bool, error) {
+	query := `
+		DELETE FROM favorites f
+		WHERE f.user_id = $1
+			AND (f.parcel_id = $2 OR EXISTS (
+				SELECT 1 FROM tax_parcels p
+				WHERE p.id = $2 AND p.county_id = f.county_id AND p.object_id = f.object_id
+			))
+	`
+
+	tag, err := r.db.Pool.Exec(ctx, query, userID, parcelID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete favorite (user=%d, parcel=%d): %w", userID, parcelID, err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,337 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/encryption"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// versionColumns are the versioned columns shared by tax_parcels and
+// parcel_versions, in the order of versionScanTargets. The geometry is returned
+// as GeoJSON, replaced by NULL for contexts created by WithoutGeometry; its hash
+// and the acres always use the stored geometry.
+var versionColumns = `
+			pin,
+			pid,
+			state_cd,
+			block,
+			lot,
+			tract,
+			owner_name,
+			owner_address,
+			situs,
+			as_code,
+			legal_description,
+			imprv_actual_year_built,
+			imprv_main_area,
+			p_year,
+			p_version,
+			p_roll_corr,
+			taxing_units,
+			exemptions,
+			market_area,
+			` + parcelGeometryColumn + `,
+			md5(ST_AsBinary(geom)) as geometry_hash,
+			` + parcelAcresExpression + ` as acres`
+
+// HistoryRepository defines the interface for parcel version data access operations.
+type HistoryRepository interface {
+	// FindHistory returns up to limit versions of the parcel with the given id,
+	// newest first: the current version from tax_parcels, then the prior versions
+	// recorded in parcel_versions. Soft-deleted parcels have a history too; their
+	// current version is Deleted.
+	// Returns nil, nil if no parcel is found (not an error).
+	FindHistory(ctx context.Context, parcelID uint, limit int) ([]*models.ParcelVersion, error)
+
+	// FindByIDAsOf returns the parcel with the given id as it was at the given
+	// time: the version valid then, live or prior.
+	// Returns nil, nil if the parcel did not exist or was soft-deleted at that time.
+	FindByIDAsOf(ctx context.Context, parcelID uint, at time.Time) (*ParcelAsOf, error)
+
+	// FindByPointAsOf returns the parcel whose boundary contained the given point
+	// at the given time, as it was then. It is limited to the county set with
+	// WithCounty.
+	// Returns nil, nil if no parcel contained the point at that time.
+	FindByPointAsOf(ctx context.Context, point models.LatLng, at time.Time) (*ParcelAsOf, error)
+}
+
+// ParcelAsOf is a parcel as it was at a past time, read from the version valid
+// then. ValidTo is nil when that version is still the current one.
+// Only versioned columns have past values: the waterfront and derived
+// attributes of the Parcel are nil, and UpdatedAt is ValidFrom.
+type ParcelAsOf struct {
+	ValidFrom time.Time
+	ValidTo   *time.Time
+	Parcel    models.TaxParcel
+}
+
+// historyRepository is the concrete implementation of HistoryRepository.
+type historyRepository struct {
+	db      *database.Database
+	keyring *encryption.Keyring
+}
+
+// NewHistoryRepository creates a new instance of HistoryRepository. keyring
+// decrypts owner_address for contexts created by WithContactAccess.
+func NewHistoryRepository(db *database.Database, keyring *encryption.Keyring) HistoryRepository {
+	return &historyRepository{
+		db:      db,
+		keyring: keyring,
+	}
+}
+
+// FindHistory reads the current and prior versions in one statement, so a
+// concurrent ingest cannot record a version between the two reads. The current
+// version is valid from the parcel's last change; an empty result means the
+// parcel does not exist, since a parcel always has a current version.
+func (r *historyRepository) FindHistory(ctx context.Context, parcelID uint, limit int) ([]*models.ParcelVersion, error) {
+	columns := versionColumns
+	if skip, _ := ctx.Value(skipGeometryKey{}).(bool); skip {
+		columns = versionColumnsWithoutGeometry
+	}
+	query := `
+		SELECT 0::bigint as id, id as parcel_id,` + columns + `,
+			deleted_at IS NOT NULL as deleted,
+			COALESCE(updated_at, created_at) as valid_from,
+			NULL::timestamp as valid_to
+		FROM tax_parcels
+		WHERE id = $1
+		UNION ALL
+		SELECT id, parcel_id,` + columns + `,
+			deleted,
+			valid_from,
+			valid_to
+		FROM parcel_versions
+		WHERE parcel_id = $1
+		ORDER BY valid_to DESC NULLS FIRST, id DESC
+		LIMIT $2`
+
+	var versions []*models.ParcelVersion
+	err := r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		rows, err := q.Query(ctx, query, parcelID, limit)
+		if err != nil {
+			return fmt.Errorf("failed to query parcel history (parcel=%d): %w", parcelID, err)
+		}
+		defer rows.Close()
+
+		versions = nil
+
+		for rows.Next() {
+			var version models.ParcelVersion
+			var geomJSON []byte
+			if err := rows.Scan(r.versionScanTargets(ctx, &version, &geomJSON)...); err != nil {
+				return fmt.Errorf("failed to scan parcel version row: %w", err)
+			}
+
+			// Parse GeoJSON geometry; it is NULL when skipped
+			if err := version.Geom.Scan(geomJSON); err != nil {
+				return fmt.Errorf("failed to parse geometry for version %d of parcel %d: %w", version.ID, parcelID, err)
+			}
+
+			versions = append(versions, &version)
+		}
+
+		// Check for errors during iteration
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating parcel version rows: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(versions) == 0 {
+		return nil, nil
+	}
+	return versions, nil
+}
+
+// versionColumnsWithoutGeometry is versionColumns with the GeoJSON replaced by NULL.
+var versionColumnsWithoutGeometry = strings.Replace(versionColumns,
+	parcelGeometryColumn, "NULL::text as geometry", 1)
+
+// versionScanTargets returns the scan destinations of the history query: the
+// id and parcel id, versionColumns, and the validity of the version.
+// Contact columns are decrypted only for contexts created by WithContactAccess.
+func (r *historyRepository) versionScanTargets(ctx context.Context, version *models.ParcelVersion, geomJSON *[]byte) []interface{} {
+	return []interface{}{
+		&version.ID,
+		&version.ParcelID,
+		&version.PIN,
+		&version.PID,
+		&version.StateCd,
+		&version.Block,
+		&version.Lot,
+		&version.Tract,
+		&version.OwnerName,
+		&contactColumn{ctx: ctx, keyring: r.keyring, target: &version.OwnerAddress, reveal: hasContactAccess(ctx)},
+		&version.Situs,
+		&version.AsCode,
+		&version.LegalDescription,
+		&version.ImprvActualYearBuilt,
+		&version.ImprvMainArea,
+		&version.PYear,
+		&version.PVersion,
+		&version.PRollCorr,
+		&version.TaxingUnits,
+		&version.Exemptions,
+		&version.MarketArea,
+		geomJSON,
+		&version.GeometryHash,
+		&version.Acres,
+		&version.Deleted,
+		&version.ValidFrom,
+		&version.ValidTo,
+	}
+}
+
+// asOfColumns are the versioned columns read by the as-of queries, in the order
+// of asOfScanTargets; both tables have them.
+var asOfColumns = []string{
+	"pin", "pid", "state_cd", "block", "lot", "tract", "owner_name", "owner_address",
+	"situs", "as_code", "legal_description", "imprv_actual_year_built", "imprv_main_area",
+	"market_area", "p_year", "p_version", "p_roll_corr", "taxing_units", "exemptions",
+}
+
+// asOfQuery returns the query of the version of each parcel valid at parameter
+// $1, live or prior, limited by the conditions on each table. Soft-deleted
+// versions are excluded: the parcel did not exist for queries at that time. A
+// version is valid from valid_from, inclusive, to valid_to, exclusive.
+// The geometry, acres, centroid and bbox are computed on the version's boundary.
+func asOfQuery(ctx context.Context, parcelCondition, versionCondition string) string {
+	versionColumns := make([]string, len(asOfColumns))
+	for i, column := range asOfColumns {
+		versionColumns[i] = "v." + column
+	}
+
+	return `
+		SELECT parcel_id, object_id, ` + strings.Join(asOfColumns, ", ") + `,
+			county_id, county_name,
+			` + geometryColumnFor(ctx) + `,
+			` + parcelAcresExpression + ` as acres,
+			ST_Y(ST_PointOnSurface(geom)) as centroid_lat,
+			ST_X(ST_PointOnSurface(geom)) as centroid_lng,
+			CASE WHEN NOT ST_IsEmpty(geom)
+				THEN ARRAY[ST_XMin(geom), ST_YMin(geom), ST_XMax(geom), ST_YMax(geom)] END as bbox,
+			created_at, valid_from, valid_to
+		FROM (
+			SELECT id as parcel_id, object_id, ` + strings.Join(asOfColumns, ", ") + `, geom,
+				county_id, county_name, created_at,
+				deleted_at IS NOT NULL as deleted,
+				COALESCE(updated_at, created_at) as valid_from,
+				NULL::timestamp as valid_to
+			FROM tax_parcels
+			WHERE COALESCE(updated_at, created_at) <= $1` + parcelCondition + `
+			UNION ALL
+			SELECT v.parcel_id, p.object_id, ` + strings.Join(versionColumns, ", ") + `, v.geom,
+				p.county_id, p.county_name, p.created_at,
+				v.deleted, v.valid_from, v.valid_to
+			FROM parcel_versions v
+			JOIN tax_parcels p ON p.id = v.parcel_id
+			WHERE v.valid_from <= $1 AND v.valid_to > $1` + versionCondition + `
+		) AS versions
+		WHERE NOT deleted
+		ORDER BY parcel_id
+		LIMIT 1`
+}
+
+// FindByIDAsOf reads the version of the parcel valid at the given time.
+func (r *historyRepository) FindByIDAsOf(ctx context.Context, parcelID uint, at time.Time) (*ParcelAsOf, error) {
+	query := asOfQuery(ctx, `
+				AND id = $2`, `
+				AND v.parcel_id = $2`)
+
+	parcel, err := r.findAsOf(ctx, query, at, parcelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query parcel as of %s (parcel=%d): %w", at.Format(time.DateOnly), parcelID, err)
+	}
+	return parcel, nil
+}
+
+// FindByPointAsOf searches the boundaries of the live and prior versions with
+// their spatial indexes; the county of a version is its parcel's.
+//
+// Note: PostGIS functions expect (longitude, latitude) order, see models.LatLng.ToPostGISOrder.
+func (r *historyRepository) FindByPointAsOf(ctx context.Context, point models.LatLng, at time.Time) (*ParcelAsOf, error) {
+	query := asOfQuery(ctx, `
+				AND ST_Contains(geom, ST_SetSRID(ST_MakePoint($2, $3), 4326))`+countyClause(4), `
+				AND ST_Contains(v.geom, ST_SetSRID(ST_MakePoint($2, $3), 4326))`+countyClause(4))
+
+	x, y := point.ToPostGISOrder()
+	parcel, err := r.findAsOf(ctx, query, at, x, y, countyParam(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query parcel at point as of %s (lat=%f, lng=%f): %w", at.Format(time.DateOnly), point.Lat, point.Lng, err)
+	}
+	return parcel, nil
+}
+
+// findAsOf runs an asOfQuery with the time and the arguments of its conditions.
+func (r *historyRepository) findAsOf(ctx context.Context, query string, at time.Time, args ...any) (*ParcelAsOf, error) {
+	var result ParcelAsOf
+	var geomJSON []byte
+	err := r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		return q.QueryRow(ctx, query, append([]any{at}, args...)...).Scan(r.asOfScanTargets(ctx, &result, &geomJSON)...)
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse GeoJSON geometry; it is NULL when skipped
+	if err := result.Parcel.Geom.Scan(geomJSON); err != nil {
+		return nil, fmt.Errorf("failed to parse geometry for parcel %d: %w", result.Parcel.ID, err)
+	}
+	result.Parcel.UpdatedAt = result.ValidFrom
+
+	return &result, nil
+}
+
+// asOfScanTargets returns the scan destinations of asOfQuery.
+// Contact columns are decrypted only for contexts created by WithContactAccess.
+func (r *historyRepository) asOfScanTargets(ctx context.Context, result *ParcelAsOf, geomJSON *[]byte) []interface{} {
+	parcel := &result.Parcel
+	return []interface{}{
+		&parcel.ID,
+		&parcel.ObjectID,
+		&parcel.PIN,
+		&parcel.PID,
+		&parcel.StateCd,
+		&parcel.Block,
+		&parcel.Lot,
+		&parcel.Tract,
+		&parcel.OwnerName,
+		&contactColumn{ctx: ctx, keyring: r.keyring, target: &parcel.OwnerAddress, reveal: hasContactAccess(ctx)},
+		&parcel.Situs,
+		&parcel.AsCode,
+		&parcel.LegalDescription,
+		&parcel.ImprvActualYearBuilt,
+		&parcel.ImprvMainArea,
+		&parcel.MarketArea,
+		&parcel.PYear,
+		&parcel.PVersion,
+		&parcel.PRollCorr,
+		&parcel.TaxingUnits,
+		&parcel.Exemptions,
+		&parcel.CountyID,
+		&parcel.CountyName,
+		geomJSON,
+		&parcel.Acres,
+		&parcel.CentroidLat,
+		&parcel.CentroidLng,
+		&parcel.BBox,
+		&parcel.CreatedAt,
+		&result.ValidFrom,
+		&result.ValidTo,
+	}
+}
@@ -0,0 +1,297 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// LayerRepository defines the interface for generic spatial layer data access operations.
+type LayerRepository interface {
+	// List returns the registered layers ordered by name, with their feature
+	// counts across counties.
+	List(ctx context.Context) ([]models.Layer, error)
+
+	// FindByName finds the layer with the given name.
+	// Returns nil if no such layer is registered (not an error).
+	FindByName(ctx context.Context, name string) (*models.Layer, error)
+
+	// FindAtPoint finds the features of the layer intersecting the given point,
+	// or within radiusMeters of it when radiusMeters is positive, ordered by ID.
+	// It is limited to the county set with WithCounty.
+	// Returns an empty slice if no feature matches (not an error).
+	FindAtPoint(ctx context.Context, layerID uint, point models.LatLng, radiusMeters float64) ([]models.LayerFeature, error)
+
+	// FindInBBox finds up to limit features of the layer intersecting the
+	// bounding box, ordered by ID. It is limited to the county set with WithCounty.
+	// Returns an empty slice if no feature matches (not an error).
+	FindInBBox(ctx context.Context, layerID uint, bbox models.BoundingBox, limit int) ([]models.LayerFeature, error)
+
+	// FindOverlayAtPoint finds the features of the overlay containing the given
+	// point whose properties include those of filter, ordered by the overlay's
+	// OrderBy, without geometries. It is limited to the county set with WithCounty.
+	// Returns an empty slice if the point is in no feature or the layer is not
+	// loaded (not an error).
+	FindOverlayAtPoint(ctx context.Context, overlay Overlay, point models.LatLng, filter map[string]any) ([]models.LayerFeature, error)
+
+	// FindOverlayForParcels finds the features of the overlay matched to each of
+	// the live parcels with the given ids, largest coverage first, then by the
+	// overlay's OrderBy, keyed by parcel ID.
+	// Parcels that do not exist or match no feature are omitted (not an error).
+	FindOverlayForParcels(ctx context.Context, overlay Overlay, ids []uint) (map[uint][]ParcelOverlay, error)
+}
+
+// layerRepository is the concrete implementation of LayerRepository.
+type layerRepository struct {
+	db *database.Database
+}
+
+// NewLayerRepository creates a new instance of LayerRepository.
+func NewLayerRepository(db *database.Database) LayerRepository {
+	return &layerRepository{
+		db: db,
+	}
+}
+
+// layerColumns are the layers columns scanned by scanLayer.
+const layerColumns = `l.id, l.name, l.title, l.description, l.updated_at,
+	(SELECT COUNT(*) FROM layer_features f WHERE f.layer_id = l.id)`
+
+// List reads the whole registry; it holds one row per dataset.
+func (r *layerRepository) List(ctx context.Context) ([]models.Layer, error) {
+	query := `SELECT ` + layerColumns + ` FROM layers l ORDER BY l.name`
+
+	layers := []models.Layer{}
+	err := r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		rows, err := q.Query(ctx, query)
+		if err != nil {
+			return fmt.Errorf("failed to query layers: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			layer, err := scanLayer(rows)
+			if err != nil {
+				return fmt.Errorf("failed to scan layer row: %w", err)
+			}
+			layers = append(layers, *layer)
+		}
+
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating layer rows: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return layers, nil
+}
+
+// FindByName looks the layer up by its unique name.
+func (r *layerRepository) FindByName(ctx context.Context, name string) (*models.Layer, error) {
+	query := `SELECT ` + layerColumns + ` FROM layers l WHERE l.name = $1`
+
+	var layer *models.Layer
+	err := r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		var err error
+		layer, err = scanLayer(q.QueryRow(ctx, query, name))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get layer %s: %w", name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return layer, nil
+}
+
+// FindAtPoint uses the GIST index of layer_features for exact hits and, like
+// the parcel nearby search, geography casting for distances in meters.
+func (r *layerRepository) FindAtPoint(ctx context.Context, layerID uint, point models.LatLng, radiusMeters float64) ([]models.LayerFeature, error) {
+	match := `ST_Intersects(f.geom, ST_SetSRID(ST_MakePoint($2, $3), 4326))`
+	if radiusMeters > 0 {
+		match = `ST_DWithin(f.geom::geography, ST_SetSRID(ST_MakePoint($2, $3), 4326)::geography, $5)`
+	}
+	query := `
+		SELECT f.id, f.properties, ST_AsGeoJSON(f.geom), c.name
+		FROM layer_features f
+		JOIN counties c ON c.id = f.county_id
+		WHERE f.layer_id = $1
+			AND ` + match + `
+			AND ($4::text IS NULL OR c.slug = $4)
+		ORDER BY f.id
+	`
+
+	x, y := point.ToPostGISOrder()
+	args := []any{layerID, x, y, countyParam(ctx)}
+	if radiusMeters > 0 {
+		args = append(args, radiusMeters)
+	}
+	features, err := r.findFeatures(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query layer features at point (lat=%f, lng=%f): %w", point.Lat, point.Lng, err)
+	}
+	return features, nil
+}
+
+// FindInBBox runs an index-assisted intersection with the box envelope.
+func (r *layerRepository) FindInBBox(ctx context.Context, layerID uint, bbox models.BoundingBox, limit int) ([]models.LayerFeature, error) {
+	query := `
+		SELECT f.id, f.properties, ST_AsGeoJSON(f.geom), c.name
+		FROM layer_features f
+		JOIN counties c ON c.id = f.county_id
+		WHERE f.layer_id = $1
+			AND ST_Intersects(f.geom, ST_MakeEnvelope($2, $3, $4, $5, 4326))
+			AND ($6::text IS NULL OR c.slug = $6)
+		ORDER BY f.id
+		LIMIT $7
+	`
+
+	args := []any{layerID, bbox.MinLng, bbox.MinLat, bbox.MaxLng, bbox.MaxLat, countyParam(ctx), limit}
+	features, err := r.findFeatures(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query layer features in bbox: %w", err)
+	}
+	return features, nil
+}
+
+// FindOverlayAtPoint runs a point-in-polygon query on the GIST index of layer_features.
+func (r *layerRepository) FindOverlayAtPoint(ctx context.Context, overlay Overlay, point models.LatLng, filter map[string]any) ([]models.LayerFeature, error) {
+	if filter == nil {
+		filter = map[string]any{}
+	}
+	encoded, err := json.Marshal(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s filter: %w", overlay.Layer, err)
+	}
+
+	x, y := point.ToPostGISOrder()
+	features := []models.LayerFeature{}
+	err = r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		rows, err := q.Query(ctx, overlay.atPointQuery(), overlay.Layer, x, y, string(encoded), countyParam(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to query %s at point (lat=%f, lng=%f): %w", overlay.Layer, point.Lat, point.Lng, err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var feature models.LayerFeature
+			if err := rows.Scan(&feature.ID, &feature.Properties, &feature.CountyName); err != nil {
+				return fmt.Errorf("failed to scan %s feature row: %w", overlay.Layer, err)
+			}
+			features = append(features, feature)
+		}
+
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating %s feature rows: %w", overlay.Layer, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return features, nil
+}
+
+// FindOverlayForParcels joins the parcels to the overlay's features, see
+// Overlay.parcelsQuery.
+func (r *layerRepository) FindOverlayForParcels(ctx context.Context, overlay Overlay, ids []uint) (map[uint][]ParcelOverlay, error) {
+	// pgx encodes int64 slices as bigint[] which matches the id column
+	idParams := make([]int64, len(ids))
+	for i, id := range ids {
+		idParams[i] = int64(id)
+	}
+	args := []any{idParams, overlay.Layer}
+	if !overlay.SurfacePoint {
+		args = append(args, overlay.MinCoverage)
+	}
+
+	var results map[uint][]ParcelOverlay
+	err := r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		rows, err := q.Query(ctx, overlay.parcelsQuery(), args...)
+		if err != nil {
+			return fmt.Errorf("failed to query parcel %s (ids=%v): %w", overlay.Layer, ids, err)
+		}
+		defer rows.Close()
+
+		results = make(map[uint][]ParcelOverlay, len(ids))
+
+		for rows.Next() {
+			var parcelID uint
+			var match ParcelOverlay
+
+			err := rows.Scan(&parcelID, &match.Feature.ID, &match.Feature.Properties, &match.Feature.CountyName, &match.Coverage)
+			if err != nil {
+				return fmt.Errorf("failed to scan parcel %s row: %w", overlay.Layer, err)
+			}
+
+			// Rounding can leave a parcel inside one feature a hair above all of it
+			match.Coverage = min(match.Coverage, 1)
+			results[parcelID] = append(results[parcelID], match)
+		}
+
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating parcel %s rows: %w", overlay.Layer, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// findFeatures runs a query selecting id, properties, geometry and county name.
+func (r *layerRepository) findFeatures(ctx context.Context, query string, args []any) ([]models.LayerFeature, error) {
+	features := []models.LayerFeature{}
+	err := r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		rows, err := q.Query(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var feature models.LayerFeature
+			var geometry string
+			if err := rows.Scan(&feature.ID, &feature.Properties, &geometry, &feature.CountyName); err != nil {
+				return fmt.Errorf("failed to scan layer feature row: %w", err)
+			}
+			feature.Geometry = []byte(geometry)
+			features = append(features, feature)
+		}
+
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating layer feature rows: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return features, nil
+}
+
+// scanLayer scans a row of layerColumns.
+func scanLayer(row pgx.Row) (*models.Layer, error) {
+	var layer models.Layer
+	if err := row.Scan(&layer.ID, &layer.Name, &layer.Title, &layer.Description, &layer.UpdatedAt, &layer.FeatureCount); err != nil {
+		return nil, err
+	}
+	return &layer, nil
+}
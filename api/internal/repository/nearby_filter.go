@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NearbyFilter narrows FindNearby results by parcel attributes.
+// Nil and empty fields are not applied, so the zero value matches every parcel.
+// Ranges are inclusive.
+type NearbyFilter struct {
+	MinAcres     *float64
+	MaxAcres     *float64
+	MinYearBuilt *int
+	MaxYearBuilt *int
+	// AmenityWithinMeters is how close an amenity of category Amenity must be,
+	// measured from the parcel boundary; both are set or neither
+	AmenityWithinMeters *float64
+	// Waterfront matches parcels with (true) or without (false) water frontage;
+	// parcels of counties without a hydrology layer match neither
+	Waterfront *bool
+	// Attributes match derived attributes; all of them must match
+	Attributes []AttributeFilter
+	// LandUse matches the land use code (as_code, the land_use response field) case-insensitively
+	LandUse string
+	// OwnerName matches owner names containing it, case-insensitively
+	OwnerName string
+	// Amenity is the category of amenity (see models.AmenityCategories) that must
+	// be within AmenityWithinMeters; amenities of any county count
+	Amenity string
+}
+
+// IsEmpty reports whether the filter applies no conditions.
+func (f NearbyFilter) IsEmpty() bool {
+	return f.MinAcres == nil && f.MaxAcres == nil &&
+		f.MinYearBuilt == nil && f.MaxYearBuilt == nil &&
+		f.Waterfront == nil && f.LandUse == "" && f.OwnerName == "" &&
+		f.Amenity == "" && f.AmenityWithinMeters == nil && len(f.Attributes) == 0
+}
+
+// nearbyFilterParams is the number of placeholders in nearbyFilterClause.
+const nearbyFilterParams = 9
+
+// nearbyFilterClause returns the SQL conditions for a NearbyFilter, numbering its
+// parameters from first. Every condition is disabled by a NULL parameter, so the
+// statement text is the same for all filters and the values come from params.
+// Parcels with a NULL column never match a condition on that column. The
+// statement must select from tax_parcels without an alias. Attributes are not
+// part of the clause, see parcelRepository.attributeFilterClause.
+func nearbyFilterClause(first int) string {
+	return fmt.Sprintf(`
+			AND ($%[1]d::text IS NULL OR upper(as_code) = upper($%[1]d))
+			AND ($%[2]d::float8 IS NULL OR `+parcelAcresExpression+` >= $%[2]d)
+			AND ($%[3]d::float8 IS NULL OR `+parcelAcresExpression+` <= $%[3]d)
+			AND ($%[4]d::int IS NULL OR imprv_actual_year_built >= $%[4]d)
+			AND ($%[5]d::int IS NULL OR imprv_actual_year_built <= $%[5]d)
+			AND ($%[6]d::text IS NULL OR owner_name ILIKE $%[6]d)
+			AND ($%[7]d::bool IS NULL OR (water_frontage_meters > 0) = $%[7]d)
+			AND ($%[8]d::text IS NULL OR EXISTS (
+				SELECT 1 FROM amenities a
+				WHERE a.category = $%[8]d
+					AND ST_DWithin(a.geom::geography, tax_parcels.geom::geography, $%[9]d::float8)
+			))`,
+		first, first+1, first+2, first+3, first+4, first+5, first+6, first+7, first+8)
+}
+
+// params returns the values for the placeholders in nearbyFilterClause, in order.
+func (f NearbyFilter) params() []any {
+	var landUse, ownerPattern, amenity *string
+	if f.LandUse != "" {
+		landUse = &f.LandUse
+	}
+	if f.OwnerName != "" {
+		pattern := containsPattern(f.OwnerName)
+		ownerPattern = &pattern
+	}
+	if f.Amenity != "" {
+		amenity = &f.Amenity
+	}
+	return []any{landUse, f.MinAcres, f.MaxAcres, f.MinYearBuilt, f.MaxYearBuilt, ownerPattern, f.Waterfront,
+		amenity, f.AmenityWithinMeters}
+}
+
+// likeEscaper escapes the LIKE wildcards and PostgreSQL's default escape character.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern returns a LIKE pattern matching values that contain s literally.
+func containsPattern(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
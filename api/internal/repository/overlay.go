@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// ZoningSliverCoverage is the share of a parcel a zoning district must cover to
+// count as the parcel's zoning. Zoning and parcel layers are digitized
+// separately, so a district ending at a parcel line usually overlaps it by a
+// thin sliver.
+const ZoningSliverCoverage = 0.01
+
+// FloodSliverCoverage is the share of a parcel a flood zone must cover to be
+// reported for the parcel, for the same reason as ZoningSliverCoverage.
+const FloodSliverCoverage = 0.01
+
+// Overlay is a polygon layer parcels are matched to per request, one of
+// models.OverlayLayers. A feature is matched to the parcels it covers at least
+// MinCoverage of or, with SurfacePoint, to those with a point on their surface
+// inside it. Features of any county count, since overlays cross county lines.
+type Overlay struct {
+	// Layer is the name of the layer
+	Layer string
+	// OrderBy are the property keys features are ordered by, after the largest
+	// coverage for parcels; missing properties sort first
+	OrderBy []string
+	// MinCoverage is the share of a parcel's area a feature must cover
+	MinCoverage float64
+	// SurfacePoint matches features by a point on the parcel's surface, for
+	// layers where a parcel belongs to one feature but the boundaries rarely
+	// follow parcel lines. Of features with the same properties loaded by
+	// several counties, that of the parcel's county is kept
+	SurfacePoint bool
+	// Merge combines the features with the same properties covering a parcel,
+	// for layers split along arbitrary lines; merged features have no ID or county
+	Merge bool
+}
+
+// The overlays of models.OverlayLayers.
+var (
+	// ZoningOverlay matches parcels to the zoning districts covering them, base
+	// and overlay districts alike
+	ZoningOverlay = Overlay{Layer: models.LayerZoning, OrderBy: []string{"code"}, MinCoverage: ZoningSliverCoverage}
+	// FloodZoneOverlay matches parcels to the flood zones covering them; NFHL
+	// areas are split along panel lines, so the areas of a zone are merged
+	FloodZoneOverlay = Overlay{Layer: models.LayerFloodZones, OrderBy: []string{"zone", "subtype"},
+		MinCoverage: FloodSliverCoverage, Merge: true}
+	// JurisdictionOverlay matches parcels to their school district and taxing
+	// units; a parcel belongs to one school district
+	JurisdictionOverlay = Overlay{Layer: models.LayerJurisdictions, OrderBy: []string{"kind", "name"}, SurfacePoint: true}
+)
+
+// ParcelOverlay is an overlay feature matched to a parcel. Coverage is the
+// share of the parcel's area in the feature, between the overlay's MinCoverage
+// and 1; it is not measured (0) for SurfacePoint overlays.
+type ParcelOverlay struct {
+	Feature  models.LayerFeature
+	Coverage float64
+}
+
+// orderClause returns the ORDER BY terms of the overlay's properties in column.
+// Keys are code constants, never request input.
+func (o Overlay) orderClause(column string) string {
+	terms := make([]string, 0, len(o.OrderBy))
+	for _, key := range o.OrderBy {
+		terms = append(terms, column+"->>'"+key+"' NULLS FIRST")
+	}
+	return strings.Join(terms, ", ")
+}
+
+// atPointQuery returns the statement of FindOverlayAtPoint, with parameters
+// layer name, x, y, property filter and county.
+func (o Overlay) atPointQuery() string {
+	return `
+		SELECT f.id, f.properties, c.name
+		FROM layer_features f
+		JOIN layers l ON l.id = f.layer_id
+		JOIN counties c ON c.id = f.county_id
+		WHERE l.name = $1
+			AND ST_Contains(f.geom, ST_SetSRID(ST_MakePoint($2, $3), 4326))
+			AND f.properties @> $4::jsonb
+			AND ($5::text IS NULL OR c.slug = $5)
+		ORDER BY ` + o.orderClause("f.properties") + `, f.id
+	`
+}
+
+// parcelsQuery returns the statement of FindOverlayForParcels, with parameters
+// parcel ids, layer name and, unless SurfacePoint, MinCoverage. Coverage is
+// measured on the geography, so it is a share of true area; parcels without
+// area are covered entirely by the features containing them.
+func (o Overlay) parcelsQuery() string {
+	if o.SurfacePoint {
+		return `
+		SELECT parcel_id, id, properties, county_name, 0::float8
+		FROM (
+			SELECT DISTINCT ON (p.id, f.properties)
+				p.id AS parcel_id, f.id, f.properties, c.name AS county_name
+			FROM tax_parcels p
+			JOIN layer_features f ON ST_Contains(f.geom, ST_PointOnSurface(p.geom))
+			JOIN layers l ON l.id = f.layer_id
+			JOIN counties c ON c.id = f.county_id
+			WHERE l.name = $2 AND p.id = ANY($1)` + liveParcelClause + `
+			ORDER BY p.id, f.properties, f.county_id = p.county_id DESC, f.id
+		) matches
+		ORDER BY parcel_id, ` + o.orderClause("properties") + `, id
+	`
+	}
+
+	feature := `f.id, c.name AS county_name,
+				COALESCE(
+					ST_Area(ST_Intersection(p.geom, f.geom)::geography) / NULLIF(ST_Area(p.geom::geography), 0),
+					1
+				) AS coverage`
+	joinCounty, group := `
+			JOIN counties c ON c.id = f.county_id`, ""
+	if o.Merge {
+		feature = `0 AS id, '' AS county_name,
+				COALESCE(
+					ST_Area(ST_Union(ST_Intersection(p.geom, f.geom))::geography) / NULLIF(ST_Area(p.geom::geography), 0),
+					1
+				) AS coverage`
+		joinCounty, group = "", `
+			GROUP BY p.id, f.properties`
+	}
+	return `
+		SELECT parcel_id, id, properties, county_name, coverage
+		FROM (
+			SELECT p.id AS parcel_id, f.properties, ` + feature + `
+			FROM tax_parcels p
+			JOIN layer_features f ON ST_Intersects(f.geom, p.geom)
+			JOIN layers l ON l.id = f.layer_id` + joinCounty + `
+			WHERE l.name = $2 AND p.id = ANY($1)` + liveParcelClause + group + `
+		) overlaps
+		WHERE coverage >= $3
+		ORDER BY parcel_id, coverage DESC, ` + o.orderClause("properties") + `, id
+	`
+}
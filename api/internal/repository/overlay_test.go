@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverlay_OrderClause(t *testing.T) {
+	assert.Equal(t, "f.properties->>'code' NULLS FIRST", ZoningOverlay.orderClause("f.properties"))
+	assert.Equal(t, "properties->>'kind' NULLS FIRST, properties->>'name' NULLS FIRST", JurisdictionOverlay.orderClause("properties"))
+}
+
+func TestOverlay_AtPointQuery(t *testing.T) {
+	query := FloodZoneOverlay.atPointQuery()
+
+	assert.Contains(t, query, "f.properties @> $4::jsonb")
+	assert.Contains(t, query, "ORDER BY f.properties->>'zone' NULLS FIRST, f.properties->>'subtype' NULLS FIRST, f.id")
+}
+
+func TestOverlay_ParcelsQuery(t *testing.T) {
+	tests := map[string]struct {
+		overlay Overlay
+		want    []string
+		notWant []string
+	}{
+		"coverage": {
+			overlay: ZoningOverlay,
+			want:    []string{"ST_Intersection(p.geom, f.geom)", "coverage >= $3", "JOIN counties c"},
+			notWant: []string{"ST_Union", "GROUP BY", "ST_PointOnSurface"},
+		},
+		"merged coverage": {
+			overlay: FloodZoneOverlay,
+			want:    []string{"ST_Union(ST_Intersection(p.geom, f.geom))", "GROUP BY p.id, f.properties", "coverage >= $3"},
+			notWant: []string{"JOIN counties c", "ST_PointOnSurface"},
+		},
+		"surface point": {
+			overlay: JurisdictionOverlay,
+			want:    []string{"ST_PointOnSurface(p.geom)", "DISTINCT ON (p.id, f.properties)", "f.county_id = p.county_id DESC"},
+			notWant: []string{"$3", "ST_Intersection"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			query := tc.overlay.parcelsQuery()
+			assert.Contains(t, query, liveParcelClause)
+			for _, fragment := range tc.want {
+				assert.Contains(t, query, fragment)
+			}
+			for _, fragment := range tc.notWant {
+				assert.NotContains(t, query, fragment)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// OwnerRepository defines the interface for owner entity data access operations.
+// The parcels of an owner are read with ParcelRepository.FindByOwner.
+type OwnerRepository interface {
+	// FindByID returns the owner with the given id and the spellings of its name.
+	// Returns nil, nil if no owner has the id (not an error).
+	FindByID(ctx context.Context, id uint) (*models.Owner, error)
+}
+
+// ownerRepository is the concrete implementation of OwnerRepository.
+type ownerRepository struct {
+	db *database.Database
+}
+
+// NewOwnerRepository creates a new instance of OwnerRepository.
+func NewOwnerRepository(db *database.Database) OwnerRepository {
+	return &ownerRepository{
+		db: db,
+	}
+}
+
+// FindByID aggregates the owner's names in one row.
+func (r *ownerRepository) FindByID(ctx context.Context, id uint) (*models.Owner, error) {
+	query := `
+		SELECT o.id, o.name,
+			COALESCE(array_agg(n.name ORDER BY n.name) FILTER (WHERE n.name IS NOT NULL), '{}')
+		FROM owners o
+		LEFT JOIN owner_names n ON n.owner_id = o.id
+		WHERE o.id = $1
+		GROUP BY o.id
+	`
+
+	var owner *models.Owner
+	err := r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		owner = &models.Owner{}
+		err := q.QueryRow(ctx, query, id).Scan(&owner.ID, &owner.Name, &owner.Names)
+		if errors.Is(err, pgx.ErrNoRows) {
+			owner = nil
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to query owner (id=%d): %w", id, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return owner, nil
+}
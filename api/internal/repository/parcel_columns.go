@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/stwalsh4118/atlas/api/internal/encryption"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/projection"
+)
+
+// parcelColumns is the column list selected for a full TaxParcel row.
+// The geometry is returned as GeoJSON so it can be parsed by models.MultiPolygon.
+// Acres are computed on the geography type (divided by SquareMetersPerAcre) so they are
+// in real-world units, and the centroid uses ST_PointOnSurface so it always falls inside
+// the parcel and can anchor a label. Both use the full-resolution geometry.
+// Derived attributes are NULL until the repository substitutes them (see withAttributes).
+// owner_id is the owner entity the owner name was resolved to by the ingest CLI, if any.
+// bbox is the envelope stored in the generated bbox_* columns, NULL for empty geometries.
+// Queries that select extra columns (distance, score, ...) append them after this list.
+var parcelColumns = `
+			id,
+			object_id,
+			pin,
+			pid,
+			state_cd,
+			block,
+			lot,
+			tract,
+			owner_name,
+			owner_address,
+			(SELECT owner_id FROM owner_names WHERE owner_names.name = owner_name) as owner_id,
+			situs,
+			as_code,
+			legal_description,
+			imprv_actual_year_built,
+			imprv_main_area,
+			market_area,
+			p_year,
+			p_version,
+			p_roll_corr,
+			taxing_units,
+			exemptions,
+			water_frontage_meters,
+			waterfront_type,
+			water_body_name,
+			` + parcelAttributesColumn + `,
+			county_id,
+			county_name,
+			ST_AsGeoJSON(geom) as geometry,
+			` + parcelAcresExpression + ` as acres,
+			ST_Y(ST_PointOnSurface(geom)) as centroid_lat,
+			ST_X(ST_PointOnSurface(geom)) as centroid_lng,
+			` + parcelBBoxColumn + `,
+			created_at,
+			updated_at`
+
+// parcelBBoxColumn selects the parcel envelope as [minLng, minLat, maxLng, maxLat].
+const parcelBBoxColumn = `CASE WHEN bbox_min_lng IS NOT NULL
+				THEN ARRAY[bbox_min_lng, bbox_min_lat, bbox_max_lng, bbox_max_lat] END as bbox`
+
+// liveParcelClause excludes parcels soft-deleted by sync loads; every tax_parcels
+// query appends it to its WHERE clause.
+const liveParcelClause = `
+			AND deleted_at IS NULL`
+
+// parcelAcresExpression computes a parcel's area in acres from its geography area.
+var parcelAcresExpression = "ST_Area(geom::geography) / " + strconv.FormatFloat(SquareMetersPerAcre, 'f', -1, 64)
+
+// parcelColumnsWithoutGeometry is parcelColumns with the geometry replaced by NULL.
+// It keeps the same column order so parcelScanTargets still applies, but skips
+// ST_AsGeoJSON, which dominates query cost and payload size for large parcels.
+var parcelColumnsWithoutGeometry = strings.Replace(parcelColumns,
+	parcelGeometryColumn, "NULL::text as geometry", 1)
+
+// parcelGeometryColumn is the geometry expression in parcelColumns.
+const parcelGeometryColumn = "ST_AsGeoJSON(geom) as geometry"
+
+// skipGeometryKey is the context key set by WithoutGeometry.
+type skipGeometryKey struct{}
+
+// simplifyToleranceKey is the context key set by WithSimplifyTolerance.
+type simplifyToleranceKey struct{}
+
+// outputSRIDKey is the context key set by WithOutputSRID.
+type outputSRIDKey struct{}
+
+// WithoutGeometry returns a context that makes parcel queries skip geometry serialization.
+// Parcels read with this context have an empty Geom. Use it when the caller only needs
+// attributes (e.g. a fields= selection without geometry).
+func WithoutGeometry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipGeometryKey{}, true)
+}
+
+// WithSimplifyTolerance returns a context that makes parcel queries simplify geometries
+// with ST_SimplifyPreserveTopology before serializing them. The tolerance is in degrees
+// (SRID 4326 units); a tolerance of zero or less leaves geometries at full resolution.
+func WithSimplifyTolerance(ctx context.Context, tolerance float64) context.Context {
+	return context.WithValue(ctx, simplifyToleranceKey{}, tolerance)
+}
+
+// SimplifyToleranceFromContext returns the tolerance set by WithSimplifyTolerance
+// and whether one was set.
+func SimplifyToleranceFromContext(ctx context.Context) (float64, bool) {
+	tolerance, ok := ctx.Value(simplifyToleranceKey{}).(float64)
+	return tolerance, ok
+}
+
+// WithOutputSRID returns a context that makes parcel queries return geometries
+// transformed to srid, one of projection.SRIDs, for clients that render in a
+// projected CRS such as Web Mercator. Geometries are transformed after any
+// simplification, whose tolerance stays in degrees; centroids stay WGS84.
+func WithOutputSRID(ctx context.Context, srid int) context.Context {
+	return context.WithValue(ctx, outputSRIDKey{}, srid)
+}
+
+// transformedGeometry wraps the geometry expression geom in ST_Transform when
+// the context sets an output SRID other than WGS84.
+func transformedGeometry(ctx context.Context, geom string) string {
+	srid, _ := ctx.Value(outputSRIDKey{}).(int)
+	if srid == 0 || srid == projection.WGS84 {
+		return geom
+	}
+	// The SRID is an int checked by the handler, so it is safe to inline
+	return "ST_Transform(" + geom + ", " + strconv.Itoa(srid) + ")"
+}
+
+// parcelColumnsFor returns the parcel column list for the given context.
+func parcelColumnsFor(ctx context.Context) string {
+	if skip, _ := ctx.Value(skipGeometryKey{}).(bool); skip {
+		return parcelColumnsWithoutGeometry
+	}
+	if column := geometryColumnFor(ctx); column != parcelGeometryColumn {
+		return strings.Replace(parcelColumns, parcelGeometryColumn, column, 1)
+	}
+	return parcelColumns
+}
+
+// geometryColumnFor returns the GeoJSON geometry expression of the geom column
+// for the given context: NULL when the geometry is skipped, simplified when a
+// tolerance is set and transformed when an output SRID is set.
+func geometryColumnFor(ctx context.Context) string {
+	if skip, _ := ctx.Value(skipGeometryKey{}).(bool); skip {
+		return "NULL::text as geometry"
+	}
+	geom := "geom"
+	if tolerance, _ := ctx.Value(simplifyToleranceKey{}).(float64); tolerance > 0 {
+		// The tolerance is a float formatted by strconv, so it is safe to inline
+		geom = "ST_SimplifyPreserveTopology(geom, " + strconv.FormatFloat(tolerance, 'g', -1, 64) + ")"
+	}
+	if geom = transformedGeometry(ctx, geom); geom == "geom" {
+		return parcelGeometryColumn
+	}
+	return "ST_AsGeoJSON(" + geom + ") as geometry"
+}
+
+// parcelColumnsParam is parcelColumnsFor with the simplification tolerance passed
+// as parameter n instead of inlined. pgx prepares and caches each distinct
+// statement text per connection, so inlined tolerances would give the hot
+// point and nearby queries one prepared statement per zoom level and push them
+// out of the cache; with the parameter they keep one text whatever the tolerance.
+// params holds the value of parameter n, none when the geometry is skipped.
+func parcelColumnsParam(ctx context.Context, n int) (columns string, params []any) {
+	if skip, _ := ctx.Value(skipGeometryKey{}).(bool); skip {
+		return parcelColumnsWithoutGeometry, nil
+	}
+	tolerance, _ := ctx.Value(simplifyToleranceKey{}).(float64)
+	simplified := transformedGeometry(ctx, fmt.Sprintf("CASE WHEN $%[1]d::float8 > 0 "+
+		"THEN ST_SimplifyPreserveTopology(geom, $%[1]d::float8) ELSE geom END", n))
+	simplified = "ST_AsGeoJSON(" + simplified + ") as geometry"
+	return strings.Replace(parcelColumns, parcelGeometryColumn, simplified, 1), []any{tolerance}
+}
+
+// contactAccessKey is the context key set by WithContactAccess.
+type contactAccessKey struct{}
+
+// WithContactAccess returns a context that makes parcel queries decrypt owner
+// contact columns (see encryptedParcelColumns). Parcels read without it have them
+// nil, so contact data is only decrypted for callers authorized to see it.
+func WithContactAccess(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contactAccessKey{}, true)
+}
+
+// hasContactAccess reports whether the context was created by WithContactAccess.
+func hasContactAccess(ctx context.Context) bool {
+	access, _ := ctx.Value(contactAccessKey{}).(bool)
+	return access
+}
+
+// contactColumn scans an encrypted contact column into target, decrypting it
+// when reveal is set and leaving target nil otherwise.
+type contactColumn struct {
+	ctx     context.Context
+	keyring *encryption.Keyring
+	target  **string
+	reveal  bool
+}
+
+// Scan implements sql.Scanner.
+func (c *contactColumn) Scan(src any) error {
+	*c.target = nil
+	if src == nil || !c.reveal {
+		return nil
+	}
+	value, ok := src.(string)
+	if !ok {
+		return fmt.Errorf("unexpected contact column type %T", src)
+	}
+	plaintext, err := c.keyring.Decrypt(c.ctx, value)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt contact column: %w", err)
+	}
+	*c.target = &plaintext
+	return nil
+}
+
+// parcelScanTargets returns the scan destinations matching the order of parcelColumns.
+// The raw GeoJSON geometry is written to geomJSON and must be parsed by the caller.
+// Contact columns are decrypted only for contexts created by WithContactAccess.
+func (r *parcelRepository) parcelScanTargets(ctx context.Context, parcel *models.TaxParcel, geomJSON *[]byte) []interface{} {
+	reveal := hasContactAccess(ctx)
+
+	return []interface{}{
+		&parcel.ID,
+		&parcel.ObjectID,
+		&parcel.PIN,
+		&parcel.PID,
+		&parcel.StateCd,
+		&parcel.Block,
+		&parcel.Lot,
+		&parcel.Tract,
+		&parcel.OwnerName,
+		&contactColumn{ctx: ctx, keyring: r.keyring, target: &parcel.OwnerAddress, reveal: reveal},
+		&parcel.OwnerID,
+		&parcel.Situs,
+		&parcel.AsCode,
+		&parcel.LegalDescription,
+		&parcel.ImprvActualYearBuilt,
+		&parcel.ImprvMainArea,
+		&parcel.MarketArea,
+		&parcel.PYear,
+		&parcel.PVersion,
+		&parcel.PRollCorr,
+		&parcel.TaxingUnits,
+		&parcel.Exemptions,
+		&parcel.WaterFrontageMeters,
+		&parcel.WaterfrontType,
+		&parcel.WaterBodyName,
+		&parcel.DerivedAttributes,
+		&parcel.CountyID,
+		&parcel.CountyName,
+		geomJSON,
+		&parcel.Acres,
+		&parcel.CentroidLat,
+		&parcel.CentroidLng,
+		&parcel.BBox,
+		&parcel.CreatedAt,
+		&parcel.UpdatedAt,
+	}
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/encryption"
+)
+
+func TestContactColumn_Scan(t *testing.T) {
+	ctx := context.Background()
+	assert.False(t, hasContactAccess(ctx))
+	assert.True(t, hasContactAccess(WithContactAccess(ctx)))
+
+	stale := "stale"
+	target := &stale
+
+	// Hidden without contact access
+	require.NoError(t, (&contactColumn{ctx: ctx, target: &target}).Scan("100 Main St"))
+	assert.Nil(t, target)
+
+	// Plaintext written before encryption was enabled is revealed as stored
+	require.NoError(t, (&contactColumn{ctx: ctx, target: &target, reveal: true}).Scan("100 Main St"))
+	require.NotNil(t, target)
+	assert.Equal(t, "100 Main St", *target)
+
+	require.NoError(t, (&contactColumn{ctx: ctx, target: &target, reveal: true}).Scan(nil))
+	assert.Nil(t, target)
+
+	// Encrypted values need the keyring
+	err := (&contactColumn{ctx: ctx, target: &target, reveal: true}).Scan(encryption.Prefix + "1:abc")
+	assert.ErrorIs(t, err, encryption.ErrNoKeyring)
+}
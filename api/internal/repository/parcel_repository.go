@@ -4,120 +4,294 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stwalsh4118/atlas/api/internal/config"
 	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	"github.com/stwalsh4118/atlas/api/internal/encryption"
 	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/pagination"
 )
 
+// ErrQueryTimeout is returned when a spatial query exceeds the statement timeout.
+var ErrQueryTimeout = domain.Timeout("spatial query exceeded its statement timeout")
+
 // ParcelWithDistance represents a parcel with its distance from a reference point.
 type ParcelWithDistance struct {
 	Parcel   models.TaxParcel
 	Distance float64 // Distance in meters
 }
 
+// ParcelWithScore represents a parcel matched by a text search with its relevance score.
+type ParcelWithScore struct {
+	Parcel models.TaxParcel
+	Score  float64 // Trigram similarity between 0 and 1
+}
+
+// ParcelCandidate represents a parcel that may contain a point reported with limited accuracy.
+type ParcelCandidate struct {
+	Parcel        models.TaxParcel
+	Probability   float64 // Share of the accuracy circle inside the parcel, between 0 and 1
+	ContainsPoint bool    // Whether the parcel contains the reported point itself
+}
+
+// ParcelAlongRoute represents a parcel within a route corridor.
+type ParcelAlongRoute struct {
+	Parcel   models.TaxParcel
+	Distance float64 // Distance from the route in meters, 0 where the route crosses the parcel
+	Station  float64 // Distance along the route to its point closest to the parcel, in meters
+}
+
+// ParcelSummary is a lightweight projection of a parcel without geometry.
+// It is used for hover/identify lookups where transferring the boundary is unnecessary.
+type ParcelSummary struct {
+	OwnerName *string
+	Situs     *string
+	Acres     float64 // Computed from geometry area
+	ID        uint
+	PIN       int
+}
+
+// ParcelWithMetrics represents a parcel with additional geometry measurements computed by PostGIS.
+// Acres and the centroid are on the parcel itself. Err is set when the parcel's
+// row could not be decoded, e.g. a corrupt geometry; only Parcel.ID is then valid.
+type ParcelWithMetrics struct {
+	Err             error
+	Parcel          models.TaxParcel
+	PerimeterMeters float64
+}
+
+// DissolvedArea is the outer boundary of a set of parcels merged into one shape.
+// Outline is empty when none of the parcels exist.
+type DissolvedArea struct {
+	Outline         models.MultiPolygon
+	ParcelIDs       []uint  // Parcels found, ordered by ID
+	Acres           float64 // Computed from the outline, so interior holes count
+	PerimeterMeters float64
+}
+
+// AdjacentParcel is a parcel of an area with the neighbors it shares a boundary with.
+// Neighbors are limited to parcels of the same area and ordered by ID.
+type AdjacentParcel struct {
+	OwnerName *string
+	Neighbors []uint
+	Acres     float64
+	ID        uint
+}
+
+// NearestAmenity is the amenity of one category closest to a parcel.
+type NearestAmenity struct {
+	Name     *string
+	Category string
+	Location models.LatLng
+	Distance float64 // Distance from the parcel boundary in meters, 0 inside the parcel
+	ID       uint
+}
+
 // ParcelRepository defines the interface for parcel data access operations.
+// Every query except FindByIDs, FindNearestAmenities and DissolveByIDs is limited to the county set with WithCounty.
+// Parcels soft-deleted by sync loads are never returned.
 type ParcelRepository interface {
-	// FindByPoint finds the parcel that contains the given lat/lng point.
+	// FindByPoint finds the parcel that contains the given point.
 	// Returns nil, nil if no parcel is found (not an error).
 	// Returns error only for actual database failures.
-	FindByPoint(ctx context.Context, lat, lng float64) (*models.TaxParcel, error)
+	FindByPoint(ctx context.Context, point models.LatLng) (*models.TaxParcel, error)
 
-	// FindNearby finds all parcels within the specified radius of the given point.
+	// FindCandidatesNearPoint finds up to limit parcels intersecting the circle of
+	// accuracyMeters around the point, with the share of the circle each one covers.
+	// Returns an empty slice if no parcels intersect the circle (not an error).
+	// Returns error only for actual database failures.
+	// Results are ordered by probability (most likely first), then ID.
+	FindCandidatesNearPoint(ctx context.Context, point models.LatLng, accuracyMeters float64, limit int) ([]ParcelCandidate, error)
+
+	// FindNearby finds up to limit parcels within the specified radius of the given point
+	// that match the filter, starting after the cursor (nil for the first page). Cursor
+	// keys are distances; later pages must use the same filter.
+	// Also returns the total number of matching parcels within the radius across all pages.
 	// Returns an empty slice if no parcels are found (not an error).
 	// Returns error only for actual database failures.
-	// Results are ordered by distance (closest first).
-	FindNearby(ctx context.Context, lat, lng float64, radiusMeters int) ([]ParcelWithDistance, error)
+	// Results are ordered by distance (closest first), then ID.
+	FindNearby(ctx context.Context, point models.LatLng, radiusMeters, limit int, after *pagination.Cursor, filter NearbyFilter) ([]ParcelWithDistance, int, error)
+
+	// SearchByAddress finds up to limit parcels whose situs address fuzzily matches the query,
+	// starting after the cursor (nil for the first page). Cursor keys are scores.
+	// Also returns the total number of matching parcels across all pages.
+	// Returns an empty slice if no parcels match (not an error).
+	// Returns error only for actual database failures.
+	// Results are ordered by similarity score (best match first), then ID.
+	SearchByAddress(ctx context.Context, address string, limit int, after *pagination.Cursor) ([]ParcelWithScore, int, error)
+
+	// IdentifyByPoint finds a summary of the parcel that contains the given point.
+	// Returns nil, nil if no parcel is found (not an error).
+	// Returns error only for actual database failures.
+	IdentifyByPoint(ctx context.Context, point models.LatLng) (*ParcelSummary, error)
+
+	// IdentifyByPoints finds a summary of the parcel containing each of the points.
+	// The result has one entry per point, in order, which is nil where no parcel
+	// contains the point (not an error).
+	// Returns error only for actual database failures.
+	IdentifyByPoints(ctx context.Context, points []models.LatLng) ([]*ParcelSummary, error)
+
+	// FindByIDs finds the parcels with the given IDs along with their geometry metrics.
+	// IDs that do not exist are omitted from the result (not an error), and a
+	// parcel whose geometry cannot be decoded is returned with Err set, so one
+	// corrupt row does not fail the others.
+	// Returns error only for actual database failures.
+	// Results are ordered by ID; callers needing request order must reorder them.
+	FindByIDs(ctx context.Context, ids []uint) ([]ParcelWithMetrics, error)
+
+	// FindNearestAmenities finds, for each of the parcels with the given IDs, the
+	// nearest amenity of every category, from any county.
+	// Parcels that do not exist and categories without amenities are omitted (not an error).
+	// Returns error only for actual database failures.
+	// Each parcel's amenities are in models.AmenityCategories order.
+	FindNearestAmenities(ctx context.Context, ids []uint) (map[uint][]NearestAmenity, error)
+
+	// DissolveByIDs unions the parcels with the given IDs and keeps only the exterior
+	// ring of each resulting polygon, so gaps enclosed by the parcels are filled.
+	// Parcels that do not touch become separate polygons of the outline.
+	// IDs that do not exist are omitted from ParcelIDs (not an error).
+	// Returns error only for actual database failures.
+	DissolveByIDs(ctx context.Context, ids []uint) (*DissolvedArea, error)
+
+	// ValidateArea checks the area with PostGIS ST_IsValid.
+	// Returns an empty reason if the geometry is valid, otherwise PostGIS's explanation.
+	// Returns error only for actual database failures.
+	ValidateArea(ctx context.Context, area models.MultiPolygon) (string, error)
+
+	// FindIntersecting finds up to limit parcels whose boundary intersects the area.
+	// Returns an empty slice if no parcels intersect (not an error).
+	// Returns error only for actual database failures.
+	// Results are ordered by ID.
+	FindIntersecting(ctx context.Context, area models.MultiPolygon, limit int) ([]models.TaxParcel, error)
+
+	// FindByOwner finds a page of up to limit parcels whose owner name was
+	// resolved to the owner, after the cursor (nil for the first page), and the
+	// total number of them across pages. Only the cursor's ID is used.
+	// Returns an empty slice if the owner holds no live parcels (not an error).
+	// Returns error only for actual database failures.
+	// Results are ordered by ID.
+	FindByOwner(ctx context.Context, ownerID uint, limit int, after *pagination.Cursor) ([]models.TaxParcel, int, error)
+
+	// FindAlongRoute finds up to limit parcels within bufferMeters of the route.
+	// Returns an empty slice if no parcels are within the corridor (not an error).
+	// Returns error only for actual database failures.
+	// Results are ordered by station (from the start of the route), then ID.
+	FindAlongRoute(ctx context.Context, route models.LineString, bufferMeters float64, limit int) ([]ParcelAlongRoute, error)
+
+	// FindAdjacency finds up to limit parcels intersecting the area, without geometry,
+	// each with the IDs of the returned parcels sharing a boundary segment with it.
+	// Parcels touching at a single corner are not neighbors.
+	// Returns an empty slice if no parcels intersect (not an error).
+	// Returns error only for actual database failures.
+	// Results are ordered by ID.
+	FindAdjacency(ctx context.Context, area models.MultiPolygon, limit int) ([]AdjacentParcel, error)
+
+	// SampleParcels picks up to n random parcels, stratified by county and tax
+	// year, each with its data quality flags (the Quality* constants).
+	// Returns fewer than n parcels only when fewer exist.
+	// Returns error only for actual database failures.
+	// Results are in random order.
+	SampleParcels(ctx context.Context, n int) ([]ParcelSample, error)
+
+	// DerivedAttributes returns the derived attributes read into every parcel's
+	// DerivedAttributes and accepted by NearbyFilter.Attributes, in configuration order.
+	DerivedAttributes() []config.DerivedAttribute
 }
 
-// parcelRepository is the concrete implementation of ParcelRepository.
+// parcelRepository is the concrete implementation of ParcelRepository. Its
+// queries only read, so they run in database.Database.Read, on a read replica
+// when there are any, failing over to the primary, where they are repeated
+// after transient errors; results are reset at the start of each attempt.
 type parcelRepository struct {
-	db *database.Database
+	db               *database.Database
+	keyring          *encryption.Keyring
+	derived          map[string]config.DerivedAttribute
+	attributesColumn string
+	attributes       []config.DerivedAttribute
+	statementTimeout time.Duration
 }
 
-// NewParcelRepository creates a new instance of ParcelRepository.
-func NewParcelRepository(db *database.Database) ParcelRepository {
+// NewParcelRepository creates a new instance of ParcelRepository that selects the
+// given derived attributes, which config.Validate has checked, with every parcel.
+// Contact columns are decrypted with keyring; a nil keyring reads them as stored.
+// Spatial queries are cancelled after statementTimeout; 0 leaves them unbounded.
+func NewParcelRepository(db *database.Database, keyring *encryption.Keyring, statementTimeout time.Duration, attributes ...config.DerivedAttribute) ParcelRepository {
+	derived := make(map[string]config.DerivedAttribute, len(attributes))
+	for _, a := range attributes {
+		derived[a.Name] = a
+	}
 	return &parcelRepository{
-		db: db,
+		db:               db,
+		keyring:          keyring,
+		derived:          derived,
+		attributesColumn: derivedAttributesColumn(attributes),
+		attributes:       attributes,
+		statementTimeout: statementTimeout,
+	}
+}
+
+// withStatementTimeout bounds ctx by the statement timeout. pgx cancels the
+// running statement on the server when the deadline passes, which frees the
+// connection instead of waiting out a runaway ST_DWithin scan.
+func (r *parcelRepository) withStatementTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.statementTimeout <= 0 {
+		return context.WithCancel(ctx)
 	}
+	return context.WithTimeout(ctx, r.statementTimeout)
+}
+
+// statementError marks errors of statements cancelled by a deadline or by
+// PostgreSQL's own statement_timeout with ErrQueryTimeout.
+func statementError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &pgErr) && pgErr.Code == "57014") { // query_canceled
+		return fmt.Errorf("%w: %w", ErrQueryTimeout, err)
+	}
+	return err
+}
+
+// DerivedAttributes returns the derived attributes selected with every parcel.
+func (r *parcelRepository) DerivedAttributes() []config.DerivedAttribute {
+	return r.attributes
 }
 
 // FindByPoint queries the database for a parcel that contains the given point.
 // It uses PostGIS ST_Contains to perform a point-in-polygon spatial query.
 // The spatial index on the geom column is automatically used by PostGIS.
+// The simplify tolerance is a parameter so the statement stays cached across zoom levels.
 //
-// Note: PostGIS functions expect (longitude, latitude) order, not (lat, lng).
-func (r *parcelRepository) FindByPoint(ctx context.Context, lat, lng float64) (*models.TaxParcel, error) {
+// Note: PostGIS functions expect (longitude, latitude) order, see models.LatLng.ToPostGISOrder.
+func (r *parcelRepository) FindByPoint(ctx context.Context, point models.LatLng) (*models.TaxParcel, error) {
+	ctx, cancel := r.withStatementTimeout(ctx)
+	defer cancel()
+
+	columns, columnParams := parcelColumnsParam(ctx, 4)
 	query := `
-		SELECT 
-			id,
-			object_id,
-			pin,
-			pid,
-			state_cd,
-			block,
-			lot,
-			tract,
-			owner_name,
-			owner_address,
-			situs,
-			as_code,
-			legal_description,
-			imprv_actual_year_built,
-			imprv_main_area,
-			market_area,
-			p_year,
-			p_version,
-			p_roll_corr,
-			taxing_units,
-			exemptions,
-			county_name,
-			ST_AsGeoJSON(geom) as geometry,
-			created_at,
-			updated_at
+		SELECT ` + r.withAttributes(columns) + `
 		FROM tax_parcels
-		WHERE ST_Contains(geom, ST_SetSRID(ST_MakePoint($1, $2), 4326))
+		WHERE ST_Contains(geom, ST_SetSRID(ST_MakePoint($1, $2), 4326))` + liveParcelClause + countyClause(3) + `
 		LIMIT 1
 	`
 
 	var parcel models.TaxParcel
 	var geomJSON []byte
 
-	// Execute query - note: PostGIS uses (lng, lat) order
-	err := r.db.Pool.QueryRow(ctx, query, lng, lat).Scan(
-		&parcel.ID,
-		&parcel.ObjectID,
-		&parcel.PIN,
-		&parcel.PID,
-		&parcel.StateCd,
-		&parcel.Block,
-		&parcel.Lot,
-		&parcel.Tract,
-		&parcel.OwnerName,
-		&parcel.OwnerAddress,
-		&parcel.Situs,
-		&parcel.AsCode,
-		&parcel.LegalDescription,
-		&parcel.ImprvActualYearBuilt,
-		&parcel.ImprvMainArea,
-		&parcel.MarketArea,
-		&parcel.PYear,
-		&parcel.PVersion,
-		&parcel.PRollCorr,
-		&parcel.TaxingUnits,
-		&parcel.Exemptions,
-		&parcel.CountyName,
-		&geomJSON,
-		&parcel.CreatedAt,
-		&parcel.UpdatedAt,
-	)
+	x, y := point.ToPostGISOrder()
+	args := append([]any{x, y, countyParam(ctx)}, columnParams...)
+	err := r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		return q.QueryRow(ctx, query, args...).Scan(r.parcelScanTargets(ctx, &parcel, &geomJSON)...)
+	})
 
 	// Handle no rows found - this is not an error at the repository level
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
-		return nil, fmt.Errorf("failed to query parcel at point (lat=%f, lng=%f): %w", lat, lng, err)
+		return nil, fmt.Errorf("failed to query parcel at point (lat=%f, lng=%f): %w", point.Lat, point.Lng, statementError(err))
 	}
 
 	// Parse GeoJSON geometry into Polygon type using its Scanner
@@ -128,122 +302,883 @@ func (r *parcelRepository) FindByPoint(ctx context.Context, lat, lng float64) (*
 	return &parcel, nil
 }
 
-// Maximum number of parcels to return from nearby query
-const maxNearbyResults = 20
+// SquareMetersPerAcre converts geography areas (square meters) to acres.
+const SquareMetersPerAcre = 4046.8564224
 
-// FindNearby queries the database for all parcels within the specified radius
-// of the given point. It uses PostGIS ST_DWithin with geography casting for
-// accurate distance calculations in meters. Results are ordered by distance.
+// IdentifyByPoint queries the database for a summary of the parcel containing the given point.
+// Only the columns needed for hover tooltips are selected and the geometry is never
+// serialized, which keeps the query well under the at-point response time.
 //
-// Note: PostGIS functions expect (longitude, latitude) order, not (lat, lng).
-func (r *parcelRepository) FindNearby(ctx context.Context, lat, lng float64, radiusMeters int) ([]ParcelWithDistance, error) {
+// Note: PostGIS functions expect (longitude, latitude) order, see models.LatLng.ToPostGISOrder.
+func (r *parcelRepository) IdentifyByPoint(ctx context.Context, point models.LatLng) (*ParcelSummary, error) {
+	ctx, cancel := r.withStatementTimeout(ctx)
+	defer cancel()
+
 	query := `
-		SELECT 
+		SELECT
 			id,
-			object_id,
 			pin,
-			pid,
-			state_cd,
-			block,
-			lot,
-			tract,
 			owner_name,
-			owner_address,
 			situs,
-			as_code,
-			legal_description,
-			imprv_actual_year_built,
-			imprv_main_area,
-			market_area,
-			p_year,
-			p_version,
-			p_roll_corr,
-			taxing_units,
-			exemptions,
-			county_name,
-			ST_AsGeoJSON(geom) as geometry,
-			created_at,
-			updated_at,
-			ST_Distance(
-				geom::geography, 
-				ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography
-			) as distance_meters
+			ST_Area(geom::geography) / $3 as acres
+		FROM tax_parcels
+		WHERE ST_Contains(geom, ST_SetSRID(ST_MakePoint($1, $2), 4326))` + liveParcelClause + countyClause(4) + `
+		LIMIT 1
+	`
+
+	var summary ParcelSummary
+
+	x, y := point.ToPostGISOrder()
+	err := r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		return q.QueryRow(ctx, query, x, y, SquareMetersPerAcre, countyParam(ctx)).Scan(
+			&summary.ID,
+			&summary.PIN,
+			&summary.OwnerName,
+			&summary.Situs,
+			&summary.Acres,
+		)
+	})
+
+	// Handle no rows found - this is not an error at the repository level
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to identify parcel at point (lat=%f, lng=%f): %w", point.Lat, point.Lng, statementError(err))
+	}
+
+	return &summary, nil
+}
+
+// IdentifyByPoints looks up all the points in one query, each with the same
+// ST_Contains lookup as IdentifyByPoint run laterally, so callers can batch points
+// instead of paying a round trip per point.
+func (r *parcelRepository) IdentifyByPoints(ctx context.Context, points []models.LatLng) ([]*ParcelSummary, error) {
+	ctx, cancel := r.withStatementTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT pt.position, s.id, s.pin, s.owner_name, s.situs, s.acres
+		FROM unnest($1::float8[], $2::float8[]) WITH ORDINALITY AS pt(x, y, position)
+		CROSS JOIN LATERAL (
+			SELECT
+				id,
+				pin,
+				owner_name,
+				situs,
+				ST_Area(geom::geography) / $3 as acres
+			FROM tax_parcels
+			WHERE ST_Contains(geom, ST_SetSRID(ST_MakePoint(pt.x, pt.y), 4326))` + liveParcelClause + countyClause(4) + `
+			LIMIT 1
+		) s
+	`
+
+	xs := make([]float64, len(points))
+	ys := make([]float64, len(points))
+	for i, point := range points {
+		xs[i], ys[i] = point.ToPostGISOrder()
+	}
+
+	var results []*ParcelSummary
+	err := r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		rows, err := q.Query(ctx, query, xs, ys, SquareMetersPerAcre, countyParam(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to identify parcels at %d points: %w", len(points), statementError(err))
+		}
+		defer rows.Close()
+
+		results = make([]*ParcelSummary, len(points))
+
+		for rows.Next() {
+			var position int64
+			var summary ParcelSummary
+			if err := rows.Scan(&position, &summary.ID, &summary.PIN, &summary.OwnerName, &summary.Situs, &summary.Acres); err != nil {
+				return fmt.Errorf("failed to scan parcel summary row: %w", err)
+			}
+			// WITH ORDINALITY numbers the points from 1
+			results[position-1] = &summary
+		}
+
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating parcel summary rows: %w", statementError(err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// FindCandidatesNearPoint treats the reported position as uniformly distributed over the
+// accuracy circle, so a parcel's probability is the fraction of the circle's area it
+// covers. The circle is buffered on the geography type so the radius is in meters.
+// Probabilities sum to less than 1 where the circle reaches outside all parcels (roads).
+//
+// Note: PostGIS functions expect (longitude, latitude) order, see models.LatLng.ToPostGISOrder.
+func (r *parcelRepository) FindCandidatesNearPoint(ctx context.Context, point models.LatLng, accuracyMeters float64, limit int) ([]ParcelCandidate, error) {
+	ctx, cancel := r.withStatementTimeout(ctx)
+	defer cancel()
+
+	query := `
+		WITH accuracy AS (
+			SELECT
+				ST_SetSRID(ST_MakePoint($1, $2), 4326) as point,
+				ST_Buffer(ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography, $3)::geometry as circle
+		)
+		SELECT ` + r.withAttributes(parcelColumnsFor(ctx)) + `,
+			ST_Area(ST_Intersection(geom, accuracy.circle)::geography) /
+				ST_Area(accuracy.circle::geography) as probability,
+			ST_Contains(geom, accuracy.point) as contains_point
+		FROM tax_parcels, accuracy
+		WHERE ST_Intersects(geom, accuracy.circle)` + liveParcelClause + countyClause(5) + `
+		ORDER BY probability DESC, id
+		LIMIT $4
+	`
+
+	x, y := point.ToPostGISOrder()
+	var candidates []ParcelCandidate
+	err := r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		rows, err := q.Query(ctx, query, x, y, accuracyMeters, limit, countyParam(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to query parcel candidates (lat=%f, lng=%f, accuracy=%f): %w",
+				point.Lat, point.Lng, accuracyMeters, statementError(err))
+		}
+		defer rows.Close()
+
+		candidates = []ParcelCandidate{}
+		for rows.Next() {
+			var candidate ParcelCandidate
+			var geomJSON []byte
+
+			targets := append(r.parcelScanTargets(ctx, &candidate.Parcel, &geomJSON), &candidate.Probability, &candidate.ContainsPoint)
+			if err := rows.Scan(targets...); err != nil {
+				return fmt.Errorf("failed to scan parcel candidate row: %w", err)
+			}
+
+			// Parse GeoJSON geometry
+			if err := candidate.Parcel.Geom.Scan(geomJSON); err != nil {
+				return fmt.Errorf("failed to parse geometry for parcel %d: %w", candidate.Parcel.ID, err)
+			}
+
+			candidates = append(candidates, candidate)
+		}
+
+		// Check for errors during iteration
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating parcel candidate rows: %w", statementError(err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+// cursorParams splits a cursor into nullable query parameters.
+// Both values are nil for the first page so "$n IS NULL" disables the keyset filter.
+func cursorParams(after *pagination.Cursor) (*float64, *int64) {
+	if after == nil {
+		return nil, nil
+	}
+	id := int64(after.ID)
+	return &after.Key, &id
+}
+
+// FindNearby queries the database for a page of parcels within the specified radius
+// of the given point. It uses PostGIS ST_DWithin with geography casting for
+// accurate distance calculations in meters. Pages are keyed on (distance, id) so
+// parcels at the same distance are neither skipped nor repeated. As in
+// FindByPoint, the simplify tolerance is a parameter of the statement.
+//
+// Note: PostGIS functions expect (longitude, latitude) order, see models.LatLng.ToPostGISOrder.
+func (r *parcelRepository) FindNearby(ctx context.Context, point models.LatLng, radiusMeters, limit int, after *pagination.Cursor, filter NearbyFilter) ([]ParcelWithDistance, int, error) {
+	ctx, cancel := r.withStatementTimeout(ctx)
+	defer cancel()
+
+	// Attribute conditions follow the county parameter; both statements have the same values
+	countAttributeClause, attributeParams, err := r.attributeFilterClause(filter.Attributes, 5+nearbyFilterParams)
+	if err != nil {
+		return nil, 0, err
+	}
+	attributeClause, _, err := r.attributeFilterClause(filter.Attributes, 8+nearbyFilterParams)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	countQuery := `
+		SELECT COUNT(*)
 		FROM tax_parcels
 		WHERE ST_DWithin(
 			geom::geography,
 			ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography,
 			$3
-		)
-		ORDER BY distance_meters
+		)` + nearbyFilterClause(4) + liveParcelClause + countyClause(4+nearbyFilterParams) + countAttributeClause + `
+	`
+
+	columns, columnParams := parcelColumnsParam(ctx, 8+nearbyFilterParams+len(attributeParams))
+	query := `
+		SELECT * FROM (
+			SELECT ` + r.withAttributes(columns) + `,
+				ST_Distance(
+					geom::geography,
+					ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography
+				) as distance_meters
+			FROM tax_parcels
+			WHERE ST_DWithin(
+				geom::geography,
+				ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography,
+				$3
+			)` + nearbyFilterClause(7) + liveParcelClause + countyClause(7+nearbyFilterParams) + attributeClause + `
+		) nearby
+		WHERE $5::float8 IS NULL OR (distance_meters, id) > ($5::float8, $6::bigint)
+		ORDER BY distance_meters, id
 		LIMIT $4
 	`
 
-	// Execute query - note: PostGIS uses (lng, lat) order
-	rows, err := r.db.Pool.Query(ctx, query, lng, lat, radiusMeters, maxNearbyResults)
+	// Count all matches so clients can show "N of total"
+	x, y := point.ToPostGISOrder()
+	var total int
+	var results []ParcelWithDistance
+	err = r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		countArgs := append(append(append([]any{x, y, radiusMeters}, filter.params()...), countyParam(ctx)), attributeParams...)
+		if err := q.QueryRow(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+			return fmt.Errorf("failed to count nearby parcels (lat=%f, lng=%f, radius=%d): %w",
+				point.Lat, point.Lng, radiusMeters, statementError(err))
+		}
+
+		afterKey, afterID := cursorParams(after)
+
+		args := append(append(append(append([]any{x, y, radiusMeters, limit, afterKey, afterID}, filter.params()...), countyParam(ctx)), attributeParams...), columnParams...)
+		rows, err := q.Query(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to query nearby parcels (lat=%f, lng=%f, radius=%d): %w",
+				point.Lat, point.Lng, radiusMeters, statementError(err))
+		}
+		defer rows.Close()
+
+		results = nil
+
+		for rows.Next() {
+			var parcel models.TaxParcel
+			var geomJSON []byte
+			var distance float64
+
+			err := rows.Scan(append(r.parcelScanTargets(ctx, &parcel, &geomJSON), &distance)...)
+			if err != nil {
+				return fmt.Errorf("failed to scan parcel row: %w", err)
+			}
+
+			// Parse GeoJSON geometry
+			if err := parcel.Geom.Scan(geomJSON); err != nil {
+				return fmt.Errorf("failed to parse geometry for parcel %d: %w", parcel.ID, err)
+			}
+
+			results = append(results, ParcelWithDistance{
+				Parcel:   parcel,
+				Distance: distance,
+			})
+		}
+
+		// Check for errors during iteration
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating parcel rows: %w", statementError(err))
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to query nearby parcels (lat=%f, lng=%f, radius=%d): %w",
-			lat, lng, radiusMeters, err)
+		return nil, 0, err
 	}
-	defer rows.Close()
 
-	var results []ParcelWithDistance
+	// Return empty slice if no parcels found (not an error)
+	if results == nil {
+		results = []ParcelWithDistance{}
+	}
+
+	return results, total, nil
+}
+
+// SearchByAddress queries the database for a page of parcels whose situs address is
+// similar to the given address string. It uses pg_trgm word similarity so partial addresses
+// ("123 main") still rank the full situs ("123 MAIN ST, CONROE TX") highly.
+// The "<%" operator filters candidates using pg_trgm.word_similarity_threshold
+// (0.6 by default) and is served by the GIN trigram index on situs.
+// Pages are keyed on (score descending, id ascending).
+func (r *parcelRepository) SearchByAddress(ctx context.Context, address string, limit int, after *pagination.Cursor) ([]ParcelWithScore, int, error) {
+	countQuery := `
+		SELECT COUNT(*)
+		FROM tax_parcels
+		WHERE situs IS NOT NULL
+			AND upper($1) <% upper(situs)` + liveParcelClause + countyClause(2) + `
+	`
+
+	query := `
+		SELECT * FROM (
+			SELECT ` + r.withAttributes(parcelColumnsFor(ctx)) + `,
+				word_similarity(upper($1), upper(situs))::float8 as score
+			FROM tax_parcels
+			WHERE situs IS NOT NULL
+				AND upper($1) <% upper(situs)` + liveParcelClause + countyClause(5) + `
+		) matches
+		WHERE $3::float8 IS NULL
+			OR score < $3::float8
+			OR (score = $3::float8 AND id > $4::bigint)
+		ORDER BY score DESC, id
+		LIMIT $2
+	`
+
+	var total int
+	var results []ParcelWithScore
+	err := r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		if err := q.QueryRow(ctx, countQuery, address, countyParam(ctx)).Scan(&total); err != nil {
+			return fmt.Errorf("failed to count parcels by address (address=%q): %w", address, err)
+		}
+
+		afterKey, afterID := cursorParams(after)
+
+		rows, err := q.Query(ctx, query, address, limit, afterKey, afterID, countyParam(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to search parcels by address (address=%q): %w", address, err)
+		}
+		defer rows.Close()
+
+		results = nil
+
+		for rows.Next() {
+			var parcel models.TaxParcel
+			var geomJSON []byte
+			var score float64
+
+			if err := rows.Scan(append(r.parcelScanTargets(ctx, &parcel, &geomJSON), &score)...); err != nil {
+				return fmt.Errorf("failed to scan parcel row: %w", err)
+			}
+
+			// Parse GeoJSON geometry
+			if err := parcel.Geom.Scan(geomJSON); err != nil {
+				return fmt.Errorf("failed to parse geometry for parcel %d: %w", parcel.ID, err)
+			}
+
+			results = append(results, ParcelWithScore{
+				Parcel: parcel,
+				Score:  score,
+			})
+		}
+
+		// Check for errors during iteration
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating parcel rows: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Return empty slice if no parcels found (not an error)
+	if results == nil {
+		results = []ParcelWithScore{}
+	}
+
+	return results, total, nil
+}
+
+// FindByIDs queries the database for the parcels with the given IDs.
+// The perimeter is computed on the geography type so it is in real-world units.
+func (r *parcelRepository) FindByIDs(ctx context.Context, ids []uint) ([]ParcelWithMetrics, error) {
+	query := `
+		SELECT ` + r.withAttributes(parcelColumns) + `,
+			ST_Perimeter(geom::geography) as perimeter_meters
+		FROM tax_parcels
+		WHERE id = ANY($1)` + liveParcelClause + `
+		ORDER BY id
+	`
+
+	// pgx encodes int64 slices as bigint[] which matches the id column
+	idParams := make([]int64, len(ids))
+	for i, id := range ids {
+		idParams[i] = int64(id)
+	}
+
+	var results []ParcelWithMetrics
+	err := r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		rows, err := q.Query(ctx, query, idParams)
+		if err != nil {
+			return fmt.Errorf("failed to query parcels by ids (ids=%v): %w", ids, err)
+		}
+		defer rows.Close()
+
+		results = nil
+
+		for rows.Next() {
+			var parcel models.TaxParcel
+			var geomJSON []byte
+			var metrics ParcelWithMetrics
+
+			err := rows.Scan(append(r.parcelScanTargets(ctx, &parcel, &geomJSON), &metrics.PerimeterMeters)...)
+			if err != nil {
+				return fmt.Errorf("failed to scan parcel row: %w", err)
+			}
+
+			// Parse GeoJSON geometry; a corrupt one fails only its own parcel
+			if err := parcel.Geom.Scan(geomJSON); err != nil {
+				metrics.Err = fmt.Errorf("failed to parse geometry for parcel %d: %w", parcel.ID, err)
+			}
+
+			metrics.Parcel = parcel
+			results = append(results, metrics)
+		}
+
+		// Check for errors during iteration
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating parcel rows: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return empty slice if no parcels found (not an error)
+	if results == nil {
+		results = []ParcelWithMetrics{}
+	}
+
+	return results, nil
+}
+
+// FindNearestAmenities runs a nearest-neighbor search per parcel and category.
+// The GIST index orders amenities by planar distance in degrees, which can rank
+// two nearby amenities differently than meters do away from the equator, so the
+// closest few candidates are re-ranked by geography distance.
+func (r *parcelRepository) FindNearestAmenities(ctx context.Context, ids []uint) (map[uint][]NearestAmenity, error) {
+	ctx, cancel := r.withStatementTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT p.id, c.category, a.id, a.name, ST_Y(a.geom), ST_X(a.geom), a.distance_meters
+		FROM tax_parcels p
+		CROSS JOIN unnest($2::text[]) WITH ORDINALITY AS c(category, position)
+		CROSS JOIN LATERAL (
+			SELECT id, name, geom, ST_Distance(geom::geography, p.geom::geography) AS distance_meters
+			FROM (
+				SELECT id, name, geom
+				FROM amenities
+				WHERE category = c.category
+				ORDER BY geom <-> p.geom
+				LIMIT 5
+			) candidates
+			ORDER BY distance_meters, id
+			LIMIT 1
+		) a
+		WHERE p.id = ANY($1)` + liveParcelClause + `
+		ORDER BY p.id, c.position
+	`
+
+	// pgx encodes int64 slices as bigint[] which matches the id column
+	idParams := make([]int64, len(ids))
+	for i, id := range ids {
+		idParams[i] = int64(id)
+	}
+
+	var results map[uint][]NearestAmenity
+	err := r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		rows, err := q.Query(ctx, query, idParams, models.AmenityCategories)
+		if err != nil {
+			return fmt.Errorf("failed to query nearest amenities (ids=%v): %w", ids, statementError(err))
+		}
+		defer rows.Close()
+
+		results = make(map[uint][]NearestAmenity, len(ids))
+
+		for rows.Next() {
+			var parcelID uint
+			var amenity NearestAmenity
+
+			err := rows.Scan(&parcelID, &amenity.Category, &amenity.ID, &amenity.Name,
+				&amenity.Location.Lat, &amenity.Location.Lng, &amenity.Distance)
+			if err != nil {
+				return fmt.Errorf("failed to scan amenity row: %w", err)
+			}
 
-	for rows.Next() {
-		var parcel models.TaxParcel
-		var geomJSON []byte
-		var distance float64
-
-		err := rows.Scan(
-			&parcel.ID,
-			&parcel.ObjectID,
-			&parcel.PIN,
-			&parcel.PID,
-			&parcel.StateCd,
-			&parcel.Block,
-			&parcel.Lot,
-			&parcel.Tract,
-			&parcel.OwnerName,
-			&parcel.OwnerAddress,
-			&parcel.Situs,
-			&parcel.AsCode,
-			&parcel.LegalDescription,
-			&parcel.ImprvActualYearBuilt,
-			&parcel.ImprvMainArea,
-			&parcel.MarketArea,
-			&parcel.PYear,
-			&parcel.PVersion,
-			&parcel.PRollCorr,
-			&parcel.TaxingUnits,
-			&parcel.Exemptions,
-			&parcel.CountyName,
-			&geomJSON,
-			&parcel.CreatedAt,
-			&parcel.UpdatedAt,
-			&distance,
+			results[parcelID] = append(results[parcelID], amenity)
+		}
+
+		// Check for errors during iteration
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating amenity rows: %w", statementError(err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// DissolveByIDs merges the parcels with ST_Union and rebuilds each part of the union
+// from its exterior ring. ST_MakeValid keeps a single invalid parcel boundary from
+// failing the union; any lines or points it produces are dropped by the polygon filter.
+func (r *parcelRepository) DissolveByIDs(ctx context.Context, ids []uint) (*DissolvedArea, error) {
+	ctx, cancel := r.withStatementTimeout(ctx)
+	defer cancel()
+
+	query := `
+		WITH selected AS (
+			SELECT id, geom
+			FROM tax_parcels
+			WHERE id = ANY($1)` + liveParcelClause + `
+		),
+		outline AS (
+			SELECT ST_Multi(ST_Union(ST_MakePolygon(ST_ExteriorRing(part.geom)))) as geom
+			FROM ST_Dump((SELECT ST_Union(ST_MakeValid(geom)) FROM selected)) part
+			WHERE ST_GeometryType(part.geom) = 'ST_Polygon'
 		)
+		SELECT
+			(SELECT COALESCE(array_agg(id ORDER BY id), '{}') FROM selected) as parcel_ids,
+			ST_AsGeoJSON(geom) as geometry,
+			COALESCE(` + parcelAcresExpression + `, 0) as acres,
+			COALESCE(ST_Perimeter(geom::geography), 0) as perimeter_meters
+		FROM outline
+	`
+
+	// pgx encodes int64 slices as bigint[] which matches the id column
+	idParams := make([]int64, len(ids))
+	for i, id := range ids {
+		idParams[i] = int64(id)
+	}
+
+	var found []int64
+	var geomJSON []byte
+	area := &DissolvedArea{}
+	err := r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		return q.QueryRow(ctx, query, idParams).Scan(&found, &geomJSON, &area.Acres, &area.PerimeterMeters)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to dissolve parcels (ids=%v): %w", ids, statementError(err))
+	}
+
+	area.ParcelIDs = make([]uint, len(found))
+	for i, id := range found {
+		area.ParcelIDs[i] = uint(id)
+	}
+
+	// The outline is NULL when no parcels were found, which leaves it empty
+	if err := area.Outline.Scan(geomJSON); err != nil {
+		return nil, fmt.Errorf("failed to parse dissolved outline: %w", err)
+	}
+
+	return area, nil
+}
+
+// ValidateArea asks PostGIS whether the area is a valid geometry.
+// Self-intersecting rings and similar defects are easier to detect in the database
+// than in Go, and reporting them avoids silently wrong intersection results.
+func (r *parcelRepository) ValidateArea(ctx context.Context, area models.MultiPolygon) (string, error) {
+	query := `
+		SELECT
+			CASE WHEN ST_IsValid(g) THEN '' ELSE ST_IsValidReason(g) END
+		FROM (SELECT ST_GeomFromGeoJSON($1::text) as g) area
+	`
+
+	geoJSON, err := area.Value()
+	if err != nil {
+		return "", err
+	}
+
+	var reason string
+	err = r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		return q.QueryRow(ctx, query, geoJSON).Scan(&reason)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to validate area geometry: %w", err)
+	}
+
+	return reason, nil
+}
+
+// FindIntersecting queries the database for parcels intersecting the given area.
+// It uses PostGIS ST_Intersects, which is served by the spatial index on geom.
+func (r *parcelRepository) FindIntersecting(ctx context.Context, area models.MultiPolygon, limit int) ([]models.TaxParcel, error) {
+	ctx, cancel := r.withStatementTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + r.withAttributes(parcelColumnsFor(ctx)) + `
+		FROM tax_parcels
+		WHERE ST_Intersects(geom, ST_SetSRID(ST_GeomFromGeoJSON($1::text), 4326))` + liveParcelClause + countyClause(3) + `
+		ORDER BY id
+		LIMIT $2
+	`
+
+	geoJSON, err := area.Value()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []models.TaxParcel
+	err = r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		rows, err := q.Query(ctx, query, geoJSON, limit, countyParam(ctx))
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan parcel row: %w", err)
+			return fmt.Errorf("failed to query parcels intersecting area (limit=%d): %w", limit, statementError(err))
 		}
+		defer rows.Close()
+
+		results = nil
+
+		for rows.Next() {
+			var parcel models.TaxParcel
+			var geomJSON []byte
 
-		// Parse GeoJSON geometry
-		if err := parcel.Geom.Scan(geomJSON); err != nil {
-			return nil, fmt.Errorf("failed to parse geometry for parcel %d: %w", parcel.ID, err)
+			if err := rows.Scan(r.parcelScanTargets(ctx, &parcel, &geomJSON)...); err != nil {
+				return fmt.Errorf("failed to scan parcel row: %w", err)
+			}
+
+			// Parse GeoJSON geometry
+			if err := parcel.Geom.Scan(geomJSON); err != nil {
+				return fmt.Errorf("failed to parse geometry for parcel %d: %w", parcel.ID, err)
+			}
+
+			results = append(results, parcel)
 		}
 
-		results = append(results, ParcelWithDistance{
-			Parcel:   parcel,
-			Distance: distance,
-		})
+		// Check for errors during iteration
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating parcel rows: %w", statementError(err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return empty slice if no parcels found (not an error)
+	if results == nil {
+		results = []models.TaxParcel{}
 	}
 
-	// Check for errors during iteration
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating parcel rows: %w", err)
+	return results, nil
+}
+
+// FindByOwner queries the database for a page of the owner's parcels. Names are
+// matched through owner_names, served by the owner_name index.
+func (r *parcelRepository) FindByOwner(ctx context.Context, ownerID uint, limit int, after *pagination.Cursor) ([]models.TaxParcel, int, error) {
+	ownerClause := `
+			owner_name IN (SELECT name FROM owner_names WHERE owner_id = $1)`
+
+	countQuery := `
+		SELECT COUNT(*)
+		FROM tax_parcels
+		WHERE` + ownerClause + liveParcelClause + countyClause(2) + `
+	`
+
+	query := `
+		SELECT ` + r.withAttributes(parcelColumnsFor(ctx)) + `
+		FROM tax_parcels
+		WHERE` + ownerClause + `
+			AND ($3::bigint IS NULL OR id > $3::bigint)` + liveParcelClause + countyClause(2) + `
+		ORDER BY id
+		LIMIT $4
+	`
+
+	var total int
+	var results []models.TaxParcel
+	err := r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		if err := q.QueryRow(ctx, countQuery, ownerID, countyParam(ctx)).Scan(&total); err != nil {
+			return fmt.Errorf("failed to count parcels of owner (owner=%d): %w", ownerID, err)
+		}
+
+		_, afterID := cursorParams(after)
+
+		rows, err := q.Query(ctx, query, ownerID, countyParam(ctx), afterID, limit)
+		if err != nil {
+			return fmt.Errorf("failed to query parcels of owner (owner=%d): %w", ownerID, err)
+		}
+		defer rows.Close()
+
+		results = nil
+
+		for rows.Next() {
+			var parcel models.TaxParcel
+			var geomJSON []byte
+
+			if err := rows.Scan(r.parcelScanTargets(ctx, &parcel, &geomJSON)...); err != nil {
+				return fmt.Errorf("failed to scan parcel row: %w", err)
+			}
+
+			// Parse GeoJSON geometry
+			if err := parcel.Geom.Scan(geomJSON); err != nil {
+				return fmt.Errorf("failed to parse geometry for parcel %d: %w", parcel.ID, err)
+			}
+
+			results = append(results, parcel)
+		}
+
+		// Check for errors during iteration
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating parcel rows: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
 	}
 
 	// Return empty slice if no parcels found (not an error)
 	if results == nil {
-		results = []ParcelWithDistance{}
+		results = []models.TaxParcel{}
+	}
+
+	return results, total, nil
+}
+
+// FindAlongRoute queries the database for parcels within a corridor around the route.
+// The corridor is buffered as geography so the width is in meters, then compared as
+// geometry with ST_Intersects so the spatial index on geom is used.
+// Stations come from ST_LineLocatePoint, scaled by the route's geodesic length.
+func (r *parcelRepository) FindAlongRoute(ctx context.Context, route models.LineString, bufferMeters float64, limit int) ([]ParcelAlongRoute, error) {
+	ctx, cancel := r.withStatementTimeout(ctx)
+	defer cancel()
+
+	query := `
+		WITH route AS (
+			SELECT
+				line,
+				ST_Buffer(line::geography, $2)::geometry as corridor,
+				ST_Length(line::geography) as length_meters
+			FROM (SELECT ST_SetSRID(ST_GeomFromGeoJSON($1::text), 4326) as line) input
+		)
+		SELECT ` + r.withAttributes(parcelColumnsFor(ctx)) + `,
+			ST_Distance(geom::geography, route.line::geography) as distance_meters,
+			ST_LineLocatePoint(route.line, ST_ClosestPoint(route.line, geom)) * route.length_meters as station_meters
+		FROM tax_parcels, route
+		WHERE ST_Intersects(geom, route.corridor)` + liveParcelClause + countyClause(4) + `
+		ORDER BY station_meters, id
+		LIMIT $3
+	`
+
+	geoJSON, err := route.Value()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []ParcelAlongRoute
+	err = r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		rows, err := q.Query(ctx, query, geoJSON, bufferMeters, limit, countyParam(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to query parcels along route (buffer=%f, limit=%d): %w", bufferMeters, limit, statementError(err))
+		}
+		defer rows.Close()
+
+		results = nil
+
+		for rows.Next() {
+			var parcel models.TaxParcel
+			var geomJSON []byte
+			var distance, station float64
+
+			if err := rows.Scan(append(r.parcelScanTargets(ctx, &parcel, &geomJSON), &distance, &station)...); err != nil {
+				return fmt.Errorf("failed to scan parcel row: %w", err)
+			}
+
+			// Parse GeoJSON geometry
+			if err := parcel.Geom.Scan(geomJSON); err != nil {
+				return fmt.Errorf("failed to parse geometry for parcel %d: %w", parcel.ID, err)
+			}
+
+			results = append(results, ParcelAlongRoute{
+				Parcel:   parcel,
+				Distance: distance,
+				Station:  station,
+			})
+		}
+
+		// Check for errors during iteration
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating parcel rows: %w", statementError(err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return empty slice if no parcels found (not an error)
+	if results == nil {
+		results = []ParcelAlongRoute{}
+	}
+
+	return results, nil
+}
+
+// FindAdjacency queries the parcels of the area and pairs them up with ST_Relate.
+// Boundaries meeting along a line (DE-9IM boundary/boundary dimension 1) make
+// neighbors, as do slightly overlapping interiors from digitizing error; the
+// neighbor join goes through tax_parcels so it uses the spatial index on geom.
+func (r *parcelRepository) FindAdjacency(ctx context.Context, area models.MultiPolygon, limit int) ([]AdjacentParcel, error) {
+	ctx, cancel := r.withStatementTimeout(ctx)
+	defer cancel()
+
+	query := `
+		WITH candidates AS (
+			SELECT id, owner_name, geom, ` + parcelAcresExpression + ` as acres
+			FROM tax_parcels
+			WHERE ST_Intersects(geom, ST_SetSRID(ST_GeomFromGeoJSON($1::text), 4326))` + liveParcelClause + countyClause(3) + `
+			ORDER BY id
+			LIMIT $2
+		)
+		SELECT c.id, c.owner_name, c.acres,
+			COALESCE(array_agg(n.id ORDER BY n.id) FILTER (WHERE n.id IS NOT NULL), '{}') as neighbors
+		FROM candidates c
+		LEFT JOIN tax_parcels n ON ST_Intersects(n.geom, c.geom)
+			AND n.id <> c.id
+			AND n.id IN (SELECT id FROM candidates)
+			AND (ST_Relate(c.geom, n.geom, '****1****') OR ST_Relate(c.geom, n.geom, '2********'))
+		GROUP BY c.id, c.owner_name, c.acres
+		ORDER BY c.id
+	`
+
+	geoJSON, err := area.Value()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []AdjacentParcel
+	err = r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		rows, err := q.Query(ctx, query, geoJSON, limit, countyParam(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to query parcel adjacency (limit=%d): %w", limit, statementError(err))
+		}
+		defer rows.Close()
+
+		results = []AdjacentParcel{}
+
+		for rows.Next() {
+			var parcel AdjacentParcel
+			var neighbors []int64
+
+			if err := rows.Scan(&parcel.ID, &parcel.OwnerName, &parcel.Acres, &neighbors); err != nil {
+				return fmt.Errorf("failed to scan adjacency row: %w", err)
+			}
+
+			parcel.Neighbors = make([]uint, len(neighbors))
+			for i, id := range neighbors {
+				parcel.Neighbors[i] = uint(id)
+			}
+			results = append(results, parcel)
+		}
+
+		// Check for errors during iteration
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating adjacency rows: %w", statementError(err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return results, nil
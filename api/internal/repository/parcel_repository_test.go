@@ -2,12 +2,20 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stwalsh4118/atlas/api/internal/config"
 	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/pagination"
 )
 
 // getTestConfig returns database configuration for integration tests.
@@ -44,7 +52,7 @@ func setupTestRepository(t *testing.T) (*ParcelRepository, *database.Database) {
 		t.Fatalf("Failed to create database connection: %v", err)
 	}
 
-	repo := NewParcelRepository(db)
+	repo := NewParcelRepository(db, nil, 0)
 	return &repo, db
 }
 
@@ -63,7 +71,7 @@ func TestNewParcelRepository(t *testing.T) {
 	}
 	defer db.Close()
 
-	repo := NewParcelRepository(db)
+	repo := NewParcelRepository(db, nil, 0)
 	if repo == nil {
 		t.Fatal("Expected repository to be initialized")
 	}
@@ -83,7 +91,7 @@ func TestFindByPoint_Success(t *testing.T) {
 	lat := 30.3477
 	lng := -95.4502
 
-	parcel, err := (*repo).FindByPoint(ctx, lat, lng)
+	parcel, err := (*repo).FindByPoint(ctx, models.LatLng{Lat: lat, Lng: lng})
 	if err != nil {
 		t.Fatalf("FindByPoint returned error: %v", err)
 	}
@@ -120,7 +128,7 @@ func TestFindByPoint_NotFound(t *testing.T) {
 	lat := 27.0
 	lng := -93.0
 
-	parcel, err := (*repo).FindByPoint(ctx, lat, lng)
+	parcel, err := (*repo).FindByPoint(ctx, models.LatLng{Lat: lat, Lng: lng})
 	if err != nil {
 		t.Errorf("FindByPoint should not return error for not found, got: %v", err)
 	}
@@ -151,7 +159,7 @@ func TestFindByPoint_ExtremeCoordinates(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			parcel, err := (*repo).FindByPoint(ctx, tc.lat, tc.lng)
+			parcel, err := (*repo).FindByPoint(ctx, models.LatLng{Lat: tc.lat, Lng: tc.lng})
 			if err != nil {
 				t.Errorf("FindByPoint with extreme coordinates should not error, got: %v", err)
 			}
@@ -175,7 +183,7 @@ func TestFindByPoint_ContextCancellation(t *testing.T) {
 	lat := 30.3477
 	lng := -95.4502
 
-	_, err := (*repo).FindByPoint(ctx, lat, lng)
+	_, err := (*repo).FindByPoint(ctx, models.LatLng{Lat: lat, Lng: lng})
 	if err == nil {
 		t.Error("Expected error when context is cancelled")
 	}
@@ -201,7 +209,7 @@ func TestFindByPoint_ContextTimeout(t *testing.T) {
 	lat := 30.3477
 	lng := -95.4502
 
-	_, err := (*repo).FindByPoint(ctx, lat, lng)
+	_, err := (*repo).FindByPoint(ctx, models.LatLng{Lat: lat, Lng: lng})
 	// Should get a context deadline exceeded error or nil if query was fast enough
 	if err != nil && ctx.Err() == nil {
 		t.Errorf("Expected context timeout error, got: %v", err)
@@ -226,7 +234,7 @@ func TestFindByPoint_MultipleQueries(t *testing.T) {
 	}
 
 	for i, coord := range coordinates {
-		parcel, err := (*repo).FindByPoint(ctx, coord.lat, coord.lng)
+		parcel, err := (*repo).FindByPoint(ctx, models.LatLng{Lat: coord.lat, Lng: coord.lng})
 		if err != nil {
 			t.Errorf("Query %d failed: %v", i+1, err)
 		}
@@ -250,7 +258,7 @@ func TestFindByPoint_GeometryParsing(t *testing.T) {
 	lat := 30.3477
 	lng := -95.4502
 
-	parcel, err := (*repo).FindByPoint(ctx, lat, lng)
+	parcel, err := (*repo).FindByPoint(ctx, models.LatLng{Lat: lat, Lng: lng})
 	if err != nil {
 		t.Fatalf("FindByPoint returned error: %v", err)
 	}
@@ -314,7 +322,7 @@ func TestFindByPoint_NullableFields(t *testing.T) {
 	lat := 30.3477
 	lng := -95.4502
 
-	parcel, err := (*repo).FindByPoint(ctx, lat, lng)
+	parcel, err := (*repo).FindByPoint(ctx, models.LatLng{Lat: lat, Lng: lng})
 	if err != nil {
 		t.Fatalf("FindByPoint returned error: %v", err)
 	}
@@ -359,14 +367,14 @@ func TestFindByPoint_CoordinateOrder(t *testing.T) {
 	lng := -95.4502
 
 	// Query with correct order
-	parcel1, err := (*repo).FindByPoint(ctx, lat, lng)
+	parcel1, err := (*repo).FindByPoint(ctx, models.LatLng{Lat: lat, Lng: lng})
 	if err != nil {
 		t.Fatalf("FindByPoint returned error: %v", err)
 	}
 
 	// Now try with swapped coordinates (should not find same parcel or any parcel)
 	// If we accidentally swap lat/lng, this would fail
-	parcel2, err := (*repo).FindByPoint(ctx, lng, lat)
+	parcel2, err := (*repo).FindByPoint(ctx, models.LatLng{Lat: lng, Lng: lat})
 	if err != nil {
 		t.Fatalf("FindByPoint with swapped coords returned error: %v", err)
 	}
@@ -385,6 +393,244 @@ func TestFindByPoint_CoordinateOrder(t *testing.T) {
 	}
 }
 
+// TestParcelColumnsFor tests that WithoutGeometry swaps only the geometry column.
+func TestParcelColumnsFor(t *testing.T) {
+	if got := parcelColumnsFor(context.Background()); got != parcelColumns {
+		t.Error("Expected full parcel columns for a plain context")
+	}
+
+	columns := parcelColumnsFor(WithoutGeometry(context.Background()))
+	if strings.Contains(columns, "ST_AsGeoJSON") {
+		t.Error("Expected geometry serialization to be skipped")
+	}
+	if !strings.Contains(columns, "NULL::text as geometry") {
+		t.Error("Expected a NULL geometry placeholder to keep the scan order")
+	}
+
+	columns = parcelColumnsFor(WithSimplifyTolerance(context.Background(), 0.0005))
+	if !strings.Contains(columns, "ST_AsGeoJSON(ST_SimplifyPreserveTopology(geom, 0.0005)) as geometry") {
+		t.Errorf("Expected simplified geometry column, got %s", columns)
+	}
+
+	if got := parcelColumnsFor(WithSimplifyTolerance(context.Background(), 0)); got != parcelColumns {
+		t.Error("Expected full resolution geometry for a zero tolerance")
+	}
+}
+
+// TestParcelColumnsParam tests that the tolerance is a parameter, so the
+// statement text, and with it pgx's cached prepared statement, is shared by all tolerances.
+func TestParcelColumnsParam(t *testing.T) {
+	coarse, coarseParams := parcelColumnsParam(WithSimplifyTolerance(context.Background(), 0.0005), 4)
+	fine, fineParams := parcelColumnsParam(WithSimplifyTolerance(context.Background(), 0.00001), 4)
+	full, fullParams := parcelColumnsParam(context.Background(), 4)
+	if coarse != fine || coarse != full {
+		t.Error("Expected the same columns for every tolerance")
+	}
+	if !strings.Contains(coarse, "ST_SimplifyPreserveTopology(geom, $4::float8)") {
+		t.Errorf("Expected the tolerance as parameter $4, got %s", coarse)
+	}
+	if len(coarseParams) != 1 || coarseParams[0] != 0.0005 || fineParams[0] != 0.00001 || fullParams[0] != 0.0 {
+		t.Errorf("Unexpected tolerance params %v %v %v", coarseParams, fineParams, fullParams)
+	}
+
+	columns, params := parcelColumnsParam(WithoutGeometry(context.Background()), 4)
+	if columns != parcelColumnsWithoutGeometry || params != nil {
+		t.Error("Expected no geometry and no parameter when geometry is skipped")
+	}
+}
+
+// TestParcelColumns_OutputSRID tests that geometries are transformed after simplification.
+func TestParcelColumns_OutputSRID(t *testing.T) {
+	if got := parcelColumnsFor(WithOutputSRID(context.Background(), 4326)); got != parcelColumns {
+		t.Error("Expected untransformed geometry for WGS84")
+	}
+
+	ctx := WithOutputSRID(WithSimplifyTolerance(context.Background(), 0.0005), 3857)
+	columns := parcelColumnsFor(ctx)
+	if !strings.Contains(columns, "ST_AsGeoJSON(ST_Transform(ST_SimplifyPreserveTopology(geom, 0.0005), 3857)) as geometry") {
+		t.Errorf("Expected transformed simplified geometry column, got %s", columns)
+	}
+	if !strings.Contains(columns, "ST_X(ST_PointOnSurface(geom)) as centroid_lng") {
+		t.Error("Expected the centroid to stay WGS84")
+	}
+
+	columns, _ = parcelColumnsParam(WithOutputSRID(context.Background(), 2278), 4)
+	if !strings.Contains(columns, "ST_AsGeoJSON(ST_Transform(CASE WHEN $4::float8 > 0 THEN ST_SimplifyPreserveTopology(geom, $4::float8) ELSE geom END, 2278)) as geometry") {
+		t.Errorf("Expected transformed geometry column, got %s", columns)
+	}
+
+	if got := parcelColumnsFor(WithOutputSRID(WithoutGeometry(context.Background()), 3857)); got != parcelColumnsWithoutGeometry {
+		t.Error("Expected no geometry when geometry is skipped")
+	}
+}
+
+// TestParcelAcresExpression tests that acres are measured on the ellipsoid in
+// real-world units: a 0.01 degree square at the equator is about 304.16 acres.
+func TestParcelAcresExpression(t *testing.T) {
+	_, db := setupTestRepository(t)
+	defer db.Close()
+
+	query := `SELECT ` + parcelAcresExpression + `
+		FROM (SELECT ST_GeomFromText('POLYGON((0 0, 0.01 0, 0.01 0.01, 0 0.01, 0 0))', 4326) as geom) parcel`
+	var acres float64
+	if err := db.Pool.QueryRow(context.Background(), query).Scan(&acres); err != nil {
+		t.Fatalf("Failed to compute acres: %v", err)
+	}
+
+	if math.Abs(acres-304.16) > 0.5 {
+		t.Errorf("Expected about 304.16 acres, got %f", acres)
+	}
+}
+
+// TestSamplePercent tests the TABLESAMPLE percentage estimated from the planner's row count.
+func TestSamplePercent(t *testing.T) {
+	tests := []struct {
+		name     string
+		n        int
+		estimate float64
+		want     float64
+	}{
+		{"never analyzed", 50, -1, 100},
+		{"empty table", 50, 0, 100},
+		{"small table", 50, 400, 100},
+		{"large table", 50, 1000000, 0.05},
+		{"huge table", 1, 100000000, minSamplePercent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := samplePercent(tt.n, tt.estimate); got != tt.want {
+				t.Errorf("samplePercent(%d, %g) = %g, want %g", tt.n, tt.estimate, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFindCandidatesNearPoint tests candidate parcels around a point with GPS accuracy.
+// Note: This test requires parcel data to be loaded in the database.
+func TestFindCandidatesNearPoint(t *testing.T) {
+	repo, db := setupTestRepository(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	point := models.LatLng{Lat: 30.3477, Lng: -95.4502}
+
+	candidates, err := (*repo).FindCandidatesNearPoint(ctx, point, 25, 10)
+	if err != nil {
+		t.Fatalf("FindCandidatesNearPoint returned error: %v", err)
+	}
+
+	if candidates == nil {
+		t.Fatal("Expected non-nil slice")
+	}
+	if len(candidates) > 10 {
+		t.Errorf("Expected at most 10 candidates, got %d", len(candidates))
+	}
+
+	// Probabilities are shares of the same circle: each in (0, 1], descending, summing to at most 1
+	total := 0.0
+	for i, candidate := range candidates {
+		if candidate.Probability <= 0 || candidate.Probability > 1.0001 {
+			t.Errorf("Candidate %d probability %f outside (0, 1]", i, candidate.Probability)
+		}
+		if i > 0 && candidate.Probability > candidates[i-1].Probability {
+			t.Errorf("Candidates not sorted by probability at index %d", i)
+		}
+		total += candidate.Probability
+	}
+	if total > 1.0001 {
+		t.Errorf("Expected probabilities to sum to at most 1, got %f", total)
+	}
+
+	t.Logf("Found %d candidates within 25m accuracy", len(candidates))
+}
+
+// TestFindCandidatesNearPoint_NoParcels tests a point with no parcels within its accuracy.
+func TestFindCandidatesNearPoint_NoParcels(t *testing.T) {
+	repo, db := setupTestRepository(t)
+	defer db.Close()
+
+	candidates, err := (*repo).FindCandidatesNearPoint(context.Background(), models.LatLng{Lat: 0, Lng: 0}, 100, 10)
+	if err != nil {
+		t.Fatalf("FindCandidatesNearPoint returned error: %v", err)
+	}
+
+	if len(candidates) != 0 {
+		t.Errorf("Expected 0 candidates for ocean coordinates, got %d", len(candidates))
+	}
+}
+
+// TestDissolveByIDs_NoParcels tests dissolving ids that do not exist.
+func TestDissolveByIDs_NoParcels(t *testing.T) {
+	repo, db := setupTestRepository(t)
+	defer db.Close()
+
+	area, err := (*repo).DissolveByIDs(context.Background(), []uint{2147483000, 2147483001})
+	if err != nil {
+		t.Fatalf("DissolveByIDs returned error: %v", err)
+	}
+
+	if len(area.ParcelIDs) != 0 {
+		t.Errorf("Expected no parcel ids, got %v", area.ParcelIDs)
+	}
+	if len(area.Outline.Coordinates) != 0 || area.Acres != 0 {
+		t.Errorf("Expected an empty outline, got %d polygons and %f acres", len(area.Outline.Coordinates), area.Acres)
+	}
+}
+
+// TestFindAlongRoute tests parcels within a corridor around a route.
+// Note: This test requires parcel data to be loaded in the database.
+func TestFindAlongRoute(t *testing.T) {
+	repo, db := setupTestRepository(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	route := models.LineString{Coordinates: [][2]float64{{-95.4530, 30.3477}, {-95.4470, 30.3477}}}
+
+	parcels, err := (*repo).FindAlongRoute(ctx, route, 50, 20)
+	if err != nil {
+		t.Fatalf("FindAlongRoute returned error: %v", err)
+	}
+
+	if parcels == nil {
+		t.Fatal("Expected non-nil slice")
+	}
+	if len(parcels) > 20 {
+		t.Errorf("Expected at most 20 parcels, got %d", len(parcels))
+	}
+
+	// The route is about 577m long; stations ascend and parcels lie within the buffer
+	for i, p := range parcels {
+		if p.Distance < 0 || p.Distance > 51 {
+			t.Errorf("Parcel %d distance %f outside the 50m corridor", p.Parcel.ID, p.Distance)
+		}
+		if p.Station < 0 || p.Station > 600 {
+			t.Errorf("Parcel %d station %f outside the route", p.Parcel.ID, p.Station)
+		}
+		if i > 0 && p.Station < parcels[i-1].Station {
+			t.Errorf("Parcels not sorted by station at index %d", i)
+		}
+	}
+
+	t.Logf("Found %d parcels along route", len(parcels))
+}
+
+// TestFindAlongRoute_NoParcels tests a route with no parcels in its corridor.
+func TestFindAlongRoute_NoParcels(t *testing.T) {
+	repo, db := setupTestRepository(t)
+	defer db.Close()
+
+	route := models.LineString{Coordinates: [][2]float64{{0, 0}, {0.001, 0.001}}}
+
+	parcels, err := (*repo).FindAlongRoute(context.Background(), route, 100, 20)
+	if err != nil {
+		t.Fatalf("FindAlongRoute returned error: %v", err)
+	}
+
+	if len(parcels) != 0 {
+		t.Errorf("Expected 0 parcels for ocean coordinates, got %d", len(parcels))
+	}
+}
+
 // TestFindNearby_Success tests finding parcels within a radius of a known location.
 // Note: This test requires parcel data to be loaded in the database.
 func TestFindNearby_Success(t *testing.T) {
@@ -398,7 +644,7 @@ func TestFindNearby_Success(t *testing.T) {
 	lng := -95.4502
 	radiusMeters := 1000 // 1km radius
 
-	parcels, err := (*repo).FindNearby(ctx, lat, lng, radiusMeters)
+	parcels, _, err := (*repo).FindNearby(ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit, nil, NearbyFilter{})
 	if err != nil {
 		t.Fatalf("FindNearby returned error: %v", err)
 	}
@@ -448,7 +694,7 @@ func TestFindNearby_EmptyResults(t *testing.T) {
 	lng := -93.0
 	radiusMeters := 5000
 
-	parcels, err := (*repo).FindNearby(ctx, lat, lng, radiusMeters)
+	parcels, _, err := (*repo).FindNearby(ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit, nil, NearbyFilter{})
 	if err != nil {
 		t.Errorf("FindNearby should not return error for empty results, got: %v", err)
 	}
@@ -473,7 +719,7 @@ func TestFindNearby_SmallRadius(t *testing.T) {
 	lng := -95.4502
 	radiusMeters := 1 // Minimum radius
 
-	parcels, err := (*repo).FindNearby(ctx, lat, lng, radiusMeters)
+	parcels, _, err := (*repo).FindNearby(ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit, nil, NearbyFilter{})
 	if err != nil {
 		t.Fatalf("FindNearby with small radius returned error: %v", err)
 	}
@@ -503,7 +749,7 @@ func TestFindNearby_LargeRadius(t *testing.T) {
 	lng := -95.4502
 	radiusMeters := 5000 // Maximum radius
 
-	parcels, err := (*repo).FindNearby(ctx, lat, lng, radiusMeters)
+	parcels, _, err := (*repo).FindNearby(ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit, nil, NearbyFilter{})
 	if err != nil {
 		t.Fatalf("FindNearby with large radius returned error: %v", err)
 	}
@@ -534,7 +780,7 @@ func TestFindNearby_DistanceAccuracy(t *testing.T) {
 	lng := -95.4502
 	radiusMeters := 2000
 
-	parcels, err := (*repo).FindNearby(ctx, lat, lng, radiusMeters)
+	parcels, _, err := (*repo).FindNearby(ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit, nil, NearbyFilter{})
 	if err != nil {
 		t.Fatalf("FindNearby returned error: %v", err)
 	}
@@ -558,7 +804,10 @@ func TestFindNearby_DistanceAccuracy(t *testing.T) {
 	}
 }
 
-// TestFindNearby_ResultLimit tests that results are limited to maxNearbyResults.
+// testNearbyLimit is the page size used by FindNearby tests.
+const testNearbyLimit = 20
+
+// TestFindNearby_ResultLimit tests that results are limited to the requested page size.
 func TestFindNearby_ResultLimit(t *testing.T) {
 	repo, db := setupTestRepository(t)
 	defer db.Close()
@@ -570,17 +819,164 @@ func TestFindNearby_ResultLimit(t *testing.T) {
 	lng := -95.4502
 	radiusMeters := 5000
 
-	parcels, err := (*repo).FindNearby(ctx, lat, lng, radiusMeters)
+	parcels, total, err := (*repo).FindNearby(ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit, nil, NearbyFilter{})
+	if err != nil {
+		t.Fatalf("FindNearby returned error: %v", err)
+	}
+
+	if len(parcels) > testNearbyLimit {
+		t.Errorf("Result count %d exceeds limit %d", len(parcels), testNearbyLimit)
+	}
+
+	if total < len(parcels) {
+		t.Errorf("Total count %d is less than page size %d", total, len(parcels))
+	}
+
+	t.Logf("Found %d of %d parcels (limit is %d)", len(parcels), total, testNearbyLimit)
+}
+
+// TestFindNearby_Filters tests that attribute filters narrow both the page and the total.
+func TestFindNearby_Filters(t *testing.T) {
+	repo, db := setupTestRepository(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	point := models.LatLng{Lat: 30.3477, Lng: -95.4502}
+	radiusMeters := 5000
+
+	_, unfilteredTotal, err := (*repo).FindNearby(ctx, point, radiusMeters, testNearbyLimit, nil, NearbyFilter{})
+	if err != nil {
+		t.Fatalf("FindNearby returned error: %v", err)
+	}
+
+	minAcres := 1.0
+	minYear := 2000
+	filter := NearbyFilter{MinAcres: &minAcres, MinYearBuilt: &minYear}
+	parcels, total, err := (*repo).FindNearby(ctx, point, radiusMeters, testNearbyLimit, nil, filter)
+	if err != nil {
+		t.Fatalf("FindNearby with filter returned error: %v", err)
+	}
+
+	if total > unfilteredTotal {
+		t.Errorf("Filtered total %d exceeds unfiltered total %d", total, unfilteredTotal)
+	}
+
+	for _, p := range parcels {
+		if p.Parcel.Acres < minAcres {
+			t.Errorf("Parcel %d has %f acres, below min_acres %f", p.Parcel.ID, p.Parcel.Acres, minAcres)
+		}
+		if p.Parcel.ImprvActualYearBuilt == nil || *p.Parcel.ImprvActualYearBuilt < minYear {
+			t.Errorf("Parcel %d year built %v is not at least %d", p.Parcel.ID, p.Parcel.ImprvActualYearBuilt, minYear)
+		}
+	}
+
+	t.Logf("Filter kept %d of %d parcels", total, unfilteredTotal)
+}
+
+// TestNearbyFilter_Params tests that filter values line up with the clause placeholders.
+func TestNearbyFilter_Params(t *testing.T) {
+	minAcres := 0.5
+	waterfront := true
+	within := 3218.7
+	params := NearbyFilter{
+		MinAcres:            &minAcres,
+		LandUse:             "A1",
+		OwnerName:           "smith_jr",
+		Waterfront:          &waterfront,
+		Amenity:             "fire_station",
+		AmenityWithinMeters: &within,
+	}.params()
+
+	clause := nearbyFilterClause(4)
+	if !strings.Contains(clause, "$4::text") || !strings.Contains(clause, "$12::float8") || strings.Contains(clause, "$13") {
+		t.Errorf("Expected placeholders $4 through $12, got %s", clause)
+	}
+	if len(params) != nearbyFilterParams {
+		t.Fatalf("Expected %d params, got %d", nearbyFilterParams, len(params))
+	}
+	if landUse, ok := params[0].(*string); !ok || landUse == nil || *landUse != "A1" {
+		t.Errorf("Expected land use param A1, got %v", params[0])
+	}
+	if owner, ok := params[5].(*string); !ok || owner == nil || *owner != `%smith\_jr%` {
+		t.Errorf("Expected escaped owner pattern, got %v", params[5])
+	}
+	if got, ok := params[6].(*bool); !ok || got == nil || !*got {
+		t.Errorf("Expected waterfront param true, got %v", params[6])
+	}
+	if amenity, ok := params[7].(*string); !ok || amenity == nil || *amenity != "fire_station" {
+		t.Errorf("Expected amenity param fire_station, got %v", params[7])
+	}
+	if got, ok := params[8].(*float64); !ok || got == nil || *got != within {
+		t.Errorf("Expected amenity distance param %g, got %v", within, params[8])
+	}
+
+	empty := NearbyFilter{}.params()
+	if landUse, ok := empty[0].(*string); !ok || landUse != nil {
+		t.Errorf("Expected NULL land use for an empty filter, got %v", empty[0])
+	}
+}
+
+// TestContainsPattern tests that LIKE wildcards in user input match literally.
+func TestContainsPattern(t *testing.T) {
+	tests := map[string]string{
+		"smith":   "%smith%",
+		"100%":    `%100\%%`,
+		"a_b":     `%a\_b%`,
+		`back\sl`: `%back\\sl%`,
+	}
+
+	for input, want := range tests {
+		if got := containsPattern(input); got != want {
+			t.Errorf("containsPattern(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+// TestFindNearby_CursorPaging tests that consecutive pages continue where the previous one ended.
+func TestFindNearby_CursorPaging(t *testing.T) {
+	repo, db := setupTestRepository(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	lat := 30.3477
+	lng := -95.4502
+	radiusMeters := 5000
+	pageSize := 5
+
+	first, total, err := (*repo).FindNearby(ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, pageSize, nil, NearbyFilter{})
 	if err != nil {
 		t.Fatalf("FindNearby returned error: %v", err)
 	}
+	if len(first) < pageSize {
+		t.Skipf("Need at least %d parcels to test paging, found %d", pageSize, len(first))
+	}
 
-	// Should not exceed the limit (currently 20)
-	if len(parcels) > maxNearbyResults {
-		t.Errorf("Result count %d exceeds maxNearbyResults %d", len(parcels), maxNearbyResults)
+	last := first[len(first)-1]
+	after := &pagination.Cursor{Key: last.Distance, ID: last.Parcel.ID}
+
+	second, secondTotal, err := (*repo).FindNearby(ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, pageSize, after, NearbyFilter{})
+	if err != nil {
+		t.Fatalf("FindNearby second page returned error: %v", err)
 	}
 
-	t.Logf("Found %d parcels (limit is %d)", len(parcels), maxNearbyResults)
+	if secondTotal != total {
+		t.Errorf("Total count changed between pages: %d then %d", total, secondTotal)
+	}
+
+	seen := make(map[uint]bool, len(first))
+	for _, result := range first {
+		seen[result.Parcel.ID] = true
+	}
+	for i, result := range second {
+		if seen[result.Parcel.ID] {
+			t.Errorf("Parcel %d repeated on second page", result.Parcel.ID)
+		}
+		if result.Distance < last.Distance {
+			t.Errorf("Second page parcel %d (dist=%f) is closer than end of first page (dist=%f)",
+				i, result.Distance, last.Distance)
+		}
+	}
 }
 
 // TestFindNearby_GeometryParsing tests that geometries are correctly parsed.
@@ -594,7 +990,7 @@ func TestFindNearby_GeometryParsing(t *testing.T) {
 	lng := -95.4502
 	radiusMeters := 1000
 
-	parcels, err := (*repo).FindNearby(ctx, lat, lng, radiusMeters)
+	parcels, _, err := (*repo).FindNearby(ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit, nil, NearbyFilter{})
 	if err != nil {
 		t.Fatalf("FindNearby returned error: %v", err)
 	}
@@ -636,7 +1032,7 @@ func TestFindNearby_ContextCancellation(t *testing.T) {
 	lng := -95.4502
 	radiusMeters := 1000
 
-	_, err := (*repo).FindNearby(ctx, lat, lng, radiusMeters)
+	_, _, err := (*repo).FindNearby(ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit, nil, NearbyFilter{})
 	if err == nil {
 		t.Error("Expected error when context is cancelled")
 	}
@@ -647,6 +1043,38 @@ func TestFindNearby_ContextCancellation(t *testing.T) {
 	}
 }
 
+// TestFindNearby_StatementTimeout tests that the repository's own timeout surfaces as ErrQueryTimeout.
+func TestFindNearby_StatementTimeout(t *testing.T) {
+	_, db := setupTestRepository(t)
+	defer db.Close()
+
+	repo := NewParcelRepository(db, nil, time.Nanosecond)
+	_, _, err := repo.FindNearby(context.Background(), models.LatLng{Lat: 30.3477, Lng: -95.4502}, 5000, 10, nil, NearbyFilter{})
+	if !errors.Is(err, ErrQueryTimeout) {
+		t.Errorf("Expected ErrQueryTimeout, got: %v", err)
+	}
+}
+
+// TestStatementError tests which errors are marked as statement timeouts.
+func TestStatementError(t *testing.T) {
+	timeouts := []error{
+		context.DeadlineExceeded,
+		fmt.Errorf("timeout: %w", context.DeadlineExceeded),
+		&pgconn.PgError{Code: "57014", Message: "canceling statement due to statement timeout"},
+	}
+	for _, err := range timeouts {
+		if !errors.Is(statementError(err), ErrQueryTimeout) {
+			t.Errorf("Expected %v to be a statement timeout", err)
+		}
+	}
+
+	for _, err := range []error{context.Canceled, &pgconn.PgError{Code: "42P01"}, pgx.ErrNoRows} {
+		if errors.Is(statementError(err), ErrQueryTimeout) {
+			t.Errorf("Expected %v not to be a statement timeout", err)
+		}
+	}
+}
+
 // TestFindNearby_ContextTimeout tests context timeout.
 func TestFindNearby_ContextTimeout(t *testing.T) {
 	repo, db := setupTestRepository(t)
@@ -663,9 +1091,72 @@ func TestFindNearby_ContextTimeout(t *testing.T) {
 	lng := -95.4502
 	radiusMeters := 1000
 
-	_, err := (*repo).FindNearby(ctx, lat, lng, radiusMeters)
+	_, _, err := (*repo).FindNearby(ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit, nil, NearbyFilter{})
 	// Should get a context deadline exceeded error or nil if query was fast enough
 	if err != nil && ctx.Err() == nil {
 		t.Errorf("Expected context timeout error, got: %v", err)
 	}
 }
+
+// benchmarkExecModes are the query exec modes the hot path benchmarks compare:
+// cached prepared statements (the default), cached descriptions only, and
+// unprepared queries as used behind PgBouncer in transaction mode.
+var benchmarkExecModes = []string{"cache_statement", "cache_describe", "exec"}
+
+// benchmarkRepository connects with the given query exec mode, skipping the
+// benchmark in short mode or without a test database.
+func benchmarkRepository(b *testing.B, mode string) (ParcelRepository, *database.Database) {
+	if testing.Short() {
+		b.Skip("Skipping integration benchmark in short mode")
+	}
+
+	cfg := getTestConfig()
+	cfg.QueryExecMode = mode
+	db, err := database.NewPostgresPool(context.Background(), cfg)
+	if err != nil {
+		b.Skipf("Skipping benchmark without a test database: %v", err)
+	}
+
+	return NewParcelRepository(db, nil, 0), db
+}
+
+// BenchmarkFindByPoint compares point lookups across query exec modes, at
+// alternating simplify tolerances as map clients request them.
+func BenchmarkFindByPoint(b *testing.B) {
+	point := models.LatLng{Lat: 30.3477, Lng: -95.4502}
+	tolerances := []float64{0, 0.0001, 0.0005}
+
+	for _, mode := range benchmarkExecModes {
+		b.Run(mode, func(b *testing.B) {
+			repo, db := benchmarkRepository(b, mode)
+			defer db.Close()
+
+			b.ReportAllocs()
+			for i := 0; b.Loop(); i++ {
+				ctx := WithSimplifyTolerance(context.Background(), tolerances[i%len(tolerances)])
+				if _, err := repo.FindByPoint(ctx, point); err != nil {
+					b.Fatalf("FindByPoint returned error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkFindNearby compares first pages of nearby parcels across query exec modes.
+func BenchmarkFindNearby(b *testing.B) {
+	point := models.LatLng{Lat: 30.3477, Lng: -95.4502}
+
+	for _, mode := range benchmarkExecModes {
+		b.Run(mode, func(b *testing.B) {
+			repo, db := benchmarkRepository(b, mode)
+			defer db.Close()
+
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, _, err := repo.FindNearby(context.Background(), point, 500, testNearbyLimit, nil, NearbyFilter{}); err != nil {
+					b.Fatalf("FindNearby returned error: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// Quality flags of sampled parcels, in the order they are reported
+const (
+	QualityInvalidGeometry = "invalid_geometry" // not valid per PostGIS ST_IsValid
+	QualityEmptyGeometry   = "empty_geometry"
+	QualitySliver          = "sliver" // under sliverAcres, usually a digitizing artifact
+	QualityMissingOwner    = "missing_owner"
+	QualityMissingSitus    = "missing_situs"
+	QualityMissingTaxYear  = "missing_tax_year"
+)
+
+// sliverAcres is the area below which a parcel is flagged as a sliver (about 435 sq ft).
+const sliverAcres = 0.01
+
+// Sampling constants
+const (
+	// sampleOversampling is how many more rows than requested TABLESAMPLE aims
+	// for, so every stratum has rows to pick from and the final pick is random.
+	sampleOversampling = 10
+	// sampleGrowth multiplies the sampling percentage when a pass comes up short.
+	sampleGrowth = 10
+	// minSamplePercent keeps tiny samples of large tables from reading no block at all.
+	minSamplePercent = 0.01
+)
+
+// qualityFlagsColumn computes the quality flags of a parcel as a text array.
+var qualityFlagsColumn = fmt.Sprintf(`
+			array_remove(ARRAY[
+				CASE WHEN NOT ST_IsValid(geom) THEN '%s' END,
+				CASE WHEN ST_IsEmpty(geom) THEN '%s' END,
+				CASE WHEN NOT ST_IsEmpty(geom) AND %s < %g THEN '%s' END,
+				CASE WHEN COALESCE(btrim(owner_name), '') = '' THEN '%s' END,
+				CASE WHEN COALESCE(btrim(situs), '') = '' THEN '%s' END,
+				CASE WHEN p_year IS NULL THEN '%s' END
+			], NULL) as quality_flags`,
+	QualityInvalidGeometry, QualityEmptyGeometry, parcelAcresExpression, sliverAcres, QualitySliver,
+	QualityMissingOwner, QualityMissingSitus, QualityMissingTaxYear)
+
+// ParcelSample is a randomly sampled parcel with its data quality flags.
+// QualityFlags is empty for a parcel without issues.
+type ParcelSample struct {
+	QualityFlags []string
+	Parcel       models.TaxParcel
+}
+
+// SampleParcels samples with TABLESAMPLE SYSTEM, which reads random blocks
+// instead of the whole table. The percentage is estimated from the planner's
+// row count; a pass returning fewer than n parcels (a small county, stale
+// statistics) is retried with a larger percentage, up to the whole table.
+func (r *parcelRepository) SampleParcels(ctx context.Context, n int) ([]ParcelSample, error) {
+	ctx, cancel := r.withStatementTimeout(ctx)
+	defer cancel()
+
+	// reltuples is -1 for a table that was never analyzed
+	var estimate float64
+	err := r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		return q.QueryRow(ctx, `SELECT reltuples FROM pg_class WHERE oid = 'tax_parcels'::regclass`).Scan(&estimate)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate parcel count: %w", statementError(err))
+	}
+
+	for percent := samplePercent(n, estimate); ; percent = min(100, percent*sampleGrowth) {
+		samples, err := r.sampleParcels(ctx, n, percent)
+		if err != nil {
+			return nil, err
+		}
+		if len(samples) >= n || percent >= 100 {
+			return samples, nil
+		}
+	}
+}
+
+// samplePercent returns the TABLESAMPLE percentage expected to read
+// sampleOversampling times n rows of a table of about estimate rows.
+func samplePercent(n int, estimate float64) float64 {
+	if estimate <= 0 {
+		return 100
+	}
+	return min(100, max(minSamplePercent, float64(n)*sampleOversampling*100/estimate))
+}
+
+// sampleParcels runs one sampling pass. Strata are the parcels of a county and
+// tax year; ranking the sampled rows within their stratum and taking the lowest
+// ranks first allocates the sample equally between strata.
+func (r *parcelRepository) sampleParcels(ctx context.Context, n int, percent float64) ([]ParcelSample, error) {
+	query := `
+		SELECT ` + r.withAttributes(parcelColumnsFor(ctx)) + `,` + qualityFlagsColumn + `
+		FROM tax_parcels TABLESAMPLE SYSTEM ($1)
+		WHERE TRUE` + liveParcelClause + countyClause(3) + `
+		ORDER BY row_number() OVER (PARTITION BY county_id, p_year ORDER BY random()), random()
+		LIMIT $2
+	`
+
+	var results []ParcelSample
+	err := r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		rows, err := q.Query(ctx, query, percent, n, countyParam(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to sample parcels (n=%d, percent=%g): %w", n, percent, statementError(err))
+		}
+		defer rows.Close()
+
+		results = []ParcelSample{}
+
+		for rows.Next() {
+			var sample ParcelSample
+			var geomJSON []byte
+
+			if err := rows.Scan(append(r.parcelScanTargets(ctx, &sample.Parcel, &geomJSON), &sample.QualityFlags)...); err != nil {
+				return fmt.Errorf("failed to scan sampled parcel row: %w", err)
+			}
+
+			// Parse GeoJSON geometry
+			if err := sample.Parcel.Geom.Scan(geomJSON); err != nil {
+				return fmt.Errorf("failed to parse geometry for parcel %d: %w", sample.Parcel.ID, err)
+			}
+
+			results = append(results, sample)
+		}
+
+		// Check for errors during iteration
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating sampled parcel rows: %w", statementError(err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// QueryAuditRepository defines the interface for the parcel query audit log.
+type QueryAuditRepository interface {
+	// AddEntries stores the entries, and the replay captures attached to them;
+	// CreatedAt is stored as given, in UTC.
+	AddEntries(ctx context.Context, entries []models.QueryAudit) error
+
+	// ListReplay returns up to limit replay captures created at or after since,
+	// oldest first.
+	ListReplay(ctx context.Context, since time.Time, limit int) ([]models.ReplayQuery, error)
+}
+
+// queryAuditRepository is the concrete implementation of QueryAuditRepository.
+type queryAuditRepository struct {
+	db *database.Database
+}
+
+// NewQueryAuditRepository creates a new instance of QueryAuditRepository.
+func NewQueryAuditRepository(db *database.Database) QueryAuditRepository {
+	return &queryAuditRepository{
+		db: db,
+	}
+}
+
+// AddEntries inserts all entries in a single statement using parallel arrays.
+func (r *queryAuditRepository) AddEntries(ctx context.Context, entries []models.QueryAudit) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	createdAt := make([]time.Time, len(entries))
+	routes := make([]string, len(entries))
+	methods := make([]string, len(entries))
+	params := make([]string, len(entries))
+	prefixes := make([]string, len(entries))
+	tenants := make([]string, len(entries))
+	requestIDs := make([]string, len(entries))
+	statuses := make([]int32, len(entries))
+	latencies := make([]int32, len(entries))
+	counts := make([]*int32, len(entries))
+	for i, e := range entries {
+		encoded := []byte("{}")
+		if e.Params != nil {
+			var err error
+			if encoded, err = json.Marshal(e.Params); err != nil {
+				return fmt.Errorf("failed to encode query audit params (route=%s): %w", e.Route, err)
+			}
+		}
+
+		createdAt[i] = e.CreatedAt.UTC()
+		routes[i] = e.Route
+		methods[i] = e.Method
+		params[i] = string(encoded)
+		prefixes[i] = e.APIKeyPrefix
+		tenants[i] = e.TenantID
+		requestIDs[i] = e.RequestID
+		statuses[i] = int32(e.Status)     // #nosec G115 -- HTTP status codes fit
+		latencies[i] = int32(e.LatencyMS) // #nosec G115 -- capped by the server's write timeout
+		if e.ResultCount != nil {
+			count := int32(*e.ResultCount) // #nosec G115 -- bounded by the endpoints' limits
+			counts[i] = &count
+		}
+	}
+
+	query := `
+		INSERT INTO query_audit (created_at, route, method, params, api_key_prefix, tenant_id, request_id, status, latency_ms, result_count)
+		SELECT created_at, route, method, params::jsonb, api_key_prefix, tenant_id, request_id, status, latency_ms, result_count
+		FROM unnest($1::timestamp[], $2::text[], $3::text[], $4::text[], $5::text[], $6::text[], $7::text[],
+			$8::smallint[], $9::int[], $10::int[])
+			AS e(created_at, route, method, params, api_key_prefix, tenant_id, request_id, status, latency_ms, result_count)
+	`
+
+	if _, err := r.db.Pool.Exec(ctx, query, createdAt, routes, methods, params, prefixes, tenants, requestIDs,
+		statuses, latencies, counts); err != nil {
+		return fmt.Errorf("failed to record %d query audit entries: %w", len(entries), err)
+	}
+
+	return r.addReplay(ctx, entries)
+}
+
+// addReplay inserts the replay captures of entries in a single statement.
+func (r *queryAuditRepository) addReplay(ctx context.Context, entries []models.QueryAudit) error {
+	var (
+		createdAt []time.Time
+		routes    []string
+		methods   []string
+		paths     []string
+		queries   []string
+		bodies    []string
+		statuses  []int32
+		latencies []int32
+		counts    []*int32
+	)
+	for _, e := range entries {
+		q := e.Replay
+		if q == nil {
+			continue
+		}
+		createdAt = append(createdAt, q.CreatedAt.UTC())
+		routes = append(routes, q.Route)
+		methods = append(methods, q.Method)
+		paths = append(paths, q.Path)
+		queries = append(queries, q.Query)
+		bodies = append(bodies, q.Body)
+		statuses = append(statuses, int32(q.Status))      // #nosec G115 -- HTTP status codes fit
+		latencies = append(latencies, int32(q.LatencyMS)) // #nosec G115 -- capped by the server's write timeout
+		var count *int32
+		if q.ResultCount != nil {
+			c := int32(*q.ResultCount) // #nosec G115 -- bounded by the endpoints' limits
+			count = &c
+		}
+		counts = append(counts, count)
+	}
+	if len(routes) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO query_replay (created_at, route, method, path, query, body, status, latency_ms, result_count)
+		SELECT * FROM unnest($1::timestamp[], $2::text[], $3::text[], $4::text[], $5::text[], $6::text[],
+			$7::smallint[], $8::int[], $9::int[])
+	`
+
+	if _, err := r.db.Pool.Exec(ctx, query, createdAt, routes, methods, paths, queries, bodies,
+		statuses, latencies, counts); err != nil {
+		return fmt.Errorf("failed to record %d query replay captures: %w", len(routes), err)
+	}
+
+	return nil
+}
+
+// ListReplay reads the captures in insertion order.
+func (r *queryAuditRepository) ListReplay(ctx context.Context, since time.Time, limit int) ([]models.ReplayQuery, error) {
+	query := `
+		SELECT id, created_at, route, method, path, query, body, status, latency_ms, result_count
+		FROM query_replay
+		WHERE created_at >= $1
+		ORDER BY id
+		LIMIT $2
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, since.UTC(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list query replay captures (since=%s, limit=%d): %w", since.Format(time.RFC3339), limit, err)
+	}
+	defer rows.Close()
+
+	captures := []models.ReplayQuery{}
+	for rows.Next() {
+		var q models.ReplayQuery
+		if err := rows.Scan(&q.ID, &q.CreatedAt, &q.Route, &q.Method, &q.Path, &q.Query, &q.Body,
+			&q.Status, &q.LatencyMS, &q.ResultCount); err != nil {
+			return nil, fmt.Errorf("failed to scan query replay row: %w", err)
+		}
+		captures = append(captures, q)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating query replay rows: %w", err)
+	}
+
+	return captures, nil
+}
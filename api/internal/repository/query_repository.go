@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// ErrQueryCanceled is returned when a query exceeds its timeout.
+var ErrQueryCanceled = errors.New("query exceeded its timeout")
+
+// ErrQueryRejected is returned when PostgreSQL rejects a query's parameter
+// values, e.g. a number out of range for its column.
+var ErrQueryRejected = errors.New("query rejected its parameters")
+
+// QueryRepository defines the interface for running analyst query templates.
+type QueryRepository interface {
+	// Run executes sql with args in a read-only transaction, stopping after
+	// limit rows and cancelling the statement after timeout.
+	// Returns ErrQueryCanceled on timeout and ErrQueryRejected for invalid values.
+	Run(ctx context.Context, sql string, args []any, limit int, timeout time.Duration) (*models.QueryResult, error)
+}
+
+// queryRepository is the concrete implementation of QueryRepository.
+type queryRepository struct {
+	db *database.Database
+}
+
+// NewQueryRepository creates a new instance of QueryRepository. db should be
+// a read replica; writes are refused either way.
+func NewQueryRepository(db *database.Database) QueryRepository {
+	return &queryRepository{
+		db: db,
+	}
+}
+
+// Run executes a query template.
+func (r *queryRepository) Run(ctx context.Context, sql string, args []any, limit int, timeout time.Duration) (*models.QueryResult, error) {
+	// The server-side timeout cancels the statement; the context also covers
+	// waiting for a connection
+	ctx, cancel := context.WithTimeout(ctx, timeout+time.Second)
+	defer cancel()
+
+	tx, err := r.db.Pool.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin read-only transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// SET does not take parameters; the value is an integer we format
+	if _, err := tx.Exec(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", timeout.Milliseconds())); err != nil {
+		return nil, fmt.Errorf("failed to set statement timeout: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	defer rows.Close()
+
+	fields := rows.FieldDescriptions()
+	result := &models.QueryResult{Columns: make([]string, len(fields)), Rows: [][]any{}}
+	for i, f := range fields {
+		result.Columns[i] = f.Name
+	}
+	for rows.Next() {
+		if len(result.Rows) == limit {
+			result.Truncated = true
+			break
+		}
+		values, err := rows.Values()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read query row: %w", err)
+		}
+		for i, v := range values {
+			values[i] = jsonValue(v)
+		}
+		result.Rows = append(result.Rows, values)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, queryError(err)
+	}
+
+	result.RowCount = len(result.Rows)
+	return result, nil
+}
+
+// queryError maps statement timeouts and invalid parameter values to the
+// repository errors.
+func queryError(err error) error {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.As(err, &pgErr) && pgErr.Code == "57014": // query_canceled
+		return ErrQueryCanceled
+	case errors.As(err, &pgErr) && pgErr.Code[:2] == "22": // data exception
+		return fmt.Errorf("%w: %s", ErrQueryRejected, pgErr.Message)
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrQueryCanceled
+	}
+	return fmt.Errorf("failed to run query: %w", err)
+}
+
+// jsonValue converts pgx values without a useful JSON encoding. NaN and
+// infinities, which JSON cannot represent, become null.
+func jsonValue(v any) any {
+	switch value := v.(type) {
+	case pgtype.Numeric:
+		f, err := value.Float64Value()
+		if err != nil || !f.Valid {
+			return nil
+		}
+		return jsonValue(f.Float64)
+	case float64:
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return nil
+		}
+	case float32:
+		return jsonValue(float64(value))
+	case [16]byte:
+		return uuid.UUID(value).String()
+	}
+	return v
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/database"
+)
+
+// Tables with a retention policy
+const (
+	RetentionSecurityEvents = "security_events"
+	RetentionQueryHotspots  = "query_hotspots"
+	RetentionIngestionRuns  = "ingestion_runs"
+	RetentionQueryAudit     = "query_audit"
+	RetentionQueryReplay    = "query_replay"
+	// RetentionWebhookDeliveries prunes delivered and failed deliveries only
+	RetentionWebhookDeliveries = "webhook_deliveries"
+	// RetentionTasks prunes succeeded and failed tasks only
+	RetentionTasks = "tasks"
+	// RetentionParcelVersions prunes prior parcel versions by when they were replaced
+	RetentionParcelVersions = "parcel_versions"
+)
+
+// retentionFilters select the expired rows of each table, created before $1.
+// The newest activated or forced import of each county is never expired: it is
+// the anomaly baseline of the county's next import and the dataset version
+// cache warming compares against. Pending webhook deliveries are still to be
+// sent, and pending or running tasks still to be run. A parcel version expires
+// by valid_to, when it stopped being the parcel's current version.
+var retentionFilters = map[string]string{
+	RetentionSecurityEvents:    `created_at < $1`,
+	RetentionQueryHotspots:     `hit_date < $1::date`,
+	RetentionQueryAudit:        `created_at < $1`,
+	RetentionQueryReplay:       `created_at < $1`,
+	RetentionWebhookDeliveries: `created_at < $1 AND status <> 'pending'`,
+	RetentionTasks:             `created_at < $1 AND status IN ('succeeded', 'failed')`,
+	RetentionParcelVersions:    `valid_to < $1`,
+	RetentionIngestionRuns: `created_at < $1 AND id NOT IN (
+		SELECT MAX(id) FROM ingestion_runs WHERE status IN ('activated', 'forced') GROUP BY county
+	)`,
+}
+
+// RetentionRepository defines the interface for pruning operational tables.
+// Table is one of the Retention* constants; others return an error.
+type RetentionRepository interface {
+	// CountExpired counts the rows of table created before before.
+	CountExpired(ctx context.Context, table string, before time.Time) (int64, error)
+
+	// DeleteExpired deletes up to limit rows of table created before before and
+	// returns how many it deleted.
+	DeleteExpired(ctx context.Context, table string, before time.Time, limit int) (int64, error)
+}
+
+// retentionRepository is the concrete implementation of RetentionRepository.
+type retentionRepository struct {
+	db *database.Database
+}
+
+// NewRetentionRepository creates a new instance of RetentionRepository.
+func NewRetentionRepository(db *database.Database) RetentionRepository {
+	return &retentionRepository{
+		db: db,
+	}
+}
+
+// CountExpired counts with the same filter DeleteExpired deletes with.
+func (r *retentionRepository) CountExpired(ctx context.Context, table string, before time.Time) (int64, error) {
+	filter, ok := retentionFilters[table]
+	if !ok {
+		return 0, fmt.Errorf("no retention policy for table %q", table)
+	}
+
+	// #nosec G202 -- table and filter come from retentionFilters, not from input
+	query := `SELECT COUNT(*) FROM ` + table + ` WHERE ` + filter
+
+	var count int64
+	if err := r.db.Pool.QueryRow(ctx, query, before.UTC()).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count expired %s rows (before=%s): %w", table, before.UTC().Format(time.RFC3339), err)
+	}
+
+	return count, nil
+}
+
+// DeleteExpired deletes one batch, selecting the rows by ctid so the statement
+// works on tables without a single-column key.
+func (r *retentionRepository) DeleteExpired(ctx context.Context, table string, before time.Time, limit int) (int64, error) {
+	filter, ok := retentionFilters[table]
+	if !ok {
+		return 0, fmt.Errorf("no retention policy for table %q", table)
+	}
+
+	// #nosec G202 -- table and filter come from retentionFilters, not from input
+	query := `DELETE FROM ` + table + ` WHERE ctid IN (
+		SELECT ctid FROM ` + table + ` WHERE ` + filter + ` LIMIT $2
+	)`
+
+	tag, err := r.db.Pool.Exec(ctx, query, before.UTC(), limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired %s rows (before=%s): %w", table, before.UTC().Format(time.RFC3339), err)
+	}
+
+	return tag.RowsAffected(), nil
+}
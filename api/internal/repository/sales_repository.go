@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// SalesRepository defines the interface for parcel sales data access operations.
+type SalesRepository interface {
+	// FindForParcel returns the sales of the live parcel with the given id,
+	// newest first: those of its county whose prop_id is the parcel's pid.
+	// Returns an empty slice if the parcel has no recorded sales, and nil if
+	// no live parcel has the id (neither is an error).
+	FindForParcel(ctx context.Context, parcelID uint) ([]models.ParcelSale, error)
+}
+
+// salesRepository is the concrete implementation of SalesRepository.
+type salesRepository struct {
+	db *database.Database
+}
+
+// NewSalesRepository creates a new instance of SalesRepository.
+func NewSalesRepository(db *database.Database) SalesRepository {
+	return &salesRepository{
+		db: db,
+	}
+}
+
+// FindForParcel left joins the parcel to its sales, so a parcel without sales
+// yields one row of NULL sale columns and a missing parcel none.
+func (r *salesRepository) FindForParcel(ctx context.Context, parcelID uint) ([]models.ParcelSale, error) {
+	query := `
+		SELECT s.id, s.sale_date, s.price, s.deed_type, s.grantor, s.grantee, s.instrument
+		FROM tax_parcels p
+		LEFT JOIN parcel_sales s ON s.county_id = p.county_id AND s.prop_id = p.pid
+		WHERE p.id = $1` + liveParcelClause + `
+		ORDER BY s.sale_date DESC, s.id DESC
+	`
+
+	var sales []models.ParcelSale
+	err := r.db.Read(ctx, func(ctx context.Context, q database.Querier) error {
+		rows, err := q.Query(ctx, query, parcelID)
+		if err != nil {
+			return fmt.Errorf("failed to query parcel sales (parcel=%d): %w", parcelID, err)
+		}
+		defer rows.Close()
+
+		sales = nil
+		for rows.Next() {
+			if sales == nil {
+				sales = []models.ParcelSale{}
+			}
+
+			var id *uint
+			var sale models.ParcelSale
+			var saleDate *time.Time
+			if err := rows.Scan(&id, &saleDate, &sale.Price, &sale.DeedType, &sale.Grantor, &sale.Grantee, &sale.Instrument); err != nil {
+				return fmt.Errorf("failed to scan parcel sale row: %w", err)
+			}
+			if id == nil {
+				continue
+			}
+			sale.ID, sale.SaleDate = *id, *saleDate
+			sales = append(sales, sale)
+		}
+
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating parcel sale rows: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sales, nil
+}
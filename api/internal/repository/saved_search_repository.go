@@ -0,0 +1,317 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// SavedSearchRepository defines the interface for saved search and match data
+// access operations.
+type SavedSearchRepository interface {
+	// Create stores a new search and returns it with its ID and timestamps.
+	// It is first evaluated by the next evaluation after its creation.
+	Create(ctx context.Context, search *models.SavedSearch) (*models.SavedSearch, error)
+
+	// List returns the user's searches with their match counts, newest first.
+	// Returns an empty slice if the user has no searches (not an error).
+	List(ctx context.Context, userID uint) ([]models.SavedSearch, error)
+
+	// ListAll returns the searches of all users, oldest first, without match counts.
+	// Returns an empty slice if there are no searches (not an error).
+	ListAll(ctx context.Context) ([]models.SavedSearch, error)
+
+	// Count returns the number of searches the user has.
+	Count(ctx context.Context, userID uint) (int, error)
+
+	// Delete deletes the user's search and its matches.
+	// Returns false if the user has no such search (not an error).
+	Delete(ctx context.Context, userID, id uint) (bool, error)
+
+	// FindMatches returns up to limit matches of the user's search, newest first.
+	// Returns nil, nil if the user has no such search (not an error).
+	FindMatches(ctx context.Context, userID, id uint, limit int) ([]models.SavedSearchMatch, error)
+
+	// Evaluate records a match for every parcel added, changed or deleted since
+	// the search was last evaluated that intersects its area and matches its
+	// filters and county, and moves EvaluatedAt to now, in one statement.
+	// Returns the number of matches recorded; 0 if the search was deleted.
+	Evaluate(ctx context.Context, search models.SavedSearch, runID int64) (int64, error)
+
+	// LatestIngestionRun returns the id of the most recent ingestion run that
+	// replaced or synced parcels (status activated or forced).
+	// Returns 0 if no import has been recorded (not an error).
+	LatestIngestionRun(ctx context.Context) (int64, error)
+}
+
+// savedSearchRepository is the concrete implementation of SavedSearchRepository.
+type savedSearchRepository struct {
+	db *database.Database
+}
+
+// NewSavedSearchRepository creates a new instance of SavedSearchRepository.
+func NewSavedSearchRepository(db *database.Database) SavedSearchRepository {
+	return &savedSearchRepository{
+		db: db,
+	}
+}
+
+// savedSearchColumns is the column list selected for a full SavedSearch row,
+// without its match count.
+const savedSearchColumns = `id, user_id, name, ST_AsGeoJSON(area), filters, county, created_at, evaluated_at`
+
+// scanSavedSearch scans a row selected with savedSearchColumns, followed by
+// the extra targets, into a SavedSearch.
+func scanSavedSearch(row pgx.Row, extra ...any) (*models.SavedSearch, error) {
+	var search models.SavedSearch
+	var areaJSON, filtersJSON []byte
+
+	targets := append([]any{
+		&search.ID,
+		&search.UserID,
+		&search.Name,
+		&areaJSON,
+		&filtersJSON,
+		&search.County,
+		&search.CreatedAt,
+		&search.EvaluatedAt,
+	}, extra...)
+	if err := row.Scan(targets...); err != nil {
+		return nil, err
+	}
+
+	if err := search.Area.Scan(areaJSON); err != nil {
+		return nil, fmt.Errorf("failed to parse area for saved search %d: %w", search.ID, err)
+	}
+	if err := json.Unmarshal(filtersJSON, &search.Filters); err != nil {
+		return nil, fmt.Errorf("failed to parse filters for saved search %d: %w", search.ID, err)
+	}
+
+	return &search, nil
+}
+
+// Create inserts a search row; evaluated_at defaults to now, so only later
+// changes match.
+func (r *savedSearchRepository) Create(ctx context.Context, search *models.SavedSearch) (*models.SavedSearch, error) {
+	area, err := search.Area.Value()
+	if err != nil {
+		return nil, err
+	}
+	filters, err := json.Marshal(search.Filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal saved search filters: %w", err)
+	}
+
+	query := `
+		INSERT INTO saved_searches (user_id, name, area, filters, county, created_at, evaluated_at)
+		VALUES ($1, $2, ST_Multi(ST_SetSRID(ST_GeomFromGeoJSON($3::text), 4326)), $4, $5, NOW(), NOW())
+		RETURNING ` + savedSearchColumns
+
+	stored, err := scanSavedSearch(r.db.Pool.QueryRow(ctx, query, search.UserID, search.Name, area, filters, search.County))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create saved search (user=%d, name=%s): %w", search.UserID, search.Name, err)
+	}
+
+	return stored, nil
+}
+
+// List queries the user's searches, counting the matches of each.
+func (r *savedSearchRepository) List(ctx context.Context, userID uint) ([]models.SavedSearch, error) {
+	query := `
+		SELECT ` + savedSearchColumns + `,
+			(SELECT COUNT(*) FROM saved_search_matches m WHERE m.saved_search_id = saved_searches.id)
+		FROM saved_searches
+		WHERE user_id = $1
+		ORDER BY id DESC
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saved searches (user=%d): %w", userID, err)
+	}
+	defer rows.Close()
+
+	searches := []models.SavedSearch{}
+	for rows.Next() {
+		var matches int64
+		search, err := scanSavedSearch(rows, &matches)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan saved search row: %w", err)
+		}
+		search.MatchCount = matches
+		searches = append(searches, *search)
+	}
+
+	// Check for errors during iteration
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating saved search rows: %w", err)
+	}
+
+	return searches, nil
+}
+
+// ListAll queries every search.
+func (r *savedSearchRepository) ListAll(ctx context.Context) ([]models.SavedSearch, error) {
+	query := `SELECT ` + savedSearchColumns + ` FROM saved_searches ORDER BY id`
+
+	rows, err := r.db.Pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list all saved searches: %w", err)
+	}
+	defer rows.Close()
+
+	searches := []models.SavedSearch{}
+	for rows.Next() {
+		search, err := scanSavedSearch(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan saved search row: %w", err)
+		}
+		searches = append(searches, *search)
+	}
+
+	// Check for errors during iteration
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating saved search rows: %w", err)
+	}
+
+	return searches, nil
+}
+
+// Count counts the user's searches.
+func (r *savedSearchRepository) Count(ctx context.Context, userID uint) (int, error) {
+	var count int
+	if err := r.db.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM saved_searches WHERE user_id = $1`, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count saved searches (user=%d): %w", userID, err)
+	}
+	return count, nil
+}
+
+// Delete removes the search; its matches are deleted by the foreign key cascade.
+func (r *savedSearchRepository) Delete(ctx context.Context, userID, id uint) (bool, error) {
+	tag, err := r.db.Pool.Exec(ctx, `DELETE FROM saved_searches WHERE user_id = $1 AND id = $2`, userID, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete saved search (user=%d, id=%d): %w", userID, id, err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// FindMatches checks that the search belongs to the user before reading its matches.
+func (r *savedSearchRepository) FindMatches(ctx context.Context, userID, id uint, limit int) ([]models.SavedSearchMatch, error) {
+	var exists bool
+	err := r.db.Pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM saved_searches WHERE user_id = $1 AND id = $2)`, userID, id).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query saved search (user=%d, id=%d): %w", userID, id, err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	query := `
+		SELECT id, saved_search_id, parcel_id, pin, situs, change, ingestion_run_id, matched_at
+		FROM saved_search_matches
+		WHERE saved_search_id = $1
+		ORDER BY id DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, id, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query saved search matches (id=%d, limit=%d): %w", id, limit, err)
+	}
+	defer rows.Close()
+
+	matches := []models.SavedSearchMatch{}
+	for rows.Next() {
+		var m models.SavedSearchMatch
+		if err := rows.Scan(&m.ID, &m.SavedSearchID, &m.ParcelID, &m.PIN, &m.Situs, &m.Change, &m.IngestionRunID, &m.MatchedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan saved search match row: %w", err)
+		}
+		matches = append(matches, m)
+	}
+
+	// Check for errors during iteration
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating saved search match rows: %w", err)
+	}
+
+	return matches, nil
+}
+
+// Evaluate reads the previous evaluated_at in the same UPDATE that advances it,
+// so concurrent evaluations of a search never record a change twice. The
+// filter and county parameters come from the search, as for nearby queries.
+// Deleted parcels are matched by their last boundary and attributes.
+func (r *savedSearchRepository) Evaluate(ctx context.Context, search models.SavedSearch, runID int64) (int64, error) {
+	query := `
+		WITH search AS (
+			UPDATE saved_searches s
+			SET evaluated_at = NOW()
+			FROM saved_searches previous
+			WHERE s.id = $1 AND previous.id = s.id
+			RETURNING s.area, previous.evaluated_at
+		)
+		INSERT INTO saved_search_matches (saved_search_id, parcel_id, pin, situs, change, ingestion_run_id, matched_at)
+		SELECT $1, tax_parcels.id, pin, situs,
+			CASE
+				WHEN deleted_at IS NOT NULL THEN '` + models.ParcelChangeDeleted + `'
+				WHEN created_at > search.evaluated_at THEN '` + models.ParcelChangeAdded + `'
+				ELSE '` + models.ParcelChangeChanged + `'
+			END,
+			$2, NOW()
+		FROM tax_parcels, search
+		WHERE COALESCE(updated_at, created_at) > search.evaluated_at
+			AND ST_Intersects(geom, search.area)` + nearbyFilterClause(3) + countyClause(3+nearbyFilterParams)
+
+	args := append([]any{search.ID, runID}, savedSearchFilter(search.Filters).params()...)
+	args = append(args, search.County)
+
+	tag, err := r.db.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to evaluate saved search %d: %w", search.ID, err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// LatestIngestionRun reads the newest run that changed the dataset; blocked runs
+// left tax_parcels unchanged and are ignored.
+func (r *savedSearchRepository) LatestIngestionRun(ctx context.Context) (int64, error) {
+	return latestIngestionRun(ctx, r.db)
+}
+
+// latestIngestionRun returns the id of the newest activated or forced ingestion
+// run, or 0 if there is none. The alerts and webhooks jobs poll it to detect imports.
+func latestIngestionRun(ctx context.Context, db *database.Database) (int64, error) {
+	query := `
+		SELECT COALESCE(MAX(id), 0)
+		FROM ingestion_runs
+		WHERE status IN ('activated', 'forced')
+	`
+
+	var id int64
+	if err := db.Pool.QueryRow(ctx, query).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to query latest ingestion run: %w", err)
+	}
+
+	return id, nil
+}
+
+// savedSearchFilter converts the filters of a saved search to a NearbyFilter.
+func savedSearchFilter(f models.SavedSearchFilters) NearbyFilter {
+	return NearbyFilter{
+		MinAcres:            f.MinAcres,
+		MaxAcres:            f.MaxAcres,
+		MinYearBuilt:        f.MinYearBuilt,
+		MaxYearBuilt:        f.MaxYearBuilt,
+		Waterfront:          f.Waterfront,
+		LandUse:             f.LandUse,
+		OwnerName:           f.OwnerName,
+		Amenity:             f.Amenity,
+		AmenityWithinMeters: f.AmenityWithin,
+	}
+}
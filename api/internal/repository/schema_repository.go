@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+)
+
+// SchemaRepository defines the interface for reading the live schema of the
+// public schema from the Postgres catalogs.
+type SchemaRepository interface {
+	// MigrationVersion returns the version golang-migrate recorded in
+	// schema_migrations and whether it is dirty; 0 when nothing was migrated.
+	MigrationVersion(ctx context.Context) (uint, bool, error)
+
+	// Indexes returns the names of all indexes.
+	Indexes(ctx context.Context) ([]string, error)
+
+	// Columns returns the type of each column of each table, as printed by
+	// format_type, by table and column name.
+	Columns(ctx context.Context) (map[string]map[string]string, error)
+}
+
+// schemaRepository is the concrete implementation of SchemaRepository.
+type schemaRepository struct {
+	db *database.Database
+}
+
+// NewSchemaRepository creates a new instance of SchemaRepository.
+func NewSchemaRepository(db *database.Database) SchemaRepository {
+	return &schemaRepository{
+		db: db,
+	}
+}
+
+// MigrationVersion checks for the table first, since it only exists once
+// golang-migrate has run.
+func (r *schemaRepository) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	var exists bool
+	if err := r.db.Pool.QueryRow(ctx, `SELECT to_regclass('public.schema_migrations') IS NOT NULL`).Scan(&exists); err != nil {
+		return 0, false, fmt.Errorf("failed to look up schema_migrations: %w", err)
+	}
+	if !exists {
+		return 0, false, nil
+	}
+
+	var version int64
+	var dirty bool
+	err := r.db.Pool.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read migration version: %w", err)
+	}
+
+	return uint(version), dirty, nil
+}
+
+// Indexes includes the indexes backing primary keys and unique constraints.
+func (r *schemaRepository) Indexes(ctx context.Context) ([]string, error) {
+	rows, err := r.db.Pool.Query(ctx, `SELECT indexname FROM pg_indexes WHERE schemaname = 'public' ORDER BY indexname`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list indexes: %w", err)
+	}
+
+	defer rows.Close()
+
+	var indexes []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan index: %w", err)
+		}
+		indexes = append(indexes, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate indexes: %w", err)
+	}
+
+	return indexes, nil
+}
+
+// Columns reads pg_attribute rather than information_schema.columns, whose
+// data_type drops lengths and PostGIS geometry types.
+func (r *schemaRepository) Columns(ctx context.Context) (map[string]map[string]string, error) {
+	rows, err := r.db.Pool.Query(ctx, `
+		SELECT c.relname, a.attname, format_type(a.atttypid, a.atttypmod)
+		FROM pg_attribute a
+		JOIN pg_class c ON c.oid = a.attrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = 'public' AND c.relkind IN ('r', 'p') AND a.attnum > 0 AND NOT a.attisdropped`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list columns: %w", err)
+	}
+	defer rows.Close()
+
+	tables := make(map[string]map[string]string)
+	for rows.Next() {
+		var table, column, columnType string
+		if err := rows.Scan(&table, &column, &columnType); err != nil {
+			return nil, fmt.Errorf("failed to scan column: %w", err)
+		}
+		if tables[table] == nil {
+			tables[table] = make(map[string]string)
+		}
+		tables[table][column] = columnType
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate columns: %w", err)
+	}
+
+	return tables, nil
+}
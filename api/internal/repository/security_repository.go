@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// SecurityRepository defines the interface for the security event audit log.
+type SecurityRepository interface {
+	// AddEvents stores the events; CreatedAt is stored as given, in UTC.
+	AddEvents(ctx context.Context, events []models.SecurityEvent) error
+
+	// Summarize counts the events on or after since, per hour and for up to
+	// limit client IPs and subjects with the most failures. ActiveLockouts is
+	// left empty; lockouts are tracked in memory.
+	Summarize(ctx context.Context, since time.Time, limit int) (*models.SecuritySummary, error)
+}
+
+// securityRepository is the concrete implementation of SecurityRepository.
+type securityRepository struct {
+	db *database.Database
+}
+
+// NewSecurityRepository creates a new instance of SecurityRepository.
+func NewSecurityRepository(db *database.Database) SecurityRepository {
+	return &securityRepository{
+		db: db,
+	}
+}
+
+// AddEvents inserts all events in a single statement using parallel arrays.
+func (r *securityRepository) AddEvents(ctx context.Context, events []models.SecurityEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	createdAt := make([]time.Time, len(events))
+	types := make([]string, len(events))
+	ips := make([]string, len(events))
+	subjects := make([]string, len(events))
+	routes := make([]string, len(events))
+	details := make([]string, len(events))
+	for i, e := range events {
+		createdAt[i] = e.CreatedAt.UTC()
+		types[i] = e.Type
+		ips[i] = e.ClientIP
+		subjects[i] = e.Subject
+		routes[i] = e.Route
+		details[i] = e.Detail
+	}
+
+	query := `
+		INSERT INTO security_events (created_at, event_type, client_ip, subject, route, detail)
+		SELECT * FROM unnest($1::timestamp[], $2::text[], $3::text[], $4::text[], $5::text[], $6::text[])
+	`
+
+	if _, err := r.db.Pool.Exec(ctx, query, createdAt, types, ips, subjects, routes, details); err != nil {
+		return fmt.Errorf("failed to record %d security events: %w", len(events), err)
+	}
+
+	return nil
+}
+
+// Summarize runs the totals, hourly and top-N aggregations as one batch.
+func (r *securityRepository) Summarize(ctx context.Context, since time.Time, limit int) (*models.SecuritySummary, error) {
+	since = since.UTC()
+	summary := &models.SecuritySummary{
+		Since:          since,
+		Hourly:         []models.SecurityHourCount{},
+		TopClients:     []models.SecurityClient{},
+		TopSubjects:    []models.SecuritySubject{},
+		ActiveLockouts: []models.SecurityLockout{},
+	}
+
+	batch := &pgx.Batch{}
+	batch.Queue(`
+		SELECT
+			COUNT(*) FILTER (WHERE event_type = 'auth_failure'),
+			COUNT(*) FILTER (WHERE event_type = 'lockout')
+		FROM security_events
+		WHERE created_at >= $1
+	`, since).QueryRow(func(row pgx.Row) error {
+		return row.Scan(&summary.Failures, &summary.Lockouts)
+	})
+
+	batch.Queue(`
+		SELECT
+			date_trunc('hour', created_at) as hour,
+			COUNT(*) FILTER (WHERE event_type = 'auth_failure'),
+			COUNT(*) FILTER (WHERE event_type = 'lockout')
+		FROM security_events
+		WHERE created_at >= $1
+		GROUP BY hour
+		ORDER BY hour
+	`, since).Query(func(rows pgx.Rows) error {
+		for rows.Next() {
+			var count models.SecurityHourCount
+			if err := rows.Scan(&count.Hour, &count.Failures, &count.Lockouts); err != nil {
+				return err
+			}
+			summary.Hourly = append(summary.Hourly, count)
+		}
+		return rows.Err()
+	})
+
+	// Lockout events of a subject also carry the client IP that triggered them,
+	// so only failures count toward the distinct subjects and clients
+	batch.Queue(`
+		SELECT
+			client_ip,
+			COUNT(*) FILTER (WHERE event_type = 'auth_failure') as failures,
+			COUNT(*) FILTER (WHERE event_type = 'lockout'),
+			COUNT(DISTINCT subject) FILTER (WHERE event_type = 'auth_failure' AND subject <> ''),
+			MAX(created_at)
+		FROM security_events
+		WHERE created_at >= $1
+		GROUP BY client_ip
+		ORDER BY failures DESC, client_ip
+		LIMIT $2
+	`, since, limit).Query(func(rows pgx.Rows) error {
+		for rows.Next() {
+			var client models.SecurityClient
+			if err := rows.Scan(&client.ClientIP, &client.Failures, &client.Lockouts, &client.Subjects, &client.LastSeen); err != nil {
+				return err
+			}
+			summary.TopClients = append(summary.TopClients, client)
+		}
+		return rows.Err()
+	})
+
+	batch.Queue(`
+		SELECT
+			subject,
+			COUNT(*) FILTER (WHERE event_type = 'auth_failure') as failures,
+			COUNT(*) FILTER (WHERE event_type = 'lockout'),
+			COUNT(DISTINCT client_ip) FILTER (WHERE event_type = 'auth_failure'),
+			MAX(created_at)
+		FROM security_events
+		WHERE created_at >= $1 AND subject <> ''
+		GROUP BY subject
+		ORDER BY failures DESC, subject
+		LIMIT $2
+	`, since, limit).Query(func(rows pgx.Rows) error {
+		for rows.Next() {
+			var subject models.SecuritySubject
+			if err := rows.Scan(&subject.Subject, &subject.Failures, &subject.Lockouts, &subject.Clients, &subject.LastSeen); err != nil {
+				return err
+			}
+			summary.TopSubjects = append(summary.TopSubjects, subject)
+		}
+		return rows.Err()
+	})
+
+	if err := r.db.Pool.SendBatch(ctx, batch).Close(); err != nil {
+		return nil, fmt.Errorf("failed to summarize security events since %s: %w", since.Format(time.RFC3339), err)
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// ShareLinkRepository defines the interface for share link data access operations.
+type ShareLinkRepository interface {
+	// Create stores a new link and returns it with its ID and creation time.
+	// Returns nil, nil if the token is already taken (not an error).
+	Create(ctx context.Context, link *models.ShareLink) (*models.ShareLink, error)
+
+	// Resolve finds the unexpired link with the given token and counts a click.
+	// Returns nil, nil if no unexpired link is found (not an error).
+	Resolve(ctx context.Context, token string) (*models.ShareLink, error)
+}
+
+// shareLinkRepository is the concrete implementation of ShareLinkRepository.
+type shareLinkRepository struct {
+	db *database.Database
+}
+
+// NewShareLinkRepository creates a new instance of ShareLinkRepository.
+func NewShareLinkRepository(db *database.Database) ShareLinkRepository {
+	return &shareLinkRepository{
+		db: db,
+	}
+}
+
+// shareLinkColumns is the column list selected for a full ShareLink row.
+const shareLinkColumns = `id, token, view, tenant_id, clicks, last_clicked_at, created_at, expires_at`
+
+// scanShareLink scans a row selected with shareLinkColumns into a ShareLink.
+func scanShareLink(row pgx.Row) (*models.ShareLink, error) {
+	var link models.ShareLink
+	var viewJSON []byte
+
+	err := row.Scan(
+		&link.ID,
+		&link.Token,
+		&viewJSON,
+		&link.TenantID,
+		&link.Clicks,
+		&link.LastClickedAt,
+		&link.CreatedAt,
+		&link.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(viewJSON, &link.View); err != nil {
+		return nil, fmt.Errorf("failed to parse view for share link %d: %w", link.ID, err)
+	}
+
+	return &link, nil
+}
+
+// Create inserts a share link row unless the token is taken.
+func (r *shareLinkRepository) Create(ctx context.Context, link *models.ShareLink) (*models.ShareLink, error) {
+	viewJSON, err := json.Marshal(link.View)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal share link view: %w", err)
+	}
+
+	query := `
+		INSERT INTO share_links (token, view, tenant_id, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (token) DO NOTHING
+		RETURNING ` + shareLinkColumns
+
+	stored, err := scanShareLink(r.db.Pool.QueryRow(ctx, query, link.Token, viewJSON, link.TenantID, link.ExpiresAt))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to create share link: %w", err)
+	}
+
+	return stored, nil
+}
+
+// Resolve increments the click count of an unexpired link in the same statement
+// that reads it, so concurrent clicks are all counted.
+func (r *shareLinkRepository) Resolve(ctx context.Context, token string) (*models.ShareLink, error) {
+	query := `
+		UPDATE share_links
+		SET clicks = clicks + 1, last_clicked_at = NOW()
+		WHERE token = $1 AND expires_at > NOW()
+		RETURNING ` + shareLinkColumns
+
+	link, err := scanShareLink(r.db.Pool.QueryRow(ctx, query, token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to resolve share link: %w", err)
+	}
+
+	return link, nil
+}
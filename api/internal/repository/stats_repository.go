@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// StatsRepository defines the interface for dataset statistics snapshot operations.
+type StatsRepository interface {
+	// CaptureSnapshot aggregates tax_parcels per county and stores the result under
+	// the given date, replacing any snapshot already captured for that date.
+	// Returns the number of county rows written.
+	CaptureSnapshot(ctx context.Context, date time.Time) (int64, error)
+
+	// ListSnapshots returns snapshots between from and to (inclusive), ordered by
+	// date then county. An empty county returns every county.
+	// Returns an empty slice if no snapshots exist in the range (not an error).
+	ListSnapshots(ctx context.Context, county string, from, to time.Time) ([]models.StatsSnapshot, error)
+}
+
+// statsRepository is the concrete implementation of StatsRepository.
+type statsRepository struct {
+	db *database.Database
+}
+
+// NewStatsRepository creates a new instance of StatsRepository.
+func NewStatsRepository(db *database.Database) StatsRepository {
+	return &statsRepository{
+		db: db,
+	}
+}
+
+// CaptureSnapshot runs the heavy aggregate once and upserts one row per county.
+// Acreage is computed on the geography type so it is in real-world units; the
+// main area median only considers improved parcels so vacant land does not skew it.
+func (r *statsRepository) CaptureSnapshot(ctx context.Context, date time.Time) (int64, error) {
+	query := `
+		INSERT INTO parcel_stats_snapshots (
+			snapshot_date,
+			county_name,
+			parcel_count,
+			total_acres,
+			median_acres,
+			median_main_area,
+			new_construction_count
+		)
+		SELECT
+			$1::date,
+			county_name,
+			COUNT(*),
+			COALESCE(SUM(acres), 0),
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY acres),
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY imprv_main_area)
+				FILTER (WHERE imprv_main_area > 0),
+			COUNT(*) FILTER (WHERE imprv_actual_year_built = EXTRACT(YEAR FROM $1::date))
+		FROM (
+			SELECT
+				county_name,
+				imprv_main_area,
+				imprv_actual_year_built,
+				ST_Area(geom::geography) / $2 as acres
+			FROM tax_parcels
+			WHERE deleted_at IS NULL
+		) parcels
+		GROUP BY county_name
+		ON CONFLICT (snapshot_date, county_name) DO UPDATE SET
+			parcel_count = EXCLUDED.parcel_count,
+			total_acres = EXCLUDED.total_acres,
+			median_acres = EXCLUDED.median_acres,
+			median_main_area = EXCLUDED.median_main_area,
+			new_construction_count = EXCLUDED.new_construction_count,
+			created_at = NOW()
+	`
+
+	tag, err := r.db.Pool.Exec(ctx, query, date, SquareMetersPerAcre)
+	if err != nil {
+		return 0, fmt.Errorf("failed to capture stats snapshot (date=%s): %w", date.Format(time.DateOnly), err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// ListSnapshots queries the stored snapshots for the date range.
+func (r *statsRepository) ListSnapshots(ctx context.Context, county string, from, to time.Time) ([]models.StatsSnapshot, error) {
+	query := `
+		SELECT
+			snapshot_date,
+			county_name,
+			parcel_count,
+			total_acres,
+			median_acres,
+			median_main_area,
+			new_construction_count,
+			created_at
+		FROM parcel_stats_snapshots
+		WHERE snapshot_date BETWEEN $1::date AND $2::date
+			AND ($3 = '' OR county_name = $3)
+		ORDER BY snapshot_date, county_name
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, from, to, county)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stats snapshots (county=%q, from=%s, to=%s): %w",
+			county, from.Format(time.DateOnly), to.Format(time.DateOnly), err)
+	}
+	defer rows.Close()
+
+	snapshots := []models.StatsSnapshot{}
+	for rows.Next() {
+		var s models.StatsSnapshot
+		err := rows.Scan(
+			&s.SnapshotDate,
+			&s.CountyName,
+			&s.ParcelCount,
+			&s.TotalAcres,
+			&s.MedianAcres,
+			&s.MedianMainArea,
+			&s.NewConstructionCount,
+			&s.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stats snapshot row: %w", err)
+		}
+		snapshots = append(snapshots, s)
+	}
+
+	// Check for errors during iteration
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating stats snapshot rows: %w", err)
+	}
+
+	return snapshots, nil
+}
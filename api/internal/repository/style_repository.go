@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// StyleRepository defines the interface for saved map style data access operations.
+// Every operation is scoped to a single tenant.
+type StyleRepository interface {
+	// List returns all styles for the tenant ordered by name.
+	// Returns an empty slice if the tenant has no styles (not an error).
+	List(ctx context.Context, tenantID string) ([]models.MapStyle, error)
+
+	// FindByName finds the tenant's style with the given name.
+	// Returns nil, nil if no style is found (not an error).
+	FindByName(ctx context.Context, tenantID, name string) (*models.MapStyle, error)
+
+	// Upsert creates the style or replaces the description and config of an
+	// existing style with the same tenant and name. Returns the stored style.
+	Upsert(ctx context.Context, style *models.MapStyle) (*models.MapStyle, error)
+
+	// Delete removes the tenant's style with the given name.
+	// Returns false if no style existed (not an error).
+	Delete(ctx context.Context, tenantID, name string) (bool, error)
+}
+
+// styleRepository is the concrete implementation of StyleRepository.
+type styleRepository struct {
+	db *database.Database
+}
+
+// NewStyleRepository creates a new instance of StyleRepository.
+func NewStyleRepository(db *database.Database) StyleRepository {
+	return &styleRepository{
+		db: db,
+	}
+}
+
+// styleColumns is the column list selected for a full MapStyle row.
+const styleColumns = `id, tenant_id, name, description, config, created_at, updated_at`
+
+// scanStyle scans a row selected with styleColumns into a MapStyle.
+func scanStyle(row pgx.Row) (*models.MapStyle, error) {
+	var style models.MapStyle
+	var configJSON []byte
+
+	err := row.Scan(
+		&style.ID,
+		&style.TenantID,
+		&style.Name,
+		&style.Description,
+		&configJSON,
+		&style.CreatedAt,
+		&style.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(configJSON, &style.Config); err != nil {
+		return nil, fmt.Errorf("failed to parse config for style %d: %w", style.ID, err)
+	}
+
+	return &style, nil
+}
+
+// List queries all styles belonging to the tenant.
+func (r *styleRepository) List(ctx context.Context, tenantID string) ([]models.MapStyle, error) {
+	query := `SELECT ` + styleColumns + ` FROM map_styles WHERE tenant_id = $1 ORDER BY name`
+
+	rows, err := r.db.Pool.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list styles (tenant=%s): %w", tenantID, err)
+	}
+	defer rows.Close()
+
+	styles := []models.MapStyle{}
+	for rows.Next() {
+		style, err := scanStyle(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan style row: %w", err)
+		}
+		styles = append(styles, *style)
+	}
+
+	// Check for errors during iteration
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating style rows: %w", err)
+	}
+
+	return styles, nil
+}
+
+// FindByName queries a single style by tenant and name.
+func (r *styleRepository) FindByName(ctx context.Context, tenantID, name string) (*models.MapStyle, error) {
+	query := `SELECT ` + styleColumns + ` FROM map_styles WHERE tenant_id = $1 AND name = $2`
+
+	style, err := scanStyle(r.db.Pool.QueryRow(ctx, query, tenantID, name))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query style (tenant=%s, name=%s): %w", tenantID, name, err)
+	}
+
+	return style, nil
+}
+
+// Upsert inserts the style or updates it in place using the (tenant_id, name) unique constraint.
+func (r *styleRepository) Upsert(ctx context.Context, style *models.MapStyle) (*models.MapStyle, error) {
+	configJSON, err := json.Marshal(style.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal style config: %w", err)
+	}
+
+	query := `
+		INSERT INTO map_styles (tenant_id, name, description, config, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NOW(), NOW())
+		ON CONFLICT (tenant_id, name) DO UPDATE SET
+			description = EXCLUDED.description,
+			config = EXCLUDED.config,
+			updated_at = NOW()
+		RETURNING ` + styleColumns
+
+	stored, err := scanStyle(r.db.Pool.QueryRow(ctx, query, style.TenantID, style.Name, style.Description, configJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert style (tenant=%s, name=%s): %w", style.TenantID, style.Name, err)
+	}
+
+	return stored, nil
+}
+
+// Delete removes a style by tenant and name.
+func (r *styleRepository) Delete(ctx context.Context, tenantID, name string) (bool, error) {
+	tag, err := r.db.Pool.Exec(ctx, `DELETE FROM map_styles WHERE tenant_id = $1 AND name = $2`, tenantID, name)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete style (tenant=%s, name=%s): %w", tenantID, name, err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
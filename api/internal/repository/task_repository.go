@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/jobs"
+)
+
+// TaskRepository stores the durable tasks run by jobs.Queue in the tasks table.
+type TaskRepository interface {
+	jobs.TaskStore
+}
+
+// taskRepository is the concrete implementation of TaskRepository.
+type taskRepository struct {
+	db *database.Database
+}
+
+// NewTaskRepository creates a new instance of TaskRepository.
+func NewTaskRepository(db *database.Database) TaskRepository {
+	return &taskRepository{db: db}
+}
+
+// Enqueue relies on the unique (kind, key) index to deduplicate keyed tasks, so
+// concurrent enqueues of the same key store one task.
+func (r *taskRepository) Enqueue(ctx context.Context, task jobs.Task) (bool, error) {
+	query := `
+		INSERT INTO tasks (kind, key, payload, max_attempts)
+		VALUES ($1, NULLIF($2, ''), $3, $4)
+		ON CONFLICT (kind, key) WHERE key IS NOT NULL DO NOTHING
+	`
+
+	tag, err := r.db.Pool.Exec(ctx, query, task.Kind, task.Key, task.Payload, task.MaxAttempts)
+	if err != nil {
+		return false, fmt.Errorf("failed to insert %s task: %w", task.Kind, err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// Claim locks the candidate row with SKIP LOCKED, so workers on several
+// instances claim different tasks without waiting on each other.
+func (r *taskRepository) Claim(ctx context.Context, kinds []string, lease time.Duration) (*jobs.Task, error) {
+	query := `
+		UPDATE tasks
+		SET status = 'running',
+			attempts = attempts + 1,
+			locked_until = NOW() + make_interval(secs => $2)
+		WHERE id = (
+			SELECT id
+			FROM tasks
+			WHERE kind = ANY($1)
+				AND ((status = 'pending' AND run_at <= NOW())
+					OR (status = 'running' AND locked_until <= NOW()))
+			ORDER BY run_at, id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, kind, COALESCE(key, ''), payload, attempts, max_attempts
+	`
+
+	var task jobs.Task
+	var payload []byte
+	err := r.db.Pool.QueryRow(ctx, query, kinds, lease.Seconds()).Scan(
+		&task.ID,
+		&task.Kind,
+		&task.Key,
+		&payload,
+		&task.Attempts,
+		&task.MaxAttempts,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim task (kinds=%v): %w", kinds, err)
+	}
+	task.Payload = payload
+
+	return &task, nil
+}
+
+// Complete releases the lease of the succeeded task.
+func (r *taskRepository) Complete(ctx context.Context, id int64) error {
+	query := `
+		UPDATE tasks
+		SET status = 'succeeded', locked_until = NULL, finished_at = NOW()
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Pool.Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to complete task %d: %w", id, err)
+	}
+
+	return nil
+}
+
+// Retry releases the lease and schedules the next attempt.
+func (r *taskRepository) Retry(ctx context.Context, id int64, delay time.Duration, reason string) error {
+	query := `
+		UPDATE tasks
+		SET status = 'pending',
+			locked_until = NULL,
+			run_at = NOW() + make_interval(secs => $2),
+			last_error = LEFT($3, 500)
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Pool.Exec(ctx, query, id, delay.Seconds(), reason); err != nil {
+		return fmt.Errorf("failed to schedule retry of task %d: %w", id, err)
+	}
+
+	return nil
+}
+
+// Fail releases the lease of the failed task, which retention prunes like
+// succeeded ones.
+func (r *taskRepository) Fail(ctx context.Context, id int64, reason string) error {
+	query := `
+		UPDATE tasks
+		SET status = 'failed', locked_until = NULL, finished_at = NOW(), last_error = LEFT($2, 500)
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Pool.Exec(ctx, query, id, reason); err != nil {
+		return fmt.Errorf("failed to fail task %d: %w", id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// UserRepository defines the interface for user data access operations.
+type UserRepository interface {
+	// Create stores a new user and returns it with its ID and creation time.
+	// Returns nil, nil if the email is already registered (not an error).
+	Create(ctx context.Context, user *models.User) (*models.User, error)
+
+	// FindByEmail finds the user with the given lowercased email.
+	// Returns nil, nil if no user is found (not an error).
+	FindByEmail(ctx context.Context, email string) (*models.User, error)
+
+	// FindByID finds the user with the given ID.
+	// Returns nil, nil if no user is found (not an error).
+	FindByID(ctx context.Context, id uint) (*models.User, error)
+
+	// TouchLastLogin records that the user just signed in.
+	TouchLastLogin(ctx context.Context, id uint) error
+}
+
+// userRepository is the concrete implementation of UserRepository.
+type userRepository struct {
+	db *database.Database
+}
+
+// NewUserRepository creates a new instance of UserRepository.
+func NewUserRepository(db *database.Database) UserRepository {
+	return &userRepository{
+		db: db,
+	}
+}
+
+// userColumns is the column list selected for a full User row.
+const userColumns = `id, email, password_hash, created_at, last_login_at`
+
+// scanUser scans a row selected with userColumns into a User.
+func scanUser(row pgx.Row) (*models.User, error) {
+	var user models.User
+	err := row.Scan(
+		&user.ID,
+		&user.Email,
+		&user.PasswordHash,
+		&user.CreatedAt,
+		&user.LastLoginAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Create inserts a user row unless the email is taken.
+func (r *userRepository) Create(ctx context.Context, user *models.User) (*models.User, error) {
+	query := `
+		INSERT INTO users (email, password_hash, created_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (email) DO NOTHING
+		RETURNING ` + userColumns
+
+	stored, err := scanUser(r.db.Pool.QueryRow(ctx, query, user.Email, user.PasswordHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to create user (email=%s): %w", user.Email, err)
+	}
+
+	return stored, nil
+}
+
+// FindByEmail queries a single user by the unique email.
+func (r *userRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE email = $1`
+
+	user, err := scanUser(r.db.Pool.QueryRow(ctx, query, email))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query user: %w", err)
+	}
+
+	return user, nil
+}
+
+// FindByID queries a single user by ID.
+func (r *userRepository) FindByID(ctx context.Context, id uint) (*models.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1`
+
+	user, err := scanUser(r.db.Pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query user (id=%d): %w", id, err)
+	}
+
+	return user, nil
+}
+
+// TouchLastLogin sets last_login_at to now.
+func (r *userRepository) TouchLastLogin(ctx context.Context, id uint) error {
+	if _, err := r.db.Pool.Exec(ctx, `UPDATE users SET last_login_at = NOW() WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to update user last login (id=%d): %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// WarmingRepository defines the interface for the usage analytics and dataset
+// version lookups behind the cache warming job.
+type WarmingRepository interface {
+	// LatestDatasetVersion returns the id of the most recent ingestion run that
+	// replaced tax_parcels (status activated or forced).
+	// Returns 0 if no import has been recorded (not an error).
+	LatestDatasetVersion(ctx context.Context) (int64, error)
+
+	// AddHotspotHits adds the hit counts to the stored rows for each hotspot's
+	// date and cell, creating rows that do not exist yet.
+	AddHotspotHits(ctx context.Context, hotspots []models.QueryHotspot) error
+
+	// TopHotspots returns up to limit grid cells with the most hits on or after since,
+	// summed across days and ordered by hits descending. HitDate is left zero.
+	// Returns an empty slice if no hits were recorded (not an error).
+	TopHotspots(ctx context.Context, since time.Time, limit int) ([]models.QueryHotspot, error)
+}
+
+// warmingRepository is the concrete implementation of WarmingRepository.
+type warmingRepository struct {
+	db *database.Database
+}
+
+// NewWarmingRepository creates a new instance of WarmingRepository.
+func NewWarmingRepository(db *database.Database) WarmingRepository {
+	return &warmingRepository{
+		db: db,
+	}
+}
+
+// LatestDatasetVersion reads the newest run that swapped the dataset; blocked runs
+// left tax_parcels unchanged and are ignored.
+func (r *warmingRepository) LatestDatasetVersion(ctx context.Context) (int64, error) {
+	query := `
+		SELECT COALESCE(MAX(id), 0)
+		FROM ingestion_runs
+		WHERE status IN ('activated', 'forced')
+	`
+
+	var version int64
+	if err := r.db.Pool.QueryRow(ctx, query).Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to query latest dataset version: %w", err)
+	}
+
+	return version, nil
+}
+
+// AddHotspotHits upserts all hotspots in a single statement using parallel arrays.
+func (r *warmingRepository) AddHotspotHits(ctx context.Context, hotspots []models.QueryHotspot) error {
+	if len(hotspots) == 0 {
+		return nil
+	}
+
+	dates := make([]time.Time, len(hotspots))
+	lats := make([]float64, len(hotspots))
+	lngs := make([]float64, len(hotspots))
+	hits := make([]int64, len(hotspots))
+	for i, h := range hotspots {
+		dates[i] = h.HitDate
+		lats[i] = h.CellLat
+		lngs[i] = h.CellLng
+		hits[i] = h.Hits
+	}
+
+	query := `
+		INSERT INTO query_hotspots (hit_date, cell_lat, cell_lng, hits)
+		SELECT * FROM unnest($1::date[], $2::float8[], $3::float8[], $4::bigint[])
+		ON CONFLICT (hit_date, cell_lat, cell_lng) DO UPDATE SET
+			hits = query_hotspots.hits + EXCLUDED.hits,
+			updated_at = NOW()
+	`
+
+	if _, err := r.db.Pool.Exec(ctx, query, dates, lats, lngs, hits); err != nil {
+		return fmt.Errorf("failed to record %d query hotspots: %w", len(hotspots), err)
+	}
+
+	return nil
+}
+
+// TopHotspots aggregates the daily rows per cell.
+func (r *warmingRepository) TopHotspots(ctx context.Context, since time.Time, limit int) ([]models.QueryHotspot, error) {
+	query := `
+		SELECT cell_lat, cell_lng, SUM(hits)::bigint as hits
+		FROM query_hotspots
+		WHERE hit_date >= $1::date
+		GROUP BY cell_lat, cell_lng
+		ORDER BY hits DESC, cell_lat, cell_lng
+		LIMIT $2
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query top hotspots (since=%s, limit=%d): %w",
+			since.Format(time.DateOnly), limit, err)
+	}
+	defer rows.Close()
+
+	hotspots := []models.QueryHotspot{}
+	for rows.Next() {
+		var h models.QueryHotspot
+		if err := rows.Scan(&h.CellLat, &h.CellLng, &h.Hits); err != nil {
+			return nil, fmt.Errorf("failed to scan hotspot row: %w", err)
+		}
+		hotspots = append(hotspots, h)
+	}
+
+	// Check for errors during iteration
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating hotspot rows: %w", err)
+	}
+
+	return hotspots, nil
+}
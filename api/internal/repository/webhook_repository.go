@@ -0,0 +1,356 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// MaxWebhookPayloadParcels bounds the parcels of one delivery; a run changing
+// more parcels of a webhook queues several deliveries.
+const MaxWebhookPayloadParcels = 1000
+
+// DueWebhookDelivery is a pending delivery whose next attempt is due, with the
+// URL and secret of its webhook.
+type DueWebhookDelivery struct {
+	URL      string
+	Secret   string
+	Delivery models.WebhookDelivery
+}
+
+// WebhookAttempt is the outcome of one delivery attempt. Status is the
+// delivery's new status; NextAttemptAt is read only when it stays pending.
+type WebhookAttempt struct {
+	NextAttemptAt  time.Time
+	ResponseStatus *int
+	Error          *string
+	Status         string
+}
+
+// WebhookRepository defines the interface for webhook and delivery data access operations.
+type WebhookRepository interface {
+	// Create stores a new webhook and returns it with its ID and timestamps.
+	// It is first evaluated by the next evaluation after its creation.
+	Create(ctx context.Context, webhook *models.Webhook) (*models.Webhook, error)
+
+	// List returns the tenant's webhooks, newest first.
+	// Returns an empty slice if the tenant has no webhooks (not an error).
+	List(ctx context.Context, tenantID string) ([]models.Webhook, error)
+
+	// ListAll returns the webhooks of all tenants, oldest first.
+	// Returns an empty slice if there are no webhooks (not an error).
+	ListAll(ctx context.Context) ([]models.Webhook, error)
+
+	// Delete deletes the tenant's webhook and its deliveries.
+	// Returns false if the tenant has no such webhook (not an error).
+	Delete(ctx context.Context, tenantID string, id uint) (bool, error)
+
+	// FindDeliveries returns up to limit deliveries of the tenant's webhook, newest first.
+	// Returns nil, nil if the tenant has no such webhook (not an error).
+	FindDeliveries(ctx context.Context, tenantID string, id uint, limit int) ([]models.WebhookDelivery, error)
+
+	// Enqueue queues deliveries of the parcels added, changed or deleted since
+	// the webhook was last evaluated that match its county and area, at most
+	// MaxWebhookPayloadParcels per delivery, and moves EvaluatedAt to now, in one statement.
+	// Returns the number of deliveries queued; 0 if the webhook was deleted.
+	Enqueue(ctx context.Context, webhook models.Webhook, runID int64) (int64, error)
+
+	// DueDeliveries returns up to limit pending deliveries whose next attempt
+	// is due, the longest waiting first.
+	DueDeliveries(ctx context.Context, limit int) ([]DueWebhookDelivery, error)
+
+	// RecordAttempt counts an attempt of the delivery and stores its outcome.
+	RecordAttempt(ctx context.Context, id int64, attempt WebhookAttempt) error
+
+	// LatestIngestionRun returns the id of the most recent ingestion run that
+	// replaced or synced parcels (status activated or forced).
+	// Returns 0 if no import has been recorded (not an error).
+	LatestIngestionRun(ctx context.Context) (int64, error)
+}
+
+// webhookRepository is the concrete implementation of WebhookRepository.
+type webhookRepository struct {
+	db *database.Database
+}
+
+// NewWebhookRepository creates a new instance of WebhookRepository.
+func NewWebhookRepository(db *database.Database) WebhookRepository {
+	return &webhookRepository{
+		db: db,
+	}
+}
+
+// webhookColumns is the column list selected for a full Webhook row.
+const webhookColumns = `id, tenant_id, url, secret, county, ST_AsGeoJSON(area), created_at, evaluated_at`
+
+// scanWebhook scans a row selected with webhookColumns into a Webhook.
+func scanWebhook(row pgx.Row) (*models.Webhook, error) {
+	var webhook models.Webhook
+	var areaJSON []byte
+
+	if err := row.Scan(&webhook.ID, &webhook.TenantID, &webhook.URL, &webhook.Secret, &webhook.County,
+		&areaJSON, &webhook.CreatedAt, &webhook.EvaluatedAt); err != nil {
+		return nil, err
+	}
+
+	if areaJSON != nil {
+		webhook.Area = &models.MultiPolygon{}
+		if err := webhook.Area.Scan(areaJSON); err != nil {
+			return nil, fmt.Errorf("failed to parse area for webhook %d: %w", webhook.ID, err)
+		}
+	}
+
+	return &webhook, nil
+}
+
+// webhookDeliveryColumns is the column list selected for a full WebhookDelivery row.
+const webhookDeliveryColumns = `d.id, d.webhook_id, d.event, d.ingestion_run_id, d.payload, d.status, d.attempts,
+	d.next_attempt_at, d.response_status, d.last_error, d.created_at, d.delivered_at`
+
+// webhookDeliveryTargets returns the scan targets of webhookDeliveryColumns;
+// the payload is scanned into payload for parseWebhookPayload.
+func webhookDeliveryTargets(d *models.WebhookDelivery, payload *[]byte) []any {
+	return []any{&d.ID, &d.WebhookID, &d.Event, &d.IngestionRunID, payload, &d.Status, &d.Attempts,
+		&d.NextAttemptAt, &d.ResponseStatus, &d.LastError, &d.CreatedAt, &d.DeliveredAt}
+}
+
+// parseWebhookPayload parses the JSONB payload of the delivery.
+func parseWebhookPayload(d *models.WebhookDelivery, payload []byte) error {
+	if err := json.Unmarshal(payload, &d.Payload); err != nil {
+		return fmt.Errorf("failed to parse payload of webhook delivery %d: %w", d.ID, err)
+	}
+	return nil
+}
+
+// Create inserts a webhook row; evaluated_at defaults to now, so only later
+// changes are delivered.
+func (r *webhookRepository) Create(ctx context.Context, webhook *models.Webhook) (*models.Webhook, error) {
+	var area any
+	if webhook.Area != nil {
+		value, err := webhook.Area.Value()
+		if err != nil {
+			return nil, err
+		}
+		area = value
+	}
+
+	query := `
+		INSERT INTO webhooks (tenant_id, url, secret, county, area, created_at, evaluated_at)
+		VALUES ($1, $2, $3, $4, ST_Multi(ST_SetSRID(ST_GeomFromGeoJSON($5::text), 4326)), NOW(), NOW())
+		RETURNING ` + webhookColumns
+
+	stored, err := scanWebhook(r.db.Pool.QueryRow(ctx, query, webhook.TenantID, webhook.URL, webhook.Secret, webhook.County, area))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook (tenant=%s): %w", webhook.TenantID, err)
+	}
+
+	return stored, nil
+}
+
+// List queries the tenant's webhooks.
+func (r *webhookRepository) List(ctx context.Context, tenantID string) ([]models.Webhook, error) {
+	return r.list(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE tenant_id = $1 ORDER BY id DESC`, tenantID)
+}
+
+// ListAll queries every webhook.
+func (r *webhookRepository) ListAll(ctx context.Context) ([]models.Webhook, error) {
+	return r.list(ctx, `SELECT `+webhookColumns+` FROM webhooks ORDER BY id`)
+}
+
+// list runs a query selecting webhookColumns.
+func (r *webhookRepository) list(ctx context.Context, query string, args ...any) ([]models.Webhook, error) {
+	rows, err := r.db.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := []models.Webhook{}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook row: %w", err)
+		}
+		webhooks = append(webhooks, *webhook)
+	}
+
+	// Check for errors during iteration
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook rows: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// Delete removes the webhook; its deliveries are deleted by the foreign key cascade.
+func (r *webhookRepository) Delete(ctx context.Context, tenantID string, id uint) (bool, error) {
+	tag, err := r.db.Pool.Exec(ctx, `DELETE FROM webhooks WHERE tenant_id = $1 AND id = $2`, tenantID, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete webhook (tenant=%s, id=%d): %w", tenantID, id, err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// FindDeliveries checks that the webhook belongs to the tenant before reading its deliveries.
+func (r *webhookRepository) FindDeliveries(ctx context.Context, tenantID string, id uint, limit int) ([]models.WebhookDelivery, error) {
+	var exists bool
+	err := r.db.Pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM webhooks WHERE tenant_id = $1 AND id = $2)`, tenantID, id).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook (tenant=%s, id=%d): %w", tenantID, id, err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries d
+		WHERE d.webhook_id = $1
+		ORDER BY d.id DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, id, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries (id=%d, limit=%d): %w", id, limit, err)
+	}
+	defer rows.Close()
+
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		var d models.WebhookDelivery
+		var payload []byte
+		if err := rows.Scan(webhookDeliveryTargets(&d, &payload)...); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery row: %w", err)
+		}
+		if err := parseWebhookPayload(&d, payload); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	// Check for errors during iteration
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook delivery rows: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// Enqueue reads the previous evaluated_at in the same UPDATE that advances it,
+// as SavedSearchRepository.Evaluate does, so a change is queued once. The
+// changes are numbered by parcel ID and grouped MaxWebhookPayloadParcels to a
+// delivery; the county parameter comes from the webhook, as for nearby queries.
+func (r *webhookRepository) Enqueue(ctx context.Context, webhook models.Webhook, runID int64) (int64, error) {
+	query := `
+		WITH hook AS (
+			UPDATE webhooks h
+			SET evaluated_at = NOW()
+			FROM webhooks previous
+			WHERE h.id = $1 AND previous.id = h.id
+			RETURNING h.area, previous.evaluated_at
+		), changes AS (
+			SELECT tax_parcels.id, pin, county_name,
+				CASE
+					WHEN deleted_at IS NOT NULL THEN '` + models.ParcelChangeDeleted + `'
+					WHEN created_at > hook.evaluated_at THEN '` + models.ParcelChangeAdded + `'
+					ELSE '` + models.ParcelChangeChanged + `'
+				END AS change,
+				(ROW_NUMBER() OVER (ORDER BY tax_parcels.id) - 1) / $3 AS batch
+			FROM tax_parcels, hook
+			WHERE COALESCE(updated_at, created_at) > hook.evaluated_at
+				AND (hook.area IS NULL OR ST_Intersects(geom, hook.area))` + countyClause(4) + `
+		)
+		INSERT INTO webhook_deliveries (webhook_id, event, ingestion_run_id, payload, status, attempts, next_attempt_at, created_at)
+		SELECT $1, '` + models.WebhookEventParcelsChanged + `', $2,
+			jsonb_build_object(
+				'event', '` + models.WebhookEventParcelsChanged + `',
+				'webhookId', $1::bigint,
+				'ingestionRunId', $2::bigint,
+				'parcels', jsonb_agg(jsonb_build_object(
+					'parcelId', id, 'pin', pin, 'countyName', county_name, 'change', change
+				) ORDER BY id)
+			),
+			'` + models.WebhookDeliveryPending + `', 0, NOW(), NOW()
+		FROM changes
+		GROUP BY batch
+	`
+
+	tag, err := r.db.Pool.Exec(ctx, query, webhook.ID, runID, MaxWebhookPayloadParcels, webhook.County)
+	if err != nil {
+		return 0, fmt.Errorf("failed to enqueue deliveries of webhook %d: %w", webhook.ID, err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// DueDeliveries joins each due delivery to its webhook. Only the elected
+// leader delivers, so the rows are not locked.
+func (r *webhookRepository) DueDeliveries(ctx context.Context, limit int) ([]DueWebhookDelivery, error) {
+	query := `
+		SELECT ` + webhookDeliveryColumns + `, h.url, h.secret
+		FROM webhook_deliveries d
+		JOIN webhooks h ON h.id = d.webhook_id
+		WHERE d.status = '` + models.WebhookDeliveryPending + `' AND d.next_attempt_at <= NOW()
+		ORDER BY d.next_attempt_at
+		LIMIT $1
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query due webhook deliveries (limit=%d): %w", limit, err)
+	}
+	defer rows.Close()
+
+	due := []DueWebhookDelivery{}
+	for rows.Next() {
+		var d DueWebhookDelivery
+		var payload []byte
+		targets := append(webhookDeliveryTargets(&d.Delivery, &payload), &d.URL, &d.Secret)
+		if err := rows.Scan(targets...); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery row: %w", err)
+		}
+		if err := parseWebhookPayload(&d.Delivery, payload); err != nil {
+			return nil, err
+		}
+		due = append(due, d)
+	}
+
+	// Check for errors during iteration
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook delivery rows: %w", err)
+	}
+
+	return due, nil
+}
+
+// RecordAttempt sets delivered_at when the delivery succeeded.
+func (r *webhookRepository) RecordAttempt(ctx context.Context, id int64, attempt WebhookAttempt) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET attempts = attempts + 1,
+			status = $2,
+			next_attempt_at = $3,
+			response_status = $4,
+			last_error = LEFT($5, 500),
+			delivered_at = CASE WHEN $2 = '` + models.WebhookDeliveryDelivered + `' THEN NOW() END
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Pool.Exec(ctx, query, id, attempt.Status, attempt.NextAttemptAt.UTC(), attempt.ResponseStatus, attempt.Error); err != nil {
+		return fmt.Errorf("failed to record attempt of webhook delivery %d: %w", id, err)
+	}
+
+	return nil
+}
+
+// LatestIngestionRun reads the newest run that changed the dataset.
+func (r *webhookRepository) LatestIngestionRun(ctx context.Context) (int64, error) {
+	return latestIngestionRun(ctx, r.db)
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stwalsh4118/atlas/api/internal/database"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// WidgetRepository defines the interface for embeddable widget data access operations.
+// Every operation is scoped to a single tenant.
+type WidgetRepository interface {
+	// List returns all widgets for the tenant ordered by name.
+	// Returns an empty slice if the tenant has no widgets (not an error).
+	List(ctx context.Context, tenantID string) ([]models.EmbedWidget, error)
+
+	// FindByName finds the tenant's widget with the given name.
+	// Returns nil, nil if no widget is found (not an error).
+	FindByName(ctx context.Context, tenantID, name string) (*models.EmbedWidget, error)
+
+	// Upsert creates the widget or replaces the configuration of an existing
+	// widget with the same tenant and name. Returns the stored widget.
+	Upsert(ctx context.Context, widget *models.EmbedWidget) (*models.EmbedWidget, error)
+}
+
+// widgetRepository is the concrete implementation of WidgetRepository.
+type widgetRepository struct {
+	db *database.Database
+}
+
+// NewWidgetRepository creates a new instance of WidgetRepository.
+func NewWidgetRepository(db *database.Database) WidgetRepository {
+	return &widgetRepository{
+		db: db,
+	}
+}
+
+// widgetColumns is the column list selected for a full EmbedWidget row.
+const widgetColumns = `id, tenant_id, name, min_lng, min_lat, max_lng, max_lat,
+	visible_fields, branding, created_at, updated_at`
+
+// scanWidget scans a row selected with widgetColumns into an EmbedWidget.
+func scanWidget(row pgx.Row) (*models.EmbedWidget, error) {
+	var widget models.EmbedWidget
+	var brandingJSON []byte
+
+	err := row.Scan(
+		&widget.ID,
+		&widget.TenantID,
+		&widget.Name,
+		&widget.AllowedBBox.MinLng,
+		&widget.AllowedBBox.MinLat,
+		&widget.AllowedBBox.MaxLng,
+		&widget.AllowedBBox.MaxLat,
+		&widget.VisibleFields,
+		&brandingJSON,
+		&widget.CreatedAt,
+		&widget.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(brandingJSON, &widget.Branding); err != nil {
+		return nil, fmt.Errorf("failed to parse branding for widget %d: %w", widget.ID, err)
+	}
+
+	return &widget, nil
+}
+
+// List queries all widgets belonging to the tenant.
+func (r *widgetRepository) List(ctx context.Context, tenantID string) ([]models.EmbedWidget, error) {
+	query := `SELECT ` + widgetColumns + ` FROM embed_widgets WHERE tenant_id = $1 ORDER BY name`
+
+	rows, err := r.db.Pool.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list widgets (tenant=%s): %w", tenantID, err)
+	}
+	defer rows.Close()
+
+	widgets := []models.EmbedWidget{}
+	for rows.Next() {
+		widget, err := scanWidget(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan widget row: %w", err)
+		}
+		widgets = append(widgets, *widget)
+	}
+
+	// Check for errors during iteration
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating widget rows: %w", err)
+	}
+
+	return widgets, nil
+}
+
+// FindByName queries a single widget by tenant and name.
+func (r *widgetRepository) FindByName(ctx context.Context, tenantID, name string) (*models.EmbedWidget, error) {
+	query := `SELECT ` + widgetColumns + ` FROM embed_widgets WHERE tenant_id = $1 AND name = $2`
+
+	widget, err := scanWidget(r.db.Pool.QueryRow(ctx, query, tenantID, name))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query widget (tenant=%s, name=%s): %w", tenantID, name, err)
+	}
+
+	return widget, nil
+}
+
+// Upsert inserts the widget or updates it in place using the (tenant_id, name) unique constraint.
+func (r *widgetRepository) Upsert(ctx context.Context, widget *models.EmbedWidget) (*models.EmbedWidget, error) {
+	brandingJSON, err := json.Marshal(widget.Branding)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal widget branding: %w", err)
+	}
+
+	query := `
+		INSERT INTO embed_widgets (
+			tenant_id, name, min_lng, min_lat, max_lng, max_lat,
+			visible_fields, branding, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
+		ON CONFLICT (tenant_id, name) DO UPDATE SET
+			min_lng = EXCLUDED.min_lng,
+			min_lat = EXCLUDED.min_lat,
+			max_lng = EXCLUDED.max_lng,
+			max_lat = EXCLUDED.max_lat,
+			visible_fields = EXCLUDED.visible_fields,
+			branding = EXCLUDED.branding,
+			updated_at = NOW()
+		RETURNING ` + widgetColumns
+
+	bbox := widget.AllowedBBox
+	stored, err := scanWidget(r.db.Pool.QueryRow(ctx, query,
+		widget.TenantID, widget.Name,
+		bbox.MinLng, bbox.MinLat, bbox.MaxLng, bbox.MaxLat,
+		widget.VisibleFields, brandingJSON,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert widget (tenant=%s, name=%s): %w", widget.TenantID, widget.Name, err)
+	}
+
+	return stored, nil
+}
@@ -0,0 +1,183 @@
+package routes
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+)
+
+// openAPIVersion is the OpenAPI specification version of generated documents.
+const openAPIVersion = "3.0.3"
+
+// Document is an OpenAPI document describing the registered routes. Schemas
+// are generated from the Query, Body and Response types of the declarations;
+// routes without them are described in docs/api-reference.md. Route metadata
+// is added to operations as x- extensions.
+type Document struct {
+	Paths      map[string]map[string]Operation `json:"paths"`
+	Components Components                      `json:"components"`
+	Info       Info                            `json:"info"`
+	OpenAPI    string                          `json:"openapi"`
+}
+
+// Components holds the named schemas referenced by operations.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Info is the OpenAPI info object.
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Operation is an OpenAPI operation object.
+type Operation struct {
+	Responses   map[string]Response `json:"responses"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary"`
+	Scope       Scope               `json:"x-auth-scope"`
+	RateClass   RateClass           `json:"x-rate-limit-class"`
+	CachePolicy string              `json:"x-cache-policy,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+}
+
+// Parameter is an OpenAPI parameter object.
+type Parameter struct {
+	Schema   Schema `json:"schema"`
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Required bool   `json:"required"`
+}
+
+// RequestBody is an OpenAPI request body object.
+type RequestBody struct {
+	Content  map[string]MediaType `json:"content"`
+	Required bool                 `json:"required"`
+}
+
+// Response is an OpenAPI response object.
+type Response struct {
+	Content     map[string]MediaType `json:"content,omitempty"`
+	Description string               `json:"description"`
+}
+
+// MediaType is an OpenAPI media type object.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// jsonContent returns the application/json content of schema.
+func jsonContent(schema *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: schema}}
+}
+
+// OpenAPI describes the declared routes. Path parameters such as :id become
+// {id}; public routes take the API key header, tenant-scoped routes require
+// the API key or, when keys are optional, the tenant header, partner routes
+// the token query parameter, user routes the Authorization header and admin
+// routes an admin API key.
+func (r *Registry) OpenAPI(title, version string) *Document {
+	doc := &Document{
+		OpenAPI: openAPIVersion,
+		Info:    Info{Title: title, Version: version},
+		Paths:   make(map[string]map[string]Operation),
+	}
+	schemas := newSchemaGenerator()
+	errorResponse := Response{
+		Description: "Error in the standard format; see docs/api-reference.md",
+		Content:     jsonContent(schemas.valueSchema(apierrors.ErrorResponse{})),
+	}
+
+	for _, route := range r.Routes() {
+		path, params := openAPIPath(route.Path)
+		switch route.Scope {
+		case ScopePublic:
+			params = append(params, Parameter{Name: middleware.APIKeyHeader, In: "header", Required: r.requireAPIKeys, Schema: Schema{Type: "string"}})
+		case ScopeTenant:
+			if r.requireAPIKeys {
+				params = append(params, Parameter{Name: middleware.APIKeyHeader, In: "header", Required: true, Schema: Schema{Type: "string"}})
+			} else {
+				params = append(params, Parameter{Name: middleware.TenantIDHeader, In: "header", Required: true, Schema: Schema{Type: "string"}})
+			}
+		case ScopePartner:
+			params = append(params, Parameter{Name: "token", In: "query", Required: true, Schema: Schema{Type: "string"}})
+		case ScopeUser:
+			params = append(params, Parameter{Name: middleware.AuthorizationHeader, In: "header", Required: true, Schema: Schema{Type: "string"}})
+		case ScopeAdmin:
+			params = append(params, Parameter{Name: middleware.APIKeyHeader, In: "header", Required: true, Schema: Schema{Type: "string"}})
+		}
+
+		if route.Query != nil {
+			for _, param := range schemas.queryParameters(route.Query) {
+				// Scope parameters such as the partner token may also be bound by the handler
+				if !slices.ContainsFunc(params, func(p Parameter) bool { return p.Name == param.Name && p.In == param.In }) {
+					params = append(params, param)
+				}
+			}
+		}
+
+		op := Operation{
+			OperationID: route.Name,
+			Summary:     route.Summary,
+			Parameters:  params,
+			Scope:       route.Scope,
+			RateClass:   route.RateClass,
+			CachePolicy: route.CachePolicy,
+			Responses:   map[string]Response{"default": errorResponse},
+		}
+		if route.Tag != "" {
+			op.Tags = []string{route.Tag}
+		}
+		if route.Body != nil {
+			op.RequestBody = &RequestBody{Required: true, Content: jsonContent(schemas.valueSchema(route.Body))}
+		}
+		status := route.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		success := Response{Description: http.StatusText(status)}
+		if route.Response != nil {
+			success.Content = jsonContent(schemas.valueSchema(route.Response))
+		} else if status != http.StatusNoContent {
+			success.Description += "; see docs/api-reference.md for the response shape"
+		}
+		op.Responses[strconv.Itoa(status)] = success
+
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]Operation)
+		}
+		doc.Paths[path][strings.ToLower(route.Method)] = op
+	}
+
+	doc.Components.Schemas = schemas.schemas
+	return doc
+}
+
+// OpenAPIHandler serves the OpenAPI document of the registry as JSON.
+// The document is built per request, so it always matches the mounted routes.
+func (r *Registry) OpenAPIHandler(title, version string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, r.OpenAPI(title, version))
+	}
+}
+
+// openAPIPath converts a gin path to an OpenAPI path template and its path parameters.
+func openAPIPath(path string) (string, []Parameter) {
+	segments := strings.Split(path, "/")
+	var params []Parameter
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			segments[i] = "{" + name + "}"
+			params = append(params, Parameter{Name: name, In: "path", Required: true, Schema: Schema{Type: "string"}})
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
@@ -0,0 +1,232 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+)
+
+func TestOpenAPIPath(t *testing.T) {
+	path, params := openAPIPath("/api/v1/widgets/:name/token")
+	assert.Equal(t, "/api/v1/widgets/{name}/token", path)
+	require.Len(t, params, 1)
+	assert.Equal(t, Parameter{Name: "name", In: "path", Required: true, Schema: Schema{Type: "string"}}, params[0])
+
+	path, params = openAPIPath("/api/v1/parcels/nearby")
+	assert.Equal(t, "/api/v1/parcels/nearby", path)
+	assert.Empty(t, params)
+}
+
+func TestRegistry_OpenAPI(t *testing.T) {
+	get := echoRoute(http.MethodGet, "/styles/:name", "styles.get")
+	get.Scope = ScopeTenant
+	get.Tag = "styles"
+	put := echoRoute(http.MethodPut, "/styles/:name", "styles.put")
+	put.Scope = ScopeTenant
+	embed := echoRoute(http.MethodGet, "/embed/config", "embed.config")
+	embed.Scope = ScopePartner
+	embed.CachePolicy = "no-store"
+	me := echoRoute(http.MethodGet, "/users/me", "users.me")
+	me.Scope = ScopeUser
+	queries := echoRoute(http.MethodGet, "/queries", "queries.list")
+	queries.Scope = ScopeAdmin
+
+	registry := NewRegistry()
+	registry.Add(get, put, embed, me, queries)
+
+	doc := registry.OpenAPI("Test API", "1.2.3")
+	assert.Equal(t, openAPIVersion, doc.OpenAPI)
+	assert.Equal(t, Info{Title: "Test API", Version: "1.2.3"}, doc.Info)
+	require.Len(t, doc.Paths, 4)
+
+	styles := doc.Paths["/styles/{name}"]
+	require.Contains(t, styles, "get")
+	require.Contains(t, styles, "put")
+	op := styles["get"]
+	assert.Equal(t, "styles.get", op.OperationID)
+	assert.Equal(t, ScopeTenant, op.Scope)
+	assert.Equal(t, RateStandard, op.RateClass)
+	assert.Equal(t, []string{"styles"}, op.Tags)
+	require.Len(t, op.Parameters, 2)
+	assert.Equal(t, "path", op.Parameters[0].In)
+	assert.Equal(t, middleware.TenantIDHeader, op.Parameters[1].Name)
+	assert.Equal(t, "header", op.Parameters[1].In)
+
+	op = doc.Paths["/embed/config"]["get"]
+	assert.Equal(t, "no-store", op.CachePolicy)
+	require.Len(t, op.Parameters, 1)
+	assert.Equal(t, Parameter{Name: "token", In: "query", Required: true, Schema: Schema{Type: "string"}}, op.Parameters[0])
+
+	op = doc.Paths["/users/me"]["get"]
+	assert.Equal(t, []Parameter{{Name: middleware.AuthorizationHeader, In: "header", Required: true, Schema: Schema{Type: "string"}}}, op.Parameters)
+
+	op = doc.Paths["/queries"]["get"]
+	assert.Equal(t, []Parameter{{Name: middleware.APIKeyHeader, In: "header", Required: true, Schema: Schema{Type: "string"}}}, op.Parameters)
+}
+
+func TestRegistry_OpenAPIWithAPIKeys(t *testing.T) {
+	tenant := echoRoute(http.MethodGet, "/styles", "styles.list")
+	tenant.Scope = ScopeTenant
+	health := echoRoute(http.MethodGet, "/health", "health")
+	health.Scope = ScopeOpen
+
+	registry := NewRegistry()
+	registry.Add(echoRoute(http.MethodGet, "/things", "things.list"), tenant, health)
+
+	apiKey := Parameter{Name: middleware.APIKeyHeader, In: "header", Schema: Schema{Type: "string"}}
+	doc := registry.OpenAPI("Test API", "1.0.0")
+	assert.Equal(t, []Parameter{apiKey}, doc.Paths["/things"]["get"].Parameters)
+	assert.Empty(t, doc.Paths["/health"]["get"].Parameters)
+
+	registry.RequireAPIKeys()
+	apiKey.Required = true
+	doc = registry.OpenAPI("Test API", "1.0.0")
+	assert.Equal(t, []Parameter{apiKey}, doc.Paths["/things"]["get"].Parameters)
+	assert.Equal(t, []Parameter{apiKey}, doc.Paths["/styles"]["get"].Parameters)
+}
+
+func TestRegistry_OpenAPIHandler(t *testing.T) {
+	registry := NewRegistry()
+	registry.Add(
+		echoRoute(http.MethodGet, "/things", "things.list"),
+		Route{Method: http.MethodGet, Path: "/openapi.json", Name: "openapi", Summary: "OpenAPI document",
+			Scope: ScopeOpen, RateClass: RateExempt, Handler: registry.OpenAPIHandler("Test API", "1.0.0")},
+	)
+
+	router := gin.New()
+	require.NoError(t, registry.Mount(router))
+
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var doc Document
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Contains(t, doc.Paths, "/things")
+	assert.Equal(t, RateExempt, doc.Paths["/openapi.json"]["get"].RateClass)
+}
+
+// testAudit is embedded in test DTOs; its fields are inlined.
+type testAudit struct {
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type testThing struct {
+	testAudit
+	Tags   map[string]string `json:"tags,omitempty"`
+	Parent *testThing        `json:"parent"`
+	Name   string            `json:"name" binding:"required,max=64"`
+	Secret string            `json:"-"`
+	Sizes  []int             `json:"sizes"`
+}
+
+type testThingRequest struct {
+	Since  time.Time `form:"since" time_format:"2006-01-02"`
+	Name   string    `form:"name" binding:"required"`
+	Limit  int       `form:"limit" binding:"omitempty,max=100"`
+	Token  string    `form:"token"`
+	Ignore string
+}
+
+type testThingResponse struct {
+	Things []testThing `json:"things"`
+	Count  int         `json:"count"`
+}
+
+func TestSchemaGenerator(t *testing.T) {
+	g := newSchemaGenerator()
+
+	assert.Equal(t, &Schema{Ref: schemaRefPrefix + "testThingResponse"}, g.valueSchema(testThingResponse{}))
+	require.Contains(t, g.schemas, "testThingResponse")
+	require.Contains(t, g.schemas, "testThing")
+
+	response := g.schemas["testThingResponse"]
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Ref: schemaRefPrefix + "testThing"}}, response.Properties["things"])
+	assert.Equal(t, &Schema{Type: "integer", Format: "int32"}, response.Properties["count"])
+
+	thing := g.schemas["testThing"]
+	assert.Equal(t, []string{"name"}, thing.Required)
+	assert.Equal(t, &Schema{Type: "string", Format: "date-time"}, thing.Properties["createdAt"], "embedded fields are inlined")
+	assert.Equal(t, &Schema{Type: "object", AdditionalProperties: &Schema{Type: "string"}}, thing.Properties["tags"])
+	assert.Equal(t, &Schema{Ref: schemaRefPrefix + "testThing"}, thing.Properties["parent"])
+	assert.NotContains(t, thing.Properties, "Secret")
+	assert.Len(t, thing.Properties, 5)
+
+	assert.Equal(t, &Schema{Description: "Any JSON value"}, g.valueSchema(json.RawMessage{}))
+	assert.Equal(t, "object", g.valueSchema(testGeometry{}).Type, "self-encoding types are opaque objects")
+	assert.Len(t, g.schemas, 2)
+}
+
+// testGeometry encodes itself, like the GeoJSON geometry models.
+type testGeometry struct{ X float64 }
+
+func (testGeometry) MarshalJSON() ([]byte, error) { return []byte(`{}`), nil }
+
+func TestSchemaGenerator_QueryParameters(t *testing.T) {
+	params := newSchemaGenerator().queryParameters(testThingRequest{})
+
+	assert.Equal(t, []Parameter{
+		{Name: "since", In: "query", Schema: Schema{Type: "string", Format: "date"}},
+		{Name: "name", In: "query", Required: true, Schema: Schema{Type: "string"}},
+		{Name: "limit", In: "query", Schema: Schema{Type: "integer", Format: "int32"}},
+		{Name: "token", In: "query", Schema: Schema{Type: "string"}},
+	}, params)
+}
+
+func TestRegistry_OpenAPISchemas(t *testing.T) {
+	list := echoRoute(http.MethodGet, "/things", "things.list")
+	list.Query, list.Response = testThingRequest{}, testThingResponse{}
+	create := echoRoute(http.MethodPost, "/things", "things.create")
+	create.Body, create.Response, create.Status = testThing{}, testThing{}, http.StatusCreated
+	remove := echoRoute(http.MethodDelete, "/things/:name", "things.delete")
+	remove.Status = http.StatusNoContent
+	embed := echoRoute(http.MethodGet, "/embed/things", "embed.things")
+	embed.Scope = ScopePartner
+	embed.Query = testThingRequest{}
+
+	registry := NewRegistry()
+	registry.Add(list, create, remove, embed, echoRoute(http.MethodGet, "/other", "other"))
+	doc := registry.OpenAPI("Test API", "1.0.0")
+
+	assert.ElementsMatch(t, []string{"testThing", "testThingResponse", "ErrorResponse", "ErrorDetail"}, mapKeys(doc.Components.Schemas))
+
+	op := doc.Paths["/things"]["get"]
+	require.Len(t, op.Parameters, 5)
+	assert.Equal(t, middleware.APIKeyHeader, op.Parameters[0].Name)
+	assert.Equal(t, "since", op.Parameters[1].Name)
+	assert.Equal(t, schemaRefPrefix+"testThingResponse", op.Responses["200"].Content["application/json"].Schema.Ref)
+	assert.Equal(t, schemaRefPrefix+"ErrorResponse", op.Responses["default"].Content["application/json"].Schema.Ref)
+	assert.Nil(t, op.RequestBody)
+
+	op = doc.Paths["/things"]["post"]
+	require.NotNil(t, op.RequestBody)
+	assert.Equal(t, schemaRefPrefix+"testThing", op.RequestBody.Content["application/json"].Schema.Ref)
+	assert.Contains(t, op.Responses, "201")
+	assert.NotContains(t, op.Responses, "200")
+
+	op = doc.Paths["/things/{name}"]["delete"]
+	assert.Equal(t, Response{Description: "No Content"}, op.Responses["204"])
+
+	op = doc.Paths["/embed/things"]["get"]
+	assert.Len(t, op.Parameters, 4, "the partner token parameter is not repeated")
+
+	op = doc.Paths["/other"]["get"]
+	assert.Nil(t, op.Responses["200"].Content)
+	assert.Contains(t, op.Responses["200"].Description, "docs/api-reference.md")
+}
+
+func mapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
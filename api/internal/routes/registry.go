@@ -0,0 +1,323 @@
+// Package routes declares HTTP endpoints together with their metadata (auth
+// scope, rate-limit class, cache policy and documentation summary). The same
+// declarations mount the routes on the router, label request logs and produce
+// the OpenAPI document, so an endpoint cannot be added without them.
+package routes
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/stwalsh4118/atlas/api/internal/errors"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// ErrInvalidRoute is returned when a route declaration is incomplete or duplicated.
+var ErrInvalidRoute = errors.New("invalid route")
+
+// Scope is who may call a route.
+type Scope string
+
+// Auth scopes
+const (
+	// ScopeOpen routes (health checks, docs, sign-in, signed downloads) never
+	// require credentials
+	ScopeOpen Scope = "open"
+	// ScopePublic routes serve API clients; anonymous callers are allowed unless
+	// API keys are required
+	ScopePublic Scope = "public"
+	// ScopeTenant routes act on a tenant's configuration and require a tenant,
+	// from an API key or the X-Tenant-ID header. An API key, required or not,
+	// must be an admin key
+	ScopeTenant Scope = "tenant"
+	// ScopePartner routes serve embed widgets; a signed token authorizes each request
+	ScopePartner Scope = "partner"
+	// ScopeUser routes act on a signed-in user's data and require a user JWT
+	// (middleware.UserAuth); the token authenticates the request, so no API key
+	// is needed
+	ScopeUser Scope = "user"
+	// ScopeAdmin routes expose operator tools such as analyst queries and
+	// always require an admin API key, even when API keys are optional
+	ScopeAdmin Scope = "admin"
+)
+
+// RateClass groups routes by cost for rate limiting.
+type RateClass string
+
+// Rate-limit classes
+const (
+	// RateExempt routes (health checks, docs) are never limited
+	RateExempt RateClass = "exempt"
+	// RateStandard routes answer from indexed lookups
+	RateStandard RateClass = "standard"
+	// RateHeavy routes scan areas, routes or build files
+	RateHeavy RateClass = "heavy"
+)
+
+// Route declares one endpoint.
+type Route struct {
+	// Handler serves the request
+	Handler gin.HandlerFunc
+	// Middleware runs after the scope and cache middleware, before Handler
+	Middleware []gin.HandlerFunc
+	// Method is the HTTP method, e.g. http.MethodGet
+	Method string
+	// Path is the full gin path, e.g. /api/v1/bundles/:id
+	Path string
+	// Name uniquely identifies the route in logs, metrics and the OpenAPI operationId
+	Name string
+	// Summary is the one-line OpenAPI summary
+	Summary string
+	// Tag groups the route in the OpenAPI document
+	Tag string
+	// CachePolicy is the Cache-Control value of successful responses; empty sets none
+	CachePolicy string
+	// Rel is the link relation of the route in responses of the resource its
+	// :id path parameter identifies, among the routes with the same Tag, e.g.
+	// "documents" for /api/v1/parcels/:id/documents; empty links nothing
+	Rel string
+	// Query, Body and Response are zero values of the query binding struct,
+	// JSON request body and JSON success response the handler uses, e.g.
+	// handlers.NearbyRequest{}. They only document the route; nil omits them
+	Query    any
+	Body     any
+	Response any
+	Scope    Scope
+	// RateClass groups the route for rate limiting
+	RateClass RateClass
+	// Status is the success status code of Response; zero is 200
+	Status int
+}
+
+// Registry holds the declared routes.
+type Registry struct {
+	routes []Route
+	// adminNetworks limit the client addresses of admin routes; nil allows any
+	adminNetworks  []netip.Prefix
+	requireAPIKeys bool
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// RequireAPIKeys makes public and tenant routes mounted afterwards reject
+// requests without a valid API key. middleware.APIKeyAuth must run before the routes.
+func (r *Registry) RequireAPIKeys() {
+	r.requireAPIKeys = true
+}
+
+// RestrictAdmin makes admin routes mounted afterwards reject requests whose
+// client IP is outside networks with 403, before the API key is checked. The
+// client IP honors X-Forwarded-For only from the router's trusted proxies.
+func (r *Registry) RestrictAdmin(networks []netip.Prefix) {
+	r.adminNetworks = networks
+}
+
+// Add declares routes. Declarations are checked when the registry is mounted.
+func (r *Registry) Add(routes ...Route) {
+	r.routes = append(r.routes, routes...)
+}
+
+// Routes returns the declared routes ordered by path, then method.
+func (r *Registry) Routes() []Route {
+	routes := slices.Clone(r.routes)
+	slices.SortStableFunc(routes, func(a, b Route) int {
+		return cmp.Or(cmp.Compare(a.Path, b.Path), cmp.Compare(a.Method, b.Method))
+	})
+	return routes
+}
+
+// CacheResponses adds cache(ttl) as the last middleware of each route named in
+// ttls, so cached responses still pass the route's other middleware. Only GET
+// routes of the open, public and admin scopes can be cached: response caches
+// key on the caller's role, not on the user or tenant. Returns ErrInvalidRoute
+// for an unknown or uncacheable route name.
+func (r *Registry) CacheResponses(ttls map[string]time.Duration, cache func(ttl time.Duration) gin.HandlerFunc) error {
+	for name, ttl := range ttls {
+		i := slices.IndexFunc(r.routes, func(route Route) bool { return route.Name == name })
+		if i < 0 {
+			return fmt.Errorf("%w: no route named %q to cache", ErrInvalidRoute, name)
+		}
+		route := &r.routes[i]
+		if route.Method != http.MethodGet || !slices.Contains([]Scope{ScopeOpen, ScopePublic, ScopeAdmin}, route.Scope) {
+			return fmt.Errorf("%w: %s %s cannot be cached", ErrInvalidRoute, route.Method, route.Path)
+		}
+		route.Middleware = append(slices.Clip(route.Middleware), cache(ttl))
+	}
+	return nil
+}
+
+// Links returns the path templates of the routes of tag that have a link
+// relation, by relation, e.g. "self": "/api/v1/parcels/:id".
+func (r *Registry) Links(tag string) map[string]string {
+	links := make(map[string]string)
+	for _, route := range r.routes {
+		if route.Tag == tag && route.Rel != "" {
+			links[route.Rel] = route.Path
+		}
+	}
+	return links
+}
+
+// Validate checks that every route has a handler, method, path, name, summary,
+// known scope and rate class, and that names and method/path pairs are unique.
+// A route with a link relation must be a GET of an :id path, and relations are
+// unique within a tag. Returns ErrInvalidRoute describing the first problem found.
+func (r *Registry) Validate() error {
+	names := make(map[string]bool, len(r.routes))
+	endpoints := make(map[string]bool, len(r.routes))
+	rels := make(map[string]bool)
+
+	for _, route := range r.routes {
+		endpoint := route.Method + " " + route.Path
+		switch {
+		case route.Handler == nil:
+			return fmt.Errorf("%w: %s has no handler", ErrInvalidRoute, endpoint)
+		case route.Method == "" || route.Path == "":
+			return fmt.Errorf("%w: route %q needs a method and path", ErrInvalidRoute, route.Name)
+		case route.Name == "":
+			return fmt.Errorf("%w: %s has no name", ErrInvalidRoute, endpoint)
+		case route.Summary == "":
+			return fmt.Errorf("%w: %s has no summary", ErrInvalidRoute, endpoint)
+		case !slices.Contains([]Scope{ScopeOpen, ScopePublic, ScopeTenant, ScopePartner, ScopeUser, ScopeAdmin}, route.Scope):
+			return fmt.Errorf("%w: %s has unknown scope %q", ErrInvalidRoute, endpoint, route.Scope)
+		case !slices.Contains([]RateClass{RateExempt, RateStandard, RateHeavy}, route.RateClass):
+			return fmt.Errorf("%w: %s has unknown rate class %q", ErrInvalidRoute, endpoint, route.RateClass)
+		case names[route.Name]:
+			return fmt.Errorf("%w: duplicate route name %q", ErrInvalidRoute, route.Name)
+		case endpoints[endpoint]:
+			return fmt.Errorf("%w: duplicate endpoint %s", ErrInvalidRoute, endpoint)
+		case route.Rel != "" && (route.Method != http.MethodGet || !strings.Contains(route.Path+"/", "/:id/")):
+			return fmt.Errorf("%w: %s links %q but is not a GET of an :id path", ErrInvalidRoute, endpoint, route.Rel)
+		case route.Rel != "" && rels[route.Tag+" "+route.Rel]:
+			return fmt.Errorf("%w: duplicate link relation %q in tag %q", ErrInvalidRoute, route.Rel, route.Tag)
+		}
+		names[route.Name] = true
+		endpoints[endpoint] = true
+		if route.Rel != "" {
+			rels[route.Tag+" "+route.Rel] = true
+		}
+	}
+
+	return nil
+}
+
+// Mount validates the declarations and registers every route on router.
+// Each handler chain is: route label, credential and scope checks, cache policy,
+// route middleware, handler.
+func (r *Registry) Mount(router gin.IRoutes) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+
+	for _, route := range r.Routes() {
+		router.Handle(route.Method, route.Path, route.chain(r.requireAPIKeys, r.adminNetworks)...)
+	}
+	return nil
+}
+
+// chain returns the handlers gin runs for the route.
+func (route Route) chain(requireAPIKeys bool, adminNetworks []netip.Prefix) []gin.HandlerFunc {
+	chain := []gin.HandlerFunc{middleware.Route(route.Name, string(route.RateClass))}
+
+	switch route.Scope {
+	case ScopePublic:
+		if requireAPIKeys {
+			chain = append(chain, requireAPIKey)
+		}
+	case ScopeTenant:
+		chain = append(chain, requireAdminKey(requireAPIKeys), requireTenant)
+	case ScopePartner:
+		chain = append(chain, middleware.WithRole(middleware.RolePartner))
+	case ScopeUser:
+		chain = append(chain, requireUser)
+	case ScopeAdmin:
+		if adminNetworks != nil {
+			chain = append(chain, requireNetwork(adminNetworks))
+		}
+		chain = append(chain, requireAdminKey(true))
+	}
+
+	if route.CachePolicy != "" {
+		chain = append(chain, middleware.CacheControl(route.CachePolicy))
+	}
+
+	chain = append(chain, route.Middleware...)
+	return append(chain, route.Handler)
+}
+
+// requireTenant rejects tenant-scoped requests without a tenant.
+func requireTenant(c *gin.Context) {
+	if middleware.GetTenantID(c) == "" {
+		apierrors.BadRequest(c, "Missing "+middleware.TenantIDHeader+" header", nil)
+		c.Abort()
+		return
+	}
+	c.Next()
+}
+
+// requireAPIKey rejects requests without a valid API key.
+func requireAPIKey(c *gin.Context) {
+	if middleware.GetAPIKey(c) == nil {
+		apierrors.Unauthorized(c, "Missing "+middleware.APIKeyHeader+" header")
+		c.Abort()
+		return
+	}
+	c.Next()
+}
+
+// requireAdminKey rejects tenant requests authenticated with a non-admin API
+// key and, when required, requests without a key.
+func requireAdminKey(required bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := middleware.GetAPIKey(c)
+		if key == nil && required {
+			apierrors.Unauthorized(c, "Missing "+middleware.APIKeyHeader+" header")
+			c.Abort()
+			return
+		}
+		if key != nil && key.Role != models.APIKeyRoleAdmin {
+			apierrors.Forbidden(c, "An admin API key is required")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// requireNetwork rejects requests whose client IP is outside networks.
+func requireNetwork(networks []netip.Prefix) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ip, err := netip.ParseAddr(c.ClientIP()); err == nil {
+			ip = ip.Unmap()
+			for _, network := range networks {
+				if network.Contains(ip) {
+					c.Next()
+					return
+				}
+			}
+		}
+		apierrors.Forbidden(c, "Client address is not allowed")
+		c.Abort()
+	}
+}
+
+// requireUser rejects requests without a valid user token.
+func requireUser(c *gin.Context) {
+	if middleware.GetUserID(c) == 0 {
+		apierrors.Unauthorized(c, "Missing bearer token in "+middleware.AuthorizationHeader+" header")
+		c.Abort()
+		return
+	}
+	c.Next()
+}
@@ -0,0 +1,393 @@
+package routes
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/middleware"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// echoRoute returns a valid route whose handler writes the route label and role.
+func echoRoute(method, path, name string) Route {
+	return Route{
+		Method:    method,
+		Path:      path,
+		Name:      name,
+		Summary:   "Test route " + name,
+		Scope:     ScopePublic,
+		RateClass: RateStandard,
+		Handler: func(c *gin.Context) {
+			if c.Query("fail") != "" {
+				c.JSON(http.StatusNotFound, gin.H{"error": "missing"})
+				return
+			}
+			c.String(http.StatusOK, middleware.GetRoute(c)+" "+string(middleware.GetRole(c)))
+		},
+	}
+}
+
+func TestRegistry_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		route func(Route) Route
+	}{
+		{"missing handler", func(r Route) Route { r.Handler = nil; return r }},
+		{"missing method", func(r Route) Route { r.Method = ""; return r }},
+		{"missing name", func(r Route) Route { r.Name = ""; return r }},
+		{"missing summary", func(r Route) Route { r.Summary = ""; return r }},
+		{"unknown scope", func(r Route) Route { r.Scope = "root"; return r }},
+		{"unknown rate class", func(r Route) Route { r.RateClass = ""; return r }},
+		{"link without id", func(r Route) Route { r.Rel = "self"; return r }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry()
+			registry.Add(tt.route(echoRoute(http.MethodGet, "/things", "things.list")))
+
+			err := registry.Validate()
+			assert.True(t, errors.Is(err, ErrInvalidRoute), "got %v", err)
+		})
+	}
+
+	t.Run("duplicate name", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.Add(
+			echoRoute(http.MethodGet, "/things", "things"),
+			echoRoute(http.MethodPost, "/things", "things"),
+		)
+		assert.ErrorIs(t, registry.Validate(), ErrInvalidRoute)
+	})
+
+	t.Run("duplicate endpoint", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.Add(
+			echoRoute(http.MethodGet, "/things", "things.list"),
+			echoRoute(http.MethodGet, "/things", "things.all"),
+		)
+		assert.ErrorIs(t, registry.Validate(), ErrInvalidRoute)
+	})
+
+	t.Run("link of a non-GET route", func(t *testing.T) {
+		route := echoRoute(http.MethodDelete, "/things/:id", "things.delete")
+		route.Rel = "delete"
+		registry := NewRegistry()
+		registry.Add(route)
+		assert.ErrorIs(t, registry.Validate(), ErrInvalidRoute)
+	})
+
+	t.Run("duplicate link relation", func(t *testing.T) {
+		get, parts := echoRoute(http.MethodGet, "/things/:id", "things.get"), echoRoute(http.MethodGet, "/things/:id/parts", "things.parts")
+		get.Rel, parts.Rel = "self", "self"
+		registry := NewRegistry()
+		registry.Add(get, parts)
+		assert.ErrorIs(t, registry.Validate(), ErrInvalidRoute)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.Add(
+			echoRoute(http.MethodGet, "/things", "things.list"),
+			echoRoute(http.MethodPost, "/things", "things.create"),
+		)
+		assert.NoError(t, registry.Validate())
+	})
+}
+
+func TestRegistry_Routes(t *testing.T) {
+	registry := NewRegistry()
+	registry.Add(
+		echoRoute(http.MethodPost, "/things", "things.create"),
+		echoRoute(http.MethodGet, "/things/:id", "things.get"),
+		echoRoute(http.MethodGet, "/things", "things.list"),
+	)
+
+	var names []string
+	for _, route := range registry.Routes() {
+		names = append(names, route.Name)
+	}
+	assert.Equal(t, []string{"things.list", "things.create", "things.get"}, names)
+}
+
+func TestRegistry_Links(t *testing.T) {
+	get := echoRoute(http.MethodGet, "/things/:id", "things.get")
+	get.Tag, get.Rel = "things", "self"
+	parts := echoRoute(http.MethodGet, "/things/:id/parts", "things.parts")
+	parts.Tag, parts.Rel = "things", "parts"
+	list := echoRoute(http.MethodGet, "/things", "things.list")
+	list.Tag = "things"
+	other := echoRoute(http.MethodGet, "/widgets/:id", "widgets.get")
+	other.Tag, other.Rel = "widgets", "self"
+
+	registry := NewRegistry()
+	registry.Add(get, parts, list, other)
+	require.NoError(t, registry.Validate())
+
+	assert.Equal(t, map[string]string{
+		"self":  "/things/:id",
+		"parts": "/things/:id/parts",
+	}, registry.Links("things"))
+	assert.Empty(t, registry.Links("users"))
+}
+
+func TestRegistry_CacheResponses(t *testing.T) {
+	var order []string
+	step := func(name string) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			order = append(order, name)
+			c.Next()
+		}
+	}
+	list := echoRoute(http.MethodGet, "/things", "things.list")
+	list.Middleware = []gin.HandlerFunc{step("usage")}
+	create := echoRoute(http.MethodPost, "/things", "things.create")
+	mine := echoRoute(http.MethodGet, "/things/mine", "things.mine")
+	mine.Scope = ScopeUser
+
+	registry := NewRegistry()
+	registry.Add(list, create, mine)
+	var ttls []time.Duration
+	cache := func(ttl time.Duration) gin.HandlerFunc {
+		ttls = append(ttls, ttl)
+		return step("cache")
+	}
+
+	require.NoError(t, registry.CacheResponses(map[string]time.Duration{"things.list": time.Minute}, cache))
+	assert.Equal(t, []time.Duration{time.Minute}, ttls)
+
+	router := gin.New()
+	require.NoError(t, registry.Mount(router))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/things", nil))
+	assert.Equal(t, []string{"usage", "cache"}, order, "the cache runs after the route's middleware")
+
+	for _, name := range []string{"things.create", "things.mine", "things.unknown"} {
+		err := registry.CacheResponses(map[string]time.Duration{name: time.Minute}, cache)
+		assert.ErrorIs(t, err, ErrInvalidRoute, name)
+	}
+}
+
+func TestRegistry_Mount(t *testing.T) {
+	tenant := echoRoute(http.MethodGet, "/tenant", "tenant")
+	tenant.Scope = ScopeTenant
+	partner := echoRoute(http.MethodGet, "/partner", "partner")
+	partner.Scope = ScopePartner
+	cached := echoRoute(http.MethodGet, "/cached", "cached")
+	cached.CachePolicy = "public, max-age=300"
+	var order []string
+	chained := echoRoute(http.MethodGet, "/chained", "chained")
+	chained.Middleware = []gin.HandlerFunc{func(c *gin.Context) {
+		order = append(order, middleware.GetRoute(c))
+		c.Next()
+	}}
+
+	registry := NewRegistry()
+	registry.Add(echoRoute(http.MethodGet, "/public", "public"), tenant, partner, cached, chained)
+
+	router := gin.New()
+	router.Use(middleware.Tenant())
+	require.NoError(t, registry.Mount(router))
+
+	serve := func(path, tenantID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if tenantID != "" {
+			req.Header.Set(middleware.TenantIDHeader, tenantID)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("labels the route", func(t *testing.T) {
+		w := serve("/public", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "public public", w.Body.String())
+	})
+
+	t.Run("tenant scope requires the tenant header", func(t *testing.T) {
+		w := serve("/tenant", "")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = serve("/tenant", "acme")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("partner scope assigns the partner role", func(t *testing.T) {
+		w := serve("/partner", "")
+		assert.Equal(t, "partner partner", w.Body.String())
+	})
+
+	t.Run("cache policy applies to successful responses", func(t *testing.T) {
+		w := serve("/cached", "")
+		assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+
+		w = serve("/cached?fail=1", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Empty(t, w.Header().Get("Cache-Control"))
+	})
+
+	t.Run("route middleware runs after the label", func(t *testing.T) {
+		serve("/chained", "")
+		assert.Equal(t, []string{"chained"}, order)
+	})
+
+	t.Run("admin API key required on tenant routes when presented", func(t *testing.T) {
+		w := serveWithKey(t, registry, "/tenant", &models.APIKey{TenantID: "acme", Role: models.APIKeyRolePublic})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = serveWithKey(t, registry, "/tenant", &models.APIKey{TenantID: "acme", Role: models.APIKeyRoleAdmin})
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("admin scope always requires an admin API key", func(t *testing.T) {
+		admin := echoRoute(http.MethodGet, "/admin", "admin")
+		admin.Scope = ScopeAdmin
+		admins := NewRegistry()
+		admins.Add(admin)
+
+		w := serveWithKey(t, admins, "/admin", nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code, "keys are optional elsewhere")
+
+		w = serveWithKey(t, admins, "/admin", &models.APIKey{TenantID: "acme", Role: models.APIKeyRolePublic})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = serveWithKey(t, admins, "/admin", &models.APIKey{TenantID: "acme", Role: models.APIKeyRoleAdmin})
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("user scope requires a user", func(t *testing.T) {
+		user := echoRoute(http.MethodGet, "/me", "me")
+		user.Scope = ScopeUser
+		users := NewRegistry()
+		users.RequireAPIKeys()
+		users.Add(user)
+
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			if c.Query("user") != "" {
+				c.Set(middleware.UserIDKey, uint(7))
+			}
+			c.Next()
+		})
+		require.NoError(t, users.Mount(router))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/me", nil))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		// The user token stands in for the required API key
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/me?user=1", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("invalid declarations are not mounted", func(t *testing.T) {
+		invalid := NewRegistry()
+		invalid.Add(Route{Method: http.MethodGet, Path: "/broken"})
+		assert.ErrorIs(t, invalid.Mount(gin.New()), ErrInvalidRoute)
+	})
+}
+
+func TestRegistry_RequireAPIKeys(t *testing.T) {
+	open := echoRoute(http.MethodGet, "/health", "health")
+	open.Scope = ScopeOpen
+	tenant := echoRoute(http.MethodGet, "/tenant", "tenant")
+	tenant.Scope = ScopeTenant
+	partner := echoRoute(http.MethodGet, "/partner", "partner")
+	partner.Scope = ScopePartner
+
+	registry := NewRegistry()
+	registry.RequireAPIKeys()
+	registry.Add(open, echoRoute(http.MethodGet, "/public", "public"), tenant, partner)
+
+	publicKey := &models.APIKey{TenantID: "acme", Role: models.APIKeyRolePublic}
+	adminKey := &models.APIKey{TenantID: "acme", Role: models.APIKeyRoleAdmin}
+	tests := []struct {
+		key    *models.APIKey
+		path   string
+		status int
+	}{
+		{path: "/health", status: http.StatusOK},
+		{path: "/partner", status: http.StatusOK},
+		{path: "/public", status: http.StatusUnauthorized},
+		{path: "/public", key: publicKey, status: http.StatusOK},
+		{path: "/tenant", status: http.StatusUnauthorized},
+		{path: "/tenant", key: publicKey, status: http.StatusForbidden},
+		{path: "/tenant", key: adminKey, status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		w := serveWithKey(t, registry, tt.path, tt.key)
+		assert.Equal(t, tt.status, w.Code, "GET %s with key %v", tt.path, tt.key)
+	}
+}
+
+func TestRegistry_RestrictAdmin(t *testing.T) {
+	admin := echoRoute(http.MethodGet, "/admin", "admin")
+	admin.Scope = ScopeAdmin
+	adminKey := &models.APIKey{TenantID: "acme", Role: models.APIKeyRoleAdmin}
+
+	serve := func(networks []netip.Prefix, path, forwardedFor string) int {
+		registry := NewRegistry()
+		registry.RestrictAdmin(networks)
+		registry.Add(admin, echoRoute(http.MethodGet, "/public", "public"))
+
+		router := gin.New()
+		require.NoError(t, router.SetTrustedProxies(nil))
+		router.Use(func(c *gin.Context) {
+			c.Set(middleware.APIKeyKey, adminKey)
+			c.Set(middleware.TenantIDKey, adminKey.TenantID)
+			c.Next()
+		})
+		require.NoError(t, registry.Mount(router))
+
+		// httptest requests come from 192.0.2.1
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	office := []netip.Prefix{netip.MustParsePrefix("192.0.2.0/24")}
+	vpn := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	assert.Equal(t, http.StatusOK, serve(nil, "/admin", ""), "unrestricted")
+	assert.Equal(t, http.StatusOK, serve(office, "/admin", ""))
+	assert.Equal(t, http.StatusForbidden, serve(vpn, "/admin", ""), "an admin key is not enough")
+	assert.Equal(t, http.StatusForbidden, serve(vpn, "/admin", "10.1.2.3"), "untrusted X-Forwarded-For is ignored")
+	assert.Equal(t, http.StatusOK, serve(vpn, "/public", ""), "other scopes are not restricted")
+}
+
+// serveWithKey serves a GET request as if middleware.APIKeyAuth had
+// authenticated key; nil serves it anonymously.
+func serveWithKey(t *testing.T, registry *Registry, path string, key *models.APIKey) *httptest.ResponseRecorder {
+	t.Helper()
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if key != nil {
+			c.Set(middleware.APIKeyKey, key)
+			c.Set(middleware.TenantIDKey, key.TenantID)
+		}
+		c.Next()
+	})
+	require.NoError(t, registry.Mount(router))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w
+}
@@ -0,0 +1,188 @@
+package routes
+
+import (
+	"encoding/json"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Schema is the subset of an OpenAPI schema object generated from Go types.
+type Schema struct {
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+}
+
+// schemaRefPrefix is the JSON pointer of named schemas in the document.
+const schemaRefPrefix = "#/components/schemas/"
+
+var (
+	timeType      = reflect.TypeFor[time.Time]()
+	rawJSONType   = reflect.TypeFor[json.RawMessage]()
+	marshalerType = reflect.TypeFor[json.Marshaler]()
+)
+
+// schemaGenerator derives schemas from the DTO types of route declarations.
+// Struct types become named component schemas referenced by $ref, so a type
+// used by several routes is described once. Fields follow their json tags;
+// fields with a binding:"required" tag are listed as required.
+type schemaGenerator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+// newSchemaGenerator creates a generator with no named schemas.
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{schemas: make(map[string]*Schema), names: make(map[reflect.Type]string)}
+}
+
+// valueSchema returns the schema of the type of v.
+func (g *schemaGenerator) valueSchema(v any) *Schema {
+	return g.schema(reflect.TypeOf(v))
+}
+
+// schema returns the schema of t, registering named struct types.
+func (g *schemaGenerator) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawJSONType:
+		return &Schema{Description: "Any JSON value"}
+	case t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType):
+		// Types encoding themselves, such as GeoJSON geometries, are documented by hand
+		return &Schema{Type: "object", Description: t.Name() + ", see docs/api-reference.md"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return &Schema{Ref: schemaRefPrefix + g.name(t)}
+	}
+	// Interfaces and anything else accept any value
+	return &Schema{}
+}
+
+// name returns the component name of a struct type, registering its schema on
+// first use. Types of different packages sharing a name are prefixed with
+// their package name.
+func (g *schemaGenerator) name(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	if _, taken := g.schemas[name]; taken {
+		name = path.Base(t.PkgPath()) + "." + name
+	}
+	g.names[t] = name
+	// Reserve the name before recursing, so self-referencing types terminate
+	g.schemas[name] = &Schema{}
+	*g.schemas[name] = *g.structSchema(t)
+	return name
+}
+
+// structSchema describes the JSON object of a struct, inlining the fields of
+// embedded structs as encoding/json does.
+func (g *schemaGenerator) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for field := range fields(t) {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				inner := g.structSchema(embedded)
+				for key, prop := range inner.Properties {
+					s.Properties[key] = prop
+				}
+				s.Required = append(s.Required, inner.Required...)
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+		s.Properties[name] = g.schema(field.Type)
+		if isRequired(field) {
+			s.Required = append(s.Required, name)
+		}
+	}
+	slices.Sort(s.Required)
+	return s
+}
+
+// queryParameters describes the fields of a query binding struct, named by
+// their form tags, as query parameters in field order. Times bound with a
+// YYYY-MM-DD time_format are dates.
+func (g *schemaGenerator) queryParameters(v any) []Parameter {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var params []Parameter
+	for field := range fields(t) {
+		name, _, _ := strings.Cut(field.Tag.Get("form"), ",")
+		if name == "" {
+			continue
+		}
+		schema := g.schema(field.Type)
+		if field.Tag.Get("time_format") == time.DateOnly {
+			schema.Format = "date"
+		}
+		params = append(params, Parameter{Name: name, In: "query", Required: isRequired(field), Schema: *schema})
+	}
+	return params
+}
+
+// fields yields the exported fields of a struct type that encoding/json and
+// gin's form binding would read.
+func fields(t reflect.Type) func(yield func(reflect.StructField) bool) {
+	return func(yield func(reflect.StructField) bool) {
+		for i := range t.NumField() {
+			field := t.Field(i)
+			if (!field.IsExported() && !field.Anonymous) || field.Tag.Get("json") == "-" {
+				continue
+			}
+			if !yield(field) {
+				return
+			}
+		}
+	}
+}
+
+// isRequired reports whether the field's validation tag requires it.
+func isRequired(field reflect.StructField) bool {
+	return slices.Contains(strings.Split(field.Tag.Get("binding"), ","), "required")
+}
@@ -0,0 +1,45 @@
+package routes
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SwaggerUIVersion is the swagger-ui-dist release the docs page loads from the jsDelivr CDN.
+const SwaggerUIVersion = "5.17.14"
+
+// swaggerUIPage renders Swagger UI for the OpenAPI document at SpecURL.
+// Credential headers such as X-API-Key are operation parameters, filled in
+// per request with try-it-out.
+var swaggerUIPage = template.Must(template.New("swagger").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<link rel="stylesheet" href="https://cdn.jsdelivr.net/npm/swagger-ui-dist@{{.Version}}/swagger-ui.css">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="https://cdn.jsdelivr.net/npm/swagger-ui-dist@{{.Version}}/swagger-ui-bundle.js" crossorigin="anonymous"></script>
+<script>
+window.ui = SwaggerUIBundle({url: {{.SpecURL}}, dom_id: "#swagger-ui", deepLinking: true});
+</script>
+</body>
+</html>
+`))
+
+// SwaggerUIHandler serves a Swagger UI page for the OpenAPI document at specURL,
+// e.g. the path of OpenAPIHandler's route.
+func SwaggerUIHandler(title, specURL string) gin.HandlerFunc {
+	var page bytes.Buffer
+	if err := swaggerUIPage.Execute(&page, struct{ Title, Version, SpecURL string }{title, SwaggerUIVersion, specURL}); err != nil {
+		panic(err)
+	}
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", page.Bytes())
+	}
+}
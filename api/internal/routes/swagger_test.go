@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwaggerUIHandler(t *testing.T) {
+	router := gin.New()
+	router.GET("/docs", SwaggerUIHandler("Test <API>", "/api/v1/openapi.json"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	body := w.Body.String()
+	assert.Contains(t, body, "<title>Test &lt;API&gt;</title>")
+	assert.Contains(t, body, `url: "/api/v1/openapi.json"`, "the spec URL is a JS string literal")
+	assert.Contains(t, body, "swagger-ui-dist@"+SwaggerUIVersion+"/swagger-ui-bundle.js")
+}
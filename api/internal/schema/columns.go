@@ -0,0 +1,218 @@
+package schema
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// Table is a model stored in a table of its own.
+type Table interface {
+	TableName() string
+}
+
+// Tables are the models whose columns are checked against the database.
+var Tables = []Table{
+	models.TaxParcel{},
+	models.Annotation{},
+	models.County{},
+	models.CountyProfile{},
+	models.APIKey{},
+	models.DataKey{},
+	models.EmbedWidget{},
+	models.Favorite{},
+	models.MapStyle{},
+	models.ParcelVersion{},
+	models.QueryAudit{},
+	models.QueryHotspot{},
+	models.ReplayQuery{},
+	models.SavedSearch{},
+	models.SavedSearchMatch{},
+	models.SecurityEvent{},
+	models.ShareLink{},
+	models.StatsSnapshot{},
+	models.User{},
+	models.Webhook{},
+	models.WebhookDelivery{},
+}
+
+// Column is a column a model reads or writes.
+type Column struct {
+	Table string
+	Name  string
+	// Type is the declared type in the form Postgres' format_type prints it
+	// (e.g. "character varying(500)"), from the size or type of the gorm tag.
+	// Empty when the tag declares neither; the Go type is checked instead.
+	Type string
+	kind family
+}
+
+// family is a set of Postgres types a Go type can be scanned from.
+type family string
+
+// Families of undeclared column types
+const (
+	familyText      family = "text"
+	familyInteger   family = "integer"
+	familyFloat     family = "floating point"
+	familyBoolean   family = "boolean"
+	familyTimestamp family = "timestamp"
+	familyAny       family = ""
+)
+
+// familyTypes are the format_type names of each family, by prefix.
+var familyTypes = map[family][]string{
+	familyText:      {"text", "character varying", "character"},
+	familyInteger:   {"smallint", "integer", "bigint"},
+	familyFloat:     {"double precision", "real", "numeric"},
+	familyBoolean:   {"boolean"},
+	familyTimestamp: {"timestamp", "date"},
+}
+
+// Expected describes the expected type for reports: the declared type, or
+// the family of the Go type.
+func (c Column) Expected() string {
+	if c.Type != "" {
+		return c.Type
+	}
+	if c.kind == familyAny {
+		return "any"
+	}
+	return string(c.kind) + " type"
+}
+
+// Matches reports whether actual, as printed by format_type, is a type the
+// column can have. A declared size matches varchar and char of that length;
+// other declared types must match exactly, ignoring case and spaces.
+func (c Column) Matches(actual string) bool {
+	if c.Type != "" {
+		if normalize(c.Type) == normalize(actual) {
+			return true
+		}
+		// size:N is also used for fixed-length columns such as CHAR(2)
+		if strings.HasPrefix(c.Type, "character varying(") {
+			return normalize(actual) == normalize("character"+strings.TrimPrefix(c.Type, "character varying"))
+		}
+		return false
+	}
+	if c.kind == familyAny {
+		return true
+	}
+	for _, name := range familyTypes[c.kind] {
+		if strings.HasPrefix(actual, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize lower-cases a type and removes its spaces.
+func normalize(t string) string {
+	return strings.ToLower(strings.ReplaceAll(t, " ", ""))
+}
+
+// Columns returns the columns of the tables by table, in field order. Fields
+// tagged gorm:"-" are skipped, embedded structs contribute their fields, and
+// fields without a column tag are named in snake case as gorm names them.
+func Columns(tables ...Table) []Column {
+	var columns []Column
+	for _, table := range tables {
+		columns = append(columns, structColumns(table.TableName(), reflect.TypeOf(table))...)
+	}
+	slices.SortStableFunc(columns, func(a, b Column) int {
+		return strings.Compare(a.Table, b.Table)
+	})
+	return columns
+}
+
+// structColumns returns the columns of the fields of t.
+func structColumns(table string, t reflect.Type) []Column {
+	var columns []Column
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := parseTag(field.Tag.Get("gorm"))
+		if _, skip := tag["-"]; skip {
+			continue
+		}
+		if _, embedded := tag["embedded"]; embedded || field.Anonymous {
+			columns = append(columns, structColumns(table, field.Type)...)
+			continue
+		}
+
+		column := Column{Table: table, Name: tag["column"], Type: tag["type"]}
+		if column.Name == "" {
+			column.Name = snakeCase(field.Name)
+		}
+		if size, ok := tag["size"]; ok && column.Type == "" {
+			column.Type = "character varying(" + size + ")"
+		}
+		if column.Type == "" {
+			column.kind = kindOf(field.Type)
+		}
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// parseTag splits a gorm tag into its settings; flags map to "".
+func parseTag(tag string) map[string]string {
+	settings := make(map[string]string)
+	for _, setting := range strings.Split(tag, ";") {
+		if setting == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(setting, ":")
+		settings[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return settings
+}
+
+// kindOf returns the family of column types a Go type can be scanned from.
+func kindOf(t reflect.Type) family {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return familyTimestamp
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return familyText
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return familyInteger
+	case reflect.Float32, reflect.Float64:
+		return familyFloat
+	case reflect.Bool:
+		return familyBoolean
+	default:
+		return familyAny
+	}
+}
+
+// snakeCase converts a Go field name to gorm's column name: MinLng is min_lng
+// and ID is id.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			// Start a word at a lower-to-upper change, or at the last capital of
+			// an acronym followed by a lower-case letter (URLPath is url_path)
+			prevLower := unicode.IsLower(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
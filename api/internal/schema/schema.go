@@ -0,0 +1,115 @@
+// Package schema describes the database schema Atlas expects: the migrations
+// in api/migrations, the indexes they leave behind and the columns of the
+// models. The schema service compares it with the live database to report
+// drift without applying anything.
+package schema
+
+import (
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Migration is one numbered migration, named after its file without the
+// version and the .up.sql suffix (e.g. 13 "add_parcel_sync_columns").
+type Migration struct {
+	Name    string `json:"name"`
+	file    string
+	Version uint `json:"version"`
+}
+
+var (
+	// migrationFile matches golang-migrate up files: 000013_add_parcel_sync_columns.up.sql
+	migrationFile = regexp.MustCompile(`^(\d+)_(.+)\.up\.sql$`)
+	// sqlComment matches line comments, which may mention index names
+	sqlComment = regexp.MustCompile(`--[^\n]*`)
+	// createIndex captures the name of a created index
+	createIndex = regexp.MustCompile(`(?i)\bCREATE\s+(?:UNIQUE\s+)?INDEX\s+(?:CONCURRENTLY\s+)?(?:IF\s+NOT\s+EXISTS\s+)?"?(\w+)"?`)
+	// dropIndex captures the names of dropped indexes
+	dropIndex = regexp.MustCompile(`(?i)\bDROP\s+INDEX\s+(?:CONCURRENTLY\s+)?(?:IF\s+EXISTS\s+)?([\w",\s]+?)\s*(?:CASCADE|RESTRICT)?\s*;`)
+	// renameIndex captures the old and new names of a renamed index
+	renameIndex = regexp.MustCompile(`(?i)\bALTER\s+INDEX\s+(?:IF\s+EXISTS\s+)?"?(\w+)"?\s+RENAME\s+TO\s+"?(\w+)"?`)
+)
+
+// Migrations lists the up migrations in fsys in version order.
+func Migrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	var migrations []Migration
+	for _, entry := range entries {
+		match := migrationFile.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", entry.Name(), err)
+		}
+		migrations = append(migrations, Migration{Version: uint(version), Name: match[2], file: entry.Name()})
+	}
+
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return int(a.Version) - int(b.Version)
+	})
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version == migrations[i-1].Version {
+			return nil, fmt.Errorf("duplicate migration version %d", migrations[i].Version)
+		}
+	}
+
+	return migrations, nil
+}
+
+// Indexes returns the names of the indexes created by CREATE INDEX in the up
+// migrations up to and including version, replaying later DROP INDEX and
+// ALTER INDEX ... RENAME TO statements, in name order. Indexes backing primary
+// keys and UNIQUE constraints are not included.
+func Indexes(fsys fs.FS, version uint) ([]string, error) {
+	migrations, err := Migrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make(map[string]bool)
+	for _, migration := range migrations {
+		if migration.Version > version {
+			break
+		}
+		data, err := fs.ReadFile(fsys, migration.file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", migration.file, err)
+		}
+
+		// Statements are replayed in file order, so an index created and
+		// dropped by the same migration is gone
+		for _, statement := range strings.Split(sqlComment.ReplaceAllString(string(data), ""), ";") {
+			statement += ";"
+			// An unnamed CREATE INDEX ON gets a generated name that cannot be checked
+			if match := createIndex.FindStringSubmatch(statement); match != nil && !strings.EqualFold(match[1], "on") {
+				indexes[strings.ToLower(match[1])] = true
+			}
+			if match := dropIndex.FindStringSubmatch(statement); match != nil {
+				for _, name := range strings.Split(match[1], ",") {
+					delete(indexes, strings.ToLower(strings.Trim(strings.TrimSpace(name), `"`)))
+				}
+			}
+			if match := renameIndex.FindStringSubmatch(statement); match != nil {
+				delete(indexes, strings.ToLower(match[1]))
+				indexes[strings.ToLower(match[2])] = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(indexes))
+	for name := range indexes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names, nil
+}
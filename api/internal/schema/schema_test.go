@@ -0,0 +1,128 @@
+package schema
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/migrations"
+)
+
+func TestMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000002_add_indexes.up.sql":      {Data: []byte("")},
+		"000002_add_indexes.down.sql":    {Data: []byte("")},
+		"000001_create_parcels.up.sql":   {Data: []byte("")},
+		"000010_add_sync_columns.up.sql": {Data: []byte("")},
+		"migrations.go":                  {Data: []byte("package migrations")},
+	}
+
+	found, err := Migrations(fsys)
+
+	require.NoError(t, err)
+	require.Len(t, found, 3)
+	assert.Equal(t, uint(1), found[0].Version)
+	assert.Equal(t, "create_parcels", found[0].Name)
+	assert.Equal(t, uint(2), found[1].Version)
+	assert.Equal(t, uint(10), found[2].Version)
+}
+
+func TestMigrations_DuplicateVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000003_a.up.sql": {Data: []byte("")},
+		"000003_b.up.sql": {Data: []byte("")},
+	}
+
+	_, err := Migrations(fsys)
+
+	assert.ErrorContains(t, err, "duplicate migration version 3")
+}
+
+func TestIndexes(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000001_create.up.sql": {Data: []byte(`
+			CREATE TABLE t (id SERIAL PRIMARY KEY, a TEXT, b TEXT);
+			-- CREATE INDEX idx_commented ON t (a);
+			CREATE INDEX idx_t_a ON t (a);
+			CREATE UNIQUE INDEX IF NOT EXISTS "idx_t_b" ON t (b);
+			CREATE INDEX ON t (a, b);
+		`)},
+		"000002_rework.up.sql": {Data: []byte(`
+			DROP INDEX IF EXISTS idx_t_a;
+			ALTER INDEX idx_t_b RENAME TO idx_t_b_unique;
+			CREATE INDEX CONCURRENTLY idx_t_ab ON t (a, b);
+		`)},
+	}
+
+	t.Run("replays all migrations", func(t *testing.T) {
+		indexes, err := Indexes(fsys, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"idx_t_ab", "idx_t_b_unique"}, indexes)
+	})
+
+	t.Run("stops at version", func(t *testing.T) {
+		indexes, err := Indexes(fsys, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"idx_t_a", "idx_t_b"}, indexes)
+	})
+}
+
+func TestIndexes_EmbeddedMigrations(t *testing.T) {
+	all, err := Migrations(migrations.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, all)
+
+	indexes, err := Indexes(migrations.FS, all[len(all)-1].Version)
+
+	require.NoError(t, err)
+	assert.Contains(t, indexes, "idx_parcels_geom")
+	assert.Contains(t, indexes, "idx_parcels_situs_trgm")
+	assert.Contains(t, indexes, "idx_parcels_bbox")
+	assert.Contains(t, indexes, "idx_security_events_created_at")
+}
+
+func TestColumns(t *testing.T) {
+	columns := make(map[string]Column)
+	for _, column := range Columns(Tables...) {
+		columns[column.Table+"."+column.Name] = column
+	}
+
+	assert.NotContains(t, columns, "tax_parcels.acres", "gorm:\"-\" fields are not columns")
+	assert.Equal(t, "character varying(100)", columns["tax_parcels.lot"].Type)
+	assert.Equal(t, "geometry(MultiPolygon,4326)", columns["tax_parcels.geom"].Type)
+	assert.Contains(t, columns, "tax_parcels.id", "untagged names are snake case")
+	assert.Contains(t, columns, "embed_widgets.min_lng", "embedded fields are columns")
+}
+
+func TestColumn_Matches(t *testing.T) {
+	tests := []struct {
+		name   string
+		column Column
+		actual string
+		want   bool
+	}{
+		{"same size", Column{Type: "character varying(100)"}, "character varying(100)", true},
+		{"fixed length of the size", Column{Type: "character varying(64)"}, "character(64)", true},
+		{"changed size", Column{Type: "character varying(50)"}, "character varying(100)", false},
+		{"declared type", Column{Type: "geometry(MultiPolygon,4326)"}, "geometry(MultiPolygon, 4326)", true},
+		{"changed declared type", Column{Type: "geometry(MultiPolygon,4326)"}, "geometry(Polygon,4326)", false},
+		{"integer family", Column{kind: familyInteger}, "bigint", true},
+		{"text is not integer", Column{kind: familyInteger}, "text", false},
+		{"timestamp family", Column{kind: familyTimestamp}, "timestamp without time zone", true},
+		{"any type", Column{kind: familyAny}, "jsonb", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.column.Matches(tt.actual))
+		})
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	assert.Equal(t, "id", snakeCase("ID"))
+	assert.Equal(t, "min_lng", snakeCase("MinLng"))
+	assert.Equal(t, "url_path", snakeCase("URLPath"))
+	assert.Equal(t, "tenant_id", snakeCase("TenantID"))
+}
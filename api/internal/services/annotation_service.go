@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// Annotation constants
+const (
+	// MaxAnnotationBodyLength bounds the body of an annotation, in characters
+	MaxAnnotationBodyLength = 5000
+	// MaxAnnotationsLimit bounds the annotations returned per request
+	MaxAnnotationsLimit = 500
+)
+
+// Annotation service errors
+var (
+	ErrInvalidAnnotation   = domain.Validation("invalid annotation")
+	ErrAnnotationNotFound  = domain.NotFound("annotation not found")
+	ErrAnnotationForbidden = domain.Forbidden("only the author can change an annotation")
+)
+
+// AnnotationService defines the interface for the notes users attach to parcels.
+// Users read their own annotations and shared ones; only the author changes one.
+type AnnotationService interface {
+	// CreateAnnotation validates and stores an annotation on the parcel.
+	// Returns ErrInvalidAnnotation or ErrInvalidGeometry when validation fails,
+	// or ErrParcelNotFound if the parcel doesn't exist.
+	CreateAnnotation(ctx context.Context, annotation models.Annotation) (*models.Annotation, error)
+
+	// GetAnnotation returns the annotation if the user may read it.
+	// Returns ErrAnnotationNotFound otherwise.
+	GetAnnotation(ctx context.Context, userID, id uint) (*models.Annotation, error)
+
+	// ListAnnotations returns up to limit annotations the user may read, newest
+	// first, only those on the parcel when parcelID is not 0.
+	// Returns ErrInvalidLimit if limit is out of range.
+	ListAnnotations(ctx context.Context, userID, parcelID uint, limit int) ([]models.Annotation, error)
+
+	// UpdateAnnotation replaces the body, markup and visibility of the user's annotation.
+	// Returns ErrInvalidAnnotation or ErrInvalidGeometry when validation fails,
+	// ErrAnnotationForbidden if another user's shared annotation, or
+	// ErrAnnotationNotFound if no annotation the user may read.
+	UpdateAnnotation(ctx context.Context, annotation models.Annotation) (*models.Annotation, error)
+
+	// DeleteAnnotation deletes the user's annotation.
+	// Returns ErrAnnotationForbidden or ErrAnnotationNotFound as UpdateAnnotation.
+	DeleteAnnotation(ctx context.Context, userID, id uint) error
+}
+
+// annotationService is the concrete implementation of AnnotationService.
+type annotationService struct {
+	repo    repository.AnnotationRepository
+	parcels repository.ParcelRepository
+	log     *logger.Logger
+}
+
+// NewAnnotationService creates a new instance of AnnotationService. Markup areas
+// are checked for topology errors with the parcel repository.
+func NewAnnotationService(repo repository.AnnotationRepository, parcels repository.ParcelRepository, log *logger.Logger) AnnotationService {
+	return &annotationService{
+		repo:    repo,
+		parcels: parcels,
+		log:     log,
+	}
+}
+
+// CreateAnnotation stores the annotation after validate.
+func (s *annotationService) CreateAnnotation(ctx context.Context, annotation models.Annotation) (*models.Annotation, error) {
+	if err := s.validate(ctx, &annotation); err != nil {
+		return nil, err
+	}
+
+	stored, err := s.repo.Create(ctx, &annotation)
+	if err != nil {
+		s.log.Error("Failed to create annotation", err, map[string]interface{}{
+			"user_id":   annotation.UserID,
+			"parcel_id": annotation.ParcelID,
+		})
+		return nil, fmt.Errorf("failed to create annotation: %w", err)
+	}
+	if stored == nil {
+		return nil, ErrParcelNotFound
+	}
+
+	return stored, nil
+}
+
+// GetAnnotation returns the annotation if the user may read it.
+func (s *annotationService) GetAnnotation(ctx context.Context, userID, id uint) (*models.Annotation, error) {
+	annotation, err := s.repo.Get(ctx, userID, id)
+	if err != nil {
+		s.log.Error("Failed to get annotation", err, map[string]interface{}{
+			"user_id":       userID,
+			"annotation_id": id,
+		})
+		return nil, fmt.Errorf("failed to get annotation: %w", err)
+	}
+	if annotation == nil {
+		return nil, ErrAnnotationNotFound
+	}
+
+	return annotation, nil
+}
+
+// ListAnnotations validates the limit and reads the annotations.
+func (s *annotationService) ListAnnotations(ctx context.Context, userID, parcelID uint, limit int) ([]models.Annotation, error) {
+	if limit < 1 || limit > MaxAnnotationsLimit {
+		return nil, fmt.Errorf("%w: must be between 1 and %d, got %d", ErrInvalidLimit, MaxAnnotationsLimit, limit)
+	}
+
+	annotations, err := s.repo.List(ctx, userID, parcelID, limit)
+	if err != nil {
+		s.log.Error("Failed to list annotations", err, map[string]interface{}{
+			"user_id":   userID,
+			"parcel_id": parcelID,
+		})
+		return nil, fmt.Errorf("failed to list annotations: %w", err)
+	}
+
+	return annotations, nil
+}
+
+// UpdateAnnotation rewrites the annotation after validate.
+func (s *annotationService) UpdateAnnotation(ctx context.Context, annotation models.Annotation) (*models.Annotation, error) {
+	if err := s.validate(ctx, &annotation); err != nil {
+		return nil, err
+	}
+
+	stored, err := s.repo.Update(ctx, &annotation)
+	if err != nil {
+		s.log.Error("Failed to update annotation", err, map[string]interface{}{
+			"user_id":       annotation.UserID,
+			"annotation_id": annotation.ID,
+		})
+		return nil, fmt.Errorf("failed to update annotation: %w", err)
+	}
+	if stored == nil {
+		return nil, s.notAuthor(ctx, annotation.UserID, annotation.ID)
+	}
+
+	return stored, nil
+}
+
+// DeleteAnnotation deletes the annotation if the user wrote it.
+func (s *annotationService) DeleteAnnotation(ctx context.Context, userID, id uint) error {
+	deleted, err := s.repo.Delete(ctx, userID, id)
+	if err != nil {
+		s.log.Error("Failed to delete annotation", err, map[string]interface{}{
+			"user_id":       userID,
+			"annotation_id": id,
+		})
+		return fmt.Errorf("failed to delete annotation: %w", err)
+	}
+	if !deleted {
+		return s.notAuthor(ctx, userID, id)
+	}
+
+	return nil
+}
+
+// notAuthor returns the error for a change the user could not make:
+// ErrAnnotationForbidden if they may read the annotation, ErrAnnotationNotFound
+// if they may not, so other users' private annotations stay hidden.
+func (s *annotationService) notAuthor(ctx context.Context, userID, id uint) error {
+	if _, err := s.GetAnnotation(ctx, userID, id); err != nil {
+		return err
+	}
+	return ErrAnnotationForbidden
+}
+
+// validate trims the body and defaults the visibility to private, then checks
+// them and the markup. Markup areas are checked as intersects areas are.
+func (s *annotationService) validate(ctx context.Context, annotation *models.Annotation) error {
+	annotation.Body = strings.TrimSpace(annotation.Body)
+	if annotation.Body == "" || utf8.RuneCountInString(annotation.Body) > MaxAnnotationBodyLength {
+		return fmt.Errorf("%w: body must be between 1 and %d characters", ErrInvalidAnnotation, MaxAnnotationBodyLength)
+	}
+
+	switch annotation.Visibility {
+	case "":
+		annotation.Visibility = models.AnnotationVisibilityPrivate
+	case models.AnnotationVisibilityPrivate, models.AnnotationVisibilityShared:
+	default:
+		return fmt.Errorf("%w: visibility must be %q or %q", ErrInvalidAnnotation,
+			models.AnnotationVisibilityPrivate, models.AnnotationVisibilityShared)
+	}
+
+	if annotation.Markup == nil {
+		return nil
+	}
+	if point := annotation.Markup.Point; point != nil {
+		if err := point.Validate(); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidGeometry, err)
+		}
+		return nil
+	}
+
+	if err := validateArea(annotation.Markup.Area); err != nil {
+		return err
+	}
+	reason, err := s.parcels.ValidateArea(ctx, *annotation.Markup.Area)
+	if err != nil {
+		s.log.Error("Failed to validate markup geometry", err, nil)
+		return fmt.Errorf("failed to validate markup: %w", err)
+	}
+	if reason != "" {
+		return fmt.Errorf("%w: %s", ErrInvalidGeometry, reason)
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// MockAnnotationRepository is a mock implementation of AnnotationRepository for testing
+type MockAnnotationRepository struct {
+	mock.Mock
+}
+
+func (m *MockAnnotationRepository) Create(ctx context.Context, annotation *models.Annotation) (*models.Annotation, error) {
+	args := m.Called(ctx, annotation)
+	stored, _ := args.Get(0).(*models.Annotation)
+	return stored, args.Error(1)
+}
+
+func (m *MockAnnotationRepository) Get(ctx context.Context, userID, id uint) (*models.Annotation, error) {
+	args := m.Called(ctx, userID, id)
+	annotation, _ := args.Get(0).(*models.Annotation)
+	return annotation, args.Error(1)
+}
+
+func (m *MockAnnotationRepository) List(ctx context.Context, userID, parcelID uint, limit int) ([]models.Annotation, error) {
+	args := m.Called(ctx, userID, parcelID, limit)
+	annotations, _ := args.Get(0).([]models.Annotation)
+	return annotations, args.Error(1)
+}
+
+func (m *MockAnnotationRepository) Update(ctx context.Context, annotation *models.Annotation) (*models.Annotation, error) {
+	args := m.Called(ctx, annotation)
+	stored, _ := args.Get(0).(*models.Annotation)
+	return stored, args.Error(1)
+}
+
+func (m *MockAnnotationRepository) Delete(ctx context.Context, userID, id uint) (bool, error) {
+	args := m.Called(ctx, userID, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestCreateAnnotation_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockAnnotationRepository)
+	mockParcels := new(MockParcelRepository)
+	service := NewAnnotationService(mockRepo, mockParcels, logger.New("test"))
+	ctx := context.Background()
+
+	mockParcels.On("ValidateArea", ctx, *testArea()).Return("", nil)
+	var created *models.Annotation
+	mockRepo.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*models.Annotation)
+	}).Return(&models.Annotation{ID: 5, ParcelID: 42}, nil)
+
+	// Act
+	result, err := service.CreateAnnotation(ctx, models.Annotation{
+		UserID:   7,
+		ParcelID: 42,
+		Body:     "  Culvert washed out  ",
+		Markup:   &models.Markup{Area: testArea()},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, uint(5), result.ID)
+	assert.Equal(t, "Culvert washed out", created.Body)
+	assert.Equal(t, models.AnnotationVisibilityPrivate, created.Visibility)
+	mockRepo.AssertExpectations(t)
+	mockParcels.AssertExpectations(t)
+}
+
+func TestCreateAnnotation_ValidationErrors(t *testing.T) {
+	testCases := []struct {
+		name       string
+		annotation models.Annotation
+		errType    error
+	}{
+		{"blank body", models.Annotation{ParcelID: 42, Body: "  "}, ErrInvalidAnnotation},
+		{"body too long", models.Annotation{ParcelID: 42, Body: strings.Repeat("x", MaxAnnotationBodyLength+1)}, ErrInvalidAnnotation},
+		{"unknown visibility", models.Annotation{ParcelID: 42, Body: "note", Visibility: "public"}, ErrInvalidAnnotation},
+		{"point out of range", models.Annotation{ParcelID: 42, Body: "note",
+			Markup: &models.Markup{Point: &models.LatLng{Lat: 91, Lng: -95.4}}}, ErrInvalidGeometry},
+		{"open ring", models.Annotation{ParcelID: 42, Body: "note", Markup: &models.Markup{Area: &models.MultiPolygon{
+			Coordinates: [][][][2]float64{{{{-95.4, 30.3}, {-95.3, 30.3}, {-95.3, 30.4}, {-95.4, 30.4}}}}}}}, ErrInvalidGeometry},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockAnnotationRepository)
+			service := NewAnnotationService(mockRepo, new(MockParcelRepository), logger.New("test"))
+
+			// Act
+			result, err := service.CreateAnnotation(context.Background(), tc.annotation)
+
+			// Assert
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tc.errType)
+			mockRepo.AssertNotCalled(t, "Create")
+		})
+	}
+}
+
+func TestCreateAnnotation_ParcelNotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockAnnotationRepository)
+	service := NewAnnotationService(mockRepo, new(MockParcelRepository), logger.New("test"))
+	ctx := context.Background()
+
+	mockRepo.On("Create", ctx, mock.Anything).Return(nil, nil)
+
+	// Act
+	result, err := service.CreateAnnotation(ctx, models.Annotation{UserID: 7, ParcelID: 42, Body: "note",
+		Markup: &models.Markup{Point: &models.LatLng{Lat: 30.3, Lng: -95.4}}})
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, ErrParcelNotFound)
+}
+
+func TestUpdateAnnotation_NotAuthor(t *testing.T) {
+	testCases := []struct {
+		name     string
+		readable *models.Annotation
+		errType  error
+	}{
+		{"shared annotation of another user", &models.Annotation{ID: 5, UserID: 8, Visibility: models.AnnotationVisibilityShared}, ErrAnnotationForbidden},
+		{"private annotation of another user", nil, ErrAnnotationNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockAnnotationRepository)
+			service := NewAnnotationService(mockRepo, new(MockParcelRepository), logger.New("test"))
+			ctx := context.Background()
+
+			mockRepo.On("Update", ctx, mock.Anything).Return(nil, nil)
+			mockRepo.On("Get", ctx, uint(7), uint(5)).Return(tc.readable, nil)
+
+			// Act
+			result, err := service.UpdateAnnotation(ctx, models.Annotation{ID: 5, UserID: 7, Body: "note"})
+
+			// Assert
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tc.errType)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteAnnotation(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockAnnotationRepository)
+	service := NewAnnotationService(mockRepo, new(MockParcelRepository), logger.New("test"))
+	ctx := context.Background()
+
+	mockRepo.On("Delete", ctx, uint(7), uint(5)).Return(true, nil)
+	mockRepo.On("Delete", ctx, uint(7), uint(6)).Return(false, nil)
+	mockRepo.On("Get", ctx, uint(7), uint(6)).Return(&models.Annotation{ID: 6, UserID: 8}, nil)
+
+	// Act & Assert
+	assert.NoError(t, service.DeleteAnnotation(ctx, 7, 5))
+	assert.ErrorIs(t, service.DeleteAnnotation(ctx, 7, 6), ErrAnnotationForbidden)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListAnnotations(t *testing.T) {
+	t.Run("returns annotations", func(t *testing.T) {
+		mockRepo := new(MockAnnotationRepository)
+		service := NewAnnotationService(mockRepo, new(MockParcelRepository), logger.New("test"))
+		ctx := context.Background()
+
+		mockRepo.On("List", ctx, uint(7), uint(42), 10).Return([]models.Annotation{{ID: 5}}, nil)
+
+		annotations, err := service.ListAnnotations(ctx, 7, 42, 10)
+
+		require.NoError(t, err)
+		assert.Len(t, annotations, 1)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		mockRepo := new(MockAnnotationRepository)
+		service := NewAnnotationService(mockRepo, new(MockParcelRepository), logger.New("test"))
+
+		_, err := service.ListAnnotations(context.Background(), 7, 0, MaxAnnotationsLimit+1)
+
+		assert.ErrorIs(t, err, ErrInvalidLimit)
+		mockRepo.AssertNotCalled(t, "List")
+	})
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// APIKeyPrefix starts every generated key, so leaked keys are easy to recognize.
+const APIKeyPrefix = "atlas_"
+
+// API key format: APIKeyPrefix followed by apiKeyRandomBytes of base64url randomness.
+// The first apiKeyDisplayLength characters are stored in the clear to identify the key.
+const (
+	apiKeyRandomBytes   = 32
+	apiKeyDisplayLength = len(APIKeyPrefix) + 6
+)
+
+// API key service errors
+var (
+	ErrInvalidAPIKey  = domain.Validation("invalid api key")
+	ErrAPIKeyNotFound = domain.NotFound("api key not found")
+)
+
+// APIKeyService defines the interface for API key operations.
+type APIKeyService interface {
+	// CreateKey generates a key for the tenant and returns it with the key
+	// itself, which is not stored and cannot be retrieved again. An empty role
+	// defaults to models.APIKeyRolePublic.
+	// Returns ErrInvalidTenant or ErrInvalidAPIKey when validation fails.
+	CreateKey(ctx context.Context, tenantID, name, role string) (*models.APIKey, string, error)
+
+	// ListKeys returns every key of the tenant, including revoked keys.
+	// Returns ErrInvalidTenant if the tenant id is malformed.
+	ListKeys(ctx context.Context, tenantID string) ([]models.APIKey, error)
+
+	// RevokeKey revokes the tenant's key.
+	// Returns ErrAPIKeyNotFound if the tenant has no such unrevoked key.
+	RevokeKey(ctx context.Context, tenantID string, id uint) error
+
+	// Authenticate returns the key a client presented.
+	// Returns nil, nil if the key is unknown or revoked.
+	Authenticate(ctx context.Context, key string) (*models.APIKey, error)
+}
+
+// cachedAPIKey is a validated key remembered until expires.
+type cachedAPIKey struct {
+	expires time.Time
+	key     *models.APIKey
+}
+
+// apiKeyService is the concrete implementation of APIKeyService.
+type apiKeyService struct {
+	repo     repository.APIKeyRepository
+	log      *logger.Logger
+	cache    map[string]cachedAPIKey
+	cacheTTL time.Duration
+	mu       sync.Mutex
+}
+
+// NewAPIKeyService creates a new instance of APIKeyService. Validated keys are
+// cached for cacheTTL, so revocations reach other instances within that time;
+// zero disables the cache. Only valid keys are cached, which bounds the cache by
+// the number of keys in use.
+func NewAPIKeyService(repo repository.APIKeyRepository, cacheTTL time.Duration, log *logger.Logger) APIKeyService {
+	return &apiKeyService{
+		repo:     repo,
+		log:      log,
+		cache:    make(map[string]cachedAPIKey),
+		cacheTTL: cacheTTL,
+	}
+}
+
+// CreateKey validates the request, generates a random key and stores its hash.
+func (s *apiKeyService) CreateKey(ctx context.Context, tenantID, name, role string) (*models.APIKey, string, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, "", err
+	}
+	if !identifierPattern.MatchString(name) {
+		return nil, "", fmt.Errorf("%w: name must be a lowercase slug of at most 100 characters, got %q", ErrInvalidAPIKey, name)
+	}
+	if role == "" {
+		role = models.APIKeyRolePublic
+	}
+	if role != models.APIKeyRolePublic && role != models.APIKeyRoleAdmin {
+		return nil, "", fmt.Errorf("%w: role must be %q or %q, got %q", ErrInvalidAPIKey, models.APIKeyRolePublic, models.APIKeyRoleAdmin, role)
+	}
+
+	random := make([]byte, apiKeyRandomBytes)
+	if _, err := rand.Read(random); err != nil {
+		return nil, "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	secret := APIKeyPrefix + base64.RawURLEncoding.EncodeToString(random)
+
+	key, err := s.repo.Create(ctx, &models.APIKey{
+		TenantID: tenantID,
+		Name:     name,
+		Prefix:   secret[:apiKeyDisplayLength],
+		KeyHash:  hashAPIKey(secret),
+		Role:     role,
+	})
+	if err != nil {
+		s.log.Error("Failed to create api key", err, map[string]interface{}{
+			"tenant_id": tenantID,
+			"name":      name,
+		})
+		return nil, "", fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	s.log.Info("API key created", map[string]interface{}{
+		"tenant_id":  tenantID,
+		"api_key_id": key.ID,
+		"prefix":     key.Prefix,
+		"role":       key.Role,
+	})
+	return key, secret, nil
+}
+
+// ListKeys returns the tenant's keys, newest first.
+func (s *apiKeyService) ListKeys(ctx context.Context, tenantID string) ([]models.APIKey, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, err
+	}
+
+	keys, err := s.repo.List(ctx, tenantID)
+	if err != nil {
+		s.log.Error("Failed to list api keys", err, map[string]interface{}{
+			"tenant_id": tenantID,
+		})
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// RevokeKey revokes the key and drops it from this instance's cache.
+func (s *apiKeyService) RevokeKey(ctx context.Context, tenantID string, id uint) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return err
+	}
+
+	revoked, err := s.repo.Revoke(ctx, tenantID, id)
+	if err != nil {
+		s.log.Error("Failed to revoke api key", err, map[string]interface{}{
+			"tenant_id":  tenantID,
+			"api_key_id": id,
+		})
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	if !revoked {
+		return ErrAPIKeyNotFound
+	}
+
+	s.mu.Lock()
+	for hash, cached := range s.cache {
+		if cached.key.ID == id {
+			delete(s.cache, hash)
+		}
+	}
+	s.mu.Unlock()
+
+	s.log.Info("API key revoked", map[string]interface{}{
+		"tenant_id":  tenantID,
+		"api_key_id": id,
+	})
+	return nil
+}
+
+// Authenticate looks the key up by hash, answering from the cache when it can.
+// last_used_at is updated on each cache miss, so it is accurate to the cache TTL.
+func (s *apiKeyService) Authenticate(ctx context.Context, key string) (*models.APIKey, error) {
+	hash := hashAPIKey(key)
+
+	s.mu.Lock()
+	cached, ok := s.cache[hash]
+	s.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.key, nil
+	}
+
+	found, err := s.repo.FindByHash(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up api key: %w", err)
+	}
+	if found == nil || found.RevokedAt != nil {
+		s.mu.Lock()
+		delete(s.cache, hash)
+		s.mu.Unlock()
+		return nil, nil
+	}
+
+	if err := s.repo.TouchLastUsed(ctx, found.ID); err != nil {
+		// Bookkeeping only; the key is still valid
+		s.log.Warn("Failed to record api key use", map[string]interface{}{
+			"api_key_id": found.ID,
+			"error":      err.Error(),
+		})
+	}
+
+	if s.cacheTTL > 0 {
+		s.mu.Lock()
+		s.cache[hash] = cachedAPIKey{key: found, expires: time.Now().Add(s.cacheTTL)}
+		s.mu.Unlock()
+	}
+	return found, nil
+}
+
+// hashAPIKey returns the hex SHA-256 of a key. Keys carry 256 random bits, so a
+// fast unsalted hash is enough and lets keys be looked up by hash.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
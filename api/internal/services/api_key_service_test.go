@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// MockAPIKeyRepository is a mock implementation of APIKeyRepository for testing
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *models.APIKey) (*models.APIKey, error) {
+	args := m.Called(ctx, key)
+	stored, _ := args.Get(0).(*models.APIKey)
+	return stored, args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) FindByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	args := m.Called(ctx, hash)
+	key, _ := args.Get(0).(*models.APIKey)
+	return key, args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) List(ctx context.Context, tenantID string) ([]models.APIKey, error) {
+	args := m.Called(ctx, tenantID)
+	keys, _ := args.Get(0).([]models.APIKey)
+	return keys, args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, tenantID string, id uint) (bool, error) {
+	args := m.Called(ctx, tenantID, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestCreateKey_StoresHashOnly(t *testing.T) {
+	mockRepo := new(MockAPIKeyRepository)
+	service := NewAPIKeyService(mockRepo, time.Minute, logger.New("test"))
+
+	var stored *models.APIKey
+	mockRepo.On("Create", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(*models.APIKey) }).
+		Return(&models.APIKey{ID: 7, TenantID: "acme", Name: "mobile", Role: models.APIKeyRolePublic}, nil)
+
+	key, secret, err := service.CreateKey(context.Background(), "acme", "mobile", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), key.ID)
+	assert.True(t, strings.HasPrefix(secret, APIKeyPrefix))
+	assert.Equal(t, models.APIKeyRolePublic, stored.Role)
+	assert.Equal(t, hashAPIKey(secret), stored.KeyHash)
+	assert.Equal(t, secret[:apiKeyDisplayLength], stored.Prefix)
+	assert.NotContains(t, stored.KeyHash, secret)
+}
+
+func TestCreateKey_Validation(t *testing.T) {
+	tests := []struct {
+		wantErr error
+		name    string
+		tenant  string
+		keyName string
+		role    string
+	}{
+		{name: "invalid tenant", tenant: "Acme Corp", keyName: "mobile", wantErr: ErrInvalidTenant},
+		{name: "invalid name", tenant: "acme", keyName: "Mobile App", wantErr: ErrInvalidAPIKey},
+		{name: "unknown role", tenant: "acme", keyName: "mobile", role: "partner", wantErr: ErrInvalidAPIKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockAPIKeyRepository)
+			service := NewAPIKeyService(mockRepo, time.Minute, logger.New("test"))
+
+			_, _, err := service.CreateKey(context.Background(), tt.tenant, tt.keyName, tt.role)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAuthenticate_CachesValidKeys(t *testing.T) {
+	mockRepo := new(MockAPIKeyRepository)
+	service := NewAPIKeyService(mockRepo, time.Minute, logger.New("test"))
+
+	stored := &models.APIKey{ID: 3, TenantID: "acme", Role: models.APIKeyRoleAdmin}
+	mockRepo.On("FindByHash", mock.Anything, hashAPIKey("atlas_secret")).Return(stored, nil).Once()
+	mockRepo.On("TouchLastUsed", mock.Anything, uint(3)).Return(errors.New("read-only replica")).Once()
+
+	for range 3 {
+		key, err := service.Authenticate(context.Background(), "atlas_secret")
+		require.NoError(t, err)
+		assert.Equal(t, stored, key)
+	}
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAuthenticate_UnknownAndRevokedKeys(t *testing.T) {
+	mockRepo := new(MockAPIKeyRepository)
+	service := NewAPIKeyService(mockRepo, time.Minute, logger.New("test"))
+
+	revokedAt := time.Now()
+	mockRepo.On("FindByHash", mock.Anything, hashAPIKey("atlas_unknown")).Return(nil, nil)
+	mockRepo.On("FindByHash", mock.Anything, hashAPIKey("atlas_revoked")).
+		Return(&models.APIKey{ID: 4, RevokedAt: &revokedAt}, nil)
+
+	for _, presented := range []string{"atlas_unknown", "atlas_revoked"} {
+		key, err := service.Authenticate(context.Background(), presented)
+		require.NoError(t, err)
+		assert.Nil(t, key, presented)
+	}
+	mockRepo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything)
+}
+
+func TestAuthenticate_RepositoryError(t *testing.T) {
+	mockRepo := new(MockAPIKeyRepository)
+	service := NewAPIKeyService(mockRepo, time.Minute, logger.New("test"))
+
+	dbError := errors.New("database connection failed")
+	mockRepo.On("FindByHash", mock.Anything, mock.Anything).Return(nil, dbError)
+
+	_, err := service.Authenticate(context.Background(), "atlas_secret")
+
+	assert.ErrorIs(t, err, dbError)
+}
+
+func TestRevokeKey_EvictsCachedKey(t *testing.T) {
+	mockRepo := new(MockAPIKeyRepository)
+	service := NewAPIKeyService(mockRepo, time.Hour, logger.New("test"))
+
+	stored := &models.APIKey{ID: 5, TenantID: "acme"}
+	mockRepo.On("FindByHash", mock.Anything, mock.Anything).Return(stored, nil).Once()
+	mockRepo.On("TouchLastUsed", mock.Anything, uint(5)).Return(nil)
+	_, err := service.Authenticate(context.Background(), "atlas_secret")
+	require.NoError(t, err)
+
+	mockRepo.On("Revoke", mock.Anything, "acme", uint(5)).Return(true, nil)
+	require.NoError(t, service.RevokeKey(context.Background(), "acme", 5))
+
+	// The next request goes back to the database
+	revokedAt := time.Now()
+	mockRepo.On("FindByHash", mock.Anything, mock.Anything).Return(&models.APIKey{ID: 5, RevokedAt: &revokedAt}, nil).Once()
+	key, err := service.Authenticate(context.Background(), "atlas_secret")
+	require.NoError(t, err)
+	assert.Nil(t, key)
+}
+
+func TestRevokeKey_NotFound(t *testing.T) {
+	mockRepo := new(MockAPIKeyRepository)
+	service := NewAPIKeyService(mockRepo, time.Minute, logger.New("test"))
+	mockRepo.On("Revoke", mock.Anything, "acme", uint(9)).Return(false, nil)
+
+	err := service.RevokeKey(context.Background(), "acme", 9)
+
+	assert.ErrorIs(t, err, ErrAPIKeyNotFound)
+}
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// Assemblage analysis constants
+const (
+	MaxAssemblageTargetAcres = 10000
+	MinAssemblageLimit       = 1
+	MaxAssemblageLimit       = 50
+	// MaxAssemblageSize caps the parcels of one candidate; sites needing more are
+	// beyond what a single acquisition project assembles
+	MaxAssemblageSize = 25
+	// MaxAssemblageAreaParcels caps the parcels analyzed in one area
+	MaxAssemblageAreaParcels = 2000
+)
+
+// Assemblage service errors
+var (
+	ErrInvalidTargetAcres     = domain.Validation("target acres must be greater than 0 and at most 10000")
+	ErrAssemblageAreaTooLarge = domain.Validation(fmt.Sprintf("area contains more than %d parcels, draw a smaller area", MaxAssemblageAreaParcels))
+)
+
+// AssemblageOptions controls an assemblage search.
+// SameOwner only groups parcels whose owner names match, ignoring case and spacing.
+type AssemblageOptions struct {
+	TargetAcres float64
+	Limit       int
+	SameOwner   bool
+}
+
+// Assemblage is a group of contiguous parcels whose combined area meets the target.
+// Owners lists each distinct owner once, in the order their parcels were added;
+// parcels without an owner name count as separate owners but are not listed.
+type Assemblage struct {
+	ParcelIDs  []uint
+	Owners     []string
+	Acres      float64
+	OwnerCount int
+}
+
+// AssemblageService defines the interface for finding development site assemblages.
+type AssemblageService interface {
+	// FindAssemblages returns up to opts.Limit groups of neighboring parcels in the
+	// area whose combined acres reach opts.TargetAcres, ranked by parcel count, then
+	// owner count, then the smallest excess over the target.
+	// Returns ErrInvalidGeometry if the area is malformed, out of range, too complex or not valid per PostGIS.
+	// Returns ErrInvalidTargetAcres if the target is not greater than 0 and at most 10000 acres.
+	// Returns ErrInvalidLimit if the limit is not between 1 and 50.
+	// Returns ErrAssemblageAreaTooLarge if the area holds more than MaxAssemblageAreaParcels parcels.
+	// Returns an empty slice if no group reaches the target (not an error).
+	// Returns error for database failures.
+	FindAssemblages(ctx context.Context, area *models.MultiPolygon, opts AssemblageOptions) ([]Assemblage, error)
+}
+
+// assemblageService is the concrete implementation of AssemblageService.
+type assemblageService struct {
+	repo repository.ParcelRepository
+	log  *logger.Logger
+}
+
+// NewAssemblageService creates a new instance of AssemblageService.
+func NewAssemblageService(repo repository.ParcelRepository, log *logger.Logger) AssemblageService {
+	return &assemblageService{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// FindAssemblages loads the adjacency graph of the area and grows a group from
+// every parcel. One extra parcel is requested to detect areas over the cap.
+func (s *assemblageService) FindAssemblages(ctx context.Context, area *models.MultiPolygon, opts AssemblageOptions) ([]Assemblage, error) {
+	if opts.TargetAcres <= 0 || opts.TargetAcres > MaxAssemblageTargetAcres {
+		return nil, fmt.Errorf("%w: got %g", ErrInvalidTargetAcres, opts.TargetAcres)
+	}
+	if opts.Limit < MinAssemblageLimit || opts.Limit > MaxAssemblageLimit {
+		return nil, fmt.Errorf("%w: must be between %d and %d, got %d",
+			ErrInvalidLimit, MinAssemblageLimit, MaxAssemblageLimit, opts.Limit)
+	}
+
+	if err := validateAreaTopology(ctx, s.repo, area, s.log); err != nil {
+		return nil, err
+	}
+
+	parcels, err := s.repo.FindAdjacency(ctx, *area, MaxAssemblageAreaParcels+1)
+	if err != nil {
+		s.log.Error("Failed to query parcel adjacency", err, nil)
+		return nil, fmt.Errorf("failed to find assemblages: %w", err)
+	}
+	if len(parcels) > MaxAssemblageAreaParcels {
+		return nil, ErrAssemblageAreaTooLarge
+	}
+
+	assemblages := findAssemblages(parcels, opts)
+
+	s.log.Info("Assemblage analysis completed", map[string]interface{}{
+		"parcels":      len(parcels),
+		"target_acres": opts.TargetAcres,
+		"same_owner":   opts.SameOwner,
+		"candidates":   len(assemblages),
+	})
+
+	if len(assemblages) > opts.Limit {
+		assemblages = assemblages[:opts.Limit]
+	}
+	return assemblages, nil
+}
+
+// findAssemblages grows a group greedily from each parcel until it reaches the
+// target or MaxAssemblageSize, and returns the distinct groups that reached it in
+// rank order. Each step adds the neighbor of an owner already in the group when
+// there is one, otherwise the largest neighbor, keeping both parcel and owner
+// counts low.
+func findAssemblages(parcels []repository.AdjacentParcel, opts AssemblageOptions) []Assemblage {
+	byID := make(map[uint]*repository.AdjacentParcel, len(parcels))
+	for i := range parcels {
+		byID[parcels[i].ID] = &parcels[i]
+	}
+
+	seen := make(map[string]bool)
+	var assemblages []Assemblage
+	for i := range parcels {
+		group, ok := growAssemblage(&parcels[i], byID, opts)
+		if !ok {
+			continue
+		}
+		key := assemblageKey(group.ParcelIDs)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		assemblages = append(assemblages, group)
+	}
+
+	slices.SortFunc(assemblages, func(a, b Assemblage) int {
+		if len(a.ParcelIDs) != len(b.ParcelIDs) {
+			return len(a.ParcelIDs) - len(b.ParcelIDs)
+		}
+		if a.OwnerCount != b.OwnerCount {
+			return a.OwnerCount - b.OwnerCount
+		}
+		if a.Acres != b.Acres {
+			if a.Acres < b.Acres {
+				return -1
+			}
+			return 1
+		}
+		return slices.Compare(a.ParcelIDs, b.ParcelIDs)
+	})
+	return assemblages
+}
+
+// growAssemblage grows a group from seed; ok is false when it cannot reach the target.
+func growAssemblage(seed *repository.AdjacentParcel, byID map[uint]*repository.AdjacentParcel, opts AssemblageOptions) (Assemblage, bool) {
+	group := Assemblage{}
+	owners := make(map[string]bool)
+	members := make(map[uint]bool)
+	add := func(p *repository.AdjacentParcel) {
+		members[p.ID] = true
+		group.ParcelIDs = append(group.ParcelIDs, p.ID)
+		group.Acres += p.Acres
+		if key := ownerKey(p); !owners[key] {
+			owners[key] = true
+			group.OwnerCount++
+			if p.OwnerName != nil && strings.TrimSpace(*p.OwnerName) != "" {
+				group.Owners = append(group.Owners, strings.TrimSpace(*p.OwnerName))
+			}
+		}
+	}
+	add(seed)
+
+	for group.Acres < opts.TargetAcres && len(group.ParcelIDs) < MaxAssemblageSize {
+		var best *repository.AdjacentParcel
+		bestKnownOwner := false
+		for _, id := range group.ParcelIDs {
+			for _, neighborID := range byID[id].Neighbors {
+				neighbor, ok := byID[neighborID]
+				if !ok || members[neighborID] {
+					continue
+				}
+				knownOwner := owners[ownerKey(neighbor)]
+				if opts.SameOwner && !knownOwner {
+					continue
+				}
+				if best == nil || betterNeighbor(neighbor, knownOwner, best, bestKnownOwner) {
+					best, bestKnownOwner = neighbor, knownOwner
+				}
+			}
+		}
+		if best == nil {
+			break
+		}
+		add(best)
+	}
+
+	if group.Acres < opts.TargetAcres {
+		return Assemblage{}, false
+	}
+	slices.Sort(group.ParcelIDs)
+	return group, true
+}
+
+// betterNeighbor reports whether p should be added before the current best:
+// owners already in the group first, then larger parcels, then lower IDs.
+func betterNeighbor(p *repository.AdjacentParcel, knownOwner bool, best *repository.AdjacentParcel, bestKnownOwner bool) bool {
+	if knownOwner != bestKnownOwner {
+		return knownOwner
+	}
+	if p.Acres != best.Acres {
+		return p.Acres > best.Acres
+	}
+	return p.ID < best.ID
+}
+
+// ownerKey normalizes an owner name for comparison. Parcels without an owner name
+// get a key of their own, so they never match another parcel's owner.
+func ownerKey(p *repository.AdjacentParcel) string {
+	if p.OwnerName != nil {
+		if name := strings.Join(strings.Fields(strings.ToUpper(*p.OwnerName)), " "); name != "" {
+			return name
+		}
+	}
+	return "#" + strconv.FormatUint(uint64(p.ID), 10)
+}
+
+// assemblageKey identifies a group by its sorted parcel IDs.
+func assemblageKey(ids []uint) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.FormatUint(uint64(id), 10)
+	}
+	return strings.Join(parts, ",")
+}
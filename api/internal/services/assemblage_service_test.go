@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// testAdjacency is a row of four parcels, 1-2-3-4, plus parcel 5 apart from them.
+// Parcels 2 and 3 share an owner, spelled differently; parcel 4 has none.
+func testAdjacency() []repository.AdjacentParcel {
+	owner := func(name string) *string { return &name }
+	return []repository.AdjacentParcel{
+		{ID: 1, OwnerName: owner("SMITH JOHN"), Acres: 3, Neighbors: []uint{2}},
+		{ID: 2, OwnerName: owner("Acme Land LLC"), Acres: 2, Neighbors: []uint{1, 3}},
+		{ID: 3, OwnerName: owner("ACME  LAND LLC"), Acres: 2, Neighbors: []uint{2, 4}},
+		{ID: 4, Acres: 4, Neighbors: []uint{3}},
+		{ID: 5, OwnerName: owner("DOE JANE"), Acres: 6},
+	}
+}
+
+func TestFindAssemblages_RankedByParcelAndOwnerCount(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	service := NewAssemblageService(mockRepo, logger.New("test"))
+
+	ctx := context.Background()
+	area := testArea()
+	mockRepo.On("ValidateArea", ctx, *area).Return("", nil)
+	mockRepo.On("FindAdjacency", ctx, *area, MaxAssemblageAreaParcels+1).Return(testAdjacency(), nil)
+
+	// Act
+	assemblages, err := service.FindAssemblages(ctx, area, AssemblageOptions{TargetAcres: 5, Limit: 10})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, assemblages, 4)
+	assert.Equal(t, []uint{5}, assemblages[0].ParcelIDs, "a single parcel meeting the target ranks first")
+	assert.Equal(t, []uint{1, 2}, assemblages[1].ParcelIDs)
+	assert.Equal(t, 2, assemblages[1].OwnerCount)
+	assert.InDelta(t, 5.0, assemblages[1].Acres, 1e-9)
+	assert.Equal(t, []uint{3, 4}, assemblages[2].ParcelIDs)
+	assert.Equal(t, []string{"ACME  LAND LLC"}, assemblages[2].Owners, "parcels without an owner are not listed")
+	// From parcel 2 the same-owner neighbor 3 is added before the larger parcel 1,
+	// then parcel 4 as the larger of the remaining neighbors
+	assert.Equal(t, []uint{2, 3, 4}, assemblages[3].ParcelIDs)
+	assert.Equal(t, 2, assemblages[3].OwnerCount)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFindAssemblages_SameOwner(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	service := NewAssemblageService(mockRepo, logger.New("test"))
+
+	ctx := context.Background()
+	area := testArea()
+	mockRepo.On("ValidateArea", ctx, *area).Return("", nil)
+	mockRepo.On("FindAdjacency", ctx, *area, MaxAssemblageAreaParcels+1).Return(testAdjacency(), nil)
+
+	assemblages, err := service.FindAssemblages(ctx, area, AssemblageOptions{TargetAcres: 4, Limit: 10, SameOwner: true})
+
+	require.NoError(t, err)
+	require.Len(t, assemblages, 3)
+	assert.Equal(t, []uint{4}, assemblages[0].ParcelIDs)
+	assert.Equal(t, []uint{5}, assemblages[1].ParcelIDs)
+	assert.Equal(t, []uint{2, 3}, assemblages[2].ParcelIDs)
+	assert.Equal(t, 1, assemblages[2].OwnerCount)
+}
+
+func TestFindAssemblages_Limit(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	service := NewAssemblageService(mockRepo, logger.New("test"))
+
+	ctx := context.Background()
+	area := testArea()
+	mockRepo.On("ValidateArea", ctx, *area).Return("", nil)
+	mockRepo.On("FindAdjacency", ctx, *area, MaxAssemblageAreaParcels+1).Return(testAdjacency(), nil)
+
+	assemblages, err := service.FindAssemblages(ctx, area, AssemblageOptions{TargetAcres: 5, Limit: 2})
+
+	require.NoError(t, err)
+	assert.Len(t, assemblages, 2)
+}
+
+func TestFindAssemblages_TargetNotReached(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	service := NewAssemblageService(mockRepo, logger.New("test"))
+
+	ctx := context.Background()
+	area := testArea()
+	mockRepo.On("ValidateArea", ctx, *area).Return("", nil)
+	mockRepo.On("FindAdjacency", ctx, *area, MaxAssemblageAreaParcels+1).Return(testAdjacency(), nil)
+
+	assemblages, err := service.FindAssemblages(ctx, area, AssemblageOptions{TargetAcres: 12, Limit: 10})
+
+	require.NoError(t, err)
+	assert.Empty(t, assemblages)
+}
+
+func TestFindAssemblages_InvalidInput(t *testing.T) {
+	testCases := []struct {
+		want error
+		name string
+		opts AssemblageOptions
+	}{
+		{ErrInvalidTargetAcres, "zero target", AssemblageOptions{Limit: 10}},
+		{ErrInvalidTargetAcres, "target too large", AssemblageOptions{TargetAcres: MaxAssemblageTargetAcres + 1, Limit: 10}},
+		{ErrInvalidLimit, "zero limit", AssemblageOptions{TargetAcres: 5}},
+		{ErrInvalidLimit, "limit too large", AssemblageOptions{TargetAcres: 5, Limit: MaxAssemblageLimit + 1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockParcelRepository)
+			service := NewAssemblageService(mockRepo, logger.New("test"))
+
+			_, err := service.FindAssemblages(context.Background(), testArea(), tc.opts)
+
+			assert.ErrorIs(t, err, tc.want)
+			mockRepo.AssertNotCalled(t, "FindAdjacency")
+		})
+	}
+}
+
+func TestFindAssemblages_InvalidArea(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	service := NewAssemblageService(mockRepo, logger.New("test"))
+
+	ctx := context.Background()
+	area := testArea()
+	mockRepo.On("ValidateArea", ctx, *area).Return("Self-intersection", nil)
+
+	_, err := service.FindAssemblages(ctx, area, AssemblageOptions{TargetAcres: 5, Limit: 10})
+
+	assert.ErrorIs(t, err, ErrInvalidGeometry)
+	mockRepo.AssertNotCalled(t, "FindAdjacency")
+}
+
+func TestFindAssemblages_AreaTooLarge(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	service := NewAssemblageService(mockRepo, logger.New("test"))
+
+	parcels := make([]repository.AdjacentParcel, MaxAssemblageAreaParcels+1)
+	mockRepo.On("ValidateArea", mock.Anything, mock.Anything).Return("", nil)
+	mockRepo.On("FindAdjacency", mock.Anything, mock.Anything, MaxAssemblageAreaParcels+1).Return(parcels, nil)
+
+	_, err := service.FindAssemblages(context.Background(), testArea(), AssemblageOptions{TargetAcres: 5, Limit: 10})
+
+	assert.ErrorIs(t, err, ErrAssemblageAreaTooLarge)
+}
+
+func TestFindAssemblages_RepositoryError(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	service := NewAssemblageService(mockRepo, logger.New("test"))
+
+	dbError := errors.New("database connection failed")
+	mockRepo.On("ValidateArea", mock.Anything, mock.Anything).Return("", nil)
+	mockRepo.On("FindAdjacency", mock.Anything, mock.Anything, mock.Anything).Return(nil, dbError)
+
+	_, err := service.FindAssemblages(context.Background(), testArea(), AssemblageOptions{TargetAcres: 5, Limit: 10})
+
+	assert.ErrorIs(t, err, dbError)
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"github.com/stwalsh4118/atlas/api/internal/tracing"
+)
+
+// ParcelAssessmentChange is an assessment with its change from the parcel's
+// previous recorded year. Change is nil for the oldest recorded year.
+type ParcelAssessmentChange struct {
+	Change     *AssessmentChange
+	Assessment models.ParcelAssessment
+}
+
+// AssessmentChange is the change of the market, appraised and assessed values
+// from PreviousYear, the closest earlier year recorded (not always the year before).
+type AssessmentChange struct {
+	Market       ValueChange
+	Appraised    ValueChange
+	Assessed     ValueChange
+	PreviousYear int
+}
+
+// ValueChange is the change of a value in whole dollars. Percent is relative
+// to the previous value, and nil where that was zero.
+type ValueChange struct {
+	Percent *float64
+	Amount  int64
+}
+
+// AssessmentService defines the interface for parcel assessment history operations.
+type AssessmentService interface {
+	// GetParcelAssessments returns the yearly assessments of the parcel, newest
+	// year first, each with its change from the previous recorded year.
+	// Returns an empty slice if the parcel has no recorded assessments (not an error).
+	// Returns ErrParcelNotFound if no live parcel has the id.
+	GetParcelAssessments(ctx context.Context, parcelID uint) ([]ParcelAssessmentChange, error)
+}
+
+// assessmentService is the concrete implementation of AssessmentService.
+type assessmentService struct {
+	repo repository.AssessmentRepository
+	log  *logger.Logger
+}
+
+// NewAssessmentService creates a new instance of AssessmentService.
+func NewAssessmentService(repo repository.AssessmentRepository, log *logger.Logger) AssessmentService {
+	return &assessmentService{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// GetParcelAssessments looks up the assessments of the parcel and compares
+// each with the next one, which is the previous year's.
+func (s *assessmentService) GetParcelAssessments(ctx context.Context, parcelID uint) ([]ParcelAssessmentChange, error) {
+	ctx, span := tracing.Start(ctx, "AssessmentService.GetParcelAssessments")
+	defer span.End()
+
+	assessments, err := s.repo.FindForParcel(ctx, parcelID)
+	if err != nil {
+		s.log.Error("Failed to find parcel assessments", err, map[string]interface{}{
+			"parcel_id": parcelID,
+		})
+		return nil, fmt.Errorf("failed to find parcel assessments: %w", err)
+	}
+	if assessments == nil {
+		return nil, fmt.Errorf("%w: id %d", ErrParcelNotFound, parcelID)
+	}
+
+	changes := make([]ParcelAssessmentChange, len(assessments))
+	for i := range assessments {
+		changes[i].Assessment = assessments[i]
+		if i+1 < len(assessments) {
+			previous := &assessments[i+1]
+			changes[i].Change = &AssessmentChange{
+				PreviousYear: previous.Year,
+				Market:       valueChange(previous.Market, assessments[i].Market),
+				Appraised:    valueChange(previous.Appraised, assessments[i].Appraised),
+				Assessed:     valueChange(previous.Assessed, assessments[i].Assessed),
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+// valueChange returns the change from previous to current.
+func valueChange(previous, current int64) ValueChange {
+	change := ValueChange{Amount: current - previous}
+	if previous != 0 {
+		percent := float64(change.Amount) / float64(previous) * 100
+		change.Percent = &percent
+	}
+	return change
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// MockAssessmentRepository is a mock implementation of AssessmentRepository for testing
+type MockAssessmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAssessmentRepository) FindForParcel(ctx context.Context, parcelID uint) ([]models.ParcelAssessment, error) {
+	args := m.Called(ctx, parcelID)
+	assessments, _ := args.Get(0).([]models.ParcelAssessment)
+	return assessments, args.Error(1)
+}
+
+func TestGetParcelAssessments_Changes(t *testing.T) {
+	mockRepo := new(MockAssessmentRepository)
+	service := NewAssessmentService(mockRepo, logger.New("test"))
+
+	ctx := context.Background()
+	mockRepo.On("FindForParcel", ctx, uint(42)).Return([]models.ParcelAssessment{
+		{Year: 2024, Market: 330000, Appraised: 330000, Assessed: 242000},
+		{Year: 2023, Market: 300000, Appraised: 300000, Assessed: 220000},
+		{Year: 2021, Market: 250000, Appraised: 0, Assessed: 200000},
+	}, nil)
+
+	result, err := service.GetParcelAssessments(ctx, 42)
+
+	require.NoError(t, err)
+	require.Len(t, result, 3)
+
+	latest := result[0].Change
+	require.NotNil(t, latest)
+	assert.Equal(t, 2023, latest.PreviousYear)
+	assert.Equal(t, int64(30000), latest.Market.Amount)
+	require.NotNil(t, latest.Market.Percent)
+	assert.InDelta(t, 10.0, *latest.Market.Percent, 1e-9)
+	assert.Equal(t, int64(22000), latest.Assessed.Amount)
+
+	// Years missing from the history compare with the closest earlier one
+	assert.Equal(t, 2021, result[1].Change.PreviousYear)
+	assert.Equal(t, int64(300000), result[1].Change.Appraised.Amount)
+	assert.Nil(t, result[1].Change.Appraised.Percent)
+
+	assert.Nil(t, result[2].Change)
+	assert.Equal(t, 2021, result[2].Assessment.Year)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetParcelAssessments_NoAssessments(t *testing.T) {
+	mockRepo := new(MockAssessmentRepository)
+	service := NewAssessmentService(mockRepo, logger.New("test"))
+
+	mockRepo.On("FindForParcel", mock.Anything, uint(42)).Return([]models.ParcelAssessment{}, nil)
+
+	result, err := service.GetParcelAssessments(context.Background(), 42)
+
+	require.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestGetParcelAssessments_ParcelNotFound(t *testing.T) {
+	mockRepo := new(MockAssessmentRepository)
+	service := NewAssessmentService(mockRepo, logger.New("test"))
+
+	mockRepo.On("FindForParcel", mock.Anything, uint(42)).Return(nil, nil)
+
+	_, err := service.GetParcelAssessments(context.Background(), 42)
+
+	assert.ErrorIs(t, err, ErrParcelNotFound)
+}
+
+func TestGetParcelAssessments_RepositoryError(t *testing.T) {
+	mockRepo := new(MockAssessmentRepository)
+	service := NewAssessmentService(mockRepo, logger.New("test"))
+
+	dbError := errors.New("connection refused")
+	mockRepo.On("FindForParcel", mock.Anything, uint(42)).Return(nil, dbError)
+
+	_, err := service.GetParcelAssessments(context.Background(), 42)
+
+	assert.ErrorIs(t, err, dbError)
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// Spatial audit constants
+const (
+	// auditQueueSize bounds the samples waiting for re-execution
+	auditQueueSize = 100
+	// auditQueryTimeout bounds each re-executed query
+	auditQueryTimeout = 5 * time.Second
+)
+
+// Audit service errors
+var (
+	ErrSpatialMismatch = errors.New("returned parcel geometry does not contain the query point")
+)
+
+// AuditService defines the interface for sampling at-point queries and checking their
+// results with an independent point-in-polygon test, guarding against SRID and
+// axis-order regressions in the PostGIS queries.
+type AuditService interface {
+	// RecordHit samples a successful at-point query with the configured rate and queues
+	// it for auditing. It never blocks; samples are dropped when the queue is full.
+	RecordHit(point models.LatLng)
+
+	// Audit re-executes the at-point query and checks in Go that the returned
+	// geometry contains the point.
+	// Returns ErrSpatialMismatch if it does not.
+	// Returns nil if no parcel is found (the dataset may have changed since the query).
+	// Returns error for database failures.
+	Audit(ctx context.Context, point models.LatLng) error
+
+	// Run audits queued samples until ctx is cancelled, logging mismatches.
+	Run(ctx context.Context)
+}
+
+// auditService is the concrete implementation of AuditService.
+type auditService struct {
+	repo       repository.ParcelRepository
+	log        *logger.Logger
+	queue      chan models.LatLng
+	sampleRate float64
+	audited    atomic.Int64
+	mismatches atomic.Int64
+}
+
+// NewAuditService creates a new instance of AuditService.
+// sampleRate is the fraction of recorded queries that are audited (0 to 1).
+func NewAuditService(repo repository.ParcelRepository, sampleRate float64, log *logger.Logger) AuditService {
+	return &auditService{
+		repo:       repo,
+		log:        log,
+		queue:      make(chan models.LatLng, auditQueueSize),
+		sampleRate: sampleRate,
+	}
+}
+
+// RecordHit enqueues roughly sampleRate of the calls.
+func (s *auditService) RecordHit(point models.LatLng) {
+	if rand.Float64() >= s.sampleRate {
+		return
+	}
+
+	select {
+	case s.queue <- point:
+	default:
+	}
+}
+
+// Audit uses the parcel repository directly so audits are not logged as user queries.
+func (s *auditService) Audit(ctx context.Context, point models.LatLng) error {
+	parcel, err := s.repo.FindByPoint(ctx, point)
+	if err != nil {
+		return fmt.Errorf("failed to re-execute at-point query: %w", err)
+	}
+	if parcel == nil {
+		return nil
+	}
+
+	if !parcel.Geom.Contains(point) {
+		// A match on the swapped point points at an axis-order regression
+		if parcel.Geom.Contains(models.LatLng{Lat: point.Lng, Lng: point.Lat}) {
+			return fmt.Errorf("%w: parcel %d at lat=%f lng=%f contains the point with lat/lng swapped",
+				ErrSpatialMismatch, parcel.ID, point.Lat, point.Lng)
+		}
+		return fmt.Errorf("%w: parcel %d at lat=%f lng=%f", ErrSpatialMismatch, parcel.ID, point.Lat, point.Lng)
+	}
+
+	return nil
+}
+
+// Run blocks until ctx is cancelled; run it in its own goroutine.
+func (s *auditService) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sample := <-s.queue:
+			s.auditSample(ctx, sample)
+		}
+	}
+}
+
+// auditSample audits one sample and logs the outcome with running totals.
+func (s *auditService) auditSample(ctx context.Context, sample models.LatLng) {
+	auditCtx, cancel := context.WithTimeout(ctx, auditQueryTimeout)
+	defer cancel()
+
+	err := s.Audit(auditCtx, sample)
+	if err != nil && !errors.Is(err, ErrSpatialMismatch) {
+		if ctx.Err() == nil {
+			s.log.Error("Spatial audit query failed", err, map[string]interface{}{
+				"lat": sample.Lat,
+				"lng": sample.Lng,
+			})
+		}
+		return
+	}
+
+	audited := s.audited.Add(1)
+	if err == nil {
+		return
+	}
+
+	s.log.Error("Spatial audit mismatch", err, map[string]interface{}{
+		"lat":        sample.Lat,
+		"lng":        sample.Lng,
+		"audited":    audited,
+		"mismatches": s.mismatches.Add(1),
+	})
+}
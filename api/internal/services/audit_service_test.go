@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// auditTestParcel is a parcel covering lng -95.46..-95.44, lat 30.34..30.36
+var auditTestParcel = &models.TaxParcel{
+	ID: 7,
+	Geom: models.MultiPolygon{
+		Coordinates: [][][][2]float64{{{
+			{-95.46, 30.34}, {-95.44, 30.34}, {-95.44, 30.36}, {-95.46, 30.36}, {-95.46, 30.34},
+		}}},
+		SRID: 4326,
+	},
+}
+
+func TestAudit_Match(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	service := NewAuditService(mockRepo, 1, logger.New("test"))
+
+	mockRepo.On("FindByPoint", mock.Anything, models.LatLng{Lat: 30.35, Lng: -95.45}).Return(auditTestParcel, nil)
+
+	require.NoError(t, service.Audit(context.Background(), models.LatLng{Lat: 30.35, Lng: -95.45}))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAudit_Mismatch(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	service := NewAuditService(mockRepo, 1, logger.New("test"))
+
+	// The repository returned a parcel that does not contain the point
+	mockRepo.On("FindByPoint", mock.Anything, models.LatLng{Lat: 30.2, Lng: -95.5}).Return(auditTestParcel, nil)
+
+	err := service.Audit(context.Background(), models.LatLng{Lat: 30.2, Lng: -95.5})
+
+	assert.ErrorIs(t, err, ErrSpatialMismatch)
+	assert.NotContains(t, err.Error(), "swapped")
+}
+
+func TestAudit_AxisSwapDetected(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	service := NewAuditService(mockRepo, 1, logger.New("test"))
+
+	// A query that swapped lat/lng would match the parcel at the transposed point
+	mockRepo.On("FindByPoint", mock.Anything, models.LatLng{Lat: -95.45, Lng: 30.35}).Return(auditTestParcel, nil)
+
+	err := service.Audit(context.Background(), models.LatLng{Lat: -95.45, Lng: 30.35})
+
+	assert.ErrorIs(t, err, ErrSpatialMismatch)
+	assert.Contains(t, err.Error(), "lat/lng swapped")
+}
+
+func TestAudit_NotFound(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	service := NewAuditService(mockRepo, 1, logger.New("test"))
+
+	mockRepo.On("FindByPoint", mock.Anything, models.LatLng{Lat: 30.35, Lng: -95.45}).Return(nil, nil)
+
+	assert.NoError(t, service.Audit(context.Background(), models.LatLng{Lat: 30.35, Lng: -95.45}))
+}
+
+func TestAudit_RepositoryError(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	service := NewAuditService(mockRepo, 1, logger.New("test"))
+
+	dbError := errors.New("database connection failed")
+	mockRepo.On("FindByPoint", mock.Anything, mock.Anything).Return(nil, dbError)
+
+	err := service.Audit(context.Background(), models.LatLng{Lat: 30.35, Lng: -95.45})
+
+	assert.ErrorIs(t, err, dbError)
+	assert.NotErrorIs(t, err, ErrSpatialMismatch)
+}
+
+func TestRecordHit_SampleRate(t *testing.T) {
+	t.Run("zero rate never samples", func(t *testing.T) {
+		service := NewAuditService(new(MockParcelRepository), 0, logger.New("test")).(*auditService)
+
+		for i := 0; i < 100; i++ {
+			service.RecordHit(models.LatLng{Lat: 30.35, Lng: -95.45})
+		}
+
+		assert.Empty(t, service.queue)
+	})
+
+	t.Run("full rate samples until the queue is full", func(t *testing.T) {
+		service := NewAuditService(new(MockParcelRepository), 1, logger.New("test")).(*auditService)
+
+		for i := 0; i < auditQueueSize+10; i++ {
+			service.RecordHit(models.LatLng{Lat: 30.35, Lng: -95.45})
+		}
+
+		assert.Len(t, service.queue, auditQueueSize)
+	})
+}
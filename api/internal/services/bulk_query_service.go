@@ -0,0 +1,289 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	"github.com/stwalsh4118/atlas/api/internal/jobs"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// Bulk query constants
+const (
+	// MaxBulkQueryPoints caps the points of one bulk query
+	MaxBulkQueryPoints = 50000
+	// MaxBulkQueryParcels caps the parcels an area bulk query may return
+	MaxBulkQueryParcels = 50000
+	// BulkQueryBatchSize is the number of points looked up per database query
+	BulkQueryBatchSize = 1000
+	// BulkQueryJobKind identifies bulk query jobs in the jobs manager
+	BulkQueryJobKind = "bulk-query"
+	// BulkQueryContentType is the content type of bulk query results
+	BulkQueryContentType = "application/json"
+	// BulkQueryFilename is the download name of bulk query results
+	BulkQueryFilename = "bulk-query.json"
+)
+
+// Bulk query service errors
+var (
+	ErrInvalidBulkQuery  = domain.Validation("bulk query needs either points or an area, not both")
+	ErrTooManyBulkPoints = domain.Validation(fmt.Sprintf("bulk query accepts at most %d points", MaxBulkQueryPoints))
+	ErrBulkQueryTooLarge = fmt.Errorf("area contains more than %d parcels, split it into smaller queries", MaxBulkQueryParcels)
+)
+
+// BulkQuery is the input of a bulk query: either Points, each resolved to the
+// parcel containing it, or Area, resolved to every parcel intersecting it.
+// County limits the parcels matched; it is empty for all counties.
+type BulkQuery struct {
+	Area   *models.MultiPolygon
+	County string
+	Points []models.LatLng
+}
+
+// BulkQueryParcel is a parcel in a bulk query result, in the identify projection.
+type BulkQueryParcel struct {
+	OwnerName    string  `json:"owner_name,omitempty"`
+	SitusAddress string  `json:"situs_address,omitempty"`
+	Acres        float64 `json:"acres"`
+	ID           uint    `json:"id"`
+	PIN          int     `json:"pin"`
+}
+
+// BulkQueryPointMatch is the result for one point of a points query.
+// Parcel is null when no parcel contains the point.
+type BulkQueryPointMatch struct {
+	Parcel *BulkQueryParcel `json:"parcel"`
+	Lat    float64          `json:"lat"`
+	Lng    float64          `json:"lng"`
+}
+
+// BulkQueryResult is the downloadable JSON result of a bulk query.
+// A points query fills Points, in request order, and Matched, the number of
+// points inside a parcel; an area query fills Parcels, ordered by ID. Count is
+// the length of whichever is filled.
+type BulkQueryResult struct {
+	Points  []BulkQueryPointMatch `json:"points,omitempty"`
+	Parcels []BulkQueryParcel     `json:"parcels,omitempty"`
+	Count   int                   `json:"count"`
+	Matched int                   `json:"matched,omitempty"`
+}
+
+// BulkQueryService defines the interface for resolving large point lists or
+// areas to parcels in the background, for enrichment jobs too large for the
+// synchronous endpoints. Results are downloaded through signed, expiring URLs,
+// like exports.
+type BulkQueryService interface {
+	// CreateBulkQuery validates the query and queues a job that resolves it.
+	// Returns ErrInvalidBulkQuery unless exactly one of points and area is set.
+	// Returns ErrTooManyBulkPoints if there are more than MaxBulkQueryPoints points.
+	// Returns models.ErrInvalidCoordinates if a point is out of range.
+	// Returns ErrInvalidGeometry if the area is malformed, out of range, too complex or not valid per PostGIS.
+	// Returns jobs.ErrQueueFull if too many jobs are waiting to run.
+	// Returns error for database failures.
+	CreateBulkQuery(ctx context.Context, query BulkQuery) (jobs.Job, error)
+
+	// GetBulkQuery returns the bulk query job with the given ID.
+	// Returns jobs.ErrJobNotFound if the ID is unknown, expired, or not a bulk query job.
+	GetBulkQuery(id string) (jobs.Job, error)
+
+	// SignDownload returns the signature authorizing downloads of the result
+	// until the returned expiry.
+	SignDownload(id string) (signature string, expires time.Time)
+
+	// VerifyDownload checks a download signature and returns the bulk query job.
+	// expires is the expiry in Unix seconds, as passed in the download URL.
+	// Returns ErrInvalidDownloadLink if the signature does not match.
+	// Returns ErrDownloadLinkExpired if the link has expired.
+	// Returns jobs.ErrJobNotFound if the ID is unknown, expired, or not a bulk query job.
+	VerifyDownload(id, expires, signature string) (jobs.Job, error)
+}
+
+// bulkQueryService is the concrete implementation of BulkQueryService.
+type bulkQueryService struct {
+	repo repository.ParcelRepository
+	jobs *jobs.Manager
+	log  *logger.Logger
+	downloadSigner
+}
+
+// NewBulkQueryService creates a new instance of BulkQueryService whose download
+// links are valid for ttl, signed with a random per-process key.
+func NewBulkQueryService(repo repository.ParcelRepository, manager *jobs.Manager, ttl time.Duration, log *logger.Logger) BulkQueryService {
+	return newBulkQueryService(repo, manager, ttl, log, newDownloadKey(), time.Now)
+}
+
+// newBulkQueryService creates a bulkQueryService with the given signing key,
+// reading the time from now; tests pass a fixed key and a fake clock.
+func newBulkQueryService(repo repository.ParcelRepository, manager *jobs.Manager, ttl time.Duration, log *logger.Logger, key []byte, now func() time.Time) *bulkQueryService {
+	return &bulkQueryService{
+		repo:           repo,
+		jobs:           manager,
+		log:            log,
+		downloadSigner: downloadSigner{key: key, ttl: ttl, now: now},
+	}
+}
+
+// CreateBulkQuery validates the whole input up front so clients learn about bad
+// input from the request itself rather than from a failed job.
+func (s *bulkQueryService) CreateBulkQuery(ctx context.Context, query BulkQuery) (jobs.Job, error) {
+	if (len(query.Points) == 0) == (query.Area == nil) {
+		return jobs.Job{}, ErrInvalidBulkQuery
+	}
+
+	var fn jobs.Func
+	if query.Area != nil {
+		if err := validateAreaTopology(ctx, s.repo, query.Area, s.log); err != nil {
+			return jobs.Job{}, err
+		}
+		area := *query.Area
+		fn = func(ctx context.Context) (*jobs.Result, error) {
+			return s.resolveArea(repository.WithCounty(ctx, query.County), area)
+		}
+	} else {
+		if len(query.Points) > MaxBulkQueryPoints {
+			return jobs.Job{}, ErrTooManyBulkPoints
+		}
+		for i, point := range query.Points {
+			if err := point.Validate(); err != nil {
+				return jobs.Job{}, fmt.Errorf("point %d: %w", i, err)
+			}
+		}
+		points := query.Points
+		fn = func(ctx context.Context) (*jobs.Result, error) {
+			return s.resolvePoints(repository.WithCounty(ctx, query.County), points)
+		}
+	}
+
+	job, err := s.jobs.Submit(BulkQueryJobKind, fn)
+	if err != nil {
+		s.log.Warn("Failed to queue bulk query job", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return jobs.Job{}, err
+	}
+
+	s.log.Info("Bulk query job queued", map[string]interface{}{
+		"job_id": job.ID,
+		"points": len(query.Points),
+		"area":   query.Area != nil,
+		"county": query.County,
+	})
+
+	return job, nil
+}
+
+// GetBulkQuery hides jobs of other kinds so bulk query IDs cannot be used to probe them.
+func (s *bulkQueryService) GetBulkQuery(id string) (jobs.Job, error) {
+	job, err := s.jobs.Get(id)
+	if err != nil {
+		return jobs.Job{}, err
+	}
+	if job.Kind != BulkQueryJobKind {
+		return jobs.Job{}, jobs.ErrJobNotFound
+	}
+	return job, nil
+}
+
+// SignDownload signs the ID with an expiry ttl from now.
+func (s *bulkQueryService) SignDownload(id string) (string, time.Time) {
+	return s.signDownload(id)
+}
+
+// VerifyDownload checks the link before looking up the job.
+func (s *bulkQueryService) VerifyDownload(id, expires, signature string) (jobs.Job, error) {
+	if err := s.verifyDownload(id, expires, signature); err != nil {
+		return jobs.Job{}, err
+	}
+	return s.GetBulkQuery(id)
+}
+
+// resolvePoints looks up the parcel containing each point, BulkQueryBatchSize
+// points per query so no single statement runs into the statement timeout.
+func (s *bulkQueryService) resolvePoints(ctx context.Context, points []models.LatLng) (*jobs.Result, error) {
+	result := BulkQueryResult{
+		Points: make([]BulkQueryPointMatch, 0, len(points)),
+		Count:  len(points),
+	}
+
+	for start := 0; start < len(points); start += BulkQueryBatchSize {
+		batch := points[start:min(start+BulkQueryBatchSize, len(points))]
+		summaries, err := s.repo.IdentifyByPoints(ctx, batch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to identify parcels at points %d-%d: %w", start, start+len(batch)-1, err)
+		}
+
+		for i, point := range batch {
+			match := BulkQueryPointMatch{Lat: point.Lat, Lng: point.Lng}
+			if summaries[i] != nil {
+				match.Parcel = bulkQueryParcelFromSummary(summaries[i])
+				result.Matched++
+			}
+			result.Points = append(result.Points, match)
+		}
+	}
+
+	return bulkQueryJSON(result)
+}
+
+// resolveArea lists the parcels intersecting area without their geometry.
+// Returns ErrBulkQueryTooLarge if more than MaxBulkQueryParcels parcels intersect the area.
+func (s *bulkQueryService) resolveArea(ctx context.Context, area models.MultiPolygon) (*jobs.Result, error) {
+	parcels, err := s.repo.FindIntersecting(repository.WithoutGeometry(ctx), area, MaxBulkQueryParcels+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find parcels for bulk query: %w", err)
+	}
+	if len(parcels) > MaxBulkQueryParcels {
+		return nil, ErrBulkQueryTooLarge
+	}
+
+	result := BulkQueryResult{
+		Parcels: make([]BulkQueryParcel, len(parcels)),
+		Count:   len(parcels),
+	}
+	for i := range parcels {
+		parcel := &parcels[i]
+		result.Parcels[i] = *bulkQueryParcelFromSummary(&repository.ParcelSummary{
+			ID:        parcel.ID,
+			PIN:       parcel.PIN,
+			OwnerName: parcel.OwnerName,
+			Situs:     parcel.Situs,
+			Acres:     parcel.Acres,
+		})
+	}
+
+	return bulkQueryJSON(result)
+}
+
+// bulkQueryParcelFromSummary converts a parcel summary into its result projection.
+func bulkQueryParcelFromSummary(summary *repository.ParcelSummary) *BulkQueryParcel {
+	parcel := &BulkQueryParcel{
+		ID:    summary.ID,
+		PIN:   summary.PIN,
+		Acres: summary.Acres,
+	}
+	if summary.OwnerName != nil {
+		parcel.OwnerName = *summary.OwnerName
+	}
+	if summary.Situs != nil {
+		parcel.SitusAddress = *summary.Situs
+	}
+	return parcel
+}
+
+// bulkQueryJSON encodes the result as the job's downloadable output.
+func bulkQueryJSON(result BulkQueryResult) (*jobs.Result, error) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode bulk query result: %w", err)
+	}
+	return &jobs.Result{
+		ContentType: BulkQueryContentType,
+		Filename:    BulkQueryFilename,
+		Data:        data,
+	}, nil
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/jobs"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// newTestBulkQueryService returns a bulk query service with a fixed key and the given clock.
+func newTestBulkQueryService(repo *MockParcelRepository, clock *fakeClock) *bulkQueryService {
+	return newBulkQueryService(repo, newTestJobManager(1), 15*time.Minute, logger.New("test"), []byte("test-key"), clock.Now)
+}
+
+func TestCreateBulkQuery_Success(t *testing.T) {
+	t.Run("points", func(t *testing.T) {
+		service := newTestBulkQueryService(new(MockParcelRepository), &fakeClock{now: time.Unix(1700000000, 0)})
+
+		job, err := service.CreateBulkQuery(context.Background(), BulkQuery{
+			Points: []models.LatLng{{Lat: 30.35, Lng: -95.45}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, BulkQueryJobKind, job.Kind)
+		assert.Equal(t, jobs.StatusQueued, job.Status)
+
+		found, err := service.GetBulkQuery(job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, job.ID, found.ID)
+	})
+
+	t.Run("area", func(t *testing.T) {
+		mockRepo := new(MockParcelRepository)
+		service := newTestBulkQueryService(mockRepo, &fakeClock{now: time.Unix(1700000000, 0)})
+
+		ctx := context.Background()
+		area := testArea()
+		mockRepo.On("ValidateArea", ctx, *area).Return("", nil)
+
+		job, err := service.CreateBulkQuery(ctx, BulkQuery{Area: area, County: "montgomery"})
+
+		require.NoError(t, err)
+		assert.Equal(t, BulkQueryJobKind, job.Kind)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestCreateBulkQuery_InvalidInput(t *testing.T) {
+	service := newTestBulkQueryService(new(MockParcelRepository), &fakeClock{now: time.Unix(1700000000, 0)})
+	ctx := context.Background()
+
+	_, err := service.CreateBulkQuery(ctx, BulkQuery{})
+	assert.ErrorIs(t, err, ErrInvalidBulkQuery)
+
+	_, err = service.CreateBulkQuery(ctx, BulkQuery{Area: testArea(), Points: []models.LatLng{{Lat: 30.35, Lng: -95.45}}})
+	assert.ErrorIs(t, err, ErrInvalidBulkQuery)
+
+	_, err = service.CreateBulkQuery(ctx, BulkQuery{Points: make([]models.LatLng, MaxBulkQueryPoints+1)})
+	assert.ErrorIs(t, err, ErrTooManyBulkPoints)
+
+	_, err = service.CreateBulkQuery(ctx, BulkQuery{Points: []models.LatLng{{Lat: 30.35, Lng: -95.45}, {Lat: 95, Lng: -95.45}}})
+	assert.ErrorIs(t, err, models.ErrInvalidCoordinates)
+	assert.Contains(t, err.Error(), "point 1")
+
+	_, err = service.CreateBulkQuery(ctx, BulkQuery{Area: &models.MultiPolygon{}})
+	assert.ErrorIs(t, err, ErrInvalidGeometry)
+}
+
+func TestGetBulkQuery_OtherKind(t *testing.T) {
+	service := newTestBulkQueryService(new(MockParcelRepository), &fakeClock{now: time.Unix(1700000000, 0)})
+
+	other, err := service.jobs.Submit(ExportJobKind, func(ctx context.Context) (*jobs.Result, error) { return &jobs.Result{}, nil })
+	require.NoError(t, err)
+
+	_, err = service.GetBulkQuery(other.ID)
+	assert.ErrorIs(t, err, jobs.ErrJobNotFound)
+
+	// A valid signature does not reveal jobs of other kinds either
+	signature, expires := service.SignDownload(other.ID)
+	_, err = service.VerifyDownload(other.ID, strconv.FormatInt(expires.Unix(), 10), signature)
+	assert.ErrorIs(t, err, jobs.ErrJobNotFound)
+}
+
+func TestResolvePoints(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	service := newTestBulkQueryService(mockRepo, &fakeClock{now: time.Unix(1700000000, 0)})
+
+	// One more point than a batch, so the points are looked up in two queries
+	points := make([]models.LatLng, BulkQueryBatchSize+1)
+	for i := range points {
+		points[i] = models.LatLng{Lat: 30.35, Lng: -95.45}
+	}
+	owner := "Test Owner"
+	first := make([]*repository.ParcelSummary, BulkQueryBatchSize)
+	first[0] = &repository.ParcelSummary{ID: 7, PIN: 700, OwnerName: &owner, Acres: 1.5}
+	mockRepo.On("IdentifyByPoints", mock.Anything, points[:BulkQueryBatchSize]).Return(first, nil).Once()
+	mockRepo.On("IdentifyByPoints", mock.Anything, points[BulkQueryBatchSize:]).Return([]*repository.ParcelSummary{{ID: 8, PIN: 800}}, nil).Once()
+
+	result, err := service.resolvePoints(context.Background(), points)
+	require.NoError(t, err)
+	assert.Equal(t, BulkQueryContentType, result.ContentType)
+	assert.Equal(t, BulkQueryFilename, result.Filename)
+
+	var decoded BulkQueryResult
+	require.NoError(t, json.Unmarshal(result.Data, &decoded))
+	assert.Equal(t, BulkQueryBatchSize+1, decoded.Count)
+	assert.Equal(t, 2, decoded.Matched)
+	require.Len(t, decoded.Points, BulkQueryBatchSize+1)
+	assert.Equal(t, &BulkQueryParcel{ID: 7, PIN: 700, OwnerName: owner, Acres: 1.5}, decoded.Points[0].Parcel)
+	assert.Nil(t, decoded.Points[1].Parcel)
+	assert.Equal(t, uint(8), decoded.Points[BulkQueryBatchSize].Parcel.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestResolveArea(t *testing.T) {
+	area := testArea()
+
+	t.Run("lists parcels", func(t *testing.T) {
+		mockRepo := new(MockParcelRepository)
+		service := newTestBulkQueryService(mockRepo, &fakeClock{now: time.Unix(1700000000, 0)})
+
+		situs := "1 Main St"
+		mockRepo.On("FindIntersecting", mock.Anything, *area, MaxBulkQueryParcels+1).Return([]models.TaxParcel{
+			{ID: 1, PIN: 100, Situs: &situs, Acres: 2},
+			{ID: 2, PIN: 200},
+		}, nil)
+
+		result, err := service.resolveArea(context.Background(), *area)
+		require.NoError(t, err)
+
+		var decoded BulkQueryResult
+		require.NoError(t, json.Unmarshal(result.Data, &decoded))
+		assert.Equal(t, 2, decoded.Count)
+		assert.Equal(t, []BulkQueryParcel{{ID: 1, PIN: 100, SitusAddress: situs, Acres: 2}, {ID: 2, PIN: 200}}, decoded.Parcels)
+	})
+
+	t.Run("too many parcels", func(t *testing.T) {
+		mockRepo := new(MockParcelRepository)
+		service := newTestBulkQueryService(mockRepo, &fakeClock{now: time.Unix(1700000000, 0)})
+		mockRepo.On("FindIntersecting", mock.Anything, *area, MaxBulkQueryParcels+1).Return(make([]models.TaxParcel, MaxBulkQueryParcels+1), nil)
+
+		_, err := service.resolveArea(context.Background(), *area)
+		assert.ErrorIs(t, err, ErrBulkQueryTooLarge)
+	})
+}
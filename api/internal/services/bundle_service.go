@@ -0,0 +1,161 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/stwalsh4118/atlas/api/internal/geopackage"
+	"github.com/stwalsh4118/atlas/api/internal/jobs"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// Mobile sync bundle constants
+const (
+	// MaxBundleParcels caps the parcels packaged into one bundle, keeping the file
+	// small enough to download over a cellular connection
+	MaxBundleParcels = 25000
+	// BundleSimplifyTolerance is about one meter at Texas latitudes, below GPS
+	// accuracy in the field while shrinking dense boundaries considerably
+	BundleSimplifyTolerance = 0.00001
+	// BundleJobKind identifies sync bundle jobs in the jobs manager
+	BundleJobKind = "sync_bundle"
+	// BundleFilename is the download name of a finished bundle
+	BundleFilename = "parcels.gpkg"
+	bundleTable    = "parcels"
+)
+
+// Bundle service errors
+var (
+	ErrBundleTooLarge = fmt.Errorf("area contains more than %d parcels, split it into smaller bundles", MaxBundleParcels)
+)
+
+// bundleColumns are the key attributes packaged with each parcel, named like the
+// API response fields so crews see the same labels offline.
+var bundleColumns = []geopackage.Column{
+	{Name: "pin", Type: geopackage.ColumnInteger},
+	{Name: "owner_name", Type: geopackage.ColumnText},
+	{Name: "situs_address", Type: geopackage.ColumnText},
+	{Name: "land_use", Type: geopackage.ColumnText},
+	{Name: "state_cd", Type: geopackage.ColumnText},
+	{Name: "legal_description", Type: geopackage.ColumnText},
+	{Name: "year_built", Type: geopackage.ColumnInteger},
+	{Name: "main_area", Type: geopackage.ColumnInteger},
+	{Name: "market_area", Type: geopackage.ColumnText},
+	{Name: "county_name", Type: geopackage.ColumnText},
+	{Name: "acres", Type: geopackage.ColumnReal},
+	{Name: "centroid_lng", Type: geopackage.ColumnReal},
+	{Name: "centroid_lat", Type: geopackage.ColumnReal},
+}
+
+// BundleService defines the interface for building offline sync bundles: GeoPackage
+// files with the simplified parcels and key attributes of an area of interest, for
+// appraisal field crews working without connectivity.
+type BundleService interface {
+	// CreateBundle validates the area and queues a job that builds its bundle.
+	// Returns ErrInvalidGeometry if the area is malformed, out of range, too complex or not valid per PostGIS.
+	// Returns jobs.ErrQueueFull if too many bundles are waiting to be built.
+	// Returns error for database failures.
+	CreateBundle(ctx context.Context, area *models.MultiPolygon) (jobs.Job, error)
+
+	// GetBundle returns the bundle job with the given ID.
+	// Returns jobs.ErrJobNotFound if the ID is unknown, expired, or not a bundle job.
+	GetBundle(id string) (jobs.Job, error)
+}
+
+// bundleService is the concrete implementation of BundleService.
+type bundleService struct {
+	repo repository.ParcelRepository
+	jobs *jobs.Manager
+	log  *logger.Logger
+}
+
+// NewBundleService creates a new instance of BundleService.
+func NewBundleService(repo repository.ParcelRepository, manager *jobs.Manager, log *logger.Logger) BundleService {
+	return &bundleService{
+		repo: repo,
+		jobs: manager,
+		log:  log,
+	}
+}
+
+// CreateBundle validates the area up front so clients learn about bad input from the
+// request itself rather than from a failed job.
+func (s *bundleService) CreateBundle(ctx context.Context, area *models.MultiPolygon) (jobs.Job, error) {
+	if err := validateAreaTopology(ctx, s.repo, area, s.log); err != nil {
+		return jobs.Job{}, err
+	}
+
+	job, err := s.jobs.Submit(BundleJobKind, func(ctx context.Context) (*jobs.Result, error) {
+		return s.buildBundle(ctx, *area)
+	})
+	if err != nil {
+		s.log.Warn("Failed to queue bundle job", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return jobs.Job{}, err
+	}
+
+	s.log.Info("Bundle job queued", map[string]interface{}{
+		"job_id":   job.ID,
+		"polygons": len(area.Coordinates),
+	})
+
+	return job, nil
+}
+
+// GetBundle hides jobs of other kinds so bundle IDs cannot be used to probe them.
+func (s *bundleService) GetBundle(id string) (jobs.Job, error) {
+	job, err := s.jobs.Get(id)
+	if err != nil {
+		return jobs.Job{}, err
+	}
+	if job.Kind != BundleJobKind {
+		return jobs.Job{}, jobs.ErrJobNotFound
+	}
+	return job, nil
+}
+
+// buildBundle packages the parcels intersecting area into a GeoPackage.
+// Returns ErrBundleTooLarge if more than MaxBundleParcels parcels intersect the area.
+func (s *bundleService) buildBundle(ctx context.Context, area models.MultiPolygon) (*jobs.Result, error) {
+	ctx = repository.WithSimplifyTolerance(ctx, BundleSimplifyTolerance)
+
+	parcels, err := s.repo.FindIntersecting(ctx, area, MaxBundleParcels+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find parcels for bundle: %w", err)
+	}
+	if len(parcels) > MaxBundleParcels {
+		return nil, ErrBundleTooLarge
+	}
+
+	writer := geopackage.NewWriter(bundleTable, "Atlas parcels for offline field use", bundleColumns)
+	for i := range parcels {
+		parcel := &parcels[i]
+		if err := writer.Add(int64(parcel.ID), parcel.Geom, bundleValues(parcel)...); err != nil { // #nosec G115 -- serial IDs fit in int64
+			return nil, fmt.Errorf("failed to add parcel %d to bundle: %w", parcel.ID, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if _, err := writer.WriteTo(&buf); err != nil {
+		return nil, fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	return &jobs.Result{
+		ContentType: geopackage.ContentType,
+		Filename:    BundleFilename,
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// bundleValues returns the attributes of parcel in bundleColumns order.
+func bundleValues(parcel *models.TaxParcel) []any {
+	return []any{
+		parcel.PIN, parcel.OwnerName, parcel.Situs, parcel.AsCode, parcel.StateCd,
+		parcel.LegalDescription, parcel.ImprvActualYearBuilt, parcel.ImprvMainArea,
+		parcel.MarketArea, parcel.CountyName, parcel.Acres, parcel.CentroidLng, parcel.CentroidLat,
+	}
+}
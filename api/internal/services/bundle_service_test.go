@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/geopackage"
+	"github.com/stwalsh4118/atlas/api/internal/jobs"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// newTestJobManager returns a job manager that is not running, so submitted jobs stay queued.
+func newTestJobManager(queueSize int) *jobs.Manager {
+	return jobs.NewManager(queueSize, time.Minute, time.Hour, logger.New("test"))
+}
+
+func TestCreateBundle_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	service := NewBundleService(mockRepo, newTestJobManager(1), logger.New("test"))
+
+	ctx := context.Background()
+	area := testArea()
+	mockRepo.On("ValidateArea", ctx, *area).Return("", nil)
+
+	// Act
+	job, err := service.CreateBundle(ctx, area)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, BundleJobKind, job.Kind)
+	assert.Equal(t, jobs.StatusQueued, job.Status)
+
+	found, err := service.GetBundle(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, job.ID, found.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateBundle_InvalidArea(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	service := NewBundleService(mockRepo, newTestJobManager(1), logger.New("test"))
+
+	ctx := context.Background()
+	openRing := testArea()
+	openRing.Coordinates[0][0][4] = [2]float64{-95.45, 30.34}
+
+	_, err := service.CreateBundle(ctx, openRing)
+	assert.ErrorIs(t, err, ErrInvalidGeometry)
+	mockRepo.AssertNotCalled(t, "ValidateArea", mock.Anything, mock.Anything)
+
+	area := testArea()
+	mockRepo.On("ValidateArea", ctx, *area).Return("Self-intersection[-95.45 30.35]", nil)
+
+	_, err = service.CreateBundle(ctx, area)
+	assert.ErrorIs(t, err, ErrInvalidGeometry)
+}
+
+func TestCreateBundle_QueueFull(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	service := NewBundleService(mockRepo, newTestJobManager(1), logger.New("test"))
+
+	ctx := context.Background()
+	area := testArea()
+	mockRepo.On("ValidateArea", ctx, *area).Return("", nil)
+
+	_, err := service.CreateBundle(ctx, area)
+	require.NoError(t, err)
+
+	_, err = service.CreateBundle(ctx, area)
+	assert.ErrorIs(t, err, jobs.ErrQueueFull)
+}
+
+func TestGetBundle_OtherKind(t *testing.T) {
+	manager := newTestJobManager(1)
+	service := NewBundleService(new(MockParcelRepository), manager, logger.New("test"))
+
+	other, err := manager.Submit("other", func(ctx context.Context) (*jobs.Result, error) { return &jobs.Result{}, nil })
+	require.NoError(t, err)
+
+	_, err = service.GetBundle(other.ID)
+	assert.ErrorIs(t, err, jobs.ErrJobNotFound)
+
+	_, err = service.GetBundle("missing")
+	assert.ErrorIs(t, err, jobs.ErrJobNotFound)
+}
+
+func TestBuildBundle_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	service := NewBundleService(mockRepo, newTestJobManager(1), logger.New("test")).(*bundleService)
+
+	area := testArea()
+	owner := "Test Owner"
+	found := []models.TaxParcel{
+		{ID: 1, PIN: 100, OwnerName: &owner, CountyName: "Montgomery", Geom: *area},
+		{ID: 2, PIN: 200, CountyName: "Montgomery", Geom: *area},
+	}
+	mockRepo.On("FindIntersecting", mock.Anything, *area, MaxBundleParcels+1).Return(found, nil)
+
+	// Act
+	result, err := service.buildBundle(context.Background(), *area)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, geopackage.ContentType, result.ContentType)
+	assert.Equal(t, BundleFilename, result.Filename)
+	assert.Equal(t, "SQLite format 3\x00", string(result.Data[:16]))
+	assert.Contains(t, string(result.Data), "Test Owner")
+}
+
+func TestBuildBundle_TooLarge(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	service := NewBundleService(mockRepo, newTestJobManager(1), logger.New("test")).(*bundleService)
+
+	area := testArea()
+	found := make([]models.TaxParcel, MaxBundleParcels+1)
+	mockRepo.On("FindIntersecting", mock.Anything, *area, MaxBundleParcels+1).Return(found, nil)
+
+	result, err := service.buildBundle(context.Background(), *area)
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, ErrBundleTooLarge)
+}
+
+func TestBuildBundle_RepositoryError(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	service := NewBundleService(mockRepo, newTestJobManager(1), logger.New("test")).(*bundleService)
+
+	area := testArea()
+	dbError := errors.New("connection reset")
+	mockRepo.On("FindIntersecting", mock.Anything, *area, MaxBundleParcels+1).Return(nil, dbError)
+
+	result, err := service.buildBundle(context.Background(), *area)
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, dbError)
+}
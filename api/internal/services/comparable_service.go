@@ -0,0 +1,219 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// Comparables constants
+const (
+	// DefaultComparablesRadiusMeters is the search radius when none is given, about a mile
+	DefaultComparablesRadiusMeters = 1600
+	MinComparablesLimit            = 1
+	MaxComparablesLimit            = 50
+	// MaxComparableCandidates bounds the nearest parcels scored per request
+	MaxComparableCandidates = 500
+	// ComparableAcresRatio bounds candidates to between 1/ratio and ratio times the subject's acres
+	ComparableAcresRatio = 2
+	// ComparableYearBuiltSpan is the difference in years at which year built similarity reaches 0
+	ComparableYearBuiltSpan = 25
+)
+
+// ComparablesOptions controls a comparables search.
+type ComparablesOptions struct {
+	RadiusMeters int
+	Limit        int
+}
+
+// ComparableSimilarity holds a comparable's similarity to the subject in each
+// scored input, between 0 and 1. LandUse and YearBuilt are nil when the subject
+// has no value to compare with; a candidate without one scores 0.
+type ComparableSimilarity struct {
+	LandUse   *float64
+	YearBuilt *float64
+	Distance  float64
+	Acres     float64
+}
+
+// Comparable is a parcel near the subject with its distance in meters and Score,
+// the weighted mean of its similarities.
+type Comparable struct {
+	Similarity ComparableSimilarity
+	Parcel     models.TaxParcel
+	Distance   float64
+	Score      float64
+}
+
+// Comparables is the result of a comparables search: the subject parcel and
+// its comparables, best first.
+type Comparables struct {
+	Subject models.TaxParcel
+	Parcels []Comparable
+}
+
+// ComparableService defines the interface for finding comparable parcels, the
+// comps appraisers value a parcel against.
+type ComparableService interface {
+	// FindComparables returns up to opts.Limit parcels within opts.RadiusMeters
+	// of the subject's centroid whose acres are within ComparableAcresRatio of
+	// the subject's, ranked by score, then distance, then ID. Only the
+	// MaxComparableCandidates nearest are scored.
+	// Returns ErrParcelNotFound if the subject doesn't exist.
+	// Returns ErrInvalidRadius or ErrInvalidLimit for out-of-range options.
+	// Returns an empty Parcels slice if no parcel qualifies (not an error).
+	// Returns error for database failures.
+	FindComparables(ctx context.Context, id uint, opts ComparablesOptions) (*Comparables, error)
+}
+
+// comparableService is the concrete implementation of ComparableService.
+type comparableService struct {
+	repo    repository.ParcelRepository
+	weights config.ComparablesConfig
+	log     *logger.Logger
+}
+
+// NewComparableService creates a new instance of ComparableService that scores
+// comparables with the weights of cfg.
+func NewComparableService(repo repository.ParcelRepository, cfg config.ComparablesConfig, log *logger.Logger) ComparableService {
+	if cfg.WeightDistance == 0 && cfg.WeightAcres == 0 && cfg.WeightLandUse == 0 && cfg.WeightYearBuilt == 0 {
+		cfg = config.ComparablesConfig{WeightDistance: 1, WeightAcres: 1, WeightLandUse: 1, WeightYearBuilt: 1}
+	}
+	return &comparableService{
+		repo:    repo,
+		weights: cfg,
+		log:     log,
+	}
+}
+
+// FindComparables loads the subject, then scores the nearest parcels of similar
+// acreage around its centroid.
+func (s *comparableService) FindComparables(ctx context.Context, id uint, opts ComparablesOptions) (*Comparables, error) {
+	if opts.RadiusMeters < MinRadiusMeters || opts.RadiusMeters > MaxRadiusMeters {
+		return nil, fmt.Errorf("%w: got %d", ErrInvalidRadius, opts.RadiusMeters)
+	}
+	if opts.Limit < MinComparablesLimit || opts.Limit > MaxComparablesLimit {
+		return nil, fmt.Errorf("%w: must be between %d and %d, got %d",
+			ErrInvalidLimit, MinComparablesLimit, MaxComparablesLimit, opts.Limit)
+	}
+
+	found, err := s.repo.FindByIDs(ctx, []uint{id})
+	if err != nil {
+		s.log.Error("Failed to query comparables subject", err, map[string]interface{}{
+			"parcel_id": id,
+		})
+		return nil, fmt.Errorf("failed to query parcel: %w", err)
+	}
+	if len(found) == 0 {
+		return nil, fmt.Errorf("%w: id %d", ErrParcelNotFound, id)
+	}
+	if found[0].Err != nil {
+		s.log.Error("Failed to decode comparables subject", found[0].Err, map[string]interface{}{
+			"parcel_id": id,
+		})
+		return nil, found[0].Err
+	}
+	subject := found[0].Parcel
+
+	var filter repository.NearbyFilter
+	if subject.Acres > 0 {
+		minAcres, maxAcres := subject.Acres/ComparableAcresRatio, subject.Acres*ComparableAcresRatio
+		filter.MinAcres, filter.MaxAcres = &minAcres, &maxAcres
+	}
+	centroid := models.LatLng{Lat: subject.CentroidLat, Lng: subject.CentroidLng}
+	candidates, _, err := s.repo.FindNearby(ctx, centroid, opts.RadiusMeters, MaxComparableCandidates+1, nil, filter)
+	if err != nil {
+		s.log.Error("Failed to query comparables candidates", err, map[string]interface{}{
+			"parcel_id": id,
+			"radius":    opts.RadiusMeters,
+		})
+		return nil, fmt.Errorf("failed to find comparables: %w", err)
+	}
+
+	// The extra candidate requested stands in for the subject, which is usually among them
+	candidates = slices.DeleteFunc(candidates, func(c repository.ParcelWithDistance) bool { return c.Parcel.ID == subject.ID })
+	candidates = candidates[:min(len(candidates), MaxComparableCandidates)]
+
+	comparables := make([]Comparable, 0, len(candidates))
+	for _, candidate := range candidates {
+		similarity := compareToSubject(&subject, &candidate, opts.RadiusMeters)
+		comparables = append(comparables, Comparable{
+			Similarity: similarity,
+			Parcel:     candidate.Parcel,
+			Distance:   candidate.Distance,
+			Score:      s.score(similarity),
+		})
+	}
+	slices.SortStableFunc(comparables, func(a, b Comparable) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.Distance, b.Distance), cmp.Compare(a.Parcel.ID, b.Parcel.ID))
+	})
+
+	s.log.Info("Comparables search completed", map[string]interface{}{
+		"parcel_id":  id,
+		"radius":     opts.RadiusMeters,
+		"candidates": len(comparables),
+	})
+
+	if len(comparables) > opts.Limit {
+		comparables = comparables[:opts.Limit]
+	}
+	return &Comparables{Subject: subject, Parcels: comparables}, nil
+}
+
+// compareToSubject returns the similarity of candidate to subject, for a
+// candidate found within radiusMeters.
+func compareToSubject(subject *models.TaxParcel, candidate *repository.ParcelWithDistance, radiusMeters int) ComparableSimilarity {
+	similarity := ComparableSimilarity{
+		Distance: math.Max(0, 1-candidate.Distance/float64(radiusMeters)),
+		Acres:    1,
+	}
+	if larger := math.Max(subject.Acres, candidate.Parcel.Acres); larger > 0 {
+		similarity.Acres = math.Min(subject.Acres, candidate.Parcel.Acres) / larger
+	}
+
+	if subject.AsCode != nil && strings.TrimSpace(*subject.AsCode) != "" {
+		landUse := 0.0
+		if candidate.Parcel.AsCode != nil && strings.EqualFold(strings.TrimSpace(*candidate.Parcel.AsCode), strings.TrimSpace(*subject.AsCode)) {
+			landUse = 1
+		}
+		similarity.LandUse = &landUse
+	}
+
+	if subject.ImprvActualYearBuilt != nil {
+		yearBuilt := 0.0
+		if candidate.Parcel.ImprvActualYearBuilt != nil {
+			years := math.Abs(float64(*candidate.Parcel.ImprvActualYearBuilt - *subject.ImprvActualYearBuilt))
+			yearBuilt = math.Max(0, 1-years/ComparableYearBuiltSpan)
+		}
+		similarity.YearBuilt = &yearBuilt
+	}
+
+	return similarity
+}
+
+// score is the weighted mean of the similarities; inputs the subject has no
+// value for are left out, along with their weight.
+func (s *comparableService) score(similarity ComparableSimilarity) float64 {
+	total := s.weights.WeightDistance*similarity.Distance + s.weights.WeightAcres*similarity.Acres
+	weights := s.weights.WeightDistance + s.weights.WeightAcres
+	if similarity.LandUse != nil {
+		total += s.weights.WeightLandUse * *similarity.LandUse
+		weights += s.weights.WeightLandUse
+	}
+	if similarity.YearBuilt != nil {
+		total += s.weights.WeightYearBuilt * *similarity.YearBuilt
+		weights += s.weights.WeightYearBuilt
+	}
+	if weights == 0 {
+		return 0
+	}
+	return total / weights
+}
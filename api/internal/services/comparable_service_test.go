@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/pagination"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// testComparablesWeights weighs every input equally.
+var testComparablesWeights = config.ComparablesConfig{WeightDistance: 1, WeightAcres: 1, WeightLandUse: 1, WeightYearBuilt: 1}
+
+// testComparableParcel returns a parcel of the given acres, land use and year built.
+func testComparableParcel(id uint, acres float64, landUse string, yearBuilt int) models.TaxParcel {
+	parcel := models.TaxParcel{ID: id, Acres: acres, CentroidLat: 30.35, CentroidLng: -95.45}
+	if landUse != "" {
+		parcel.AsCode = &landUse
+	}
+	if yearBuilt != 0 {
+		parcel.ImprvActualYearBuilt = &yearBuilt
+	}
+	return parcel
+}
+
+func TestFindComparables_RankedBySimilarity(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	service := NewComparableService(mockRepo, testComparablesWeights, logger.New("test"))
+
+	ctx := context.Background()
+	subject := testComparableParcel(1, 2, "A1", 2000)
+	mockRepo.On("FindByIDs", ctx, []uint{1}).Return([]repository.ParcelWithMetrics{{Parcel: subject}}, nil)
+	minAcres, maxAcres := 1.0, 4.0
+	mockRepo.On("FindNearby", ctx, models.LatLng{Lat: 30.35, Lng: -95.45}, 1000, MaxComparableCandidates+1, (*pagination.Cursor)(nil),
+		repository.NearbyFilter{MinAcres: &minAcres, MaxAcres: &maxAcres}).Return([]repository.ParcelWithDistance{
+		{Parcel: subject, Distance: 0},
+		{Parcel: testComparableParcel(2, 1, "C1", 1960), Distance: 100},
+		{Parcel: testComparableParcel(3, 2, "a1", 2000), Distance: 500},
+		{Parcel: testComparableParcel(4, 4, "A1", 0), Distance: 250},
+	}, 4, nil)
+
+	comparables, err := service.FindComparables(ctx, 1, ComparablesOptions{RadiusMeters: 1000, Limit: 2})
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), comparables.Subject.ID)
+	require.Len(t, comparables.Parcels, 2, "the subject is not its own comparable")
+
+	best := comparables.Parcels[0]
+	assert.Equal(t, uint(3), best.Parcel.ID, "same acres, land use (ignoring case) and year built")
+	assert.InDelta(t, (0.5+1+1+1)/4, best.Score, 1e-9)
+	assert.Equal(t, 500.0, best.Distance)
+
+	second := comparables.Parcels[1]
+	assert.Equal(t, uint(4), second.Parcel.ID)
+	assert.InDelta(t, 0.75, second.Similarity.Distance, 1e-9)
+	assert.InDelta(t, 0.5, second.Similarity.Acres, 1e-9)
+	assert.Equal(t, 0.0, *second.Similarity.YearBuilt, "a candidate without a year built scores 0")
+	assert.InDelta(t, (0.75+0.5+1+0)/4, second.Score, 1e-9)
+}
+
+func TestFindComparables_WeightsAndMissingSubjectValues(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	weights := config.ComparablesConfig{WeightDistance: 1, WeightAcres: 3, WeightLandUse: 2}
+	service := NewComparableService(mockRepo, weights, logger.New("test"))
+
+	ctx := context.Background()
+	subject := testComparableParcel(1, 0, "", 0)
+	mockRepo.On("FindByIDs", ctx, []uint{1}).Return([]repository.ParcelWithMetrics{{Parcel: subject}}, nil)
+	mockRepo.On("FindNearby", ctx, mock.Anything, 1000, MaxComparableCandidates+1, (*pagination.Cursor)(nil),
+		repository.NearbyFilter{}).Return([]repository.ParcelWithDistance{
+		{Parcel: testComparableParcel(2, 0, "A1", 1990), Distance: 500},
+	}, 1, nil)
+
+	comparables, err := service.FindComparables(ctx, 1, ComparablesOptions{RadiusMeters: 1000, Limit: 10})
+
+	require.NoError(t, err)
+	require.Len(t, comparables.Parcels, 1)
+	similarity := comparables.Parcels[0].Similarity
+	assert.Nil(t, similarity.LandUse, "the subject has no land use")
+	assert.Nil(t, similarity.YearBuilt, "the subject has no year built")
+	assert.Equal(t, 1.0, similarity.Acres, "two parcels without area are alike")
+	assert.InDelta(t, (1*0.5+3*1.0)/4, comparables.Parcels[0].Score, 1e-9, "only the weights of compared inputs count")
+}
+
+func TestFindComparables_NotFound(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	service := NewComparableService(mockRepo, testComparablesWeights, logger.New("test"))
+
+	mockRepo.On("FindByIDs", mock.Anything, []uint{9}).Return([]repository.ParcelWithMetrics{}, nil)
+
+	_, err := service.FindComparables(context.Background(), 9, ComparablesOptions{RadiusMeters: 1000, Limit: 10})
+
+	assert.ErrorIs(t, err, ErrParcelNotFound)
+	mockRepo.AssertNotCalled(t, "FindNearby", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFindComparables_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		err  error
+		name string
+		opts ComparablesOptions
+	}{
+		{ErrInvalidRadius, "zero radius", ComparablesOptions{RadiusMeters: 0, Limit: 10}},
+		{ErrInvalidRadius, "radius too large", ComparablesOptions{RadiusMeters: MaxRadiusMeters + 1, Limit: 10}},
+		{ErrInvalidLimit, "limit too large", ComparablesOptions{RadiusMeters: 1000, Limit: MaxComparablesLimit + 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockParcelRepository)
+			service := NewComparableService(mockRepo, testComparablesWeights, logger.New("test"))
+
+			_, err := service.FindComparables(context.Background(), 1, tt.opts)
+
+			assert.ErrorIs(t, err, tt.err)
+			mockRepo.AssertNotCalled(t, "FindByIDs", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestFindComparables_DatabaseError(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	service := NewComparableService(mockRepo, testComparablesWeights, logger.New("test"))
+
+	subject := testComparableParcel(1, 2, "A1", 2000)
+	mockRepo.On("FindByIDs", mock.Anything, []uint{1}).Return([]repository.ParcelWithMetrics{{Parcel: subject}}, nil)
+	mockRepo.On("FindNearby", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, 0, errors.New("connection refused"))
+
+	_, err := service.FindComparables(context.Background(), 1, ComparablesOptions{RadiusMeters: 1000, Limit: 10})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to find comparables")
+}
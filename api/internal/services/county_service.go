@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// County profile constants
+const (
+	// MaxProfileSimplifyTolerance matches the largest simplify_tolerance requests may set
+	MaxProfileSimplifyTolerance = 0.01
+	// CountyProfileCacheTTL is how long profiles are cached for the parcel
+	// services; other instances see a saved profile within it
+	CountyProfileCacheTTL = time.Minute
+)
+
+// County service errors
+var (
+	ErrCountyNotFound = domain.NotFound("county not found")
+	ErrInvalidProfile = domain.Validation("invalid county profile")
+)
+
+// ProfileAttributes are the attributes a county profile can flag as not
+// provided by the county's data. Nearby filters on them are rejected for that
+// county rather than matching nothing.
+var ProfileAttributes = map[string]bool{
+	"owner_name": true,
+	"land_use":   true,
+	"year_built": true,
+	"waterfront": true,
+}
+
+// CountyProfiles looks up county profiles for the services whose behavior
+// varies per county; CountyService implements it.
+type CountyProfiles interface {
+	// Profile returns the profile of the county with the slug, nil for an
+	// empty or unknown slug. Profiles may be up to CountyProfileCacheTTL old.
+	Profile(ctx context.Context, slug string) (*models.CountyProfile, error)
+}
+
+// CountyService defines the interface for county operations.
+type CountyService interface {
+	CountyProfiles
+
+	// ListCounties returns every county with loaded parcels, ordered by slug.
+	// Slugs are the values accepted by the county filter of the parcel endpoints.
+	ListCounties(ctx context.Context) ([]models.County, error)
+
+	// ListProfiles returns the profile of every county, ordered by slug.
+	ListProfiles(ctx context.Context) ([]models.CountyProfile, error)
+
+	// GetProfile returns the profile of the county with the slug.
+	// Returns ErrCountyNotFound if no county has the slug.
+	GetProfile(ctx context.Context, slug string) (*models.CountyProfile, error)
+
+	// SaveProfile validates and replaces the profile of the county with the slug.
+	// Returns ErrInvalidProfile when validation fails.
+	// Returns ErrCountyNotFound if no county has the slug.
+	SaveProfile(ctx context.Context, slug string, profile *models.CountyProfile) (*models.CountyProfile, error)
+}
+
+// countyService is the concrete implementation of CountyService.
+type countyService struct {
+	repo repository.CountyRepository
+	log  *logger.Logger
+	now  func() time.Time
+
+	// mu guards the profile cache
+	mu       sync.Mutex
+	profiles map[string]*models.CountyProfile
+	loadedAt time.Time
+}
+
+// NewCountyService creates a new instance of CountyService.
+func NewCountyService(repo repository.CountyRepository, log *logger.Logger) CountyService {
+	return newCountyService(repo, log, time.Now)
+}
+
+// newCountyService creates a countyService reading the time from now; tests
+// pass a fake clock.
+func newCountyService(repo repository.CountyRepository, log *logger.Logger, now func() time.Time) *countyService {
+	return &countyService{
+		repo: repo,
+		log:  log,
+		now:  now,
+	}
+}
+
+// ListCounties returns the counties from the repository.
+func (s *countyService) ListCounties(ctx context.Context) ([]models.County, error) {
+	counties, err := s.repo.List(ctx)
+	if err != nil {
+		s.log.Error("Failed to list counties", err, nil)
+		return nil, fmt.Errorf("failed to list counties: %w", err)
+	}
+
+	return counties, nil
+}
+
+// ListProfiles returns the profiles from the repository.
+func (s *countyService) ListProfiles(ctx context.Context) ([]models.CountyProfile, error) {
+	profiles, err := s.repo.ListProfiles(ctx)
+	if err != nil {
+		s.log.Error("Failed to list county profiles", err, nil)
+		return nil, fmt.Errorf("failed to list county profiles: %w", err)
+	}
+
+	return profiles, nil
+}
+
+// GetProfile reads the profiles uncached, so admins see a saved profile at once.
+func (s *countyService) GetProfile(ctx context.Context, slug string) (*models.CountyProfile, error) {
+	profiles, err := s.ListProfiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range profiles {
+		if profiles[i].County == slug {
+			return &profiles[i], nil
+		}
+	}
+	return nil, ErrCountyNotFound
+}
+
+// SaveProfile drops the profile cache, so this instance applies the profile at once.
+func (s *countyService) SaveProfile(ctx context.Context, slug string, profile *models.CountyProfile) (*models.CountyProfile, error) {
+	if err := validateProfile(profile); err != nil {
+		s.log.Warn("Invalid county profile provided", map[string]interface{}{
+			"county": slug,
+			"error":  err.Error(),
+		})
+		return nil, err
+	}
+
+	if profile.Attributes == nil {
+		profile.Attributes = map[string]bool{}
+	}
+
+	stored, err := s.repo.UpsertProfile(ctx, slug, profile)
+	if err != nil {
+		s.log.Error("Failed to save county profile", err, map[string]interface{}{
+			"county": slug,
+		})
+		return nil, fmt.Errorf("failed to save county profile: %w", err)
+	}
+	if stored == nil {
+		return nil, ErrCountyNotFound
+	}
+
+	s.mu.Lock()
+	s.profiles = nil
+	s.mu.Unlock()
+
+	s.log.Info("County profile saved", map[string]interface{}{
+		"county": slug,
+	})
+
+	return stored, nil
+}
+
+// Profile loads all profiles at once when the cache is empty or expired; a
+// county's profile is one small row.
+func (s *countyService) Profile(ctx context.Context, slug string) (*models.CountyProfile, error) {
+	if slug == "" {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.profiles == nil || s.now().Sub(s.loadedAt) >= CountyProfileCacheTTL {
+		profiles, err := s.repo.ListProfiles(ctx)
+		if err != nil {
+			s.log.Error("Failed to load county profiles", err, nil)
+			return nil, fmt.Errorf("failed to load county profiles: %w", err)
+		}
+		s.profiles = make(map[string]*models.CountyProfile, len(profiles))
+		for i := range profiles {
+			s.profiles[profiles[i].County] = &profiles[i]
+		}
+		s.loadedAt = s.now()
+	}
+
+	return s.profiles[slug], nil
+}
+
+// validateProfile checks the limits against the global ones, the attribute
+// names and the source URL.
+func validateProfile(profile *models.CountyProfile) error {
+	if r := profile.MaxRadiusMeters; r != nil && (*r < MinRadiusMeters || *r > MaxRadiusMeters) {
+		return fmt.Errorf("%w: maxRadiusMeters must be between %d and %d, got %d",
+			ErrInvalidProfile, MinRadiusMeters, MaxRadiusMeters, *r)
+	}
+	if r := profile.DefaultRadiusMeters; r != nil {
+		maxRadius := MaxRadiusMeters
+		if profile.MaxRadiusMeters != nil {
+			maxRadius = *profile.MaxRadiusMeters
+		}
+		if *r < MinRadiusMeters || *r > maxRadius {
+			return fmt.Errorf("%w: defaultRadiusMeters must be between %d and %d, got %d",
+				ErrInvalidProfile, MinRadiusMeters, maxRadius, *r)
+		}
+	}
+	if t := profile.SimplifyTolerance; t != nil && (*t < 0 || *t > MaxProfileSimplifyTolerance) {
+		return fmt.Errorf("%w: simplifyTolerance must be between 0 and %g, got %g",
+			ErrInvalidProfile, MaxProfileSimplifyTolerance, *t)
+	}
+	for name := range profile.Attributes {
+		if !ProfileAttributes[name] {
+			return fmt.Errorf("%w: unknown attribute %q", ErrInvalidProfile, name)
+		}
+	}
+
+	source := profile.Source
+	if source.ArcGISURL != "" {
+		u, err := url.Parse(source.ArcGISURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: source arcgisUrl must be an http or https URL", ErrInvalidProfile)
+		}
+	}
+	if source.ArcGISPageSize < 0 || source.ArcGISRateLimit < 0 {
+		return fmt.Errorf("%w: source arcgisPageSize and arcgisRateLimit must not be negative", ErrInvalidProfile)
+	}
+	return nil
+}
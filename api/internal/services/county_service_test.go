@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// MockCountyRepository is a mock implementation of CountyRepository for testing
+type MockCountyRepository struct {
+	mock.Mock
+}
+
+func (m *MockCountyRepository) List(ctx context.Context) ([]models.County, error) {
+	args := m.Called(ctx)
+	counties, _ := args.Get(0).([]models.County)
+	return counties, args.Error(1)
+}
+
+func (m *MockCountyRepository) ListProfiles(ctx context.Context) ([]models.CountyProfile, error) {
+	args := m.Called(ctx)
+	profiles, _ := args.Get(0).([]models.CountyProfile)
+	return profiles, args.Error(1)
+}
+
+func (m *MockCountyRepository) UpsertProfile(ctx context.Context, slug string, profile *models.CountyProfile) (*models.CountyProfile, error) {
+	args := m.Called(ctx, slug, profile)
+	stored, _ := args.Get(0).(*models.CountyProfile)
+	return stored, args.Error(1)
+}
+
+func TestListCounties(t *testing.T) {
+	mockRepo := new(MockCountyRepository)
+	service := NewCountyService(mockRepo, logger.New("test"))
+
+	mockRepo.On("List", mock.Anything).Return([]models.County{
+		{ID: 1, Slug: "montgomery-tx", Name: "Montgomery", State: "TX"},
+	}, nil)
+
+	counties, err := service.ListCounties(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, counties, 1)
+	assert.Equal(t, "montgomery-tx", counties[0].Slug)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListCounties_RepositoryError(t *testing.T) {
+	mockRepo := new(MockCountyRepository)
+	service := NewCountyService(mockRepo, logger.New("test"))
+
+	dbError := errors.New("database connection failed")
+	mockRepo.On("List", mock.Anything).Return(nil, dbError)
+
+	_, err := service.ListCounties(context.Background())
+
+	assert.ErrorIs(t, err, dbError)
+}
+
+func intPtr(v int) *int { return &v }
+
+func TestSaveProfile_Validation(t *testing.T) {
+	tolerance := 0.5
+	tests := []struct {
+		name    string
+		profile models.CountyProfile
+	}{
+		{"max radius above the global maximum", models.CountyProfile{MaxRadiusMeters: intPtr(MaxRadiusMeters + 1)}},
+		{"default radius above the county maximum", models.CountyProfile{MaxRadiusMeters: intPtr(500), DefaultRadiusMeters: intPtr(1000)}},
+		{"tolerance too large", models.CountyProfile{SimplifyTolerance: &tolerance}},
+		{"unknown attribute", models.CountyProfile{Attributes: map[string]bool{"pool": false}}},
+		{"source URL without scheme", models.CountyProfile{Source: models.CountySource{ArcGISURL: "gis.example.gov/FeatureServer/0"}}},
+		{"negative page size", models.CountyProfile{Source: models.CountySource{ArcGISPageSize: -1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockCountyRepository)
+			service := NewCountyService(mockRepo, logger.New("test"))
+
+			_, err := service.SaveProfile(context.Background(), "montgomery-tx", &tt.profile)
+
+			assert.ErrorIs(t, err, ErrInvalidProfile)
+			mockRepo.AssertNotCalled(t, "UpsertProfile", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSaveProfile_UnknownCounty(t *testing.T) {
+	mockRepo := new(MockCountyRepository)
+	service := NewCountyService(mockRepo, logger.New("test"))
+	mockRepo.On("UpsertProfile", mock.Anything, "nowhere-tx", mock.Anything).Return(nil, nil)
+
+	_, err := service.SaveProfile(context.Background(), "nowhere-tx", &models.CountyProfile{})
+
+	assert.ErrorIs(t, err, ErrCountyNotFound)
+}
+
+func TestProfile_CachedUntilSaved(t *testing.T) {
+	mockRepo := new(MockCountyRepository)
+	clock := &fakeClock{now: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	service := newCountyService(mockRepo, logger.New("test"), clock.Now)
+	ctx := context.Background()
+
+	mockRepo.On("ListProfiles", mock.Anything).Return([]models.CountyProfile{
+		{CountyID: 1, County: "montgomery-tx", MaxRadiusMeters: intPtr(2000)},
+	}, nil).Once()
+
+	profile, err := service.Profile(ctx, "montgomery-tx")
+	require.NoError(t, err)
+	assert.Equal(t, 2000, *profile.MaxRadiusMeters)
+
+	// Served from the cache within the TTL; unknown counties have no profile
+	profile, err = service.Profile(ctx, "nowhere-tx")
+	require.NoError(t, err)
+	assert.Nil(t, profile)
+	mockRepo.AssertNumberOfCalls(t, "ListProfiles", 1)
+
+	// Saving drops the cache
+	mockRepo.On("UpsertProfile", mock.Anything, "montgomery-tx", mock.Anything).Return(&models.CountyProfile{CountyID: 1, County: "montgomery-tx"}, nil)
+	mockRepo.On("ListProfiles", mock.Anything).Return([]models.CountyProfile{{CountyID: 1, County: "montgomery-tx"}}, nil).Once()
+	_, err = service.SaveProfile(ctx, "montgomery-tx", &models.CountyProfile{})
+	require.NoError(t, err)
+
+	profile, err = service.Profile(ctx, "montgomery-tx")
+	require.NoError(t, err)
+	assert.Nil(t, profile.MaxRadiusMeters)
+	mockRepo.AssertNumberOfCalls(t, "ListProfiles", 2)
+}
+
+func TestProfile_ReloadedAfterTTL(t *testing.T) {
+	mockRepo := new(MockCountyRepository)
+	clock := &fakeClock{now: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	service := newCountyService(mockRepo, logger.New("test"), clock.Now)
+
+	mockRepo.On("ListProfiles", mock.Anything).Return([]models.CountyProfile{}, nil)
+
+	_, err := service.Profile(context.Background(), "montgomery-tx")
+	require.NoError(t, err)
+	clock.now = clock.now.Add(CountyProfileCacheTTL)
+	_, err = service.Profile(context.Background(), "montgomery-tx")
+	require.NoError(t, err)
+
+	mockRepo.AssertNumberOfCalls(t, "ListProfiles", 2)
+}
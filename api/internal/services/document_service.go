@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stwalsh4118/atlas/api/internal/documents"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// ParcelDocuments lists the recorded documents a parcel's records cite.
+type ParcelDocuments struct {
+	Documents []models.DocumentReference
+	ParcelID  uint
+}
+
+// DocumentService defines the interface for document reference operations.
+type DocumentService interface {
+	// GetParcelDocuments returns the deeds, plats and other instruments cited by
+	// the parcel's legal description, in the order they appear. References are
+	// linked to the county clerk's records search when the county has a URL
+	// template for their type.
+	// Returns ErrParcelNotFound if no live parcel has the id.
+	GetParcelDocuments(ctx context.Context, parcelID uint) (*ParcelDocuments, error)
+}
+
+// documentService is the concrete implementation of DocumentService.
+type documentService struct {
+	repo repository.DocumentRepository
+	log  *logger.Logger
+}
+
+// NewDocumentService creates a new instance of DocumentService.
+func NewDocumentService(repo repository.DocumentRepository, log *logger.Logger) DocumentService {
+	return &documentService{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// GetParcelDocuments parses and links the parcel's document references.
+func (s *documentService) GetParcelDocuments(ctx context.Context, parcelID uint) (*ParcelDocuments, error) {
+	sources, err := s.repo.FindParcelSources(ctx, parcelID)
+	if err != nil {
+		s.log.Error("Failed to find parcel document sources", err, map[string]interface{}{
+			"parcel_id": parcelID,
+		})
+		return nil, fmt.Errorf("failed to find parcel document sources: %w", err)
+	}
+	if sources == nil {
+		return nil, ErrParcelNotFound
+	}
+
+	refs := []models.DocumentReference{}
+	if sources.LegalDescription != nil {
+		refs = documents.Parse(*sources.LegalDescription, models.DocumentSourceLegalDescription)
+	}
+	documents.Link(refs, sources.URLTemplates)
+
+	return &ParcelDocuments{ParcelID: sources.ParcelID, Documents: refs}, nil
+}
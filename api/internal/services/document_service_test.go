@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// MockDocumentRepository is a mock implementation of DocumentRepository for testing
+type MockDocumentRepository struct {
+	mock.Mock
+}
+
+func (m *MockDocumentRepository) FindParcelSources(ctx context.Context, parcelID uint) (*repository.ParcelDocumentSources, error) {
+	args := m.Called(ctx, parcelID)
+	sources, _ := args.Get(0).(*repository.ParcelDocumentSources)
+	return sources, args.Error(1)
+}
+
+func TestGetParcelDocuments(t *testing.T) {
+	mockRepo := new(MockDocumentRepository)
+	service := NewDocumentService(mockRepo, logger.New("test"))
+
+	legal := "S1234 - LAKE ESTATES, CAB Z SHEET 12, VOL 1234 PG 56"
+	mockRepo.On("FindParcelSources", mock.Anything, uint(7)).Return(&repository.ParcelDocumentSources{
+		ParcelID:         7,
+		LegalDescription: &legal,
+		URLTemplates: map[string]string{
+			models.DocumentTypeVolumePage: "https://clerk.example.gov/search?vol={volume}&page={page}",
+		},
+	}, nil)
+
+	result, err := service.GetParcelDocuments(context.Background(), 7)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), result.ParcelID)
+	require.Len(t, result.Documents, 2)
+	assert.Equal(t, models.DocumentTypePlat, result.Documents[0].Type)
+	assert.Empty(t, result.Documents[0].URL, "plats have no template")
+	assert.Equal(t, "https://clerk.example.gov/search?vol=1234&page=56", result.Documents[1].URL)
+}
+
+func TestGetParcelDocuments_NoLegalDescription(t *testing.T) {
+	mockRepo := new(MockDocumentRepository)
+	service := NewDocumentService(mockRepo, logger.New("test"))
+
+	mockRepo.On("FindParcelSources", mock.Anything, uint(7)).Return(&repository.ParcelDocumentSources{ParcelID: 7}, nil)
+
+	result, err := service.GetParcelDocuments(context.Background(), 7)
+
+	require.NoError(t, err)
+	assert.NotNil(t, result.Documents)
+	assert.Empty(t, result.Documents)
+}
+
+func TestGetParcelDocuments_NotFound(t *testing.T) {
+	mockRepo := new(MockDocumentRepository)
+	service := NewDocumentService(mockRepo, logger.New("test"))
+
+	mockRepo.On("FindParcelSources", mock.Anything, uint(7)).Return(nil, nil)
+
+	_, err := service.GetParcelDocuments(context.Background(), 7)
+
+	assert.ErrorIs(t, err, ErrParcelNotFound)
+}
+
+func TestGetParcelDocuments_RepositoryError(t *testing.T) {
+	mockRepo := new(MockDocumentRepository)
+	service := NewDocumentService(mockRepo, logger.New("test"))
+
+	dbError := errors.New("database connection failed")
+	mockRepo.On("FindParcelSources", mock.Anything, uint(7)).Return(nil, dbError)
+
+	_, err := service.GetParcelDocuments(context.Background(), 7)
+
+	assert.ErrorIs(t, err, dbError)
+}
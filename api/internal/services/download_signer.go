@@ -0,0 +1,66 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+)
+
+// Download link errors
+var (
+	ErrInvalidDownloadLink = domain.Forbidden("download link is invalid")
+	ErrDownloadLinkExpired = domain.Forbidden("download link has expired, request the job status for a new one")
+)
+
+// downloadSigner signs the download links of job results, so the link itself
+// authorizes the download and can be handed to tools that cannot send API
+// credentials.
+type downloadSigner struct {
+	// key signs download links; it lives as long as the process, like the jobs
+	key []byte
+	ttl time.Duration
+	now func() time.Time
+}
+
+// newDownloadKey returns a random signing key. The key is per process: jobs are
+// held in memory, so a link is only useful on the instance that ran the job.
+func newDownloadKey() []byte {
+	key := make([]byte, sha256.Size)
+	_, _ = rand.Read(key) // never returns an error; it crashes the program instead
+	return key
+}
+
+// signDownload signs the ID with an expiry ttl from now, in whole seconds.
+func (d downloadSigner) signDownload(id string) (string, time.Time) {
+	expires := d.now().Add(d.ttl).Truncate(time.Second)
+	return d.sign(id, expires.Unix()), expires
+}
+
+// verifyDownload checks the signature before the expiry, so a forged expiry is
+// reported as an invalid link rather than an expired one. expires is the expiry
+// in Unix seconds, as passed in the download URL.
+func (d downloadSigner) verifyDownload(id, expires, signature string) error {
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidDownloadLink
+	}
+	if !hmac.Equal([]byte(signature), []byte(d.sign(id, unix))) {
+		return ErrInvalidDownloadLink
+	}
+	if d.now().Unix() >= unix {
+		return ErrDownloadLinkExpired
+	}
+	return nil
+}
+
+// sign computes the base64url HMAC-SHA256 of the ID and expiry.
+func (d downloadSigner) sign(id string, expires int64) string {
+	mac := hmac.New(sha256.New, d.key)
+	mac.Write([]byte(id + "\n" + strconv.FormatInt(expires, 10)))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
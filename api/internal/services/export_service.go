@@ -0,0 +1,223 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	"github.com/stwalsh4118/atlas/api/internal/geopackage"
+	"github.com/stwalsh4118/atlas/api/internal/jobs"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"github.com/stwalsh4118/atlas/api/internal/shapefile"
+)
+
+// Export constants
+const (
+	// ExportFormatShapefile packages the parcels as a zipped ESRI Shapefile
+	ExportFormatShapefile = "shp"
+	// ExportFormatGeoPackage packages the parcels as a GeoPackage
+	ExportFormatGeoPackage = "gpkg"
+	// MaxExportParcels caps the parcels packaged into one export; exports keep
+	// full-resolution boundaries, so they are larger per parcel than bundles
+	MaxExportParcels = 50000
+	// ExportJobKind identifies export jobs in the jobs manager
+	ExportJobKind = "export"
+	exportBase    = "parcels"
+	exportTable   = "parcels"
+)
+
+// Export service errors
+var (
+	ErrInvalidExportFormat = domain.Validation(fmt.Sprintf("export format must be %q or %q", ExportFormatShapefile, ExportFormatGeoPackage))
+	ErrExportTooLarge      = fmt.Errorf("area contains more than %d parcels, split it into smaller exports", MaxExportParcels)
+)
+
+// exportFieldTypes maps the bundle column types to shapefile field types.
+var exportFieldTypes = map[geopackage.ColumnType]shapefile.FieldType{
+	geopackage.ColumnText:    shapefile.FieldText,
+	geopackage.ColumnInteger: shapefile.FieldInteger,
+	geopackage.ColumnReal:    shapefile.FieldReal,
+}
+
+// ExportService defines the interface for exporting the parcels of an area as
+// GIS files, for loading selections into QGIS or ArcGIS. Exports are built in
+// the background and downloaded through signed, expiring URLs, so the download
+// link can be handed to desktop tools that cannot send API credentials.
+type ExportService interface {
+	// CreateExport validates the area and format and queues a job that packages
+	// the parcels intersecting the area, limited to county when it is set.
+	// Returns ErrInvalidExportFormat if format is not ExportFormatShapefile or ExportFormatGeoPackage.
+	// Returns ErrInvalidGeometry if the area is malformed, out of range, too complex or not valid per PostGIS.
+	// Returns jobs.ErrQueueFull if too many jobs are waiting to run.
+	// Returns error for database failures.
+	CreateExport(ctx context.Context, area *models.MultiPolygon, format, county string) (jobs.Job, error)
+
+	// GetExport returns the export job with the given ID.
+	// Returns jobs.ErrJobNotFound if the ID is unknown, expired, or not an export job.
+	GetExport(id string) (jobs.Job, error)
+
+	// SignDownload returns the signature authorizing downloads of the export
+	// until the returned expiry.
+	SignDownload(id string) (signature string, expires time.Time)
+
+	// VerifyDownload checks a download signature and returns the export job.
+	// expires is the expiry in Unix seconds, as passed in the download URL.
+	// Returns ErrInvalidDownloadLink if the signature does not match.
+	// Returns ErrDownloadLinkExpired if the link has expired.
+	// Returns jobs.ErrJobNotFound if the ID is unknown, expired, or not an export job.
+	VerifyDownload(id, expires, signature string) (jobs.Job, error)
+}
+
+// exportService is the concrete implementation of ExportService.
+type exportService struct {
+	repo repository.ParcelRepository
+	jobs *jobs.Manager
+	log  *logger.Logger
+	downloadSigner
+}
+
+// NewExportService creates a new instance of ExportService whose download links
+// are valid for ttl, signed with a random per-process key.
+func NewExportService(repo repository.ParcelRepository, manager *jobs.Manager, ttl time.Duration, log *logger.Logger) ExportService {
+	return newExportService(repo, manager, ttl, log, newDownloadKey(), time.Now)
+}
+
+// newExportService creates an exportService with the given signing key, reading
+// the time from now; tests pass a fixed key and a fake clock.
+func newExportService(repo repository.ParcelRepository, manager *jobs.Manager, ttl time.Duration, log *logger.Logger, key []byte, now func() time.Time) *exportService {
+	return &exportService{
+		repo:           repo,
+		jobs:           manager,
+		log:            log,
+		downloadSigner: downloadSigner{key: key, ttl: ttl, now: now},
+	}
+}
+
+// CreateExport validates the request up front so clients learn about bad input
+// from the request itself rather than from a failed job.
+func (s *exportService) CreateExport(ctx context.Context, area *models.MultiPolygon, format, county string) (jobs.Job, error) {
+	if format != ExportFormatShapefile && format != ExportFormatGeoPackage {
+		return jobs.Job{}, ErrInvalidExportFormat
+	}
+
+	if err := validateAreaTopology(ctx, s.repo, area, s.log); err != nil {
+		return jobs.Job{}, err
+	}
+
+	job, err := s.jobs.Submit(ExportJobKind, func(ctx context.Context) (*jobs.Result, error) {
+		return s.buildExport(repository.WithCounty(ctx, county), *area, format)
+	})
+	if err != nil {
+		s.log.Warn("Failed to queue export job", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return jobs.Job{}, err
+	}
+
+	s.log.Info("Export job queued", map[string]interface{}{
+		"job_id":   job.ID,
+		"format":   format,
+		"county":   county,
+		"polygons": len(area.Coordinates),
+	})
+
+	return job, nil
+}
+
+// GetExport hides jobs of other kinds so export IDs cannot be used to probe them.
+func (s *exportService) GetExport(id string) (jobs.Job, error) {
+	job, err := s.jobs.Get(id)
+	if err != nil {
+		return jobs.Job{}, err
+	}
+	if job.Kind != ExportJobKind {
+		return jobs.Job{}, jobs.ErrJobNotFound
+	}
+	return job, nil
+}
+
+// SignDownload signs the ID with an expiry ttl from now.
+func (s *exportService) SignDownload(id string) (string, time.Time) {
+	return s.signDownload(id)
+}
+
+// VerifyDownload checks the link before looking up the job.
+func (s *exportService) VerifyDownload(id, expires, signature string) (jobs.Job, error) {
+	if err := s.verifyDownload(id, expires, signature); err != nil {
+		return jobs.Job{}, err
+	}
+	return s.GetExport(id)
+}
+
+// buildExport packages the parcels intersecting area in the given format.
+// Returns ErrExportTooLarge if more than MaxExportParcels parcels intersect the area.
+func (s *exportService) buildExport(ctx context.Context, area models.MultiPolygon, format string) (*jobs.Result, error) {
+	parcels, err := s.repo.FindIntersecting(ctx, area, MaxExportParcels+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find parcels for export: %w", err)
+	}
+	if len(parcels) > MaxExportParcels {
+		return nil, ErrExportTooLarge
+	}
+
+	if format == ExportFormatShapefile {
+		return buildShapefileExport(parcels)
+	}
+	return buildGeoPackageExport(parcels)
+}
+
+// buildShapefileExport writes the parcels as a zipped shapefile with the bundle
+// attributes, preceded by an id field since shapefiles have no feature IDs.
+func buildShapefileExport(parcels []models.TaxParcel) (*jobs.Result, error) {
+	fields := make([]shapefile.Field, 0, len(bundleColumns)+1)
+	fields = append(fields, shapefile.Field{Name: "id", Type: shapefile.FieldInteger})
+	for _, column := range bundleColumns {
+		fields = append(fields, shapefile.Field{Name: column.Name, Type: exportFieldTypes[column.Type]})
+	}
+
+	writer := shapefile.NewWriter(fields)
+	for i := range parcels {
+		parcel := &parcels[i]
+		values := append([]any{int64(parcel.ID)}, bundleValues(parcel)...) // #nosec G115 -- serial IDs fit in int64
+		if err := writer.Add(parcel.Geom, values...); err != nil {
+			return nil, fmt.Errorf("failed to add parcel %d to export: %w", parcel.ID, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := writer.WriteZip(&buf, exportBase); err != nil {
+		return nil, fmt.Errorf("failed to write shapefile: %w", err)
+	}
+
+	return &jobs.Result{
+		ContentType: shapefile.ContentType,
+		Filename:    exportBase + ".zip",
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// buildGeoPackageExport writes the parcels as a GeoPackage with the bundle attributes.
+func buildGeoPackageExport(parcels []models.TaxParcel) (*jobs.Result, error) {
+	writer := geopackage.NewWriter(exportTable, "Atlas parcels export", bundleColumns)
+	for i := range parcels {
+		parcel := &parcels[i]
+		if err := writer.Add(int64(parcel.ID), parcel.Geom, bundleValues(parcel)...); err != nil { // #nosec G115 -- serial IDs fit in int64
+			return nil, fmt.Errorf("failed to add parcel %d to export: %w", parcel.ID, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if _, err := writer.WriteTo(&buf); err != nil {
+		return nil, fmt.Errorf("failed to write GeoPackage: %w", err)
+	}
+
+	return &jobs.Result{
+		ContentType: geopackage.ContentType,
+		Filename:    exportBase + ".gpkg",
+		Data:        buf.Bytes(),
+	}, nil
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/geopackage"
+	"github.com/stwalsh4118/atlas/api/internal/jobs"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/shapefile"
+)
+
+// newTestExportService returns an export service with a fixed key and the given clock.
+func newTestExportService(repo *MockParcelRepository, clock *fakeClock) *exportService {
+	return newExportService(repo, newTestJobManager(1), 15*time.Minute, logger.New("test"), []byte("test-key"), clock.Now)
+}
+
+func TestCreateExport_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	service := newTestExportService(mockRepo, &fakeClock{now: time.Unix(1700000000, 0)})
+
+	ctx := context.Background()
+	area := testArea()
+	mockRepo.On("ValidateArea", ctx, *area).Return("", nil)
+
+	// Act
+	job, err := service.CreateExport(ctx, area, ExportFormatShapefile, "montgomery")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, ExportJobKind, job.Kind)
+	assert.Equal(t, jobs.StatusQueued, job.Status)
+
+	found, err := service.GetExport(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, job.ID, found.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateExport_InvalidInput(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	service := newTestExportService(mockRepo, &fakeClock{now: time.Unix(1700000000, 0)})
+
+	ctx := context.Background()
+	_, err := service.CreateExport(ctx, testArea(), "kml", "")
+	assert.ErrorIs(t, err, ErrInvalidExportFormat)
+
+	area := testArea()
+	mockRepo.On("ValidateArea", ctx, *area).Return("Self-intersection[-95.45 30.35]", nil)
+	_, err = service.CreateExport(ctx, area, ExportFormatGeoPackage, "")
+	assert.ErrorIs(t, err, ErrInvalidGeometry)
+}
+
+func TestGetExport_OtherKind(t *testing.T) {
+	service := newTestExportService(new(MockParcelRepository), &fakeClock{now: time.Unix(1700000000, 0)})
+
+	other, err := service.jobs.Submit(BundleJobKind, func(ctx context.Context) (*jobs.Result, error) { return &jobs.Result{}, nil })
+	require.NoError(t, err)
+
+	_, err = service.GetExport(other.ID)
+	assert.ErrorIs(t, err, jobs.ErrJobNotFound)
+}
+
+func TestVerifyDownload(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	service := newTestExportService(mockRepo, clock)
+
+	ctx := context.Background()
+	area := testArea()
+	mockRepo.On("ValidateArea", ctx, *area).Return("", nil)
+	job, err := service.CreateExport(ctx, area, ExportFormatGeoPackage, "")
+	require.NoError(t, err)
+
+	signature, expires := service.SignDownload(job.ID)
+	assert.Equal(t, clock.now.Add(15*time.Minute), expires)
+	unix := strconv.FormatInt(expires.Unix(), 10)
+
+	t.Run("valid link", func(t *testing.T) {
+		found, err := service.VerifyDownload(job.ID, unix, signature)
+		require.NoError(t, err)
+		assert.Equal(t, job.ID, found.ID)
+	})
+
+	t.Run("tampered links", func(t *testing.T) {
+		later := strconv.FormatInt(expires.Add(time.Hour).Unix(), 10)
+		for _, args := range [][3]string{
+			{job.ID, unix, signature + "x"},
+			{job.ID, later, signature},
+			{job.ID, "soon", signature},
+			{"other", unix, signature},
+		} {
+			_, err := service.VerifyDownload(args[0], args[1], args[2])
+			assert.ErrorIs(t, err, ErrInvalidDownloadLink, args)
+		}
+	})
+
+	t.Run("expired link", func(t *testing.T) {
+		clock.now = clock.now.Add(15 * time.Minute)
+		_, err := service.VerifyDownload(job.ID, unix, signature)
+		assert.ErrorIs(t, err, ErrDownloadLinkExpired)
+	})
+}
+
+func TestBuildExport(t *testing.T) {
+	area := testArea()
+	owner := "Test Owner"
+	found := []models.TaxParcel{
+		{ID: 1, PIN: 100, OwnerName: &owner, CountyName: "Montgomery", Geom: *area},
+		{ID: 2, PIN: 200, CountyName: "Montgomery", Geom: *area},
+	}
+
+	t.Run("shapefile", func(t *testing.T) {
+		mockRepo := new(MockParcelRepository)
+		service := newTestExportService(mockRepo, &fakeClock{now: time.Unix(1700000000, 0)})
+		mockRepo.On("FindIntersecting", mock.Anything, *area, MaxExportParcels+1).Return(found, nil)
+
+		result, err := service.buildExport(context.Background(), *area, ExportFormatShapefile)
+		require.NoError(t, err)
+		assert.Equal(t, shapefile.ContentType, result.ContentType)
+		assert.Equal(t, "parcels.zip", result.Filename)
+		assert.Equal(t, "PK", string(result.Data[:2]))
+	})
+
+	t.Run("geopackage", func(t *testing.T) {
+		mockRepo := new(MockParcelRepository)
+		service := newTestExportService(mockRepo, &fakeClock{now: time.Unix(1700000000, 0)})
+		mockRepo.On("FindIntersecting", mock.Anything, *area, MaxExportParcels+1).Return(found, nil)
+
+		result, err := service.buildExport(context.Background(), *area, ExportFormatGeoPackage)
+		require.NoError(t, err)
+		assert.Equal(t, geopackage.ContentType, result.ContentType)
+		assert.Equal(t, "parcels.gpkg", result.Filename)
+		assert.Contains(t, string(result.Data), "Test Owner")
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		mockRepo := new(MockParcelRepository)
+		service := newTestExportService(mockRepo, &fakeClock{now: time.Unix(1700000000, 0)})
+		mockRepo.On("FindIntersecting", mock.Anything, *area, MaxExportParcels+1).Return(make([]models.TaxParcel, MaxExportParcels+1), nil)
+
+		result, err := service.buildExport(context.Background(), *area, ExportFormatShapefile)
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, ErrExportTooLarge)
+	})
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// MaxFavoriteNoteLength bounds the note of a favorite, in characters
+const MaxFavoriteNoteLength = 1000
+
+// Favorite service errors
+var (
+	ErrInvalidFavorite  = domain.Validation("invalid favorite")
+	ErrFavoriteNotFound = domain.NotFound("favorite not found")
+)
+
+// FavoriteService defines the interface for the users' parcel bookmarks.
+type FavoriteService interface {
+	// SaveFavorite bookmarks the parcel for the user, or updates the note of the
+	// existing favorite. A nil note keeps the existing note; a blank one clears it.
+	// Returns the favorite and whether it was created, ErrInvalidFavorite if the
+	// note is too long, or ErrParcelNotFound if the parcel doesn't exist.
+	SaveFavorite(ctx context.Context, userID, parcelID uint, note *string) (*models.Favorite, bool, error)
+
+	// ListFavorites returns the user's favorites, newest first.
+	ListFavorites(ctx context.Context, userID uint) ([]models.Favorite, error)
+
+	// DeleteFavorite removes the user's favorite of the parcel.
+	// Returns ErrFavoriteNotFound if the user has not bookmarked it.
+	DeleteFavorite(ctx context.Context, userID, parcelID uint) error
+}
+
+// favoriteService is the concrete implementation of FavoriteService.
+type favoriteService struct {
+	repo repository.FavoriteRepository
+	log  *logger.Logger
+}
+
+// NewFavoriteService creates a new instance of FavoriteService.
+func NewFavoriteService(repo repository.FavoriteRepository, log *logger.Logger) FavoriteService {
+	return &favoriteService{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// SaveFavorite trims the note before checking its length.
+func (s *favoriteService) SaveFavorite(ctx context.Context, userID, parcelID uint, note *string) (*models.Favorite, bool, error) {
+	if note != nil {
+		trimmed := strings.TrimSpace(*note)
+		if utf8.RuneCountInString(trimmed) > MaxFavoriteNoteLength {
+			return nil, false, fmt.Errorf("%w: note must be at most %d characters", ErrInvalidFavorite, MaxFavoriteNoteLength)
+		}
+		note = &trimmed
+	}
+
+	favorite, created, err := s.repo.Save(ctx, userID, parcelID, note)
+	if err != nil {
+		s.log.Error("Failed to save favorite", err, map[string]interface{}{
+			"user_id":   userID,
+			"parcel_id": parcelID,
+		})
+		return nil, false, fmt.Errorf("failed to save favorite: %w", err)
+	}
+	if favorite == nil {
+		return nil, false, ErrParcelNotFound
+	}
+
+	return favorite, created, nil
+}
+
+// ListFavorites returns the user's favorites.
+func (s *favoriteService) ListFavorites(ctx context.Context, userID uint) ([]models.Favorite, error) {
+	favorites, err := s.repo.List(ctx, userID)
+	if err != nil {
+		s.log.Error("Failed to list favorites", err, map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, fmt.Errorf("failed to list favorites: %w", err)
+	}
+
+	return favorites, nil
+}
+
+// DeleteFavorite deletes the favorite if the user has one for the parcel.
+func (s *favoriteService) DeleteFavorite(ctx context.Context, userID, parcelID uint) error {
+	deleted, err := s.repo.Delete(ctx, userID, parcelID)
+	if err != nil {
+		s.log.Error("Failed to delete favorite", err, map[string]interface{}{
+			"user_id":   userID,
+			"parcel_id": parcelID,
+		})
+		return fmt.Errorf("failed to delete favorite: %w", err)
+	}
+	if !deleted {
+		return ErrFavoriteNotFound
+	}
+
+	return nil
+}
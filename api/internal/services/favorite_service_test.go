@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// MockFavoriteRepository is a mock implementation of FavoriteRepository for testing
+type MockFavoriteRepository struct {
+	mock.Mock
+}
+
+func (m *MockFavoriteRepository) Save(ctx context.Context, userID, parcelID uint, note *string) (*models.Favorite, bool, error) {
+	args := m.Called(ctx, userID, parcelID, note)
+	favorite, _ := args.Get(0).(*models.Favorite)
+	return favorite, args.Bool(1), args.Error(2)
+}
+
+func (m *MockFavoriteRepository) List(ctx context.Context, userID uint) ([]models.Favorite, error) {
+	args := m.Called(ctx, userID)
+	favorites, _ := args.Get(0).([]models.Favorite)
+	return favorites, args.Error(1)
+}
+
+func (m *MockFavoriteRepository) Delete(ctx context.Context, userID, parcelID uint) (bool, error) {
+	args := m.Called(ctx, userID, parcelID)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestSaveFavorite_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockFavoriteRepository)
+	service := NewFavoriteService(mockRepo, logger.New("test"))
+	ctx := context.Background()
+	note := "  call the owner  "
+	trimmed := "call the owner"
+
+	mockRepo.On("Save", ctx, uint(7), uint(42), &trimmed).Return(&models.Favorite{ID: 1, ParcelID: 42, Note: &trimmed}, true, nil)
+
+	// Act
+	favorite, created, err := service.SaveFavorite(ctx, 7, 42, &note)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, uint(42), favorite.ParcelID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSaveFavorite_KeepsNoteWhenOmitted(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockFavoriteRepository)
+	service := NewFavoriteService(mockRepo, logger.New("test"))
+	ctx := context.Background()
+
+	mockRepo.On("Save", ctx, uint(7), uint(42), (*string)(nil)).Return(&models.Favorite{ID: 1}, false, nil)
+
+	// Act
+	_, created, err := service.SaveFavorite(ctx, 7, 42, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, created)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSaveFavorite_Errors(t *testing.T) {
+	t.Run("note too long", func(t *testing.T) {
+		mockRepo := new(MockFavoriteRepository)
+		service := NewFavoriteService(mockRepo, logger.New("test"))
+		note := strings.Repeat("x", MaxFavoriteNoteLength+1)
+
+		_, _, err := service.SaveFavorite(context.Background(), 7, 42, &note)
+
+		assert.ErrorIs(t, err, ErrInvalidFavorite)
+		mockRepo.AssertNotCalled(t, "Save")
+	})
+
+	t.Run("parcel not found", func(t *testing.T) {
+		mockRepo := new(MockFavoriteRepository)
+		service := NewFavoriteService(mockRepo, logger.New("test"))
+		ctx := context.Background()
+
+		mockRepo.On("Save", ctx, uint(7), uint(42), (*string)(nil)).Return(nil, false, nil)
+
+		_, _, err := service.SaveFavorite(ctx, 7, 42, nil)
+
+		assert.ErrorIs(t, err, ErrParcelNotFound)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo := new(MockFavoriteRepository)
+		service := NewFavoriteService(mockRepo, logger.New("test"))
+		ctx := context.Background()
+
+		mockRepo.On("Save", ctx, uint(7), uint(42), (*string)(nil)).Return(nil, false, errors.New("connection refused"))
+
+		_, _, err := service.SaveFavorite(ctx, 7, 42, nil)
+
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrParcelNotFound)
+	})
+}
+
+func TestDeleteFavorite(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockFavoriteRepository)
+	service := NewFavoriteService(mockRepo, logger.New("test"))
+	ctx := context.Background()
+
+	mockRepo.On("Delete", ctx, uint(7), uint(42)).Return(true, nil)
+	mockRepo.On("Delete", ctx, uint(7), uint(43)).Return(false, nil)
+
+	// Act & Assert
+	assert.NoError(t, service.DeleteFavorite(ctx, 7, 42))
+	assert.ErrorIs(t, service.DeleteFavorite(ctx, 7, 43), ErrFavoriteNotFound)
+	mockRepo.AssertExpectations(t)
+}
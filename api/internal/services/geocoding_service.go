@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	"github.com/stwalsh4118/atlas/api/internal/location"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// Geocoding constants
+const (
+	// MaxGeocodeQueryLength bounds a forward geocoding query, in characters
+	MaxGeocodeQueryLength = 200
+	// DefaultGeocodeLimit is the number of matches returned when no limit is given
+	DefaultGeocodeLimit = 5
+	// MaxGeocodeLimit bounds the matches returned per query
+	MaxGeocodeLimit = 10
+)
+
+// Geocoding service errors
+var (
+	ErrInvalidGeocodeQuery = domain.Validation(fmt.Sprintf("geocode query must be 1 to %d characters", MaxGeocodeQueryLength))
+	ErrGeocoderUnavailable = domain.Unavailable("geocoding service is temporarily unavailable, try again shortly").WithRetryAfter(30 * time.Second)
+)
+
+// GeocodingService defines the interface for address lookups with the
+// configured external geocoder.
+type GeocodingService interface {
+	// ReverseGeocode returns the normalized address nearest point.
+	// Returns nil, nil if the geocoder knows no address there.
+	// Returns error for geocoder failures.
+	ReverseGeocode(ctx context.Context, point models.LatLng) (*location.Address, error)
+
+	// Geocode returns up to limit addresses matching the free-form query,
+	// best first; a limit of 0 means DefaultGeocodeLimit.
+	// Returns ErrInvalidGeocodeQuery for an empty or overlong query, or
+	// ErrGeocoderUnavailable for geocoder failures.
+	Geocode(ctx context.Context, query string, limit int) ([]location.Match, error)
+}
+
+// geocodingService is the concrete implementation of GeocodingService.
+type geocodingService struct {
+	geocoder location.Geocoder
+	log      *logger.Logger
+}
+
+// NewGeocodingService creates a new instance of GeocodingService.
+func NewGeocodingService(geocoder location.Geocoder, log *logger.Logger) GeocodingService {
+	return &geocodingService{
+		geocoder: geocoder,
+		log:      log,
+	}
+}
+
+// ReverseGeocode logs failures, since callers treat the address as optional
+// and drop the error.
+func (s *geocodingService) ReverseGeocode(ctx context.Context, point models.LatLng) (*location.Address, error) {
+	address, err := s.geocoder.Reverse(ctx, point)
+	if err != nil {
+		s.log.Error("Failed to reverse geocode point", err, map[string]interface{}{
+			"lat": point.Lat,
+			"lng": point.Lng,
+		})
+		return nil, fmt.Errorf("failed to reverse geocode point: %w", err)
+	}
+
+	if address == nil {
+		s.log.Debug("No address found for point", map[string]interface{}{
+			"lat": point.Lat,
+			"lng": point.Lng,
+		})
+	}
+
+	return address, nil
+}
+
+// Geocode normalizes whitespace and case in the query before searching, so
+// that spellings of the same address share the outbound response cache.
+func (s *geocodingService) Geocode(ctx context.Context, query string, limit int) ([]location.Match, error) {
+	query = strings.ToLower(strings.Join(strings.Fields(query), " "))
+	if query == "" || utf8.RuneCountInString(query) > MaxGeocodeQueryLength {
+		return nil, ErrInvalidGeocodeQuery
+	}
+	if limit <= 0 {
+		limit = DefaultGeocodeLimit
+	}
+	limit = min(limit, MaxGeocodeLimit)
+
+	matches, err := s.geocoder.Search(ctx, query, limit)
+	if err != nil {
+		s.log.Error("Failed to geocode query", err, map[string]interface{}{
+			"query": query,
+		})
+		return nil, fmt.Errorf("%w: %v", ErrGeocoderUnavailable, err)
+	}
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/location"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// MockGeocoder is a mock implementation of location.Geocoder for testing
+type MockGeocoder struct {
+	mock.Mock
+}
+
+func (m *MockGeocoder) Reverse(ctx context.Context, point models.LatLng) (*location.Address, error) {
+	args := m.Called(ctx, point)
+	address, _ := args.Get(0).(*location.Address)
+	return address, args.Error(1)
+}
+
+func (m *MockGeocoder) Search(ctx context.Context, query string, limit int) ([]location.Match, error) {
+	args := m.Called(ctx, query, limit)
+	matches, _ := args.Get(0).([]location.Match)
+	return matches, args.Error(1)
+}
+
+func TestReverseGeocode(t *testing.T) {
+	geocoder := new(MockGeocoder)
+	service := NewGeocodingService(geocoder, logger.New("test"))
+	point := models.LatLng{Lat: 30.3119, Lng: -95.4561}
+
+	expected := &location.Address{Label: "301 N Main St, Conroe, TX 77301"}
+	geocoder.On("Reverse", mock.Anything, point).Return(expected, nil)
+
+	address, err := service.ReverseGeocode(context.Background(), point)
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, address)
+}
+
+func TestReverseGeocode_NoAddress(t *testing.T) {
+	geocoder := new(MockGeocoder)
+	service := NewGeocodingService(geocoder, logger.New("test"))
+
+	geocoder.On("Reverse", mock.Anything, mock.Anything).Return(nil, nil)
+
+	address, err := service.ReverseGeocode(context.Background(), models.LatLng{Lat: 30.3, Lng: -95.4})
+
+	require.NoError(t, err)
+	assert.Nil(t, address)
+}
+
+func TestReverseGeocode_GeocoderError(t *testing.T) {
+	geocoder := new(MockGeocoder)
+	service := NewGeocodingService(geocoder, logger.New("test"))
+
+	geocoder.On("Reverse", mock.Anything, mock.Anything).Return(nil, errors.New("circuit breaker open"))
+
+	_, err := service.ReverseGeocode(context.Background(), models.LatLng{Lat: 30.3, Lng: -95.4})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "circuit breaker open")
+}
+
+func TestGeocode_NormalizesQuery(t *testing.T) {
+	geocoder := new(MockGeocoder)
+	service := NewGeocodingService(geocoder, logger.New("test"))
+
+	expected := []location.Match{{Address: location.Address{Label: "301 N Main St, Conroe, TX 77301"}}}
+	geocoder.On("Search", mock.Anything, "301 n main st conroe", DefaultGeocodeLimit).Return(expected, nil)
+
+	matches, err := service.Geocode(context.Background(), "  301 N Main St\tConroe ", 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, matches)
+}
+
+func TestGeocode_CapsLimit(t *testing.T) {
+	geocoder := new(MockGeocoder)
+	service := NewGeocodingService(geocoder, logger.New("test"))
+
+	geocoder.On("Search", mock.Anything, mock.Anything, MaxGeocodeLimit).Return([]location.Match{}, nil)
+
+	_, err := service.Geocode(context.Background(), "conroe", 50)
+
+	require.NoError(t, err)
+	geocoder.AssertExpectations(t)
+}
+
+func TestGeocode_InvalidQuery(t *testing.T) {
+	geocoder := new(MockGeocoder)
+	service := NewGeocodingService(geocoder, logger.New("test"))
+
+	for name, query := range map[string]string{
+		"blank":    " \t ",
+		"too long": strings.Repeat("a", MaxGeocodeQueryLength+1),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := service.Geocode(context.Background(), query, 5)
+
+			assert.ErrorIs(t, err, ErrInvalidGeocodeQuery)
+		})
+	}
+	geocoder.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGeocode_GeocoderError(t *testing.T) {
+	geocoder := new(MockGeocoder)
+	service := NewGeocodingService(geocoder, logger.New("test"))
+
+	geocoder.On("Search", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("circuit breaker open"))
+
+	_, err := service.Geocode(context.Background(), "conroe", 5)
+
+	assert.ErrorIs(t, err, ErrGeocoderUnavailable)
+	assert.Contains(t, err.Error(), "circuit breaker open")
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// ParcelHistory is the version history of a parcel, newest first. The first
+// version is the current one; Deleted reports whether it is soft-deleted.
+type ParcelHistory struct {
+	Versions []ParcelVersionChange
+	ParcelID uint
+	Deleted  bool
+}
+
+// ParcelVersionChange is a version with the fields that changed from the
+// version before it. Changed is nil for the oldest recorded version.
+type ParcelVersionChange struct {
+	Version *models.ParcelVersion
+	Changed []string
+}
+
+// HistoryService defines the interface for parcel history operations.
+type HistoryService interface {
+	// GetParcelHistory returns up to limit versions of the parcel, newest first,
+	// each with the fields changed from the version before it.
+	// Soft-deleted parcels are included.
+	// Returns ErrParcelNotFound if no parcel has the id.
+	GetParcelHistory(ctx context.Context, parcelID uint, limit int) (*ParcelHistory, error)
+
+	// GetParcelAsOf returns the parcel as it was at the end of the given day
+	// (UTC): the version valid then.
+	// Returns ErrParcelNotFound if the parcel did not exist or was soft-deleted then.
+	GetParcelAsOf(ctx context.Context, parcelID uint, date time.Time) (*repository.ParcelAsOf, error)
+
+	// GetParcelAtPointAsOf returns the parcel whose boundary contained the point
+	// at the end of the given day (UTC), as it was then.
+	// Returns models.ErrInvalidCoordinates if the point is out of range.
+	// Returns ErrParcelNotFound if no parcel contained the point then.
+	GetParcelAtPointAsOf(ctx context.Context, point models.LatLng, date time.Time) (*repository.ParcelAsOf, error)
+}
+
+// historyService is the concrete implementation of HistoryService.
+type historyService struct {
+	repo repository.HistoryRepository
+	log  *logger.Logger
+}
+
+// NewHistoryService creates a new instance of HistoryService.
+func NewHistoryService(repo repository.HistoryRepository, log *logger.Logger) HistoryService {
+	return &historyService{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// GetParcelHistory reads one version more than limit, so the last version
+// returned is still compared with the one before it.
+func (s *historyService) GetParcelHistory(ctx context.Context, parcelID uint, limit int) (*ParcelHistory, error) {
+	versions, err := s.repo.FindHistory(ctx, parcelID, limit+1)
+	if err != nil {
+		s.log.Error("Failed to find parcel history", err, map[string]interface{}{
+			"parcel_id": parcelID,
+		})
+		return nil, fmt.Errorf("failed to find parcel history: %w", err)
+	}
+	if len(versions) == 0 {
+		return nil, ErrParcelNotFound
+	}
+
+	history := &ParcelHistory{
+		ParcelID: parcelID,
+		Deleted:  versions[0].Deleted,
+		Versions: make([]ParcelVersionChange, 0, min(len(versions), limit)),
+	}
+	for i, version := range versions[:min(len(versions), limit)] {
+		change := ParcelVersionChange{Version: version}
+		if i+1 < len(versions) {
+			change.Changed = changedFields(versions[i+1], version)
+		}
+		history.Versions = append(history.Versions, change)
+	}
+
+	return history, nil
+}
+
+// GetParcelAsOf reads the version valid at the end of the day.
+func (s *historyService) GetParcelAsOf(ctx context.Context, parcelID uint, date time.Time) (*repository.ParcelAsOf, error) {
+	parcel, err := s.repo.FindByIDAsOf(ctx, parcelID, endOfDay(date))
+	if err != nil {
+		s.log.Error("Failed to query parcel as of date", err, map[string]interface{}{
+			"parcel_id": parcelID,
+			"as_of":     date.Format(time.DateOnly),
+		})
+		return nil, fmt.Errorf("failed to query parcel: %w", err)
+	}
+	if parcel == nil {
+		return nil, fmt.Errorf("%w: id %d as of %s", ErrParcelNotFound, parcelID, date.Format(time.DateOnly))
+	}
+
+	return parcel, nil
+}
+
+// GetParcelAtPointAsOf validates the point and reads the version valid at the
+// end of the day.
+func (s *historyService) GetParcelAtPointAsOf(ctx context.Context, point models.LatLng, date time.Time) (*repository.ParcelAsOf, error) {
+	if err := point.Validate(); err != nil {
+		return nil, err
+	}
+
+	parcel, err := s.repo.FindByPointAsOf(ctx, point, endOfDay(date))
+	if err != nil {
+		s.log.Error("Failed to query parcel at point as of date", err, map[string]interface{}{
+			"lat":   point.Lat,
+			"lng":   point.Lng,
+			"as_of": date.Format(time.DateOnly),
+		})
+		return nil, fmt.Errorf("failed to query parcel: %w", err)
+	}
+	if parcel == nil {
+		return nil, ErrParcelNotFound
+	}
+
+	return parcel, nil
+}
+
+// endOfDay returns the last instant of the UTC day of date, so changes made
+// during the day are part of the parcel as of that day.
+func endOfDay(date time.Time) time.Time {
+	year, month, day := date.UTC().Date()
+	return time.Date(year, month, day+1, 0, 0, 0, 0, time.UTC).Add(-time.Microsecond)
+}
+
+// changedFields lists the fields of version that differ from previous, by their
+// parcel response member names. owner_address is compared only as read: without
+// contact access both are nil and it is never reported.
+func changedFields(previous, version *models.ParcelVersion) []string {
+	changed := []string{}
+	add := func(name string, differs bool) {
+		if differs {
+			changed = append(changed, name)
+		}
+	}
+
+	add("pin", previous.PIN != version.PIN)
+	add("pid", !equalPtr(previous.PID, version.PID))
+	add("owner_name", !equalPtr(previous.OwnerName, version.OwnerName))
+	add("owner_address", !equalPtr(previous.OwnerAddress, version.OwnerAddress))
+	add("situs_address", !equalPtr(previous.Situs, version.Situs))
+	add("land_use", !equalPtr(previous.AsCode, version.AsCode))
+	add("legal_description", !equalPtr(previous.LegalDescription, version.LegalDescription))
+	add("state_cd", !equalPtr(previous.StateCd, version.StateCd))
+	add("block", !equalPtr(previous.Block, version.Block))
+	add("lot", !equalPtr(previous.Lot, version.Lot))
+	add("tract", !equalPtr(previous.Tract, version.Tract))
+	add("year_built", !equalPtr(previous.ImprvActualYearBuilt, version.ImprvActualYearBuilt))
+	add("main_area", !equalPtr(previous.ImprvMainArea, version.ImprvMainArea))
+	add("market_area", !equalPtr(previous.MarketArea, version.MarketArea))
+	add("tax_year", !equalPtr(previous.PYear, version.PYear))
+	add("roll_version", !equalPtr(previous.PVersion, version.PVersion))
+	add("roll_correction", !equalPtr(previous.PRollCorr, version.PRollCorr))
+	add("taxing_units", !equalPtr(previous.TaxingUnits, version.TaxingUnits))
+	add("exemptions", !equalPtr(previous.Exemptions, version.Exemptions))
+	add("geometry", previous.GeometryHash != version.GeometryHash)
+	add("deleted", previous.Deleted != version.Deleted)
+
+	return changed
+}
+
+// equalPtr reports whether two optional values are both nil or equal.
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
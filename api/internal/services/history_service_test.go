@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// MockHistoryRepository is a mock implementation of HistoryRepository for testing
+type MockHistoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockHistoryRepository) FindHistory(ctx context.Context, parcelID uint, limit int) ([]*models.ParcelVersion, error) {
+	args := m.Called(ctx, parcelID, limit)
+	versions, _ := args.Get(0).([]*models.ParcelVersion)
+	return versions, args.Error(1)
+}
+
+func (m *MockHistoryRepository) FindByIDAsOf(ctx context.Context, parcelID uint, at time.Time) (*repository.ParcelAsOf, error) {
+	args := m.Called(ctx, parcelID, at)
+	parcel, _ := args.Get(0).(*repository.ParcelAsOf)
+	return parcel, args.Error(1)
+}
+
+func (m *MockHistoryRepository) FindByPointAsOf(ctx context.Context, point models.LatLng, at time.Time) (*repository.ParcelAsOf, error) {
+	args := m.Called(ctx, point, at)
+	parcel, _ := args.Get(0).(*repository.ParcelAsOf)
+	return parcel, args.Error(1)
+}
+
+// parcelVersions returns versions of parcel 7, newest first, valid until the
+// given days of 2026; day 0 is the current version.
+func parcelVersions(owners []string, days ...int) []*models.ParcelVersion {
+	versions := make([]*models.ParcelVersion, len(days))
+	for i, day := range days {
+		version := &models.ParcelVersion{ParcelID: 7, PIN: 100, OwnerName: &owners[i], GeometryHash: "a"}
+		if day > 0 {
+			validTo := time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC)
+			version.ValidTo = &validTo
+		}
+		versions[i] = version
+	}
+	return versions
+}
+
+func TestGetParcelHistory(t *testing.T) {
+	mockRepo := new(MockHistoryRepository)
+	service := NewHistoryService(mockRepo, logger.New("test"))
+
+	versions := parcelVersions([]string{"C", "B", "A"}, 0, 20, 10)
+	versions[0].GeometryHash = "b"
+	mockRepo.On("FindHistory", mock.Anything, uint(7), 21).Return(versions, nil)
+
+	history, err := service.GetParcelHistory(context.Background(), 7, 20)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), history.ParcelID)
+	assert.False(t, history.Deleted)
+	require.Len(t, history.Versions, 3)
+	assert.Equal(t, []string{"owner_name", "geometry"}, history.Versions[0].Changed)
+	assert.Equal(t, []string{"owner_name"}, history.Versions[1].Changed)
+	assert.Nil(t, history.Versions[2].Changed, "the oldest version has nothing to compare with")
+}
+
+func TestGetParcelHistory_Limit(t *testing.T) {
+	mockRepo := new(MockHistoryRepository)
+	service := NewHistoryService(mockRepo, logger.New("test"))
+
+	versions := parcelVersions([]string{"B", "B", "A"}, 0, 20, 10)
+	versions[0].Deleted = true
+	mockRepo.On("FindHistory", mock.Anything, uint(7), 3).Return(versions, nil)
+
+	history, err := service.GetParcelHistory(context.Background(), 7, 2)
+
+	require.NoError(t, err)
+	assert.True(t, history.Deleted)
+	require.Len(t, history.Versions, 2)
+	assert.Equal(t, []string{"deleted"}, history.Versions[0].Changed)
+	assert.Equal(t, []string{"owner_name"}, history.Versions[1].Changed, "the last version is compared with the one read beyond the limit")
+}
+
+func TestGetParcelHistory_NotFound(t *testing.T) {
+	mockRepo := new(MockHistoryRepository)
+	service := NewHistoryService(mockRepo, logger.New("test"))
+
+	mockRepo.On("FindHistory", mock.Anything, uint(7), 21).Return(nil, nil)
+
+	_, err := service.GetParcelHistory(context.Background(), 7, 20)
+	assert.ErrorIs(t, err, ErrParcelNotFound)
+}
+
+func TestGetParcelHistory_RepositoryError(t *testing.T) {
+	mockRepo := new(MockHistoryRepository)
+	service := NewHistoryService(mockRepo, logger.New("test"))
+
+	mockRepo.On("FindHistory", mock.Anything, uint(7), 21).Return(nil, errors.New("connection refused"))
+
+	_, err := service.GetParcelHistory(context.Background(), 7, 20)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrParcelNotFound)
+}
+
+func TestGetParcelAsOf(t *testing.T) {
+	mockRepo := new(MockHistoryRepository)
+	service := NewHistoryService(mockRepo, logger.New("test"))
+
+	date := time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC)
+	endOfDate := time.Date(2024, 6, 14, 23, 59, 59, 999999000, time.UTC)
+	mockRepo.On("FindByIDAsOf", mock.Anything, uint(7), endOfDate).Return(&repository.ParcelAsOf{Parcel: models.TaxParcel{ID: 7}}, nil).Once()
+
+	parcel, err := service.GetParcelAsOf(context.Background(), 7, date)
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), parcel.Parcel.ID)
+
+	mockRepo.On("FindByIDAsOf", mock.Anything, uint(7), endOfDate).Return(nil, nil)
+
+	_, err = service.GetParcelAsOf(context.Background(), 7, date)
+	assert.ErrorIs(t, err, ErrParcelNotFound)
+}
+
+func TestGetParcelAtPointAsOf(t *testing.T) {
+	mockRepo := new(MockHistoryRepository)
+	service := NewHistoryService(mockRepo, logger.New("test"))
+
+	date := time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC)
+	_, err := service.GetParcelAtPointAsOf(context.Background(), models.LatLng{Lat: 91, Lng: 0}, date)
+	assert.ErrorIs(t, err, models.ErrInvalidCoordinates)
+
+	point := models.LatLng{Lat: 30.3, Lng: -95.5}
+	mockRepo.On("FindByPointAsOf", mock.Anything, point, mock.Anything).Return(nil, nil)
+
+	_, err = service.GetParcelAtPointAsOf(context.Background(), point, date)
+	assert.ErrorIs(t, err, ErrParcelNotFound)
+}
+
+func TestEndOfDay(t *testing.T) {
+	central := time.FixedZone("CST", -6*60*60)
+	assert.Equal(t, time.Date(2024, 6, 14, 23, 59, 59, 999999000, time.UTC), endOfDay(time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2024, 6, 15, 23, 59, 59, 999999000, time.UTC), endOfDay(time.Date(2024, 6, 14, 20, 0, 0, 0, central)), "the UTC day counts")
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"github.com/stwalsh4118/atlas/api/internal/tracing"
+)
+
+// Layer query limits
+const (
+	// MaxLayerRadiusMeters is the largest search radius around an at-point query
+	MaxLayerRadiusMeters = 1000
+	// DefaultLayerBBoxLimit is how many features an in-bbox query returns by default
+	DefaultLayerBBoxLimit = 1000
+	// MaxLayerBBoxLimit is the most features an in-bbox query may return
+	MaxLayerBBoxLimit = 5000
+)
+
+// Layer errors
+var (
+	ErrLayerNotFound     = domain.NotFound("layer not found")
+	ErrInvalidLayerQuery = domain.Validation("invalid layer query")
+)
+
+// LayerFeatures is a page of a layer's features. Truncated reports that more
+// features matched than were returned.
+type LayerFeatures struct {
+	Layer     *models.Layer
+	Features  []models.LayerFeature
+	Truncated bool
+}
+
+// LayerService defines the interface for generic spatial layer operations.
+type LayerService interface {
+	// ListLayers returns the registered layers ordered by name.
+	ListLayers(ctx context.Context) ([]models.Layer, error)
+
+	// GetFeaturesAtPoint returns the features of the named layer at the point,
+	// or within radiusMeters of it when radiusMeters is positive.
+	// Returns ErrLayerNotFound if no such layer is registered,
+	// models.ErrInvalidCoordinates if the point is out of range and
+	// ErrInvalidLayerQuery if the radius is out of range.
+	GetFeaturesAtPoint(ctx context.Context, name string, point models.LatLng, radiusMeters float64) (*LayerFeatures, error)
+
+	// GetFeaturesInBBox returns up to limit features of the named layer
+	// intersecting the bounding box, DefaultLayerBBoxLimit when limit is 0.
+	// Returns ErrLayerNotFound if no such layer is registered and
+	// ErrInvalidLayerQuery if the box or limit is out of range.
+	GetFeaturesInBBox(ctx context.Context, name string, bbox models.BoundingBox, limit int) (*LayerFeatures, error)
+
+	// GetOverlayAtPoint returns the features of the overlay containing the
+	// point whose properties include those of filter, such as a jurisdiction kind.
+	// Returns an empty slice if the point is in no feature or the layer is not loaded (not an error).
+	// Returns models.ErrInvalidCoordinates if the point is out of range.
+	GetOverlayAtPoint(ctx context.Context, overlay repository.Overlay, point models.LatLng, filter map[string]any) ([]models.LayerFeature, error)
+
+	// GetParcelOverlays returns the features of the overlay matched to each of
+	// the given parcels, keyed by parcel ID.
+	// Returns an empty map if ids is empty or the layer is not loaded (not an error).
+	GetParcelOverlays(ctx context.Context, overlay repository.Overlay, ids []uint) (map[uint][]repository.ParcelOverlay, error)
+}
+
+// layerService is the concrete implementation of LayerService.
+type layerService struct {
+	repo repository.LayerRepository
+	log  *logger.Logger
+}
+
+// NewLayerService creates a new instance of LayerService.
+func NewLayerService(repo repository.LayerRepository, log *logger.Logger) LayerService {
+	return &layerService{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// ListLayers reads the layer registry.
+func (s *layerService) ListLayers(ctx context.Context) ([]models.Layer, error) {
+	ctx, span := tracing.Start(ctx, "LayerService.ListLayers")
+	defer span.End()
+
+	layers, err := s.repo.List(ctx)
+	if err != nil {
+		s.log.Error("Failed to list layers", err, nil)
+		return nil, fmt.Errorf("failed to list layers: %w", err)
+	}
+
+	return layers, nil
+}
+
+// GetFeaturesAtPoint validates the query, resolves the layer and looks up its features.
+func (s *layerService) GetFeaturesAtPoint(ctx context.Context, name string, point models.LatLng, radiusMeters float64) (*LayerFeatures, error) {
+	ctx, span := tracing.Start(ctx, "LayerService.GetFeaturesAtPoint")
+	defer span.End()
+
+	if err := point.Validate(); err != nil {
+		s.log.Warn("Invalid coordinates provided", map[string]interface{}{
+			"lat": point.Lat,
+			"lng": point.Lng,
+		})
+		return nil, err
+	}
+	if radiusMeters < 0 || radiusMeters > MaxLayerRadiusMeters {
+		return nil, fmt.Errorf("%w: radius must be between 0 and %d meters", ErrInvalidLayerQuery, MaxLayerRadiusMeters)
+	}
+
+	layer, err := s.findLayer(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	features, err := s.repo.FindAtPoint(ctx, layer.ID, point, radiusMeters)
+	if err != nil {
+		s.log.Error("Failed to query layer at point", err, map[string]interface{}{
+			"layer": name,
+			"lat":   point.Lat,
+			"lng":   point.Lng,
+		})
+		return nil, fmt.Errorf("failed to query layer features: %w", err)
+	}
+
+	return &LayerFeatures{Layer: layer, Features: features}, nil
+}
+
+// GetFeaturesInBBox validates the query, resolves the layer and looks up one
+// feature more than the limit to tell whether the page is truncated.
+func (s *layerService) GetFeaturesInBBox(ctx context.Context, name string, bbox models.BoundingBox, limit int) (*LayerFeatures, error) {
+	ctx, span := tracing.Start(ctx, "LayerService.GetFeaturesInBBox")
+	defer span.End()
+
+	if bbox.MinLat < MinLatitude || bbox.MaxLat > MaxLatitude || bbox.MinLng < MinLongitude || bbox.MaxLng > MaxLongitude {
+		return nil, fmt.Errorf("%w: bbox must be within WGS84 bounds", ErrInvalidLayerQuery)
+	}
+	if bbox.MinLat >= bbox.MaxLat || bbox.MinLng >= bbox.MaxLng {
+		return nil, fmt.Errorf("%w: bbox min values must be less than max values", ErrInvalidLayerQuery)
+	}
+	if limit == 0 {
+		limit = DefaultLayerBBoxLimit
+	}
+	if limit < 0 || limit > MaxLayerBBoxLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidLayerQuery, MaxLayerBBoxLimit)
+	}
+
+	layer, err := s.findLayer(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	features, err := s.repo.FindInBBox(ctx, layer.ID, bbox, limit+1)
+	if err != nil {
+		s.log.Error("Failed to query layer in bbox", err, map[string]interface{}{
+			"layer": name,
+		})
+		return nil, fmt.Errorf("failed to query layer features: %w", err)
+	}
+
+	result := &LayerFeatures{Layer: layer, Features: features}
+	if len(features) > limit {
+		result.Features = features[:limit]
+		result.Truncated = true
+	}
+	return result, nil
+}
+
+// GetOverlayAtPoint validates the point and looks up the overlay's features.
+func (s *layerService) GetOverlayAtPoint(ctx context.Context, overlay repository.Overlay, point models.LatLng, filter map[string]any) ([]models.LayerFeature, error) {
+	ctx, span := tracing.Start(ctx, "LayerService.GetOverlayAtPoint")
+	defer span.End()
+
+	if err := point.Validate(); err != nil {
+		s.log.Warn("Invalid coordinates provided", map[string]interface{}{
+			"lat": point.Lat,
+			"lng": point.Lng,
+		})
+		return nil, err
+	}
+
+	features, err := s.repo.FindOverlayAtPoint(ctx, overlay, point, filter)
+	if err != nil {
+		s.log.Error("Failed to query overlay at point", err, map[string]interface{}{
+			"layer": overlay.Layer,
+			"lat":   point.Lat,
+			"lng":   point.Lng,
+		})
+		return nil, fmt.Errorf("failed to query %s: %w", overlay.Layer, err)
+	}
+
+	return features, nil
+}
+
+// GetParcelOverlays looks up the overlay's features of the given parcels.
+func (s *layerService) GetParcelOverlays(ctx context.Context, overlay repository.Overlay, ids []uint) (map[uint][]repository.ParcelOverlay, error) {
+	ctx, span := tracing.Start(ctx, "LayerService.GetParcelOverlays")
+	defer span.End()
+
+	if len(ids) == 0 {
+		return map[uint][]repository.ParcelOverlay{}, nil
+	}
+
+	matches, err := s.repo.FindOverlayForParcels(ctx, overlay, ids)
+	if err != nil {
+		s.log.Error("Failed to find parcel overlays", err, map[string]interface{}{
+			"layer": overlay.Layer,
+			"count": len(ids),
+		})
+		return nil, fmt.Errorf("failed to find parcel %s: %w", overlay.Layer, err)
+	}
+
+	return matches, nil
+}
+
+// findLayer looks up the named layer, ErrLayerNotFound if it is not registered.
+func (s *layerService) findLayer(ctx context.Context, name string) (*models.Layer, error) {
+	layer, err := s.repo.FindByName(ctx, name)
+	if err != nil {
+		s.log.Error("Failed to find layer", err, map[string]interface{}{
+			"layer": name,
+		})
+		return nil, fmt.Errorf("failed to find layer: %w", err)
+	}
+	if layer == nil {
+		return nil, ErrLayerNotFound
+	}
+	return layer, nil
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// MockLayerRepository is a mock implementation of LayerRepository for testing
+type MockLayerRepository struct {
+	mock.Mock
+}
+
+func (m *MockLayerRepository) List(ctx context.Context) ([]models.Layer, error) {
+	args := m.Called(ctx)
+	layers, _ := args.Get(0).([]models.Layer)
+	return layers, args.Error(1)
+}
+
+func (m *MockLayerRepository) FindByName(ctx context.Context, name string) (*models.Layer, error) {
+	args := m.Called(ctx, name)
+	layer, _ := args.Get(0).(*models.Layer)
+	return layer, args.Error(1)
+}
+
+func (m *MockLayerRepository) FindAtPoint(ctx context.Context, layerID uint, point models.LatLng, radiusMeters float64) ([]models.LayerFeature, error) {
+	args := m.Called(ctx, layerID, point, radiusMeters)
+	features, _ := args.Get(0).([]models.LayerFeature)
+	return features, args.Error(1)
+}
+
+func (m *MockLayerRepository) FindInBBox(ctx context.Context, layerID uint, bbox models.BoundingBox, limit int) ([]models.LayerFeature, error) {
+	args := m.Called(ctx, layerID, bbox, limit)
+	features, _ := args.Get(0).([]models.LayerFeature)
+	return features, args.Error(1)
+}
+
+func (m *MockLayerRepository) FindOverlayAtPoint(ctx context.Context, overlay repository.Overlay, point models.LatLng, filter map[string]any) ([]models.LayerFeature, error) {
+	args := m.Called(ctx, overlay, point, filter)
+	features, _ := args.Get(0).([]models.LayerFeature)
+	return features, args.Error(1)
+}
+
+func (m *MockLayerRepository) FindOverlayForParcels(ctx context.Context, overlay repository.Overlay, ids []uint) (map[uint][]repository.ParcelOverlay, error) {
+	args := m.Called(ctx, overlay, ids)
+	matches, _ := args.Get(0).(map[uint][]repository.ParcelOverlay)
+	return matches, args.Error(1)
+}
+
+var testLayerBBox = models.BoundingBox{MinLng: -95.6, MinLat: 30.2, MaxLng: -95.4, MaxLat: 30.4}
+
+func TestGetFeaturesAtPoint_Success(t *testing.T) {
+	mockRepo := new(MockLayerRepository)
+	service := NewLayerService(mockRepo, logger.New("test"))
+
+	ctx := context.Background()
+	point := models.LatLng{Lat: 30.35, Lng: -95.45}
+	layer := &models.Layer{ID: 3, Name: "wetlands", Title: "Wetlands"}
+	features := []models.LayerFeature{{ID: 11, Properties: map[string]any{"WETLAND_TYPE": "Freshwater Pond"}, CountyName: "Montgomery"}}
+	mockRepo.On("FindByName", ctx, "wetlands").Return(layer, nil)
+	mockRepo.On("FindAtPoint", ctx, uint(3), point, 50.0).Return(features, nil)
+
+	result, err := service.GetFeaturesAtPoint(ctx, "wetlands", point, 50)
+
+	require.NoError(t, err)
+	assert.Equal(t, &LayerFeatures{Layer: layer, Features: features}, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetFeaturesAtPoint_LayerNotFound(t *testing.T) {
+	mockRepo := new(MockLayerRepository)
+	service := NewLayerService(mockRepo, logger.New("test"))
+
+	mockRepo.On("FindByName", mock.Anything, "easements").Return(nil, nil)
+
+	_, err := service.GetFeaturesAtPoint(context.Background(), "easements", models.LatLng{Lat: 30.35, Lng: -95.45}, 0)
+
+	assert.ErrorIs(t, err, ErrLayerNotFound)
+	mockRepo.AssertNotCalled(t, "FindAtPoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetFeaturesAtPoint_InvalidQuery(t *testing.T) {
+	testCases := []struct {
+		err    error
+		name   string
+		point  models.LatLng
+		radius float64
+	}{
+		{models.ErrInvalidCoordinates, "latitude out of range", models.LatLng{Lat: 91, Lng: -95.45}, 0},
+		{ErrInvalidLayerQuery, "negative radius", models.LatLng{Lat: 30.35, Lng: -95.45}, -1},
+		{ErrInvalidLayerQuery, "radius too large", models.LatLng{Lat: 30.35, Lng: -95.45}, MaxLayerRadiusMeters + 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockLayerRepository)
+			service := NewLayerService(mockRepo, logger.New("test"))
+
+			_, err := service.GetFeaturesAtPoint(context.Background(), "wetlands", tc.point, tc.radius)
+
+			assert.ErrorIs(t, err, tc.err)
+			mockRepo.AssertNotCalled(t, "FindByName", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGetFeaturesAtPoint_RepositoryError(t *testing.T) {
+	mockRepo := new(MockLayerRepository)
+	service := NewLayerService(mockRepo, logger.New("test"))
+
+	dbError := errors.New("connection refused")
+	mockRepo.On("FindByName", mock.Anything, "wetlands").Return(&models.Layer{ID: 3}, nil)
+	mockRepo.On("FindAtPoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, dbError)
+
+	_, err := service.GetFeaturesAtPoint(context.Background(), "wetlands", models.LatLng{Lat: 30.35, Lng: -95.45}, 0)
+
+	assert.ErrorIs(t, err, dbError)
+}
+
+func TestGetFeaturesInBBox_Truncated(t *testing.T) {
+	mockRepo := new(MockLayerRepository)
+	service := NewLayerService(mockRepo, logger.New("test"))
+
+	ctx := context.Background()
+	layer := &models.Layer{ID: 3, Name: "wetlands"}
+	features := []models.LayerFeature{{ID: 11}, {ID: 12}, {ID: 13}}
+	mockRepo.On("FindByName", ctx, "wetlands").Return(layer, nil)
+	mockRepo.On("FindInBBox", ctx, uint(3), testLayerBBox, 3).Return(features, nil)
+
+	result, err := service.GetFeaturesInBBox(ctx, "wetlands", testLayerBBox, 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, &LayerFeatures{Layer: layer, Features: features[:2], Truncated: true}, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetFeaturesInBBox_DefaultLimit(t *testing.T) {
+	mockRepo := new(MockLayerRepository)
+	service := NewLayerService(mockRepo, logger.New("test"))
+
+	features := []models.LayerFeature{{ID: 11}}
+	mockRepo.On("FindByName", mock.Anything, "wetlands").Return(&models.Layer{ID: 3}, nil)
+	mockRepo.On("FindInBBox", mock.Anything, uint(3), testLayerBBox, DefaultLayerBBoxLimit+1).Return(features, nil)
+
+	result, err := service.GetFeaturesInBBox(context.Background(), "wetlands", testLayerBBox, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, features, result.Features)
+	assert.False(t, result.Truncated)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetFeaturesInBBox_InvalidQuery(t *testing.T) {
+	testCases := []struct {
+		name  string
+		bbox  models.BoundingBox
+		limit int
+	}{
+		{"inverted bbox", models.BoundingBox{MinLng: -95.4, MinLat: 30.2, MaxLng: -95.6, MaxLat: 30.4}, 0},
+		{"bbox out of range", models.BoundingBox{MinLng: -95.6, MinLat: 30.2, MaxLng: -95.4, MaxLat: 91}, 0},
+		{"negative limit", testLayerBBox, -1},
+		{"limit too large", testLayerBBox, MaxLayerBBoxLimit + 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockLayerRepository)
+			service := NewLayerService(mockRepo, logger.New("test"))
+
+			_, err := service.GetFeaturesInBBox(context.Background(), "wetlands", tc.bbox, tc.limit)
+
+			assert.ErrorIs(t, err, ErrInvalidLayerQuery)
+			mockRepo.AssertNotCalled(t, "FindByName", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGetOverlayAtPoint_Success(t *testing.T) {
+	mockRepo := new(MockLayerRepository)
+	service := NewLayerService(mockRepo, logger.New("test"))
+
+	ctx := context.Background()
+	point := models.LatLng{Lat: 30.35, Lng: -95.45}
+	filter := map[string]any{"kind": models.JurisdictionTaxingUnit}
+	features := []models.LayerFeature{{ID: 9, Properties: map[string]any{"kind": "taxing_unit", "name": "Montgomery County"}, CountyName: "Montgomery"}}
+	mockRepo.On("FindOverlayAtPoint", ctx, repository.JurisdictionOverlay, point, filter).Return(features, nil)
+
+	result, err := service.GetOverlayAtPoint(ctx, repository.JurisdictionOverlay, point, filter)
+
+	require.NoError(t, err)
+	assert.Equal(t, features, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetOverlayAtPoint_InvalidCoordinates(t *testing.T) {
+	mockRepo := new(MockLayerRepository)
+	service := NewLayerService(mockRepo, logger.New("test"))
+
+	_, err := service.GetOverlayAtPoint(context.Background(), repository.ZoningOverlay, models.LatLng{Lat: 91, Lng: -95.45}, nil)
+
+	assert.ErrorIs(t, err, models.ErrInvalidCoordinates)
+	mockRepo.AssertNotCalled(t, "FindOverlayAtPoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetOverlayAtPoint_RepositoryError(t *testing.T) {
+	mockRepo := new(MockLayerRepository)
+	service := NewLayerService(mockRepo, logger.New("test"))
+
+	dbError := errors.New("connection refused")
+	mockRepo.On("FindOverlayAtPoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, dbError)
+
+	_, err := service.GetOverlayAtPoint(context.Background(), repository.FloodZoneOverlay, models.LatLng{Lat: 30.35, Lng: -95.45}, nil)
+
+	assert.ErrorIs(t, err, dbError)
+}
+
+func TestGetParcelOverlays_Success(t *testing.T) {
+	mockRepo := new(MockLayerRepository)
+	service := NewLayerService(mockRepo, logger.New("test"))
+
+	ctx := context.Background()
+	ids := []uint{1, 2}
+	matches := map[uint][]repository.ParcelOverlay{
+		1: {
+			{Feature: models.LayerFeature{ID: 7, Properties: map[string]any{"code": "R-1"}}, Coverage: 0.75},
+			{Feature: models.LayerFeature{ID: 8, Properties: map[string]any{"code": "C-2"}}, Coverage: 0.25},
+		},
+	}
+	mockRepo.On("FindOverlayForParcels", ctx, repository.ZoningOverlay, ids).Return(matches, nil)
+
+	result, err := service.GetParcelOverlays(ctx, repository.ZoningOverlay, ids)
+
+	require.NoError(t, err)
+	assert.Equal(t, matches, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetParcelOverlays_NoParcels(t *testing.T) {
+	mockRepo := new(MockLayerRepository)
+	service := NewLayerService(mockRepo, logger.New("test"))
+
+	result, err := service.GetParcelOverlays(context.Background(), repository.ZoningOverlay, nil)
+
+	require.NoError(t, err)
+	assert.Empty(t, result)
+	mockRepo.AssertNotCalled(t, "FindOverlayForParcels", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetParcelOverlays_RepositoryError(t *testing.T) {
+	mockRepo := new(MockLayerRepository)
+	service := NewLayerService(mockRepo, logger.New("test"))
+
+	dbError := errors.New("connection refused")
+	mockRepo.On("FindOverlayForParcels", mock.Anything, repository.JurisdictionOverlay, []uint{1}).Return(nil, dbError)
+
+	_, err := service.GetParcelOverlays(context.Background(), repository.JurisdictionOverlay, []uint{1})
+
+	assert.ErrorIs(t, err, dbError)
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	"github.com/stwalsh4118/atlas/api/internal/location"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// Location service errors
+var (
+	ErrInvalidLocationCode = domain.Validation("invalid location code")
+	ErrWhat3WordsDisabled  = domain.Validation("what3words addresses are not supported by this server")
+)
+
+// LocationService defines the interface for resolving location references that are not
+// raw coordinates.
+type LocationService interface {
+	// Resolve returns the point for a plus code or a what3words address; exactly one must be set.
+	// Plus codes are decoded locally; what3words addresses are looked up with the external client.
+	// Returns ErrInvalidLocationCode if the reference is malformed, unknown, or both/neither are set.
+	// Returns ErrWhat3WordsDisabled if a what3words address is given without a configured client.
+	// Returns error for what3words service failures.
+	Resolve(ctx context.Context, plusCode, what3words string) (models.LatLng, error)
+}
+
+// locationService is the concrete implementation of LocationService.
+type locationService struct {
+	what3words location.What3WordsClient
+	log        *logger.Logger
+}
+
+// NewLocationService creates a new instance of LocationService.
+// what3words may be nil, in which case only plus codes are supported.
+func NewLocationService(what3words location.What3WordsClient, log *logger.Logger) LocationService {
+	return &locationService{
+		what3words: what3words,
+		log:        log,
+	}
+}
+
+// Resolve decodes plus codes without I/O, so only what3words lookups are logged.
+func (s *locationService) Resolve(ctx context.Context, plusCode, what3words string) (models.LatLng, error) {
+	if (plusCode == "") == (what3words == "") {
+		return models.LatLng{}, fmt.Errorf("%w: provide exactly one of plus_code or w3w", ErrInvalidLocationCode)
+	}
+
+	if plusCode != "" {
+		point, err := location.DecodePlusCode(plusCode)
+		if err != nil {
+			return models.LatLng{}, fmt.Errorf("%w: %w", ErrInvalidLocationCode, err)
+		}
+		return point, nil
+	}
+
+	words, err := location.NormalizeWhat3Words(what3words)
+	if err != nil {
+		return models.LatLng{}, fmt.Errorf("%w: %w", ErrInvalidLocationCode, err)
+	}
+	if s.what3words == nil {
+		return models.LatLng{}, ErrWhat3WordsDisabled
+	}
+
+	point, err := s.what3words.ConvertToCoordinates(ctx, words)
+	if errors.Is(err, location.ErrUnknownWhat3Words) {
+		s.log.Debug("Unknown what3words address", map[string]interface{}{
+			"words": words,
+		})
+		return models.LatLng{}, fmt.Errorf("%w: %w", ErrInvalidLocationCode, err)
+	}
+	if err != nil {
+		s.log.Error("Failed to resolve what3words address", err, map[string]interface{}{
+			"words": words,
+		})
+		return models.LatLng{}, fmt.Errorf("failed to resolve what3words address: %w", err)
+	}
+
+	s.log.Info("Resolved what3words address", map[string]interface{}{
+		"words": words,
+		"lat":   point.Lat,
+		"lng":   point.Lng,
+	})
+
+	return point, nil
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/location"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// MockWhat3WordsClient is a mock implementation of location.What3WordsClient for testing
+type MockWhat3WordsClient struct {
+	mock.Mock
+}
+
+func (m *MockWhat3WordsClient) ConvertToCoordinates(ctx context.Context, words string) (models.LatLng, error) {
+	args := m.Called(ctx, words)
+	return args.Get(0).(models.LatLng), args.Error(1)
+}
+
+func TestResolve_PlusCode(t *testing.T) {
+	client := new(MockWhat3WordsClient)
+	service := NewLocationService(client, logger.New("test"))
+
+	point, err := service.Resolve(context.Background(), "8FVC9G8F+6X", "")
+
+	require.NoError(t, err)
+	assert.InDelta(t, 47.3655625, point.Lat, 1e-9)
+	assert.InDelta(t, 8.5249375, point.Lng, 1e-9)
+	client.AssertNotCalled(t, "ConvertToCoordinates", mock.Anything, mock.Anything)
+}
+
+func TestResolve_What3Words(t *testing.T) {
+	client := new(MockWhat3WordsClient)
+	service := NewLocationService(client, logger.New("test"))
+
+	expected := models.LatLng{Lat: 51.520847, Lng: -0.195543}
+	client.On("ConvertToCoordinates", mock.Anything, "filled.count.soap").Return(expected, nil)
+
+	point, err := service.Resolve(context.Background(), "", "///Filled.Count.Soap")
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, point)
+}
+
+func TestResolve_InvalidReferences(t *testing.T) {
+	client := new(MockWhat3WordsClient)
+	client.On("ConvertToCoordinates", mock.Anything, "index.home.rafts").
+		Return(models.LatLng{}, location.ErrUnknownWhat3Words)
+	service := NewLocationService(client, logger.New("test"))
+
+	tests := []struct {
+		name       string
+		plusCode   string
+		what3words string
+	}{
+		{"neither", "", ""},
+		{"both", "8FVC9G8F+6X", "filled.count.soap"},
+		{"invalid plus code", "8FVC9G8F", ""},
+		{"short plus code", "9G8F+6X", ""},
+		{"malformed what3words", "", "filled.count"},
+		{"unknown what3words", "", "index.home.rafts"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.Resolve(context.Background(), tt.plusCode, tt.what3words)
+			assert.ErrorIs(t, err, ErrInvalidLocationCode)
+		})
+	}
+}
+
+func TestResolve_What3WordsDisabled(t *testing.T) {
+	service := NewLocationService(nil, logger.New("test"))
+
+	_, err := service.Resolve(context.Background(), "", "filled.count.soap")
+
+	assert.ErrorIs(t, err, ErrWhat3WordsDisabled)
+}
+
+func TestResolve_What3WordsServiceError(t *testing.T) {
+	client := new(MockWhat3WordsClient)
+	service := NewLocationService(client, logger.New("test"))
+
+	upstreamError := errors.New("what3words request failed: timeout")
+	client.On("ConvertToCoordinates", mock.Anything, "filled.count.soap").Return(models.LatLng{}, upstreamError)
+
+	_, err := service.Resolve(context.Background(), "", "filled.count.soap")
+
+	assert.ErrorIs(t, err, upstreamError)
+	assert.NotErrorIs(t, err, ErrInvalidLocationCode)
+}
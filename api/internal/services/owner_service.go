@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/pagination"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"github.com/stwalsh4118/atlas/api/internal/tracing"
+)
+
+// Owner portfolio page sizes
+const (
+	// DefaultOwnerParcelsLimit is how many parcels a portfolio page holds by default
+	DefaultOwnerParcelsLimit = 100
+	// MaxOwnerParcelsLimit is the most parcels a portfolio page may hold
+	MaxOwnerParcelsLimit = 1000
+)
+
+// ErrOwnerNotFound is returned when no owner has the requested id.
+var ErrOwnerNotFound = domain.NotFound("owner not found")
+
+// OwnerParcelsPage is a page of the parcels held by an owner under any
+// spelling of its name. NextCursor is empty on the last page; TotalCount
+// covers all pages.
+type OwnerParcelsPage struct {
+	Owner      *models.Owner
+	NextCursor string
+	Parcels    []models.TaxParcel
+	TotalCount int
+}
+
+// OwnerService defines the interface for owner entity operations.
+type OwnerService interface {
+	// GetOwnerParcels returns the owner and a page of up to limit of its live
+	// parcels, ordered by ID, after the cursor ("" for the first page).
+	// Returns ErrInvalidLimit, pagination.ErrInvalidCursor or ErrOwnerNotFound.
+	GetOwnerParcels(ctx context.Context, ownerID uint, limit int, cursor string) (*OwnerParcelsPage, error)
+}
+
+// ownerService is the concrete implementation of OwnerService.
+type ownerService struct {
+	repo    repository.OwnerRepository
+	parcels repository.ParcelRepository
+	log     *logger.Logger
+}
+
+// NewOwnerService creates a new instance of OwnerService.
+func NewOwnerService(repo repository.OwnerRepository, parcels repository.ParcelRepository, log *logger.Logger) OwnerService {
+	return &ownerService{
+		repo:    repo,
+		parcels: parcels,
+		log:     log,
+	}
+}
+
+// GetOwnerParcels reads one parcel more than limit to know whether another page exists.
+func (s *ownerService) GetOwnerParcels(ctx context.Context, ownerID uint, limit int, cursor string) (*OwnerParcelsPage, error) {
+	ctx, span := tracing.Start(ctx, "OwnerService.GetOwnerParcels")
+	defer span.End()
+
+	if limit < 1 || limit > MaxOwnerParcelsLimit {
+		return nil, fmt.Errorf("%w: must be between 1 and %d, got %d", ErrInvalidLimit, MaxOwnerParcelsLimit, limit)
+	}
+	after, err := pagination.Decode(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := s.repo.FindByID(ctx, ownerID)
+	if err != nil {
+		s.log.Error("Failed to find owner", err, map[string]interface{}{
+			"owner_id": ownerID,
+		})
+		return nil, fmt.Errorf("failed to find owner: %w", err)
+	}
+	if owner == nil {
+		return nil, fmt.Errorf("%w: id %d", ErrOwnerNotFound, ownerID)
+	}
+
+	parcels, total, err := s.parcels.FindByOwner(ctx, ownerID, limit+1, after)
+	if err != nil {
+		s.log.Error("Failed to find owner parcels", err, map[string]interface{}{
+			"owner_id": ownerID,
+		})
+		return nil, fmt.Errorf("failed to find owner parcels: %w", err)
+	}
+
+	page := &OwnerParcelsPage{Owner: owner, Parcels: parcels, TotalCount: total}
+	if len(parcels) > limit {
+		page.Parcels = parcels[:limit]
+		page.NextCursor = pagination.Cursor{ID: page.Parcels[limit-1].ID}.Encode()
+	}
+
+	return page, nil
+}
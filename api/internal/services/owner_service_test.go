@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/pagination"
+)
+
+// MockOwnerRepository is a mock implementation of OwnerRepository for testing
+type MockOwnerRepository struct {
+	mock.Mock
+}
+
+func (m *MockOwnerRepository) FindByID(ctx context.Context, id uint) (*models.Owner, error) {
+	args := m.Called(ctx, id)
+	owner, _ := args.Get(0).(*models.Owner)
+	return owner, args.Error(1)
+}
+
+func TestGetOwnerParcels_Pages(t *testing.T) {
+	owners, parcels := new(MockOwnerRepository), new(MockParcelRepository)
+	service := NewOwnerService(owners, parcels, logger.New("test"))
+
+	ctx := context.Background()
+	owner := &models.Owner{ID: 7, Name: "LONE STAR HOLDINGS LLC", Names: []string{"LONE STAR HOLDINGS L L C", "LONE STAR HOLDINGS LLC"}}
+	owners.On("FindByID", ctx, uint(7)).Return(owner, nil)
+	parcels.On("FindByOwner", ctx, uint(7), 3, (*pagination.Cursor)(nil)).
+		Return([]models.TaxParcel{{ID: 4}, {ID: 9}, {ID: 12}}, 5, nil)
+
+	page, err := service.GetOwnerParcels(ctx, 7, 2, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, owner, page.Owner)
+	assert.Equal(t, []models.TaxParcel{{ID: 4}, {ID: 9}}, page.Parcels)
+	assert.Equal(t, 5, page.TotalCount)
+
+	after, err := pagination.Decode(page.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, uint(9), after.ID)
+
+	parcels.On("FindByOwner", ctx, uint(7), 3, after).Return([]models.TaxParcel{{ID: 12}}, 5, nil)
+
+	page, err = service.GetOwnerParcels(ctx, 7, 2, page.NextCursor)
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.TaxParcel{{ID: 12}}, page.Parcels)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestGetOwnerParcels_Errors(t *testing.T) {
+	dbError := errors.New("connection refused")
+
+	tests := []struct {
+		owner *models.Owner
+		err   error
+		want  error
+		name  string
+		limit int
+	}{
+		{name: "limit too small", limit: 0, want: ErrInvalidLimit},
+		{name: "limit too large", limit: MaxOwnerParcelsLimit + 1, want: ErrInvalidLimit},
+		{name: "owner not found", limit: 10, want: ErrOwnerNotFound},
+		{name: "database error", limit: 10, err: dbError, want: dbError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owners, parcels := new(MockOwnerRepository), new(MockParcelRepository)
+			service := NewOwnerService(owners, parcels, logger.New("test"))
+			owners.On("FindByID", mock.Anything, uint(7)).Return(tt.owner, tt.err)
+
+			_, err := service.GetOwnerParcels(context.Background(), 7, tt.limit, "")
+
+			assert.ErrorIs(t, err, tt.want)
+			parcels.AssertNotCalled(t, "FindByOwner", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGetOwnerParcels_InvalidCursor(t *testing.T) {
+	service := NewOwnerService(new(MockOwnerRepository), new(MockParcelRepository), logger.New("test"))
+
+	_, err := service.GetOwnerParcels(context.Background(), 7, 10, "not a cursor!")
+
+	assert.ErrorIs(t, err, pagination.ErrInvalidCursor)
+}
@@ -2,33 +2,113 @@ package services
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/domain"
 	"github.com/stwalsh4118/atlas/api/internal/logger"
 	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/pagination"
 	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"github.com/stwalsh4118/atlas/api/internal/tracing"
 )
 
-// Coordinate validation constants
+// Coordinate validation constants, kept as aliases of the models ranges
 const (
-	MinLatitude  = -90.0
-	MaxLatitude  = 90.0
-	MinLongitude = -180.0
-	MaxLongitude = 180.0
+	MinLatitude  = models.MinLatitude
+	MaxLatitude  = models.MaxLatitude
+	MinLongitude = models.MinLongitude
+	MaxLongitude = models.MaxLongitude
 )
 
 // Radius validation constants
 const (
 	MinRadiusMeters = 1
 	MaxRadiusMeters = 5000
+	// DefaultRadiusMeters is used when a request gives no radius and its
+	// county's profile sets no default
+	DefaultRadiusMeters = 1000
+)
+
+// Nearby pagination constants
+const (
+	MinNearbyLimit = 1
+	MaxNearbyLimit = 100
+)
+
+// Nearby filter validation constants
+const (
+	MaxOwnerNameFilterLength = 200
+	MaxAmenityWithinMeters   = 50000
+	MaxAttributeFilters      = 10
+)
+
+// GPS accuracy constants
+const (
+	MaxAccuracyMeters   = 100
+	MaxParcelCandidates = 10
+	// certainProbability is the containment probability above which a single
+	// candidate is treated as an exact match (allows for area rounding).
+	certainProbability = 0.999
+)
+
+// Address search validation constants
+const (
+	MinAddressQueryLength = 3
+	MaxAddressQueryLength = 200
+	MinSearchLimit        = 1
+	MaxSearchLimit        = 50
+)
+
+// Parcel comparison constants
+const (
+	MinCompareParcels = 2
+	MaxCompareParcels = 5
+)
+
+// Parcel dissolve constants
+const (
+	MinDissolveParcels = 1
+	MaxDissolveParcels = 500
+)
+
+// Area intersection constants
+const (
+	MinIntersectLimit = 1
+	MaxIntersectLimit = 500
+	MaxAreaVertices   = 10000
+	minRingPositions  = 4 // GeoJSON linear rings are closed with at least 4 positions
+)
+
+// Route corridor constants; limits are shared with area intersection
+const (
+	MinRouteBufferMeters = 1
+	MaxRouteBufferMeters = 1000
+	MaxRouteVertices     = 10000
+)
+
+// Parcel sample constants
+const (
+	MinSampleSize = 1
+	MaxSampleSize = 500
 )
 
 // Service-level errors
 var (
-	ErrInvalidCoordinates = errors.New("invalid coordinates")
-	ErrParcelNotFound     = errors.New("parcel not found")
-	ErrInvalidRadius      = errors.New("radius must be between 1 and 5000 meters")
+	ErrInvalidCoordinates  = models.ErrInvalidCoordinates
+	ErrParcelNotFound      = domain.NotFound("parcel not found")
+	ErrInvalidRadius       = domain.Validation("radius must be between 1 and 5000 meters")
+	ErrInvalidAddressQuery = domain.Validation("address query must be between 3 and 200 characters")
+	ErrInvalidLimit        = domain.Validation("invalid limit")
+	ErrInvalidCompareIDs   = domain.Validation("compare requires between 2 and 5 distinct parcel ids")
+	ErrInvalidDissolveIDs  = domain.Validation("dissolve requires between 1 and 500 distinct parcel ids")
+	ErrInvalidGeometry     = domain.Validation("invalid geometry")
+	ErrInvalidFilter       = domain.Validation("invalid filter")
+	ErrInvalidAccuracy     = domain.Validation("accuracy must be between 0 and 100 meters")
+	ErrInvalidBuffer       = domain.Validation("buffer must be between 1 and 1000 meters")
 )
 
 // ParcelService defines the interface for parcel business logic operations.
@@ -37,66 +117,204 @@ type ParcelService interface {
 	// Returns ErrInvalidCoordinates if coordinates are out of valid range.
 	// Returns ErrParcelNotFound if no parcel exists at the point.
 	// Returns error for database failures.
-	GetParcelAtPoint(ctx context.Context, lat, lng float64) (*models.TaxParcel, error)
+	GetParcelAtPoint(ctx context.Context, point models.LatLng) (*models.TaxParcel, error)
+
+	// GetParcel retrieves the live parcel with the given ID.
+	// Returns ErrParcelNotFound if no live parcel has the id.
+	// Returns error for database failures or a corrupt geometry.
+	GetParcel(ctx context.Context, id uint) (*models.TaxParcel, error)
+
+	// GetParcelCandidatesAtPoint retrieves the parcels a point reported with the given GPS
+	// accuracy (meters) may fall in. Candidates is empty when the accuracy circle lies
+	// entirely inside one parcel; otherwise it lists up to 10 parcels by probability.
+	// Returns ErrInvalidCoordinates if coordinates are out of valid range.
+	// Returns ErrInvalidAccuracy if accuracy is not greater than 0 and at most 100 meters.
+	// Returns ErrParcelNotFound if no parcel intersects the accuracy circle.
+	// Returns error for database failures.
+	GetParcelCandidatesAtPoint(ctx context.Context, point models.LatLng, accuracyMeters float64) (*PointCandidates, error)
+
+	// GetNearbyParcels retrieves one page of parcels within the specified radius of the given point.
+	// An empty cursor requests the first page; NextCursor on the result requests the following one.
+	// Callers without a radius pass DefaultNearbyRadius.
+	// Returns ErrInvalidCoordinates if coordinates are out of valid range.
+	// Returns ErrInvalidRadius if radius is not between 1 and 5000 meters, or above the
+	// maximum radius of the county profile.
+	// Returns ErrInvalidLimit if limit is not between 1 and 100.
+	// Returns ErrInvalidFilter if a filter range is negative or inverted, owner_name is too long,
+	// or the amenity filter has an unknown category, lacks its distance or exceeds MaxAmenityWithinMeters,
+	// or an attribute filter names an unknown derived attribute or has a value of the wrong type,
+	// or a filter uses an attribute the county profile marks as not provided.
+	// Returns pagination.ErrInvalidCursor if the cursor cannot be decoded.
+	// Returns an empty page if no parcels found (not an error).
+	// Returns error for database failures.
+	GetNearbyParcels(ctx context.Context, point models.LatLng, radiusMeters, limit int, cursor string, filter repository.NearbyFilter) (*NearbyPage, error)
 
-	// GetNearbyParcels retrieves all parcels within the specified radius of the given point.
+	// DefaultNearbyRadius returns the radius of a nearby request that gives none: the
+	// default radius of the county profile, or DefaultRadiusMeters.
+	// Returns error for database failures.
+	DefaultNearbyRadius(ctx context.Context) (int, error)
+
+	// SearchByAddress retrieves one page of parcels whose situs address fuzzily matches the given text.
+	// An empty cursor requests the first page; NextCursor on the result requests the following one.
+	// Returns ErrInvalidAddressQuery if the trimmed address is not between 3 and 200 characters.
+	// Returns ErrInvalidLimit if limit is not between 1 and 50.
+	// Returns pagination.ErrInvalidCursor if the cursor cannot be decoded.
+	// Returns an empty page if no parcels match (not an error).
+	// Returns error for database failures.
+	SearchByAddress(ctx context.Context, address string, limit int, cursor string) (*AddressSearchPage, error)
+
+	// IdentifyParcelAtPoint retrieves a geometry-free summary of the parcel at the given point.
 	// Returns ErrInvalidCoordinates if coordinates are out of valid range.
-	// Returns ErrInvalidRadius if radius is not between 1 and 5000 meters.
-	// Returns empty slice if no parcels found (not an error).
+	// Returns ErrParcelNotFound if no parcel exists at the point.
+	// Returns error for database failures.
+	IdentifyParcelAtPoint(ctx context.Context, point models.LatLng) (*repository.ParcelSummary, error)
+
+	// CompareParcels retrieves the given parcels and computes attribute differences between them.
+	// Returns ErrInvalidCompareIDs if ids are not 2 to 5 distinct values.
+	// Parcels that are missing or cannot be decoded are reported in Failed
+	// instead of failing the comparison.
+	// Returns ErrParcelNotFound if any of the ids does not exist.
+	// Returns error for database failures.
+	CompareParcels(ctx context.Context, ids []uint) (*ParcelComparison, error)
+
+	// DissolveParcels merges the given parcels into their outer boundary, e.g. the
+	// outline of a multi-parcel assemblage.
+	// Returns ErrInvalidDissolveIDs if ids are not 1 to 500 distinct values.
+	// Returns ErrParcelNotFound if any of the ids does not exist.
+	// Returns error for database failures.
+	DissolveParcels(ctx context.Context, ids []uint) (*repository.DissolvedArea, error)
+
+	// FindParcelsInArea retrieves up to limit parcels intersecting the given area.
+	// The boolean result reports whether more parcels intersect than were returned.
+	// Returns ErrInvalidGeometry if the area is malformed, out of range, too complex or not valid per PostGIS.
+	// Returns ErrInvalidLimit if limit is not between 1 and 500.
+	// Returns empty slice if no parcels intersect (not an error).
 	// Returns error for database failures.
-	GetNearbyParcels(ctx context.Context, lat, lng float64, radiusMeters int) ([]repository.ParcelWithDistance, error)
+	FindParcelsInArea(ctx context.Context, area *models.MultiPolygon, limit int) ([]models.TaxParcel, bool, error)
+
+	// FindParcelsAlongRoute retrieves up to limit parcels within bufferMeters of the route,
+	// ordered by station from the start of the route.
+	// The boolean result reports whether more parcels are in the corridor than were returned.
+	// Returns ErrInvalidGeometry if the route has fewer than 2 distinct positions, is out of range or too complex.
+	// Returns ErrInvalidBuffer if the buffer is not between 1 and 1000 meters.
+	// Returns ErrInvalidLimit if limit is not between 1 and 500.
+	// Returns empty slice if no parcels are in the corridor (not an error).
+	// Returns error for database failures.
+	FindParcelsAlongRoute(ctx context.Context, route *models.LineString, bufferMeters float64, limit int) ([]repository.ParcelAlongRoute, bool, error)
+
+	// GetNearestAmenities retrieves the nearest amenity of each category for each of the
+	// given parcels, keyed by parcel ID, to enrich parcels already returned by another query.
+	// Returns an empty map if ids is empty or no amenities are loaded (not an error).
+	// Returns error for database failures.
+	GetNearestAmenities(ctx context.Context, ids []uint) (map[uint][]repository.NearestAmenity, error)
+
+	// SampleParcels picks n random parcels, stratified by county and tax year, with
+	// their data quality flags, for QA spot checks of an ingested roll.
+	// Returns ErrInvalidLimit if n is not between 1 and 500.
+	// Returns fewer than n parcels only when fewer exist (not an error).
+	// Returns error for database failures.
+	SampleParcels(ctx context.Context, n int) ([]repository.ParcelSample, error)
+}
+
+// PointCandidates is the result of an accuracy-aware point query.
+// Parcel is the parcel containing the point, or the most likely candidate when
+// the point falls outside every parcel. Candidates is empty when the match is certain.
+type PointCandidates struct {
+	Parcel     *models.TaxParcel
+	Candidates []repository.ParcelCandidate
+}
+
+// NearbyPage is one page of nearby parcels ordered by distance.
+// NextCursor is empty on the last page; TotalCount covers all pages.
+type NearbyPage struct {
+	NextCursor string
+	Parcels    []repository.ParcelWithDistance
+	TotalCount int
+}
+
+// AddressSearchPage is one page of address matches ordered by score.
+// NextCursor is empty on the last page; TotalCount covers all pages.
+type AddressSearchPage struct {
+	NextCursor string
+	Matches    []repository.ParcelWithScore
+	TotalCount int
+}
+
+// ParcelComparison holds the compared parcels in request order together with
+// the per-attribute differences between them. Failed are the requested parcels
+// left out, in request order; Differences cover only Parcels.
+type ParcelComparison struct {
+	Parcels     []repository.ParcelWithMetrics
+	Failed      []CompareFailure
+	Differences []AttributeDifference
+}
+
+// CompareFailure is a requested parcel left out of a comparison. Index is its
+// position in the request; Err wraps ErrParcelNotFound when it does not exist,
+// otherwise it is the parcel's decoding error.
+type CompareFailure struct {
+	Err   error
+	ID    uint
+	Index int
+}
+
+// AttributeDifference describes one compared attribute across all parcels.
+// Values has one entry per parcel in request order; nil means the attribute is unset.
+// For numeric attributes, Deltas holds each value minus the first parcel's value
+// (nil when either side is unset); Deltas is nil for text attributes.
+type AttributeDifference struct {
+	Values  []interface{}
+	Deltas  []*float64
+	Field   string
+	Differs bool
 }
 
 // parcelService is the concrete implementation of ParcelService.
 type parcelService struct {
-	repo repository.ParcelRepository
-	log  *logger.Logger
+	repo     repository.ParcelRepository
+	profiles CountyProfiles
+	log      *logger.Logger
 }
 
-// NewParcelService creates a new instance of ParcelService.
-func NewParcelService(repo repository.ParcelRepository, log *logger.Logger) ParcelService {
+// NewParcelService creates a new instance of ParcelService. Queries limited to
+// a county (repository.WithCounty) apply the county's profile from profiles;
+// nil profiles applies the global defaults everywhere.
+func NewParcelService(repo repository.ParcelRepository, profiles CountyProfiles, log *logger.Logger) ParcelService {
 	return &parcelService{
-		repo: repo,
-		log:  log,
+		repo:     repo,
+		profiles: profiles,
+		log:      log,
 	}
 }
 
 // GetParcelAtPoint retrieves the parcel containing the given point.
 // It validates the coordinates, logs the query, and transforms repository
 // responses into appropriate business-level errors.
-func (s *parcelService) GetParcelAtPoint(ctx context.Context, lat, lng float64) (*models.TaxParcel, error) {
-	// Validate latitude range
-	if lat < MinLatitude || lat > MaxLatitude {
-		s.log.Warn("Invalid latitude provided", map[string]interface{}{
-			"lat": lat,
-			"lng": lng,
-		})
-		return nil, fmt.Errorf("%w: latitude must be between %f and %f, got %f",
-			ErrInvalidCoordinates, MinLatitude, MaxLatitude, lat)
-	}
+func (s *parcelService) GetParcelAtPoint(ctx context.Context, point models.LatLng) (*models.TaxParcel, error) {
+	ctx, span := tracing.Start(ctx, "ParcelService.GetParcelAtPoint")
+	defer span.End()
 
-	// Validate longitude range
-	if lng < MinLongitude || lng > MaxLongitude {
-		s.log.Warn("Invalid longitude provided", map[string]interface{}{
-			"lat": lat,
-			"lng": lng,
+	// Validate coordinate ranges
+	if err := point.Validate(); err != nil {
+		s.log.Warn("Invalid coordinates provided", map[string]interface{}{
+			"lat": point.Lat,
+			"lng": point.Lng,
 		})
-		return nil, fmt.Errorf("%w: longitude must be between %f and %f, got %f",
-			ErrInvalidCoordinates, MinLongitude, MaxLongitude, lng)
+		return nil, err
 	}
 
 	// Log the query
 	s.log.Info("Querying parcel at point", map[string]interface{}{
-		"lat": lat,
-		"lng": lng,
+		"lat": point.Lat,
+		"lng": point.Lng,
 	})
 
 	// Query repository
-	parcel, err := s.repo.FindByPoint(ctx, lat, lng)
+	parcel, err := s.repo.FindByPoint(ctx, point)
 	if err != nil {
 		s.log.Error("Failed to query parcel at point", err, map[string]interface{}{
-			"lat": lat,
-			"lng": lng,
+			"lat": point.Lat,
+			"lng": point.Lng,
 		})
 		return nil, fmt.Errorf("failed to query parcel: %w", err)
 	}
@@ -104,16 +322,16 @@ func (s *parcelService) GetParcelAtPoint(ctx context.Context, lat, lng float64)
 	// Repository returns nil, nil when no parcel found - transform to domain error
 	if parcel == nil {
 		s.log.Debug("No parcel found at point", map[string]interface{}{
-			"lat": lat,
-			"lng": lng,
+			"lat": point.Lat,
+			"lng": point.Lng,
 		})
 		return nil, ErrParcelNotFound
 	}
 
 	// Success - log and return parcel
 	s.log.Info("Parcel found at point", map[string]interface{}{
-		"lat":       lat,
-		"lng":       lng,
+		"lat":       point.Lat,
+		"lng":       point.Lng,
 		"parcel_id": parcel.ID,
 		"owner":     parcel.OwnerName,
 	})
@@ -121,54 +339,211 @@ func (s *parcelService) GetParcelAtPoint(ctx context.Context, lat, lng float64)
 	return parcel, nil
 }
 
-// GetNearbyParcels retrieves all parcels within the specified radius of the given point.
-// It validates coordinates and radius, logs the query, and returns results ordered by distance.
-func (s *parcelService) GetNearbyParcels(ctx context.Context, lat, lng float64, radiusMeters int) ([]repository.ParcelWithDistance, error) {
-	// Validate latitude range
-	if lat < MinLatitude || lat > MaxLatitude {
-		s.log.Warn("Invalid latitude provided", map[string]interface{}{
-			"lat":    lat,
-			"lng":    lng,
-			"radius": radiusMeters,
+// GetParcel retrieves a parcel by ID.
+func (s *parcelService) GetParcel(ctx context.Context, id uint) (*models.TaxParcel, error) {
+	ctx, span := tracing.Start(ctx, "ParcelService.GetParcel")
+	defer span.End()
+
+	found, err := s.repo.FindByIDs(ctx, []uint{id})
+	if err != nil {
+		s.log.Error("Failed to query parcel by id", err, map[string]interface{}{
+			"parcel_id": id,
+		})
+		return nil, fmt.Errorf("failed to query parcel: %w", err)
+	}
+	if len(found) == 0 {
+		return nil, fmt.Errorf("%w: id %d", ErrParcelNotFound, id)
+	}
+	if found[0].Err != nil {
+		s.log.Error("Failed to decode parcel", found[0].Err, map[string]interface{}{
+			"parcel_id": id,
+		})
+		return nil, found[0].Err
+	}
+
+	return &found[0].Parcel, nil
+}
+
+// GetParcelCandidatesAtPoint retrieves the parcels that may contain a point with limited accuracy.
+// It validates the coordinates and accuracy, and collapses the candidates to a single
+// match when the accuracy circle lies entirely inside one parcel.
+func (s *parcelService) GetParcelCandidatesAtPoint(ctx context.Context, point models.LatLng, accuracyMeters float64) (*PointCandidates, error) {
+	ctx, span := tracing.Start(ctx, "ParcelService.GetParcelCandidatesAtPoint")
+	defer span.End()
+
+	// Validate coordinate ranges
+	if err := point.Validate(); err != nil {
+		s.log.Warn("Invalid coordinates provided", map[string]interface{}{
+			"lat": point.Lat,
+			"lng": point.Lng,
+		})
+		return nil, err
+	}
+
+	// Validate accuracy range
+	if accuracyMeters <= 0 || accuracyMeters > MaxAccuracyMeters {
+		s.log.Warn("Invalid accuracy provided", map[string]interface{}{
+			"accuracy": accuracyMeters,
+		})
+		return nil, ErrInvalidAccuracy
+	}
+
+	// Log the query
+	s.log.Info("Querying parcel candidates at point", map[string]interface{}{
+		"lat":      point.Lat,
+		"lng":      point.Lng,
+		"accuracy": accuracyMeters,
+	})
+
+	// Query repository
+	candidates, err := s.repo.FindCandidatesNearPoint(ctx, point, accuracyMeters, MaxParcelCandidates)
+	if err != nil {
+		s.log.Error("Failed to query parcel candidates at point", err, map[string]interface{}{
+			"lat":      point.Lat,
+			"lng":      point.Lng,
+			"accuracy": accuracyMeters,
 		})
-		return nil, fmt.Errorf("%w: latitude must be between %f and %f, got %f",
-			ErrInvalidCoordinates, MinLatitude, MaxLatitude, lat)
+		return nil, fmt.Errorf("failed to query parcel candidates: %w", err)
 	}
 
-	// Validate longitude range
-	if lng < MinLongitude || lng > MaxLongitude {
-		s.log.Warn("Invalid longitude provided", map[string]interface{}{
-			"lat":    lat,
-			"lng":    lng,
+	if len(candidates) == 0 {
+		s.log.Debug("No parcel candidates found at point", map[string]interface{}{
+			"lat":      point.Lat,
+			"lng":      point.Lng,
+			"accuracy": accuracyMeters,
+		})
+		return nil, ErrParcelNotFound
+	}
+
+	// Candidates are ordered by probability, so the first one is the best guess
+	// unless another parcel contains the reported point itself.
+	result := &PointCandidates{Parcel: &candidates[0].Parcel}
+	for i := range candidates {
+		if candidates[i].ContainsPoint {
+			result.Parcel = &candidates[i].Parcel
+			break
+		}
+	}
+
+	// The whole accuracy circle is inside one parcel - no ambiguity to report
+	if len(candidates) > 1 || candidates[0].Probability < certainProbability {
+		result.Candidates = candidates
+	}
+
+	s.log.Info("Parcel candidates found at point", map[string]interface{}{
+		"lat":        point.Lat,
+		"lng":        point.Lng,
+		"accuracy":   accuracyMeters,
+		"parcel_id":  result.Parcel.ID,
+		"candidates": len(result.Candidates),
+	})
+
+	return result, nil
+}
+
+// DefaultNearbyRadius looks up the profile of the county the request is limited to.
+func (s *parcelService) DefaultNearbyRadius(ctx context.Context) (int, error) {
+	profile, err := s.countyProfile(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if profile != nil && profile.DefaultRadiusMeters != nil {
+		return *profile.DefaultRadiusMeters, nil
+	}
+	return DefaultRadiusMeters, nil
+}
+
+// GetNearbyParcels retrieves one page of parcels within the specified radius of the given point.
+// It validates coordinates, radius, limit, filter and cursor, logs the query, and returns results ordered by distance.
+func (s *parcelService) GetNearbyParcels(ctx context.Context, point models.LatLng, radiusMeters, limit int, cursor string, filter repository.NearbyFilter) (*NearbyPage, error) {
+	ctx, span := tracing.Start(ctx, "ParcelService.GetNearbyParcels")
+	defer span.End()
+
+	// Validate coordinate ranges
+	if err := point.Validate(); err != nil {
+		s.log.Warn("Invalid coordinates provided", map[string]interface{}{
+			"lat":    point.Lat,
+			"lng":    point.Lng,
 			"radius": radiusMeters,
 		})
-		return nil, fmt.Errorf("%w: longitude must be between %f and %f, got %f",
-			ErrInvalidCoordinates, MinLongitude, MaxLongitude, lng)
+		return nil, err
 	}
 
-	// Validate radius range
-	if radiusMeters < MinRadiusMeters || radiusMeters > MaxRadiusMeters {
+	profile, err := s.countyProfile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx = withProfileSimplification(ctx, profile)
+
+	// Validate radius range, which the county may narrow
+	maxRadius := MaxRadiusMeters
+	if profile != nil && profile.MaxRadiusMeters != nil {
+		maxRadius = *profile.MaxRadiusMeters
+	}
+	if radiusMeters < MinRadiusMeters || radiusMeters > maxRadius {
 		s.log.Warn("Invalid radius provided", map[string]interface{}{
-			"lat":    lat,
-			"lng":    lng,
+			"lat":    point.Lat,
+			"lng":    point.Lng,
 			"radius": radiusMeters,
 		})
+		if maxRadius < MaxRadiusMeters {
+			return nil, fmt.Errorf("%w: county %s allows at most %d, got %d", ErrInvalidRadius, profile.County, maxRadius, radiusMeters)
+		}
 		return nil, fmt.Errorf("%w: got %d", ErrInvalidRadius, radiusMeters)
 	}
 
+	// Validate limit range
+	if limit < MinNearbyLimit || limit > MaxNearbyLimit {
+		s.log.Warn("Invalid nearby limit provided", map[string]interface{}{
+			"lat":   point.Lat,
+			"lng":   point.Lng,
+			"limit": limit,
+		})
+		return nil, fmt.Errorf("%w: must be between %d and %d, got %d",
+			ErrInvalidLimit, MinNearbyLimit, MaxNearbyLimit, limit)
+	}
+
+	filter, err = normalizeNearbyFilter(filter)
+	if err == nil {
+		err = checkFilterAvailability(filter, profile)
+	}
+	if err == nil && len(filter.Attributes) > 0 {
+		filter.Attributes, err = parseAttributeFilters(filter.Attributes, s.repo.DerivedAttributes())
+	}
+	if err != nil {
+		s.log.Warn("Invalid nearby filter provided", map[string]interface{}{
+			"lat":   point.Lat,
+			"lng":   point.Lng,
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	after, err := pagination.Decode(cursor)
+	if err != nil {
+		s.log.Warn("Invalid nearby cursor provided", map[string]interface{}{
+			"lat":    point.Lat,
+			"lng":    point.Lng,
+			"cursor": cursor,
+		})
+		return nil, err
+	}
+
 	// Log the query
 	s.log.Info("Querying nearby parcels", map[string]interface{}{
-		"lat":    lat,
-		"lng":    lng,
-		"radius": radiusMeters,
+		"lat":      point.Lat,
+		"lng":      point.Lng,
+		"radius":   radiusMeters,
+		"limit":    limit,
+		"filtered": !filter.IsEmpty(),
 	})
 
-	// Query repository
-	parcels, err := s.repo.FindNearby(ctx, lat, lng, radiusMeters)
+	// Fetch one extra row so we know whether another page exists
+	parcels, total, err := s.repo.FindNearby(ctx, point, radiusMeters, limit+1, after, filter)
 	if err != nil {
 		s.log.Error("Failed to query nearby parcels", err, map[string]interface{}{
-			"lat":    lat,
-			"lng":    lng,
+			"lat":    point.Lat,
+			"lng":    point.Lng,
 			"radius": radiusMeters,
 		})
 		return nil, fmt.Errorf("failed to query nearby parcels: %w", err)
@@ -176,11 +551,721 @@ func (s *parcelService) GetNearbyParcels(ctx context.Context, lat, lng float64,
 
 	// Log results
 	s.log.Info("Nearby parcels found", map[string]interface{}{
-		"lat":    lat,
-		"lng":    lng,
+		"lat":    point.Lat,
+		"lng":    point.Lng,
 		"radius": radiusMeters,
 		"count":  len(parcels),
+		"total":  total,
+	})
+
+	page := &NearbyPage{Parcels: parcels, TotalCount: total}
+	if len(parcels) > limit {
+		page.Parcels = parcels[:limit]
+		last := page.Parcels[limit-1]
+		page.NextCursor = pagination.Cursor{Key: last.Distance, ID: last.Parcel.ID}.Encode()
+	}
+
+	return page, nil
+}
+
+// SearchByAddress retrieves one page of parcels ranked by how closely their situs address matches the input.
+// The address is trimmed before validation; results are ordered by similarity score.
+func (s *parcelService) SearchByAddress(ctx context.Context, address string, limit int, cursor string) (*AddressSearchPage, error) {
+	ctx, span := tracing.Start(ctx, "ParcelService.SearchByAddress")
+	defer span.End()
+
+	address = strings.TrimSpace(address)
+
+	// Validate address length
+	if len(address) < MinAddressQueryLength || len(address) > MaxAddressQueryLength {
+		s.log.Warn("Invalid address query provided", map[string]interface{}{
+			"address": address,
+			"limit":   limit,
+		})
+		return nil, fmt.Errorf("%w: got %d characters", ErrInvalidAddressQuery, len(address))
+	}
+
+	// Validate limit range
+	if limit < MinSearchLimit || limit > MaxSearchLimit {
+		s.log.Warn("Invalid search limit provided", map[string]interface{}{
+			"address": address,
+			"limit":   limit,
+		})
+		return nil, fmt.Errorf("%w: must be between %d and %d, got %d",
+			ErrInvalidLimit, MinSearchLimit, MaxSearchLimit, limit)
+	}
+
+	profile, err := s.countyProfile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx = withProfileSimplification(ctx, profile)
+
+	after, err := pagination.Decode(cursor)
+	if err != nil {
+		s.log.Warn("Invalid search cursor provided", map[string]interface{}{
+			"address": address,
+			"cursor":  cursor,
+		})
+		return nil, err
+	}
+
+	// Log the query
+	s.log.Info("Searching parcels by address", map[string]interface{}{
+		"address": address,
+		"limit":   limit,
+	})
+
+	// Fetch one extra row so we know whether another page exists
+	matches, total, err := s.repo.SearchByAddress(ctx, address, limit+1, after)
+	if err != nil {
+		s.log.Error("Failed to search parcels by address", err, map[string]interface{}{
+			"address": address,
+			"limit":   limit,
+		})
+		return nil, fmt.Errorf("failed to search parcels by address: %w", err)
+	}
+
+	// Log results
+	s.log.Info("Address search completed", map[string]interface{}{
+		"address": address,
+		"limit":   limit,
+		"count":   len(matches),
+		"total":   total,
 	})
 
-	return parcels, nil
+	page := &AddressSearchPage{Matches: matches, TotalCount: total}
+	if len(matches) > limit {
+		page.Matches = matches[:limit]
+		last := page.Matches[limit-1]
+		page.NextCursor = pagination.Cursor{Key: last.Score, ID: last.Parcel.ID}.Encode()
+	}
+
+	return page, nil
+}
+
+// IdentifyParcelAtPoint retrieves a lightweight parcel summary for hover tooltips.
+// Hover traffic is high-volume, so successful lookups are logged at debug level.
+func (s *parcelService) IdentifyParcelAtPoint(ctx context.Context, point models.LatLng) (*repository.ParcelSummary, error) {
+	ctx, span := tracing.Start(ctx, "ParcelService.IdentifyParcelAtPoint")
+	defer span.End()
+
+	if err := point.Validate(); err != nil {
+		s.log.Warn("Invalid coordinates provided", map[string]interface{}{
+			"lat": point.Lat,
+			"lng": point.Lng,
+		})
+		return nil, err
+	}
+
+	// Query repository
+	summary, err := s.repo.IdentifyByPoint(ctx, point)
+	if err != nil {
+		s.log.Error("Failed to identify parcel at point", err, map[string]interface{}{
+			"lat": point.Lat,
+			"lng": point.Lng,
+		})
+		return nil, fmt.Errorf("failed to identify parcel: %w", err)
+	}
+
+	// Repository returns nil, nil when no parcel found - transform to domain error
+	if summary == nil {
+		return nil, ErrParcelNotFound
+	}
+
+	s.log.Debug("Parcel identified at point", map[string]interface{}{
+		"lat":       point.Lat,
+		"lng":       point.Lng,
+		"parcel_id": summary.ID,
+	})
+
+	return summary, nil
+}
+
+// CompareParcels loads the requested parcels and diffs their attributes.
+// Parcels are returned in the order requested so the frontend can keep its column order.
+func (s *parcelService) CompareParcels(ctx context.Context, ids []uint) (*ParcelComparison, error) {
+	ctx, span := tracing.Start(ctx, "ParcelService.CompareParcels")
+	defer span.End()
+
+	// Validate id count and uniqueness
+	if len(ids) < MinCompareParcels || len(ids) > MaxCompareParcels {
+		s.log.Warn("Invalid compare ids provided", map[string]interface{}{
+			"ids": ids,
+		})
+		return nil, fmt.Errorf("%w: got %d ids", ErrInvalidCompareIDs, len(ids))
+	}
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			s.log.Warn("Duplicate compare ids provided", map[string]interface{}{
+				"ids": ids,
+			})
+			return nil, fmt.Errorf("%w: duplicate id %d", ErrInvalidCompareIDs, id)
+		}
+		seen[id] = true
+	}
+
+	// Log the query
+	s.log.Info("Comparing parcels", map[string]interface{}{
+		"ids": ids,
+	})
+
+	// Query repository
+	found, err := s.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		s.log.Error("Failed to query parcels for comparison", err, map[string]interface{}{
+			"ids": ids,
+		})
+		return nil, fmt.Errorf("failed to compare parcels: %w", err)
+	}
+
+	// Reorder into request order, reporting the ids the repository did not
+	// return or could not decode
+	byID := make(map[uint]repository.ParcelWithMetrics, len(found))
+	for _, p := range found {
+		byID[p.Parcel.ID] = p
+	}
+	parcels := make([]repository.ParcelWithMetrics, 0, len(ids))
+	var failed []CompareFailure
+	for i, id := range ids {
+		p, ok := byID[id]
+		switch {
+		case !ok:
+			failed = append(failed, CompareFailure{Index: i, ID: id, Err: fmt.Errorf("%w: id %d", ErrParcelNotFound, id)})
+		case p.Err != nil:
+			s.log.Warn("Parcel left out of comparison", map[string]interface{}{
+				"id":    id,
+				"error": p.Err.Error(),
+			})
+			failed = append(failed, CompareFailure{Index: i, ID: id, Err: p.Err})
+		default:
+			parcels = append(parcels, p)
+		}
+	}
+
+	return &ParcelComparison{
+		Parcels:     parcels,
+		Failed:      failed,
+		Differences: diffParcelAttributes(parcels),
+	}, nil
+}
+
+// DissolveParcels validates the ids and unions the parcels into one outline.
+func (s *parcelService) DissolveParcels(ctx context.Context, ids []uint) (*repository.DissolvedArea, error) {
+	ctx, span := tracing.Start(ctx, "ParcelService.DissolveParcels")
+	defer span.End()
+
+	// Validate id count and uniqueness
+	if len(ids) < MinDissolveParcels || len(ids) > MaxDissolveParcels {
+		s.log.Warn("Invalid dissolve ids provided", map[string]interface{}{
+			"count": len(ids),
+		})
+		return nil, fmt.Errorf("%w: got %d ids", ErrInvalidDissolveIDs, len(ids))
+	}
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return nil, fmt.Errorf("%w: duplicate id %d", ErrInvalidDissolveIDs, id)
+		}
+		seen[id] = true
+	}
+
+	// Log the query
+	s.log.Info("Dissolving parcels", map[string]interface{}{
+		"count": len(ids),
+	})
+
+	// Query repository
+	area, err := s.repo.DissolveByIDs(ctx, ids)
+	if err != nil {
+		s.log.Error("Failed to dissolve parcels", err, map[string]interface{}{
+			"count": len(ids),
+		})
+		return nil, fmt.Errorf("failed to dissolve parcels: %w", err)
+	}
+
+	// Report any ids the repository did not find, in request order
+	if len(area.ParcelIDs) < len(ids) {
+		found := make(map[uint]bool, len(area.ParcelIDs))
+		for _, id := range area.ParcelIDs {
+			found[id] = true
+		}
+		var missing []uint
+		for _, id := range ids {
+			if !found[id] {
+				missing = append(missing, id)
+			}
+		}
+		return nil, fmt.Errorf("%w: ids %v", ErrParcelNotFound, missing)
+	}
+
+	return area, nil
+}
+
+// FindParcelsInArea validates a user-drawn area and returns the parcels intersecting it.
+// One extra row is requested from the repository to detect truncation without a COUNT query.
+func (s *parcelService) FindParcelsInArea(ctx context.Context, area *models.MultiPolygon, limit int) ([]models.TaxParcel, bool, error) {
+	ctx, span := tracing.Start(ctx, "ParcelService.FindParcelsInArea")
+	defer span.End()
+
+	// Validate limit range
+	if limit < MinIntersectLimit || limit > MaxIntersectLimit {
+		return nil, false, fmt.Errorf("%w: must be between %d and %d, got %d",
+			ErrInvalidLimit, MinIntersectLimit, MaxIntersectLimit, limit)
+	}
+
+	if err := validateAreaTopology(ctx, s.repo, area, s.log); err != nil {
+		return nil, false, err
+	}
+
+	profile, err := s.countyProfile(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	ctx = withProfileSimplification(ctx, profile)
+
+	// Log the query
+	s.log.Info("Searching parcels intersecting area", map[string]interface{}{
+		"polygons": len(area.Coordinates),
+		"limit":    limit,
+	})
+
+	// Query repository
+	parcels, err := s.repo.FindIntersecting(ctx, *area, limit+1)
+	if err != nil {
+		s.log.Error("Failed to query parcels intersecting area", err, map[string]interface{}{
+			"limit": limit,
+		})
+		return nil, false, fmt.Errorf("failed to find parcels in area: %w", err)
+	}
+
+	truncated := len(parcels) > limit
+	if truncated {
+		parcels = parcels[:limit]
+	}
+
+	// Log results
+	s.log.Info("Area intersection completed", map[string]interface{}{
+		"limit":     limit,
+		"count":     len(parcels),
+		"truncated": truncated,
+	})
+
+	return parcels, truncated, nil
+}
+
+// FindParcelsAlongRoute validates a user-drawn route and returns the parcels in its corridor.
+// One extra row is requested from the repository to detect truncation without a COUNT query.
+func (s *parcelService) FindParcelsAlongRoute(ctx context.Context, route *models.LineString, bufferMeters float64, limit int) ([]repository.ParcelAlongRoute, bool, error) {
+	ctx, span := tracing.Start(ctx, "ParcelService.FindParcelsAlongRoute")
+	defer span.End()
+
+	// Validate buffer range
+	if bufferMeters < MinRouteBufferMeters || bufferMeters > MaxRouteBufferMeters {
+		return nil, false, fmt.Errorf("%w: got %g", ErrInvalidBuffer, bufferMeters)
+	}
+
+	// Validate limit range
+	if limit < MinIntersectLimit || limit > MaxIntersectLimit {
+		return nil, false, fmt.Errorf("%w: must be between %d and %d, got %d",
+			ErrInvalidLimit, MinIntersectLimit, MaxIntersectLimit, limit)
+	}
+
+	// Validate structure before sending the geometry to PostGIS
+	if err := validateRoute(route); err != nil {
+		s.log.Warn("Invalid route geometry provided", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil, false, err
+	}
+
+	profile, err := s.countyProfile(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	ctx = withProfileSimplification(ctx, profile)
+
+	// Log the query
+	s.log.Info("Searching parcels along route", map[string]interface{}{
+		"positions":     len(route.Coordinates),
+		"buffer_meters": bufferMeters,
+		"limit":         limit,
+	})
+
+	// Query repository
+	parcels, err := s.repo.FindAlongRoute(ctx, *route, bufferMeters, limit+1)
+	if err != nil {
+		s.log.Error("Failed to query parcels along route", err, map[string]interface{}{
+			"buffer_meters": bufferMeters,
+			"limit":         limit,
+		})
+		return nil, false, fmt.Errorf("failed to find parcels along route: %w", err)
+	}
+
+	truncated := len(parcels) > limit
+	if truncated {
+		parcels = parcels[:limit]
+	}
+
+	// Log results
+	s.log.Info("Route corridor search completed", map[string]interface{}{
+		"limit":     limit,
+		"count":     len(parcels),
+		"truncated": truncated,
+	})
+
+	return parcels, truncated, nil
+}
+
+// GetNearestAmenities looks up the nearest amenities of the given parcels.
+func (s *parcelService) GetNearestAmenities(ctx context.Context, ids []uint) (map[uint][]repository.NearestAmenity, error) {
+	ctx, span := tracing.Start(ctx, "ParcelService.GetNearestAmenities")
+	defer span.End()
+
+	if len(ids) == 0 {
+		return map[uint][]repository.NearestAmenity{}, nil
+	}
+
+	amenities, err := s.repo.FindNearestAmenities(ctx, ids)
+	if err != nil {
+		s.log.Error("Failed to find nearest amenities", err, map[string]interface{}{
+			"count": len(ids),
+		})
+		return nil, fmt.Errorf("failed to find nearest amenities: %w", err)
+	}
+
+	return amenities, nil
+}
+
+// SampleParcels validates the sample size and samples the repository.
+func (s *parcelService) SampleParcels(ctx context.Context, n int) ([]repository.ParcelSample, error) {
+	ctx, span := tracing.Start(ctx, "ParcelService.SampleParcels")
+	defer span.End()
+
+	if n < MinSampleSize || n > MaxSampleSize {
+		return nil, fmt.Errorf("%w: must be between %d and %d, got %d",
+			ErrInvalidLimit, MinSampleSize, MaxSampleSize, n)
+	}
+
+	samples, err := s.repo.SampleParcels(ctx, n)
+	if err != nil {
+		s.log.Error("Failed to sample parcels", err, map[string]interface{}{
+			"n": n,
+		})
+		return nil, fmt.Errorf("failed to sample parcels: %w", err)
+	}
+
+	flagged := 0
+	for _, sample := range samples {
+		if len(sample.QualityFlags) > 0 {
+			flagged++
+		}
+	}
+	s.log.Info("Parcel sample completed", map[string]interface{}{
+		"county":  repository.CountyFromContext(ctx),
+		"n":       n,
+		"count":   len(samples),
+		"flagged": flagged,
+	})
+
+	return samples, nil
+}
+
+// validateRoute checks that the route has at least two distinct positions within WGS84
+// bounds and is within MaxRouteVertices. A line collapsed to one point has no direction
+// to measure stations along, so it is rejected.
+func validateRoute(route *models.LineString) error {
+	if route == nil || len(route.Coordinates) < 2 {
+		return fmt.Errorf("%w: route must contain at least 2 positions", ErrInvalidGeometry)
+	}
+	if len(route.Coordinates) > MaxRouteVertices {
+		return fmt.Errorf("%w: %d vertices exceeds maximum of %d", ErrInvalidGeometry, len(route.Coordinates), MaxRouteVertices)
+	}
+
+	distinct := false
+	for _, pos := range route.Coordinates {
+		// GeoJSON positions are [lng, lat]
+		if err := (models.LatLng{Lat: pos[1], Lng: pos[0]}).Validate(); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidGeometry, err)
+		}
+		if pos != route.Coordinates[0] {
+			distinct = true
+		}
+	}
+	if !distinct {
+		return fmt.Errorf("%w: route positions are all identical", ErrInvalidGeometry)
+	}
+
+	return nil
+}
+
+// validateAreaTopology checks the area's structure with validateArea, then asks
+// PostGIS to reject self-intersections and other topology errors, which are
+// easier to detect in the database than in Go. Invalid areas are logged as
+// warnings and returned as ErrInvalidGeometry.
+func validateAreaTopology(ctx context.Context, repo repository.ParcelRepository, area *models.MultiPolygon, log *logger.Logger) error {
+	// Validate structure before sending the geometry to PostGIS
+	if err := validateArea(area); err != nil {
+		log.Warn("Invalid area geometry provided", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return err
+	}
+
+	reason, err := repo.ValidateArea(ctx, *area)
+	if err != nil {
+		log.Error("Failed to validate area geometry", err, nil)
+		return fmt.Errorf("failed to validate area: %w", err)
+	}
+	if reason != "" {
+		log.Warn("Invalid area geometry provided", map[string]interface{}{
+			"reason": reason,
+		})
+		return fmt.Errorf("%w: %s", ErrInvalidGeometry, reason)
+	}
+	return nil
+}
+
+// validateArea checks that every ring is closed, has enough positions and lies within
+// WGS84 bounds, and that the area is within MaxAreaVertices.
+func validateArea(area *models.MultiPolygon) error {
+	if area == nil || len(area.Coordinates) == 0 {
+		return fmt.Errorf("%w: area must contain at least one polygon", ErrInvalidGeometry)
+	}
+
+	vertices := 0
+	for p, polygon := range area.Coordinates {
+		if len(polygon) == 0 {
+			return fmt.Errorf("%w: polygon %d has no rings", ErrInvalidGeometry, p)
+		}
+		for r, ring := range polygon {
+			if len(ring) < minRingPositions {
+				return fmt.Errorf("%w: polygon %d ring %d has %d positions, need at least %d",
+					ErrInvalidGeometry, p, r, len(ring), minRingPositions)
+			}
+			if ring[0] != ring[len(ring)-1] {
+				return fmt.Errorf("%w: polygon %d ring %d is not closed", ErrInvalidGeometry, p, r)
+			}
+			for _, pos := range ring {
+				// GeoJSON positions are [lng, lat]
+				if err := (models.LatLng{Lat: pos[1], Lng: pos[0]}).Validate(); err != nil {
+					return fmt.Errorf("%w: %w", ErrInvalidGeometry, err)
+				}
+			}
+			vertices += len(ring)
+		}
+	}
+
+	if vertices > MaxAreaVertices {
+		return fmt.Errorf("%w: %d vertices exceeds maximum of %d", ErrInvalidGeometry, vertices, MaxAreaVertices)
+	}
+
+	return nil
+}
+
+// comparedAttribute extracts a single attribute from a parcel for comparison.
+// Numeric attributes return float64 (or nil), text attributes return string (or nil).
+type comparedAttribute struct {
+	value   func(p *repository.ParcelWithMetrics) interface{}
+	field   string
+	numeric bool
+}
+
+// comparedAttributes lists the attributes included in a parcel comparison, in response order.
+// Field names match the JSON keys used by the parcel DTOs.
+var comparedAttributes = []comparedAttribute{
+	{field: "owner_name", value: func(p *repository.ParcelWithMetrics) interface{} { return optionalString(p.Parcel.OwnerName) }},
+	{field: "situs_address", value: func(p *repository.ParcelWithMetrics) interface{} { return optionalString(p.Parcel.Situs) }},
+	{field: "county_name", value: func(p *repository.ParcelWithMetrics) interface{} { return p.Parcel.CountyName }},
+	{field: "land_use", value: func(p *repository.ParcelWithMetrics) interface{} { return optionalString(p.Parcel.AsCode) }},
+	{field: "state_cd", value: func(p *repository.ParcelWithMetrics) interface{} { return optionalString(p.Parcel.StateCd) }},
+	{field: "market_area", value: func(p *repository.ParcelWithMetrics) interface{} { return optionalString(p.Parcel.MarketArea) }},
+	{field: "exemptions", value: func(p *repository.ParcelWithMetrics) interface{} { return optionalString(p.Parcel.Exemptions) }},
+	{field: "taxing_units", value: func(p *repository.ParcelWithMetrics) interface{} { return optionalString(p.Parcel.TaxingUnits) }},
+	{field: "tax_year", numeric: true, value: func(p *repository.ParcelWithMetrics) interface{} { return optionalInt(p.Parcel.PYear) }},
+	{field: "year_built", numeric: true, value: func(p *repository.ParcelWithMetrics) interface{} { return optionalInt(p.Parcel.ImprvActualYearBuilt) }},
+	{field: "main_area", numeric: true, value: func(p *repository.ParcelWithMetrics) interface{} { return optionalInt(p.Parcel.ImprvMainArea) }},
+	{field: "acres", numeric: true, value: func(p *repository.ParcelWithMetrics) interface{} { return p.Parcel.Acres }},
+	{field: "perimeter_meters", numeric: true, value: func(p *repository.ParcelWithMetrics) interface{} { return p.PerimeterMeters }},
+}
+
+// diffParcelAttributes builds an AttributeDifference for every compared attribute.
+// Deltas are computed against the first parcel, which the frontend treats as the baseline.
+// There are no differences without parcels, when every compared parcel failed.
+func diffParcelAttributes(parcels []repository.ParcelWithMetrics) []AttributeDifference {
+	diffs := make([]AttributeDifference, 0, len(comparedAttributes))
+	if len(parcels) == 0 {
+		return diffs
+	}
+
+	for _, attr := range comparedAttributes {
+		diff := AttributeDifference{
+			Field:  attr.field,
+			Values: make([]interface{}, len(parcels)),
+		}
+		for i := range parcels {
+			diff.Values[i] = attr.value(&parcels[i])
+			if i > 0 && diff.Values[i] != diff.Values[0] {
+				diff.Differs = true
+			}
+		}
+
+		if attr.numeric {
+			diff.Deltas = make([]*float64, len(parcels))
+			base, baseOK := diff.Values[0].(float64)
+			for i, v := range diff.Values {
+				if f, ok := v.(float64); ok && baseOK {
+					delta := f - base
+					diff.Deltas[i] = &delta
+				}
+			}
+		}
+
+		diffs = append(diffs, diff)
+	}
+
+	return diffs
+}
+
+// optionalString dereferences a nullable string, returning nil for NULL.
+func optionalString(s *string) interface{} {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+// countyProfile returns the profile of the county the query is limited to
+// (repository.WithCounty), nil when it spans all counties or has no profile.
+func (s *parcelService) countyProfile(ctx context.Context) (*models.CountyProfile, error) {
+	if s.profiles == nil {
+		return nil, nil
+	}
+	profile, err := s.profiles.Profile(ctx, repository.CountyFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load county profile: %w", err)
+	}
+	return profile, nil
+}
+
+// withProfileSimplification simplifies geometries to the profile's tolerance
+// when the request set none. A request asking for full resolution cannot
+// override it; counties get a tolerance because their geometries are heavy.
+func withProfileSimplification(ctx context.Context, profile *models.CountyProfile) context.Context {
+	if profile == nil || profile.SimplifyTolerance == nil {
+		return ctx
+	}
+	if _, ok := repository.SimplifyToleranceFromContext(ctx); ok {
+		return ctx
+	}
+	return repository.WithSimplifyTolerance(ctx, *profile.SimplifyTolerance)
+}
+
+// checkFilterAvailability rejects filters on attributes the county's data does
+// not provide, which would otherwise silently match nothing.
+func checkFilterAvailability(filter repository.NearbyFilter, profile *models.CountyProfile) error {
+	used := map[string]bool{
+		"owner_name": filter.OwnerName != "",
+		"land_use":   filter.LandUse != "",
+		"year_built": filter.MinYearBuilt != nil || filter.MaxYearBuilt != nil,
+		"waterfront": filter.Waterfront != nil,
+	}
+	for _, attribute := range []string{"owner_name", "land_use", "year_built", "waterfront"} {
+		if used[attribute] && !profile.Available(attribute) {
+			return fmt.Errorf("%w: county %s does not provide %s", ErrInvalidFilter, profile.County, attribute)
+		}
+	}
+	return nil
+}
+
+// normalizeNearbyFilter trims the text filters and checks that the ranges are non-negative and not inverted.
+// Returns an error wrapping ErrInvalidFilter describing the offending value.
+func normalizeNearbyFilter(filter repository.NearbyFilter) (repository.NearbyFilter, error) {
+	filter.LandUse = strings.TrimSpace(filter.LandUse)
+	filter.OwnerName = strings.TrimSpace(filter.OwnerName)
+
+	if len(filter.OwnerName) > MaxOwnerNameFilterLength {
+		return filter, fmt.Errorf("%w: owner_name must be at most %d characters",
+			ErrInvalidFilter, MaxOwnerNameFilterLength)
+	}
+	if (filter.MinAcres != nil && *filter.MinAcres < 0) || (filter.MaxAcres != nil && *filter.MaxAcres < 0) {
+		return filter, fmt.Errorf("%w: acres must not be negative", ErrInvalidFilter)
+	}
+	if filter.MinAcres != nil && filter.MaxAcres != nil && *filter.MinAcres > *filter.MaxAcres {
+		return filter, fmt.Errorf("%w: min_acres %g is greater than max_acres %g",
+			ErrInvalidFilter, *filter.MinAcres, *filter.MaxAcres)
+	}
+	if filter.MinYearBuilt != nil && filter.MaxYearBuilt != nil && *filter.MinYearBuilt > *filter.MaxYearBuilt {
+		return filter, fmt.Errorf("%w: min_year_built %d is greater than max_year_built %d",
+			ErrInvalidFilter, *filter.MinYearBuilt, *filter.MaxYearBuilt)
+	}
+	if (filter.Amenity == "") != (filter.AmenityWithinMeters == nil) {
+		return filter, fmt.Errorf("%w: amenity and amenity_within must be given together", ErrInvalidFilter)
+	}
+	if filter.Amenity != "" && !models.IsAmenityCategory(filter.Amenity) {
+		return filter, fmt.Errorf("%w: amenity must be one of %s, got %q",
+			ErrInvalidFilter, strings.Join(models.AmenityCategories, ", "), filter.Amenity)
+	}
+	if filter.AmenityWithinMeters != nil &&
+		(*filter.AmenityWithinMeters <= 0 || *filter.AmenityWithinMeters > MaxAmenityWithinMeters) {
+		return filter, fmt.Errorf("%w: amenity_within must be greater than 0 and at most %d meters, got %g",
+			ErrInvalidFilter, MaxAmenityWithinMeters, *filter.AmenityWithinMeters)
+	}
+
+	return filter, nil
+}
+
+// parseAttributeFilters checks attribute filters against the configured derived
+// attributes and converts their string values to the attribute's type.
+// Returns an error wrapping ErrInvalidFilter describing the offending filter.
+func parseAttributeFilters(filters []repository.AttributeFilter, attributes []config.DerivedAttribute) ([]repository.AttributeFilter, error) {
+	if len(filters) > MaxAttributeFilters {
+		return nil, fmt.Errorf("%w: at most %d attribute filters are supported, got %d",
+			ErrInvalidFilter, MaxAttributeFilters, len(filters))
+	}
+
+	parsed := make([]repository.AttributeFilter, 0, len(filters))
+	for _, f := range filters {
+		i := slices.IndexFunc(attributes, func(a config.DerivedAttribute) bool { return a.Name == f.Name })
+		if i < 0 {
+			return nil, fmt.Errorf("%w: unknown attribute %q", ErrInvalidFilter, f.Name)
+		}
+		attribute := attributes[i]
+		if f.Op != repository.AttributeEquals && !attribute.Type.Numeric() {
+			return nil, fmt.Errorf("%w: attribute %q is %s and only supports equality",
+				ErrInvalidFilter, f.Name, attribute.Type)
+		}
+
+		raw, _ := f.Value.(string)
+		raw = strings.TrimSpace(raw)
+		var value any
+		var err error
+		switch attribute.Type {
+		case config.DerivedFloat:
+			value, err = strconv.ParseFloat(raw, 64)
+		case config.DerivedInt:
+			value, err = strconv.ParseInt(raw, 10, 64)
+		case config.DerivedBool:
+			value, err = strconv.ParseBool(raw)
+		default:
+			value = raw
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: attribute %q expects a %s value, got %q",
+				ErrInvalidFilter, f.Name, attribute.Type, raw)
+		}
+
+		f.Value = value
+		parsed = append(parsed, f)
+	}
+	return parsed, nil
+}
+
+// optionalInt converts a nullable int to float64, returning nil for NULL.
+func optionalInt(i *int) interface{} {
+	if i == nil {
+		return nil
+	}
+	return float64(*i)
 }
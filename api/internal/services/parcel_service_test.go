@@ -3,24 +3,36 @@ package services
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/config"
 	"github.com/stwalsh4118/atlas/api/internal/logger"
 	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/pagination"
 	"github.com/stwalsh4118/atlas/api/internal/repository"
 )
 
+// testNearbyLimit is the page size used by GetNearbyParcels tests.
+const testNearbyLimit = 20
+
+// noCursor matches the nil cursor passed to the repository for first pages.
+var noCursor *pagination.Cursor
+
+// noFilter matches the empty filter passed to the repository for unfiltered queries.
+var noFilter = repository.NearbyFilter{}
+
 // MockParcelRepository is a mock implementation of ParcelRepository for testing
 type MockParcelRepository struct {
 	mock.Mock
 }
 
-func (m *MockParcelRepository) FindByPoint(ctx context.Context, lat, lng float64) (*models.TaxParcel, error) {
-	args := m.Called(ctx, lat, lng)
+func (m *MockParcelRepository) FindByPoint(ctx context.Context, point models.LatLng) (*models.TaxParcel, error) {
+	args := m.Called(ctx, point)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -31,12 +43,161 @@ func (m *MockParcelRepository) FindByPoint(ctx context.Context, lat, lng float64
 	return parcel, args.Error(1)
 }
 
-func (m *MockParcelRepository) FindNearby(ctx context.Context, lat, lng float64, radiusMeters int) ([]repository.ParcelWithDistance, error) {
-	args := m.Called(ctx, lat, lng, radiusMeters)
+func (m *MockParcelRepository) FindCandidatesNearPoint(ctx context.Context, point models.LatLng, accuracyMeters float64, limit int) ([]repository.ParcelCandidate, error) {
+	args := m.Called(ctx, point, accuracyMeters, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
+	candidates, ok := args.Get(0).([]repository.ParcelCandidate)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return candidates, args.Error(1)
+}
+
+func (m *MockParcelRepository) FindNearby(ctx context.Context, point models.LatLng, radiusMeters, limit int, after *pagination.Cursor, filter repository.NearbyFilter) ([]repository.ParcelWithDistance, int, error) {
+	args := m.Called(ctx, point, radiusMeters, limit, after, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
 	parcels, ok := args.Get(0).([]repository.ParcelWithDistance)
+	if !ok {
+		return nil, 0, args.Error(2)
+	}
+	return parcels, args.Int(1), args.Error(2)
+}
+
+func (m *MockParcelRepository) SearchByAddress(ctx context.Context, address string, limit int, after *pagination.Cursor) ([]repository.ParcelWithScore, int, error) {
+	args := m.Called(ctx, address, limit, after)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	matches, ok := args.Get(0).([]repository.ParcelWithScore)
+	if !ok {
+		return nil, 0, args.Error(2)
+	}
+	return matches, args.Int(1), args.Error(2)
+}
+
+func (m *MockParcelRepository) IdentifyByPoint(ctx context.Context, point models.LatLng) (*repository.ParcelSummary, error) {
+	args := m.Called(ctx, point)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	summary, ok := args.Get(0).(*repository.ParcelSummary)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return summary, args.Error(1)
+}
+
+func (m *MockParcelRepository) IdentifyByPoints(ctx context.Context, points []models.LatLng) ([]*repository.ParcelSummary, error) {
+	args := m.Called(ctx, points)
+	summaries, _ := args.Get(0).([]*repository.ParcelSummary)
+	return summaries, args.Error(1)
+}
+
+func (m *MockParcelRepository) FindByIDs(ctx context.Context, ids []uint) ([]repository.ParcelWithMetrics, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	parcels, ok := args.Get(0).([]repository.ParcelWithMetrics)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return parcels, args.Error(1)
+}
+
+func (m *MockParcelRepository) FindNearestAmenities(ctx context.Context, ids []uint) (map[uint][]repository.NearestAmenity, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	amenities, ok := args.Get(0).(map[uint][]repository.NearestAmenity)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return amenities, args.Error(1)
+}
+
+func (m *MockParcelRepository) DerivedAttributes() []config.DerivedAttribute {
+	args := m.Called()
+	attributes, _ := args.Get(0).([]config.DerivedAttribute)
+	return attributes
+}
+
+func (m *MockParcelRepository) ValidateArea(ctx context.Context, area models.MultiPolygon) (string, error) {
+	args := m.Called(ctx, area)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockParcelRepository) FindIntersecting(ctx context.Context, area models.MultiPolygon, limit int) ([]models.TaxParcel, error) {
+	args := m.Called(ctx, area, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	parcels, ok := args.Get(0).([]models.TaxParcel)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return parcels, args.Error(1)
+}
+
+func (m *MockParcelRepository) FindByOwner(ctx context.Context, ownerID uint, limit int, after *pagination.Cursor) ([]models.TaxParcel, int, error) {
+	args := m.Called(ctx, ownerID, limit, after)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	parcels, ok := args.Get(0).([]models.TaxParcel)
+	if !ok {
+		return nil, 0, args.Error(2)
+	}
+	return parcels, args.Int(1), args.Error(2)
+}
+
+func (m *MockParcelRepository) DissolveByIDs(ctx context.Context, ids []uint) (*repository.DissolvedArea, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	area, ok := args.Get(0).(*repository.DissolvedArea)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return area, args.Error(1)
+}
+
+func (m *MockParcelRepository) FindAdjacency(ctx context.Context, area models.MultiPolygon, limit int) ([]repository.AdjacentParcel, error) {
+	args := m.Called(ctx, area, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	parcels, ok := args.Get(0).([]repository.AdjacentParcel)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return parcels, args.Error(1)
+}
+
+func (m *MockParcelRepository) SampleParcels(ctx context.Context, n int) ([]repository.ParcelSample, error) {
+	args := m.Called(ctx, n)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	samples, ok := args.Get(0).([]repository.ParcelSample)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return samples, args.Error(1)
+}
+
+func (m *MockParcelRepository) FindAlongRoute(ctx context.Context, route models.LineString, bufferMeters float64, limit int) ([]repository.ParcelAlongRoute, error) {
+	args := m.Called(ctx, route, bufferMeters, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	parcels, ok := args.Get(0).([]repository.ParcelAlongRoute)
 	if !ok {
 		return nil, args.Error(1)
 	}
@@ -47,7 +208,7 @@ func TestGetParcelAtPoint_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockParcelRepository)
 	log := logger.New("test")
-	service := NewParcelService(mockRepo, log)
+	service := NewParcelService(mockRepo, nil, log)
 
 	ctx := context.Background()
 	lat, lng := 30.3477, -95.4502
@@ -62,10 +223,10 @@ func TestGetParcelAtPoint_Success(t *testing.T) {
 		UpdatedAt:  time.Now(),
 	}
 
-	mockRepo.On("FindByPoint", ctx, lat, lng).Return(expectedParcel, nil)
+	mockRepo.On("FindByPoint", ctx, models.LatLng{Lat: lat, Lng: lng}).Return(expectedParcel, nil)
 
 	// Act
-	parcel, err := service.GetParcelAtPoint(ctx, lat, lng)
+	parcel, err := service.GetParcelAtPoint(ctx, models.LatLng{Lat: lat, Lng: lng})
 
 	// Assert
 	require.NoError(t, err)
@@ -79,16 +240,16 @@ func TestGetParcelAtPoint_NotFound(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockParcelRepository)
 	log := logger.New("test")
-	service := NewParcelService(mockRepo, log)
+	service := NewParcelService(mockRepo, nil, log)
 
 	ctx := context.Background()
 	lat, lng := 30.3477, -95.4502
 
 	// Repository returns nil, nil when no parcel found
-	mockRepo.On("FindByPoint", ctx, lat, lng).Return(nil, nil)
+	mockRepo.On("FindByPoint", ctx, models.LatLng{Lat: lat, Lng: lng}).Return(nil, nil)
 
 	// Act
-	parcel, err := service.GetParcelAtPoint(ctx, lat, lng)
+	parcel, err := service.GetParcelAtPoint(ctx, models.LatLng{Lat: lat, Lng: lng})
 
 	// Assert
 	assert.Error(t, err)
@@ -97,17 +258,56 @@ func TestGetParcelAtPoint_NotFound(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestGetParcel(t *testing.T) {
+	ctx := context.Background()
+	log := logger.New("test")
+
+	t.Run("found", func(t *testing.T) {
+		mockRepo := new(MockParcelRepository)
+		mockRepo.On("FindByIDs", ctx, []uint{7}).Return([]repository.ParcelWithMetrics{
+			{Parcel: models.TaxParcel{ID: 7, CountyName: "Montgomery"}},
+		}, nil)
+
+		parcel, err := NewParcelService(mockRepo, nil, log).GetParcel(ctx, 7)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(7), parcel.ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockRepo := new(MockParcelRepository)
+		mockRepo.On("FindByIDs", ctx, []uint{7}).Return([]repository.ParcelWithMetrics{}, nil)
+
+		_, err := NewParcelService(mockRepo, nil, log).GetParcel(ctx, 7)
+
+		assert.ErrorIs(t, err, ErrParcelNotFound)
+	})
+
+	t.Run("corrupt geometry", func(t *testing.T) {
+		corrupt := errors.New("failed to parse geometry for parcel 7")
+		mockRepo := new(MockParcelRepository)
+		mockRepo.On("FindByIDs", ctx, []uint{7}).Return([]repository.ParcelWithMetrics{
+			{Parcel: models.TaxParcel{ID: 7}, Err: corrupt},
+		}, nil)
+
+		_, err := NewParcelService(mockRepo, nil, log).GetParcel(ctx, 7)
+
+		assert.ErrorIs(t, err, corrupt)
+	})
+}
+
 func TestGetParcelAtPoint_InvalidLatitude_TooHigh(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockParcelRepository)
 	log := logger.New("test")
-	service := NewParcelService(mockRepo, log)
+	service := NewParcelService(mockRepo, nil, log)
 
 	ctx := context.Background()
 	lat, lng := 91.0, -95.4502 // Latitude > 90
 
 	// Act
-	parcel, err := service.GetParcelAtPoint(ctx, lat, lng)
+	parcel, err := service.GetParcelAtPoint(ctx, models.LatLng{Lat: lat, Lng: lng})
 
 	// Assert
 	assert.Error(t, err)
@@ -122,13 +322,13 @@ func TestGetParcelAtPoint_InvalidLatitude_TooLow(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockParcelRepository)
 	log := logger.New("test")
-	service := NewParcelService(mockRepo, log)
+	service := NewParcelService(mockRepo, nil, log)
 
 	ctx := context.Background()
 	lat, lng := -91.0, -95.4502 // Latitude < -90
 
 	// Act
-	parcel, err := service.GetParcelAtPoint(ctx, lat, lng)
+	parcel, err := service.GetParcelAtPoint(ctx, models.LatLng{Lat: lat, Lng: lng})
 
 	// Assert
 	assert.Error(t, err)
@@ -142,13 +342,13 @@ func TestGetParcelAtPoint_InvalidLongitude_TooHigh(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockParcelRepository)
 	log := logger.New("test")
-	service := NewParcelService(mockRepo, log)
+	service := NewParcelService(mockRepo, nil, log)
 
 	ctx := context.Background()
 	lat, lng := 30.3477, 181.0 // Longitude > 180
 
 	// Act
-	parcel, err := service.GetParcelAtPoint(ctx, lat, lng)
+	parcel, err := service.GetParcelAtPoint(ctx, models.LatLng{Lat: lat, Lng: lng})
 
 	// Assert
 	assert.Error(t, err)
@@ -162,13 +362,13 @@ func TestGetParcelAtPoint_InvalidLongitude_TooLow(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockParcelRepository)
 	log := logger.New("test")
-	service := NewParcelService(mockRepo, log)
+	service := NewParcelService(mockRepo, nil, log)
 
 	ctx := context.Background()
 	lat, lng := 30.3477, -181.0 // Longitude < -180
 
 	// Act
-	parcel, err := service.GetParcelAtPoint(ctx, lat, lng)
+	parcel, err := service.GetParcelAtPoint(ctx, models.LatLng{Lat: lat, Lng: lng})
 
 	// Assert
 	assert.Error(t, err)
@@ -182,16 +382,16 @@ func TestGetParcelAtPoint_RepositoryError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockParcelRepository)
 	log := logger.New("test")
-	service := NewParcelService(mockRepo, log)
+	service := NewParcelService(mockRepo, nil, log)
 
 	ctx := context.Background()
 	lat, lng := 30.3477, -95.4502
 
 	dbError := errors.New("database connection failed")
-	mockRepo.On("FindByPoint", ctx, lat, lng).Return(nil, dbError)
+	mockRepo.On("FindByPoint", ctx, models.LatLng{Lat: lat, Lng: lng}).Return(nil, dbError)
 
 	// Act
-	parcel, err := service.GetParcelAtPoint(ctx, lat, lng)
+	parcel, err := service.GetParcelAtPoint(ctx, models.LatLng{Lat: lat, Lng: lng})
 
 	// Assert
 	assert.Error(t, err)
@@ -205,17 +405,17 @@ func TestGetParcelAtPoint_ContextCancellation(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockParcelRepository)
 	log := logger.New("test")
-	service := NewParcelService(mockRepo, log)
+	service := NewParcelService(mockRepo, nil, log)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel() // Cancel context immediately
 
 	lat, lng := 30.3477, -95.4502
 
-	mockRepo.On("FindByPoint", ctx, lat, lng).Return(nil, context.Canceled)
+	mockRepo.On("FindByPoint", ctx, models.LatLng{Lat: lat, Lng: lng}).Return(nil, context.Canceled)
 
 	// Act
-	parcel, err := service.GetParcelAtPoint(ctx, lat, lng)
+	parcel, err := service.GetParcelAtPoint(ctx, models.LatLng{Lat: lat, Lng: lng})
 
 	// Assert
 	assert.Error(t, err)
@@ -269,16 +469,16 @@ func TestGetParcelAtPoint_BoundaryValues(t *testing.T) {
 			// Arrange
 			mockRepo := new(MockParcelRepository)
 			log := logger.New("test")
-			service := NewParcelService(mockRepo, log)
+			service := NewParcelService(mockRepo, nil, log)
 
 			ctx := context.Background()
 
 			if !tc.expectErr {
-				mockRepo.On("FindByPoint", ctx, tc.lat, tc.lng).Return(nil, nil)
+				mockRepo.On("FindByPoint", ctx, models.LatLng{Lat: tc.lat, Lng: tc.lng}).Return(nil, nil)
 			}
 
 			// Act
-			parcel, err := service.GetParcelAtPoint(ctx, tc.lat, tc.lng)
+			parcel, err := service.GetParcelAtPoint(ctx, models.LatLng{Lat: tc.lat, Lng: tc.lng})
 
 			// Assert
 			if tc.expectErr {
@@ -294,6 +494,145 @@ func TestGetParcelAtPoint_BoundaryValues(t *testing.T) {
 	}
 }
 
+func TestGetParcelCandidatesAtPoint_CertainMatch(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+	ctx := context.Background()
+	point := models.LatLng{Lat: 30.3477, Lng: -95.4502}
+
+	candidates := []repository.ParcelCandidate{
+		{Parcel: models.TaxParcel{ID: 1}, Probability: 0.9999, ContainsPoint: true},
+	}
+	mockRepo.On("FindCandidatesNearPoint", ctx, point, 5.0, MaxParcelCandidates).Return(candidates, nil)
+
+	// Act
+	result, err := service.GetParcelCandidatesAtPoint(ctx, point, 5)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), result.Parcel.ID)
+	assert.Empty(t, result.Candidates)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetParcelCandidatesAtPoint_NearBoundary(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+	ctx := context.Background()
+	point := models.LatLng{Lat: 30.3477, Lng: -95.4502}
+
+	// The point lies in the less likely parcel, so it is still the primary match
+	candidates := []repository.ParcelCandidate{
+		{Parcel: models.TaxParcel{ID: 1}, Probability: 0.6},
+		{Parcel: models.TaxParcel{ID: 2}, Probability: 0.4, ContainsPoint: true},
+	}
+	mockRepo.On("FindCandidatesNearPoint", ctx, point, 20.0, MaxParcelCandidates).Return(candidates, nil)
+
+	// Act
+	result, err := service.GetParcelCandidatesAtPoint(ctx, point, 20)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), result.Parcel.ID)
+	assert.Equal(t, candidates, result.Candidates)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetParcelCandidatesAtPoint_OutsideParcels(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+	ctx := context.Background()
+	point := models.LatLng{Lat: 30.3477, Lng: -95.4502}
+
+	// A point in a road right-of-way: one parcel covers part of the accuracy circle
+	candidates := []repository.ParcelCandidate{
+		{Parcel: models.TaxParcel{ID: 3}, Probability: 0.3},
+	}
+	mockRepo.On("FindCandidatesNearPoint", ctx, point, 10.0, MaxParcelCandidates).Return(candidates, nil)
+
+	// Act
+	result, err := service.GetParcelCandidatesAtPoint(ctx, point, 10)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, uint(3), result.Parcel.ID)
+	assert.Len(t, result.Candidates, 1)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetParcelCandidatesAtPoint_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+	ctx := context.Background()
+	point := models.LatLng{Lat: 30.3477, Lng: -95.4502}
+
+	mockRepo.On("FindCandidatesNearPoint", ctx, point, 10.0, MaxParcelCandidates).
+		Return([]repository.ParcelCandidate{}, nil)
+
+	// Act
+	result, err := service.GetParcelCandidatesAtPoint(ctx, point, 10)
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, ErrParcelNotFound)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetParcelCandidatesAtPoint_InvalidInput(t *testing.T) {
+	testCases := []struct {
+		expected error
+		name     string
+		point    models.LatLng
+		accuracy float64
+	}{
+		{name: "zero accuracy", point: models.LatLng{Lat: 30.3477, Lng: -95.4502}, accuracy: 0, expected: ErrInvalidAccuracy},
+		{name: "negative accuracy", point: models.LatLng{Lat: 30.3477, Lng: -95.4502}, accuracy: -5, expected: ErrInvalidAccuracy},
+		{name: "accuracy too large", point: models.LatLng{Lat: 30.3477, Lng: -95.4502}, accuracy: 100.5, expected: ErrInvalidAccuracy},
+		{name: "invalid coordinates", point: models.LatLng{Lat: 91, Lng: -95.4502}, accuracy: 10, expected: ErrInvalidCoordinates},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockParcelRepository)
+			service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+			result, err := service.GetParcelCandidatesAtPoint(context.Background(), tc.point, tc.accuracy)
+
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tc.expected)
+			mockRepo.AssertNotCalled(t, "FindCandidatesNearPoint")
+		})
+	}
+}
+
+func TestGetParcelCandidatesAtPoint_RepositoryError(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+	ctx := context.Background()
+	point := models.LatLng{Lat: 30.3477, Lng: -95.4502}
+
+	dbError := errors.New("database connection failed")
+	mockRepo.On("FindCandidatesNearPoint", ctx, point, 10.0, MaxParcelCandidates).Return(nil, dbError)
+
+	// Act
+	result, err := service.GetParcelCandidatesAtPoint(ctx, point, 10)
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, dbError)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestCoordinateConstants(t *testing.T) {
 	// Verify constants are set correctly
 	assert.Equal(t, -90.0, MinLatitude)
@@ -306,7 +645,7 @@ func TestGetNearbyParcels_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockParcelRepository)
 	log := logger.New("test")
-	service := NewParcelService(mockRepo, log)
+	service := NewParcelService(mockRepo, nil, log)
 
 	ctx := context.Background()
 	lat, lng := 30.3477, -95.4502
@@ -338,17 +677,19 @@ func TestGetNearbyParcels_Success(t *testing.T) {
 		},
 	}
 
-	mockRepo.On("FindNearby", ctx, lat, lng, radiusMeters).Return(expectedParcels, nil)
+	mockRepo.On("FindNearby", ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit+1, noCursor, noFilter).Return(expectedParcels, len(expectedParcels), nil)
 
 	// Act
-	parcels, err := service.GetNearbyParcels(ctx, lat, lng, radiusMeters)
+	page, err := service.GetNearbyParcels(ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit, "", noFilter)
 
 	// Assert
 	require.NoError(t, err)
-	assert.NotNil(t, parcels)
-	assert.Len(t, parcels, 2)
-	assert.Equal(t, expectedParcels[0].Parcel.ID, parcels[0].Parcel.ID)
-	assert.Equal(t, expectedParcels[0].Distance, parcels[0].Distance)
+	require.NotNil(t, page)
+	assert.Len(t, page.Parcels, 2)
+	assert.Equal(t, expectedParcels[0].Parcel.ID, page.Parcels[0].Parcel.ID)
+	assert.Equal(t, expectedParcels[0].Distance, page.Parcels[0].Distance)
+	assert.Equal(t, 2, page.TotalCount)
+	assert.Empty(t, page.NextCursor)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -356,22 +697,24 @@ func TestGetNearbyParcels_EmptyResults(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockParcelRepository)
 	log := logger.New("test")
-	service := NewParcelService(mockRepo, log)
+	service := NewParcelService(mockRepo, nil, log)
 
 	ctx := context.Background()
 	lat, lng := 30.3477, -95.4502
 	radiusMeters := 1000
 
 	emptyResults := []repository.ParcelWithDistance{}
-	mockRepo.On("FindNearby", ctx, lat, lng, radiusMeters).Return(emptyResults, nil)
+	mockRepo.On("FindNearby", ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit+1, noCursor, noFilter).Return(emptyResults, len(emptyResults), nil)
 
 	// Act
-	parcels, err := service.GetNearbyParcels(ctx, lat, lng, radiusMeters)
+	page, err := service.GetNearbyParcels(ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit, "", noFilter)
 
 	// Assert
 	require.NoError(t, err)
-	assert.NotNil(t, parcels)
-	assert.Len(t, parcels, 0)
+	require.NotNil(t, page)
+	assert.NotNil(t, page.Parcels)
+	assert.Len(t, page.Parcels, 0)
+	assert.Zero(t, page.TotalCount)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -379,18 +722,18 @@ func TestGetNearbyParcels_InvalidLatitude_TooHigh(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockParcelRepository)
 	log := logger.New("test")
-	service := NewParcelService(mockRepo, log)
+	service := NewParcelService(mockRepo, nil, log)
 
 	ctx := context.Background()
 	lat, lng := 91.0, -95.4502 // Latitude > 90
 	radiusMeters := 1000
 
 	// Act
-	parcels, err := service.GetNearbyParcels(ctx, lat, lng, radiusMeters)
+	page, err := service.GetNearbyParcels(ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit, "", noFilter)
 
 	// Assert
 	assert.Error(t, err)
-	assert.Nil(t, parcels)
+	assert.Nil(t, page)
 	assert.ErrorIs(t, err, ErrInvalidCoordinates)
 	assert.Contains(t, err.Error(), "latitude must be between")
 	mockRepo.AssertNotCalled(t, "FindNearby")
@@ -400,18 +743,18 @@ func TestGetNearbyParcels_InvalidLatitude_TooLow(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockParcelRepository)
 	log := logger.New("test")
-	service := NewParcelService(mockRepo, log)
+	service := NewParcelService(mockRepo, nil, log)
 
 	ctx := context.Background()
 	lat, lng := -91.0, -95.4502 // Latitude < -90
 	radiusMeters := 1000
 
 	// Act
-	parcels, err := service.GetNearbyParcels(ctx, lat, lng, radiusMeters)
+	page, err := service.GetNearbyParcels(ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit, "", noFilter)
 
 	// Assert
 	assert.Error(t, err)
-	assert.Nil(t, parcels)
+	assert.Nil(t, page)
 	assert.ErrorIs(t, err, ErrInvalidCoordinates)
 	assert.Contains(t, err.Error(), "latitude must be between")
 	mockRepo.AssertNotCalled(t, "FindNearby")
@@ -421,18 +764,18 @@ func TestGetNearbyParcels_InvalidLongitude_TooHigh(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockParcelRepository)
 	log := logger.New("test")
-	service := NewParcelService(mockRepo, log)
+	service := NewParcelService(mockRepo, nil, log)
 
 	ctx := context.Background()
 	lat, lng := 30.3477, 181.0 // Longitude > 180
 	radiusMeters := 1000
 
 	// Act
-	parcels, err := service.GetNearbyParcels(ctx, lat, lng, radiusMeters)
+	page, err := service.GetNearbyParcels(ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit, "", noFilter)
 
 	// Assert
 	assert.Error(t, err)
-	assert.Nil(t, parcels)
+	assert.Nil(t, page)
 	assert.ErrorIs(t, err, ErrInvalidCoordinates)
 	assert.Contains(t, err.Error(), "longitude must be between")
 	mockRepo.AssertNotCalled(t, "FindNearby")
@@ -442,18 +785,18 @@ func TestGetNearbyParcels_InvalidLongitude_TooLow(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockParcelRepository)
 	log := logger.New("test")
-	service := NewParcelService(mockRepo, log)
+	service := NewParcelService(mockRepo, nil, log)
 
 	ctx := context.Background()
 	lat, lng := 30.3477, -181.0 // Longitude < -180
 	radiusMeters := 1000
 
 	// Act
-	parcels, err := service.GetNearbyParcels(ctx, lat, lng, radiusMeters)
+	page, err := service.GetNearbyParcels(ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit, "", noFilter)
 
 	// Assert
 	assert.Error(t, err)
-	assert.Nil(t, parcels)
+	assert.Nil(t, page)
 	assert.ErrorIs(t, err, ErrInvalidCoordinates)
 	assert.Contains(t, err.Error(), "longitude must be between")
 	mockRepo.AssertNotCalled(t, "FindNearby")
@@ -463,18 +806,18 @@ func TestGetNearbyParcels_InvalidRadius_TooSmall(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockParcelRepository)
 	log := logger.New("test")
-	service := NewParcelService(mockRepo, log)
+	service := NewParcelService(mockRepo, nil, log)
 
 	ctx := context.Background()
 	lat, lng := 30.3477, -95.4502
-	radiusMeters := 0 // Radius < 1
+	radiusMeters := -1 // Radius < 1; zero selects the default
 
 	// Act
-	parcels, err := service.GetNearbyParcels(ctx, lat, lng, radiusMeters)
+	page, err := service.GetNearbyParcels(ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit, "", noFilter)
 
 	// Assert
 	assert.Error(t, err)
-	assert.Nil(t, parcels)
+	assert.Nil(t, page)
 	assert.ErrorIs(t, err, ErrInvalidRadius)
 	mockRepo.AssertNotCalled(t, "FindNearby")
 }
@@ -483,18 +826,18 @@ func TestGetNearbyParcels_InvalidRadius_TooLarge(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockParcelRepository)
 	log := logger.New("test")
-	service := NewParcelService(mockRepo, log)
+	service := NewParcelService(mockRepo, nil, log)
 
 	ctx := context.Background()
 	lat, lng := 30.3477, -95.4502
 	radiusMeters := 5001 // Radius > 5000
 
 	// Act
-	parcels, err := service.GetNearbyParcels(ctx, lat, lng, radiusMeters)
+	page, err := service.GetNearbyParcels(ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit, "", noFilter)
 
 	// Assert
 	assert.Error(t, err)
-	assert.Nil(t, parcels)
+	assert.Nil(t, page)
 	assert.ErrorIs(t, err, ErrInvalidRadius)
 	mockRepo.AssertNotCalled(t, "FindNearby")
 }
@@ -503,21 +846,21 @@ func TestGetNearbyParcels_RepositoryError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockParcelRepository)
 	log := logger.New("test")
-	service := NewParcelService(mockRepo, log)
+	service := NewParcelService(mockRepo, nil, log)
 
 	ctx := context.Background()
 	lat, lng := 30.3477, -95.4502
 	radiusMeters := 1000
 
 	dbError := errors.New("database connection failed")
-	mockRepo.On("FindNearby", ctx, lat, lng, radiusMeters).Return(nil, dbError)
+	mockRepo.On("FindNearby", ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit+1, noCursor, noFilter).Return(nil, 0, dbError)
 
 	// Act
-	parcels, err := service.GetNearbyParcels(ctx, lat, lng, radiusMeters)
+	page, err := service.GetNearbyParcels(ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit, "", noFilter)
 
 	// Assert
 	assert.Error(t, err)
-	assert.Nil(t, parcels)
+	assert.Nil(t, page)
 	assert.Contains(t, err.Error(), "failed to query nearby parcels")
 	assert.ErrorIs(t, err, dbError)
 	mockRepo.AssertExpectations(t)
@@ -527,7 +870,7 @@ func TestGetNearbyParcels_ContextCancellation(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockParcelRepository)
 	log := logger.New("test")
-	service := NewParcelService(mockRepo, log)
+	service := NewParcelService(mockRepo, nil, log)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel() // Cancel context immediately
@@ -535,14 +878,14 @@ func TestGetNearbyParcels_ContextCancellation(t *testing.T) {
 	lat, lng := 30.3477, -95.4502
 	radiusMeters := 1000
 
-	mockRepo.On("FindNearby", ctx, lat, lng, radiusMeters).Return(nil, context.Canceled)
+	mockRepo.On("FindNearby", ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit+1, noCursor, noFilter).Return(nil, 0, context.Canceled)
 
 	// Act
-	parcels, err := service.GetNearbyParcels(ctx, lat, lng, radiusMeters)
+	page, err := service.GetNearbyParcels(ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit, "", noFilter)
 
 	// Assert
 	assert.Error(t, err)
-	assert.Nil(t, parcels)
+	assert.Nil(t, page)
 	assert.ErrorIs(t, err, context.Canceled)
 	mockRepo.AssertExpectations(t)
 }
@@ -623,28 +966,28 @@ func TestGetNearbyParcels_BoundaryValues(t *testing.T) {
 			// Arrange
 			mockRepo := new(MockParcelRepository)
 			log := logger.New("test")
-			service := NewParcelService(mockRepo, log)
+			service := NewParcelService(mockRepo, nil, log)
 
 			ctx := context.Background()
 
 			if !tc.expectErr {
-				mockRepo.On("FindNearby", ctx, tc.lat, tc.lng, tc.radiusMeters).
-					Return([]repository.ParcelWithDistance{}, nil)
+				mockRepo.On("FindNearby", ctx, models.LatLng{Lat: tc.lat, Lng: tc.lng}, tc.radiusMeters, testNearbyLimit+1, noCursor, noFilter).
+					Return([]repository.ParcelWithDistance{}, 0, nil)
 			}
 
 			// Act
-			parcels, err := service.GetNearbyParcels(ctx, tc.lat, tc.lng, tc.radiusMeters)
+			page, err := service.GetNearbyParcels(ctx, models.LatLng{Lat: tc.lat, Lng: tc.lng}, tc.radiusMeters, testNearbyLimit, "", noFilter)
 
 			// Assert
 			if tc.expectErr {
 				assert.Error(t, err)
-				assert.Nil(t, parcels)
+				assert.Nil(t, page)
 				if tc.errType != nil {
 					assert.ErrorIs(t, err, tc.errType)
 				}
 			} else {
 				assert.NoError(t, err)
-				assert.NotNil(t, parcels)
+				assert.NotNil(t, page)
 				mockRepo.AssertExpectations(t)
 			}
 		})
@@ -656,3 +999,1130 @@ func TestRadiusConstants(t *testing.T) {
 	assert.Equal(t, 1, MinRadiusMeters)
 	assert.Equal(t, 5000, MaxRadiusMeters)
 }
+
+func TestGetNearbyParcels_NextCursor(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	log := logger.New("test")
+	service := NewParcelService(mockRepo, nil, log)
+
+	ctx := context.Background()
+	lat, lng := 30.3477, -95.4502
+	radiusMeters := 1000
+	limit := 2
+
+	// The repository is asked for one extra row to detect another page
+	rows := []repository.ParcelWithDistance{
+		{Parcel: models.TaxParcel{ID: 1}, Distance: 10},
+		{Parcel: models.TaxParcel{ID: 2}, Distance: 20},
+		{Parcel: models.TaxParcel{ID: 3}, Distance: 30},
+	}
+	mockRepo.On("FindNearby", ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, limit+1, noCursor, noFilter).Return(rows, 7, nil)
+
+	// Act
+	page, err := service.GetNearbyParcels(ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, limit, "", noFilter)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, page.Parcels, 2)
+	assert.Equal(t, 7, page.TotalCount)
+
+	cursor, err := pagination.Decode(page.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, &pagination.Cursor{Key: 20, ID: 2}, cursor)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetNearbyParcels_WithCursor(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	log := logger.New("test")
+	service := NewParcelService(mockRepo, nil, log)
+
+	ctx := context.Background()
+	lat, lng := 30.3477, -95.4502
+	radiusMeters := 1000
+	after := pagination.Cursor{Key: 20, ID: 2}
+
+	rows := []repository.ParcelWithDistance{
+		{Parcel: models.TaxParcel{ID: 3}, Distance: 30},
+	}
+	mockRepo.On("FindNearby", ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit+1, &after, noFilter).Return(rows, 3, nil)
+
+	// Act
+	page, err := service.GetNearbyParcels(ctx, models.LatLng{Lat: lat, Lng: lng}, radiusMeters, testNearbyLimit, after.Encode(), noFilter)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, page.Parcels, 1)
+	assert.Empty(t, page.NextCursor, "last page should not have a next cursor")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetNearbyParcels_InvalidPagination(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cursor  string
+		errType error
+		limit   int
+	}{
+		{"limit too small", "", ErrInvalidLimit, 0},
+		{"limit too large", "", ErrInvalidLimit, MaxNearbyLimit + 1},
+		{"malformed cursor", "not-a-cursor", pagination.ErrInvalidCursor, testNearbyLimit},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockParcelRepository)
+			log := logger.New("test")
+			service := NewParcelService(mockRepo, nil, log)
+
+			// Act
+			page, err := service.GetNearbyParcels(context.Background(), models.LatLng{Lat: 30.3477, Lng: -95.4502}, 1000, tc.limit, tc.cursor, noFilter)
+
+			// Assert
+			assert.Nil(t, page)
+			assert.ErrorIs(t, err, tc.errType)
+			mockRepo.AssertNotCalled(t, "FindNearby")
+		})
+	}
+}
+
+func TestGetNearbyParcels_FilterNormalized(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	log := logger.New("test")
+	service := NewParcelService(mockRepo, nil, log)
+
+	ctx := context.Background()
+	point := models.LatLng{Lat: 30.3477, Lng: -95.4502}
+	minAcres := 0.5
+	minYear := 1990
+	maxYear := 2010
+	filter := repository.NearbyFilter{
+		MinAcres:     &minAcres,
+		MinYearBuilt: &minYear,
+		MaxYearBuilt: &maxYear,
+		LandUse:      " a1 ",
+		OwnerName:    "  smith ",
+	}
+	want := filter
+	want.LandUse = "a1"
+	want.OwnerName = "smith"
+
+	mockRepo.On("FindNearby", ctx, point, 1000, testNearbyLimit+1, noCursor, want).
+		Return([]repository.ParcelWithDistance{}, 0, nil)
+
+	// Act
+	page, err := service.GetNearbyParcels(ctx, point, 1000, testNearbyLimit, "", filter)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, page.Parcels)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetNearbyParcels_InvalidFilter(t *testing.T) {
+	negative := -1.0
+	small := 1.0
+	large := 5.0
+	early := 1980
+	late := 2000
+	twoMiles := 3218.7
+	tooFar := MaxAmenityWithinMeters + 1.0
+
+	testCases := []struct {
+		name   string
+		filter repository.NearbyFilter
+	}{
+		{"negative acres", repository.NearbyFilter{MinAcres: &negative}},
+		{"inverted acres", repository.NearbyFilter{MinAcres: &large, MaxAcres: &small}},
+		{"inverted year built", repository.NearbyFilter{MinYearBuilt: &late, MaxYearBuilt: &early}},
+		{"owner name too long", repository.NearbyFilter{OwnerName: strings.Repeat("a", MaxOwnerNameFilterLength+1)}},
+		{"amenity without distance", repository.NearbyFilter{Amenity: models.AmenityFireStation}},
+		{"distance without amenity", repository.NearbyFilter{AmenityWithinMeters: &twoMiles}},
+		{"unknown amenity", repository.NearbyFilter{Amenity: "library", AmenityWithinMeters: &twoMiles}},
+		{"amenity too far", repository.NearbyFilter{Amenity: models.AmenitySchool, AmenityWithinMeters: &tooFar}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockParcelRepository)
+			log := logger.New("test")
+			service := NewParcelService(mockRepo, nil, log)
+
+			// Act
+			page, err := service.GetNearbyParcels(context.Background(), models.LatLng{Lat: 30.3477, Lng: -95.4502}, 1000, testNearbyLimit, "", tc.filter)
+
+			// Assert
+			assert.Nil(t, page)
+			assert.ErrorIs(t, err, ErrInvalidFilter)
+			mockRepo.AssertNotCalled(t, "FindNearby")
+		})
+	}
+}
+
+// testDerivedAttributes are the derived attributes configured for attribute filter tests.
+var testDerivedAttributes = []config.DerivedAttribute{
+	{Name: "improvement_ratio", SQL: "imprv_main_area / 2.0", Type: config.DerivedFloat, Stage: config.DerivedAtQuery},
+	{Name: "building_age", SQL: "2026 - imprv_actual_year_built", Type: config.DerivedInt, Stage: config.DerivedAtIngest},
+	{Name: "homestead", SQL: "exemptions ILIKE '%HS%'", Type: config.DerivedBool, Stage: config.DerivedAtQuery},
+	{Name: "zoning", SQL: "upper(as_code)", Type: config.DerivedText, Stage: config.DerivedAtQuery},
+}
+
+func TestGetNearbyParcels_AttributeFilters(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+	ctx := context.Background()
+	point := models.LatLng{Lat: 30.3477, Lng: -95.4502}
+	noCursor := (*pagination.Cursor)(nil)
+	filter := repository.NearbyFilter{Attributes: []repository.AttributeFilter{
+		{Name: "improvement_ratio", Op: repository.AttributeMin, Value: "1500.5"},
+		{Name: "building_age", Op: repository.AttributeMax, Value: " 20 "},
+		{Name: "homestead", Op: repository.AttributeEquals, Value: "true"},
+		{Name: "zoning", Op: repository.AttributeEquals, Value: "A1"},
+	}}
+	want := repository.NearbyFilter{Attributes: []repository.AttributeFilter{
+		{Name: "improvement_ratio", Op: repository.AttributeMin, Value: 1500.5},
+		{Name: "building_age", Op: repository.AttributeMax, Value: int64(20)},
+		{Name: "homestead", Op: repository.AttributeEquals, Value: true},
+		{Name: "zoning", Op: repository.AttributeEquals, Value: "A1"},
+	}}
+
+	mockRepo.On("DerivedAttributes").Return(testDerivedAttributes)
+	mockRepo.On("FindNearby", ctx, point, 1000, testNearbyLimit+1, noCursor, want).
+		Return([]repository.ParcelWithDistance{}, 0, nil)
+
+	// Act
+	page, err := service.GetNearbyParcels(ctx, point, 1000, testNearbyLimit, "", filter)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, page.Parcels)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetNearbyParcels_InvalidAttributeFilter(t *testing.T) {
+	tooMany := make([]repository.AttributeFilter, MaxAttributeFilters+1)
+	for i := range tooMany {
+		tooMany[i] = repository.AttributeFilter{Name: "building_age", Op: repository.AttributeMin, Value: "1"}
+	}
+
+	testCases := []struct {
+		name    string
+		filters []repository.AttributeFilter
+	}{
+		{"unknown attribute", []repository.AttributeFilter{{Name: "value_per_acre", Op: repository.AttributeEquals, Value: "1"}}},
+		{"range on text", []repository.AttributeFilter{{Name: "zoning", Op: repository.AttributeMin, Value: "A"}}},
+		{"range on bool", []repository.AttributeFilter{{Name: "homestead", Op: repository.AttributeMax, Value: "true"}}},
+		{"not a number", []repository.AttributeFilter{{Name: "improvement_ratio", Op: repository.AttributeMin, Value: "high"}}},
+		{"not an integer", []repository.AttributeFilter{{Name: "building_age", Op: repository.AttributeEquals, Value: "2.5"}}},
+		{"not a bool", []repository.AttributeFilter{{Name: "homestead", Op: repository.AttributeEquals, Value: "maybe"}}},
+		{"too many", tooMany},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockParcelRepository)
+			mockRepo.On("DerivedAttributes").Return(testDerivedAttributes).Maybe()
+			service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+			// Act
+			page, err := service.GetNearbyParcels(context.Background(), models.LatLng{Lat: 30.3477, Lng: -95.4502}, 1000,
+				testNearbyLimit, "", repository.NearbyFilter{Attributes: tc.filters})
+
+			// Assert
+			assert.Nil(t, page)
+			assert.ErrorIs(t, err, ErrInvalidFilter)
+			mockRepo.AssertNotCalled(t, "FindNearby")
+		})
+	}
+}
+
+func TestSearchByAddress_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	log := logger.New("test")
+	service := NewParcelService(mockRepo, nil, log)
+
+	ctx := context.Background()
+	situs := "123 MAIN ST, CONROE TX"
+	expectedMatches := []repository.ParcelWithScore{
+		{
+			Parcel: models.TaxParcel{
+				ID:         1,
+				ObjectID:   101,
+				Situs:      &situs,
+				CountyName: "Montgomery",
+			},
+			Score: 0.92,
+		},
+	}
+
+	mockRepo.On("SearchByAddress", ctx, "123 main", 11, noCursor).Return(expectedMatches, len(expectedMatches), nil)
+
+	// Act - surrounding whitespace is trimmed before querying
+	page, err := service.SearchByAddress(ctx, "  123 main  ", 10, "")
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, page)
+	assert.Len(t, page.Matches, 1)
+	assert.Equal(t, expectedMatches[0].Parcel.ID, page.Matches[0].Parcel.ID)
+	assert.Equal(t, 0.92, page.Matches[0].Score)
+	assert.Equal(t, 1, page.TotalCount)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSearchByAddress_EmptyResults(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	log := logger.New("test")
+	service := NewParcelService(mockRepo, nil, log)
+
+	ctx := context.Background()
+	mockRepo.On("SearchByAddress", ctx, "999 nowhere rd", 11, noCursor).Return([]repository.ParcelWithScore{}, 0, nil)
+
+	// Act
+	page, err := service.SearchByAddress(ctx, "999 nowhere rd", 10, "")
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, page)
+	assert.NotNil(t, page.Matches)
+	assert.Len(t, page.Matches, 0)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSearchByAddress_InvalidInput(t *testing.T) {
+	testCases := []struct {
+		name    string
+		address string
+		limit   int
+		errType error
+	}{
+		{"empty address", "", 10, ErrInvalidAddressQuery},
+		{"whitespace only", "     ", 10, ErrInvalidAddressQuery},
+		{"too short", "ab", 10, ErrInvalidAddressQuery},
+		{"too long", strings.Repeat("a", MaxAddressQueryLength+1), 10, ErrInvalidAddressQuery},
+		{"limit too small", "123 main", 0, ErrInvalidLimit},
+		{"limit too large", "123 main", MaxSearchLimit + 1, ErrInvalidLimit},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockParcelRepository)
+			log := logger.New("test")
+			service := NewParcelService(mockRepo, nil, log)
+
+			// Act
+			page, err := service.SearchByAddress(context.Background(), tc.address, tc.limit, "")
+
+			// Assert
+			assert.Error(t, err)
+			assert.Nil(t, page)
+			assert.ErrorIs(t, err, tc.errType)
+			mockRepo.AssertNotCalled(t, "SearchByAddress")
+		})
+	}
+}
+
+func TestSearchByAddress_RepositoryError(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	log := logger.New("test")
+	service := NewParcelService(mockRepo, nil, log)
+
+	ctx := context.Background()
+	dbError := errors.New("database connection failed")
+	mockRepo.On("SearchByAddress", ctx, "123 main", 11, noCursor).Return(nil, 0, dbError)
+
+	// Act
+	page, err := service.SearchByAddress(ctx, "123 main", 10, "")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, page)
+	assert.Contains(t, err.Error(), "failed to search parcels by address")
+	assert.ErrorIs(t, err, dbError)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSearchByAddress_NextCursor(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	log := logger.New("test")
+	service := NewParcelService(mockRepo, nil, log)
+
+	ctx := context.Background()
+	rows := []repository.ParcelWithScore{
+		{Parcel: models.TaxParcel{ID: 4}, Score: 0.9},
+		{Parcel: models.TaxParcel{ID: 9}, Score: 0.8},
+	}
+	mockRepo.On("SearchByAddress", ctx, "123 main", 2, noCursor).Return(rows, 5, nil)
+
+	// Act
+	page, err := service.SearchByAddress(ctx, "123 main", 1, "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, page.Matches, 1)
+	assert.Equal(t, 5, page.TotalCount)
+
+	cursor, err := pagination.Decode(page.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, &pagination.Cursor{Key: 0.9, ID: 4}, cursor)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSearchByAddress_InvalidCursor(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	log := logger.New("test")
+	service := NewParcelService(mockRepo, nil, log)
+
+	// Act
+	page, err := service.SearchByAddress(context.Background(), "123 main", 10, "%%%")
+
+	// Assert
+	assert.Nil(t, page)
+	assert.ErrorIs(t, err, pagination.ErrInvalidCursor)
+	mockRepo.AssertNotCalled(t, "SearchByAddress")
+}
+
+func TestIdentifyParcelAtPoint_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	log := logger.New("test")
+	service := NewParcelService(mockRepo, nil, log)
+
+	ctx := context.Background()
+	lat, lng := 30.3477, -95.4502
+
+	ownerName := "John Doe"
+	expected := &repository.ParcelSummary{
+		ID:        12345,
+		PIN:       67890,
+		OwnerName: &ownerName,
+		Acres:     1.25,
+	}
+
+	mockRepo.On("IdentifyByPoint", ctx, models.LatLng{Lat: lat, Lng: lng}).Return(expected, nil)
+
+	// Act
+	summary, err := service.IdentifyParcelAtPoint(ctx, models.LatLng{Lat: lat, Lng: lng})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, expected, summary)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestIdentifyParcelAtPoint_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	log := logger.New("test")
+	service := NewParcelService(mockRepo, nil, log)
+
+	ctx := context.Background()
+	lat, lng := 30.3477, -95.4502
+
+	mockRepo.On("IdentifyByPoint", ctx, models.LatLng{Lat: lat, Lng: lng}).Return(nil, nil)
+
+	// Act
+	summary, err := service.IdentifyParcelAtPoint(ctx, models.LatLng{Lat: lat, Lng: lng})
+
+	// Assert
+	assert.Nil(t, summary)
+	assert.ErrorIs(t, err, ErrParcelNotFound)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestIdentifyParcelAtPoint_InvalidCoordinates(t *testing.T) {
+	testCases := []struct {
+		name string
+		lat  float64
+		lng  float64
+	}{
+		{"latitude too high", 91.0, -95.4502},
+		{"latitude too low", -91.0, -95.4502},
+		{"longitude too high", 30.3477, 181.0},
+		{"longitude too low", 30.3477, -181.0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockParcelRepository)
+			log := logger.New("test")
+			service := NewParcelService(mockRepo, nil, log)
+
+			// Act
+			summary, err := service.IdentifyParcelAtPoint(context.Background(), models.LatLng{Lat: tc.lat, Lng: tc.lng})
+
+			// Assert
+			assert.Nil(t, summary)
+			assert.ErrorIs(t, err, ErrInvalidCoordinates)
+			mockRepo.AssertNotCalled(t, "IdentifyByPoint")
+		})
+	}
+}
+
+func TestIdentifyParcelAtPoint_RepositoryError(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	log := logger.New("test")
+	service := NewParcelService(mockRepo, nil, log)
+
+	ctx := context.Background()
+	lat, lng := 30.3477, -95.4502
+
+	dbError := errors.New("database connection failed")
+	mockRepo.On("IdentifyByPoint", ctx, models.LatLng{Lat: lat, Lng: lng}).Return(nil, dbError)
+
+	// Act
+	summary, err := service.IdentifyParcelAtPoint(ctx, models.LatLng{Lat: lat, Lng: lng})
+
+	// Assert
+	assert.Nil(t, summary)
+	assert.Contains(t, err.Error(), "failed to identify parcel")
+	assert.ErrorIs(t, err, dbError)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCompareParcels_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	log := logger.New("test")
+	service := NewParcelService(mockRepo, nil, log)
+
+	ctx := context.Background()
+	ids := []uint{2, 1}
+
+	owner1, owner2 := "John Doe", "Jane Doe"
+	built := 1998
+	landUse := "A1"
+	// Repository returns rows ordered by id
+	found := []repository.ParcelWithMetrics{
+		{Parcel: models.TaxParcel{ID: 1, OwnerName: &owner1, AsCode: &landUse, ImprvActualYearBuilt: &built, CountyName: "Montgomery", Acres: 1.5}},
+		{Parcel: models.TaxParcel{ID: 2, OwnerName: &owner2, AsCode: &landUse, CountyName: "Montgomery", Acres: 2.0}},
+	}
+
+	mockRepo.On("FindByIDs", ctx, ids).Return(found, nil)
+
+	// Act
+	comparison, err := service.CompareParcels(ctx, ids)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, comparison.Parcels, 2)
+	assert.Equal(t, uint(2), comparison.Parcels[0].Parcel.ID)
+	assert.Equal(t, uint(1), comparison.Parcels[1].Parcel.ID)
+
+	diffs := make(map[string]AttributeDifference)
+	for _, d := range comparison.Differences {
+		diffs[d.Field] = d
+	}
+
+	assert.True(t, diffs["owner_name"].Differs)
+	assert.Equal(t, []interface{}{"Jane Doe", "John Doe"}, diffs["owner_name"].Values)
+	assert.Nil(t, diffs["owner_name"].Deltas)
+
+	assert.False(t, diffs["land_use"].Differs)
+	assert.False(t, diffs["county_name"].Differs)
+
+	// Deltas are relative to the first requested parcel
+	require.Len(t, diffs["acres"].Deltas, 2)
+	assert.InDelta(t, 0.0, *diffs["acres"].Deltas[0], 1e-9)
+	assert.InDelta(t, -0.5, *diffs["acres"].Deltas[1], 1e-9)
+
+	// Missing values on either side produce a nil delta
+	assert.True(t, diffs["year_built"].Differs)
+	assert.Nil(t, diffs["year_built"].Deltas[1])
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCompareParcels_InvalidIDs(t *testing.T) {
+	testCases := []struct {
+		name string
+		ids  []uint
+	}{
+		{"too few", []uint{1}},
+		{"too many", []uint{1, 2, 3, 4, 5, 6}},
+		{"duplicate", []uint{1, 2, 1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockParcelRepository)
+			service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+			comparison, err := service.CompareParcels(context.Background(), tc.ids)
+
+			assert.Nil(t, comparison)
+			assert.ErrorIs(t, err, ErrInvalidCompareIDs)
+			mockRepo.AssertNotCalled(t, "FindByIDs")
+		})
+	}
+}
+
+func TestCompareParcels_PartialFailure(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+	ctx := context.Background()
+	ids := []uint{99, 1, 2}
+	corrupt := errors.New("failed to parse geometry for parcel 2")
+	found := []repository.ParcelWithMetrics{
+		{Parcel: models.TaxParcel{ID: 1, Acres: 2}},
+		{Parcel: models.TaxParcel{ID: 2}, Err: corrupt},
+	}
+
+	mockRepo.On("FindByIDs", ctx, ids).Return(found, nil)
+
+	// Act
+	comparison, err := service.CompareParcels(ctx, ids)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, comparison.Parcels, 1)
+	assert.Equal(t, uint(1), comparison.Parcels[0].Parcel.ID)
+	require.Len(t, comparison.Failed, 2)
+	assert.Equal(t, 0, comparison.Failed[0].Index)
+	assert.Equal(t, uint(99), comparison.Failed[0].ID)
+	assert.ErrorIs(t, comparison.Failed[0].Err, ErrParcelNotFound)
+	assert.Equal(t, CompareFailure{Index: 2, ID: 2, Err: corrupt}, comparison.Failed[1])
+	for _, d := range comparison.Differences {
+		assert.Len(t, d.Values, 1, "differences cover only the compared parcels")
+	}
+}
+
+func TestCompareParcels_AllFailed(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+	ctx := context.Background()
+	ids := []uint{98, 99}
+
+	mockRepo.On("FindByIDs", ctx, ids).Return([]repository.ParcelWithMetrics{}, nil)
+
+	// Act
+	comparison, err := service.CompareParcels(ctx, ids)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, comparison.Parcels)
+	assert.Len(t, comparison.Failed, 2)
+	assert.Empty(t, comparison.Differences)
+}
+
+func TestCompareParcels_RepositoryError(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+	ctx := context.Background()
+	ids := []uint{1, 2}
+	dbError := errors.New("database connection failed")
+
+	mockRepo.On("FindByIDs", ctx, ids).Return(nil, dbError)
+
+	// Act
+	comparison, err := service.CompareParcels(ctx, ids)
+
+	// Assert
+	assert.Nil(t, comparison)
+	assert.ErrorIs(t, err, dbError)
+}
+
+func TestDissolveParcels_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+	ctx := context.Background()
+	ids := []uint{7, 3}
+	area := &repository.DissolvedArea{
+		Outline:   models.MultiPolygon{Coordinates: [][][][2]float64{{{{-95.45, 30.34}, {-95.44, 30.34}, {-95.44, 30.35}, {-95.45, 30.34}}}}},
+		ParcelIDs: []uint{3, 7},
+		Acres:     12.5,
+	}
+
+	mockRepo.On("DissolveByIDs", ctx, ids).Return(area, nil)
+
+	// Act
+	result, err := service.DissolveParcels(ctx, ids)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, area, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDissolveParcels_InvalidIDs(t *testing.T) {
+	tooMany := make([]uint, MaxDissolveParcels+1)
+	for i := range tooMany {
+		tooMany[i] = uint(i + 1)
+	}
+	testCases := []struct {
+		name string
+		ids  []uint
+	}{
+		{"empty", nil},
+		{"too many", tooMany},
+		{"duplicate", []uint{1, 2, 1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockParcelRepository)
+			service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+			area, err := service.DissolveParcels(context.Background(), tc.ids)
+
+			assert.Nil(t, area)
+			assert.ErrorIs(t, err, ErrInvalidDissolveIDs)
+			mockRepo.AssertNotCalled(t, "DissolveByIDs")
+		})
+	}
+}
+
+func TestDissolveParcels_MissingParcel(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+	ctx := context.Background()
+	ids := []uint{99, 1, 98}
+
+	mockRepo.On("DissolveByIDs", ctx, ids).Return(&repository.DissolvedArea{ParcelIDs: []uint{1}}, nil)
+
+	// Act
+	area, err := service.DissolveParcels(ctx, ids)
+
+	// Assert
+	assert.Nil(t, area)
+	assert.ErrorIs(t, err, ErrParcelNotFound)
+	assert.Contains(t, err.Error(), "[99 98]")
+}
+
+func TestDissolveParcels_RepositoryError(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+	ctx := context.Background()
+	dbError := errors.New("database connection failed")
+	mockRepo.On("DissolveByIDs", ctx, []uint{1, 2}).Return(nil, dbError)
+
+	area, err := service.DissolveParcels(ctx, []uint{1, 2})
+
+	assert.Nil(t, area)
+	assert.ErrorIs(t, err, dbError)
+}
+
+// testArea returns a small closed square near Conroe, TX.
+func testArea() *models.MultiPolygon {
+	return &models.MultiPolygon{
+		Coordinates: [][][][2]float64{{{
+			{-95.46, 30.34}, {-95.44, 30.34}, {-95.44, 30.36}, {-95.46, 30.36}, {-95.46, 30.34},
+		}}},
+		SRID: 4326,
+	}
+}
+
+func TestFindParcelsInArea_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+	ctx := context.Background()
+	area := testArea()
+	found := []models.TaxParcel{{ID: 1}, {ID: 2}}
+
+	mockRepo.On("ValidateArea", ctx, *area).Return("", nil)
+	mockRepo.On("FindIntersecting", ctx, *area, 3).Return(found, nil)
+
+	// Act
+	parcels, truncated, err := service.FindParcelsInArea(ctx, area, 2)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, parcels, 2)
+	assert.False(t, truncated)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFindParcelsInArea_Truncated(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+	ctx := context.Background()
+	area := testArea()
+	found := []models.TaxParcel{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	mockRepo.On("ValidateArea", ctx, *area).Return("", nil)
+	mockRepo.On("FindIntersecting", ctx, *area, 3).Return(found, nil)
+
+	// Act
+	parcels, truncated, err := service.FindParcelsInArea(ctx, area, 2)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, parcels, 2)
+	assert.True(t, truncated)
+}
+
+func TestFindParcelsInArea_InvalidInput(t *testing.T) {
+	openRing := testArea()
+	openRing.Coordinates[0][0][4] = [2]float64{-95.45, 30.34}
+
+	tooFew := testArea()
+	tooFew.Coordinates[0][0] = tooFew.Coordinates[0][0][:3]
+
+	outOfRange := testArea()
+	outOfRange.Coordinates[0][0][1] = [2]float64{-195.44, 30.34}
+
+	tooComplex := &models.MultiPolygon{Coordinates: [][][][2]float64{{make([][2]float64, MaxAreaVertices+1)}}}
+
+	testCases := []struct {
+		name    string
+		area    *models.MultiPolygon
+		limit   int
+		errType error
+	}{
+		{"nil area", nil, 10, ErrInvalidGeometry},
+		{"empty area", &models.MultiPolygon{}, 10, ErrInvalidGeometry},
+		{"open ring", openRing, 10, ErrInvalidGeometry},
+		{"too few positions", tooFew, 10, ErrInvalidGeometry},
+		{"longitude out of range", outOfRange, 10, ErrInvalidGeometry},
+		{"too many vertices", tooComplex, 10, ErrInvalidGeometry},
+		{"limit too small", testArea(), 0, ErrInvalidLimit},
+		{"limit too large", testArea(), MaxIntersectLimit + 1, ErrInvalidLimit},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockParcelRepository)
+			service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+			parcels, _, err := service.FindParcelsInArea(context.Background(), tc.area, tc.limit)
+
+			assert.Nil(t, parcels)
+			assert.ErrorIs(t, err, tc.errType)
+			mockRepo.AssertNotCalled(t, "FindIntersecting")
+		})
+	}
+}
+
+func TestFindParcelsInArea_InvalidTopology(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+	ctx := context.Background()
+	area := testArea()
+
+	mockRepo.On("ValidateArea", ctx, *area).Return("Self-intersection[-95.45 30.35]", nil)
+
+	// Act
+	parcels, _, err := service.FindParcelsInArea(ctx, area, 10)
+
+	// Assert
+	assert.Nil(t, parcels)
+	assert.ErrorIs(t, err, ErrInvalidGeometry)
+	assert.Contains(t, err.Error(), "Self-intersection")
+	mockRepo.AssertNotCalled(t, "FindIntersecting")
+}
+
+func TestFindParcelsInArea_RepositoryError(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+	ctx := context.Background()
+	area := testArea()
+	dbError := errors.New("database connection failed")
+
+	mockRepo.On("ValidateArea", ctx, *area).Return("", nil)
+	mockRepo.On("FindIntersecting", ctx, *area, 11).Return(nil, dbError)
+
+	// Act
+	parcels, _, err := service.FindParcelsInArea(ctx, area, 10)
+
+	// Assert
+	assert.Nil(t, parcels)
+	assert.ErrorIs(t, err, dbError)
+}
+
+// testRoute returns a short east-west route near Conroe, TX.
+func testRoute() *models.LineString {
+	return &models.LineString{
+		Coordinates: [][2]float64{{-95.46, 30.35}, {-95.45, 30.35}, {-95.44, 30.35}},
+		SRID:        4326,
+	}
+}
+
+func TestFindParcelsAlongRoute_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+	ctx := context.Background()
+	route := testRoute()
+	found := []repository.ParcelAlongRoute{
+		{Parcel: models.TaxParcel{ID: 1}, Station: 10},
+		{Parcel: models.TaxParcel{ID: 2}, Station: 250},
+		{Parcel: models.TaxParcel{ID: 3}, Station: 900},
+	}
+
+	mockRepo.On("FindAlongRoute", ctx, *route, 30.0, 3).Return(found, nil)
+
+	// Act
+	parcels, truncated, err := service.FindParcelsAlongRoute(ctx, route, 30, 2)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, parcels, 2)
+	assert.True(t, truncated)
+	assert.Equal(t, uint(2), parcels[1].Parcel.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFindParcelsAlongRoute_InvalidInput(t *testing.T) {
+	outOfRange := testRoute()
+	outOfRange.Coordinates[1] = [2]float64{-95.45, 95.35}
+
+	collapsed := &models.LineString{Coordinates: [][2]float64{{-95.45, 30.35}, {-95.45, 30.35}}}
+	tooComplex := &models.LineString{Coordinates: make([][2]float64, MaxRouteVertices+1)}
+
+	testCases := []struct {
+		name    string
+		route   *models.LineString
+		buffer  float64
+		limit   int
+		errType error
+	}{
+		{"nil route", nil, 30, 10, ErrInvalidGeometry},
+		{"single position", &models.LineString{Coordinates: [][2]float64{{-95.45, 30.35}}}, 30, 10, ErrInvalidGeometry},
+		{"identical positions", collapsed, 30, 10, ErrInvalidGeometry},
+		{"latitude out of range", outOfRange, 30, 10, ErrInvalidGeometry},
+		{"too many vertices", tooComplex, 30, 10, ErrInvalidGeometry},
+		{"buffer too small", testRoute(), 0.5, 10, ErrInvalidBuffer},
+		{"buffer too large", testRoute(), MaxRouteBufferMeters + 1, 10, ErrInvalidBuffer},
+		{"limit too small", testRoute(), 30, 0, ErrInvalidLimit},
+		{"limit too large", testRoute(), 30, MaxIntersectLimit + 1, ErrInvalidLimit},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockParcelRepository)
+			service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+			parcels, _, err := service.FindParcelsAlongRoute(context.Background(), tc.route, tc.buffer, tc.limit)
+
+			assert.Nil(t, parcels)
+			assert.ErrorIs(t, err, tc.errType)
+			mockRepo.AssertNotCalled(t, "FindAlongRoute")
+		})
+	}
+}
+
+func TestFindParcelsAlongRoute_RepositoryError(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+	ctx := context.Background()
+	route := testRoute()
+	dbError := errors.New("database connection failed")
+
+	mockRepo.On("FindAlongRoute", ctx, *route, 30.0, 11).Return(nil, dbError)
+
+	// Act
+	parcels, _, err := service.FindParcelsAlongRoute(ctx, route, 30, 10)
+
+	// Assert
+	assert.Nil(t, parcels)
+	assert.ErrorIs(t, err, dbError)
+}
+
+func TestGetNearestAmenities_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+	ctx := context.Background()
+	ids := []uint{1, 2}
+	name := "Station 4"
+	amenities := map[uint][]repository.NearestAmenity{
+		1: {{Name: &name, Category: models.AmenityFireStation, Location: models.LatLng{Lat: 30.35, Lng: -95.45}, Distance: 812.5, ID: 4}},
+	}
+
+	mockRepo.On("FindNearestAmenities", ctx, ids).Return(amenities, nil)
+
+	// Act
+	result, err := service.GetNearestAmenities(ctx, ids)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, amenities, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetNearestAmenities_NoParcels(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+	result, err := service.GetNearestAmenities(context.Background(), nil)
+
+	require.NoError(t, err)
+	assert.Empty(t, result)
+	mockRepo.AssertNotCalled(t, "FindNearestAmenities")
+}
+
+func TestGetNearestAmenities_RepositoryError(t *testing.T) {
+	mockRepo := new(MockParcelRepository)
+	service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+	ctx := context.Background()
+	dbError := errors.New("connection refused")
+	mockRepo.On("FindNearestAmenities", ctx, []uint{1}).Return(nil, dbError)
+
+	result, err := service.GetNearestAmenities(ctx, []uint{1})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, dbError)
+}
+
+// staticProfiles serves fixed county profiles by slug
+type staticProfiles map[string]*models.CountyProfile
+
+func (p staticProfiles) Profile(_ context.Context, slug string) (*models.CountyProfile, error) {
+	return p[slug], nil
+}
+
+func TestGetNearbyParcels_CountyProfile(t *testing.T) {
+	tolerance := 0.0001
+	profiles := staticProfiles{"harris-tx": {
+		County:              "harris-tx",
+		DefaultRadiusMeters: intPtr(250),
+		MaxRadiusMeters:     intPtr(500),
+		SimplifyTolerance:   &tolerance,
+		Attributes:          map[string]bool{"year_built": false},
+	}}
+	point := models.LatLng{Lat: 29.76, Lng: -95.37}
+	ctx := repository.WithCounty(context.Background(), "harris-tx")
+
+	t.Run("default radius and tolerance of the county", func(t *testing.T) {
+		mockRepo := new(MockParcelRepository)
+		service := NewParcelService(mockRepo, profiles, logger.New("test"))
+
+		simplified := mock.MatchedBy(func(ctx context.Context) bool {
+			got, ok := repository.SimplifyToleranceFromContext(ctx)
+			return ok && got == tolerance
+		})
+		mockRepo.On("FindNearby", simplified, point, 250, 21, (*pagination.Cursor)(nil), repository.NearbyFilter{}).
+			Return([]repository.ParcelWithDistance{}, 0, nil)
+
+		radius, err := service.DefaultNearbyRadius(ctx)
+		require.NoError(t, err)
+		_, err = service.GetNearbyParcels(ctx, point, radius, 20, "", repository.NearbyFilter{})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("radius above the county maximum", func(t *testing.T) {
+		service := NewParcelService(new(MockParcelRepository), profiles, logger.New("test"))
+
+		_, err := service.GetNearbyParcels(ctx, point, 1000, 20, "", repository.NearbyFilter{})
+
+		assert.ErrorIs(t, err, ErrInvalidRadius)
+		assert.Contains(t, err.Error(), "harris-tx allows at most 500")
+	})
+
+	t.Run("filter on an attribute the county lacks", func(t *testing.T) {
+		service := NewParcelService(new(MockParcelRepository), profiles, logger.New("test"))
+
+		_, err := service.GetNearbyParcels(ctx, point, 100, 20, "", repository.NearbyFilter{MinYearBuilt: intPtr(1990)})
+
+		assert.ErrorIs(t, err, ErrInvalidFilter)
+		assert.Contains(t, err.Error(), "does not provide year_built")
+	})
+
+	t.Run("other counties keep the global limits", func(t *testing.T) {
+		mockRepo := new(MockParcelRepository)
+		service := NewParcelService(mockRepo, profiles, logger.New("test"))
+		mockRepo.On("FindNearby", mock.Anything, point, DefaultRadiusMeters, 21, (*pagination.Cursor)(nil), repository.NearbyFilter{}).
+			Return([]repository.ParcelWithDistance{}, 0, nil)
+
+		radius, err := service.DefaultNearbyRadius(context.Background())
+		require.NoError(t, err)
+		_, err = service.GetNearbyParcels(context.Background(), point, radius, 20, "", repository.NearbyFilter{})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestSampleParcels_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+	ctx := context.Background()
+	samples := []repository.ParcelSample{
+		{Parcel: models.TaxParcel{ID: 1}, QualityFlags: []string{}},
+		{Parcel: models.TaxParcel{ID: 2}, QualityFlags: []string{repository.QualityMissingOwner}},
+	}
+
+	mockRepo.On("SampleParcels", ctx, 50).Return(samples, nil)
+
+	// Act
+	result, err := service.SampleParcels(ctx, 50)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, samples, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSampleParcels_InvalidSize(t *testing.T) {
+	for _, n := range []int{0, -1, MaxSampleSize + 1} {
+		mockRepo := new(MockParcelRepository)
+		service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+		samples, err := service.SampleParcels(context.Background(), n)
+
+		assert.Nil(t, samples)
+		assert.ErrorIs(t, err, ErrInvalidLimit, "n=%d", n)
+		mockRepo.AssertNotCalled(t, "SampleParcels", mock.Anything, mock.Anything)
+	}
+}
+
+func TestSampleParcels_RepositoryError(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParcelRepository)
+	service := NewParcelService(mockRepo, nil, logger.New("test"))
+
+	ctx := context.Background()
+	dbError := errors.New("database connection failed")
+
+	mockRepo.On("SampleParcels", ctx, 10).Return(nil, dbError)
+
+	// Act
+	samples, err := service.SampleParcels(ctx, 10)
+
+	// Assert
+	assert.Nil(t, samples)
+	assert.ErrorIs(t, err, dbError)
+}
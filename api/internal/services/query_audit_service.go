@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// queryAuditBatchSize bounds the entries written per statement
+const queryAuditBatchSize = 1000
+
+// QueryAuditService defines the interface for the query audit log, which
+// records every parcel query with its caller, latency and result count for
+// usage accounting. Entries are written asynchronously so requests never
+// wait on the audit table.
+type QueryAuditService interface {
+	// Record queues an entry for the next write. It never blocks; entries are
+	// dropped when the queue is full. Safe for concurrent use.
+	Record(entry models.QueryAudit)
+
+	// Flush writes the queued entries, in batches. Entries are dropped if a
+	// write fails; usage accounting is best-effort.
+	Flush(ctx context.Context)
+
+	// Run flushes every configured interval until ctx is cancelled, then
+	// flushes once more. It runs in the process that records the entries.
+	Run(ctx context.Context)
+}
+
+// queryAuditService is the concrete implementation of QueryAuditService.
+type queryAuditService struct {
+	repo    repository.QueryAuditRepository
+	log     *logger.Logger
+	cfg     config.QueryAuditConfig
+	queue   chan models.QueryAudit
+	dropped atomic.Int64
+}
+
+// NewQueryAuditService creates a new instance of QueryAuditService queuing up
+// to cfg.QueueSize entries.
+func NewQueryAuditService(repo repository.QueryAuditRepository, cfg config.QueryAuditConfig, log *logger.Logger) QueryAuditService {
+	return &queryAuditService{
+		repo:  repo,
+		log:   log,
+		cfg:   cfg,
+		queue: make(chan models.QueryAudit, cfg.QueueSize),
+	}
+}
+
+// Record drops the entry and counts it when the queue is full.
+func (s *queryAuditService) Record(entry models.QueryAudit) {
+	select {
+	case s.queue <- entry:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// Flush drains at most a full queue, so a steady stream of new entries cannot
+// keep it writing forever.
+func (s *queryAuditService) Flush(ctx context.Context) {
+	if dropped := s.dropped.Swap(0); dropped > 0 {
+		s.log.Warn("Query audit queue full, entries dropped", map[string]interface{}{
+			"dropped": dropped,
+		})
+	}
+
+	for remaining := cap(s.queue); remaining > 0; {
+		batch := s.drain(min(remaining, queryAuditBatchSize))
+		if len(batch) == 0 {
+			return
+		}
+		remaining -= len(batch)
+
+		if err := s.repo.AddEntries(ctx, batch); err != nil {
+			s.log.Error("Failed to write query audit entries", err, map[string]interface{}{
+				"entries": len(batch),
+			})
+		}
+	}
+}
+
+// drain takes up to limit queued entries without waiting.
+func (s *queryAuditService) drain(limit int) []models.QueryAudit {
+	var batch []models.QueryAudit
+	for len(batch) < limit {
+		select {
+		case entry := <-s.queue:
+			batch = append(batch, entry)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+// Run blocks until ctx is cancelled; run it in its own goroutine.
+func (s *queryAuditService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Persist what was recorded since the last tick
+			flushCtx, cancel := context.WithTimeout(context.Background(), s.cfg.FlushInterval)
+			s.Flush(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			s.Flush(ctx)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// MockQueryAuditRepository is a mock implementation of QueryAuditRepository for testing
+type MockQueryAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockQueryAuditRepository) AddEntries(ctx context.Context, entries []models.QueryAudit) error {
+	args := m.Called(ctx, entries)
+	return args.Error(0)
+}
+
+func (m *MockQueryAuditRepository) ListReplay(ctx context.Context, since time.Time, limit int) ([]models.ReplayQuery, error) {
+	args := m.Called(ctx, since, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ReplayQuery), args.Error(1)
+}
+
+func newTestQueryAuditService(repo *MockQueryAuditRepository, queueSize int) QueryAuditService {
+	return NewQueryAuditService(repo, config.QueryAuditConfig{
+		Enabled: true, QueueSize: queueSize, FlushInterval: time.Second,
+	}, logger.New("test"))
+}
+
+func TestQueryAudit_FlushWritesQueuedEntries(t *testing.T) {
+	mockRepo := new(MockQueryAuditRepository)
+	service := newTestQueryAuditService(mockRepo, 10)
+
+	first := models.QueryAudit{Route: "parcels.nearby", Status: 200}
+	second := models.QueryAudit{Route: "parcels.at_point", Status: 404}
+	mockRepo.On("AddEntries", mock.Anything, []models.QueryAudit{first, second}).Return(nil).Once()
+
+	service.Record(first)
+	service.Record(second)
+	service.Flush(context.Background())
+	// Nothing left to write
+	service.Flush(context.Background())
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestQueryAudit_RecordDropsWhenQueueFull(t *testing.T) {
+	mockRepo := new(MockQueryAuditRepository)
+	service := newTestQueryAuditService(mockRepo, 2)
+
+	var written []models.QueryAudit
+	mockRepo.On("AddEntries", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		written = append(written, args.Get(1).([]models.QueryAudit)...)
+	}).Return(nil)
+
+	for i := 0; i < 5; i++ {
+		service.Record(models.QueryAudit{LatencyMS: i})
+	}
+	service.Flush(context.Background())
+
+	assert.Equal(t, []models.QueryAudit{{LatencyMS: 0}, {LatencyMS: 1}}, written)
+}
+
+func TestQueryAudit_FlushWritesInBatches(t *testing.T) {
+	mockRepo := new(MockQueryAuditRepository)
+	service := newTestQueryAuditService(mockRepo, queryAuditBatchSize+1)
+
+	var batches []int
+	mockRepo.On("AddEntries", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		batches = append(batches, len(args.Get(1).([]models.QueryAudit)))
+	}).Return(nil)
+
+	for i := 0; i <= queryAuditBatchSize; i++ {
+		service.Record(models.QueryAudit{})
+	}
+	service.Flush(context.Background())
+
+	assert.Equal(t, []int{queryAuditBatchSize, 1}, batches)
+}
+
+func TestQueryAudit_FlushDropsFailedBatch(t *testing.T) {
+	mockRepo := new(MockQueryAuditRepository)
+	service := newTestQueryAuditService(mockRepo, 10)
+
+	mockRepo.On("AddEntries", mock.Anything, mock.Anything).Return(errors.New("connection refused")).Once()
+
+	service.Record(models.QueryAudit{Route: "parcels.nearby"})
+	service.Flush(context.Background())
+	service.Flush(context.Background())
+
+	mockRepo.AssertNumberOfCalls(t, "AddEntries", 1)
+}
+
+func TestQueryAudit_RunFlushesOnShutdown(t *testing.T) {
+	mockRepo := new(MockQueryAuditRepository)
+	service := NewQueryAuditService(mockRepo, config.QueryAuditConfig{
+		Enabled: true, QueueSize: 10, FlushInterval: time.Hour,
+	}, logger.New("test"))
+
+	entry := models.QueryAudit{Route: "parcels.nearby", Status: 200}
+	mockRepo.On("AddEntries", mock.Anything, []models.QueryAudit{entry}).Return(nil).Once()
+
+	service.Record(entry)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	service.Run(ctx)
+
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// Query service errors
+var (
+	ErrQueryNotFound      = domain.NotFound("query template not found")
+	ErrInvalidQueryParams = domain.Validation("invalid query parameters")
+	ErrQueryTimeout       = domain.Timeout("query exceeded its timeout")
+)
+
+// maxExactFloat is the largest integer a JSON number holds exactly (2^53).
+const maxExactFloat = 1 << 53
+
+// QueryService defines the interface for running analyst query templates.
+type QueryService interface {
+	// Templates returns the configured templates ordered by name.
+	Templates() []config.QueryTemplate
+
+	// Run executes the named template with params, as decoded from JSON: numbers
+	// are float64 and dates YYYY-MM-DD strings. Every declared parameter is
+	// required and undeclared ones are rejected. caller names the API key in
+	// the audit log.
+	// Returns ErrQueryNotFound, ErrInvalidQueryParams or ErrQueryTimeout.
+	Run(ctx context.Context, name string, params map[string]any, caller string) (*models.QueryResult, error)
+}
+
+// queryService is the concrete implementation of QueryService.
+type queryService struct {
+	repo      repository.QueryRepository
+	log       *logger.Logger
+	templates map[string]config.QueryTemplate
+	cfg       config.QueriesConfig
+}
+
+// NewQueryService creates a new instance of QueryService for the templates
+// of cfg, which config.Validate has checked.
+func NewQueryService(repo repository.QueryRepository, cfg config.QueriesConfig, log *logger.Logger) QueryService {
+	templates := make(map[string]config.QueryTemplate, len(cfg.Templates))
+	for _, t := range cfg.Templates {
+		templates[t.Name] = t
+	}
+	return &queryService{
+		repo:      repo,
+		log:       log,
+		templates: templates,
+		cfg:       cfg,
+	}
+}
+
+// Templates returns the templates ordered by name.
+func (s *queryService) Templates() []config.QueryTemplate {
+	templates := make([]config.QueryTemplate, 0, len(s.templates))
+	for _, t := range s.templates {
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates
+}
+
+// Run binds the parameters in declaration order and runs the template with
+// the lower of its own and the configured row limit.
+func (s *queryService) Run(ctx context.Context, name string, params map[string]any, caller string) (*models.QueryResult, error) {
+	template, ok := s.templates[name]
+	if !ok {
+		return nil, ErrQueryNotFound
+	}
+	args, err := bindQueryParams(template.Params, params)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := s.cfg.RowLimit
+	if template.RowLimit > 0 && template.RowLimit < limit {
+		limit = template.RowLimit
+	}
+
+	start := time.Now()
+	result, err := s.repo.Run(ctx, template.SQL, args, limit, s.cfg.Timeout)
+	fields := map[string]interface{}{
+		"query":       name,
+		"caller":      caller,
+		"duration_ms": time.Since(start).Milliseconds(),
+	}
+	switch {
+	case errors.Is(err, repository.ErrQueryCanceled):
+		s.log.Warn("Analyst query timed out", fields)
+		return nil, fmt.Errorf("%w after %s", ErrQueryTimeout, s.cfg.Timeout)
+	case errors.Is(err, repository.ErrQueryRejected):
+		return nil, fmt.Errorf("%w: %w", ErrInvalidQueryParams, err)
+	case err != nil:
+		return nil, fmt.Errorf("failed to run query %s: %w", name, err)
+	}
+
+	// Analysts bypass the API's field visibility rules, so every run is logged
+	fields["rows"] = result.RowCount
+	fields["truncated"] = result.Truncated
+	s.log.Info("Analyst query executed", fields)
+	return result, nil
+}
+
+// bindQueryParams converts params to the declared types, in declaration order.
+func bindQueryParams(declared []config.QueryParam, params map[string]any) ([]any, error) {
+	for name := range params {
+		if !declaresParam(declared, name) {
+			return nil, fmt.Errorf("%w: unknown parameter %q", ErrInvalidQueryParams, name)
+		}
+	}
+
+	args := make([]any, 0, len(declared))
+	for _, p := range declared {
+		value, ok := params[p.Name]
+		if !ok || value == nil {
+			return nil, fmt.Errorf("%w: %s is required", ErrInvalidQueryParams, p.Name)
+		}
+		arg, ok := convertQueryParam(p.Type, value)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s must be a %s", ErrInvalidQueryParams, p.Name, p.Type)
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// declaresParam reports whether name is a declared parameter.
+func declaresParam(declared []config.QueryParam, name string) bool {
+	for _, p := range declared {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// convertQueryParam converts a JSON value to typ; ok is false when it does not fit.
+func convertQueryParam(typ config.QueryParamType, value any) (any, bool) {
+	switch typ {
+	case config.QueryParamText:
+		s, ok := value.(string)
+		return s, ok
+	case config.QueryParamInt:
+		f, ok := value.(float64)
+		if !ok || f != math.Trunc(f) || math.Abs(f) > maxExactFloat {
+			return nil, false
+		}
+		return int64(f), true
+	case config.QueryParamFloat:
+		f, ok := value.(float64)
+		return f, ok
+	case config.QueryParamBool:
+		b, ok := value.(bool)
+		return b, ok
+	case config.QueryParamDate:
+		s, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		date, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			return nil, false
+		}
+		return date, true
+	}
+	return nil, false
+}
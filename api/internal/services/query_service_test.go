@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// MockQueryRepository is a mock implementation of QueryRepository for testing
+type MockQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockQueryRepository) Run(ctx context.Context, sql string, args []any, limit int, timeout time.Duration) (*models.QueryResult, error) {
+	called := m.Called(ctx, sql, args, limit, timeout)
+	if called.Get(0) == nil {
+		return nil, called.Error(1)
+	}
+	result, ok := called.Get(0).(*models.QueryResult)
+	if !ok {
+		return nil, called.Error(1)
+	}
+	return result, called.Error(1)
+}
+
+// testQueriesConfig has one template with a parameter of every type and one without parameters.
+func testQueriesConfig() config.QueriesConfig {
+	return config.QueriesConfig{
+		File:     "queries.json",
+		RowLimit: 1000,
+		Timeout:  30 * time.Second,
+		Templates: []config.QueryTemplate{
+			{Name: "sales_since", SQL: "SELECT $1::text, $2::int, $3::float, $4::bool, $5::date", RowLimit: 50, Params: []config.QueryParam{
+				{Name: "county", Type: config.QueryParamText},
+				{Name: "min_year", Type: config.QueryParamInt},
+				{Name: "min_acres", Type: config.QueryParamFloat},
+				{Name: "homestead", Type: config.QueryParamBool},
+				{Name: "since", Type: config.QueryParamDate},
+			}},
+			{Name: "county_totals", SQL: "SELECT county_name, COUNT(*) FROM tax_parcels GROUP BY 1"},
+		},
+	}
+}
+
+func TestQueryTemplates_SortedByName(t *testing.T) {
+	service := NewQueryService(new(MockQueryRepository), testQueriesConfig(), logger.New("test"))
+
+	templates := service.Templates()
+	require.Len(t, templates, 2)
+	assert.Equal(t, "county_totals", templates[0].Name)
+	assert.Equal(t, "sales_since", templates[1].Name)
+}
+
+func TestRunQuery_BindsTypedParams(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockQueryRepository)
+	service := NewQueryService(mockRepo, testQueriesConfig(), logger.New("test"))
+	ctx := context.Background()
+	result := &models.QueryResult{Columns: []string{"county"}, Rows: [][]any{{"Travis"}}, RowCount: 1}
+
+	since := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("Run", ctx, "SELECT $1::text, $2::int, $3::float, $4::bool, $5::date",
+		[]any{"Travis", int64(2020), 1.5, true, since}, 50, 30*time.Second).Return(result, nil)
+
+	// Act
+	got, err := service.Run(ctx, "sales_since", map[string]any{
+		"county": "Travis", "min_year": float64(2020), "min_acres": 1.5, "homestead": true, "since": "2024-01-31",
+	}, "atlas_adm")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, result, got)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRunQuery_ConfiguredRowLimit(t *testing.T) {
+	mockRepo := new(MockQueryRepository)
+	cfg := testQueriesConfig()
+	cfg.RowLimit = 10
+	service := NewQueryService(mockRepo, cfg, logger.New("test"))
+	ctx := context.Background()
+
+	// The template's own limit of 50 cannot raise the configured limit
+	mockRepo.On("Run", ctx, mock.Anything, []any{}, 10, 30*time.Second).Return(&models.QueryResult{}, nil).Once()
+	_, err := service.Run(ctx, "county_totals", nil, "")
+	require.NoError(t, err)
+
+	mockRepo.On("Run", ctx, mock.Anything, mock.Anything, 10, 30*time.Second).Return(&models.QueryResult{}, nil).Once()
+	_, err = service.Run(ctx, "sales_since", map[string]any{
+		"county": "Travis", "min_year": float64(2020), "min_acres": 1.5, "homestead": true, "since": "2024-01-31",
+	}, "")
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRunQuery_InvalidParams(t *testing.T) {
+	valid := func() map[string]any {
+		return map[string]any{"county": "Travis", "min_year": float64(2020), "min_acres": 1.5, "homestead": true, "since": "2024-01-31"}
+	}
+	tests := []struct {
+		change func(map[string]any)
+		name   string
+	}{
+		{func(p map[string]any) { delete(p, "county") }, "missing"},
+		{func(p map[string]any) { p["county"] = nil }, "null"},
+		{func(p map[string]any) { p["owner"] = "Smith" }, "undeclared"},
+		{func(p map[string]any) { p["county"] = float64(48) }, "number for text"},
+		{func(p map[string]any) { p["min_year"] = 2020.5 }, "fractional int"},
+		{func(p map[string]any) { p["min_year"] = float64(1 << 60) }, "int beyond exact range"},
+		{func(p map[string]any) { p["min_acres"] = "1.5" }, "string for float"},
+		{func(p map[string]any) { p["homestead"] = "yes" }, "string for bool"},
+		{func(p map[string]any) { p["since"] = "01/31/2024" }, "malformed date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockQueryRepository)
+			service := NewQueryService(mockRepo, testQueriesConfig(), logger.New("test"))
+			params := valid()
+			tt.change(params)
+
+			_, err := service.Run(context.Background(), "sales_since", params, "")
+
+			assert.ErrorIs(t, err, ErrInvalidQueryParams)
+			mockRepo.AssertNotCalled(t, "Run")
+		})
+	}
+}
+
+func TestRunQuery_Errors(t *testing.T) {
+	tests := []struct {
+		repoErr error
+		want    error
+		name    string
+	}{
+		{repository.ErrQueryCanceled, ErrQueryTimeout, "timeout"},
+		{errors.Join(repository.ErrQueryRejected, errors.New("value out of range")), ErrInvalidQueryParams, "rejected values"},
+		{errors.New("connection refused"), nil, "database error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockQueryRepository)
+			service := NewQueryService(mockRepo, testQueriesConfig(), logger.New("test"))
+			mockRepo.On("Run", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, tt.repoErr)
+
+			_, err := service.Run(context.Background(), "county_totals", nil, "atlas_adm")
+
+			require.Error(t, err)
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			} else {
+				assert.NotErrorIs(t, err, ErrQueryTimeout)
+				assert.NotErrorIs(t, err, ErrInvalidQueryParams)
+			}
+		})
+	}
+}
+
+func TestRunQuery_NotFound(t *testing.T) {
+	service := NewQueryService(new(MockQueryRepository), testQueriesConfig(), logger.New("test"))
+
+	_, err := service.Run(context.Background(), "drop_tables", nil, "")
+
+	assert.ErrorIs(t, err, ErrQueryNotFound)
+}
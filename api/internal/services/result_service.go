@@ -0,0 +1,230 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	"github.com/stwalsh4118/atlas/api/internal/jobs"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/outbound"
+)
+
+// Async result constants
+const (
+	// ResultJobKindPrefix starts the job kind of async results, followed by the
+	// operation, e.g. "result:assemblages"
+	ResultJobKindPrefix = "result:"
+	// ResultContentType is the content type of stored results
+	ResultContentType = "application/json"
+	// ResultFilename is the download name of a stored result
+	ResultFilename = "result.json"
+	// callbackTimeout bounds the delivery of one callback, retries included
+	callbackTimeout = time.Minute
+)
+
+// Result service errors
+var (
+	ErrInvalidCallbackURL = domain.Validation("callback_url must be an https URL of a public host")
+)
+
+// ResultNotification is the JSON body POSTed to the callback URL of an async
+// result once it has succeeded or failed. Clients fetch the result itself with
+// the ID, which the notification does not carry.
+type ResultNotification struct {
+	FinishedAt time.Time   `json:"finished_at"`
+	ID         string      `json:"id"`
+	Operation  string      `json:"operation"`
+	Status     jobs.Status `json:"status"`
+	Error      string      `json:"error,omitempty"`
+}
+
+// ResultService defines the interface for running expensive queries in the
+// background: the query's JSON response is stored as the result of a job, which
+// clients poll by its ID or learn about from a callback.
+type ResultService interface {
+	// Submit queues fn as a job of the given operation whose return value is
+	// stored as the JSON result. callbackURL, when set, is sent a
+	// ResultNotification once the job has finished.
+	// Returns ErrInvalidCallbackURL if callbackURL is not an https URL of a public host.
+	// Returns jobs.ErrQueueFull if too many jobs are waiting to run.
+	Submit(operation, callbackURL string, fn func(ctx context.Context) (any, error)) (jobs.Job, error)
+
+	// GetResult returns the async result job with the given ID.
+	// Returns jobs.ErrJobNotFound if the ID is unknown, expired, or not an async result.
+	GetResult(id string) (jobs.Job, error)
+}
+
+// resultService is the concrete implementation of ResultService.
+type resultService struct {
+	jobs   *jobs.Manager
+	client *outbound.Client
+	log    *logger.Logger
+	// checkCallback validates callback URLs; tests allow local servers
+	checkCallback func(rawURL string) error
+	// callbacks holds the callback URL of each pending job by ID
+	callbacks map[string]string
+	mu        sync.Mutex
+}
+
+// NewResultService creates a new instance of ResultService that delivers
+// callbacks with client and registers for finished jobs on manager.
+func NewResultService(manager *jobs.Manager, client *outbound.Client, log *logger.Logger) ResultService {
+	return newResultService(manager, client, log, validateCallbackURL)
+}
+
+// newResultService creates a resultService validating callback URLs with
+// checkCallback.
+func newResultService(manager *jobs.Manager, client *outbound.Client, log *logger.Logger, checkCallback func(string) error) *resultService {
+	s := &resultService{
+		jobs:          manager,
+		client:        client,
+		log:           log,
+		checkCallback: checkCallback,
+		callbacks:     make(map[string]string),
+	}
+	manager.OnFinish(s.finished)
+	return s
+}
+
+// Submit records the callback while holding the lock that finished takes, so a
+// job finishing at once still finds its callback.
+func (s *resultService) Submit(operation, callbackURL string, fn func(ctx context.Context) (any, error)) (jobs.Job, error) {
+	if callbackURL != "" {
+		if err := s.checkCallback(callbackURL); err != nil {
+			return jobs.Job{}, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, err := s.jobs.Submit(ResultJobKindPrefix+operation, func(ctx context.Context) (*jobs.Result, error) {
+		value, err := fn(ctx)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s result: %w", operation, err)
+		}
+		return &jobs.Result{ContentType: ResultContentType, Filename: ResultFilename, Data: data}, nil
+	})
+	if err != nil {
+		s.log.Warn("Failed to queue async result job", map[string]interface{}{
+			"operation": operation,
+			"error":     err.Error(),
+		})
+		return jobs.Job{}, err
+	}
+	if callbackURL != "" {
+		s.callbacks[job.ID] = callbackURL
+	}
+
+	s.log.Info("Async result job queued", map[string]interface{}{
+		"job_id":    job.ID,
+		"operation": operation,
+		"callback":  callbackURL != "",
+	})
+
+	return job, nil
+}
+
+// GetResult hides jobs of other kinds so result IDs cannot be used to probe them.
+func (s *resultService) GetResult(id string) (jobs.Job, error) {
+	job, err := s.jobs.Get(id)
+	if err != nil {
+		return jobs.Job{}, err
+	}
+	if !strings.HasPrefix(job.Kind, ResultJobKindPrefix) {
+		return jobs.Job{}, jobs.ErrJobNotFound
+	}
+	return job, nil
+}
+
+// finished delivers the callback of a finished job, if it has one, without
+// holding up the worker.
+func (s *resultService) finished(job jobs.Job) {
+	s.mu.Lock()
+	callbackURL, ok := s.callbacks[job.ID]
+	delete(s.callbacks, job.ID)
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	go s.notify(callbackURL, job)
+}
+
+// notify POSTs the ResultNotification of job to callbackURL. Failures are
+// logged only: the result can still be polled.
+func (s *resultService) notify(callbackURL string, job jobs.Job) {
+	notification := ResultNotification{
+		ID:        job.ID,
+		Operation: strings.TrimPrefix(job.Kind, ResultJobKindPrefix),
+		Status:    job.Status,
+		Error:     job.Error,
+	}
+	if job.FinishedAt != nil {
+		notification.FinishedAt = *job.FinishedAt
+	}
+	body, err := json.Marshal(notification)
+	if err != nil {
+		s.log.Error("Failed to encode result callback", err, map[string]interface{}{"job_id": job.ID})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), callbackTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		s.log.Error("Failed to build result callback", err, map[string]interface{}{"job_id": job.ID})
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err == nil && resp.StatusCode >= http.StatusBadRequest {
+		err = fmt.Errorf("callback answered status %d", resp.StatusCode)
+	}
+	if err != nil {
+		s.log.Warn("Result callback failed", map[string]interface{}{
+			"job_id": job.ID,
+			"error":  err.Error(),
+		})
+		return
+	}
+	s.log.Info("Result callback delivered", map[string]interface{}{
+		"job_id": job.ID,
+		"status": job.Status,
+	})
+}
+
+// validateCallbackURL accepts https URLs without credentials whose host is a
+// name or a public IP address, so callbacks cannot be aimed at the API's own
+// network. Names resolving to private addresses are not caught.
+func validateCallbackURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" || u.User != nil {
+		return ErrInvalidCallbackURL
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrInvalidCallbackURL
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsMulticast() {
+			return ErrInvalidCallbackURL
+		}
+	}
+	return nil
+}
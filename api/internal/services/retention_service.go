@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// RetentionService defines the interface for enforcing the retention of
+// operational tables (security events, query hotspots, ingestion runs and the
+// query audit log), so they do not grow unbounded over years of traffic and
+// imports.
+type RetentionService interface {
+	// Prune deletes the rows older than each table's retention, in batches, and
+	// reports how many it deleted per table. With dryRun it deletes nothing and
+	// reports how many rows it would delete. A table that fails does not stop
+	// the others; the report carries its error and Prune returns them joined.
+	Prune(ctx context.Context, dryRun bool) (*models.RetentionReport, error)
+
+	// Run prunes immediately and then every configured interval until ctx is
+	// cancelled, as a dry run when the configuration says so. Failures are
+	// logged and retried on the next run.
+	Run(ctx context.Context)
+}
+
+// retentionPolicy is the retention of one table.
+type retentionPolicy struct {
+	table     string
+	retention time.Duration
+}
+
+// retentionService is the concrete implementation of RetentionService.
+type retentionService struct {
+	repo     repository.RetentionRepository
+	log      *logger.Logger
+	cfg      config.RetentionConfig
+	policies []retentionPolicy
+	now      func() time.Time
+}
+
+// NewRetentionService creates a new instance of RetentionService with the
+// retentions of cfg. Tables with a zero retention are never pruned.
+func NewRetentionService(repo repository.RetentionRepository, cfg config.RetentionConfig, log *logger.Logger) RetentionService {
+	return newRetentionService(repo, cfg, log, time.Now)
+}
+
+// newRetentionService creates a retentionService reading the time from now;
+// tests pass a fake clock.
+func newRetentionService(repo repository.RetentionRepository, cfg config.RetentionConfig, log *logger.Logger, now func() time.Time) *retentionService {
+	var policies []retentionPolicy
+	for _, p := range []retentionPolicy{
+		{table: repository.RetentionSecurityEvents, retention: cfg.SecurityEvents},
+		{table: repository.RetentionQueryHotspots, retention: cfg.QueryHotspots},
+		{table: repository.RetentionIngestionRuns, retention: cfg.IngestionRuns},
+		{table: repository.RetentionQueryAudit, retention: cfg.QueryAudit},
+		{table: repository.RetentionQueryReplay, retention: cfg.QueryReplay},
+		{table: repository.RetentionWebhookDeliveries, retention: cfg.WebhookDeliveries},
+		{table: repository.RetentionTasks, retention: cfg.Tasks},
+		{table: repository.RetentionParcelVersions, retention: cfg.ParcelVersions},
+	} {
+		if p.retention > 0 {
+			policies = append(policies, p)
+		}
+	}
+
+	return &retentionService{
+		repo:     repo,
+		log:      log,
+		cfg:      cfg,
+		policies: policies,
+		now:      now,
+	}
+}
+
+// Prune computes every cutoff from one start time, so a pass is consistent
+// however long it takes.
+func (s *retentionService) Prune(ctx context.Context, dryRun bool) (*models.RetentionReport, error) {
+	report := &models.RetentionReport{
+		StartedAt: s.now().UTC(),
+		DryRun:    dryRun,
+		Tables:    []models.RetentionResult{},
+	}
+
+	var errs []error
+	for _, policy := range s.policies {
+		result := models.RetentionResult{
+			Table:     policy.table,
+			Retention: policy.retention.String(),
+			Before:    report.StartedAt.Add(-policy.retention),
+		}
+
+		var err error
+		if dryRun {
+			result.Rows, err = s.repo.CountExpired(ctx, policy.table, result.Before)
+		} else {
+			result.Rows, err = s.delete(ctx, policy.table, result.Before)
+		}
+		if err != nil {
+			s.log.Error("Failed to prune table", err, map[string]interface{}{
+				"table":   policy.table,
+				"dry_run": dryRun,
+				"rows":    result.Rows,
+			})
+			result.Error = err.Error()
+			errs = append(errs, fmt.Errorf("failed to prune %s: %w", policy.table, err))
+		}
+		report.Tables = append(report.Tables, result)
+	}
+
+	return report, errors.Join(errs...)
+}
+
+// delete deletes the expired rows of table batch by batch until a batch comes
+// back short, and returns how many it deleted.
+func (s *retentionService) delete(ctx context.Context, table string, before time.Time) (int64, error) {
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		deleted, err := s.repo.DeleteExpired(ctx, table, before, s.cfg.BatchSize)
+		if err != nil {
+			return total, err
+		}
+		total += deleted
+		if deleted < int64(s.cfg.BatchSize) {
+			return total, nil
+		}
+	}
+}
+
+// Run blocks until ctx is cancelled; run it in its own goroutine.
+func (s *retentionService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		started := time.Now()
+		// Errors are already logged by Prune; the next run retries
+		report, _ := s.Prune(ctx, s.cfg.DryRun)
+		s.logReport(report, time.Since(started))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// logReport logs the rows deleted, or that would be deleted, per table.
+func (s *retentionService) logReport(report *models.RetentionReport, took time.Duration) {
+	rows := make(map[string]interface{}, len(report.Tables))
+	for _, result := range report.Tables {
+		rows[result.Table] = result.Rows
+	}
+
+	msg := "Retention pruning finished"
+	if report.DryRun {
+		msg = "Retention dry run finished, nothing deleted"
+	}
+	s.log.Info(msg, map[string]interface{}{
+		"rows":        rows,
+		"duration_ms": took.Milliseconds(),
+	})
+}
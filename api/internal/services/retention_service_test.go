@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// testRetentionConfig prunes security events after a day and hotspots after
+// a week, in batches of 2, and keeps ingestion runs forever
+var testRetentionConfig = config.RetentionConfig{
+	Enabled:        true,
+	Interval:       time.Hour,
+	BatchSize:      2,
+	SecurityEvents: 24 * time.Hour,
+	QueryHotspots:  7 * 24 * time.Hour,
+}
+
+// MockRetentionRepository is a mock implementation of RetentionRepository for testing
+type MockRetentionRepository struct {
+	mock.Mock
+}
+
+func (m *MockRetentionRepository) CountExpired(ctx context.Context, table string, before time.Time) (int64, error) {
+	args := m.Called(ctx, table, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRetentionRepository) DeleteExpired(ctx context.Context, table string, before time.Time, limit int) (int64, error) {
+	args := m.Called(ctx, table, before, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func newTestRetentionService(repo *MockRetentionRepository) (*retentionService, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	return newRetentionService(repo, testRetentionConfig, logger.New("test"), clock.Now), clock
+}
+
+func TestPrune_DryRunCounts(t *testing.T) {
+	mockRepo := new(MockRetentionRepository)
+	service, clock := newTestRetentionService(mockRepo)
+
+	mockRepo.On("CountExpired", mock.Anything, repository.RetentionSecurityEvents, clock.now.Add(-24*time.Hour)).Return(int64(12), nil)
+	mockRepo.On("CountExpired", mock.Anything, repository.RetentionQueryHotspots, clock.now.Add(-7*24*time.Hour)).Return(int64(3), nil)
+
+	report, err := service.Prune(context.Background(), true)
+
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	require.Len(t, report.Tables, 2, "ingestion runs have no retention")
+	assert.Equal(t, int64(12), report.Tables[0].Rows)
+	assert.Equal(t, "24h0m0s", report.Tables[0].Retention)
+	assert.Equal(t, int64(3), report.Tables[1].Rows)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "DeleteExpired", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPrune_DeletesInBatches(t *testing.T) {
+	mockRepo := new(MockRetentionRepository)
+	service, _ := newTestRetentionService(mockRepo)
+
+	// Full batches are followed by another; a short one ends the table
+	mockRepo.On("DeleteExpired", mock.Anything, repository.RetentionSecurityEvents, mock.Anything, 2).Return(int64(2), nil).Twice()
+	mockRepo.On("DeleteExpired", mock.Anything, repository.RetentionSecurityEvents, mock.Anything, 2).Return(int64(1), nil).Once()
+	mockRepo.On("DeleteExpired", mock.Anything, repository.RetentionQueryHotspots, mock.Anything, 2).Return(int64(0), nil).Once()
+
+	report, err := service.Prune(context.Background(), false)
+
+	require.NoError(t, err)
+	assert.False(t, report.DryRun)
+	assert.Equal(t, int64(5), report.Tables[0].Rows)
+	assert.Equal(t, int64(0), report.Tables[1].Rows)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPrune_FailureDoesNotStopOtherTables(t *testing.T) {
+	mockRepo := new(MockRetentionRepository)
+	service, _ := newTestRetentionService(mockRepo)
+
+	mockRepo.On("DeleteExpired", mock.Anything, repository.RetentionSecurityEvents, mock.Anything, 2).Return(int64(2), nil).Once()
+	mockRepo.On("DeleteExpired", mock.Anything, repository.RetentionSecurityEvents, mock.Anything, 2).Return(int64(0), errors.New("lock timeout")).Once()
+	mockRepo.On("DeleteExpired", mock.Anything, repository.RetentionQueryHotspots, mock.Anything, 2).Return(int64(1), nil).Once()
+
+	report, err := service.Prune(context.Background(), false)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "security_events")
+	assert.Equal(t, int64(2), report.Tables[0].Rows, "rows deleted before the failure are reported")
+	assert.Equal(t, "lock timeout", report.Tables[0].Error)
+	assert.Equal(t, int64(1), report.Tables[1].Rows)
+	assert.Empty(t, report.Tables[1].Error)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPrune_NoRetentions(t *testing.T) {
+	mockRepo := new(MockRetentionRepository)
+	service := newRetentionService(mockRepo, config.RetentionConfig{Enabled: true, Interval: time.Hour, BatchSize: 10}, logger.New("test"), time.Now)
+
+	report, err := service.Prune(context.Background(), false)
+
+	require.NoError(t, err)
+	assert.Empty(t, report.Tables)
+	mockRepo.AssertNotCalled(t, "DeleteExpired", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"github.com/stwalsh4118/atlas/api/internal/tracing"
+)
+
+// SalesService defines the interface for parcel sales history operations.
+type SalesService interface {
+	// GetParcelSales returns the recorded sales of the parcel, newest first.
+	// Returns an empty slice if the parcel has no recorded sales (not an error).
+	// Returns ErrParcelNotFound if no live parcel has the id.
+	GetParcelSales(ctx context.Context, parcelID uint) ([]models.ParcelSale, error)
+}
+
+// salesService is the concrete implementation of SalesService.
+type salesService struct {
+	repo repository.SalesRepository
+	log  *logger.Logger
+}
+
+// NewSalesService creates a new instance of SalesService.
+func NewSalesService(repo repository.SalesRepository, log *logger.Logger) SalesService {
+	return &salesService{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// GetParcelSales looks up the sales of the parcel.
+func (s *salesService) GetParcelSales(ctx context.Context, parcelID uint) ([]models.ParcelSale, error) {
+	ctx, span := tracing.Start(ctx, "SalesService.GetParcelSales")
+	defer span.End()
+
+	sales, err := s.repo.FindForParcel(ctx, parcelID)
+	if err != nil {
+		s.log.Error("Failed to find parcel sales", err, map[string]interface{}{
+			"parcel_id": parcelID,
+		})
+		return nil, fmt.Errorf("failed to find parcel sales: %w", err)
+	}
+	if sales == nil {
+		return nil, fmt.Errorf("%w: id %d", ErrParcelNotFound, parcelID)
+	}
+
+	return sales, nil
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// MockSalesRepository is a mock implementation of SalesRepository for testing
+type MockSalesRepository struct {
+	mock.Mock
+}
+
+func (m *MockSalesRepository) FindForParcel(ctx context.Context, parcelID uint) ([]models.ParcelSale, error) {
+	args := m.Called(ctx, parcelID)
+	sales, _ := args.Get(0).([]models.ParcelSale)
+	return sales, args.Error(1)
+}
+
+func TestGetParcelSales_Success(t *testing.T) {
+	mockRepo := new(MockSalesRepository)
+	service := NewSalesService(mockRepo, logger.New("test"))
+
+	ctx := context.Background()
+	price := int64(385000)
+	sales := []models.ParcelSale{{ID: 2, SaleDate: time.Date(2019, 6, 14, 0, 0, 0, 0, time.UTC), Price: &price}}
+	mockRepo.On("FindForParcel", ctx, uint(42)).Return(sales, nil)
+
+	result, err := service.GetParcelSales(ctx, 42)
+
+	require.NoError(t, err)
+	assert.Equal(t, sales, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetParcelSales_NoSales(t *testing.T) {
+	mockRepo := new(MockSalesRepository)
+	service := NewSalesService(mockRepo, logger.New("test"))
+
+	mockRepo.On("FindForParcel", mock.Anything, uint(42)).Return([]models.ParcelSale{}, nil)
+
+	result, err := service.GetParcelSales(context.Background(), 42)
+
+	require.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestGetParcelSales_ParcelNotFound(t *testing.T) {
+	mockRepo := new(MockSalesRepository)
+	service := NewSalesService(mockRepo, logger.New("test"))
+
+	mockRepo.On("FindForParcel", mock.Anything, uint(42)).Return(nil, nil)
+
+	_, err := service.GetParcelSales(context.Background(), 42)
+
+	assert.ErrorIs(t, err, ErrParcelNotFound)
+}
+
+func TestGetParcelSales_RepositoryError(t *testing.T) {
+	mockRepo := new(MockSalesRepository)
+	service := NewSalesService(mockRepo, logger.New("test"))
+
+	dbError := errors.New("connection refused")
+	mockRepo.On("FindForParcel", mock.Anything, uint(42)).Return(nil, dbError)
+
+	_, err := service.GetParcelSales(context.Background(), 42)
+
+	assert.ErrorIs(t, err, dbError)
+}
@@ -0,0 +1,360 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	"github.com/stwalsh4118/atlas/api/internal/jobs"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// Saved search constants
+const (
+	// MaxSavedSearchNameLength bounds the name of a saved search, in characters
+	MaxSavedSearchNameLength = 100
+	// MaxSavedSearchMatchesLimit bounds the matches returned per request
+	MaxSavedSearchMatchesLimit = 500
+	// SavedSearchEvaluateTask identifies the durable task evaluating the
+	// searches against one ingestion run
+	SavedSearchEvaluateTask = "saved-searches.evaluate"
+)
+
+// Saved search service errors
+var (
+	ErrInvalidSavedSearch   = domain.Validation("invalid saved search")
+	ErrSavedSearchNotFound  = domain.NotFound("saved search not found")
+	ErrTooManySavedSearches = domain.Conflict("too many saved searches")
+)
+
+// SavedSearchService defines the interface for saved search operations and the
+// alerts job that evaluates them.
+type SavedSearchService interface {
+	// CreateSearch validates and stores a search of the user. Parcels changed by
+	// ingestion runs after its creation are matched.
+	// Returns ErrInvalidSavedSearch, ErrInvalidGeometry or ErrInvalidFilter when
+	// validation fails, or ErrTooManySavedSearches if the user has the maximum
+	// number of searches (config.SavedSearchesConfig.MaxPerUser).
+	CreateSearch(ctx context.Context, search models.SavedSearch) (*models.SavedSearch, error)
+
+	// ListSearches returns the user's searches with their match counts, newest first.
+	ListSearches(ctx context.Context, userID uint) ([]models.SavedSearch, error)
+
+	// DeleteSearch deletes the user's search and its matches.
+	// Returns ErrSavedSearchNotFound if the user has no such search.
+	DeleteSearch(ctx context.Context, userID, id uint) error
+
+	// ListMatches returns up to limit matches of the user's search, newest first.
+	// Returns ErrInvalidLimit if limit is out of range, or ErrSavedSearchNotFound
+	// if the user has no such search.
+	ListMatches(ctx context.Context, userID, id uint, limit int) ([]models.SavedSearchMatch, error)
+
+	// EvaluateAll evaluates every search, recording runID with the matches.
+	// Searches that fail are logged and skipped; they are retried by the next
+	// evaluation, since their EvaluatedAt did not move.
+	// Returns the number of matches recorded.
+	EvaluateAll(ctx context.Context, runID int64) (int64, error)
+
+	// RunAlerts checks the latest ingestion run every poll interval until ctx is
+	// cancelled, evaluating all searches whenever a new run has been recorded,
+	// and once at startup if any run has been. With a task queue the evaluation
+	// is enqueued as a SavedSearchEvaluateTask instead of run in place.
+	RunAlerts(ctx context.Context)
+}
+
+// savedSearchService is the concrete implementation of SavedSearchService.
+type savedSearchService struct {
+	repo    repository.SavedSearchRepository
+	parcels repository.ParcelRepository
+	tasks   *jobs.Queue
+	log     *logger.Logger
+	cfg     config.SavedSearchesConfig
+}
+
+// NewSavedSearchService creates a new instance of SavedSearchService. Areas are
+// checked for topology errors with the parcel repository. tasks may be nil, in
+// which case RunAlerts evaluates in place; otherwise the service registers its
+// evaluation task with it.
+func NewSavedSearchService(repo repository.SavedSearchRepository, parcels repository.ParcelRepository, tasks *jobs.Queue, cfg config.SavedSearchesConfig, log *logger.Logger) SavedSearchService {
+	s := &savedSearchService{
+		repo:    repo,
+		parcels: parcels,
+		tasks:   tasks,
+		log:     log,
+		cfg:     cfg,
+	}
+	if tasks != nil {
+		tasks.Handle(SavedSearchEvaluateTask, s.evaluateTask)
+	}
+	return s
+}
+
+// CreateSearch validates the name, area and filters with the rules of the
+// intersects and nearby endpoints before counting the user's searches.
+func (s *savedSearchService) CreateSearch(ctx context.Context, search models.SavedSearch) (*models.SavedSearch, error) {
+	search.Name = strings.TrimSpace(search.Name)
+	if search.Name == "" || utf8.RuneCountInString(search.Name) > MaxSavedSearchNameLength {
+		return nil, fmt.Errorf("%w: name must be between 1 and %d characters", ErrInvalidSavedSearch, MaxSavedSearchNameLength)
+	}
+	if search.County != nil {
+		if county := strings.TrimSpace(*search.County); county != "" {
+			search.County = &county
+		} else {
+			search.County = nil
+		}
+	}
+
+	filters, err := normalizeSavedSearchFilters(search.Filters)
+	if err != nil {
+		return nil, err
+	}
+	search.Filters = filters
+
+	if err := validateArea(&search.Area); err != nil {
+		return nil, err
+	}
+	reason, err := s.parcels.ValidateArea(ctx, search.Area)
+	if err != nil {
+		s.log.Error("Failed to validate area geometry", err, nil)
+		return nil, fmt.Errorf("failed to validate area: %w", err)
+	}
+	if reason != "" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidGeometry, reason)
+	}
+
+	if s.cfg.MaxPerUser > 0 {
+		count, err := s.repo.Count(ctx, search.UserID)
+		if err != nil {
+			s.log.Error("Failed to count saved searches", err, map[string]interface{}{
+				"user_id": search.UserID,
+			})
+			return nil, fmt.Errorf("failed to count saved searches: %w", err)
+		}
+		if count >= s.cfg.MaxPerUser {
+			return nil, fmt.Errorf("%w: at most %d saved searches are allowed per user", ErrTooManySavedSearches, s.cfg.MaxPerUser)
+		}
+	}
+
+	stored, err := s.repo.Create(ctx, &search)
+	if err != nil {
+		s.log.Error("Failed to create saved search", err, map[string]interface{}{
+			"user_id": search.UserID,
+		})
+		return nil, fmt.Errorf("failed to create saved search: %w", err)
+	}
+
+	s.log.Info("Saved search created", map[string]interface{}{
+		"user_id":         stored.UserID,
+		"saved_search_id": stored.ID,
+	})
+	return stored, nil
+}
+
+// ListSearches returns the user's searches.
+func (s *savedSearchService) ListSearches(ctx context.Context, userID uint) ([]models.SavedSearch, error) {
+	searches, err := s.repo.List(ctx, userID)
+	if err != nil {
+		s.log.Error("Failed to list saved searches", err, map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, fmt.Errorf("failed to list saved searches: %w", err)
+	}
+
+	return searches, nil
+}
+
+// DeleteSearch deletes the search if the user owns it.
+func (s *savedSearchService) DeleteSearch(ctx context.Context, userID, id uint) error {
+	deleted, err := s.repo.Delete(ctx, userID, id)
+	if err != nil {
+		s.log.Error("Failed to delete saved search", err, map[string]interface{}{
+			"user_id":         userID,
+			"saved_search_id": id,
+		})
+		return fmt.Errorf("failed to delete saved search: %w", err)
+	}
+	if !deleted {
+		return ErrSavedSearchNotFound
+	}
+
+	s.log.Info("Saved search deleted", map[string]interface{}{
+		"user_id":         userID,
+		"saved_search_id": id,
+	})
+	return nil
+}
+
+// ListMatches validates the limit and reads the search's matches.
+func (s *savedSearchService) ListMatches(ctx context.Context, userID, id uint, limit int) ([]models.SavedSearchMatch, error) {
+	if limit < 1 || limit > MaxSavedSearchMatchesLimit {
+		return nil, fmt.Errorf("%w: must be between 1 and %d, got %d", ErrInvalidLimit, MaxSavedSearchMatchesLimit, limit)
+	}
+
+	matches, err := s.repo.FindMatches(ctx, userID, id, limit)
+	if err != nil {
+		s.log.Error("Failed to find saved search matches", err, map[string]interface{}{
+			"user_id":         userID,
+			"saved_search_id": id,
+		})
+		return nil, fmt.Errorf("failed to find saved search matches: %w", err)
+	}
+	if matches == nil {
+		return nil, ErrSavedSearchNotFound
+	}
+
+	return matches, nil
+}
+
+// EvaluateAll evaluates the searches one at a time, so the job holds at most
+// one pool connection.
+func (s *savedSearchService) EvaluateAll(ctx context.Context, runID int64) (int64, error) {
+	total, _, err := s.evaluateAll(ctx, runID)
+	return total, err
+}
+
+// evaluateAll implements EvaluateAll, also returning the number of searches
+// that failed.
+func (s *savedSearchService) evaluateAll(ctx context.Context, runID int64) (int64, int, error) {
+	started := time.Now()
+
+	searches, err := s.repo.ListAll(ctx)
+	if err != nil {
+		s.log.Error("Failed to load saved searches", err, nil)
+		return 0, 0, fmt.Errorf("failed to load saved searches: %w", err)
+	}
+
+	var total int64
+	failed := 0
+	for _, search := range searches {
+		if ctx.Err() != nil {
+			return total, failed, ctx.Err()
+		}
+		matches, err := s.repo.Evaluate(ctx, search, runID)
+		if err != nil {
+			failed++
+			s.log.Error("Failed to evaluate saved search", err, map[string]interface{}{
+				"saved_search_id": search.ID,
+				"run_id":          runID,
+			})
+			continue
+		}
+		total += matches
+	}
+
+	s.log.Info("Saved searches evaluated", map[string]interface{}{
+		"run_id":      runID,
+		"searches":    len(searches),
+		"failed":      failed,
+		"matches":     total,
+		"duration_ms": time.Since(started).Milliseconds(),
+	})
+
+	return total, failed, nil
+}
+
+// runTaskPayload is the payload of the tasks processing one ingestion run.
+type runTaskPayload struct {
+	RunID int64 `json:"run_id"`
+}
+
+// evaluateTask runs a SavedSearchEvaluateTask. Searches that failed fail the
+// task, so it is retried; searches already evaluated then match nothing new.
+func (s *savedSearchService) evaluateTask(ctx context.Context, payload json.RawMessage) error {
+	var p runTaskPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return fmt.Errorf("invalid %s payload: %w", SavedSearchEvaluateTask, err)
+	}
+
+	_, failed, err := s.evaluateAll(ctx, p.RunID)
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d saved searches failed to evaluate", failed)
+	}
+	return nil
+}
+
+// enqueueRunTask enqueues a task of the given kind for an ingestion run. The
+// task is keyed by the run, so instances that detect the same run enqueue it once.
+func enqueueRunTask(ctx context.Context, tasks *jobs.Queue, kind string, runID int64) error {
+	_, err := tasks.Enqueue(ctx, kind, fmt.Sprintf("run:%d", runID), runTaskPayload{RunID: runID})
+	return err
+}
+
+// RunAlerts blocks until ctx is cancelled; run it in its own goroutine.
+// Evaluation only matches changes made since each search was last evaluated,
+// so the evaluation at startup picks up runs recorded while no instance ran the job.
+func (s *savedSearchService) RunAlerts(ctx context.Context) {
+	var run int64
+
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		latest, err := s.repo.LatestIngestionRun(ctx)
+		if err != nil {
+			s.log.Error("Failed to read latest ingestion run", err, nil)
+			continue
+		}
+		if latest == run {
+			continue
+		}
+
+		s.log.Info("New ingestion run detected, evaluating saved searches", map[string]interface{}{
+			"previous_run_id": run,
+			"run_id":          latest,
+		})
+
+		if s.tasks == nil {
+			run = latest
+			// Errors are already logged by EvaluateAll; the next run retries
+			_, _ = s.EvaluateAll(ctx, latest)
+			continue
+		}
+		if err := enqueueRunTask(ctx, s.tasks, SavedSearchEvaluateTask, latest); err != nil {
+			// The run is detected again at the next tick
+			s.log.Error("Failed to enqueue saved search evaluation", err, map[string]interface{}{
+				"run_id": latest,
+			})
+			continue
+		}
+		run = latest
+	}
+}
+
+// normalizeSavedSearchFilters checks the filters with the rules of the nearby endpoint.
+// Returns an error wrapping ErrInvalidFilter describing the offending value.
+func normalizeSavedSearchFilters(f models.SavedSearchFilters) (models.SavedSearchFilters, error) {
+	filter, err := normalizeNearbyFilter(repository.NearbyFilter{
+		MinAcres:            f.MinAcres,
+		MaxAcres:            f.MaxAcres,
+		MinYearBuilt:        f.MinYearBuilt,
+		MaxYearBuilt:        f.MaxYearBuilt,
+		Waterfront:          f.Waterfront,
+		LandUse:             f.LandUse,
+		OwnerName:           f.OwnerName,
+		Amenity:             f.Amenity,
+		AmenityWithinMeters: f.AmenityWithin,
+	})
+	if err != nil {
+		return f, err
+	}
+
+	f.LandUse = filter.LandUse
+	f.OwnerName = filter.OwnerName
+	return f, nil
+}
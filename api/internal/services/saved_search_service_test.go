@@ -0,0 +1,288 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// MockSavedSearchRepository is a mock implementation of SavedSearchRepository for testing
+type MockSavedSearchRepository struct {
+	mock.Mock
+}
+
+func (m *MockSavedSearchRepository) Create(ctx context.Context, search *models.SavedSearch) (*models.SavedSearch, error) {
+	args := m.Called(ctx, search)
+	stored, _ := args.Get(0).(*models.SavedSearch)
+	return stored, args.Error(1)
+}
+
+func (m *MockSavedSearchRepository) List(ctx context.Context, userID uint) ([]models.SavedSearch, error) {
+	args := m.Called(ctx, userID)
+	searches, _ := args.Get(0).([]models.SavedSearch)
+	return searches, args.Error(1)
+}
+
+func (m *MockSavedSearchRepository) ListAll(ctx context.Context) ([]models.SavedSearch, error) {
+	args := m.Called(ctx)
+	searches, _ := args.Get(0).([]models.SavedSearch)
+	return searches, args.Error(1)
+}
+
+func (m *MockSavedSearchRepository) Count(ctx context.Context, userID uint) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockSavedSearchRepository) Delete(ctx context.Context, userID, id uint) (bool, error) {
+	args := m.Called(ctx, userID, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSavedSearchRepository) FindMatches(ctx context.Context, userID, id uint, limit int) ([]models.SavedSearchMatch, error) {
+	args := m.Called(ctx, userID, id, limit)
+	matches, _ := args.Get(0).([]models.SavedSearchMatch)
+	return matches, args.Error(1)
+}
+
+func (m *MockSavedSearchRepository) Evaluate(ctx context.Context, search models.SavedSearch, runID int64) (int64, error) {
+	args := m.Called(ctx, search, runID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSavedSearchRepository) LatestIngestionRun(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// testSavedSearchConfig allows two searches per user.
+var testSavedSearchConfig = config.SavedSearchesConfig{MaxPerUser: 2}
+
+func TestCreateSearch_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSavedSearchRepository)
+	mockParcels := new(MockParcelRepository)
+	service := NewSavedSearchService(mockRepo, mockParcels, nil, testSavedSearchConfig, logger.New("test"))
+	ctx := context.Background()
+	county := " montgomery-tx "
+	minAcres := 5.0
+
+	mockParcels.On("ValidateArea", ctx, *testArea()).Return("", nil)
+	mockRepo.On("Count", ctx, uint(7)).Return(1, nil)
+	var created *models.SavedSearch
+	mockRepo.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*models.SavedSearch)
+	}).Return(&models.SavedSearch{ID: 3, UserID: 7}, nil)
+
+	// Act
+	result, err := service.CreateSearch(ctx, models.SavedSearch{
+		UserID:  7,
+		Name:    "  Lake lots ",
+		Area:    *testArea(),
+		County:  &county,
+		Filters: models.SavedSearchFilters{MinAcres: &minAcres, LandUse: " A1 "},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, uint(3), result.ID)
+	assert.Equal(t, "Lake lots", created.Name)
+	assert.Equal(t, "montgomery-tx", *created.County)
+	assert.Equal(t, "A1", created.Filters.LandUse)
+	assert.Equal(t, &minAcres, created.Filters.MinAcres)
+	mockRepo.AssertExpectations(t)
+	mockParcels.AssertExpectations(t)
+}
+
+func TestCreateSearch_ValidationErrors(t *testing.T) {
+	amenityOnly := models.SavedSearchFilters{Amenity: models.AmenitySchool}
+	maxAcres, minAcres := 1.0, 2.0
+
+	testCases := []struct {
+		name    string
+		search  models.SavedSearch
+		errType error
+	}{
+		{"blank name", models.SavedSearch{Name: "  ", Area: *testArea()}, ErrInvalidSavedSearch},
+		{"name too long", models.SavedSearch{Name: string(make([]rune, MaxSavedSearchNameLength+1)), Area: *testArea()}, ErrInvalidSavedSearch},
+		{"inverted acres", models.SavedSearch{Name: "s", Area: *testArea(),
+			Filters: models.SavedSearchFilters{MinAcres: &minAcres, MaxAcres: &maxAcres}}, ErrInvalidFilter},
+		{"amenity without distance", models.SavedSearch{Name: "s", Area: *testArea(), Filters: amenityOnly}, ErrInvalidFilter},
+		{"empty area", models.SavedSearch{Name: "s"}, ErrInvalidGeometry},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockSavedSearchRepository)
+			mockParcels := new(MockParcelRepository)
+			service := NewSavedSearchService(mockRepo, mockParcels, nil, testSavedSearchConfig, logger.New("test"))
+
+			// Act
+			result, err := service.CreateSearch(context.Background(), tc.search)
+
+			// Assert
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tc.errType)
+			mockRepo.AssertNotCalled(t, "Create")
+		})
+	}
+}
+
+func TestCreateSearch_InvalidTopology(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSavedSearchRepository)
+	mockParcels := new(MockParcelRepository)
+	service := NewSavedSearchService(mockRepo, mockParcels, nil, testSavedSearchConfig, logger.New("test"))
+	ctx := context.Background()
+
+	mockParcels.On("ValidateArea", ctx, *testArea()).Return("Self-intersection", nil)
+
+	// Act
+	result, err := service.CreateSearch(ctx, models.SavedSearch{UserID: 7, Name: "s", Area: *testArea()})
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, ErrInvalidGeometry)
+	assert.Contains(t, err.Error(), "Self-intersection")
+	mockRepo.AssertNotCalled(t, "Create")
+}
+
+func TestCreateSearch_TooMany(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSavedSearchRepository)
+	mockParcels := new(MockParcelRepository)
+	service := NewSavedSearchService(mockRepo, mockParcels, nil, testSavedSearchConfig, logger.New("test"))
+	ctx := context.Background()
+
+	mockParcels.On("ValidateArea", ctx, *testArea()).Return("", nil)
+	mockRepo.On("Count", ctx, uint(7)).Return(2, nil)
+
+	// Act
+	result, err := service.CreateSearch(ctx, models.SavedSearch{UserID: 7, Name: "s", Area: *testArea()})
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, ErrTooManySavedSearches)
+	mockRepo.AssertNotCalled(t, "Create")
+}
+
+func TestCreateSearch_Unlimited(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSavedSearchRepository)
+	mockParcels := new(MockParcelRepository)
+	service := NewSavedSearchService(mockRepo, mockParcels, nil, config.SavedSearchesConfig{}, logger.New("test"))
+	ctx := context.Background()
+
+	mockParcels.On("ValidateArea", ctx, *testArea()).Return("", nil)
+	mockRepo.On("Create", ctx, mock.Anything).Return(&models.SavedSearch{ID: 1}, nil)
+
+	// Act
+	_, err := service.CreateSearch(ctx, models.SavedSearch{UserID: 7, Name: "s", Area: *testArea()})
+
+	// Assert
+	require.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "Count")
+}
+
+func TestDeleteSearch(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSavedSearchRepository)
+	service := NewSavedSearchService(mockRepo, new(MockParcelRepository), nil, testSavedSearchConfig, logger.New("test"))
+	ctx := context.Background()
+
+	mockRepo.On("Delete", ctx, uint(7), uint(3)).Return(true, nil)
+	mockRepo.On("Delete", ctx, uint(7), uint(4)).Return(false, nil)
+
+	// Act & Assert
+	assert.NoError(t, service.DeleteSearch(ctx, 7, 3))
+	assert.ErrorIs(t, service.DeleteSearch(ctx, 7, 4), ErrSavedSearchNotFound)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListMatches(t *testing.T) {
+	t.Run("returns matches", func(t *testing.T) {
+		mockRepo := new(MockSavedSearchRepository)
+		service := NewSavedSearchService(mockRepo, new(MockParcelRepository), nil, testSavedSearchConfig, logger.New("test"))
+		ctx := context.Background()
+
+		mockRepo.On("FindMatches", ctx, uint(7), uint(3), 10).Return([]models.SavedSearchMatch{}, nil)
+
+		matches, err := service.ListMatches(ctx, 7, 3, 10)
+
+		require.NoError(t, err)
+		assert.Empty(t, matches)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("search not found", func(t *testing.T) {
+		mockRepo := new(MockSavedSearchRepository)
+		service := NewSavedSearchService(mockRepo, new(MockParcelRepository), nil, testSavedSearchConfig, logger.New("test"))
+		ctx := context.Background()
+
+		mockRepo.On("FindMatches", ctx, uint(7), uint(3), 10).Return(nil, nil)
+
+		_, err := service.ListMatches(ctx, 7, 3, 10)
+
+		assert.ErrorIs(t, err, ErrSavedSearchNotFound)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		mockRepo := new(MockSavedSearchRepository)
+		service := NewSavedSearchService(mockRepo, new(MockParcelRepository), nil, testSavedSearchConfig, logger.New("test"))
+
+		_, err := service.ListMatches(context.Background(), 7, 3, MaxSavedSearchMatchesLimit+1)
+
+		assert.ErrorIs(t, err, ErrInvalidLimit)
+		mockRepo.AssertNotCalled(t, "FindMatches")
+	})
+}
+
+func TestEvaluateAll_SkipsFailedSearches(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSavedSearchRepository)
+	service := NewSavedSearchService(mockRepo, new(MockParcelRepository), nil, testSavedSearchConfig, logger.New("test"))
+	ctx := context.Background()
+	searches := []models.SavedSearch{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	mockRepo.On("ListAll", ctx).Return(searches, nil)
+	mockRepo.On("Evaluate", ctx, searches[0], int64(9)).Return(int64(2), nil)
+	mockRepo.On("Evaluate", ctx, searches[1], int64(9)).Return(int64(0), errors.New("statement timeout"))
+	mockRepo.On("Evaluate", ctx, searches[2], int64(9)).Return(int64(1), nil)
+
+	// Act
+	matches, err := service.EvaluateAll(ctx, 9)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), matches)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestEvaluateTask_FailsWhenSearchesFail(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSavedSearchRepository)
+	service := NewSavedSearchService(mockRepo, new(MockParcelRepository), nil, testSavedSearchConfig, logger.New("test")).(*savedSearchService)
+	ctx := context.Background()
+	searches := []models.SavedSearch{{ID: 1}, {ID: 2}}
+
+	mockRepo.On("ListAll", ctx).Return(searches, nil)
+	mockRepo.On("Evaluate", ctx, searches[0], int64(9)).Return(int64(2), nil)
+	mockRepo.On("Evaluate", ctx, searches[1], int64(9)).Return(int64(0), errors.New("statement timeout"))
+
+	// Act
+	err := service.evaluateTask(ctx, json.RawMessage(`{"run_id":9}`))
+
+	// Assert
+	require.Error(t, err, "the task is retried")
+	assert.Contains(t, err.Error(), "1 saved searches failed")
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"github.com/stwalsh4118/atlas/api/internal/schema"
+)
+
+// SchemaService defines the interface for detecting schema drift: differences
+// between the live database and the migrations and models, such as a skipped
+// migration, an index dropped by hand or a column whose type was changed.
+type SchemaService interface {
+	// Plan compares the database with the migrations and the model columns and
+	// reports the differences. It changes nothing.
+	Plan(ctx context.Context) (*models.SchemaReport, error)
+
+	// CheckMigrations returns an error when the database is behind the latest
+	// migration or its last migration failed halfway (dirty). It only reads the
+	// migration version, so it is cheap enough for readiness checks. A database
+	// ahead of the migrations, as while an older API is rolled back to, passes.
+	CheckMigrations(ctx context.Context) error
+}
+
+// schemaService is the concrete implementation of SchemaService.
+type schemaService struct {
+	repo       repository.SchemaRepository
+	migrations fs.FS
+	tables     []schema.Table
+	now        func() time.Time
+}
+
+// NewSchemaService creates a new instance of SchemaService comparing the
+// database with the migration files in migrations (see migrations.FS) and the
+// columns of schema.Tables.
+func NewSchemaService(repo repository.SchemaRepository, migrations fs.FS) SchemaService {
+	return newSchemaService(repo, migrations, schema.Tables, time.Now)
+}
+
+// newSchemaService creates a schemaService for the given tables, reading the
+// time from now; tests pass their own.
+func newSchemaService(repo repository.SchemaRepository, migrations fs.FS, tables []schema.Table, now func() time.Time) *schemaService {
+	return &schemaService{
+		repo:       repo,
+		migrations: migrations,
+		tables:     tables,
+		now:        now,
+	}
+}
+
+// Plan expects the indexes of the migrations up to the applied version, so a
+// pending migration is reported once rather than again as its missing indexes.
+// Columns are always checked: models are updated with their migration.
+func (s *schemaService) Plan(ctx context.Context) (*models.SchemaReport, error) {
+	migrations, err := schema.Migrations(s.migrations)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.SchemaReport{
+		CheckedAt:      s.now().UTC(),
+		Pending:        []models.SchemaMigration{},
+		MissingIndexes: []string{},
+		MissingColumns: []models.SchemaColumn{},
+		TypeMismatches: []models.SchemaColumn{},
+	}
+	if len(migrations) > 0 {
+		report.LatestVersion = migrations[len(migrations)-1].Version
+	}
+
+	report.CurrentVersion, report.Dirty, err = s.repo.MigrationVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, migration := range migrations {
+		if migration.Version > report.CurrentVersion {
+			report.Pending = append(report.Pending, models.SchemaMigration{Version: migration.Version, Name: migration.Name})
+		}
+	}
+
+	if err := s.checkIndexes(ctx, report); err != nil {
+		return nil, err
+	}
+	if err := s.checkColumns(ctx, report); err != nil {
+		return nil, err
+	}
+
+	report.Drift = report.Dirty || len(report.Pending) > 0 || len(report.MissingIndexes) > 0 ||
+		len(report.MissingColumns) > 0 || len(report.TypeMismatches) > 0
+	return report, nil
+}
+
+// CheckMigrations compares the recorded version with the embedded migrations.
+func (s *schemaService) CheckMigrations(ctx context.Context) error {
+	migrations, err := schema.Migrations(s.migrations)
+	if err != nil {
+		return err
+	}
+
+	current, dirty, err := s.repo.MigrationVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("migration %d is dirty", current)
+	}
+	if len(migrations) > 0 && current < migrations[len(migrations)-1].Version {
+		return fmt.Errorf("database is at migration %d, expected %d", current, migrations[len(migrations)-1].Version)
+	}
+	return nil
+}
+
+// checkIndexes adds the indexes the applied migrations create that the
+// database does not have.
+func (s *schemaService) checkIndexes(ctx context.Context, report *models.SchemaReport) error {
+	expected, err := schema.Indexes(s.migrations, report.CurrentVersion)
+	if err != nil {
+		return fmt.Errorf("failed to read expected indexes: %w", err)
+	}
+
+	actual, err := s.repo.Indexes(ctx)
+	if err != nil {
+		return err
+	}
+	present := make(map[string]bool, len(actual))
+	for _, name := range actual {
+		present[name] = true
+	}
+
+	for _, name := range expected {
+		if !present[name] {
+			report.MissingIndexes = append(report.MissingIndexes, name)
+		}
+	}
+	return nil
+}
+
+// checkColumns adds the model columns the database does not have or has with
+// another type. Columns without a model are not reported.
+func (s *schemaService) checkColumns(ctx context.Context, report *models.SchemaReport) error {
+	actual, err := s.repo.Columns(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, column := range schema.Columns(s.tables...) {
+		result := models.SchemaColumn{Table: column.Table, Column: column.Name, Expected: column.Expected()}
+		columnType, ok := actual[column.Table][column.Name]
+		switch {
+		case !ok:
+			report.MissingColumns = append(report.MissingColumns, result)
+		case !column.Matches(columnType):
+			result.Actual = columnType
+			report.TypeMismatches = append(report.TypeMismatches, result)
+		}
+	}
+	return nil
+}
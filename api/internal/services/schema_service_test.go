@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/schema"
+)
+
+// MockSchemaRepository is a mock implementation of SchemaRepository for testing
+type MockSchemaRepository struct {
+	mock.Mock
+}
+
+func (m *MockSchemaRepository) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(uint), args.Bool(1), args.Error(2)
+}
+
+func (m *MockSchemaRepository) Indexes(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	indexes, _ := args.Get(0).([]string)
+	return indexes, args.Error(1)
+}
+
+func (m *MockSchemaRepository) Columns(ctx context.Context) (map[string]map[string]string, error) {
+	args := m.Called(ctx)
+	columns, _ := args.Get(0).(map[string]map[string]string)
+	return columns, args.Error(1)
+}
+
+// testSchemaTable is the model of the widgets table created by testMigrations
+type testSchemaTable struct {
+	CreatedAt time.Time `gorm:"column:created_at"`
+	Name      string    `gorm:"size:100;column:name"`
+	Internal  string    `gorm:"-"`
+	ID        uint      `gorm:"primaryKey"`
+}
+
+func (testSchemaTable) TableName() string { return "widgets" }
+
+var testMigrations = fstest.MapFS{
+	"000001_create_widgets.up.sql": {Data: []byte(`
+		CREATE TABLE widgets (id SERIAL PRIMARY KEY, name VARCHAR(100), created_at TIMESTAMP);
+		CREATE INDEX idx_widgets_name ON widgets (name);`)},
+	"000002_add_widgets_created_index.up.sql": {Data: []byte(`
+		CREATE INDEX idx_widgets_created_at ON widgets (created_at);`)},
+}
+
+// testSchemaColumns are the columns of widgets as migrated
+var testSchemaColumns = map[string]map[string]string{
+	"widgets": {"id": "integer", "name": "character varying(100)", "created_at": "timestamp without time zone"},
+}
+
+func newTestSchemaService(repo *MockSchemaRepository) *schemaService {
+	clock := &fakeClock{now: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	return newSchemaService(repo, testMigrations, []schema.Table{testSchemaTable{}}, clock.Now)
+}
+
+func TestPlan_NoDrift(t *testing.T) {
+	mockRepo := new(MockSchemaRepository)
+	service := newTestSchemaService(mockRepo)
+
+	mockRepo.On("MigrationVersion", mock.Anything).Return(uint(2), false, nil)
+	mockRepo.On("Indexes", mock.Anything).Return([]string{"widgets_pkey", "idx_widgets_name", "idx_widgets_created_at"}, nil)
+	mockRepo.On("Columns", mock.Anything).Return(testSchemaColumns, nil)
+
+	report, err := service.Plan(context.Background())
+
+	require.NoError(t, err)
+	assert.False(t, report.Drift)
+	assert.Equal(t, uint(2), report.CurrentVersion)
+	assert.Equal(t, uint(2), report.LatestVersion)
+	assert.Empty(t, report.Pending)
+	assert.Empty(t, report.MissingIndexes)
+	assert.Empty(t, report.MissingColumns)
+	assert.Empty(t, report.TypeMismatches)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPlan_ReportsDrift(t *testing.T) {
+	mockRepo := new(MockSchemaRepository)
+	service := newTestSchemaService(mockRepo)
+
+	mockRepo.On("MigrationVersion", mock.Anything).Return(uint(1), false, nil)
+	mockRepo.On("Indexes", mock.Anything).Return([]string{"widgets_pkey"}, nil)
+	mockRepo.On("Columns", mock.Anything).Return(map[string]map[string]string{
+		"widgets": {"id": "integer", "name": "character varying(50)"},
+	}, nil)
+
+	report, err := service.Plan(context.Background())
+
+	require.NoError(t, err)
+	assert.True(t, report.Drift)
+	assert.Equal(t, []models.SchemaMigration{{Version: 2, Name: "add_widgets_created_index"}}, report.Pending)
+	assert.Equal(t, []string{"idx_widgets_name"}, report.MissingIndexes, "indexes of pending migrations are not missing")
+	assert.Equal(t, []models.SchemaColumn{
+		{Table: "widgets", Column: "created_at", Expected: "timestamp type"},
+	}, report.MissingColumns)
+	assert.Equal(t, []models.SchemaColumn{
+		{Table: "widgets", Column: "name", Expected: "character varying(100)", Actual: "character varying(50)"},
+	}, report.TypeMismatches)
+}
+
+func TestPlan_DirtyIsDrift(t *testing.T) {
+	mockRepo := new(MockSchemaRepository)
+	service := newTestSchemaService(mockRepo)
+
+	mockRepo.On("MigrationVersion", mock.Anything).Return(uint(2), true, nil)
+	mockRepo.On("Indexes", mock.Anything).Return([]string{"idx_widgets_name", "idx_widgets_created_at"}, nil)
+	mockRepo.On("Columns", mock.Anything).Return(testSchemaColumns, nil)
+
+	report, err := service.Plan(context.Background())
+
+	require.NoError(t, err)
+	assert.True(t, report.Dirty)
+	assert.True(t, report.Drift)
+}
+
+func TestPlan_RepositoryError(t *testing.T) {
+	mockRepo := new(MockSchemaRepository)
+	service := newTestSchemaService(mockRepo)
+
+	mockRepo.On("MigrationVersion", mock.Anything).Return(uint(0), false, errors.New("connection refused"))
+
+	report, err := service.Plan(context.Background())
+
+	assert.Nil(t, report)
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestCheckMigrations(t *testing.T) {
+	tests := []struct {
+		name    string
+		version uint
+		dirty   bool
+		wantErr string
+	}{
+		{name: "latest migration passes", version: 2},
+		{name: "newer schema passes", version: 3},
+		{name: "pending migration fails", version: 1, wantErr: "database is at migration 1, expected 2"},
+		{name: "dirty migration fails", version: 2, dirty: true, wantErr: "migration 2 is dirty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockSchemaRepository)
+			service := newTestSchemaService(mockRepo)
+			mockRepo.On("MigrationVersion", mock.Anything).Return(tt.version, tt.dirty, nil)
+
+			err := service.CheckMigrations(context.Background())
+
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			mockRepo.AssertNotCalled(t, "Indexes", mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,379 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"github.com/stwalsh4118/atlas/api/internal/siem"
+)
+
+// Brute-force guard constants
+const (
+	// SecuritySummaryWindow is how far back the security dashboard looks
+	SecuritySummaryWindow = 24 * time.Hour
+	// SecuritySummaryLimit bounds the client IPs and subjects listed on the dashboard
+	SecuritySummaryLimit = 20
+	// securityQueueSize bounds the events waiting to be written
+	securityQueueSize = 1000
+	// securityFlushInterval is how often queued events are written
+	securityFlushInterval = 5 * time.Second
+	// maxTrackedAttempts bounds the client IPs and subjects with failures held in memory
+	maxTrackedAttempts = 100000
+)
+
+// SecurityService defines the interface for detecting brute-force attacks on
+// authentication. It counts failures per client IP and per subject (the API key
+// or account attempted) and locks out those that keep failing, with a lockout
+// twice as long as the previous one each time. Lockouts are held in memory, so
+// each instance enforces its own.
+type SecurityService interface {
+	// LockedFor returns how much longer the client IP or subject is locked out,
+	// or 0 if neither is. Either may be empty. Safe for concurrent use.
+	LockedFor(ip, subject string) time.Duration
+
+	// RecordFailure counts a failed authentication by the client IP on subject,
+	// which is empty when the credential is unknown, e.g. a bearer token. The
+	// failure and any lockout it causes are queued for the security event log.
+	// It never blocks; events are dropped when the queue is full.
+	RecordFailure(ip, subject, route string)
+
+	// RecordSuccess clears the failures of subject. The client IP's failures
+	// stand, so signing in to one account does not reset guesses at others.
+	RecordSuccess(subject string)
+
+	// Summary summarizes the security events of the last SecuritySummaryWindow
+	// and lists the lockouts active on this instance.
+	// Returns error for database failures.
+	Summary(ctx context.Context) (*models.SecuritySummary, error)
+
+	// Run writes queued events every few seconds and forgets expired lockouts
+	// until ctx is cancelled, then writes once more. It runs in the process that
+	// records the failures.
+	Run(ctx context.Context)
+}
+
+// EventPublisher forwards events to an external sink such as a SIEM.
+// Publish must not block.
+type EventPublisher interface {
+	Publish(event siem.Event)
+}
+
+// attempts are the recent failures of one client IP or subject.
+type attempts struct {
+	windowStart time.Time
+	lockedUntil time.Time
+	// failures counts failures since windowStart
+	failures int
+	// lockouts counts the lockouts so far; each doubles the next one
+	lockouts int
+}
+
+// securityService is the concrete implementation of SecurityService.
+type securityService struct {
+	repo repository.SecurityRepository
+	log  *logger.Logger
+	cfg  config.AuthConfig
+	now  func() time.Time
+	// events receives a copy of each security event; nil when no SIEM is configured
+	events EventPublisher
+
+	mu       sync.Mutex
+	attempts map[string]*attempts
+
+	queue   chan models.SecurityEvent
+	dropped atomic.Int64
+}
+
+// NewSecurityService creates a new instance of SecurityService with the lockout
+// settings of cfg. Security events are also published to events unless it is nil.
+func NewSecurityService(repo repository.SecurityRepository, cfg config.AuthConfig, events EventPublisher, log *logger.Logger) SecurityService {
+	return newSecurityService(repo, cfg, events, log, time.Now)
+}
+
+// newSecurityService creates a securityService reading the time from now;
+// tests pass a fake clock.
+func newSecurityService(repo repository.SecurityRepository, cfg config.AuthConfig, events EventPublisher, log *logger.Logger, now func() time.Time) *securityService {
+	return &securityService{
+		repo:     repo,
+		log:      log,
+		cfg:      cfg,
+		now:      now,
+		events:   events,
+		attempts: make(map[string]*attempts),
+		queue:    make(chan models.SecurityEvent, securityQueueSize),
+	}
+}
+
+// ipKey is the attempts key of a client IP; subjects carry their own prefix.
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+// LockedFor returns the longer of the two remaining lockouts.
+func (s *securityService) LockedFor(ip, subject string) time.Duration {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var wait time.Duration
+	for _, key := range attemptKeys(ip, subject) {
+		if a, ok := s.attempts[key]; ok && a.lockedUntil.After(now) {
+			wait = max(wait, a.lockedUntil.Sub(now))
+		}
+	}
+	return wait
+}
+
+// RecordFailure counts the failure against the client IP and the subject.
+func (s *securityService) RecordFailure(ip, subject, route string) {
+	now := s.now()
+	s.enqueue(models.SecurityEvent{
+		CreatedAt: now,
+		Type:      models.SecurityEventAuthFailure,
+		ClientIP:  ip,
+		Subject:   subject,
+		Route:     route,
+	})
+	if s.cfg.LockoutThreshold <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	var locked []models.SecurityEvent
+	for _, key := range attemptKeys(ip, subject) {
+		if duration := s.countFailure(key, now); duration > 0 {
+			locked = append(locked, models.SecurityEvent{
+				CreatedAt: now,
+				Type:      models.SecurityEventLockout,
+				ClientIP:  ip,
+				Subject:   subject,
+				Route:     route,
+				Detail:    fmt.Sprintf("locked %s for %s", key, duration),
+			})
+		}
+	}
+	s.mu.Unlock()
+
+	for _, event := range locked {
+		s.log.Warn("Authentication lockout", map[string]interface{}{
+			"client_ip": ip,
+			"subject":   subject,
+			"route":     route,
+			"detail":    event.Detail,
+		})
+		s.enqueue(event)
+	}
+}
+
+// countFailure counts a failure of key and locks it out when the threshold is
+// reached within the window. Returns the lockout duration, or 0 if key was not
+// locked out. The caller holds s.mu.
+func (s *securityService) countFailure(key string, now time.Time) time.Duration {
+	a, ok := s.attempts[key]
+	if !ok {
+		if len(s.attempts) >= maxTrackedAttempts {
+			s.prune(now)
+		}
+		if len(s.attempts) >= maxTrackedAttempts {
+			return 0
+		}
+		a = &attempts{windowStart: now}
+		s.attempts[key] = a
+	}
+
+	// Locked out clients are rejected before authenticating, so failures only
+	// arrive here from requests racing the lockout; they do not extend it
+	if a.lockedUntil.After(now) {
+		return 0
+	}
+	if now.Sub(a.windowStart) > s.cfg.LockoutWindow {
+		a.windowStart = now
+		a.failures = 0
+	}
+
+	a.failures++
+	if a.failures < s.cfg.LockoutThreshold {
+		return 0
+	}
+
+	duration := s.cfg.LockoutDuration
+	for i := 0; i < a.lockouts && duration < s.cfg.LockoutMax; i++ {
+		duration *= 2
+	}
+	duration = min(duration, s.cfg.LockoutMax)
+	a.lockouts++
+	a.failures = 0
+	a.windowStart = now
+	a.lockedUntil = now.Add(duration)
+	return duration
+}
+
+// RecordSuccess forgets the subject unless it is locked out, so a correct
+// password does not lift a lockout early.
+func (s *securityService) RecordSuccess(subject string) {
+	if subject == "" {
+		return
+	}
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a, ok := s.attempts[subject]; ok && !a.lockedUntil.After(now) {
+		delete(s.attempts, subject)
+	}
+}
+
+// prune forgets the keys whose window and lockout have passed and that have
+// not been locked out for LockoutMax, so lockouts stop doubling after a quiet
+// period. The caller holds s.mu.
+func (s *securityService) prune(now time.Time) {
+	for key, a := range s.attempts {
+		if now.Sub(a.windowStart) > s.cfg.LockoutWindow && now.Sub(a.lockedUntil) > s.cfg.LockoutMax {
+			delete(s.attempts, key)
+		}
+	}
+}
+
+// activeLockouts lists the keys locked out now, longest first.
+func (s *securityService) activeLockouts() []models.SecurityLockout {
+	now := s.now()
+
+	s.mu.Lock()
+	lockouts := []models.SecurityLockout{}
+	for key, a := range s.attempts {
+		if a.lockedUntil.After(now) {
+			lockouts = append(lockouts, models.SecurityLockout{Key: key, Until: a.lockedUntil})
+		}
+	}
+	s.mu.Unlock()
+
+	sort.Slice(lockouts, func(i, j int) bool {
+		if !lockouts[i].Until.Equal(lockouts[j].Until) {
+			return lockouts[i].Until.After(lockouts[j].Until)
+		}
+		return lockouts[i].Key < lockouts[j].Key
+	})
+	return lockouts
+}
+
+// Summary reads the event counts and adds this instance's lockouts.
+func (s *securityService) Summary(ctx context.Context) (*models.SecuritySummary, error) {
+	until := s.now().UTC()
+	summary, err := s.repo.Summarize(ctx, until.Add(-SecuritySummaryWindow), SecuritySummaryLimit)
+	if err != nil {
+		s.log.Error("Failed to summarize security events", err, nil)
+		return nil, fmt.Errorf("failed to summarize security events: %w", err)
+	}
+
+	summary.Until = until
+	summary.ActiveLockouts = s.activeLockouts()
+	return summary, nil
+}
+
+// enqueue publishes an event and queues it for the next write, dropping it if
+// the queue is full.
+func (s *securityService) enqueue(event models.SecurityEvent) {
+	if s.events != nil {
+		s.events.Publish(siemEvent(event))
+	}
+	select {
+	case s.queue <- event:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// siemEvent converts a security event to the SIEM schema. Lockouts are more
+// severe than the failures that cause them.
+func siemEvent(event models.SecurityEvent) siem.Event {
+	severity := 5
+	if event.Type == models.SecurityEventLockout {
+		severity = 8
+	}
+	return siem.Event{
+		Time:     event.CreatedAt,
+		Category: siem.CategorySecurity,
+		Type:     event.Type,
+		ClientIP: event.ClientIP,
+		Subject:  event.Subject,
+		Route:    event.Route,
+		Detail:   event.Detail,
+		Severity: severity,
+	}
+}
+
+// flush writes the queued events. Events are dropped if the write fails; the
+// lockouts they describe are enforced either way.
+func (s *securityService) flush(ctx context.Context) {
+	var events []models.SecurityEvent
+drain:
+	for len(events) < securityQueueSize {
+		select {
+		case event := <-s.queue:
+			events = append(events, event)
+		default:
+			break drain
+		}
+	}
+
+	if dropped := s.dropped.Swap(0); dropped > 0 {
+		s.log.Warn("Security event queue full, events dropped", map[string]interface{}{
+			"dropped": dropped,
+		})
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	if err := s.repo.AddEvents(ctx, events); err != nil {
+		s.log.Error("Failed to write security events", err, map[string]interface{}{
+			"events": len(events),
+		})
+	}
+}
+
+// Run blocks until ctx is cancelled; run it in its own goroutine.
+func (s *securityService) Run(ctx context.Context) {
+	ticker := time.NewTicker(securityFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Persist what was recorded since the last tick
+			flushCtx, cancel := context.WithTimeout(context.Background(), securityFlushInterval)
+			s.flush(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			s.flush(ctx)
+
+			s.mu.Lock()
+			s.prune(s.now())
+			s.mu.Unlock()
+		}
+	}
+}
+
+// attemptKeys returns the attempts keys of the client IP and subject that are set.
+func attemptKeys(ip, subject string) []string {
+	keys := make([]string, 0, 2)
+	if ip != "" {
+		keys = append(keys, ipKey(ip))
+	}
+	if subject = strings.TrimSpace(subject); subject != "" {
+		keys = append(keys, subject)
+	}
+	return keys
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/siem"
+)
+
+// testLockoutConfig locks out after 3 failures in a minute for 1s, doubling up to 4s
+var testLockoutConfig = config.AuthConfig{
+	LockoutThreshold: 3,
+	LockoutWindow:    time.Minute,
+	LockoutDuration:  time.Second,
+	LockoutMax:       4 * time.Second,
+}
+
+// MockSecurityRepository is a mock implementation of SecurityRepository for testing
+type MockSecurityRepository struct {
+	mock.Mock
+}
+
+func (m *MockSecurityRepository) AddEvents(ctx context.Context, events []models.SecurityEvent) error {
+	args := m.Called(ctx, events)
+	return args.Error(0)
+}
+
+func (m *MockSecurityRepository) Summarize(ctx context.Context, since time.Time, limit int) (*models.SecuritySummary, error) {
+	args := m.Called(ctx, since, limit)
+	summary, _ := args.Get(0).(*models.SecuritySummary)
+	return summary, args.Error(1)
+}
+
+// fakeClock is a settable time source for lockout tests.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func newTestSecurityService(repo *MockSecurityRepository, cfg config.AuthConfig) (*securityService, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	return newSecurityService(repo, cfg, nil, logger.New("test"), clock.Now), clock
+}
+
+func TestRecordFailure_LocksOutAtThreshold(t *testing.T) {
+	service, clock := newTestSecurityService(new(MockSecurityRepository), testLockoutConfig)
+
+	service.RecordFailure("10.0.0.1", "user:a@example.com", "users.login")
+	service.RecordFailure("10.0.0.1", "user:a@example.com", "users.login")
+	assert.Zero(t, service.LockedFor("10.0.0.1", ""))
+
+	service.RecordFailure("10.0.0.1", "user:a@example.com", "users.login")
+	assert.Equal(t, time.Second, service.LockedFor("10.0.0.1", ""))
+	assert.Equal(t, time.Second, service.LockedFor("", "user:a@example.com"))
+	assert.Equal(t, time.Second, service.LockedFor("10.0.0.9", "user:a@example.com"), "the subject is locked from any IP")
+	assert.Zero(t, service.LockedFor("10.0.0.9", "user:b@example.com"))
+
+	clock.now = clock.now.Add(time.Second)
+	assert.Zero(t, service.LockedFor("10.0.0.1", "user:a@example.com"))
+}
+
+func TestRecordFailure_WindowResets(t *testing.T) {
+	service, clock := newTestSecurityService(new(MockSecurityRepository), testLockoutConfig)
+
+	service.RecordFailure("10.0.0.1", "", "parcels.get")
+	service.RecordFailure("10.0.0.1", "", "parcels.get")
+	clock.now = clock.now.Add(2 * time.Minute)
+	service.RecordFailure("10.0.0.1", "", "parcels.get")
+
+	assert.Zero(t, service.LockedFor("10.0.0.1", ""))
+}
+
+func TestRecordFailure_LockoutDoublesUpToMax(t *testing.T) {
+	service, clock := newTestSecurityService(new(MockSecurityRepository), testLockoutConfig)
+
+	for _, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second} {
+		for i := 0; i < testLockoutConfig.LockoutThreshold; i++ {
+			service.RecordFailure("10.0.0.1", "", "parcels.get")
+		}
+		require.Equal(t, want, service.LockedFor("10.0.0.1", ""))
+
+		// Failures while locked out do not extend the lockout
+		service.RecordFailure("10.0.0.1", "", "parcels.get")
+		assert.Equal(t, want, service.LockedFor("10.0.0.1", ""))
+		clock.now = clock.now.Add(want)
+	}
+}
+
+func TestRecordSuccess_ClearsSubjectOnly(t *testing.T) {
+	service, _ := newTestSecurityService(new(MockSecurityRepository), testLockoutConfig)
+
+	service.RecordFailure("10.0.0.1", "user:a@example.com", "users.login")
+	service.RecordFailure("10.0.0.1", "user:a@example.com", "users.login")
+	service.RecordSuccess("user:a@example.com")
+	service.RecordFailure("10.0.0.1", "user:a@example.com", "users.login")
+
+	assert.Zero(t, service.LockedFor("", "user:a@example.com"))
+	assert.Equal(t, time.Second, service.LockedFor("10.0.0.1", ""), "the IP keeps its failures")
+
+	// A correct password does not lift an active lockout
+	for i := 0; i < testLockoutConfig.LockoutThreshold; i++ {
+		service.RecordFailure("10.0.0.2", "user:b@example.com", "users.login")
+	}
+	service.RecordSuccess("user:b@example.com")
+	assert.Equal(t, time.Second, service.LockedFor("", "user:b@example.com"))
+}
+
+func TestRecordFailure_DisabledLockouts(t *testing.T) {
+	cfg := testLockoutConfig
+	cfg.LockoutThreshold = 0
+	mockRepo := new(MockSecurityRepository)
+	service, _ := newTestSecurityService(mockRepo, cfg)
+
+	for i := 0; i < 10; i++ {
+		service.RecordFailure("10.0.0.1", "", "parcels.get")
+	}
+	assert.Zero(t, service.LockedFor("10.0.0.1", ""))
+
+	// Failures are still recorded for the dashboard
+	mockRepo.On("AddEvents", mock.Anything, mock.MatchedBy(func(events []models.SecurityEvent) bool {
+		return len(events) == 10
+	})).Return(nil)
+	service.flush(context.Background())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFlush_WritesFailuresAndLockouts(t *testing.T) {
+	mockRepo := new(MockSecurityRepository)
+	service, _ := newTestSecurityService(mockRepo, testLockoutConfig)
+
+	for i := 0; i < testLockoutConfig.LockoutThreshold; i++ {
+		service.RecordFailure("10.0.0.1", "api_key:atlas_abcdef", "parcels.get")
+	}
+
+	var written []models.SecurityEvent
+	mockRepo.On("AddEvents", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		written = args.Get(1).([]models.SecurityEvent)
+	}).Return(errors.New("db down")).Once()
+	service.flush(context.Background())
+
+	require.Len(t, written, 5)
+	types := map[string]int{}
+	for _, event := range written {
+		types[event.Type]++
+		assert.Equal(t, "10.0.0.1", event.ClientIP)
+		assert.Equal(t, "parcels.get", event.Route)
+	}
+	assert.Equal(t, map[string]int{models.SecurityEventAuthFailure: 3, models.SecurityEventLockout: 2}, types)
+
+	// Failed writes are dropped rather than retried
+	service.flush(context.Background())
+	mockRepo.AssertExpectations(t)
+}
+
+// recordingPublisher records the events published to it.
+type recordingPublisher struct {
+	events []siem.Event
+}
+
+func (p *recordingPublisher) Publish(event siem.Event) { p.events = append(p.events, event) }
+
+func TestRecordFailure_PublishesEvents(t *testing.T) {
+	publisher := &recordingPublisher{}
+	clock := &fakeClock{now: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	service := newSecurityService(new(MockSecurityRepository), testLockoutConfig, publisher, logger.New("test"), clock.Now)
+
+	for i := 0; i < testLockoutConfig.LockoutThreshold; i++ {
+		service.RecordFailure("10.0.0.1", "api_key:atlas_abcdef", "parcels.get")
+	}
+
+	require.Len(t, publisher.events, 5)
+	severities := map[string]int{}
+	for _, event := range publisher.events {
+		severities[event.Type] = event.Severity
+		assert.Equal(t, siem.CategorySecurity, event.Category)
+		assert.Equal(t, "10.0.0.1", event.ClientIP)
+		assert.Equal(t, "parcels.get", event.Route)
+		assert.True(t, clock.now.Equal(event.Time))
+	}
+	assert.Equal(t, map[string]int{siem.TypeAuthFailure: 5, siem.TypeLockout: 8}, severities)
+}
+
+func TestSecuritySummary_AddsActiveLockouts(t *testing.T) {
+	mockRepo := new(MockSecurityRepository)
+	service, clock := newTestSecurityService(mockRepo, testLockoutConfig)
+
+	for i := 0; i < testLockoutConfig.LockoutThreshold; i++ {
+		service.RecordFailure("10.0.0.1", "", "parcels.get")
+	}
+	clock.now = clock.now.Add(500 * time.Millisecond)
+	for i := 0; i < testLockoutConfig.LockoutThreshold; i++ {
+		service.RecordFailure("", "user:a@example.com", "users.login")
+	}
+
+	mockRepo.On("Summarize", mock.Anything, clock.now.Add(-SecuritySummaryWindow), SecuritySummaryLimit).
+		Return(&models.SecuritySummary{Failures: 6, Lockouts: 2}, nil)
+
+	summary, err := service.Summary(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, clock.now, summary.Until)
+	assert.Equal(t, int64(6), summary.Failures)
+	assert.Equal(t, []models.SecurityLockout{
+		{Key: "user:a@example.com", Until: clock.now.Add(time.Second)},
+		{Key: "ip:10.0.0.1", Until: clock.now.Add(500 * time.Millisecond)},
+	}, summary.ActiveLockouts)
+}
+
+func TestSecuritySummary_Error(t *testing.T) {
+	mockRepo := new(MockSecurityRepository)
+	service, _ := newTestSecurityService(mockRepo, testLockoutConfig)
+	mockRepo.On("Summarize", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+
+	summary, err := service.Summary(context.Background())
+	assert.Error(t, err)
+	assert.Nil(t, summary)
+}
+
+func TestPrune_ForgetsQuietKeys(t *testing.T) {
+	service, clock := newTestSecurityService(new(MockSecurityRepository), testLockoutConfig)
+
+	for i := 0; i < testLockoutConfig.LockoutThreshold; i++ {
+		service.RecordFailure("10.0.0.1", "", "parcels.get")
+	}
+	service.RecordFailure("10.0.0.2", "", "parcels.get")
+
+	clock.now = clock.now.Add(2 * time.Minute)
+	service.mu.Lock()
+	service.prune(clock.now)
+	service.mu.Unlock()
+
+	assert.Len(t, service.attempts, 0)
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// Share link lifetime limits in days.
+const (
+	DefaultShareLinkDays = 30
+	MaxShareLinkDays     = 365
+)
+
+// Share link token format: shareTokenRandomBytes of base64url randomness, 12
+// characters. Tokens are drawn again when one is already taken, up to
+// shareTokenAttempts times.
+const (
+	shareTokenRandomBytes = 9
+	shareTokenAttempts    = 3
+)
+
+// Share link service errors
+var (
+	ErrInvalidShareLink  = domain.Validation("invalid share link")
+	ErrShareLinkNotFound = domain.NotFound("share link not found or expired")
+)
+
+// shareTokenPattern matches strings that could be share link tokens.
+var shareTokenPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+// ShareLinkService defines the interface for share link operations.
+type ShareLinkService interface {
+	// CreateShareLink validates the view and stores it under a new random token,
+	// expiring after expiresInDays (DefaultShareLinkDays when zero). tenantID
+	// records the creating tenant and may be empty.
+	// Returns ErrInvalidShareLink, ErrInvalidGeometry or ErrInvalidStyle when validation fails.
+	CreateShareLink(ctx context.Context, view models.ShareView, tenantID string, expiresInDays int) (*models.ShareLink, error)
+
+	// ResolveShareLink returns the link with the given token and counts a click.
+	// Returns ErrShareLinkNotFound if the token is unknown or the link has expired.
+	ResolveShareLink(ctx context.Context, token string) (*models.ShareLink, error)
+}
+
+// shareLinkService is the concrete implementation of ShareLinkService.
+type shareLinkService struct {
+	repo repository.ShareLinkRepository
+	log  *logger.Logger
+	now  func() time.Time
+}
+
+// NewShareLinkService creates a new instance of ShareLinkService.
+func NewShareLinkService(repo repository.ShareLinkRepository, log *logger.Logger) ShareLinkService {
+	return &shareLinkService{
+		repo: repo,
+		log:  log,
+		now:  time.Now,
+	}
+}
+
+// CreateShareLink validates the view, then inserts it under fresh tokens until one is free.
+func (s *shareLinkService) CreateShareLink(ctx context.Context, view models.ShareView, tenantID string, expiresInDays int) (*models.ShareLink, error) {
+	if err := validateShareView(&view); err != nil {
+		return nil, err
+	}
+	if expiresInDays == 0 {
+		expiresInDays = DefaultShareLinkDays
+	}
+	if expiresInDays < 1 || expiresInDays > MaxShareLinkDays {
+		return nil, fmt.Errorf("%w: expiresInDays must be between 1 and %d, got %d", ErrInvalidShareLink, MaxShareLinkDays, expiresInDays)
+	}
+
+	link := &models.ShareLink{
+		View:      view,
+		ExpiresAt: s.now().UTC().AddDate(0, 0, expiresInDays),
+	}
+	if tenantID != "" {
+		if err := validateTenantID(tenantID); err != nil {
+			return nil, err
+		}
+		link.TenantID = &tenantID
+	}
+
+	for attempt := 0; attempt < shareTokenAttempts; attempt++ {
+		random := make([]byte, shareTokenRandomBytes)
+		if _, err := rand.Read(random); err != nil {
+			return nil, fmt.Errorf("failed to generate share token: %w", err)
+		}
+		link.Token = base64.RawURLEncoding.EncodeToString(random)
+
+		stored, err := s.repo.Create(ctx, link)
+		if err != nil {
+			s.log.Error("Failed to create share link", err, map[string]interface{}{
+				"tenant_id": tenantID,
+			})
+			return nil, fmt.Errorf("failed to create share link: %w", err)
+		}
+		if stored != nil {
+			s.log.Info("Share link created", map[string]interface{}{
+				"share_link_id": stored.ID,
+				"tenant_id":     tenantID,
+				"expires_at":    stored.ExpiresAt,
+			})
+			return stored, nil
+		}
+	}
+
+	return nil, fmt.Errorf("failed to create share link: no free token after %d attempts", shareTokenAttempts)
+}
+
+// ResolveShareLink looks up the token, transforming a missing or expired link into ErrShareLinkNotFound.
+func (s *shareLinkService) ResolveShareLink(ctx context.Context, token string) (*models.ShareLink, error) {
+	// Malformed tokens cannot exist, so they are not looked up
+	if !shareTokenPattern.MatchString(token) {
+		return nil, ErrShareLinkNotFound
+	}
+
+	link, err := s.repo.Resolve(ctx, token)
+	if err != nil {
+		s.log.Error("Failed to resolve share link", err, nil)
+		return nil, fmt.Errorf("failed to resolve share link: %w", err)
+	}
+
+	if link == nil {
+		return nil, ErrShareLinkNotFound
+	}
+
+	return link, nil
+}
+
+// validateShareView checks that the view names exactly one of a parcel or an
+// area, and validates the area, style and zoom.
+func validateShareView(view *models.ShareView) error {
+	if (view.ParcelID == nil) == (view.Area == nil) {
+		return fmt.Errorf("%w: exactly one of parcelId or area is required", ErrInvalidShareLink)
+	}
+	if view.ParcelID != nil && *view.ParcelID == 0 {
+		return fmt.Errorf("%w: parcelId must be positive", ErrInvalidShareLink)
+	}
+	if view.Area != nil {
+		if err := validateArea(view.Area); err != nil {
+			return err
+		}
+	}
+	if view.Style != nil {
+		if err := validateLayerStyleConfig(view.Style); err != nil {
+			return err
+		}
+	}
+	if view.Zoom != nil && (*view.Zoom < MinZoomLevel || *view.Zoom > MaxZoomLevel) {
+		return fmt.Errorf("%w: zoom must be between %d and %d, got %d", ErrInvalidShareLink, MinZoomLevel, MaxZoomLevel, *view.Zoom)
+	}
+	return nil
+}
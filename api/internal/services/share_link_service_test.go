@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// MockShareLinkRepository is a mock implementation of ShareLinkRepository for testing
+type MockShareLinkRepository struct {
+	mock.Mock
+}
+
+func (m *MockShareLinkRepository) Create(ctx context.Context, link *models.ShareLink) (*models.ShareLink, error) {
+	args := m.Called(ctx, link)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	stored, ok := args.Get(0).(*models.ShareLink)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return stored, args.Error(1)
+}
+
+func (m *MockShareLinkRepository) Resolve(ctx context.Context, token string) (*models.ShareLink, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	link, ok := args.Get(0).(*models.ShareLink)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return link, args.Error(1)
+}
+
+// newTestShareLinkService returns a service whose clock is fixed at now.
+func newTestShareLinkService(repo *MockShareLinkRepository, now time.Time) ShareLinkService {
+	return &shareLinkService{repo: repo, log: logger.New("test"), now: func() time.Time { return now }}
+}
+
+func TestCreateShareLink_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockShareLinkRepository)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service := newTestShareLinkService(mockRepo, now)
+	ctx := context.Background()
+	parcelID := uint(42)
+
+	var created *models.ShareLink
+	mockRepo.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*models.ShareLink)
+	}).Return(&models.ShareLink{ID: 1, Token: "stored"}, nil)
+
+	// Act
+	result, err := service.CreateShareLink(ctx, models.ShareView{ParcelID: &parcelID}, "acme", 0)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "stored", result.Token)
+	assert.Regexp(t, `^[A-Za-z0-9_-]{12}$`, created.Token)
+	assert.Equal(t, now.AddDate(0, 0, DefaultShareLinkDays), created.ExpiresAt)
+	require.NotNil(t, created.TenantID)
+	assert.Equal(t, "acme", *created.TenantID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateShareLink_RetriesTakenToken(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockShareLinkRepository)
+	service := newTestShareLinkService(mockRepo, time.Now())
+	ctx := context.Background()
+
+	var tokens []string
+	record := func(args mock.Arguments) { tokens = append(tokens, args.Get(1).(*models.ShareLink).Token) }
+	mockRepo.On("Create", ctx, mock.Anything).Run(record).Return(nil, nil).Once()
+	mockRepo.On("Create", ctx, mock.Anything).Run(record).Return(&models.ShareLink{ID: 2}, nil).Once()
+
+	// Act
+	result, err := service.CreateShareLink(ctx, models.ShareView{Area: testArea()}, "", 7)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), result.ID)
+	require.Len(t, tokens, 2)
+	assert.NotEqual(t, tokens[0], tokens[1])
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateShareLink_ValidationErrors(t *testing.T) {
+	parcelID := uint(42)
+	zero := uint(0)
+	zoom := 23
+	badStyle := validStyle().Config
+	badStyle.Fill.DefaultColor = "red"
+
+	testCases := []struct {
+		name    string
+		view    models.ShareView
+		days    int
+		tenant  string
+		errType error
+	}{
+		{"neither parcel nor area", models.ShareView{}, 0, "", ErrInvalidShareLink},
+		{"both parcel and area", models.ShareView{ParcelID: &parcelID, Area: testArea()}, 0, "", ErrInvalidShareLink},
+		{"zero parcel id", models.ShareView{ParcelID: &zero}, 0, "", ErrInvalidShareLink},
+		{"open ring", models.ShareView{Area: &models.MultiPolygon{Coordinates: [][][][2]float64{{{{0, 0}, {1, 0}, {1, 1}, {0, 1}}}}}}, 0, "", ErrInvalidGeometry},
+		{"invalid style", models.ShareView{ParcelID: &parcelID, Style: &badStyle}, 0, "", ErrInvalidStyle},
+		{"zoom out of range", models.ShareView{ParcelID: &parcelID, Zoom: &zoom}, 0, "", ErrInvalidShareLink},
+		{"expiry too long", models.ShareView{ParcelID: &parcelID}, MaxShareLinkDays + 1, "", ErrInvalidShareLink},
+		{"negative expiry", models.ShareView{ParcelID: &parcelID}, -1, "", ErrInvalidShareLink},
+		{"invalid tenant", models.ShareView{ParcelID: &parcelID}, 0, "Acme Corp", ErrInvalidTenant},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockShareLinkRepository)
+			service := NewShareLinkService(mockRepo, logger.New("test"))
+
+			// Act
+			result, err := service.CreateShareLink(context.Background(), tc.view, tc.tenant, tc.days)
+
+			// Assert
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tc.errType)
+			mockRepo.AssertNotCalled(t, "Create")
+		})
+	}
+}
+
+func TestResolveShareLink(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("found", func(t *testing.T) {
+		mockRepo := new(MockShareLinkRepository)
+		service := NewShareLinkService(mockRepo, logger.New("test"))
+		mockRepo.On("Resolve", ctx, "abc_DEF-123").Return(&models.ShareLink{ID: 3, Clicks: 1}, nil)
+
+		result, err := service.ResolveShareLink(ctx, "abc_DEF-123")
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), result.Clicks)
+	})
+
+	t.Run("unknown or expired", func(t *testing.T) {
+		mockRepo := new(MockShareLinkRepository)
+		service := NewShareLinkService(mockRepo, logger.New("test"))
+		mockRepo.On("Resolve", ctx, "gone").Return(nil, nil)
+
+		_, err := service.ResolveShareLink(ctx, "gone")
+
+		assert.ErrorIs(t, err, ErrShareLinkNotFound)
+	})
+
+	t.Run("malformed token is not looked up", func(t *testing.T) {
+		mockRepo := new(MockShareLinkRepository)
+		service := NewShareLinkService(mockRepo, logger.New("test"))
+
+		_, err := service.ResolveShareLink(ctx, "not a token!")
+
+		assert.ErrorIs(t, err, ErrShareLinkNotFound)
+		mockRepo.AssertNotCalled(t, "Resolve")
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo := new(MockShareLinkRepository)
+		service := NewShareLinkService(mockRepo, logger.New("test"))
+		mockRepo.On("Resolve", ctx, "abc").Return(nil, errors.New("connection refused"))
+
+		_, err := service.ResolveShareLink(ctx, "abc")
+
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrShareLinkNotFound)
+	})
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// MaxSnapshotRangeDays bounds a single time series request to roughly one year of daily rows per county.
+const MaxSnapshotRangeDays = 366
+
+// Stats service errors
+var (
+	ErrInvalidDateRange = domain.Validation("invalid date range")
+)
+
+// StatsService defines the interface for dataset statistics snapshot operations.
+type StatsService interface {
+	// CaptureSnapshot computes and stores the per-county snapshot for the UTC day containing at.
+	// Re-capturing the same day replaces its rows.
+	// Returns the number of county rows written.
+	CaptureSnapshot(ctx context.Context, at time.Time) (int64, error)
+
+	// GetSnapshots returns the stored time series between from and to (inclusive).
+	// An empty county returns every county.
+	// Returns ErrInvalidDateRange if from is after to or the range exceeds MaxSnapshotRangeDays.
+	GetSnapshots(ctx context.Context, county string, from, to time.Time) ([]models.StatsSnapshot, error)
+
+	// RunDailySnapshots captures a snapshot immediately and then after every UTC midnight
+	// until ctx is cancelled. Capture failures are logged and retried on the next run.
+	RunDailySnapshots(ctx context.Context)
+}
+
+// statsService is the concrete implementation of StatsService.
+type statsService struct {
+	repo repository.StatsRepository
+	log  *logger.Logger
+}
+
+// NewStatsService creates a new instance of StatsService.
+func NewStatsService(repo repository.StatsRepository, log *logger.Logger) StatsService {
+	return &statsService{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// CaptureSnapshot truncates at to its UTC date and stores the aggregates under that date.
+func (s *statsService) CaptureSnapshot(ctx context.Context, at time.Time) (int64, error) {
+	date := truncateToUTCDate(at)
+	started := time.Now()
+
+	counties, err := s.repo.CaptureSnapshot(ctx, date)
+	if err != nil {
+		s.log.Error("Failed to capture stats snapshot", err, map[string]interface{}{
+			"date": date.Format(time.DateOnly),
+		})
+		return 0, fmt.Errorf("failed to capture stats snapshot: %w", err)
+	}
+
+	s.log.Info("Stats snapshot captured", map[string]interface{}{
+		"date":        date.Format(time.DateOnly),
+		"counties":    counties,
+		"duration_ms": time.Since(started).Milliseconds(),
+	})
+
+	return counties, nil
+}
+
+// GetSnapshots validates the date range and returns the stored snapshots.
+func (s *statsService) GetSnapshots(ctx context.Context, county string, from, to time.Time) ([]models.StatsSnapshot, error) {
+	from, to = truncateToUTCDate(from), truncateToUTCDate(to)
+
+	// Validate date range
+	if from.After(to) {
+		return nil, fmt.Errorf("%w: from %s is after to %s",
+			ErrInvalidDateRange, from.Format(time.DateOnly), to.Format(time.DateOnly))
+	}
+	if days := int(to.Sub(from).Hours()/24) + 1; days > MaxSnapshotRangeDays {
+		return nil, fmt.Errorf("%w: range of %d days exceeds %d",
+			ErrInvalidDateRange, days, MaxSnapshotRangeDays)
+	}
+
+	snapshots, err := s.repo.ListSnapshots(ctx, county, from, to)
+	if err != nil {
+		s.log.Error("Failed to list stats snapshots", err, map[string]interface{}{
+			"county": county,
+			"from":   from.Format(time.DateOnly),
+			"to":     to.Format(time.DateOnly),
+		})
+		return nil, fmt.Errorf("failed to list stats snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// RunDailySnapshots blocks until ctx is cancelled; run it in its own goroutine.
+func (s *statsService) RunDailySnapshots(ctx context.Context) {
+	for {
+		// Errors are already logged by CaptureSnapshot; the next run retries
+		_, _ = s.CaptureSnapshot(ctx, time.Now())
+
+		timer := time.NewTimer(time.Until(nextUTCMidnight(time.Now())))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// truncateToUTCDate returns midnight UTC of the day containing t.
+func truncateToUTCDate(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// nextUTCMidnight returns the first UTC midnight strictly after t.
+func nextUTCMidnight(t time.Time) time.Time {
+	return truncateToUTCDate(t).AddDate(0, 0, 1)
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// MockStatsRepository is a mock implementation of StatsRepository for testing
+type MockStatsRepository struct {
+	mock.Mock
+}
+
+func (m *MockStatsRepository) CaptureSnapshot(ctx context.Context, date time.Time) (int64, error) {
+	args := m.Called(ctx, date)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockStatsRepository) ListSnapshots(ctx context.Context, county string, from, to time.Time) ([]models.StatsSnapshot, error) {
+	args := m.Called(ctx, county, from, to)
+	snapshots, _ := args.Get(0).([]models.StatsSnapshot)
+	return snapshots, args.Error(1)
+}
+
+func TestCaptureSnapshot_TruncatesToUTCDate(t *testing.T) {
+	mockRepo := new(MockStatsRepository)
+	service := NewStatsService(mockRepo, logger.New("test"))
+
+	ctx := context.Background()
+	// 20:30 in Houston on Oct 14 is already Oct 15 in UTC
+	houston := time.FixedZone("CDT", -5*60*60)
+	at := time.Date(2025, 10, 14, 20, 30, 0, 0, houston)
+	expected := time.Date(2025, 10, 15, 0, 0, 0, 0, time.UTC)
+
+	mockRepo.On("CaptureSnapshot", ctx, expected).Return(int64(3), nil)
+
+	counties, err := service.CaptureSnapshot(ctx, at)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), counties)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCaptureSnapshot_RepositoryError(t *testing.T) {
+	mockRepo := new(MockStatsRepository)
+	service := NewStatsService(mockRepo, logger.New("test"))
+
+	dbError := errors.New("database connection failed")
+	mockRepo.On("CaptureSnapshot", mock.Anything, mock.Anything).Return(int64(0), dbError)
+
+	_, err := service.CaptureSnapshot(context.Background(), time.Now())
+
+	assert.ErrorIs(t, err, dbError)
+}
+
+func TestGetSnapshots_Success(t *testing.T) {
+	mockRepo := new(MockStatsRepository)
+	service := NewStatsService(mockRepo, logger.New("test"))
+
+	ctx := context.Background()
+	from := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC)
+	expected := []models.StatsSnapshot{{SnapshotDate: from, CountyName: "Montgomery", ParcelCount: 10}}
+
+	mockRepo.On("ListSnapshots", ctx, "Montgomery", from, to).Return(expected, nil)
+
+	snapshots, err := service.GetSnapshots(ctx, "Montgomery", from, to)
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, snapshots)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetSnapshots_InvalidRange(t *testing.T) {
+	day := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		from, to time.Time
+	}{
+		{"from after to", day.AddDate(0, 0, 1), day},
+		{"range too long", day.AddDate(0, 0, -MaxSnapshotRangeDays), day},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockStatsRepository)
+			service := NewStatsService(mockRepo, logger.New("test"))
+
+			snapshots, err := service.GetSnapshots(context.Background(), "", tc.from, tc.to)
+
+			assert.Nil(t, snapshots)
+			assert.ErrorIs(t, err, ErrInvalidDateRange)
+			mockRepo.AssertNotCalled(t, "ListSnapshots")
+		})
+	}
+}
+
+func TestGetSnapshots_MaxRangeAllowed(t *testing.T) {
+	mockRepo := new(MockStatsRepository)
+	service := NewStatsService(mockRepo, logger.New("test"))
+
+	to := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, 0, -(MaxSnapshotRangeDays - 1))
+	mockRepo.On("ListSnapshots", mock.Anything, "", from, to).Return([]models.StatsSnapshot{}, nil)
+
+	_, err := service.GetSnapshots(context.Background(), "", from, to)
+
+	require.NoError(t, err)
+}
+
+func TestRunDailySnapshots_StopsOnCancel(t *testing.T) {
+	mockRepo := new(MockStatsRepository)
+	service := NewStatsService(mockRepo, logger.New("test"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	captured := make(chan struct{})
+	mockRepo.On("CaptureSnapshot", mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) { close(captured) }).
+		Return(int64(1), nil).Once()
+
+	done := make(chan struct{})
+	go func() {
+		service.RunDailySnapshots(ctx)
+		close(done)
+	}()
+
+	// The first snapshot is captured immediately on start
+	<-captured
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("RunDailySnapshots did not return after cancel")
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestNextUTCMidnight(t *testing.T) {
+	at := time.Date(2025, 12, 31, 23, 59, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), nextUTCMidnight(at))
+
+	midnight := time.Date(2025, 10, 15, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, midnight.AddDate(0, 0, 1), nextUTCMidnight(midnight))
+}
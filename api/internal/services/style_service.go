@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// Zoom validation constants (standard web map zoom levels)
+const (
+	MinZoomLevel = 0
+	MaxZoomLevel = 22
+)
+
+// Style service errors
+var (
+	ErrInvalidTenant = domain.Validation("invalid tenant id")
+	ErrInvalidStyle  = domain.Validation("invalid style")
+	ErrStyleNotFound = domain.NotFound("style not found")
+)
+
+// identifierPattern matches tenant IDs and style names: lowercase slugs up to 100 characters.
+var identifierPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,99}$`)
+
+// colorPattern matches #RGB and #RRGGBB hex colors.
+var colorPattern = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// LabelFields are the parcel attributes that may be rendered as labels.
+var LabelFields = map[string]bool{
+	"owner_name":    true,
+	"situs_address": true,
+	"land_use":      true,
+	"county_name":   true,
+	"pin":           true,
+	"acres":         true,
+}
+
+// StyleService defines the interface for saved map style operations.
+type StyleService interface {
+	// ListStyles returns every style saved for the tenant.
+	// Returns ErrInvalidTenant if the tenant id is malformed.
+	ListStyles(ctx context.Context, tenantID string) ([]models.MapStyle, error)
+
+	// GetStyle returns the tenant's style with the given name.
+	// Returns ErrInvalidTenant or ErrInvalidStyle for malformed identifiers.
+	// Returns ErrStyleNotFound if the style does not exist.
+	GetStyle(ctx context.Context, tenantID, name string) (*models.MapStyle, error)
+
+	// SaveStyle validates and creates or replaces a style.
+	// Returns ErrInvalidTenant or ErrInvalidStyle when validation fails.
+	SaveStyle(ctx context.Context, style *models.MapStyle) (*models.MapStyle, error)
+
+	// DeleteStyle removes the tenant's style with the given name.
+	// Returns ErrStyleNotFound if the style does not exist.
+	DeleteStyle(ctx context.Context, tenantID, name string) error
+}
+
+// styleService is the concrete implementation of StyleService.
+type styleService struct {
+	repo repository.StyleRepository
+	log  *logger.Logger
+}
+
+// NewStyleService creates a new instance of StyleService.
+func NewStyleService(repo repository.StyleRepository, log *logger.Logger) StyleService {
+	return &styleService{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// ListStyles returns the tenant's styles ordered by name.
+func (s *styleService) ListStyles(ctx context.Context, tenantID string) ([]models.MapStyle, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, err
+	}
+
+	styles, err := s.repo.List(ctx, tenantID)
+	if err != nil {
+		s.log.Error("Failed to list styles", err, map[string]interface{}{
+			"tenant_id": tenantID,
+		})
+		return nil, fmt.Errorf("failed to list styles: %w", err)
+	}
+
+	return styles, nil
+}
+
+// GetStyle returns a single style, transforming a missing row into ErrStyleNotFound.
+func (s *styleService) GetStyle(ctx context.Context, tenantID, name string) (*models.MapStyle, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, err
+	}
+	if err := validateStyleName(name); err != nil {
+		return nil, err
+	}
+
+	style, err := s.repo.FindByName(ctx, tenantID, name)
+	if err != nil {
+		s.log.Error("Failed to query style", err, map[string]interface{}{
+			"tenant_id": tenantID,
+			"name":      name,
+		})
+		return nil, fmt.Errorf("failed to query style: %w", err)
+	}
+
+	if style == nil {
+		return nil, ErrStyleNotFound
+	}
+
+	return style, nil
+}
+
+// SaveStyle validates the style configuration and upserts it.
+func (s *styleService) SaveStyle(ctx context.Context, style *models.MapStyle) (*models.MapStyle, error) {
+	if err := validateTenantID(style.TenantID); err != nil {
+		return nil, err
+	}
+	if err := validateStyleName(style.Name); err != nil {
+		return nil, err
+	}
+	if err := validateLayerStyleConfig(&style.Config); err != nil {
+		s.log.Warn("Invalid style configuration provided", map[string]interface{}{
+			"tenant_id": style.TenantID,
+			"name":      style.Name,
+			"error":     err.Error(),
+		})
+		return nil, err
+	}
+
+	stored, err := s.repo.Upsert(ctx, style)
+	if err != nil {
+		s.log.Error("Failed to save style", err, map[string]interface{}{
+			"tenant_id": style.TenantID,
+			"name":      style.Name,
+		})
+		return nil, fmt.Errorf("failed to save style: %w", err)
+	}
+
+	s.log.Info("Style saved", map[string]interface{}{
+		"tenant_id": stored.TenantID,
+		"name":      stored.Name,
+		"style_id":  stored.ID,
+	})
+
+	return stored, nil
+}
+
+// DeleteStyle removes a style, returning ErrStyleNotFound if nothing was deleted.
+func (s *styleService) DeleteStyle(ctx context.Context, tenantID, name string) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return err
+	}
+	if err := validateStyleName(name); err != nil {
+		return err
+	}
+
+	deleted, err := s.repo.Delete(ctx, tenantID, name)
+	if err != nil {
+		s.log.Error("Failed to delete style", err, map[string]interface{}{
+			"tenant_id": tenantID,
+			"name":      name,
+		})
+		return fmt.Errorf("failed to delete style: %w", err)
+	}
+
+	if !deleted {
+		return ErrStyleNotFound
+	}
+
+	s.log.Info("Style deleted", map[string]interface{}{
+		"tenant_id": tenantID,
+		"name":      name,
+	})
+
+	return nil
+}
+
+// validateTenantID checks that the tenant id is a lowercase slug.
+func validateTenantID(tenantID string) error {
+	if !identifierPattern.MatchString(tenantID) {
+		return fmt.Errorf("%w: must be a lowercase slug of at most 100 characters, got %q", ErrInvalidTenant, tenantID)
+	}
+	return nil
+}
+
+// validateStyleName checks that the style name is a lowercase slug.
+func validateStyleName(name string) error {
+	if !identifierPattern.MatchString(name) {
+		return fmt.Errorf("%w: name must be a lowercase slug of at most 100 characters, got %q", ErrInvalidStyle, name)
+	}
+	return nil
+}
+
+// validateLayerStyleConfig checks colors, opacity, zoom ranges and label fields.
+func validateLayerStyleConfig(cfg *models.LayerStyleConfig) error {
+	if !colorPattern.MatchString(cfg.Fill.DefaultColor) {
+		return fmt.Errorf("%w: fill.defaultColor must be a hex color, got %q", ErrInvalidStyle, cfg.Fill.DefaultColor)
+	}
+	for landUse, color := range cfg.Fill.ByLandUse {
+		if !colorPattern.MatchString(color) {
+			return fmt.Errorf("%w: fill.byLandUse[%s] must be a hex color, got %q", ErrInvalidStyle, landUse, color)
+		}
+	}
+	if cfg.Fill.Opacity < 0 || cfg.Fill.Opacity > 1 {
+		return fmt.Errorf("%w: fill.opacity must be between 0 and 1, got %f", ErrInvalidStyle, cfg.Fill.Opacity)
+	}
+
+	if err := validateZoom("visibility.minZoom", cfg.Visibility.MinZoom); err != nil {
+		return err
+	}
+	if err := validateZoom("visibility.maxZoom", cfg.Visibility.MaxZoom); err != nil {
+		return err
+	}
+	if cfg.Visibility.MinZoom > cfg.Visibility.MaxZoom {
+		return fmt.Errorf("%w: visibility.minZoom must be less than or equal to visibility.maxZoom", ErrInvalidStyle)
+	}
+
+	for i, label := range cfg.Labels {
+		if !LabelFields[label.Field] {
+			return fmt.Errorf("%w: labels[%d].field %q is not a labelable parcel field", ErrInvalidStyle, i, label.Field)
+		}
+		if err := validateZoom(fmt.Sprintf("labels[%d].minZoom", i), label.MinZoom); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateZoom checks that a zoom level is within the supported range.
+func validateZoom(field string, zoom int) error {
+	if zoom < MinZoomLevel || zoom > MaxZoomLevel {
+		return fmt.Errorf("%w: %s must be between %d and %d, got %d", ErrInvalidStyle, field, MinZoomLevel, MaxZoomLevel, zoom)
+	}
+	return nil
+}
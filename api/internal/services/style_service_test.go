@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// MockStyleRepository is a mock implementation of StyleRepository for testing
+type MockStyleRepository struct {
+	mock.Mock
+}
+
+func (m *MockStyleRepository) List(ctx context.Context, tenantID string) ([]models.MapStyle, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	styles, ok := args.Get(0).([]models.MapStyle)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return styles, args.Error(1)
+}
+
+func (m *MockStyleRepository) FindByName(ctx context.Context, tenantID, name string) (*models.MapStyle, error) {
+	args := m.Called(ctx, tenantID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	style, ok := args.Get(0).(*models.MapStyle)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return style, args.Error(1)
+}
+
+func (m *MockStyleRepository) Upsert(ctx context.Context, style *models.MapStyle) (*models.MapStyle, error) {
+	args := m.Called(ctx, style)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	stored, ok := args.Get(0).(*models.MapStyle)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return stored, args.Error(1)
+}
+
+func (m *MockStyleRepository) Delete(ctx context.Context, tenantID, name string) (bool, error) {
+	args := m.Called(ctx, tenantID, name)
+	return args.Bool(0), args.Error(1)
+}
+
+// validStyle returns a style that passes all validation rules.
+func validStyle() *models.MapStyle {
+	return &models.MapStyle{
+		TenantID: "acme",
+		Name:     "land-use",
+		Config: models.LayerStyleConfig{
+			Fill: models.FillStyle{
+				DefaultColor: "#cccccc",
+				Opacity:      0.6,
+				ByLandUse:    map[string]string{"A1": "#ff0000", "F1": "#00f"},
+			},
+			Labels:     []models.LabelRule{{Field: "situs_address", MinZoom: 17}},
+			Visibility: models.ZoomRange{MinZoom: 12, MaxZoom: 22},
+		},
+	}
+}
+
+func TestSaveStyle_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStyleRepository)
+	service := NewStyleService(mockRepo, logger.New("test"))
+
+	ctx := context.Background()
+	style := validStyle()
+	stored := *style
+	stored.ID = 7
+
+	mockRepo.On("Upsert", ctx, style).Return(&stored, nil)
+
+	// Act
+	result, err := service.SaveStyle(ctx, style)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), result.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSaveStyle_ValidationErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mutate  func(s *models.MapStyle)
+		errType error
+	}{
+		{"invalid tenant", func(s *models.MapStyle) { s.TenantID = "Acme Corp" }, ErrInvalidTenant},
+		{"empty name", func(s *models.MapStyle) { s.Name = "" }, ErrInvalidStyle},
+		{"bad default color", func(s *models.MapStyle) { s.Config.Fill.DefaultColor = "red" }, ErrInvalidStyle},
+		{"bad land use color", func(s *models.MapStyle) { s.Config.Fill.ByLandUse["A1"] = "#12" }, ErrInvalidStyle},
+		{"opacity too high", func(s *models.MapStyle) { s.Config.Fill.Opacity = 1.5 }, ErrInvalidStyle},
+		{"zoom out of range", func(s *models.MapStyle) { s.Config.Visibility.MaxZoom = 23 }, ErrInvalidStyle},
+		{"zoom range inverted", func(s *models.MapStyle) { s.Config.Visibility.MinZoom = 18; s.Config.Visibility.MaxZoom = 10 }, ErrInvalidStyle},
+		{"unknown label field", func(s *models.MapStyle) { s.Config.Labels[0].Field = "owner_address" }, ErrInvalidStyle},
+		{"label zoom out of range", func(s *models.MapStyle) { s.Config.Labels[0].MinZoom = -1 }, ErrInvalidStyle},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockStyleRepository)
+			service := NewStyleService(mockRepo, logger.New("test"))
+			style := validStyle()
+			tc.mutate(style)
+
+			// Act
+			result, err := service.SaveStyle(context.Background(), style)
+
+			// Assert
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tc.errType)
+			mockRepo.AssertNotCalled(t, "Upsert")
+		})
+	}
+}
+
+func TestGetStyle_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStyleRepository)
+	service := NewStyleService(mockRepo, logger.New("test"))
+
+	ctx := context.Background()
+	mockRepo.On("FindByName", ctx, "acme", "missing").Return(nil, nil)
+
+	// Act
+	style, err := service.GetStyle(ctx, "acme", "missing")
+
+	// Assert
+	assert.Nil(t, style)
+	assert.ErrorIs(t, err, ErrStyleNotFound)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListStyles_RepositoryError(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStyleRepository)
+	service := NewStyleService(mockRepo, logger.New("test"))
+
+	ctx := context.Background()
+	dbError := errors.New("database connection failed")
+	mockRepo.On("List", ctx, "acme").Return(nil, dbError)
+
+	// Act
+	styles, err := service.ListStyles(ctx, "acme")
+
+	// Assert
+	assert.Nil(t, styles)
+	assert.ErrorIs(t, err, dbError)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDeleteStyle(t *testing.T) {
+	t.Run("deletes existing style", func(t *testing.T) {
+		mockRepo := new(MockStyleRepository)
+		service := NewStyleService(mockRepo, logger.New("test"))
+
+		ctx := context.Background()
+		mockRepo.On("Delete", ctx, "acme", "land-use").Return(true, nil)
+
+		err := service.DeleteStyle(ctx, "acme", "land-use")
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("missing style returns not found", func(t *testing.T) {
+		mockRepo := new(MockStyleRepository)
+		service := NewStyleService(mockRepo, logger.New("test"))
+
+		ctx := context.Background()
+		mockRepo.On("Delete", ctx, "acme", "missing").Return(false, nil)
+
+		err := service.DeleteStyle(ctx, "acme", "missing")
+
+		assert.ErrorIs(t, err, ErrStyleNotFound)
+	})
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/auth"
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Password length limits in bytes; bcrypt ignores everything past 72 bytes.
+const (
+	MinPasswordLength = 8
+	MaxPasswordLength = 72
+)
+
+// maxEmailLength matches the users.email column.
+const maxEmailLength = 254
+
+// User service errors
+var (
+	ErrInvalidUser        = domain.Validation("invalid user")
+	ErrEmailTaken         = domain.Conflict("email is already registered")
+	ErrInvalidCredentials = domain.Unauthorized("invalid email or password")
+	ErrUserNotFound       = domain.NotFound("user not found")
+)
+
+// UserSession is a signed-in user with the token that authenticates them.
+type UserSession struct {
+	ExpiresAt time.Time
+	User      *models.User
+	Token     string
+}
+
+// UserService defines the interface for user account operations.
+type UserService interface {
+	// Register creates an account and signs it in. The email is stored lowercased.
+	// Returns ErrInvalidUser when validation fails or ErrEmailTaken if the email
+	// is already registered.
+	Register(ctx context.Context, email, password string) (*UserSession, error)
+
+	// Login checks the credentials and issues a token.
+	// Returns ErrInvalidCredentials for an unknown email or wrong password.
+	Login(ctx context.Context, email, password string) (*UserSession, error)
+
+	// GetUser returns the user with the given ID.
+	// Returns ErrUserNotFound if the user does not exist.
+	GetUser(ctx context.Context, id uint) (*models.User, error)
+}
+
+// userService is the concrete implementation of UserService.
+type userService struct {
+	repo   repository.UserRepository
+	signer *auth.Signer
+	log    *logger.Logger
+	// dummyHash is compared against when the email is unknown, so a login takes
+	// as long whether or not the account exists
+	dummyHash []byte
+	cost      int
+}
+
+// NewUserService creates a new instance of UserService that issues tokens with signer.
+func NewUserService(repo repository.UserRepository, signer *auth.Signer, log *logger.Logger) UserService {
+	return newUserService(repo, signer, log, bcrypt.DefaultCost)
+}
+
+// newUserService creates a userService hashing passwords at the given bcrypt
+// cost; tests use bcrypt.MinCost.
+func newUserService(repo repository.UserRepository, signer *auth.Signer, log *logger.Logger, cost int) *userService {
+	dummyHash, _ := bcrypt.GenerateFromPassword([]byte("atlas-dummy-password"), cost)
+	return &userService{
+		repo:      repo,
+		signer:    signer,
+		log:       log,
+		dummyHash: dummyHash,
+		cost:      cost,
+	}
+}
+
+// Register validates the credentials, stores the bcrypt hash of the password
+// and issues a token for the new user.
+func (s *userService) Register(ctx context.Context, email, password string) (*UserSession, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	if len(password) < MinPasswordLength || len(password) > MaxPasswordLength {
+		return nil, fmt.Errorf("%w: password must be %d to %d bytes long", ErrInvalidUser, MinPasswordLength, MaxPasswordLength)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), s.cost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user, err := s.repo.Create(ctx, &models.User{Email: email, PasswordHash: string(hash)})
+	if err != nil {
+		s.log.Error("Failed to create user", err, nil)
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	if user == nil {
+		return nil, ErrEmailTaken
+	}
+
+	s.log.Info("User registered", map[string]interface{}{
+		"user_id": user.ID,
+	})
+	return s.session(user)
+}
+
+// Login looks the user up by email and compares the password with its hash.
+func (s *userService) Login(ctx context.Context, email, password string) (*UserSession, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	user, err := s.repo.FindByEmail(ctx, email)
+	if err != nil {
+		s.log.Error("Failed to look up user", err, nil)
+		return nil, fmt.Errorf("failed to look up user: %w", err)
+	}
+	if user == nil {
+		_ = bcrypt.CompareHashAndPassword(s.dummyHash, []byte(password))
+		return nil, ErrInvalidCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		s.log.Info("Login failed", map[string]interface{}{
+			"user_id": user.ID,
+		})
+		return nil, ErrInvalidCredentials
+	}
+
+	if err := s.repo.TouchLastLogin(ctx, user.ID); err != nil {
+		// Bookkeeping only; the login still succeeds
+		s.log.Warn("Failed to record user login", map[string]interface{}{
+			"user_id": user.ID,
+			"error":   err.Error(),
+		})
+	}
+
+	return s.session(user)
+}
+
+// GetUser looks the user up by ID.
+func (s *userService) GetUser(ctx context.Context, id uint) (*models.User, error) {
+	user, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		s.log.Error("Failed to get user", err, map[string]interface{}{
+			"user_id": id,
+		})
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// session issues a token for the user.
+func (s *userService) session(user *models.User) (*UserSession, error) {
+	token, expiresAt, err := s.signer.Issue(user.ID, user.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue token: %w", err)
+	}
+	return &UserSession{User: user, Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// normalizeEmail lowercases a bare email address and checks its syntax.
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email || len(email) > maxEmailLength {
+		return "", fmt.Errorf("%w: %q is not a valid email address", ErrInvalidUser, email)
+	}
+	return email, nil
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/auth"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MockUserRepository is a mock implementation of UserRepository for testing
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) Create(ctx context.Context, user *models.User) (*models.User, error) {
+	args := m.Called(ctx, user)
+	stored, _ := args.Get(0).(*models.User)
+	return stored, args.Error(1)
+}
+
+func (m *MockUserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	args := m.Called(ctx, email)
+	user, _ := args.Get(0).(*models.User)
+	return user, args.Error(1)
+}
+
+func (m *MockUserRepository) FindByID(ctx context.Context, id uint) (*models.User, error) {
+	args := m.Called(ctx, id)
+	user, _ := args.Get(0).(*models.User)
+	return user, args.Error(1)
+}
+
+func (m *MockUserRepository) TouchLastLogin(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+const testJWTSecret = "0123456789abcdef0123456789abcdef"
+
+// newTestUserService creates a user service with a cheap bcrypt cost.
+func newTestUserService(repo *MockUserRepository) (*userService, *auth.Signer) {
+	signer := auth.NewSigner(testJWTSecret, time.Hour)
+	return newUserService(repo, signer, logger.New("test"), bcrypt.MinCost), signer
+}
+
+func TestRegister_StoresHashAndIssuesToken(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	service, signer := newTestUserService(mockRepo)
+
+	var stored *models.User
+	mockRepo.On("Create", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(*models.User) }).
+		Return(&models.User{ID: 7, Email: "ada@example.com"}, nil)
+
+	session, err := service.Register(context.Background(), " Ada@Example.com ", "correct horse")
+
+	require.NoError(t, err)
+	assert.Equal(t, "ada@example.com", stored.Email)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(stored.PasswordHash), []byte("correct horse")))
+	assert.Equal(t, uint(7), session.User.ID)
+
+	claims, err := signer.Verify(session.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "7", claims.Subject)
+	assert.Equal(t, session.ExpiresAt.Unix(), claims.ExpiresAt)
+}
+
+func TestRegister_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		email    string
+		password string
+	}{
+		{name: "invalid email", email: "ada", password: "correct horse"},
+		{name: "display name", email: "Ada <ada@example.com>", password: "correct horse"},
+		{name: "short password", email: "ada@example.com", password: "short"},
+		{name: "long password", email: "ada@example.com", password: strings.Repeat("x", MaxPasswordLength+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			service, _ := newTestUserService(mockRepo)
+
+			_, err := service.Register(context.Background(), tt.email, tt.password)
+
+			assert.ErrorIs(t, err, ErrInvalidUser)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRegister_EmailTaken(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	service, _ := newTestUserService(mockRepo)
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil, nil)
+
+	_, err := service.Register(context.Background(), "ada@example.com", "correct horse")
+
+	assert.ErrorIs(t, err, ErrEmailTaken)
+}
+
+func TestLogin(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	require.NoError(t, err)
+	user := &models.User{ID: 7, Email: "ada@example.com", PasswordHash: string(hash)}
+
+	t.Run("valid credentials", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service, _ := newTestUserService(mockRepo)
+		mockRepo.On("FindByEmail", mock.Anything, "ada@example.com").Return(user, nil)
+		mockRepo.On("TouchLastLogin", mock.Anything, uint(7)).Return(nil)
+
+		session, err := service.Login(context.Background(), "ADA@example.com", "correct horse")
+
+		require.NoError(t, err)
+		assert.Equal(t, user, session.User)
+		assert.NotEmpty(t, session.Token)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service, _ := newTestUserService(mockRepo)
+		mockRepo.On("FindByEmail", mock.Anything, "ada@example.com").Return(user, nil)
+
+		_, err := service.Login(context.Background(), "ada@example.com", "wrong horse")
+
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+		mockRepo.AssertNotCalled(t, "TouchLastLogin", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown email", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service, _ := newTestUserService(mockRepo)
+		mockRepo.On("FindByEmail", mock.Anything, "bob@example.com").Return(nil, nil)
+
+		_, err := service.Login(context.Background(), "bob@example.com", "correct horse")
+
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	t.Run("last login failure does not fail the login", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service, _ := newTestUserService(mockRepo)
+		mockRepo.On("FindByEmail", mock.Anything, "ada@example.com").Return(user, nil)
+		mockRepo.On("TouchLastLogin", mock.Anything, uint(7)).Return(errors.New("db down"))
+
+		_, err := service.Login(context.Background(), "ada@example.com", "correct horse")
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetUser_NotFound(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	service, _ := newTestUserService(mockRepo)
+	mockRepo.On("FindByID", mock.Anything, uint(7)).Return(nil, nil)
+
+	_, err := service.GetUser(context.Background(), 7)
+
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
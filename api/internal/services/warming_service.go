@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// Cache warming constants
+const (
+	// HotspotCellDegrees is the grid size used to bucket query locations (roughly 1 km)
+	HotspotCellDegrees = 0.01
+	// HotspotLookbackDays is how far back usage is considered when picking cells to warm
+	HotspotLookbackDays = 7
+	// maxPendingHotspotCells bounds the in-memory hit buffer between flushes
+	maxPendingHotspotCells = 10000
+	// warmNearbyRadius and warmNearbyLimit match the nearby endpoint defaults
+	warmNearbyRadius = 1000
+	warmNearbyLimit  = 20
+)
+
+// WarmingService defines the interface for usage tracking and post-import cache warming.
+type WarmingService interface {
+	// RecordHit counts a successful point query toward its grid cell.
+	// Hits are buffered in memory until FlushHits. Safe for concurrent use.
+	RecordHit(point models.LatLng)
+
+	// FlushHits writes the buffered hits to the hotspot table and clears the buffer.
+	// Hits are dropped if the write fails; usage counts are best-effort.
+	FlushHits(ctx context.Context) error
+
+	// WarmHotspots replays the at-point and nearby queries at the busiest grid cells of
+	// the last HotspotLookbackDays so their index and table pages are loaded into the
+	// PostgreSQL buffer cache. Individual query failures are logged and skipped.
+	// Returns the number of cells warmed.
+	WarmHotspots(ctx context.Context) (int, error)
+
+	// RunFlush flushes hits every poll interval until ctx is cancelled, then flushes
+	// once more. It runs in the process that records the hits.
+	RunFlush(ctx context.Context)
+
+	// RunWarming checks the dataset version every poll interval until ctx is
+	// cancelled, warming hotspots whenever a new import has been activated.
+	// It only reads the database, so it can run in a separate worker process.
+	RunWarming(ctx context.Context)
+}
+
+// hotspotCell identifies a grid cell by its rounded center.
+type hotspotCell struct {
+	lat float64
+	lng float64
+}
+
+// warmingService is the concrete implementation of WarmingService.
+type warmingService struct {
+	repo    repository.WarmingRepository
+	parcels repository.ParcelRepository
+	log     *logger.Logger
+	cfg     config.WarmingConfig
+
+	mu      sync.Mutex
+	pending map[hotspotCell]int64
+}
+
+// NewWarmingService creates a new instance of WarmingService.
+func NewWarmingService(repo repository.WarmingRepository, parcels repository.ParcelRepository, cfg config.WarmingConfig, log *logger.Logger) WarmingService {
+	return &warmingService{
+		repo:    repo,
+		parcels: parcels,
+		log:     log,
+		cfg:     cfg,
+		pending: make(map[hotspotCell]int64),
+	}
+}
+
+// RecordHit rounds the point to its cell; new cells are ignored once the buffer is full.
+func (s *warmingService) RecordHit(point models.LatLng) {
+	cell := hotspotCell{lat: roundToCell(point.Lat), lng: roundToCell(point.Lng)}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.pending[cell]; !ok && len(s.pending) >= maxPendingHotspotCells {
+		return
+	}
+	s.pending[cell]++
+}
+
+// FlushHits swaps out the buffer so requests are not blocked by the write.
+func (s *warmingService) FlushHits(ctx context.Context) error {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[hotspotCell]int64)
+	s.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	today := truncateToUTCDate(time.Now())
+	hotspots := make([]models.QueryHotspot, 0, len(pending))
+	for cell, hits := range pending {
+		hotspots = append(hotspots, models.QueryHotspot{
+			HitDate: today,
+			CellLat: cell.lat,
+			CellLng: cell.lng,
+			Hits:    hits,
+		})
+	}
+
+	if err := s.repo.AddHotspotHits(ctx, hotspots); err != nil {
+		s.log.Error("Failed to flush query hotspots", err, map[string]interface{}{
+			"cells": len(hotspots),
+		})
+		return fmt.Errorf("failed to flush query hotspots: %w", err)
+	}
+
+	return nil
+}
+
+// WarmHotspots runs up to cfg.Concurrency cells at a time so warming does not
+// starve live traffic of pool connections.
+func (s *warmingService) WarmHotspots(ctx context.Context) (int, error) {
+	since := truncateToUTCDate(time.Now()).AddDate(0, 0, -HotspotLookbackDays)
+	started := time.Now()
+
+	hotspots, err := s.repo.TopHotspots(ctx, since, s.cfg.Hotspots)
+	if err != nil {
+		s.log.Error("Failed to load query hotspots", err, map[string]interface{}{
+			"since": since.Format(time.DateOnly),
+		})
+		return 0, fmt.Errorf("failed to load query hotspots: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, s.cfg.Concurrency)
+	for _, h := range hotspots {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return 0, ctx.Err()
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(h models.QueryHotspot) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			s.warmCell(ctx, models.LatLng{Lat: h.CellLat, Lng: h.CellLng})
+		}(h)
+	}
+	wg.Wait()
+
+	s.log.Info("Cache warming completed", map[string]interface{}{
+		"cells":       len(hotspots),
+		"duration_ms": time.Since(started).Milliseconds(),
+	})
+
+	return len(hotspots), nil
+}
+
+// warmCell issues the queries a map client sends when panning to the cell.
+// Results are discarded; only the side effect on the buffer cache matters.
+func (s *warmingService) warmCell(ctx context.Context, point models.LatLng) {
+	fields := map[string]interface{}{
+		"lat": point.Lat,
+		"lng": point.Lng,
+	}
+
+	if _, err := s.parcels.FindByPoint(ctx, point); err != nil && ctx.Err() == nil {
+		s.log.Error("Cache warming at-point query failed", err, fields)
+	}
+	if _, _, err := s.parcels.FindNearby(ctx, point, warmNearbyRadius, warmNearbyLimit, nil, repository.NearbyFilter{}); err != nil && ctx.Err() == nil {
+		s.log.Error("Cache warming nearby query failed", err, fields)
+	}
+}
+
+// RunFlush blocks until ctx is cancelled; run it in its own goroutine.
+func (s *warmingService) RunFlush(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Persist what was counted since the last tick
+			flushCtx, cancel := context.WithTimeout(context.Background(), s.cfg.PollInterval)
+			_ = s.FlushHits(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			// Errors are already logged by FlushHits; the hits are dropped
+			_ = s.FlushHits(ctx)
+		}
+	}
+}
+
+// RunWarming blocks until ctx is cancelled; run it in its own goroutine.
+// The version seen at startup is the baseline, so only later imports trigger warming.
+func (s *warmingService) RunWarming(ctx context.Context) {
+	version, err := s.repo.LatestDatasetVersion(ctx)
+	if err != nil {
+		s.log.Error("Failed to read dataset version", err, nil)
+	}
+
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		latest, err := s.repo.LatestDatasetVersion(ctx)
+		if err != nil {
+			s.log.Error("Failed to read dataset version", err, nil)
+			continue
+		}
+		if latest == version {
+			continue
+		}
+
+		s.log.Info("Dataset switch detected, warming caches", map[string]interface{}{
+			"previous_version": version,
+			"version":          latest,
+		})
+		version = latest
+
+		// Errors are already logged by WarmHotspots; the next switch retries
+		_, _ = s.WarmHotspots(ctx)
+	}
+}
+
+// roundToCell snaps a coordinate to the center line of its HotspotCellDegrees cell.
+func roundToCell(v float64) float64 {
+	cells := math.Round(v / HotspotCellDegrees)
+	// Round again to drop floating point noise so equal cells compare equal in SQL
+	return math.Round(cells*HotspotCellDegrees*1e6) / 1e6
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// testWarmingConfig is a valid warming configuration for unit tests
+var testWarmingConfig = config.WarmingConfig{
+	Enabled:      true,
+	PollInterval: time.Minute,
+	Hotspots:     10,
+	Concurrency:  2,
+}
+
+// MockWarmingRepository is a mock implementation of WarmingRepository for testing
+type MockWarmingRepository struct {
+	mock.Mock
+}
+
+func (m *MockWarmingRepository) LatestDatasetVersion(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockWarmingRepository) AddHotspotHits(ctx context.Context, hotspots []models.QueryHotspot) error {
+	args := m.Called(ctx, hotspots)
+	return args.Error(0)
+}
+
+func (m *MockWarmingRepository) TopHotspots(ctx context.Context, since time.Time, limit int) ([]models.QueryHotspot, error) {
+	args := m.Called(ctx, since, limit)
+	hotspots, _ := args.Get(0).([]models.QueryHotspot)
+	return hotspots, args.Error(1)
+}
+
+func TestRecordHit_AggregatesByCell(t *testing.T) {
+	mockRepo := new(MockWarmingRepository)
+	service := NewWarmingService(mockRepo, new(MockParcelRepository), testWarmingConfig, logger.New("test"))
+
+	// The first two points share a 0.01 degree cell
+	service.RecordHit(models.LatLng{Lat: 30.3477, Lng: -95.4502})
+	service.RecordHit(models.LatLng{Lat: 30.3521, Lng: -95.4478})
+	service.RecordHit(models.LatLng{Lat: 30.2000, Lng: -95.5000})
+
+	var flushed []models.QueryHotspot
+	mockRepo.On("AddHotspotHits", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { flushed = args.Get(1).([]models.QueryHotspot) }).
+		Return(nil)
+
+	require.NoError(t, service.FlushHits(context.Background()))
+
+	sort.Slice(flushed, func(i, j int) bool { return flushed[i].CellLat < flushed[j].CellLat })
+	require.Len(t, flushed, 2)
+	assert.Equal(t, 30.2, flushed[0].CellLat)
+	assert.Equal(t, -95.5, flushed[0].CellLng)
+	assert.Equal(t, int64(1), flushed[0].Hits)
+	assert.Equal(t, 30.35, flushed[1].CellLat)
+	assert.Equal(t, -95.45, flushed[1].CellLng)
+	assert.Equal(t, int64(2), flushed[1].Hits)
+	assert.Equal(t, truncateToUTCDate(time.Now()), flushed[1].HitDate)
+}
+
+func TestFlushHits_EmptyBufferSkipsWrite(t *testing.T) {
+	mockRepo := new(MockWarmingRepository)
+	service := NewWarmingService(mockRepo, new(MockParcelRepository), testWarmingConfig, logger.New("test"))
+
+	require.NoError(t, service.FlushHits(context.Background()))
+
+	mockRepo.AssertNotCalled(t, "AddHotspotHits", mock.Anything, mock.Anything)
+}
+
+func TestFlushHits_RepositoryErrorDropsHits(t *testing.T) {
+	mockRepo := new(MockWarmingRepository)
+	service := NewWarmingService(mockRepo, new(MockParcelRepository), testWarmingConfig, logger.New("test"))
+
+	dbError := errors.New("database connection failed")
+	mockRepo.On("AddHotspotHits", mock.Anything, mock.Anything).Return(dbError).Once()
+
+	service.RecordHit(models.LatLng{Lat: 30.35, Lng: -95.45})
+	err := service.FlushHits(context.Background())
+	assert.ErrorIs(t, err, dbError)
+
+	// The failed batch is not retried
+	require.NoError(t, service.FlushHits(context.Background()))
+	mockRepo.AssertNumberOfCalls(t, "AddHotspotHits", 1)
+}
+
+func TestRunFlush_FlushesOnStop(t *testing.T) {
+	mockRepo := new(MockWarmingRepository)
+	service := NewWarmingService(mockRepo, new(MockParcelRepository), testWarmingConfig, logger.New("test"))
+	mockRepo.On("AddHotspotHits", mock.Anything, mock.Anything).Return(nil).Once()
+
+	service.RecordHit(models.LatLng{Lat: 30.35, Lng: -95.45})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	service.RunFlush(ctx)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWarmHotspots_QueriesEachCell(t *testing.T) {
+	mockRepo := new(MockWarmingRepository)
+	mockParcels := new(MockParcelRepository)
+	service := NewWarmingService(mockRepo, mockParcels, testWarmingConfig, logger.New("test"))
+
+	since := truncateToUTCDate(time.Now()).AddDate(0, 0, -HotspotLookbackDays)
+	hotspots := []models.QueryHotspot{
+		{CellLat: 30.35, CellLng: -95.45, Hits: 40},
+		{CellLat: 30.2, CellLng: -95.5, Hits: 12},
+	}
+	mockRepo.On("TopHotspots", mock.Anything, since, testWarmingConfig.Hotspots).Return(hotspots, nil)
+
+	for _, h := range hotspots {
+		point := models.LatLng{Lat: h.CellLat, Lng: h.CellLng}
+		mockParcels.On("FindByPoint", mock.Anything, point).Return(nil, nil)
+		mockParcels.On("FindNearby", mock.Anything, point, warmNearbyRadius, warmNearbyLimit, noCursor, noFilter).
+			Return(nil, 0, nil)
+	}
+
+	warmed, err := service.WarmHotspots(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, warmed)
+	mockParcels.AssertExpectations(t)
+}
+
+func TestWarmHotspots_QueryErrorsAreSkipped(t *testing.T) {
+	mockRepo := new(MockWarmingRepository)
+	mockParcels := new(MockParcelRepository)
+	service := NewWarmingService(mockRepo, mockParcels, testWarmingConfig, logger.New("test"))
+
+	hotspots := []models.QueryHotspot{{CellLat: 30.35, CellLng: -95.45, Hits: 40}}
+	mockRepo.On("TopHotspots", mock.Anything, mock.Anything, mock.Anything).Return(hotspots, nil)
+
+	dbError := errors.New("statement timeout")
+	mockParcels.On("FindByPoint", mock.Anything, mock.Anything).Return(nil, dbError)
+	mockParcels.On("FindNearby", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, 0, dbError)
+
+	warmed, err := service.WarmHotspots(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, warmed)
+}
+
+func TestWarmHotspots_RepositoryError(t *testing.T) {
+	mockRepo := new(MockWarmingRepository)
+	service := NewWarmingService(mockRepo, new(MockParcelRepository), testWarmingConfig, logger.New("test"))
+
+	dbError := errors.New("database connection failed")
+	mockRepo.On("TopHotspots", mock.Anything, mock.Anything, mock.Anything).Return(nil, dbError)
+
+	_, err := service.WarmHotspots(context.Background())
+
+	assert.ErrorIs(t, err, dbError)
+}
+
+func TestRoundToCell(t *testing.T) {
+	tests := []struct {
+		input float64
+		want  float64
+	}{
+		{input: 30.3477, want: 30.35},
+		{input: 30.3449, want: 30.34},
+		{input: -95.4502, want: -95.45},
+		{input: 0.004, want: 0},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, roundToCell(tt.input), "roundToCell(%v)", tt.input)
+	}
+}
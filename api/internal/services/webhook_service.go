@@ -0,0 +1,478 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	"github.com/stwalsh4118/atlas/api/internal/jobs"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/outbound"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// Webhook constants
+const (
+	// WebhookSecretPrefix starts every webhook signing secret.
+	WebhookSecretPrefix = "whsec_"
+	// MaxWebhookURLLength bounds the URL of a webhook, in bytes
+	MaxWebhookURLLength = 2048
+	// MaxWebhookDeliveriesLimit bounds the deliveries returned per request
+	MaxWebhookDeliveriesLimit = 500
+	// MaxWebhookRetryDelay caps the backoff between attempts of a delivery
+	MaxWebhookRetryDelay = 6 * time.Hour
+	// WebhookEnqueueTask identifies the durable task queueing the deliveries
+	// of one ingestion run
+	WebhookEnqueueTask = "webhooks.enqueue"
+
+	// Signed delivery headers; WebhookSignatureHeader is "t=<unix time>,v1=<hex HMAC>"
+	WebhookSignatureHeader = "X-Atlas-Signature"
+	WebhookEventHeader     = "X-Atlas-Event"
+	WebhookDeliveryHeader  = "X-Atlas-Delivery"
+
+	// webhookSecretBytes is the randomness of a signing secret
+	webhookSecretBytes = 32
+	// webhookDeliveryBatch is the number of due deliveries loaded at a time
+	webhookDeliveryBatch = 100
+)
+
+// Webhook service errors
+var (
+	ErrInvalidWebhook  = domain.Validation("invalid webhook")
+	ErrWebhookNotFound = domain.NotFound("webhook not found")
+)
+
+// WebhookService defines the interface for tenants' webhooks and the job that
+// notifies them of parcels changed by ingestion.
+type WebhookService interface {
+	// CreateWebhook validates and stores a webhook of the tenant, returning it
+	// with its signing secret, which is shown only once. Parcels changed by
+	// ingestion runs after its creation are delivered.
+	// Returns ErrInvalidTenant, ErrInvalidWebhook or ErrInvalidGeometry when validation fails.
+	CreateWebhook(ctx context.Context, webhook models.Webhook) (*models.Webhook, string, error)
+
+	// ListWebhooks returns the tenant's webhooks, newest first.
+	ListWebhooks(ctx context.Context, tenantID string) ([]models.Webhook, error)
+
+	// DeleteWebhook deletes the tenant's webhook and its deliveries.
+	// Returns ErrWebhookNotFound if the tenant has no such webhook.
+	DeleteWebhook(ctx context.Context, tenantID string, id uint) error
+
+	// ListDeliveries returns up to limit deliveries of the tenant's webhook, newest first.
+	// Returns ErrInvalidLimit if limit is out of range, or ErrWebhookNotFound
+	// if the tenant has no such webhook.
+	ListDeliveries(ctx context.Context, tenantID string, id uint, limit int) ([]models.WebhookDelivery, error)
+
+	// EnqueueAll queues the deliveries of every webhook for runID. Webhooks that
+	// fail are logged and skipped; they are retried by the next evaluation,
+	// since their EvaluatedAt did not move.
+	// Returns the number of deliveries queued.
+	EnqueueAll(ctx context.Context, runID int64) (int64, error)
+
+	// DeliverDue attempts every pending delivery whose next attempt is due.
+	// Failed attempts are retried with exponential backoff until the delivery
+	// has been attempted config.WebhooksConfig.MaxAttempts times.
+	// Returns the number of deliveries delivered.
+	DeliverDue(ctx context.Context) (int, error)
+
+	// Run checks the latest ingestion run and the due deliveries every poll
+	// interval until ctx is cancelled, queueing deliveries whenever a new run
+	// has been recorded, and once at startup if any run has been. With a task
+	// queue the deliveries are queued by a WebhookEnqueueTask instead of in place.
+	Run(ctx context.Context)
+}
+
+// webhookService is the concrete implementation of WebhookService.
+type webhookService struct {
+	repo    repository.WebhookRepository
+	parcels repository.ParcelRepository
+	client  *outbound.Client
+	tasks   *jobs.Queue
+	log     *logger.Logger
+	// checkURL validates webhook URLs; tests allow local servers
+	checkURL func(rawURL string) error
+	cfg      config.WebhooksConfig
+}
+
+// NewWebhookService creates a new instance of WebhookService delivering with
+// client. Areas are checked for topology errors with the parcel repository.
+// tasks may be nil, in which case Run queues deliveries in place; otherwise the
+// service registers its enqueue task with it.
+func NewWebhookService(repo repository.WebhookRepository, parcels repository.ParcelRepository, client *outbound.Client, tasks *jobs.Queue, cfg config.WebhooksConfig, log *logger.Logger) WebhookService {
+	return newWebhookService(repo, parcels, client, tasks, cfg, log, validateCallbackURL)
+}
+
+// newWebhookService creates the service with the given URL check.
+func newWebhookService(repo repository.WebhookRepository, parcels repository.ParcelRepository, client *outbound.Client, tasks *jobs.Queue, cfg config.WebhooksConfig, log *logger.Logger, checkURL func(string) error) *webhookService {
+	s := &webhookService{
+		repo:     repo,
+		parcels:  parcels,
+		client:   client,
+		tasks:    tasks,
+		log:      log,
+		checkURL: checkURL,
+		cfg:      cfg,
+	}
+	if tasks != nil {
+		tasks.Handle(WebhookEnqueueTask, s.enqueueTask)
+	}
+	return s
+}
+
+// CreateWebhook checks the URL as result callback URLs are checked and the
+// area with the rules of the intersects endpoint, then generates the secret.
+func (s *webhookService) CreateWebhook(ctx context.Context, webhook models.Webhook) (*models.Webhook, string, error) {
+	if err := validateTenantID(webhook.TenantID); err != nil {
+		return nil, "", err
+	}
+	webhook.URL = strings.TrimSpace(webhook.URL)
+	if len(webhook.URL) > MaxWebhookURLLength {
+		return nil, "", fmt.Errorf("%w: url must be at most %d characters", ErrInvalidWebhook, MaxWebhookURLLength)
+	}
+	if err := s.checkURL(webhook.URL); err != nil {
+		return nil, "", fmt.Errorf("%w: url must be an https URL of a public host", ErrInvalidWebhook)
+	}
+	if webhook.County != nil {
+		if county := strings.TrimSpace(*webhook.County); county != "" {
+			webhook.County = &county
+		} else {
+			webhook.County = nil
+		}
+	}
+
+	if webhook.Area != nil {
+		if err := validateArea(webhook.Area); err != nil {
+			return nil, "", err
+		}
+		reason, err := s.parcels.ValidateArea(ctx, *webhook.Area)
+		if err != nil {
+			s.log.Error("Failed to validate area geometry", err, nil)
+			return nil, "", fmt.Errorf("failed to validate area: %w", err)
+		}
+		if reason != "" {
+			return nil, "", fmt.Errorf("%w: %s", ErrInvalidGeometry, reason)
+		}
+	}
+
+	random := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(random); err != nil {
+		return nil, "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	webhook.Secret = WebhookSecretPrefix + base64.RawURLEncoding.EncodeToString(random)
+
+	stored, err := s.repo.Create(ctx, &webhook)
+	if err != nil {
+		s.log.Error("Failed to create webhook", err, map[string]interface{}{
+			"tenant_id": webhook.TenantID,
+		})
+		return nil, "", fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	s.log.Info("Webhook created", map[string]interface{}{
+		"tenant_id":  stored.TenantID,
+		"webhook_id": stored.ID,
+	})
+	return stored, webhook.Secret, nil
+}
+
+// ListWebhooks returns the tenant's webhooks.
+func (s *webhookService) ListWebhooks(ctx context.Context, tenantID string) ([]models.Webhook, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := s.repo.List(ctx, tenantID)
+	if err != nil {
+		s.log.Error("Failed to list webhooks", err, map[string]interface{}{
+			"tenant_id": tenantID,
+		})
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// DeleteWebhook deletes the webhook if the tenant owns it.
+func (s *webhookService) DeleteWebhook(ctx context.Context, tenantID string, id uint) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return err
+	}
+
+	deleted, err := s.repo.Delete(ctx, tenantID, id)
+	if err != nil {
+		s.log.Error("Failed to delete webhook", err, map[string]interface{}{
+			"tenant_id":  tenantID,
+			"webhook_id": id,
+		})
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	if !deleted {
+		return ErrWebhookNotFound
+	}
+
+	s.log.Info("Webhook deleted", map[string]interface{}{
+		"tenant_id":  tenantID,
+		"webhook_id": id,
+	})
+	return nil
+}
+
+// ListDeliveries validates the limit and reads the webhook's deliveries.
+func (s *webhookService) ListDeliveries(ctx context.Context, tenantID string, id uint, limit int) ([]models.WebhookDelivery, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, err
+	}
+	if limit < 1 || limit > MaxWebhookDeliveriesLimit {
+		return nil, fmt.Errorf("%w: must be between 1 and %d, got %d", ErrInvalidLimit, MaxWebhookDeliveriesLimit, limit)
+	}
+
+	deliveries, err := s.repo.FindDeliveries(ctx, tenantID, id, limit)
+	if err != nil {
+		s.log.Error("Failed to find webhook deliveries", err, map[string]interface{}{
+			"tenant_id":  tenantID,
+			"webhook_id": id,
+		})
+		return nil, fmt.Errorf("failed to find webhook deliveries: %w", err)
+	}
+	if deliveries == nil {
+		return nil, ErrWebhookNotFound
+	}
+
+	return deliveries, nil
+}
+
+// EnqueueAll enqueues the deliveries of each webhook in turn.
+func (s *webhookService) EnqueueAll(ctx context.Context, runID int64) (int64, error) {
+	total, _, err := s.enqueueAll(ctx, runID)
+	return total, err
+}
+
+// enqueueAll implements EnqueueAll, also returning the number of webhooks that
+// failed.
+func (s *webhookService) enqueueAll(ctx context.Context, runID int64) (int64, int, error) {
+	started := time.Now()
+
+	webhooks, err := s.repo.ListAll(ctx)
+	if err != nil {
+		s.log.Error("Failed to load webhooks", err, nil)
+		return 0, 0, fmt.Errorf("failed to load webhooks: %w", err)
+	}
+
+	var total int64
+	failed := 0
+	for _, webhook := range webhooks {
+		if ctx.Err() != nil {
+			return total, failed, ctx.Err()
+		}
+		queued, err := s.repo.Enqueue(ctx, webhook, runID)
+		if err != nil {
+			failed++
+			s.log.Error("Failed to enqueue webhook deliveries", err, map[string]interface{}{
+				"webhook_id": webhook.ID,
+				"run_id":     runID,
+			})
+			continue
+		}
+		total += queued
+	}
+
+	s.log.Info("Webhook deliveries queued", map[string]interface{}{
+		"run_id":      runID,
+		"webhooks":    len(webhooks),
+		"failed":      failed,
+		"deliveries":  total,
+		"duration_ms": time.Since(started).Milliseconds(),
+	})
+
+	return total, failed, nil
+}
+
+// enqueueTask runs a WebhookEnqueueTask. Webhooks that failed fail the task, so
+// it is retried; webhooks already evaluated then queue nothing new.
+func (s *webhookService) enqueueTask(ctx context.Context, payload json.RawMessage) error {
+	var p runTaskPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return fmt.Errorf("invalid %s payload: %w", WebhookEnqueueTask, err)
+	}
+
+	_, failed, err := s.enqueueAll(ctx, p.RunID)
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d webhooks failed to queue deliveries", failed)
+	}
+	return nil
+}
+
+// DeliverDue loads due deliveries a batch at a time until none are left.
+// Deliveries of one batch are attempted in turn, so a slow endpoint delays the
+// others by at most the client timeout.
+func (s *webhookService) DeliverDue(ctx context.Context) (int, error) {
+	delivered := 0
+	for {
+		due, err := s.repo.DueDeliveries(ctx, webhookDeliveryBatch)
+		if err != nil {
+			s.log.Error("Failed to load due webhook deliveries", err, nil)
+			return delivered, fmt.Errorf("failed to load due webhook deliveries: %w", err)
+		}
+
+		for _, d := range due {
+			if ctx.Err() != nil {
+				return delivered, ctx.Err()
+			}
+			attempt := s.attempt(ctx, d)
+			if err := s.repo.RecordAttempt(ctx, d.Delivery.ID, attempt); err != nil {
+				s.log.Error("Failed to record webhook delivery attempt", err, map[string]interface{}{
+					"delivery_id": d.Delivery.ID,
+				})
+				return delivered, fmt.Errorf("failed to record webhook delivery attempt: %w", err)
+			}
+			if attempt.Status == models.WebhookDeliveryDelivered {
+				delivered++
+			}
+		}
+
+		if len(due) < webhookDeliveryBatch {
+			return delivered, nil
+		}
+	}
+}
+
+// attempt POSTs the signed payload of the delivery and returns the outcome.
+// Any 2xx response counts as delivered.
+func (s *webhookService) attempt(ctx context.Context, d repository.DueWebhookDelivery) repository.WebhookAttempt {
+	delivery := d.Delivery
+	body, err := json.Marshal(delivery.Payload)
+	if err != nil {
+		// Payloads are built by the database and always encode
+		return s.failedAttempt(delivery, nil, fmt.Errorf("failed to encode payload: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(body))
+	if err != nil {
+		return s.failedAttempt(delivery, nil, fmt.Errorf("failed to build request: %w", err))
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, delivery.Event)
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(WebhookSignatureHeader, fmt.Sprintf("t=%d,v1=%s", timestamp, SignWebhook(d.Secret, timestamp, body)))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return s.failedAttempt(delivery, nil, err)
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return s.failedAttempt(delivery, &resp.StatusCode, fmt.Errorf("endpoint answered status %d", resp.StatusCode))
+	}
+
+	return repository.WebhookAttempt{
+		Status:         models.WebhookDeliveryDelivered,
+		ResponseStatus: &resp.StatusCode,
+		NextAttemptAt:  time.Now(),
+	}
+}
+
+// failedAttempt schedules the next attempt of the delivery after
+// RetryDelay doubled for each earlier attempt, or fails it once MaxAttempts
+// attempts have been made.
+func (s *webhookService) failedAttempt(delivery models.WebhookDelivery, status *int, err error) repository.WebhookAttempt {
+	message := err.Error()
+	attempts := delivery.Attempts + 1
+	attempt := repository.WebhookAttempt{
+		Status:         models.WebhookDeliveryPending,
+		ResponseStatus: status,
+		Error:          &message,
+		NextAttemptAt:  time.Now().Add(webhookRetryDelay(s.cfg.RetryDelay, attempts)),
+	}
+	if attempts >= s.cfg.MaxAttempts {
+		attempt.Status = models.WebhookDeliveryFailed
+	}
+
+	s.log.Warn("Webhook delivery attempt failed", map[string]interface{}{
+		"delivery_id": delivery.ID,
+		"webhook_id":  delivery.WebhookID,
+		"attempts":    attempts,
+		"status":      attempt.Status,
+		"error":       message,
+	})
+	return attempt
+}
+
+// webhookRetryDelay returns the delay after the given number of attempts:
+// base doubled for each attempt after the first, at most MaxWebhookRetryDelay.
+func webhookRetryDelay(base time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < MaxWebhookRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, MaxWebhookRetryDelay)
+}
+
+// SignWebhook returns the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with
+// the webhook secret, the v1 value of the WebhookSignatureHeader. Receivers
+// recompute it to authenticate a delivery and reject stale timestamps to stop replays.
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Run blocks until ctx is cancelled; run it in its own goroutine. Queueing only
+// covers changes made since each webhook was last evaluated, so the evaluation
+// at startup picks up runs recorded while no instance ran the job.
+func (s *webhookService) Run(ctx context.Context) {
+	var run int64
+
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		latest, err := s.repo.LatestIngestionRun(ctx)
+		if err != nil {
+			s.log.Error("Failed to read latest ingestion run", err, nil)
+		} else if latest != run {
+			s.log.Info("New ingestion run detected, queueing webhook deliveries", map[string]interface{}{
+				"previous_run_id": run,
+				"run_id":          latest,
+			})
+			if s.tasks == nil {
+				run = latest
+				// Errors are already logged by EnqueueAll; the next run retries
+				_, _ = s.EnqueueAll(ctx, latest)
+			} else if err := enqueueRunTask(ctx, s.tasks, WebhookEnqueueTask, latest); err != nil {
+				// The run is detected again at the next tick
+				s.log.Error("Failed to enqueue webhook delivery queueing", err, map[string]interface{}{
+					"run_id": latest,
+				})
+			} else {
+				run = latest
+			}
+		}
+
+		// Errors are already logged by DeliverDue; the next tick retries
+		_, _ = s.DeliverDue(ctx)
+	}
+}
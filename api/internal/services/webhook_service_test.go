@@ -0,0 +1,314 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/config"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/outbound"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// MockWebhookRepository is a mock implementation of WebhookRepository for testing
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) Create(ctx context.Context, webhook *models.Webhook) (*models.Webhook, error) {
+	args := m.Called(ctx, webhook)
+	stored, _ := args.Get(0).(*models.Webhook)
+	return stored, args.Error(1)
+}
+
+func (m *MockWebhookRepository) List(ctx context.Context, tenantID string) ([]models.Webhook, error) {
+	args := m.Called(ctx, tenantID)
+	webhooks, _ := args.Get(0).([]models.Webhook)
+	return webhooks, args.Error(1)
+}
+
+func (m *MockWebhookRepository) ListAll(ctx context.Context) ([]models.Webhook, error) {
+	args := m.Called(ctx)
+	webhooks, _ := args.Get(0).([]models.Webhook)
+	return webhooks, args.Error(1)
+}
+
+func (m *MockWebhookRepository) Delete(ctx context.Context, tenantID string, id uint) (bool, error) {
+	args := m.Called(ctx, tenantID, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockWebhookRepository) FindDeliveries(ctx context.Context, tenantID string, id uint, limit int) ([]models.WebhookDelivery, error) {
+	args := m.Called(ctx, tenantID, id, limit)
+	deliveries, _ := args.Get(0).([]models.WebhookDelivery)
+	return deliveries, args.Error(1)
+}
+
+func (m *MockWebhookRepository) Enqueue(ctx context.Context, webhook models.Webhook, runID int64) (int64, error) {
+	args := m.Called(ctx, webhook, runID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockWebhookRepository) DueDeliveries(ctx context.Context, limit int) ([]repository.DueWebhookDelivery, error) {
+	args := m.Called(ctx, limit)
+	due, _ := args.Get(0).([]repository.DueWebhookDelivery)
+	return due, args.Error(1)
+}
+
+func (m *MockWebhookRepository) RecordAttempt(ctx context.Context, id int64, attempt repository.WebhookAttempt) error {
+	args := m.Called(ctx, id, attempt)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) LatestIngestionRun(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// testWebhooksConfig fails a delivery on its third attempt.
+var testWebhooksConfig = config.WebhooksConfig{Enabled: true, PollInterval: time.Second, MaxAttempts: 3, RetryDelay: time.Minute}
+
+// newTestWebhookService returns a service delivering to any URL, httptest servers included.
+func newTestWebhookService(repo *MockWebhookRepository, parcels *MockParcelRepository) *webhookService {
+	client := outbound.NewClient("webhooks", outbound.Options{MaxRetries: -1, BreakerThreshold: -1})
+	return newWebhookService(repo, parcels, client, nil, testWebhooksConfig, logger.New("test"), allowAnyCallback)
+}
+
+func TestCreateWebhook_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockWebhookRepository)
+	mockParcels := new(MockParcelRepository)
+	service := newTestWebhookService(mockRepo, mockParcels)
+	ctx := context.Background()
+	county := " montgomery-tx "
+
+	mockParcels.On("ValidateArea", ctx, *testArea()).Return("", nil)
+	var created *models.Webhook
+	mockRepo.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*models.Webhook)
+	}).Return(&models.Webhook{ID: 3, TenantID: "acme"}, nil)
+
+	// Act
+	result, secret, err := service.CreateWebhook(ctx, models.Webhook{
+		TenantID: "acme",
+		URL:      " https://hooks.example.com/atlas ",
+		County:   &county,
+		Area:     testArea(),
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, uint(3), result.ID)
+	assert.True(t, strings.HasPrefix(secret, WebhookSecretPrefix))
+	assert.Equal(t, secret, created.Secret)
+	assert.Equal(t, "https://hooks.example.com/atlas", created.URL)
+	assert.Equal(t, "montgomery-tx", *created.County)
+	mockRepo.AssertExpectations(t)
+	mockParcels.AssertExpectations(t)
+}
+
+func TestCreateWebhook_ValidationErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		webhook models.Webhook
+		errType error
+	}{
+		{"invalid tenant", models.Webhook{TenantID: "Acme Corp", URL: "https://hooks.example.com"}, ErrInvalidTenant},
+		{"http URL", models.Webhook{TenantID: "acme", URL: "http://hooks.example.com"}, ErrInvalidWebhook},
+		{"private host", models.Webhook{TenantID: "acme", URL: "https://10.0.0.1/hook"}, ErrInvalidWebhook},
+		{"URL too long", models.Webhook{TenantID: "acme", URL: "https://hooks.example.com/" + strings.Repeat("a", MaxWebhookURLLength)}, ErrInvalidWebhook},
+		{"empty area", models.Webhook{TenantID: "acme", URL: "https://hooks.example.com", Area: &models.MultiPolygon{}}, ErrInvalidGeometry},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockWebhookRepository)
+			service := NewWebhookService(mockRepo, new(MockParcelRepository), nil, nil, testWebhooksConfig, logger.New("test"))
+
+			_, _, err := service.CreateWebhook(context.Background(), tc.webhook)
+
+			assert.ErrorIs(t, err, tc.errType)
+			mockRepo.AssertNotCalled(t, "Create")
+		})
+	}
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockWebhookRepository)
+	service := newTestWebhookService(mockRepo, new(MockParcelRepository))
+	ctx := context.Background()
+
+	mockRepo.On("Delete", ctx, "acme", uint(3)).Return(true, nil)
+	mockRepo.On("Delete", ctx, "acme", uint(4)).Return(false, nil)
+
+	// Act & Assert
+	assert.NoError(t, service.DeleteWebhook(ctx, "acme", 3))
+	assert.ErrorIs(t, service.DeleteWebhook(ctx, "acme", 4), ErrWebhookNotFound)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListDeliveries(t *testing.T) {
+	t.Run("webhook not found", func(t *testing.T) {
+		mockRepo := new(MockWebhookRepository)
+		service := newTestWebhookService(mockRepo, new(MockParcelRepository))
+		ctx := context.Background()
+
+		mockRepo.On("FindDeliveries", ctx, "acme", uint(3), 10).Return(nil, nil)
+
+		_, err := service.ListDeliveries(ctx, "acme", 3, 10)
+
+		assert.ErrorIs(t, err, ErrWebhookNotFound)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		mockRepo := new(MockWebhookRepository)
+		service := newTestWebhookService(mockRepo, new(MockParcelRepository))
+
+		_, err := service.ListDeliveries(context.Background(), "acme", 3, MaxWebhookDeliveriesLimit+1)
+
+		assert.ErrorIs(t, err, ErrInvalidLimit)
+		mockRepo.AssertNotCalled(t, "FindDeliveries")
+	})
+}
+
+func TestEnqueueAll_SkipsFailedWebhooks(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockWebhookRepository)
+	service := newTestWebhookService(mockRepo, new(MockParcelRepository))
+	ctx := context.Background()
+	webhooks := []models.Webhook{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	mockRepo.On("ListAll", ctx).Return(webhooks, nil)
+	mockRepo.On("Enqueue", ctx, webhooks[0], int64(9)).Return(int64(2), nil)
+	mockRepo.On("Enqueue", ctx, webhooks[1], int64(9)).Return(int64(0), errors.New("statement timeout"))
+	mockRepo.On("Enqueue", ctx, webhooks[2], int64(9)).Return(int64(1), nil)
+
+	// Act
+	queued, err := service.EnqueueAll(ctx, 9)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), queued)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDeliverDue_SignsPayload(t *testing.T) {
+	// Arrange
+	requests := make(chan *http.Request, 1)
+	bodies := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- r
+		bodies <- body
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	mockRepo := new(MockWebhookRepository)
+	service := newTestWebhookService(mockRepo, new(MockParcelRepository))
+	ctx := context.Background()
+	payload := models.WebhookPayload{
+		Event: models.WebhookEventParcelsChanged, WebhookID: 3, IngestionRunID: 9,
+		Parcels: []models.WebhookParcelChange{{ParcelID: 12, PIN: 345, CountyName: "Montgomery", Change: models.ParcelChangeAdded}},
+	}
+	due := repository.DueWebhookDelivery{
+		URL:    server.URL,
+		Secret: "whsec_test",
+		Delivery: models.WebhookDelivery{
+			ID: 21, WebhookID: 3, Event: models.WebhookEventParcelsChanged, Payload: payload,
+		},
+	}
+
+	mockRepo.On("DueDeliveries", ctx, webhookDeliveryBatch).Return([]repository.DueWebhookDelivery{due}, nil)
+	var recorded repository.WebhookAttempt
+	mockRepo.On("RecordAttempt", ctx, int64(21), mock.Anything).Run(func(args mock.Arguments) {
+		recorded = args.Get(2).(repository.WebhookAttempt)
+	}).Return(nil)
+
+	// Act
+	delivered, err := service.DeliverDue(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, models.WebhookDeliveryDelivered, recorded.Status)
+	require.NotNil(t, recorded.ResponseStatus)
+	assert.Equal(t, http.StatusNoContent, *recorded.ResponseStatus)
+
+	req, body := <-requests, <-bodies
+	assert.Equal(t, models.WebhookEventParcelsChanged, req.Header.Get(WebhookEventHeader))
+	assert.Equal(t, "21", req.Header.Get(WebhookDeliveryHeader))
+	var timestamp int64
+	var signature string
+	_, err = fmt.Sscanf(req.Header.Get(WebhookSignatureHeader), "t=%d,v1=%s", &timestamp, &signature)
+	require.NoError(t, err)
+	assert.Equal(t, SignWebhook("whsec_test", timestamp, body), signature)
+
+	var received models.WebhookPayload
+	require.NoError(t, json.Unmarshal(body, &received))
+	assert.Equal(t, payload, received)
+}
+
+func TestDeliverDue_RetriesThenFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name       string
+		attempts   int
+		wantStatus string
+		wantDelay  time.Duration
+	}{
+		{"first attempt", 0, models.WebhookDeliveryPending, time.Minute},
+		{"second attempt backs off", 1, models.WebhookDeliveryPending, 2 * time.Minute},
+		{"last attempt", 2, models.WebhookDeliveryFailed, 4 * time.Minute},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockWebhookRepository)
+			service := newTestWebhookService(mockRepo, new(MockParcelRepository))
+			ctx := context.Background()
+			due := repository.DueWebhookDelivery{URL: server.URL, Secret: "whsec_test",
+				Delivery: models.WebhookDelivery{ID: 21, Attempts: tc.attempts}}
+
+			mockRepo.On("DueDeliveries", ctx, webhookDeliveryBatch).Return([]repository.DueWebhookDelivery{due}, nil)
+			var recorded repository.WebhookAttempt
+			mockRepo.On("RecordAttempt", ctx, int64(21), mock.Anything).Run(func(args mock.Arguments) {
+				recorded = args.Get(2).(repository.WebhookAttempt)
+			}).Return(nil)
+
+			started := time.Now()
+			delivered, err := service.DeliverDue(ctx)
+
+			require.NoError(t, err)
+			assert.Zero(t, delivered)
+			assert.Equal(t, tc.wantStatus, recorded.Status)
+			require.NotNil(t, recorded.ResponseStatus)
+			assert.Equal(t, http.StatusGone, *recorded.ResponseStatus)
+			require.NotNil(t, recorded.Error)
+			assert.WithinDuration(t, started.Add(tc.wantDelay), recorded.NextAttemptAt, 5*time.Second)
+		})
+	}
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Minute, webhookRetryDelay(time.Minute, 1))
+	assert.Equal(t, 8*time.Minute, webhookRetryDelay(time.Minute, 4))
+	assert.Equal(t, MaxWebhookRetryDelay, webhookRetryDelay(time.Minute, 40))
+}
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/domain"
+	"github.com/stwalsh4118/atlas/api/internal/embed"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+	"github.com/stwalsh4118/atlas/api/internal/repository"
+)
+
+// MaxWidgetTitleLength is the maximum length of a widget branding title.
+const MaxWidgetTitleLength = 100
+
+// Widget service errors
+var (
+	ErrInvalidWidget     = domain.Validation("invalid widget")
+	ErrWidgetNotFound    = domain.NotFound("widget not found")
+	ErrInvalidEmbedToken = domain.Unauthorized("invalid embed token")
+)
+
+// WidgetFields are the parcel response fields a widget may expose.
+// The parcel id and geometry are always included so the viewer can draw the parcel.
+var WidgetFields = map[string]bool{
+	"parcel_id":     true,
+	"owner_name":    true,
+	"situs_address": true,
+	"prop_type":     true,
+	"land_use":      true,
+	"county_name":   true,
+	"acres":         true,
+}
+
+// EmbedGrant is a signed embed token issued for a widget.
+type EmbedGrant struct {
+	ExpiresAt time.Time
+	Widget    *models.EmbedWidget
+	Token     string
+}
+
+// WidgetService defines the interface for embeddable widget operations.
+type WidgetService interface {
+	// ListWidgets returns every widget configured for the tenant.
+	// Returns ErrInvalidTenant if the tenant id is malformed.
+	ListWidgets(ctx context.Context, tenantID string) ([]models.EmbedWidget, error)
+
+	// GetWidget returns the tenant's widget with the given name.
+	// Returns ErrWidgetNotFound if the widget does not exist.
+	GetWidget(ctx context.Context, tenantID, name string) (*models.EmbedWidget, error)
+
+	// SaveWidget validates and creates or replaces a widget.
+	// Returns ErrInvalidTenant or ErrInvalidWidget when validation fails.
+	SaveWidget(ctx context.Context, widget *models.EmbedWidget) (*models.EmbedWidget, error)
+
+	// IssueEmbedToken signs a token granting access to the tenant's widget.
+	// Returns ErrWidgetNotFound if the widget does not exist.
+	IssueEmbedToken(ctx context.Context, tenantID, name string) (*EmbedGrant, error)
+
+	// ResolveEmbedToken verifies a token and returns the widget it grants access to.
+	// Returns ErrInvalidEmbedToken if the token is malformed, forged, expired,
+	// or refers to a widget that no longer exists.
+	ResolveEmbedToken(ctx context.Context, token string) (*models.EmbedWidget, error)
+}
+
+// widgetService is the concrete implementation of WidgetService.
+type widgetService struct {
+	repo   repository.WidgetRepository
+	signer *embed.Signer
+	log    *logger.Logger
+}
+
+// NewWidgetService creates a new instance of WidgetService.
+func NewWidgetService(repo repository.WidgetRepository, signer *embed.Signer, log *logger.Logger) WidgetService {
+	return &widgetService{
+		repo:   repo,
+		signer: signer,
+		log:    log,
+	}
+}
+
+// ListWidgets returns the tenant's widgets ordered by name.
+func (s *widgetService) ListWidgets(ctx context.Context, tenantID string) ([]models.EmbedWidget, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, err
+	}
+
+	widgets, err := s.repo.List(ctx, tenantID)
+	if err != nil {
+		s.log.Error("Failed to list widgets", err, map[string]interface{}{
+			"tenant_id": tenantID,
+		})
+		return nil, fmt.Errorf("failed to list widgets: %w", err)
+	}
+
+	return widgets, nil
+}
+
+// GetWidget returns a single widget, transforming a missing row into ErrWidgetNotFound.
+func (s *widgetService) GetWidget(ctx context.Context, tenantID, name string) (*models.EmbedWidget, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, err
+	}
+	if !identifierPattern.MatchString(name) {
+		return nil, fmt.Errorf("%w: name must be a lowercase slug of at most 100 characters, got %q", ErrInvalidWidget, name)
+	}
+
+	widget, err := s.repo.FindByName(ctx, tenantID, name)
+	if err != nil {
+		s.log.Error("Failed to query widget", err, map[string]interface{}{
+			"tenant_id": tenantID,
+			"name":      name,
+		})
+		return nil, fmt.Errorf("failed to query widget: %w", err)
+	}
+
+	if widget == nil {
+		return nil, ErrWidgetNotFound
+	}
+
+	return widget, nil
+}
+
+// SaveWidget validates the widget configuration and upserts it.
+func (s *widgetService) SaveWidget(ctx context.Context, widget *models.EmbedWidget) (*models.EmbedWidget, error) {
+	if err := validateTenantID(widget.TenantID); err != nil {
+		return nil, err
+	}
+	if err := validateWidget(widget); err != nil {
+		s.log.Warn("Invalid widget configuration provided", map[string]interface{}{
+			"tenant_id": widget.TenantID,
+			"name":      widget.Name,
+			"error":     err.Error(),
+		})
+		return nil, err
+	}
+
+	stored, err := s.repo.Upsert(ctx, widget)
+	if err != nil {
+		s.log.Error("Failed to save widget", err, map[string]interface{}{
+			"tenant_id": widget.TenantID,
+			"name":      widget.Name,
+		})
+		return nil, fmt.Errorf("failed to save widget: %w", err)
+	}
+
+	s.log.Info("Widget saved", map[string]interface{}{
+		"tenant_id": stored.TenantID,
+		"name":      stored.Name,
+		"widget_id": stored.ID,
+	})
+
+	return stored, nil
+}
+
+// IssueEmbedToken signs a token for an existing widget.
+func (s *widgetService) IssueEmbedToken(ctx context.Context, tenantID, name string) (*EmbedGrant, error) {
+	widget, err := s.GetWidget(ctx, tenantID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	token, expiresAt, err := s.signer.Issue(widget.TenantID, widget.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue embed token: %w", err)
+	}
+
+	s.log.Info("Embed token issued", map[string]interface{}{
+		"tenant_id":  tenantID,
+		"name":       name,
+		"expires_at": expiresAt,
+	})
+
+	return &EmbedGrant{
+		Token:     token,
+		ExpiresAt: expiresAt,
+		Widget:    widget,
+	}, nil
+}
+
+// ResolveEmbedToken verifies the token and loads the current widget configuration.
+// The widget is re-read on every call so configuration changes apply to tokens already issued.
+func (s *widgetService) ResolveEmbedToken(ctx context.Context, token string) (*models.EmbedWidget, error) {
+	claims, err := s.signer.Verify(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEmbedToken, err)
+	}
+
+	widget, err := s.GetWidget(ctx, claims.TenantID, claims.Widget)
+	if err != nil {
+		if errors.Is(err, ErrWidgetNotFound) {
+			return nil, fmt.Errorf("%w: widget no longer exists", ErrInvalidEmbedToken)
+		}
+		return nil, err
+	}
+
+	return widget, nil
+}
+
+// validateWidget checks the widget name, bounding box, visible fields and branding.
+func validateWidget(widget *models.EmbedWidget) error {
+	if !identifierPattern.MatchString(widget.Name) {
+		return fmt.Errorf("%w: name must be a lowercase slug of at most 100 characters, got %q", ErrInvalidWidget, widget.Name)
+	}
+
+	bbox := widget.AllowedBBox
+	if bbox.MinLat < MinLatitude || bbox.MaxLat > MaxLatitude || bbox.MinLng < MinLongitude || bbox.MaxLng > MaxLongitude {
+		return fmt.Errorf("%w: allowedBbox must be within WGS84 bounds", ErrInvalidWidget)
+	}
+	if bbox.MinLat >= bbox.MaxLat || bbox.MinLng >= bbox.MaxLng {
+		return fmt.Errorf("%w: allowedBbox min values must be less than max values", ErrInvalidWidget)
+	}
+
+	if len(widget.VisibleFields) == 0 {
+		return fmt.Errorf("%w: visibleFields must include at least one field", ErrInvalidWidget)
+	}
+	for _, field := range widget.VisibleFields {
+		if !WidgetFields[field] {
+			return fmt.Errorf("%w: visibleFields contains unknown field %q", ErrInvalidWidget, field)
+		}
+	}
+
+	branding := widget.Branding
+	if len(branding.Title) > MaxWidgetTitleLength {
+		return fmt.Errorf("%w: branding.title must be at most %d characters", ErrInvalidWidget, MaxWidgetTitleLength)
+	}
+	if branding.PrimaryColor != "" && !colorPattern.MatchString(branding.PrimaryColor) {
+		return fmt.Errorf("%w: branding.primaryColor must be a hex color, got %q", ErrInvalidWidget, branding.PrimaryColor)
+	}
+	if branding.LogoURL != "" {
+		logo, err := url.Parse(branding.LogoURL)
+		if err != nil || logo.Scheme != "https" || logo.Host == "" {
+			return fmt.Errorf("%w: branding.logoUrl must be an absolute https URL", ErrInvalidWidget)
+		}
+	}
+
+	return nil
+}
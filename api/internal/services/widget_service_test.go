@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/embed"
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// MockWidgetRepository is a mock implementation of WidgetRepository for testing
+type MockWidgetRepository struct {
+	mock.Mock
+}
+
+func (m *MockWidgetRepository) List(ctx context.Context, tenantID string) ([]models.EmbedWidget, error) {
+	args := m.Called(ctx, tenantID)
+	widgets, _ := args.Get(0).([]models.EmbedWidget)
+	return widgets, args.Error(1)
+}
+
+func (m *MockWidgetRepository) FindByName(ctx context.Context, tenantID, name string) (*models.EmbedWidget, error) {
+	args := m.Called(ctx, tenantID, name)
+	widget, _ := args.Get(0).(*models.EmbedWidget)
+	return widget, args.Error(1)
+}
+
+func (m *MockWidgetRepository) Upsert(ctx context.Context, widget *models.EmbedWidget) (*models.EmbedWidget, error) {
+	args := m.Called(ctx, widget)
+	stored, _ := args.Get(0).(*models.EmbedWidget)
+	return stored, args.Error(1)
+}
+
+const testEmbedSecret = "0123456789abcdef0123456789abcdef"
+
+// validWidget returns a widget that passes all validation rules.
+func validWidget() *models.EmbedWidget {
+	return &models.EmbedWidget{
+		TenantID:      "acme",
+		Name:          "county-viewer",
+		AllowedBBox:   models.BoundingBox{MinLng: -95.9, MinLat: 30.0, MaxLng: -95.0, MaxLat: 30.6},
+		VisibleFields: []string{"owner_name", "situs_address"},
+		Branding: models.WidgetBranding{
+			Title:        "Acme Parcels",
+			LogoURL:      "https://acme.example.com/logo.png",
+			PrimaryColor: "#004488",
+		},
+	}
+}
+
+func newTestWidgetService(repo *MockWidgetRepository) WidgetService {
+	return NewWidgetService(repo, embed.NewSigner(testEmbedSecret, time.Hour), logger.New("test"))
+}
+
+func TestSaveWidget_ValidationErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mutate  func(w *models.EmbedWidget)
+		errType error
+	}{
+		{"invalid tenant", func(w *models.EmbedWidget) { w.TenantID = "" }, ErrInvalidTenant},
+		{"invalid name", func(w *models.EmbedWidget) { w.Name = "County Viewer" }, ErrInvalidWidget},
+		{"inverted bbox", func(w *models.EmbedWidget) { w.AllowedBBox.MinLat = 31.0 }, ErrInvalidWidget},
+		{"bbox out of range", func(w *models.EmbedWidget) { w.AllowedBBox.MaxLat = 91.0 }, ErrInvalidWidget},
+		{"no visible fields", func(w *models.EmbedWidget) { w.VisibleFields = nil }, ErrInvalidWidget},
+		{"unknown visible field", func(w *models.EmbedWidget) { w.VisibleFields = []string{"owner_address"} }, ErrInvalidWidget},
+		{"bad primary color", func(w *models.EmbedWidget) { w.Branding.PrimaryColor = "blue" }, ErrInvalidWidget},
+		{"insecure logo url", func(w *models.EmbedWidget) { w.Branding.LogoURL = "http://acme.example.com/logo.png" }, ErrInvalidWidget},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockWidgetRepository)
+			service := newTestWidgetService(mockRepo)
+			widget := validWidget()
+			tc.mutate(widget)
+
+			result, err := service.SaveWidget(context.Background(), widget)
+
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tc.errType)
+			mockRepo.AssertNotCalled(t, "Upsert")
+		})
+	}
+}
+
+func TestSaveWidget_Success(t *testing.T) {
+	mockRepo := new(MockWidgetRepository)
+	service := newTestWidgetService(mockRepo)
+
+	ctx := context.Background()
+	widget := validWidget()
+	mockRepo.On("Upsert", ctx, widget).Return(widget, nil)
+
+	result, err := service.SaveWidget(ctx, widget)
+
+	require.NoError(t, err)
+	assert.Equal(t, widget, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestIssueAndResolveEmbedToken(t *testing.T) {
+	mockRepo := new(MockWidgetRepository)
+	service := newTestWidgetService(mockRepo)
+
+	ctx := context.Background()
+	widget := validWidget()
+	mockRepo.On("FindByName", ctx, "acme", "county-viewer").Return(widget, nil)
+
+	grant, err := service.IssueEmbedToken(ctx, "acme", "county-viewer")
+	require.NoError(t, err)
+	assert.NotEmpty(t, grant.Token)
+	assert.True(t, grant.ExpiresAt.After(time.Now()))
+
+	resolved, err := service.ResolveEmbedToken(ctx, grant.Token)
+	require.NoError(t, err)
+	assert.Equal(t, widget, resolved)
+}
+
+func TestIssueEmbedToken_WidgetNotFound(t *testing.T) {
+	mockRepo := new(MockWidgetRepository)
+	service := newTestWidgetService(mockRepo)
+
+	ctx := context.Background()
+	mockRepo.On("FindByName", ctx, "acme", "missing").Return(nil, nil)
+
+	grant, err := service.IssueEmbedToken(ctx, "acme", "missing")
+
+	assert.Nil(t, grant)
+	assert.ErrorIs(t, err, ErrWidgetNotFound)
+}
+
+func TestResolveEmbedToken_Invalid(t *testing.T) {
+	t.Run("forged token", func(t *testing.T) {
+		mockRepo := new(MockWidgetRepository)
+		service := newTestWidgetService(mockRepo)
+
+		forged, _, err := embed.NewSigner("ffffffffffffffffffffffffffffffff", time.Hour).Issue("acme", "county-viewer")
+		require.NoError(t, err)
+
+		widget, err := service.ResolveEmbedToken(context.Background(), forged)
+
+		assert.Nil(t, widget)
+		assert.ErrorIs(t, err, ErrInvalidEmbedToken)
+		assert.ErrorIs(t, err, embed.ErrInvalidSignature)
+		mockRepo.AssertNotCalled(t, "FindByName")
+	})
+
+	t.Run("widget deleted after issue", func(t *testing.T) {
+		mockRepo := new(MockWidgetRepository)
+		service := newTestWidgetService(mockRepo)
+
+		ctx := context.Background()
+		token, _, err := embed.NewSigner(testEmbedSecret, time.Hour).Issue("acme", "county-viewer")
+		require.NoError(t, err)
+		mockRepo.On("FindByName", ctx, "acme", "county-viewer").Return(nil, nil)
+
+		widget, err := service.ResolveEmbedToken(ctx, token)
+
+		assert.Nil(t, widget)
+		assert.ErrorIs(t, err, ErrInvalidEmbedToken)
+	})
+}
@@ -0,0 +1,467 @@
+// Package shapefile writes ESRI Shapefiles holding polygon features in WGS84,
+// packaged as a zip archive of the .shp, .shx, .dbf, .prj and .cpg members that
+// QGIS and ArcGIS open directly. Attributes are stored in a dBASE III table with
+// UTF-8 text, declared by the .cpg member.
+package shapefile
+
+import (
+	"archive/zip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// ContentType is the media type of the zipped shapefile.
+const ContentType = "application/zip"
+
+// Shapefile format values
+const (
+	fileCode     = 9994
+	version      = 1000
+	headerLength = 100
+	// recordHeaderLength is the record number and content length preceding each shape
+	recordHeaderLength = 8
+	shapeNull          = 0
+	shapePolygon       = 5
+	// maxFileWords is the largest file length the header can declare, in 16-bit words
+	maxFileWords = math.MaxInt32
+
+	// wgs84Projection is the ESRI WKT of EPSG:4326 written to the .prj member
+	wgs84Projection = `GEOGCS["GCS_WGS_1984",DATUM["D_WGS_1984",SPHEROID["WGS_1984",6378137.0,298.257223563]],` +
+		`PRIMEM["Greenwich",0.0],UNIT["Degree",0.0174532925199433]]`
+)
+
+// dBASE field limits
+const (
+	// MaxFieldNameLength is the longest dBASE field name; longer names are truncated
+	MaxFieldNameLength = 10
+	// maxTextLength is the width of text fields; longer values are truncated
+	maxTextLength = 254
+	integerLength = 18
+	realLength    = 24
+	realDecimals  = 15
+)
+
+// Errors returned by the writer
+var (
+	ErrFieldMismatch = errors.New("feature values do not match the fields")
+	ErrTooLarge      = errors.New("shapefile exceeds the 4 GB format limit")
+)
+
+// FieldType is the type of an attribute field.
+type FieldType int
+
+// Attribute field types
+const (
+	FieldText FieldType = iota
+	FieldInteger
+	FieldReal
+)
+
+// Field describes an attribute field. Names longer than MaxFieldNameLength
+// are truncated, with a numeric suffix when truncation makes two names equal.
+type Field struct {
+	Name string
+	Type FieldType
+}
+
+// Writer collects features in memory and writes them as a zipped shapefile.
+// Features are numbered in the order they are added.
+type Writer struct {
+	fields []Field
+	names  []string
+	shapes [][]byte
+	rows   [][]any
+	bounds envelope
+}
+
+// NewWriter returns a writer for features with the given attribute fields.
+func NewWriter(fields []Field) *Writer {
+	return &Writer{
+		fields: fields,
+		names:  fieldNames(fields),
+		bounds: emptyEnvelope(),
+	}
+}
+
+// Add adds a feature. values holds one entry per field; nil, string, int, int64
+// and float64 are stored as is, and nil *string, *int and *float64 pointers
+// become blank. A geometry without polygons is written as a null shape.
+// Returns ErrFieldMismatch if the number or types of values do not match the fields.
+func (w *Writer) Add(geometry models.MultiPolygon, values ...any) error {
+	if len(values) != len(w.fields) {
+		return fmt.Errorf("%w: got %d values for %d fields", ErrFieldMismatch, len(values), len(w.fields))
+	}
+
+	row := make([]any, len(values))
+	for i, value := range values {
+		normalized, err := normalizeValue(value)
+		if err != nil {
+			return fmt.Errorf("%w: field %s: %w", ErrFieldMismatch, w.fields[i].Name, err)
+		}
+		row[i] = normalized
+	}
+
+	shape, bounds := encodePolygon(geometry)
+	w.bounds.extend(bounds)
+	w.shapes = append(w.shapes, shape)
+	w.rows = append(w.rows, row)
+	return nil
+}
+
+// Len returns the number of features added.
+func (w *Writer) Len() int {
+	return len(w.shapes)
+}
+
+// WriteZip writes the shapefile members, named base.shp, base.shx and so on,
+// into a zip archive on out.
+// Returns ErrTooLarge if the .shp member would exceed the format's size limit.
+func (w *Writer) WriteZip(out io.Writer, base string) error {
+	shp, shx, err := w.buildShapes()
+	if err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(out)
+	members := []struct {
+		ext  string
+		data []byte
+	}{
+		{ext: ".shp", data: shp},
+		{ext: ".shx", data: shx},
+		{ext: ".dbf", data: w.buildTable(time.Now().UTC())},
+		{ext: ".prj", data: []byte(wgs84Projection)},
+		{ext: ".cpg", data: []byte("UTF-8")},
+	}
+	for _, member := range members {
+		file, err := archive.Create(base + member.ext)
+		if err != nil {
+			return fmt.Errorf("failed to add %s%s: %w", base, member.ext, err)
+		}
+		if _, err := file.Write(member.data); err != nil {
+			return fmt.Errorf("failed to write %s%s: %w", base, member.ext, err)
+		}
+	}
+	return archive.Close()
+}
+
+// buildShapes assembles the .shp file and its .shx index.
+func (w *Writer) buildShapes() (shp, shx []byte, err error) {
+	shpLength := headerLength
+	for _, shape := range w.shapes {
+		shpLength += recordHeaderLength + len(shape)
+	}
+	if shpLength/2 > maxFileWords {
+		return nil, nil, ErrTooLarge
+	}
+	shxLength := headerLength + recordHeaderLength*len(w.shapes)
+
+	shp = w.appendHeader(make([]byte, 0, shpLength), shpLength)
+	shx = w.appendHeader(make([]byte, 0, shxLength), shxLength)
+	for i, shape := range w.shapes {
+		offset := len(shp)
+		shp = binary.BigEndian.AppendUint32(shp, uint32(i+1))          // #nosec G115 -- bounded by maxFileWords
+		shp = binary.BigEndian.AppendUint32(shp, uint32(len(shape)/2)) // #nosec G115 -- bounded by maxFileWords
+		shp = append(shp, shape...)
+
+		shx = binary.BigEndian.AppendUint32(shx, uint32(offset/2))     // #nosec G115 -- bounded by maxFileWords
+		shx = binary.BigEndian.AppendUint32(shx, uint32(len(shape)/2)) // #nosec G115 -- bounded by maxFileWords
+	}
+	return shp, shx, nil
+}
+
+// appendHeader appends the 100-byte main file header shared by .shp and .shx.
+func (w *Writer) appendHeader(buf []byte, length int) []byte {
+	buf = binary.BigEndian.AppendUint32(buf, fileCode)
+	buf = append(buf, make([]byte, 20)...)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(length/2)) // #nosec G115 -- bounded by maxFileWords
+	buf = binary.LittleEndian.AppendUint32(buf, version)
+	buf = binary.LittleEndian.AppendUint32(buf, shapePolygon)
+
+	bounds := w.bounds
+	if bounds.isEmpty() {
+		bounds = envelope{}
+	}
+	for _, v := range []float64{bounds.minX, bounds.minY, bounds.maxX, bounds.maxY} {
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
+	}
+	// Z and M ranges are unused by polygon shapes
+	return append(buf, make([]byte, 32)...)
+}
+
+// encodePolygon encodes the content of a polygon shape record. Shapefiles
+// store outer rings clockwise and holes counter-clockwise, the reverse of
+// RFC 7946 GeoJSON, so rings are reoriented as needed.
+func encodePolygon(geometry models.MultiPolygon) ([]byte, envelope) {
+	var rings [][][2]float64
+	for _, polygon := range geometry.Coordinates {
+		for i, ring := range polygon {
+			if len(ring) == 0 {
+				continue
+			}
+			if clockwise := signedArea(ring) < 0; clockwise != (i == 0) {
+				ring = reversed(ring)
+			}
+			rings = append(rings, ring)
+		}
+	}
+
+	bounds := emptyEnvelope()
+	if len(rings) == 0 {
+		return binary.LittleEndian.AppendUint32(nil, shapeNull), bounds
+	}
+
+	points := 0
+	for _, ring := range rings {
+		points += len(ring)
+		for _, position := range ring {
+			bounds.add(position[0], position[1])
+		}
+	}
+
+	shape := make([]byte, 0, 44+4*len(rings)+16*points)
+	shape = binary.LittleEndian.AppendUint32(shape, shapePolygon)
+	for _, v := range []float64{bounds.minX, bounds.minY, bounds.maxX, bounds.maxY} {
+		shape = binary.LittleEndian.AppendUint64(shape, math.Float64bits(v))
+	}
+	shape = binary.LittleEndian.AppendUint32(shape, uint32(len(rings))) // #nosec G115 -- ring counts fit uint32
+	shape = binary.LittleEndian.AppendUint32(shape, uint32(points))     // #nosec G115 -- point counts fit uint32
+	start := 0
+	for _, ring := range rings {
+		shape = binary.LittleEndian.AppendUint32(shape, uint32(start)) // #nosec G115 -- point counts fit uint32
+		start += len(ring)
+	}
+	for _, ring := range rings {
+		for _, position := range ring {
+			shape = binary.LittleEndian.AppendUint64(shape, math.Float64bits(position[0]))
+			shape = binary.LittleEndian.AppendUint64(shape, math.Float64bits(position[1]))
+		}
+	}
+
+	return shape, bounds
+}
+
+// signedArea returns twice the signed planar area of a ring: positive when
+// the ring runs counter-clockwise.
+func signedArea(ring [][2]float64) float64 {
+	var area float64
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		area += ring[j][0]*ring[i][1] - ring[i][0]*ring[j][1]
+	}
+	return area
+}
+
+// reversed returns a copy of the ring in the opposite direction.
+func reversed(ring [][2]float64) [][2]float64 {
+	out := make([][2]float64, len(ring))
+	for i, position := range ring {
+		out[len(ring)-1-i] = position
+	}
+	return out
+}
+
+// buildTable assembles the dBASE III attribute table, one record per feature.
+func (w *Writer) buildTable(modified time.Time) []byte {
+	widths := w.fieldWidths()
+	recordLength := 1 // Deletion flag
+	for _, width := range widths {
+		recordLength += width
+	}
+	tableHeaderLength := 32 + 32*len(w.fields) + 1
+
+	buf := make([]byte, 0, tableHeaderLength+recordLength*len(w.rows)+1)
+	buf = append(buf, 0x03, byte(modified.Year()-1900), byte(modified.Month()), byte(modified.Day()))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(w.rows)))       // #nosec G115 -- row counts fit uint32
+	buf = binary.LittleEndian.AppendUint16(buf, uint16(tableHeaderLength)) // #nosec G115 -- callers declare a handful of fields
+	buf = binary.LittleEndian.AppendUint16(buf, uint16(recordLength))      // #nosec G115 -- callers declare a handful of fields
+	buf = append(buf, make([]byte, 20)...)
+
+	for i, field := range w.fields {
+		descriptor := make([]byte, 32)
+		copy(descriptor, w.names[i])
+		descriptor[11] = 'C'
+		if field.Type != FieldText {
+			descriptor[11] = 'N'
+		}
+		descriptor[16] = byte(widths[i])
+		if field.Type == FieldReal {
+			descriptor[17] = realDecimals
+		}
+		buf = append(buf, descriptor...)
+	}
+	buf = append(buf, 0x0D)
+
+	for _, row := range w.rows {
+		buf = append(buf, ' ')
+		for i, value := range row {
+			buf = append(buf, formatValue(value, w.fields[i].Type, widths[i])...)
+		}
+	}
+	return append(buf, 0x1A)
+}
+
+// fieldWidths returns the dBASE width of each field. Text fields are as wide
+// as their longest value, up to maxTextLength, so short codes do not pad every
+// record to the maximum.
+func (w *Writer) fieldWidths() []int {
+	widths := make([]int, len(w.fields))
+	for i, field := range w.fields {
+		switch field.Type {
+		case FieldInteger:
+			widths[i] = integerLength
+		case FieldReal:
+			widths[i] = realLength
+		default:
+			widths[i] = 1
+			for _, row := range w.rows {
+				if text, ok := row[i].(string); ok {
+					widths[i] = max(widths[i], min(len(text), maxTextLength))
+				}
+			}
+		}
+	}
+	return widths
+}
+
+// formatValue renders a value as a fixed-width dBASE cell: text left-aligned,
+// numbers right-aligned, and nil blank. Text is cut at a UTF-8 boundary.
+func formatValue(value any, fieldType FieldType, width int) []byte {
+	var text string
+	switch v := value.(type) {
+	case nil:
+	case string:
+		text = truncateUTF8(v, width)
+	case int64:
+		text = strconv.FormatInt(v, 10)
+	case float64:
+		text = formatReal(v, fieldType, width)
+	}
+	if len(text) > width {
+		text = ""
+	}
+
+	cell := make([]byte, width)
+	for i := range cell {
+		cell[i] = ' '
+	}
+	if fieldType == FieldText {
+		copy(cell, text)
+	} else {
+		copy(cell[width-len(text):], text)
+	}
+	return cell
+}
+
+// formatReal formats a float for a numeric field, falling back to exponent
+// notation for values too wide for it. NaN and infinities are blank.
+func formatReal(v float64, fieldType FieldType, width int) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return ""
+	}
+	if fieldType == FieldInteger {
+		return strconv.FormatFloat(math.Round(v), 'f', 0, 64)
+	}
+	if text := strconv.FormatFloat(v, 'f', -1, 64); len(text) <= width {
+		return text
+	}
+	return strconv.FormatFloat(v, 'e', realDecimals-6, 64)
+}
+
+// truncateUTF8 cuts s to at most n bytes without splitting a character.
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// fieldNames truncates field names to MaxFieldNameLength, replacing the end of
+// a truncated name with a number when it would repeat an earlier one, so
+// centroid_lng and centroid_lat become centroid_l and centroid_1.
+func fieldNames(fields []Field) []string {
+	names := make([]string, len(fields))
+	taken := make(map[string]bool, len(fields))
+	for i, field := range fields {
+		name := field.Name
+		if len(name) > MaxFieldNameLength {
+			name = name[:MaxFieldNameLength]
+		}
+		for n := 1; taken[name]; n++ {
+			suffix := strconv.Itoa(n)
+			name = field.Name[:min(len(field.Name), MaxFieldNameLength-len(suffix))] + suffix
+		}
+		taken[name] = true
+		names[i] = name
+	}
+	return names
+}
+
+// normalizeValue converts a feature value to a cell value.
+func normalizeValue(value any) (any, error) {
+	switch v := value.(type) {
+	case nil, string, int64, float64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case *string:
+		if v == nil {
+			return nil, nil
+		}
+		return *v, nil
+	case *int:
+		if v == nil {
+			return nil, nil
+		}
+		return int64(*v), nil
+	case *float64:
+		if v == nil {
+			return nil, nil
+		}
+		return *v, nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", value)
+	}
+}
+
+// envelope is the bounding box of the written geometries.
+type envelope struct {
+	minX, minY, maxX, maxY float64
+}
+
+// emptyEnvelope returns an envelope that any point extends.
+func emptyEnvelope() envelope {
+	return envelope{minX: math.Inf(1), minY: math.Inf(1), maxX: math.Inf(-1), maxY: math.Inf(-1)}
+}
+
+// isEmpty reports whether no point has been added.
+func (e envelope) isEmpty() bool {
+	return e.minX > e.maxX
+}
+
+// add extends the envelope to cover the point.
+func (e *envelope) add(x, y float64) {
+	e.minX = math.Min(e.minX, x)
+	e.minY = math.Min(e.minY, y)
+	e.maxX = math.Max(e.maxX, x)
+	e.maxY = math.Max(e.maxY, y)
+}
+
+// extend extends the envelope to cover another.
+func (e *envelope) extend(other envelope) {
+	if other.isEmpty() {
+		return
+	}
+	e.add(other.minX, other.minY)
+	e.add(other.maxX, other.maxY)
+}
@@ -0,0 +1,179 @@
+package shapefile
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/models"
+)
+
+// square is a counter-clockwise GeoJSON outer ring with a clockwise hole.
+var square = models.MultiPolygon{Coordinates: [][][][2]float64{{
+	{{-95.45, 30.34}, {-95.44, 30.34}, {-95.44, 30.35}, {-95.45, 30.35}, {-95.45, 30.34}},
+	{{-95.448, 30.342}, {-95.448, 30.348}, {-95.442, 30.348}, {-95.442, 30.342}, {-95.448, 30.342}},
+}}}
+
+var testFields = []Field{
+	{Name: "owner_name", Type: FieldText},
+	{Name: "year_built", Type: FieldInteger},
+	{Name: "acres", Type: FieldReal},
+	{Name: "centroid_lng", Type: FieldReal},
+	{Name: "centroid_lat", Type: FieldReal},
+}
+
+// unzip returns the members of a zip archive by name.
+func unzip(t *testing.T, data []byte) map[string][]byte {
+	t.Helper()
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	members := make(map[string][]byte)
+	for _, file := range archive.File {
+		r, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+		members[file.Name] = content
+	}
+	return members
+}
+
+func TestWriteZip(t *testing.T) {
+	yearBuilt := 1998
+	w := NewWriter(testFields)
+	require.NoError(t, w.Add(square, "Test Owner", &yearBuilt, 2.5, -95.445, 30.345))
+	require.NoError(t, w.Add(models.MultiPolygon{}, nil, (*int)(nil), nil, nil, nil))
+	assert.Equal(t, 2, w.Len())
+
+	var buf bytes.Buffer
+	require.NoError(t, w.WriteZip(&buf, "parcels"))
+	members := unzip(t, buf.Bytes())
+
+	require.Len(t, members, 5)
+	assert.Equal(t, wgs84Projection, string(members["parcels.prj"]))
+	assert.Equal(t, "UTF-8", string(members["parcels.cpg"]))
+
+	t.Run("shp", func(t *testing.T) {
+		shp := members["parcels.shp"]
+		assert.Equal(t, uint32(fileCode), binary.BigEndian.Uint32(shp[0:]))
+		assert.Equal(t, uint32(len(shp)/2), binary.BigEndian.Uint32(shp[24:]))
+		assert.Equal(t, uint32(version), binary.LittleEndian.Uint32(shp[28:]))
+		assert.Equal(t, uint32(shapePolygon), binary.LittleEndian.Uint32(shp[32:]))
+		assert.Equal(t, -95.45, math.Float64frombits(binary.LittleEndian.Uint64(shp[36:])))
+		assert.Equal(t, 30.35, math.Float64frombits(binary.LittleEndian.Uint64(shp[60:])))
+
+		// First record: one polygon with its hole, as two parts of five points
+		record := shp[headerLength:]
+		assert.Equal(t, uint32(1), binary.BigEndian.Uint32(record[0:]))
+		content := record[recordHeaderLength:]
+		assert.Equal(t, uint32(shapePolygon), binary.LittleEndian.Uint32(content[0:]))
+		assert.Equal(t, uint32(2), binary.LittleEndian.Uint32(content[36:]))
+		assert.Equal(t, uint32(10), binary.LittleEndian.Uint32(content[40:]))
+		assert.Equal(t, uint32(5), binary.LittleEndian.Uint32(content[48:]))
+
+		points := make([][2]float64, 10)
+		for i := range points {
+			offset := 52 + 16*i
+			points[i] = [2]float64{
+				math.Float64frombits(binary.LittleEndian.Uint64(content[offset:])),
+				math.Float64frombits(binary.LittleEndian.Uint64(content[offset+8:])),
+			}
+		}
+		assert.Less(t, signedArea(points[:5]), 0.0, "outer ring must be clockwise")
+		assert.Greater(t, signedArea(points[5:]), 0.0, "hole must be counter-clockwise")
+
+		// Second record: a null shape
+		second := record[recordHeaderLength+len(content[:52+16*10]):]
+		assert.Equal(t, uint32(2), binary.BigEndian.Uint32(second[0:]))
+		assert.Equal(t, uint32(2), binary.BigEndian.Uint32(second[4:]))
+		assert.Equal(t, uint32(shapeNull), binary.LittleEndian.Uint32(second[8:]))
+	})
+
+	t.Run("shx", func(t *testing.T) {
+		shx := members["parcels.shx"]
+		require.Len(t, shx, headerLength+2*recordHeaderLength)
+		assert.Equal(t, uint32(len(shx)/2), binary.BigEndian.Uint32(shx[24:]))
+		assert.Equal(t, uint32(headerLength/2), binary.BigEndian.Uint32(shx[headerLength:]))
+		assert.Equal(t, uint32((52+16*10)/2), binary.BigEndian.Uint32(shx[headerLength+4:]))
+		assert.Equal(t, uint32((headerLength+recordHeaderLength+52+16*10)/2), binary.BigEndian.Uint32(shx[headerLength+8:]))
+	})
+
+	t.Run("dbf", func(t *testing.T) {
+		dbf := members["parcels.dbf"]
+		assert.Equal(t, byte(0x03), dbf[0])
+		assert.Equal(t, uint32(2), binary.LittleEndian.Uint32(dbf[4:]))
+		tableHeader := int(binary.LittleEndian.Uint16(dbf[8:]))
+		recordLength := int(binary.LittleEndian.Uint16(dbf[10:]))
+		assert.Equal(t, 32+32*len(testFields)+1, tableHeader)
+		assert.Equal(t, 1+len("Test Owner")+integerLength+3*realLength, recordLength)
+		assert.Equal(t, byte(0x0D), dbf[tableHeader-1])
+		assert.Equal(t, byte(0x1A), dbf[len(dbf)-1])
+
+		var names []string
+		for i := range testFields {
+			descriptor := dbf[32+32*i : 64+32*i]
+			names = append(names, strings.TrimRight(string(descriptor[:11]), "\x00"))
+		}
+		assert.Equal(t, []string{"owner_name", "year_built", "acres", "centroid_l", "centroid_1"}, names)
+		assert.Equal(t, byte('C'), dbf[32+11])
+		assert.Equal(t, byte('N'), dbf[64+11])
+		assert.Equal(t, byte(realDecimals), dbf[96+17])
+
+		first := string(dbf[tableHeader : tableHeader+recordLength])
+		fields := strings.Fields(first)
+		assert.Equal(t, []string{"Test", "Owner", "1998", "2.5", "-95.445", "30.345"}, fields)
+		second := dbf[tableHeader+recordLength : tableHeader+2*recordLength]
+		assert.Equal(t, strings.Repeat(" ", recordLength), string(second))
+	})
+}
+
+func TestAdd_FieldMismatch(t *testing.T) {
+	w := NewWriter(testFields)
+	assert.ErrorIs(t, w.Add(square, "too few"), ErrFieldMismatch)
+	assert.ErrorIs(t, w.Add(square, true, 1, 1.0, 1.0, 1.0), ErrFieldMismatch)
+	assert.Equal(t, 0, w.Len())
+}
+
+func TestFieldNames(t *testing.T) {
+	fields := []Field{
+		{Name: "legal_description"},
+		{Name: "legal_description_2"},
+		{Name: "id"},
+		{Name: "id"},
+	}
+	assert.Equal(t, []string{"legal_desc", "legal_des1", "id", "id1"}, fieldNames(fields))
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     any
+		want      string
+		fieldType FieldType
+		width     int
+	}{
+		{name: "text is left aligned", value: "ab", fieldType: FieldText, width: 4, want: "ab  "},
+		{name: "long text is cut", value: "abcdef", fieldType: FieldText, width: 4, want: "abcd"},
+		{name: "text is cut between characters", value: "añb", fieldType: FieldText, width: 2, want: "a "},
+		{name: "integer is right aligned", value: int64(42), fieldType: FieldInteger, width: 5, want: "   42"},
+		{name: "real integer field is rounded", value: 41.6, fieldType: FieldInteger, width: 5, want: "   42"},
+		{name: "real", value: -95.445, fieldType: FieldReal, width: 10, want: "   -95.445"},
+		{name: "wide real uses exponent", value: 1.5e30, fieldType: FieldReal, width: 16, want: " 1.500000000e+30"},
+		{name: "NaN is blank", value: math.NaN(), fieldType: FieldReal, width: 3, want: "   "},
+		{name: "nil is blank", value: nil, fieldType: FieldInteger, width: 3, want: "   "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, string(formatValue(tt.value, tt.fieldType, tt.width)))
+		})
+	}
+}
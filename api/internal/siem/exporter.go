@@ -0,0 +1,362 @@
+package siem
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+	"github.com/stwalsh4118/atlas/api/internal/outbound"
+)
+
+// Export defaults
+const (
+	// DefaultQueueSize bounds the events buffered while the sink is unavailable
+	DefaultQueueSize = 10000
+	// DefaultBatchSize is the most events sent in one request
+	DefaultBatchSize = 500
+	// DefaultFlushInterval is how long events wait before a partial batch is sent
+	DefaultFlushInterval = 5 * time.Second
+	// DefaultMaxRetryDelay caps the delay between attempts to send a failed batch
+	DefaultMaxRetryDelay = 5 * time.Minute
+	// sendTimeout bounds one batch, including the client's own retries
+	sendTimeout = time.Minute
+	// kafkaContentType is the Kafka REST Proxy v2 media type of JSON records
+	kafkaContentType = "application/vnd.kafka.json.v2+json"
+)
+
+// errRejected marks batches the sink refused for good, e.g. with 400 or 401.
+var errRejected = errors.New("sink rejected events")
+
+// Options configures an Exporter.
+type Options struct {
+	Log *logger.Logger
+	// Client sends the batches; its retries and circuit breaker apply to each one
+	Client *outbound.Client
+	// Sink is SinkHTTP or SinkKafka
+	Sink string
+	// URL is the collector endpoint, or the Kafka REST Proxy base URL
+	URL string
+	// Topic is the Kafka topic; records are posted to URL + "/topics/" + Topic
+	Topic string
+	// Format is FormatJSON or FormatCEF
+	Format string
+	// Token is sent as a bearer token when set
+	Token         string
+	QueueSize     int
+	BatchSize     int
+	FlushInterval time.Duration
+	// MaxRetryDelay caps the backoff between attempts to send a failed batch;
+	// the first retry waits FlushInterval
+	MaxRetryDelay time.Duration
+}
+
+// Exporter buffers events and sends them in batches from a background
+// goroutine started by New and stopped by Shutdown. Batches that fail are kept
+// and retried with exponential backoff, in order, so events are delayed rather
+// than lost while the sink is down; events beyond QueueSize are dropped.
+type Exporter struct {
+	opts     Options
+	queue    chan Event
+	flush    chan chan struct{}
+	stop     chan struct{}
+	done     chan struct{}
+	dropped  atomic.Int64
+	stopOnce sync.Once
+
+	// pending, retryAt and retryDelay belong to the run goroutine
+	pending    []Event
+	retryAt    time.Time
+	retryDelay time.Duration
+}
+
+// New creates an Exporter and starts it.
+func New(opts Options) *Exporter {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = DefaultQueueSize
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = DefaultFlushInterval
+	}
+	if opts.MaxRetryDelay <= 0 {
+		opts.MaxRetryDelay = DefaultMaxRetryDelay
+	}
+	e := &Exporter{
+		opts:  opts,
+		queue: make(chan Event, opts.QueueSize),
+		flush: make(chan chan struct{}),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	go e.run()
+	return e
+}
+
+// Publish queues an event without blocking. Safe for concurrent use.
+func (e *Exporter) Publish(event Event) {
+	select {
+	case <-e.stop:
+		e.dropped.Add(1)
+		return
+	default:
+	}
+	select {
+	case e.queue <- event:
+	default:
+		e.dropped.Add(1)
+	}
+}
+
+// Dropped returns how many events were dropped because the buffer was full.
+func (e *Exporter) Dropped() int64 {
+	return e.dropped.Load()
+}
+
+// Flush sends the buffered events, ignoring any retry backoff, and waits until
+// they are sent or have failed, or ctx is done.
+func (e *Exporter) Flush(ctx context.Context) error {
+	sent := make(chan struct{})
+	select {
+	case e.flush <- sent:
+	case <-e.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-sent:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Shutdown makes a last attempt to send the buffered events and stops the
+// exporter. Events published afterwards are dropped.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	e.stopOnce.Do(func() { close(e.stop) })
+	select {
+	case <-e.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run sends full batches at once and partial batches every FlushInterval.
+func (e *Exporter) run() {
+	defer close(e.done)
+	ticker := time.NewTicker(e.opts.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event := <-e.queue:
+			e.buffer(event)
+			if len(e.pending) >= e.opts.BatchSize {
+				e.send(false)
+			}
+		case <-ticker.C:
+			e.drain()
+			e.send(false)
+			if dropped := e.dropped.Swap(0); dropped > 0 {
+				e.warn("SIEM buffer full, events dropped", map[string]interface{}{
+					"dropped": dropped,
+				})
+			}
+		case sent := <-e.flush:
+			e.drain()
+			e.send(true)
+			close(sent)
+		case <-e.stop:
+			e.drain()
+			e.send(true)
+			return
+		}
+	}
+}
+
+// buffer appends an event to the pending events, dropping it if the buffer is full.
+func (e *Exporter) buffer(event Event) {
+	if len(e.pending) >= e.opts.QueueSize {
+		e.dropped.Add(1)
+		return
+	}
+	e.pending = append(e.pending, event)
+}
+
+// drain moves the queued events to the pending events.
+func (e *Exporter) drain() {
+	for {
+		select {
+		case event := <-e.queue:
+			e.buffer(event)
+		default:
+			return
+		}
+	}
+}
+
+// send sends the pending events batch by batch until one fails. Unless force
+// is set, nothing is sent before the backoff of the last failure has passed.
+func (e *Exporter) send(force bool) {
+	if !force && time.Now().Before(e.retryAt) {
+		return
+	}
+
+	for len(e.pending) > 0 {
+		batch := e.pending[:min(len(e.pending), e.opts.BatchSize)]
+		err := e.export(batch)
+		switch {
+		case err == nil:
+			e.retryDelay = 0
+			e.retryAt = time.Time{}
+		case errors.Is(err, errRejected):
+			// Retrying a malformed or unauthorized batch cannot succeed
+			e.error("SIEM sink rejected events, batch dropped", err, len(batch))
+		default:
+			e.retryDelay = min(max(2*e.retryDelay, e.opts.FlushInterval), e.opts.MaxRetryDelay)
+			e.retryAt = time.Now().Add(e.retryDelay)
+			e.warn("Failed to send events to SIEM, retrying", map[string]interface{}{
+				"error":    err.Error(),
+				"events":   len(batch),
+				"buffered": len(e.pending),
+				"retry_in": e.retryDelay.String(),
+			})
+			return
+		}
+		e.pending = e.pending[len(batch):]
+	}
+	// Release the memory of a buffer grown during an outage
+	e.pending = nil
+}
+
+// export sends one batch to the sink.
+// Returns errRejected for 4xx responses other than 408 and 429.
+func (e *Exporter) export(batch []Event) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+
+	req, err := e.request(ctx, batch)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errRejected, err)
+	}
+	resp, err := e.opts.Client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case resp.StatusCode/100 == 2:
+		return nil
+	case resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests:
+		return fmt.Errorf("sink responded %d", resp.StatusCode)
+	case resp.StatusCode/100 == 4:
+		return fmt.Errorf("%w with status %d", errRejected, resp.StatusCode)
+	default:
+		return fmt.Errorf("sink responded %d", resp.StatusCode)
+	}
+}
+
+// request builds the sink request for a batch: newline-delimited JSON objects
+// or CEF lines for SinkHTTP, one record per event for SinkKafka.
+func (e *Exporter) request(ctx context.Context, batch []Event) (*http.Request, error) {
+	endpoint := e.opts.URL
+	var body bytes.Buffer
+	contentType := "application/x-ndjson"
+
+	if e.opts.Sink == SinkKafka {
+		endpoint += "/topics/" + url.PathEscape(e.opts.Topic)
+		contentType = kafkaContentType
+		records, err := e.kafkaRecords(batch)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.NewEncoder(&body).Encode(records); err != nil {
+			return nil, fmt.Errorf("failed to encode records: %w", err)
+		}
+	} else {
+		if e.opts.Format == FormatCEF {
+			contentType = "text/plain; charset=utf-8"
+		}
+		for _, event := range batch {
+			line, err := e.encode(event)
+			if err != nil {
+				return nil, err
+			}
+			body.Write(line)
+			body.WriteByte('\n')
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, &body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build SIEM request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if e.opts.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+e.opts.Token)
+	}
+	return req, nil
+}
+
+// kafkaRecord is a Kafka REST Proxy record: a JSON object, or a CEF line as a JSON string.
+type kafkaRecord struct {
+	Value json.RawMessage `json:"value"`
+}
+
+// kafkaRecords returns the REST Proxy request body of a batch.
+func (e *Exporter) kafkaRecords(batch []Event) (map[string][]kafkaRecord, error) {
+	records := make([]kafkaRecord, 0, len(batch))
+	for _, event := range batch {
+		value, err := e.encode(event)
+		if err != nil {
+			return nil, err
+		}
+		if e.opts.Format == FormatCEF {
+			if value, err = json.Marshal(string(value)); err != nil {
+				return nil, fmt.Errorf("failed to encode record: %w", err)
+			}
+		}
+		records = append(records, kafkaRecord{Value: value})
+	}
+	return map[string][]kafkaRecord{"records": records}, nil
+}
+
+// encode encodes an event in the configured format.
+func (e *Exporter) encode(event Event) ([]byte, error) {
+	if e.opts.Format == FormatCEF {
+		return []byte(EncodeCEF(event)), nil
+	}
+	return EncodeJSON(event)
+}
+
+// warn logs a warning, if the exporter has a logger.
+func (e *Exporter) warn(msg string, fields map[string]interface{}) {
+	if e.opts.Log == nil {
+		return
+	}
+	fields["sink"] = e.opts.Sink
+	e.opts.Log.Warn(msg, fields)
+}
+
+// error logs a dropped batch, if the exporter has a logger.
+func (e *Exporter) error(msg string, err error, events int) {
+	if e.opts.Log == nil {
+		return
+	}
+	e.opts.Log.Error(msg, err, map[string]interface{}{
+		"sink":   e.opts.Sink,
+		"events": events,
+	})
+}
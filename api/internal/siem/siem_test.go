@@ -0,0 +1,222 @@
+package siem
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stwalsh4118/atlas/api/internal/outbound"
+)
+
+var testEvent = Event{
+	Time:      time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	Category:  CategorySecurity,
+	Type:      TypeLockout,
+	ClientIP:  "203.0.113.7",
+	Subject:   "user:a=b@example.com",
+	Route:     "users.login",
+	Detail:    "locked ip:203.0.113.7 for 1m0s",
+	Status:    401,
+	Severity:  8,
+	RequestID: "req-1",
+}
+
+func TestEncodeJSON(t *testing.T) {
+	data, err := EncodeJSON(testEvent)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"time": "2024-05-01T12:00:00Z", "category": "security", "type": "lockout",
+		"client_ip": "203.0.113.7", "subject": "user:a=b@example.com", "route": "users.login",
+		"request_id": "req-1", "detail": "locked ip:203.0.113.7 for 1m0s", "status": 401, "severity": 8
+	}`, string(data))
+}
+
+func TestEncodeCEF(t *testing.T) {
+	assert.Equal(t,
+		`CEF:0|Atlas|Atlas API|1|lockout|Client locked out|8|rt=1714564800000 cat=security src=203.0.113.7 `+
+			`suser=user:a\=b@example.com externalId=req-1 msg=locked ip:203.0.113.7 for 1m0s `+
+			`cs1Label=route cs1=users.login cn1Label=status cn1=401`,
+		EncodeCEF(testEvent))
+
+	t.Run("escaping", func(t *testing.T) {
+		line := EncodeCEF(Event{Type: "a|b\\c", Detail: "line\nbreak\\"})
+		assert.Contains(t, line, `|a\|b\\c|a\|b\\c|0|`)
+		assert.Contains(t, line, `msg=line\nbreak\\`)
+		assert.NotContains(t, line, "\n")
+	})
+}
+
+// collector records the requests of a fake sink, answering with the statuses
+// in order and 200 once they run out.
+type collector struct {
+	mu       sync.Mutex
+	statuses []int
+	bodies   []string
+	headers  []http.Header
+	paths    []string
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.bodies = append(c.bodies, string(body))
+	c.headers = append(c.headers, r.Header.Clone())
+	c.paths = append(c.paths, r.URL.Path)
+	status := http.StatusOK
+	if len(c.statuses) > 0 {
+		status, c.statuses = c.statuses[0], c.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func (c *collector) requests() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.bodies)
+}
+
+// newTestExporter returns an exporter sending to sink without client retries.
+func newTestExporter(t *testing.T, sink *collector, opts Options) *Exporter {
+	t.Helper()
+	server := httptest.NewServer(sink)
+	t.Cleanup(server.Close)
+
+	opts.Client = outbound.NewClient("siem", outbound.Options{MaxRetries: -1, BreakerThreshold: -1})
+	opts.URL = server.URL + opts.URL
+	if opts.FlushInterval == 0 {
+		opts.FlushInterval = time.Hour
+	}
+	e := New(opts)
+	t.Cleanup(func() { _ = e.Shutdown(context.Background()) })
+	return e
+}
+
+func TestExporter_HTTP(t *testing.T) {
+	t.Run("sends newline-delimited JSON in batches", func(t *testing.T) {
+		sink := &collector{}
+		e := newTestExporter(t, sink, Options{Sink: SinkHTTP, Format: FormatJSON, URL: "/ingest", Token: "secret", BatchSize: 2})
+
+		for range 3 {
+			e.Publish(testEvent)
+		}
+		require.NoError(t, e.Flush(context.Background()))
+
+		require.Equal(t, 2, sink.requests())
+		assert.Equal(t, "/ingest", sink.paths[0])
+		assert.Equal(t, "Bearer secret", sink.headers[0].Get("Authorization"))
+		assert.Equal(t, "application/x-ndjson", sink.headers[0].Get("Content-Type"))
+
+		lines := 0
+		scanner := bufio.NewScanner(strings.NewReader(sink.bodies[0]))
+		for scanner.Scan() {
+			var event Event
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+			assert.Equal(t, testEvent.Subject, event.Subject)
+			lines++
+		}
+		assert.Equal(t, 2, lines)
+		assert.Equal(t, 1, strings.Count(sink.bodies[1], "\n"))
+	})
+
+	t.Run("CEF lines", func(t *testing.T) {
+		sink := &collector{}
+		e := newTestExporter(t, sink, Options{Sink: SinkHTTP, Format: FormatCEF})
+
+		e.Publish(testEvent)
+		require.NoError(t, e.Flush(context.Background()))
+
+		require.Equal(t, 1, sink.requests())
+		assert.Equal(t, EncodeCEF(testEvent)+"\n", sink.bodies[0])
+		assert.Empty(t, sink.headers[0].Get("Authorization"))
+	})
+
+	t.Run("failed batches are kept and retried", func(t *testing.T) {
+		sink := &collector{statuses: []int{http.StatusServiceUnavailable}}
+		e := newTestExporter(t, sink, Options{Sink: SinkHTTP, Format: FormatJSON})
+
+		e.Publish(testEvent)
+		require.NoError(t, e.Flush(context.Background()))
+		require.NoError(t, e.Flush(context.Background()))
+
+		require.Equal(t, 2, sink.requests())
+		assert.Equal(t, sink.bodies[0], sink.bodies[1])
+	})
+
+	t.Run("rejected batches are dropped", func(t *testing.T) {
+		sink := &collector{statuses: []int{http.StatusBadRequest}}
+		e := newTestExporter(t, sink, Options{Sink: SinkHTTP, Format: FormatJSON})
+
+		e.Publish(testEvent)
+		require.NoError(t, e.Flush(context.Background()))
+		require.NoError(t, e.Flush(context.Background()))
+
+		assert.Equal(t, 1, sink.requests())
+	})
+
+	t.Run("backoff holds batches until it passes", func(t *testing.T) {
+		sink := &collector{statuses: []int{http.StatusBadGateway}}
+		e := newTestExporter(t, sink, Options{Sink: SinkHTTP, Format: FormatJSON, BatchSize: 1})
+
+		e.Publish(testEvent)
+		require.NoError(t, e.Flush(context.Background()))
+		// A full batch would normally be sent at once; the backoff holds it
+		e.Publish(testEvent)
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, 1, sink.requests())
+
+		require.NoError(t, e.Shutdown(context.Background()))
+		assert.Equal(t, 3, sink.requests())
+	})
+
+	t.Run("events beyond the buffer are dropped", func(t *testing.T) {
+		sink := &collector{statuses: []int{http.StatusServiceUnavailable}}
+		e := newTestExporter(t, sink, Options{Sink: SinkHTTP, Format: FormatJSON, QueueSize: 2, BatchSize: 10})
+
+		e.Publish(testEvent)
+		require.NoError(t, e.Flush(context.Background()))
+		for range 3 {
+			e.Publish(testEvent)
+		}
+		require.NoError(t, e.Flush(context.Background()))
+
+		assert.Equal(t, int64(2), e.Dropped())
+		assert.Equal(t, 2, strings.Count(sink.bodies[1], "\n"))
+	})
+}
+
+func TestExporter_Kafka(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{name: "JSON records", format: FormatJSON, want: `{"records":[{"value":{"time":"2024-05-01T12:00:00Z"`},
+		{name: "CEF records", format: FormatCEF, want: `{"records":[{"value":"CEF:0|Atlas|Atlas API|1|lockout|`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &collector{}
+			e := newTestExporter(t, sink, Options{Sink: SinkKafka, Format: tt.format, URL: "/proxy", Topic: "atlas.audit"})
+
+			e.Publish(testEvent)
+			require.NoError(t, e.Flush(context.Background()))
+
+			require.Equal(t, 1, sink.requests())
+			assert.Equal(t, "/proxy/topics/atlas.audit", sink.paths[0])
+			assert.Equal(t, kafkaContentType, sink.headers[0].Get("Content-Type"))
+			assert.True(t, strings.HasPrefix(sink.bodies[0], tt.want), sink.bodies[0])
+			assert.True(t, json.Valid([]byte(sink.bodies[0])))
+		})
+	}
+}
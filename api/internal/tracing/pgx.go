@@ -0,0 +1,64 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// MaxStatementLength caps the SQL recorded on query spans, in bytes.
+const MaxStatementLength = 4096
+
+// QueryTracer is a pgx.QueryTracer recording a client span per query, with the
+// SQL text but not its arguments, which may hold owner names or addresses.
+// Queries run outside a traced request are not recorded.
+type QueryTracer struct{}
+
+// TraceQueryStart starts the query span.
+func (QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if SpanFromContext(ctx) == nil {
+		return ctx
+	}
+	statement := normalizeStatement(data.SQL)
+	operation := statementOperation(statement)
+	ctx, _ = StartKind(ctx, "db "+operation, KindClient,
+		String("db.system.name", "postgresql"),
+		String("db.operation.name", operation),
+		String("db.query.text", statement),
+	)
+	return ctx
+}
+
+// TraceQueryEnd records the outcome and ends the span started by TraceQueryStart.
+func (QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := SpanFromContext(ctx)
+	if span == nil || span.kind != KindClient {
+		return
+	}
+	// A missing row is an expected result, not a failed query
+	if data.Err != nil && !errors.Is(data.Err, pgx.ErrNoRows) {
+		span.RecordError(data.Err)
+	}
+	span.SetAttributes(Int("db.response.affected_rows", data.CommandTag.RowsAffected()))
+	span.End()
+}
+
+// normalizeStatement collapses whitespace and truncates the statement to MaxStatementLength.
+func normalizeStatement(sql string) string {
+	statement := strings.Join(strings.Fields(sql), " ")
+	if len(statement) > MaxStatementLength {
+		statement = strings.ToValidUTF8(statement[:MaxStatementLength], "")
+	}
+	return statement
+}
+
+// statementOperation returns the leading keyword of a statement, e.g. SELECT or WITH.
+func statementOperation(statement string) string {
+	operation, _, _ := strings.Cut(statement, " ")
+	if operation == "" {
+		return "QUERY"
+	}
+	return strings.ToUpper(operation)
+}
@@ -0,0 +1,383 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/stwalsh4118/atlas/api/internal/logger"
+)
+
+// Export defaults
+const (
+	// DefaultQueueSize bounds the spans waiting for export; further spans are dropped
+	DefaultQueueSize = 2048
+	// DefaultBatchSize is the most spans sent in one request
+	DefaultBatchSize = 512
+	// DefaultFlushInterval is how long spans wait before a partial batch is sent
+	DefaultFlushInterval = 5 * time.Second
+	// exportTimeout bounds one export request
+	exportTimeout = 10 * time.Second
+	// tracesPath is the OTLP/HTTP path of trace exports below the endpoint
+	tracesPath = "/v1/traces"
+)
+
+// Options configures a Tracer.
+type Options struct {
+	Log *logger.Logger
+	// Headers are sent with every export, e.g. collector API keys
+	Headers map[string]string
+	// Endpoint is the OTLP/HTTP base URL; spans are posted to Endpoint + "/v1/traces"
+	Endpoint    string
+	ServiceName string
+	// SampleRatio is the fraction of new traces recorded; requests continuing a
+	// trace follow the caller's sampling decision
+	SampleRatio   float64
+	QueueSize     int
+	BatchSize     int
+	FlushInterval time.Duration
+}
+
+// Tracer starts server spans and exports finished spans in batches from a
+// background goroutine started by New and stopped by Shutdown.
+type Tracer struct {
+	opts     Options
+	client   *http.Client
+	queue    chan *Span
+	flush    chan chan struct{}
+	stop     chan struct{}
+	done     chan struct{}
+	dropped  atomic.Int64
+	stopOnce sync.Once
+}
+
+// New creates a Tracer and starts its exporter.
+func New(opts Options) *Tracer {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = DefaultQueueSize
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = DefaultFlushInterval
+	}
+	t := &Tracer{
+		opts:   opts,
+		client: &http.Client{Timeout: exportTimeout},
+		queue:  make(chan *Span, opts.QueueSize),
+		flush:  make(chan chan struct{}),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go t.run()
+	return t
+}
+
+// StartServer starts the server span of an incoming request, continuing the
+// trace of a valid traceparent header. It returns ctx and nil when the trace is
+// not sampled.
+func (t *Tracer) StartServer(ctx context.Context, name, traceparent string, attributes ...Attribute) (context.Context, *Span) {
+	span := &Span{
+		tracer:     t,
+		name:       name,
+		kind:       KindServer,
+		spanID:     newSpanID(),
+		start:      time.Now(),
+		attributes: attributes,
+	}
+	if traceID, parentID, sampled, ok := parseTraceparent(traceparent); ok {
+		if !sampled {
+			return ctx, nil
+		}
+		span.traceID, span.parentID = traceID, parentID
+	} else {
+		span.traceID = newTraceID()
+		if !sampledByRatio(span.traceID, t.opts.SampleRatio) {
+			return ctx, nil
+		}
+	}
+	return ContextWithSpan(ctx, span), span
+}
+
+// Dropped returns how many spans were dropped because the export queue was full.
+func (t *Tracer) Dropped() int64 {
+	return t.dropped.Load()
+}
+
+// Flush exports the queued spans and waits until they are sent or ctx is done.
+func (t *Tracer) Flush(ctx context.Context) error {
+	sent := make(chan struct{})
+	select {
+	case t.flush <- sent:
+	case <-t.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-sent:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Shutdown exports the queued spans and stops the exporter. Spans ended
+// afterwards are dropped.
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	t.stopOnce.Do(func() { close(t.stop) })
+	select {
+	case <-t.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// enqueue queues an ended span without blocking the request.
+func (t *Tracer) enqueue(span *Span) {
+	select {
+	case <-t.stop:
+		t.dropped.Add(1)
+		return
+	default:
+	}
+	select {
+	case t.queue <- span:
+	default:
+		t.dropped.Add(1)
+	}
+}
+
+// run sends full batches at once and partial batches every FlushInterval.
+func (t *Tracer) run() {
+	defer close(t.done)
+	ticker := time.NewTicker(t.opts.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*Span, 0, t.opts.BatchSize)
+	send := func() {
+		if len(batch) > 0 {
+			t.export(batch)
+			batch = batch[:0]
+		}
+	}
+	drain := func() {
+		for {
+			select {
+			case span := <-t.queue:
+				batch = append(batch, span)
+				if len(batch) == t.opts.BatchSize {
+					send()
+				}
+			default:
+				send()
+				return
+			}
+		}
+	}
+
+	for {
+		select {
+		case span := <-t.queue:
+			batch = append(batch, span)
+			if len(batch) == t.opts.BatchSize {
+				send()
+			}
+		case <-ticker.C:
+			send()
+		case sent := <-t.flush:
+			drain()
+			close(sent)
+		case <-t.stop:
+			drain()
+			return
+		}
+	}
+}
+
+// export posts a batch to the collector. Failures are logged and the batch is
+// dropped; traces are diagnostics and not worth holding requests for.
+func (t *Tracer) export(batch []*Span) {
+	body, err := json.Marshal(t.request(batch))
+	if err != nil {
+		t.logWarn("Failed to encode spans", err, len(batch))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.opts.Endpoint+tracesPath, bytes.NewReader(body))
+	if err != nil {
+		t.logWarn("Failed to build span export request", err, len(batch))
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range t.opts.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		t.logWarn("Failed to export spans", err, len(batch))
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode/100 != 2 {
+		t.logWarn("Failed to export spans", fmt.Errorf("collector responded %s", resp.Status), len(batch))
+	}
+}
+
+// logWarn logs an export failure.
+func (t *Tracer) logWarn(msg string, err error, spans int) {
+	if t.opts.Log == nil {
+		return
+	}
+	t.opts.Log.Warn(msg, map[string]interface{}{
+		"error":    err.Error(),
+		"spans":    spans,
+		"endpoint": t.opts.Endpoint,
+	})
+}
+
+// OTLP/JSON request shapes; IDs are hex and 64-bit integers are decimal strings.
+type (
+	otlpRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpResource struct {
+		Attributes []otlpAttribute `json:"attributes"`
+	}
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+	otlpSpan struct {
+		Status            *otlpStatus     `json:"status,omitempty"`
+		TraceID           string          `json:"traceId"`
+		SpanID            string          `json:"spanId"`
+		ParentSpanID      string          `json:"parentSpanId,omitempty"`
+		Name              string          `json:"name"`
+		StartTimeUnixNano string          `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+		Attributes        []otlpAttribute `json:"attributes,omitempty"`
+		Kind              SpanKind        `json:"kind"`
+	}
+	otlpStatus struct {
+		Message string `json:"message,omitempty"`
+		// Code 2 is STATUS_CODE_ERROR
+		Code int `json:"code"`
+	}
+	otlpAttribute struct {
+		Value otlpValue `json:"value"`
+		Key   string    `json:"key"`
+	}
+	otlpValue struct {
+		StringValue *string  `json:"stringValue,omitempty"`
+		IntValue    *string  `json:"intValue,omitempty"`
+		DoubleValue *float64 `json:"doubleValue,omitempty"`
+		BoolValue   *bool    `json:"boolValue,omitempty"`
+	}
+)
+
+// instrumentationScope names the code that produced the spans.
+const instrumentationScope = "github.com/stwalsh4118/atlas/api/internal/tracing"
+
+// request builds the OTLP export request of a batch.
+func (t *Tracer) request(batch []*Span) otlpRequest {
+	spans := make([]otlpSpan, 0, len(batch))
+	for _, s := range batch {
+		s.mu.Lock()
+		span := otlpSpan{
+			TraceID:           hex.EncodeToString(s.traceID[:]),
+			SpanID:            hex.EncodeToString(s.spanID[:]),
+			Name:              s.name,
+			Kind:              s.kind,
+			StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+			Attributes:        otlpAttributes(s.attributes),
+		}
+		if s.parentID != [8]byte{} {
+			span.ParentSpanID = hex.EncodeToString(s.parentID[:])
+		}
+		if s.failed {
+			span.Status = &otlpStatus{Code: 2, Message: s.errorMessage}
+		}
+		s.mu.Unlock()
+		spans = append(spans, span)
+	}
+
+	return otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: otlpAttributes([]Attribute{String("service.name", t.opts.ServiceName)})},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: instrumentationScope}, Spans: spans}},
+	}}}
+}
+
+// otlpAttributes converts attributes; values of other types are formatted as strings.
+func otlpAttributes(attributes []Attribute) []otlpAttribute {
+	out := make([]otlpAttribute, 0, len(attributes))
+	for _, a := range attributes {
+		var v otlpValue
+		switch value := a.Value.(type) {
+		case string:
+			v.StringValue = &value
+		case int64:
+			s := strconv.FormatInt(value, 10)
+			v.IntValue = &s
+		case float64:
+			v.DoubleValue = &value
+		case bool:
+			v.BoolValue = &value
+		default:
+			s := fmt.Sprint(value)
+			v.StringValue = &s
+		}
+		out = append(out, otlpAttribute{Key: a.Key, Value: v})
+	}
+	return out
+}
+
+// ErrInvalidHeaders is returned by ParseHeaders for malformed header lists.
+var ErrInvalidHeaders = errors.New("invalid OTLP headers")
+
+// ParseHeaders reads the OTEL_EXPORTER_OTLP_HEADERS format: comma-separated
+// name=value pairs with URL-encoded values, e.g. "api-key=abc%3D,x-team=gis".
+func ParseHeaders(raw string) (map[string]string, error) {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%w: %q is not name=value", ErrInvalidHeaders, strings.TrimSpace(pair))
+		}
+		decoded, err := url.QueryUnescape(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("%w: value of %s: %w", ErrInvalidHeaders, name, err)
+		}
+		headers[name] = decoded
+	}
+	return headers, nil
+}
@@ -0,0 +1,228 @@
+// Package tracing records spans of request handling, service calls and SQL
+// queries and exports them to an OpenTelemetry collector over OTLP/HTTP with
+// JSON encoding. Spans are carried in the context: the tracing middleware
+// starts a server span per request, and Start adds children to it. Without a
+// span in the context, Start returns a nil span whose methods do nothing, so
+// background work and disabled tracing cost nothing.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TraceparentHeader is the W3C Trace Context header continued by server spans.
+const TraceparentHeader = "traceparent"
+
+// SpanKind is the OTLP kind of a span.
+type SpanKind int
+
+// Span kinds, numbered as in OTLP
+const (
+	KindInternal SpanKind = 1
+	KindServer   SpanKind = 2
+	KindClient   SpanKind = 3
+)
+
+// Attribute is a key and a string, int64, float64 or bool value.
+type Attribute struct {
+	Value any
+	Key   string
+}
+
+// String returns a string attribute.
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int returns an integer attribute.
+func Int(key string, value int64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Float returns a floating point attribute.
+func Float(key string, value float64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Bool returns a boolean attribute.
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Span is a timed operation of a trace. A nil *Span is valid and ignores every call.
+type Span struct {
+	start        time.Time
+	end          time.Time
+	tracer       *Tracer
+	name         string
+	errorMessage string
+	attributes   []Attribute
+	kind         SpanKind
+	mu           sync.Mutex
+	traceID      [16]byte
+	spanID       [8]byte
+	parentID     [8]byte
+	failed       bool
+	ended        atomic.Bool
+}
+
+// spanKey is the context key of the current span.
+type spanKey struct{}
+
+// ContextWithSpan returns ctx carrying span as the current span.
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext returns the current span of ctx, or nil.
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// Start starts an internal span as a child of the current span of ctx and
+// returns a context carrying it. Without a current span it returns ctx and nil.
+// Callers end the span with defer span.End().
+func Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, *Span) {
+	return StartKind(ctx, name, KindInternal, attributes...)
+}
+
+// StartKind is Start with the given span kind, e.g. KindClient for database queries.
+func StartKind(ctx context.Context, name string, kind SpanKind, attributes ...Attribute) (context.Context, *Span) {
+	parent := SpanFromContext(ctx)
+	if parent == nil {
+		return ctx, nil
+	}
+	span := &Span{
+		tracer:     parent.tracer,
+		name:       name,
+		kind:       kind,
+		traceID:    parent.traceID,
+		parentID:   parent.spanID,
+		spanID:     newSpanID(),
+		start:      time.Now(),
+		attributes: attributes,
+	}
+	return ContextWithSpan(ctx, span), span
+}
+
+// SetAttributes adds attributes to the span.
+func (s *Span) SetAttributes(attributes ...Attribute) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attributes = append(s.attributes, attributes...)
+}
+
+// SetName replaces the span name, e.g. once the request's route is known.
+func (s *Span) SetName(name string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.name = name
+}
+
+// RecordError marks the span failed with the error's message. Nil errors are ignored.
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.SetError(err.Error())
+}
+
+// SetError marks the span failed with message.
+func (s *Span) SetError(message string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failed = true
+	s.errorMessage = message
+}
+
+// End records the end time and queues the span for export. Later calls do nothing.
+func (s *Span) End() {
+	if s == nil || s.ended.Swap(true) {
+		return
+	}
+	s.mu.Lock()
+	s.end = time.Now()
+	s.mu.Unlock()
+	s.tracer.enqueue(s)
+}
+
+// TraceID returns the hex trace ID, or "" for a nil span.
+func (s *Span) TraceID() string {
+	if s == nil {
+		return ""
+	}
+	return hex.EncodeToString(s.traceID[:])
+}
+
+// Traceparent returns the W3C traceparent value naming the span as parent.
+func (s *Span) Traceparent() string {
+	if s == nil {
+		return ""
+	}
+	return fmt.Sprintf("00-%s-%s-01", s.TraceID(), hex.EncodeToString(s.spanID[:]))
+}
+
+// parseTraceparent reads a version 00 traceparent header. ok is false for
+// missing or malformed headers, which start a new trace.
+func parseTraceparent(header string) (traceID [16]byte, parentID [8]byte, sampled, ok bool) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) != 4 || parts[0] != "00" || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return traceID, parentID, false, false
+	}
+	if _, err := hex.Decode(traceID[:], []byte(parts[1])); err != nil || traceID == [16]byte{} {
+		return traceID, parentID, false, false
+	}
+	if _, err := hex.Decode(parentID[:], []byte(parts[2])); err != nil || parentID == [8]byte{} {
+		return traceID, parentID, false, false
+	}
+	var flags [1]byte
+	if _, err := hex.Decode(flags[:], []byte(parts[3])); err != nil {
+		return traceID, parentID, false, false
+	}
+	return traceID, parentID, flags[0]&0x01 == 1, true
+}
+
+// newTraceID returns a random trace ID.
+func newTraceID() [16]byte {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+// newSpanID returns a random span ID.
+func newSpanID() [8]byte {
+	var id [8]byte
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+// sampledByRatio reports whether a new trace is kept at ratio, deciding by the
+// trace ID so every instance makes the same choice for a trace.
+func sampledByRatio(traceID [16]byte, ratio float64) bool {
+	if ratio >= 1 {
+		return true
+	}
+	if ratio <= 0 {
+		return false
+	}
+	// Top 63 bits of the trace ID's second half, compared as in OpenTelemetry's TraceIDRatioBased
+	return binary.BigEndian.Uint64(traceID[8:])>>1 < uint64(ratio*(1<<63))
+}
@@ -0,0 +1,259 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// collector is an OTLP/HTTP endpoint recording the exported requests.
+type collector struct {
+	server   *httptest.Server
+	requests []otlpRequest
+	headers  []http.Header
+	mu       sync.Mutex
+}
+
+func newCollector(t *testing.T) *collector {
+	c := &collector{}
+	c.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, tracesPath, r.URL.Path)
+		var req otlpRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		c.mu.Lock()
+		c.requests = append(c.requests, req)
+		c.headers = append(c.headers, r.Header.Clone())
+		c.mu.Unlock()
+	}))
+	t.Cleanup(c.server.Close)
+	return c
+}
+
+// spans returns the exported spans by name.
+func (c *collector) spans() map[string]otlpSpan {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	spans := make(map[string]otlpSpan)
+	for _, req := range c.requests {
+		for _, rs := range req.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				for _, s := range ss.Spans {
+					spans[s.Name] = s
+				}
+			}
+		}
+	}
+	return spans
+}
+
+func TestTracer_Export(t *testing.T) {
+	c := newCollector(t)
+	tracer := New(Options{
+		Endpoint:    c.server.URL,
+		ServiceName: "atlas-test",
+		Headers:     map[string]string{"api-key": "secret"},
+		SampleRatio: 1,
+	})
+
+	ctx, server := tracer.StartServer(context.Background(), "GET /parcels/:id", "", String("http.request.method", "GET"))
+	require.NotNil(t, server)
+	_, child := Start(ctx, "ParcelService.GetParcelByID", Int("parcel.id", 7))
+	child.RecordError(errors.New("boom"))
+	child.End()
+	server.End()
+	server.End()
+
+	require.NoError(t, tracer.Shutdown(context.Background()))
+
+	spans := c.spans()
+	require.Len(t, spans, 2, "a span ended twice is exported once")
+	root, service := spans["GET /parcels/:id"], spans["ParcelService.GetParcelByID"]
+	assert.Equal(t, server.TraceID(), root.TraceID)
+	assert.Empty(t, root.ParentSpanID)
+	assert.Equal(t, KindServer, root.Kind)
+	assert.Nil(t, root.Status)
+	assert.Equal(t, root.TraceID, service.TraceID)
+	assert.Equal(t, root.SpanID, service.ParentSpanID)
+	assert.Equal(t, KindInternal, service.Kind)
+	require.NotNil(t, service.Status)
+	assert.Equal(t, 2, service.Status.Code)
+	assert.Equal(t, "boom", service.Status.Message)
+	require.Len(t, service.Attributes, 1)
+	assert.Equal(t, "parcel.id", service.Attributes[0].Key)
+	assert.Equal(t, "7", *service.Attributes[0].Value.IntValue)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	assert.Equal(t, "secret", c.headers[0].Get("api-key"))
+	assert.Equal(t, "service.name", c.requests[0].ResourceSpans[0].Resource.Attributes[0].Key)
+	assert.Equal(t, "atlas-test", *c.requests[0].ResourceSpans[0].Resource.Attributes[0].Value.StringValue)
+}
+
+func TestTracer_Flush(t *testing.T) {
+	c := newCollector(t)
+	tracer := New(Options{Endpoint: c.server.URL, SampleRatio: 1, FlushInterval: time.Hour})
+	defer func() { _ = tracer.Shutdown(context.Background()) }()
+
+	_, span := tracer.StartServer(context.Background(), "GET /health", "")
+	span.End()
+
+	require.NoError(t, tracer.Flush(context.Background()))
+	assert.Len(t, c.spans(), 1)
+}
+
+func TestTracer_ShutdownDropsLateSpans(t *testing.T) {
+	c := newCollector(t)
+	tracer := New(Options{Endpoint: c.server.URL, SampleRatio: 1})
+
+	_, span := tracer.StartServer(context.Background(), "GET /health", "")
+	require.NoError(t, tracer.Shutdown(context.Background()))
+	span.End()
+
+	assert.Equal(t, int64(1), tracer.Dropped())
+	assert.NoError(t, tracer.Shutdown(context.Background()), "shutdown is idempotent")
+}
+
+func TestTracer_StartServerSampling(t *testing.T) {
+	tracer := New(Options{Endpoint: "http://localhost:0", SampleRatio: 0})
+	defer func() { _ = tracer.Shutdown(context.Background()) }()
+
+	_, span := tracer.StartServer(context.Background(), "GET /", "")
+	assert.Nil(t, span, "new traces follow the ratio")
+
+	_, span = tracer.StartServer(context.Background(), "GET /", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	require.NotNil(t, span, "sampled callers are followed")
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.TraceID())
+	assert.True(t, strings.HasPrefix(span.Traceparent(), "00-4bf92f3577b34da6a3ce929d0e0e4736-"))
+	assert.NotContains(t, span.Traceparent(), "00f067aa0ba902b7")
+
+	_, span = tracer.StartServer(context.Background(), "GET /", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	assert.Nil(t, span, "unsampled callers are followed")
+}
+
+func TestParseTraceparent(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		sampled bool
+		ok      bool
+	}{
+		{name: "sampled", header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", sampled: true, ok: true},
+		{name: "not sampled", header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", ok: true},
+		{name: "empty", header: ""},
+		{name: "unknown version", header: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		{name: "zero trace ID", header: "00-00000000000000000000000000000000-00f067aa0ba902b7-01"},
+		{name: "zero parent ID", header: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01"},
+		{name: "not hex", header: "00-4bf92f3577b34da6a3ce929d0e0e473z-00f067aa0ba902b7-01"},
+		{name: "short", header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, sampled, ok := parseTraceparent(tt.header)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.sampled, sampled)
+		})
+	}
+}
+
+func TestSampledByRatio(t *testing.T) {
+	kept := 0
+	for i := 0; i < 10000; i++ {
+		if sampledByRatio(newTraceID(), 0.25) {
+			kept++
+		}
+	}
+	assert.InDelta(t, 2500, kept, 300)
+
+	id := newTraceID()
+	assert.True(t, sampledByRatio(id, 1))
+	assert.False(t, sampledByRatio(id, 0))
+}
+
+func TestNilSpan(t *testing.T) {
+	ctx, span := Start(context.Background(), "untraced")
+	assert.Nil(t, span)
+	assert.Nil(t, SpanFromContext(ctx))
+
+	// Every method of a nil span is a no-op
+	span.SetAttributes(String("k", "v"))
+	span.SetName("renamed")
+	span.RecordError(errors.New("boom"))
+	span.SetError("boom")
+	span.End()
+	assert.Empty(t, span.TraceID())
+	assert.Empty(t, span.Traceparent())
+}
+
+func TestQueryTracer(t *testing.T) {
+	c := newCollector(t)
+	tracer := New(Options{Endpoint: c.server.URL, SampleRatio: 1})
+	var qt QueryTracer
+
+	// Untraced queries are not recorded
+	ctx := qt.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+	assert.Nil(t, SpanFromContext(ctx))
+	qt.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+
+	ctx, server := tracer.StartServer(context.Background(), "GET /parcels", "")
+	queryCtx := qt.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{
+		SQL:  "update parcels\n\t SET owner_name = $1\n WHERE id = $2",
+		Args: []any{"Jane Doe", 7},
+	})
+	qt.TraceQueryEnd(queryCtx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("UPDATE 1")})
+	missingCtx := qt.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "SELECT * FROM parcels WHERE id = $1"})
+	qt.TraceQueryEnd(missingCtx, nil, pgx.TraceQueryEndData{Err: pgx.ErrNoRows})
+	server.End()
+	require.NoError(t, tracer.Shutdown(context.Background()))
+
+	spans := c.spans()
+	update := spans["db UPDATE"]
+	assert.Equal(t, KindClient, update.Kind)
+	assert.Equal(t, server.TraceID(), update.TraceID)
+	attributes := make(map[string]otlpValue)
+	for _, a := range update.Attributes {
+		attributes[a.Key] = a.Value
+	}
+	assert.Equal(t, "postgresql", *attributes["db.system.name"].StringValue)
+	assert.Equal(t, "UPDATE", *attributes["db.operation.name"].StringValue)
+	assert.Equal(t, "update parcels SET owner_name = $1 WHERE id = $2", *attributes["db.query.text"].StringValue)
+	assert.Equal(t, "1", *attributes["db.response.affected_rows"].IntValue)
+	body, _ := json.Marshal(update)
+	assert.NotContains(t, string(body), "Jane Doe", "arguments are not recorded")
+	assert.Nil(t, spans["db SELECT"].Status, "no rows is not an error")
+}
+
+func TestNormalizeStatement(t *testing.T) {
+	long := "SELECT '" + strings.Repeat("é", MaxStatementLength) + "'"
+	statement := normalizeStatement(long)
+	assert.LessOrEqual(t, len(statement), MaxStatementLength)
+	assert.True(t, strings.HasPrefix(statement, "SELECT 'é"))
+	assert.NotContains(t, statement, "�")
+	assert.Equal(t, "QUERY", statementOperation(normalizeStatement(" \n ")))
+	assert.Equal(t, "WITH", statementOperation("with x AS (SELECT 1) SELECT * FROM x"))
+}
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := ParseHeaders(" api-key = abc%3D ,x-team=gis,,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"api-key": "abc=", "x-team": "gis"}, headers)
+
+	headers, err = ParseHeaders("")
+	require.NoError(t, err)
+	assert.Empty(t, headers)
+
+	_, err = ParseHeaders("api-key")
+	assert.ErrorIs(t, err, ErrInvalidHeaders)
+	_, err = ParseHeaders("api-key=%zz")
+	assert.ErrorIs(t, err, ErrInvalidHeaders)
+}
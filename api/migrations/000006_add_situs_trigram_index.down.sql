@@ -0,0 +1,4 @@
+-- Drop the situs trigram index
+-- The pg_trgm extension is left installed since other objects may depend on it
+
+DROP INDEX IF EXISTS idx_parcels_situs_trgm;
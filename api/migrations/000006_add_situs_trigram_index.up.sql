@@ -0,0 +1,9 @@
+-- Enable trigram matching for fuzzy address (situs) search
+-- pg_trgm ships with PostgreSQL contrib and provides similarity() / word_similarity()
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- GIN trigram index on the upper-cased situs address
+-- Supports the "<%" word-similarity operator used by the search-address endpoint
+CREATE INDEX idx_parcels_situs_trgm ON tax_parcels USING GIN (upper(situs) gin_trgm_ops);
+
+COMMENT ON INDEX idx_parcels_situs_trgm IS 'GIN trigram index for fuzzy situs address search';
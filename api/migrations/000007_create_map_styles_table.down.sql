@@ -0,0 +1,3 @@
+-- Drop map_styles table
+
+DROP TABLE IF EXISTS map_styles;
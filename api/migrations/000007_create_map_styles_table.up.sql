@@ -0,0 +1,21 @@
+-- Create map_styles table for saved layer style configurations
+-- Styles are scoped per tenant so multiple embedding applications can share cartography
+
+CREATE TABLE map_styles (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+
+    -- Layer configuration (fill colors by land use, label rules, zoom visibility)
+    config JSONB NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+
+    CONSTRAINT uq_map_styles_tenant_name UNIQUE (tenant_id, name)
+);
+
+COMMENT ON TABLE map_styles IS 'Named layer style configurations per tenant';
+COMMENT ON COLUMN map_styles.config IS 'JSON layer style: fill colors, label rules, visibility by zoom';
@@ -0,0 +1,3 @@
+-- Drop embed_widgets table
+
+DROP TABLE IF EXISTS embed_widgets;
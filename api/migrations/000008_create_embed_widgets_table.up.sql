@@ -0,0 +1,30 @@
+-- Create embed_widgets table for embeddable parcel viewer configurations
+-- Each widget restricts partners to a bounding box and a subset of parcel fields
+
+CREATE TABLE embed_widgets (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+
+    -- Allowed area (SRID 4326)
+    min_lng DOUBLE PRECISION NOT NULL,
+    min_lat DOUBLE PRECISION NOT NULL,
+    max_lng DOUBLE PRECISION NOT NULL,
+    max_lat DOUBLE PRECISION NOT NULL,
+
+    -- Parcel response fields exposed to the widget
+    visible_fields TEXT[] NOT NULL DEFAULT '{}',
+
+    -- Title, logo and colors shown by the viewer script
+    branding JSONB NOT NULL DEFAULT '{}',
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+
+    CONSTRAINT uq_embed_widgets_tenant_name UNIQUE (tenant_id, name),
+    CONSTRAINT chk_embed_widgets_bbox CHECK (min_lng < max_lng AND min_lat < max_lat)
+);
+
+COMMENT ON TABLE embed_widgets IS 'Embeddable parcel viewer configurations per tenant';
+COMMENT ON COLUMN embed_widgets.visible_fields IS 'Parcel DTO field names exposed through embed tokens';
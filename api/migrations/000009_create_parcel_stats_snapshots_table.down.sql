@@ -0,0 +1,3 @@
+-- Drop parcel_stats_snapshots table
+
+DROP TABLE IF EXISTS parcel_stats_snapshots;
@@ -0,0 +1,26 @@
+-- Create parcel_stats_snapshots table for precomputed daily dataset statistics
+-- The ops dashboard reads these rows instead of aggregating tax_parcels live
+
+CREATE TABLE parcel_stats_snapshots (
+    snapshot_date DATE NOT NULL,
+    county_name VARCHAR(100) NOT NULL,
+
+    -- Aggregates over tax_parcels for the county at capture time
+    parcel_count BIGINT NOT NULL,
+    total_acres DOUBLE PRECISION NOT NULL,
+    median_acres DOUBLE PRECISION,
+    median_main_area DOUBLE PRECISION,
+    new_construction_count BIGINT NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT NOW(),
+
+    PRIMARY KEY (snapshot_date, county_name)
+);
+
+-- Time series queries filter by county then scan a date range
+CREATE INDEX idx_parcel_stats_snapshots_county_date ON parcel_stats_snapshots (county_name, snapshot_date);
+
+COMMENT ON TABLE parcel_stats_snapshots IS 'Daily per-county parcel statistics for dashboards';
+COMMENT ON COLUMN parcel_stats_snapshots.median_main_area IS 'Median improvement main area (sq ft) of improved parcels';
+COMMENT ON COLUMN parcel_stats_snapshots.new_construction_count IS 'Parcels whose improvement was built in the snapshot year';
@@ -0,0 +1,3 @@
+-- Drop ingestion_runs table
+
+DROP TABLE IF EXISTS ingestion_runs;
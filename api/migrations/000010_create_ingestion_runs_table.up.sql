@@ -0,0 +1,31 @@
+-- Create ingestion_runs table recording metrics for every parcel import
+-- Activated runs form the historical baseline that new imports are compared against
+
+CREATE TABLE ingestion_runs (
+    id BIGSERIAL PRIMARY KEY,
+    county VARCHAR(100) NOT NULL,
+    source_file TEXT NOT NULL,
+
+    -- Metrics measured on the staged data before activation
+    record_count BIGINT NOT NULL,
+    total_acres DOUBLE PRECISION NOT NULL,
+    owner_name_null_rate DOUBLE PRECISION,
+    situs_null_rate DOUBLE PRECISION,
+    year_built_null_rate DOUBLE PRECISION,
+
+    -- Outcome: activated runs replaced tax_parcels, blocked runs were rejected by anomaly checks
+    status VARCHAR(20) NOT NULL,
+    anomalies TEXT[] NOT NULL DEFAULT '{}',
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT NOW(),
+
+    CONSTRAINT chk_ingestion_runs_status CHECK (status IN ('activated', 'blocked', 'forced'))
+);
+
+-- Baseline lookups fetch the latest activated runs per county
+CREATE INDEX idx_ingestion_runs_county_created ON ingestion_runs (county, created_at DESC);
+
+COMMENT ON TABLE ingestion_runs IS 'Per-import dataset metrics and anomaly check outcomes';
+COMMENT ON COLUMN ingestion_runs.county IS 'County slug from the mapping file (e.g. montgomery-tx)';
+COMMENT ON COLUMN ingestion_runs.anomalies IS 'Human-readable descriptions of metrics outside baseline thresholds';
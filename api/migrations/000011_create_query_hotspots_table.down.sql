@@ -0,0 +1,3 @@
+-- Drop query_hotspots table
+
+DROP TABLE IF EXISTS query_hotspots;
@@ -0,0 +1,20 @@
+-- Create query_hotspots table recording daily point query volume per grid cell
+-- The cache warming job replays queries for the busiest cells after a dataset switch
+
+CREATE TABLE query_hotspots (
+    hit_date DATE NOT NULL,
+
+    -- Grid cell center, rounded to the cell size (0.01 degrees, roughly 1 km)
+    cell_lat DOUBLE PRECISION NOT NULL,
+    cell_lng DOUBLE PRECISION NOT NULL,
+
+    hits BIGINT NOT NULL,
+
+    -- Timestamps
+    updated_at TIMESTAMP DEFAULT NOW(),
+
+    PRIMARY KEY (hit_date, cell_lat, cell_lng)
+);
+
+COMMENT ON TABLE query_hotspots IS 'Daily point query counts per grid cell for cache warming';
+COMMENT ON COLUMN query_hotspots.hits IS 'Successful at-point, nearby and identify requests in the cell';
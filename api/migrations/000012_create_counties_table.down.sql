@@ -0,0 +1,9 @@
+-- Drop counties table and restore the single-county tax_parcels columns
+-- WARNING: Fails if object_id values repeat across counties
+
+ALTER TABLE tax_parcels DROP CONSTRAINT IF EXISTS uq_parcels_county_object_id;
+ALTER TABLE tax_parcels ADD CONSTRAINT tax_parcels_object_id_key UNIQUE (object_id);
+ALTER TABLE tax_parcels ALTER COLUMN county_name SET DEFAULT 'Montgomery';
+ALTER TABLE tax_parcels DROP COLUMN IF EXISTS county_id;
+
+DROP TABLE IF EXISTS counties;
@@ -0,0 +1,63 @@
+-- Create counties table and link every parcel to its county
+-- Each county keeps the attribute mapping its source data was loaded with, so
+-- counties with different source schemas can share tax_parcels
+
+CREATE TABLE counties (
+    id SERIAL PRIMARY KEY,
+
+    -- Identifier used by mapping files and the county query parameter (e.g. montgomery-tx)
+    slug VARCHAR(100) UNIQUE NOT NULL,
+    -- Bare county name, copied to tax_parcels.county_name (e.g. Montgomery)
+    name VARCHAR(100) NOT NULL,
+    state CHAR(2) NOT NULL,
+
+    -- tax_parcels column -> source property name, as in scripts/mappings/*.json
+    field_mappings JSONB NOT NULL DEFAULT '{}',
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Existing parcels were all loaded from Montgomery County, TX
+INSERT INTO counties (slug, name, state, field_mappings) VALUES (
+    'montgomery-tx', 'Montgomery', 'TX',
+    '{
+        "object_id": "OBJECTID",
+        "pin": "PIN",
+        "pid": "pid",
+        "state_cd": "stateCd",
+        "block": "Block",
+        "lot": "Lot",
+        "tract": "Tract",
+        "owner_name": "ownerName",
+        "owner_address": "ownerAddress",
+        "situs": "situs",
+        "as_code": "asCode",
+        "legal_description": "legalDescription",
+        "imprv_actual_year_built": "imprvActualYearBuilt",
+        "imprv_main_area": "imprvMainArea",
+        "p_year": "pYear",
+        "p_version": "pVersion",
+        "p_roll_corr": "pRollCorr",
+        "taxing_units": "taxingUnits",
+        "exemptions": "exemptions",
+        "market_area": "marketArea"
+    }'
+);
+
+ALTER TABLE tax_parcels ADD COLUMN county_id INTEGER REFERENCES counties(id);
+UPDATE tax_parcels SET county_id = (SELECT id FROM counties WHERE slug = 'montgomery-tx');
+ALTER TABLE tax_parcels ALTER COLUMN county_id SET NOT NULL;
+
+-- county_name is copied from counties by the importers instead of defaulting to Montgomery
+ALTER TABLE tax_parcels ALTER COLUMN county_name DROP DEFAULT;
+
+-- Source object IDs are only unique within a county
+-- The composite index also serves county filters, which lead with county_id
+ALTER TABLE tax_parcels DROP CONSTRAINT tax_parcels_object_id_key;
+ALTER TABLE tax_parcels ADD CONSTRAINT uq_parcels_county_object_id UNIQUE (county_id, object_id);
+
+COMMENT ON TABLE counties IS 'Counties with loaded parcels and the attribute mapping of their source data';
+COMMENT ON COLUMN counties.field_mappings IS 'tax_parcels column to source property name; null leaves the column empty';
+COMMENT ON COLUMN tax_parcels.county_id IS 'County the parcel was loaded for';
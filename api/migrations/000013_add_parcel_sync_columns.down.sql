@@ -0,0 +1,7 @@
+-- Soft-deleted parcels would reappear once deleted_at is dropped
+DELETE FROM tax_parcels WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_parcels_live_county;
+
+ALTER TABLE tax_parcels DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE tax_parcels DROP COLUMN IF EXISTS content_hash;
@@ -0,0 +1,13 @@
+-- Support incremental sync of county parcel data
+-- Sync loads compare each incoming parcel's content hash with the stored one and
+-- only write changed rows; parcels missing from the source are soft-deleted so
+-- live traffic never sees a county disappear mid-load
+
+ALTER TABLE tax_parcels ADD COLUMN content_hash CHAR(32);
+ALTER TABLE tax_parcels ADD COLUMN deleted_at TIMESTAMP;
+
+-- Parcel queries only read live rows
+CREATE INDEX idx_parcels_live_county ON tax_parcels (county_id) WHERE deleted_at IS NULL;
+
+COMMENT ON COLUMN tax_parcels.content_hash IS 'md5 of the loaded attributes and GeoJSON geometry; NULL until loaded by the ingest CLI';
+COMMENT ON COLUMN tax_parcels.deleted_at IS 'Set when a sync load no longer finds the parcel in its county source; hidden from every query';
@@ -0,0 +1,3 @@
+-- Drop api_keys table
+
+DROP TABLE IF EXISTS api_keys;
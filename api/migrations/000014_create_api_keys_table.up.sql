@@ -0,0 +1,25 @@
+-- Create api_keys table for authenticating API clients
+-- Only a SHA-256 hash of each key is stored; the key itself is shown once when created
+
+CREATE TABLE api_keys (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+
+    -- Leading characters of the key, safe to show in listings and logs
+    prefix VARCHAR(16) NOT NULL,
+    -- Hex SHA-256 of the full key
+    key_hash CHAR(64) UNIQUE NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'public' CHECK (role IN ('public', 'admin')),
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT NOW(),
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+
+CREATE INDEX idx_api_keys_tenant ON api_keys(tenant_id);
+
+COMMENT ON TABLE api_keys IS 'API keys of tenants; only hashes of the keys are stored';
+COMMENT ON COLUMN api_keys.role IS 'public keys call the parcel API; admin keys also manage the tenant';
+COMMENT ON COLUMN api_keys.last_used_at IS 'Approximate; updated at most once per key cache TTL per instance';
@@ -0,0 +1,3 @@
+-- Drop users table
+
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,16 @@
+-- Create users table for user accounts that sign in with email and password
+-- Passwords are stored as bcrypt hashes
+
+CREATE TABLE users (
+    id BIGSERIAL PRIMARY KEY,
+    -- Stored lowercased, so lookups are case-insensitive
+    email VARCHAR(254) UNIQUE NOT NULL,
+    password_hash VARCHAR(72) NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT NOW(),
+    last_login_at TIMESTAMP
+);
+
+COMMENT ON TABLE users IS 'User accounts; authenticated with JWTs issued at login';
+COMMENT ON COLUMN users.password_hash IS 'bcrypt hash of the password';
@@ -0,0 +1 @@
+ALTER TABLE counties DROP COLUMN IF EXISTS document_url_templates;
@@ -0,0 +1,7 @@
+-- Link parcels to the recorded documents their legal descriptions cite
+-- Each county clerk has its own records search, so the deep links are built from
+-- per-county URL templates set by the county's mapping file
+
+ALTER TABLE counties ADD COLUMN document_url_templates JSONB NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN counties.document_url_templates IS 'Document reference type (volume_page, instrument, plat) to clerk search URL template with {volume}, {page}, {instrument}, {cabinet} and {slide} placeholders';
@@ -0,0 +1,3 @@
+-- Drop share_links table
+
+DROP TABLE IF EXISTS share_links;
@@ -0,0 +1,23 @@
+-- Create share_links table for short links to a saved map view
+-- A link stores the view (a parcel or an area, plus layer style) so partners can
+-- email a short token instead of a URL-encoded map state
+
+CREATE TABLE share_links (
+    id BIGSERIAL PRIMARY KEY,
+    -- Random base64url token resolved by the frontend
+    token VARCHAR(32) UNIQUE NOT NULL,
+    view JSONB NOT NULL,
+    -- Tenant that created the link, when the request named one
+    tenant_id VARCHAR(100),
+
+    -- Click tracking
+    clicks BIGINT NOT NULL DEFAULT 0,
+    last_clicked_at TIMESTAMP,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL
+);
+
+COMMENT ON TABLE share_links IS 'Short links to a saved map view; resolving a link counts a click';
+COMMENT ON COLUMN share_links.view IS 'Shared view: parcelId or area (GeoJSON MultiPolygon), optional layer style and zoom';
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_parcels_waterfront;
+
+ALTER TABLE tax_parcels DROP COLUMN IF EXISTS water_body_name;
+ALTER TABLE tax_parcels DROP COLUMN IF EXISTS waterfront_type;
+ALTER TABLE tax_parcels DROP COLUMN IF EXISTS water_frontage_meters;
+
+DROP TABLE IF EXISTS water_features;
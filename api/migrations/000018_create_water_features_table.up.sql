@@ -0,0 +1,27 @@
+-- Waterfront detection for parcels
+-- Lakes and rivers are loaded per county from a hydrology layer by the ingest CLI;
+-- each load recomputes how much of every parcel's boundary runs along water
+
+CREATE TABLE water_features (
+    id SERIAL PRIMARY KEY,
+    county_id INTEGER NOT NULL REFERENCES counties(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('lake', 'river')),
+    name VARCHAR(255),
+    geom GEOMETRY(Geometry, 4326) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_water_features_geom ON water_features USING GIST (geom);
+CREATE INDEX idx_water_features_county_kind ON water_features (county_id, kind);
+
+ALTER TABLE tax_parcels ADD COLUMN water_frontage_meters DOUBLE PRECISION;
+ALTER TABLE tax_parcels ADD COLUMN waterfront_type VARCHAR(20);
+ALTER TABLE tax_parcels ADD COLUMN water_body_name VARCHAR(255);
+
+-- Waterfront searches only read live parcels with frontage
+CREATE INDEX idx_parcels_waterfront ON tax_parcels (county_id) WHERE water_frontage_meters > 0 AND deleted_at IS NULL;
+
+COMMENT ON COLUMN water_features.geom IS 'Polygon or MultiPolygon water bodies, LineString or MultiLineString river centerlines';
+COMMENT ON COLUMN tax_parcels.water_frontage_meters IS 'Length of the parcel boundary within the waterfront tolerance of a water feature; 0 when none, NULL until the county has a hydrology layer';
+COMMENT ON COLUMN tax_parcels.waterfront_type IS 'Kind (lake, river) of the water feature nearest the parcel; NULL when the parcel has no frontage';
+COMMENT ON COLUMN tax_parcels.water_body_name IS 'Name of the water feature nearest the parcel; NULL when the parcel has no frontage or the feature is unnamed';
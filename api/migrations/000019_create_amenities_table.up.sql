@@ -0,0 +1,18 @@
+-- Points of interest for nearest-amenity distances
+-- Schools, hospitals and fire stations are loaded per county and category by the
+-- ingest CLI; distances are computed per request with a nearest-neighbor search,
+-- so amenities of neighboring counties count too
+
+CREATE TABLE amenities (
+    id SERIAL PRIMARY KEY,
+    county_id INTEGER NOT NULL REFERENCES counties(id) ON DELETE CASCADE,
+    category VARCHAR(20) NOT NULL CHECK (category IN ('school', 'hospital', 'fire_station')),
+    name VARCHAR(255),
+    geom GEOMETRY(Point, 4326) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_amenities_geom ON amenities USING GIST (geom);
+CREATE INDEX idx_amenities_county_category ON amenities (county_id, category);
+
+COMMENT ON COLUMN amenities.geom IS 'Amenity location; polygon footprints are loaded as a point on their surface';
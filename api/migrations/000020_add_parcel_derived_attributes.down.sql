@@ -0,0 +1 @@
+ALTER TABLE tax_parcels DROP COLUMN IF EXISTS derived_attributes;
@@ -0,0 +1,7 @@
+-- Derived parcel attributes configured in DERIVED_ATTRIBUTES_FILE
+-- Attributes of the ingest stage are computed by the ingest CLI after every load
+-- and stored here by name; query-stage attributes are never stored
+
+ALTER TABLE tax_parcels ADD COLUMN derived_attributes JSONB NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN tax_parcels.derived_attributes IS 'Values of the ingest-stage derived attributes by name, as of the last load of the county';
@@ -0,0 +1,2 @@
+COMMENT ON COLUMN tax_parcels.owner_address IS NULL;
+DROP TABLE IF EXISTS data_keys;
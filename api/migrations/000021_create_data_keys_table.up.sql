@@ -0,0 +1,14 @@
+-- Data keys for column-level encryption of owner contact data
+-- Each key is stored wrapped by a master key of the key provider; the newest key
+-- encrypts new values and older keys stay to decrypt values written before a
+-- rotation, until the ingest CLI re-encrypts them
+
+CREATE TABLE data_keys (
+    id SERIAL PRIMARY KEY,
+    master_key_id VARCHAR(100) NOT NULL,
+    wrapped_key BYTEA NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON COLUMN data_keys.wrapped_key IS 'AES-256 data key encrypted by the master key master_key_id';
+COMMENT ON COLUMN tax_parcels.owner_address IS 'Encrypted as enc:v1:<data key id>:<ciphertext> when ENCRYPTION_MASTER_KEYS is set';
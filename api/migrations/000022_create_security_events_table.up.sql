@@ -0,0 +1,21 @@
+-- Security events of the authentication brute-force guard
+-- Every failed authentication with credentials (an invalid API key, token or
+-- password) is recorded, as is every lockout it caused, so the admin security
+-- dashboard can summarize suspicious access across instances
+
+CREATE TABLE security_events (
+    id BIGSERIAL PRIMARY KEY,
+    event_type VARCHAR(20) NOT NULL,
+    client_ip VARCHAR(45) NOT NULL,
+    -- Credential attempted: api_key:<display prefix> or user:<email>; empty when unknown
+    subject VARCHAR(300) NOT NULL DEFAULT '',
+    route VARCHAR(100) NOT NULL DEFAULT '',
+    detail TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- The dashboard reads the last 24 hours
+CREATE INDEX idx_security_events_created_at ON security_events (created_at);
+
+COMMENT ON TABLE security_events IS 'Failed authentication attempts and the lockouts they caused';
+COMMENT ON COLUMN security_events.event_type IS 'auth_failure or lockout';
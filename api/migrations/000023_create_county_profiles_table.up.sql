@@ -0,0 +1,27 @@
+-- Per-county configuration profiles
+-- Limits and defaults that differ between counties (radius limits, geometry
+-- simplification, which attributes the county's data provides, where its data
+-- comes from), edited through the admin API so no redeploy is needed.
+-- A county without a row uses the global defaults.
+
+CREATE TABLE county_profiles (
+    county_id INTEGER PRIMARY KEY REFERENCES counties(id) ON DELETE CASCADE,
+
+    -- Nearby radius used when a request gives none, and the largest accepted
+    default_radius_meters INTEGER CHECK (default_radius_meters > 0),
+    max_radius_meters INTEGER CHECK (max_radius_meters > 0),
+
+    -- Simplification tolerance in degrees applied when a request asks for none
+    simplify_tolerance DOUBLE PRECISION CHECK (simplify_tolerance >= 0),
+
+    -- Attribute name to whether the county's data provides it
+    attributes JSONB NOT NULL DEFAULT '{}',
+
+    -- Source settings of the county's parcel loads (ArcGIS layer and options)
+    source JSONB NOT NULL DEFAULT '{}',
+
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE county_profiles IS 'Per-county limits, defaults and source settings consulted by the parcel services';
+COMMENT ON COLUMN county_profiles.attributes IS 'Attribute availability flags, e.g. {"year_built": false}; missing attributes are available';
@@ -0,0 +1,28 @@
+-- Query audit log
+-- One row per parcel query: the route, its query parameters, the API key and
+-- tenant that made it, the status, latency and number of results. Counties
+-- bill and report usage from it; rows older than RETENTION_QUERY_AUDIT are
+-- pruned by the retention job.
+
+CREATE TABLE query_audit (
+    id BIGSERIAL PRIMARY KEY,
+    route VARCHAR(100) NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    -- Query string parameters; request bodies (e.g. GeoJSON areas) are not kept
+    params JSONB NOT NULL DEFAULT '{}',
+    -- API key by display prefix; empty for anonymous requests
+    api_key_prefix VARCHAR(16) NOT NULL DEFAULT '',
+    tenant_id TEXT NOT NULL DEFAULT '',
+    request_id TEXT NOT NULL DEFAULT '',
+    status SMALLINT NOT NULL,
+    latency_ms INTEGER NOT NULL,
+    -- Parcels returned; NULL for errors and cached responses
+    result_count INTEGER,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Usage reports group a period by tenant or key; pruning deletes by age
+CREATE INDEX idx_query_audit_created_at ON query_audit (created_at);
+CREATE INDEX idx_query_audit_tenant_created_at ON query_audit (tenant_id, created_at);
+
+COMMENT ON TABLE query_audit IS 'Parcel queries with caller, latency and result count, for usage accounting';
@@ -0,0 +1,27 @@
+-- Query replay log
+-- A sample of parcel requests, anonymized, that cmd/replay drives against a
+-- staging deployment to compare statuses, result counts and latency before a
+-- schema or query change rolls out. Capture is opt-in
+-- (QUERY_AUDIT_REPLAY_SAMPLE_RATIO); rows older than RETENTION_QUERY_REPLAY
+-- are pruned by the retention job.
+
+CREATE TABLE query_replay (
+    id BIGSERIAL PRIMARY KEY,
+    route VARCHAR(100) NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    path TEXT NOT NULL,
+    -- Raw query string without credential parameters (e.g. embed tokens)
+    query TEXT NOT NULL DEFAULT '',
+    -- JSON request body; empty for GET requests
+    body TEXT NOT NULL DEFAULT '',
+    status SMALLINT NOT NULL,
+    latency_ms INTEGER NOT NULL,
+    -- Parcels returned; NULL for errors and cached responses
+    result_count INTEGER,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Exports read a recent window; pruning deletes by age
+CREATE INDEX idx_query_replay_created_at ON query_replay (created_at);
+
+COMMENT ON TABLE query_replay IS 'Sampled, anonymized parcel requests for replay against staging';
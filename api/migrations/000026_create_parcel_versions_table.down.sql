@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS trg_parcel_versions ON tax_parcels;
+DROP FUNCTION IF EXISTS record_parcel_version();
+DROP TABLE IF EXISTS parcel_versions;
@@ -0,0 +1,89 @@
+-- Parcel history
+-- Each row is a prior version of a tax parcel: its attributes and boundary as
+-- they were from valid_from until valid_to, when an ingest update replaced them
+-- or a sync load soft-deleted the parcel. The current version stays in
+-- tax_parcels. Versions are recorded by a trigger, so every writer (sync loads,
+-- appraisal refreshes) keeps history without knowing about it.
+--
+-- Only changes to the versioned columns below, the boundary or deleted_at record
+-- a version. Writes of other columns (content_hash, derived and waterfront
+-- attributes) and of owner_address alone do not: re-encrypting owner_address
+-- after a key rotation rewrites its ciphertext without changing the address, so
+-- a mailing address change is kept with the next versioned change instead.
+-- Replace-mode loads delete and reinsert a county's parcels, which deletes their
+-- history with them.
+
+CREATE TABLE parcel_versions (
+    id BIGSERIAL PRIMARY KEY,
+    parcel_id BIGINT NOT NULL REFERENCES tax_parcels(id) ON DELETE CASCADE,
+
+    -- Versioned columns, as in tax_parcels
+    pin INTEGER NOT NULL,
+    pid INTEGER,
+    state_cd VARCHAR(10),
+    block INTEGER,
+    lot VARCHAR(100),
+    tract VARCHAR(50),
+    owner_name VARCHAR(500),
+    -- Encrypted like tax_parcels.owner_address; re-encrypted by datakeys -reencrypt
+    owner_address TEXT,
+    situs VARCHAR(500),
+    as_code VARCHAR(50),
+    legal_description TEXT,
+    imprv_actual_year_built INTEGER,
+    imprv_main_area INTEGER,
+    p_year INTEGER,
+    p_version INTEGER,
+    p_roll_corr INTEGER,
+    taxing_units VARCHAR(255),
+    exemptions VARCHAR(255),
+    market_area VARCHAR(50),
+    geom GEOMETRY(MultiPolygon, 4326) NOT NULL,
+
+    -- Whether the parcel was soft-deleted during this version
+    deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    valid_from TIMESTAMP NOT NULL,
+    valid_to TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- History is read newest first per parcel
+CREATE INDEX idx_parcel_versions_parcel_valid_to ON parcel_versions (parcel_id, valid_to DESC);
+
+CREATE FUNCTION record_parcel_version() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO parcel_versions (
+        parcel_id, pin, pid, state_cd, block, lot, tract, owner_name, owner_address,
+        situs, as_code, legal_description, imprv_actual_year_built, imprv_main_area,
+        p_year, p_version, p_roll_corr, taxing_units, exemptions, market_area, geom,
+        deleted, valid_from, valid_to
+    ) VALUES (
+        OLD.id, OLD.pin, OLD.pid, OLD.state_cd, OLD.block, OLD.lot, OLD.tract, OLD.owner_name, OLD.owner_address,
+        OLD.situs, OLD.as_code, OLD.legal_description, OLD.imprv_actual_year_built, OLD.imprv_main_area,
+        OLD.p_year, OLD.p_version, OLD.p_roll_corr, OLD.taxing_units, OLD.exemptions, OLD.market_area, OLD.geom,
+        OLD.deleted_at IS NOT NULL, COALESCE(OLD.updated_at, OLD.created_at, NOW()), NOW()
+    );
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Geometries are compared by their binary form: the geometry = operator is not
+-- an exact comparison on every PostGIS version
+CREATE TRIGGER trg_parcel_versions
+    AFTER UPDATE ON tax_parcels
+    FOR EACH ROW
+    WHEN (
+        OLD.deleted_at IS DISTINCT FROM NEW.deleted_at
+        OR (OLD.pin, OLD.pid, OLD.state_cd, OLD.block, OLD.lot, OLD.tract, OLD.owner_name,
+            OLD.situs, OLD.as_code, OLD.legal_description, OLD.imprv_actual_year_built, OLD.imprv_main_area,
+            OLD.p_year, OLD.p_version, OLD.p_roll_corr, OLD.taxing_units, OLD.exemptions, OLD.market_area)
+        IS DISTINCT FROM
+           (NEW.pin, NEW.pid, NEW.state_cd, NEW.block, NEW.lot, NEW.tract, NEW.owner_name,
+            NEW.situs, NEW.as_code, NEW.legal_description, NEW.imprv_actual_year_built, NEW.imprv_main_area,
+            NEW.p_year, NEW.p_version, NEW.p_roll_corr, NEW.taxing_units, NEW.exemptions, NEW.market_area)
+        OR ST_AsBinary(OLD.geom) IS DISTINCT FROM ST_AsBinary(NEW.geom)
+    )
+    EXECUTE FUNCTION record_parcel_version();
+
+COMMENT ON TABLE parcel_versions IS 'Prior versions of tax parcels, recorded by trg_parcel_versions when ingest changes them';
+COMMENT ON COLUMN parcel_versions.valid_from IS 'updated_at of the parcel when this version was written';
+COMMENT ON COLUMN parcel_versions.valid_to IS 'When this version was replaced or the parcel restored or soft-deleted';
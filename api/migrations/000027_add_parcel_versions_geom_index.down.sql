@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_parcel_versions_geom;
@@ -0,0 +1,7 @@
+-- Point-in-time parcel queries
+-- at-point with as_of searches the boundaries of prior parcel versions as well as
+-- the current ones, so parcel_versions needs a spatial index like tax_parcels
+
+CREATE INDEX idx_parcel_versions_geom ON parcel_versions USING GIST(geom);
+
+COMMENT ON INDEX idx_parcel_versions_geom IS 'GiST spatial index for point-in-polygon queries against past parcel versions';
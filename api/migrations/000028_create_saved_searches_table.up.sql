@@ -0,0 +1,40 @@
+-- Saved searches: an area and parcel filters a user monitors
+-- After each new ingestion run the alerts job records the parcels that were
+-- added, changed or deleted since the search was last evaluated and match it
+
+CREATE TABLE saved_searches (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    area geometry(MultiPolygon, 4326) NOT NULL,
+    filters JSONB NOT NULL DEFAULT '{}',
+    county VARCHAR(100),
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT NOW(),
+    evaluated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_saved_searches_user ON saved_searches (user_id, id);
+
+CREATE TABLE saved_search_matches (
+    id BIGSERIAL PRIMARY KEY,
+    saved_search_id BIGINT NOT NULL REFERENCES saved_searches(id) ON DELETE CASCADE,
+    -- Not a foreign key: replace loads recreate a county's parcels, and the
+    -- matches outlive them
+    parcel_id BIGINT NOT NULL,
+    pin INTEGER NOT NULL,
+    situs VARCHAR(500),
+    change VARCHAR(10) NOT NULL,
+    ingestion_run_id BIGINT,
+    matched_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_saved_search_matches_change CHECK (change IN ('added', 'changed', 'deleted'))
+);
+
+CREATE INDEX idx_saved_search_matches_search ON saved_search_matches (saved_search_id, id DESC);
+
+COMMENT ON TABLE saved_searches IS 'User-scoped areas and parcel filters evaluated after every ingestion run';
+COMMENT ON COLUMN saved_searches.filters IS 'Nearby filters (land use, acres, year built, owner, waterfront, amenity) as JSON';
+COMMENT ON COLUMN saved_searches.evaluated_at IS 'Parcels added, changed or deleted after this time are matched on the next evaluation';
+COMMENT ON COLUMN saved_search_matches.ingestion_run_id IS 'Newest activated ingestion run when the match was recorded';
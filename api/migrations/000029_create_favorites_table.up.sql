@@ -0,0 +1,27 @@
+-- Favorites: parcels a user bookmarked, with an optional note
+-- A favorite is keyed by the parcel's county and object ID rather than its ID:
+-- replace loads recreate a county's parcels under new IDs, and the bookmark
+-- follows the reloaded parcel. parcel_id is the ID it was saved under, kept so
+-- a favorite whose parcel is gone can still be deleted.
+
+CREATE TABLE favorites (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    parcel_id BIGINT NOT NULL,
+    county_id BIGINT NOT NULL,
+    object_id INTEGER NOT NULL,
+    pin INTEGER NOT NULL,
+    note VARCHAR(1000),
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+
+    CONSTRAINT uq_favorites_user_parcel UNIQUE (user_id, county_id, object_id)
+);
+
+CREATE INDEX idx_favorites_user ON favorites (user_id, id DESC);
+
+COMMENT ON TABLE favorites IS 'User-scoped parcel bookmarks';
+COMMENT ON COLUMN favorites.parcel_id IS 'Parcel ID when the favorite was last saved; replace loads may have reissued it';
+COMMENT ON COLUMN favorites.pin IS 'Parcel PIN when the favorite was last saved, shown once the parcel is gone';
@@ -0,0 +1,30 @@
+-- Annotations: notes users attach to a parcel, with optional point or area markup
+-- Like favorites, an annotation is keyed by the parcel's county and object ID so
+-- it follows the parcel across replace loads; parcel_id is the ID it was written on.
+-- Private annotations are read by their author only, shared ones by every user.
+
+CREATE TABLE annotations (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    parcel_id BIGINT NOT NULL,
+    county_id BIGINT NOT NULL,
+    object_id INTEGER NOT NULL,
+    body VARCHAR(5000) NOT NULL,
+    markup geometry(Geometry, 4326),
+    visibility VARCHAR(10) NOT NULL DEFAULT 'private',
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+
+    CONSTRAINT chk_annotations_visibility CHECK (visibility IN ('private', 'shared')),
+    CONSTRAINT chk_annotations_markup_type CHECK (markup IS NULL OR GeometryType(markup) IN ('POINT', 'MULTIPOLYGON'))
+);
+
+-- A parcel's annotations are listed newest first
+CREATE INDEX idx_annotations_parcel ON annotations (county_id, object_id, id DESC);
+CREATE INDEX idx_annotations_user ON annotations (user_id, id DESC);
+
+COMMENT ON TABLE annotations IS 'User notes and markup attached to parcels';
+COMMENT ON COLUMN annotations.markup IS 'Point or MultiPolygon drawn with the note';
+COMMENT ON COLUMN annotations.visibility IS 'private (author only) or shared (all users)';
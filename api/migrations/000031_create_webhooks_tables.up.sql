@@ -0,0 +1,49 @@
+-- Webhooks: URLs a tenant registers to be notified of parcel changes
+-- After each new ingestion run the webhooks job queues a delivery of the parcels
+-- added, changed or deleted since the webhook was last evaluated, limited to its
+-- county and area when set, then POSTs due deliveries signed with the webhook's
+-- secret, retrying failures with exponential backoff.
+
+CREATE TABLE webhooks (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    -- HMAC-SHA256 signing key, shown to the tenant once when the webhook is created
+    secret VARCHAR(100) NOT NULL,
+    county VARCHAR(100),
+    area geometry(MultiPolygon, 4326),
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT NOW(),
+    evaluated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhooks_tenant ON webhooks (tenant_id, id);
+
+CREATE TABLE webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id BIGINT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event VARCHAR(50) NOT NULL,
+    ingestion_run_id BIGINT,
+    payload JSONB NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    response_status INTEGER,
+    last_error VARCHAR(500),
+
+    -- Timestamps
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMP,
+
+    CONSTRAINT chk_webhook_deliveries_status CHECK (status IN ('pending', 'delivered', 'failed'))
+);
+
+-- The delivery worker reads the pending deliveries that are due
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_webhook_deliveries_webhook ON webhook_deliveries (webhook_id, id DESC);
+
+COMMENT ON TABLE webhooks IS 'Tenant URLs notified of parcels changed by ingestion';
+COMMENT ON COLUMN webhooks.evaluated_at IS 'Parcels added, changed or deleted after this time are queued on the next evaluation';
+COMMENT ON TABLE webhook_deliveries IS 'Queued and sent webhook notifications, pruned by retention';
+COMMENT ON COLUMN webhook_deliveries.payload IS 'JSON body POSTed to the webhook URL';
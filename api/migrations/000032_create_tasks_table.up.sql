@@ -0,0 +1,32 @@
+-- Tasks: the durable queue of background work run by jobs.Queue
+-- Workers on any instance claim due tasks with FOR UPDATE SKIP LOCKED and hold
+-- them for a lease; a task whose worker died is claimed again once the lease
+-- expires. Failed attempts are retried with exponential backoff until
+-- max_attempts, after which the task is failed and kept for inspection.
+
+CREATE TABLE tasks (
+    id BIGSERIAL PRIMARY KEY,
+    kind VARCHAR(100) NOT NULL,
+    -- Deduplicates tasks of a kind, e.g. one evaluation per ingestion run
+    key VARCHAR(200),
+    payload JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(10) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL,
+    run_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    locked_until TIMESTAMP,
+    last_error VARCHAR(500),
+
+    -- Timestamps
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP,
+
+    CONSTRAINT chk_tasks_status CHECK (status IN ('pending', 'running', 'succeeded', 'failed'))
+);
+
+CREATE UNIQUE INDEX idx_tasks_kind_key ON tasks (kind, key) WHERE key IS NOT NULL;
+-- Workers read the pending tasks that are due and the running ones whose lease expired
+CREATE INDEX idx_tasks_due ON tasks (run_at) WHERE status IN ('pending', 'running');
+
+COMMENT ON TABLE tasks IS 'Durable background work queue, finished tasks pruned by retention';
+COMMENT ON COLUMN tasks.locked_until IS 'End of the lease of the worker running the task';
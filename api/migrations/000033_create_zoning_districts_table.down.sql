@@ -0,0 +1 @@
+DROP TABLE IF EXISTS zoning_districts;
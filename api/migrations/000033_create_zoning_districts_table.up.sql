@@ -0,0 +1,19 @@
+-- Zoning districts
+-- Each county's zoning layer is loaded by the ingest CLI, replacing the county's
+-- districts; parcels are joined to the districts they intersect per request, so
+-- a reload applies immediately and a parcel split by a district line reports both
+
+CREATE TABLE zoning_districts (
+    id SERIAL PRIMARY KEY,
+    county_id INTEGER NOT NULL REFERENCES counties(id) ON DELETE CASCADE,
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(255),
+    geom GEOMETRY(MultiPolygon, 4326) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_zoning_districts_geom ON zoning_districts USING GIST (geom);
+CREATE INDEX idx_zoning_districts_county ON zoning_districts (county_id);
+
+COMMENT ON COLUMN zoning_districts.code IS 'Zoning designation as published by the jurisdiction, e.g. R-1 or C-2';
+COMMENT ON COLUMN zoning_districts.name IS 'Description of the designation, e.g. Single Family Residential; NULL when the layer has none';
@@ -0,0 +1,21 @@
+-- FEMA flood zones
+-- Each county's National Flood Hazard Layer (NFHL) flood hazard areas are loaded
+-- by the ingest CLI, replacing the county's flood zones; parcels are intersected
+-- with the zones per request, so a reload applies immediately
+
+CREATE TABLE flood_zones (
+    id SERIAL PRIMARY KEY,
+    county_id INTEGER NOT NULL REFERENCES counties(id) ON DELETE CASCADE,
+    zone VARCHAR(20) NOT NULL,
+    subtype VARCHAR(255),
+    sfha BOOLEAN NOT NULL,
+    geom GEOMETRY(MultiPolygon, 4326) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_flood_zones_geom ON flood_zones USING GIST (geom);
+CREATE INDEX idx_flood_zones_county ON flood_zones (county_id);
+
+COMMENT ON COLUMN flood_zones.zone IS 'FEMA flood zone designation (NFHL FLD_ZONE), e.g. AE, VE or X';
+COMMENT ON COLUMN flood_zones.subtype IS 'Zone subtype (NFHL ZONE_SUBTY), e.g. FLOODWAY or 0.2 PCT ANNUAL CHANCE FLOOD HAZARD; NULL when none';
+COMMENT ON COLUMN flood_zones.sfha IS 'Whether the zone is a Special Flood Hazard Area, where federally backed mortgages require flood insurance';
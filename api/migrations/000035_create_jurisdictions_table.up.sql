@@ -0,0 +1,20 @@
+-- School district and taxing jurisdiction boundaries
+-- Each county's school district and taxing unit layers are loaded by the ingest
+-- CLI, replacing the county's jurisdictions of that kind; parcels are matched to
+-- the jurisdictions containing them per request, so a reload applies immediately
+
+CREATE TABLE jurisdictions (
+    id SERIAL PRIMARY KEY,
+    county_id INTEGER NOT NULL REFERENCES counties(id) ON DELETE CASCADE,
+    kind VARCHAR(30) NOT NULL CHECK (kind IN ('school_district', 'taxing_unit')),
+    code VARCHAR(50),
+    name VARCHAR(255) NOT NULL,
+    geom GEOMETRY(MultiPolygon, 4326) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_jurisdictions_geom ON jurisdictions USING GIST (geom);
+CREATE INDEX idx_jurisdictions_county_kind ON jurisdictions (county_id, kind);
+
+COMMENT ON COLUMN jurisdictions.kind IS 'school_district or taxing_unit (county, city, MUD, ESD and other units levying property tax)';
+COMMENT ON COLUMN jurisdictions.code IS 'Identifier published with the boundary, e.g. the TEA district number or the appraisal district''s taxing unit code; NULL when none';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS layer_features;
+DROP TABLE IF EXISTS layers;
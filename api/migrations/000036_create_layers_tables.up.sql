@@ -0,0 +1,29 @@
+-- Generic spatial layers
+-- A layer is a named dataset (wetlands, easements, utility lines) registered by
+-- the ingest CLI the first time it is loaded; its features keep their source
+-- attributes as JSON so new datasets need no schema changes. Loads replace a
+-- county's features of one layer
+
+CREATE TABLE layers (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(63) NOT NULL UNIQUE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE layer_features (
+    id BIGSERIAL PRIMARY KEY,
+    layer_id INTEGER NOT NULL REFERENCES layers(id) ON DELETE CASCADE,
+    county_id INTEGER NOT NULL REFERENCES counties(id) ON DELETE CASCADE,
+    properties JSONB NOT NULL DEFAULT '{}',
+    geom GEOMETRY(Geometry, 4326) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_layer_features_geom ON layer_features USING GIST (geom);
+CREATE INDEX idx_layer_features_layer_county ON layer_features (layer_id, county_id);
+
+COMMENT ON COLUMN layers.name IS 'URL name of the layer, e.g. wetlands; lower-case letters, digits and underscores';
+COMMENT ON COLUMN layer_features.properties IS 'Source attributes of the feature, all of them or those chosen at load';
@@ -0,0 +1,23 @@
+-- Parcel sales history
+-- Each county's deed or sales export is loaded by the ingest CLI, replacing the
+-- county's sales. Sales are keyed by the appraisal district property ID and
+-- matched to the pid of parcels when read, so they survive parcel reloads
+
+CREATE TABLE parcel_sales (
+    id BIGSERIAL PRIMARY KEY,
+    county_id INTEGER NOT NULL REFERENCES counties(id) ON DELETE CASCADE,
+    prop_id INTEGER NOT NULL,
+    sale_date DATE NOT NULL,
+    price BIGINT,
+    deed_type VARCHAR(50),
+    grantor VARCHAR(500),
+    grantee VARCHAR(500),
+    instrument VARCHAR(100),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_parcel_sales_county_prop ON parcel_sales (county_id, prop_id, sale_date DESC);
+
+COMMENT ON COLUMN parcel_sales.prop_id IS 'Appraisal district property ID, matching tax_parcels.pid';
+COMMENT ON COLUMN parcel_sales.price IS 'Sale price in whole dollars; NULL where the price is not disclosed';
+COMMENT ON COLUMN parcel_sales.instrument IS 'Clerk instrument number or volume/page of the recorded deed';
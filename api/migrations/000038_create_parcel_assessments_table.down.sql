@@ -0,0 +1 @@
+DROP TABLE IF EXISTS parcel_assessments;
@@ -0,0 +1,26 @@
+-- Yearly assessment history
+-- Each appraisal refresh by the ingest CLI records the certified values of the
+-- roll's year, replacing those already recorded for that year. Assessments are
+-- keyed by the appraisal district property ID and matched to the pid of
+-- parcels when read, so they survive parcel reloads
+
+CREATE TABLE parcel_assessments (
+    id BIGSERIAL PRIMARY KEY,
+    county_id INTEGER NOT NULL REFERENCES counties(id) ON DELETE CASCADE,
+    prop_id INTEGER NOT NULL,
+    tax_year INTEGER NOT NULL,
+    land_value BIGINT NOT NULL,
+    improvement_value BIGINT NOT NULL,
+    ag_use_value BIGINT NOT NULL,
+    market_value BIGINT NOT NULL,
+    appraised_value BIGINT NOT NULL,
+    assessed_value BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (county_id, prop_id, tax_year)
+);
+
+COMMENT ON COLUMN parcel_assessments.prop_id IS 'Appraisal district property ID, matching tax_parcels.pid';
+COMMENT ON COLUMN parcel_assessments.market_value IS 'Land, improvement, agricultural and timber market value in whole dollars';
+COMMENT ON COLUMN parcel_assessments.appraised_value IS 'Market value with productivity (ag and timber use) values applied';
+COMMENT ON COLUMN parcel_assessments.assessed_value IS 'Appraised value after the homestead cap';
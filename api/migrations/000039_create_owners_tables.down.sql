@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS owner_names;
+DROP TABLE IF EXISTS owners;
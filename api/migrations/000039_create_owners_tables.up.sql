@@ -0,0 +1,28 @@
+-- Owner entities
+-- Every load and appraisal refresh by the ingest CLI resolves the owner names
+-- of the county's parcels to owners: names whose normalized key equals or
+-- closely resembles an owner's key are spelling variants of that owner.
+-- Parcels are matched to their owner by owner_name when read, so owners hold
+-- parcels across counties and survive parcel reloads
+
+CREATE TABLE owners (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(500) NOT NULL,
+    owner_key VARCHAR(500) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Supports the "%" similarity operator used to match spelling variants
+CREATE INDEX idx_owners_key_trgm ON owners USING GIN (owner_key gin_trgm_ops);
+
+CREATE TABLE owner_names (
+    name VARCHAR(500) PRIMARY KEY,
+    owner_id INTEGER NOT NULL REFERENCES owners(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_owner_names_owner ON owner_names (owner_id);
+
+COMMENT ON COLUMN owners.name IS 'Display name: the first spelling the owner was resolved from';
+COMMENT ON COLUMN owners.owner_key IS 'Normalized owner name: upper case, punctuation and ET AL dropped, entity suffixes abbreviated';
+COMMENT ON COLUMN owner_names.name IS 'Owner name exactly as on tax_parcels.owner_name';
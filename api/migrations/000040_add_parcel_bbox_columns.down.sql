@@ -0,0 +1,5 @@
+ALTER TABLE tax_parcels
+    DROP COLUMN IF EXISTS bbox_max_lat,
+    DROP COLUMN IF EXISTS bbox_max_lng,
+    DROP COLUMN IF EXISTS bbox_min_lat,
+    DROP COLUMN IF EXISTS bbox_min_lng;
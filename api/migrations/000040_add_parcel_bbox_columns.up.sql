@@ -0,0 +1,12 @@
+-- Parcel bounding boxes
+-- The envelope (ST_Envelope) of each parcel boundary as generated columns, so
+-- responses return it and queries sort or filter on it without reading the
+-- geometry. Adding stored generated columns rewrites the table
+
+ALTER TABLE tax_parcels
+    ADD COLUMN bbox_min_lng DOUBLE PRECISION GENERATED ALWAYS AS (ST_XMin(geom)) STORED,
+    ADD COLUMN bbox_min_lat DOUBLE PRECISION GENERATED ALWAYS AS (ST_YMin(geom)) STORED,
+    ADD COLUMN bbox_max_lng DOUBLE PRECISION GENERATED ALWAYS AS (ST_XMax(geom)) STORED,
+    ADD COLUMN bbox_max_lat DOUBLE PRECISION GENERATED ALWAYS AS (ST_YMax(geom)) STORED;
+
+COMMENT ON COLUMN tax_parcels.bbox_min_lng IS 'West edge of the parcel envelope; the bbox columns are NULL for empty geometries';
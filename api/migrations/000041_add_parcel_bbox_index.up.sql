@@ -0,0 +1,5 @@
+-- Parcel bounding box index
+-- Supports sorting and range filters on the generated bbox_* columns of live
+-- parcels (for example parcels west of a longitude, or ordered by west edge)
+-- without reading the geometry. Empty geometries have NULL bbox columns.
+CREATE INDEX idx_parcels_bbox ON tax_parcels (bbox_min_lng, bbox_min_lat, bbox_max_lng, bbox_max_lat) WHERE deleted_at IS NULL;
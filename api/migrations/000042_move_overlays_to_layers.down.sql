@@ -0,0 +1,58 @@
+-- The overlay tables were kept by the up migration with their original rows.
+-- Counties loaded since then have features newer than every row the table
+-- holds for them (loads stamp created_at, the copies kept the original); only
+-- their rows are replaced from the layers, so neither the original IDs nor the
+-- later loads are lost. Jurisdictions are loaded per county and kind.
+
+WITH reloaded AS (
+    SELECT DISTINCT f.county_id
+    FROM layer_features f
+    JOIN layers l ON l.id = f.layer_id AND l.name = 'zoning'
+    WHERE f.created_at > (SELECT COALESCE(MAX(z.created_at), '-infinity') FROM zoning_districts z WHERE z.county_id = f.county_id)
+), replaced AS (
+    DELETE FROM zoning_districts WHERE county_id IN (SELECT county_id FROM reloaded)
+)
+INSERT INTO zoning_districts (county_id, code, name, geom, created_at)
+SELECT f.county_id, f.properties->>'code', f.properties->>'name', ST_Multi(f.geom), f.created_at
+FROM layer_features f
+JOIN layers l ON l.id = f.layer_id AND l.name = 'zoning'
+WHERE f.county_id IN (SELECT county_id FROM reloaded)
+ORDER BY f.id;
+
+WITH reloaded AS (
+    SELECT DISTINCT f.county_id
+    FROM layer_features f
+    JOIN layers l ON l.id = f.layer_id AND l.name = 'flood_zones'
+    WHERE f.created_at > (SELECT COALESCE(MAX(z.created_at), '-infinity') FROM flood_zones z WHERE z.county_id = f.county_id)
+), replaced AS (
+    DELETE FROM flood_zones WHERE county_id IN (SELECT county_id FROM reloaded)
+)
+INSERT INTO flood_zones (county_id, zone, subtype, sfha, geom, created_at)
+SELECT f.county_id, f.properties->>'zone', f.properties->>'subtype', (f.properties->>'sfha')::boolean, ST_Multi(f.geom), f.created_at
+FROM layer_features f
+JOIN layers l ON l.id = f.layer_id AND l.name = 'flood_zones'
+WHERE f.county_id IN (SELECT county_id FROM reloaded)
+ORDER BY f.id;
+
+WITH reloaded AS (
+    SELECT DISTINCT f.county_id, f.properties->>'kind' as kind
+    FROM layer_features f
+    JOIN layers l ON l.id = f.layer_id AND l.name = 'jurisdictions'
+    WHERE f.created_at > (SELECT COALESCE(MAX(j.created_at), '-infinity') FROM jurisdictions j
+        WHERE j.county_id = f.county_id AND j.kind = f.properties->>'kind')
+), replaced AS (
+    DELETE FROM jurisdictions WHERE (county_id, kind) IN (SELECT county_id, kind FROM reloaded)
+)
+INSERT INTO jurisdictions (county_id, kind, code, name, geom, created_at)
+SELECT f.county_id, f.properties->>'kind', f.properties->>'code', f.properties->>'name', ST_Multi(f.geom), f.created_at
+FROM layer_features f
+JOIN layers l ON l.id = f.layer_id AND l.name = 'jurisdictions'
+WHERE (f.county_id, f.properties->>'kind') IN (SELECT county_id, kind FROM reloaded)
+ORDER BY f.id;
+
+COMMENT ON TABLE zoning_districts IS NULL;
+COMMENT ON TABLE flood_zones IS NULL;
+COMMENT ON TABLE jurisdictions IS NULL;
+
+-- Their features cascade
+DELETE FROM layers WHERE name IN ('zoning', 'flood_zones', 'jurisdictions');
@@ -0,0 +1,38 @@
+-- Zoning, flood zones and jurisdictions as layers
+-- The rows of the overlay tables are copied into the zoning, flood_zones and
+-- jurisdictions layers, their columns kept as properties, so the overlay
+-- endpoints are answered by the layer queries. Feature IDs change; a layer is
+-- only registered when its table has rows, as a first load would register it.
+-- The tables are kept, no longer written, for one release so a rollback
+-- restores their rows with the original IDs; a later migration drops them
+
+INSERT INTO layers (name, title)
+SELECT 'zoning', 'Zoning districts' WHERE EXISTS (SELECT 1 FROM zoning_districts);
+
+INSERT INTO layers (name, title)
+SELECT 'flood_zones', 'FEMA flood zones' WHERE EXISTS (SELECT 1 FROM flood_zones);
+
+INSERT INTO layers (name, title)
+SELECT 'jurisdictions', 'School districts and taxing units' WHERE EXISTS (SELECT 1 FROM jurisdictions);
+
+INSERT INTO layer_features (layer_id, county_id, properties, geom, created_at)
+SELECT l.id, z.county_id, jsonb_strip_nulls(jsonb_build_object('code', z.code, 'name', z.name)), z.geom, z.created_at
+FROM zoning_districts z
+JOIN layers l ON l.name = 'zoning'
+ORDER BY z.id;
+
+INSERT INTO layer_features (layer_id, county_id, properties, geom, created_at)
+SELECT l.id, f.county_id, jsonb_strip_nulls(jsonb_build_object('zone', f.zone, 'subtype', f.subtype, 'sfha', f.sfha)), f.geom, f.created_at
+FROM flood_zones f
+JOIN layers l ON l.name = 'flood_zones'
+ORDER BY f.id;
+
+INSERT INTO layer_features (layer_id, county_id, properties, geom, created_at)
+SELECT l.id, j.county_id, jsonb_strip_nulls(jsonb_build_object('kind', j.kind, 'code', j.code, 'name', j.name)), j.geom, j.created_at
+FROM jurisdictions j
+JOIN layers l ON l.name = 'jurisdictions'
+ORDER BY j.id;
+
+COMMENT ON TABLE zoning_districts IS 'Deprecated by migration 000042, superseded by the zoning layer; kept for rollback';
+COMMENT ON TABLE flood_zones IS 'Deprecated by migration 000042, superseded by the flood_zones layer; kept for rollback';
+COMMENT ON TABLE jurisdictions IS 'Deprecated by migration 000042, superseded by the jurisdictions layer; kept for rollback';
@@ -0,0 +1,11 @@
+// Package migrations embeds the SQL migrations applied by golang-migrate
+// (make migrate-up), so tools can compare a database against them without the
+// source tree. golang-migrate ignores this file.
+package migrations
+
+import "embed"
+
+// FS holds the numbered *.up.sql and *.down.sql migration files.
+//
+//go:embed *.sql
+var FS embed.FS
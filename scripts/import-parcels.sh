@@ -3,19 +3,25 @@
 #==============================================================================
 # Import Tax Parcels Script
 #==============================================================================
-# Description: Imports GeoJSON tax parcel data into PostgreSQL using ogr2ogr
+# Description: Imports GeoJSON tax parcel data into PostgreSQL
 # Usage: ./import-parcels.sh --file <geojson> --mapping <config.json> [options]
 #
 # This script:
 # 1. Reads field mapping configuration from JSON
-# 2. Imports GeoJSON into a staging table using ogr2ogr
-# 3. Maps fields from staging to final tax_parcels table via SQL
-# 4. Executes all operations within a transaction for atomicity
+# 2. In replace mode, loads the file with cmd/ingest, which compares the new
+#    data against historical ingestion runs and blocks activation when metrics
+#    deviate beyond thresholds (exit code 4)
+# 3. In append mode, imports GeoJSON into a staging table using ogr2ogr and
+#    maps fields from staging to the final tax_parcels table via SQL, in one
+#    transaction
+# 4. Holds the county's advisory lock throughout, so imports of the same county
+#    (including cmd/ingest loads) never overlap
 #
 # Requirements:
 # - GDAL/OGR tools (ogr2ogr, ogrinfo)
 # - jq (JSON processor)
 # - PostgreSQL client (psql)
+# - The cmd/ingest binary (make build-ingest, or INGEST_BIN) or Go, for replace mode
 # - Database with PostGIS extension enabled
 #==============================================================================
 
@@ -26,9 +32,15 @@ set -euo pipefail
 #------------------------------------------------------------------------------
 readonly SCRIPT_NAME="$(basename "$0")"
 readonly SCRIPT_DIR="$(cd "$(dirname "$0")" && pwd)"
-readonly STAGING_TABLE="tax_parcels_staging"
+readonly STAGING_TABLE_PREFIX="tax_parcels_staging"
 readonly FINAL_TABLE="tax_parcels"
 readonly LOG_DIR="${SCRIPT_DIR}/../logs"
+readonly API_DIR="${SCRIPT_DIR}/../api"
+# Exit codes of cmd/ingest, which this script passes on
+readonly EXIT_COUNTY_LOCKED=3
+readonly EXIT_ANOMALY_BLOCKED=4
+# Prefix of the county lock key; cmd/ingest hashes the same key
+readonly LOCK_NAMESPACE="atlas:ingest:"
 
 # Color codes for output
 readonly RED='\033[0;31m'
@@ -51,6 +63,16 @@ VALIDATE_GEOMETRIES=false
 POST_IMPORT_VALIDATION=false
 GEOJSON_FILE=""
 MAPPING_FILE=""
+LOCK_TIMEOUT=30                 # Seconds to wait for a running import of the same county
+STAGING_TABLE=""                # Per county, set by read_mapping_config
+
+# Replace mode loads through cmd/ingest, which runs the anomaly check
+INGEST_BIN="${INGEST_BIN:-${API_DIR}/bin/ingest}"
+ALLOW_ANOMALIES=false
+# Anomaly thresholds passed to cmd/ingest; empty keeps its defaults
+MAX_COUNT_CHANGE_PCT=""
+MAX_AREA_CHANGE_PCT=""
+MAX_NULL_RATE_INCREASE=""
 
 # Logging variables
 LOG_FILE=""
@@ -139,7 +161,8 @@ usage() {
     cat << EOF
 Usage: ${SCRIPT_NAME} [OPTIONS]
 
-Import GeoJSON tax parcel data into PostgreSQL using ogr2ogr.
+Import GeoJSON tax parcel data into PostgreSQL: replace mode loads with
+cmd/ingest (${INGEST_BIN}, or go run when it is not built), append mode with ogr2ogr.
 
 Required Options:
   --file <path>           Path to GeoJSON file to import
@@ -157,6 +180,16 @@ Import Options:
   --validate-geometries   Run geometry validation and repair after import
   --post-validate         Run comprehensive post-import validation
   --dry-run               Preview operations without executing
+  --lock-timeout <secs>   Wait for a running import of the same county, then exit with
+                          code ${EXIT_COUNTY_LOCKED} (default: ${LOCK_TIMEOUT}; 0 fails at once)
+
+Anomaly Detection (replace mode only, run by cmd/ingest):
+  --allow-anomalies       Activate the new data even if anomalies are found
+  --max-count-change <n>  Max record count change vs baseline, percent (default: cmd/ingest's, 10)
+  --max-area-change <n>   Max total acreage change vs baseline, percent (default: cmd/ingest's, 10)
+  --max-null-increase <n> Max null rate increase for owner/situs/year built, 0-1 (default: cmd/ingest's, 0.05)
+
+  Runs that fail the checks leave ${FINAL_TABLE} untouched and exit with code ${EXIT_ANOMALY_BLOCKED}.
 
 Other Options:
   -h, --help              Show this help message
@@ -167,6 +200,7 @@ Environment Variables:
   DB_NAME                 Database name
   DB_USER                 Database user
   DB_PASSWORD             Database password
+  INGEST_BIN              cmd/ingest binary for replace mode (default: api/bin/ingest)
 
 Examples:
   # Import Montgomery County parcels (replace mode)
@@ -211,6 +245,10 @@ check_dependencies() {
         missing_deps+=("psql (PostgreSQL client)")
     fi
     
+    if [ "${MODE}" = "replace" ] && [ ! -x "${INGEST_BIN}" ] && ! command -v go &> /dev/null; then
+        missing_deps+=("cmd/ingest (make build-ingest in api/, or set INGEST_BIN) or go")
+    fi
+    
     if [ ${#missing_deps[@]} -gt 0 ]; then
         print_error "Missing required dependencies:"
         for dep in "${missing_deps[@]}"; do
@@ -326,8 +364,13 @@ read_mapping_config() {
     SOURCE_CRS=$(jq -r '.source_crs' "${file}")
     TARGET_CRS=$(jq -r '.target_crs' "${file}")
     COUNTY_NAME=$(jq -r '.county_name // "Unknown"' "${file}")
+    COUNTY_SLUG=$(jq -r '.county // "unknown"' "${file}")
+    COUNTY_STATE=$(jq -r '.state // ""' "${file}" | tr '[:lower:]' '[:upper:]')
+    
+    # Counties are staged separately so imports of different counties can run at once
+    STAGING_TABLE="${STAGING_TABLE_PREFIX}_$(echo "${COUNTY_SLUG}" | tr -c 'a-z0-9\n' '_')"
     
-    print_info "County: ${COUNTY_NAME}"
+    print_info "County: ${COUNTY_NAME} (${COUNTY_SLUG}, ${COUNTY_STATE})"
     print_info "Source CRS: ${SOURCE_CRS}"
     print_info "Target CRS: ${TARGET_CRS}"
 }
@@ -354,6 +397,11 @@ generate_field_mapping_sql() {
         fi
     done <<< "${field_mappings}"
     
+    # Parcels reference their county row, created by generate_county_sql
+    local county_row="FROM counties WHERE slug = '$(sql_quote "${COUNTY_SLUG}")'"
+    target_columns+=("county_id" "county_name")
+    source_columns+=("(SELECT id ${county_row})" "(SELECT name ${county_row})")
+    
     # Add geometry column (always present)
     # Convert to MultiPolygon to handle both Polygon and MultiPolygon geometries
     target_columns+=("geom")
@@ -380,6 +428,214 @@ WHERE wkb_geometry IS NOT NULL
 EOF
 }
 
+# Generate the upsert of the mapping's county. An existing county keeps its name
+# and state; only its field mappings and document links are updated.
+generate_county_sql() {
+    local mapping_file="$1"
+    local field_mappings=$(jq -c '.field_mappings' "${mapping_file}")
+    local document_links=$(jq -c '.document_links // {}' "${mapping_file}")
+    
+    cat << EOF
+INSERT INTO counties (slug, name, state, field_mappings, document_url_templates)
+VALUES ('$(sql_quote "${COUNTY_SLUG}")', '$(sql_quote "${COUNTY_NAME% County}")', '$(sql_quote "${COUNTY_STATE}")',
+    '$(sql_quote "${field_mappings}")'::jsonb, '$(sql_quote "${document_links}")'::jsonb)
+ON CONFLICT (slug) DO UPDATE SET field_mappings = EXCLUDED.field_mappings,
+    document_url_templates = EXCLUDED.document_url_templates, updated_at = NOW();
+EOF
+}
+
+# Take the county's advisory lock in a background psql session that holds it
+# until the script exits. Retries once a second for up to LOCK_TIMEOUT seconds,
+# then names the session holding the lock and exits with EXIT_COUNTY_LOCKED.
+acquire_county_lock() {
+    local slug="$1"
+    local key="$(sql_quote "${LOCK_NAMESPACE}${slug}")"
+    
+    print_step "Acquiring import lock for ${slug}..."
+    
+    if [ "${DRY_RUN}" = true ]; then
+        print_warning "DRY RUN - Would lock county ${slug}"
+        return 0
+    fi
+    
+    # PGAPPNAME identifies this import to blocked imports in pg_stat_activity
+    coproc LOCK_SESSION {
+        PGPASSWORD="${DB_PASSWORD}" PGAPPNAME="atlas-import-parcels ${MODE} ${slug}" psql \
+            -h "${DB_HOST}" \
+            -p "${DB_PORT}" \
+            -U "${DB_USER}" \
+            -d "${DB_NAME}" \
+            -X -q -t -A \
+            -v ON_ERROR_STOP=1 2>&1
+    }
+    # Ending the session releases the lock
+    trap release_county_lock EXIT
+    
+    local deadline=$(( $(date +%s) + LOCK_TIMEOUT ))
+    local waited=false
+    local result=""
+    while true; do
+        echo "SELECT pg_try_advisory_lock(hashtextextended('${key}', 0));" >&"${LOCK_SESSION[1]}"
+        if ! read -r -t 30 result <&"${LOCK_SESSION[0]}"; then
+            print_error "Lock session did not respond"
+            exit 1
+        fi
+        case "${result}" in
+            t)
+                break
+                ;;
+            f)
+                ;;
+            *)
+                print_error "Failed to take import lock: ${result}"
+                exit 1
+                ;;
+        esac
+        
+        if [ "$(date +%s)" -ge "${deadline}" ]; then
+            local holder=$(query_value "$(generate_lock_holder_sql "${key}")" || true)
+            print_error "Another import of ${slug} is running: ${holder:-session has since ended}"
+            print_info "Gave up after ${LOCK_TIMEOUT}s; re-run later or raise --lock-timeout"
+            log_to_file "Import skipped, ${slug} locked by: ${holder}" "ERROR"
+            exit ${EXIT_COUNTY_LOCKED}
+        fi
+        if [ "${waited}" = false ]; then
+            waited=true
+            print_warning "Another import of ${slug} is running; waiting up to ${LOCK_TIMEOUT}s"
+        fi
+        sleep 1
+    done
+    
+    print_success "Import lock acquired"
+}
+
+# End the lock session, releasing the county lock
+release_county_lock() {
+    if [ -n "${LOCK_SESSION_PID:-}" ]; then
+        local fd="${LOCK_SESSION[1]:-}"
+        if [ -n "${fd}" ]; then
+            eval "exec ${fd}>&-"
+        fi
+        wait "${LOCK_SESSION_PID}" 2> /dev/null || true
+    fi
+}
+
+# Generate the query describing the session holding a county lock. A bigint
+# advisory lock key is split into classid (high 32 bits) and objid (low 32 bits).
+generate_lock_holder_sql() {
+    local key="$1"
+    
+    cat << EOF
+WITH lock_key AS (SELECT hashtextextended('${key}', 0) AS k)
+SELECT format('"%s" (pid %s, client %s) running since %s',
+    a.application_name, a.pid, COALESCE(host(a.client_addr), 'local'),
+    to_char(COALESCE(a.xact_start, a.backend_start) AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"'))
+FROM pg_locks l
+JOIN pg_stat_activity a ON a.pid = l.pid
+CROSS JOIN lock_key
+WHERE l.locktype = 'advisory'
+    AND l.granted
+    AND l.objsubid = 1
+    AND l.classid = ((lock_key.k >> 32) & 4294967295)::oid
+    AND l.objid = (lock_key.k & 4294967295)::oid
+LIMIT 1;
+EOF
+}
+
+# Run a query and print the unaligned, pipe-separated result
+query_value() {
+    local sql="$1"
+    PGPASSWORD="${DB_PASSWORD}" psql \
+        -h "${DB_HOST}" \
+        -p "${DB_PORT}" \
+        -U "${DB_USER}" \
+        -d "${DB_NAME}" \
+        -v ON_ERROR_STOP=1 \
+        -t -A -F '|' \
+        -c "${sql}"
+}
+
+# Escape a value for use inside a single-quoted SQL literal
+sql_quote() {
+    local value="$1"
+    echo "${value//\'/\'\'}"
+}
+
+# Print the absolute path of a file, so cmd/ingest can run from the api directory
+absolute_path() {
+    local file="$1"
+    echo "$(cd "$(dirname "${file}")" && pwd)/$(basename "${file}")"
+}
+
+# Replace the county's parcels with cmd/ingest, which takes the county lock,
+# checks the load against the county's recent runs before committing and
+# records the run. Exits with cmd/ingest's code when it fails, e.g.
+# EXIT_ANOMALY_BLOCKED or EXIT_COUNTY_LOCKED.
+run_ingest_replace() {
+    print_step "Loading parcels with cmd/ingest (replace mode)..."
+    
+    local args=(
+        -file "$(absolute_path "${GEOJSON_FILE}")"
+        -mapping "$(absolute_path "${MAPPING_FILE}")"
+        -mode replace
+        -lock-timeout "${LOCK_TIMEOUT}s"
+    )
+    if [[ "${SOURCE_CRS}" =~ ^EPSG:([0-9]+)$ ]]; then
+        args+=(-srid "${BASH_REMATCH[1]}")
+    fi
+    if [ "${ALLOW_ANOMALIES}" = true ]; then
+        args+=(-allow-anomalies)
+    fi
+    if [ -n "${MAX_COUNT_CHANGE_PCT}" ]; then
+        args+=(-max-count-change "${MAX_COUNT_CHANGE_PCT}")
+    fi
+    if [ -n "${MAX_AREA_CHANGE_PCT}" ]; then
+        args+=(-max-area-change "${MAX_AREA_CHANGE_PCT}")
+    fi
+    if [ -n "${MAX_NULL_RATE_INCREASE}" ]; then
+        args+=(-max-null-increase "${MAX_NULL_RATE_INCREASE}")
+    fi
+    if [ "${DRY_RUN}" = true ]; then
+        args+=(-dry-run)
+    fi
+    
+    local ingest_cmd=("${INGEST_BIN}")
+    if [ ! -x "${INGEST_BIN}" ]; then
+        ingest_cmd=(go run ./cmd/ingest)
+    fi
+    
+    local status=0
+    (
+        cd "${API_DIR}" &&
+        DB_HOST="${DB_HOST}" DB_PORT="${DB_PORT}" DB_NAME="${DB_NAME}" \
+            DB_USER="${DB_USER}" DB_PASSWORD="${DB_PASSWORD}" \
+            "${ingest_cmd[@]}" "${args[@]}"
+    ) || status=$?
+    
+    case "${status}" in
+        0)
+            ;;
+        "${EXIT_ANOMALY_BLOCKED}")
+            print_error "Activation blocked by anomalies (existing ${FINAL_TABLE} data left unchanged)"
+            print_info "Re-run with --allow-anomalies to activate anyway"
+            log_to_file "Import blocked by anomalies" "ERROR"
+            exit ${EXIT_ANOMALY_BLOCKED}
+            ;;
+        "${EXIT_COUNTY_LOCKED}")
+            print_error "Another import of ${COUNTY_SLUG} is running"
+            print_info "Gave up after ${LOCK_TIMEOUT}s; re-run later or raise --lock-timeout"
+            log_to_file "Import skipped, ${COUNTY_SLUG} locked" "ERROR"
+            exit ${EXIT_COUNTY_LOCKED}
+            ;;
+        *)
+            print_error "cmd/ingest failed with exit code ${status}"
+            exit "${status}"
+            ;;
+    esac
+    
+    print_success "Parcels loaded"
+}
+
 # Import GeoJSON into staging table
 import_to_staging() {
     local geojson_file="$1"
@@ -659,6 +915,30 @@ while [[ $# -gt 0 ]]; do
             DRY_RUN=true
             shift
             ;;
+        --lock-timeout)
+            LOCK_TIMEOUT="$2"
+            if [[ ! "${LOCK_TIMEOUT}" =~ ^[0-9]+$ ]]; then
+                print_error "Invalid lock timeout: ${LOCK_TIMEOUT}. Must be a whole number of seconds"
+                exit 1
+            fi
+            shift 2
+            ;;
+        --allow-anomalies)
+            ALLOW_ANOMALIES=true
+            shift
+            ;;
+        --max-count-change)
+            MAX_COUNT_CHANGE_PCT="$2"
+            shift 2
+            ;;
+        --max-area-change)
+            MAX_AREA_CHANGE_PCT="$2"
+            shift 2
+            ;;
+        --max-null-increase)
+            MAX_NULL_RATE_INCREASE="$2"
+            shift 2
+            ;;
         -h|--help)
             usage
             ;;
@@ -725,37 +1005,36 @@ main() {
     print_info "Records to import: ${record_count}"
     log_to_file "Records to import: ${record_count}" "INFO"
     
-    # Handle mode
     if [ "${MODE}" = "replace" ]; then
-        print_warning "Mode: REPLACE - Existing data in ${FINAL_TABLE} will be cleared"
-        if [ "${DRY_RUN}" = false ]; then
-            # TRUNCATE with RESTART IDENTITY resets the BIGSERIAL sequence to 1
-            PGPASSWORD="${DB_PASSWORD}" psql \
-                -h "${DB_HOST}" \
-                -p "${DB_PORT}" \
-                -U "${DB_USER}" \
-                -d "${DB_NAME}" \
-                -c "TRUNCATE TABLE ${FINAL_TABLE} RESTART IDENTITY;" &> /dev/null
-            print_success "Existing data cleared and ID sequence reset"
-        fi
+        # cmd/ingest replaces the county's parcels in one transaction, after
+        # checking them against the county's recent runs
+        print_warning "Mode: REPLACE - Existing ${COUNTY_SLUG} parcels in ${FINAL_TABLE} will be replaced once the new data passes the anomaly check"
+        echo ""
+        run_ingest_replace
     else
         print_info "Mode: APPEND - Data will be added to existing records"
+        echo ""
+        
+        # Hold the county lock before touching its staging table
+        acquire_county_lock "${COUNTY_SLUG}"
+        
+        # Generate field mapping SQL
+        local mapping_sql=$(generate_field_mapping_sql "${MAPPING_FILE}")
+        
+        # Import to staging table
+        import_to_staging "${GEOJSON_FILE}" "${TARGET_CRS}"
+        
+        # Register the county before its parcels reference it
+        mapping_sql="$(generate_county_sql "${MAPPING_FILE}")
+${mapping_sql}"
+        
+        # Execute field mapping
+        execute_field_mapping "${mapping_sql}"
+        
+        # Clean up staging table
+        cleanup_staging
     fi
     
-    echo ""
-    
-    # Generate field mapping SQL
-    local mapping_sql=$(generate_field_mapping_sql "${MAPPING_FILE}")
-    
-    # Import to staging table
-    import_to_staging "${GEOJSON_FILE}" "${TARGET_CRS}"
-    
-    # Execute field mapping
-    execute_field_mapping "${mapping_sql}"
-    
-    # Clean up staging table
-    cleanup_staging
-    
     # Get statistics
     get_import_stats
     